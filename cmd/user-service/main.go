@@ -30,16 +30,29 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/alex-necsoiu/pandora-exchange/internal/alerting"
 	"github.com/alex-necsoiu/pandora-exchange/internal/config"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/email"
 	"github.com/alex-necsoiu/pandora-exchange/internal/events"
+	"github.com/alex-necsoiu/pandora-exchange/internal/kycprovider"
 	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
 	"github.com/alex-necsoiu/pandora-exchange/internal/repository"
 	"github.com/alex-necsoiu/pandora-exchange/internal/service"
+	"github.com/alex-necsoiu/pandora-exchange/internal/sms"
+	"github.com/alex-necsoiu/pandora-exchange/internal/storage"
 	grpcTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/grpc"
 	pb "github.com/alex-necsoiu/pandora-exchange/internal/transport/grpc/proto"
 	httpTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/http"
 	"github.com/alex-necsoiu/pandora-exchange/internal/vault"
+	"github.com/alex-necsoiu/pandora-exchange/internal/watchlist"
+	"github.com/alex-necsoiu/pandora-exchange/internal/webhooks"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
@@ -55,6 +68,15 @@ var (
 )
 
 func main() {
+	// `user-service replay ...` runs an event replay job instead of the service
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		os.Exit(runReplayCommand(os.Args[2:]))
+	}
+	// `user-service reencrypt-pii ...` encrypts or rewraps user PII instead of running the service
+	if len(os.Args) > 1 && os.Args[1] == "reencrypt-pii" {
+		os.Exit(runReencryptPIICommand(os.Args[2:]))
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -116,39 +138,8 @@ func main() {
 
 	logger.Info("Database connection pool initialized")
 
-	// Initialize Redis client for event publishing
-	redisAddr := fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port)
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     redisAddr,
-		Password: cfg.Redis.Password,
-		DB:       cfg.Redis.DB,
-	})
-
-	// Test Redis connection
-	if err := redisClient.Ping(ctx).Err(); err != nil {
-		logger.WithField("error", err.Error()).Warn("Failed to connect to Redis, event publishing will be disabled")
-		redisClient = nil // Disable event publishing if Redis is unavailable
-	} else {
-		logger.WithField("redis_addr", redisAddr).Info("Redis connection established")
-	}
-
-	// Initialize event publisher
-	var eventPublisher *events.RedisEventPublisher
-	if redisClient != nil {
-		// Create a zap logger for the event publisher
-		var zapLogger *zap.Logger
-		if cfg.AppEnv == "prod" {
-			zapLogger, err = zap.NewProduction()
-		} else {
-			zapLogger, err = zap.NewDevelopment()
-		}
-		if err != nil {
-			logger.WithField("error", err.Error()).Warn("Failed to create zap logger for event publisher")
-		} else {
-			eventPublisher = events.NewRedisEventPublisher(redisClient, zapLogger)
-			logger.Info("Event publisher initialized")
-		}
-	}
+	// Initialize event publishers on the configured transport
+	eventPublisher, auditEventPublisher := initEventPublishers(ctx, cfg, logger)
 
 	// Initialize JWT manager
 	jwtManager, err := auth.NewJWTManager(
@@ -162,13 +153,109 @@ func main() {
 
 	logger.Info("JWT manager initialized")
 
+	// PII field encryption is disabled (nil) unless PII_ENCRYPTION_PROVIDER is set
+	piiEncryptor, err := newPIIEncryptor(cfg, vaultClient)
+	if err != nil {
+		logger.WithField("error", err.Error()).Fatal("Failed to initialize PII encryption")
+	}
+
 	// Initialize repositories
-	userRepo := repository.NewUserRepository(dbPool, logger)
+	userRepo := repository.NewUserRepository(dbPool, logger).WithFieldEncryption(piiEncryptor)
 	tokenRepo := repository.NewRefreshTokenRepository(dbPool, logger)
-	auditRepo := repository.NewAuditRepository(dbPool, logger)
+	var auditRepo audit.Repository = repository.NewAuditRepository(dbPool, logger)
+	if auditEventPublisher != nil {
+		auditRepo = service.NewPublishingAuditRepository(auditRepo, auditEventPublisher, logger)
+	}
+	auditPartitionRepo := repository.NewAuditPartitionRepository(dbPool, logger)
+	legalHoldRepo := repository.NewLegalHoldRepository(dbPool, logger)
+	auditRetentionRepo := repository.NewAuditRetentionRepository(dbPool, logger)
+	alertRepo := repository.NewAlertRepository(dbPool, logger)
+	outboxRepo := repository.NewOutboxRepository(dbPool, logger)
+	webhookRepo := repository.NewWebhookRepository(dbPool, logger).WithFieldEncryption(piiEncryptor)
+	userTransactor := repository.NewUserTransactor(dbPool, logger).WithFieldEncryption(piiEncryptor)
 
 	logger.Info("Repositories initialized")
 
+	// Security alerting: rules are evaluated over stored audit logs and user service security events
+	alertCtx, stopAlerting := context.WithCancel(context.Background())
+	defer stopAlerting()
+	var alertEngine *service.AlertEngine
+	if cfg.Alert.Enabled {
+		alertEngine = initAlerting(alertCtx, cfg, alertRepo, logger)
+		auditRepo = service.NewAlertingAuditRepository(auditRepo, alertEngine)
+	}
+	alertService := service.NewAlertService(alertRepo, auditRepo, logger)
+
+	// Initialize object store (audit log archives)
+	objectStore, err := storage.NewObjectStore(cfg.Storage.Backend, cfg.Storage.LocalPath)
+	if err != nil {
+		logger.WithField("error", err.Error()).Fatal("Failed to initialize object store")
+	}
+
+	legalHoldService := service.NewLegalHoldService(legalHoldRepo, auditRepo, logger)
+
+	// Retention policy was validated with the config; per-category rules are applied to new logs
+	// by the audit middleware and to existing logs on demand through the admin API
+	retentionPolicy, err := cfg.Audit.RetentionPolicy()
+	if err != nil {
+		logger.WithField("error", err.Error()).Fatal("Invalid audit retention policy")
+	}
+	auditRetentionService := service.NewAuditRetentionService(auditRetentionRepo, auditRepo, retentionPolicy, logger)
+
+	// Whole partitions are only archived once the longest-retained category has expired
+	auditArchiveService := service.NewAuditArchiveService(
+		auditPartitionRepo,
+		objectStore,
+		logger,
+		retentionPolicy.MaxDays(),
+		cfg.Audit.PartitionMonthsAhead,
+		cfg.Audit.ArchivePrefix,
+	).WithLegalHolds(legalHoldService)
+
+	// Start audit retention job (partition maintenance, archival, cleanup)
+	auditCleanupJob := service.NewAuditCleanupJob(auditRepo, logger, cfg.Audit.CleanupInterval)
+	if cfg.Audit.ArchiveEnabled {
+		auditCleanupJob.WithArchiver(auditArchiveService)
+	}
+	auditCleanupJob.Start(context.Background())
+
+	logger.WithFields(map[string]interface{}{
+		"storage_backend": cfg.Storage.Backend,
+		"archive_enabled": cfg.Audit.ArchiveEnabled,
+		"retention_rules": len(retentionPolicy.Rules()),
+	}).Info("Audit retention job started")
+
+	// Encrypt PII stored before encryption was enabled and rewrap data keys after key rotation
+	var piiReencryptionJob *service.PIIReencryptionJob
+	if piiEncryptor != nil && cfg.PII.ReencryptInterval > 0 {
+		piiReencryptionJob = service.NewPIIReencryptionJob(
+			userRepo,
+			logger,
+			cfg.PII.ReencryptInterval,
+			int32(cfg.PII.ReencryptBatchSize), // #nosec G115 -- bounded by config validation (max 10000)
+		)
+		piiReencryptionJob.Start(context.Background())
+	}
+	if piiEncryptor != nil {
+		logger.WithFields(map[string]interface{}{
+			"provider":           cfg.PII.EncryptionProvider,
+			"reencrypt_interval": cfg.PII.ReencryptInterval.String(),
+		}).Info("PII field encryption enabled")
+
+		// Webhook secrets are few, so they are encrypted and rewrapped once at startup
+		encrypted, err := webhookRepo.EncryptSecrets(context.Background())
+		if err != nil {
+			logger.WithField("error", err.Error()).Error("Failed to encrypt webhook secrets")
+		} else if encrypted > 0 {
+			logger.WithField("subscriptions", encrypted).Info("Webhook secrets encrypted")
+		}
+	}
+
+	// Events accepted by the transport are also queued for matching webhook subscriptions
+	if eventPublisher != nil {
+		eventPublisher = service.NewWebhookPublisher(eventPublisher, webhookRepo, logger)
+	}
+
 	// Initialize service
 	userService, err := service.NewUserService(
 		userRepo,
@@ -177,11 +264,213 @@ func main() {
 		cfg.JWT.AccessTokenExpiry,
 		cfg.JWT.RefreshTokenExpiry,
 		logger,
-		eventPublisher, // Event publisher (can be nil if Redis is unavailable)
+		eventPublisher, // Event publisher (can be nil if the event transport is unavailable)
 	)
 	if err != nil {
 		logger.WithField("error", err.Error()).Fatal("Failed to initialize user service")
 	}
+	userService.WithLegalHolds(legalHoldService).WithOutbox(userTransactor)
+
+	// User events are written to the outbox with the change and relayed to the event transport.
+	// Without it they stay in the outbox and are relayed once the service restarts with it.
+	metrics := observability.NewMetricsCollector("pandora", "user_service")
+	var outboxRelay *service.OutboxRelay
+	if eventPublisher != nil {
+		outboxRelay = service.NewOutboxRelay(
+			outboxRepo,
+			eventPublisher,
+			logger,
+			cfg.Outbox.RelayInterval,
+			int32(cfg.Outbox.BatchSize), // #nosec G115 -- bounded by config validation (max 1000)
+			cfg.Outbox.Retention,
+		).WithMetrics(metrics)
+		outboxRelay.Start(context.Background())
+	} else {
+		logger.Warn("Event publisher unavailable, outbox relay disabled; user events will accumulate in the outbox")
+	}
+	if alertEngine != nil {
+		userService.WithAlertObserver(alertEngine)
+	}
+
+	// Phone verification is only offered when a code sender is configured
+	if cfg.Phone.VerificationSender == sms.SenderLog {
+		userService.WithPhoneVerification(
+			repository.NewPhoneVerificationRepository(dbPool, logger),
+			sms.NewLogSender(logger),
+			cfg.Phone.CodeTTL,
+			cfg.Phone.MaxAttempts,
+		)
+		logger.Warn("Phone verification codes are logged, not delivered")
+	}
+
+	// Deleted accounts can be restored within the grace period by an admin and, when a link
+	// sender is configured, by the user through the link sent on deletion
+	if cfg.Restore.GracePeriod > 0 {
+		userService.WithAccountRestore(cfg.Restore.GracePeriod, auditRepo)
+	}
+	if cfg.Restore.LinkSender == email.SenderLog {
+		restoreKey, err := cfg.Restore.SigningKeyBytes()
+		if err != nil {
+			logger.WithError(err).Fatal("Invalid account restore signing key")
+		}
+		restoreSigner, err := userDomain.NewRestoreTokenSigner(restoreKey)
+		if err != nil {
+			logger.WithError(err).Fatal("Failed to create account restore link signer")
+		}
+		userService.WithRestoreLinks(restoreSigner, email.NewLogSender(logger), cfg.Restore.LinkURL)
+		logger.Warn("Account restore links are logged, not delivered")
+	}
+
+	// Admins can suspend, freeze withdrawals of and close accounts; suspensions and freezes
+	// are lifted by the expiry job once they expire
+	revokeSessionsOn, err := cfg.AccountStatus.RevokeSessionsOn()
+	if err != nil {
+		logger.WithError(err).Fatal("Invalid account status config")
+	}
+	userService.WithAccountStatuses(revokeSessionsOn, auditRepo)
+	var statusExpiryJob *service.AccountStatusExpiryJob
+	if cfg.AccountStatus.ExpiryInterval > 0 {
+		statusExpiryJob = service.NewAccountStatusExpiryJob(
+			userService,
+			logger,
+			cfg.AccountStatus.ExpiryInterval,
+			cfg.AccountStatus.ExpiryBatchSize,
+		)
+		statusExpiryJob.Start(context.Background())
+	}
+
+	// The admin dashboard reads statistics rollups kept up to date by the rollup job
+	userService.WithStatistics(repository.NewStatsRepository(dbPool, logger), cfg.Stats.BackfillDays)
+	var statsRollupJob *service.StatsRollupJob
+	if cfg.Stats.RefreshInterval > 0 {
+		statsRollupJob = service.NewStatsRollupJob(userService, logger, cfg.Stats.RefreshInterval)
+		statsRollupJob.Start(context.Background())
+	}
+
+	// Event replay jobs queued through the admin API run here; each job publishes to its own
+	// target over a dedicated connection. Instances share the queue through job leases.
+	replayZapLogger, err := newEventLogger(cfg)
+	if err != nil {
+		replayZapLogger = zap.NewNop()
+	}
+	hostname, _ := os.Hostname()
+	replayService := service.NewReplayService(
+		repository.NewReplayRepository(dbPool, logger).WithFieldEncryption(piiEncryptor),
+		auditRepo,
+		newReplayPublisherFactory(ctx, cfg, replayZapLogger),
+		logger,
+		hostname,
+	)
+	replayService.Start(context.Background())
+
+	// Outbound webhooks: subscriptions are managed through the admin API and queued deliveries
+	// are sent by the dispatcher. Instances share the queue through delivery leases.
+	// Outside development, endpoints must use https and resolve to public addresses.
+	webhookService := service.NewWebhookService(webhookRepo, auditRepo, logger).WithPlainHTTP(cfg.IsDevelopment())
+	webhookDispatcher := service.NewWebhookDispatcher(
+		webhookRepo,
+		webhooks.NewHTTPSender(nil, cfg.Webhook.Timeout).WithPrivateNetworks(cfg.IsDevelopment()),
+		logger,
+		cfg.Webhook.DeliveryInterval,
+		int32(cfg.Webhook.BatchSize), // #nosec G115 -- bounded by config validation (max 500)
+		cfg.Webhook.MaxAttempts,
+		cfg.Webhook.DisableAfterFailures,
+		cfg.Webhook.Retention,
+	)
+	webhookDispatcher.Start(context.Background())
+
+	// KYC review workflow: documents share the object store with the audit archives
+	tierPolicy, err := cfg.KYC.TierPolicy()
+	if err != nil {
+		logger.WithField("error", err.Error()).Fatal("Invalid KYC tier policy")
+	}
+	kycRepo := repository.NewKYCRepository(dbPool, logger)
+	kycService := service.NewKYCService(
+		repository.NewKYCTransactor(dbPool, logger).WithFieldEncryption(piiEncryptor),
+		kycRepo,
+		userRepo,
+		objectStore,
+		auditRepo,
+		logger,
+		cfg.KYC.DocumentPrefix,
+		cfg.KYC.MaxDocumentBytes,
+	).WithTierPolicy(tierPolicy)
+
+	// External KYC verification is optional; the simulator decides cases locally and is
+	// rejected by config validation in production
+	if cfg.KYC.Provider == kycprovider.ProviderSimulator {
+		simulator, err := kycprovider.NewSimulator(kycprovider.SimulatorOptions{
+			Secret:        cfg.KYC.ProviderWebhookSecret,
+			Scenario:      kycprovider.Scenario(cfg.KYC.SimulatorScenario),
+			CallbackURL:   cfg.KYC.SimulatorCallbackURL,
+			DecisionDelay: cfg.KYC.SimulatorDecisionDelay,
+		})
+		if err != nil {
+			logger.WithField("error", err.Error()).Fatal("Invalid KYC simulator config")
+		}
+		defer simulator.Close()
+		kycService.WithProvider(simulator, cfg.KYC.ProviderTimeout)
+		logger.WithField("scenario", cfg.KYC.SimulatorScenario).Warn("KYC simulator provider enabled")
+	}
+
+	// Sanctions and PEP screening: users are screened at registration, on profile changes and
+	// before KYC approval, and re-screened whenever the list files change
+	screeningCtx, stopScreening := context.WithCancel(context.Background())
+	defer stopScreening()
+	var adminScreeningOptions []httpTransport.AdminRouterOption
+	if cfg.Screening.Enabled {
+		screeningService := initScreening(screeningCtx, cfg, dbPool, userRepo, kycRepo, auditRepo, logger)
+		userService.WithScreener(screeningService)
+		kycService.WithScreening(screeningService)
+		adminScreeningOptions = append(adminScreeningOptions, httpTransport.WithScreeningService(screeningService))
+	}
+
+	// GDPR data subject access exports: archives are built in the background and downloaded
+	// through short-lived links. Instances share the queue through job leases.
+	userRouterOptions := []httpTransport.UserRouterOption{httpTransport.WithKYCService(kycService)}
+	var adminDataExportOptions []httpTransport.AdminRouterOption
+	var dataExportService *service.DataExportService
+	if cfg.DataExport.Enabled {
+		signingKey, err := cfg.DataExport.SigningPrivateKey()
+		if err != nil {
+			logger.WithField("error", err.Error()).Fatal("Invalid data export signing key")
+		}
+		dataExportService = service.NewDataExportService(
+			repository.NewDataExportRepository(dbPool, logger).WithFieldEncryption(piiEncryptor),
+			userRepo,
+			objectStore,
+			auditRepo,
+			logger,
+			signingKey,
+			hostname,
+		).
+			WithPrefix(cfg.DataExport.Prefix).
+			WithRetention(cfg.DataExport.Retention, cfg.DataExport.LinkTTL).
+			WithPollInterval(cfg.DataExport.PollInterval)
+		dataExportService.Start(context.Background())
+		userRouterOptions = append(userRouterOptions, httpTransport.WithDataExportService(dataExportService))
+		adminDataExportOptions = append(adminDataExportOptions, httpTransport.WithAdminDataExportService(dataExportService))
+	}
+
+	// GDPR erasure: deleted accounts are erased once their cooling-off period has passed,
+	// unless a legal hold or AML retention defers them
+	var erasureJob *service.ErasureJob
+	if cfg.Erasure.Enabled && cfg.Erasure.Interval > 0 {
+		erasureJob = service.NewErasureJob(
+			repository.NewErasureRepository(dbPool, logger).WithFieldEncryption(piiEncryptor),
+			userRepo,
+			legalHoldRepo,
+			objectStore,
+			auditRepo,
+			logger,
+			cfg.Erasure.Interval,
+			int32(cfg.Erasure.BatchSize), // #nosec G115 -- bounded by config validation (max 1000)
+		).
+			WithCoolingOff(cfg.Erasure.CoolingOff).
+			WithAMLRetention(cfg.Erasure.AMLRetention).
+			WithArchives(auditArchiveService)
+		erasureJob.Start(context.Background())
+	}
 
 	logger.WithFields(map[string]interface{}{
 		"version":    version,
@@ -234,7 +523,7 @@ func main() {
 	logger.WithField("reflection_enabled", enableReflection).Info("Service registry initialized")
 
 	// Register gRPC service
-	userGRPCService := grpcTransport.NewServer(userService, logger)
+	userGRPCService := grpcTransport.NewServer(userService, logger, grpcTransport.WithKYCService(kycService))
 	pb.RegisterUserServiceServer(grpcServer, userGRPCService)
 
 	// Register UserService metadata in registry
@@ -250,6 +539,7 @@ func main() {
 			"DeleteUser",
 			"RefreshToken",
 			"Logout",
+			"GetUserEntitlements",
 		},
 		ProtoFile: "internal/transport/grpc/proto/user_service.proto",
 		Metadata: map[string]string{
@@ -270,8 +560,22 @@ func main() {
 		ginMode = "debug"
 	}
 
-	userRouter := httpTransport.SetupUserRouter(userService, jwtManager, auditRepo, cfg, logger, ginMode, cfg.Tracing.Enabled)
-	adminRouter := httpTransport.SetupAdminRouter(userService, jwtManager, auditRepo, cfg, logger, ginMode, cfg.Tracing.Enabled, registry)
+	userRouter := httpTransport.SetupUserRouter(userService, jwtManager, auditRepo, cfg, logger, ginMode, cfg.Tracing.Enabled,
+		userRouterOptions...,
+	)
+	adminRouterOptions := append([]httpTransport.AdminRouterOption{
+		httpTransport.WithAuditArchiveService(auditArchiveService),
+		httpTransport.WithLegalHoldService(legalHoldService),
+		httpTransport.WithAuditRetentionService(auditRetentionService),
+		httpTransport.WithAlertService(alertService),
+		httpTransport.WithReplayService(replayService),
+		httpTransport.WithWebhookService(webhookService),
+		httpTransport.WithKYCReviewService(kycService),
+	}, adminScreeningOptions...)
+	adminRouterOptions = append(adminRouterOptions, adminDataExportOptions...)
+	adminRouter := httpTransport.SetupAdminRouter(userService, jwtManager, auditRepo, cfg, logger, ginMode, cfg.Tracing.Enabled, registry,
+		adminRouterOptions...,
+	)
 
 	logger.Info("HTTP routers initialized")
 
@@ -356,7 +660,31 @@ func main() {
 		logger.WithField("error", err.Error()).Error("Service registry forced to shutdown")
 	}
 
-	// Close event publisher and Redis connection
+	// Stop audit retention job and alert rule reloading
+	auditCleanupJob.Stop()
+	if piiReencryptionJob != nil {
+		piiReencryptionJob.Stop()
+	}
+	stopAlerting()
+	if outboxRelay != nil {
+		outboxRelay.Stop()
+	}
+	replayService.Stop()
+	webhookDispatcher.Stop()
+	if dataExportService != nil {
+		dataExportService.Stop()
+	}
+	if erasureJob != nil {
+		erasureJob.Stop()
+	}
+	if statusExpiryJob != nil {
+		statusExpiryJob.Stop()
+	}
+	if statsRollupJob != nil {
+		statsRollupJob.Stop()
+	}
+
+	// Close event publisher and its transport connection
 	if eventPublisher != nil {
 		logger.Info("Closing event publisher...")
 		if err := eventPublisher.Close(); err != nil {
@@ -400,3 +728,199 @@ func initDatabase(ctx context.Context, cfg *config.Config, logger *observability
 
 	return pool, nil
 }
+
+// initEventPublishers connects to the event transport selected by EVENT_TRANSPORT and returns
+// the publishers for user events and for high and critical audit events. Both are nil when
+// the transport is unavailable; the audit publisher shares the user publisher's connection.
+func initEventPublishers(ctx context.Context, cfg *config.Config, logger *observability.Logger) (common.EventPublisher, common.EventPublisher) {
+	zapLogger, err := newEventLogger(cfg)
+	if err != nil {
+		logger.WithField("error", err.Error()).Warn("Failed to create zap logger for event publisher")
+		return nil, nil
+	}
+
+	switch cfg.Events.Transport {
+	case events.TransportKafka:
+		client, err := events.NewKafkaClient(kafkaOptions(cfg))
+		if err != nil {
+			logger.WithField("error", err.Error()).Warn("Failed to create Kafka client, event publishing will be disabled")
+			return nil, nil
+		}
+		eventPublisher := events.NewKafkaEventPublisher(client, cfg.Events.KafkaTopic, zapLogger).
+			WithTimeout(cfg.Events.PublishTimeout)
+		auditEventPublisher := events.NewKafkaEventPublisher(client, cfg.Events.KafkaAuditTopic, zapLogger).
+			WithTimeout(cfg.Events.PublishTimeout)
+		logger.WithFields(map[string]interface{}{
+			"brokers": cfg.Events.KafkaBrokers,
+			"topic":   cfg.Events.KafkaTopic,
+		}).Info("Kafka event publisher initialized")
+		return eventPublisher, auditEventPublisher
+
+	case events.TransportNATS:
+		js, err := events.ConnectJetStream(cfg.Events.NATSURL, cfg.Tracing.ServiceName)
+		if err != nil {
+			logger.WithField("error", err.Error()).Warn("Failed to connect to NATS, event publishing will be disabled")
+			return nil, nil
+		}
+		eventPublisher := events.NewJetStreamEventPublisher(js, cfg.Events.NATSSubjectPrefix, zapLogger).
+			WithTimeout(cfg.Events.PublishTimeout)
+		auditEventPublisher := events.NewJetStreamEventPublisher(js, cfg.Events.NATSAuditSubjectPrefix, zapLogger).
+			WithTimeout(cfg.Events.PublishTimeout)
+		logger.WithField("subject_prefix", cfg.Events.NATSSubjectPrefix).Info("NATS JetStream event publisher initialized")
+		return eventPublisher, auditEventPublisher
+	}
+
+	redisAddr := fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port)
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     redisAddr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+
+	// Test Redis connection
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		logger.WithField("error", err.Error()).Warn("Failed to connect to Redis, event publishing will be disabled")
+		_ = redisClient.Close()
+		return nil, nil
+	}
+	logger.WithField("redis_addr", redisAddr).Info("Redis connection established")
+
+	eventPublisher := events.NewRedisEventPublisher(redisClient, zapLogger).
+		WithMaxLength(cfg.Events.RedisStreamMaxLen)
+	// High and critical audit entries go to their own stream for fraud and alerting consumers
+	auditEventPublisher := events.NewRedisEventPublisher(redisClient, zapLogger).
+		WithStreamName(events.AuditStreamName).
+		WithMaxLength(cfg.Events.RedisStreamMaxLen)
+	logger.Info("Event publisher initialized")
+	return eventPublisher, auditEventPublisher
+}
+
+// kafkaOptions returns the Kafka client options of the configured event transport
+func kafkaOptions(cfg *config.Config) events.KafkaOptions {
+	return events.KafkaOptions{
+		Brokers:       cfg.Events.KafkaBrokerList(),
+		ClientID:      cfg.Tracing.ServiceName,
+		TLS:           cfg.Events.KafkaTLS,
+		SASLMechanism: cfg.Events.KafkaSASLMechanism,
+		Username:      cfg.Events.KafkaUsername,
+		Password:      cfg.Events.KafkaPassword,
+	}
+}
+
+// newEventLogger creates the zap logger used by the event publishers
+func newEventLogger(cfg *config.Config) (*zap.Logger, error) {
+	if cfg.AppEnv == "prod" {
+		return zap.NewProduction()
+	}
+	return zap.NewDevelopment()
+}
+
+// initAlerting builds the alert rule engine with its notifiers, loads the rules file and
+// starts hot reloading and state sweeping until ctx is cancelled
+func initAlerting(ctx context.Context, cfg *config.Config, alertRepo alert.Repository, logger *observability.Logger) *service.AlertEngine {
+	notifiers := []alert.Notifier{
+		alerting.NewLogNotifier(logger),
+		alerting.NewStoreNotifier(alertRepo),
+	}
+	if cfg.Alert.WebhookURL != "" {
+		notifiers = append(notifiers, alerting.NewWebhookNotifier(cfg.Alert.WebhookURL, nil, cfg.Alert.WebhookTimeout))
+	}
+
+	engine := service.NewAlertEngine(notifiers, logger)
+	watcher := alerting.NewRuleWatcher(cfg.Alert.RulesFile, cfg.Alert.ReloadInterval, engine, logger)
+	if _, err := watcher.Load(); err != nil {
+		logger.WithField("error", err.Error()).Fatal("Failed to load alert rules")
+	}
+	go watcher.Run(ctx)
+	go engine.RunSweeper(ctx, time.Minute)
+
+	logger.WithFields(map[string]interface{}{
+		"rules_file":      cfg.Alert.RulesFile,
+		"rules":           len(engine.Rules()),
+		"reload_interval": cfg.Alert.ReloadInterval.String(),
+		"webhook":         cfg.Alert.WebhookURL != "",
+	}).Info("Security alerting enabled")
+
+	return engine
+}
+
+// initScreening builds the screening service, loads the watch list files and starts
+// watching them for changes until ctx is cancelled. A failed first load is not fatal: KYC
+// approvals fail until the files can be loaded.
+func initScreening(
+	ctx context.Context,
+	cfg *config.Config,
+	dbPool *pgxpool.Pool,
+	userRepo *repository.UserRepository,
+	kycRepo *repository.KYCRepository,
+	auditRepo audit.Repository,
+	logger *observability.Logger,
+) *service.ScreeningService {
+	screeningService := service.NewScreeningService(
+		repository.NewScreeningRepository(dbPool, logger),
+		userRepo,
+		kycRepo,
+		auditRepo,
+		logger,
+		cfg.Screening.Thresholds(),
+	)
+
+	var files []watchlist.File
+	for _, f := range []watchlist.File{
+		{Source: screening.SourceOFACSDN, Path: cfg.Screening.OFACSDNFile},
+		{Source: screening.SourceEUConsolidated, Path: cfg.Screening.EUFile},
+		{Source: screening.SourcePEP, Path: cfg.Screening.PEPFile},
+	} {
+		if f.Path != "" {
+			files = append(files, f)
+		}
+	}
+
+	watcher := watchlist.NewWatcher(files, cfg.Screening.ReloadInterval, screeningService, logger)
+	if _, err := watcher.Load(); err != nil {
+		logger.WithError(err).Error("Failed to load screening watch lists, KYC approvals will fail until they load")
+	}
+	go watcher.Run(ctx)
+
+	logger.WithFields(map[string]interface{}{
+		"lists":           len(files),
+		"reload_interval": cfg.Screening.ReloadInterval.String(),
+		"name_threshold":  cfg.Screening.NameThreshold,
+	}).Info("Sanctions and PEP screening enabled")
+
+	return screeningService
+}
+
+// newPIIEncryptor builds the PII field encryptor for the configured key provider.
+// Returns nil when PII_ENCRYPTION_PROVIDER is unset and PII is stored in plaintext.
+func newPIIEncryptor(cfg *config.Config, vaultClient *vault.Client) (*pii.Encryptor, error) {
+	var keys common.KeyManager
+	switch cfg.PII.EncryptionProvider {
+	case "":
+		return nil, nil
+	case pii.ProviderVault:
+		transit, err := vault.NewTransitKeyManager(vaultClient, cfg.PII.VaultTransitMount, cfg.PII.VaultTransitKey)
+		if err != nil {
+			return nil, err
+		}
+		keys = transit
+	case pii.ProviderLocal:
+		masterKeys, err := pii.ParseLocalMasterKeys(cfg.PII.LocalMasterKeys)
+		if err != nil {
+			return nil, err
+		}
+		local, err := pii.NewLocalKeyManager(masterKeys)
+		if err != nil {
+			return nil, err
+		}
+		keys = local
+	default:
+		return nil, fmt.Errorf("unsupported PII encryption provider %q", cfg.PII.EncryptionProvider)
+	}
+
+	indexKey, err := cfg.PII.BlindIndexKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+	return pii.NewEncryptor(keys, indexKey, cfg.PII.DataKeyCacheTTL, cfg.PII.DataKeyCacheSize)
+}
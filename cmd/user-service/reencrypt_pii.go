@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/config"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/repository"
+	"github.com/alex-necsoiu/pandora-exchange/internal/vault"
+)
+
+// reencryptPIIUsage is printed for `user-service reencrypt-pii -h`
+const reencryptPIIUsage = `Usage: user-service reencrypt-pii [flags]
+
+Encrypts user PII stored before field encryption was enabled and rewraps data keys wrapped
+with an older key version, e.g. after "vault write -f transit/keys/user-pii/rotate". The
+running service does the same periodically (PII_REENCRYPT_INTERVAL); this command drains the
+backlog in the foreground. Interrupting it is safe; remaining users stay pending.
+
+Examples:
+  user-service reencrypt-pii -dry-run
+  user-service reencrypt-pii -batch 500
+
+Flags:
+`
+
+// runReencryptPIICommand implements the `reencrypt-pii` subcommand and returns the process exit code
+func runReencryptPIICommand(args []string) int {
+	flags := flag.NewFlagSet("reencrypt-pii", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), reencryptPIIUsage)
+		flags.PrintDefaults()
+	}
+	batchSize := flags.Int("batch", 0, "users re-encrypted per batch (default: PII_REENCRYPT_BATCH_SIZE)")
+	dryRun := flags.Bool("dry-run", false, "only print the number of pending users")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+		return 1
+	}
+	if cfg.PII.EncryptionProvider == "" {
+		fmt.Fprintln(os.Stderr, "PII_ENCRYPTION_PROVIDER is not set; nothing to encrypt")
+		return 1
+	}
+	if *batchSize <= 0 {
+		*batchSize = cfg.PII.ReencryptBatchSize
+	}
+	if *batchSize <= 0 || *batchSize > 10000 {
+		fmt.Fprintln(os.Stderr, "-batch must be between 1 and 10000")
+		return 2
+	}
+	logger := observability.NewLogger(cfg.AppEnv, "user-service-reencrypt-pii")
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	vaultClient := vault.NewDisabledClient()
+	if cfg.Vault.Enabled {
+		if vaultClient, err = vault.NewClient(cfg.Vault.Addr, cfg.Vault.Token); err != nil {
+			logger.WithField("error", err.Error()).Error("Failed to initialize Vault client")
+			return 1
+		}
+	}
+	if err := cfg.LoadSecretsFromVault(ctx, vaultClient); err != nil {
+		logger.WithField("error", err.Error()).Error("Failed to load secrets from Vault")
+		return 1
+	}
+
+	piiEncryptor, err := newPIIEncryptor(cfg, vaultClient)
+	if err != nil {
+		logger.WithField("error", err.Error()).Error("Failed to initialize PII encryption")
+		return 1
+	}
+
+	dbPool, err := initDatabase(ctx, cfg, logger)
+	if err != nil {
+		logger.WithField("error", err.Error()).Error("Failed to initialize database")
+		return 1
+	}
+	defer dbPool.Close()
+
+	userRepo := repository.NewUserRepository(dbPool, logger).WithFieldEncryption(piiEncryptor)
+
+	pending, err := userRepo.CountPendingPIIReencryption(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to count pending users: %v\n", err)
+		return 1
+	}
+	fmt.Printf("%d users pending\n", pending)
+	if *dryRun || pending == 0 {
+		return 0
+	}
+
+	total := 0
+	for {
+		updated, err := userRepo.ReencryptPII(ctx, int32(*batchSize)) // #nosec G115 -- validated above
+		total += updated
+		if err != nil {
+			if ctx.Err() != nil {
+				fmt.Printf("interrupted after %d users\n", total)
+				return 130
+			}
+			fmt.Fprintf(os.Stderr, "re-encryption failed after %d users: %v\n", total, err)
+			return 1
+		}
+		if updated == 0 {
+			break
+		}
+		fmt.Printf("%d/%d users re-encrypted\n", total, pending)
+	}
+
+	remaining, err := userRepo.CountPendingPIIReencryption(context.WithoutCancel(ctx))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to count pending users: %v\n", err)
+		return 1
+	}
+	fmt.Printf("done: %d users re-encrypted, %d pending\n", total, remaining)
+	if remaining > 0 {
+		// Users changed while they were processed; run again to pick them up
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/config"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/replay"
+	"github.com/alex-necsoiu/pandora-exchange/internal/events"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/repository"
+	"github.com/alex-necsoiu/pandora-exchange/internal/service"
+	"github.com/alex-necsoiu/pandora-exchange/internal/vault"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// replayUsage is printed for `user-service replay -h`
+const replayUsage = `Usage: user-service replay [flags]
+
+Queues an event replay job and runs it in the foreground, printing progress until it
+finishes. Interrupting the command leaves the job resumable from its last checkpoint.
+
+Examples:
+  user-service replay -source snapshot -types user.registered,user.kyc.updated -target user-service:replay:billing
+  user-service replay -source outbox -from 2024-01-01T00:00:00Z -to 2024-02-01T00:00:00Z -target replay.events
+  user-service replay -resume 6f1c3a52-6a0e-4d0c-9a43-2f7d1c0e8b11
+
+Flags:
+`
+
+// runReplayCommand implements the `replay` subcommand and returns the process exit code
+func runReplayCommand(args []string) int {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), replayUsage)
+		flags.PrintDefaults()
+	}
+	source := flags.String("source", "", "event source: snapshot, outbox or audit")
+	types := flags.String("types", "", "comma-separated event types to replay (default: all)")
+	from := flags.String("from", "", "replay records created at or after this RFC 3339 time")
+	to := flags.String("to", "", "replay records created before this RFC 3339 time")
+	target := flags.String("target", "", "stream, topic or subject prefix to publish to")
+	rateLimit := flags.Int("rate", replay.DefaultRateLimit, "maximum events published per second")
+	batchSize := flags.Int("batch", replay.DefaultBatchSize, "records read per page; progress is checkpointed after each page")
+	actor := flags.String("actor", os.Getenv("USER"), "operator recorded as the job requester in the audit log")
+	resume := flags.String("resume", "", "ID of a failed or cancelled job to resume instead of creating one")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load configuration: %v\n", err)
+		return 1
+	}
+	logger := observability.NewLogger(cfg.AppEnv, "user-service-replay")
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	vaultClient := vault.NewDisabledClient()
+	if cfg.Vault.Enabled {
+		if vaultClient, err = vault.NewClient(cfg.Vault.Addr, cfg.Vault.Token); err != nil {
+			logger.WithField("error", err.Error()).Error("Failed to initialize Vault client")
+			return 1
+		}
+	}
+	if err := cfg.LoadSecretsFromVault(ctx, vaultClient); err != nil {
+		logger.WithField("error", err.Error()).Error("Failed to load secrets from Vault")
+		return 1
+	}
+
+	dbPool, err := initDatabase(ctx, cfg, logger)
+	if err != nil {
+		logger.WithField("error", err.Error()).Error("Failed to initialize database")
+		return 1
+	}
+	defer dbPool.Close()
+
+	zapLogger, err := newEventLogger(cfg)
+	if err != nil {
+		logger.WithField("error", err.Error()).Error("Failed to create zap logger for event publisher")
+		return 1
+	}
+
+	piiEncryptor, err := newPIIEncryptor(cfg, vaultClient)
+	if err != nil {
+		logger.WithField("error", err.Error()).Error("Failed to initialize PII encryption")
+		return 1
+	}
+
+	hostname, _ := os.Hostname()
+	replayService := service.NewReplayService(
+		repository.NewReplayRepository(dbPool, logger).WithFieldEncryption(piiEncryptor),
+		repository.NewAuditRepository(dbPool, logger),
+		newReplayPublisherFactory(ctx, cfg, zapLogger),
+		logger,
+		"cli:"+hostname,
+	)
+
+	var job *replay.Job
+	if *resume != "" {
+		id, err := uuid.Parse(*resume)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid job ID %q\n", *resume)
+			return 2
+		}
+		job, err = replayService.GetJob(ctx, id)
+		if err == nil && (job.Status == replay.StatusFailed || job.Status == replay.StatusCancelled) {
+			job, err = replayService.ResumeJob(ctx, id, *actor)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to resume job: %v\n", err)
+			return 1
+		}
+	} else {
+		req := &replay.Request{
+			Source:       replay.Source(*source),
+			TargetStream: *target,
+			RateLimit:    *rateLimit,
+			BatchSize:    *batchSize,
+			RequestedBy:  *actor,
+		}
+		if *types != "" {
+			req.EventTypes = strings.Split(*types, ",")
+		}
+		if req.From, err = parseReplayTime("from", *from); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		if req.To, err = parseReplayTime("to", *to); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		if job, err = replayService.CreateJob(ctx, req); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to create job: %v\n", err)
+			return 1
+		}
+	}
+
+	fmt.Printf("replay job %s (%s -> %s)\n", job.ID, job.Source, job.TargetStream)
+	return waitForReplayJob(ctx, replayService, job.ID)
+}
+
+// waitForReplayJob runs queued jobs until the given job finishes, printing its progress.
+// The job may be picked up by a running service instance instead; it is then only watched.
+func waitForReplayJob(ctx context.Context, replayService *service.ReplayService, id uuid.UUID) int {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	go func() {
+		for {
+			if _, err := replayService.RunNext(ctx); err != nil && ctx.Err() == nil {
+				fmt.Fprintf(os.Stderr, "replay job failed: %v\n", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+	}()
+
+	for {
+		job, err := replayService.GetJob(context.WithoutCancel(ctx), id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to read job: %v\n", err)
+			return 1
+		}
+		fmt.Printf("%-9s %6.1f%%  %d/%d records, %d events published\n",
+			job.Status, job.Progress()*100, job.ProcessedRecords, job.TotalRecords, job.PublishedEvents)
+
+		if job.Status.IsTerminal() {
+			if job.Status != replay.StatusCompleted {
+				if job.LastError != nil {
+					fmt.Fprintf(os.Stderr, "last error: %s\n", *job.LastError)
+				}
+				return 1
+			}
+			return 0
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Printf("interrupted; resume with: user-service replay -resume %s\n", id)
+			return 130
+		case <-ticker.C:
+		}
+	}
+}
+
+// parseReplayTime parses an optional RFC 3339 flag value
+func parseReplayTime(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -%s time %q: expected RFC 3339", name, value)
+	}
+	return &t, nil
+}
+
+// newReplayPublisherFactory returns a factory that opens a publisher to a replay job's target
+// on the configured transport. Each job gets its own connection, closed when the job ends;
+// target is a Redis stream, a Kafka topic or a NATS subject prefix. Redis targets are not
+// trimmed, so a backfill is never cut short by the stream length limit.
+func newReplayPublisherFactory(ctx context.Context, cfg *config.Config, zapLogger *zap.Logger) service.ReplayPublisherFactory {
+	return func(target string) (common.EventPublisher, error) {
+		switch cfg.Events.Transport {
+		case events.TransportKafka:
+			client, err := events.NewKafkaClient(kafkaOptions(cfg))
+			if err != nil {
+				return nil, err
+			}
+			return events.NewKafkaEventPublisher(client, target, zapLogger).
+				WithTimeout(cfg.Events.PublishTimeout), nil
+
+		case events.TransportNATS:
+			js, err := events.ConnectJetStream(cfg.Events.NATSURL, cfg.Tracing.ServiceName)
+			if err != nil {
+				return nil, err
+			}
+			return events.NewJetStreamEventPublisher(js, target, zapLogger).
+				WithTimeout(cfg.Events.PublishTimeout), nil
+		}
+
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port),
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		if err := redisClient.Ping(ctx).Err(); err != nil {
+			_ = redisClient.Close()
+			return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+		}
+		return events.NewRedisEventPublisher(redisClient, zapLogger).
+			WithStreamName(target).
+			WithMaxLength(0), nil
+	}
+}
@@ -0,0 +1,124 @@
+// Package alerting provides delivery channels for security alerts and loading of alert
+// rules from YAML. Notifiers implement alert.Notifier and are handed to the rule engine
+// in the service layer; the engine selects them per rule by Name.
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+)
+
+// Notifier names used in rule configuration
+const (
+	NotifierLog     = "log"
+	NotifierWebhook = "webhook"
+	NotifierTable   = "table"
+)
+
+// LogNotifier writes fired alerts to the service log
+type LogNotifier struct {
+	logger *observability.Logger
+}
+
+// NewLogNotifier creates a notifier that logs alerts at error level so they reach log-based paging
+func NewLogNotifier(logger *observability.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+// Name returns "log"
+func (n *LogNotifier) Name() string { return NotifierLog }
+
+// Notify logs the alert
+func (n *LogNotifier) Notify(ctx context.Context, a *alert.Alert) error {
+	n.logger.WithFields(map[string]interface{}{
+		"alert_id":      a.ID.String(),
+		"rule":          a.RuleName,
+		"severity":      string(a.Severity),
+		"dedup_key":     a.DedupKey,
+		"group_by":      string(a.GroupBy),
+		"group_value":   a.GroupValue,
+		"event_count":   a.EventCount,
+		"first_seen_at": a.FirstSeenAt,
+		"last_seen_at":  a.LastSeenAt,
+	}).Error("SECURITY ALERT: " + a.Summary)
+	return nil
+}
+
+// WebhookNotifier posts fired alerts as JSON to an HTTP endpoint (chat, paging, SOAR)
+type WebhookNotifier struct {
+	url     string
+	client  *http.Client
+	timeout time.Duration
+}
+
+// NewWebhookNotifier creates a notifier posting to url. A nil client uses http.DefaultClient;
+// timeout bounds each delivery.
+func NewWebhookNotifier(url string, client *http.Client, timeout time.Duration) *WebhookNotifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &WebhookNotifier{url: url, client: client, timeout: timeout}
+}
+
+// Name returns "webhook"
+func (n *WebhookNotifier) Name() string { return NotifierWebhook }
+
+// Notify posts the alert. Any non-2xx response is an error.
+func (n *WebhookNotifier) Notify(ctx context.Context, a *alert.Alert) error {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "pandora-user-service-alerts")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// StoreNotifier records fired alerts in the alerts table for the acknowledge workflow
+type StoreNotifier struct {
+	repo alert.Repository
+}
+
+// NewStoreNotifier creates a notifier that persists alerts through repo
+func NewStoreNotifier(repo alert.Repository) *StoreNotifier {
+	return &StoreNotifier{repo: repo}
+}
+
+// Name returns "table"
+func (n *StoreNotifier) Name() string { return NotifierTable }
+
+// Notify stores the alert, folding it into an open alert with the same dedup key
+func (n *StoreNotifier) Notify(ctx context.Context, a *alert.Alert) error {
+	_, err := n.repo.Record(ctx, a)
+	return err
+}
@@ -0,0 +1,112 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAlert() *alert.Alert {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	return &alert.Alert{
+		ID:          uuid.New(),
+		RuleName:    "admin-login-bruteforce",
+		Severity:    alert.SeverityCritical,
+		Summary:     "admin-login-bruteforce: 5 admin.login.failed events within 10m0s for ip 10.0.0.1",
+		GroupBy:     alert.GroupByIP,
+		GroupValue:  "10.0.0.1",
+		DedupKey:    "admin-login-bruteforce|ip:10.0.0.1",
+		EventCount:  5,
+		FireCount:   1,
+		FirstSeenAt: now.Add(-time.Minute),
+		LastSeenAt:  now,
+		Status:      alert.StatusOpen,
+		CreatedAt:   now,
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Run("posts the alert as JSON", func(t *testing.T) {
+		var received alert.Alert
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		a := testAlert()
+		err := NewWebhookNotifier(server.URL, server.Client(), time.Second).Notify(context.Background(), a)
+
+		require.NoError(t, err)
+		assert.Equal(t, a.ID, received.ID)
+		assert.Equal(t, a.DedupKey, received.DedupKey)
+		assert.Equal(t, alert.SeverityCritical, received.Severity)
+	})
+
+	t.Run("non-2xx response is an error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		err := NewWebhookNotifier(server.URL, server.Client(), time.Second).Notify(context.Background(), testAlert())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "502")
+	})
+
+	t.Run("times out slow endpoints", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		defer close(release)
+
+		err := NewWebhookNotifier(server.URL, server.Client(), 50*time.Millisecond).Notify(context.Background(), testAlert())
+
+		require.Error(t, err)
+	})
+}
+
+// stubAlertRepository records alerts passed to Record
+type stubAlertRepository struct {
+	alert.Repository
+	recorded []*alert.Alert
+	err      error
+}
+
+func (r *stubAlertRepository) Record(ctx context.Context, a *alert.Alert) (*alert.Alert, error) {
+	r.recorded = append(r.recorded, a)
+	return a, r.err
+}
+
+func TestStoreNotifier(t *testing.T) {
+	repo := &stubAlertRepository{}
+	notifier := NewStoreNotifier(repo)
+
+	require.NoError(t, notifier.Notify(context.Background(), testAlert()))
+	assert.Len(t, repo.recorded, 1)
+
+	repo.err = errors.New("db down")
+	assert.Error(t, notifier.Notify(context.Background(), testAlert()))
+}
+
+func TestLogNotifier(t *testing.T) {
+	notifier := NewLogNotifier(observability.NewLogger("dev", "test-service"))
+
+	assert.Equal(t, NotifierLog, notifier.Name())
+	assert.NoError(t, notifier.Notify(context.Background(), testAlert()))
+}
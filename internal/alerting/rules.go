@@ -0,0 +1,128 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"gopkg.in/yaml.v3"
+)
+
+// rulesFile is the layout of an alert rules YAML file
+type rulesFile struct {
+	Rules []alert.Rule `yaml:"rules"`
+}
+
+// ParseRules decodes and validates alert rules from YAML. Unknown fields are rejected so
+// that a typo does not silently disable part of a rule.
+func ParseRules(data []byte) ([]alert.Rule, error) {
+	var file rulesFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse alert rules: %w", err)
+	}
+	if err := alert.ValidateRules(file.Rules); err != nil {
+		return nil, err
+	}
+	return file.Rules, nil
+}
+
+// LoadRules reads and validates alert rules from a YAML file
+func LoadRules(filename string) ([]alert.Rule, error) {
+	data, _, err := readRulesFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRules(data)
+}
+
+func readRulesFile(filename string) ([]byte, [sha256.Size]byte, error) {
+	if strings.Contains(filename, "..") {
+		return nil, [sha256.Size]byte{}, fmt.Errorf("invalid alert rules file path: %s", filename)
+	}
+	data, err := os.ReadFile(filename) // #nosec G304 - path is validated above
+	if err != nil {
+		return nil, [sha256.Size]byte{}, fmt.Errorf("failed to read alert rules file: %w", err)
+	}
+	return data, sha256.Sum256(data), nil
+}
+
+// RuleSink receives rule sets; implemented by the alert engine
+type RuleSink interface {
+	SetRules(rules []alert.Rule) error
+}
+
+// RuleWatcher hot-reloads alert rules by polling a YAML file for changes.
+// A file that fails to parse or validate is logged and the previous rules stay active.
+type RuleWatcher struct {
+	filename string
+	interval time.Duration
+	sink     RuleSink
+	logger   *observability.Logger
+	lastHash [sha256.Size]byte
+}
+
+// NewRuleWatcher creates a watcher that installs rules from filename into sink
+func NewRuleWatcher(filename string, interval time.Duration, sink RuleSink, logger *observability.Logger) *RuleWatcher {
+	return &RuleWatcher{
+		filename: filename,
+		interval: interval,
+		sink:     sink,
+		logger:   logger,
+	}
+}
+
+// Load reads the file and installs its rules if the content changed since the last load.
+// It reports whether a new rule set was installed.
+func (w *RuleWatcher) Load() (bool, error) {
+	data, hash, err := readRulesFile(w.filename)
+	if err != nil {
+		return false, err
+	}
+	if hash == w.lastHash {
+		return false, nil
+	}
+
+	rules, err := ParseRules(data)
+	if err != nil {
+		return false, err
+	}
+	if err := w.sink.SetRules(rules); err != nil {
+		return false, err
+	}
+
+	w.lastHash = hash
+	w.logger.WithFields(map[string]interface{}{
+		"file":  w.filename,
+		"rules": len(rules),
+	}).Info("Alert rules loaded")
+	return true, nil
+}
+
+// Run polls the file every interval until ctx is cancelled
+func (w *RuleWatcher) Run(ctx context.Context) {
+	if w.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := w.Load(); err != nil {
+				w.logger.WithError(err).WithField("file", w.filename).Error("Failed to reload alert rules, keeping current rules")
+			}
+		}
+	}
+}
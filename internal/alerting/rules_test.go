@@ -0,0 +1,112 @@
+package alerting
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRulesYAML = `
+rules:
+  - name: admin-login-bruteforce
+    type: threshold
+    severity: critical
+    event_types: [admin.login.failed]
+    group_by: ip
+    threshold: 5
+    window: 10m
+    notifiers: [webhook, table]
+  - name: revoked-token-then-password-change
+    type: sequence
+    sequence: [token.refresh.revoked, user.password.changed]
+    group_by: user
+    window: 1h
+`
+
+// recordingSink records rule sets installed by the watcher
+type recordingSink struct {
+	sets [][]alert.Rule
+}
+
+func (s *recordingSink) SetRules(rules []alert.Rule) error {
+	s.sets = append(s.sets, rules)
+	return nil
+}
+
+func writeRules(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestParseRules(t *testing.T) {
+	t.Run("parses threshold and sequence rules", func(t *testing.T) {
+		rules, err := ParseRules([]byte(testRulesYAML))
+
+		require.NoError(t, err)
+		require.Len(t, rules, 2)
+		assert.Equal(t, alert.RuleThreshold, rules[0].Type)
+		assert.Equal(t, 10*time.Minute, rules[0].Window)
+		assert.Equal(t, 10*time.Minute, rules[0].Cooldown)
+		assert.Equal(t, []string{"webhook", "table"}, rules[0].Notifiers)
+		assert.Equal(t, alert.RuleSequence, rules[1].Type)
+		assert.Equal(t, alert.SeverityMedium, rules[1].Severity)
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		_, err := ParseRules([]byte("rules:\n  - name: x\n    treshold: 5\n"))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects invalid rules", func(t *testing.T) {
+		_, err := ParseRules([]byte("rules:\n  - name: x\n    type: threshold\n    window: 1m\n"))
+
+		assert.True(t, errors.Is(err, alert.ErrInvalidRule))
+	})
+}
+
+func TestLoadRules_ExampleFile(t *testing.T) {
+	path, err := filepath.Abs(filepath.Join("..", "..", "configs", "alert-rules.example.yaml"))
+	require.NoError(t, err)
+
+	rules, err := LoadRules(path)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, rules)
+}
+
+func TestRuleWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alert-rules.yaml")
+	writeRules(t, path, testRulesYAML)
+
+	sink := &recordingSink{}
+	watcher := NewRuleWatcher(path, time.Second, sink, observability.NewLogger("dev", "test-service"))
+
+	loaded, err := watcher.Load()
+	require.NoError(t, err)
+	assert.True(t, loaded)
+	require.Len(t, sink.sets, 1)
+
+	loaded, err = watcher.Load()
+	require.NoError(t, err)
+	assert.False(t, loaded, "unchanged file must not reload")
+
+	writeRules(t, path, "rules:\n  - name: broken\n")
+	_, err = watcher.Load()
+	assert.Error(t, err)
+	assert.Len(t, sink.sets, 1, "invalid file must keep the current rules")
+
+	writeRules(t, path, "rules: []\n")
+	loaded, err = watcher.Load()
+	require.NoError(t, err)
+	assert.True(t, loaded)
+	require.Len(t, sink.sets, 2)
+	assert.Empty(t, sink.sets[1])
+}
@@ -8,13 +8,22 @@ package config
 
 import (
 	"context"
+	"crypto/ed25519"
+	"encoding/base64"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
@@ -33,15 +42,29 @@ const (
 
 // Config holds all configuration for the User Service
 type Config struct {
-	AppEnv    string          `mapstructure:"APP_ENV"`
-	Server    ServerConfig    `mapstructure:",squash"`
-	Database  DatabaseConfig  `mapstructure:",squash"`
-	JWT       JWTConfig       `mapstructure:",squash"`
-	Redis     RedisConfig     `mapstructure:",squash"`
-	Tracing   TracingConfig   `mapstructure:",squash"`
-	Audit     AuditConfig     `mapstructure:",squash"`
-	Vault     VaultConfig     `mapstructure:",squash"`
-	RateLimit RateLimitConfig `mapstructure:",squash"`
+	AppEnv        string              `mapstructure:"APP_ENV"`
+	Server        ServerConfig        `mapstructure:",squash"`
+	Database      DatabaseConfig      `mapstructure:",squash"`
+	JWT           JWTConfig           `mapstructure:",squash"`
+	Redis         RedisConfig         `mapstructure:",squash"`
+	Tracing       TracingConfig       `mapstructure:",squash"`
+	Audit         AuditConfig         `mapstructure:",squash"`
+	Alert         AlertConfig         `mapstructure:",squash"`
+	Outbox        OutboxConfig        `mapstructure:",squash"`
+	Webhook       WebhookConfig       `mapstructure:",squash"`
+	Events        EventsConfig        `mapstructure:",squash"`
+	Storage       StorageConfig       `mapstructure:",squash"`
+	KYC           KYCConfig           `mapstructure:",squash"`
+	Screening     ScreeningConfig     `mapstructure:",squash"`
+	Phone         PhoneConfig         `mapstructure:",squash"`
+	PII           PIIConfig           `mapstructure:",squash"`
+	DataExport    DataExportConfig    `mapstructure:",squash"`
+	Erasure       ErasureConfig       `mapstructure:",squash"`
+	Restore       RestoreConfig       `mapstructure:",squash"`
+	AccountStatus AccountStatusConfig `mapstructure:",squash"`
+	Stats         StatsConfig         `mapstructure:",squash"`
+	Vault         VaultConfig         `mapstructure:",squash"`
+	RateLimit     RateLimitConfig     `mapstructure:",squash"`
 }
 
 // ServerConfig holds HTTP/gRPC server configuration
@@ -99,6 +122,426 @@ type AuditConfig struct {
 	// CleanupInterval specifies how often to run the cleanup job (in hours)
 	// Default: 24 hours (daily cleanup)
 	CleanupInterval time.Duration `mapstructure:"AUDIT_CLEANUP_INTERVAL"`
+
+	// PartitionMonthsAhead specifies how many future monthly partitions of audit_logs
+	// are kept pre-created so inserts never hit a missing partition
+	// Default: 3 months
+	PartitionMonthsAhead int `mapstructure:"AUDIT_PARTITION_MONTHS_AHEAD"`
+
+	// ArchiveEnabled turns on archiving of partitions older than RetentionDays to the
+	// object store (the partition is dropped once archived)
+	// Default: true
+	ArchiveEnabled bool `mapstructure:"AUDIT_ARCHIVE_ENABLED"`
+
+	// ArchivePrefix is the object store key prefix for audit log archives
+	// Default: "audit-logs"
+	ArchivePrefix string `mapstructure:"AUDIT_ARCHIVE_PREFIX"`
+
+	// RetentionPolicyFile is an optional YAML file with per-category, per-severity and
+	// per-event-type retention rules. RetentionDays stays the default for unmatched events.
+	RetentionPolicyFile string `mapstructure:"AUDIT_RETENTION_POLICY_FILE" yaml:"retention_policy_file"`
+
+	// RetentionRules are the rules loaded from RetentionPolicyFile
+	RetentionRules []RetentionRuleConfig `mapstructure:"-" yaml:"retention_rules"`
+}
+
+// RetentionRuleConfig is one entry of the audit retention policy file.
+// At least one of EventType, Category or Severity must be set.
+type RetentionRuleConfig struct {
+	EventType string `yaml:"event_type"`
+	Category  string `yaml:"category"`
+	Severity  string `yaml:"severity"`
+	Days      int    `yaml:"days"`
+}
+
+// AlertConfig holds security alerting configuration
+type AlertConfig struct {
+	// Enabled turns on the alert rule engine over audit and security events
+	// Default: false
+	Enabled bool `mapstructure:"ALERTS_ENABLED" yaml:"enabled"`
+
+	// RulesFile is the YAML file with alert rules (see configs/alert-rules.example.yaml).
+	// Required when Enabled is true.
+	RulesFile string `mapstructure:"ALERT_RULES_FILE" yaml:"rules_file"`
+
+	// ReloadInterval is how often RulesFile is checked for changes; 0 disables hot reload
+	// Default: 30s
+	ReloadInterval time.Duration `mapstructure:"ALERT_RULES_RELOAD_INTERVAL" yaml:"reload_interval"`
+
+	// WebhookURL receives fired alerts as JSON POSTs; empty disables the webhook notifier
+	WebhookURL string `mapstructure:"ALERT_WEBHOOK_URL" yaml:"webhook_url"`
+
+	// WebhookTimeout bounds each webhook delivery
+	// Default: 5s
+	WebhookTimeout time.Duration `mapstructure:"ALERT_WEBHOOK_TIMEOUT" yaml:"webhook_timeout"`
+}
+
+// OutboxConfig holds transactional outbox relay configuration
+type OutboxConfig struct {
+	// RelayInterval is how often the relay polls the outbox for due events
+	// Default: 1s
+	RelayInterval time.Duration `mapstructure:"OUTBOX_RELAY_INTERVAL" yaml:"relay_interval"`
+
+	// BatchSize is the maximum number of events published per poll
+	// Default: 100
+	BatchSize int `mapstructure:"OUTBOX_BATCH_SIZE" yaml:"batch_size"`
+
+	// Retention is how long published events are kept before they are deleted
+	// Default: 24h
+	Retention time.Duration `mapstructure:"OUTBOX_RETENTION" yaml:"retention"`
+}
+
+// WebhookConfig holds outbound webhook delivery configuration.
+// Subscriptions themselves are managed through the admin API.
+type WebhookConfig struct {
+	// DeliveryInterval is how often the dispatcher polls for due deliveries
+	// Default: 1s
+	DeliveryInterval time.Duration `mapstructure:"WEBHOOK_DELIVERY_INTERVAL" yaml:"delivery_interval"`
+
+	// BatchSize is the maximum number of deliveries attempted per poll
+	// Default: 50
+	BatchSize int `mapstructure:"WEBHOOK_BATCH_SIZE" yaml:"batch_size"`
+
+	// Timeout bounds each delivery request
+	// Default: 10s
+	Timeout time.Duration `mapstructure:"WEBHOOK_TIMEOUT" yaml:"timeout"`
+
+	// MaxAttempts is how often a delivery is tried before it is marked failed
+	// Default: 12
+	MaxAttempts int `mapstructure:"WEBHOOK_MAX_ATTEMPTS" yaml:"max_attempts"`
+
+	// DisableAfterFailures disables a subscription after this many consecutive failed attempts
+	// Default: 20
+	DisableAfterFailures int `mapstructure:"WEBHOOK_DISABLE_AFTER_FAILURES" yaml:"disable_after_failures"`
+
+	// Retention is how long succeeded and failed deliveries stay in the delivery log
+	// Default: 168h (7 days)
+	Retention time.Duration `mapstructure:"WEBHOOK_DELIVERY_RETENTION" yaml:"delivery_retention"`
+}
+
+// EventsConfig selects and configures the transport domain events are published on
+type EventsConfig struct {
+	// Transport selects the event bus
+	// Supported: "redis", "kafka", "nats"
+	// Default: redis
+	Transport string `mapstructure:"EVENT_TRANSPORT" yaml:"transport"`
+
+	// PublishTimeout bounds each publish to Kafka or NATS
+	// Default: 10s
+	PublishTimeout time.Duration `mapstructure:"EVENT_PUBLISH_TIMEOUT" yaml:"publish_timeout"`
+
+	// RedisStreamMaxLen is the approximate number of events kept in each Redis stream;
+	// 0 keeps every event
+	// Default: 10000
+	RedisStreamMaxLen int64 `mapstructure:"EVENT_REDIS_STREAM_MAX_LEN" yaml:"redis_stream_max_len"`
+
+	// KafkaBrokers are the comma-separated seed brokers (host:port) events are produced to
+	KafkaBrokers string `mapstructure:"KAFKA_BROKERS" yaml:"kafka_brokers"`
+
+	// KafkaTLS connects to the brokers over TLS
+	// Default: false
+	KafkaTLS bool `mapstructure:"KAFKA_TLS" yaml:"kafka_tls"`
+
+	// KafkaTopic receives user events, keyed by user ID
+	// Default: user-service.events
+	KafkaTopic string `mapstructure:"KAFKA_TOPIC" yaml:"kafka_topic"`
+
+	// KafkaAuditTopic receives high and critical severity audit events
+	// Default: user-service.audit
+	KafkaAuditTopic string `mapstructure:"KAFKA_AUDIT_TOPIC" yaml:"kafka_audit_topic"`
+
+	// KafkaSASLMechanism authenticates with KafkaUsername and KafkaPassword when a username is set
+	// Supported: "plain", "scram-sha-256", "scram-sha-512"
+	// Default: scram-sha-512
+	KafkaSASLMechanism string `mapstructure:"KAFKA_SASL_MECHANISM" yaml:"kafka_sasl_mechanism"`
+
+	// KafkaUsername and KafkaPassword are the SASL credentials; empty disables SASL
+	KafkaUsername string `mapstructure:"KAFKA_USERNAME" yaml:"kafka_username"`
+	KafkaPassword string `mapstructure:"KAFKA_PASSWORD" yaml:"kafka_password"`
+
+	// NATSURL is the NATS server, as nats://[user:pass@]host:port or tls://...
+	// Default: nats://localhost:4222
+	NATSURL string `mapstructure:"NATS_URL" yaml:"nats_url"`
+
+	// NATSSubjectPrefix prefixes user event subjects (<prefix>.<event type>)
+	// Default: user-service.events
+	NATSSubjectPrefix string `mapstructure:"NATS_SUBJECT_PREFIX" yaml:"nats_subject_prefix"`
+
+	// NATSAuditSubjectPrefix prefixes audit event subjects
+	// Default: user-service.audit
+	NATSAuditSubjectPrefix string `mapstructure:"NATS_AUDIT_SUBJECT_PREFIX" yaml:"nats_audit_subject_prefix"`
+}
+
+// StorageConfig holds object storage configuration (audit archives, documents, exports)
+type StorageConfig struct {
+	// Backend selects the object store implementation
+	// Supported: "local"
+	Backend string `mapstructure:"STORAGE_BACKEND"`
+
+	// LocalPath is the root directory of the local filesystem backend
+	// Default: "./data/objects"
+	LocalPath string `mapstructure:"STORAGE_LOCAL_PATH"`
+}
+
+// KYCConfig holds KYC document storage and tier configuration.
+// Documents are kept in the object store configured by StorageConfig.
+type KYCConfig struct {
+	// DocumentPrefix is the object store prefix documents are stored under
+	// Default: "kyc-documents"
+	DocumentPrefix string `mapstructure:"KYC_DOCUMENT_PREFIX" yaml:"document_prefix"`
+
+	// MaxDocumentBytes is the largest document an applicant may upload
+	// Default: 10485760 (10 MiB); must not exceed 20 MiB
+	MaxDocumentBytes int64 `mapstructure:"KYC_MAX_DOCUMENT_BYTES" yaml:"max_document_bytes"`
+
+	// TiersFile is an optional YAML file with the currency and limits of every KYC tier
+	// (see configs/kyc-tiers.example.yaml). The built-in tiers are used otherwise.
+	TiersFile string `mapstructure:"KYC_TIERS_FILE" yaml:"tiers_file"`
+
+	// TierCurrency and Tiers are the policy loaded from TiersFile
+	TierCurrency string          `mapstructure:"-" yaml:"tier_currency"`
+	Tiers        []KYCTierConfig `mapstructure:"-" yaml:"tiers"`
+
+	// Provider is the external verification provider; empty disables external verification
+	// Supported: "simulator" (not allowed in production)
+	Provider string `mapstructure:"KYC_PROVIDER" yaml:"provider"`
+
+	// ProviderWebhookSecret verifies the provider's decision webhooks
+	// Required when Provider is set
+	ProviderWebhookSecret string `mapstructure:"KYC_PROVIDER_WEBHOOK_SECRET" yaml:"provider_webhook_secret"`
+
+	// ProviderTimeout bounds each call to the provider
+	// Default: 15s
+	ProviderTimeout time.Duration `mapstructure:"KYC_PROVIDER_TIMEOUT" yaml:"provider_timeout"`
+
+	// SimulatorScenario is how the simulator decides cases that tests did not script:
+	// approve, reject, resubmit, expire or timeout
+	// Default: approve
+	SimulatorScenario string `mapstructure:"KYC_SIMULATOR_SCENARIO" yaml:"simulator_scenario"`
+
+	// SimulatorCallbackURL receives the simulator's decision webhooks, normally this
+	// service's /api/v1/webhooks/kyc/simulator endpoint. When empty, nothing is delivered
+	// automatically.
+	SimulatorCallbackURL string `mapstructure:"KYC_SIMULATOR_CALLBACK_URL" yaml:"simulator_callback_url"`
+
+	// SimulatorDecisionDelay is how long after a verification link is issued the simulator
+	// delivers its decision
+	// Default: 5s
+	SimulatorDecisionDelay time.Duration `mapstructure:"KYC_SIMULATOR_DECISION_DELAY" yaml:"simulator_decision_delay"`
+}
+
+// KYCTierConfig is one entry of the KYC tier file.
+// Empty limits are unlimited; empty jurisdictions allow every jurisdiction.
+type KYCTierConfig struct {
+	Tier                 int      `yaml:"tier"`
+	Name                 string   `yaml:"name"`
+	DailyDepositLimit    string   `yaml:"daily_deposit_limit"`
+	DailyWithdrawalLimit string   `yaml:"daily_withdrawal_limit"`
+	Products             []string `yaml:"products"`
+	Jurisdictions        []string `yaml:"jurisdictions"`
+}
+
+// ScreeningConfig holds sanctions and PEP screening configuration.
+// The lists are read from local files kept up to date outside the service.
+type ScreeningConfig struct {
+	// Enabled turns on screening at registration, profile changes and KYC approval.
+	// While enabled and no list could be loaded, KYC approvals fail.
+	// Default: false
+	Enabled bool `mapstructure:"SCREENING_ENABLED" yaml:"enabled"`
+
+	// OFACSDNFile is the OFAC SDN list in its sdn.csv layout
+	OFACSDNFile string `mapstructure:"SCREENING_OFAC_SDN_FILE" yaml:"ofac_sdn_file"`
+
+	// EUFile is the EU consolidated financial sanctions list in its XML layout
+	EUFile string `mapstructure:"SCREENING_EU_FILE" yaml:"eu_file"`
+
+	// PEPFile is a CSV of politically exposed persons (see configs/pep-list.example.csv)
+	PEPFile string `mapstructure:"SCREENING_PEP_FILE" yaml:"pep_file"`
+
+	// ReloadInterval is how often the files are checked for changes; every user is
+	// re-screened when they changed. 0 disables reloading.
+	// Default: 1h
+	ReloadInterval time.Duration `mapstructure:"SCREENING_RELOAD_INTERVAL" yaml:"reload_interval"`
+
+	// NameThreshold is the name similarity (0.5-1) from which a list entry matches
+	// Default: 0.9
+	NameThreshold float64 `mapstructure:"SCREENING_NAME_THRESHOLD" yaml:"name_threshold"`
+
+	// DOBYearTolerance is how many years a date of birth may differ from a listed one
+	// before it rules the entry out
+	// Default: 1
+	DOBYearTolerance int `mapstructure:"SCREENING_DOB_YEAR_TOLERANCE" yaml:"dob_year_tolerance"`
+}
+
+// Thresholds returns the configured matching thresholds
+func (s ScreeningConfig) Thresholds() screening.Thresholds {
+	return screening.Thresholds{Name: s.NameThreshold, DOBYearTolerance: s.DOBYearTolerance}
+}
+
+// PhoneConfig holds phone number verification configuration.
+type PhoneConfig struct {
+	// VerificationSender delivers verification codes: "" (phone verification disabled) or
+	// "log" (codes are written to the service log; not allowed in production)
+	// Default: ""
+	VerificationSender string `mapstructure:"PHONE_VERIFICATION_SENDER" yaml:"verification_sender"`
+
+	// CodeTTL is how long a verification code can be confirmed
+	// Default: 10m
+	CodeTTL time.Duration `mapstructure:"PHONE_VERIFICATION_CODE_TTL" yaml:"code_ttl"`
+
+	// MaxAttempts is how many wrong codes are accepted before a new one has to be requested
+	// Default: 5
+	MaxAttempts int `mapstructure:"PHONE_VERIFICATION_MAX_ATTEMPTS" yaml:"max_attempts"`
+}
+
+// PIIConfig holds field-level encryption configuration for user PII.
+// Each user's PII is encrypted with its own data key, which is wrapped by the key provider.
+type PIIConfig struct {
+	// EncryptionProvider wraps data keys: "" (PII stored in plaintext), "vault" (Vault
+	// Transit; requires VAULT_ENABLED) or "local" (PII_LOCAL_MASTER_KEYS; not allowed in production)
+	// Default: ""
+	EncryptionProvider string `mapstructure:"PII_ENCRYPTION_PROVIDER" yaml:"encryption_provider"`
+
+	// VaultTransitMount is the mount path of the Vault Transit secrets engine
+	// Default: "transit"
+	VaultTransitMount string `mapstructure:"PII_VAULT_TRANSIT_MOUNT" yaml:"vault_transit_mount"`
+
+	// VaultTransitKey is the name of the Transit key wrapping data keys
+	// Default: "user-pii"
+	VaultTransitKey string `mapstructure:"PII_VAULT_TRANSIT_KEY" yaml:"vault_transit_key"`
+
+	// LocalMasterKeys is a comma-separated list of base64 encoded 32-byte keys for the local
+	// provider, oldest first; append a key to rotate
+	LocalMasterKeys string `mapstructure:"PII_LOCAL_MASTER_KEYS" yaml:"local_master_keys"`
+
+	// BlindIndexKey is the base64 encoded HMAC key (at least 32 bytes) of the email blind index.
+	// Loaded from Vault when enabled; changing it makes existing users unfindable by email.
+	BlindIndexKey string `mapstructure:"PII_BLIND_INDEX_KEY" yaml:"blind_index_key"`
+
+	// DataKeyCacheTTL is how long unwrapped data keys are cached in memory (0 disables caching)
+	// Default: 5m
+	DataKeyCacheTTL time.Duration `mapstructure:"PII_DATA_KEY_CACHE_TTL" yaml:"data_key_cache_ttl"`
+
+	// DataKeyCacheSize is the maximum number of cached data keys
+	// Default: 10000
+	DataKeyCacheSize int `mapstructure:"PII_DATA_KEY_CACHE_SIZE" yaml:"data_key_cache_size"`
+
+	// ReencryptInterval is how often plaintext PII is encrypted and data keys wrapped with an
+	// older key version are rewrapped (0 disables the job)
+	// Default: 1h
+	ReencryptInterval time.Duration `mapstructure:"PII_REENCRYPT_INTERVAL" yaml:"reencrypt_interval"`
+
+	// ReencryptBatchSize is the number of users re-encrypted per batch
+	// Default: 100
+	ReencryptBatchSize int `mapstructure:"PII_REENCRYPT_BATCH_SIZE" yaml:"reencrypt_batch_size"`
+}
+
+// DataExportConfig holds data subject access export configuration.
+// Exports are built by a background job into signed ZIP archives in the object store.
+type DataExportConfig struct {
+	// Enabled turns on the data export endpoints and the export job
+	// Default: false
+	Enabled bool `mapstructure:"DATA_EXPORT_ENABLED" yaml:"enabled"`
+
+	// SigningKey is the base64 encoded 32-byte Ed25519 seed archive manifests are signed with.
+	// Loaded from Vault when enabled.
+	SigningKey string `mapstructure:"DATA_EXPORT_SIGNING_KEY" yaml:"signing_key"`
+
+	// Prefix is the object store prefix archives are written under
+	// Default: "data-exports"
+	Prefix string `mapstructure:"DATA_EXPORT_PREFIX" yaml:"prefix"`
+
+	// Retention is how long a completed archive can be downloaded before it is deleted
+	// Default: 168h
+	Retention time.Duration `mapstructure:"DATA_EXPORT_RETENTION" yaml:"retention"`
+
+	// LinkTTL is how long a download link is valid
+	// Default: 15m
+	LinkTTL time.Duration `mapstructure:"DATA_EXPORT_LINK_TTL" yaml:"link_ttl"`
+
+	// PollInterval is how often the export job looks for queued exports
+	// Default: 10s
+	PollInterval time.Duration `mapstructure:"DATA_EXPORT_POLL_INTERVAL" yaml:"poll_interval"`
+}
+
+// ErasureConfig holds GDPR erasure configuration.
+// Deleted accounts are erased by a background job once their cooling-off period has passed.
+type ErasureConfig struct {
+	// Enabled turns on the erasure job
+	// Default: false
+	Enabled bool `mapstructure:"ERASURE_ENABLED" yaml:"enabled"`
+
+	// CoolingOff is how long a deleted account waits before it is erased
+	// Default: 720h (30 days)
+	CoolingOff time.Duration `mapstructure:"ERASURE_COOLING_OFF" yaml:"cooling_off"`
+
+	// AMLRetention is how long after deletion a user who submitted KYC or was screened keeps
+	// their KYC and screening records; their erasure is deferred until then. 0 disables it.
+	// Default: 43800h (5 years)
+	AMLRetention time.Duration `mapstructure:"ERASURE_AML_RETENTION" yaml:"aml_retention"`
+
+	// Interval is how often the erasure job looks for due erasures
+	// Default: 1h
+	Interval time.Duration `mapstructure:"ERASURE_INTERVAL" yaml:"interval"`
+
+	// BatchSize is the number of users considered per batch
+	// Default: 50
+	BatchSize int `mapstructure:"ERASURE_BATCH_SIZE" yaml:"batch_size"`
+}
+
+// RestoreConfig holds account restore configuration.
+// Deleted accounts can be restored within a grace period, by an admin or by the user
+// through a signed link sent when they delete their account.
+type RestoreConfig struct {
+	// GracePeriod is how long after deletion an account can be restored (0 disables restore).
+	// Must not exceed ERASURE_COOLING_OFF when erasure is enabled.
+	// Default: 720h (30 days)
+	GracePeriod time.Duration `mapstructure:"ACCOUNT_RESTORE_GRACE_PERIOD" yaml:"grace_period"`
+
+	// LinkSender delivers restore links: "" (no links; admins can still restore) or "log"
+	// (links are written to the service log; not allowed in production)
+	// Default: ""
+	LinkSender string `mapstructure:"ACCOUNT_RESTORE_LINK_SENDER" yaml:"link_sender"`
+
+	// LinkURL is the page restore links point at; the token is added as the token query parameter
+	// Default: "http://localhost:3000/account/restore"
+	LinkURL string `mapstructure:"ACCOUNT_RESTORE_LINK_URL" yaml:"link_url"`
+
+	// SigningKey is the base64 encoded HMAC key (at least 32 bytes) restore links are signed with.
+	// Loaded from Vault when enabled.
+	SigningKey string `mapstructure:"ACCOUNT_RESTORE_SIGNING_KEY" yaml:"signing_key"`
+}
+
+// AccountStatusConfig holds account status configuration.
+// Admins can suspend, freeze withdrawals of or close accounts without deleting them.
+type AccountStatusConfig struct {
+	// RevokeSessions lists the statuses whose setting revokes the user's sessions,
+	// comma-separated, or "none"
+	// Default: "suspended,closed"
+	RevokeSessions string `mapstructure:"ACCOUNT_STATUS_REVOKE_SESSIONS" yaml:"revoke_sessions"`
+
+	// ExpiryInterval is how often suspensions and freezes past their expiry are lifted
+	// (0 disables the job; expired statuses are still not enforced)
+	// Default: 1m
+	ExpiryInterval time.Duration `mapstructure:"ACCOUNT_STATUS_EXPIRY_INTERVAL" yaml:"expiry_interval"`
+
+	// ExpiryBatchSize is the number of expired statuses lifted per batch
+	// Default: 100
+	ExpiryBatchSize int `mapstructure:"ACCOUNT_STATUS_EXPIRY_BATCH_SIZE" yaml:"expiry_batch_size"`
+}
+
+// StatsConfig holds admin statistics configuration.
+// The admin dashboard reads daily rollups and materialized views refreshed by the statistics
+// rollup job rather than scanning users, sessions and audit logs.
+type StatsConfig struct {
+	// RefreshInterval is how often the statistics rollups are refreshed
+	// (0 disables the job; the dashboard keeps showing the last refresh)
+	// Default: 5m
+	RefreshInterval time.Duration `mapstructure:"STATS_REFRESH_INTERVAL" yaml:"refresh_interval"`
+
+	// BackfillDays is the number of past days rolled up on the first refresh
+	// Default: 90
+	BackfillDays int `mapstructure:"STATS_BACKFILL_DAYS" yaml:"backfill_days"`
 }
 
 // VaultConfig holds HashiCorp Vault configuration for secret management
@@ -164,6 +607,74 @@ func Load() (*Config, error) {
 	v.SetDefault("OTEL_SAMPLE_RATE", 1.0)
 	v.SetDefault("AUDIT_LOGS_KEEP_FOR_DAYS", 90)
 	v.SetDefault("AUDIT_CLEANUP_INTERVAL", "24h")
+	v.SetDefault("AUDIT_PARTITION_MONTHS_AHEAD", 3)
+	v.SetDefault("AUDIT_ARCHIVE_ENABLED", true)
+	v.SetDefault("AUDIT_ARCHIVE_PREFIX", "audit-logs")
+	v.SetDefault("AUDIT_RETENTION_POLICY_FILE", "")
+	v.SetDefault("ALERTS_ENABLED", false)
+	v.SetDefault("ALERT_RULES_FILE", "")
+	v.SetDefault("ALERT_RULES_RELOAD_INTERVAL", "30s")
+	v.SetDefault("ALERT_WEBHOOK_URL", "")
+	v.SetDefault("ALERT_WEBHOOK_TIMEOUT", "5s")
+	v.SetDefault("OUTBOX_RELAY_INTERVAL", "1s")
+	v.SetDefault("OUTBOX_BATCH_SIZE", 100)
+	v.SetDefault("OUTBOX_RETENTION", "24h")
+	v.SetDefault("WEBHOOK_DELIVERY_INTERVAL", "1s")
+	v.SetDefault("WEBHOOK_BATCH_SIZE", 50)
+	v.SetDefault("WEBHOOK_TIMEOUT", "10s")
+	v.SetDefault("WEBHOOK_MAX_ATTEMPTS", 12)
+	v.SetDefault("WEBHOOK_DISABLE_AFTER_FAILURES", 20)
+	v.SetDefault("WEBHOOK_DELIVERY_RETENTION", "168h")
+	v.SetDefault("EVENT_TRANSPORT", "redis")
+	v.SetDefault("EVENT_PUBLISH_TIMEOUT", "10s")
+	v.SetDefault("EVENT_REDIS_STREAM_MAX_LEN", 10000)
+	v.SetDefault("KAFKA_SASL_MECHANISM", "scram-sha-512")
+	v.SetDefault("KAFKA_TOPIC", "user-service.events")
+	v.SetDefault("KAFKA_AUDIT_TOPIC", "user-service.audit")
+	v.SetDefault("NATS_URL", "nats://localhost:4222")
+	v.SetDefault("NATS_SUBJECT_PREFIX", "user-service.events")
+	v.SetDefault("NATS_AUDIT_SUBJECT_PREFIX", "user-service.audit")
+	v.SetDefault("STORAGE_BACKEND", "local")
+	v.SetDefault("STORAGE_LOCAL_PATH", "./data/objects")
+	v.SetDefault("KYC_DOCUMENT_PREFIX", "kyc-documents")
+	v.SetDefault("KYC_MAX_DOCUMENT_BYTES", 10<<20)
+	v.SetDefault("KYC_TIERS_FILE", "")
+	v.SetDefault("KYC_PROVIDER", "")
+	v.SetDefault("KYC_PROVIDER_TIMEOUT", "15s")
+	v.SetDefault("KYC_SIMULATOR_SCENARIO", "approve")
+	v.SetDefault("KYC_SIMULATOR_DECISION_DELAY", "5s")
+	v.SetDefault("SCREENING_ENABLED", false)
+	v.SetDefault("SCREENING_RELOAD_INTERVAL", "1h")
+	v.SetDefault("SCREENING_NAME_THRESHOLD", 0.9)
+	v.SetDefault("SCREENING_DOB_YEAR_TOLERANCE", 1)
+	v.SetDefault("PHONE_VERIFICATION_SENDER", "")
+	v.SetDefault("PHONE_VERIFICATION_CODE_TTL", "10m")
+	v.SetDefault("PHONE_VERIFICATION_MAX_ATTEMPTS", 5)
+	v.SetDefault("PII_ENCRYPTION_PROVIDER", "")
+	v.SetDefault("PII_VAULT_TRANSIT_MOUNT", "transit")
+	v.SetDefault("PII_VAULT_TRANSIT_KEY", "user-pii")
+	v.SetDefault("PII_DATA_KEY_CACHE_TTL", "5m")
+	v.SetDefault("PII_DATA_KEY_CACHE_SIZE", 10000)
+	v.SetDefault("PII_REENCRYPT_INTERVAL", "1h")
+	v.SetDefault("PII_REENCRYPT_BATCH_SIZE", 100)
+	v.SetDefault("DATA_EXPORT_ENABLED", false)
+	v.SetDefault("DATA_EXPORT_PREFIX", "data-exports")
+	v.SetDefault("DATA_EXPORT_RETENTION", "168h")
+	v.SetDefault("DATA_EXPORT_LINK_TTL", "15m")
+	v.SetDefault("DATA_EXPORT_POLL_INTERVAL", "10s")
+	v.SetDefault("ERASURE_ENABLED", false)
+	v.SetDefault("ERASURE_COOLING_OFF", "720h")
+	v.SetDefault("ERASURE_AML_RETENTION", "43800h")
+	v.SetDefault("ERASURE_INTERVAL", "1h")
+	v.SetDefault("ERASURE_BATCH_SIZE", 50)
+	v.SetDefault("ACCOUNT_RESTORE_GRACE_PERIOD", "720h")
+	v.SetDefault("ACCOUNT_RESTORE_LINK_SENDER", "")
+	v.SetDefault("ACCOUNT_RESTORE_LINK_URL", "http://localhost:3000/account/restore")
+	v.SetDefault("ACCOUNT_STATUS_REVOKE_SESSIONS", "suspended,closed")
+	v.SetDefault("ACCOUNT_STATUS_EXPIRY_INTERVAL", "1m")
+	v.SetDefault("ACCOUNT_STATUS_EXPIRY_BATCH_SIZE", 100)
+	v.SetDefault("STATS_REFRESH_INTERVAL", "5m")
+	v.SetDefault("STATS_BACKFILL_DAYS", 90)
 	v.SetDefault("VAULT_ENABLED", false)
 	v.SetDefault("VAULT_ADDR", "http://localhost:8200")
 	v.SetDefault("VAULT_SECRET_PATH", "secret/data/pandora/user-service")
@@ -189,6 +700,35 @@ func Load() (*Config, error) {
 		"REDIS_HOST", "REDIS_PORT", "REDIS_PASSWORD", "REDIS_DB",
 		"OTEL_ENABLED", "OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_SERVICE_NAME", "OTEL_SAMPLE_RATE",
 		"AUDIT_LOGS_KEEP_FOR_DAYS", "AUDIT_CLEANUP_INTERVAL",
+		"AUDIT_PARTITION_MONTHS_AHEAD", "AUDIT_ARCHIVE_ENABLED", "AUDIT_ARCHIVE_PREFIX",
+		"AUDIT_RETENTION_POLICY_FILE",
+		"ALERTS_ENABLED", "ALERT_RULES_FILE", "ALERT_RULES_RELOAD_INTERVAL",
+		"ALERT_WEBHOOK_URL", "ALERT_WEBHOOK_TIMEOUT",
+		"OUTBOX_RELAY_INTERVAL", "OUTBOX_BATCH_SIZE", "OUTBOX_RETENTION",
+		"WEBHOOK_DELIVERY_INTERVAL", "WEBHOOK_BATCH_SIZE", "WEBHOOK_TIMEOUT",
+		"WEBHOOK_MAX_ATTEMPTS", "WEBHOOK_DISABLE_AFTER_FAILURES", "WEBHOOK_DELIVERY_RETENTION",
+		"EVENT_TRANSPORT", "EVENT_PUBLISH_TIMEOUT", "EVENT_REDIS_STREAM_MAX_LEN",
+		"KAFKA_BROKERS", "KAFKA_TLS", "KAFKA_TOPIC", "KAFKA_AUDIT_TOPIC",
+		"KAFKA_SASL_MECHANISM", "KAFKA_USERNAME", "KAFKA_PASSWORD",
+		"NATS_URL", "NATS_SUBJECT_PREFIX", "NATS_AUDIT_SUBJECT_PREFIX",
+		"STORAGE_BACKEND", "STORAGE_LOCAL_PATH",
+		"KYC_DOCUMENT_PREFIX", "KYC_MAX_DOCUMENT_BYTES", "KYC_TIERS_FILE",
+		"KYC_PROVIDER", "KYC_PROVIDER_WEBHOOK_SECRET", "KYC_PROVIDER_TIMEOUT",
+		"KYC_SIMULATOR_SCENARIO", "KYC_SIMULATOR_CALLBACK_URL", "KYC_SIMULATOR_DECISION_DELAY",
+		"SCREENING_ENABLED", "SCREENING_OFAC_SDN_FILE", "SCREENING_EU_FILE", "SCREENING_PEP_FILE",
+		"SCREENING_RELOAD_INTERVAL", "SCREENING_NAME_THRESHOLD", "SCREENING_DOB_YEAR_TOLERANCE",
+		"PHONE_VERIFICATION_SENDER", "PHONE_VERIFICATION_CODE_TTL", "PHONE_VERIFICATION_MAX_ATTEMPTS",
+		"PII_ENCRYPTION_PROVIDER", "PII_VAULT_TRANSIT_MOUNT", "PII_VAULT_TRANSIT_KEY",
+		"PII_LOCAL_MASTER_KEYS", "PII_BLIND_INDEX_KEY", "PII_DATA_KEY_CACHE_TTL",
+		"PII_DATA_KEY_CACHE_SIZE", "PII_REENCRYPT_INTERVAL", "PII_REENCRYPT_BATCH_SIZE",
+		"DATA_EXPORT_ENABLED", "DATA_EXPORT_SIGNING_KEY", "DATA_EXPORT_PREFIX",
+		"DATA_EXPORT_RETENTION", "DATA_EXPORT_LINK_TTL", "DATA_EXPORT_POLL_INTERVAL",
+		"ERASURE_ENABLED", "ERASURE_COOLING_OFF", "ERASURE_AML_RETENTION",
+		"ERASURE_INTERVAL", "ERASURE_BATCH_SIZE",
+		"ACCOUNT_RESTORE_GRACE_PERIOD", "ACCOUNT_RESTORE_LINK_SENDER", "ACCOUNT_RESTORE_LINK_URL",
+		"ACCOUNT_RESTORE_SIGNING_KEY",
+		"ACCOUNT_STATUS_REVOKE_SESSIONS", "ACCOUNT_STATUS_EXPIRY_INTERVAL", "ACCOUNT_STATUS_EXPIRY_BATCH_SIZE",
+		"STATS_REFRESH_INTERVAL", "STATS_BACKFILL_DAYS",
 		"VAULT_ENABLED", "VAULT_ADDR", "VAULT_TOKEN", "VAULT_SECRET_PATH",
 		"RATE_LIMIT_REQUESTS_PER_WINDOW", "RATE_LIMIT_WINDOW_DURATION",
 		"RATE_LIMIT_ENABLE_PER_USER", "RATE_LIMIT_USER_REQUESTS_PER_WINDOW",
@@ -204,6 +744,22 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// Load per-category retention rules if a policy file is configured
+	if cfg.Audit.RetentionPolicyFile != "" {
+		rules, err := loadRetentionRules(cfg.Audit.RetentionPolicyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Audit.RetentionRules = rules
+	}
+
+	// Load KYC tier limits if a tier file is configured
+	if cfg.KYC.TiersFile != "" {
+		if err := loadKYCTiers(&cfg.KYC); err != nil {
+			return nil, err
+		}
+	}
+
 	// Validate configuration
 	if err := Validate(&cfg); err != nil {
 		return nil, err
@@ -342,6 +898,22 @@ func loadFromYAML(filename string) (*Config, error) {
 		return nil, fmt.Errorf("failed to parse YAML config: %w", err)
 	}
 
+	// Inline retention rules take precedence over a policy file
+	if len(cfg.Audit.RetentionRules) == 0 && cfg.Audit.RetentionPolicyFile != "" {
+		rules, err := loadRetentionRules(cfg.Audit.RetentionPolicyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Audit.RetentionRules = rules
+	}
+
+	// Inline tiers take precedence over a tier file
+	if len(cfg.KYC.Tiers) == 0 && cfg.KYC.TiersFile != "" {
+		if err := loadKYCTiers(&cfg.KYC); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set environment from YAML
 	if cfg.AppEnv != "" {
 		_ = os.Setenv("APP_ENV", cfg.AppEnv) // #nosec G104 -- error is always nil
@@ -355,6 +927,98 @@ func loadFromYAML(filename string) (*Config, error) {
 	return &cfg, nil
 }
 
+// retentionPolicyFile is the layout of AUDIT_RETENTION_POLICY_FILE
+type retentionPolicyFile struct {
+	Rules []RetentionRuleConfig `yaml:"rules"`
+}
+
+// loadRetentionRules loads audit retention rules from a YAML file
+func loadRetentionRules(filename string) ([]RetentionRuleConfig, error) {
+	if strings.Contains(filename, "..") {
+		return nil, fmt.Errorf("invalid retention policy file path: path traversal detected")
+	}
+
+	data, err := os.ReadFile(filename) // #nosec G304 -- filename is from AUDIT_RETENTION_POLICY_FILE, validated above
+	if err != nil {
+		return nil, fmt.Errorf("failed to read retention policy file: %w", err)
+	}
+
+	var file retentionPolicyFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse retention policy file: %w", err)
+	}
+
+	return file.Rules, nil
+}
+
+// kycTiersFile is the layout of KYC_TIERS_FILE
+type kycTiersFile struct {
+	Currency string          `yaml:"currency"`
+	Tiers    []KYCTierConfig `yaml:"tiers"`
+}
+
+// loadKYCTiers loads the KYC tier currency and limits from k.TiersFile
+func loadKYCTiers(k *KYCConfig) error {
+	if strings.Contains(k.TiersFile, "..") {
+		return fmt.Errorf("invalid KYC tiers file path: path traversal detected")
+	}
+
+	data, err := os.ReadFile(k.TiersFile) // #nosec G304 -- filename is from KYC_TIERS_FILE, validated above
+	if err != nil {
+		return fmt.Errorf("failed to read KYC tiers file: %w", err)
+	}
+
+	var file kycTiersFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse KYC tiers file: %w", err)
+	}
+
+	k.TierCurrency = file.Currency
+	k.Tiers = file.Tiers
+	return nil
+}
+
+// TierPolicy builds the KYC tier policy from Tiers; kyc.DefaultTierPolicy when none are set
+func (k KYCConfig) TierPolicy() (*kyc.TierPolicy, error) {
+	if len(k.Tiers) == 0 {
+		return kyc.DefaultTierPolicy(), nil
+	}
+
+	tiers := make([]kyc.TierLimits, len(k.Tiers))
+	for i, tier := range k.Tiers {
+		tiers[i] = kyc.TierLimits{
+			Tier:                 kyc.Tier(tier.Tier),
+			Name:                 tier.Name,
+			DailyDepositLimit:    tier.DailyDepositLimit,
+			DailyWithdrawalLimit: tier.DailyWithdrawalLimit,
+			Products:             tier.Products,
+			Jurisdictions:        tier.Jurisdictions,
+		}
+	}
+
+	return kyc.NewTierPolicy(k.TierCurrency, tiers)
+}
+
+// RetentionPolicy builds the audit retention policy from RetentionDays and RetentionRules
+func (a AuditConfig) RetentionPolicy() (*audit.RetentionPolicy, error) {
+	defaultDays := a.RetentionDays
+	if defaultDays == 0 {
+		defaultDays = 90 // Default fallback
+	}
+
+	rules := make([]audit.RetentionRule, len(a.RetentionRules))
+	for i, rule := range a.RetentionRules {
+		rules[i] = audit.RetentionRule{
+			EventType: rule.EventType,
+			Category:  audit.EventCategory(rule.Category),
+			Severity:  audit.Severity(rule.Severity),
+			Days:      rule.Days,
+		}
+	}
+
+	return audit.NewRetentionPolicy(defaultDays, rules)
+}
+
 // validateVaultPlaceholders validates that Vault placeholders follow the expected format
 // Format: vault://secret/path/to/key
 func validateVaultPlaceholders(cfg *Config) error {
@@ -447,9 +1111,368 @@ func Validate(cfg *Config) error {
 		return fmt.Errorf("JWT refresh token expiry must be positive")
 	}
 
+	// Validate audit retention policy
+	if _, err := cfg.Audit.RetentionPolicy(); err != nil {
+		return err
+	}
+
+	// Validate alerting config
+	if cfg.Alert.Enabled {
+		if cfg.Alert.RulesFile == "" {
+			return fmt.Errorf("ALERT_RULES_FILE is required when ALERTS_ENABLED is true")
+		}
+		if cfg.Alert.ReloadInterval < 0 {
+			return fmt.Errorf("ALERT_RULES_RELOAD_INTERVAL must not be negative")
+		}
+		if cfg.Alert.WebhookURL != "" {
+			webhookURL, err := url.Parse(cfg.Alert.WebhookURL)
+			if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+				return fmt.Errorf("ALERT_WEBHOOK_URL must be an http or https URL")
+			}
+		}
+	}
+
+	// Validate outbox relay config
+	if cfg.Outbox.RelayInterval < 0 {
+		return fmt.Errorf("OUTBOX_RELAY_INTERVAL must not be negative")
+	}
+	if cfg.Outbox.BatchSize < 0 || cfg.Outbox.BatchSize > 1000 {
+		return fmt.Errorf("OUTBOX_BATCH_SIZE must be between 0 and 1000")
+	}
+	if cfg.Outbox.Retention < 0 {
+		return fmt.Errorf("OUTBOX_RETENTION must not be negative")
+	}
+
+	// Validate webhook delivery config
+	if cfg.Webhook.DeliveryInterval < 0 || cfg.Webhook.Timeout < 0 || cfg.Webhook.Retention < 0 {
+		return fmt.Errorf("WEBHOOK_DELIVERY_INTERVAL, WEBHOOK_TIMEOUT and WEBHOOK_DELIVERY_RETENTION must not be negative")
+	}
+	if cfg.Webhook.BatchSize < 0 || cfg.Webhook.BatchSize > 500 {
+		return fmt.Errorf("WEBHOOK_BATCH_SIZE must be between 0 and 500")
+	}
+	if cfg.Webhook.MaxAttempts < 0 || cfg.Webhook.MaxAttempts > 50 {
+		return fmt.Errorf("WEBHOOK_MAX_ATTEMPTS must be between 0 and 50")
+	}
+	if cfg.Webhook.DisableAfterFailures < 0 {
+		return fmt.Errorf("WEBHOOK_DISABLE_AFTER_FAILURES must not be negative")
+	}
+
+	// Validate KYC document config; the HTTP layer rejects upload requests above 25 MiB
+	if cfg.KYC.MaxDocumentBytes < 0 || cfg.KYC.MaxDocumentBytes > 20<<20 {
+		return fmt.Errorf("KYC_MAX_DOCUMENT_BYTES must be between 0 and 20971520")
+	}
+
+	// Validate KYC tier limits
+	if _, err := cfg.KYC.TierPolicy(); err != nil {
+		return err
+	}
+
+	// Validate KYC provider config; the simulator approves whatever it is told to
+	switch cfg.KYC.Provider {
+	case "":
+	case "simulator":
+		if cfg.AppEnv == EnvProduction {
+			return fmt.Errorf("KYC_PROVIDER simulator is not allowed in %s environment", cfg.AppEnv)
+		}
+	default:
+		return fmt.Errorf("unsupported KYC_PROVIDER %q", cfg.KYC.Provider)
+	}
+	if cfg.KYC.Provider != "" && cfg.KYC.ProviderWebhookSecret == "" {
+		return fmt.Errorf("KYC_PROVIDER_WEBHOOK_SECRET is required when KYC_PROVIDER is set")
+	}
+	if cfg.KYC.ProviderTimeout < 0 || cfg.KYC.SimulatorDecisionDelay < 0 {
+		return fmt.Errorf("KYC_PROVIDER_TIMEOUT and KYC_SIMULATOR_DECISION_DELAY must not be negative")
+	}
+
+	// Validate screening config
+	if cfg.Screening.Enabled {
+		if cfg.Screening.OFACSDNFile == "" && cfg.Screening.EUFile == "" && cfg.Screening.PEPFile == "" {
+			return fmt.Errorf("at least one of SCREENING_OFAC_SDN_FILE, SCREENING_EU_FILE or SCREENING_PEP_FILE is required when SCREENING_ENABLED is true")
+		}
+		if err := cfg.Screening.Thresholds().Validate(); err != nil {
+			return fmt.Errorf("SCREENING_NAME_THRESHOLD or SCREENING_DOB_YEAR_TOLERANCE: %w", err)
+		}
+		if cfg.Screening.ReloadInterval < 0 {
+			return fmt.Errorf("SCREENING_RELOAD_INTERVAL must not be negative")
+		}
+	}
+
+	// Validate phone verification config; the log sender never delivers codes
+	switch cfg.Phone.VerificationSender {
+	case "":
+	case "log":
+		if cfg.AppEnv == EnvProduction {
+			return fmt.Errorf("PHONE_VERIFICATION_SENDER log is not allowed in %s environment", cfg.AppEnv)
+		}
+	default:
+		return fmt.Errorf("unsupported PHONE_VERIFICATION_SENDER %q", cfg.Phone.VerificationSender)
+	}
+	if cfg.Phone.CodeTTL < 0 || cfg.Phone.MaxAttempts < 0 || cfg.Phone.MaxAttempts > 20 {
+		return fmt.Errorf("PHONE_VERIFICATION_CODE_TTL must not be negative and PHONE_VERIFICATION_MAX_ATTEMPTS must be between 0 and 20")
+	}
+
+	if err := validatePII(cfg); err != nil {
+		return err
+	}
+
+	if err := validateDataExport(cfg); err != nil {
+		return err
+	}
+
+	e := cfg.Erasure
+	if e.CoolingOff < 0 || e.AMLRetention < 0 || e.Interval < 0 {
+		return fmt.Errorf("ERASURE_COOLING_OFF, ERASURE_AML_RETENTION and ERASURE_INTERVAL must not be negative")
+	}
+	if e.BatchSize < 0 || e.BatchSize > 1000 {
+		return fmt.Errorf("ERASURE_BATCH_SIZE must be between 0 and 1000")
+	}
+
+	if err := validateRestore(cfg); err != nil {
+		return err
+	}
+
+	if err := validateAccountStatus(cfg.AccountStatus); err != nil {
+		return err
+	}
+
+	if cfg.Stats.RefreshInterval < 0 {
+		return fmt.Errorf("STATS_REFRESH_INTERVAL must not be negative")
+	}
+	if cfg.Stats.BackfillDays < 0 || cfg.Stats.BackfillDays > stats.MaxRangeDays {
+		return fmt.Errorf("STATS_BACKFILL_DAYS must be between 0 and %d", stats.MaxRangeDays)
+	}
+
+	// Validate event transport config; an empty transport means redis
+	if cfg.Events.PublishTimeout < 0 {
+		return fmt.Errorf("EVENT_PUBLISH_TIMEOUT must not be negative")
+	}
+	if cfg.Events.RedisStreamMaxLen < 0 {
+		return fmt.Errorf("EVENT_REDIS_STREAM_MAX_LEN must not be negative")
+	}
+	switch cfg.Events.Transport {
+	case "", "redis":
+	case "kafka":
+		brokers := cfg.Events.KafkaBrokerList()
+		if len(brokers) == 0 {
+			return fmt.Errorf("KAFKA_BROKERS is required when EVENT_TRANSPORT is kafka")
+		}
+		for _, broker := range brokers {
+			if _, _, err := net.SplitHostPort(broker); err != nil {
+				return fmt.Errorf("KAFKA_BROKERS must be host:port pairs, got %q", broker)
+			}
+		}
+		if cfg.Events.KafkaUsername != "" {
+			switch cfg.Events.KafkaSASLMechanism {
+			case "plain", "scram-sha-256", "scram-sha-512":
+			default:
+				return fmt.Errorf("KAFKA_SASL_MECHANISM must be one of plain, scram-sha-256, scram-sha-512")
+			}
+		}
+		if cfg.Events.KafkaTopic == "" || cfg.Events.KafkaAuditTopic == "" {
+			return fmt.Errorf("KAFKA_TOPIC and KAFKA_AUDIT_TOPIC are required when EVENT_TRANSPORT is kafka")
+		}
+	case "nats":
+		natsURL, err := url.Parse(cfg.Events.NATSURL)
+		if err != nil || (natsURL.Scheme != "nats" && natsURL.Scheme != "tls") || natsURL.Host == "" {
+			return fmt.Errorf("NATS_URL must be a nats:// or tls:// URL when EVENT_TRANSPORT is nats")
+		}
+		if cfg.Events.NATSSubjectPrefix == "" || cfg.Events.NATSAuditSubjectPrefix == "" {
+			return fmt.Errorf("NATS_SUBJECT_PREFIX and NATS_AUDIT_SUBJECT_PREFIX are required when EVENT_TRANSPORT is nats")
+		}
+	default:
+		return fmt.Errorf("EVENT_TRANSPORT must be one of redis, kafka, nats")
+	}
+
+	return nil
+}
+
+// validatePII validates PII field encryption config. With the vault provider the blind index
+// key may still be missing here; it is loaded from Vault by LoadSecretsFromVault.
+func validatePII(cfg *Config) error {
+	p := cfg.PII
+	switch p.EncryptionProvider {
+	case "":
+		return nil
+	case pii.ProviderVault:
+		if !cfg.Vault.Enabled {
+			return fmt.Errorf("PII_ENCRYPTION_PROVIDER vault requires VAULT_ENABLED")
+		}
+		if p.VaultTransitMount == "" || p.VaultTransitKey == "" {
+			return fmt.Errorf("PII_VAULT_TRANSIT_MOUNT and PII_VAULT_TRANSIT_KEY are required when PII_ENCRYPTION_PROVIDER is vault")
+		}
+	case pii.ProviderLocal:
+		if cfg.AppEnv == EnvProduction {
+			return fmt.Errorf("PII_ENCRYPTION_PROVIDER local is not allowed in %s environment", cfg.AppEnv)
+		}
+		masterKeys, err := pii.ParseLocalMasterKeys(p.LocalMasterKeys)
+		if err == nil {
+			_, err = pii.NewLocalKeyManager(masterKeys)
+		}
+		if err != nil {
+			return fmt.Errorf("PII_LOCAL_MASTER_KEYS: %w", err)
+		}
+		if p.BlindIndexKey == "" {
+			return fmt.Errorf("PII_BLIND_INDEX_KEY is required when PII_ENCRYPTION_PROVIDER is local")
+		}
+	default:
+		return fmt.Errorf("PII_ENCRYPTION_PROVIDER must be one of vault, local")
+	}
+
+	if p.BlindIndexKey != "" {
+		if _, err := p.BlindIndexKeyBytes(); err != nil {
+			return err
+		}
+	}
+	if p.DataKeyCacheTTL < 0 || p.DataKeyCacheSize < 0 {
+		return fmt.Errorf("PII_DATA_KEY_CACHE_TTL and PII_DATA_KEY_CACHE_SIZE must not be negative")
+	}
+	if p.ReencryptInterval < 0 {
+		return fmt.Errorf("PII_REENCRYPT_INTERVAL must not be negative")
+	}
+	if p.ReencryptBatchSize < 0 || p.ReencryptBatchSize > 10000 {
+		return fmt.Errorf("PII_REENCRYPT_BATCH_SIZE must be between 0 and 10000")
+	}
 	return nil
 }
 
+// BlindIndexKeyBytes decodes BlindIndexKey
+func (p PIIConfig) BlindIndexKeyBytes() ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(p.BlindIndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("PII_BLIND_INDEX_KEY must be base64 encoded: %w", err)
+	}
+	if len(key) < pii.MinBlindIndexKeySize {
+		return nil, fmt.Errorf("PII_BLIND_INDEX_KEY must be at least %d bytes", pii.MinBlindIndexKeySize)
+	}
+	return key, nil
+}
+
+// validateDataExport validates data export config. With Vault enabled the signing key may
+// still be missing here; it is loaded from Vault by LoadSecretsFromVault.
+func validateDataExport(cfg *Config) error {
+	d := cfg.DataExport
+	if !d.Enabled {
+		return nil
+	}
+	if d.Retention < 0 || d.LinkTTL < 0 || d.PollInterval < 0 {
+		return fmt.Errorf("DATA_EXPORT_RETENTION, DATA_EXPORT_LINK_TTL and DATA_EXPORT_POLL_INTERVAL must not be negative")
+	}
+	if d.SigningKey == "" {
+		if cfg.Vault.Enabled {
+			return nil
+		}
+		return fmt.Errorf("DATA_EXPORT_SIGNING_KEY is required when DATA_EXPORT_ENABLED is true")
+	}
+	_, err := d.SigningPrivateKey()
+	return err
+}
+
+// SigningPrivateKey decodes SigningKey
+func (d DataExportConfig) SigningPrivateKey() (ed25519.PrivateKey, error) {
+	seed, err := base64.StdEncoding.DecodeString(d.SigningKey)
+	if err != nil {
+		return nil, fmt.Errorf("DATA_EXPORT_SIGNING_KEY must be base64 encoded: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("DATA_EXPORT_SIGNING_KEY must be %d bytes", ed25519.SeedSize)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// validateRestore validates account restore config. Erasing an account within its grace
+// period would leave a restore link that can no longer work, so erasure has to wait at
+// least as long. With Vault enabled the signing key may still be missing here; it is loaded
+// from Vault by LoadSecretsFromVault.
+func validateRestore(cfg *Config) error {
+	r := cfg.Restore
+	if r.GracePeriod < 0 {
+		return fmt.Errorf("ACCOUNT_RESTORE_GRACE_PERIOD must not be negative")
+	}
+	if cfg.Erasure.Enabled && r.GracePeriod > cfg.Erasure.CoolingOff {
+		return fmt.Errorf("ACCOUNT_RESTORE_GRACE_PERIOD must not exceed ERASURE_COOLING_OFF")
+	}
+
+	switch r.LinkSender {
+	case "":
+		return nil
+	case "log":
+		if cfg.AppEnv == EnvProduction {
+			return fmt.Errorf("ACCOUNT_RESTORE_LINK_SENDER log is not allowed in %s environment", cfg.AppEnv)
+		}
+	default:
+		return fmt.Errorf("unsupported ACCOUNT_RESTORE_LINK_SENDER %q", r.LinkSender)
+	}
+	if r.GracePeriod == 0 {
+		return fmt.Errorf("ACCOUNT_RESTORE_GRACE_PERIOD is required when ACCOUNT_RESTORE_LINK_SENDER is set")
+	}
+	linkURL, err := url.Parse(r.LinkURL)
+	if err != nil || (linkURL.Scheme != "http" && linkURL.Scheme != "https") || linkURL.Host == "" {
+		return fmt.Errorf("ACCOUNT_RESTORE_LINK_URL must be an absolute http(s) URL")
+	}
+	if r.SigningKey == "" {
+		if cfg.Vault.Enabled {
+			return nil
+		}
+		return fmt.Errorf("ACCOUNT_RESTORE_SIGNING_KEY is required when ACCOUNT_RESTORE_LINK_SENDER is set")
+	}
+	_, err = r.SigningKeyBytes()
+	return err
+}
+
+// SigningKeyBytes decodes SigningKey
+func (r RestoreConfig) SigningKeyBytes() ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(r.SigningKey)
+	if err != nil {
+		return nil, fmt.Errorf("ACCOUNT_RESTORE_SIGNING_KEY must be base64 encoded: %w", err)
+	}
+	if len(key) < user.MinRestoreKeyLength {
+		return nil, fmt.Errorf("ACCOUNT_RESTORE_SIGNING_KEY must be at least %d bytes", user.MinRestoreKeyLength)
+	}
+	return key, nil
+}
+
+// validateAccountStatus validates account status config
+func validateAccountStatus(a AccountStatusConfig) error {
+	if _, err := a.RevokeSessionsOn(); err != nil {
+		return err
+	}
+	if a.ExpiryInterval < 0 {
+		return fmt.Errorf("ACCOUNT_STATUS_EXPIRY_INTERVAL must not be negative")
+	}
+	if a.ExpiryInterval > 0 && (a.ExpiryBatchSize < 1 || a.ExpiryBatchSize > 1000) {
+		return fmt.Errorf("ACCOUNT_STATUS_EXPIRY_BATCH_SIZE must be between 1 and 1000")
+	}
+	return nil
+}
+
+// RevokeSessionsOn parses RevokeSessions
+func (a AccountStatusConfig) RevokeSessionsOn() ([]user.AccountStatus, error) {
+	if strings.TrimSpace(a.RevokeSessions) == "none" {
+		return []user.AccountStatus{}, nil
+	}
+	statuses, err := user.ParseAccountStatuses(a.RevokeSessions)
+	if err != nil {
+		return nil, fmt.Errorf("ACCOUNT_STATUS_REVOKE_SESSIONS: %w", err)
+	}
+	for _, status := range statuses {
+		if status == user.AccountStatusActive {
+			return nil, fmt.Errorf("ACCOUNT_STATUS_REVOKE_SESSIONS must not include %s", status)
+		}
+	}
+	return statuses, nil
+}
+
+// KafkaBrokerList splits KafkaBrokers, dropping empty entries
+func (e EventsConfig) KafkaBrokerList() []string {
+	var brokers []string
+	for _, broker := range strings.Split(e.KafkaBrokers, ",") {
+		if broker = strings.TrimSpace(broker); broker != "" {
+			brokers = append(brokers, broker)
+		}
+	}
+	return brokers
+}
+
 // GetDatabaseURL returns the PostgreSQL connection string
 func (c *Config) GetDatabaseURL() string {
 	return fmt.Sprintf(
@@ -502,6 +1525,9 @@ func (c *Config) IsAudit() bool {
 //   - JWT_SECRET: JWT signing key
 //   - DB_PASSWORD: PostgreSQL password
 //   - REDIS_PASSWORD: Redis password
+//   - PII_BLIND_INDEX_KEY: Email blind index key (when PII encryption is enabled)
+//   - DATA_EXPORT_SIGNING_KEY: Data export archive signing key (when data exports are enabled)
+//   - ACCOUNT_RESTORE_SIGNING_KEY: Restore link signing key (when restore links are sent)
 //
 // In development (Vault disabled): Falls back to environment variables
 // In production (Vault enabled): Fetches from Vault, fails if unavailable
@@ -554,6 +1580,33 @@ func (c *Config) LoadSecretsFromVault(ctx context.Context, vaultClient interface
 		c.Redis.Password = redisPassword
 	}
 
+	// Fetch the email blind index key when PII encryption is enabled
+	if c.PII.EncryptionProvider != "" {
+		blindIndexKey, err := client.GetSecret(ctx, basePath+"/pii", "blind_index_key", "PII_BLIND_INDEX_KEY")
+		if err != nil {
+			return fmt.Errorf("failed to load PII blind index key from vault: %w", err)
+		}
+		c.PII.BlindIndexKey = blindIndexKey
+	}
+
+	// Fetch the data export signing key when data exports are enabled
+	if c.DataExport.Enabled {
+		signingKey, err := client.GetSecret(ctx, basePath+"/data-export", "signing_key", "DATA_EXPORT_SIGNING_KEY")
+		if err != nil {
+			return fmt.Errorf("failed to load data export signing key from vault: %w", err)
+		}
+		c.DataExport.SigningKey = signingKey
+	}
+
+	// Fetch the restore link signing key when restore links are sent
+	if c.Restore.LinkSender != "" {
+		signingKey, err := client.GetSecret(ctx, basePath+"/account-restore", "signing_key", "ACCOUNT_RESTORE_SIGNING_KEY")
+		if err != nil {
+			return fmt.Errorf("failed to load account restore signing key from vault: %w", err)
+		}
+		c.Restore.SigningKey = signingKey
+	}
+
 	// Re-validate config after loading secrets
 	if err := Validate(c); err != nil {
 		return fmt.Errorf("config validation failed after loading vault secrets: %w", err)
@@ -2,10 +2,15 @@ package config_test
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/alex-necsoiu/pandora-exchange/internal/config"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -342,6 +347,11 @@ func TestLoadFromEnv(t *testing.T) {
 		assert.Equal(t, "0.0.0.0", cfg.Server.Host)
 		assert.Equal(t, 15*time.Minute, cfg.JWT.AccessTokenExpiry)
 		assert.Equal(t, 7*24*time.Hour, cfg.JWT.RefreshTokenExpiry)
+		assert.Equal(t, 3, cfg.Audit.PartitionMonthsAhead)
+		assert.True(t, cfg.Audit.ArchiveEnabled)
+		assert.Equal(t, "audit-logs", cfg.Audit.ArchivePrefix)
+		assert.Equal(t, "local", cfg.Storage.Backend)
+		assert.Equal(t, "./data/objects", cfg.Storage.LocalPath)
 	})
 
 	t.Run("fail when JWT secret too short", func(t *testing.T) {
@@ -521,7 +531,32 @@ func clearEnv() {
 		"REDIS_HOST", "REDIS_PORT", "REDIS_PASSWORD", "REDIS_DB",
 		"REDIS_URL",
 		"OTEL_ENABLED", "OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_SERVICE_NAME", "OTEL_SAMPLE_RATE",
-		"CONFIG_FILE",
+		"CONFIG_FILE", "AUDIT_RETENTION_POLICY_FILE",
+		"ALERTS_ENABLED", "ALERT_RULES_FILE", "ALERT_RULES_RELOAD_INTERVAL", "ALERT_WEBHOOK_URL",
+		"OUTBOX_RELAY_INTERVAL", "OUTBOX_BATCH_SIZE", "OUTBOX_RETENTION",
+		"WEBHOOK_DELIVERY_INTERVAL", "WEBHOOK_BATCH_SIZE", "WEBHOOK_TIMEOUT",
+		"WEBHOOK_MAX_ATTEMPTS", "WEBHOOK_DISABLE_AFTER_FAILURES", "WEBHOOK_DELIVERY_RETENTION",
+		"EVENT_TRANSPORT", "EVENT_PUBLISH_TIMEOUT", "EVENT_REDIS_STREAM_MAX_LEN",
+		"KAFKA_BROKERS", "KAFKA_TLS", "KAFKA_TOPIC", "KAFKA_AUDIT_TOPIC",
+		"KAFKA_SASL_MECHANISM", "KAFKA_USERNAME", "KAFKA_PASSWORD",
+		"NATS_URL", "NATS_SUBJECT_PREFIX", "NATS_AUDIT_SUBJECT_PREFIX",
+		"KYC_DOCUMENT_PREFIX", "KYC_MAX_DOCUMENT_BYTES", "KYC_TIERS_FILE",
+		"KYC_PROVIDER", "KYC_PROVIDER_WEBHOOK_SECRET", "KYC_PROVIDER_TIMEOUT",
+		"KYC_SIMULATOR_SCENARIO", "KYC_SIMULATOR_CALLBACK_URL", "KYC_SIMULATOR_DECISION_DELAY",
+		"SCREENING_ENABLED", "SCREENING_OFAC_SDN_FILE", "SCREENING_EU_FILE", "SCREENING_PEP_FILE",
+		"SCREENING_RELOAD_INTERVAL", "SCREENING_NAME_THRESHOLD", "SCREENING_DOB_YEAR_TOLERANCE",
+		"PHONE_VERIFICATION_SENDER", "PHONE_VERIFICATION_CODE_TTL", "PHONE_VERIFICATION_MAX_ATTEMPTS",
+		"PII_ENCRYPTION_PROVIDER", "PII_VAULT_TRANSIT_MOUNT", "PII_VAULT_TRANSIT_KEY",
+		"PII_LOCAL_MASTER_KEYS", "PII_BLIND_INDEX_KEY", "PII_DATA_KEY_CACHE_TTL",
+		"PII_DATA_KEY_CACHE_SIZE", "PII_REENCRYPT_INTERVAL", "PII_REENCRYPT_BATCH_SIZE",
+		"DATA_EXPORT_ENABLED", "DATA_EXPORT_SIGNING_KEY", "DATA_EXPORT_PREFIX",
+		"DATA_EXPORT_RETENTION", "DATA_EXPORT_LINK_TTL", "DATA_EXPORT_POLL_INTERVAL",
+		"ERASURE_ENABLED", "ERASURE_COOLING_OFF", "ERASURE_AML_RETENTION",
+		"ERASURE_INTERVAL", "ERASURE_BATCH_SIZE",
+		"ACCOUNT_RESTORE_GRACE_PERIOD", "ACCOUNT_RESTORE_LINK_SENDER", "ACCOUNT_RESTORE_LINK_URL",
+		"ACCOUNT_RESTORE_SIGNING_KEY",
+		"ACCOUNT_STATUS_REVOKE_SESSIONS", "ACCOUNT_STATUS_EXPIRY_INTERVAL", "ACCOUNT_STATUS_EXPIRY_BATCH_SIZE",
+		"STATS_REFRESH_INTERVAL", "STATS_BACKFILL_DAYS",
 	}
 	for _, v := range envVars {
 		os.Unsetenv(v)
@@ -573,3 +608,1058 @@ func TestTracingConfiguration(t *testing.T) {
 		assert.Equal(t, 0.1, cfg.Tracing.SampleRate)
 	})
 }
+
+// TestAuditRetentionPolicy tests loading per-category audit retention rules
+func TestAuditRetentionPolicy(t *testing.T) {
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+
+	writePolicy := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "retention.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("default policy uses retention days", func(t *testing.T) {
+		setRequired()
+		os.Setenv("AUDIT_LOGS_KEEP_FOR_DAYS", "30")
+		defer os.Unsetenv("AUDIT_LOGS_KEEP_FOR_DAYS")
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+
+		policy, err := cfg.Audit.RetentionPolicy()
+		require.NoError(t, err)
+		assert.Equal(t, 30, policy.DefaultDays())
+		assert.Empty(t, policy.Rules())
+	})
+
+	t.Run("load rules from policy file", func(t *testing.T) {
+		setRequired()
+		os.Setenv("AUDIT_RETENTION_POLICY_FILE", writePolicy(t, `
+rules:
+  - category: security
+    severity: critical
+    days: 3650
+  - category: data_access
+    days: 30
+  - event_type: user.login.failed
+    days: 365
+`))
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		require.Len(t, cfg.Audit.RetentionRules, 3)
+
+		policy, err := cfg.Audit.RetentionPolicy()
+		require.NoError(t, err)
+		assert.Equal(t, 3650, policy.DaysFor("security.alert", audit.CategorySecurity, audit.SeverityCritical))
+		assert.Equal(t, 30, policy.DaysFor("admin.users.list", audit.CategoryDataAccess, audit.SeverityInfo))
+		assert.Equal(t, 365, policy.DaysFor("user.login.failed", audit.CategoryAuthentication, audit.SeverityWarning))
+		assert.Equal(t, 90, policy.DaysFor("user.login", audit.CategoryAuthentication, audit.SeverityInfo))
+	})
+
+	t.Run("example policy file is valid", func(t *testing.T) {
+		setRequired()
+		example, err := filepath.Abs("../../configs/audit-retention.example.yaml")
+		require.NoError(t, err)
+		os.Setenv("AUDIT_RETENTION_POLICY_FILE", example)
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.NotEmpty(t, cfg.Audit.RetentionRules)
+	})
+
+	t.Run("fail on invalid rule", func(t *testing.T) {
+		setRequired()
+		os.Setenv("AUDIT_RETENTION_POLICY_FILE", writePolicy(t, `
+rules:
+  - category: billing
+    days: 30
+`))
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.ErrorIs(t, err, audit.ErrInvalidRetentionPolicy)
+	})
+
+	t.Run("fail on missing policy file", func(t *testing.T) {
+		setRequired()
+		os.Setenv("AUDIT_RETENTION_POLICY_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "retention policy file")
+	})
+}
+
+// TestAlertConfig tests security alerting configuration
+func TestAlertConfig(t *testing.T) {
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.False(t, cfg.Alert.Enabled)
+		assert.Equal(t, 30*time.Second, cfg.Alert.ReloadInterval)
+		assert.Equal(t, 5*time.Second, cfg.Alert.WebhookTimeout)
+	})
+
+	t.Run("enabled with rules file and webhook", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ALERTS_ENABLED", "true")
+		os.Setenv("ALERT_RULES_FILE", "/etc/pandora/alert-rules.yaml")
+		os.Setenv("ALERT_RULES_RELOAD_INTERVAL", "1m")
+		os.Setenv("ALERT_WEBHOOK_URL", "https://hooks.example.com/security")
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.True(t, cfg.Alert.Enabled)
+		assert.Equal(t, "/etc/pandora/alert-rules.yaml", cfg.Alert.RulesFile)
+		assert.Equal(t, time.Minute, cfg.Alert.ReloadInterval)
+		assert.Equal(t, "https://hooks.example.com/security", cfg.Alert.WebhookURL)
+	})
+
+	t.Run("fail when enabled without rules file", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ALERTS_ENABLED", "true")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ALERT_RULES_FILE")
+	})
+
+	t.Run("fail on invalid webhook URL", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ALERTS_ENABLED", "true")
+		os.Setenv("ALERT_RULES_FILE", "/etc/pandora/alert-rules.yaml")
+		os.Setenv("ALERT_WEBHOOK_URL", "ftp://hooks.example.com")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ALERT_WEBHOOK_URL")
+	})
+}
+
+// TestOutboxConfig tests outbox relay configuration
+func TestOutboxConfig(t *testing.T) {
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, time.Second, cfg.Outbox.RelayInterval)
+		assert.Equal(t, 100, cfg.Outbox.BatchSize)
+		assert.Equal(t, 24*time.Hour, cfg.Outbox.Retention)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		setRequired()
+		os.Setenv("OUTBOX_RELAY_INTERVAL", "250ms")
+		os.Setenv("OUTBOX_BATCH_SIZE", "500")
+		os.Setenv("OUTBOX_RETENTION", "72h")
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, 250*time.Millisecond, cfg.Outbox.RelayInterval)
+		assert.Equal(t, 500, cfg.Outbox.BatchSize)
+		assert.Equal(t, 72*time.Hour, cfg.Outbox.Retention)
+	})
+
+	t.Run("fail on oversized batch", func(t *testing.T) {
+		setRequired()
+		os.Setenv("OUTBOX_BATCH_SIZE", "5000")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "OUTBOX_BATCH_SIZE")
+	})
+}
+
+// TestWebhookConfig tests outbound webhook delivery configuration
+func TestWebhookConfig(t *testing.T) {
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, time.Second, cfg.Webhook.DeliveryInterval)
+		assert.Equal(t, 50, cfg.Webhook.BatchSize)
+		assert.Equal(t, 10*time.Second, cfg.Webhook.Timeout)
+		assert.Equal(t, 12, cfg.Webhook.MaxAttempts)
+		assert.Equal(t, 20, cfg.Webhook.DisableAfterFailures)
+		assert.Equal(t, 7*24*time.Hour, cfg.Webhook.Retention)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		setRequired()
+		os.Setenv("WEBHOOK_TIMEOUT", "3s")
+		os.Setenv("WEBHOOK_MAX_ATTEMPTS", "5")
+		os.Setenv("WEBHOOK_DISABLE_AFTER_FAILURES", "8")
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, 3*time.Second, cfg.Webhook.Timeout)
+		assert.Equal(t, 5, cfg.Webhook.MaxAttempts)
+		assert.Equal(t, 8, cfg.Webhook.DisableAfterFailures)
+	})
+
+	t.Run("fail on oversized batch", func(t *testing.T) {
+		setRequired()
+		os.Setenv("WEBHOOK_BATCH_SIZE", "1000")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "WEBHOOK_BATCH_SIZE")
+	})
+}
+
+// TestKYCConfig tests KYC document storage and tier configuration
+func TestKYCConfig(t *testing.T) {
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, "kyc-documents", cfg.KYC.DocumentPrefix)
+		assert.Equal(t, int64(10<<20), cfg.KYC.MaxDocumentBytes)
+	})
+
+	t.Run("fail on oversized document limit", func(t *testing.T) {
+		setRequired()
+		os.Setenv("KYC_MAX_DOCUMENT_BYTES", "52428800")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "KYC_MAX_DOCUMENT_BYTES")
+	})
+
+	t.Run("simulator provider", func(t *testing.T) {
+		setRequired()
+		os.Setenv("KYC_PROVIDER", "simulator")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "KYC_PROVIDER_WEBHOOK_SECRET")
+
+		os.Setenv("KYC_PROVIDER_WEBHOOK_SECRET", "simulator-secret")
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, "approve", cfg.KYC.SimulatorScenario)
+		assert.Equal(t, 15*time.Second, cfg.KYC.ProviderTimeout)
+		assert.Equal(t, 5*time.Second, cfg.KYC.SimulatorDecisionDelay)
+	})
+
+	t.Run("fail on simulator in production", func(t *testing.T) {
+		setRequired()
+		os.Setenv("APP_ENV", "prod")
+		os.Setenv("KYC_PROVIDER", "simulator")
+		os.Setenv("KYC_PROVIDER_WEBHOOK_SECRET", "simulator-secret")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "KYC_PROVIDER")
+	})
+
+	t.Run("fail on unknown provider", func(t *testing.T) {
+		setRequired()
+		os.Setenv("KYC_PROVIDER", "acme")
+		os.Setenv("KYC_PROVIDER_WEBHOOK_SECRET", "secret")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported KYC_PROVIDER")
+	})
+
+	writeTiers := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "tiers.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("default tiers", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+
+		policy, err := cfg.KYC.TierPolicy()
+		require.NoError(t, err)
+		assert.Equal(t, kyc.DefaultTierPolicy(), policy)
+	})
+
+	t.Run("example tiers file is valid", func(t *testing.T) {
+		setRequired()
+		example, err := filepath.Abs("../../configs/kyc-tiers.example.yaml")
+		require.NoError(t, err)
+		os.Setenv("KYC_TIERS_FILE", example)
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		require.Len(t, cfg.KYC.Tiers, 4)
+
+		policy, err := cfg.KYC.TierPolicy()
+		require.NoError(t, err)
+		assert.Equal(t, "EUR", policy.Currency())
+		assert.Equal(t, "basic", policy.Limits(kyc.TierBasic).Name)
+	})
+
+	t.Run("fail on invalid tiers", func(t *testing.T) {
+		setRequired()
+		os.Setenv("KYC_TIERS_FILE", writeTiers(t, `
+currency: USD
+tiers:
+  - tier: 0
+    name: unverified
+`))
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.ErrorIs(t, err, kyc.ErrInvalidTierPolicy)
+	})
+
+	t.Run("fail on missing tiers file", func(t *testing.T) {
+		setRequired()
+		os.Setenv("KYC_TIERS_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "KYC tiers file")
+	})
+}
+
+// TestScreeningConfig tests sanctions and PEP screening configuration
+func TestScreeningConfig(t *testing.T) {
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.False(t, cfg.Screening.Enabled)
+		assert.Equal(t, time.Hour, cfg.Screening.ReloadInterval)
+		assert.Equal(t, screening.DefaultThresholds(), cfg.Screening.Thresholds())
+	})
+
+	t.Run("enabled without list files", func(t *testing.T) {
+		setRequired()
+		os.Setenv("SCREENING_ENABLED", "true")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SCREENING_OFAC_SDN_FILE")
+
+		os.Setenv("SCREENING_PEP_FILE", "/etc/pandora/pep.csv")
+		_, err = config.Load()
+		require.NoError(t, err)
+	})
+
+	t.Run("fail on loose name threshold", func(t *testing.T) {
+		setRequired()
+		os.Setenv("SCREENING_ENABLED", "true")
+		os.Setenv("SCREENING_PEP_FILE", "/etc/pandora/pep.csv")
+		os.Setenv("SCREENING_NAME_THRESHOLD", "0.3")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SCREENING_NAME_THRESHOLD")
+	})
+}
+
+// TestPhoneConfig tests phone verification configuration
+func TestPhoneConfig(t *testing.T) {
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Phone.VerificationSender)
+		assert.Equal(t, 10*time.Minute, cfg.Phone.CodeTTL)
+		assert.Equal(t, 5, cfg.Phone.MaxAttempts)
+	})
+
+	t.Run("fail on log sender in production", func(t *testing.T) {
+		setRequired()
+		os.Setenv("APP_ENV", "prod")
+		os.Setenv("PHONE_VERIFICATION_SENDER", "log")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PHONE_VERIFICATION_SENDER")
+	})
+
+	t.Run("fail on unknown sender", func(t *testing.T) {
+		setRequired()
+		os.Setenv("PHONE_VERIFICATION_SENDER", "carrier-pigeon")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported PHONE_VERIFICATION_SENDER")
+	})
+}
+
+// TestPIIConfig tests PII field encryption configuration
+func TestPIIConfig(t *testing.T) {
+	// base64 of 32 bytes
+	const key32 = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Empty(t, cfg.PII.EncryptionProvider)
+		assert.Equal(t, "transit", cfg.PII.VaultTransitMount)
+		assert.Equal(t, "user-pii", cfg.PII.VaultTransitKey)
+		assert.Equal(t, 5*time.Minute, cfg.PII.DataKeyCacheTTL)
+		assert.Equal(t, 10000, cfg.PII.DataKeyCacheSize)
+		assert.Equal(t, time.Hour, cfg.PII.ReencryptInterval)
+		assert.Equal(t, 100, cfg.PII.ReencryptBatchSize)
+	})
+
+	t.Run("local provider", func(t *testing.T) {
+		setRequired()
+		os.Setenv("PII_ENCRYPTION_PROVIDER", "local")
+		os.Setenv("PII_LOCAL_MASTER_KEYS", key32)
+		os.Setenv("PII_BLIND_INDEX_KEY", key32)
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		key, err := cfg.PII.BlindIndexKeyBytes()
+		require.NoError(t, err)
+		assert.Len(t, key, 32)
+	})
+
+	t.Run("fail on local provider in production", func(t *testing.T) {
+		setRequired()
+		os.Setenv("APP_ENV", "prod")
+		os.Setenv("PII_ENCRYPTION_PROVIDER", "local")
+		os.Setenv("PII_LOCAL_MASTER_KEYS", key32)
+		os.Setenv("PII_BLIND_INDEX_KEY", key32)
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PII_ENCRYPTION_PROVIDER local is not allowed")
+	})
+
+	t.Run("fail on local provider without keys", func(t *testing.T) {
+		setRequired()
+		os.Setenv("PII_ENCRYPTION_PROVIDER", "local")
+		os.Setenv("PII_BLIND_INDEX_KEY", key32)
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PII_LOCAL_MASTER_KEYS")
+	})
+
+	t.Run("fail on short blind index key", func(t *testing.T) {
+		setRequired()
+		os.Setenv("PII_ENCRYPTION_PROVIDER", "local")
+		os.Setenv("PII_LOCAL_MASTER_KEYS", key32)
+		os.Setenv("PII_BLIND_INDEX_KEY", "c2hvcnQ=")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PII_BLIND_INDEX_KEY must be at least")
+	})
+
+	t.Run("fail on vault provider without vault", func(t *testing.T) {
+		setRequired()
+		os.Setenv("PII_ENCRYPTION_PROVIDER", "vault")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires VAULT_ENABLED")
+	})
+
+	t.Run("fail on unknown provider", func(t *testing.T) {
+		setRequired()
+		os.Setenv("PII_ENCRYPTION_PROVIDER", "rot13")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PII_ENCRYPTION_PROVIDER must be one of")
+	})
+}
+
+// TestEventsConfig tests event transport selection
+func TestEventsConfig(t *testing.T) {
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+
+	t.Run("defaults to redis", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, "redis", cfg.Events.Transport)
+		assert.Equal(t, 10*time.Second, cfg.Events.PublishTimeout)
+		assert.Equal(t, int64(10000), cfg.Events.RedisStreamMaxLen)
+		assert.Equal(t, "user-service.events", cfg.Events.KafkaTopic)
+		assert.Equal(t, "user-service.audit", cfg.Events.KafkaAuditTopic)
+		assert.Equal(t, "scram-sha-512", cfg.Events.KafkaSASLMechanism)
+		assert.Equal(t, "nats://localhost:4222", cfg.Events.NATSURL)
+		assert.Equal(t, "user-service.events", cfg.Events.NATSSubjectPrefix)
+	})
+
+	t.Run("kafka", func(t *testing.T) {
+		setRequired()
+		os.Setenv("EVENT_TRANSPORT", "kafka")
+		os.Setenv("KAFKA_BROKERS", "kafka-1.internal:9093, kafka-2.internal:9093,")
+		os.Setenv("KAFKA_TLS", "true")
+		os.Setenv("KAFKA_TOPIC", "trading.user-events")
+		os.Setenv("KAFKA_SASL_MECHANISM", "plain")
+		os.Setenv("KAFKA_USERNAME", "user-service")
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, "kafka", cfg.Events.Transport)
+		assert.Equal(t, []string{"kafka-1.internal:9093", "kafka-2.internal:9093"}, cfg.Events.KafkaBrokerList())
+		assert.True(t, cfg.Events.KafkaTLS)
+		assert.Equal(t, "trading.user-events", cfg.Events.KafkaTopic)
+		assert.Equal(t, "plain", cfg.Events.KafkaSASLMechanism)
+		assert.Equal(t, "user-service", cfg.Events.KafkaUsername)
+	})
+
+	t.Run("nats with unbounded redis fallback stream", func(t *testing.T) {
+		setRequired()
+		os.Setenv("EVENT_TRANSPORT", "nats")
+		os.Setenv("NATS_URL", "tls://nats.internal:4222")
+		os.Setenv("NATS_SUBJECT_PREFIX", "pandora.users")
+		os.Setenv("EVENT_REDIS_STREAM_MAX_LEN", "0")
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, "nats", cfg.Events.Transport)
+		assert.Equal(t, "tls://nats.internal:4222", cfg.Events.NATSURL)
+		assert.Equal(t, "pandora.users", cfg.Events.NATSSubjectPrefix)
+		assert.Equal(t, int64(0), cfg.Events.RedisStreamMaxLen)
+	})
+
+	t.Run("fail on kafka without brokers", func(t *testing.T) {
+		setRequired()
+		os.Setenv("EVENT_TRANSPORT", "kafka")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "KAFKA_BROKERS")
+	})
+
+	t.Run("fail on kafka broker without port", func(t *testing.T) {
+		setRequired()
+		os.Setenv("EVENT_TRANSPORT", "kafka")
+		os.Setenv("KAFKA_BROKERS", "kafka-1.internal")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "KAFKA_BROKERS")
+	})
+
+	t.Run("fail on unknown kafka SASL mechanism", func(t *testing.T) {
+		setRequired()
+		os.Setenv("EVENT_TRANSPORT", "kafka")
+		os.Setenv("KAFKA_BROKERS", "localhost:9092")
+		os.Setenv("KAFKA_USERNAME", "user-service")
+		os.Setenv("KAFKA_SASL_MECHANISM", "gssapi")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "KAFKA_SASL_MECHANISM")
+	})
+
+	t.Run("fail on invalid NATS URL", func(t *testing.T) {
+		setRequired()
+		os.Setenv("EVENT_TRANSPORT", "nats")
+		os.Setenv("NATS_URL", "http://nats.internal:4222")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "NATS_URL")
+	})
+
+	t.Run("fail on unknown transport", func(t *testing.T) {
+		setRequired()
+		os.Setenv("EVENT_TRANSPORT", "rabbitmq")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "EVENT_TRANSPORT")
+	})
+}
+
+// TestDataExportConfig tests data subject access export configuration
+func TestDataExportConfig(t *testing.T) {
+	// base64 of 32 bytes
+	const seed32 = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.False(t, cfg.DataExport.Enabled)
+		assert.Equal(t, "data-exports", cfg.DataExport.Prefix)
+		assert.Equal(t, 7*24*time.Hour, cfg.DataExport.Retention)
+		assert.Equal(t, 15*time.Minute, cfg.DataExport.LinkTTL)
+		assert.Equal(t, 10*time.Second, cfg.DataExport.PollInterval)
+	})
+
+	t.Run("enabled with signing key", func(t *testing.T) {
+		setRequired()
+		os.Setenv("DATA_EXPORT_ENABLED", "true")
+		os.Setenv("DATA_EXPORT_SIGNING_KEY", seed32)
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		key, err := cfg.DataExport.SigningPrivateKey()
+		require.NoError(t, err)
+		assert.Len(t, key.Public(), 32)
+	})
+
+	t.Run("fail when enabled without signing key", func(t *testing.T) {
+		setRequired()
+		os.Setenv("DATA_EXPORT_ENABLED", "true")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "DATA_EXPORT_SIGNING_KEY is required")
+	})
+
+	t.Run("fail on short signing key", func(t *testing.T) {
+		setRequired()
+		os.Setenv("DATA_EXPORT_ENABLED", "true")
+		os.Setenv("DATA_EXPORT_SIGNING_KEY", "c2hvcnQ=")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "DATA_EXPORT_SIGNING_KEY must be 32 bytes")
+	})
+
+	t.Run("fail on negative retention", func(t *testing.T) {
+		setRequired()
+		os.Setenv("DATA_EXPORT_ENABLED", "true")
+		os.Setenv("DATA_EXPORT_SIGNING_KEY", seed32)
+		os.Setenv("DATA_EXPORT_RETENTION", "-1h")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must not be negative")
+	})
+}
+
+func TestErasureConfig(t *testing.T) {
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.False(t, cfg.Erasure.Enabled)
+		assert.Equal(t, 30*24*time.Hour, cfg.Erasure.CoolingOff)
+		assert.Equal(t, 5*365*24*time.Hour, cfg.Erasure.AMLRetention)
+		assert.Equal(t, time.Hour, cfg.Erasure.Interval)
+		assert.Equal(t, 50, cfg.Erasure.BatchSize)
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ERASURE_ENABLED", "true")
+		os.Setenv("ERASURE_COOLING_OFF", "168h")
+		os.Setenv("ERASURE_AML_RETENTION", "0")
+		os.Setenv("ACCOUNT_RESTORE_GRACE_PERIOD", "168h")
+		os.Setenv("ERASURE_BATCH_SIZE", "10")
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.True(t, cfg.Erasure.Enabled)
+		assert.Equal(t, 7*24*time.Hour, cfg.Erasure.CoolingOff)
+		assert.Zero(t, cfg.Erasure.AMLRetention)
+		assert.Equal(t, 10, cfg.Erasure.BatchSize)
+	})
+
+	t.Run("fail on negative cooling-off", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ERASURE_COOLING_OFF", "-1h")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must not be negative")
+	})
+
+	t.Run("fail on batch size out of range", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ERASURE_BATCH_SIZE", "5000")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ERASURE_BATCH_SIZE must be between 0 and 1000")
+	})
+}
+
+func TestRestoreConfig(t *testing.T) {
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+	const signingKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+	t.Run("defaults", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, 30*24*time.Hour, cfg.Restore.GracePeriod)
+		assert.Empty(t, cfg.Restore.LinkSender)
+		assert.Equal(t, "http://localhost:3000/account/restore", cfg.Restore.LinkURL)
+	})
+
+	t.Run("log sender", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ACCOUNT_RESTORE_LINK_SENDER", "log")
+		os.Setenv("ACCOUNT_RESTORE_SIGNING_KEY", signingKey)
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		key, err := cfg.Restore.SigningKeyBytes()
+		require.NoError(t, err)
+		assert.Len(t, key, 32)
+	})
+
+	t.Run("fail on grace period longer than erasure cooling-off", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ERASURE_ENABLED", "true")
+		os.Setenv("ERASURE_COOLING_OFF", "168h")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ACCOUNT_RESTORE_GRACE_PERIOD must not exceed ERASURE_COOLING_OFF")
+	})
+
+	t.Run("fail on log sender in production", func(t *testing.T) {
+		setRequired()
+		os.Setenv("APP_ENV", "prod")
+		os.Setenv("ACCOUNT_RESTORE_LINK_SENDER", "log")
+		os.Setenv("ACCOUNT_RESTORE_SIGNING_KEY", signingKey)
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ACCOUNT_RESTORE_LINK_SENDER log is not allowed")
+	})
+
+	t.Run("fail without signing key", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ACCOUNT_RESTORE_LINK_SENDER", "log")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ACCOUNT_RESTORE_SIGNING_KEY is required")
+	})
+
+	t.Run("fail on short signing key", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ACCOUNT_RESTORE_LINK_SENDER", "log")
+		os.Setenv("ACCOUNT_RESTORE_SIGNING_KEY", "c2hvcnQ=")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ACCOUNT_RESTORE_SIGNING_KEY must be at least 32 bytes")
+	})
+
+	t.Run("fail on relative link URL", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ACCOUNT_RESTORE_LINK_SENDER", "log")
+		os.Setenv("ACCOUNT_RESTORE_SIGNING_KEY", signingKey)
+		os.Setenv("ACCOUNT_RESTORE_LINK_URL", "/account/restore")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ACCOUNT_RESTORE_LINK_URL must be an absolute http(s) URL")
+	})
+}
+
+func TestAccountStatusConfig(t *testing.T) {
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		statuses, err := cfg.AccountStatus.RevokeSessionsOn()
+		require.NoError(t, err)
+		assert.Equal(t, []user.AccountStatus{user.AccountStatusSuspended, user.AccountStatusClosed}, statuses)
+		assert.Equal(t, time.Minute, cfg.AccountStatus.ExpiryInterval)
+		assert.Equal(t, 100, cfg.AccountStatus.ExpiryBatchSize)
+	})
+
+	t.Run("revoke on no status", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ACCOUNT_STATUS_REVOKE_SESSIONS", "none")
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		statuses, err := cfg.AccountStatus.RevokeSessionsOn()
+		require.NoError(t, err)
+		assert.Empty(t, statuses)
+	})
+
+	t.Run("fail on unknown status", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ACCOUNT_STATUS_REVOKE_SESSIONS", "suspended,banned")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ACCOUNT_STATUS_REVOKE_SESSIONS")
+	})
+
+	t.Run("fail on revoking for active", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ACCOUNT_STATUS_REVOKE_SESSIONS", "active,closed")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ACCOUNT_STATUS_REVOKE_SESSIONS must not include active")
+	})
+
+	t.Run("fail on batch size out of range", func(t *testing.T) {
+		setRequired()
+		os.Setenv("ACCOUNT_STATUS_EXPIRY_BATCH_SIZE", "5000")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ACCOUNT_STATUS_EXPIRY_BATCH_SIZE must be between 1 and 1000")
+	})
+}
+
+func TestStatsConfig(t *testing.T) {
+	setRequired := func() {
+		os.Setenv("APP_ENV", "dev")
+		os.Setenv("DB_HOST", "localhost")
+		os.Setenv("DB_PORT", "5432")
+		os.Setenv("DB_USER", "user")
+		os.Setenv("DB_PASSWORD", "pass")
+		os.Setenv("DB_NAME", "db")
+		os.Setenv("JWT_SECRET", "test-secret-key-min-32-characters-long")
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		setRequired()
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Minute, cfg.Stats.RefreshInterval)
+		assert.Equal(t, 90, cfg.Stats.BackfillDays)
+	})
+
+	t.Run("disabled refresh", func(t *testing.T) {
+		setRequired()
+		os.Setenv("STATS_REFRESH_INTERVAL", "0")
+		defer clearEnv()
+
+		cfg, err := config.Load()
+		require.NoError(t, err)
+		assert.Zero(t, cfg.Stats.RefreshInterval)
+	})
+
+	t.Run("fail on negative refresh interval", func(t *testing.T) {
+		setRequired()
+		os.Setenv("STATS_REFRESH_INTERVAL", "-1m")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "STATS_REFRESH_INTERVAL must not be negative")
+	})
+
+	t.Run("fail on backfill out of range", func(t *testing.T) {
+		setRequired()
+		os.Setenv("STATS_BACKFILL_DAYS", "400")
+		defer clearEnv()
+
+		_, err := config.Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "STATS_BACKFILL_DAYS must be between 0 and 366")
+	})
+}
@@ -0,0 +1,15 @@
+package alert
+
+import "errors"
+
+// Domain-level errors for security alerting.
+var (
+	// ErrAlertNotFound is returned when an alert does not exist.
+	ErrAlertNotFound = errors.New("alert not found")
+
+	// ErrAlreadyAcknowledged is returned when acknowledging an alert that is no longer open.
+	ErrAlreadyAcknowledged = errors.New("alert already acknowledged")
+
+	// ErrInvalidRule is returned when an alert rule fails validation.
+	ErrInvalidRule = errors.New("invalid alert rule")
+)
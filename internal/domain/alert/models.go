@@ -0,0 +1,125 @@
+// Package alert contains the security alerting domain model: declarative rules evaluated
+// against audit and security events, the alerts they raise, and the ports used to deliver
+// and store them. It has no knowledge of where events come from or how alerts are sent.
+package alert
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Severity is the urgency of an alert
+type Severity string
+
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// IsValid reports whether s is a known severity
+func (s Severity) IsValid() bool {
+	switch s {
+	case SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return true
+	}
+	return false
+}
+
+// Status is where an alert is in the acknowledge workflow
+type Status string
+
+const (
+	StatusOpen         Status = "open"
+	StatusAcknowledged Status = "acknowledged"
+)
+
+// Event is an audit or security event as seen by the rule engine.
+// Attributes hold extra fields rules can match on (status, category, ...).
+type Event struct {
+	Type       string            `json:"type"`
+	Severity   string            `json:"severity,omitempty"`
+	UserID     string            `json:"user_id,omitempty"`
+	IPAddress  string            `json:"ip_address,omitempty"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Alert is raised when a rule matches.
+// Alerts with the same DedupKey are folded together while one is open.
+type Alert struct {
+	ID       uuid.UUID `json:"id"`
+	RuleName string    `json:"rule_name"`
+	Severity Severity  `json:"severity"`
+	Summary  string    `json:"summary"`
+
+	// Grouping and deduplication
+	GroupBy    GroupBy `json:"group_by,omitempty"`
+	GroupValue string  `json:"group_value,omitempty"`
+	DedupKey   string  `json:"dedup_key"`
+
+	// Evidence
+	EventCount  int       `json:"event_count"`
+	FireCount   int       `json:"fire_count"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+	Events      []Event   `json:"events,omitempty"`
+
+	// Acknowledge workflow
+	Status         Status     `json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy *string    `json:"acknowledged_by,omitempty"`
+	AckNote        *string    `json:"ack_note,omitempty"`
+}
+
+// IsOpen reports whether the alert still needs attention
+func (a *Alert) IsOpen() bool {
+	return a.Status == StatusOpen
+}
+
+// Observer receives events to evaluate.
+// Implemented by the rule engine; called by audit log writes and services emitting security events.
+type Observer interface {
+	Observe(ctx context.Context, event Event)
+}
+
+// Notifier delivers fired alerts (webhook, log, alerts table, ...)
+type Notifier interface {
+	// Name identifies the notifier in rule configuration
+	Name() string
+
+	// Notify delivers an alert
+	Notify(ctx context.Context, alert *Alert) error
+}
+
+// Repository persists alerts for the acknowledge workflow
+type Repository interface {
+	// Record stores a fired alert. If an open alert with the same dedup key exists it is
+	// updated instead (event count, fire count and last seen), and the stored alert is returned.
+	Record(ctx context.Context, alert *Alert) (*Alert, error)
+
+	// GetByID retrieves an alert
+	GetByID(ctx context.Context, id uuid.UUID) (*Alert, error)
+
+	// List retrieves alerts, newest first, optionally filtered by status
+	List(ctx context.Context, status *Status, limit, offset int32) ([]*Alert, error)
+
+	// Acknowledge moves an open alert to acknowledged
+	Acknowledge(ctx context.Context, id uuid.UUID, acknowledgedBy string, note *string) (*Alert, error)
+}
+
+// Service exposes stored alerts to admins
+type Service interface {
+	// ListAlerts retrieves alerts, newest first, optionally filtered by status
+	ListAlerts(ctx context.Context, status *Status, limit, offset int32) ([]*Alert, error)
+
+	// GetAlert retrieves an alert
+	GetAlert(ctx context.Context, id uuid.UUID) (*Alert, error)
+
+	// AcknowledgeAlert acknowledges an open alert on behalf of actor
+	AcknowledgeAlert(ctx context.Context, id uuid.UUID, actor string, note *string) (*Alert, error)
+}
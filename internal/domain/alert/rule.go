@@ -0,0 +1,182 @@
+package alert
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RuleType selects how a rule is evaluated
+type RuleType string
+
+const (
+	// RuleThreshold fires when at least Threshold matching events occur within Window
+	RuleThreshold RuleType = "threshold"
+
+	// RuleSequence fires when the event types in Sequence occur in order within Window
+	RuleSequence RuleType = "sequence"
+)
+
+// GroupBy selects how events are partitioned before a rule counts them
+type GroupBy string
+
+const (
+	GroupByNone GroupBy = ""
+	GroupByUser GroupBy = "user"
+	GroupByIP   GroupBy = "ip"
+)
+
+// Rule is a declarative alerting rule, loaded from YAML.
+//
+// Threshold example: five failed admin logins from one IP within ten minutes.
+//
+//	name: admin-login-bruteforce
+//	type: threshold
+//	event_types: [admin.login.failed]
+//	group_by: ip
+//	threshold: 5
+//	window: 10m
+//
+// Sequence example: a revoked refresh token replayed, then a password change, by one user.
+//
+//	name: token-replay-then-password-change
+//	type: sequence
+//	sequence: [token.refresh.revoked, user.password.changed]
+//	group_by: user
+//	window: 1h
+type Rule struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description" json:"description,omitempty"`
+	Type        RuleType `yaml:"type" json:"type"`
+	Severity    Severity `yaml:"severity" json:"severity"`
+	Disabled    bool     `yaml:"disabled" json:"disabled,omitempty"`
+
+	// Matching: event types counted by threshold rules, and attribute filters for both types
+	EventTypes []string          `yaml:"event_types" json:"event_types,omitempty"`
+	Match      map[string]string `yaml:"match" json:"match,omitempty"`
+
+	// Evaluation
+	GroupBy   GroupBy       `yaml:"group_by" json:"group_by,omitempty"`
+	Threshold int           `yaml:"threshold" json:"threshold,omitempty"`
+	Sequence  []string      `yaml:"sequence" json:"sequence,omitempty"`
+	Window    time.Duration `yaml:"window" json:"window"`
+
+	// Cooldown suppresses repeat alerts for the same rule and group; defaults to Window
+	Cooldown time.Duration `yaml:"cooldown" json:"cooldown,omitempty"`
+
+	// Notifiers lists notifier names to deliver to; empty means all
+	Notifiers []string `yaml:"notifiers" json:"notifiers,omitempty"`
+}
+
+// Validate checks a rule and fills in defaults
+func (r *Rule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRule)
+	}
+	if r.Severity == "" {
+		r.Severity = SeverityMedium
+	}
+	if !r.Severity.IsValid() {
+		return fmt.Errorf("%w: rule %q has unknown severity %q", ErrInvalidRule, r.Name, r.Severity)
+	}
+	switch r.GroupBy {
+	case GroupByNone, GroupByUser, GroupByIP:
+	default:
+		return fmt.Errorf("%w: rule %q has unknown group_by %q", ErrInvalidRule, r.Name, r.GroupBy)
+	}
+	if r.Window <= 0 {
+		return fmt.Errorf("%w: rule %q needs a positive window", ErrInvalidRule, r.Name)
+	}
+	if r.Cooldown < 0 {
+		return fmt.Errorf("%w: rule %q has a negative cooldown", ErrInvalidRule, r.Name)
+	}
+	if r.Cooldown == 0 {
+		r.Cooldown = r.Window
+	}
+
+	switch r.Type {
+	case RuleThreshold:
+		if len(r.EventTypes) == 0 {
+			return fmt.Errorf("%w: threshold rule %q needs event_types", ErrInvalidRule, r.Name)
+		}
+		if r.Threshold < 1 {
+			return fmt.Errorf("%w: threshold rule %q needs a threshold of at least 1", ErrInvalidRule, r.Name)
+		}
+	case RuleSequence:
+		if len(r.Sequence) < 2 {
+			return fmt.Errorf("%w: sequence rule %q needs at least two steps", ErrInvalidRule, r.Name)
+		}
+	default:
+		return fmt.Errorf("%w: rule %q has unknown type %q", ErrInvalidRule, r.Name, r.Type)
+	}
+	return nil
+}
+
+// ValidateRules validates every rule and rejects duplicate names
+func ValidateRules(rules []Rule) error {
+	seen := make(map[string]bool, len(rules))
+	for i := range rules {
+		if err := rules[i].Validate(); err != nil {
+			return err
+		}
+		if seen[rules[i].Name] {
+			return fmt.Errorf("%w: duplicate rule name %q", ErrInvalidRule, rules[i].Name)
+		}
+		seen[rules[i].Name] = true
+	}
+	return nil
+}
+
+// MatchesAttributes reports whether the event passes the rule's attribute filters
+func (r *Rule) MatchesAttributes(event Event) bool {
+	for key, want := range r.Match {
+		if event.Attributes[key] != want {
+			return false
+		}
+	}
+	return true
+}
+
+// Counts reports whether a threshold rule counts the event
+func (r *Rule) Counts(event Event) bool {
+	for _, eventType := range r.EventTypes {
+		if eventType == event.Type {
+			return r.MatchesAttributes(event)
+		}
+	}
+	return false
+}
+
+// GroupValue returns the value the event is grouped under, and false when the event
+// lacks the grouping field and so cannot be attributed to a group
+func (r *Rule) GroupValue(event Event) (string, bool) {
+	switch r.GroupBy {
+	case GroupByUser:
+		return event.UserID, event.UserID != ""
+	case GroupByIP:
+		return event.IPAddress, event.IPAddress != ""
+	default:
+		return "", true
+	}
+}
+
+// DedupKey identifies alerts of this rule for one group
+func (r *Rule) DedupKey(groupValue string) string {
+	if r.GroupBy == GroupByNone {
+		return r.Name
+	}
+	return fmt.Sprintf("%s|%s:%s", r.Name, r.GroupBy, groupValue)
+}
+
+// NotifiesVia reports whether alerts of this rule go to the named notifier
+func (r *Rule) NotifiesVia(name string) bool {
+	if len(r.Notifiers) == 0 {
+		return true
+	}
+	for _, n := range r.Notifiers {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package alert_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+)
+
+// TestRuleValidate tests rule validation and defaults.
+func TestRuleValidate(t *testing.T) {
+	threshold := func() alert.Rule {
+		return alert.Rule{
+			Name:       "admin-login-bruteforce",
+			Type:       alert.RuleThreshold,
+			EventTypes: []string{"admin.login.failed"},
+			GroupBy:    alert.GroupByIP,
+			Threshold:  5,
+			Window:     10 * time.Minute,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(r *alert.Rule)
+		wantErr bool
+	}{
+		{name: "valid threshold", mutate: func(r *alert.Rule) {}},
+		{
+			name: "valid sequence",
+			mutate: func(r *alert.Rule) {
+				r.Type = alert.RuleSequence
+				r.EventTypes = nil
+				r.Threshold = 0
+				r.Sequence = []string{"token.refresh.revoked", "user.password.changed"}
+			},
+		},
+		{name: "missing name", mutate: func(r *alert.Rule) { r.Name = " " }, wantErr: true},
+		{name: "unknown type", mutate: func(r *alert.Rule) { r.Type = "anomaly" }, wantErr: true},
+		{name: "unknown severity", mutate: func(r *alert.Rule) { r.Severity = "urgent" }, wantErr: true},
+		{name: "unknown group_by", mutate: func(r *alert.Rule) { r.GroupBy = "country" }, wantErr: true},
+		{name: "zero window", mutate: func(r *alert.Rule) { r.Window = 0 }, wantErr: true},
+		{name: "negative cooldown", mutate: func(r *alert.Rule) { r.Cooldown = -time.Second }, wantErr: true},
+		{name: "threshold without event types", mutate: func(r *alert.Rule) { r.EventTypes = nil }, wantErr: true},
+		{name: "threshold below one", mutate: func(r *alert.Rule) { r.Threshold = 0 }, wantErr: true},
+		{
+			name: "sequence with one step",
+			mutate: func(r *alert.Rule) {
+				r.Type = alert.RuleSequence
+				r.Sequence = []string{"token.refresh.revoked"}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := threshold()
+			tt.mutate(&rule)
+			err := rule.Validate()
+			if tt.wantErr {
+				if !errors.Is(err, alert.ErrInvalidRule) {
+					t.Fatalf("expected ErrInvalidRule, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rule.Severity != alert.SeverityMedium {
+				t.Errorf("expected default severity medium, got %s", rule.Severity)
+			}
+			if rule.Cooldown != rule.Window {
+				t.Errorf("expected cooldown to default to window, got %s", rule.Cooldown)
+			}
+		})
+	}
+}
+
+// TestValidateRules tests duplicate rule names are rejected.
+func TestValidateRules(t *testing.T) {
+	rule := alert.Rule{Name: "dup", Type: alert.RuleThreshold, EventTypes: []string{"x"}, Threshold: 1, Window: time.Minute}
+
+	if err := alert.ValidateRules([]alert.Rule{rule}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := alert.ValidateRules([]alert.Rule{rule, rule}); !errors.Is(err, alert.ErrInvalidRule) {
+		t.Fatalf("expected ErrInvalidRule for duplicate names, got %v", err)
+	}
+}
+
+// TestRuleMatching tests event matching, grouping and dedup keys.
+func TestRuleMatching(t *testing.T) {
+	rule := alert.Rule{
+		Name:       "failed-logins",
+		Type:       alert.RuleThreshold,
+		EventTypes: []string{"user.login.failed", "admin.login.failed"},
+		Match:      map[string]string{"status": "failure"},
+		GroupBy:    alert.GroupByIP,
+		Threshold:  3,
+		Window:     time.Minute,
+		Notifiers:  []string{"webhook"},
+	}
+
+	failed := alert.Event{Type: "admin.login.failed", IPAddress: "10.0.0.1", Attributes: map[string]string{"status": "failure"}}
+	if !rule.Counts(failed) {
+		t.Error("expected failed admin login to count")
+	}
+	if rule.Counts(alert.Event{Type: "admin.login.failed", Attributes: map[string]string{"status": "success"}}) {
+		t.Error("expected attribute filter to reject success")
+	}
+	if rule.Counts(alert.Event{Type: "user.registered", Attributes: map[string]string{"status": "failure"}}) {
+		t.Error("expected other event type not to count")
+	}
+
+	if value, ok := rule.GroupValue(failed); !ok || value != "10.0.0.1" {
+		t.Errorf("expected group value 10.0.0.1, got %q (%v)", value, ok)
+	}
+	if _, ok := rule.GroupValue(alert.Event{Type: "admin.login.failed"}); ok {
+		t.Error("expected event without IP not to be groupable")
+	}
+	if key := rule.DedupKey("10.0.0.1"); key != "failed-logins|ip:10.0.0.1" {
+		t.Errorf("unexpected dedup key %q", key)
+	}
+
+	if !rule.NotifiesVia("webhook") || rule.NotifiesVia("log") {
+		t.Error("expected rule to notify only via webhook")
+	}
+}
@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArchiveFormat identifies the encoding of archived partitions
+const ArchiveFormat = "ndjson+gzip"
+
+// ArchiveManifestVersion is the current version of the archive manifest layout
+const ArchiveManifestVersion = 1
+
+// partitionNameLayout matches the naming used by audit_logs_partition_name() in migrations
+const partitionNameLayout = "audit_logs_y2006m01"
+
+// Partition describes one monthly partition of the audit_logs table
+type Partition struct {
+	Name        string    `json:"name"`
+	Month       time.Time `json:"month"`        // First instant of the month (UTC)
+	Attached    bool      `json:"attached"`     // False once detached for archival
+	RowEstimate int64     `json:"row_estimate"` // Planner estimate, not an exact count
+}
+
+// End returns the exclusive upper bound of the partition range
+func (p *Partition) End() time.Time {
+	return p.Month.AddDate(0, 1, 0)
+}
+
+// Archive records a partition exported to cold storage and dropped from the database
+type Archive struct {
+	ID            uuid.UUID  `json:"id"`
+	Month         time.Time  `json:"month"`
+	PartitionName string     `json:"partition_name"`
+	ObjectKey     string     `json:"object_key"`
+	ManifestKey   string     `json:"manifest_key"`
+	RowCount      int64      `json:"row_count"`
+	SizeBytes     int64      `json:"size_bytes"`
+	Checksum      string     `json:"checksum_sha256"`
+	ArchivedAt    time.Time  `json:"archived_at"`
+	RestoredAt    *time.Time `json:"restored_at,omitempty"`
+	RestoredBy    *string    `json:"restored_by,omitempty"`
+}
+
+// IsRestored reports whether the archived month is currently restored into audit_logs
+func (a *Archive) IsRestored() bool {
+	return a.RestoredAt != nil
+}
+
+// Manifest describes an archive object; it is stored next to the data object
+type Manifest struct {
+	Version        int        `json:"version"`
+	Table          string     `json:"table"`
+	PartitionName  string     `json:"partition_name"`
+	RangeStart     time.Time  `json:"range_start"`
+	RangeEnd       time.Time  `json:"range_end"`
+	Format         string     `json:"format"`
+	ObjectKey      string     `json:"object_key"`
+	RowCount       int64      `json:"row_count"`
+	SizeBytes      int64      `json:"size_bytes"`
+	Checksum       string     `json:"checksum_sha256"`
+	FirstCreatedAt *time.Time `json:"first_created_at,omitempty"`
+	LastCreatedAt  *time.Time `json:"last_created_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	RewrittenAt    *time.Time `json:"rewritten_at,omitempty"` // Set once entries were rewritten by an erasure
+}
+
+// MonthStart truncates t to the first instant of its month in UTC
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// PartitionName returns the audit_logs partition name for the month containing t
+func PartitionName(t time.Time) string {
+	return MonthStart(t).Format(partitionNameLayout)
+}
+
+// ParsePartitionName returns the month covered by a partition name such as audit_logs_y2025m11
+func ParsePartitionName(name string) (time.Time, error) {
+	month, err := time.Parse(partitionNameLayout, name)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid partition name %q: %w", name, err)
+	}
+	return month, nil
+}
+
+// ParseMonth parses a YYYY-MM month identifier as used by the admin API
+func ParseMonth(value string) (time.Time, error) {
+	month, err := time.Parse("2006-01", value)
+	if err != nil {
+		return time.Time{}, ErrInvalidMonth
+	}
+	return month, nil
+}
@@ -0,0 +1,39 @@
+package audit
+
+import "errors"
+
+// Domain-level errors for audit operations.
+var (
+	// ErrArchiveNotFound is returned when no archive exists for the requested month.
+	ErrArchiveNotFound = errors.New("audit archive not found")
+
+	// ErrArchiveAlreadyRestored is returned when restoring a month that is already restored.
+	ErrArchiveAlreadyRestored = errors.New("audit archive already restored")
+
+	// ErrArchiveNotRestored is returned when releasing a month that is not restored.
+	ErrArchiveNotRestored = errors.New("audit archive is not restored")
+
+	// ErrArchiveCorrupted is returned when an archive does not match its manifest.
+	ErrArchiveCorrupted = errors.New("audit archive does not match its manifest")
+
+	// ErrArchiveChanged is returned when an archive was rewritten or replaced concurrently.
+	ErrArchiveChanged = errors.New("audit archive changed concurrently")
+
+	// ErrInvalidMonth is returned when a month identifier is not in YYYY-MM format.
+	ErrInvalidMonth = errors.New("invalid month: expected YYYY-MM")
+
+	// ErrLegalHoldNotFound is returned when a legal hold does not exist.
+	ErrLegalHoldNotFound = errors.New("legal hold not found")
+
+	// ErrLegalHoldReleased is returned when changing a hold that has already been released.
+	ErrLegalHoldReleased = errors.New("legal hold already released")
+
+	// ErrInvalidLegalHold is returned when a legal hold fails validation.
+	ErrInvalidLegalHold = errors.New("invalid legal hold")
+
+	// ErrLegalHoldActive is returned when an operation would destroy data covered by an active legal hold.
+	ErrLegalHoldActive = errors.New("data is under legal hold")
+
+	// ErrInvalidRetentionPolicy is returned when a retention policy fails validation.
+	ErrInvalidRetentionPolicy = errors.New("invalid retention policy")
+)
@@ -3,6 +3,7 @@ package audit
 import (
 	"time"
 
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
 	"github.com/google/uuid"
 )
 
@@ -14,26 +15,111 @@ const (
 	EventTypeAuditLogged EventType = "audit.logged"
 )
 
+// Compile-time check to ensure Event carries the CloudEvents attributes
+var _ common.VersionedEnvelope = (*Event)(nil)
+
 // Event represents an audit domain event
 type Event struct {
-	ID        string                 `json:"id"`        // Unique event ID
-	Type      EventType              `json:"type"`      // Event type
-	Timestamp time.Time              `json:"timestamp"` // When the event occurred
-	AuditID   uuid.UUID              `json:"audit_id"`  // Audit log ID
-	Payload   map[string]interface{} `json:"payload"`   // Event-specific data
-	Metadata  map[string]string      `json:"metadata"`  // Additional metadata
+	ID            string                 `json:"id"`             // Unique event ID
+	Type          EventType              `json:"type"`           // Event type
+	Timestamp     time.Time              `json:"timestamp"`      // When the event occurred
+	AuditID       uuid.UUID              `json:"audit_id"`       // Audit log ID
+	SchemaVersion int                    `json:"schema_version"` // Version of the payload schema
+	Payload       map[string]interface{} `json:"payload"`        // Event-specific data
+	Metadata      map[string]string      `json:"metadata"`       // Additional metadata
+}
+
+// LoggedPayload is the data of audit.logged
+type LoggedPayload struct {
+	// AuditEventType is the audited event type, e.g. "admin.login.failed"
+	AuditEventType  string                 `json:"event_type"`
+	EventCategory   EventCategory          `json:"event_category"`
+	Severity        Severity               `json:"severity"`
+	ActorType       ActorType              `json:"actor_type"`
+	Action          string                 `json:"action"`
+	Status          Status                 `json:"status"`
+	IsSensitive     bool                   `json:"is_sensitive"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UserID          string                 `json:"user_id,omitempty"`
+	ActorIdentifier string                 `json:"actor_identifier,omitempty"`
+	ResourceType    string                 `json:"resource_type,omitempty"`
+	ResourceID      string                 `json:"resource_id,omitempty"`
+	IPAddress       string                 `json:"ip_address,omitempty"`
+	FailureReason   string                 `json:"failure_reason,omitempty"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
 }
 
-// NewEvent creates a new audit domain event
+// EventType returns audit.logged
+func (LoggedPayload) EventType() string { return string(EventTypeAuditLogged) }
+
+// SchemaVersion returns the payload schema version
+func (LoggedPayload) SchemaVersion() int { return 1 }
+
+// NewEvent creates a new audit domain event from an untyped payload at schema version 1
 func NewEvent(eventType EventType, auditID uuid.UUID, payload map[string]interface{}) *Event {
 	return &Event{
-		ID:        uuid.New().String(),
-		Type:      eventType,
-		Timestamp: time.Now().UTC(),
-		AuditID:   auditID,
-		Payload:   payload,
-		Metadata:  make(map[string]string),
+		ID:            uuid.New().String(),
+		Type:          eventType,
+		Timestamp:     time.Now().UTC(),
+		AuditID:       auditID,
+		SchemaVersion: 1,
+		Payload:       payload,
+		Metadata:      make(map[string]string),
+	}
+}
+
+// NewTypedEvent creates an audit domain event from a typed payload;
+// the event type and schema version are taken from the payload
+func NewTypedEvent(auditID uuid.UUID, data common.EventData) *Event {
+	event := NewEvent(EventType(data.EventType()), auditID, common.EventDataMap(data))
+	event.SchemaVersion = data.SchemaVersion()
+	return event
+}
+
+// NewLoggedEvent creates an EventTypeAuditLogged event for a stored audit log.
+// Previous and new state are left out so the stream never carries full record snapshots;
+// request metadata is also dropped for sensitive entries.
+func NewLoggedEvent(log *Log) *Event {
+	payload := LoggedPayload{
+		AuditEventType: log.EventType,
+		EventCategory:  log.EventCategory,
+		Severity:       log.Severity,
+		ActorType:      log.ActorType,
+		Action:         log.Action,
+		Status:         log.Status,
+		IsSensitive:    log.IsSensitive,
+		CreatedAt:      log.CreatedAt,
+	}
+	if log.UserID != nil {
+		payload.UserID = log.UserID.String()
+	}
+	if log.ActorIdentifier != nil {
+		payload.ActorIdentifier = *log.ActorIdentifier
+	}
+	if log.ResourceType != nil {
+		payload.ResourceType = *log.ResourceType
+	}
+	if log.ResourceID != nil {
+		payload.ResourceID = *log.ResourceID
+	}
+	if log.IPAddress != nil {
+		payload.IPAddress = *log.IPAddress
+	}
+	if log.FailureReason != nil {
+		payload.FailureReason = *log.FailureReason
+	}
+	if !log.IsSensitive && len(log.Metadata) > 0 {
+		payload.Metadata = log.Metadata
+	}
+
+	event := NewTypedEvent(log.ID, payload)
+	if log.RequestID != nil {
+		event.WithMetadata("request_id", *log.RequestID)
+	}
+	if log.SessionID != nil {
+		event.WithMetadata("session_id", *log.SessionID)
 	}
+	return event
 }
 
 // WithMetadata adds metadata to the event
@@ -44,3 +130,57 @@ func (e *Event) WithMetadata(key, value string) *Event {
 	e.Metadata[key] = value
 	return e
 }
+
+// EventID returns the unique event ID
+func (e *Event) EventID() string {
+	return e.ID
+}
+
+// EventType returns the event type as a string
+func (e *Event) EventType() string {
+	return string(e.Type)
+}
+
+// OccurredAt returns when the event occurred
+func (e *Event) OccurredAt() time.Time {
+	return e.Timestamp
+}
+
+// Attributes returns the audit log ID and, when present in the payload, the severity,
+// category and user ID so consumers can filter without decoding the payload
+func (e *Event) Attributes() map[string]string {
+	attrs := map[string]string{
+		"audit_id": e.AuditID.String(),
+	}
+	for _, key := range []string{"severity", "event_category", "user_id"} {
+		if value, ok := e.Payload[key].(string); ok {
+			attrs[key] = value
+		}
+	}
+	return attrs
+}
+
+// EventPayload returns the event-specific data
+func (e *Event) EventPayload() map[string]interface{} {
+	return e.Payload
+}
+
+// EventMetadata returns the event metadata
+func (e *Event) EventMetadata() map[string]string {
+	return e.Metadata
+}
+
+// EventSource returns the user service as the event producer
+func (e *Event) EventSource() string {
+	return common.EventSource
+}
+
+// EventSubject returns the audit log ID
+func (e *Event) EventSubject() string {
+	return e.AuditID.String()
+}
+
+// EventSchemaVersion returns the version of the payload schema
+func (e *Event) EventSchemaVersion() int {
+	return e.SchemaVersion
+}
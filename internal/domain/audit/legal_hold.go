@@ -0,0 +1,162 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LegalHold freezes audit records and user data while an investigation is open.
+// A hold matches audit logs by any combination of user, created_at range and event category;
+// nil scope fields match everything, but at least one must be set.
+type LegalHold struct {
+	ID uuid.UUID `json:"id"`
+
+	// Scope
+	UserID        *uuid.UUID     `json:"user_id,omitempty"`
+	StartsAt      *time.Time     `json:"starts_at,omitempty"` // Inclusive
+	EndsAt        *time.Time     `json:"ends_at,omitempty"`   // Exclusive
+	EventCategory *EventCategory `json:"event_category,omitempty"`
+
+	// Justification
+	Reason    string    `json:"reason"`
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Lifecycle
+	CreatedBy     string     `json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	ReleasedAt    *time.Time `json:"released_at,omitempty"`
+	ReleasedBy    *string    `json:"released_by,omitempty"`
+	ReleaseReason *string    `json:"release_reason,omitempty"`
+}
+
+// IsReleased reports whether the hold has been lifted
+func (h *LegalHold) IsReleased() bool {
+	return h.ReleasedAt != nil
+}
+
+// IsActive reports whether the hold is in force at now
+func (h *LegalHold) IsActive(now time.Time) bool {
+	return !h.IsReleased() && now.Before(h.ExpiresAt)
+}
+
+// Validate checks a new hold before it is placed
+func (h *LegalHold) Validate(now time.Time) error {
+	if h.UserID == nil && h.StartsAt == nil && h.EndsAt == nil && h.EventCategory == nil {
+		return fmt.Errorf("%w: scope requires a user, a date range or an event category", ErrInvalidLegalHold)
+	}
+	if h.StartsAt != nil && h.EndsAt != nil && !h.StartsAt.Before(*h.EndsAt) {
+		return fmt.Errorf("%w: starts_at must be before ends_at", ErrInvalidLegalHold)
+	}
+	if h.EventCategory != nil && !IsValidCategory(*h.EventCategory) {
+		return fmt.Errorf("%w: unknown event category %q", ErrInvalidLegalHold, *h.EventCategory)
+	}
+	return ValidateLegalHoldTerms(h.Reason, h.Owner, h.ExpiresAt, now)
+}
+
+// ValidateLegalHoldTerms checks the mutable terms of a hold
+func ValidateLegalHoldTerms(reason, owner string, expiresAt, now time.Time) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("%w: reason is required", ErrInvalidLegalHold)
+	}
+	if strings.TrimSpace(owner) == "" {
+		return fmt.Errorf("%w: owner is required", ErrInvalidLegalHold)
+	}
+	if !expiresAt.After(now) {
+		return fmt.Errorf("%w: expires_at must be in the future", ErrInvalidLegalHold)
+	}
+	return nil
+}
+
+// Matches reports whether an audit log falls within the scope of the hold
+func (h *LegalHold) Matches(log *Log) bool {
+	if h.UserID != nil && (log.UserID == nil || *log.UserID != *h.UserID) {
+		return false
+	}
+	if h.EventCategory != nil && log.EventCategory != *h.EventCategory {
+		return false
+	}
+	return h.overlaps(log.CreatedAt, log.CreatedAt.Add(time.Nanosecond))
+}
+
+// CoversRange reports whether the hold's date range overlaps [start, end).
+// User and category scopes are not considered.
+func (h *LegalHold) CoversRange(start, end time.Time) bool {
+	return h.overlaps(start, end)
+}
+
+func (h *LegalHold) overlaps(start, end time.Time) bool {
+	if h.StartsAt != nil && !h.StartsAt.Before(end) {
+		return false
+	}
+	if h.EndsAt != nil && !h.EndsAt.After(start) {
+		return false
+	}
+	return true
+}
+
+// IsValidCategory reports whether c is a known event category
+func IsValidCategory(c EventCategory) bool {
+	switch c {
+	case CategoryAuthentication, CategoryAuthorization, CategoryDataAccess,
+		CategoryDataModification, CategorySecurity, CategoryCompliance:
+		return true
+	}
+	return false
+}
+
+// LegalHoldChecker answers whether data may be destroyed.
+// Used by retention cleanup, partition archival and user erasure.
+type LegalHoldChecker interface {
+	// IsUserOnHold reports whether an active hold names the user
+	IsUserOnHold(ctx context.Context, userID uuid.UUID) (bool, error)
+
+	// IsRangeOnHold reports whether an active hold's date range overlaps [start, end)
+	IsRangeOnHold(ctx context.Context, start, end time.Time) (bool, error)
+}
+
+// LegalHoldRepository defines the interface for legal hold persistence
+type LegalHoldRepository interface {
+	LegalHoldChecker
+
+	// Create places a new hold
+	Create(ctx context.Context, hold *LegalHold) (*LegalHold, error)
+
+	// GetByID retrieves a hold by ID, released or not
+	GetByID(ctx context.Context, id uuid.UUID) (*LegalHold, error)
+
+	// List retrieves holds, newest first; activeOnly leaves out released and expired holds
+	List(ctx context.Context, activeOnly bool, limit, offset int32) ([]*LegalHold, error)
+
+	// UpdateTerms changes the reason, owner and expiry of an unreleased hold
+	UpdateTerms(ctx context.Context, id uuid.UUID, reason, owner string, expiresAt time.Time) (*LegalHold, error)
+
+	// Release lifts an unreleased hold
+	Release(ctx context.Context, id uuid.UUID, releasedBy, reason string) (*LegalHold, error)
+}
+
+// LegalHoldService defines the admin-facing legal hold operations.
+// Every change is recorded in audit_logs.
+type LegalHoldService interface {
+	LegalHoldChecker
+
+	// PlaceHold validates and creates a hold on behalf of actor
+	PlaceHold(ctx context.Context, hold *LegalHold, actor string) (*LegalHold, error)
+
+	// GetHold retrieves a hold by ID
+	GetHold(ctx context.Context, id uuid.UUID) (*LegalHold, error)
+
+	// ListHolds retrieves holds, newest first
+	ListHolds(ctx context.Context, activeOnly bool, limit, offset int32) ([]*LegalHold, error)
+
+	// UpdateHold changes the reason, owner and expiry of an unreleased hold
+	UpdateHold(ctx context.Context, id uuid.UUID, reason, owner string, expiresAt time.Time, actor string) (*LegalHold, error)
+
+	// ReleaseHold lifts a hold
+	ReleaseHold(ctx context.Context, id uuid.UUID, reason, actor string) (*LegalHold, error)
+}
@@ -0,0 +1,169 @@
+package audit_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/google/uuid"
+)
+
+// TestLegalHold_Validate tests the LegalHold.Validate method.
+func TestLegalHold_Validate(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	start := now.AddDate(0, -6, 0)
+	end := now.AddDate(0, -1, 0)
+	security := audit.CategorySecurity
+	unknown := audit.EventCategory("billing")
+
+	valid := func() *audit.LegalHold {
+		return &audit.LegalHold{
+			UserID:    &userID,
+			Reason:    "Regulator inquiry",
+			Owner:     "legal",
+			ExpiresAt: now.AddDate(1, 0, 0),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(h *audit.LegalHold)
+		wantErr bool
+	}{
+		{name: "user scope is valid", mutate: func(h *audit.LegalHold) {}},
+		{name: "date range scope is valid", mutate: func(h *audit.LegalHold) { h.UserID, h.StartsAt, h.EndsAt = nil, &start, &end }},
+		{name: "open-ended range is valid", mutate: func(h *audit.LegalHold) { h.UserID, h.StartsAt = nil, &start }},
+		{name: "category scope is valid", mutate: func(h *audit.LegalHold) { h.UserID, h.EventCategory = nil, &security }},
+		{name: "missing scope", mutate: func(h *audit.LegalHold) { h.UserID = nil }, wantErr: true},
+		{name: "inverted range", mutate: func(h *audit.LegalHold) { h.StartsAt, h.EndsAt = &end, &start }, wantErr: true},
+		{name: "unknown category", mutate: func(h *audit.LegalHold) { h.EventCategory = &unknown }, wantErr: true},
+		{name: "missing reason", mutate: func(h *audit.LegalHold) { h.Reason = "  " }, wantErr: true},
+		{name: "missing owner", mutate: func(h *audit.LegalHold) { h.Owner = "" }, wantErr: true},
+		{name: "expiry in the past", mutate: func(h *audit.LegalHold) { h.ExpiresAt = now.Add(-time.Second) }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hold := valid()
+			tt.mutate(hold)
+
+			err := hold.Validate(now)
+			if tt.wantErr {
+				if !errors.Is(err, audit.ErrInvalidLegalHold) {
+					t.Errorf("Validate() error = %v, want ErrInvalidLegalHold", err)
+				}
+			} else if err != nil {
+				t.Errorf("Validate() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+// TestLegalHold_IsActive tests the LegalHold.IsActive method.
+func TestLegalHold_IsActive(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	released := now.Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		hold audit.LegalHold
+		want bool
+	}{
+		{name: "unreleased before expiry", hold: audit.LegalHold{ExpiresAt: now.Add(time.Hour)}, want: true},
+		{name: "expired", hold: audit.LegalHold{ExpiresAt: now}, want: false},
+		{name: "released", hold: audit.LegalHold{ExpiresAt: now.Add(time.Hour), ReleasedAt: &released}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hold.IsActive(now); got != tt.want {
+				t.Errorf("IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLegalHold_Matches tests the LegalHold.Matches method.
+func TestLegalHold_Matches(t *testing.T) {
+	userID := uuid.New()
+	otherUser := uuid.New()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	security := audit.CategorySecurity
+
+	hold := &audit.LegalHold{UserID: &userID, StartsAt: &start, EndsAt: &end, EventCategory: &security}
+
+	tests := []struct {
+		name string
+		log  audit.Log
+		want bool
+	}{
+		{
+			name: "all scopes match",
+			log:  audit.Log{UserID: &userID, EventCategory: audit.CategorySecurity, CreatedAt: start},
+			want: true,
+		},
+		{
+			name: "other user",
+			log:  audit.Log{UserID: &otherUser, EventCategory: audit.CategorySecurity, CreatedAt: start},
+			want: false,
+		},
+		{
+			name: "no user on log",
+			log:  audit.Log{EventCategory: audit.CategorySecurity, CreatedAt: start},
+			want: false,
+		},
+		{
+			name: "other category",
+			log:  audit.Log{UserID: &userID, EventCategory: audit.CategoryAuthentication, CreatedAt: start},
+			want: false,
+		},
+		{
+			name: "end of range is exclusive",
+			log:  audit.Log{UserID: &userID, EventCategory: audit.CategorySecurity, CreatedAt: end},
+			want: false,
+		},
+		{
+			name: "before range",
+			log:  audit.Log{UserID: &userID, EventCategory: audit.CategorySecurity, CreatedAt: start.Add(-time.Second)},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hold.Matches(&tt.log); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLegalHold_CoversRange tests the LegalHold.CoversRange method.
+func TestLegalHold_CoversRange(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	openEnded := &audit.LegalHold{StartsAt: &start}
+	if !openEnded.CoversRange(jan, feb) {
+		t.Error("open-ended hold starting mid-January should cover January")
+	}
+	if openEnded.CoversRange(jan.AddDate(0, -1, 0), jan) {
+		t.Error("hold starting mid-January should not cover December")
+	}
+
+	bounded := &audit.LegalHold{StartsAt: &jan, EndsAt: &feb}
+	if bounded.CoversRange(feb, mar) {
+		t.Error("hold ending at February should not cover February")
+	}
+
+	userOnly := &audit.LegalHold{UserID: &userID}
+	if !userOnly.CoversRange(jan, feb) {
+		t.Error("hold without a date range should cover every range")
+	}
+}
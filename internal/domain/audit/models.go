@@ -92,6 +92,12 @@ type Log struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// IsHighSeverity reports whether the entry is high or critical severity.
+// These entries are published to the audit event stream for real-time consumers.
+func (l *Log) IsHighSeverity() bool {
+	return l.Severity == SeverityHigh || l.Severity == SeverityCritical
+}
+
 // Filter represents filter criteria for searching audit logs
 type Filter struct {
 	UserID        *uuid.UUID
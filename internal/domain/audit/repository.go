@@ -2,6 +2,7 @@ package audit
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -50,3 +51,49 @@ type Repository interface {
 	// DeleteExpired removes audit logs past their retention period
 	DeleteExpired(ctx context.Context) error
 }
+
+// PartitionRepository manages the monthly partitions of audit_logs and their archive records.
+// Months are identified by any instant inside them and normalised to UTC.
+type PartitionRepository interface {
+	// EnsurePartitions creates every missing partition between the months of from and to (inclusive)
+	// and returns how many were created
+	EnsurePartitions(ctx context.Context, from, to time.Time) (int, error)
+
+	// ListPartitions returns all partition tables, including detached ones awaiting archival
+	ListPartitions(ctx context.Context) ([]*Partition, error)
+
+	// CreatePartition creates an empty partition for a month
+	CreatePartition(ctx context.Context, month time.Time) error
+
+	// DetachPartition detaches a month from audit_logs without dropping its table
+	DetachPartition(ctx context.Context, month time.Time) error
+
+	// StreamPartition calls fn for every row of a month's partition table in created_at order
+	StreamPartition(ctx context.Context, month time.Time, fn func(*Log) error) error
+
+	// DropPartition drops a month's partition table, detaching it first if needed
+	DropPartition(ctx context.Context, month time.Time) error
+
+	// InsertLogs bulk-inserts previously archived entries, preserving their IDs and timestamps
+	InsertLogs(ctx context.Context, logs []*Log) (int64, error)
+
+	// CreateArchive records (or replaces) the archive of a month
+	CreateArchive(ctx context.Context, archive *Archive) (*Archive, error)
+
+	// ReplaceArchiveObject points the archive of a month at a rewritten archive object and
+	// manifest, keeping its restored flag. Returns ErrArchiveChanged if the archive's object is
+	// no longer previousObjectKey.
+	ReplaceArchiveObject(ctx context.Context, archive *Archive, previousObjectKey string) (*Archive, error)
+
+	// GetArchive retrieves the archive of a month
+	GetArchive(ctx context.Context, month time.Time) (*Archive, error)
+
+	// ListArchives retrieves archive records, newest month first
+	ListArchives(ctx context.Context, limit, offset int32) ([]*Archive, error)
+
+	// MarkArchiveRestored flags a month as restored into audit_logs
+	MarkArchiveRestored(ctx context.Context, month time.Time, restoredBy string) (*Archive, error)
+
+	// ClearArchiveRestored clears the restored flag after the month has been released
+	ClearArchiveRestored(ctx context.Context, month time.Time) (*Archive, error)
+}
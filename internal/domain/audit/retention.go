@@ -0,0 +1,176 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RetentionRule sets how long matching audit logs are kept.
+// Empty selector fields match everything, but at least one must be set;
+// the catch-all is the policy's default.
+type RetentionRule struct {
+	EventType string        `json:"event_type,omitempty"`
+	Category  EventCategory `json:"category,omitempty"`
+	Severity  Severity      `json:"severity,omitempty"`
+	Days      int           `json:"days"`
+}
+
+// Specificity ranks rules when several match the same log.
+// An event type override beats category + severity, which beats category alone, which beats severity alone.
+func (r RetentionRule) Specificity() int {
+	score := 0
+	if r.EventType != "" {
+		score += 4
+	}
+	if r.Category != "" {
+		score += 2
+	}
+	if r.Severity != "" {
+		score++
+	}
+	return score
+}
+
+// Matches reports whether the rule applies to an event
+func (r RetentionRule) Matches(eventType string, category EventCategory, severity Severity) bool {
+	return (r.EventType == "" || r.EventType == eventType) &&
+		(r.Category == "" || r.Category == category) &&
+		(r.Severity == "" || r.Severity == severity)
+}
+
+// selector identifies the events a rule targets, used to reject duplicates
+func (r RetentionRule) selector() string {
+	return fmt.Sprintf("%s|%s|%s", r.EventType, r.Category, r.Severity)
+}
+
+// RetentionPolicy maps audit events to retention periods.
+// The most specific matching rule wins; events no rule matches get the default.
+type RetentionPolicy struct {
+	defaultDays int
+	rules       []RetentionRule // Most specific first
+}
+
+// NewRetentionPolicy validates the rules and builds a policy
+func NewRetentionPolicy(defaultDays int, rules []RetentionRule) (*RetentionPolicy, error) {
+	if defaultDays <= 0 {
+		return nil, fmt.Errorf("%w: default retention must be a positive number of days", ErrInvalidRetentionPolicy)
+	}
+
+	seen := make(map[string]bool, len(rules))
+	ordered := make([]RetentionRule, 0, len(rules))
+	for i, rule := range rules {
+		if rule.Specificity() == 0 {
+			return nil, fmt.Errorf("%w: rule %d needs an event type, category or severity", ErrInvalidRetentionPolicy, i)
+		}
+		if rule.Category != "" && !IsValidCategory(rule.Category) {
+			return nil, fmt.Errorf("%w: rule %d has unknown event category %q", ErrInvalidRetentionPolicy, i, rule.Category)
+		}
+		if rule.Severity != "" && !IsValidSeverity(rule.Severity) {
+			return nil, fmt.Errorf("%w: rule %d has unknown severity %q", ErrInvalidRetentionPolicy, i, rule.Severity)
+		}
+		if rule.Days <= 0 {
+			return nil, fmt.Errorf("%w: rule %d must keep logs for a positive number of days", ErrInvalidRetentionPolicy, i)
+		}
+		if seen[rule.selector()] {
+			return nil, fmt.Errorf("%w: rule %d duplicates an earlier rule", ErrInvalidRetentionPolicy, i)
+		}
+		seen[rule.selector()] = true
+		ordered = append(ordered, rule)
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Specificity() > ordered[j].Specificity()
+	})
+
+	return &RetentionPolicy{defaultDays: defaultDays, rules: ordered}, nil
+}
+
+// DefaultDays returns the retention applied when no rule matches
+func (p *RetentionPolicy) DefaultDays() int {
+	return p.defaultDays
+}
+
+// Rules returns the policy rules, most specific first
+func (p *RetentionPolicy) Rules() []RetentionRule {
+	rules := make([]RetentionRule, len(p.rules))
+	copy(rules, p.rules)
+	return rules
+}
+
+// MaxDays returns the longest retention any event can get under the policy
+func (p *RetentionPolicy) MaxDays() int {
+	longest := p.defaultDays
+	for _, rule := range p.rules {
+		if rule.Days > longest {
+			longest = rule.Days
+		}
+	}
+	return longest
+}
+
+// DaysFor returns the retention in days for an event
+func (p *RetentionPolicy) DaysFor(eventType string, category EventCategory, severity Severity) int {
+	for _, rule := range p.rules {
+		if rule.Matches(eventType, category, severity) {
+			return rule.Days
+		}
+	}
+	return p.defaultDays
+}
+
+// RetentionUntil returns when a log created at createdAt may be deleted
+func (p *RetentionPolicy) RetentionUntil(log *Log, createdAt time.Time) time.Time {
+	days := p.DaysFor(log.EventType, log.EventCategory, log.Severity)
+	return createdAt.Add(time.Duration(days) * 24 * time.Hour)
+}
+
+// IsValidSeverity reports whether s is a known severity
+func IsValidSeverity(s Severity) bool {
+	switch s {
+	case SeverityInfo, SeverityWarning, SeverityHigh, SeverityCritical:
+		return true
+	}
+	return false
+}
+
+// ExpiryBucket counts expired audit logs of one category and severity.
+// Held rows are past retention but kept back by an active legal hold or a restored archive month.
+type ExpiryBucket struct {
+	Category        EventCategory `json:"category"`
+	Severity        Severity      `json:"severity"`
+	DeletableCount  int64         `json:"deletable_count"`
+	HeldCount       int64         `json:"held_count"`
+	OldestCreatedAt time.Time     `json:"oldest_created_at"`
+}
+
+// RetentionReport is a dry run of the cleanup job: what it would delete at AsOf
+type RetentionReport struct {
+	AsOf           time.Time       `json:"as_of"`
+	Buckets        []*ExpiryBucket `json:"buckets"`
+	TotalDeletable int64           `json:"total_deletable"`
+	TotalHeld      int64           `json:"total_held"`
+}
+
+// RetentionRepository applies retention policies to stored audit logs
+type RetentionRepository interface {
+	// Restamp recomputes retention_until from created_at for every log that has one
+	// and returns how many rows changed. Logs kept indefinitely (NULL) are left alone.
+	Restamp(ctx context.Context, policy *RetentionPolicy) (int64, error)
+
+	// SummarizeExpired groups logs whose retention has passed at asOf by category and severity
+	SummarizeExpired(ctx context.Context, asOf time.Time) ([]*ExpiryBucket, error)
+}
+
+// RetentionService exposes the active retention policy to admins
+type RetentionService interface {
+	// Policy returns the policy used for new audit logs
+	Policy() *RetentionPolicy
+
+	// RestampRetention applies the current policy to existing audit logs on behalf of actor
+	RestampRetention(ctx context.Context, actor string) (int64, error)
+
+	// DryRun reports what the cleanup job would delete at asOf
+	DryRun(ctx context.Context, asOf time.Time) (*RetentionReport, error)
+}
@@ -0,0 +1,121 @@
+package audit_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+)
+
+// TestNewRetentionPolicy tests retention policy validation.
+func TestNewRetentionPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultDays int
+		rules       []audit.RetentionRule
+		wantErr     bool
+	}{
+		{name: "default only", defaultDays: 90},
+		{
+			name:        "category, severity and event type rules",
+			defaultDays: 90,
+			rules: []audit.RetentionRule{
+				{Category: audit.CategorySecurity, Days: 730},
+				{Severity: audit.SeverityCritical, Days: 2555},
+				{EventType: "user.login.failed", Days: 365},
+			},
+		},
+		{name: "non-positive default", defaultDays: 0, wantErr: true},
+		{name: "rule without selector", defaultDays: 90, rules: []audit.RetentionRule{{Days: 30}}, wantErr: true},
+		{name: "unknown category", defaultDays: 90, rules: []audit.RetentionRule{{Category: "billing", Days: 30}}, wantErr: true},
+		{name: "unknown severity", defaultDays: 90, rules: []audit.RetentionRule{{Severity: "urgent", Days: 30}}, wantErr: true},
+		{name: "non-positive days", defaultDays: 90, rules: []audit.RetentionRule{{Category: audit.CategorySecurity}}, wantErr: true},
+		{
+			name:        "duplicate selector",
+			defaultDays: 90,
+			rules: []audit.RetentionRule{
+				{Category: audit.CategorySecurity, Days: 30},
+				{Category: audit.CategorySecurity, Days: 60},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := audit.NewRetentionPolicy(tt.defaultDays, tt.rules)
+			if tt.wantErr {
+				if !errors.Is(err, audit.ErrInvalidRetentionPolicy) {
+					t.Fatalf("expected ErrInvalidRetentionPolicy, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// TestRetentionPolicy_DaysFor tests that the most specific rule wins.
+func TestRetentionPolicy_DaysFor(t *testing.T) {
+	policy, err := audit.NewRetentionPolicy(90, []audit.RetentionRule{
+		{Severity: audit.SeverityCritical, Days: 2555},
+		{Category: audit.CategorySecurity, Days: 730},
+		{Category: audit.CategorySecurity, Severity: audit.SeverityCritical, Days: 3650},
+		{EventType: "user.login.failed", Days: 365},
+		{Category: audit.CategoryDataAccess, Days: 30},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		eventType string
+		category  audit.EventCategory
+		severity  audit.Severity
+		want      int
+	}{
+		{name: "category and severity beat category", eventType: "security.alert", category: audit.CategorySecurity, severity: audit.SeverityCritical, want: 3650},
+		{name: "category beats default", eventType: "security.alert", category: audit.CategorySecurity, severity: audit.SeverityWarning, want: 730},
+		{name: "category beats severity", eventType: "admin.users.list", category: audit.CategoryDataAccess, severity: audit.SeverityCritical, want: 30},
+		{name: "severity beats default", eventType: "user.delete", category: audit.CategoryDataModification, severity: audit.SeverityCritical, want: 2555},
+		{name: "event type beats everything", eventType: "user.login.failed", category: audit.CategorySecurity, severity: audit.SeverityCritical, want: 365},
+		{name: "no match uses default", eventType: "user.login", category: audit.CategoryAuthentication, severity: audit.SeverityInfo, want: 90},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.DaysFor(tt.eventType, tt.category, tt.severity); got != tt.want {
+				t.Errorf("DaysFor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if got := policy.MaxDays(); got != 3650 {
+		t.Errorf("MaxDays() = %d, want 3650", got)
+	}
+	if rules := policy.Rules(); rules[0].EventType != "user.login.failed" {
+		t.Errorf("Rules()[0] = %+v, want the event type override first", rules[0])
+	}
+}
+
+// TestRetentionPolicy_RetentionUntil tests the retention timestamp of a log.
+func TestRetentionPolicy_RetentionUntil(t *testing.T) {
+	policy, err := audit.NewRetentionPolicy(90, []audit.RetentionRule{
+		{Category: audit.CategorySecurity, Days: 730},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	log := &audit.Log{EventType: "security.alert", EventCategory: audit.CategorySecurity, Severity: audit.SeverityHigh}
+
+	want := createdAt.Add(730 * 24 * time.Hour)
+	if got := policy.RetentionUntil(log, createdAt); !got.Equal(want) {
+		t.Errorf("RetentionUntil() = %v, want %v", got, want)
+	}
+}
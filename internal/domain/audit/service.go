@@ -0,0 +1,20 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// ArchiveService defines the admin-facing operations on archived audit log months.
+// Implemented by the service layer; used by the admin HTTP transport.
+type ArchiveService interface {
+	// ListArchives returns archived months, newest first
+	ListArchives(ctx context.Context, limit, offset int32) ([]*Archive, error)
+
+	// RestoreArchive loads an archived month back into audit_logs for investigation.
+	// The month stays queryable through the regular audit log APIs until it is released.
+	RestoreArchive(ctx context.Context, month time.Time, restoredBy string) (*Archive, error)
+
+	// ReleaseArchive drops a restored month from audit_logs again; the archive object is kept
+	ReleaseArchive(ctx context.Context, month time.Time) (*Archive, error)
+}
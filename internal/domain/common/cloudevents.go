@@ -0,0 +1,136 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// CloudEvents context attributes shared by every event this service emits.
+// See https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/spec.md
+const (
+	// CloudEventsSpecVersion is the CloudEvents specification version events follow
+	CloudEventsSpecVersion = "1.0"
+
+	// EventDataContentType is the media type of event data
+	EventDataContentType = "application/json"
+
+	// EventSource identifies the user service as the producer of an event
+	EventSource = "/pandora-exchange/user-service"
+
+	// EventSchemaBaseURI is the prefix of every event's dataschema URI
+	EventSchemaBaseURI = "https://schemas.pandora.exchange/events"
+)
+
+// EventData is the typed payload of one event type.
+// Its JSON shape is published as a JSON Schema and versioned by SchemaVersion:
+// a change that could break consumers must come with a new version.
+type EventData interface {
+	// EventType returns the event type the payload belongs to
+	EventType() string
+
+	// SchemaVersion returns the version of the payload's JSON Schema
+	SchemaVersion() int
+}
+
+// VersionedEnvelope is an EventEnvelope that carries the CloudEvents attributes
+// beyond those every envelope has
+type VersionedEnvelope interface {
+	EventEnvelope
+
+	// EventSource returns the producer of the event (CloudEvents "source")
+	EventSource() string
+
+	// EventSubject returns the entity the event is about, e.g. the user ID (CloudEvents "subject")
+	EventSubject() string
+
+	// EventSchemaVersion returns the version of the payload schema
+	EventSchemaVersion() int
+}
+
+// EventContext returns the source, subject and payload schema version of an event.
+// Envelopes that do not implement VersionedEnvelope are reported as EventSource,
+// without subject, at schema version 1.
+func EventContext(event EventEnvelope) (source, subject string, schemaVersion int) {
+	versioned, ok := event.(VersionedEnvelope)
+	if !ok {
+		return EventSource, "", 1
+	}
+	source = versioned.EventSource()
+	if source == "" {
+		source = EventSource
+	}
+	schemaVersion = versioned.EventSchemaVersion()
+	if schemaVersion <= 0 {
+		schemaVersion = 1
+	}
+	return source, versioned.EventSubject(), schemaVersion
+}
+
+// DataSchemaURI returns the dataschema URI of an event type's payload at a schema version
+func DataSchemaURI(eventType string, schemaVersion int) string {
+	return fmt.Sprintf("%s/%s/v%d.json", EventSchemaBaseURI, eventType, schemaVersion)
+}
+
+// EventDataMap converts a typed payload to the generic map carried by EventEnvelope.
+// Payload structs only hold JSON-safe fields, so a marshalling failure is a programming
+// error and panics.
+func EventDataMap(data EventData) map[string]interface{} {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		panic(fmt.Sprintf("event data %T is not JSON serializable: %v", data, err))
+	}
+	payload := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		panic(fmt.Sprintf("event data %T is not a JSON object: %v", data, err))
+	}
+	return payload
+}
+
+// CloudEvent is an event in the CloudEvents 1.0 JSON format (structured content mode)
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	DataSchema      string    `json:"dataschema"`
+
+	// SchemaVersion is the payload schema version, an extension attribute
+	SchemaVersion int `json:"schemaversion"`
+
+	// Metadata holds request context (request ID, IP, user agent); not a CloudEvents attribute
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	Data json.RawMessage `json:"data"`
+}
+
+// NewCloudEvent converts an event to its CloudEvents form
+func NewCloudEvent(event EventEnvelope) (*CloudEvent, error) {
+	data, err := json.Marshal(event.EventPayload())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	source, subject, schemaVersion := EventContext(event)
+	return &CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              event.EventID(),
+		Source:          source,
+		Type:            event.EventType(),
+		Subject:         subject,
+		Time:            event.OccurredAt(),
+		DataContentType: EventDataContentType,
+		DataSchema:      DataSchemaURI(event.EventType(), schemaVersion),
+		SchemaVersion:   schemaVersion,
+		Metadata:        event.EventMetadata(),
+		Data:            data,
+	}, nil
+}
+
+// DecodeData unmarshals the event data into v
+func (e *CloudEvent) DecodeData(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
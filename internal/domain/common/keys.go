@@ -0,0 +1,22 @@
+package common
+
+import "context"
+
+// KeyManager wraps data encryption keys with a key encryption key it never exposes.
+// Wrapped keys carry the version of the key encryption key that wrapped them, so keys
+// wrapped before a rotation can still be unwrapped and later rewrapped.
+// This interface is implemented by the infrastructure layer (Vault Transit, local KMS).
+type KeyManager interface {
+	// WrapKey encrypts key with the current key encryption key
+	WrapKey(ctx context.Context, key []byte) (wrapped string, version int, err error)
+
+	// UnwrapKey decrypts a key returned by WrapKey or RewrapKey
+	UnwrapKey(ctx context.Context, wrapped string) ([]byte, error)
+
+	// RewrapKey re-encrypts a wrapped key with the current key encryption key
+	// without returning the key itself
+	RewrapKey(ctx context.Context, wrapped string) (rewrapped string, version int, err error)
+
+	// CurrentVersion returns the version of the current key encryption key
+	CurrentVersion(ctx context.Context) (int, error)
+}
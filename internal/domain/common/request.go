@@ -0,0 +1,42 @@
+package common
+
+import "context"
+
+// RequestMetadata describes the client request that triggered an operation. Transports attach
+// it to the request context so services can record where an action came from without taking
+// it as parameters.
+type RequestMetadata struct {
+	IPAddress string
+	UserAgent string
+	RequestID string
+}
+
+type requestMetadataKey struct{}
+
+// ContextWithRequestMetadata returns ctx carrying metadata
+func ContextWithRequestMetadata(ctx context.Context, metadata RequestMetadata) context.Context {
+	return context.WithValue(ctx, requestMetadataKey{}, metadata)
+}
+
+// RequestMetadataFromContext returns the request metadata carried by ctx; the zero value
+// when there is none, e.g. for background jobs
+func RequestMetadataFromContext(ctx context.Context) RequestMetadata {
+	metadata, _ := ctx.Value(requestMetadataKey{}).(RequestMetadata)
+	return metadata
+}
+
+// Fields returns the non-empty metadata as event metadata keys (ip_address, user_agent,
+// request_id)
+func (m RequestMetadata) Fields() map[string]string {
+	fields := make(map[string]string, 3)
+	if m.IPAddress != "" {
+		fields["ip_address"] = m.IPAddress
+	}
+	if m.UserAgent != "" {
+		fields["user_agent"] = m.UserAgent
+	}
+	if m.RequestID != "" {
+		fields["request_id"] = m.RequestID
+	}
+	return fields
+}
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrObjectNotFound is returned when an object store key does not exist.
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectStore defines the interface for durable blob storage (archives, documents, exports).
+// Keys are slash-separated relative paths such as "audit-logs/2025/11/audit_logs_y2025m11.ndjson.gz".
+// This interface is implemented by the infrastructure layer (local filesystem, S3-compatible stores).
+type ObjectStore interface {
+	// Put stores the content read from r under key, replacing any existing object.
+	// The object must not become visible under key until it has been fully written.
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Get opens the object stored under key. Returns ErrObjectNotFound if it does not exist.
+	// The caller must close the returned reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Exists reports whether an object is stored under key
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// Delete removes the object stored under key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 // ErrInternalServer is returned for unexpected internal errors.
@@ -251,13 +252,37 @@ func containsInner(s, substr string) bool {
 	return false
 }
 
+// EventEnvelope is the publishable view of a domain event.
+// Publishers only depend on this interface, so any domain package can emit events
+// without the infrastructure layer knowing its concrete event type.
+type EventEnvelope interface {
+	// EventID returns the unique event ID
+	EventID() string
+
+	// EventType returns the event type (e.g. "user.registered", "audit.logged")
+	EventType() string
+
+	// OccurredAt returns when the event occurred
+	OccurredAt() time.Time
+
+	// Attributes returns routing fields stored next to the payload so consumers can
+	// filter without decoding it (e.g. user_id, severity)
+	Attributes() map[string]string
+
+	// EventPayload returns the event-specific data
+	EventPayload() map[string]interface{}
+
+	// EventMetadata returns additional metadata (IP, user agent, request ID, etc.)
+	EventMetadata() map[string]string
+}
+
 // EventPublisher defines the interface for publishing domain events.
 // This interface is implemented by the infrastructure layer (Redis Streams).
 type EventPublisher interface {
-	// Publish publishes a single event
+	// Publish publishes a single event; event must implement EventEnvelope
 	Publish(event interface{}) error
 
-	// PublishBatch publishes multiple events in a batch
+	// PublishBatch publishes multiple events in a batch; each must implement EventEnvelope
 	PublishBatch(events []interface{}) error
 
 	// Close closes the publisher and releases resources
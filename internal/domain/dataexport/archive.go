@@ -0,0 +1,271 @@
+package dataexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Archive layout: every data file is listed with its SHA-256 in manifest.json, and
+// manifest.sig holds the base64 Ed25519 signature of manifest.json. Anyone holding the
+// service's public key can check an archive was issued by the service and not altered.
+const (
+	ArchiveFormat      = "pandora-data-export/v1"
+	ManifestFileName   = "manifest.json"
+	SignatureFileName  = "manifest.sig"
+	SignatureAlgorithm = "ed25519"
+)
+
+// Manifest lists the files of an archive
+type Manifest struct {
+	Format             string         `json:"format"`
+	JobID              uuid.UUID      `json:"job_id"`
+	UserID             uuid.UUID      `json:"user_id"`
+	GeneratedAt        time.Time      `json:"generated_at"`
+	SignatureAlgorithm string         `json:"signature_algorithm"`
+	KeyID              string         `json:"key_id"`
+	Files              []ManifestFile `json:"files"`
+}
+
+// ManifestFile is a data file of an archive
+type ManifestFile struct {
+	Name      string `json:"name"`
+	SizeBytes int64  `json:"size_bytes"`
+	SHA256    string `json:"sha256"`
+}
+
+// KeyID identifies a signing key in manifests: the first 8 bytes of the SHA-256 of the
+// public key, hex encoded
+func KeyID(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:8])
+}
+
+// ArchiveWriter writes a signed export archive. Add the data files, then Close to append
+// the manifest and its signature.
+type ArchiveWriter struct {
+	zw       *zip.Writer
+	manifest Manifest
+	names    map[string]bool
+}
+
+// NewArchiveWriter starts an archive of userID's data for job jobID, written to w
+func NewArchiveWriter(w io.Writer, jobID, userID uuid.UUID, generatedAt time.Time) *ArchiveWriter {
+	return &ArchiveWriter{
+		zw: zip.NewWriter(w),
+		manifest: Manifest{
+			Format:             ArchiveFormat,
+			JobID:              jobID,
+			UserID:             userID,
+			GeneratedAt:        generatedAt.UTC(),
+			SignatureAlgorithm: SignatureAlgorithm,
+			Files:              []ManifestFile{},
+		},
+		names: make(map[string]bool),
+	}
+}
+
+// AddFile adds a data file with the content read from r
+func (a *ArchiveWriter) AddFile(name string, r io.Reader) error {
+	if name == ManifestFileName || name == SignatureFileName || a.names[name] {
+		return fmt.Errorf("duplicate archive file %q", name)
+	}
+
+	w, err := a.create(name)
+	if err != nil {
+		return err
+	}
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(w, hasher), r)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	a.names[name] = true
+	a.manifest.Files = append(a.manifest.Files, ManifestFile{
+		Name:      name,
+		SizeBytes: size,
+		SHA256:    hex.EncodeToString(hasher.Sum(nil)),
+	})
+	return nil
+}
+
+// AddJSON adds a data file holding v as indented JSON
+func (a *ArchiveWriter) AddJSON(name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return a.AddFile(name, bytes.NewReader(append(data, '\n')))
+}
+
+// AddCSV adds a data file holding a header line and rows. Cells that spreadsheet software
+// would evaluate as formulas are prefixed with a single quote; the JSON files keep the
+// original values.
+func (a *ArchiveWriter) AddCSV(name string, header []string, rows [][]string) error {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	for _, row := range rows {
+		escaped := make([]string, len(row))
+		for i, cell := range row {
+			escaped[i] = escapeCSVCell(cell)
+		}
+		if err := w.Write(escaped); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return a.AddFile(name, &buf)
+}
+
+// Close writes the manifest signed with key and finishes the archive.
+// It does not close the underlying writer.
+func (a *ArchiveWriter) Close(key ed25519.PrivateKey) (*Manifest, error) {
+	a.manifest.KeyID = KeyID(key.Public().(ed25519.PublicKey))
+
+	manifestJSON, err := json.MarshalIndent(a.manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, manifestJSON))
+
+	for _, file := range []struct {
+		name    string
+		content []byte
+	}{
+		{ManifestFileName, manifestJSON},
+		{SignatureFileName, []byte(signature + "\n")},
+	} {
+		w, err := a.create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(file.content); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+
+	if err := a.zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	manifest := a.manifest
+	return &manifest, nil
+}
+
+// create starts a compressed entry timed at the archive's generation time
+func (a *ArchiveWriter) create(name string) (io.Writer, error) {
+	w, err := a.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: a.manifest.GeneratedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	return w, nil
+}
+
+// VerifyArchive checks an archive's manifest signature against publicKey and every data
+// file against the manifest, and returns the manifest. Returns ErrInvalidArchive if the
+// archive was altered or signed with another key.
+func VerifyArchive(r io.ReaderAt, size int64, publicKey ed25519.PublicKey) (*Manifest, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	manifestJSON, err := readArchiveFile(files, ManifestFileName)
+	if err != nil {
+		return nil, err
+	}
+	encodedSignature, err := readArchiveFile(files, SignatureFileName)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encodedSignature)))
+	if err != nil || !ed25519.Verify(publicKey, manifestJSON, signature) {
+		return nil, fmt.Errorf("%w: manifest signature does not match", ErrInvalidArchive)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	if manifest.Format != ArchiveFormat {
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidArchive, manifest.Format)
+	}
+
+	listed := map[string]bool{ManifestFileName: true, SignatureFileName: true}
+	for _, file := range manifest.Files {
+		content, err := readArchiveFile(files, file.Name)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(content)
+		if int64(len(content)) != file.SizeBytes || hex.EncodeToString(sum[:]) != file.SHA256 {
+			return nil, fmt.Errorf("%w: %s does not match the manifest", ErrInvalidArchive, file.Name)
+		}
+		listed[file.Name] = true
+	}
+
+	var unlisted []string
+	for name := range files {
+		if !listed[name] {
+			unlisted = append(unlisted, name)
+		}
+	}
+	if len(unlisted) > 0 {
+		sort.Strings(unlisted)
+		return nil, fmt.Errorf("%w: files not in the manifest: %s", ErrInvalidArchive, strings.Join(unlisted, ", "))
+	}
+	return &manifest, nil
+}
+
+// readArchiveFile reads an entry of an archive being verified
+func readArchiveFile(files map[string]*zip.File, name string) ([]byte, error) {
+	f, ok := files[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is missing", ErrInvalidArchive, name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArchive, name, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArchive, name, err)
+	}
+	return content, nil
+}
+
+// escapeCSVCell defuses cells that spreadsheet software would evaluate as formulas
+func escapeCSVCell(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
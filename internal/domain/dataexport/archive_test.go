@@ -0,0 +1,232 @@
+package dataexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSigningKey(seed byte) ed25519.PrivateKey {
+	return ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize))
+}
+
+// writeTestArchive builds an archive with a JSON, a CSV and a raw file
+func writeTestArchive(t *testing.T, key ed25519.PrivateKey) ([]byte, *Manifest) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := NewArchiveWriter(&buf, uuid.New(), uuid.New(), time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, w.AddJSON("profile.json", map[string]string{"email": "alice@example.com"}))
+	require.NoError(t, w.AddCSV("profile.csv", []string{"email", "phone"}, [][]string{{"alice@example.com", "+40712345678"}}))
+	require.NoError(t, w.AddFile("kyc_documents/passport.pdf", strings.NewReader("%PDF-1.4")))
+	manifest, err := w.Close(key)
+	require.NoError(t, err)
+	return buf.Bytes(), manifest
+}
+
+// rewriteArchive copies an archive, letting edit replace or drop (nil) entries and add more
+func rewriteArchive(t *testing.T, archive []byte, edit func(name string, content []byte) []byte, extra map[string]string) []byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+
+		if content = edit(f.Name, content); content == nil {
+			continue
+		}
+		w, err := zw.Create(f.Name)
+		require.NoError(t, err)
+		_, err = w.Write(content)
+		require.NoError(t, err)
+	}
+	for name, content := range extra {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestArchive_SignAndVerify(t *testing.T) {
+	key := testSigningKey(1)
+	publicKey := key.Public().(ed25519.PublicKey)
+	archive, written := writeTestArchive(t, key)
+
+	manifest, err := VerifyArchive(bytes.NewReader(archive), int64(len(archive)), publicKey)
+	require.NoError(t, err)
+	assert.Equal(t, written, manifest)
+	assert.Equal(t, ArchiveFormat, manifest.Format)
+	assert.Equal(t, KeyID(publicKey), manifest.KeyID)
+	require.Len(t, manifest.Files, 3)
+	assert.Equal(t, "kyc_documents/passport.pdf", manifest.Files[2].Name)
+	assert.Equal(t, int64(8), manifest.Files[2].SizeBytes)
+
+	t.Run("csv cells are defused", func(t *testing.T) {
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		require.NoError(t, err)
+		f, err := zr.Open("profile.csv")
+		require.NoError(t, err)
+		records, err := csv.NewReader(f).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice@example.com", "'+40712345678"}, records[1])
+	})
+
+	tampered := []struct {
+		name    string
+		archive func() []byte
+		key     ed25519.PublicKey
+	}{
+		{"other key", func() []byte { return archive }, testSigningKey(2).Public().(ed25519.PublicKey)},
+		{"altered file", func() []byte {
+			return rewriteArchive(t, archive, func(name string, content []byte) []byte {
+				if name == "profile.json" {
+					return []byte(`{"email":"mallory@example.com"}`)
+				}
+				return content
+			}, nil)
+		}, publicKey},
+		{"missing file", func() []byte {
+			return rewriteArchive(t, archive, func(name string, content []byte) []byte {
+				if name == "profile.csv" {
+					return nil
+				}
+				return content
+			}, nil)
+		}, publicKey},
+		{"unlisted file", func() []byte {
+			return rewriteArchive(t, archive, func(_ string, content []byte) []byte { return content },
+				map[string]string{"extra.txt": "injected"})
+		}, publicKey},
+		{"altered manifest", func() []byte {
+			return rewriteArchive(t, archive, func(name string, content []byte) []byte {
+				if name == ManifestFileName {
+					return bytes.Replace(content, []byte(ArchiveFormat), []byte("other/v1"), 1)
+				}
+				return content
+			}, nil)
+		}, publicKey},
+		{"not a zip", func() []byte { return []byte("not a zip") }, publicKey},
+	}
+	for _, tt := range tampered {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.archive()
+			_, err := VerifyArchive(bytes.NewReader(data), int64(len(data)), tt.key)
+			assert.True(t, errors.Is(err, ErrInvalidArchive), "got %v", err)
+		})
+	}
+}
+
+func TestArchiveWriter_RejectsDuplicateNames(t *testing.T) {
+	w := NewArchiveWriter(io.Discard, uuid.New(), uuid.New(), time.Now())
+	require.NoError(t, w.AddFile("a.json", strings.NewReader("{}")))
+	assert.Error(t, w.AddFile("a.json", strings.NewReader("{}")))
+	assert.Error(t, w.AddFile(ManifestFileName, strings.NewReader("{}")))
+}
+
+func TestDatasets(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	caseID := uuid.New()
+	reviewNote := "=HYPERLINK(\"http://evil\")"
+	data := &SubjectData{
+		Profile: &user.User{
+			ID:             userID,
+			Email:          "alice@example.com",
+			FirstName:      "Alice",
+			LastName:       "Smith",
+			HashedPassword: "$argon2id$secret",
+			Role:           user.RoleUser,
+			KYCStatus:      user.KYCStatusVerified,
+			Address:        user.Address{City: "Bucharest", Country: "RO"},
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		},
+		Sessions: []*auth.RefreshToken{{
+			Token:     "refresh-token-value",
+			UserID:    userID,
+			CreatedAt: now,
+			ExpiresAt: now.Add(time.Hour),
+			IPAddress: "192.0.2.1",
+		}},
+		KYCCases: []*kyc.Case{{
+			ID:         caseID,
+			UserID:     userID,
+			Status:     kyc.StatusApproved,
+			ReviewNote: &reviewNote,
+			Documents: []*kyc.Document{{
+				ID: uuid.New(), CaseID: caseID, Type: kyc.DocumentPassport,
+				FileName: "../passport.pdf", StorageKey: "kyc-documents/secret-key",
+			}},
+		}},
+	}
+	logs := []*audit.Log{{ID: uuid.New(), EventType: "user.login", Metadata: map[string]interface{}{"a": 1}}}
+
+	datasets := Datasets(data, logs)
+
+	names := make([]string, len(datasets))
+	for i, d := range datasets {
+		names[i] = d.Name
+		for _, row := range d.Rows {
+			assert.Len(t, row, len(d.Header), d.Name)
+		}
+	}
+	assert.Equal(t, []string{"profile", "sessions", "kyc_cases", "kyc_documents", "kyc_history", "kyc_tier_changes", "audit_logs"}, names)
+
+	encoded, err := json.Marshal(datasets)
+	require.NoError(t, err)
+	assert.NotContains(t, string(encoded), "argon2id", "password hashes are not exported")
+	assert.NotContains(t, string(encoded), "refresh-token-value", "session tokens are not exported")
+	assert.NotContains(t, string(encoded), "secret-key", "storage keys are not exported")
+	assert.Contains(t, string(encoded), `"kyc_history","Records":[]`, "empty datasets are arrays")
+
+	assert.Equal(t, "Bucharest", datasets[0].Rows[0][11])
+	assert.Equal(t, `{"a":1}`, datasets[6].Rows[0][14])
+	assert.Equal(t, "kyc_documents/"+data.KYCCases[0].Documents[0].ID.String()+"_passport.pdf",
+		DocumentPath(data.KYCCases[0].Documents[0]))
+}
+
+func TestJob_IsDownloadable(t *testing.T) {
+	now := time.Now()
+	key := "data-exports/a.zip"
+	later, earlier := now.Add(time.Hour), now.Add(-time.Hour)
+
+	assert.True(t, (&Job{Status: StatusCompleted, ObjectKey: &key, ExpiresAt: &later}).IsDownloadable(now))
+	assert.False(t, (&Job{Status: StatusCompleted, ObjectKey: &key, ExpiresAt: &earlier}).IsDownloadable(now))
+	assert.False(t, (&Job{Status: StatusRunning}).IsDownloadable(now))
+	assert.False(t, (&Job{Status: StatusExpired, ObjectKey: &key}).IsDownloadable(now))
+}
+
+func TestDownloadToken(t *testing.T) {
+	token, hash, err := NewDownloadToken()
+	require.NoError(t, err)
+	other, _, err := NewDownloadToken()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, token, other)
+	assert.Equal(t, hash, HashDownloadToken(token))
+	assert.Len(t, hash, 32)
+}
@@ -0,0 +1,30 @@
+package dataexport
+
+import "errors"
+
+// Domain-level errors for data subject access exports.
+var (
+	// ErrJobNotFound is returned when an export does not exist or belongs to another user.
+	ErrJobNotFound = errors.New("data export not found")
+
+	// ErrNoJob is returned by Claim when no export is waiting to run.
+	ErrNoJob = errors.New("no data export to run")
+
+	// ErrLeaseLost is returned when a running export was taken over by another instance.
+	ErrLeaseLost = errors.New("data export lease lost")
+
+	// ErrExportInProgress is returned when the user already has a pending or running export.
+	ErrExportInProgress = errors.New("a data export is already in progress")
+
+	// ErrNotReady is returned when a download link is requested for an export that has not completed.
+	ErrNotReady = errors.New("data export is not ready")
+
+	// ErrExpired is returned when the archive of an export has been deleted.
+	ErrExpired = errors.New("data export has expired")
+
+	// ErrInvalidDownloadToken is returned when a download link is unknown or no longer valid.
+	ErrInvalidDownloadToken = errors.New("invalid or expired download link")
+
+	// ErrInvalidArchive is returned when an archive fails signature or checksum verification.
+	ErrInvalidArchive = errors.New("invalid data export archive")
+)
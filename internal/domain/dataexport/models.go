@@ -0,0 +1,220 @@
+// Package dataexport contains the data subject access export domain model (GDPR Art. 15
+// and 20). An export job collects the personal data held about a user (profile, sessions,
+// KYC records and the audit entries about them) into a signed ZIP archive of JSON and CSV
+// files. Jobs run in the background; once completed, the user downloads the archive through
+// a short-lived link until the archive expires and is deleted.
+package dataexport
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// Status is where an export is in its lifecycle
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	// StatusExpired exports completed, but their archive was deleted after the retention period
+	StatusExpired Status = "expired"
+)
+
+// IsValid reports whether s is a known status
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusRunning, StatusCompleted, StatusFailed, StatusExpired:
+		return true
+	}
+	return false
+}
+
+// IsActive reports whether an export in status s has not finished yet; a user has at most
+// one active export
+func (s Status) IsActive() bool {
+	return s == StatusPending || s == StatusRunning
+}
+
+// Requester is who asked for an export: the user themselves, or an admin on their behalf
+type Requester struct {
+	Type audit.ActorType
+	// ID identifies the requester in the audit log: the user ID, or the admin's email
+	ID string
+}
+
+// Job is a stored export and, once completed, its archive
+type Job struct {
+	ID            uuid.UUID       `json:"id"`
+	UserID        uuid.UUID       `json:"user_id"`
+	Status        Status          `json:"status"`
+	RequestedBy   string          `json:"requested_by"`
+	RequesterType audit.ActorType `json:"requester_type"`
+	LastError     *string         `json:"last_error,omitempty"`
+
+	// Archive, set once completed
+	ObjectKey *string `json:"-"`
+	SizeBytes *int64  `json:"size_bytes,omitempty"`
+	SHA256    *string `json:"sha256,omitempty"`
+	// ExpiresAt is when the archive is deleted
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Download links; only the latest link of a job is valid
+	DownloadExpiresAt *time.Time `json:"download_expires_at,omitempty"`
+	DownloadCount     int        `json:"download_count"`
+	LastDownloadedAt  *time.Time `json:"last_downloaded_at,omitempty"`
+
+	// Lease held by the instance building the archive
+	LeaseOwner     *string    `json:"-"`
+	LeaseExpiresAt *time.Time `json:"-"`
+
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// IsDownloadable reports whether the archive of the job can be downloaded at now
+func (j *Job) IsDownloadable(now time.Time) bool {
+	return j.Status == StatusCompleted && j.ObjectKey != nil && (j.ExpiresAt == nil || now.Before(*j.ExpiresAt))
+}
+
+// ArchiveInfo describes an archive stored for a completed job
+type ArchiveInfo struct {
+	ObjectKey string
+	SizeBytes int64
+	SHA256    string
+	ExpiresAt time.Time
+}
+
+// DownloadLink is a short-lived credential to download a job's archive. Only the hash of the
+// token is stored.
+type DownloadLink struct {
+	JobID     uuid.UUID
+	Token     string
+	ExpiresAt time.Time
+}
+
+// downloadTokenBytes is the entropy of a download token
+const downloadTokenBytes = 32
+
+// NewDownloadToken returns a random download token and the hash stored for it
+func NewDownloadToken() (string, []byte, error) {
+	raw := make([]byte, downloadTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, fmt.Errorf("failed to generate download token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashDownloadToken(token), nil
+}
+
+// HashDownloadToken returns the stored form of a download token
+func HashDownloadToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+// SubjectData is the personal data held about a user, apart from audit entries which are
+// read page by page
+type SubjectData struct {
+	Profile  *user.User
+	Sessions []*auth.RefreshToken
+	// KYCCases hold their documents; document content is read from the object store
+	KYCCases       []*kyc.Case
+	KYCHistory     []*kyc.Transition
+	KYCTierChanges []*kyc.TierChange
+}
+
+// Repository persists export jobs and reads the data they collect
+type Repository interface {
+	// Create stores a new pending job. Returns ErrExportInProgress if the user already has
+	// a pending or running job.
+	Create(ctx context.Context, userID uuid.UUID, requester Requester) (*Job, error)
+
+	// GetByID retrieves a job. Returns ErrJobNotFound if it does not exist.
+	GetByID(ctx context.Context, id uuid.UUID) (*Job, error)
+
+	// ListForUser retrieves a user's jobs, newest first
+	ListForUser(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*Job, error)
+
+	// Claim leases the oldest pending job, or running job whose lease expired, to owner
+	// until leaseUntil. Returns ErrNoJob when there is nothing to run.
+	Claim(ctx context.Context, owner string, now, leaseUntil time.Time) (*Job, error)
+
+	// Complete records the archive of a running job and releases its lease.
+	// Returns ErrLeaseLost when the job is now held by another owner.
+	Complete(ctx context.Context, id uuid.UUID, owner string, archive ArchiveInfo, at time.Time) error
+
+	// Fail moves a running job to failed and releases its lease.
+	// Returns ErrLeaseLost when the job is now held by another owner.
+	Fail(ctx context.Context, id uuid.UUID, owner string, lastError string, at time.Time) error
+
+	// SetDownloadToken replaces the download link of a completed job, invalidating earlier
+	// links. Returns ErrNotReady if the job is not completed.
+	SetDownloadToken(ctx context.Context, id uuid.UUID, tokenHash []byte, expiresAt time.Time) (*Job, error)
+
+	// GetByDownloadToken retrieves the job a download token was issued for.
+	// Returns ErrInvalidDownloadToken if no job has that token.
+	GetByDownloadToken(ctx context.Context, tokenHash []byte) (*Job, error)
+
+	// RecordDownload counts a download of a job's archive
+	RecordDownload(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// ListExpired retrieves up to limit completed jobs whose archive expired before now
+	ListExpired(ctx context.Context, now time.Time, limit int32) ([]*Job, error)
+
+	// MarkExpired moves a completed job to expired once its archive was deleted
+	MarkExpired(ctx context.Context, id uuid.UUID) error
+
+	// GetSubjectData reads the personal data held about a user, including a deleted one.
+	// Returns user.ErrNotFound if the user does not exist.
+	GetSubjectData(ctx context.Context, userID uuid.UUID) (*SubjectData, error)
+
+	// ListAuditLogs returns up to limit audit entries about a user (entries of their own
+	// actions and entries whose resource is the user) after position (nil for the first
+	// page), in (created_at, id) order
+	ListAuditLogs(ctx context.Context, userID uuid.UUID, after *Position, limit int32) ([]*audit.Log, error)
+}
+
+// Position is a keyset position in (created_at, id) order
+type Position struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// Service exposes data exports to users and to admins acting on their behalf
+type Service interface {
+	// RequestExport queues an export of userID's data on behalf of requester.
+	// Returns ErrExportInProgress if one is already pending or running.
+	RequestExport(ctx context.Context, userID uuid.UUID, requester Requester) (*Job, error)
+
+	// GetExport retrieves an export of userID. Returns ErrJobNotFound if it belongs to another user.
+	GetExport(ctx context.Context, userID, jobID uuid.UUID) (*Job, error)
+
+	// ListExports retrieves userID's exports, newest first
+	ListExports(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*Job, error)
+
+	// CreateDownloadLink issues a short-lived link to a completed export of userID,
+	// invalidating earlier links. Returns ErrNotReady or ErrExpired when there is nothing to download.
+	CreateDownloadLink(ctx context.Context, userID, jobID uuid.UUID) (*DownloadLink, error)
+
+	// OpenDownload opens the archive a download token was issued for. The caller must close
+	// the returned reader. Returns ErrInvalidDownloadToken if the link is unknown or expired.
+	OpenDownload(ctx context.Context, token string) (*Job, io.ReadCloser, error)
+
+	// PublicKey is the key archive manifests are signed with, for users to verify archives
+	PublicKey() ed25519.PublicKey
+}
@@ -0,0 +1,306 @@
+package dataexport
+
+import (
+	"encoding/json"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// Dataset is one kind of record of an export, written as <Name>.json and <Name>.csv
+type Dataset struct {
+	Name string
+	// Records is encoded as the JSON file
+	Records interface{}
+	// Header and Rows make up the CSV file
+	Header []string
+	Rows   [][]string
+}
+
+// Datasets arranges the data collected for an export into the files of its archive
+func Datasets(data *SubjectData, auditLogs []*audit.Log) []Dataset {
+	var documents []*kyc.Document
+	for _, c := range data.KYCCases {
+		documents = append(documents, c.Documents...)
+	}
+
+	return []Dataset{
+		profileDataset(data.Profile),
+		sessionsDataset(data.Sessions),
+		kycCasesDataset(data.KYCCases),
+		kycDocumentsDataset(documents),
+		kycHistoryDataset(data.KYCHistory),
+		kycTierChangesDataset(data.KYCTierChanges),
+		auditLogsDataset(auditLogs),
+	}
+}
+
+// DocumentPath is where the content of a KYC document is stored in an archive
+func DocumentPath(d *kyc.Document) string {
+	return "kyc_documents/" + d.ID.String() + "_" + path.Base("/"+d.FileName)
+}
+
+// ProfileRecord is the account data of the user
+type ProfileRecord struct {
+	ID                uuid.UUID      `json:"id"`
+	Email             string         `json:"email"`
+	FirstName         string         `json:"first_name"`
+	LastName          string         `json:"last_name"`
+	Role              user.Role      `json:"role"`
+	KYCStatus         user.KYCStatus `json:"kyc_status"`
+	Phone             string         `json:"phone,omitempty"`
+	PhoneVerifiedAt   *time.Time     `json:"phone_verified_at,omitempty"`
+	DateOfBirth       string         `json:"date_of_birth,omitempty"`
+	AddressLine1      string         `json:"address_line1,omitempty"`
+	AddressLine2      string         `json:"address_line2,omitempty"`
+	AddressCity       string         `json:"address_city,omitempty"`
+	AddressPostalCode string         `json:"address_postal_code,omitempty"`
+	AddressRegion     string         `json:"address_region,omitempty"`
+	AddressCountry    string         `json:"address_country,omitempty"`
+	Nationality       string         `json:"nationality,omitempty"`
+	TaxResidency      string         `json:"tax_residency,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         *time.Time     `json:"deleted_at,omitempty"`
+}
+
+// SessionRecord is a login session of the user; the token itself is not exported
+type SessionRecord struct {
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	IPAddress string     `json:"ip_address,omitempty"`
+	UserAgent string     `json:"user_agent,omitempty"`
+	Active    bool       `json:"active"`
+}
+
+func profileDataset(u *user.User) Dataset {
+	record := ProfileRecord{
+		ID:                u.ID,
+		Email:             u.Email,
+		FirstName:         u.FirstName,
+		LastName:          u.LastName,
+		Role:              u.Role,
+		KYCStatus:         u.KYCStatus,
+		Phone:             u.Phone,
+		PhoneVerifiedAt:   u.PhoneVerifiedAt,
+		DateOfBirth:       u.DateOfBirth,
+		AddressLine1:      u.Address.Line1,
+		AddressLine2:      u.Address.Line2,
+		AddressCity:       u.Address.City,
+		AddressPostalCode: u.Address.PostalCode,
+		AddressRegion:     u.Address.Region,
+		AddressCountry:    u.Address.Country,
+		Nationality:       u.Nationality,
+		TaxResidency:      u.TaxResidency,
+		CreatedAt:         u.CreatedAt,
+		UpdatedAt:         u.UpdatedAt,
+		DeletedAt:         u.DeletedAt,
+	}
+
+	return Dataset{
+		Name:    "profile",
+		Records: record,
+		Header: []string{
+			"id", "email", "first_name", "last_name", "role", "kyc_status", "phone", "phone_verified_at",
+			"date_of_birth", "address_line1", "address_line2", "address_city", "address_postal_code",
+			"address_region", "address_country", "nationality", "tax_residency",
+			"created_at", "updated_at", "deleted_at",
+		},
+		Rows: [][]string{{
+			record.ID.String(), record.Email, record.FirstName, record.LastName, string(record.Role),
+			string(record.KYCStatus), record.Phone, formatOptionalTime(record.PhoneVerifiedAt),
+			record.DateOfBirth, record.AddressLine1, record.AddressLine2, record.AddressCity,
+			record.AddressPostalCode, record.AddressRegion, record.AddressCountry, record.Nationality,
+			record.TaxResidency, formatTime(record.CreatedAt), formatTime(record.UpdatedAt),
+			formatOptionalTime(record.DeletedAt),
+		}},
+	}
+}
+
+func sessionsDataset(sessions []*auth.RefreshToken) Dataset {
+	records := make([]SessionRecord, len(sessions))
+	rows := make([][]string, len(sessions))
+	for i, s := range sessions {
+		records[i] = SessionRecord{
+			CreatedAt: s.CreatedAt,
+			ExpiresAt: s.ExpiresAt,
+			RevokedAt: s.RevokedAt,
+			IPAddress: s.IPAddress,
+			UserAgent: s.UserAgent,
+			Active:    s.IsActive(),
+		}
+		rows[i] = []string{
+			formatTime(s.CreatedAt), formatTime(s.ExpiresAt), formatOptionalTime(s.RevokedAt),
+			s.IPAddress, s.UserAgent, strconv.FormatBool(records[i].Active),
+		}
+	}
+
+	return Dataset{
+		Name:    "sessions",
+		Records: records,
+		Header:  []string{"created_at", "expires_at", "revoked_at", "ip_address", "user_agent", "active"},
+		Rows:    rows,
+	}
+}
+
+func kycCasesDataset(cases []*kyc.Case) Dataset {
+	rows := make([][]string, len(cases))
+	for i, c := range cases {
+		var rejectionReason string
+		if c.RejectionReason != nil {
+			rejectionReason = string(*c.RejectionReason)
+		}
+		rows[i] = []string{
+			c.ID.String(), string(c.Status), string(c.RiskLevel),
+			c.Applicant.FirstName, c.Applicant.LastName, c.Applicant.DateOfBirth,
+			c.Applicant.Nationality, c.Applicant.CountryOfResidence, c.Applicant.AddressLine1,
+			c.Applicant.AddressLine2, c.Applicant.City, c.Applicant.PostalCode,
+			rejectionReason, stringValue(c.ReviewNote), stringValue(c.Provider),
+			formatOptionalTime(c.SubmittedAt), formatOptionalTime(c.DecidedAt),
+			formatTime(c.CreatedAt), formatTime(c.UpdatedAt),
+		}
+	}
+
+	return Dataset{
+		Name:    "kyc_cases",
+		Records: nonNil(cases),
+		Header: []string{
+			"id", "status", "risk_level", "first_name", "last_name", "date_of_birth", "nationality",
+			"country_of_residence", "address_line1", "address_line2", "city", "postal_code",
+			"rejection_reason", "review_note", "provider", "submitted_at", "decided_at",
+			"created_at", "updated_at",
+		},
+		Rows: rows,
+	}
+}
+
+func kycDocumentsDataset(documents []*kyc.Document) Dataset {
+	rows := make([][]string, len(documents))
+	for i, d := range documents {
+		rows[i] = []string{
+			d.ID.String(), d.CaseID.String(), string(d.Type), d.FileName, d.ContentType,
+			strconv.FormatInt(d.SizeBytes, 10), d.SHA256, formatTime(d.UploadedAt), DocumentPath(d),
+		}
+	}
+
+	return Dataset{
+		Name:    "kyc_documents",
+		Records: nonNil(documents),
+		Header: []string{
+			"id", "case_id", "type", "file_name", "content_type", "size_bytes", "sha256",
+			"uploaded_at", "archive_path",
+		},
+		Rows: rows,
+	}
+}
+
+func kycHistoryDataset(history []*kyc.Transition) Dataset {
+	rows := make([][]string, len(history))
+	for i, t := range history {
+		var reasonCode string
+		if t.ReasonCode != nil {
+			reasonCode = string(*t.ReasonCode)
+		}
+		rows[i] = []string{
+			t.ID.String(), t.CaseID.String(), string(t.Action), string(t.FromStatus),
+			string(t.ToStatus), t.ActorID.String(), reasonCode, stringValue(t.Note),
+			formatTime(t.CreatedAt),
+		}
+	}
+
+	return Dataset{
+		Name:    "kyc_history",
+		Records: nonNil(history),
+		Header: []string{
+			"id", "case_id", "action", "from_status", "to_status", "actor_id", "reason_code",
+			"note", "created_at",
+		},
+		Rows: rows,
+	}
+}
+
+func kycTierChangesDataset(changes []*kyc.TierChange) Dataset {
+	rows := make([][]string, len(changes))
+	for i, c := range changes {
+		rows[i] = []string{
+			c.ID.String(), strconv.Itoa(int(c.OldTier)), strconv.Itoa(int(c.NewTier)), c.Reason,
+			optionalUUIDString(c.ChangedBy), optionalUUIDString(c.CaseID), formatTime(c.CreatedAt),
+		}
+	}
+
+	return Dataset{
+		Name:    "kyc_tier_changes",
+		Records: nonNil(changes),
+		Header:  []string{"id", "old_tier", "new_tier", "reason", "changed_by", "case_id", "created_at"},
+		Rows:    rows,
+	}
+}
+
+func auditLogsDataset(logs []*audit.Log) Dataset {
+	rows := make([][]string, len(logs))
+	for i, l := range logs {
+		var metadata string
+		if len(l.Metadata) > 0 {
+			if encoded, err := json.Marshal(l.Metadata); err == nil {
+				metadata = string(encoded)
+			}
+		}
+		rows[i] = []string{
+			l.ID.String(), formatTime(l.CreatedAt), l.EventType, string(l.EventCategory),
+			string(l.Severity), string(l.ActorType), stringValue(l.ActorIdentifier), l.Action,
+			stringValue(l.ResourceType), stringValue(l.ResourceID), stringValue(l.IPAddress),
+			stringValue(l.UserAgent), string(l.Status), stringValue(l.FailureReason), metadata,
+		}
+	}
+
+	return Dataset{
+		Name:    "audit_logs",
+		Records: nonNil(logs),
+		Header: []string{
+			"id", "created_at", "event_type", "event_category", "severity", "actor_type",
+			"actor_identifier", "action", "resource_type", "resource_id", "ip_address",
+			"user_agent", "status", "failure_reason", "metadata",
+		},
+		Rows: rows,
+	}
+}
+
+// nonNil makes empty datasets encode as [] rather than null
+func nonNil[T any](records []T) []T {
+	if records == nil {
+		return []T{}
+	}
+	return records
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func optionalUUIDString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,15 @@
+package erasure
+
+import "errors"
+
+// Domain-level errors for GDPR erasure.
+var (
+	// ErrNotFound is returned when a user has no erasure record.
+	ErrNotFound = errors.New("erasure record not found")
+
+	// ErrNotDeleted is returned when erasing a user that does not exist or has not been deleted.
+	ErrNotDeleted = errors.New("user is not deleted")
+
+	// ErrAlreadyErased is returned when erasing or deferring a user that has been erased.
+	ErrAlreadyErased = errors.New("user has already been erased")
+)
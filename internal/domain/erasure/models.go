@@ -0,0 +1,100 @@
+// Package erasure contains the GDPR right to erasure domain model (GDPR Art. 17).
+// Deleting an account only soft deletes it; once the cooling-off period has passed, the
+// erasure job erases the deleted user:
+//
+//   - the user row is pseudonymised and its data key destroyed, so ciphertexts of the row
+//     left in backups can no longer be decrypted (crypto-shredding)
+//   - sessions, pending phone verifications, export archives, outbox messages and webhook
+//     deliveries are deleted, and KYC documents, applicant data and screening subjects cleared
+//   - the PII in the audit history, archived months included, is replaced with a stable
+//     pseudonym (see Subject), keeping every entry, its type, category, user ID and timestamp
+//     so statistics still add up
+//
+// Erasure is deferred while a legal hold names the user or covers their audit history, and
+// while KYC and screening records must be kept for the AML retention period.
+package erasure
+
+import (
+	"context"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/google/uuid"
+)
+
+// Status is where a user's erasure is
+type Status string
+
+const (
+	// StatusDeferred erasures wait until DeferredUntil
+	StatusDeferred Status = "deferred"
+	// StatusErased users have been erased
+	StatusErased Status = "erased"
+)
+
+// DeferralReason is why an erasure waits
+type DeferralReason string
+
+const (
+	// ReasonLegalHold: an active legal hold names the user or covers their audit history
+	ReasonLegalHold DeferralReason = "legal_hold"
+	// ReasonRetention: the user's KYC and screening records are within the AML retention period
+	ReasonRetention DeferralReason = "retention"
+)
+
+// Record is the erasure ledger entry of a user
+type Record struct {
+	UserID uuid.UUID `json:"user_id"`
+	Status Status    `json:"status"`
+
+	// Set while deferred
+	DeferralReason *DeferralReason `json:"deferral_reason,omitempty"`
+	DeferredUntil  *time.Time      `json:"deferred_until,omitempty"`
+
+	// Set once erased
+	Pseudonym          *string    `json:"pseudonym,omitempty"`
+	AuditLogsRewritten int64      `json:"audit_logs_rewritten"`
+	ErasedAt           *time.Time `json:"erased_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Candidate is a deleted user whose erasure is due
+type Candidate struct {
+	UserID    uuid.UUID
+	DeletedAt time.Time
+}
+
+// Repository persists erasures and erases users
+type Repository interface {
+	// ListDue retrieves up to limit users deleted at or before deletedBefore that are neither
+	// erased nor deferred past now, oldest deletion first
+	ListDue(ctx context.Context, deletedBefore, now time.Time, limit int32) ([]Candidate, error)
+
+	// Get retrieves a user's erasure record. Returns ErrNotFound if there is none.
+	Get(ctx context.Context, userID uuid.UUID) (*Record, error)
+
+	// Defer records that a user's erasure waits until until.
+	// Returns ErrAlreadyErased if the user has been erased.
+	Defer(ctx context.Context, userID uuid.UUID, reason DeferralReason, until time.Time) (*Record, error)
+
+	// HasRetainedRecords reports whether a user has KYC or screening records subject to AML retention
+	HasRetainedRecords(ctx context.Context, userID uuid.UUID) (bool, error)
+
+	// IsHistoryOnHold reports whether an active legal hold covers an audit entry about the
+	// subject: one on every user or on the user the entry is filed under, whose category and
+	// date range match the entry. With archivedSince, such holds whose date range overlaps a
+	// month archived from archivedSince on count as well, since archived entries are not matched
+	// one by one.
+	IsHistoryOnHold(ctx context.Context, subject *Subject, archivedSince *time.Time) (bool, error)
+
+	// ObjectKeys lists the object store keys of a user's KYC documents and export archives
+	ObjectKeys(ctx context.Context, userID uuid.UUID) ([]string, error)
+
+	// Erase erases the subject and enqueues event (user.erased) in the outbox in one
+	// transaction. The objects listed by ObjectKeys must be deleted first. Returns
+	// ErrNotDeleted if the user does not exist or is not deleted, and ErrAlreadyErased if
+	// they have been erased.
+	Erase(ctx context.Context, subject *Subject, at time.Time, event common.EventEnvelope) (*Record, error)
+}
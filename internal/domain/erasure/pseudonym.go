@@ -0,0 +1,255 @@
+package erasure
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// pseudonymDomain separates pseudonym hashes from other hashes of user IDs
+const pseudonymDomain = "pandora-erasure/v1:"
+
+// PseudonymEmailDomain is the domain of the email an erased user row keeps. The .invalid
+// top-level domain is reserved, so the address can never receive mail or be registered.
+const PseudonymEmailDomain = "erased.invalid"
+
+// Pseudonym returns the stable pseudonym of a user. It is derived from the user ID only, so
+// every audit entry about the user gets the same pseudonym and entries stay linkable to
+// each other, but not to the person.
+func Pseudonym(userID uuid.UUID) string {
+	sum := sha256.Sum256([]byte(pseudonymDomain + userID.String()))
+	return "erased-" + hex.EncodeToString(sum[:])[:16]
+}
+
+// PseudonymEmail returns the email an erased user row keeps in place of theirs
+func PseudonymEmail(userID uuid.UUID) string {
+	return Pseudonym(userID) + "@" + PseudonymEmailDomain
+}
+
+// piiKeys are the JSON keys whose values are the acting user's PII in audit metadata and
+// states; their values are replaced with the pseudonym whatever they hold
+var piiKeys = map[string]bool{
+	"email":               true,
+	"first_name":          true,
+	"last_name":           true,
+	"full_name":           true,
+	"phone":               true,
+	"date_of_birth":       true,
+	"address_line1":       true,
+	"address_line2":       true,
+	"address_city":        true,
+	"address_postal_code": true,
+	"address_region":      true,
+	"address_country":     true,
+	"nationality":         true,
+	"tax_residency":       true,
+}
+
+// ipKeys are the JSON keys whose values are the acting user's IP address; their values are truncated
+var ipKeys = map[string]bool{
+	"ip_address": true,
+	"ip":         true,
+	"client_ip":  true,
+}
+
+// Subject is a user being erased and the PII to remove from their audit history
+type Subject struct {
+	UserID    uuid.UUID
+	Pseudonym string
+	Email     string
+
+	// values are replaced when a string equals one of them, ignoring case
+	values []string
+	// embedded matches occurrences inside longer strings (email and phone), ignoring case
+	embedded *regexp.Regexp
+}
+
+// NewSubject builds the subject of erasing u from their decrypted profile
+func NewSubject(u *user.User) *Subject {
+	s := &Subject{
+		UserID:    u.ID,
+		Pseudonym: Pseudonym(u.ID),
+		Email:     u.Email,
+	}
+	fullName := strings.TrimSpace(u.FirstName + " " + u.LastName)
+	for _, v := range []string{u.Email, u.FirstName, u.LastName, fullName, u.Phone, u.DateOfBirth, u.Address.Line1, u.Address.Line2} {
+		if v = strings.TrimSpace(v); v != "" {
+			s.values = append(s.values, v)
+		}
+	}
+	var patterns []string
+	for _, v := range []string{u.Email, u.Phone} {
+		if v != "" {
+			patterns = append(patterns, regexp.QuoteMeta(v))
+		}
+	}
+	if len(patterns) > 0 {
+		s.embedded = regexp.MustCompile("(?i)" + strings.Join(patterns, "|"))
+	}
+	return s
+}
+
+// Identifiers returns the actor identifiers audit entries of the subject's actions may carry
+func (s *Subject) Identifiers() []string {
+	if s.Email == "" {
+		return []string{}
+	}
+	if lower := strings.ToLower(s.Email); lower != s.Email {
+		return []string{s.Email, lower}
+	}
+	return []string{s.Email}
+}
+
+// IsAbout reports whether an audit entry is about the subject: an entry of their own actions,
+// one whose resource is the user, or one whose actor identifier is one of their identifiers.
+// These are the entries erasure rewrites, in the database and in archived months alike.
+func (s *Subject) IsAbout(log *audit.Log) bool {
+	if log.UserID != nil && *log.UserID == s.UserID {
+		return true
+	}
+	if log.ResourceType != nil && *log.ResourceType == "user" && log.ResourceID != nil && *log.ResourceID == s.UserID.String() {
+		return true
+	}
+	if log.ActorIdentifier != nil {
+		for _, identifier := range s.Identifiers() {
+			if *log.ActorIdentifier == identifier {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RewriteLog replaces the subject's PII in an audit entry with their pseudonym and reports
+// whether anything changed. The entry's ID, type, category, severity, user ID, resource and
+// timestamps are kept.
+//
+// In entries of the subject's own actions, values under PII keys (email, names, phone,
+// address...) are replaced whatever they hold and IP addresses are truncated to their /24
+// (IPv4) or /48 (IPv6) network. In other entries, such as an admin acting on the subject,
+// only values that are the subject's PII are replaced, so the other actor's data is kept.
+func (s *Subject) RewriteLog(log *audit.Log) bool {
+	acting := s.isActor(log)
+	changed := false
+
+	if log.ActorIdentifier != nil {
+		if v := s.rewriteString(*log.ActorIdentifier); v != *log.ActorIdentifier {
+			log.ActorIdentifier = &v
+			changed = true
+		}
+	}
+	if acting && log.IPAddress != nil {
+		if v := TruncateIP(*log.IPAddress); v != *log.IPAddress {
+			log.IPAddress = &v
+			changed = true
+		}
+	}
+	for _, m := range []map[string]interface{}{log.Metadata, log.PreviousState, log.NewState} {
+		if s.rewriteMap(m, acting) {
+			changed = true
+		}
+	}
+	if log.FailureReason != nil {
+		if v := s.rewriteString(*log.FailureReason); v != *log.FailureReason {
+			log.FailureReason = &v
+			changed = true
+		}
+	}
+	return changed
+}
+
+// isActor reports whether log records an action of the subject
+func (s *Subject) isActor(log *audit.Log) bool {
+	if log.ActorType != audit.ActorUser {
+		return false
+	}
+	if log.UserID != nil && *log.UserID == s.UserID {
+		return true
+	}
+	return log.ActorIdentifier != nil && s.Email != "" && strings.EqualFold(*log.ActorIdentifier, s.Email)
+}
+
+// rewriteMap rewrites the values of m in place and reports whether any changed
+func (s *Subject) rewriteMap(m map[string]interface{}, acting bool) bool {
+	changed := false
+	for k, v := range m {
+		nv, c := s.rewriteValue(k, v, acting)
+		if c {
+			m[k] = nv
+			changed = true
+		}
+	}
+	return changed
+}
+
+// rewriteValue rewrites the JSON value v found under key and reports whether it changed
+func (s *Subject) rewriteValue(key string, v interface{}, acting bool) (interface{}, bool) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return val, s.rewriteMap(val, acting)
+	case []interface{}:
+		changed := false
+		for i, item := range val {
+			if nv, c := s.rewriteValue(key, item, acting); c {
+				val[i] = nv
+				changed = true
+			}
+		}
+		return val, changed
+	case string:
+		lower := strings.ToLower(key)
+		switch {
+		case acting && piiKeys[lower]:
+			if val == "" || val == s.Pseudonym {
+				return val, false
+			}
+			return s.Pseudonym, true
+		case acting && ipKeys[lower]:
+			nv := TruncateIP(val)
+			return nv, nv != val
+		}
+		nv := s.rewriteString(val)
+		return nv, nv != val
+	}
+	return v, false
+}
+
+// rewriteString replaces the subject's PII in a string: the whole string if it is one of
+// their values, and their email and phone wherever they occur
+func (s *Subject) rewriteString(v string) string {
+	trimmed := strings.TrimSpace(v)
+	for _, pii := range s.values {
+		if strings.EqualFold(trimmed, pii) {
+			return s.Pseudonym
+		}
+	}
+	if s.embedded != nil {
+		return s.embedded.ReplaceAllLiteralString(v, s.Pseudonym)
+	}
+	return v
+}
+
+// TruncateIP keeps the /24 network of an IPv4 address and the /48 network of an IPv6
+// address, enough for coarse location analytics but not to single out a subscriber.
+// Values that are not IP addresses are returned unchanged.
+func TruncateIP(v string) string {
+	addr, err := netip.ParseAddr(v)
+	if err != nil {
+		return v
+	}
+	bits := 48
+	if addr.Unmap().Is4() {
+		addr, bits = addr.Unmap(), 24
+	}
+	prefix, err := addr.WithZone("").Prefix(bits)
+	if err != nil {
+		return v
+	}
+	return prefix.Addr().String()
+}
@@ -0,0 +1,138 @@
+package erasure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string { return &s }
+
+func testSubject() *Subject {
+	return NewSubject(&user.User{
+		ID:          uuid.MustParse("7b1e5c3a-2d4f-4e8a-9c6b-1a2b3c4d5e6f"),
+		Email:       "Alice@Example.com",
+		FirstName:   "Alice",
+		LastName:    "Smith",
+		Phone:       "+40712345678",
+		DateOfBirth: "1990-05-17",
+		Address:     user.Address{Line1: "1 Main Street", City: "Bucharest", Country: "RO"},
+	})
+}
+
+func TestPseudonym(t *testing.T) {
+	id := uuid.New()
+	assert.Equal(t, Pseudonym(id), Pseudonym(id), "stable")
+	assert.NotEqual(t, Pseudonym(id), Pseudonym(uuid.New()))
+	assert.True(t, strings.HasPrefix(Pseudonym(id), "erased-"))
+	assert.NotContains(t, Pseudonym(id), id.String())
+	assert.Equal(t, Pseudonym(id)+"@erased.invalid", PseudonymEmail(id))
+}
+
+func TestSubject_Identifiers(t *testing.T) {
+	assert.Equal(t, []string{"Alice@Example.com", "alice@example.com"}, testSubject().Identifiers())
+	assert.Equal(t, []string{}, NewSubject(&user.User{ID: uuid.New()}).Identifiers())
+}
+
+func TestSubject_IsAbout(t *testing.T) {
+	s := testSubject()
+	other := uuid.New()
+	userResource := "user"
+
+	tests := []struct {
+		name string
+		log  *audit.Log
+		want bool
+	}{
+		{"own action", &audit.Log{UserID: &s.UserID}, true},
+		{"user as resource", &audit.Log{UserID: &other, ResourceType: &userResource, ResourceID: strPtr(s.UserID.String())}, true},
+		{"email as actor", &audit.Log{ActorIdentifier: strPtr("alice@example.com")}, true},
+		{"another user as resource", &audit.Log{ResourceType: &userResource, ResourceID: strPtr(other.String())}, false},
+		{"other resource with the same ID", &audit.Log{ResourceType: strPtr("kyc_case"), ResourceID: strPtr(s.UserID.String())}, false},
+		{"another actor", &audit.Log{UserID: &other, ActorIdentifier: strPtr("admin@pandora.test")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.IsAbout(tt.log))
+		})
+	}
+}
+
+func TestSubject_RewriteLog(t *testing.T) {
+	s := testSubject()
+	p := s.Pseudonym
+
+	t.Run("own action", func(t *testing.T) {
+		log := &audit.Log{
+			EventType:       "user.profile.updated",
+			UserID:          &s.UserID,
+			ActorType:       audit.ActorUser,
+			ActorIdentifier: strPtr("alice@example.com"),
+			IPAddress:       strPtr("203.0.113.77"),
+			UserAgent:       strPtr("Mozilla/5.0"),
+			Metadata: map[string]interface{}{
+				"ip":     "2001:db8:1234:5678::1",
+				"fields": []interface{}{"phone", "address_city"},
+				"note":   "contact alice@example.com or +40712345678",
+				"count":  float64(2),
+			},
+			PreviousState: map[string]interface{}{"first_name": "Alicia", "address_city": "Cluj"},
+			NewState:      map[string]interface{}{"first_name": "Alice", "address": map[string]interface{}{"address_city": "Bucharest"}},
+			FailureReason: strPtr("duplicate email Alice@Example.com"),
+		}
+
+		assert.True(t, s.RewriteLog(log))
+		assert.Equal(t, p, *log.ActorIdentifier)
+		assert.Equal(t, "203.0.113.0", *log.IPAddress)
+		assert.Equal(t, "Mozilla/5.0", *log.UserAgent)
+		assert.Equal(t, "2001:db8:1234::", log.Metadata["ip"])
+		assert.Equal(t, []interface{}{"phone", "address_city"}, log.Metadata["fields"], "field names are kept")
+		assert.Equal(t, "contact "+p+" or "+p, log.Metadata["note"])
+		assert.Equal(t, float64(2), log.Metadata["count"])
+		assert.Equal(t, map[string]interface{}{"first_name": p, "address_city": p}, log.PreviousState)
+		assert.Equal(t, map[string]interface{}{"first_name": p, "address": map[string]interface{}{"address_city": p}}, log.NewState)
+		assert.Equal(t, "duplicate email "+p, *log.FailureReason)
+
+		assert.False(t, s.RewriteLog(log), "rewriting is idempotent")
+	})
+
+	t.Run("admin acting on the subject", func(t *testing.T) {
+		adminID := uuid.New()
+		log := &audit.Log{
+			EventType:       "admin.user.role_changed",
+			UserID:          &adminID,
+			ActorType:       audit.ActorAdmin,
+			ActorIdentifier: strPtr("admin@pandora.exchange"),
+			IPAddress:       strPtr("198.51.100.7"),
+			Metadata:        map[string]interface{}{"email": "admin@pandora.exchange", "target_email": "alice@example.com", "target_name": "Alice Smith"},
+		}
+
+		assert.True(t, s.RewriteLog(log))
+		assert.Equal(t, "admin@pandora.exchange", *log.ActorIdentifier)
+		assert.Equal(t, "198.51.100.7", *log.IPAddress)
+		assert.Equal(t, map[string]interface{}{"email": "admin@pandora.exchange", "target_email": p, "target_name": p}, log.Metadata)
+	})
+
+	t.Run("nothing to rewrite", func(t *testing.T) {
+		log := &audit.Log{ActorType: audit.ActorSystem, Metadata: map[string]interface{}{"reason": "expired"}}
+		assert.False(t, s.RewriteLog(log))
+	})
+}
+
+func TestTruncateIP(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"192.0.2.200", "192.0.2.0"},
+		{"::ffff:192.0.2.200", "192.0.2.0"},
+		{"2001:db8:abcd:12::1", "2001:db8:abcd::"},
+		{"not an ip", "not an ip"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, TruncateIP(tt.in), tt.in)
+	}
+}
@@ -0,0 +1,105 @@
+package kyc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// MinimumAge is the youngest applicant age accepted, in years
+	MinimumAge = 18
+	// maxFieldLength bounds each free-text applicant field
+	maxFieldLength = 200
+	// dateOfBirthLayout is the format of Applicant.DateOfBirth
+	dateOfBirthLayout = "2006-01-02"
+)
+
+// countryCodeRe matches ISO 3166-1 alpha-2 country codes after normalization
+var countryCodeRe = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// Applicant is the identity data a user submits for review. A draft may be incomplete;
+// submission requires every field except AddressLine2.
+type Applicant struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	// DateOfBirth is formatted YYYY-MM-DD
+	DateOfBirth string `json:"date_of_birth"`
+	// Nationality and CountryOfResidence are ISO 3166-1 alpha-2 codes
+	Nationality        string `json:"nationality"`
+	CountryOfResidence string `json:"country_of_residence"`
+	AddressLine1       string `json:"address_line1"`
+	AddressLine2       string `json:"address_line2,omitempty"`
+	City               string `json:"city"`
+	PostalCode         string `json:"postal_code"`
+}
+
+// Normalize trims whitespace and upper-cases country codes
+func (a *Applicant) Normalize() {
+	for _, field := range []*string{&a.FirstName, &a.LastName, &a.DateOfBirth, &a.AddressLine1, &a.AddressLine2, &a.City, &a.PostalCode} {
+		*field = strings.TrimSpace(*field)
+	}
+	a.Nationality = strings.ToUpper(strings.TrimSpace(a.Nationality))
+	a.CountryOfResidence = strings.ToUpper(strings.TrimSpace(a.CountryOfResidence))
+}
+
+// ValidateDraft checks the fields that are set; missing fields are allowed in a draft
+func (a *Applicant) ValidateDraft() error {
+	for name, value := range a.fields() {
+		if len(value) > maxFieldLength {
+			return fmt.Errorf("%w: %s exceeds %d characters", ErrInvalidApplicant, name, maxFieldLength)
+		}
+	}
+	if a.DateOfBirth != "" {
+		if _, err := time.Parse(dateOfBirthLayout, a.DateOfBirth); err != nil {
+			return fmt.Errorf("%w: date_of_birth must be formatted YYYY-MM-DD", ErrInvalidApplicant)
+		}
+	}
+	if a.Nationality != "" && !countryCodeRe.MatchString(a.Nationality) {
+		return fmt.Errorf("%w: nationality must be an ISO 3166-1 alpha-2 code", ErrInvalidApplicant)
+	}
+	if a.CountryOfResidence != "" && !countryCodeRe.MatchString(a.CountryOfResidence) {
+		return fmt.Errorf("%w: country_of_residence must be an ISO 3166-1 alpha-2 code", ErrInvalidApplicant)
+	}
+	return nil
+}
+
+// ValidateComplete checks the applicant can be submitted: every required field is set and
+// the applicant is at least MinimumAge years old at now
+func (a *Applicant) ValidateComplete(now time.Time) error {
+	if err := a.ValidateDraft(); err != nil {
+		return err
+	}
+	for _, field := range []struct{ name, value string }{
+		{"first_name", a.FirstName},
+		{"last_name", a.LastName},
+		{"date_of_birth", a.DateOfBirth},
+		{"nationality", a.Nationality},
+		{"country_of_residence", a.CountryOfResidence},
+		{"address_line1", a.AddressLine1},
+		{"city", a.City},
+		{"postal_code", a.PostalCode},
+	} {
+		if field.value == "" {
+			return fmt.Errorf("%w: %s is required", ErrInvalidApplicant, field.name)
+		}
+	}
+
+	born, _ := time.Parse(dateOfBirthLayout, a.DateOfBirth)
+	if born.AddDate(MinimumAge, 0, 0).After(now) {
+		return fmt.Errorf("%w: applicant must be at least %d years old", ErrInvalidApplicant, MinimumAge)
+	}
+	return nil
+}
+
+func (a *Applicant) fields() map[string]string {
+	return map[string]string{
+		"first_name":    a.FirstName,
+		"last_name":     a.LastName,
+		"address_line1": a.AddressLine1,
+		"address_line2": a.AddressLine2,
+		"city":          a.City,
+		"postal_code":   a.PostalCode,
+	}
+}
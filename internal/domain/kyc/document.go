@@ -0,0 +1,63 @@
+package kyc
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DocumentType is the kind of document an applicant uploads
+type DocumentType string
+
+const (
+	DocumentPassport       DocumentType = "passport"
+	DocumentNationalID     DocumentType = "national_id"
+	DocumentDriversLicense DocumentType = "drivers_license"
+	DocumentProofOfAddress DocumentType = "proof_of_address"
+	DocumentSelfie         DocumentType = "selfie"
+)
+
+// IsValid reports whether t is a known document type
+func (t DocumentType) IsValid() bool {
+	switch t {
+	case DocumentPassport, DocumentNationalID, DocumentDriversLicense, DocumentProofOfAddress, DocumentSelfie:
+		return true
+	}
+	return false
+}
+
+// IsIdentity reports whether the document proves the applicant's identity; a case needs one
+// to be submitted
+func (t DocumentType) IsIdentity() bool {
+	return t == DocumentPassport || t == DocumentNationalID || t == DocumentDriversLicense
+}
+
+// AllowedContentTypes are the sniffed content types accepted for uploads
+var AllowedContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"application/pdf": true,
+}
+
+// Document is a file uploaded to a case. The content lives in the object store under
+// StorageKey; SHA256 lets reviewers and audits verify it was not altered.
+type Document struct {
+	ID          uuid.UUID    `json:"id"`
+	CaseID      uuid.UUID    `json:"case_id"`
+	Type        DocumentType `json:"type"`
+	FileName    string       `json:"file_name"`
+	ContentType string       `json:"content_type"`
+	SizeBytes   int64        `json:"size_bytes"`
+	SHA256      string       `json:"sha256"`
+	StorageKey  string       `json:"-"`
+	UploadedAt  time.Time    `json:"uploaded_at"`
+}
+
+func hasIdentityDocument(documents []*Document) bool {
+	for _, d := range documents {
+		if d.Type.IsIdentity() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package kyc
+
+import "errors"
+
+// Domain-level errors for KYC cases.
+var (
+	// ErrCaseNotFound is returned when a KYC case does not exist or belongs to another user.
+	ErrCaseNotFound = errors.New("kyc case not found")
+
+	// ErrCaseAlreadyOpen is returned when opening a case while the user has one in progress.
+	ErrCaseAlreadyOpen = errors.New("kyc case already open")
+
+	// ErrAlreadyVerified is returned when a verified user opens a new case.
+	ErrAlreadyVerified = errors.New("user is already kyc verified")
+
+	// ErrInvalidTransition is returned when an action is not allowed in the case's status.
+	ErrInvalidTransition = errors.New("invalid kyc case transition")
+
+	// ErrCaseNotEditable is returned when changing applicant data or documents of a case
+	// that is not in draft or waiting for more information.
+	ErrCaseNotEditable = errors.New("kyc case cannot be edited")
+
+	// ErrCaseConflict is returned when a case was changed concurrently.
+	ErrCaseConflict = errors.New("kyc case was modified concurrently")
+
+	// ErrInvalidApplicant is returned when applicant data fails validation.
+	ErrInvalidApplicant = errors.New("invalid kyc applicant data")
+
+	// ErrMissingDocuments is returned when submitting a case without an identity document.
+	ErrMissingDocuments = errors.New("kyc case is missing required documents")
+
+	// ErrInvalidDocument is returned when an uploaded document is not accepted.
+	ErrInvalidDocument = errors.New("invalid kyc document")
+
+	// ErrDocumentTooLarge is returned when an uploaded document exceeds the size limit.
+	ErrDocumentTooLarge = errors.New("kyc document too large")
+
+	// ErrDocumentNotFound is returned when a document does not exist for the case.
+	ErrDocumentNotFound = errors.New("kyc document not found")
+
+	// ErrInvalidDecision is returned when a review decision is malformed, such as a
+	// rejection without a known reason code.
+	ErrInvalidDecision = errors.New("invalid kyc review decision")
+
+	// ErrInvalidReviewer is returned when assigning a case to a user who is not an admin.
+	ErrInvalidReviewer = errors.New("kyc reviewer must be an admin")
+
+	// ErrNotAssignedReviewer is returned when an admin other than the assigned reviewer
+	// makes a decision that only the reviewer may make.
+	ErrNotAssignedReviewer = errors.New("only the assigned reviewer can decide this kyc case")
+
+	// ErrSelfReview is returned when an admin assigns, reviews or approves their own case,
+	// or assigns a case to its own subject.
+	ErrSelfReview = errors.New("kyc case cannot be reviewed by its own subject")
+
+	// ErrFourEyesRequired is returned when the admin who gave the first approval of a
+	// high-risk case also tries to give the second.
+	ErrFourEyesRequired = errors.New("high-risk kyc case needs approval by a second admin")
+
+	// ErrInvalidTierPolicy is returned when the configured tier limits are malformed.
+	ErrInvalidTierPolicy = errors.New("invalid kyc tier policy")
+
+	// ErrInvalidTier is returned when a tier change names an unknown tier or has no reason.
+	ErrInvalidTier = errors.New("invalid kyc tier change")
+
+	// ErrTierNotFound is returned when a user was never assigned a tier.
+	ErrTierNotFound = errors.New("kyc tier not found")
+
+	// ErrTierRequiresVerification is returned when granting a tier above 0 to a user who
+	// is not KYC verified.
+	ErrTierRequiresVerification = errors.New("kyc tier above 0 requires a verified user")
+
+	// ErrProviderNotConfigured is returned when external verification is requested but no
+	// provider, or a different one, is configured.
+	ErrProviderNotConfigured = errors.New("kyc provider not configured")
+
+	// ErrProviderUnavailable is returned when the external provider fails or times out.
+	ErrProviderUnavailable = errors.New("kyc provider unavailable")
+
+	// ErrInvalidProviderWebhook is returned when a provider webhook has a bad signature or
+	// cannot be decoded.
+	ErrInvalidProviderWebhook = errors.New("invalid kyc provider webhook")
+
+	// ErrScreeningHitsOpen is returned when approving a case of a user whose sanctions or
+	// PEP screening matches are not cleared.
+	ErrScreeningHitsOpen = errors.New("kyc case has uncleared screening matches")
+)
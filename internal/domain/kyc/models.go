@@ -0,0 +1,538 @@
+// Package kyc contains the KYC case domain model: an applicant's submission with identity
+// data and documents, and its review by admins through a strict state machine:
+//
+//	draft → submitted → in_review → approved
+//	                        │     → rejected
+//	                        └───→ needs_more_info → submitted
+//
+// A case can instead be verified by an external Provider: starting verification submits it,
+// and the provider's decision moves a submitted or in-review case directly (see
+// Case.ApplyProviderDecision).
+//
+// A user's kyc_status is derived from their latest case (see Status.UserKYCStatus).
+// Storage of cases and of document content lives behind the Repository and
+// common.ObjectStore ports.
+package kyc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// Status is where a case is in the review workflow
+type Status string
+
+const (
+	// StatusDraft cases are being filled in by the applicant
+	StatusDraft Status = "draft"
+	// StatusSubmitted cases wait for a reviewer to pick them up
+	StatusSubmitted Status = "submitted"
+	// StatusInReview cases are assigned to a reviewer
+	StatusInReview Status = "in_review"
+	// StatusApproved cases verified the applicant; final
+	StatusApproved Status = "approved"
+	// StatusRejected cases were turned down with a reason code; final
+	StatusRejected Status = "rejected"
+	// StatusNeedsMoreInfo cases went back to the applicant for corrections
+	StatusNeedsMoreInfo Status = "needs_more_info"
+)
+
+// transitions lists the statuses each status can move to
+var transitions = map[Status][]Status{
+	StatusDraft:         {StatusSubmitted},
+	StatusSubmitted:     {StatusInReview},
+	StatusInReview:      {StatusApproved, StatusRejected, StatusNeedsMoreInfo},
+	StatusNeedsMoreInfo: {StatusSubmitted},
+}
+
+// IsValid reports whether s is a known case status
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusDraft, StatusSubmitted, StatusInReview, StatusApproved, StatusRejected, StatusNeedsMoreInfo:
+		return true
+	}
+	return false
+}
+
+// IsFinal reports whether the case is decided; a user can open a new case after a rejection
+func (s Status) IsFinal() bool {
+	return s == StatusApproved || s == StatusRejected
+}
+
+// IsEditable reports whether the applicant can change data and documents
+func (s Status) IsEditable() bool {
+	return s == StatusDraft || s == StatusNeedsMoreInfo
+}
+
+// CanTransitionTo reports whether the state machine allows moving from s to next
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// UserKYCStatus is the users.kyc_status of a user whose latest case has status s
+func (s Status) UserKYCStatus() user.KYCStatus {
+	switch s {
+	case StatusApproved:
+		return user.KYCStatusVerified
+	case StatusRejected:
+		return user.KYCStatusRejected
+	default:
+		return user.KYCStatusPending
+	}
+}
+
+// RiskLevel is the reviewer's assessment of a case; high-risk cases need two approvals
+type RiskLevel string
+
+const (
+	RiskLow    RiskLevel = "low"
+	RiskMedium RiskLevel = "medium"
+	RiskHigh   RiskLevel = "high"
+)
+
+// IsValid reports whether r is a known risk level
+func (r RiskLevel) IsValid() bool {
+	switch r {
+	case RiskLow, RiskMedium, RiskHigh:
+		return true
+	}
+	return false
+}
+
+// RequiresFourEyes reports whether approval needs two different admins
+func (r RiskLevel) RequiresFourEyes() bool {
+	return r == RiskHigh
+}
+
+// RejectionReason is the code recorded when a case is rejected
+type RejectionReason string
+
+const (
+	ReasonDocumentUnreadable      RejectionReason = "document_unreadable"
+	ReasonDocumentExpired         RejectionReason = "document_expired"
+	ReasonDocumentMismatch        RejectionReason = "document_mismatch"
+	ReasonUnderage                RejectionReason = "underage"
+	ReasonUnsupportedJurisdiction RejectionReason = "unsupported_jurisdiction"
+	ReasonSanctionsMatch          RejectionReason = "sanctions_match"
+	ReasonSuspectedFraud          RejectionReason = "suspected_fraud"
+	ReasonDuplicateAccount        RejectionReason = "duplicate_account"
+	// ReasonOther requires a note explaining the rejection
+	ReasonOther RejectionReason = "other"
+)
+
+// IsValid reports whether r is a known rejection reason
+func (r RejectionReason) IsValid() bool {
+	switch r {
+	case ReasonDocumentUnreadable, ReasonDocumentExpired, ReasonDocumentMismatch, ReasonUnderage,
+		ReasonUnsupportedJurisdiction, ReasonSanctionsMatch, ReasonSuspectedFraud, ReasonDuplicateAccount, ReasonOther:
+		return true
+	}
+	return false
+}
+
+// Case is one KYC application of a user and its review
+type Case struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Status    Status    `json:"status"`
+	RiskLevel RiskLevel `json:"risk_level"`
+	Applicant Applicant `json:"applicant"`
+
+	// Review
+	ReviewerID      *uuid.UUID       `json:"reviewer_id,omitempty"`
+	FirstApproverID *uuid.UUID       `json:"first_approver_id,omitempty"`
+	FirstApprovedAt *time.Time       `json:"first_approved_at,omitempty"`
+	DecidedBy       *uuid.UUID       `json:"decided_by,omitempty"`
+	DecidedAt       *time.Time       `json:"decided_at,omitempty"`
+	RejectionReason *RejectionReason `json:"rejection_reason,omitempty"`
+	// ReviewNote is shown to the applicant when more information is needed or the case is rejected
+	ReviewNote *string `json:"review_note,omitempty"`
+
+	// External verification; nil for cases reviewed by admins only
+	Provider            *string `json:"provider,omitempty"`
+	ProviderApplicantID *string `json:"provider_applicant_id,omitempty"`
+
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	// Version increments on every update; updates of a stale copy fail with ErrCaseConflict
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Documents are loaded on request; nil when not loaded
+	Documents []*Document `json:"documents,omitempty"`
+}
+
+// Action names an entry of a case's history
+type Action string
+
+const (
+	ActionCreate        Action = "create"
+	ActionSubmit        Action = "submit"
+	ActionAssign        Action = "assign"
+	ActionSetRiskLevel  Action = "set_risk_level"
+	ActionFirstApproval Action = "first_approval"
+	ActionApprove       Action = "approve"
+	ActionReject        Action = "reject"
+	ActionRequestInfo   Action = "request_info"
+	// ActionProviderStart and ActionProviderDecision are recorded with ProviderActorID as
+	// the actor of decisions
+	ActionProviderStart    Action = "provider_start"
+	ActionProviderDecision Action = "provider_decision"
+)
+
+// Transition is an entry of a case's history: who did what, and why.
+// Actions that do not change the status, such as a first approval, have FromStatus == ToStatus.
+type Transition struct {
+	ID         uuid.UUID        `json:"id"`
+	CaseID     uuid.UUID        `json:"case_id"`
+	Action     Action           `json:"action"`
+	FromStatus Status           `json:"from_status"`
+	ToStatus   Status           `json:"to_status"`
+	ActorID    uuid.UUID        `json:"actor_id"`
+	ReasonCode *RejectionReason `json:"reason_code,omitempty"`
+	Note       *string          `json:"note,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// NewCase starts a draft case for userID
+func NewCase(userID uuid.UUID, applicant Applicant, now time.Time) (*Case, *Transition, error) {
+	applicant.Normalize()
+	if err := applicant.ValidateDraft(); err != nil {
+		return nil, nil, err
+	}
+	c := &Case{
+		UserID:    userID,
+		Status:    StatusDraft,
+		RiskLevel: RiskLow,
+		Applicant: applicant,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return c, c.record(ActionCreate, StatusDraft, userID, now), nil
+}
+
+// UpdateApplicant replaces the applicant data of an editable case
+func (c *Case) UpdateApplicant(applicant Applicant, now time.Time) error {
+	if !c.Status.IsEditable() {
+		return fmt.Errorf("%w: case is %s", ErrCaseNotEditable, c.Status)
+	}
+	applicant.Normalize()
+	if err := applicant.ValidateDraft(); err != nil {
+		return err
+	}
+	c.Applicant = applicant
+	c.UpdatedAt = now
+	return nil
+}
+
+// Submit hands the case to reviewers. The applicant data must be complete and documents
+// must include an identity document.
+func (c *Case) Submit(documents []*Document, now time.Time) (*Transition, error) {
+	if err := c.checkTransition(StatusSubmitted); err != nil {
+		return nil, err
+	}
+	if err := c.Applicant.ValidateComplete(now); err != nil {
+		return nil, err
+	}
+	if !hasIdentityDocument(documents) {
+		return nil, fmt.Errorf("%w: an identity document (passport, national ID or driver's license) is required", ErrMissingDocuments)
+	}
+
+	t := c.move(StatusSubmitted, ActionSubmit, c.UserID, now)
+	c.SubmittedAt = &now
+	c.ReviewNote = nil
+	return t, nil
+}
+
+// Assign makes reviewerID the case's reviewer. A submitted case moves to in_review; a case
+// already in review is reassigned. Neither the reviewer nor the assigning admin may be the
+// case's subject.
+func (c *Case) Assign(reviewerID, actorID uuid.UUID, now time.Time) (*Transition, error) {
+	if reviewerID == c.UserID || actorID == c.UserID {
+		return nil, ErrSelfReview
+	}
+
+	var t *Transition
+	switch c.Status {
+	case StatusInReview:
+		t = c.record(ActionAssign, StatusInReview, actorID, now)
+	default:
+		if err := c.checkTransition(StatusInReview); err != nil {
+			return nil, err
+		}
+		t = c.move(StatusInReview, ActionAssign, actorID, now)
+	}
+	c.ReviewerID = &reviewerID
+	note := "assigned to " + reviewerID.String()
+	t.Note = &note
+	return t, nil
+}
+
+// SetRiskLevel records the reviewer's risk assessment. It cannot change once a first
+// approval was given, so a high-risk case cannot be downgraded to skip the second approval.
+func (c *Case) SetRiskLevel(level RiskLevel, actorID uuid.UUID, now time.Time) (*Transition, error) {
+	if !level.IsValid() {
+		return nil, fmt.Errorf("%w: unknown risk level %q", ErrInvalidDecision, level)
+	}
+	if c.Status != StatusSubmitted && c.Status != StatusInReview {
+		return nil, fmt.Errorf("%w: risk level cannot be set on a %s case", ErrInvalidTransition, c.Status)
+	}
+	if c.FirstApproverID != nil {
+		return nil, fmt.Errorf("%w: risk level cannot change after the first approval", ErrInvalidTransition)
+	}
+
+	t := c.record(ActionSetRiskLevel, c.Status, actorID, now)
+	note := fmt.Sprintf("risk level %s -> %s", c.RiskLevel, level)
+	t.Note = &note
+	c.RiskLevel = level
+	return t, nil
+}
+
+// Approve approves the case. The assigned reviewer approves; a high-risk case then stays in
+// review until a second, different admin approves it too (four-eyes principle). The case's
+// subject can give neither approval.
+func (c *Case) Approve(actorID uuid.UUID, note string, now time.Time) (*Transition, error) {
+	if err := c.checkTransition(StatusApproved); err != nil {
+		return nil, err
+	}
+	if actorID == c.UserID {
+		return nil, ErrSelfReview
+	}
+
+	if c.RiskLevel.RequiresFourEyes() {
+		if c.FirstApproverID == nil {
+			if err := c.checkReviewer(actorID); err != nil {
+				return nil, err
+			}
+			t := c.record(ActionFirstApproval, StatusInReview, actorID, now)
+			t.Note = optionalNote(note)
+			c.FirstApproverID = &actorID
+			c.FirstApprovedAt = &now
+			return t, nil
+		}
+		if *c.FirstApproverID == actorID {
+			return nil, ErrFourEyesRequired
+		}
+	} else if err := c.checkReviewer(actorID); err != nil {
+		return nil, err
+	}
+
+	t := c.move(StatusApproved, ActionApprove, actorID, now)
+	t.Note = optionalNote(note)
+	c.decide(actorID, now)
+	return t, nil
+}
+
+// Reject turns the case down with a reason code; ReasonOther requires a note
+func (c *Case) Reject(actorID uuid.UUID, reason RejectionReason, note string, now time.Time) (*Transition, error) {
+	if !reason.IsValid() {
+		return nil, fmt.Errorf("%w: unknown rejection reason %q", ErrInvalidDecision, reason)
+	}
+	if reason == ReasonOther && note == "" {
+		return nil, fmt.Errorf("%w: a note is required when rejecting for reason %q", ErrInvalidDecision, ReasonOther)
+	}
+	if err := c.checkTransition(StatusRejected); err != nil {
+		return nil, err
+	}
+	if err := c.checkReviewer(actorID); err != nil {
+		return nil, err
+	}
+
+	t := c.move(StatusRejected, ActionReject, actorID, now)
+	t.ReasonCode = &reason
+	t.Note = optionalNote(note)
+	c.RejectionReason = &reason
+	c.ReviewNote = optionalNote(note)
+	c.decide(actorID, now)
+	return t, nil
+}
+
+// RequestMoreInfo returns the case to the applicant; note tells them what to correct.
+// Any first approval is discarded, since the case will change.
+func (c *Case) RequestMoreInfo(actorID uuid.UUID, note string, now time.Time) (*Transition, error) {
+	if note == "" {
+		return nil, fmt.Errorf("%w: a note telling the applicant what is missing is required", ErrInvalidDecision)
+	}
+	if err := c.checkTransition(StatusNeedsMoreInfo); err != nil {
+		return nil, err
+	}
+	if err := c.checkReviewer(actorID); err != nil {
+		return nil, err
+	}
+
+	t := c.move(StatusNeedsMoreInfo, ActionRequestInfo, actorID, now)
+	t.Note = &note
+	c.ReviewNote = &note
+	c.FirstApproverID = nil
+	c.FirstApprovedAt = nil
+	return t, nil
+}
+
+// StartProviderVerification hands the case to an external provider, which collects the
+// documents itself. The applicant data must be complete. Restarting after the provider asked
+// for a resubmission keeps the provider's applicant.
+func (c *Case) StartProviderVerification(provider, applicantID string, now time.Time) (*Transition, error) {
+	if err := c.checkTransition(StatusSubmitted); err != nil {
+		return nil, err
+	}
+	if err := c.Applicant.ValidateComplete(now); err != nil {
+		return nil, err
+	}
+
+	t := c.move(StatusSubmitted, ActionProviderStart, c.UserID, now)
+	note := "verification started with " + provider
+	t.Note = &note
+	c.Provider = &provider
+	c.ProviderApplicantID = &applicantID
+	c.SubmittedAt = &now
+	c.ReviewNote = nil
+	return t, nil
+}
+
+// ApplyProviderDecision maps a provider's decision onto the case. Approval and rejection
+// decide a submitted or in-review case without a reviewer, except that approving a high-risk
+// case is left to admins (four-eyes). A resubmission request returns the case to the
+// applicant; an expired verification leaves it for manual review.
+func (c *Case) ApplyProviderDecision(d *ProviderDecision, now time.Time) (*Transition, error) {
+	if err := c.checkProviderDecision(d); err != nil {
+		return nil, err
+	}
+
+	var t *Transition
+	switch d.Outcome {
+	case ProviderApproved:
+		if c.RiskLevel.RequiresFourEyes() {
+			return c.HoldProviderApproval(d, "approved by provider; high-risk case needs approval by two admins", now)
+		}
+		t = c.move(StatusApproved, ActionProviderDecision, ProviderActorID, now)
+		t.Note = optionalNote(d.Note)
+		c.DecidedAt = &now
+	case ProviderRejected:
+		reason := d.Reason
+		if !reason.IsValid() {
+			reason = ReasonOther
+		}
+		note := d.Note
+		if note == "" && reason == ReasonOther {
+			note = "rejected by " + *c.Provider
+		}
+		t = c.move(StatusRejected, ActionProviderDecision, ProviderActorID, now)
+		t.ReasonCode = &reason
+		t.Note = optionalNote(note)
+		c.RejectionReason = &reason
+		c.ReviewNote = optionalNote(note)
+		c.DecidedAt = &now
+	case ProviderResubmissionRequested:
+		note := d.Note
+		if note == "" {
+			note = "the verification provider asked you to verify again"
+		}
+		t = c.move(StatusNeedsMoreInfo, ActionProviderDecision, ProviderActorID, now)
+		t.Note = &note
+		c.ReviewNote = &note
+		c.FirstApproverID = nil
+		c.FirstApprovedAt = nil
+	case ProviderExpired:
+		t = c.record(ActionProviderDecision, c.Status, ProviderActorID, now)
+		t.Note = optionalNote("verification with provider expired; left for manual review")
+	default:
+		return nil, fmt.Errorf("%w: unknown provider outcome %q", ErrInvalidDecision, d.Outcome)
+	}
+	return t, nil
+}
+
+// HoldProviderApproval records a provider's approval without approving the case, which stays
+// for admins to decide; reason is noted in the history
+func (c *Case) HoldProviderApproval(d *ProviderDecision, reason string, now time.Time) (*Transition, error) {
+	if err := c.checkProviderDecision(d); err != nil {
+		return nil, err
+	}
+	if d.Outcome != ProviderApproved {
+		return nil, fmt.Errorf("%w: only approvals can be held", ErrInvalidDecision)
+	}
+	t := c.record(ActionProviderDecision, c.Status, ProviderActorID, now)
+	t.Note = optionalNote(reason)
+	return t, nil
+}
+
+func (c *Case) checkProviderDecision(d *ProviderDecision) error {
+	if c.ProviderApplicantID == nil || *c.ProviderApplicantID != d.ApplicantID {
+		return fmt.Errorf("%w: decision is for another applicant", ErrInvalidDecision)
+	}
+	if c.Status != StatusSubmitted && c.Status != StatusInReview {
+		return fmt.Errorf("%w: provider decision on a %s case", ErrInvalidTransition, c.Status)
+	}
+	return nil
+}
+
+func (c *Case) checkTransition(next Status) error {
+	if !c.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, c.Status, next)
+	}
+	return nil
+}
+
+func (c *Case) checkReviewer(actorID uuid.UUID) error {
+	if actorID == c.UserID {
+		return ErrSelfReview
+	}
+	if c.ReviewerID == nil || *c.ReviewerID != actorID {
+		return ErrNotAssignedReviewer
+	}
+	return nil
+}
+
+// move changes the status and records the transition
+func (c *Case) move(to Status, action Action, actorID uuid.UUID, now time.Time) *Transition {
+	t := c.record(action, to, actorID, now)
+	c.Status = to
+	return t
+}
+
+// record creates the history entry of an action moving the case to status to
+func (c *Case) record(action Action, to Status, actorID uuid.UUID, now time.Time) *Transition {
+	c.UpdatedAt = now
+	return &Transition{
+		CaseID:     c.ID,
+		Action:     action,
+		FromStatus: c.Status,
+		ToStatus:   to,
+		ActorID:    actorID,
+		CreatedAt:  now,
+	}
+}
+
+func (c *Case) decide(actorID uuid.UUID, now time.Time) {
+	c.DecidedBy = &actorID
+	c.DecidedAt = &now
+}
+
+func optionalNote(note string) *string {
+	if note == "" {
+		return nil
+	}
+	return &note
+}
+
+// ListFilter narrows the review queue; nil fields match every case
+type ListFilter struct {
+	Status     *Status
+	ReviewerID *uuid.UUID
+	RiskLevel  *RiskLevel
+	Limit      int32
+	Offset     int32
+}
+
+// Actor is the admin acting on a case; Email identifies them in the audit log
+type Actor struct {
+	ID    uuid.UUID
+	Email string
+}
@@ -0,0 +1,321 @@
+package kyc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2025, 11, 20, 10, 0, 0, 0, time.UTC)
+
+func completeApplicant() Applicant {
+	return Applicant{
+		FirstName:          "Jane",
+		LastName:           "Doe",
+		DateOfBirth:        "1990-04-12",
+		Nationality:        "de",
+		CountryOfResidence: " DE ",
+		AddressLine1:       "Hauptstrasse 1",
+		City:               "Berlin",
+		PostalCode:         "10115",
+	}
+}
+
+var passport = []*Document{{Type: DocumentPassport}}
+
+// inReview returns a case assigned to reviewer
+func inReview(t *testing.T, reviewer uuid.UUID, risk RiskLevel) *Case {
+	t.Helper()
+	c, _, err := NewCase(uuid.New(), completeApplicant(), now)
+	require.NoError(t, err)
+	_, err = c.Submit(passport, now)
+	require.NoError(t, err)
+	_, err = c.Assign(reviewer, reviewer, now)
+	require.NoError(t, err)
+	_, err = c.SetRiskLevel(risk, reviewer, now)
+	require.NoError(t, err)
+	return c
+}
+
+func TestStatus_Transitions(t *testing.T) {
+	allowed := map[Status][]Status{
+		StatusDraft:         {StatusSubmitted},
+		StatusSubmitted:     {StatusInReview},
+		StatusInReview:      {StatusApproved, StatusRejected, StatusNeedsMoreInfo},
+		StatusNeedsMoreInfo: {StatusSubmitted},
+	}
+	all := []Status{StatusDraft, StatusSubmitted, StatusInReview, StatusApproved, StatusRejected, StatusNeedsMoreInfo}
+	for _, from := range all {
+		for _, to := range all {
+			assert.Equal(t, contains(allowed[from], to), from.CanTransitionTo(to), "%s -> %s", from, to)
+		}
+	}
+
+	assert.Equal(t, user.KYCStatusVerified, StatusApproved.UserKYCStatus())
+	assert.Equal(t, user.KYCStatusRejected, StatusRejected.UserKYCStatus())
+	assert.Equal(t, user.KYCStatusPending, StatusNeedsMoreInfo.UserKYCStatus())
+	assert.False(t, Status("approved_manually").IsValid())
+}
+
+func contains(statuses []Status, s Status) bool {
+	for _, candidate := range statuses {
+		if candidate == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestApplicant_Validate(t *testing.T) {
+	a := completeApplicant()
+	a.Normalize()
+	assert.Equal(t, "DE", a.Nationality)
+	assert.Equal(t, "DE", a.CountryOfResidence)
+	require.NoError(t, a.ValidateComplete(now))
+
+	draft := Applicant{FirstName: "Jane"}
+	assert.NoError(t, draft.ValidateDraft(), "a draft may be incomplete")
+	assert.ErrorIs(t, draft.ValidateComplete(now), ErrInvalidApplicant)
+
+	tests := []struct {
+		name   string
+		mutate func(a *Applicant)
+	}{
+		{"malformed date of birth", func(a *Applicant) { a.DateOfBirth = "12/04/1990" }},
+		{"unknown country code", func(a *Applicant) { a.Nationality = "DEU" }},
+		{"underage", func(a *Applicant) { a.DateOfBirth = "2007-11-21" }},
+		{"missing postal code", func(a *Applicant) { a.PostalCode = "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := completeApplicant()
+			tt.mutate(&a)
+			a.Normalize()
+			assert.ErrorIs(t, a.ValidateComplete(now), ErrInvalidApplicant)
+		})
+	}
+
+	adult := completeApplicant()
+	adult.DateOfBirth = "2007-11-20"
+	adult.Normalize()
+	assert.NoError(t, adult.ValidateComplete(now), "18th birthday today")
+}
+
+func TestCase_Submit(t *testing.T) {
+	c, created, err := NewCase(uuid.New(), completeApplicant(), now)
+	require.NoError(t, err)
+	assert.Equal(t, ActionCreate, created.Action)
+
+	_, err = c.Submit([]*Document{{Type: DocumentSelfie}}, now)
+	assert.ErrorIs(t, err, ErrMissingDocuments)
+
+	tr, err := c.Submit(passport, now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSubmitted, c.Status)
+	assert.Equal(t, StatusDraft, tr.FromStatus)
+	assert.Equal(t, StatusSubmitted, tr.ToStatus)
+	assert.Equal(t, c.UserID, tr.ActorID)
+	require.NotNil(t, c.SubmittedAt)
+
+	assert.ErrorIs(t, c.UpdateApplicant(completeApplicant(), now), ErrCaseNotEditable)
+	_, err = c.Submit(passport, now)
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+}
+
+func TestCase_Approve(t *testing.T) {
+	reviewer, second := uuid.New(), uuid.New()
+
+	t.Run("only the assigned reviewer approves", func(t *testing.T) {
+		c := inReview(t, reviewer, RiskLow)
+		_, err := c.Approve(second, "", now)
+		assert.ErrorIs(t, err, ErrNotAssignedReviewer)
+
+		tr, err := c.Approve(reviewer, "documents match", now)
+		require.NoError(t, err)
+		assert.Equal(t, StatusApproved, c.Status)
+		assert.Equal(t, ActionApprove, tr.Action)
+		assert.Equal(t, &reviewer, c.DecidedBy)
+	})
+
+	t.Run("high risk needs a second admin", func(t *testing.T) {
+		c := inReview(t, reviewer, RiskHigh)
+
+		tr, err := c.Approve(reviewer, "", now)
+		require.NoError(t, err)
+		assert.Equal(t, ActionFirstApproval, tr.Action)
+		assert.Equal(t, StatusInReview, c.Status)
+		assert.Equal(t, &reviewer, c.FirstApproverID)
+
+		_, err = c.Approve(reviewer, "", now)
+		assert.ErrorIs(t, err, ErrFourEyesRequired)
+		_, err = c.SetRiskLevel(RiskLow, reviewer, now)
+		assert.ErrorIs(t, err, ErrInvalidTransition, "risk cannot be lowered after a first approval")
+
+		tr, err = c.Approve(second, "", now)
+		require.NoError(t, err)
+		assert.Equal(t, ActionApprove, tr.Action)
+		assert.Equal(t, StatusApproved, c.Status)
+		assert.Equal(t, &second, c.DecidedBy)
+	})
+
+	t.Run("requesting information discards the first approval", func(t *testing.T) {
+		c := inReview(t, reviewer, RiskHigh)
+		_, err := c.Approve(reviewer, "", now)
+		require.NoError(t, err)
+
+		_, err = c.RequestMoreInfo(reviewer, "", now)
+		assert.ErrorIs(t, err, ErrInvalidDecision, "a note is required")
+		_, err = c.RequestMoreInfo(reviewer, "passport photo is blurred", now)
+		require.NoError(t, err)
+		assert.Equal(t, StatusNeedsMoreInfo, c.Status)
+		assert.Nil(t, c.FirstApproverID)
+		require.NoError(t, c.UpdateApplicant(completeApplicant(), now))
+
+		_, err = c.Submit(passport, now)
+		require.NoError(t, err)
+		assert.Nil(t, c.ReviewNote)
+	})
+
+	t.Run("the subject cannot approve their own case", func(t *testing.T) {
+		c := inReview(t, reviewer, RiskHigh)
+		_, err := c.Approve(c.UserID, "", now)
+		assert.ErrorIs(t, err, ErrSelfReview)
+
+		_, err = c.Approve(reviewer, "", now)
+		require.NoError(t, err)
+		_, err = c.Approve(c.UserID, "", now)
+		assert.ErrorIs(t, err, ErrSelfReview, "nor give the second approval")
+		assert.Equal(t, StatusInReview, c.Status)
+
+		_, err = c.Reject(c.UserID, ReasonDocumentExpired, "", now)
+		assert.ErrorIs(t, err, ErrSelfReview)
+	})
+}
+
+func TestCase_Reject(t *testing.T) {
+	reviewer := uuid.New()
+	c := inReview(t, reviewer, RiskMedium)
+
+	_, err := c.Reject(reviewer, "bad_vibes", "", now)
+	assert.ErrorIs(t, err, ErrInvalidDecision)
+	_, err = c.Reject(reviewer, ReasonOther, "", now)
+	assert.ErrorIs(t, err, ErrInvalidDecision, "other needs a note")
+
+	tr, err := c.Reject(reviewer, ReasonDocumentExpired, "passport expired in 2023", now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusRejected, c.Status)
+	assert.Equal(t, ReasonDocumentExpired, *tr.ReasonCode)
+	assert.Equal(t, ReasonDocumentExpired, *c.RejectionReason)
+	assert.True(t, c.Status.IsFinal())
+
+	_, err = c.Approve(reviewer, "", now)
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+}
+
+func TestCase_Assign(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	c, _, err := NewCase(uuid.New(), completeApplicant(), now)
+	require.NoError(t, err)
+
+	_, err = c.Assign(first, first, now)
+	assert.ErrorIs(t, err, ErrInvalidTransition, "drafts cannot be assigned")
+
+	_, err = c.Submit(passport, now)
+	require.NoError(t, err)
+	_, err = c.Assign(c.UserID, second, now)
+	assert.ErrorIs(t, err, ErrSelfReview, "the subject cannot review their own case")
+	_, err = c.Assign(first, c.UserID, now)
+	assert.ErrorIs(t, err, ErrSelfReview, "the subject cannot assign their own case")
+	assert.Equal(t, StatusSubmitted, c.Status)
+
+	tr, err := c.Assign(first, second, now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusInReview, tr.ToStatus)
+	assert.Equal(t, second, tr.ActorID)
+
+	tr, err = c.Assign(second, second, now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusInReview, tr.FromStatus, "reassignment keeps the status")
+	assert.Equal(t, &second, c.ReviewerID)
+}
+
+func TestCase_ProviderVerification(t *testing.T) {
+	c, _, err := NewCase(uuid.New(), Applicant{FirstName: "Jane"}, now)
+	require.NoError(t, err)
+	_, err = c.StartProviderVerification("simulator", "app-1", now)
+	assert.ErrorIs(t, err, ErrInvalidApplicant)
+
+	c, _, err = NewCase(uuid.New(), completeApplicant(), now)
+	require.NoError(t, err)
+	tr, err := c.StartProviderVerification("simulator", "app-1", now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSubmitted, c.Status, "the provider collects documents itself")
+	assert.Equal(t, ActionProviderStart, tr.Action)
+	assert.Equal(t, "app-1", *c.ProviderApplicantID)
+
+	_, err = c.ApplyProviderDecision(&ProviderDecision{ApplicantID: "app-2", Outcome: ProviderApproved}, now)
+	assert.ErrorIs(t, err, ErrInvalidDecision)
+	_, err = c.ApplyProviderDecision(&ProviderDecision{ApplicantID: "app-1", Outcome: "maybe"}, now)
+	assert.ErrorIs(t, err, ErrInvalidDecision)
+
+	tr, err = c.ApplyProviderDecision(&ProviderDecision{ApplicantID: "app-1", Outcome: ProviderResubmissionRequested}, now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusNeedsMoreInfo, c.Status)
+	assert.Equal(t, ProviderActorID, tr.ActorID)
+	assert.NotNil(t, c.ReviewNote)
+
+	_, err = c.StartProviderVerification("simulator", "app-1", now)
+	require.NoError(t, err)
+	tr, err = c.ApplyProviderDecision(&ProviderDecision{ApplicantID: "app-1", Outcome: ProviderExpired}, now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSubmitted, tr.ToStatus, "expired verifications are left for manual review")
+
+	tr, err = c.ApplyProviderDecision(&ProviderDecision{ApplicantID: "app-1", Outcome: ProviderRejected}, now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusRejected, c.Status)
+	assert.Equal(t, ReasonOther, *tr.ReasonCode, "unmapped rejections use other")
+	assert.Nil(t, c.DecidedBy)
+	assert.NotNil(t, c.DecidedAt)
+
+	_, err = c.ApplyProviderDecision(&ProviderDecision{ApplicantID: "app-1", Outcome: ProviderApproved}, now)
+	assert.ErrorIs(t, err, ErrInvalidTransition, "decided cases ignore later decisions")
+}
+
+func TestCase_ProviderApprovalOfHighRiskCase(t *testing.T) {
+	reviewer := uuid.New()
+	c := inReview(t, reviewer, RiskHigh)
+	applicantID := "app-1"
+	provider := "simulator"
+	c.Provider, c.ProviderApplicantID = &provider, &applicantID
+
+	tr, err := c.ApplyProviderDecision(&ProviderDecision{ApplicantID: applicantID, Outcome: ProviderApproved}, now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusInReview, c.Status)
+	assert.Equal(t, StatusInReview, tr.ToStatus)
+
+	c.RiskLevel = RiskLow
+	_, err = c.ApplyProviderDecision(&ProviderDecision{ApplicantID: applicantID, Outcome: ProviderApproved}, now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusApproved, c.Status)
+}
+
+func TestCase_HoldProviderApproval(t *testing.T) {
+	c, _, err := NewCase(uuid.New(), completeApplicant(), now)
+	require.NoError(t, err)
+	_, err = c.StartProviderVerification("simulator", "app-1", now)
+	require.NoError(t, err)
+
+	_, err = c.HoldProviderApproval(&ProviderDecision{ApplicantID: "app-1", Outcome: ProviderRejected}, "held", now)
+	assert.ErrorIs(t, err, ErrInvalidDecision)
+
+	tr, err := c.HoldProviderApproval(&ProviderDecision{ApplicantID: "app-1", Outcome: ProviderApproved}, "screening matches need review", now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSubmitted, c.Status)
+	assert.Equal(t, StatusSubmitted, tr.ToStatus)
+	assert.Equal(t, "screening matches need review", *tr.Note)
+	assert.Nil(t, c.DecidedAt)
+}
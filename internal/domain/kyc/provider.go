@@ -0,0 +1,109 @@
+package kyc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProviderActorID is the actor recorded in a case's history for decisions made by an
+// external provider rather than an admin
+var ProviderActorID = uuid.Nil
+
+// Provider is an external identity verification vendor. Adapters translate the vendor's API,
+// webhooks and labels into the provider-agnostic types below; the service maps those onto
+// the case workflow. This interface is implemented by the infrastructure layer
+// (kycprovider package).
+type Provider interface {
+	// Name identifies the provider in configuration, webhook URLs and stored cases
+	Name() string
+
+	// CreateApplicant registers the case's applicant with the provider and returns the
+	// provider's applicant ID
+	CreateApplicant(ctx context.Context, req ProviderApplicantRequest) (string, error)
+
+	// VerificationLink returns a link where the applicant completes verification with the provider
+	VerificationLink(ctx context.Context, applicantID string) (*VerificationLink, error)
+
+	// ParseDecision verifies the signature of a decision webhook and decodes it.
+	// Returns ErrInvalidProviderWebhook if the signature does not verify or the body is malformed.
+	ParseDecision(header http.Header, body []byte) (*ProviderDecision, error)
+
+	// FetchReport retrieves the provider's report on an applicant
+	FetchReport(ctx context.Context, applicantID string) (*ProviderReport, error)
+}
+
+// ProviderApplicantRequest is what a provider is told about an applicant.
+// CaseID is passed as the provider's external reference.
+type ProviderApplicantRequest struct {
+	CaseID    uuid.UUID
+	UserID    uuid.UUID
+	Email     string
+	Applicant Applicant
+}
+
+// VerificationLink is where the applicant completes verification with the provider
+type VerificationLink struct {
+	Provider  string    `json:"provider"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ProviderOutcome is a provider's verdict on an applicant, independent of the vendor
+type ProviderOutcome string
+
+const (
+	// ProviderPending means the provider has not decided yet; only seen in reports
+	ProviderPending ProviderOutcome = "pending"
+	// ProviderApproved verified the applicant
+	ProviderApproved ProviderOutcome = "approved"
+	// ProviderRejected turned the applicant down for good
+	ProviderRejected ProviderOutcome = "rejected"
+	// ProviderResubmissionRequested asks the applicant to try again, such as with a readable document
+	ProviderResubmissionRequested ProviderOutcome = "resubmission_requested"
+	// ProviderExpired means the applicant did not finish verification in time
+	ProviderExpired ProviderOutcome = "expired"
+)
+
+// IsValid reports whether o is a known outcome
+func (o ProviderOutcome) IsValid() bool {
+	switch o {
+	case ProviderPending, ProviderApproved, ProviderRejected, ProviderResubmissionRequested, ProviderExpired:
+		return true
+	}
+	return false
+}
+
+// ProviderDecision is a provider's decision webhook. Adapters map the vendor's rejection
+// labels to a RejectionReason; an unmapped rejection uses ReasonOther.
+type ProviderDecision struct {
+	// EventID is the provider's ID of the webhook, for tracing redeliveries
+	EventID     string
+	ApplicantID string
+	Outcome     ProviderOutcome
+	Reason      RejectionReason
+	// Note is the provider's comment, shown to the applicant when they must act
+	Note       string
+	OccurredAt time.Time
+}
+
+// ProviderCheck is one check a provider ran, such as document authenticity or liveness
+type ProviderCheck struct {
+	Name   string `json:"name"`
+	Result string `json:"result"`
+}
+
+// ProviderReport is a provider's report on an applicant. Raw keeps the vendor's report so
+// reviewers can see details the mapping does not carry.
+type ProviderReport struct {
+	Provider    string          `json:"provider"`
+	ApplicantID string          `json:"applicant_id"`
+	Outcome     ProviderOutcome `json:"outcome"`
+	Reason      RejectionReason `json:"reason,omitempty"`
+	Checks      []ProviderCheck `json:"checks"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	Raw         json.RawMessage `json:"raw,omitempty"`
+}
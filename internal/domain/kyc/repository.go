@@ -0,0 +1,73 @@
+package kyc
+
+import (
+	"context"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for KYC case persistence.
+// This interface is implemented by the infrastructure layer (repository package).
+type Repository interface {
+	// Create stores a new case and assigns its ID and version.
+	// Returns ErrCaseAlreadyOpen if the user already has a case that is not final.
+	Create(ctx context.Context, c *Case) (*Case, error)
+
+	// GetByID retrieves a case without its documents. Returns ErrCaseNotFound if it does not exist.
+	GetByID(ctx context.Context, id uuid.UUID) (*Case, error)
+
+	// GetLatestForUser retrieves the user's most recently created case.
+	// Returns ErrCaseNotFound if the user never opened one.
+	GetLatestForUser(ctx context.Context, userID uuid.UUID) (*Case, error)
+
+	// GetByProviderApplicant retrieves the case an external provider's applicant belongs to.
+	// Returns ErrCaseNotFound if there is none.
+	GetByProviderApplicant(ctx context.Context, provider, applicantID string) (*Case, error)
+
+	// List retrieves cases matching filter, oldest submission first so the queue is worked in order
+	List(ctx context.Context, filter ListFilter) ([]*Case, error)
+
+	// Count returns how many cases match filter, ignoring its limit and offset
+	Count(ctx context.Context, filter ListFilter) (int64, error)
+
+	// Update stores c if it still has the version it was loaded with, and increments the version.
+	// Returns ErrCaseConflict if the case was changed in the meantime.
+	Update(ctx context.Context, c *Case) (*Case, error)
+
+	// AddDocument stores a document's metadata; the content is already in the object store
+	AddDocument(ctx context.Context, d *Document) (*Document, error)
+
+	// ListDocuments retrieves a case's documents in upload order
+	ListDocuments(ctx context.Context, caseID uuid.UUID) ([]*Document, error)
+
+	// GetDocument retrieves a document of a case. Returns ErrDocumentNotFound if it does not exist.
+	GetDocument(ctx context.Context, caseID, documentID uuid.UUID) (*Document, error)
+
+	// AddTransition appends an entry to a case's history
+	AddTransition(ctx context.Context, t *Transition) error
+
+	// ListTransitions retrieves a case's history in order
+	ListTransitions(ctx context.Context, caseID uuid.UUID) ([]*Transition, error)
+
+	// GetTier retrieves the tier a user holds. Returns ErrTierNotFound if they were never assigned one.
+	GetTier(ctx context.Context, userID uuid.UUID) (*TierAssignment, error)
+
+	// SetTier stores the tier a user holds, replacing any earlier assignment
+	SetTier(ctx context.Context, a *TierAssignment) (*TierAssignment, error)
+
+	// AddTierChange appends an entry to a user's tier history
+	AddTierChange(ctx context.Context, c *TierChange) error
+
+	// ListTierChanges retrieves a user's tier history in order
+	ListTierChanges(ctx context.Context, userID uuid.UUID) ([]*TierChange, error)
+}
+
+// Transactor runs case changes, the derived user KYC status and the events describing them
+// atomically. This interface is implemented by the infrastructure layer (repository package).
+type Transactor interface {
+	// WithinTx runs fn in a database transaction shared by the repositories and outbox
+	// writer passed to it; it commits when fn returns nil and rolls back otherwise.
+	WithinTx(ctx context.Context, fn func(cases Repository, users user.Repository, events outbox.Writer) error) error
+}
@@ -0,0 +1,84 @@
+package kyc
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// DocumentUpload is a document as received from the applicant. ContentType is detected from
+// the content; the client's claim is not trusted.
+type DocumentUpload struct {
+	Type     DocumentType
+	FileName string
+	Content  io.Reader
+}
+
+// Service manages KYC cases and tiers: applicants fill in and submit their case, admins review
+// it, and downstream services read the entitlements that follow from the user's tier.
+// Implemented by the service layer; used by the HTTP and gRPC transports.
+type Service interface {
+	// CreateCase opens a draft case for the user.
+	// Returns ErrCaseAlreadyOpen if one is in progress and ErrAlreadyVerified if the user is verified.
+	CreateCase(ctx context.Context, userID uuid.UUID, applicant Applicant) (*Case, error)
+
+	// GetMyCase retrieves the user's latest case with its documents
+	GetMyCase(ctx context.Context, userID uuid.UUID) (*Case, error)
+
+	// UpdateApplicant replaces the applicant data of the user's editable case
+	UpdateApplicant(ctx context.Context, userID, caseID uuid.UUID, applicant Applicant) (*Case, error)
+
+	// UploadDocument stores a document of the user's editable case
+	UploadDocument(ctx context.Context, userID, caseID uuid.UUID, upload DocumentUpload) (*Document, error)
+
+	// Submit hands the user's case to reviewers
+	Submit(ctx context.Context, userID, caseID uuid.UUID) (*Case, error)
+
+	// ListCases retrieves the review queue
+	ListCases(ctx context.Context, filter ListFilter) ([]*Case, int64, error)
+
+	// GetCase retrieves any case with its documents
+	GetCase(ctx context.Context, caseID uuid.UUID) (*Case, error)
+
+	// GetHistory retrieves a case's transitions in order
+	GetHistory(ctx context.Context, caseID uuid.UUID) ([]*Transition, error)
+
+	// OpenDocument opens a document's content for a reviewer; the caller must close it
+	OpenDocument(ctx context.Context, caseID, documentID uuid.UUID, actor Actor) (*Document, io.ReadCloser, error)
+
+	// Assign makes reviewerID, who must be an admin, the case's reviewer
+	Assign(ctx context.Context, caseID, reviewerID uuid.UUID, actor Actor) (*Case, error)
+
+	// SetRiskLevel records the risk assessment of a case
+	SetRiskLevel(ctx context.Context, caseID uuid.UUID, level RiskLevel, actor Actor) (*Case, error)
+
+	// Approve approves a case; high-risk cases need approvals by two different admins
+	Approve(ctx context.Context, caseID uuid.UUID, note string, actor Actor) (*Case, error)
+
+	// Reject turns a case down with a reason code
+	Reject(ctx context.Context, caseID uuid.UUID, reason RejectionReason, note string, actor Actor) (*Case, error)
+
+	// RequestMoreInfo returns a case to the applicant with a note on what to correct
+	RequestMoreInfo(ctx context.Context, caseID uuid.UUID, note string, actor Actor) (*Case, error)
+
+	// StartVerification hands the user's case to the external provider and returns the link
+	// where they complete verification. Returns ErrProviderNotConfigured without a provider.
+	StartVerification(ctx context.Context, userID, caseID uuid.UUID) (*VerificationLink, error)
+
+	// HandleProviderWebhook verifies a provider's decision webhook and applies it to the case
+	HandleProviderWebhook(ctx context.Context, provider string, header http.Header, body []byte) error
+
+	// GetProviderReport retrieves the external provider's report on a case
+	GetProviderReport(ctx context.Context, caseID uuid.UUID) (*ProviderReport, error)
+
+	// GetEntitlements computes what the user is allowed to do from their tier and KYC status
+	GetEntitlements(ctx context.Context, userID uuid.UUID) (*Entitlements, error)
+
+	// SetTier assigns a tier to a user; tiers above TierNone require a verified user
+	SetTier(ctx context.Context, userID uuid.UUID, tier Tier, reason string, actor Actor) (*Entitlements, error)
+
+	// GetTierHistory retrieves a user's tier changes in order
+	GetTierHistory(ctx context.Context, userID uuid.UUID) ([]*TierChange, error)
+}
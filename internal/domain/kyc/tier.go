@@ -0,0 +1,231 @@
+package kyc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// Tier is a user's KYC level. Higher tiers unlock higher limits and more products;
+// tiers above TierNone require a verified user.
+type Tier int
+
+const (
+	// TierNone is the level of every user until their KYC is approved
+	TierNone Tier = 0
+	// TierBasic is granted automatically when a KYC case is approved
+	TierBasic Tier = 1
+	// MaxTier is the highest level; tiers above TierBasic are granted by admins
+	MaxTier Tier = 3
+)
+
+// IsValid reports whether t is within TierNone..MaxTier
+func (t Tier) IsValid() bool {
+	return t >= TierNone && t <= MaxTier
+}
+
+var (
+	amountRe      = regexp.MustCompile(`^\d{1,15}(\.\d{1,8})?$`)
+	productRe     = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+	currencyRe    = regexp.MustCompile(`^[A-Z]{3}$`)
+	defaultPolicy = mustTierPolicy("EUR", []TierLimits{
+		{Tier: 0, Name: "unverified", DailyDepositLimit: "0", DailyWithdrawalLimit: "0"},
+		{Tier: 1, Name: "basic", DailyDepositLimit: "2000", DailyWithdrawalLimit: "1000", Products: []string{"spot"}},
+		{Tier: 2, Name: "verified", DailyDepositLimit: "50000", DailyWithdrawalLimit: "25000", Products: []string{"spot", "convert"}},
+		{Tier: 3, Name: "institutional", Products: []string{"spot", "convert", "margin", "otc"}},
+	})
+)
+
+// TierLimits is what users of a tier are allowed to do.
+// Limits are decimal amounts in the policy currency; an empty limit means unlimited.
+// An empty Jurisdictions list allows every jurisdiction.
+type TierLimits struct {
+	Tier                 Tier     `json:"tier"`
+	Name                 string   `json:"name"`
+	DailyDepositLimit    string   `json:"daily_deposit_limit"`
+	DailyWithdrawalLimit string   `json:"daily_withdrawal_limit"`
+	Products             []string `json:"products"`
+	Jurisdictions        []string `json:"jurisdictions"`
+}
+
+// TierPolicy holds the limits of every tier
+type TierPolicy struct {
+	currency string
+	tiers    [MaxTier + 1]TierLimits
+}
+
+// DefaultTierPolicy returns the built-in limits, used when no tier file is configured
+func DefaultTierPolicy() *TierPolicy {
+	return defaultPolicy
+}
+
+// NewTierPolicy validates the limits and builds a policy. Every tier from TierNone to
+// MaxTier must be defined exactly once, and TierNone may not allow any product.
+func NewTierPolicy(currency string, tiers []TierLimits) (*TierPolicy, error) {
+	if !currencyRe.MatchString(currency) {
+		return nil, fmt.Errorf("%w: currency must be an ISO 4217 code", ErrInvalidTierPolicy)
+	}
+
+	policy := &TierPolicy{currency: currency}
+	defined := make(map[Tier]bool, len(tiers))
+	for i, limits := range tiers {
+		if !limits.Tier.IsValid() {
+			return nil, fmt.Errorf("%w: entry %d has tier %d outside 0..%d", ErrInvalidTierPolicy, i, limits.Tier, MaxTier)
+		}
+		if defined[limits.Tier] {
+			return nil, fmt.Errorf("%w: tier %d is defined twice", ErrInvalidTierPolicy, limits.Tier)
+		}
+		if strings.TrimSpace(limits.Name) == "" {
+			return nil, fmt.Errorf("%w: tier %d needs a name", ErrInvalidTierPolicy, limits.Tier)
+		}
+		for field, amount := range map[string]string{
+			"daily_deposit_limit":    limits.DailyDepositLimit,
+			"daily_withdrawal_limit": limits.DailyWithdrawalLimit,
+		} {
+			if amount != "" && !amountRe.MatchString(amount) {
+				return nil, fmt.Errorf("%w: tier %d has invalid %s %q", ErrInvalidTierPolicy, limits.Tier, field, amount)
+			}
+		}
+		for _, product := range limits.Products {
+			if !productRe.MatchString(product) {
+				return nil, fmt.Errorf("%w: tier %d has invalid product %q", ErrInvalidTierPolicy, limits.Tier, product)
+			}
+		}
+		jurisdictions := make([]string, len(limits.Jurisdictions))
+		for j, code := range limits.Jurisdictions {
+			jurisdictions[j] = strings.ToUpper(strings.TrimSpace(code))
+			if !countryCodeRe.MatchString(jurisdictions[j]) {
+				return nil, fmt.Errorf("%w: tier %d has invalid jurisdiction %q", ErrInvalidTierPolicy, limits.Tier, code)
+			}
+		}
+		if limits.Tier == TierNone && len(limits.Products) > 0 {
+			return nil, fmt.Errorf("%w: tier 0 is for unverified users and cannot allow products", ErrInvalidTierPolicy)
+		}
+
+		limits.Jurisdictions = jurisdictions
+		policy.tiers[limits.Tier] = limits
+		defined[limits.Tier] = true
+	}
+	for tier := TierNone; tier <= MaxTier; tier++ {
+		if !defined[tier] {
+			return nil, fmt.Errorf("%w: tier %d is not defined", ErrInvalidTierPolicy, tier)
+		}
+	}
+	return policy, nil
+}
+
+func mustTierPolicy(currency string, tiers []TierLimits) *TierPolicy {
+	policy, err := NewTierPolicy(currency, tiers)
+	if err != nil {
+		panic(err)
+	}
+	return policy
+}
+
+// Currency returns the currency limits are expressed in
+func (p *TierPolicy) Currency() string {
+	return p.currency
+}
+
+// Limits returns the limits of a tier; invalid tiers get TierNone's limits
+func (p *TierPolicy) Limits(tier Tier) TierLimits {
+	if !tier.IsValid() {
+		tier = TierNone
+	}
+	return p.tiers[tier]
+}
+
+// Entitlements computes what a user may do. Users who are deleted or not verified get
+// TierNone's limits whatever tier they hold.
+func (p *TierPolicy) Entitlements(u *user.User, assignment *TierAssignment) *Entitlements {
+	held := TierNone
+	var updatedAt *time.Time
+	if assignment != nil {
+		held = assignment.Tier
+		updatedAt = &assignment.UpdatedAt
+	}
+	active := !u.IsDeleted()
+	effective := held
+	if !active || u.KYCStatus != user.KYCStatusVerified {
+		effective = TierNone
+	}
+
+	limits := p.Limits(effective)
+	return &Entitlements{
+		UserID:               u.ID,
+		Tier:                 effective,
+		HeldTier:             held,
+		TierName:             limits.Name,
+		KYCStatus:            u.KYCStatus,
+		Active:               active,
+		Currency:             p.currency,
+		DailyDepositLimit:    limits.DailyDepositLimit,
+		DailyWithdrawalLimit: limits.DailyWithdrawalLimit,
+		Products:             append([]string{}, limits.Products...),
+		Jurisdictions:        append([]string{}, limits.Jurisdictions...),
+		UpdatedAt:            updatedAt,
+	}
+}
+
+// TierAssignment is the tier a user holds
+type TierAssignment struct {
+	UserID    uuid.UUID  `json:"user_id"`
+	Tier      Tier       `json:"tier"`
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// Tier change reasons recorded by the service; admins give free-text reasons
+const (
+	TierReasonKYCApproved = "kyc_approved"
+)
+
+// TierChange is an entry of a user's tier history
+type TierChange struct {
+	ID      uuid.UUID `json:"id"`
+	UserID  uuid.UUID `json:"user_id"`
+	OldTier Tier      `json:"old_tier"`
+	NewTier Tier      `json:"new_tier"`
+	Reason  string    `json:"reason"`
+	// ChangedBy is the admin who changed the tier; nil for automatic upgrades
+	ChangedBy *uuid.UUID `json:"changed_by,omitempty"`
+	// CaseID is the approved case behind an automatic upgrade
+	CaseID    *uuid.UUID `json:"case_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Entitlements is what a user is allowed to do, as served to downstream services
+type Entitlements struct {
+	UserID uuid.UUID `json:"user_id"`
+	// Tier is the effective tier: TierNone while the user is not verified or is deleted
+	Tier Tier `json:"tier"`
+	// HeldTier is the tier assigned to the user, which applies once they are verified
+	HeldTier             Tier           `json:"held_tier"`
+	TierName             string         `json:"tier_name"`
+	KYCStatus            user.KYCStatus `json:"kyc_status"`
+	Active               bool           `json:"active"`
+	Currency             string         `json:"currency"`
+	DailyDepositLimit    string         `json:"daily_deposit_limit"`
+	DailyWithdrawalLimit string         `json:"daily_withdrawal_limit"`
+	Products             []string       `json:"products"`
+	Jurisdictions        []string       `json:"jurisdictions"`
+	// UpdatedAt is when the held tier last changed; nil if it never did
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Equal reports whether two entitlements grant the same tier and limits
+func (e *Entitlements) Equal(other *Entitlements) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+	return e.Tier == other.Tier &&
+		e.Currency == other.Currency &&
+		e.DailyDepositLimit == other.DailyDepositLimit &&
+		e.DailyWithdrawalLimit == other.DailyWithdrawalLimit &&
+		strings.Join(e.Products, ",") == strings.Join(other.Products, ",") &&
+		strings.Join(e.Jurisdictions, ",") == strings.Join(other.Jurisdictions, ",")
+}
@@ -0,0 +1,86 @@
+package kyc
+
+import (
+	"testing"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validTiers() []TierLimits {
+	return []TierLimits{
+		{Tier: 0, Name: "unverified", DailyDepositLimit: "0", DailyWithdrawalLimit: "0"},
+		{Tier: 1, Name: "basic", DailyDepositLimit: "1000.50", DailyWithdrawalLimit: "500", Products: []string{"spot"}, Jurisdictions: []string{"de", " FR "}},
+		{Tier: 2, Name: "verified", DailyDepositLimit: "10000", Products: []string{"spot", "convert"}},
+		{Tier: 3, Name: "institutional", Products: []string{"spot", "otc"}},
+	}
+}
+
+func TestNewTierPolicy(t *testing.T) {
+	policy, err := NewTierPolicy("USD", validTiers())
+	require.NoError(t, err)
+	assert.Equal(t, "USD", policy.Currency())
+	assert.Equal(t, []string{"DE", "FR"}, policy.Limits(1).Jurisdictions)
+	assert.Equal(t, "unverified", policy.Limits(7).Name, "invalid tiers fall back to tier 0")
+
+	tests := []struct {
+		name   string
+		mutate func(currency *string, tiers []TierLimits) []TierLimits
+	}{
+		{"bad currency", func(c *string, tiers []TierLimits) []TierLimits { *c = "euro"; return tiers }},
+		{"missing tier", func(c *string, tiers []TierLimits) []TierLimits { return tiers[:3] }},
+		{"duplicate tier", func(c *string, tiers []TierLimits) []TierLimits { tiers[2].Tier = 1; return tiers }},
+		{"tier out of range", func(c *string, tiers []TierLimits) []TierLimits { tiers[3].Tier = 4; return tiers }},
+		{"missing name", func(c *string, tiers []TierLimits) []TierLimits { tiers[1].Name = " "; return tiers }},
+		{"bad amount", func(c *string, tiers []TierLimits) []TierLimits { tiers[1].DailyDepositLimit = "-5"; return tiers }},
+		{"bad product", func(c *string, tiers []TierLimits) []TierLimits {
+			tiers[1].Products = []string{"Spot Trading"}
+			return tiers
+		}},
+		{"bad jurisdiction", func(c *string, tiers []TierLimits) []TierLimits {
+			tiers[1].Jurisdictions = []string{"DEU"}
+			return tiers
+		}},
+		{"tier 0 with products", func(c *string, tiers []TierLimits) []TierLimits { tiers[0].Products = []string{"spot"}; return tiers }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currency := "EUR"
+			tiers := tt.mutate(&currency, validTiers())
+			_, err := NewTierPolicy(currency, tiers)
+			assert.ErrorIs(t, err, ErrInvalidTierPolicy)
+		})
+	}
+}
+
+func TestTierPolicy_Entitlements(t *testing.T) {
+	policy := DefaultTierPolicy()
+	u := &user.User{ID: uuid.New(), KYCStatus: user.KYCStatusVerified}
+
+	none := policy.Entitlements(u, nil)
+	assert.Equal(t, TierNone, none.Tier)
+	assert.Equal(t, "0", none.DailyDepositLimit)
+	assert.Nil(t, none.UpdatedAt)
+
+	assignment := &TierAssignment{UserID: u.ID, Tier: 2, UpdatedAt: now}
+	verified := policy.Entitlements(u, assignment)
+	assert.Equal(t, Tier(2), verified.Tier)
+	assert.Equal(t, "verified", verified.TierName)
+	assert.Equal(t, []string{"spot", "convert"}, verified.Products)
+	assert.True(t, verified.Active)
+	assert.False(t, none.Equal(verified))
+
+	u.KYCStatus = user.KYCStatusPending
+	pending := policy.Entitlements(u, assignment)
+	assert.Equal(t, TierNone, pending.Tier, "tiers only apply to verified users")
+	assert.Equal(t, Tier(2), pending.HeldTier)
+	assert.True(t, none.Equal(pending))
+
+	u.KYCStatus = user.KYCStatusVerified
+	u.DeletedAt = &now
+	deleted := policy.Entitlements(u, assignment)
+	assert.False(t, deleted.Active)
+	assert.Equal(t, TierNone, deleted.Tier)
+}
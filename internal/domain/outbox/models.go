@@ -0,0 +1,103 @@
+// Package outbox defines the transactional outbox used to publish domain events.
+// Events are written to the outbox table in the same database transaction as the
+// change they describe, and a relay publishes them to the event bus afterwards.
+// Delivery is at-least-once and ordered per aggregate (e.g. per user).
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+)
+
+// Message is an event waiting in (or already relayed from) the outbox
+type Message struct {
+	ID            int64
+	AggregateType string // e.g. "user"
+	AggregateID   string
+	EventID       string
+	EventType     string
+	OccurredAt    time.Time
+	Source        string
+	Subject       string
+	SchemaVersion int
+	Attributes    map[string]string
+	Payload       map[string]interface{}
+	Metadata      map[string]string
+	Attempts      int32
+	LastError     *string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	PublishedAt   *time.Time
+}
+
+// Envelope returns the message as a publishable event, keeping its CloudEvents attributes
+func (m *Message) Envelope() common.EventEnvelope {
+	return envelope{m}
+}
+
+// envelope adapts a Message to common.VersionedEnvelope
+type envelope struct {
+	m *Message
+}
+
+func (e envelope) EventID() string                      { return e.m.EventID }
+func (e envelope) EventType() string                    { return e.m.EventType }
+func (e envelope) OccurredAt() time.Time                { return e.m.OccurredAt }
+func (e envelope) Attributes() map[string]string        { return e.m.Attributes }
+func (e envelope) EventPayload() map[string]interface{} { return e.m.Payload }
+func (e envelope) EventMetadata() map[string]string     { return e.m.Metadata }
+func (e envelope) EventSource() string                  { return e.m.Source }
+func (e envelope) EventSubject() string                 { return e.m.Subject }
+func (e envelope) EventSchemaVersion() int              { return e.m.SchemaVersion }
+
+// Stats describes the outbox backlog
+type Stats struct {
+	// Pending is the number of messages not yet published
+	Pending int64
+
+	// OldestPendingAt is when the oldest pending message was written; nil when nothing is pending
+	OldestPendingAt *time.Time
+}
+
+// Lag returns how long the oldest pending message has been waiting
+func (s Stats) Lag(now time.Time) time.Duration {
+	if s.OldestPendingAt == nil {
+		return 0
+	}
+	return now.Sub(*s.OldestPendingAt)
+}
+
+// Writer appends events to the outbox. Implementations are bound to a database
+// transaction, so the events commit or roll back together with the domain change.
+type Writer interface {
+	// Enqueue stores event for relay, keyed by the aggregate it belongs to
+	Enqueue(ctx context.Context, aggregateType, aggregateID string, event common.EventEnvelope) error
+}
+
+// Repository is the relay's access to the outbox table
+type Repository interface {
+	// WithRelayLock runs fn in a transaction holding the relay lock, so only one relay
+	// publishes at a time. ran is false when another relay holds the lock.
+	WithRelayLock(ctx context.Context, fn func(tx RelayTx) error) (ran bool, err error)
+
+	// Stats returns the current backlog
+	Stats(ctx context.Context) (Stats, error)
+
+	// DeletePublishedBefore removes messages published before the cutoff
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// RelayTx is the set of outbox operations available while holding the relay lock
+type RelayTx interface {
+	// ListDue returns up to limit pending messages that are due at now, oldest first.
+	// A message is withheld while an earlier message of the same aggregate is backing off.
+	ListDue(ctx context.Context, now time.Time, limit int32) ([]*Message, error)
+
+	// MarkPublished records a successful publish
+	MarkPublished(ctx context.Context, id int64, publishedAt time.Time) error
+
+	// MarkFailed records a failed publish attempt and schedules the next one
+	MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+}
@@ -0,0 +1,24 @@
+package replay
+
+import "errors"
+
+// Domain-level errors for event replay.
+var (
+	// ErrJobNotFound is returned when a replay job does not exist.
+	ErrJobNotFound = errors.New("replay job not found")
+
+	// ErrNoJob is returned by Claim when no job is waiting to run.
+	ErrNoJob = errors.New("no replay job to run")
+
+	// ErrLeaseLost is returned when a running job was cancelled or taken over by another instance.
+	ErrLeaseLost = errors.New("replay job lease lost")
+
+	// ErrInvalidJob is returned when a replay request fails validation.
+	ErrInvalidJob = errors.New("invalid replay job")
+
+	// ErrJobNotCancellable is returned when cancelling a job that already finished.
+	ErrJobNotCancellable = errors.New("replay job is not pending or running")
+
+	// ErrJobNotResumable is returned when resuming a job that neither failed nor was cancelled.
+	ErrJobNotResumable = errors.New("replay job is not failed or cancelled")
+)
@@ -0,0 +1,74 @@
+package replay
+
+import (
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/google/uuid"
+)
+
+// Replay markers, added to both the attributes and the metadata of replayed events so
+// consumers can tell them apart from live events on any transport
+const (
+	AttributeReplay      = "replay"
+	AttributeReplayJobID = "replay_job_id"
+)
+
+// Mark wraps an event as a replay by job jobID. The event keeps its ID, type, time and
+// CloudEvents attributes, so consumers deduplicating on event ID skip events they already saw.
+func Mark(event common.EventEnvelope, jobID uuid.UUID) common.EventEnvelope {
+	return replayedEvent{event: event, jobID: jobID.String()}
+}
+
+// IsReplay reports whether an event was published by a replay job
+func IsReplay(event common.EventEnvelope) bool {
+	return event.Attributes()[AttributeReplay] == "true"
+}
+
+// Compile-time check to ensure replayed events keep the CloudEvents attributes
+var _ common.VersionedEnvelope = replayedEvent{}
+
+// replayedEvent adds the replay markers to an event
+type replayedEvent struct {
+	event common.EventEnvelope
+	jobID string
+}
+
+func (e replayedEvent) EventID() string                      { return e.event.EventID() }
+func (e replayedEvent) EventType() string                    { return e.event.EventType() }
+func (e replayedEvent) OccurredAt() time.Time                { return e.event.OccurredAt() }
+func (e replayedEvent) EventPayload() map[string]interface{} { return e.event.EventPayload() }
+
+func (e replayedEvent) Attributes() map[string]string {
+	return e.mark(e.event.Attributes())
+}
+
+func (e replayedEvent) EventMetadata() map[string]string {
+	return e.mark(e.event.EventMetadata())
+}
+
+func (e replayedEvent) EventSource() string {
+	source, _, _ := common.EventContext(e.event)
+	return source
+}
+
+func (e replayedEvent) EventSubject() string {
+	_, subject, _ := common.EventContext(e.event)
+	return subject
+}
+
+func (e replayedEvent) EventSchemaVersion() int {
+	_, _, schemaVersion := common.EventContext(e.event)
+	return schemaVersion
+}
+
+// mark returns a copy of values with the replay markers added
+func (e replayedEvent) mark(values map[string]string) map[string]string {
+	marked := make(map[string]string, len(values)+2)
+	for key, value := range values {
+		marked[key] = value
+	}
+	marked[AttributeReplay] = "true"
+	marked[AttributeReplayJobID] = e.jobID
+	return marked
+}
@@ -0,0 +1,332 @@
+// Package replay contains the event replay and backfill domain model. A replay job regenerates
+// events for consumers that need history the event bus no longer holds, either as snapshot
+// events built from current user state or by re-reading the outbox or audit log for a time
+// range, and publishes them to a target stream at a bounded rate.
+package replay
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// Source is where a replay job reads events from
+type Source string
+
+const (
+	// SourceSnapshot builds events from current users state: user.registered for every user,
+	// user.kyc.updated for users whose KYC was decided and user.deleted for deleted users
+	SourceSnapshot Source = "snapshot"
+
+	// SourceOutbox re-publishes outbox messages with their original event IDs
+	SourceOutbox Source = "outbox"
+
+	// SourceAudit re-publishes audit logs as audit.logged events
+	SourceAudit Source = "audit"
+)
+
+// IsValid reports whether s is a known source
+func (s Source) IsValid() bool {
+	switch s {
+	case SourceSnapshot, SourceOutbox, SourceAudit:
+		return true
+	}
+	return false
+}
+
+// SnapshotEventTypes are the event types a snapshot replay can produce
+var SnapshotEventTypes = []string{
+	string(user.EventTypeUserRegistered),
+	string(user.EventTypeUserKYCUpdated),
+	string(user.EventTypeUserDeleted),
+}
+
+// Status is where a job is in its lifecycle
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// IsValid reports whether s is a known status
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusRunning, StatusCompleted, StatusFailed, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether a job in status s no longer runs without being resumed
+func (s Status) IsTerminal() bool {
+	return s == StatusCompleted || s == StatusFailed || s == StatusCancelled
+}
+
+// Defaults and limits for replay requests
+const (
+	DefaultRateLimit = 100
+	MaxRateLimit     = 10000
+	DefaultBatchSize = 100
+	MaxBatchSize     = 1000
+)
+
+// Request describes a replay job to create
+type Request struct {
+	Source Source
+	// EventTypes restricts the replay to these types; empty replays every type the source has
+	EventTypes []string
+	// From and To bound the replay to [From, To); nil leaves that side open
+	From *time.Time
+	To   *time.Time
+	// TargetStream is the stream (topic or subject prefix on Kafka and NATS) replayed events go to
+	TargetStream string
+	// RateLimit is the maximum number of events published per second
+	RateLimit int
+	// BatchSize is the number of source records read and checkpointed at a time
+	BatchSize   int
+	RequestedBy string
+}
+
+// Validate checks a request and fills in defaults
+func (r *Request) Validate() error {
+	if !r.Source.IsValid() {
+		return fmt.Errorf("%w: unknown source %q", ErrInvalidJob, r.Source)
+	}
+	if strings.TrimSpace(r.TargetStream) == "" {
+		return fmt.Errorf("%w: target_stream is required", ErrInvalidJob)
+	}
+	if strings.TrimSpace(r.RequestedBy) == "" {
+		return fmt.Errorf("%w: requested_by is required", ErrInvalidJob)
+	}
+	if r.From != nil && r.To != nil && !r.From.Before(*r.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidJob)
+	}
+	if r.Source == SourceSnapshot {
+		for _, eventType := range r.EventTypes {
+			if !containsString(SnapshotEventTypes, eventType) {
+				return fmt.Errorf("%w: snapshot replay cannot produce %q", ErrInvalidJob, eventType)
+			}
+		}
+	}
+
+	if r.RateLimit == 0 {
+		r.RateLimit = DefaultRateLimit
+	}
+	if r.RateLimit < 0 || r.RateLimit > MaxRateLimit {
+		return fmt.Errorf("%w: rate_limit must be between 1 and %d", ErrInvalidJob, MaxRateLimit)
+	}
+	if r.BatchSize == 0 {
+		r.BatchSize = DefaultBatchSize
+	}
+	if r.BatchSize < 0 || r.BatchSize > MaxBatchSize {
+		return fmt.Errorf("%w: batch_size must be between 1 and %d", ErrInvalidJob, MaxBatchSize)
+	}
+	return nil
+}
+
+// Job is a stored replay job and its progress
+type Job struct {
+	ID           uuid.UUID  `json:"id"`
+	Source       Source     `json:"source"`
+	EventTypes   []string   `json:"event_types"`
+	From         *time.Time `json:"from,omitempty"`
+	To           *time.Time `json:"to,omitempty"`
+	TargetStream string     `json:"target_stream"`
+	RateLimit    int        `json:"rate_limit"`
+	BatchSize    int        `json:"batch_size"`
+
+	// Progress
+	Status           Status  `json:"status"`
+	Cursor           Cursor  `json:"cursor"`
+	TotalRecords     int64   `json:"total_records"`
+	ProcessedRecords int64   `json:"processed_records"`
+	PublishedEvents  int64   `json:"published_events"`
+	LastError        *string `json:"last_error,omitempty"`
+
+	// Lease held by the instance running the job
+	LeaseOwner     *string    `json:"lease_owner,omitempty"`
+	LeaseExpiresAt *time.Time `json:"lease_expires_at,omitempty"`
+
+	RequestedBy string     `json:"requested_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// Progress returns the share of source records processed, from 0 to 1. Records written to
+// the source after the job counted them can take a running job past 1; the result is capped.
+func (j *Job) Progress() float64 {
+	if j.Status == StatusCompleted {
+		return 1
+	}
+	if j.TotalRecords <= 0 {
+		return 0
+	}
+	progress := float64(j.ProcessedRecords) / float64(j.TotalRecords)
+	if progress > 1 {
+		return 1
+	}
+	return progress
+}
+
+// Includes reports whether the job replays events of the given type
+func (j *Job) Includes(eventType string) bool {
+	return len(j.EventTypes) == 0 || containsString(j.EventTypes, eventType)
+}
+
+// Window is the time range a job reads; nil leaves that side open
+type Window struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// Window returns the job's time range
+func (j *Job) Window() Window {
+	return Window{From: j.From, To: j.To}
+}
+
+// Cursor is the source position after the last replayed record; empty means the start.
+// Outbox cursors hold the message ID; snapshot and audit cursors hold the created_at and ID
+// of the last record, as records are read in that order.
+type Cursor string
+
+// OutboxCursor returns the cursor after the outbox message with the given ID
+func OutboxCursor(id int64) Cursor {
+	return Cursor(strconv.FormatInt(id, 10))
+}
+
+// OutboxID returns the outbox message ID held by c; 0 for the empty cursor
+func (c Cursor) OutboxID() (int64, error) {
+	if c == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(string(c), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid outbox cursor %q: %w", c, err)
+	}
+	return id, nil
+}
+
+// Position is a keyset position in (created_at, id) order
+type Position struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// PositionCursor returns the cursor after the record at position p
+func PositionCursor(p Position) Cursor {
+	return Cursor(p.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + p.ID.String())
+}
+
+// Position returns the keyset position held by c; nil for the empty cursor
+func (c Cursor) Position() (*Position, error) {
+	if c == "" {
+		return nil, nil
+	}
+	createdAt, id, ok := strings.Cut(string(c), "|")
+	if !ok {
+		return nil, fmt.Errorf("invalid cursor %q", c)
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q: %w", c, err)
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q: %w", c, err)
+	}
+	return &Position{CreatedAt: t, ID: parsedID}, nil
+}
+
+// Repository persists replay jobs and reads the records they replay
+type Repository interface {
+	// Create stores a new pending job
+	Create(ctx context.Context, req *Request) (*Job, error)
+
+	// GetByID retrieves a job
+	GetByID(ctx context.Context, id uuid.UUID) (*Job, error)
+
+	// List retrieves jobs, newest first
+	List(ctx context.Context, limit, offset int32) ([]*Job, error)
+
+	// Claim leases the oldest pending job, or running job whose lease expired, to owner
+	// until leaseUntil. Returns ErrNoJob when there is nothing to run.
+	Claim(ctx context.Context, owner string, now, leaseUntil time.Time) (*Job, error)
+
+	// SetTotal records how many source records a running job covers
+	SetTotal(ctx context.Context, id uuid.UUID, owner string, total int64) error
+
+	// SaveProgress checkpoints a running job and extends its lease.
+	// Returns ErrLeaseLost when the job was cancelled or is now held by another owner.
+	SaveProgress(ctx context.Context, job *Job, owner string, leaseUntil time.Time) error
+
+	// Finish moves a running job to completed or failed and releases its lease.
+	// Returns ErrLeaseLost when the job was cancelled or is now held by another owner.
+	Finish(ctx context.Context, id uuid.UUID, owner string, status Status, lastError *string, at time.Time) error
+
+	// Cancel stops a pending or running job
+	Cancel(ctx context.Context, id uuid.UUID) (*Job, error)
+
+	// Resume makes a failed or cancelled job pending again
+	Resume(ctx context.Context, id uuid.UUID) (*Job, error)
+
+	// CountUsers counts users, including deleted ones, created in window
+	CountUsers(ctx context.Context, window Window) (int64, error)
+
+	// ListUsers returns up to limit users, including deleted ones, created in window after
+	// position (nil for the first page), in (created_at, id) order
+	ListUsers(ctx context.Context, window Window, after *Position, limit int32) ([]*user.User, error)
+
+	// CountOutbox counts outbox messages of eventTypes (all when empty) that occurred in window
+	CountOutbox(ctx context.Context, window Window, eventTypes []string) (int64, error)
+
+	// ListOutbox returns up to limit outbox messages of eventTypes that occurred in window,
+	// with IDs above afterID, in ID order
+	ListOutbox(ctx context.Context, window Window, eventTypes []string, afterID int64, limit int32) ([]*outbox.Message, error)
+
+	// CountAuditLogs counts audit logs of eventTypes (all when empty) created in window
+	CountAuditLogs(ctx context.Context, window Window, eventTypes []string) (int64, error)
+
+	// ListAuditLogs returns up to limit audit logs of eventTypes created in window after
+	// position (nil for the first page), in (created_at, id) order
+	ListAuditLogs(ctx context.Context, window Window, eventTypes []string, after *Position, limit int32) ([]*audit.Log, error)
+}
+
+// Service exposes replay jobs to admins
+type Service interface {
+	// CreateJob validates and queues a replay job
+	CreateJob(ctx context.Context, req *Request) (*Job, error)
+
+	// GetJob retrieves a job and its progress
+	GetJob(ctx context.Context, id uuid.UUID) (*Job, error)
+
+	// ListJobs retrieves jobs, newest first
+	ListJobs(ctx context.Context, limit, offset int32) ([]*Job, error)
+
+	// CancelJob stops a pending or running job on behalf of actor
+	CancelJob(ctx context.Context, id uuid.UUID, actor string) (*Job, error)
+
+	// ResumeJob queues a failed or cancelled job again, continuing from its cursor
+	ResumeJob(ctx context.Context, id uuid.UUID, actor string) (*Job, error)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package replay
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest_Validate(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	valid := func() *Request {
+		return &Request{Source: SourceOutbox, TargetStream: "replay:billing", RequestedBy: "admin@example.com"}
+	}
+
+	req := valid()
+	require.NoError(t, req.Validate())
+	assert.Equal(t, DefaultRateLimit, req.RateLimit)
+	assert.Equal(t, DefaultBatchSize, req.BatchSize)
+
+	tests := []struct {
+		name   string
+		mutate func(r *Request)
+	}{
+		{"unknown source", func(r *Request) { r.Source = "kafka" }},
+		{"missing target", func(r *Request) { r.TargetStream = " " }},
+		{"missing requester", func(r *Request) { r.RequestedBy = "" }},
+		{"empty range", func(r *Request) { r.From, r.To = &to, &from }},
+		{"rate limit too high", func(r *Request) { r.RateLimit = MaxRateLimit + 1 }},
+		{"negative batch size", func(r *Request) { r.BatchSize = -1 }},
+		{"snapshot of unsupported type", func(r *Request) {
+			r.Source = SourceSnapshot
+			r.EventTypes = []string{string(user.EventTypeUserLoggedIn)}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.mutate(req)
+			assert.True(t, errors.Is(req.Validate(), ErrInvalidJob))
+		})
+	}
+}
+
+func TestJob_Progress(t *testing.T) {
+	assert.Equal(t, 0.0, (&Job{Status: StatusRunning}).Progress())
+	assert.Equal(t, 0.25, (&Job{Status: StatusRunning, TotalRecords: 8, ProcessedRecords: 2}).Progress())
+	assert.Equal(t, 1.0, (&Job{Status: StatusRunning, TotalRecords: 8, ProcessedRecords: 9}).Progress())
+	assert.Equal(t, 1.0, (&Job{Status: StatusCompleted}).Progress())
+}
+
+func TestCursor(t *testing.T) {
+	id, err := OutboxCursor(42).OutboxID()
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+
+	position := Position{CreatedAt: time.Date(2024, 3, 1, 12, 0, 0, 123456000, time.UTC), ID: uuid.New()}
+	parsed, err := PositionCursor(position).Position()
+	require.NoError(t, err)
+	assert.True(t, position.CreatedAt.Equal(parsed.CreatedAt))
+	assert.Equal(t, position.ID, parsed.ID)
+
+	empty, err := Cursor("").Position()
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+
+	_, err = Cursor("not-a-cursor").Position()
+	assert.Error(t, err)
+	_, err = Cursor("abc").OutboxID()
+	assert.Error(t, err)
+}
+
+func TestMark(t *testing.T) {
+	userID := uuid.New()
+	event := user.NewTypedEvent(userID, user.RegisteredPayload{Email: "jane@example.com"}).WithMetadata("request_id", "req-1")
+	jobID := uuid.New()
+
+	marked := Mark(event, jobID)
+	assert.True(t, IsReplay(marked))
+	assert.False(t, IsReplay(event))
+
+	assert.Equal(t, event.ID, marked.EventID())
+	assert.Equal(t, "user.registered", marked.EventType())
+	assert.Equal(t, userID.String(), marked.Attributes()["user_id"])
+	assert.Equal(t, jobID.String(), marked.Attributes()[AttributeReplayJobID])
+	assert.Equal(t, "true", marked.EventMetadata()[AttributeReplay])
+	assert.Equal(t, "req-1", marked.EventMetadata()["request_id"])
+	assert.NotContains(t, event.Metadata, AttributeReplay, "marking must not change the original event")
+
+	source, subject, schemaVersion := common.EventContext(marked)
+	assert.Equal(t, common.EventSource, source)
+	assert.Equal(t, userID.String(), subject)
+	assert.Equal(t, 1, schemaVersion)
+}
@@ -0,0 +1,24 @@
+package screening
+
+import "errors"
+
+// Domain-level errors for screening.
+var (
+	// ErrCaseNotFound is returned when a screening case does not exist.
+	ErrCaseNotFound = errors.New("screening case not found")
+
+	// ErrCaseAlreadyDecided is returned when deciding a case that is no longer open.
+	ErrCaseAlreadyDecided = errors.New("screening case already decided")
+
+	// ErrInvalidDecision is returned when a decision lacks a valid note.
+	ErrInvalidDecision = errors.New("invalid screening decision")
+
+	// ErrCaseConflict is returned when a case was modified by a concurrent request.
+	ErrCaseConflict = errors.New("screening case was modified concurrently")
+
+	// ErrInvalidThresholds is returned for matching thresholds out of range.
+	ErrInvalidThresholds = errors.New("invalid screening thresholds")
+
+	// ErrListsNotLoaded is returned when screening runs before any watch list was loaded.
+	ErrListsNotLoaded = errors.New("screening watch lists not loaded")
+)
@@ -0,0 +1,300 @@
+package screening
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Thresholds tune how closely a subject must resemble a list entry to match it
+type Thresholds struct {
+	// Name is the minimum name similarity, from 0.5 to 1, for an entry to match
+	Name float64
+	// DOBYearTolerance is how many years a known date of birth may differ from every listed
+	// one before it rules the entry out
+	DOBYearTolerance int
+}
+
+// DefaultThresholds are used when none are configured
+func DefaultThresholds() Thresholds {
+	return Thresholds{Name: 0.9, DOBYearTolerance: 1}
+}
+
+// Validate checks that the thresholds are in range
+func (t Thresholds) Validate() error {
+	if t.Name < 0.5 || t.Name > 1 {
+		return fmt.Errorf("%w: name threshold must be between 0.5 and 1", ErrInvalidThresholds)
+	}
+	if t.DOBYearTolerance < 0 || t.DOBYearTolerance > 10 {
+		return fmt.Errorf("%w: date of birth tolerance must be between 0 and 10 years", ErrInvalidThresholds)
+	}
+	return nil
+}
+
+// singleTokenPenalty scales the score of a one-word name matched against a longer one, since
+// one matching word says little about the person
+const singleTokenPenalty = 0.85
+
+// NewWatchList builds a watch list, preparing the entries' names for matching
+func NewWatchList(entries []Entry, version string, loadedAt time.Time) *WatchList {
+	l := &WatchList{Entries: entries, Version: version, LoadedAt: loadedAt}
+	l.tokens = make([][][]string, len(entries))
+	for i, e := range entries {
+		l.tokens[i] = make([][]string, len(e.Names))
+		for j, name := range e.Names {
+			l.tokens[i][j] = NormalizeName(name)
+		}
+	}
+	return l
+}
+
+// Screen matches subject against every entry of l and returns the matches, best first.
+// An entry matches when one of its names is at least t.Name similar to one of the subject's
+// and the subject's date of birth, if known, does not rule it out.
+func Screen(subject Subject, l *WatchList, t Thresholds) []Match {
+	if l == nil {
+		return nil
+	}
+	if l.tokens == nil {
+		l = NewWatchList(l.Entries, l.Version, l.LoadedAt)
+	}
+
+	names := make([][]string, 0, len(subject.Names))
+	for _, name := range subject.Names {
+		if tokens := NormalizeName(name); len(tokens) > 0 {
+			names = append(names, tokens)
+		}
+	}
+
+	var matches []Match
+	for i, e := range l.Entries {
+		dobMatched, ruledOut := compareDOB(subject.DateOfBirth, e.DatesOfBirth, t.DOBYearTolerance)
+		if ruledOut {
+			continue
+		}
+
+		best := Match{Score: -1}
+		for j, listed := range l.tokens[i] {
+			for k, own := range names {
+				if score := nameSimilarity(own, listed); score > best.Score {
+					best.Score = score
+					best.ListedName = e.Names[j]
+					best.MatchedName = subject.Names[k]
+				}
+			}
+		}
+		if best.Score < t.Name {
+			continue
+		}
+		best.Source = e.Source
+		best.EntryID = e.ID
+		best.Kind = e.Kind
+		best.DOBMatched = dobMatched
+		best.Programs = e.Programs
+		best.Score = float64(int(best.Score*1000+0.5)) / 1000
+		matches = append(matches, best)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].DOBMatched && !matches[j].DOBMatched
+	})
+	return matches
+}
+
+// NormalizeName transliterates a name to lowercase ASCII and splits it into words. Cyrillic
+// and Greek are transliterated, Latin diacritics dropped, and initials and punctuation
+// removed, so "ПУТИН, Владимир" and "Putin Vladimir" compare equal.
+func NormalizeName(name string) []string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if s, ok := transliterations[r]; ok {
+			b.WriteString(s)
+			continue
+		}
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+		default:
+			b.WriteByte(' ')
+		}
+	}
+
+	var tokens []string
+	for _, token := range strings.Fields(b.String()) {
+		if len(token) > 1 {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// nameSimilarity scores two tokenized names from 0 to 1, ignoring word order. Each word of
+// the shorter name is paired with its most similar unused word of the longer one, so a
+// listed middle name or patronymic does not count against the subject.
+func nameSimilarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	short, long := a, b
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+
+	used := make([]bool, len(long))
+	var sum float64
+	for _, word := range short {
+		best, bestIdx := 0.0, -1
+		for j, other := range long {
+			if used[j] {
+				continue
+			}
+			if score := jaroWinkler(word, other); score > best {
+				best, bestIdx = score, j
+			}
+		}
+		if bestIdx >= 0 {
+			used[bestIdx] = true
+		}
+		sum += best
+	}
+
+	score := sum / float64(len(short))
+	if len(short) == 1 && len(long) > 1 {
+		score *= singleTokenPenalty
+	}
+	return score
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of two ASCII words
+func jaroWinkler(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	window := max(len(a), len(b))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+	matches := 0
+	for i := 0; i < len(a); i++ {
+		lo, hi := max(0, i-window), min(len(b), i+window+1)
+		for j := lo; j < hi; j++ {
+			if !matchedB[j] && a[i] == b[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+	for i := 0; i < len(a); i++ {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(a), len(b)) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// compareDOB compares a subject's date of birth with an entry's. matched means a listed date
+// equals it (or a listed year-only date has its year); ruledOut means both are known and every
+// listed year is more than tolerance years away.
+func compareDOB(dob string, listed []string, tolerance int) (matched, ruledOut bool) {
+	year, ok := dobYear(dob)
+	if !ok || len(listed) == 0 {
+		return false, false
+	}
+
+	ruledOut = true
+	for _, l := range listed {
+		listedYear, ok := dobYear(l)
+		if !ok {
+			// An unparsable listed date cannot rule anything out
+			ruledOut = false
+			continue
+		}
+		if l == dob || (len(l) == 4 && listedYear == year) {
+			matched = true
+		}
+		if diff := listedYear - year; diff <= tolerance && diff >= -tolerance {
+			ruledOut = false
+		}
+	}
+	return matched, ruledOut
+}
+
+// dobYear returns the year of a "YYYY-MM-DD" or "YYYY" date
+func dobYear(dob string) (int, bool) {
+	if len(dob) < 4 {
+		return 0, false
+	}
+	year, err := strconv.Atoi(dob[:4])
+	if err != nil || (len(dob) > 4 && dob[4] != '-') {
+		return 0, false
+	}
+	return year, true
+}
+
+// transliterations maps non-ASCII letters to ASCII: Latin letters with diacritics, Cyrillic
+// (Russian, Ukrainian and Belarusian, close to BGN/PCGN) and Greek
+var transliterations = map[rune]string{
+	// Latin
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'ç': "c", 'ć': "c", 'ĉ': "c", 'ċ': "c", 'č': "c",
+	'ď': "d", 'đ': "d", 'ð': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ĕ': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'ĝ': "g", 'ğ': "g", 'ġ': "g", 'ģ': "g",
+	'ĥ': "h", 'ħ': "h",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ĩ': "i", 'ī': "i", 'ĭ': "i", 'į': "i", 'ı': "i",
+	'ĵ': "j", 'ķ': "k",
+	'ĺ': "l", 'ļ': "l", 'ľ': "l", 'ŀ': "l", 'ł': "l",
+	'ñ': "n", 'ń': "n", 'ņ': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ŏ': "o", 'ő': "o",
+	'ŕ': "r", 'ŗ': "r", 'ř': "r",
+	'ś': "s", 'ŝ': "s", 'ş': "s", 'š': "s", 'ș': "s",
+	'ţ': "t", 'ť': "t", 'ŧ': "t", 'ț': "t",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ũ': "u", 'ū': "u", 'ŭ': "u", 'ů': "u", 'ű': "u", 'ų': "u",
+	'ŵ': "w", 'ý': "y", 'ÿ': "y", 'ŷ': "y",
+	'ź': "z", 'ż': "z", 'ž': "z",
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'þ': "th",
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh",
+	'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i",
+	'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s",
+	'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+	'ά': "a", 'έ': "e", 'ή': "i", 'ί': "i", 'ό': "o", 'ύ': "y", 'ώ': "o",
+}
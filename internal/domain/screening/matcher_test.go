@@ -0,0 +1,130 @@
+package screening
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2025, 11, 20, 10, 0, 0, 0, time.UTC)
+
+func testList() *WatchList {
+	return NewWatchList([]Entry{
+		{Source: SourceOFACSDN, ID: "36", Kind: KindSanctions, Names: []string{"IVANOV, Sergei Borisovich"}, DatesOfBirth: []string{"1953-01-31"}, Programs: []string{"UKRAINE-EO13661"}},
+		{Source: SourceEUConsolidated, ID: "EU.27.28", Kind: KindSanctions, Names: []string{"Müller Hans-Jürgen", "Hans Mueller"}, DatesOfBirth: []string{"1970"}},
+		{Source: SourcePEP, ID: "pep-1", Kind: KindPEP, Names: []string{"Олег Петренко"}},
+	}, "v1", now)
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, []string{"ivanov", "sergei", "borisovich"}, NormalizeName("IVANOV, Sergei Borisovich"))
+	assert.Equal(t, []string{"muller", "hans", "jurgen"}, NormalizeName("Müller Hans-Jürgen"))
+	assert.Equal(t, []string{"oleg", "petrenko"}, NormalizeName("Олег Петренко"))
+	assert.Equal(t, []string{"strasse"}, NormalizeName("Straße"))
+	assert.Equal(t, []string{"smith"}, NormalizeName("J. Smith"), "initials are dropped")
+	assert.Empty(t, NormalizeName(" - "))
+}
+
+func TestJaroWinkler(t *testing.T) {
+	assert.Equal(t, 1.0, jaroWinkler("ivanov", "ivanov"))
+	assert.InDelta(t, 0.961, jaroWinkler("martha", "marhta"), 0.001)
+	assert.InDelta(t, 0.813, jaroWinkler("dixon", "dicksonx"), 0.001)
+	assert.Equal(t, 0.0, jaroWinkler("abc", "xyz"))
+}
+
+func TestScreen(t *testing.T) {
+	l := testList()
+	th := DefaultThresholds()
+
+	tests := []struct {
+		name    string
+		subject Subject
+		want    []string
+		dob     bool
+	}{
+		{"word order and patronymic", Subject{Names: []string{"Sergei Ivanov"}}, []string{"ofac_sdn:36"}, false},
+		{"transliteration variant", Subject{Names: []string{"Sergey Ivanov"}}, []string{"ofac_sdn:36"}, false},
+		{"matching date of birth", Subject{Names: []string{"Sergei Ivanov"}, DateOfBirth: "1953-01-31"}, []string{"ofac_sdn:36"}, true},
+		{"date of birth within tolerance", Subject{Names: []string{"Sergei Ivanov"}, DateOfBirth: "1954-06-01"}, []string{"ofac_sdn:36"}, false},
+		{"date of birth rules the entry out", Subject{Names: []string{"Sergei Ivanov"}, DateOfBirth: "1990-01-01"}, nil, false},
+		{"diacritics", Subject{Names: []string{"Hans Muller"}}, []string{"eu_consolidated:EU.27.28"}, false},
+		{"year-only date of birth", Subject{Names: []string{"Hans Mueller"}, DateOfBirth: "1970-05-05"}, []string{"eu_consolidated:EU.27.28"}, true},
+		{"cyrillic list entry", Subject{Names: []string{"Oleg Petrenko"}}, []string{"pep:pep-1"}, false},
+		{"different person", Subject{Names: []string{"Jane Doe"}}, nil, false},
+		{"shared surname only", Subject{Names: []string{"Anna Ivanova"}}, nil, false},
+		{"single name", Subject{Names: []string{"Ivanov"}}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.subject.UserID = uuid.New()
+			matches := Screen(tt.subject, l, th)
+			var keys []string
+			for _, m := range matches {
+				keys = append(keys, m.Key())
+			}
+			assert.Equal(t, tt.want, keys)
+			if len(matches) > 0 {
+				assert.Equal(t, tt.dob, matches[0].DOBMatched)
+				assert.GreaterOrEqual(t, matches[0].Score, th.Name)
+				assert.NotEmpty(t, matches[0].ListedName)
+				assert.Equal(t, tt.subject.Names[0], matches[0].MatchedName)
+			}
+		})
+	}
+}
+
+func TestScreen_Threshold(t *testing.T) {
+	l := testList()
+	subject := Subject{Names: []string{"Sergiu Ivanescu"}}
+
+	assert.Empty(t, Screen(subject, l, DefaultThresholds()))
+	assert.Len(t, Screen(subject, l, Thresholds{Name: 0.8, DOBYearTolerance: 1}), 1)
+	assert.Empty(t, Screen(Subject{Names: []string{"Sergei Ivanov"}, DateOfBirth: "1954-06-01"}, l, Thresholds{Name: 0.9}),
+		"a zero tolerance needs the same year")
+	assert.Nil(t, Screen(subject, nil, DefaultThresholds()))
+}
+
+func TestScreen_UnpreparedList(t *testing.T) {
+	l := &WatchList{Entries: testList().Entries}
+	assert.Len(t, Screen(Subject{Names: []string{"Sergei Ivanov"}}, l, DefaultThresholds()), 1)
+}
+
+func TestThresholds_Validate(t *testing.T) {
+	assert.NoError(t, DefaultThresholds().Validate())
+	assert.ErrorIs(t, Thresholds{Name: 0.3}.Validate(), ErrInvalidThresholds)
+	assert.ErrorIs(t, Thresholds{Name: 1.1}.Validate(), ErrInvalidThresholds)
+	assert.ErrorIs(t, Thresholds{Name: 0.9, DOBYearTolerance: -1}.Validate(), ErrInvalidThresholds)
+}
+
+func TestCase_Decide(t *testing.T) {
+	subject := Subject{UserID: uuid.New(), Names: []string{"Sergei Ivanov"}}
+	c := NewCase(subject, Screen(subject, testList(), DefaultThresholds()), TriggerRegistration, "v1", now)
+	assert.Equal(t, StatusOpen, c.Status)
+	assert.True(t, c.Status.BlocksApproval())
+
+	admin := uuid.New()
+	assert.ErrorIs(t, c.Clear(admin, "  ", now), ErrInvalidDecision)
+	require.NoError(t, c.Clear(admin, " different date of birth ", now.Add(time.Hour)))
+	assert.Equal(t, StatusCleared, c.Status)
+	assert.False(t, c.Status.BlocksApproval())
+	assert.Equal(t, "different date of birth", *c.ReviewNote)
+	assert.Equal(t, admin, *c.ReviewedBy)
+	assert.Equal(t, now.Add(time.Hour), c.UpdatedAt)
+
+	assert.ErrorIs(t, c.Confirm(admin, "changed my mind", now), ErrCaseAlreadyDecided)
+
+	other := NewCase(subject, nil, TriggerManual, "v1", now)
+	require.NoError(t, other.Confirm(admin, "passport matches the listing", now))
+	assert.Equal(t, StatusConfirmed, other.Status)
+	assert.True(t, other.Status.BlocksApproval())
+}
+
+func TestWatchList_Counts(t *testing.T) {
+	counts := testList().Counts()
+	assert.Equal(t, map[Source]int{SourceOFACSDN: 1, SourceEUConsolidated: 1, SourcePEP: 1}, counts)
+	var nilList *WatchList
+	assert.Empty(t, nilList.Counts())
+}
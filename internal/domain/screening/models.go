@@ -0,0 +1,212 @@
+// Package screening contains the sanctions and PEP screening domain model. Users are matched
+// against watch lists (sanctions lists such as OFAC SDN and the EU consolidated list, and
+// politically exposed persons); list entries a user matches are put in a review case:
+//
+//	open → cleared    (false positive)
+//	     → confirmed  (the user is the listed person)
+//
+// A user with an open or confirmed case cannot be KYC approved (see Gate). Entries already
+// reviewed for a user do not open new cases when the user is screened again.
+// Loading lists lives behind the watchlist package; case storage behind the Repository port.
+package screening
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Source is the list an entry comes from
+type Source string
+
+const (
+	// SourceOFACSDN is the US Treasury OFAC Specially Designated Nationals list
+	SourceOFACSDN Source = "ofac_sdn"
+	// SourceEUConsolidated is the EU consolidated financial sanctions list
+	SourceEUConsolidated Source = "eu_consolidated"
+	// SourcePEP is a list of politically exposed persons
+	SourcePEP Source = "pep"
+)
+
+// Kind is why an entry is listed
+type Kind string
+
+const (
+	KindSanctions Kind = "sanctions"
+	KindPEP       Kind = "pep"
+)
+
+// Entry is a listed person. Names holds the primary name followed by aliases; dates of
+// birth are "YYYY-MM-DD" or, when only the year is known, "YYYY". Programs are the sanctions
+// programmes, or for a PEP their country and position.
+type Entry struct {
+	Source       Source   `json:"source"`
+	ID           string   `json:"id"`
+	Kind         Kind     `json:"kind"`
+	Names        []string `json:"names"`
+	DatesOfBirth []string `json:"dates_of_birth,omitempty"`
+	Programs     []string `json:"programs,omitempty"`
+}
+
+// WatchList is the set of entries screened against. Version identifies the list files it
+// was loaded from, so cases record what they were screened against. Build it with
+// NewWatchList so entry names are normalized once rather than on every screening.
+type WatchList struct {
+	Entries  []Entry
+	Version  string
+	LoadedAt time.Time
+
+	// tokens holds the normalized words of each entry's names, indexed like Entries
+	tokens [][][]string
+}
+
+// Counts returns the number of entries per source
+func (l *WatchList) Counts() map[Source]int {
+	counts := make(map[Source]int)
+	if l == nil {
+		return counts
+	}
+	for _, e := range l.Entries {
+		counts[e.Source]++
+	}
+	return counts
+}
+
+// Subject is who is screened: the user's names (profile and KYC legal name) and, once they
+// gave it during KYC, their date of birth
+type Subject struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Names       []string  `json:"names"`
+	DateOfBirth string    `json:"date_of_birth,omitempty"`
+}
+
+// Match is a list entry a subject matched
+type Match struct {
+	Source      Source   `json:"source"`
+	EntryID     string   `json:"entry_id"`
+	Kind        Kind     `json:"kind"`
+	ListedName  string   `json:"listed_name"`
+	MatchedName string   `json:"matched_name"`
+	Score       float64  `json:"score"`
+	DOBMatched  bool     `json:"dob_matched"`
+	Programs    []string `json:"programs,omitempty"`
+}
+
+// Key identifies the matched entry across list versions
+func (m Match) Key() string {
+	return string(m.Source) + ":" + m.EntryID
+}
+
+// Status is where a case is in review
+type Status string
+
+const (
+	// StatusOpen cases wait for a compliance decision and block KYC approval
+	StatusOpen Status = "open"
+	// StatusCleared cases were false positives
+	StatusCleared Status = "cleared"
+	// StatusConfirmed cases matched the listed person; they keep blocking KYC approval
+	StatusConfirmed Status = "confirmed"
+)
+
+// IsValid reports whether s is a known case status
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusOpen, StatusCleared, StatusConfirmed:
+		return true
+	}
+	return false
+}
+
+// BlocksApproval reports whether a case in this status prevents KYC approval
+func (s Status) BlocksApproval() bool {
+	return s == StatusOpen || s == StatusConfirmed
+}
+
+// Trigger is why a user was screened
+type Trigger string
+
+const (
+	TriggerRegistration  Trigger = "registration"
+	TriggerProfileChange Trigger = "profile_change"
+	TriggerKYCApproval   Trigger = "kyc_approval"
+	TriggerRescreen      Trigger = "rescreen"
+	TriggerManual        Trigger = "manual"
+)
+
+// maxReviewNoteLength bounds the note an admin gives with a decision
+const maxReviewNoteLength = 2000
+
+// Case is the review of the list entries a user matched
+type Case struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Status      Status     `json:"status"`
+	Trigger     Trigger    `json:"trigger"`
+	Subject     Subject    `json:"subject"`
+	Matches     []Match    `json:"matches"`
+	ListVersion string     `json:"list_version"`
+	ReviewedBy  *uuid.UUID `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote  *string    `json:"review_note,omitempty"`
+	// Version increments on every update; updates of a stale copy fail with ErrCaseConflict
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewCase opens a case for the matches of subject
+func NewCase(subject Subject, matches []Match, trigger Trigger, listVersion string, now time.Time) *Case {
+	return &Case{
+		UserID:      subject.UserID,
+		Status:      StatusOpen,
+		Trigger:     trigger,
+		Subject:     subject,
+		Matches:     matches,
+		ListVersion: listVersion,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Clear closes the case as a false positive
+func (c *Case) Clear(actorID uuid.UUID, note string, now time.Time) error {
+	return c.decide(StatusCleared, actorID, note, now)
+}
+
+// Confirm closes the case as a true match; the user stays blocked from KYC approval
+func (c *Case) Confirm(actorID uuid.UUID, note string, now time.Time) error {
+	return c.decide(StatusConfirmed, actorID, note, now)
+}
+
+func (c *Case) decide(status Status, actorID uuid.UUID, note string, now time.Time) error {
+	if c.Status != StatusOpen {
+		return fmt.Errorf("%w: case is %s", ErrCaseAlreadyDecided, c.Status)
+	}
+	note = strings.TrimSpace(note)
+	if note == "" || len(note) > maxReviewNoteLength {
+		return fmt.Errorf("%w: a note of at most %d characters is required", ErrInvalidDecision, maxReviewNoteLength)
+	}
+	c.Status = status
+	c.ReviewedBy = &actorID
+	c.ReviewedAt = &now
+	c.ReviewNote = &note
+	c.UpdatedAt = now
+	return nil
+}
+
+// ListFilter narrows the review queue; nil fields match every case
+type ListFilter struct {
+	Status *Status
+	UserID *uuid.UUID
+	Limit  int32
+	Offset int32
+}
+
+// Actor is the admin deciding a case
+type Actor struct {
+	ID    uuid.UUID
+	Email string
+}
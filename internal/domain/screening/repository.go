@@ -0,0 +1,30 @@
+package screening
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for screening case persistence.
+// This interface is implemented by the infrastructure layer (repository package).
+type Repository interface {
+	// Create stores a new case and assigns its ID and version
+	Create(ctx context.Context, c *Case) (*Case, error)
+
+	// GetByID retrieves a case. Returns ErrCaseNotFound if it does not exist.
+	GetByID(ctx context.Context, id uuid.UUID) (*Case, error)
+
+	// List retrieves cases matching filter, oldest first so the queue is worked in order
+	List(ctx context.Context, filter ListFilter) ([]*Case, error)
+
+	// Count returns the number of cases matching filter, ignoring its limit and offset
+	Count(ctx context.Context, filter ListFilter) (int64, error)
+
+	// ListForUser retrieves every case of a user, newest first
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]*Case, error)
+
+	// Update stores a decided case if its version is unchanged and increments the version.
+	// Returns ErrCaseConflict if the case was modified since it was read.
+	Update(ctx context.Context, c *Case) (*Case, error)
+}
@@ -0,0 +1,45 @@
+package screening
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Service defines the business logic interface for screening.
+// This interface is implemented by the service layer.
+type Service interface {
+	Screener
+	Gate
+
+	// ListCases retrieves the review queue and the number of matching cases
+	ListCases(ctx context.Context, filter ListFilter) ([]*Case, int64, error)
+
+	// GetCase retrieves a case
+	GetCase(ctx context.Context, caseID uuid.UUID) (*Case, error)
+
+	// Clear closes a case as a false positive
+	Clear(ctx context.Context, caseID uuid.UUID, note string, actor Actor) (*Case, error)
+
+	// Confirm closes a case as a true match
+	Confirm(ctx context.Context, caseID uuid.UUID, note string, actor Actor) (*Case, error)
+
+	// WatchList returns the lists currently screened against, or nil before the first load
+	WatchList() *WatchList
+}
+
+// Screener screens users against the watch lists.
+// Used by the user service at registration and on profile changes.
+type Screener interface {
+	// ScreenUser matches the user against the lists and opens a case for entries not
+	// reviewed for them before. Returns the opened case, or nil when there is nothing new.
+	ScreenUser(ctx context.Context, userID uuid.UUID, trigger Trigger) (*Case, error)
+}
+
+// Gate answers whether screening blocks a user's KYC approval.
+// Used by the KYC service before approving a case.
+type Gate interface {
+	// ApprovalBlocked screens the user with their current data and reports whether an open
+	// or confirmed case blocks approval
+	ApprovalBlocked(ctx context.Context, userID uuid.UUID) (bool, error)
+}
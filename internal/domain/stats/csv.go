@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Dataset is a table of a dashboard that can be exported as CSV
+type Dataset string
+
+const (
+	// DatasetSeries is the series of daily or weekly statistics. It is the default.
+	DatasetSeries Dataset = "series"
+	// DatasetKYCFunnel is the KYC funnel by status
+	DatasetKYCFunnel Dataset = "kyc_funnel"
+	// DatasetSessionsByDevice is active sessions by device
+	DatasetSessionsByDevice Dataset = "sessions_by_device"
+)
+
+// IsValid reports whether d is a known dataset
+func (d Dataset) IsValid() bool {
+	switch d {
+	case DatasetSeries, DatasetKYCFunnel, DatasetSessionsByDevice:
+		return true
+	}
+	return false
+}
+
+// WriteCSV writes a dataset of a dashboard to w as CSV with a header row.
+// Returns ErrInvalidQuery for an unknown dataset.
+func WriteCSV(w io.Writer, d *Dashboard, dataset Dataset) error {
+	var header []string
+	var rows [][]string
+	switch dataset {
+	case DatasetSeries:
+		header = []string{"period_start", "registrations", "logins", "failed_logins", "kyc_approvals", "active_users", "monthly_active_users"}
+		for _, b := range d.Series {
+			rows = append(rows, []string{
+				b.Start.Format("2006-01-02"),
+				formatCount(b.Registrations),
+				formatCount(b.Logins),
+				formatCount(b.FailedLogins),
+				formatCount(b.KYCApprovals),
+				formatCount(b.ActiveUsers),
+				formatCount(b.MonthlyActiveUsers),
+			})
+		}
+	case DatasetKYCFunnel:
+		header = []string{"status", "users"}
+		for _, stage := range d.KYCFunnel {
+			rows = append(rows, []string{stage.Status, formatCount(stage.Users)})
+		}
+	case DatasetSessionsByDevice:
+		header = []string{"device", "sessions", "users"}
+		for _, s := range d.SessionsByDevice {
+			rows = append(rows, []string{string(s.Device), formatCount(s.Sessions), formatCount(s.Users)})
+		}
+	default:
+		return fmt.Errorf("%w: unknown dataset %q", ErrInvalidQuery, dataset)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func formatCount(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
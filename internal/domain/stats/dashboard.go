@@ -0,0 +1,126 @@
+package stats
+
+import "time"
+
+// Bucket holds the statistics of a point of a series: a UTC day, or an ISO week clipped to
+// the queried range
+type Bucket struct {
+	Start         time.Time
+	Registrations int64
+	Logins        int64
+	FailedLogins  int64
+	KYCApprovals  int64
+	// ActiveUsers is the DAU of a day, or the average DAU of the days of a week
+	ActiveUsers int64
+	// MonthlyActiveUsers is the MAU of the last day of the bucket
+	MonthlyActiveUsers int64
+}
+
+// Totals sums the daily counters over the range of a dashboard
+type Totals struct {
+	Registrations int64
+	Logins        int64
+	FailedLogins  int64
+	KYCApprovals  int64
+}
+
+// Dashboard holds the admin statistics of a range of days
+type Dashboard struct {
+	Query Query
+	// TotalUsers is the number of non-deleted users as of the last snapshot
+	TotalUsers int64
+	// ActiveSessions is the number of active sessions as of the last snapshot
+	ActiveSessions int64
+	// DailyActiveUsers and MonthlyActiveUsers are the DAU and MAU of the last day of the range
+	DailyActiveUsers   int64
+	MonthlyActiveUsers int64
+	Totals             Totals
+	Series             []Bucket
+	KYCFunnel          []FunnelStage
+	SessionsByDevice   []DeviceSessions
+	// RefreshedAt is when the snapshot was last refreshed; nil if it never was
+	RefreshedAt *time.Time
+}
+
+// BuildDashboard builds the dashboard of a normalized query from the daily statistics of its
+// range and the current snapshot. Days without statistics count as zero, and every funnel
+// stage and device is reported, in order, even when empty.
+func BuildDashboard(q Query, days []Daily, snapshot *Snapshot) *Dashboard {
+	byDay := make(map[time.Time]Daily, len(days))
+	for _, d := range days {
+		byDay[Day(d.Day)] = d
+	}
+
+	dashboard := &Dashboard{
+		Query:            q,
+		Series:           []Bucket{},
+		KYCFunnel:        make([]FunnelStage, 0, len(FunnelStatuses)),
+		SessionsByDevice: make([]DeviceSessions, 0, len(Devices)),
+	}
+
+	var bucket *Bucket
+	var bucketDays int64
+	for day := q.From; !day.After(q.To); day = day.AddDate(0, 0, 1) {
+		d := byDay[day]
+		start := day
+		if q.Granularity == GranularityWeek {
+			start = Week(day)
+			if start.Before(q.From) {
+				start = q.From
+			}
+		}
+		if bucket == nil || !bucket.Start.Equal(start) {
+			closeBucket(bucket, bucketDays)
+			dashboard.Series = append(dashboard.Series, Bucket{Start: start})
+			bucket = &dashboard.Series[len(dashboard.Series)-1]
+			bucketDays = 0
+		}
+		bucketDays++
+		bucket.Registrations += d.Registrations
+		bucket.Logins += d.Logins
+		bucket.FailedLogins += d.FailedLogins
+		bucket.KYCApprovals += d.KYCApprovals
+		bucket.ActiveUsers += d.ActiveUsers
+		bucket.MonthlyActiveUsers = d.MonthlyActiveUsers
+
+		dashboard.Totals.Registrations += d.Registrations
+		dashboard.Totals.Logins += d.Logins
+		dashboard.Totals.FailedLogins += d.FailedLogins
+		dashboard.Totals.KYCApprovals += d.KYCApprovals
+		dashboard.DailyActiveUsers = d.ActiveUsers
+		dashboard.MonthlyActiveUsers = d.MonthlyActiveUsers
+	}
+	closeBucket(bucket, bucketDays)
+
+	if snapshot == nil {
+		snapshot = &Snapshot{}
+	}
+	funnel := make(map[string]int64, len(snapshot.KYCFunnel))
+	for _, stage := range snapshot.KYCFunnel {
+		funnel[stage.Status] += stage.Users
+	}
+	for _, status := range FunnelStatuses {
+		dashboard.KYCFunnel = append(dashboard.KYCFunnel, FunnelStage{Status: status, Users: funnel[status]})
+		dashboard.TotalUsers += funnel[status]
+	}
+
+	devices := make(map[Device]DeviceSessions, len(snapshot.SessionsByDevice))
+	for _, d := range snapshot.SessionsByDevice {
+		devices[d.Device] = d
+	}
+	for _, device := range Devices {
+		d := devices[device]
+		dashboard.SessionsByDevice = append(dashboard.SessionsByDevice, DeviceSessions{Device: device, Sessions: d.Sessions, Users: d.Users})
+		dashboard.ActiveSessions += d.Sessions
+	}
+	dashboard.RefreshedAt = snapshot.RefreshedAt
+
+	return dashboard
+}
+
+// closeBucket turns the summed DAU of a bucket of days into their average
+func closeBucket(b *Bucket, days int64) {
+	if b != nil && days > 1 {
+		b.ActiveUsers = (b.ActiveUsers + days/2) / days
+	}
+}
@@ -0,0 +1,103 @@
+package stats_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// TestBuildDashboard_Daily tests that missing days count as zero and snapshots are complete.
+func TestBuildDashboard_Daily(t *testing.T) {
+	refreshedAt := date(11, 8).Add(time.Hour)
+	q := stats.Query{From: date(11, 3), To: date(11, 5), Granularity: stats.GranularityDay}
+	days := []stats.Daily{
+		{Day: date(11, 3), Registrations: 4, Logins: 10, FailedLogins: 2, KYCApprovals: 1, ActiveUsers: 8, MonthlyActiveUsers: 40},
+		{Day: date(11, 5), Registrations: 1, Logins: 6, ActiveUsers: 5, MonthlyActiveUsers: 42},
+	}
+	snapshot := &stats.Snapshot{
+		KYCFunnel: []stats.FunnelStage{
+			{Status: "approved", Users: 30},
+			{Status: stats.FunnelStatusNotStarted, Users: 15},
+		},
+		SessionsByDevice: []stats.DeviceSessions{
+			{Device: stats.DeviceMobile, Sessions: 7, Users: 5},
+			{Device: stats.DeviceDesktop, Sessions: 3, Users: 3},
+		},
+		RefreshedAt: &refreshedAt,
+	}
+
+	d := stats.BuildDashboard(q, days, snapshot)
+
+	require.Len(t, d.Series, 3)
+	assert.Equal(t, date(11, 4), d.Series[1].Start)
+	assert.Equal(t, stats.Bucket{Start: date(11, 4)}, d.Series[1])
+	assert.Equal(t, int64(8), d.Series[0].ActiveUsers)
+	assert.Equal(t, stats.Totals{Registrations: 5, Logins: 16, FailedLogins: 2, KYCApprovals: 1}, d.Totals)
+	assert.Equal(t, int64(5), d.DailyActiveUsers)
+	assert.Equal(t, int64(42), d.MonthlyActiveUsers)
+
+	require.Len(t, d.KYCFunnel, len(stats.FunnelStatuses))
+	assert.Equal(t, stats.FunnelStage{Status: stats.FunnelStatusNotStarted, Users: 15}, d.KYCFunnel[0])
+	assert.Equal(t, stats.FunnelStage{Status: "draft"}, d.KYCFunnel[1])
+	assert.Equal(t, int64(45), d.TotalUsers)
+
+	require.Len(t, d.SessionsByDevice, len(stats.Devices))
+	assert.Equal(t, stats.DeviceSessions{Device: stats.DeviceDesktop, Sessions: 3, Users: 3}, d.SessionsByDevice[0])
+	assert.Equal(t, int64(10), d.ActiveSessions)
+	assert.Equal(t, &refreshedAt, d.RefreshedAt)
+}
+
+// TestBuildDashboard_Weekly tests weekly buckets clipped to the range.
+func TestBuildDashboard_Weekly(t *testing.T) {
+	// Thursday 2025-10-30 to Tuesday 2025-11-11
+	q := stats.Query{From: date(10, 30), To: date(11, 11), Granularity: stats.GranularityWeek}
+	var days []stats.Daily
+	for day := q.From; !day.After(q.To); day = day.AddDate(0, 0, 1) {
+		days = append(days, stats.Daily{Day: day, Logins: 1, ActiveUsers: int64(day.Day()), MonthlyActiveUsers: int64(100 + day.Day())})
+	}
+
+	d := stats.BuildDashboard(q, days, nil)
+
+	require.Len(t, d.Series, 3)
+	assert.Equal(t, []time.Time{date(10, 30), date(11, 3), date(11, 10)},
+		[]time.Time{d.Series[0].Start, d.Series[1].Start, d.Series[2].Start})
+	assert.Equal(t, []int64{4, 7, 2}, []int64{d.Series[0].Logins, d.Series[1].Logins, d.Series[2].Logins})
+	// Average DAU: (30+31+1+2)/4 = 16, (3+...+9)/7 = 6, (10+11)/2 = 10.5
+	assert.Equal(t, []int64{16, 6, 11}, []int64{d.Series[0].ActiveUsers, d.Series[1].ActiveUsers, d.Series[2].ActiveUsers})
+	assert.Equal(t, int64(102), d.Series[0].MonthlyActiveUsers)
+	assert.Equal(t, int64(111), d.MonthlyActiveUsers)
+	assert.Equal(t, int64(13), d.Totals.Logins)
+	assert.Zero(t, d.TotalUsers)
+	assert.Nil(t, d.RefreshedAt)
+}
+
+// TestWriteCSV tests exporting each dataset.
+func TestWriteCSV(t *testing.T) {
+	q := stats.Query{From: date(11, 3), To: date(11, 4), Granularity: stats.GranularityDay}
+	d := stats.BuildDashboard(q, []stats.Daily{{Day: date(11, 3), Registrations: 2, Logins: 5, FailedLogins: 1, ActiveUsers: 3, MonthlyActiveUsers: 9}},
+		&stats.Snapshot{SessionsByDevice: []stats.DeviceSessions{{Device: stats.DeviceTablet, Sessions: 2, Users: 1}}})
+
+	var buf bytes.Buffer
+	require.NoError(t, stats.WriteCSV(&buf, d, stats.DatasetSeries))
+	assert.Equal(t, "period_start,registrations,logins,failed_logins,kyc_approvals,active_users,monthly_active_users\n"+
+		"2025-11-03,2,5,1,0,3,9\n"+
+		"2025-11-04,0,0,0,0,0,0\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, stats.WriteCSV(&buf, d, stats.DatasetKYCFunnel))
+	assert.Contains(t, buf.String(), "status,users\nnot_started,0\ndraft,0\n")
+
+	buf.Reset()
+	require.NoError(t, stats.WriteCSV(&buf, d, stats.DatasetSessionsByDevice))
+	assert.Equal(t, "device,sessions,users\ndesktop,0,0\nmobile,0,0\ntablet,2,1\nother,0,0\nunknown,0,0\n", buf.String())
+
+	assert.ErrorIs(t, stats.WriteCSV(&buf, d, "users"), stats.ErrInvalidQuery)
+}
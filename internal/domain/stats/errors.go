@@ -0,0 +1,12 @@
+package stats
+
+import "errors"
+
+// Domain-level errors for admin statistics.
+var (
+	// ErrInvalidQuery is returned when a statistics query fails validation.
+	ErrInvalidQuery = errors.New("invalid statistics query")
+
+	// ErrUnavailable is returned when no statistics repository is configured.
+	ErrUnavailable = errors.New("statistics are not available")
+)
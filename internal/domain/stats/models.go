@@ -0,0 +1,184 @@
+// Package stats contains the admin statistics domain model: daily user statistics rolled up
+// by a background job, the current KYC funnel and sessions by device, and the dashboard and
+// CSV export built from them. Dashboards only read the rollups; nothing here scans users,
+// sessions or audit logs.
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Granularity is the period each point of a statistics series covers
+type Granularity string
+
+const (
+	// GranularityDay has a point per UTC day. It is the default.
+	GranularityDay Granularity = "day"
+	// GranularityWeek has a point per ISO week, starting on Monday.
+	GranularityWeek Granularity = "week"
+)
+
+// IsValid reports whether g is a known granularity
+func (g Granularity) IsValid() bool {
+	switch g {
+	case GranularityDay, GranularityWeek:
+		return true
+	}
+	return false
+}
+
+// Limits of statistics queries.
+const (
+	DefaultRangeDays = 30
+	MaxRangeDays     = 366
+	// MAUWindowDays is the number of days, up to and including a day, that its monthly
+	// active users were active in.
+	MAUWindowDays = 30
+)
+
+// Query selects the days of a dashboard. Zero values select the last DefaultRangeDays days.
+type Query struct {
+	From        time.Time // first UTC day, inclusive
+	To          time.Time // last UTC day, inclusive
+	Granularity Granularity
+}
+
+// Normalize truncates the range to UTC days, applies defaults relative to now and validates
+// the query. Returns ErrInvalidQuery.
+func (q *Query) Normalize(now time.Time) error {
+	today := Day(now)
+	if q.To.IsZero() {
+		q.To = today
+	}
+	q.To = Day(q.To)
+	if q.From.IsZero() {
+		q.From = q.To.AddDate(0, 0, 1-DefaultRangeDays)
+	}
+	q.From = Day(q.From)
+
+	if q.To.After(today) {
+		return fmt.Errorf("%w: to must not be in the future", ErrInvalidQuery)
+	}
+	if q.From.After(q.To) {
+		return fmt.Errorf("%w: from must not be after to", ErrInvalidQuery)
+	}
+	if q.Days() > MaxRangeDays {
+		return fmt.Errorf("%w: range must be at most %d days", ErrInvalidQuery, MaxRangeDays)
+	}
+
+	if q.Granularity == "" {
+		q.Granularity = GranularityDay
+	}
+	if !q.Granularity.IsValid() {
+		return fmt.Errorf("%w: granularity must be day or week", ErrInvalidQuery)
+	}
+	return nil
+}
+
+// Days returns the number of days in the range of a normalized query
+func (q Query) Days() int {
+	return int(q.To.Sub(q.From).Hours()/24) + 1
+}
+
+// Day returns the start of the UTC day containing t
+func Day(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Week returns the start of the ISO week, a Monday, of the UTC day containing t
+func Week(t time.Time) time.Time {
+	day := Day(t)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// Daily holds the statistics of one UTC day
+type Daily struct {
+	Day           time.Time
+	Registrations int64
+	Logins        int64
+	FailedLogins  int64
+	KYCApprovals  int64
+	// ActiveUsers is the number of users who logged in or refreshed a session (DAU)
+	ActiveUsers int64
+	// MonthlyActiveUsers is the number of users active in the MAUWindowDays days ending on
+	// the day (MAU)
+	MonthlyActiveUsers int64
+	RefreshedAt        time.Time
+}
+
+// FunnelStatusNotStarted is the KYC funnel stage of users who never opened a KYC case
+const FunnelStatusNotStarted = "not_started"
+
+// FunnelStatuses are the stages of the KYC funnel in order: users without a case, then the
+// statuses of their latest case
+var FunnelStatuses = []string{
+	FunnelStatusNotStarted,
+	"draft",
+	"submitted",
+	"in_review",
+	"needs_more_info",
+	"approved",
+	"rejected",
+}
+
+// FunnelStage is the number of non-deleted users at a stage of the KYC funnel
+type FunnelStage struct {
+	Status string
+	Users  int64
+}
+
+// Device is the kind of device a session was opened from, classified from its user agent
+type Device string
+
+const (
+	DeviceDesktop Device = "desktop"
+	DeviceMobile  Device = "mobile"
+	DeviceTablet  Device = "tablet"
+	// DeviceOther covers API clients, bots and unrecognised user agents
+	DeviceOther Device = "other"
+	// DeviceUnknown covers sessions without a user agent
+	DeviceUnknown Device = "unknown"
+)
+
+// Devices are the device kinds in the order they are reported
+var Devices = []Device{DeviceDesktop, DeviceMobile, DeviceTablet, DeviceOther, DeviceUnknown}
+
+// DeviceSessions is the number of active sessions from a kind of device and of users holding them
+type DeviceSessions struct {
+	Device   Device
+	Sessions int64
+	Users    int64
+}
+
+// Snapshot holds the current KYC funnel and active sessions by device as of their last refresh
+type Snapshot struct {
+	KYCFunnel        []FunnelStage
+	SessionsByDevice []DeviceSessions
+	// RefreshedAt is when the snapshot was computed; nil if it never was
+	RefreshedAt *time.Time
+}
+
+// Repository maintains and reads the statistics rollups
+type Repository interface {
+	// RecordActivity marks the users issued a refresh token, on login or refresh, at or after
+	// since as active on the UTC day it was issued
+	RecordActivity(ctx context.Context, since time.Time) (int64, error)
+	// RefreshDaily recomputes the daily statistics of the UTC days from from to to inclusive
+	// from the recorded activity, users, audit logs and KYC cases
+	RefreshDaily(ctx context.Context, from, to time.Time) (int64, error)
+	// RefreshSnapshot recomputes the KYC funnel and sessions by device
+	RefreshSnapshot(ctx context.Context) error
+	// PruneActivity deletes the activity of days before before
+	PruneActivity(ctx context.Context, before time.Time) (int64, error)
+	// LatestDay returns the last day with daily statistics, or nil if there are none
+	LatestDay(ctx context.Context) (*time.Time, error)
+	// ListDaily lists the daily statistics from from to to inclusive in order. Days never
+	// refreshed are missing.
+	ListDaily(ctx context.Context, from, to time.Time) ([]Daily, error)
+	// GetSnapshot returns the KYC funnel and sessions by device as of their last refresh
+	GetSnapshot(ctx context.Context) (*Snapshot, error)
+}
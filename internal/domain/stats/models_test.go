@@ -0,0 +1,70 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQuery_Normalize tests statistics query defaults and validation.
+func TestQuery_Normalize(t *testing.T) {
+	now := time.Date(2025, 11, 8, 15, 30, 0, 0, time.UTC)
+	today := time.Date(2025, 11, 8, 0, 0, 0, 0, time.UTC)
+
+	t.Run("defaults", func(t *testing.T) {
+		q := stats.Query{}
+		require.NoError(t, q.Normalize(now))
+		assert.Equal(t, today, q.To)
+		assert.Equal(t, today.AddDate(0, 0, 1-stats.DefaultRangeDays), q.From)
+		assert.Equal(t, stats.DefaultRangeDays, q.Days())
+		assert.Equal(t, stats.GranularityDay, q.Granularity)
+	})
+
+	t.Run("truncates to UTC days", func(t *testing.T) {
+		berlin := time.FixedZone("CET", 3600)
+		q := stats.Query{
+			From: time.Date(2025, 11, 1, 0, 30, 0, 0, berlin),
+			To:   time.Date(2025, 11, 7, 23, 0, 0, 0, time.UTC),
+		}
+		require.NoError(t, q.Normalize(now))
+		assert.Equal(t, time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC), q.From)
+		assert.Equal(t, time.Date(2025, 11, 7, 0, 0, 0, 0, time.UTC), q.To)
+		assert.Equal(t, 8, q.Days())
+	})
+
+	tests := []struct {
+		name  string
+		query stats.Query
+		valid bool
+	}{
+		{"single day", stats.Query{From: today, To: today}, true},
+		{"weekly", stats.Query{Granularity: stats.GranularityWeek}, true},
+		{"longest range", stats.Query{From: today.AddDate(0, 0, 1-stats.MaxRangeDays), To: today}, true},
+		{"range too long", stats.Query{From: today.AddDate(0, 0, -stats.MaxRangeDays), To: today}, false},
+		{"from after to", stats.Query{From: today, To: today.AddDate(0, 0, -1)}, false},
+		{"to in the future", stats.Query{To: today.AddDate(0, 0, 1)}, false},
+		{"unknown granularity", stats.Query{Granularity: "month"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Normalize(now)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, stats.ErrInvalidQuery)
+			}
+		})
+	}
+}
+
+// TestWeek tests that weeks start on Monday.
+func TestWeek(t *testing.T) {
+	monday := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 7; day++ {
+		assert.Equal(t, monday, stats.Week(monday.AddDate(0, 0, day).Add(13*time.Hour)))
+	}
+	assert.Equal(t, monday.AddDate(0, 0, 7), stats.Week(monday.AddDate(0, 0, 7)))
+}
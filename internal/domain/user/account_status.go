@@ -0,0 +1,144 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountStatus restricts what a user may do without deleting their account.
+type AccountStatus string
+
+const (
+	// AccountStatusActive is the status of accounts without restrictions.
+	AccountStatusActive AccountStatus = "active"
+	// AccountStatusSuspended blocks logging in and using existing sessions.
+	AccountStatusSuspended AccountStatus = "suspended"
+	// AccountStatusFrozenWithdrawals lets the user log in but blocks withdrawals, which are
+	// enforced by the services moving funds.
+	AccountStatusFrozenWithdrawals AccountStatus = "frozen_withdrawals"
+	// AccountStatusClosed blocks logging in for good; unlike deletion the account is kept.
+	AccountStatusClosed AccountStatus = "closed"
+)
+
+// MaxStatusReasonLength bounds the reason given for a status change.
+const MaxStatusReasonLength = 500
+
+// StatusReasonExpired is the reason recorded when a status is lifted on expiry.
+const StatusReasonExpired = "expired"
+
+// IsValid checks if the account status is one of the allowed values.
+func (s AccountStatus) IsValid() bool {
+	switch s {
+	case AccountStatusActive, AccountStatusSuspended, AccountStatusFrozenWithdrawals, AccountStatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of AccountStatus.
+func (s AccountStatus) String() string {
+	return string(s)
+}
+
+// CanExpire reports whether the status may be set with an expiry.
+func (s AccountStatus) CanExpire() bool {
+	return s == AccountStatusSuspended || s == AccountStatusFrozenWithdrawals
+}
+
+// AllowsLogin reports whether users with the status can log in and use their sessions.
+func (s AccountStatus) AllowsLogin() bool {
+	return s == AccountStatusActive || s == AccountStatusFrozenWithdrawals
+}
+
+// AllowsWithdrawals reports whether users with the status can withdraw funds.
+func (s AccountStatus) AllowsWithdrawals() bool {
+	return s == AccountStatusActive
+}
+
+// ParseAccountStatuses parses a comma-separated list of account statuses, e.g. from
+// configuration. Empty entries are ignored.
+func ParseAccountStatuses(list string) ([]AccountStatus, error) {
+	var statuses []AccountStatus
+	for _, part := range strings.Split(list, ",") {
+		status := AccountStatus(strings.TrimSpace(part))
+		if status == "" {
+			continue
+		}
+		if !status.IsValid() {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAccountStatus, status)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// EffectiveStatus returns the user's account status at now. A suspension or freeze whose
+// expiry has passed no longer applies, even before the expiry job has lifted it.
+func (u *User) EffectiveStatus(now time.Time) AccountStatus {
+	if u.Status == "" {
+		return AccountStatusActive
+	}
+	if u.StatusExpiresAt != nil && !now.Before(*u.StatusExpiresAt) {
+		return AccountStatusActive
+	}
+	return u.Status
+}
+
+// CheckAccess returns ErrAccountSuspended or ErrAccountClosed if the user's status at now
+// does not let them log in or use their sessions.
+func (u *User) CheckAccess(now time.Time) error {
+	switch u.EffectiveStatus(now) {
+	case AccountStatusSuspended:
+		return ErrAccountSuspended
+	case AccountStatusClosed:
+		return ErrAccountClosed
+	default:
+		return nil
+	}
+}
+
+// AccountStatusUpdate is an admin's request to change a user's account status.
+type AccountStatusUpdate struct {
+	Status    AccountStatus
+	Reason    string
+	ExpiresAt *time.Time // optional; only for suspensions and freezes
+}
+
+// Validate checks the update at now: a known status, a reason of at most
+// MaxStatusReasonLength characters and, if given, an expiry in the future on a status that
+// can expire.
+func (u AccountStatusUpdate) Validate(now time.Time) error {
+	if !u.Status.IsValid() {
+		return fmt.Errorf("%w: %q", ErrInvalidAccountStatus, u.Status)
+	}
+	reason := strings.TrimSpace(u.Reason)
+	if reason == "" || len(reason) > MaxStatusReasonLength {
+		return fmt.Errorf("%w: a reason of at most %d characters is required", ErrInvalidAccountStatus, MaxStatusReasonLength)
+	}
+	if u.ExpiresAt != nil {
+		if !u.Status.CanExpire() {
+			return fmt.Errorf("%w: %s does not expire", ErrInvalidAccountStatus, u.Status)
+		}
+		if !u.ExpiresAt.After(now) {
+			return fmt.Errorf("%w: expiry must be in the future", ErrInvalidAccountStatus)
+		}
+	}
+	return nil
+}
+
+// AccountStatusChange is an entry of a user's account status history.
+type AccountStatusChange struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	OldStatus       AccountStatus
+	NewStatus       AccountStatus
+	Reason          string
+	ExpiresAt       *time.Time
+	ChangedBy       *uuid.UUID // nil when lifted on expiry
+	SessionsRevoked bool
+	CreatedAt       time.Time
+}
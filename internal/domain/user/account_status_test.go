@@ -0,0 +1,94 @@
+package user_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAccountStatus tests what each account status allows.
+func TestAccountStatus(t *testing.T) {
+	tests := []struct {
+		status      user.AccountStatus
+		login       bool
+		withdrawals bool
+		canExpire   bool
+	}{
+		{user.AccountStatusActive, true, true, false},
+		{user.AccountStatusFrozenWithdrawals, true, false, true},
+		{user.AccountStatusSuspended, false, false, true},
+		{user.AccountStatusClosed, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			assert.True(t, tt.status.IsValid())
+			assert.Equal(t, tt.login, tt.status.AllowsLogin())
+			assert.Equal(t, tt.withdrawals, tt.status.AllowsWithdrawals())
+			assert.Equal(t, tt.canExpire, tt.status.CanExpire())
+		})
+	}
+	assert.False(t, user.AccountStatus("banned").IsValid())
+}
+
+// TestUser_EffectiveStatus tests that expired statuses no longer apply.
+func TestUser_EffectiveStatus(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Second)
+	future := now.Add(time.Hour)
+
+	assert.Equal(t, user.AccountStatusActive, (&user.User{}).EffectiveStatus(now))
+	assert.Equal(t, user.AccountStatusSuspended, (&user.User{Status: user.AccountStatusSuspended}).EffectiveStatus(now))
+	assert.Equal(t, user.AccountStatusSuspended, (&user.User{Status: user.AccountStatusSuspended, StatusExpiresAt: &future}).EffectiveStatus(now))
+	assert.Equal(t, user.AccountStatusActive, (&user.User{Status: user.AccountStatusSuspended, StatusExpiresAt: &past}).EffectiveStatus(now))
+	assert.Equal(t, user.AccountStatusActive, (&user.User{Status: user.AccountStatusSuspended, StatusExpiresAt: &now}).EffectiveStatus(now))
+
+	assert.ErrorIs(t, (&user.User{Status: user.AccountStatusSuspended}).CheckAccess(now), user.ErrAccountSuspended)
+	assert.ErrorIs(t, (&user.User{Status: user.AccountStatusClosed}).CheckAccess(now), user.ErrAccountClosed)
+	assert.NoError(t, (&user.User{Status: user.AccountStatusFrozenWithdrawals}).CheckAccess(now))
+}
+
+// TestAccountStatusUpdate_Validate tests validation of admin status changes.
+func TestAccountStatusUpdate_Validate(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		update user.AccountStatusUpdate
+		valid  bool
+	}{
+		{"suspension", user.AccountStatusUpdate{Status: user.AccountStatusSuspended, Reason: "fraud review"}, true},
+		{"expiring freeze", user.AccountStatusUpdate{Status: user.AccountStatusFrozenWithdrawals, Reason: "review", ExpiresAt: &future}, true},
+		{"reactivation", user.AccountStatusUpdate{Status: user.AccountStatusActive, Reason: "review cleared"}, true},
+		{"unknown status", user.AccountStatusUpdate{Status: "banned", Reason: "spam"}, false},
+		{"missing reason", user.AccountStatusUpdate{Status: user.AccountStatusSuspended, Reason: " "}, false},
+		{"long reason", user.AccountStatusUpdate{Status: user.AccountStatusSuspended, Reason: strings.Repeat("x", user.MaxStatusReasonLength+1)}, false},
+		{"expiring closure", user.AccountStatusUpdate{Status: user.AccountStatusClosed, Reason: "requested", ExpiresAt: &future}, false},
+		{"expiry in the past", user.AccountStatusUpdate{Status: user.AccountStatusSuspended, Reason: "review", ExpiresAt: &past}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.update.Validate(now)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, user.ErrInvalidAccountStatus)
+			}
+		})
+	}
+}
+
+// TestParseAccountStatuses tests parsing configured status lists.
+func TestParseAccountStatuses(t *testing.T) {
+	statuses, err := user.ParseAccountStatuses(" suspended, closed ,")
+	require.NoError(t, err)
+	assert.Equal(t, []user.AccountStatus{user.AccountStatusSuspended, user.AccountStatusClosed}, statuses)
+
+	_, err = user.ParseAccountStatuses("suspended,banned")
+	assert.ErrorIs(t, err, user.ErrInvalidAccountStatus)
+}
@@ -31,4 +31,61 @@ var (
 
 	// ErrInvalidInput is returned when input validation fails.
 	ErrInvalidInput = errors.New("invalid input")
+
+	// ErrInvalidProfile is returned when a profile field fails validation.
+	ErrInvalidProfile = errors.New("invalid profile")
+
+	// ErrUnderage is returned when a date of birth makes the user younger than MinimumAge.
+	ErrUnderage = errors.New("user is under the minimum age")
+
+	// ErrPhoneNotSet is returned when verifying a phone number the user has not given.
+	ErrPhoneNotSet = errors.New("phone number not set")
+
+	// ErrPhoneAlreadyVerified is returned when the user's phone number is already verified.
+	ErrPhoneAlreadyVerified = errors.New("phone number already verified")
+
+	// ErrPhoneVerificationNotFound is returned when no code is pending for the user's phone.
+	ErrPhoneVerificationNotFound = errors.New("no phone verification pending")
+
+	// ErrInvalidPhoneCode is returned when a phone verification code is wrong or expired.
+	ErrInvalidPhoneCode = errors.New("invalid or expired phone verification code")
+
+	// ErrPhoneVerificationThrottled is returned when codes are requested or tried too often.
+	ErrPhoneVerificationThrottled = errors.New("too many phone verification requests")
+
+	// ErrPhoneVerificationUnavailable is returned when no phone code sender is configured.
+	ErrPhoneVerificationUnavailable = errors.New("phone verification is not available")
+
+	// ErrNotDeleted is returned when restoring a user that is not deleted.
+	ErrNotDeleted = errors.New("user is not deleted")
+
+	// ErrRestoreWindowExpired is returned when restoring a user after the grace period, or
+	// once they have been erased.
+	ErrRestoreWindowExpired = errors.New("account can no longer be restored")
+
+	// ErrInvalidRestoreToken is returned when a restore link is forged, expired or for an
+	// earlier deletion.
+	ErrInvalidRestoreToken = errors.New("invalid or expired restore link")
+
+	// ErrAccountRestoreUnavailable is returned when no restore grace period is configured.
+	ErrAccountRestoreUnavailable = errors.New("account restore is not available")
+
+	// ErrAccountSuspended is returned when a suspended user logs in or uses a session.
+	ErrAccountSuspended = errors.New("account is suspended")
+
+	// ErrAccountClosed is returned when a user of a closed account logs in or uses a session.
+	ErrAccountClosed = errors.New("account is closed")
+
+	// ErrInvalidAccountStatus is returned when an account status change fails validation.
+	ErrInvalidAccountStatus = errors.New("invalid account status")
+
+	// ErrInvalidSearch is returned when an admin user search has invalid filters, sort or limit.
+	ErrInvalidSearch = errors.New("invalid user search")
+
+	// ErrInvalidSearchCursor is returned when a search cursor is malformed or was returned for
+	// a different query, filters or sort.
+	ErrInvalidSearchCursor = errors.New("invalid search cursor")
+
+	// ErrPIIEncryptionDisabled is returned when re-encrypting PII without field encryption configured.
+	ErrPIIEncryptionDisabled = errors.New("PII field encryption is not enabled")
 )
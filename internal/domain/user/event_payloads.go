@@ -0,0 +1,257 @@
+package user
+
+import (
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+)
+
+// Typed payloads of the user events. The JSON Schema of each payload is generated from
+// these structs; changing a field in a way that could break consumers requires bumping
+// the payload's SchemaVersion.
+
+// RegisteredPayload is the data of user.registered
+type RegisteredPayload struct {
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Role      Role   `json:"role"`
+}
+
+// EventType returns user.registered
+func (RegisteredPayload) EventType() string { return string(EventTypeUserRegistered) }
+
+// SchemaVersion returns the payload schema version
+func (RegisteredPayload) SchemaVersion() int { return 1 }
+
+// KYCUpdatedPayload is the data of user.kyc.updated
+type KYCUpdatedPayload struct {
+	Email     string    `json:"email"`
+	KYCStatus KYCStatus `json:"kyc_status"`
+	// OldStatus is the status before the update; empty when unknown
+	OldStatus KYCStatus `json:"old_status"`
+}
+
+// EventType returns user.kyc.updated
+func (KYCUpdatedPayload) EventType() string { return string(EventTypeUserKYCUpdated) }
+
+// SchemaVersion returns the payload schema version
+func (KYCUpdatedPayload) SchemaVersion() int { return 1 }
+
+// EntitlementsChangedPayload is the data of user.entitlements.changed. It carries the
+// entitlements in force after the change; limits are decimal amounts in Currency and an
+// empty limit means unlimited.
+type EntitlementsChangedPayload struct {
+	Tier                 int      `json:"tier"`
+	OldTier              int      `json:"old_tier"`
+	TierName             string   `json:"tier_name"`
+	Currency             string   `json:"currency"`
+	DailyDepositLimit    string   `json:"daily_deposit_limit"`
+	DailyWithdrawalLimit string   `json:"daily_withdrawal_limit"`
+	Products             []string `json:"products"`
+	Jurisdictions        []string `json:"jurisdictions"`
+	// Reason is why the entitlements changed, such as kyc_approved or an admin's note
+	Reason string `json:"reason"`
+}
+
+// EventType returns user.entitlements.changed
+func (EntitlementsChangedPayload) EventType() string { return string(EventTypeUserEntitlementsChanged) }
+
+// SchemaVersion returns the payload schema version
+func (EntitlementsChangedPayload) SchemaVersion() int { return 1 }
+
+// ProfileUpdatedPayload is the data of user.profile.updated
+type ProfileUpdatedPayload struct {
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	// ChangedFields names the profile fields the update changed (FieldFirstName, ...). Values
+	// of fields other than the names are not included.
+	ChangedFields []string `json:"changed_fields"`
+}
+
+// EventType returns user.profile.updated
+func (ProfileUpdatedPayload) EventType() string { return string(EventTypeUserProfileUpdated) }
+
+// SchemaVersion returns the payload schema version
+func (ProfileUpdatedPayload) SchemaVersion() int { return 1 }
+
+// DeletedPayload is the data of user.deleted
+type DeletedPayload struct {
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// EventType returns user.deleted
+func (DeletedPayload) EventType() string { return string(EventTypeUserDeleted) }
+
+// SchemaVersion returns the payload schema version
+func (DeletedPayload) SchemaVersion() int { return 1 }
+
+// RestoredPayload is the data of user.restored, published when a deleted account is
+// restored within the grace period. RestoredBy is "user" for restore links and "admin".
+type RestoredPayload struct {
+	DeletedAt  time.Time `json:"deleted_at"`
+	RestoredAt time.Time `json:"restored_at"`
+	RestoredBy string    `json:"restored_by"`
+}
+
+// EventType returns user.restored
+func (RestoredPayload) EventType() string { return string(EventTypeUserRestored) }
+
+// SchemaVersion returns the payload schema version
+func (RestoredPayload) SchemaVersion() int { return 1 }
+
+// ErasedPayload is the data of user.erased, published once a deleted user's PII has been
+// erased. Consumers holding copies of the user's PII must delete them; Pseudonym is what
+// now stands for the user in the audit history.
+type ErasedPayload struct {
+	ErasedAt  time.Time `json:"erased_at"`
+	Pseudonym string    `json:"pseudonym"`
+}
+
+// EventType returns user.erased
+func (ErasedPayload) EventType() string { return string(EventTypeUserErased) }
+
+// SchemaVersion returns the payload schema version
+func (ErasedPayload) SchemaVersion() int { return 1 }
+
+// StatusChangedPayload is the data of user.status.changed, published when an admin changes
+// a user's account status or a suspension or freeze expires. ChangedBy is the admin's ID,
+// empty on expiry.
+type StatusChangedPayload struct {
+	Status    AccountStatus `json:"status"`
+	OldStatus AccountStatus `json:"old_status"`
+	Reason    string        `json:"reason"`
+	// ExpiresAt is when the new status ends, if it expires
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	ChangedBy       string     `json:"changed_by"`
+	SessionsRevoked bool       `json:"sessions_revoked"`
+}
+
+// EventType returns user.status.changed
+func (StatusChangedPayload) EventType() string { return string(EventTypeUserStatusChanged) }
+
+// SchemaVersion returns the payload schema version
+func (StatusChangedPayload) SchemaVersion() int { return 1 }
+
+// LoggedInPayload is the data of user.logged_in
+type LoggedInPayload struct {
+	Email     string `json:"email"`
+	IPAddress string `json:"ip_address"`
+	UserAgent string `json:"user_agent"`
+}
+
+// EventType returns user.logged_in
+func (LoggedInPayload) EventType() string { return string(EventTypeUserLoggedIn) }
+
+// SchemaVersion returns the payload schema version
+func (LoggedInPayload) SchemaVersion() int { return 1 }
+
+// PasswordChangedPayload is the data of user.password.changed
+type PasswordChangedPayload struct {
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// EventType returns user.password.changed
+func (PasswordChangedPayload) EventType() string { return string(EventTypeUserPasswordChanged) }
+
+// SchemaVersion returns the payload schema version
+func (PasswordChangedPayload) SchemaVersion() int { return 1 }
+
+// LoggedOutPayload is the data of user.logged_out, published when a user ends one session
+type LoggedOutPayload struct {
+	LoggedOutAt time.Time `json:"logged_out_at"`
+}
+
+// EventType returns user.logged_out
+func (LoggedOutPayload) EventType() string { return string(EventTypeUserLoggedOut) }
+
+// SchemaVersion returns the payload schema version
+func (LoggedOutPayload) SchemaVersion() int { return 1 }
+
+// SessionsRevokedPayload is the data of user.sessions.revoked, published when a user ends
+// all of their sessions
+type SessionsRevokedPayload struct {
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+// EventType returns user.sessions.revoked
+func (SessionsRevokedPayload) EventType() string { return string(EventTypeUserSessionsRevoked) }
+
+// SchemaVersion returns the payload schema version
+func (SessionsRevokedPayload) SchemaVersion() int { return 1 }
+
+// RoleChangedPayload is the data of user.role.changed
+type RoleChangedPayload struct {
+	Email string `json:"email"`
+	Role  Role   `json:"role"`
+}
+
+// EventType returns user.role.changed
+func (RoleChangedPayload) EventType() string { return string(EventTypeUserRoleChanged) }
+
+// SchemaVersion returns the payload schema version
+func (RoleChangedPayload) SchemaVersion() int { return 1 }
+
+// AdminLoggedInPayload is the data of admin.logged_in
+type AdminLoggedInPayload struct {
+	Email     string `json:"email"`
+	Role      Role   `json:"role"`
+	IPAddress string `json:"ip_address"`
+	UserAgent string `json:"user_agent"`
+}
+
+// EventType returns admin.logged_in
+func (AdminLoggedInPayload) EventType() string { return string(EventTypeAdminLoggedIn) }
+
+// SchemaVersion returns the payload schema version
+func (AdminLoggedInPayload) SchemaVersion() int { return 1 }
+
+// SessionForceRevokedPayload is the data of session.force_revoked, published when an admin
+// ends one of a user's sessions
+type SessionForceRevokedPayload struct {
+	RevokedAt time.Time `json:"revoked_at"`
+	// SessionIPAddress and SessionUserAgent identify the client the session was issued to
+	SessionIPAddress string `json:"session_ip_address"`
+	SessionUserAgent string `json:"session_user_agent"`
+}
+
+// EventType returns session.force_revoked
+func (SessionForceRevokedPayload) EventType() string { return string(EventTypeSessionForceRevoked) }
+
+// SchemaVersion returns the payload schema version
+func (SessionForceRevokedPayload) SchemaVersion() int { return 1 }
+
+// TokenRefreshedPayload is the data of token.refreshed
+type TokenRefreshedPayload struct {
+	IPAddress string `json:"ip_address"`
+	UserAgent string `json:"user_agent"`
+	// ExpiresAt is when the newly issued refresh token expires
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// EventType returns token.refreshed
+func (TokenRefreshedPayload) EventType() string { return string(EventTypeTokenRefreshed) }
+
+// SchemaVersion returns the payload schema version
+func (TokenRefreshedPayload) SchemaVersion() int { return 1 }
+
+// Compile-time checks that every payload implements common.EventData
+var (
+	_ common.EventData = RegisteredPayload{}
+	_ common.EventData = KYCUpdatedPayload{}
+	_ common.EventData = EntitlementsChangedPayload{}
+	_ common.EventData = ProfileUpdatedPayload{}
+	_ common.EventData = DeletedPayload{}
+	_ common.EventData = RestoredPayload{}
+	_ common.EventData = ErasedPayload{}
+	_ common.EventData = StatusChangedPayload{}
+	_ common.EventData = LoggedInPayload{}
+	_ common.EventData = PasswordChangedPayload{}
+	_ common.EventData = LoggedOutPayload{}
+	_ common.EventData = SessionsRevokedPayload{}
+	_ common.EventData = RoleChangedPayload{}
+	_ common.EventData = AdminLoggedInPayload{}
+	_ common.EventData = SessionForceRevokedPayload{}
+	_ common.EventData = TokenRefreshedPayload{}
+)
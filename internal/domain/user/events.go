@@ -3,6 +3,7 @@ package user
 import (
 	"time"
 
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
 	"github.com/google/uuid"
 )
 
@@ -15,32 +16,63 @@ const (
 	EventTypeUserKYCUpdated      EventType = "user.kyc.updated"
 	EventTypeUserProfileUpdated  EventType = "user.profile.updated"
 	EventTypeUserDeleted         EventType = "user.deleted"
+	EventTypeUserRestored        EventType = "user.restored"
+	EventTypeUserErased          EventType = "user.erased"
+	EventTypeUserStatusChanged   EventType = "user.status.changed"
 	EventTypeUserLoggedIn        EventType = "user.logged_in"
 	EventTypeUserPasswordChanged EventType = "user.password.changed"
+
+	// Session and access events
+	EventTypeUserLoggedOut       EventType = "user.logged_out"
+	EventTypeUserSessionsRevoked EventType = "user.sessions.revoked"
+	EventTypeUserRoleChanged     EventType = "user.role.changed"
+	EventTypeAdminLoggedIn       EventType = "admin.logged_in"
+	EventTypeSessionForceRevoked EventType = "session.force_revoked"
+	EventTypeTokenRefreshed      EventType = "token.refreshed"
+
+	// Entitlement events
+	EventTypeUserEntitlementsChanged EventType = "user.entitlements.changed"
 )
 
+// AggregateType identifies user events in the outbox; events are relayed in order per user
+const AggregateType = "user"
+
+// Compile-time check to ensure Event carries the CloudEvents attributes
+var _ common.VersionedEnvelope = (*Event)(nil)
+
 // Event represents a domain event that occurred in the user domain
 type Event struct {
-	ID        string                 `json:"id"`        // Unique event ID
-	Type      EventType              `json:"type"`      // Event type
-	Timestamp time.Time              `json:"timestamp"` // When the event occurred
-	UserID    uuid.UUID              `json:"user_id"`   // User associated with the event
-	Payload   map[string]interface{} `json:"payload"`   // Event-specific data
-	Metadata  map[string]string      `json:"metadata"`  // Additional metadata (IP, user agent, etc.)
+	ID            string                 `json:"id"`             // Unique event ID
+	Type          EventType              `json:"type"`           // Event type
+	Timestamp     time.Time              `json:"timestamp"`      // When the event occurred
+	UserID        uuid.UUID              `json:"user_id"`        // User associated with the event
+	SchemaVersion int                    `json:"schema_version"` // Version of the payload schema
+	Payload       map[string]interface{} `json:"payload"`        // Event-specific data
+	Metadata      map[string]string      `json:"metadata"`       // Additional metadata (IP, user agent, etc.)
 }
 
-// NewEvent creates a new user domain event
+// NewEvent creates a new user domain event from an untyped payload at schema version 1.
+// Prefer NewTypedEvent, which keeps the payload in line with its published schema.
 func NewEvent(eventType EventType, userID uuid.UUID, payload map[string]interface{}) *Event {
 	return &Event{
-		ID:        uuid.New().String(),
-		Type:      eventType,
-		Timestamp: time.Now().UTC(),
-		UserID:    userID,
-		Payload:   payload,
-		Metadata:  make(map[string]string),
+		ID:            uuid.New().String(),
+		Type:          eventType,
+		Timestamp:     time.Now().UTC(),
+		UserID:        userID,
+		SchemaVersion: 1,
+		Payload:       payload,
+		Metadata:      make(map[string]string),
 	}
 }
 
+// NewTypedEvent creates a user domain event from a typed payload such as RegisteredPayload;
+// the event type and schema version are taken from the payload
+func NewTypedEvent(userID uuid.UUID, data common.EventData) *Event {
+	event := NewEvent(EventType(data.EventType()), userID, common.EventDataMap(data))
+	event.SchemaVersion = data.SchemaVersion()
+	return event
+}
+
 // WithMetadata adds metadata to the event
 func (e *Event) WithMetadata(key, value string) *Event {
 	if e.Metadata == nil {
@@ -49,3 +81,50 @@ func (e *Event) WithMetadata(key, value string) *Event {
 	e.Metadata[key] = value
 	return e
 }
+
+// EventID returns the unique event ID
+func (e *Event) EventID() string {
+	return e.ID
+}
+
+// EventType returns the event type as a string
+func (e *Event) EventType() string {
+	return string(e.Type)
+}
+
+// OccurredAt returns when the event occurred
+func (e *Event) OccurredAt() time.Time {
+	return e.Timestamp
+}
+
+// Attributes returns the user ID as a routing field
+func (e *Event) Attributes() map[string]string {
+	return map[string]string{
+		"user_id": e.UserID.String(),
+	}
+}
+
+// EventPayload returns the event-specific data
+func (e *Event) EventPayload() map[string]interface{} {
+	return e.Payload
+}
+
+// EventMetadata returns the event metadata
+func (e *Event) EventMetadata() map[string]string {
+	return e.Metadata
+}
+
+// EventSource returns the user service as the event producer
+func (e *Event) EventSource() string {
+	return common.EventSource
+}
+
+// EventSubject returns the user ID
+func (e *Event) EventSubject() string {
+	return e.UserID.String()
+}
+
+// EventSchemaVersion returns the version of the payload schema
+func (e *Event) EventSchemaVersion() int {
+	return e.SchemaVersion
+}
@@ -0,0 +1,55 @@
+package user
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTypedEvent(t *testing.T) {
+	userID := uuid.New()
+	event := NewTypedEvent(userID, RegisteredPayload{Email: "jane@example.com", FirstName: "Jane", LastName: "Doe", Role: RoleUser})
+
+	assert.Equal(t, EventTypeUserRegistered, event.Type)
+	assert.Equal(t, 1, event.SchemaVersion)
+	assert.Equal(t, map[string]interface{}{
+		"email":      "jane@example.com",
+		"first_name": "Jane",
+		"last_name":  "Doe",
+		"role":       "user",
+	}, event.Payload)
+	assert.Equal(t, common.EventSource, event.EventSource())
+	assert.Equal(t, userID.String(), event.EventSubject())
+}
+
+func TestEvent_CloudEvent(t *testing.T) {
+	userID := uuid.New()
+	deletedAt := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	event := NewTypedEvent(userID, DeletedPayload{DeletedAt: deletedAt}).WithMetadata("request_id", "req-1")
+
+	cloudEvent, err := common.NewCloudEvent(event)
+	require.NoError(t, err)
+
+	encoded, err := json.Marshal(cloudEvent)
+	require.NoError(t, err)
+	var wire map[string]interface{}
+	require.NoError(t, json.Unmarshal(encoded, &wire))
+
+	assert.Equal(t, "1.0", wire["specversion"])
+	assert.Equal(t, event.ID, wire["id"])
+	assert.Equal(t, "/pandora-exchange/user-service", wire["source"])
+	assert.Equal(t, "user.deleted", wire["type"])
+	assert.Equal(t, userID.String(), wire["subject"])
+	assert.Equal(t, "application/json", wire["datacontenttype"])
+	assert.Equal(t, "https://schemas.pandora.exchange/events/user.deleted/v1.json", wire["dataschema"])
+	assert.Equal(t, float64(1), wire["schemaversion"])
+
+	var data DeletedPayload
+	require.NoError(t, cloudEvent.DecodeData(&data))
+	assert.Equal(t, deletedAt, data.DeletedAt)
+}
@@ -72,9 +72,22 @@ type User struct {
 	HashedPassword string // Argon2id hashed password
 	Role           Role   // User role for authorization (user or admin)
 	KYCStatus      KYCStatus
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	DeletedAt      *time.Time // nil if not deleted
+	// Extended profile; see Profile. Optional fields are empty when not given.
+	Phone           string
+	PhoneVerifiedAt *time.Time
+	DateOfBirth     string // YYYY-MM-DD
+	Address         Address
+	Nationality     string
+	TaxResidency    string
+	// Account status; see AccountStatus. Empty is treated as active.
+	Status          AccountStatus
+	StatusReason    string
+	StatusExpiresAt *time.Time // nil if the status does not expire
+	StatusSetBy     *uuid.UUID // admin who last changed the status
+	StatusSetAt     *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       *time.Time // nil if not deleted
 }
 
 // IsDeleted returns true if the user has been soft-deleted.
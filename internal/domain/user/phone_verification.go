@@ -0,0 +1,61 @@
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PhoneVerification is a code sent to a user's phone that they confirm to verify the
+// number. Only a hash of the code is kept.
+type PhoneVerification struct {
+	UserID    uuid.UUID
+	Phone     string
+	CodeHash  []byte
+	Attempts  int
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// HashPhoneCode returns the hash a verification code is stored as
+func HashPhoneCode(code string) []byte {
+	sum := sha256.Sum256([]byte(code))
+	return sum[:]
+}
+
+// Matches reports whether code is the code that was sent, in constant time
+func (v *PhoneVerification) Matches(code string) bool {
+	return subtle.ConstantTimeCompare(v.CodeHash, HashPhoneCode(code)) == 1
+}
+
+// IsExpired reports whether the code can no longer be confirmed at now
+func (v *PhoneVerification) IsExpired(now time.Time) bool {
+	return !now.Before(v.ExpiresAt)
+}
+
+// PhoneVerificationRepository stores pending phone verifications, one per user.
+// This interface is implemented by the infrastructure layer (repository package).
+type PhoneVerificationRepository interface {
+	// Save stores v, replacing any pending verification of the user
+	Save(ctx context.Context, v *PhoneVerification) (*PhoneVerification, error)
+
+	// Get retrieves the user's pending verification.
+	// Returns ErrPhoneVerificationNotFound if there is none.
+	Get(ctx context.Context, userID uuid.UUID) (*PhoneVerification, error)
+
+	// IncrementAttempts records a wrong code and returns the number of attempts so far
+	IncrementAttempts(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// Delete removes the user's pending verification
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+// PhoneCodeSender delivers verification codes to phones, e.g. by SMS.
+// Implemented by adapters in the sms package.
+type PhoneCodeSender interface {
+	// SendPhoneCode delivers code to the E.164 number phone
+	SendPhoneCode(ctx context.Context, phone, code string) error
+}
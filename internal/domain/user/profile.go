@@ -0,0 +1,263 @@
+package user
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// MinimumAge is the youngest a user giving their date of birth may be, in years
+	MinimumAge = 18
+	// dateOfBirthLayout is the format of User.DateOfBirth
+	dateOfBirthLayout = "2006-01-02"
+	// maxNameLength bounds first and last names
+	maxNameLength = 100
+	// maxAddressFieldLength bounds each free-text address field
+	maxAddressFieldLength = 200
+)
+
+var (
+	// phoneRe matches E.164 phone numbers
+	phoneRe = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	// countryCodeRe matches ISO 3166-1 alpha-2 country codes after normalization
+	countryCodeRe = regexp.MustCompile(`^[A-Z]{2}$`)
+	// earliestDateOfBirth rules out dates of birth that are typing mistakes
+	earliestDateOfBirth = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// Profile field names, as reported in the changed fields of user.profile.updated
+const (
+	FieldFirstName     = "first_name"
+	FieldLastName      = "last_name"
+	FieldPhone         = "phone"
+	FieldPhoneVerified = "phone_verified"
+	FieldDateOfBirth   = "date_of_birth"
+	FieldAddress       = "address"
+	FieldNationality   = "nationality"
+	FieldTaxResidency  = "tax_residency"
+)
+
+// Address is a residential address. The zero Address means none was given.
+type Address struct {
+	Line1      string
+	Line2      string
+	City       string
+	PostalCode string
+	Region     string
+	// Country is an ISO 3166-1 alpha-2 code
+	Country string
+}
+
+// IsZero reports whether no address was given
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
+
+// Profile is the personal data a user keeps on their account. Optional fields are empty
+// when not given.
+type Profile struct {
+	FirstName string
+	LastName  string
+	// Phone is an E.164 number; PhoneVerifiedAt is set once the user confirmed a code sent
+	// to it and cleared when it changes
+	Phone           string
+	PhoneVerifiedAt *time.Time
+	// DateOfBirth is formatted YYYY-MM-DD
+	DateOfBirth string
+	Address     Address
+	// Nationality and TaxResidency are ISO 3166-1 alpha-2 codes
+	Nationality  string
+	TaxResidency string
+}
+
+// ProfileUpdate is a partial profile change: nil fields are left unchanged and empty
+// strings clear optional fields. A non-nil Address replaces the whole address; the zero
+// Address clears it.
+type ProfileUpdate struct {
+	FirstName    *string
+	LastName     *string
+	Phone        *string
+	DateOfBirth  *string
+	Address      *Address
+	Nationality  *string
+	TaxResidency *string
+}
+
+// IsEmpty reports whether the update changes nothing
+func (u ProfileUpdate) IsEmpty() bool {
+	return u == ProfileUpdate{}
+}
+
+// Profile returns the user's profile
+func (u *User) Profile() Profile {
+	return Profile{
+		FirstName:       u.FirstName,
+		LastName:        u.LastName,
+		Phone:           u.Phone,
+		PhoneVerifiedAt: u.PhoneVerifiedAt,
+		DateOfBirth:     u.DateOfBirth,
+		Address:         u.Address,
+		Nationality:     u.Nationality,
+		TaxResidency:    u.TaxResidency,
+	}
+}
+
+// IsPhoneVerified returns true if the user confirmed their current phone number
+func (u *User) IsPhoneVerified() bool {
+	return u.Phone != "" && u.PhoneVerifiedAt != nil
+}
+
+// Apply returns p with update applied and the names of the fields whose value changed.
+// Values are normalized and validated first; a user giving their date of birth must be at
+// least MinimumAge years old at now. Changing the phone number clears its verification.
+func (p Profile) Apply(update ProfileUpdate, now time.Time) (Profile, []string, error) {
+	update = update.normalized()
+	if err := update.validate(now); err != nil {
+		return p, nil, err
+	}
+
+	var changed []string
+	set := func(field string, target *string, value *string) {
+		if value != nil && *value != *target {
+			*target = *value
+			changed = append(changed, field)
+		}
+	}
+	set(FieldFirstName, &p.FirstName, update.FirstName)
+	set(FieldLastName, &p.LastName, update.LastName)
+	if update.Phone != nil && *update.Phone != p.Phone {
+		p.Phone = *update.Phone
+		changed = append(changed, FieldPhone)
+		if p.PhoneVerifiedAt != nil {
+			p.PhoneVerifiedAt = nil
+			changed = append(changed, FieldPhoneVerified)
+		}
+	}
+	set(FieldDateOfBirth, &p.DateOfBirth, update.DateOfBirth)
+	if update.Address != nil && *update.Address != p.Address {
+		p.Address = *update.Address
+		changed = append(changed, FieldAddress)
+	}
+	set(FieldNationality, &p.Nationality, update.Nationality)
+	set(FieldTaxResidency, &p.TaxResidency, update.TaxResidency)
+	return p, changed, nil
+}
+
+// normalized returns a copy of u with whitespace trimmed, country codes upper-cased and the
+// separators people type in phone numbers removed
+func (u ProfileUpdate) normalized() ProfileUpdate {
+	trim := func(value *string, transform func(string) string) *string {
+		if value == nil {
+			return nil
+		}
+		v := transform(strings.TrimSpace(*value))
+		return &v
+	}
+	same := func(s string) string { return s }
+	phoneSeparators := strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+
+	out := ProfileUpdate{
+		FirstName:    trim(u.FirstName, same),
+		LastName:     trim(u.LastName, same),
+		Phone:        trim(u.Phone, phoneSeparators.Replace),
+		DateOfBirth:  trim(u.DateOfBirth, same),
+		Nationality:  trim(u.Nationality, strings.ToUpper),
+		TaxResidency: trim(u.TaxResidency, strings.ToUpper),
+	}
+	if u.Address != nil {
+		a := *u.Address
+		for _, field := range []*string{&a.Line1, &a.Line2, &a.City, &a.PostalCode, &a.Region} {
+			*field = strings.TrimSpace(*field)
+		}
+		a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+		out.Address = &a
+	}
+	return out
+}
+
+// validate checks the fields the update sets
+func (u ProfileUpdate) validate(now time.Time) error {
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{{FieldFirstName, u.FirstName}, {FieldLastName, u.LastName}} {
+		if field.value == nil {
+			continue
+		}
+		if *field.value == "" {
+			return fmt.Errorf("%w: %s cannot be empty", ErrInvalidProfile, field.name)
+		}
+		if len(*field.value) > maxNameLength {
+			return fmt.Errorf("%w: %s exceeds %d characters", ErrInvalidProfile, field.name, maxNameLength)
+		}
+	}
+	if u.Phone != nil && *u.Phone != "" && !phoneRe.MatchString(*u.Phone) {
+		return fmt.Errorf("%w: phone must be an E.164 number such as +40721234567", ErrInvalidProfile)
+	}
+	if u.DateOfBirth != nil && *u.DateOfBirth != "" {
+		if err := validateDateOfBirth(*u.DateOfBirth, now); err != nil {
+			return err
+		}
+	}
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{{FieldNationality, u.Nationality}, {FieldTaxResidency, u.TaxResidency}} {
+		if field.value != nil && *field.value != "" && !countryCodeRe.MatchString(*field.value) {
+			return fmt.Errorf("%w: %s must be an ISO 3166-1 alpha-2 code", ErrInvalidProfile, field.name)
+		}
+	}
+	if u.Address != nil && !u.Address.IsZero() {
+		if err := u.Address.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDateOfBirth checks dob is a past date making the user at least MinimumAge years
+// old at now
+func validateDateOfBirth(dob string, now time.Time) error {
+	born, err := time.Parse(dateOfBirthLayout, dob)
+	if err != nil {
+		return fmt.Errorf("%w: date_of_birth must be formatted YYYY-MM-DD", ErrInvalidProfile)
+	}
+	if born.Before(earliestDateOfBirth) || born.After(now) {
+		return fmt.Errorf("%w: date_of_birth is out of range", ErrInvalidProfile)
+	}
+	if born.AddDate(MinimumAge, 0, 0).After(now) {
+		return fmt.Errorf("%w: users must be at least %d years old", ErrUnderage, MinimumAge)
+	}
+	return nil
+}
+
+// validate checks a given address is complete: everything but Line2 and Region is required
+func (a Address) validate() error {
+	for _, field := range []struct{ name, value string }{
+		{"address.line1", a.Line1},
+		{"address.city", a.City},
+		{"address.postal_code", a.PostalCode},
+		{"address.country", a.Country},
+	} {
+		if field.value == "" {
+			return fmt.Errorf("%w: %s is required", ErrInvalidProfile, field.name)
+		}
+	}
+	for _, field := range []struct{ name, value string }{
+		{"address.line1", a.Line1},
+		{"address.line2", a.Line2},
+		{"address.city", a.City},
+		{"address.postal_code", a.PostalCode},
+		{"address.region", a.Region},
+	} {
+		if len(field.value) > maxAddressFieldLength {
+			return fmt.Errorf("%w: %s exceeds %d characters", ErrInvalidProfile, field.name, maxAddressFieldLength)
+		}
+	}
+	if !countryCodeRe.MatchString(a.Country) {
+		return fmt.Errorf("%w: address.country must be an ISO 3166-1 alpha-2 code", ErrInvalidProfile)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package user_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+// TestProfile_Apply tests merging, normalizing and validating profile updates.
+func TestProfile_Apply(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	verifiedAt := now.Add(-time.Hour)
+	current := user.Profile{
+		FirstName:       "Jane",
+		LastName:        "Doe",
+		Phone:           "+40721234567",
+		PhoneVerifiedAt: &verifiedAt,
+		Nationality:     "RO",
+	}
+
+	t.Run("normalizes and reports changed fields", func(t *testing.T) {
+		updated, changed, err := current.Apply(user.ProfileUpdate{
+			FirstName:    strPtr(" Jane "),
+			Phone:        strPtr("+40 (729) 999-999"),
+			DateOfBirth:  strPtr("1990-04-12"),
+			TaxResidency: strPtr("de"),
+			Address: &user.Address{
+				Line1:      "Strada Lipscani 10 ",
+				City:       "Bucharest",
+				PostalCode: "030031",
+				Country:    "ro",
+			},
+		}, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			user.FieldPhone, user.FieldPhoneVerified, user.FieldDateOfBirth, user.FieldAddress, user.FieldTaxResidency,
+		}, changed)
+		assert.Equal(t, "+40729999999", updated.Phone)
+		assert.Nil(t, updated.PhoneVerifiedAt, "a new number has to be verified again")
+		assert.Equal(t, "Strada Lipscani 10", updated.Address.Line1)
+		assert.Equal(t, "RO", updated.Address.Country)
+		assert.Equal(t, "DE", updated.TaxResidency)
+		assert.Equal(t, "RO", updated.Nationality, "fields not in the update are kept")
+		assert.NotNil(t, current.PhoneVerifiedAt, "the receiver is not modified")
+	})
+
+	t.Run("empty values clear optional fields", func(t *testing.T) {
+		updated, changed, err := current.Apply(user.ProfileUpdate{
+			Phone:       strPtr(""),
+			Nationality: strPtr(""),
+			Address:     &user.Address{},
+		}, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{user.FieldPhone, user.FieldPhoneVerified, user.FieldNationality}, changed)
+		assert.Equal(t, user.Profile{FirstName: "Jane", LastName: "Doe"}, updated)
+	})
+
+	t.Run("same values change nothing", func(t *testing.T) {
+		_, changed, err := current.Apply(user.ProfileUpdate{Phone: strPtr("+40721234567"), Nationality: strPtr("ro")}, now)
+		require.NoError(t, err)
+		assert.Empty(t, changed)
+	})
+
+	t.Run("age gate", func(t *testing.T) {
+		_, _, err := current.Apply(user.ProfileUpdate{DateOfBirth: strPtr("2007-11-08")}, now)
+		assert.NoError(t, err, "18 today")
+
+		_, _, err = current.Apply(user.ProfileUpdate{DateOfBirth: strPtr("2007-11-09")}, now)
+		assert.ErrorIs(t, err, user.ErrUnderage)
+	})
+
+	invalid := []struct {
+		name   string
+		update user.ProfileUpdate
+	}{
+		{"empty first name", user.ProfileUpdate{FirstName: strPtr("  ")}},
+		{"phone without country code", user.ProfileUpdate{Phone: strPtr("0721234567")}},
+		{"phone with letters", user.ProfileUpdate{Phone: strPtr("+4072123ABCD")}},
+		{"malformed date of birth", user.ProfileUpdate{DateOfBirth: strPtr("12/04/1990")}},
+		{"date of birth in the future", user.ProfileUpdate{DateOfBirth: strPtr("2030-01-01")}},
+		{"date of birth before 1900", user.ProfileUpdate{DateOfBirth: strPtr("1899-12-31")}},
+		{"three letter nationality", user.ProfileUpdate{Nationality: strPtr("ROU")}},
+		{"incomplete address", user.ProfileUpdate{Address: &user.Address{Line1: "Strada Lipscani 10", Country: "RO"}}},
+		{"address country name", user.ProfileUpdate{Address: &user.Address{
+			Line1: "Strada Lipscani 10", City: "Bucharest", PostalCode: "030031", Country: "Romania",
+		}}},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := current.Apply(tc.update, now)
+			assert.ErrorIs(t, err, user.ErrInvalidProfile)
+		})
+	}
+}
+
+// TestPhoneVerification_Matches tests checking verification codes against their hash.
+func TestPhoneVerification_Matches(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	v := user.PhoneVerification{CodeHash: user.HashPhoneCode("123456"), ExpiresAt: now.Add(time.Minute)}
+
+	assert.True(t, v.Matches("123456"))
+	assert.False(t, v.Matches("123457"))
+	assert.False(t, v.IsExpired(now))
+	assert.True(t, v.IsExpired(now.Add(time.Minute)))
+}
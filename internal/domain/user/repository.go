@@ -2,7 +2,9 @@ package user
 
 import (
 	"context"
+	"time"
 
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
 	"github.com/google/uuid"
 )
 
@@ -26,14 +28,32 @@ type Repository interface {
 	// Returns error if user doesn't exist or status is invalid.
 	UpdateKYCStatus(ctx context.Context, id uuid.UUID, status KYCStatus) (*User, error)
 
-	// UpdateProfile updates the user's profile information (first name and last name).
-	// Returns error if user doesn't exist.
-	UpdateProfile(ctx context.Context, id uuid.UUID, firstName, lastName string) (*User, error)
+	// UpdateProfile replaces the user's profile with profile.
+	// Returns ErrNotFound if user doesn't exist.
+	UpdateProfile(ctx context.Context, id uuid.UUID, profile Profile) (*User, error)
 
 	// SoftDelete marks a user as deleted without removing the record.
 	// Returns error if user doesn't exist or is already deleted.
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 
+	// Restore undeletes the user deleted at deletedAt and drops any deferred erasure of them.
+	// Sessions revoked on deletion stay revoked.
+	// Returns ErrNotFound if the user is not deleted at deletedAt or has been erased, and
+	// ErrAlreadyExists if an active user has taken their email since.
+	Restore(ctx context.Context, id uuid.UUID, deletedAt time.Time) (*User, error)
+
+	// SetStatus sets the user's account status as change describes and appends change to
+	// their status history; the old status recorded is the one the user held.
+	// Returns ErrNotFound if user doesn't exist or is soft-deleted.
+	SetStatus(ctx context.Context, change *AccountStatusChange) (*User, error)
+
+	// ListStatusChanges retrieves a user's account status history in order.
+	ListStatusChanges(ctx context.Context, userID uuid.UUID) ([]*AccountStatusChange, error)
+
+	// ListExpiredStatuses retrieves up to limit active users whose suspension or freeze
+	// expired at or before now, earliest first.
+	ListExpiredStatuses(ctx context.Context, now time.Time, limit int) ([]*User, error)
+
 	// List retrieves a paginated list of active users.
 	// Returns empty slice if no users found.
 	List(ctx context.Context, limit, offset int) ([]*User, error)
@@ -41,9 +61,9 @@ type Repository interface {
 	// Count returns the total count of active (non-deleted) users.
 	Count(ctx context.Context) (int64, error)
 
-	// SearchUsers searches users by email, first name, or last name with pagination.
+	// SearchUsers returns a page of users matching an admin search, which must be normalized.
 	// Admin-only operation for user management.
-	SearchUsers(ctx context.Context, query string, limit, offset int) ([]*User, error)
+	SearchUsers(ctx context.Context, search UserSearch) (*UserSearchPage, error)
 
 	// UpdateRole updates a user's role (admin-only operation).
 	// Returns error if user doesn't exist or role is invalid.
@@ -53,3 +73,24 @@ type Repository interface {
 	// Admin-only operation for user recovery or audit purposes.
 	GetByIDIncludeDeleted(ctx context.Context, id uuid.UUID) (*User, error)
 }
+
+// Transactor runs user changes and the events describing them atomically.
+// This interface is implemented by the infrastructure layer (repository package).
+type Transactor interface {
+	// WithinTx runs fn in a database transaction. The repository and outbox writer passed
+	// to fn share the transaction; it commits when fn returns nil and rolls back otherwise.
+	WithinTx(ctx context.Context, fn func(repo Repository, events outbox.Writer) error) error
+}
+
+// PIIReencryptor encrypts PII stored before field encryption was enabled and rewraps data
+// keys after the key encryption key was rotated.
+// This interface is implemented by the infrastructure layer (repository package).
+type PIIReencryptor interface {
+	// CountPendingPIIReencryption counts users still holding plaintext PII or a data key
+	// wrapped with an older key version.
+	CountPendingPIIReencryption(ctx context.Context) (int64, error)
+
+	// ReencryptPII processes up to limit pending users and returns how many were updated.
+	// Users changed concurrently are skipped and stay pending.
+	ReencryptPII(ctx context.Context, limit int32) (int, error)
+}
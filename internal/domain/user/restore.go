@@ -0,0 +1,91 @@
+package user
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MinRestoreKeyLength is the minimum length of the key restore links are signed with
+const MinRestoreKeyLength = 32
+
+// restoreTokenContext separates restore link signatures from other uses of the key
+const restoreTokenContext = "pandora-restore/v1:"
+
+// restoreTokenPayloadSize is the user ID, deletion time and expiry of a restore token
+const restoreTokenPayloadSize = 16 + 8 + 8
+
+// RestoreToken is what a restore link grants: undeleting one deletion of a user until
+// ExpiresAt. A link sent for an earlier deletion does not restore a later one.
+type RestoreToken struct {
+	UserID    uuid.UUID
+	DeletedAt time.Time
+	ExpiresAt time.Time
+}
+
+// RestoreTokenSigner signs and verifies restore tokens with an HMAC-SHA256 key
+type RestoreTokenSigner struct {
+	key []byte
+}
+
+// NewRestoreTokenSigner creates a signer using key, which must be at least
+// MinRestoreKeyLength bytes
+func NewRestoreTokenSigner(key []byte) (*RestoreTokenSigner, error) {
+	if len(key) < MinRestoreKeyLength {
+		return nil, errors.New("restore link signing key is too short")
+	}
+	return &RestoreTokenSigner{key: key}, nil
+}
+
+// Sign encodes t as a URL-safe token. The deletion time is kept to the microsecond, the
+// precision PostgreSQL stores it with.
+func (s *RestoreTokenSigner) Sign(t RestoreToken) string {
+	payload := make([]byte, restoreTokenPayloadSize)
+	copy(payload, t.UserID[:])
+	binary.BigEndian.PutUint64(payload[16:], uint64(t.DeletedAt.UnixMicro()))
+	binary.BigEndian.PutUint64(payload[24:], uint64(t.ExpiresAt.Unix()))
+	return base64.RawURLEncoding.EncodeToString(append(payload, s.mac(payload)...))
+}
+
+// Verify decodes a token signed by Sign.
+// Returns ErrInvalidRestoreToken if it is malformed, forged or expired at now.
+func (s *RestoreTokenSigner) Verify(token string, now time.Time) (*RestoreToken, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != restoreTokenPayloadSize+sha256.Size {
+		return nil, ErrInvalidRestoreToken
+	}
+	payload, mac := raw[:restoreTokenPayloadSize], raw[restoreTokenPayloadSize:]
+	if !hmac.Equal(mac, s.mac(payload)) {
+		return nil, ErrInvalidRestoreToken
+	}
+
+	t := &RestoreToken{
+		DeletedAt: time.UnixMicro(int64(binary.BigEndian.Uint64(payload[16:]))).UTC(),
+		ExpiresAt: time.Unix(int64(binary.BigEndian.Uint64(payload[24:])), 0).UTC(),
+	}
+	copy(t.UserID[:], payload[:16])
+	if !now.Before(t.ExpiresAt) {
+		return nil, ErrInvalidRestoreToken
+	}
+	return t, nil
+}
+
+func (s *RestoreTokenSigner) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(restoreTokenContext))
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// RestoreLinkSender delivers restore links to deleted users, e.g. by email.
+// Implemented by adapters in the email package.
+type RestoreLinkSender interface {
+	// SendRestoreLink delivers link, which restores the account until expiresAt, to email
+	SendRestoreLink(ctx context.Context, email, link string, expiresAt time.Time) error
+}
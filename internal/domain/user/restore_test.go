@@ -0,0 +1,67 @@
+package user_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRestoreTokenSigner tests signing and verifying restore link tokens.
+func TestRestoreTokenSigner(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	signer, err := user.NewRestoreTokenSigner(bytes.Repeat([]byte("k"), 32))
+	require.NoError(t, err)
+
+	token := user.RestoreToken{
+		UserID:    uuid.New(),
+		DeletedAt: now.Add(-time.Hour).Add(123456 * time.Nanosecond),
+		ExpiresAt: now.Add(24 * time.Hour),
+	}
+	signed := signer.Sign(token)
+
+	t.Run("round trip", func(t *testing.T) {
+		got, err := signer.Verify(signed, now)
+		require.NoError(t, err)
+		assert.Equal(t, token.UserID, got.UserID)
+		assert.True(t, got.DeletedAt.Equal(token.DeletedAt.Truncate(time.Microsecond)))
+		assert.True(t, got.ExpiresAt.Equal(token.ExpiresAt))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		_, err := signer.Verify(signed, token.ExpiresAt)
+		assert.ErrorIs(t, err, user.ErrInvalidRestoreToken)
+	})
+
+	t.Run("other key", func(t *testing.T) {
+		other, err := user.NewRestoreTokenSigner(bytes.Repeat([]byte("o"), 32))
+		require.NoError(t, err)
+		_, err = other.Verify(signed, now)
+		assert.ErrorIs(t, err, user.ErrInvalidRestoreToken)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		raw, err := base64.RawURLEncoding.DecodeString(signed)
+		require.NoError(t, err)
+		raw[0] ^= 1
+		_, err = signer.Verify(base64.RawURLEncoding.EncodeToString(raw), now)
+		assert.ErrorIs(t, err, user.ErrInvalidRestoreToken)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, bad := range []string{"", "not-a-token!", signed[:20]} {
+			_, err := signer.Verify(bad, now)
+			assert.ErrorIs(t, err, user.ErrInvalidRestoreToken, bad)
+		}
+	})
+
+	t.Run("short key", func(t *testing.T) {
+		_, err := user.NewRestoreTokenSigner([]byte("short"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,223 @@
+package user
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// SearchSort orders admin user search results.
+type SearchSort string
+
+const (
+	// SearchSortRelevance puts the best matches first. It requires a query and is the
+	// default when one is given.
+	SearchSortRelevance SearchSort = "relevance"
+	// SearchSortNewest puts the most recently created users first. It is the default
+	// without a query.
+	SearchSortNewest SearchSort = "created_at_desc"
+	// SearchSortOldest puts the earliest created users first.
+	SearchSortOldest SearchSort = "created_at_asc"
+)
+
+// IsValid checks if the sort is one of the allowed values.
+func (s SearchSort) IsValid() bool {
+	switch s {
+	case SearchSortRelevance, SearchSortNewest, SearchSortOldest:
+		return true
+	default:
+		return false
+	}
+}
+
+// DeletedFilter selects users by whether they are soft-deleted.
+type DeletedFilter string
+
+const (
+	// DeletedExclude leaves deleted users out. It is the default.
+	DeletedExclude DeletedFilter = "exclude"
+	// DeletedOnly returns only deleted users.
+	DeletedOnly DeletedFilter = "only"
+	// DeletedInclude returns deleted and non-deleted users.
+	DeletedInclude DeletedFilter = "include"
+)
+
+// IsValid checks if the filter is one of the allowed values.
+func (f DeletedFilter) IsValid() bool {
+	switch f {
+	case DeletedExclude, DeletedOnly, DeletedInclude:
+		return true
+	default:
+		return false
+	}
+}
+
+// Limits for admin user search.
+const (
+	DefaultSearchLimit   = 20
+	MaxSearchLimit       = 100
+	MaxSearchQueryLength = 200
+)
+
+// UserSearch is an admin search for users. The query matches plaintext emails and names by
+// substring or full-text search, the exact email of encrypted users and user IDs; every
+// other field narrows the results. Zero values mean no filter. With PII encryption enabled
+// the query must be an exact email or user ID (see IsExactQuery).
+type UserSearch struct {
+	Query     string
+	Role      Role
+	KYCStatus KYCStatus
+	// Status filters by the stored account status, which may have expired but not yet
+	// been lifted.
+	Status      AccountStatus
+	CreatedFrom *time.Time // inclusive
+	CreatedTo   *time.Time // exclusive
+	Deleted     DeletedFilter
+	Sort        SearchSort
+	// Cursor is the NextCursor of the previous page, or empty for the first page. It is
+	// only valid for the query, filters and sort it was returned for.
+	Cursor string
+	Limit  int
+}
+
+// UserSearchPage is one page of user search results.
+type UserSearchPage struct {
+	Users []*User
+	// NextCursor fetches the following page; empty on the last page.
+	NextCursor string
+}
+
+// SearchCursor is the position after the last user of a search page.
+type SearchCursor struct {
+	// Fingerprint identifies the query, filters and sort the cursor was returned for.
+	Fingerprint string    `json:"f"`
+	CreatedAt   time.Time `json:"c"`
+	Rank        float64   `json:"r,omitempty"`
+	ID          uuid.UUID `json:"i"`
+}
+
+// Normalize trims the query, applies defaults and validates the search, including its cursor.
+// Returns ErrInvalidSearch or ErrInvalidSearchCursor.
+func (s *UserSearch) Normalize() error {
+	s.Query = strings.TrimSpace(s.Query)
+	if utf8.RuneCountInString(s.Query) > MaxSearchQueryLength {
+		return fmt.Errorf("%w: query must be at most %d characters", ErrInvalidSearch, MaxSearchQueryLength)
+	}
+	if s.Role != "" && !s.Role.IsValid() {
+		return fmt.Errorf("%w: unknown role %q", ErrInvalidSearch, s.Role)
+	}
+	if s.KYCStatus != "" && !s.KYCStatus.IsValid() {
+		return fmt.Errorf("%w: unknown KYC status %q", ErrInvalidSearch, s.KYCStatus)
+	}
+	if s.Status != "" && !s.Status.IsValid() {
+		return fmt.Errorf("%w: unknown account status %q", ErrInvalidSearch, s.Status)
+	}
+	if s.CreatedFrom != nil && s.CreatedTo != nil && !s.CreatedFrom.Before(*s.CreatedTo) {
+		return fmt.Errorf("%w: created_from must be before created_to", ErrInvalidSearch)
+	}
+
+	if s.Deleted == "" {
+		s.Deleted = DeletedExclude
+	}
+	if !s.Deleted.IsValid() {
+		return fmt.Errorf("%w: deleted must be exclude, only or include", ErrInvalidSearch)
+	}
+
+	if s.Sort == "" {
+		s.Sort = SearchSortNewest
+		if s.Query != "" {
+			s.Sort = SearchSortRelevance
+		}
+	}
+	if !s.Sort.IsValid() {
+		return fmt.Errorf("%w: unknown sort %q", ErrInvalidSearch, s.Sort)
+	}
+	if s.Sort == SearchSortRelevance && s.Query == "" {
+		return fmt.Errorf("%w: relevance sort requires a query", ErrInvalidSearch)
+	}
+
+	if s.Limit == 0 {
+		s.Limit = DefaultSearchLimit
+	}
+	if s.Limit < 1 || s.Limit > MaxSearchLimit {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidSearch, MaxSearchLimit)
+	}
+
+	_, err := s.DecodeCursor()
+	return err
+}
+
+// IsExactQuery reports whether the query is empty, a user ID or a single email address.
+// These are the only queries that can match users whose PII is encrypted: encrypted values
+// can't be matched by substring or words, only an email through its blind index.
+func (s UserSearch) IsExactQuery() bool {
+	if s.Query == "" {
+		return true
+	}
+	if _, err := uuid.Parse(s.Query); err == nil {
+		return true
+	}
+	addr, err := mail.ParseAddress(s.Query)
+	return err == nil && addr.Address == s.Query
+}
+
+// DecodeCursor returns the position the search continues after, or nil for the first page.
+// Returns ErrInvalidSearchCursor if the cursor is malformed or was returned for a different
+// query, filters or sort.
+func (s UserSearch) DecodeCursor() (*SearchCursor, error) {
+	if s.Cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s.Cursor)
+	if err != nil {
+		return nil, ErrInvalidSearchCursor
+	}
+	var c SearchCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == uuid.Nil {
+		return nil, ErrInvalidSearchCursor
+	}
+	if c.Fingerprint != s.fingerprint() {
+		return nil, ErrInvalidSearchCursor
+	}
+	return &c, nil
+}
+
+// NextCursor returns the cursor of the page following last, the final user of a page.
+// rank is the relevance of last and is ignored unless sorting by relevance.
+func (s UserSearch) NextCursor(last *User, rank float64) string {
+	c := SearchCursor{Fingerprint: s.fingerprint(), CreatedAt: last.CreatedAt.UTC(), ID: last.ID}
+	if s.Sort == SearchSortRelevance {
+		c.Rank = rank
+	}
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// fingerprint hashes the query, filters and sort, which a cursor must be used with
+func (s UserSearch) fingerprint() string {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		s.Query,
+		string(s.Role),
+		string(s.KYCStatus),
+		string(s.Status),
+		formatTime(s.CreatedFrom),
+		formatTime(s.CreatedTo),
+		string(s.Deleted),
+		string(s.Sort),
+	}, "\x1f")))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,106 @@
+package user_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUserSearch_Normalize tests search defaults and validation.
+func TestUserSearch_Normalize(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		s := user.UserSearch{}
+		require.NoError(t, s.Normalize())
+		assert.Equal(t, user.SearchSortNewest, s.Sort)
+		assert.Equal(t, user.DeletedExclude, s.Deleted)
+		assert.Equal(t, user.DefaultSearchLimit, s.Limit)
+
+		s = user.UserSearch{Query: "  alice  "}
+		require.NoError(t, s.Normalize())
+		assert.Equal(t, "alice", s.Query)
+		assert.Equal(t, user.SearchSortRelevance, s.Sort)
+	})
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	tests := []struct {
+		name   string
+		search user.UserSearch
+		valid  bool
+	}{
+		{"filters", user.UserSearch{Role: user.RoleAdmin, KYCStatus: user.KYCStatusVerified, Status: user.AccountStatusSuspended, CreatedFrom: &from, CreatedTo: &to, Deleted: user.DeletedOnly}, true},
+		{"oldest first with query", user.UserSearch{Query: "alice", Sort: user.SearchSortOldest, Limit: user.MaxSearchLimit}, true},
+		{"long query", user.UserSearch{Query: strings.Repeat("a", user.MaxSearchQueryLength+1)}, false},
+		{"unknown role", user.UserSearch{Role: "root"}, false},
+		{"unknown KYC status", user.UserSearch{KYCStatus: "approved"}, false},
+		{"unknown account status", user.UserSearch{Status: "banned"}, false},
+		{"empty created range", user.UserSearch{CreatedFrom: &to, CreatedTo: &from}, false},
+		{"unknown deleted filter", user.UserSearch{Deleted: "all"}, false},
+		{"unknown sort", user.UserSearch{Sort: "name"}, false},
+		{"relevance without query", user.UserSearch{Sort: user.SearchSortRelevance}, false},
+		{"limit too large", user.UserSearch{Limit: user.MaxSearchLimit + 1}, false},
+		{"negative limit", user.UserSearch{Limit: -1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.search.Normalize()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, user.ErrInvalidSearch)
+			}
+		})
+	}
+}
+
+// TestUserSearch_Cursor tests that cursors round-trip and only fit the search they came from.
+func TestUserSearch_Cursor(t *testing.T) {
+	search := user.UserSearch{Query: "alice", Role: user.RoleUser}
+	require.NoError(t, search.Normalize())
+
+	last := &user.User{ID: uuid.New(), CreatedAt: time.Date(2025, 3, 1, 12, 0, 0, 123, time.UTC)}
+	search.Cursor = search.NextCursor(last, 0.4375)
+	require.NoError(t, search.Normalize())
+
+	cursor, err := search.DecodeCursor()
+	require.NoError(t, err)
+	assert.Equal(t, last.ID, cursor.ID)
+	assert.True(t, last.CreatedAt.Equal(cursor.CreatedAt))
+	assert.Equal(t, 0.4375, cursor.Rank)
+
+	// The page size may change between pages
+	resized := search
+	resized.Limit = 50
+	_, err = resized.DecodeCursor()
+	assert.NoError(t, err)
+
+	for name, other := range map[string]user.UserSearch{
+		"other query":  {Query: "bob", Role: user.RoleUser, Cursor: search.Cursor},
+		"other filter": {Query: "alice", Role: user.RoleAdmin, Cursor: search.Cursor},
+		"other sort":   {Query: "alice", Role: user.RoleUser, Sort: user.SearchSortOldest, Cursor: search.Cursor},
+		"malformed":    {Query: "alice", Role: user.RoleUser, Cursor: "not a cursor"},
+		"not json":     {Query: "alice", Role: user.RoleUser, Cursor: "bm90IGpzb24"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, other.Normalize(), user.ErrInvalidSearchCursor)
+		})
+	}
+}
+
+// TestUserSearch_IsExactQuery tests which queries can match users with encrypted PII.
+func TestUserSearch_IsExactQuery(t *testing.T) {
+	exact := []string{"", "alice@example.com", "alice.smith+vip@mail.example.org", uuid.New().String()}
+	for _, q := range exact {
+		assert.True(t, user.UserSearch{Query: q}.IsExactQuery(), q)
+	}
+
+	partial := []string{"alice", "Alice Smith", "@example.com", "alice@", "Alice <alice@example.com>", "alice@example.com bob@example.com"}
+	for _, q := range partial {
+		assert.False(t, user.UserSearch{Query: q}.IsExactQuery(), q)
+	}
+}
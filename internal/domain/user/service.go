@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
 	"github.com/google/uuid"
 )
 
@@ -59,20 +60,35 @@ type Service interface {
 	// Used by gRPC service for internal service-to-service calls.
 	GetByEmail(ctx context.Context, email string) (*User, error)
 
-	// UpdateKYC updates the KYC verification status for a user.
-	// Emits a kyc.updated event to Redis Streams.
-	// Returns error if user doesn't exist or status is invalid.
-	UpdateKYC(ctx context.Context, id uuid.UUID, status KYCStatus) (*User, error)
+	// UpdateProfile applies a partial update to the user's profile.
+	// Emits a profile.updated event listing the changed fields when anything changed.
+	// Returns ErrInvalidProfile or ErrUnderage if a field fails validation.
+	UpdateProfile(ctx context.Context, id uuid.UUID, update ProfileUpdate) (*User, error)
 
-	// UpdateProfile updates the user's profile information (first name and last name).
-	// Returns error if user doesn't exist.
-	UpdateProfile(ctx context.Context, id uuid.UUID, firstName, lastName string) (*User, error)
+	// StartPhoneVerification sends a verification code to the user's phone number.
+	// Returns the pending verification; ErrPhoneVerificationThrottled if a code was sent
+	// moments ago.
+	StartPhoneVerification(ctx context.Context, id uuid.UUID) (*PhoneVerification, error)
+
+	// ConfirmPhone verifies the user's phone number with the code sent to it.
+	// Returns ErrInvalidPhoneCode if the code is wrong or expired.
+	ConfirmPhone(ctx context.Context, id uuid.UUID, code string) (*User, error)
 
 	// DeleteAccount soft-deletes a user account.
 	// Revokes all active refresh tokens for the user.
 	// Returns error if user doesn't exist or is already deleted.
 	DeleteAccount(ctx context.Context, id uuid.UUID) error
 
+	// RestoreAccount undeletes the account a restore link was sent for, within the grace period.
+	// Sessions revoked on deletion stay revoked; the user logs in again.
+	// Returns ErrInvalidRestoreToken if the link is invalid or expired.
+	RestoreAccount(ctx context.Context, token string) (*User, error)
+
+	// CheckAccountAccess returns ErrAccountSuspended or ErrAccountClosed if the user's
+	// account status does not let them use their sessions.
+	// Returns ErrNotFound if the user doesn't exist or is deleted.
+	CheckAccountAccess(ctx context.Context, id uuid.UUID) error
+
 	// GetActiveSessions retrieves all active sessions (refresh tokens) for a user.
 	// Useful for "active devices" feature in user dashboard.
 	GetActiveSessions(ctx context.Context, userID uuid.UUID) ([]*auth.RefreshToken, error)
@@ -82,8 +98,8 @@ type Service interface {
 	// ListUsers retrieves a paginated list of all users (admin only).
 	ListUsers(ctx context.Context, limit, offset int) ([]*User, int64, error)
 
-	// SearchUsers searches for users by email, first name, or last name (admin only).
-	SearchUsers(ctx context.Context, query string, limit, offset int) ([]*User, error)
+	// SearchUsers searches users with filters, sorting and cursor pagination (admin only).
+	SearchUsers(ctx context.Context, search UserSearch) (*UserSearchPage, error)
 
 	// GetUserByIDAdmin retrieves a user by ID including deleted users (admin only).
 	GetUserByIDAdmin(ctx context.Context, id uuid.UUID) (*User, error)
@@ -91,12 +107,26 @@ type Service interface {
 	// UpdateUserRole updates a user's role (admin only).
 	UpdateUserRole(ctx context.Context, id uuid.UUID, role Role) (*User, error)
 
+	// AdminRestoreAccount undeletes a user within the grace period (admin only).
+	// Returns ErrNotDeleted if the user is not deleted and ErrRestoreWindowExpired after the grace period.
+	AdminRestoreAccount(ctx context.Context, id, adminID uuid.UUID) (*User, error)
+
+	// SetAccountStatus suspends, freezes withdrawals of, closes or reactivates a user (admin only).
+	// Emits a status.changed event and revokes the user's sessions if configured for the status.
+	// Returns ErrInvalidAccountStatus if the update is invalid or admins change their own status.
+	SetAccountStatus(ctx context.Context, id uuid.UUID, update AccountStatusUpdate, adminID uuid.UUID) (*User, error)
+
+	// GetAccountStatusHistory retrieves a user's account status changes, oldest first (admin only).
+	GetAccountStatusHistory(ctx context.Context, id uuid.UUID) ([]*AccountStatusChange, error)
+
 	// GetAllActiveSessions retrieves all active sessions across all users (admin only).
 	GetAllActiveSessions(ctx context.Context, limit, offset int) ([]*auth.RefreshToken, int64, error)
 
 	// ForceLogout revokes a specific refresh token (admin only).
 	ForceLogout(ctx context.Context, token string) error
 
-	// GetSystemStats retrieves system statistics for admin dashboard (admin only).
-	GetSystemStats(ctx context.Context) (map[string]interface{}, error)
+	// GetSystemStats builds the admin statistics dashboard of the days selected by query
+	// from the statistics rollups (admin only).
+	// Returns stats.ErrInvalidQuery or stats.ErrUnavailable if statistics are not configured.
+	GetSystemStats(ctx context.Context, query stats.Query) (*stats.Dashboard, error)
 }
@@ -0,0 +1,24 @@
+package webhook
+
+import "errors"
+
+// Domain-level errors for outbound webhooks.
+var (
+	// ErrSubscriptionNotFound is returned when a webhook subscription does not exist.
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+
+	// ErrInvalidSubscription is returned when a subscription fails validation.
+	ErrInvalidSubscription = errors.New("invalid webhook subscription")
+
+	// ErrSubscriptionDisabled is returned when redelivering to a disabled subscription.
+	ErrSubscriptionDisabled = errors.New("webhook subscription is disabled")
+
+	// ErrDeliveryNotFound is returned when a delivery does not exist for the subscription.
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+
+	// ErrDeliveryPending is returned when redelivering a delivery that has not finished yet.
+	ErrDeliveryPending = errors.New("webhook delivery is still pending")
+
+	// ErrInvalidSignature is returned when a delivery signature does not verify.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
@@ -0,0 +1,308 @@
+// Package webhook contains the outbound webhook domain model: subscriptions of HTTP endpoints
+// to user lifecycle events, the deliveries queued for them, and how deliveries are signed.
+// Transport concerns (HTTP client, storage) live behind the Repository and Sender ports.
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxEventTypes bounds the event type filter of a subscription
+	MaxEventTypes = 50
+	// MaxDescriptionLength bounds a subscription's description
+	MaxDescriptionLength = 255
+	// MinSecretLength is the shortest secret accepted when an admin supplies one
+	MinSecretLength = 16
+)
+
+// Subscription is an HTTP endpoint receiving events.
+// Secret signs every delivery and is only shown to admins when it is created or rotated.
+type Subscription struct {
+	ID          uuid.UUID `json:"id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	// EventTypes filters delivered events; empty delivers every type
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"-"`
+
+	// Health: a subscription whose endpoint keeps failing is disabled
+	Enabled             bool       `json:"enabled"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason      *string    `json:"disabled_reason,omitempty"`
+
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Matches reports whether events of eventType are delivered to the subscription
+func (s *Subscription) Matches(eventType string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// DeliveryStatus is where a delivery is in its lifecycle
+type DeliveryStatus string
+
+const (
+	// DeliveryPending deliveries are waiting for their next attempt
+	DeliveryPending DeliveryStatus = "pending"
+	// DeliverySucceeded deliveries were accepted with a 2xx response
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed deliveries ran out of attempts; they can be redelivered manually
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// IsValid reports whether s is a known delivery status
+func (s DeliveryStatus) IsValid() bool {
+	switch s {
+	case DeliveryPending, DeliverySucceeded, DeliveryFailed:
+		return true
+	}
+	return false
+}
+
+// Delivery is one event queued for one subscription. It doubles as the delivery log entry.
+// Payload is the exact request body, so redeliveries are byte-identical.
+type Delivery struct {
+	ID             uuid.UUID      `json:"id"`
+	SubscriptionID uuid.UUID      `json:"subscription_id"`
+	EventID        string         `json:"event_id"`
+	EventType      string         `json:"event_type"`
+	Payload        []byte         `json:"-"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time     `json:"last_attempt_at,omitempty"`
+	LastStatusCode *int           `json:"last_status_code,omitempty"`
+	LastError      *string        `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+// CreateRequest is an admin request to add a subscription.
+// An empty Secret makes the service generate one.
+type CreateRequest struct {
+	URL         string
+	Description string
+	EventTypes  []string
+	Secret      string
+	CreatedBy   string
+}
+
+// Validate checks a create request and normalizes its event types.
+// Plain http URLs are only accepted when allowHTTP is set (development).
+func (r *CreateRequest) Validate(allowHTTP bool) error {
+	if err := validateURL(r.URL, allowHTTP); err != nil {
+		return err
+	}
+	if len(r.Description) > MaxDescriptionLength {
+		return fmt.Errorf("%w: description must be at most %d characters", ErrInvalidSubscription, MaxDescriptionLength)
+	}
+	if r.Secret != "" && len(r.Secret) < MinSecretLength {
+		return fmt.Errorf("%w: secret must be at least %d characters", ErrInvalidSubscription, MinSecretLength)
+	}
+	if strings.TrimSpace(r.CreatedBy) == "" {
+		return fmt.Errorf("%w: created_by is required", ErrInvalidSubscription)
+	}
+	eventTypes, err := normalizeEventTypes(r.EventTypes)
+	if err != nil {
+		return err
+	}
+	r.EventTypes = eventTypes
+	return nil
+}
+
+// UpdateRequest changes a subscription; nil fields are left as they are.
+// Enabling a disabled subscription clears its failure count. RotateSecret replaces the
+// secret with a generated one, returned once in the response.
+type UpdateRequest struct {
+	URL          *string
+	Description  *string
+	EventTypes   *[]string
+	Enabled      *bool
+	RotateSecret bool
+	Actor        string
+}
+
+// Apply validates the request and applies it to a copy of sub.
+// Plain http URLs are only accepted when allowHTTP is set (development).
+func (r *UpdateRequest) Apply(sub *Subscription, allowHTTP bool) (*Subscription, error) {
+	updated := *sub
+	if r.URL != nil {
+		if err := validateURL(*r.URL, allowHTTP); err != nil {
+			return nil, err
+		}
+		updated.URL = *r.URL
+	}
+	if r.Description != nil {
+		if len(*r.Description) > MaxDescriptionLength {
+			return nil, fmt.Errorf("%w: description must be at most %d characters", ErrInvalidSubscription, MaxDescriptionLength)
+		}
+		updated.Description = *r.Description
+	}
+	if r.EventTypes != nil {
+		eventTypes, err := normalizeEventTypes(*r.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		updated.EventTypes = eventTypes
+	}
+	if r.Enabled != nil {
+		updated.Enabled = *r.Enabled
+	}
+	return &updated, nil
+}
+
+// validateURL accepts absolute https URLs without credentials, and http URLs if allowHTTP is set.
+// Deliveries carry user data, so they must not travel in the clear.
+func validateURL(raw string, allowHTTP bool) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidSubscription)
+	}
+	if u.Scheme == "http" && !allowHTTP {
+		return fmt.Errorf("%w: url must use https", ErrInvalidSubscription)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: url must not contain credentials; deliveries are authenticated by signature", ErrInvalidSubscription)
+	}
+	return nil
+}
+
+// normalizeEventTypes trims and de-duplicates an event type filter
+func normalizeEventTypes(eventTypes []string) ([]string, error) {
+	if len(eventTypes) > MaxEventTypes {
+		return nil, fmt.Errorf("%w: at most %d event types", ErrInvalidSubscription, MaxEventTypes)
+	}
+	seen := make(map[string]bool, len(eventTypes))
+	normalized := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			return nil, fmt.Errorf("%w: event types must not be empty", ErrInvalidSubscription)
+		}
+		if !seen[t] {
+			seen[t] = true
+			normalized = append(normalized, t)
+		}
+	}
+	return normalized, nil
+}
+
+// SubscriptionUpdate is the full set of mutable subscription settings written by Repository.Update.
+// A nil Secret keeps the current one; DisabledReason is recorded when Enabled turns false.
+type SubscriptionUpdate struct {
+	URL            string
+	Description    string
+	EventTypes     []string
+	Enabled        bool
+	Secret         *string
+	DisabledReason *string
+}
+
+// Attempt is the outcome of sending a delivery once
+type Attempt struct {
+	// StatusCode is the response status; 0 when no response was received
+	StatusCode int
+	// Err is nil when the endpoint answered 2xx
+	Err error
+	At  time.Time
+}
+
+// Repository persists subscriptions and their delivery queue
+type Repository interface {
+	// Create stores a new enabled subscription
+	Create(ctx context.Context, sub *Subscription) (*Subscription, error)
+
+	// GetByID retrieves a subscription
+	GetByID(ctx context.Context, id uuid.UUID) (*Subscription, error)
+
+	// List retrieves subscriptions, newest first
+	List(ctx context.Context, limit, offset int32) ([]*Subscription, error)
+
+	// Update replaces a subscription's settings
+	Update(ctx context.Context, id uuid.UUID, update SubscriptionUpdate) (*Subscription, error)
+
+	// Delete removes a subscription and its deliveries
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// RecordFailure counts a failed attempt against an enabled subscription and disables it
+	// once disableAfter consecutive attempts failed. Reports whether this call disabled it.
+	RecordFailure(ctx context.Context, id uuid.UUID, disableAfter int, reason string, at time.Time) (bool, error)
+
+	// ResetFailures clears a subscription's failure count after a successful delivery
+	ResetFailures(ctx context.Context, id uuid.UUID) error
+
+	// Enqueue queues an event for every enabled subscription matching eventType and returns
+	// how many deliveries were added. Enqueueing the same event again adds none.
+	Enqueue(ctx context.Context, eventID, eventType string, payload []byte, at time.Time) (int64, error)
+
+	// ClaimDue leases up to limit pending deliveries of enabled subscriptions due at now
+	// until leaseUntil, so other dispatchers skip them
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int32) ([]*Delivery, error)
+
+	// MarkSucceeded records an accepted delivery
+	MarkSucceeded(ctx context.Context, id uuid.UUID, attempt Attempt) error
+
+	// MarkFailed records a failed attempt; a nil nextAttemptAt marks the delivery failed
+	MarkFailed(ctx context.Context, id uuid.UUID, attempt Attempt, nextAttemptAt *time.Time) error
+
+	// GetDelivery retrieves a delivery
+	GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error)
+
+	// ListDeliveries retrieves a subscription's deliveries, newest first, optionally filtered by status
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, status *DeliveryStatus, limit, offset int32) ([]*Delivery, error)
+
+	// Redeliver queues a succeeded or failed delivery again with a fresh set of attempts.
+	// Returns ErrDeliveryPending if it has not finished yet.
+	Redeliver(ctx context.Context, subscriptionID, deliveryID uuid.UUID, now time.Time) (*Delivery, error)
+
+	// DeleteFinishedBefore removes succeeded and failed deliveries created before the cutoff
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Sender sends a delivery to a subscription's endpoint
+type Sender interface {
+	// Send POSTs the delivery payload, signed with the subscription secret
+	Send(ctx context.Context, sub *Subscription, delivery *Delivery) Attempt
+}
+
+// Service manages webhook subscriptions for admins
+type Service interface {
+	// CreateSubscription validates and stores a subscription; the result includes its secret
+	CreateSubscription(ctx context.Context, req *CreateRequest) (*Subscription, error)
+
+	// GetSubscription retrieves a subscription
+	GetSubscription(ctx context.Context, id uuid.UUID) (*Subscription, error)
+
+	// ListSubscriptions retrieves subscriptions, newest first
+	ListSubscriptions(ctx context.Context, limit, offset int32) ([]*Subscription, error)
+
+	// UpdateSubscription changes a subscription; the result includes the secret only if rotated
+	UpdateSubscription(ctx context.Context, id uuid.UUID, req *UpdateRequest) (*Subscription, error)
+
+	// DeleteSubscription removes a subscription and its delivery log on behalf of actor
+	DeleteSubscription(ctx context.Context, id uuid.UUID, actor string) error
+
+	// ListDeliveries retrieves a subscription's delivery log, newest first
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, status *DeliveryStatus, limit, offset int32) ([]*Delivery, error)
+
+	// Redeliver queues a finished delivery again on behalf of actor
+	Redeliver(ctx context.Context, subscriptionID, deliveryID uuid.UUID, actor string) (*Delivery, error)
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Delivery request headers
+const (
+	// HeaderSignature carries "v1=" and the hex HMAC-SHA256 of "<timestamp>.<body>"
+	HeaderSignature = "X-Pandora-Signature"
+	// HeaderTimestamp carries the Unix time the request was signed at
+	HeaderTimestamp = "X-Pandora-Timestamp"
+	// HeaderDeliveryID identifies the delivery; it is stable across retries and redeliveries
+	HeaderDeliveryID = "X-Pandora-Delivery-Id"
+	// HeaderEventID and HeaderEventType identify the delivered event
+	HeaderEventID   = "X-Pandora-Event-Id"
+	HeaderEventType = "X-Pandora-Event-Type"
+)
+
+// signatureVersion prefixes signatures so the scheme can change without breaking receivers
+const signatureVersion = "v1"
+
+// Sign returns the signature header value for body sent at timestamp.
+// The timestamp is part of the signed content, so a captured request cannot be replayed
+// later with a fresh timestamp.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and timestamp headers of a received delivery. Requests signed
+// more than tolerance before or after now are rejected; receivers should use a few minutes.
+func Verify(secret, signature, timestamp string, body []byte, now time.Time, tolerance time.Duration) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp", ErrInvalidSignature)
+	}
+	signedAt := time.Unix(unix, 0)
+	if now.Sub(signedAt) > tolerance || signedAt.Sub(now) > tolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidSignature)
+	}
+	if !strings.HasPrefix(signature, signatureVersion+"=") {
+		return fmt.Errorf("%w: unsupported signature version", ErrInvalidSignature)
+	}
+
+	expected := Sign(secret, signedAt, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
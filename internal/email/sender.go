@@ -0,0 +1,40 @@
+// Package email delivers account restore links. Senders implement user.RestoreLinkSender and
+// are selected by name in configuration.
+package email
+
+import (
+	"context"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+)
+
+// Sender names used in configuration
+const (
+	SenderLog = "log"
+)
+
+// Compile-time check to ensure LogSender implements user.RestoreLinkSender
+var _ user.RestoreLinkSender = (*LogSender)(nil)
+
+// LogSender writes restore links to the service log instead of emailing them. It lets
+// account restore be exercised in development and must not be used in production.
+type LogSender struct {
+	logger *observability.Logger
+}
+
+// NewLogSender creates a sender that logs links
+func NewLogSender(logger *observability.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+// SendRestoreLink logs link for email
+func (s *LogSender) SendRestoreLink(ctx context.Context, email, link string, expiresAt time.Time) error {
+	s.logger.WithFields(map[string]interface{}{
+		"email":      email,
+		"link":       link,
+		"expires_at": expiresAt.UTC().Format(time.RFC3339),
+	}).Warn("Account restore link (log sender, not delivered)")
+	return nil
+}
@@ -0,0 +1,666 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Consumer defaults, applied to zero ConsumerConfig fields
+const (
+	DefaultConsumerConcurrency = 10
+	DefaultConsumerBatchSize   = 10
+	DefaultConsumerBlock       = 2 * time.Second
+	DefaultClaimMinIdle        = time.Minute
+	DefaultClaimInterval       = 30 * time.Second
+	DefaultMaxDeliveries       = 5
+	DefaultHandlerTimeout      = 30 * time.Second
+	// DeadLetterSuffix is appended to the stream name when no dead-letter stream is configured
+	DeadLetterSuffix = ":dlq"
+)
+
+// ackTimeout bounds acknowledgements and dead-letter writes, which still run during shutdown
+const ackTimeout = 5 * time.Second
+
+// Handler processes one event. Returning nil acknowledges the message. Any other error leaves
+// it pending, so it is redelivered once idle for ClaimMinIdle; wrap the error with Permanent to
+// dead-letter it right away instead.
+type Handler func(ctx context.Context, msg *Message) error
+
+// Message is a stream entry written by RedisEventPublisher
+type Message struct {
+	// StreamID is the Redis stream entry ID
+	StreamID   string
+	EventID    string
+	EventType  string
+	OccurredAt time.Time
+	Source     string
+	Subject    string
+	DataSchema string
+	// SchemaVersion is the payload schema version; entries written before versioning report 1.
+	// Handlers should check it before decoding when they depend on a particular version.
+	SchemaVersion int
+	// Attributes holds the routing fields stored next to the payload (e.g. user_id, severity)
+	Attributes map[string]string
+	Payload    json.RawMessage
+	Metadata   map[string]string
+	// Deliveries counts deliveries of this message to the group, including this one
+	Deliveries int64
+}
+
+// DecodePayload unmarshals the event payload into v
+func (m *Message) DecodePayload(v interface{}) error {
+	return json.Unmarshal(m.Payload, v)
+}
+
+// CloudEvent returns the message in CloudEvents form
+func (m *Message) CloudEvent() *common.CloudEvent {
+	return &common.CloudEvent{
+		SpecVersion:     common.CloudEventsSpecVersion,
+		ID:              m.EventID,
+		Source:          m.Source,
+		Type:            m.EventType,
+		Subject:         m.Subject,
+		Time:            m.OccurredAt,
+		DataContentType: common.EventDataContentType,
+		DataSchema:      m.DataSchema,
+		SchemaVersion:   m.SchemaVersion,
+		Metadata:        m.Metadata,
+		Data:            m.Payload,
+	}
+}
+
+// permanentError marks a handler error that retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so the consumer dead-letters the message instead of retrying it
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// ConsumerConfig configures a Consumer. Zero values select the defaults above.
+type ConsumerConfig struct {
+	// Stream to consume; defaults to DefaultStreamName
+	Stream string
+
+	// Group is the consumer group name, usually the consuming service (e.g. "wallet-service")
+	Group string
+
+	// Consumer identifies this instance within the group; defaults to the hostname.
+	// It must be unique among running instances of the group.
+	Consumer string
+
+	// StartID is where a newly created group starts reading: "$" (default) for new events only,
+	// "0" for everything still in the stream. Ignored when the group already exists.
+	StartID string
+
+	// Concurrency bounds the number of handlers running at once.
+	// Messages are processed concurrently, so ordering is not preserved when it is above 1.
+	Concurrency int
+
+	// BatchSize is the number of messages read or claimed per request
+	BatchSize int64
+
+	// Block is how long a read waits for new messages
+	Block time.Duration
+
+	// ClaimMinIdle is how long a message must stay unacknowledged before it is claimed for
+	// redelivery, whether its handler failed or its consumer died
+	ClaimMinIdle time.Duration
+
+	// ClaimInterval is how often stuck pending messages are claimed
+	ClaimInterval time.Duration
+
+	// MaxDeliveries is how often a message is delivered before it moves to the dead-letter stream
+	MaxDeliveries int64
+
+	// DeadLetterStream receives poison messages; defaults to Stream + DeadLetterSuffix
+	DeadLetterStream string
+
+	// HandlerTimeout bounds a single handler call
+	HandlerTimeout time.Duration
+}
+
+// withDefaults returns cfg with zero fields replaced by defaults
+func (cfg ConsumerConfig) withDefaults() ConsumerConfig {
+	if cfg.Stream == "" {
+		cfg.Stream = DefaultStreamName
+	}
+	if cfg.Consumer == "" {
+		if hostname, err := os.Hostname(); err == nil {
+			cfg.Consumer = hostname
+		}
+	}
+	if cfg.StartID == "" {
+		cfg.StartID = "$"
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = DefaultConsumerConcurrency
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultConsumerBatchSize
+	}
+	if cfg.Block <= 0 {
+		cfg.Block = DefaultConsumerBlock
+	}
+	if cfg.ClaimMinIdle <= 0 {
+		cfg.ClaimMinIdle = DefaultClaimMinIdle
+	}
+	if cfg.ClaimInterval <= 0 {
+		cfg.ClaimInterval = DefaultClaimInterval
+	}
+	if cfg.MaxDeliveries <= 0 {
+		cfg.MaxDeliveries = DefaultMaxDeliveries
+	}
+	if cfg.DeadLetterStream == "" {
+		cfg.DeadLetterStream = cfg.Stream + DeadLetterSuffix
+	}
+	if cfg.HandlerTimeout <= 0 {
+		cfg.HandlerTimeout = DefaultHandlerTimeout
+	}
+	return cfg
+}
+
+// Consumer reads events from a Redis Stream as part of a consumer group and dispatches them
+// to handlers registered per event type. Delivery is at-least-once: a message is acknowledged
+// only after its handler succeeded, so handlers must be idempotent (deduplicate on EventID).
+//
+// Messages of event types without a handler are acknowledged and skipped. Messages that
+// cannot be decoded, fail permanently, or exceed MaxDeliveries are copied to the dead-letter
+// stream with the failure details and acknowledged.
+type Consumer struct {
+	client *redis.Client
+	cfg    ConsumerConfig
+	logger *zap.Logger
+
+	mu         sync.RWMutex
+	handlers   map[string]Handler
+	inflight   map[string]struct{}
+	lastErrors map[string]string
+
+	slots chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewConsumer creates a consumer; register handlers with Handle before calling Run
+func NewConsumer(client *redis.Client, cfg ConsumerConfig, logger *zap.Logger) (*Consumer, error) {
+	cfg = cfg.withDefaults()
+	if cfg.Group == "" {
+		return nil, fmt.Errorf("consumer group is required")
+	}
+	if cfg.Consumer == "" {
+		return nil, fmt.Errorf("consumer name is required")
+	}
+	if cfg.DeadLetterStream == cfg.Stream {
+		return nil, fmt.Errorf("dead-letter stream must differ from the consumed stream")
+	}
+
+	return &Consumer{
+		client:     client,
+		cfg:        cfg,
+		logger:     logger,
+		handlers:   make(map[string]Handler),
+		inflight:   make(map[string]struct{}),
+		lastErrors: make(map[string]string),
+		slots:      make(chan struct{}, cfg.Concurrency),
+	}, nil
+}
+
+// Handle registers handler for eventType, replacing any earlier registration
+func (c *Consumer) Handle(eventType string, handler Handler) *Consumer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers[eventType] = handler
+	return c
+}
+
+// HandlePayload registers a handler that receives the payload decoded into T.
+// A payload that does not decode into T is dead-lettered.
+func HandlePayload[T any](c *Consumer, eventType string, handler func(ctx context.Context, msg *Message, payload T) error) *Consumer {
+	return c.Handle(eventType, func(ctx context.Context, msg *Message) error {
+		var payload T
+		if err := msg.DecodePayload(&payload); err != nil {
+			return Permanent(fmt.Errorf("failed to decode %s payload: %w", eventType, err))
+		}
+		return handler(ctx, msg, payload)
+	})
+}
+
+// Run creates the consumer group if needed and consumes until ctx is cancelled.
+// On cancellation it stops reading, waits for running handlers to finish and returns nil;
+// messages read but not yet started stay pending and are claimed by another consumer.
+func (c *Consumer) Run(ctx context.Context) error {
+	if err := c.ensureGroup(ctx); err != nil {
+		return err
+	}
+
+	c.logger.Info("Event consumer started",
+		zap.String("stream", c.cfg.Stream),
+		zap.String("group", c.cfg.Group),
+		zap.String("consumer", c.cfg.Consumer),
+		zap.Int("concurrency", c.cfg.Concurrency))
+
+	claimDone := make(chan struct{})
+	go func() {
+		defer close(claimDone)
+		c.claimLoop(ctx)
+	}()
+
+	c.readLoop(ctx)
+	<-claimDone
+	c.wg.Wait()
+
+	c.logger.Info("Event consumer stopped",
+		zap.String("stream", c.cfg.Stream),
+		zap.String("group", c.cfg.Group),
+		zap.String("consumer", c.cfg.Consumer))
+	return nil
+}
+
+// ensureGroup creates the consumer group (and the stream) unless it exists
+func (c *Consumer) ensureGroup(ctx context.Context) error {
+	err := c.client.XGroupCreateMkStream(ctx, c.cfg.Stream, c.cfg.Group, c.cfg.StartID).Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group %s: %w", c.cfg.Group, err)
+	}
+	return nil
+}
+
+// readLoop reads new messages for this consumer and dispatches them
+func (c *Consumer) readLoop(ctx context.Context) {
+	for ctx.Err() == nil {
+		streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.cfg.Group,
+			Consumer: c.cfg.Consumer,
+			Streams:  []string{c.cfg.Stream, ">"},
+			Count:    c.cfg.BatchSize,
+			Block:    c.cfg.Block,
+		}).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+				continue
+			}
+			c.logger.Error("Failed to read from stream",
+				zap.String("stream", c.cfg.Stream),
+				zap.String("group", c.cfg.Group),
+				zap.Error(err))
+			sleepContext(ctx, time.Second)
+			continue
+		}
+
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				if !c.dispatch(ctx, message, 1) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// claimLoop periodically claims messages left pending by failed handlers or dead consumers
+func (c *Consumer) claimLoop(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.ClaimInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.claimStuck(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// claimStuck claims every message idle for at least ClaimMinIdle and dispatches it
+func (c *Consumer) claimStuck(ctx context.Context) {
+	start := "0-0"
+	for ctx.Err() == nil {
+		messages, next, err := c.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   c.cfg.Stream,
+			Group:    c.cfg.Group,
+			Consumer: c.cfg.Consumer,
+			MinIdle:  c.cfg.ClaimMinIdle,
+			Start:    start,
+			Count:    c.cfg.BatchSize,
+		}).Result()
+		if err != nil {
+			if ctx.Err() == nil {
+				c.logger.Error("Failed to claim pending messages",
+					zap.String("stream", c.cfg.Stream),
+					zap.String("group", c.cfg.Group),
+					zap.Error(err))
+			}
+			return
+		}
+
+		for _, message := range messages {
+			if !c.dispatch(ctx, message, c.deliveries(ctx, message.ID)) {
+				return
+			}
+		}
+
+		if next == "0-0" || len(messages) == 0 {
+			return
+		}
+		start = next
+	}
+}
+
+// deliveries returns the delivery count of a pending message
+func (c *Consumer) deliveries(ctx context.Context, id string) int64 {
+	pending, err := c.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: c.cfg.Stream,
+		Group:  c.cfg.Group,
+		Start:  id,
+		End:    id,
+		Count:  1,
+	}).Result()
+	if err != nil || len(pending) == 0 {
+		c.logger.Warn("Failed to read delivery count, assuming redelivery",
+			zap.String("stream_id", id),
+			zap.Error(err))
+		return 2
+	}
+	return pending[0].RetryCount
+}
+
+// dispatch waits for a free handler slot and processes message in the background.
+// It returns false when ctx was cancelled before a slot became free.
+func (c *Consumer) dispatch(ctx context.Context, message redis.XMessage, deliveries int64) bool {
+	select {
+	case c.slots <- struct{}{}:
+	case <-ctx.Done():
+		return false
+	}
+
+	c.mu.Lock()
+	if _, running := c.inflight[message.ID]; running {
+		// Claimed again while its handler is still running here
+		c.mu.Unlock()
+		<-c.slots
+		return true
+	}
+	c.inflight[message.ID] = struct{}{}
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer func() {
+			c.mu.Lock()
+			delete(c.inflight, message.ID)
+			c.mu.Unlock()
+			<-c.slots
+		}()
+		// Handlers and acknowledgements outlive cancellation so shutdown finishes in-flight work
+		c.process(context.WithoutCancel(ctx), message, deliveries)
+	}()
+	return true
+}
+
+// process runs the handler for one message and acknowledges or dead-letters it
+func (c *Consumer) process(ctx context.Context, message redis.XMessage, deliveries int64) {
+	msg, err := decodeMessage(message, deliveries)
+	if err != nil {
+		c.deadLetter(ctx, message, deliveries, fmt.Sprintf("malformed message: %v", err))
+		return
+	}
+
+	c.mu.RLock()
+	handler, ok := c.handlers[msg.EventType]
+	lastError := c.lastErrors[message.ID]
+	c.mu.RUnlock()
+
+	if !ok {
+		c.logger.Debug("No handler registered, skipping event",
+			zap.String("event_type", msg.EventType),
+			zap.String("stream_id", message.ID))
+		c.ack(ctx, message.ID)
+		return
+	}
+
+	if deliveries > c.cfg.MaxDeliveries {
+		reason := fmt.Sprintf("exceeded %d deliveries", c.cfg.MaxDeliveries)
+		if lastError != "" {
+			reason += ": " + lastError
+		}
+		c.deadLetter(ctx, message, deliveries, reason)
+		return
+	}
+
+	handlerCtx, span := c.startProcessSpan(ctx, msg)
+	handlerCtx, cancel := context.WithTimeout(handlerCtx, c.cfg.HandlerTimeout)
+	err = callHandler(handlerCtx, handler, msg)
+	cancel()
+	endSpan(span, err)
+
+	switch {
+	case err == nil:
+		c.ack(ctx, message.ID)
+	case IsPermanent(err):
+		c.deadLetter(ctx, message, deliveries, err.Error())
+	default:
+		c.mu.Lock()
+		c.lastErrors[message.ID] = err.Error()
+		c.mu.Unlock()
+		c.logger.Warn("Event handler failed, message will be redelivered",
+			zap.String("event_id", msg.EventID),
+			zap.String("event_type", msg.EventType),
+			zap.String("stream_id", message.ID),
+			zap.Int64("deliveries", deliveries),
+			zap.Error(err))
+	}
+}
+
+// startProcessSpan starts the consumer span around a handler call. The publisher stores its
+// span context in the message metadata; the process span is its child, so the handler's work
+// joins the trace of the request that produced the event, and links to it as the messaging
+// semantic conventions recommend. Handlers receive the span in their context.
+func (c *Consumer) startProcessSpan(ctx context.Context, msg *Message) (context.Context, trace.Span) {
+	ctx = observability.ExtractTraceContext(ctx, msg.Metadata)
+
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", TransportRedis),
+			attribute.String("messaging.operation", "process"),
+			attribute.String("messaging.destination.name", c.cfg.Stream),
+			attribute.String("messaging.consumer.group.name", c.cfg.Group),
+			attribute.String("messaging.message.id", msg.EventID),
+			attribute.String("event.type", msg.EventType),
+			attribute.Int64("messaging.message.deliveries", msg.Deliveries),
+		),
+	}
+	if producer := trace.SpanContextFromContext(ctx); producer.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: producer}))
+	}
+	return otel.Tracer(observability.TracerName).Start(ctx, c.cfg.Stream+" process", opts...)
+}
+
+// callHandler runs handler, turning a panic into an error
+func callHandler(ctx context.Context, handler Handler, msg *Message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, msg)
+}
+
+// ack acknowledges a message
+func (c *Consumer) ack(ctx context.Context, id string) {
+	ctx, cancel := context.WithTimeout(ctx, ackTimeout)
+	defer cancel()
+
+	if err := c.client.XAck(ctx, c.cfg.Stream, c.cfg.Group, id).Err(); err != nil {
+		c.logger.Error("Failed to acknowledge message",
+			zap.String("stream_id", id),
+			zap.String("group", c.cfg.Group),
+			zap.Error(err))
+		return
+	}
+	c.forget(id)
+}
+
+// deadLetter copies message to the dead-letter stream and acknowledges it in one transaction
+func (c *Consumer) deadLetter(ctx context.Context, message redis.XMessage, deliveries int64, reason string) {
+	ctx, cancel := context.WithTimeout(ctx, ackTimeout)
+	defer cancel()
+
+	values := make(map[string]interface{}, len(message.Values)+7)
+	for key, value := range message.Values {
+		values[key] = value
+	}
+	values["dlq_original_stream"] = c.cfg.Stream
+	values["dlq_original_id"] = message.ID
+	values["dlq_group"] = c.cfg.Group
+	values["dlq_consumer"] = c.cfg.Consumer
+	values["dlq_deliveries"] = strconv.FormatInt(deliveries, 10)
+	values["dlq_reason"] = reason
+	values["dlq_failed_at"] = time.Now().UTC().Format(time.RFC3339Nano)
+
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: c.cfg.DeadLetterStream,
+			MaxLen: MaxStreamLength,
+			Approx: true,
+			Values: values,
+		})
+		pipe.XAck(ctx, c.cfg.Stream, c.cfg.Group, message.ID)
+		return nil
+	})
+	if err != nil {
+		c.logger.Error("Failed to dead-letter message",
+			zap.String("stream_id", message.ID),
+			zap.String("dead_letter_stream", c.cfg.DeadLetterStream),
+			zap.Error(err))
+		return
+	}
+	c.forget(message.ID)
+
+	c.logger.Warn("Message moved to dead-letter stream",
+		zap.String("stream_id", message.ID),
+		zap.Any("event_type", message.Values["event_type"]),
+		zap.String("dead_letter_stream", c.cfg.DeadLetterStream),
+		zap.Int64("deliveries", deliveries),
+		zap.String("reason", reason))
+}
+
+// forget drops the last recorded handler error of a finished message
+func (c *Consumer) forget(id string) {
+	c.mu.Lock()
+	delete(c.lastErrors, id)
+	c.mu.Unlock()
+}
+
+// decodeMessage parses a stream entry written by RedisEventPublisher.streamValues
+func decodeMessage(message redis.XMessage, deliveries int64) (*Message, error) {
+	field := func(key string) string {
+		value, _ := message.Values[key].(string)
+		return value
+	}
+
+	msg := &Message{
+		StreamID:      message.ID,
+		EventID:       field("event_id"),
+		EventType:     field("event_type"),
+		Source:        field("source"),
+		Subject:       field("subject"),
+		DataSchema:    field("dataschema"),
+		SchemaVersion: 1,
+		Attributes:    make(map[string]string),
+		Deliveries:    deliveries,
+	}
+	if msg.EventType == "" {
+		return nil, fmt.Errorf("missing event_type")
+	}
+	if msg.Source == "" {
+		msg.Source = common.EventSource
+	}
+
+	if version := field("schemaversion"); version != "" {
+		schemaVersion, err := strconv.Atoi(version)
+		if err != nil || schemaVersion < 1 {
+			return nil, fmt.Errorf("invalid schemaversion %q", version)
+		}
+		msg.SchemaVersion = schemaVersion
+	}
+	if msg.DataSchema == "" {
+		msg.DataSchema = common.DataSchemaURI(msg.EventType, msg.SchemaVersion)
+	}
+
+	if timestamp := field("timestamp"); timestamp != "" {
+		occurredAt, err := time.Parse(time.RFC3339Nano, timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp: %w", err)
+		}
+		msg.OccurredAt = occurredAt
+	}
+
+	payload := field("payload")
+	if payload == "" {
+		payload = "null"
+	}
+	if !json.Valid([]byte(payload)) {
+		return nil, fmt.Errorf("payload is not valid JSON")
+	}
+	msg.Payload = json.RawMessage(payload)
+
+	if metadata := field("metadata"); metadata != "" {
+		if err := json.Unmarshal([]byte(metadata), &msg.Metadata); err != nil {
+			return nil, fmt.Errorf("invalid metadata: %w", err)
+		}
+	}
+
+	for key, value := range message.Values {
+		switch key {
+		case "event_id", "event_type", "timestamp", "payload", "metadata",
+			"specversion", "source", "subject", "datacontenttype", "dataschema", "schemaversion":
+			continue
+		}
+		if s, ok := value.(string); ok {
+			msg.Attributes[key] = s
+		}
+	}
+	return msg, nil
+}
+
+// sleepContext sleeps for d or until ctx is cancelled
+func sleepContext(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
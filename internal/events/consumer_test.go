@@ -0,0 +1,422 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zaptest"
+)
+
+func newTestConsumer(t *testing.T, cfg ConsumerConfig) (*Consumer, *redis.Client, *RedisEventPublisher) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	cfg.Group = "wallet-service"
+	cfg.Consumer = "wallet-1"
+	cfg.StartID = "0"
+	cfg.Block = 20 * time.Millisecond
+	if cfg.ClaimMinIdle == 0 {
+		cfg.ClaimMinIdle = 10 * time.Millisecond
+	}
+	if cfg.ClaimInterval == 0 {
+		cfg.ClaimInterval = 20 * time.Millisecond
+	}
+
+	consumer, err := NewConsumer(client, cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	return consumer, client, NewRedisEventPublisher(client, zaptest.NewLogger(t))
+}
+
+// runConsumer starts c and returns a function that stops it and waits for Run to return
+func runConsumer(t *testing.T, c *Consumer) func() {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			cancel()
+			select {
+			case err := <-done:
+				assert.NoError(t, err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("consumer did not stop")
+			}
+		})
+	}
+	t.Cleanup(stop)
+	return stop
+}
+
+func pendingCount(t *testing.T, client *redis.Client) int64 {
+	t.Helper()
+	pending, err := client.XPending(context.Background(), DefaultStreamName, "wallet-service").Result()
+	require.NoError(t, err)
+	return pending.Count
+}
+
+func registeredEvent(email string) *user.Event {
+	return user.NewTypedEvent(uuid.New(), user.RegisteredPayload{Email: email, Role: user.RoleUser})
+}
+
+func TestNewConsumer_Validation(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:0"})
+	defer client.Close()
+
+	_, err := NewConsumer(client, ConsumerConfig{Consumer: "c1"}, zaptest.NewLogger(t))
+	assert.Error(t, err, "group is required")
+
+	_, err = NewConsumer(client, ConsumerConfig{Group: "g", Consumer: "c1", DeadLetterStream: DefaultStreamName}, zaptest.NewLogger(t))
+	assert.Error(t, err, "dead-letter stream must differ")
+
+	consumer, err := NewConsumer(client, ConsumerConfig{Group: "g", Consumer: "c1"}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultStreamName, consumer.cfg.Stream)
+	assert.Equal(t, DefaultStreamName+DeadLetterSuffix, consumer.cfg.DeadLetterStream)
+	assert.Equal(t, int64(DefaultMaxDeliveries), consumer.cfg.MaxDeliveries)
+	assert.Equal(t, DefaultConsumerConcurrency, cap(consumer.slots))
+}
+
+func TestConsumer_HandlesAndAcknowledges(t *testing.T) {
+	consumer, client, publisher := newTestConsumer(t, ConsumerConfig{})
+
+	type registered struct {
+		Email string `json:"email"`
+	}
+	received := make(chan *Message, 1)
+	emails := make(chan string, 1)
+	HandlePayload(consumer, string(user.EventTypeUserRegistered), func(ctx context.Context, msg *Message, payload registered) error {
+		received <- msg
+		emails <- payload.Email
+		return nil
+	})
+
+	event := registeredEvent("jane@example.com").WithMetadata("request_id", "req-1")
+	require.NoError(t, publisher.Publish(event))
+	runConsumer(t, consumer)
+
+	select {
+	case msg := <-received:
+		assert.Equal(t, event.ID, msg.EventID)
+		assert.Equal(t, event.UserID.String(), msg.Attributes["user_id"])
+		assert.Equal(t, "req-1", msg.Metadata["request_id"])
+		assert.Equal(t, int64(1), msg.Deliveries)
+		assert.WithinDuration(t, event.Timestamp, msg.OccurredAt, time.Millisecond)
+		assert.Equal(t, event.UserID.String(), msg.Subject)
+		assert.Equal(t, 1, msg.SchemaVersion)
+		assert.Empty(t, msg.Attributes["schemaversion"])
+
+		cloudEvent := msg.CloudEvent()
+		assert.Equal(t, "/pandora-exchange/user-service", cloudEvent.Source)
+		assert.Equal(t, "https://schemas.pandora.exchange/events/user.registered/v1.json", cloudEvent.DataSchema)
+		assert.Equal(t, "jane@example.com", <-emails)
+	case <-time.After(2 * time.Second):
+		t.Fatal("event not handled")
+	}
+
+	assert.Eventually(t, func() bool { return pendingCount(t, client) == 0 }, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestConsumer_AcknowledgesUnhandledEventTypes(t *testing.T) {
+	consumer, client, publisher := newTestConsumer(t, ConsumerConfig{})
+	require.NoError(t, publisher.Publish(registeredEvent("jane@example.com")))
+	messages, err := client.XRange(context.Background(), DefaultStreamName, "-", "+").Result()
+	require.NoError(t, err)
+
+	runConsumer(t, consumer)
+
+	assert.Eventually(t, func() bool {
+		groups, err := client.XInfoGroups(context.Background(), DefaultStreamName).Result()
+		return err == nil && len(groups) == 1 && groups[0].LastDeliveredID == messages[0].ID && groups[0].Pending == 0
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Zero(t, client.XLen(context.Background(), DefaultStreamName+DeadLetterSuffix).Val())
+}
+
+func TestConsumer_RedeliversFailedMessages(t *testing.T) {
+	consumer, client, publisher := newTestConsumer(t, ConsumerConfig{})
+
+	var calls atomic.Int64
+	handled := make(chan int64, 1)
+	consumer.Handle(string(user.EventTypeUserRegistered), func(ctx context.Context, msg *Message) error {
+		if calls.Add(1) == 1 {
+			return errors.New("wallet database unavailable")
+		}
+		handled <- msg.Deliveries
+		return nil
+	})
+	require.NoError(t, publisher.Publish(registeredEvent("jane@example.com")))
+
+	runConsumer(t, consumer)
+
+	select {
+	case deliveries := <-handled:
+		assert.Equal(t, int64(2), deliveries)
+	case <-time.After(2 * time.Second):
+		t.Fatal("failed message was not redelivered")
+	}
+	assert.Eventually(t, func() bool { return pendingCount(t, client) == 0 }, 2*time.Second, 10*time.Millisecond)
+	assert.Zero(t, client.XLen(context.Background(), DefaultStreamName+DeadLetterSuffix).Val())
+}
+
+func TestConsumer_DeadLettersAfterMaxDeliveries(t *testing.T) {
+	consumer, client, publisher := newTestConsumer(t, ConsumerConfig{MaxDeliveries: 3})
+
+	var calls atomic.Int64
+	consumer.Handle(string(user.EventTypeUserRegistered), func(ctx context.Context, msg *Message) error {
+		calls.Add(1)
+		return errors.New("wallet database unavailable")
+	})
+	event := registeredEvent("jane@example.com")
+	require.NoError(t, publisher.Publish(event))
+
+	stop := runConsumer(t, consumer)
+
+	dlq := DefaultStreamName + DeadLetterSuffix
+	assert.Eventually(t, func() bool { return client.XLen(context.Background(), dlq).Val() == 1 }, 3*time.Second, 10*time.Millisecond)
+	stop()
+
+	assert.Equal(t, int64(3), calls.Load())
+	assert.Zero(t, pendingCount(t, client))
+
+	messages, err := client.XRange(context.Background(), dlq, "-", "+").Result()
+	require.NoError(t, err)
+	values := messages[0].Values
+	assert.Equal(t, event.ID, values["event_id"])
+	assert.Equal(t, string(user.EventTypeUserRegistered), values["event_type"])
+	assert.Equal(t, DefaultStreamName, values["dlq_original_stream"])
+	assert.Equal(t, "wallet-service", values["dlq_group"])
+	assert.Equal(t, "4", values["dlq_deliveries"])
+	assert.Contains(t, values["dlq_reason"], "exceeded 3 deliveries: wallet database unavailable")
+}
+
+func TestConsumer_DeadLettersPermanentFailures(t *testing.T) {
+	consumer, client, publisher := newTestConsumer(t, ConsumerConfig{})
+
+	var calls atomic.Int64
+	HandlePayload(consumer, string(user.EventTypeUserRegistered), func(ctx context.Context, msg *Message, payload struct{}) error {
+		calls.Add(1)
+		return Permanent(errors.New("unsupported currency"))
+	})
+	require.NoError(t, publisher.Publish(registeredEvent("jane@example.com")))
+
+	// Entries the publisher never writes (no event_type) are dead-lettered without reaching a handler
+	require.NoError(t, client.XAdd(context.Background(), &redis.XAddArgs{
+		Stream: DefaultStreamName,
+		Values: map[string]interface{}{"garbage": "1"},
+	}).Err())
+
+	stop := runConsumer(t, consumer)
+
+	dlq := DefaultStreamName + DeadLetterSuffix
+	assert.Eventually(t, func() bool { return client.XLen(context.Background(), dlq).Val() == 2 }, 2*time.Second, 10*time.Millisecond)
+	stop()
+
+	assert.Equal(t, int64(1), calls.Load())
+	assert.Zero(t, pendingCount(t, client))
+
+	messages, err := client.XRange(context.Background(), dlq, "-", "+").Result()
+	require.NoError(t, err)
+	reasons := map[interface{}]interface{}{}
+	for _, message := range messages {
+		reasons[message.Values["event_type"]] = message.Values["dlq_reason"]
+	}
+	assert.Equal(t, "unsupported currency", reasons[string(user.EventTypeUserRegistered)])
+	assert.Equal(t, "malformed message: missing event_type", reasons[nil])
+}
+
+func TestConsumer_BoundsConcurrency(t *testing.T) {
+	consumer, client, publisher := newTestConsumer(t, ConsumerConfig{Concurrency: 2, ClaimMinIdle: time.Minute})
+
+	var running, maxRunning, handled atomic.Int64
+	consumer.Handle(string(user.EventTypeUserRegistered), func(ctx context.Context, msg *Message) error {
+		now := running.Add(1)
+		for {
+			seen := maxRunning.Load()
+			if now <= seen || maxRunning.CompareAndSwap(seen, now) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		running.Add(-1)
+		handled.Add(1)
+		return nil
+	})
+	for i := 0; i < 6; i++ {
+		require.NoError(t, publisher.Publish(registeredEvent("jane@example.com")))
+	}
+
+	runConsumer(t, consumer)
+
+	assert.Eventually(t, func() bool { return handled.Load() == 6 }, 3*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(2), maxRunning.Load())
+	assert.Eventually(t, func() bool { return pendingCount(t, client) == 0 }, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestConsumer_ShutdownFinishesInFlightHandlers(t *testing.T) {
+	consumer, client, publisher := newTestConsumer(t, ConsumerConfig{ClaimMinIdle: time.Minute})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var handlerCtxErr error
+	consumer.Handle(string(user.EventTypeUserRegistered), func(ctx context.Context, msg *Message) error {
+		close(started)
+		<-release
+		handlerCtxErr = ctx.Err()
+		return nil
+	})
+	require.NoError(t, publisher.Publish(registeredEvent("jane@example.com")))
+
+	stop := runConsumer(t, consumer)
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler not started")
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		t.Fatal("Run returned while a handler was running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-stopped
+
+	assert.NoError(t, handlerCtxErr, "handler context is not cancelled by shutdown")
+	assert.Zero(t, pendingCount(t, client))
+}
+
+// useTestTracing records spans in memory and propagates W3C trace context for the test
+func useTestTracing(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return exporter
+}
+
+// findSpan returns the ended span named name
+func findSpan(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range exporter.GetSpans() {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("span %q not recorded", name)
+	return tracetest.SpanStub{}
+}
+
+func TestConsumer_ContinuesTraceFromPublisher(t *testing.T) {
+	exporter := useTestTracing(t)
+	consumer, client, publisher := newTestConsumer(t, ConsumerConfig{})
+
+	// The request that changed the user records its trace in the event metadata
+	requestCtx, requestSpan := otel.Tracer("test").Start(context.Background(), "PUT /users/me")
+	event := registeredEvent("jane@example.com")
+	observability.InjectTraceContext(requestCtx, event.Metadata)
+	requestSpan.End()
+
+	handled := make(chan trace.SpanContext, 1)
+	consumer.Handle("user.registered", func(ctx context.Context, msg *Message) error {
+		_, child := otel.Tracer("test").Start(ctx, "credit welcome bonus")
+		child.End()
+		handled <- trace.SpanContextFromContext(ctx)
+		return nil
+	})
+	runConsumer(t, consumer)
+
+	require.NoError(t, publisher.Publish(event))
+	var handlerSpan trace.SpanContext
+	select {
+	case handlerSpan = <-handled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("event was not handled")
+	}
+	require.Eventually(t, func() bool { return pendingCount(t, client) == 0 }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, requestSpan.SpanContext().SpanID().String(), event.Metadata[observability.TraceParentKey][36:52], "publishing does not modify the event")
+
+	publish := findSpan(t, exporter, DefaultStreamName+" publish")
+	assert.Equal(t, trace.SpanKindProducer, publish.SpanKind)
+	assert.Equal(t, requestSpan.SpanContext().SpanID(), publish.Parent.SpanID())
+
+	process := findSpan(t, exporter, DefaultStreamName+" process")
+	assert.Equal(t, trace.SpanKindConsumer, process.SpanKind)
+	assert.Equal(t, publish.SpanContext.SpanID(), process.Parent.SpanID())
+	require.Len(t, process.Links, 1)
+	assert.Equal(t, publish.SpanContext.SpanID(), process.Links[0].SpanContext.SpanID())
+	assert.Equal(t, process.SpanContext.SpanID(), handlerSpan.SpanID())
+
+	attributes := map[string]string{}
+	for _, attribute := range process.Attributes {
+		attributes[string(attribute.Key)] = attribute.Value.Emit()
+	}
+	assert.Equal(t, "wallet-service", attributes["messaging.consumer.group.name"])
+	assert.Equal(t, event.ID, attributes["messaging.message.id"])
+
+	traceID := requestSpan.SpanContext().TraceID()
+	for _, span := range exporter.GetSpans() {
+		assert.Equal(t, traceID, span.SpanContext.TraceID(), "span %q is part of the request trace", span.Name)
+	}
+	assert.Len(t, exporter.GetSpans(), 4)
+}
+
+func TestConsumer_FailedHandlerMarksSpan(t *testing.T) {
+	exporter := useTestTracing(t)
+	consumer, _, publisher := newTestConsumer(t, ConsumerConfig{})
+
+	consumer.Handle("user.registered", func(ctx context.Context, msg *Message) error {
+		return Permanent(errors.New("unknown currency"))
+	})
+	runConsumer(t, consumer)
+
+	// Without trace context in the metadata the publish span starts a new trace
+	require.NoError(t, publisher.Publish(registeredEvent("jane@example.com")))
+	require.Eventually(t, func() bool {
+		for _, span := range exporter.GetSpans() {
+			if span.Name == DefaultStreamName+" process" {
+				return true
+			}
+		}
+		return false
+	}, 2*time.Second, 10*time.Millisecond)
+
+	publish := findSpan(t, exporter, DefaultStreamName+" publish")
+	assert.False(t, publish.Parent.IsValid())
+
+	process := findSpan(t, exporter, DefaultStreamName+" process")
+	assert.Equal(t, codes.Error, process.Status.Code)
+	assert.Equal(t, publish.SpanContext.TraceID(), process.SpanContext.TraceID())
+}
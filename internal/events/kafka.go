@@ -0,0 +1,220 @@
+package events
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultKafkaTopic is the topic user service events are produced to
+	DefaultKafkaTopic = "user-service.events"
+	// DefaultKafkaAuditTopic is the topic high and critical severity audit events are produced to
+	DefaultKafkaAuditTopic = "user-service.audit"
+	// DefaultPublishTimeout bounds a single Publish or PublishBatch call
+	DefaultPublishTimeout = 10 * time.Second
+)
+
+// Kafka SASL mechanisms, selected with KAFKA_SASL_MECHANISM
+const (
+	KafkaSASLPlain       = "plain"
+	KafkaSASLScramSHA256 = "scram-sha-256"
+	KafkaSASLScramSHA512 = "scram-sha-512"
+)
+
+// KafkaOptions configures the Kafka client events are produced with
+type KafkaOptions struct {
+	// Brokers are the seed brokers (host:port); the rest of the cluster is discovered
+	Brokers  []string
+	ClientID string
+	TLS      bool
+	// SASLMechanism authenticates with Username and Password when Username is set
+	SASLMechanism string
+	Username      string
+	Password      string
+}
+
+// NewKafkaClient creates a Kafka producer client. The client is idempotent and hashes record
+// keys to partitions, so records with the same key are appended to one partition in the
+// order they were produced, also across retries.
+func NewKafkaClient(opts KafkaOptions) (*kgo.Client, error) {
+	clientOpts := []kgo.Opt{
+		kgo.SeedBrokers(opts.Brokers...),
+		kgo.ClientID(opts.ClientID),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+	}
+	if opts.TLS {
+		clientOpts = append(clientOpts, kgo.DialTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}))
+	}
+	if opts.Username != "" {
+		switch opts.SASLMechanism {
+		case KafkaSASLPlain:
+			clientOpts = append(clientOpts, kgo.SASL(plain.Auth{User: opts.Username, Pass: opts.Password}.AsMechanism()))
+		case KafkaSASLScramSHA256:
+			clientOpts = append(clientOpts, kgo.SASL(scram.Auth{User: opts.Username, Pass: opts.Password}.AsSha256Mechanism()))
+		case KafkaSASLScramSHA512:
+			clientOpts = append(clientOpts, kgo.SASL(scram.Auth{User: opts.Username, Pass: opts.Password}.AsSha512Mechanism()))
+		default:
+			return nil, fmt.Errorf("unsupported Kafka SASL mechanism %q", opts.SASLMechanism)
+		}
+	}
+
+	client, err := kgo.NewClient(clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+	return client, nil
+}
+
+// KafkaEventPublisher publishes domain events to a Kafka topic as structured-mode CloudEvents.
+// Records are keyed by user ID, so each user's events stay ordered within one partition.
+type KafkaEventPublisher struct {
+	client  *kgo.Client
+	topic   string
+	timeout time.Duration
+	logger  *zap.Logger
+}
+
+// NewKafkaEventPublisher creates a publisher producing to topic
+func NewKafkaEventPublisher(client *kgo.Client, topic string, logger *zap.Logger) *KafkaEventPublisher {
+	return &KafkaEventPublisher{
+		client:  client,
+		topic:   topic,
+		timeout: DefaultPublishTimeout,
+		logger:  logger,
+	}
+}
+
+// WithTimeout sets how long a publish waits for the broker
+func (p *KafkaEventPublisher) WithTimeout(timeout time.Duration) *KafkaEventPublisher {
+	p.timeout = timeout
+	return p
+}
+
+// Publish produces a single event
+func (p *KafkaEventPublisher) Publish(event interface{}) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	encoded, err := encodeEvent(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	ctx, span := startPublishSpan(ctx, TransportKafka, p.topic, encoded.envelope)
+	observability.InjectTraceContext(ctx, encoded.headers)
+	err = p.client.ProduceSync(ctx, p.record(encoded)).FirstErr()
+	endSpan(span, err)
+	if err != nil {
+		p.logger.Error("Failed to publish event to Kafka",
+			zap.String("event_id", encoded.envelope.EventID()),
+			zap.String("event_type", encoded.envelope.EventType()),
+			zap.String("topic", p.topic),
+			zap.Error(err))
+		return fmt.Errorf("failed to publish event to Kafka: %w", err)
+	}
+
+	p.logger.Info("Event published successfully",
+		zap.String("event_id", encoded.envelope.EventID()),
+		zap.String("event_type", encoded.envelope.EventType()),
+		zap.String("topic", p.topic),
+		zap.String("key", encoded.key))
+
+	return nil
+}
+
+// PublishBatch produces multiple events, preserving their order. Nothing is produced if an
+// event cannot be encoded.
+func (p *KafkaEventPublisher) PublishBatch(events []interface{}) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	records := make([]*kgo.Record, 0, len(events))
+	var spans []trace.Span
+	for _, event := range events {
+		if event == nil {
+			p.logger.Warn("Skipping nil event in batch")
+			continue
+		}
+
+		encoded, err := encodeEvent(event)
+		if err != nil {
+			for _, span := range spans {
+				endSpan(span, err)
+			}
+			return fmt.Errorf("failed to encode batch event: %w", err)
+		}
+
+		spanCtx, span := startPublishSpan(ctx, TransportKafka, p.topic, encoded.envelope)
+		observability.InjectTraceContext(spanCtx, encoded.headers)
+		spans = append(spans, span)
+		records = append(records, p.record(encoded))
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	err := p.client.ProduceSync(ctx, records...).FirstErr()
+	for _, span := range spans {
+		endSpan(span, err)
+	}
+	if err != nil {
+		p.logger.Error("Failed to publish batch events to Kafka",
+			zap.Int("batch_size", len(records)),
+			zap.String("topic", p.topic),
+			zap.Error(err))
+		return fmt.Errorf("failed to publish batch events: %w", err)
+	}
+
+	p.logger.Info("Batch events published successfully",
+		zap.Int("batch_size", len(records)),
+		zap.String("topic", p.topic))
+
+	return nil
+}
+
+// Close closes the Kafka client, which may be shared with other publishers
+func (p *KafkaEventPublisher) Close() error {
+	if p.client != nil {
+		p.client.Close()
+	}
+	return nil
+}
+
+// record converts an encoded event to a Kafka record; headers are sorted for stable output
+func (p *KafkaEventPublisher) record(event *encodedEvent) *kgo.Record {
+	keys := make([]string, 0, len(event.headers))
+	for key := range event.headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	headers := make([]kgo.RecordHeader, 0, len(keys))
+	for _, key := range keys {
+		headers = append(headers, kgo.RecordHeader{Key: key, Value: []byte(event.headers[key])})
+	}
+
+	return &kgo.Record{
+		Topic:   p.topic,
+		Key:     []byte(event.key),
+		Value:   event.body,
+		Headers: headers,
+	}
+}
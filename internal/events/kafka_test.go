@@ -0,0 +1,257 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zaptest"
+)
+
+// newKafkaTestCluster starts an in-process Kafka cluster with the event topics
+func newKafkaTestCluster(t *testing.T, partitions int32, opts ...kfake.Opt) *kfake.Cluster {
+	opts = append([]kfake.Opt{
+		kfake.NumBrokers(1),
+		kfake.SeedTopics(partitions, DefaultKafkaTopic, DefaultKafkaAuditTopic),
+	}, opts...)
+	cluster, err := kfake.NewCluster(opts...)
+	require.NoError(t, err)
+	t.Cleanup(cluster.Close)
+	return cluster
+}
+
+func newKafkaTestPublisher(t *testing.T, cluster *kfake.Cluster, topic string) *KafkaEventPublisher {
+	client, err := NewKafkaClient(KafkaOptions{Brokers: cluster.ListenAddrs(), ClientID: "user-service-test"})
+	require.NoError(t, err)
+	publisher := NewKafkaEventPublisher(client, topic, zaptest.NewLogger(t)).WithTimeout(5 * time.Second)
+	t.Cleanup(func() { publisher.Close() })
+	return publisher
+}
+
+// consumeRecords reads the first n records of topic, in order within each partition
+func consumeRecords(t *testing.T, cluster *kfake.Cluster, topic string, n int) []*kgo.Record {
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(cluster.ListenAddrs()...),
+		kgo.ConsumeTopics(topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var records []*kgo.Record
+	for len(records) < n {
+		fetches := client.PollFetches(ctx)
+		require.NoError(t, ctx.Err(), "consumed %d of %d records", len(records), n)
+		require.Empty(t, fetches.Errors())
+		fetches.EachRecord(func(record *kgo.Record) {
+			records = append(records, record)
+		})
+	}
+	return records
+}
+
+func recordHeaders(record *kgo.Record) map[string]string {
+	headers := map[string]string{}
+	for _, header := range record.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	return headers
+}
+
+func TestKafkaEventPublisher_PublishesCloudEvent(t *testing.T) {
+	cluster := newKafkaTestCluster(t, 3)
+	publisher := newKafkaTestPublisher(t, cluster, DefaultKafkaTopic)
+
+	userID := uuid.New()
+	event := user.NewTypedEvent(userID, user.KYCUpdatedPayload{Email: "jane@example.com", KYCStatus: user.KYCStatusVerified})
+	require.NoError(t, publisher.Publish(event))
+
+	record := consumeRecords(t, cluster, DefaultKafkaTopic, 1)[0]
+	headers := recordHeaders(record)
+
+	assert.Equal(t, userID.String(), string(record.Key))
+	assert.Equal(t, ContentTypeCloudEventsJSON, headers["content-type"])
+	assert.Equal(t, event.ID, headers["ce_id"])
+	assert.Equal(t, "user.kyc.updated", headers["ce_type"])
+	assert.Equal(t, common.EventSource, headers["ce_source"])
+	assert.Equal(t, userID.String(), headers["ce_subject"])
+
+	var cloudEvent common.CloudEvent
+	require.NoError(t, json.Unmarshal(record.Value, &cloudEvent))
+	assert.Equal(t, event.ID, cloudEvent.ID)
+	assert.Equal(t, "https://schemas.pandora.exchange/events/user.kyc.updated/v1.json", cloudEvent.DataSchema)
+
+	var payload user.KYCUpdatedPayload
+	require.NoError(t, cloudEvent.DecodeData(&payload))
+	assert.Equal(t, user.KYCStatusVerified, payload.KYCStatus)
+}
+
+func TestKafkaEventPublisher_KeepsEachUsersEventsInOnePartition(t *testing.T) {
+	cluster := newKafkaTestCluster(t, 4)
+	publisher := newKafkaTestPublisher(t, cluster, DefaultKafkaTopic)
+
+	users := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+	var batch []interface{}
+	for i := 0; i < 4; i++ {
+		for _, userID := range users {
+			batch = append(batch, user.NewTypedEvent(userID, user.ProfileUpdatedPayload{FirstName: fmt.Sprintf("v%d", i)}))
+		}
+	}
+	batch = append(batch, nil)
+	require.NoError(t, publisher.PublishBatch(batch))
+
+	partitionOf := map[string]int32{}
+	versions := map[string][]string{}
+	for _, record := range consumeRecords(t, cluster, DefaultKafkaTopic, 20) {
+		key := string(record.Key)
+		if previous, seen := partitionOf[key]; seen {
+			assert.Equal(t, previous, record.Partition, "events of user %s split across partitions", key)
+		}
+		partitionOf[key] = record.Partition
+
+		var cloudEvent common.CloudEvent
+		require.NoError(t, json.Unmarshal(record.Value, &cloudEvent))
+		var payload user.ProfileUpdatedPayload
+		require.NoError(t, cloudEvent.DecodeData(&payload))
+		versions[key] = append(versions[key], payload.FirstName)
+	}
+
+	for _, userID := range users {
+		assert.Equal(t, []string{"v0", "v1", "v2", "v3"}, versions[userID.String()], "events of a user must stay in publish order")
+	}
+}
+
+func TestKafkaEventPublisher_KeysAuditEventsBySubject(t *testing.T) {
+	cluster := newKafkaTestCluster(t, 2)
+	publisher := newKafkaTestPublisher(t, cluster, DefaultKafkaAuditTopic)
+
+	auditID := uuid.New()
+	require.NoError(t, publisher.Publish(audit.NewTypedEvent(auditID, audit.LoggedPayload{AuditEventType: "admin.login.failed"})))
+
+	record := consumeRecords(t, cluster, DefaultKafkaAuditTopic, 1)[0]
+	assert.Equal(t, auditID.String(), string(record.Key))
+}
+
+func TestKafkaEventPublisher_PublishBatchRejectsUnencodableEvent(t *testing.T) {
+	cluster := newKafkaTestCluster(t, 1)
+	publisher := newKafkaTestPublisher(t, cluster, DefaultKafkaTopic)
+
+	err := publisher.PublishBatch([]interface{}{registeredEvent("first@example.com"), "not an event"})
+	assert.ErrorContains(t, err, "must implement common.EventEnvelope")
+
+	// Nothing of the rejected batch was produced
+	next := registeredEvent("next@example.com")
+	require.NoError(t, publisher.Publish(next))
+	record := consumeRecords(t, cluster, DefaultKafkaTopic, 1)[0]
+	assert.Equal(t, next.ID, recordHeaders(record)["ce_id"])
+}
+
+func TestKafkaEventPublisher_Errors(t *testing.T) {
+	cluster := newKafkaTestCluster(t, 1)
+	publisher := newKafkaTestPublisher(t, cluster, "missing-topic").WithTimeout(500 * time.Millisecond)
+
+	assert.EqualError(t, publisher.Publish(nil), "event cannot be nil")
+	assert.ErrorContains(t, publisher.Publish("not an event"), "must implement common.EventEnvelope")
+	assert.NoError(t, publisher.PublishBatch(nil))
+
+	err := publisher.Publish(registeredEvent("jane@example.com"))
+	assert.ErrorContains(t, err, "failed to publish event to Kafka")
+}
+
+func TestNewKafkaClient_SASL(t *testing.T) {
+	cluster := newKafkaTestCluster(t, 1, kfake.EnableSASL(), kfake.Superuser("SCRAM-SHA-512", "svc", "secret"))
+
+	client, err := NewKafkaClient(KafkaOptions{
+		Brokers:       cluster.ListenAddrs(),
+		SASLMechanism: KafkaSASLScramSHA512,
+		Username:      "svc",
+		Password:      "secret",
+	})
+	require.NoError(t, err)
+	publisher := NewKafkaEventPublisher(client, DefaultKafkaTopic, zaptest.NewLogger(t))
+	defer publisher.Close()
+	require.NoError(t, publisher.Publish(registeredEvent("jane@example.com")))
+
+	client, err = NewKafkaClient(KafkaOptions{
+		Brokers:       cluster.ListenAddrs(),
+		SASLMechanism: KafkaSASLScramSHA512,
+		Username:      "svc",
+		Password:      "wrong",
+	})
+	require.NoError(t, err)
+	unauthorized := NewKafkaEventPublisher(client, DefaultKafkaTopic, zaptest.NewLogger(t)).WithTimeout(2 * time.Second)
+	defer unauthorized.Close()
+	assert.Error(t, unauthorized.PublishBatch([]interface{}{registeredEvent("jane@example.com")}))
+
+	_, err = NewKafkaClient(KafkaOptions{Brokers: cluster.ListenAddrs(), SASLMechanism: "gssapi", Username: "svc"})
+	assert.ErrorContains(t, err, "unsupported Kafka SASL mechanism")
+}
+
+func TestKafkaEventPublisher_TracesPublish(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	cluster := newKafkaTestCluster(t, 1)
+	publisher := newKafkaTestPublisher(t, cluster, DefaultKafkaTopic)
+
+	event := registeredEvent("jane@example.com")
+	require.NoError(t, publisher.Publish(event))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, DefaultKafkaTopic+" publish", span.Name())
+	assert.Equal(t, trace.SpanKindProducer, span.SpanKind())
+
+	attributes := map[string]string{}
+	for _, attribute := range span.Attributes() {
+		attributes[string(attribute.Key)] = attribute.Value.Emit()
+	}
+	assert.Equal(t, TransportKafka, attributes["messaging.system"])
+	assert.Equal(t, DefaultKafkaTopic, attributes["messaging.destination.name"])
+	assert.Equal(t, event.ID, attributes["messaging.message.id"])
+	assert.Equal(t, "user.registered", attributes["event.type"])
+}
+
+func TestKafkaEventPublisher_PropagatesTraceContext(t *testing.T) {
+	exporter := useTestTracing(t)
+	cluster := newKafkaTestCluster(t, 1)
+	publisher := newKafkaTestPublisher(t, cluster, DefaultKafkaTopic)
+
+	requestCtx, requestSpan := otel.Tracer("test").Start(context.Background(), "PATCH /admin/users/:id/kyc")
+	event := registeredEvent("jane@example.com")
+	observability.InjectTraceContext(requestCtx, event.Metadata)
+	requestSpan.End()
+
+	require.NoError(t, publisher.Publish(event))
+
+	publish := findSpan(t, exporter, DefaultKafkaTopic+" publish")
+	assert.Equal(t, requestSpan.SpanContext().TraceID(), publish.SpanContext.TraceID())
+	assert.Equal(t, requestSpan.SpanContext().SpanID(), publish.Parent.SpanID())
+
+	// Consumers continue the trace from the publish span, not the request span
+	record := consumeRecords(t, cluster, DefaultKafkaTopic, 1)[0]
+	remote := trace.SpanContextFromContext(observability.ExtractTraceContext(context.Background(), recordHeaders(record)))
+	assert.Equal(t, publish.SpanContext.TraceID(), remote.TraceID())
+	assert.Equal(t, publish.SpanContext.SpanID(), remote.SpanID())
+}
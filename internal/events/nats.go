@@ -0,0 +1,171 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultNATSSubjectPrefix prefixes the subject of every user service event
+	DefaultNATSSubjectPrefix = "user-service.events"
+	// DefaultNATSAuditSubjectPrefix prefixes the subject of high and critical severity audit events
+	DefaultNATSAuditSubjectPrefix = "user-service.audit"
+)
+
+// ConnectJetStream connects to the NATS server at url (nats://[user:pass@]host:port, or tls://
+// for TLS) and returns its JetStream context. name identifies the client in the server's
+// connection list. The connection reconnects on its own for as long as it is open.
+func ConnectJetStream(url, name string) (jetstream.JetStream, error) {
+	conn, err := nats.Connect(url,
+		nats.Name(name),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+	return js, nil
+}
+
+// JetStreamEventPublisher publishes domain events to NATS JetStream as structured-mode
+// CloudEvents, on the subject <prefix>.<event type>. The event ID is sent as Nats-Msg-Id,
+// so a retried publish is stored once.
+type JetStreamEventPublisher struct {
+	js            jetstream.JetStream
+	subjectPrefix string
+	timeout       time.Duration
+	logger        *zap.Logger
+}
+
+// NewJetStreamEventPublisher creates a publisher using subjects under subjectPrefix
+func NewJetStreamEventPublisher(js jetstream.JetStream, subjectPrefix string, logger *zap.Logger) *JetStreamEventPublisher {
+	return &JetStreamEventPublisher{
+		js:            js,
+		subjectPrefix: subjectPrefix,
+		timeout:       DefaultPublishTimeout,
+		logger:        logger,
+	}
+}
+
+// WithTimeout sets how long a publish waits for the stream to acknowledge
+func (p *JetStreamEventPublisher) WithTimeout(timeout time.Duration) *JetStreamEventPublisher {
+	p.timeout = timeout
+	return p
+}
+
+// Subject returns the subject an event type is published on
+func (p *JetStreamEventPublisher) Subject(eventType string) string {
+	if p.subjectPrefix == "" {
+		return eventType
+	}
+	return p.subjectPrefix + "." + eventType
+}
+
+// Publish publishes a single event
+func (p *JetStreamEventPublisher) Publish(event interface{}) error {
+	if event == nil {
+		return fmt.Errorf("event cannot be nil")
+	}
+
+	encoded, err := encodeEvent(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	return p.publish(ctx, encoded)
+}
+
+// PublishBatch publishes events in order, stopping at the first event that cannot be
+// encoded or published
+func (p *JetStreamEventPublisher) PublishBatch(events []interface{}) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	published := 0
+	for _, event := range events {
+		if event == nil {
+			p.logger.Warn("Skipping nil event in batch")
+			continue
+		}
+
+		encoded, err := encodeEvent(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode batch event: %w", err)
+		}
+
+		if err := p.publish(ctx, encoded); err != nil {
+			return fmt.Errorf("failed to publish batch events: %w", err)
+		}
+		published++
+	}
+
+	p.logger.Info("Batch events published successfully",
+		zap.Int("batch_size", published),
+		zap.String("subject_prefix", p.subjectPrefix))
+
+	return nil
+}
+
+func (p *JetStreamEventPublisher) publish(ctx context.Context, event *encodedEvent) error {
+	subject := p.Subject(event.envelope.EventType())
+
+	ctx, span := startPublishSpan(ctx, TransportNATS, subject, event.envelope)
+	header := make(map[string]string, len(event.headers)+2)
+	for key, value := range event.headers {
+		header[key] = value
+	}
+	observability.InjectTraceContext(ctx, header)
+
+	msg := nats.NewMsg(subject)
+	msg.Data = event.body
+	for key, value := range header {
+		msg.Header.Set(key, value)
+	}
+
+	ack, err := p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(event.envelope.EventID()))
+	endSpan(span, err)
+	if err != nil {
+		p.logger.Error("Failed to publish event to NATS JetStream",
+			zap.String("event_id", event.envelope.EventID()),
+			zap.String("event_type", event.envelope.EventType()),
+			zap.String("subject", subject),
+			zap.Error(err))
+		return fmt.Errorf("failed to publish event to NATS: %w", err)
+	}
+
+	p.logger.Info("Event published successfully",
+		zap.String("event_id", event.envelope.EventID()),
+		zap.String("event_type", event.envelope.EventType()),
+		zap.String("subject", subject),
+		zap.String("stream", ack.Stream),
+		zap.Uint64("sequence", ack.Sequence),
+		zap.Bool("duplicate", ack.Duplicate))
+
+	return nil
+}
+
+// Close closes the NATS connection, which may be shared with other publishers
+func (p *JetStreamEventPublisher) Close() error {
+	if p.js != nil {
+		p.js.Conn().Close()
+	}
+	return nil
+}
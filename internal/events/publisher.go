@@ -4,18 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
-	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
 
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 const (
 	// Default stream name for user service events
 	DefaultStreamName = "user-service:events"
-	// Maximum number of events to retain in the stream (trimming)
+	// Stream name for high and critical severity audit events (fraud, alerting)
+	AuditStreamName = "user-service:audit-events"
+	// Default maximum number of events to retain in the stream (trimming)
 	MaxStreamLength = 10000
 )
 
@@ -23,6 +28,7 @@ const (
 type RedisEventPublisher struct {
 	client     *redis.Client
 	streamName string
+	maxLength  int64
 	logger     *zap.Logger
 }
 
@@ -31,6 +37,7 @@ func NewRedisEventPublisher(client *redis.Client, logger *zap.Logger) *RedisEven
 	return &RedisEventPublisher{
 		client:     client,
 		streamName: DefaultStreamName,
+		maxLength:  MaxStreamLength,
 		logger:     logger,
 	}
 }
@@ -41,65 +48,48 @@ func (p *RedisEventPublisher) WithStreamName(streamName string) *RedisEventPubli
 	return p
 }
 
+// WithMaxLength sets the approximate number of events retained in the stream;
+// 0 disables trimming
+func (p *RedisEventPublisher) WithMaxLength(maxLength int64) *RedisEventPublisher {
+	p.maxLength = maxLength
+	return p
+}
+
 // Publish publishes a single event to Redis Streams
 func (p *RedisEventPublisher) Publish(event interface{}) error {
 	if event == nil {
 		return fmt.Errorf("event cannot be nil")
 	}
 
-	// Type assert to user.Event
-	userEvent, ok := event.(*user.Event)
+	envelope, ok := event.(common.EventEnvelope)
 	if !ok {
-		return fmt.Errorf("event must be of type *user.Event")
+		return fmt.Errorf("event must implement common.EventEnvelope, got %T", event)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Serialize the event payload to JSON
-	payloadJSON, err := json.Marshal(userEvent.Payload)
+	ctx, span := startPublishSpan(ctx, TransportRedis, p.streamName, envelope)
+	values, err := p.streamValues(ctx, envelope)
 	if err != nil {
-		p.logger.Error("Failed to marshal event payload",
-			zap.String("event_id", userEvent.ID),
-			zap.String("event_type", string(userEvent.Type)),
-			zap.Error(err))
-		return fmt.Errorf("failed to marshal event payload: %w", err)
-	}
-
-	// Serialize metadata to JSON
-	metadataJSON, err := json.Marshal(userEvent.Metadata)
-	if err != nil {
-		p.logger.Error("Failed to marshal event metadata",
-			zap.String("event_id", userEvent.ID),
-			zap.String("event_type", string(userEvent.Type)),
-			zap.Error(err))
-		return fmt.Errorf("failed to marshal event metadata: %w", err)
-	}
-
-	// Prepare Redis Stream entry
-	// Each field is stored as a key-value pair in the stream entry
-	values := map[string]interface{}{
-		"event_id":   userEvent.ID,
-		"event_type": string(userEvent.Type),
-		"user_id":    userEvent.UserID.String(),
-		"timestamp":  userEvent.Timestamp.Format(time.RFC3339Nano),
-		"payload":    string(payloadJSON),
-		"metadata":   string(metadataJSON),
+		endSpan(span, err)
+		return err
 	}
 
 	// Add event to Redis Stream with automatic ID generation (*)
 	// MAXLEN ~ caps the stream size (approximate trimming for performance)
 	result := p.client.XAdd(ctx, &redis.XAddArgs{
 		Stream: p.streamName,
-		MaxLen: MaxStreamLength, // Trim to prevent unbounded growth
-		Approx: true,            // Approximate trimming for better performance
+		MaxLen: p.maxLength, // Trim to prevent unbounded growth; 0 keeps everything
+		Approx: true,        // Approximate trimming for better performance
 		Values: values,
 	})
+	endSpan(span, result.Err())
 
 	if err := result.Err(); err != nil {
 		p.logger.Error("Failed to publish event to Redis Stream",
-			zap.String("event_id", userEvent.ID),
-			zap.String("event_type", string(userEvent.Type)),
+			zap.String("event_id", envelope.EventID()),
+			zap.String("event_type", envelope.EventType()),
 			zap.String("stream", p.streamName),
 			zap.Error(err))
 		return fmt.Errorf("failed to publish event to Redis: %w", err)
@@ -107,9 +97,9 @@ func (p *RedisEventPublisher) Publish(event interface{}) error {
 
 	streamID := result.Val()
 	p.logger.Info("Event published successfully",
-		zap.String("event_id", userEvent.ID),
-		zap.String("event_type", string(userEvent.Type)),
-		zap.String("user_id", userEvent.UserID.String()),
+		zap.String("event_id", envelope.EventID()),
+		zap.String("event_type", envelope.EventType()),
+		zap.Any("attributes", envelope.Attributes()),
 		zap.String("stream", p.streamName),
 		zap.String("stream_id", streamID))
 
@@ -127,6 +117,7 @@ func (p *RedisEventPublisher) PublishBatch(events []interface{}) error {
 
 	// Use Redis pipeline for batch operations
 	pipe := p.client.Pipeline()
+	var spans []trace.Span
 
 	for _, event := range events {
 		if event == nil {
@@ -134,45 +125,27 @@ func (p *RedisEventPublisher) PublishBatch(events []interface{}) error {
 			continue
 		}
 
-		// Type assert to user.Event
-		userEvent, ok := event.(*user.Event)
+		envelope, ok := event.(common.EventEnvelope)
 		if !ok {
-			p.logger.Warn("Skipping non-user.Event in batch")
+			p.logger.Warn("Skipping event that does not implement common.EventEnvelope in batch",
+				zap.String("type", fmt.Sprintf("%T", event)))
 			continue
 		}
 
-		// Serialize payload
-		payloadJSON, err := json.Marshal(userEvent.Payload)
+		spanCtx, span := startPublishSpan(ctx, TransportRedis, p.streamName, envelope)
+		values, err := p.streamValues(spanCtx, envelope)
 		if err != nil {
-			p.logger.Error("Failed to marshal event payload in batch",
-				zap.String("event_id", userEvent.ID),
-				zap.String("event_type", string(userEvent.Type)),
-				zap.Error(err))
-			return fmt.Errorf("failed to marshal event payload: %w", err)
-		}
-
-		// Serialize metadata
-		metadataJSON, err := json.Marshal(userEvent.Metadata)
-		if err != nil {
-			p.logger.Error("Failed to marshal event metadata in batch",
-				zap.String("event_id", userEvent.ID),
-				zap.String("event_type", string(userEvent.Type)),
-				zap.Error(err))
-			return fmt.Errorf("failed to marshal event metadata: %w", err)
-		}
-
-		values := map[string]interface{}{
-			"event_id":   userEvent.ID,
-			"event_type": string(userEvent.Type),
-			"user_id":    userEvent.UserID.String(),
-			"timestamp":  userEvent.Timestamp.Format(time.RFC3339Nano),
-			"payload":    string(payloadJSON),
-			"metadata":   string(metadataJSON),
+			endSpan(span, err)
+			for _, started := range spans {
+				endSpan(started, err)
+			}
+			return err
 		}
+		spans = append(spans, span)
 
 		pipe.XAdd(ctx, &redis.XAddArgs{
 			Stream: p.streamName,
-			MaxLen: MaxStreamLength,
+			MaxLen: p.maxLength,
 			Approx: true,
 			Values: values,
 		})
@@ -180,6 +153,9 @@ func (p *RedisEventPublisher) PublishBatch(events []interface{}) error {
 
 	// Execute all commands in the pipeline
 	_, err := pipe.Exec(ctx)
+	for _, span := range spans {
+		endSpan(span, err)
+	}
 	if err != nil {
 		p.logger.Error("Failed to publish batch events to Redis Stream",
 			zap.Int("batch_size", len(events)),
@@ -195,6 +171,61 @@ func (p *RedisEventPublisher) PublishBatch(events []interface{}) error {
 	return nil
 }
 
+// streamValues builds the Redis Stream entry for an event.
+// Each field is stored as a key-value pair: the CloudEvents context attributes, the payload
+// and metadata as JSON strings, and the attributes as top-level fields unless they collide
+// with one of the envelope fields. The trace context of ctx, the publish span, is added to the
+// stored metadata so consumers continue the trace.
+func (p *RedisEventPublisher) streamValues(ctx context.Context, event common.EventEnvelope) (map[string]interface{}, error) {
+	// Serialize the event payload to JSON
+	payloadJSON, err := json.Marshal(event.EventPayload())
+	if err != nil {
+		p.logger.Error("Failed to marshal event payload",
+			zap.String("event_id", event.EventID()),
+			zap.String("event_type", event.EventType()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	// Serialize metadata to JSON; the event's own map is left untouched
+	metadata := make(map[string]string, len(event.EventMetadata())+1)
+	for key, value := range event.EventMetadata() {
+		metadata[key] = value
+	}
+	observability.InjectTraceContext(ctx, metadata)
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		p.logger.Error("Failed to marshal event metadata",
+			zap.String("event_id", event.EventID()),
+			zap.String("event_type", event.EventType()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to marshal event metadata: %w", err)
+	}
+
+	// event_id, event_type and timestamp predate the CloudEvents fields and are kept for
+	// existing readers; they duplicate id, type and time
+	source, subject, schemaVersion := common.EventContext(event)
+	values := map[string]interface{}{
+		"event_id":        event.EventID(),
+		"event_type":      event.EventType(),
+		"timestamp":       event.OccurredAt().Format(time.RFC3339Nano),
+		"payload":         string(payloadJSON),
+		"metadata":        string(metadataJSON),
+		"specversion":     common.CloudEventsSpecVersion,
+		"source":          source,
+		"subject":         subject,
+		"datacontenttype": common.EventDataContentType,
+		"dataschema":      common.DataSchemaURI(event.EventType(), schemaVersion),
+		"schemaversion":   strconv.Itoa(schemaVersion),
+	}
+	for key, value := range event.Attributes() {
+		if _, reserved := values[key]; !reserved {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
 // Close closes the Redis connection
 func (p *RedisEventPublisher) Close() error {
 	if p.client != nil {
@@ -7,7 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alicebob/miniredis/v2"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
@@ -119,6 +121,112 @@ func TestPublish_NilEvent(t *testing.T) {
 	assert.Contains(t, err.Error(), "event cannot be nil")
 }
 
+func TestPublish_NotAnEnvelope(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	client := redis.NewClient(&redis.Options{})
+	publisher := NewRedisEventPublisher(client, logger)
+
+	err := publisher.Publish(map[string]string{"event": "not a domain event"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "common.EventEnvelope")
+}
+
+func TestPublish_AuditEvent(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	publisher := NewRedisEventPublisher(client, zaptest.NewLogger(t)).WithStreamName(AuditStreamName)
+
+	userID := uuid.New()
+	requestID := "req-42"
+	entry := &audit.Log{
+		ID:            uuid.New(),
+		EventType:     "admin.put",
+		EventCategory: audit.CategorySecurity,
+		Severity:      audit.SeverityCritical,
+		UserID:        &userID,
+		ActorType:     audit.ActorAdmin,
+		Action:        "PUT /admin/users/:id/role",
+		RequestID:     &requestID,
+		Status:        audit.StatusSuccess,
+		CreatedAt:     time.Now(),
+	}
+	event := audit.NewLoggedEvent(entry)
+
+	require.NoError(t, publisher.Publish(event))
+
+	messages, err := client.XRange(context.Background(), AuditStreamName, "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	values := messages[0].Values
+	assert.Equal(t, event.ID, values["event_id"])
+	assert.Equal(t, string(audit.EventTypeAuditLogged), values["event_type"])
+	assert.Equal(t, entry.ID.String(), values["audit_id"])
+	assert.Equal(t, "critical", values["severity"])
+	assert.Equal(t, "security", values["event_category"])
+	assert.Equal(t, userID.String(), values["user_id"])
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(values["payload"].(string)), &payload))
+	assert.Equal(t, "admin.put", payload["event_type"])
+
+	var metadata map[string]string
+	require.NoError(t, json.Unmarshal([]byte(values["metadata"].(string)), &metadata))
+	assert.Equal(t, "req-42", metadata["request_id"])
+}
+
+func TestPublish_CloudEventsAttributes(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	publisher := NewRedisEventPublisher(client, zaptest.NewLogger(t))
+	userID := uuid.New()
+	event := user.NewTypedEvent(userID, user.KYCUpdatedPayload{Email: "jane@example.com", KYCStatus: user.KYCStatusVerified})
+
+	require.NoError(t, publisher.Publish(event))
+
+	messages, err := client.XRange(context.Background(), DefaultStreamName, "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	values := messages[0].Values
+	assert.Equal(t, "1.0", values["specversion"])
+	assert.Equal(t, "/pandora-exchange/user-service", values["source"])
+	assert.Equal(t, userID.String(), values["subject"])
+	assert.Equal(t, "application/json", values["datacontenttype"])
+	assert.Equal(t, "https://schemas.pandora.exchange/events/user.kyc.updated/v1.json", values["dataschema"])
+	assert.Equal(t, "1", values["schemaversion"])
+	assert.JSONEq(t, `{"email":"jane@example.com","kyc_status":"verified","old_status":""}`, values["payload"].(string))
+}
+
+func TestPublishBatch_MixedEventTypes(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	publisher := NewRedisEventPublisher(client, zaptest.NewLogger(t))
+
+	batch := []interface{}{
+		user.NewEvent(user.EventTypeUserRegistered, uuid.New(), map[string]interface{}{"email": "a@example.com"}),
+		audit.NewEvent(audit.EventTypeAuditLogged, uuid.New(), map[string]interface{}{"severity": "high"}),
+		"not an event",
+	}
+
+	require.NoError(t, publisher.PublishBatch(batch))
+
+	messages, err := client.XRange(context.Background(), DefaultStreamName, "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, string(user.EventTypeUserRegistered), messages[0].Values["event_type"])
+	assert.NotEmpty(t, messages[0].Values["user_id"])
+	assert.Equal(t, string(audit.EventTypeAuditLogged), messages[1].Values["event_type"])
+	assert.Equal(t, "high", messages[1].Values["severity"])
+}
+
 func TestPublish_InvalidPayload(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	client := redis.NewClient(&redis.Options{
@@ -414,10 +522,17 @@ func TestEventTypes(t *testing.T) {
 	}{
 		{"User Registered", user.EventTypeUserRegistered, "user.registered"},
 		{"KYC Updated", user.EventTypeUserKYCUpdated, "user.kyc.updated"},
+		{"Entitlements Changed", user.EventTypeUserEntitlementsChanged, "user.entitlements.changed"},
 		{"Profile Updated", user.EventTypeUserProfileUpdated, "user.profile.updated"},
 		{"User Deleted", user.EventTypeUserDeleted, "user.deleted"},
 		{"User Logged In", user.EventTypeUserLoggedIn, "user.logged_in"},
 		{"Password Changed", user.EventTypeUserPasswordChanged, "user.password.changed"},
+		{"User Logged Out", user.EventTypeUserLoggedOut, "user.logged_out"},
+		{"Sessions Revoked", user.EventTypeUserSessionsRevoked, "user.sessions.revoked"},
+		{"Role Changed", user.EventTypeUserRoleChanged, "user.role.changed"},
+		{"Admin Logged In", user.EventTypeAdminLoggedIn, "admin.logged_in"},
+		{"Session Force Revoked", user.EventTypeSessionForceRevoked, "session.force_revoked"},
+		{"Token Refreshed", user.EventTypeTokenRefreshed, "token.refreshed"},
 	}
 
 	for _, tt := range tests {
@@ -466,3 +581,26 @@ func TestStreamConstants(t *testing.T) {
 	assert.Equal(t, "user-service:events", DefaultStreamName)
 	assert.Equal(t, int64(10000), int64(MaxStreamLength))
 }
+
+func TestWithMaxLength(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	trimmed := NewRedisEventPublisher(client, zaptest.NewLogger(t)).WithStreamName("trimmed").WithMaxLength(5)
+	untrimmed := NewRedisEventPublisher(client, zaptest.NewLogger(t)).WithStreamName("untrimmed").WithMaxLength(0)
+	for i := 0; i < 20; i++ {
+		event := user.NewTypedEvent(uuid.New(), user.RegisteredPayload{Email: "jane@example.com"})
+		require.NoError(t, trimmed.Publish(event))
+		require.NoError(t, untrimmed.Publish(event))
+	}
+
+	ctx := context.Background()
+	trimmedLen, err := client.XLen(ctx, "trimmed").Result()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, trimmedLen, int64(5))
+
+	untrimmedLen, err := client.XLen(ctx, "untrimmed").Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), untrimmedLen)
+}
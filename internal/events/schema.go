@@ -0,0 +1,280 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// JSONSchemaDialect is the JSON Schema version generated schemas declare
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Payloads returns a zero value of every typed event payload, one per event type.
+// Each has a JSON Schema published under docs/events/schemas; add new payloads here.
+func Payloads() []common.EventData {
+	return []common.EventData{
+		user.RegisteredPayload{},
+		user.KYCUpdatedPayload{},
+		user.EntitlementsChangedPayload{},
+		user.ProfileUpdatedPayload{},
+		user.DeletedPayload{},
+		user.RestoredPayload{},
+		user.ErasedPayload{},
+		user.StatusChangedPayload{},
+		user.LoggedInPayload{},
+		user.PasswordChangedPayload{},
+		user.LoggedOutPayload{},
+		user.SessionsRevokedPayload{},
+		user.RoleChangedPayload{},
+		user.AdminLoggedInPayload{},
+		user.SessionForceRevokedPayload{},
+		user.TokenRefreshedPayload{},
+		audit.LoggedPayload{},
+	}
+}
+
+// SchemaTypes is the JSON Schema "type" keyword: a single type or a list of types
+type SchemaTypes []string
+
+// MarshalJSON writes a single type as a string and several as an array
+func (t SchemaTypes) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON accepts a string or an array of strings
+func (t *SchemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = SchemaTypes{single}
+		return nil
+	}
+	var types []string
+	if err := json.Unmarshal(data, &types); err != nil {
+		return err
+	}
+	*t = types
+	return nil
+}
+
+// JSONSchema is the subset of JSON Schema used to describe event payloads
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 SchemaTypes            `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+}
+
+// GenerateSchema returns the JSON Schema of a payload, derived from its Go type and json tags.
+// Fields tagged omitempty are optional; all others are required.
+func GenerateSchema(data common.EventData) (*JSONSchema, error) {
+	schema, err := schemaForType(reflect.TypeOf(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate schema for %s: %w", data.EventType(), err)
+	}
+	schema.Schema = JSONSchemaDialect
+	schema.ID = common.DataSchemaURI(data.EventType(), data.SchemaVersion())
+	schema.Title = fmt.Sprintf("%s v%d", data.EventType(), data.SchemaVersion())
+	return schema, nil
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	uuidType    = reflect.TypeOf(uuid.UUID{})
+	rawJSONType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaForType maps a Go type to its JSON Schema
+func schemaForType(t reflect.Type) (*JSONSchema, error) {
+	switch t {
+	case timeType:
+		return &JSONSchema{Type: SchemaTypes{"string"}, Format: "date-time"}, nil
+	case uuidType:
+		return &JSONSchema{Type: SchemaTypes{"string"}, Format: "uuid"}, nil
+	case rawJSONType:
+		return &JSONSchema{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema, err := schemaForType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		if len(schema.Type) > 0 {
+			schema.Type = append(schema.Type, "null")
+		}
+		return schema, nil
+	case reflect.String:
+		return &JSONSchema{Type: SchemaTypes{"string"}}, nil
+	case reflect.Bool:
+		return &JSONSchema{Type: SchemaTypes{"boolean"}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: SchemaTypes{"integer"}}, nil
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: SchemaTypes{"number"}}, nil
+	case reflect.Interface:
+		return &JSONSchema{}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := schemaForType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &JSONSchema{Type: SchemaTypes{"array"}, Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map key type %s is not a string", t.Key())
+		}
+		values, err := schemaForType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema := &JSONSchema{Type: SchemaTypes{"object"}}
+		if len(values.Type) > 0 {
+			schema.AdditionalProperties = values
+		}
+		return schema, nil
+	case reflect.Struct:
+		return schemaForStruct(t)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// schemaForStruct describes a struct as an object, following encoding/json field rules
+func schemaForStruct(t reflect.Type) (*JSONSchema, error) {
+	schema := &JSONSchema{Type: SchemaTypes{"object"}, Properties: map[string]*JSONSchema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded, err := schemaForStruct(field.Type)
+			if err != nil {
+				return nil, err
+			}
+			for property, propertySchema := range embedded.Properties {
+				schema.Properties[property] = propertySchema
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		propertySchema, err := schemaForType(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		schema.Properties[name] = propertySchema
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	sort.Strings(schema.Required)
+	return schema, nil
+}
+
+// BreakingChanges lists the differences between two versions of a payload schema that could
+// break a consumer written against previous: a required property that is removed or made
+// optional, or a property whose type or format changes. Adding properties is compatible.
+func BreakingChanges(previous, current *JSONSchema) []string {
+	var changes []string
+	compareSchemas("", previous, current, &changes)
+	sort.Strings(changes)
+	return changes
+}
+
+// compareSchemas appends the breaking changes between two schemas at path to changes
+func compareSchemas(path string, previous, current *JSONSchema, changes *[]string) {
+	where := path
+	if where == "" {
+		where = "payload"
+	}
+
+	if !typesNarrowed(previous.Type, current.Type) {
+		*changes = append(*changes, fmt.Sprintf("%s: type changed from %v to %v", where, []string(previous.Type), []string(current.Type)))
+		return
+	}
+	if previous.Format != current.Format {
+		*changes = append(*changes, fmt.Sprintf("%s: format changed from %q to %q", where, previous.Format, current.Format))
+	}
+
+	required := make(map[string]bool, len(current.Required))
+	for _, name := range current.Required {
+		required[name] = true
+	}
+	for _, name := range previous.Required {
+		if _, exists := current.Properties[name]; !exists {
+			*changes = append(*changes, fmt.Sprintf("%s: required property removed", joinPath(path, name)))
+		} else if !required[name] {
+			*changes = append(*changes, fmt.Sprintf("%s: property is no longer required", joinPath(path, name)))
+		}
+	}
+
+	for name, previousProperty := range previous.Properties {
+		if currentProperty, exists := current.Properties[name]; exists {
+			compareSchemas(joinPath(path, name), previousProperty, currentProperty, changes)
+		}
+	}
+
+	if previous.Items != nil && current.Items != nil {
+		compareSchemas(path+"[]", previous.Items, current.Items, changes)
+	}
+	if previous.AdditionalProperties != nil && current.AdditionalProperties != nil {
+		compareSchemas(path+"{}", previous.AdditionalProperties, current.AdditionalProperties, changes)
+	}
+}
+
+// typesNarrowed reports whether every type allowed by current was allowed by previous.
+// An empty type list allows any value, and integers are numbers.
+func typesNarrowed(previous, current SchemaTypes) bool {
+	if len(previous) == 0 {
+		return true
+	}
+	if len(current) == 0 {
+		return false
+	}
+	allowed := make(map[string]bool, len(previous))
+	for _, t := range previous {
+		allowed[t] = true
+	}
+	for _, t := range current {
+		if !allowed[t] && !(t == "integer" && allowed["number"]) {
+			return false
+		}
+	}
+	return true
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,184 @@
+package events
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaDir holds the published payload schemas, one file per event type and version
+const schemaDir = "../../docs/events/schemas"
+
+var updateSchemas = flag.Bool("update-schemas", false, "write generated payload schemas to docs/events/schemas")
+
+// TestPayloadSchemas keeps docs/events/schemas in sync with the payload structs and fails when a
+// payload changes in a way that breaks consumers of its published schema version.
+// Regenerate with `make event-schemas`.
+func TestPayloadSchemas(t *testing.T) {
+	seen := map[string]bool{}
+	for _, data := range Payloads() {
+		data := data
+		t.Run(data.EventType(), func(t *testing.T) {
+			require.False(t, seen[data.EventType()], "more than one payload for %s", data.EventType())
+			seen[data.EventType()] = true
+
+			generated, err := GenerateSchema(data)
+			require.NoError(t, err)
+			encoded, err := json.MarshalIndent(generated, "", "  ")
+			require.NoError(t, err)
+			encoded = append(encoded, '\n')
+
+			path := filepath.Join(schemaDir, data.EventType(), fmt.Sprintf("v%d.json", data.SchemaVersion()))
+			published, readErr := os.ReadFile(path)
+			if readErr == nil {
+				var previous JSONSchema
+				require.NoError(t, json.Unmarshal(published, &previous))
+				if breaking := BreakingChanges(&previous, generated); len(breaking) > 0 {
+					t.Fatalf("%s changed incompatibly with its published v%d schema:\n  %v\nbump SchemaVersion() to publish it as a new version",
+						data.EventType(), data.SchemaVersion(), breaking)
+				}
+			}
+
+			if *updateSchemas {
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, encoded, 0o644))
+				return
+			}
+
+			require.NoError(t, readErr, "schema not published; run make event-schemas")
+			assert.JSONEq(t, string(published), string(encoded), "schema out of date; run make event-schemas")
+		})
+	}
+}
+
+// TestPayloadSchemas_MatchSerialization checks that the zero value of every payload serializes
+// exactly its required properties, i.e. the schema agrees with encoding/json
+func TestPayloadSchemas_MatchSerialization(t *testing.T) {
+	for _, data := range Payloads() {
+		schema, err := GenerateSchema(data)
+		require.NoError(t, err)
+
+		var keys []string
+		for key := range common.EventDataMap(data) {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		assert.Equal(t, schema.Required, keys, data.EventType())
+	}
+}
+
+type schemaTestAddress struct {
+	City string `json:"city"`
+}
+
+type schemaTestPayload struct {
+	Email      string            `json:"email"`
+	Age        int               `json:"age"`
+	Nickname   *string           `json:"nickname"`
+	Tags       []string          `json:"tags,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Address    schemaTestAddress `json:"address"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Internal   string            `json:"-"`
+	unexported string
+}
+
+func (schemaTestPayload) EventType() string  { return "test.happened" }
+func (schemaTestPayload) SchemaVersion() int { return 3 }
+
+func TestGenerateSchema(t *testing.T) {
+	schema, err := GenerateSchema(schemaTestPayload{})
+	require.NoError(t, err)
+
+	assert.Equal(t, JSONSchemaDialect, schema.Schema)
+	assert.Equal(t, "https://schemas.pandora.exchange/events/test.happened/v3.json", schema.ID)
+	assert.Equal(t, SchemaTypes{"object"}, schema.Type)
+	assert.Equal(t, []string{"address", "age", "created_at", "email", "nickname"}, schema.Required)
+	assert.Len(t, schema.Properties, 7)
+
+	assert.Equal(t, SchemaTypes{"integer"}, schema.Properties["age"].Type)
+	assert.Equal(t, SchemaTypes{"string", "null"}, schema.Properties["nickname"].Type)
+	assert.Equal(t, "date-time", schema.Properties["created_at"].Format)
+	assert.Equal(t, SchemaTypes{"string"}, schema.Properties["tags"].Items.Type)
+	assert.Equal(t, SchemaTypes{"string"}, schema.Properties["labels"].AdditionalProperties.Type)
+	assert.Equal(t, []string{"city"}, schema.Properties["address"].Required)
+
+	encoded, err := json.Marshal(schema.Properties["nickname"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":["string","null"]}`, string(encoded))
+}
+
+func TestBreakingChanges(t *testing.T) {
+	str := func() *JSONSchema { return &JSONSchema{Type: SchemaTypes{"string"}} }
+	object := func(required []string, properties map[string]*JSONSchema) *JSONSchema {
+		return &JSONSchema{Type: SchemaTypes{"object"}, Required: required, Properties: properties}
+	}
+	previous := object([]string{"email", "status"}, map[string]*JSONSchema{
+		"email":  str(),
+		"status": str(),
+		"note":   str(),
+		"amount": {Type: SchemaTypes{"number"}},
+	})
+
+	tests := []struct {
+		name     string
+		current  *JSONSchema
+		breaking []string
+	}{
+		{
+			name: "adding properties is compatible",
+			current: object([]string{"email", "status", "currency"}, map[string]*JSONSchema{
+				"email": str(), "status": str(), "note": str(), "amount": {Type: SchemaTypes{"number"}}, "currency": str(),
+			}),
+		},
+		{
+			name: "removing an optional property and narrowing number to integer is compatible",
+			current: object([]string{"email", "status"}, map[string]*JSONSchema{
+				"email": str(), "status": str(), "amount": {Type: SchemaTypes{"integer"}},
+			}),
+		},
+		{
+			name: "removing a required property breaks consumers",
+			current: object([]string{"email"}, map[string]*JSONSchema{
+				"email": str(), "note": str(), "amount": {Type: SchemaTypes{"number"}},
+			}),
+			breaking: []string{"status: required property removed"},
+		},
+		{
+			name: "making a required property optional breaks consumers",
+			current: object([]string{"email"}, map[string]*JSONSchema{
+				"email": str(), "status": str(), "note": str(), "amount": {Type: SchemaTypes{"number"}},
+			}),
+			breaking: []string{"status: property is no longer required"},
+		},
+		{
+			name: "changing a type or format breaks consumers",
+			current: object([]string{"email", "status"}, map[string]*JSONSchema{
+				"email":  {Type: SchemaTypes{"string"}, Format: "email"},
+				"status": {Type: SchemaTypes{"string", "null"}},
+				"note":   str(),
+				"amount": {Type: SchemaTypes{"string"}},
+			}),
+			breaking: []string{
+				`amount: type changed from [number] to [string]`,
+				`email: format changed from "" to "email"`,
+				`status: type changed from [string] to [string null]`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.breaking, BreakingChanges(previous, tt.current))
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Event transports, selected with EVENT_TRANSPORT
+const (
+	TransportRedis = "redis"
+	TransportKafka = "kafka"
+	TransportNATS  = "nats"
+)
+
+// ContentTypeCloudEventsJSON is the media type of a structured-mode CloudEvent
+const ContentTypeCloudEventsJSON = "application/cloudevents+json"
+
+// encodedEvent is an event serialized for the Kafka and NATS transports: the message body is
+// the structured-mode CloudEvent, and the context attributes are repeated as headers so
+// brokers and consumers can route without parsing the body
+type encodedEvent struct {
+	envelope common.EventEnvelope
+	// key orders events: events with the same key are delivered in publish order
+	key     string
+	body    []byte
+	headers map[string]string
+}
+
+// encodeEvent serializes an event; event must implement common.EventEnvelope
+func encodeEvent(event interface{}) (*encodedEvent, error) {
+	envelope, ok := event.(common.EventEnvelope)
+	if !ok || envelope == nil {
+		return nil, fmt.Errorf("event must implement common.EventEnvelope, got %T", event)
+	}
+
+	cloudEvent, err := common.NewCloudEvent(envelope)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(cloudEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cloud event: %w", err)
+	}
+
+	headers := map[string]string{
+		"content-type":     ContentTypeCloudEventsJSON,
+		"ce_specversion":   cloudEvent.SpecVersion,
+		"ce_id":            cloudEvent.ID,
+		"ce_type":          cloudEvent.Type,
+		"ce_source":        cloudEvent.Source,
+		"ce_schemaversion": strconv.Itoa(cloudEvent.SchemaVersion),
+	}
+	if cloudEvent.Subject != "" {
+		headers["ce_subject"] = cloudEvent.Subject
+	}
+	key := orderingKey(envelope, cloudEvent.Subject)
+	headers["ce_partitionkey"] = key
+
+	return &encodedEvent{
+		envelope: envelope,
+		key:      key,
+		body:     body,
+		headers:  headers,
+	}, nil
+}
+
+// orderingKey returns the user ID of an event, so all events of a user share a partition;
+// events without one are keyed by subject, then by ID
+func orderingKey(envelope common.EventEnvelope, subject string) string {
+	if userID := envelope.Attributes()["user_id"]; userID != "" {
+		return userID
+	}
+	if subject != "" {
+		return subject
+	}
+	return envelope.EventID()
+}
+
+// startPublishSpan starts the producer span around publishing one event. The span continues
+// the trace recorded in the event metadata when the event was created, so a publish from the
+// outbox relay still joins the trace of the request that changed the user.
+func startPublishSpan(ctx context.Context, system, destination string, envelope common.EventEnvelope) (context.Context, trace.Span) {
+	ctx = observability.ExtractTraceContext(ctx, envelope.EventMetadata())
+	return otel.Tracer(observability.TracerName).Start(ctx, destination+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", system),
+			attribute.String("messaging.operation", "publish"),
+			attribute.String("messaging.destination.name", destination),
+			attribute.String("messaging.message.id", envelope.EventID()),
+			attribute.String("event.type", envelope.EventType()),
+		),
+	)
+}
+
+// endSpan records the outcome of a publish or handler call and ends its span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+	span.End()
+}
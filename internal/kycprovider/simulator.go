@@ -0,0 +1,481 @@
+// Package kycprovider holds adapters for external KYC providers. Each adapter implements
+// kyc.Provider, translating the vendor's API, webhook format and labels into the
+// provider-agnostic types the KYC service works with.
+//
+// The Simulator is a provider that runs entirely in process. It can be scripted to approve,
+// reject or time out individual cases, so development and end-to-end tests need neither a
+// vendor account nor network access.
+package kycprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
+	"github.com/google/uuid"
+)
+
+// Supported providers
+const (
+	ProviderSimulator = "simulator"
+)
+
+// Headers of the simulator's decision webhooks
+const (
+	// HeaderSimulatorSignature carries "v1=" and the hex HMAC-SHA256 of "<timestamp>.<body>"
+	HeaderSimulatorSignature = "X-Kyc-Simulator-Signature"
+	// HeaderSimulatorTimestamp carries the Unix time the webhook was signed at
+	HeaderSimulatorTimestamp = "X-Kyc-Simulator-Timestamp"
+)
+
+const (
+	// signatureTolerance bounds the clock skew accepted on decision webhooks
+	signatureTolerance = 5 * time.Minute
+	// linkValidity is how long a verification link can be used
+	linkValidity = time.Hour
+	// defaultBaseURL prefixes verification links when no base URL is configured
+	defaultBaseURL = "http://localhost:8080/kyc-simulator"
+	// callbackTimeout bounds the delivery of a decision webhook
+	callbackTimeout = 10 * time.Second
+)
+
+// ErrNoDecision is returned when a decision is requested for an applicant whose scenario
+// never decides
+var ErrNoDecision = errors.New("simulator applicant has no decision")
+
+// Scenario scripts how the simulator treats an applicant
+type Scenario string
+
+const (
+	// ScenarioApprove verifies the applicant
+	ScenarioApprove Scenario = "approve"
+	// ScenarioReject turns the applicant down because the document does not match them
+	ScenarioReject Scenario = "reject"
+	// ScenarioResubmit asks the applicant to verify again; the second attempt is approved
+	ScenarioResubmit Scenario = "resubmit"
+	// ScenarioExpire lets the verification expire unfinished
+	ScenarioExpire Scenario = "expire"
+	// ScenarioTimeout makes every call for the applicant hang until the caller gives up,
+	// and never decides
+	ScenarioTimeout Scenario = "timeout"
+)
+
+// IsValid reports whether s is a known scenario
+func (s Scenario) IsValid() bool {
+	switch s {
+	case ScenarioApprove, ScenarioReject, ScenarioResubmit, ScenarioExpire, ScenarioTimeout:
+		return true
+	}
+	return false
+}
+
+// Review answers of the simulator's webhook format
+const (
+	answerGreen   = "GREEN"
+	answerRed     = "RED"
+	answerRetry   = "RETRY"
+	answerExpired = "EXPIRED"
+)
+
+// rejectLabels maps the simulator's rejection labels to our reasons
+var rejectLabels = map[string]kyc.RejectionReason{
+	"BAD_QUALITY":       kyc.ReasonDocumentUnreadable,
+	"DOCUMENT_EXPIRED":  kyc.ReasonDocumentExpired,
+	"DOCUMENT_MISMATCH": kyc.ReasonDocumentMismatch,
+	"UNDERAGE":          kyc.ReasonUnderage,
+	"WRONG_COUNTRY":     kyc.ReasonUnsupportedJurisdiction,
+	"SANCTIONS":         kyc.ReasonSanctionsMatch,
+	"FORGERY":           kyc.ReasonSuspectedFraud,
+	"DUPLICATE":         kyc.ReasonDuplicateAccount,
+}
+
+// SimulatorOptions configures a Simulator
+type SimulatorOptions struct {
+	// Secret signs decision webhooks
+	Secret string
+	// Scenario applies to cases that were not scripted (defaults to ScenarioApprove)
+	Scenario Scenario
+	// CallbackURL receives decision webhooks DecisionDelay after a verification link is
+	// issued. When empty, decisions are only delivered through Decide or DecisionWebhook.
+	CallbackURL   string
+	DecisionDelay time.Duration
+	// BaseURL prefixes verification links
+	BaseURL string
+	// Client sends decision webhooks (defaults to http.DefaultClient)
+	Client *http.Client
+}
+
+// Simulator is an in-process kyc.Provider
+type Simulator struct {
+	opts SimulatorOptions
+	now  func() time.Time
+
+	mu         sync.Mutex
+	scripts    map[uuid.UUID]Scenario
+	applicants map[string]*simApplicant
+	timers     map[string]*time.Timer
+}
+
+// simApplicant is an applicant registered with the simulator
+type simApplicant struct {
+	id        string
+	caseID    uuid.UUID
+	scenario  Scenario
+	createdAt time.Time
+	decision  *simDecision
+}
+
+// simDecision is the simulator's webhook body. It deliberately differs from our own types
+// so that ParseDecision exercises the same kind of mapping a vendor adapter needs.
+type simDecision struct {
+	EventID      string    `json:"event_id"`
+	ApplicantID  string    `json:"applicant_id"`
+	ExternalRef  string    `json:"external_ref"`
+	ReviewAnswer string    `json:"review_answer"`
+	RejectLabels []string  `json:"reject_labels,omitempty"`
+	Comment      string    `json:"comment,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Compile-time check to ensure Simulator implements kyc.Provider
+var _ kyc.Provider = (*Simulator)(nil)
+
+// NewSimulator creates a simulator provider
+func NewSimulator(opts SimulatorOptions) (*Simulator, error) {
+	if opts.Secret == "" {
+		return nil, fmt.Errorf("simulator webhook secret cannot be empty")
+	}
+	if opts.Scenario == "" {
+		opts.Scenario = ScenarioApprove
+	}
+	if !opts.Scenario.IsValid() {
+		return nil, fmt.Errorf("unknown simulator scenario %q", opts.Scenario)
+	}
+	if opts.BaseURL == "" {
+		opts.BaseURL = defaultBaseURL
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	return &Simulator{
+		opts:       opts,
+		now:        time.Now,
+		scripts:    make(map[uuid.UUID]Scenario),
+		applicants: make(map[string]*simApplicant),
+		timers:     make(map[string]*time.Timer),
+	}, nil
+}
+
+// Name identifies the simulator
+func (s *Simulator) Name() string {
+	return ProviderSimulator
+}
+
+// Script sets the scenario of a case; it applies to applicants created for the case afterwards
+func (s *Simulator) Script(caseID uuid.UUID, scenario Scenario) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[caseID] = scenario
+}
+
+// CreateApplicant registers the applicant under the case's scenario
+func (s *Simulator) CreateApplicant(ctx context.Context, req kyc.ProviderApplicantRequest) (string, error) {
+	s.mu.Lock()
+	scenario, ok := s.scripts[req.CaseID]
+	if !ok {
+		scenario = s.opts.Scenario
+	}
+	s.mu.Unlock()
+
+	if scenario == ScenarioTimeout {
+		return "", hang(ctx)
+	}
+
+	a := &simApplicant{
+		id:        "sim_" + strings.ReplaceAll(uuid.NewString(), "-", ""),
+		caseID:    req.CaseID,
+		scenario:  scenario,
+		createdAt: s.now(),
+	}
+	s.mu.Lock()
+	s.applicants[a.id] = a
+	s.mu.Unlock()
+	return a.id, nil
+}
+
+// VerificationLink returns a link to the simulated verification flow. With a callback URL
+// configured, the decision webhook is sent DecisionDelay later. A link issued after a
+// resubmission request starts a new attempt.
+func (s *Simulator) VerificationLink(ctx context.Context, applicantID string) (*kyc.VerificationLink, error) {
+	a, err := s.applicant(ctx, applicantID)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if a.decision != nil && a.decision.ReviewAnswer == answerRetry {
+		a.decision = nil
+		a.scenario = ScenarioApprove
+		delete(s.timers, applicantID)
+	}
+	s.mu.Unlock()
+	if s.opts.CallbackURL != "" {
+		s.scheduleDecision(applicantID)
+	}
+	return &kyc.VerificationLink{
+		Provider:  ProviderSimulator,
+		URL:       strings.TrimSuffix(s.opts.BaseURL, "/") + "/verify/" + applicantID,
+		ExpiresAt: s.now().Add(linkValidity),
+	}, nil
+}
+
+// DecisionWebhook decides the applicant according to its scenario and returns the signed
+// webhook the simulator delivers, for tests that hand it to the service directly.
+// Deciding again returns the same decision, as a redelivery would.
+func (s *Simulator) DecisionWebhook(applicantID string) (http.Header, []byte, error) {
+	s.mu.Lock()
+	a, ok := s.applicants[applicantID]
+	if !ok {
+		s.mu.Unlock()
+		return nil, nil, fmt.Errorf("unknown simulator applicant %q", applicantID)
+	}
+	if a.scenario == ScenarioTimeout {
+		s.mu.Unlock()
+		return nil, nil, ErrNoDecision
+	}
+	if a.decision == nil {
+		a.decision = s.decide(a)
+	}
+	decision := *a.decision
+	s.mu.Unlock()
+
+	body, err := json.Marshal(decision)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal simulator decision: %w", err)
+	}
+	signedAt := s.now()
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(HeaderSimulatorTimestamp, strconv.FormatInt(signedAt.Unix(), 10))
+	header.Set(HeaderSimulatorSignature, webhook.Sign(s.opts.Secret, signedAt, body))
+	return header, body, nil
+}
+
+// Decide delivers the applicant's decision webhook to the callback URL
+func (s *Simulator) Decide(ctx context.Context, applicantID string) error {
+	if s.opts.CallbackURL == "" {
+		return fmt.Errorf("simulator callback URL is not configured")
+	}
+	header, body, err := s.DecisionWebhook(applicantID)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, callbackTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.opts.CallbackURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build simulator webhook request: %w", err)
+	}
+	req.Header = header
+
+	resp, err := s.opts.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send simulator webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("simulator webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// ParseDecision verifies and decodes a decision webhook
+func (s *Simulator) ParseDecision(header http.Header, body []byte) (*kyc.ProviderDecision, error) {
+	err := webhook.Verify(
+		s.opts.Secret,
+		header.Get(HeaderSimulatorSignature),
+		header.Get(HeaderSimulatorTimestamp),
+		body,
+		s.now(),
+		signatureTolerance,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", kyc.ErrInvalidProviderWebhook, err)
+	}
+
+	var d simDecision
+	if err := json.Unmarshal(body, &d); err != nil {
+		return nil, fmt.Errorf("%w: malformed body: %v", kyc.ErrInvalidProviderWebhook, err)
+	}
+	if d.ApplicantID == "" {
+		return nil, fmt.Errorf("%w: missing applicant_id", kyc.ErrInvalidProviderWebhook)
+	}
+	outcome, err := outcomeOf(d.ReviewAnswer)
+	if err != nil {
+		return nil, err
+	}
+	return &kyc.ProviderDecision{
+		EventID:     d.EventID,
+		ApplicantID: d.ApplicantID,
+		Outcome:     outcome,
+		Reason:      reasonOf(d.RejectLabels),
+		Note:        d.Comment,
+		OccurredAt:  d.CreatedAt,
+	}, nil
+}
+
+// FetchReport returns the checks the simulator ran on the applicant
+func (s *Simulator) FetchReport(ctx context.Context, applicantID string) (*kyc.ProviderReport, error) {
+	a, err := s.applicant(ctx, applicantID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report := &kyc.ProviderReport{
+		Provider:    ProviderSimulator,
+		ApplicantID: a.id,
+		Outcome:     kyc.ProviderPending,
+		Checks:      []kyc.ProviderCheck{},
+	}
+	if a.decision == nil {
+		return report, nil
+	}
+
+	outcome, _ := outcomeOf(a.decision.ReviewAnswer)
+	report.Outcome = outcome
+	report.Reason = reasonOf(a.decision.RejectLabels)
+	report.CompletedAt = &a.decision.CreatedAt
+	report.Checks = checksFor(a.scenario)
+	if report.Raw, err = json.Marshal(a.decision); err != nil {
+		return nil, fmt.Errorf("failed to marshal simulator decision: %w", err)
+	}
+	return report, nil
+}
+
+// Close stops pending automatic decisions
+func (s *Simulator) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, t := range s.timers {
+		t.Stop()
+		delete(s.timers, id)
+	}
+}
+
+// applicant looks an applicant up, hanging like a stalled vendor under ScenarioTimeout
+func (s *Simulator) applicant(ctx context.Context, applicantID string) (*simApplicant, error) {
+	s.mu.Lock()
+	a, ok := s.applicants[applicantID]
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown simulator applicant %q", applicantID)
+	}
+	if a.scenario == ScenarioTimeout {
+		return nil, hang(ctx)
+	}
+	return a, nil
+}
+
+// scheduleDecision sends the applicant's decision webhook after the decision delay, once
+func (s *Simulator) scheduleDecision(applicantID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.timers[applicantID]; ok {
+		return
+	}
+	s.timers[applicantID] = time.AfterFunc(s.opts.DecisionDelay, func() {
+		// Errors are dropped: like a vendor, the simulator does not retry failed deliveries,
+		// and Decide can resend the webhook
+		_ = s.Decide(context.Background(), applicantID)
+	})
+}
+
+// decide creates the decision of a's scenario; the caller holds s.mu
+func (s *Simulator) decide(a *simApplicant) *simDecision {
+	d := &simDecision{
+		EventID:     uuid.NewString(),
+		ApplicantID: a.id,
+		ExternalRef: a.caseID.String(),
+		CreatedAt:   s.now().UTC().Truncate(time.Second),
+	}
+	switch a.scenario {
+	case ScenarioApprove:
+		d.ReviewAnswer = answerGreen
+	case ScenarioReject:
+		d.ReviewAnswer = answerRed
+		d.RejectLabels = []string{"DOCUMENT_MISMATCH"}
+		d.Comment = "The document does not match the applicant's details."
+	case ScenarioResubmit:
+		d.ReviewAnswer = answerRetry
+		d.RejectLabels = []string{"BAD_QUALITY"}
+		d.Comment = "The document photo is blurry; please upload a sharper one."
+	case ScenarioExpire:
+		d.ReviewAnswer = answerExpired
+	}
+	return d
+}
+
+// outcomeOf maps a review answer to an outcome
+func outcomeOf(answer string) (kyc.ProviderOutcome, error) {
+	switch answer {
+	case answerGreen:
+		return kyc.ProviderApproved, nil
+	case answerRed:
+		return kyc.ProviderRejected, nil
+	case answerRetry:
+		return kyc.ProviderResubmissionRequested, nil
+	case answerExpired:
+		return kyc.ProviderExpired, nil
+	default:
+		return "", fmt.Errorf("%w: unknown review answer %q", kyc.ErrInvalidProviderWebhook, answer)
+	}
+}
+
+// reasonOf maps the first known rejection label to a reason
+func reasonOf(labels []string) kyc.RejectionReason {
+	for _, label := range labels {
+		if reason, ok := rejectLabels[label]; ok {
+			return reason
+		}
+	}
+	if len(labels) > 0 {
+		return kyc.ReasonOther
+	}
+	return ""
+}
+
+// checksFor returns the checks a scenario reports
+func checksFor(scenario Scenario) []kyc.ProviderCheck {
+	document, liveness := "clear", "clear"
+	switch scenario {
+	case ScenarioReject:
+		document = "consider"
+	case ScenarioResubmit:
+		document = "unreadable"
+	case ScenarioExpire:
+		document, liveness = "not_started", "not_started"
+	}
+	return []kyc.ProviderCheck{
+		{Name: "document", Result: document},
+		{Name: "liveness", Result: liveness},
+	}
+}
+
+// hang blocks until ctx is done, like a request to an unresponsive vendor
+func hang(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
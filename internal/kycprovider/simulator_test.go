@@ -0,0 +1,184 @@
+package kycprovider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSimulator(t *testing.T, opts SimulatorOptions) *Simulator {
+	t.Helper()
+	opts.Secret = "simulator-secret"
+	sim, err := NewSimulator(opts)
+	require.NoError(t, err)
+	t.Cleanup(sim.Close)
+	return sim
+}
+
+func createApplicant(t *testing.T, sim *Simulator, caseID uuid.UUID) string {
+	t.Helper()
+	id, err := sim.CreateApplicant(context.Background(), kyc.ProviderApplicantRequest{CaseID: caseID, UserID: uuid.New()})
+	require.NoError(t, err)
+	return id
+}
+
+func TestNewSimulator(t *testing.T) {
+	_, err := NewSimulator(SimulatorOptions{})
+	assert.Error(t, err, "secret is required")
+
+	_, err = NewSimulator(SimulatorOptions{Secret: "s", Scenario: "maybe"})
+	assert.Error(t, err)
+
+	sim, err := NewSimulator(SimulatorOptions{Secret: "s"})
+	require.NoError(t, err)
+	assert.Equal(t, ScenarioApprove, sim.opts.Scenario)
+	assert.Equal(t, ProviderSimulator, sim.Name())
+}
+
+func TestSimulator_Decisions(t *testing.T) {
+	tests := []struct {
+		scenario Scenario
+		outcome  kyc.ProviderOutcome
+		reason   kyc.RejectionReason
+	}{
+		{ScenarioApprove, kyc.ProviderApproved, ""},
+		{ScenarioReject, kyc.ProviderRejected, kyc.ReasonDocumentMismatch},
+		{ScenarioResubmit, kyc.ProviderResubmissionRequested, kyc.ReasonDocumentUnreadable},
+		{ScenarioExpire, kyc.ProviderExpired, ""},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.scenario), func(t *testing.T) {
+			sim := newTestSimulator(t, SimulatorOptions{})
+			caseID := uuid.New()
+			sim.Script(caseID, tt.scenario)
+			applicantID := createApplicant(t, sim, caseID)
+
+			report, err := sim.FetchReport(context.Background(), applicantID)
+			require.NoError(t, err)
+			assert.Equal(t, kyc.ProviderPending, report.Outcome)
+
+			header, body, err := sim.DecisionWebhook(applicantID)
+			require.NoError(t, err)
+			decision, err := sim.ParseDecision(header, body)
+			require.NoError(t, err)
+			assert.Equal(t, applicantID, decision.ApplicantID)
+			assert.Equal(t, tt.outcome, decision.Outcome)
+			assert.Equal(t, tt.reason, decision.Reason)
+
+			_, again, err := sim.DecisionWebhook(applicantID)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(body), string(again), "redeliveries carry the same decision")
+
+			report, err = sim.FetchReport(context.Background(), applicantID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.outcome, report.Outcome)
+			assert.Len(t, report.Checks, 2)
+			assert.NotNil(t, report.CompletedAt)
+			assert.NotEmpty(t, report.Raw)
+		})
+	}
+}
+
+func TestSimulator_ResubmissionStartsNewAttempt(t *testing.T) {
+	sim := newTestSimulator(t, SimulatorOptions{Scenario: ScenarioResubmit})
+	applicantID := createApplicant(t, sim, uuid.New())
+
+	header, body, err := sim.DecisionWebhook(applicantID)
+	require.NoError(t, err)
+	first, err := sim.ParseDecision(header, body)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.ProviderResubmissionRequested, first.Outcome)
+
+	_, err = sim.VerificationLink(context.Background(), applicantID)
+	require.NoError(t, err)
+	header, body, err = sim.DecisionWebhook(applicantID)
+	require.NoError(t, err)
+	second, err := sim.ParseDecision(header, body)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.ProviderApproved, second.Outcome)
+	assert.NotEqual(t, first.EventID, second.EventID)
+}
+
+func TestSimulator_Timeout(t *testing.T) {
+	sim := newTestSimulator(t, SimulatorOptions{Scenario: ScenarioTimeout})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := sim.CreateApplicant(ctx, kyc.ProviderApplicantRequest{CaseID: uuid.New()})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A scripted case overrides the default scenario, and the script can also stall later calls
+	caseID := uuid.New()
+	sim.Script(caseID, ScenarioApprove)
+	applicantID := createApplicant(t, sim, caseID)
+	sim.applicants[applicantID].scenario = ScenarioTimeout
+
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = sim.VerificationLink(ctx, applicantID)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	_, _, err = sim.DecisionWebhook(applicantID)
+	assert.ErrorIs(t, err, ErrNoDecision)
+}
+
+func TestSimulator_ParseDecisionRejectsBadSignatures(t *testing.T) {
+	sim := newTestSimulator(t, SimulatorOptions{})
+	applicantID := createApplicant(t, sim, uuid.New())
+	header, body, err := sim.DecisionWebhook(applicantID)
+	require.NoError(t, err)
+
+	tampered := append([]byte{}, body...)
+	tampered[len(tampered)-2] = ' '
+	_, err = sim.ParseDecision(header, tampered)
+	assert.ErrorIs(t, err, kyc.ErrInvalidProviderWebhook)
+
+	other := newTestSimulator(t, SimulatorOptions{})
+	other.opts.Secret = "another-secret"
+	_, err = other.ParseDecision(header, body)
+	assert.ErrorIs(t, err, kyc.ErrInvalidProviderWebhook)
+
+	_, err = sim.ParseDecision(http.Header{}, body)
+	assert.ErrorIs(t, err, kyc.ErrInvalidProviderWebhook)
+}
+
+func TestSimulator_DeliversDecisionToCallback(t *testing.T) {
+	received := make(chan *kyc.ProviderDecision, 1)
+	var sim *Simulator
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		decision, err := sim.ParseDecision(r.Header, body)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		received <- decision
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sim = newTestSimulator(t, SimulatorOptions{CallbackURL: server.URL, DecisionDelay: time.Millisecond})
+	applicantID := createApplicant(t, sim, uuid.New())
+
+	link, err := sim.VerificationLink(context.Background(), applicantID)
+	require.NoError(t, err)
+	assert.Contains(t, link.URL, applicantID)
+
+	select {
+	case decision := <-received:
+		assert.Equal(t, applicantID, decision.ApplicantID)
+		assert.Equal(t, kyc.ProviderApproved, decision.Outcome)
+	case <-time.After(2 * time.Second):
+		t.Fatal("decision webhook was not delivered")
+	}
+
+	require.NoError(t, sim.Decide(context.Background(), applicantID), "Decide redelivers on demand")
+	<-received
+}
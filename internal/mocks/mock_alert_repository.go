@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAlertRepository is a mock implementation of alert.Repository
+type MockAlertRepository struct {
+	mock.Mock
+}
+
+// Record mocks the Record method
+func (m *MockAlertRepository) Record(ctx context.Context, a *alert.Alert) (*alert.Alert, error) {
+	args := m.Called(ctx, a)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*alert.Alert), args.Error(1)
+}
+
+// GetByID mocks the GetByID method
+func (m *MockAlertRepository) GetByID(ctx context.Context, id uuid.UUID) (*alert.Alert, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*alert.Alert), args.Error(1)
+}
+
+// List mocks the List method
+func (m *MockAlertRepository) List(ctx context.Context, status *alert.Status, limit, offset int32) ([]*alert.Alert, error) {
+	args := m.Called(ctx, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*alert.Alert), args.Error(1)
+}
+
+// Acknowledge mocks the Acknowledge method
+func (m *MockAlertRepository) Acknowledge(ctx context.Context, id uuid.UUID, acknowledgedBy string, note *string) (*alert.Alert, error) {
+	args := m.Called(ctx, id, acknowledgedBy, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*alert.Alert), args.Error(1)
+}
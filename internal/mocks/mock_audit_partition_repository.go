@@ -0,0 +1,129 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuditPartitionRepository is a mock implementation of audit.PartitionRepository
+type MockAuditPartitionRepository struct {
+	mock.Mock
+}
+
+// EnsurePartitions mocks the EnsurePartitions method
+func (m *MockAuditPartitionRepository) EnsurePartitions(ctx context.Context, from, to time.Time) (int, error) {
+	args := m.Called(ctx, from, to)
+	return args.Int(0), args.Error(1)
+}
+
+// ListPartitions mocks the ListPartitions method
+func (m *MockAuditPartitionRepository) ListPartitions(ctx context.Context) ([]*audit.Partition, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*audit.Partition), args.Error(1)
+}
+
+// CreatePartition mocks the CreatePartition method
+func (m *MockAuditPartitionRepository) CreatePartition(ctx context.Context, month time.Time) error {
+	args := m.Called(ctx, month)
+	return args.Error(0)
+}
+
+// DetachPartition mocks the DetachPartition method
+func (m *MockAuditPartitionRepository) DetachPartition(ctx context.Context, month time.Time) error {
+	args := m.Called(ctx, month)
+	return args.Error(0)
+}
+
+// StreamPartition mocks the StreamPartition method.
+// The first return value is the slice of logs fed to fn.
+func (m *MockAuditPartitionRepository) StreamPartition(ctx context.Context, month time.Time, fn func(*audit.Log) error) error {
+	args := m.Called(ctx, month, fn)
+	if logs, ok := args.Get(0).([]*audit.Log); ok {
+		for _, log := range logs {
+			if err := fn(log); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+// DropPartition mocks the DropPartition method
+func (m *MockAuditPartitionRepository) DropPartition(ctx context.Context, month time.Time) error {
+	args := m.Called(ctx, month)
+	return args.Error(0)
+}
+
+// InsertLogs mocks the InsertLogs method
+func (m *MockAuditPartitionRepository) InsertLogs(ctx context.Context, logs []*audit.Log) (int64, error) {
+	args := m.Called(ctx, logs)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// CreateArchive mocks the CreateArchive method.
+// The first return value may be a func(context.Context, *audit.Archive) *audit.Archive to echo the input.
+func (m *MockAuditPartitionRepository) CreateArchive(ctx context.Context, archive *audit.Archive) (*audit.Archive, error) {
+	args := m.Called(ctx, archive)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	if fn, ok := args.Get(0).(func(context.Context, *audit.Archive) *audit.Archive); ok {
+		return fn(ctx, archive), args.Error(1)
+	}
+	return args.Get(0).(*audit.Archive), args.Error(1)
+}
+
+// GetArchive mocks the GetArchive method
+func (m *MockAuditPartitionRepository) GetArchive(ctx context.Context, month time.Time) (*audit.Archive, error) {
+	args := m.Called(ctx, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.Archive), args.Error(1)
+}
+
+// ReplaceArchiveObject mocks the ReplaceArchiveObject method.
+// The first return value may be a func(context.Context, *audit.Archive) *audit.Archive to echo the input.
+func (m *MockAuditPartitionRepository) ReplaceArchiveObject(ctx context.Context, archive *audit.Archive, previousObjectKey string) (*audit.Archive, error) {
+	args := m.Called(ctx, archive, previousObjectKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	if fn, ok := args.Get(0).(func(context.Context, *audit.Archive) *audit.Archive); ok {
+		return fn(ctx, archive), args.Error(1)
+	}
+	return args.Get(0).(*audit.Archive), args.Error(1)
+}
+
+// ListArchives mocks the ListArchives method
+func (m *MockAuditPartitionRepository) ListArchives(ctx context.Context, limit, offset int32) ([]*audit.Archive, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*audit.Archive), args.Error(1)
+}
+
+// MarkArchiveRestored mocks the MarkArchiveRestored method
+func (m *MockAuditPartitionRepository) MarkArchiveRestored(ctx context.Context, month time.Time, restoredBy string) (*audit.Archive, error) {
+	args := m.Called(ctx, month, restoredBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.Archive), args.Error(1)
+}
+
+// ClearArchiveRestored mocks the ClearArchiveRestored method
+func (m *MockAuditPartitionRepository) ClearArchiveRestored(ctx context.Context, month time.Time) (*audit.Archive, error) {
+	args := m.Called(ctx, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.Archive), args.Error(1)
+}
@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuditRetentionRepository is a mock implementation of audit.RetentionRepository
+type MockAuditRetentionRepository struct {
+	mock.Mock
+}
+
+// Restamp mocks the Restamp method
+func (m *MockAuditRetentionRepository) Restamp(ctx context.Context, policy *audit.RetentionPolicy) (int64, error) {
+	args := m.Called(ctx, policy)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// SummarizeExpired mocks the SummarizeExpired method
+func (m *MockAuditRetentionRepository) SummarizeExpired(ctx context.Context, asOf time.Time) ([]*audit.ExpiryBucket, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*audit.ExpiryBucket), args.Error(1)
+}
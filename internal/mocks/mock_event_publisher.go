@@ -1,7 +1,6 @@
 package mocks
 
 import (
-	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -11,13 +10,13 @@ type MockEventPublisher struct {
 }
 
 // Publish mocks the Publish method
-func (m *MockEventPublisher) Publish(event *user.Event) error {
+func (m *MockEventPublisher) Publish(event interface{}) error {
 	args := m.Called(event)
 	return args.Error(0)
 }
 
 // PublishBatch mocks the PublishBatch method
-func (m *MockEventPublisher) PublishBatch(events []*user.Event) error {
+func (m *MockEventPublisher) PublishBatch(events []interface{}) error {
 	args := m.Called(events)
 	return args.Error(0)
 }
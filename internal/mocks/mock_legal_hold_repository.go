@@ -0,0 +1,76 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLegalHoldRepository is a mock implementation of audit.LegalHoldRepository
+type MockLegalHoldRepository struct {
+	mock.Mock
+}
+
+// Create mocks the Create method.
+// The first return value may be a func(context.Context, *audit.LegalHold) *audit.LegalHold to echo the input.
+func (m *MockLegalHoldRepository) Create(ctx context.Context, hold *audit.LegalHold) (*audit.LegalHold, error) {
+	args := m.Called(ctx, hold)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	if fn, ok := args.Get(0).(func(context.Context, *audit.LegalHold) *audit.LegalHold); ok {
+		return fn(ctx, hold), args.Error(1)
+	}
+	return args.Get(0).(*audit.LegalHold), args.Error(1)
+}
+
+// GetByID mocks the GetByID method
+func (m *MockLegalHoldRepository) GetByID(ctx context.Context, id uuid.UUID) (*audit.LegalHold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.LegalHold), args.Error(1)
+}
+
+// List mocks the List method
+func (m *MockLegalHoldRepository) List(ctx context.Context, activeOnly bool, limit, offset int32) ([]*audit.LegalHold, error) {
+	args := m.Called(ctx, activeOnly, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*audit.LegalHold), args.Error(1)
+}
+
+// UpdateTerms mocks the UpdateTerms method
+func (m *MockLegalHoldRepository) UpdateTerms(ctx context.Context, id uuid.UUID, reason, owner string, expiresAt time.Time) (*audit.LegalHold, error) {
+	args := m.Called(ctx, id, reason, owner, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.LegalHold), args.Error(1)
+}
+
+// Release mocks the Release method
+func (m *MockLegalHoldRepository) Release(ctx context.Context, id uuid.UUID, releasedBy, reason string) (*audit.LegalHold, error) {
+	args := m.Called(ctx, id, releasedBy, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.LegalHold), args.Error(1)
+}
+
+// IsUserOnHold mocks the IsUserOnHold method
+func (m *MockLegalHoldRepository) IsUserOnHold(ctx context.Context, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+// IsRangeOnHold mocks the IsRangeOnHold method
+func (m *MockLegalHoldRepository) IsRangeOnHold(ctx context.Context, start, end time.Time) (bool, error) {
+	args := m.Called(ctx, start, end)
+	return args.Bool(0), args.Error(1)
+}
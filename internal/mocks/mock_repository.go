@@ -134,19 +134,79 @@ func (mr *MockUserRepositoryMockRecorder) List(ctx, limit, offset any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), ctx, limit, offset)
 }
 
-// SearchUsers mocks base method.
-func (m *MockUserRepository) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*user.User, error) {
+// ListExpiredStatuses mocks base method.
+func (m *MockUserRepository) ListExpiredStatuses(ctx context.Context, now time.Time, limit int) ([]*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchUsers", ctx, query, limit, offset)
+	ret := m.ctrl.Call(m, "ListExpiredStatuses", ctx, now, limit)
 	ret0, _ := ret[0].([]*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ListExpiredStatuses indicates an expected call of ListExpiredStatuses.
+func (mr *MockUserRepositoryMockRecorder) ListExpiredStatuses(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredStatuses", reflect.TypeOf((*MockUserRepository)(nil).ListExpiredStatuses), ctx, now, limit)
+}
+
+// ListStatusChanges mocks base method.
+func (m *MockUserRepository) ListStatusChanges(ctx context.Context, userID uuid.UUID) ([]*user.AccountStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusChanges", ctx, userID)
+	ret0, _ := ret[0].([]*user.AccountStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusChanges indicates an expected call of ListStatusChanges.
+func (mr *MockUserRepositoryMockRecorder) ListStatusChanges(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusChanges", reflect.TypeOf((*MockUserRepository)(nil).ListStatusChanges), ctx, userID)
+}
+
+// Restore mocks base method.
+func (m *MockUserRepository) Restore(ctx context.Context, id uuid.UUID, deletedAt time.Time) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, deletedAt)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockUserRepositoryMockRecorder) Restore(ctx, id, deletedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockUserRepository)(nil).Restore), ctx, id, deletedAt)
+}
+
+// SearchUsers mocks base method.
+func (m *MockUserRepository) SearchUsers(ctx context.Context, search user.UserSearch) (*user.UserSearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUsers", ctx, search)
+	ret0, _ := ret[0].(*user.UserSearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // SearchUsers indicates an expected call of SearchUsers.
-func (mr *MockUserRepositoryMockRecorder) SearchUsers(ctx, query, limit, offset any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) SearchUsers(ctx, search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockUserRepository)(nil).SearchUsers), ctx, search)
+}
+
+// SetStatus mocks base method.
+func (m *MockUserRepository) SetStatus(ctx context.Context, change *user.AccountStatusChange) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, change)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockUserRepositoryMockRecorder) SetStatus(ctx, change any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockUserRepository)(nil).SearchUsers), ctx, query, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockUserRepository)(nil).SetStatus), ctx, change)
 }
 
 // SoftDelete mocks base method.
@@ -179,18 +239,18 @@ func (mr *MockUserRepositoryMockRecorder) UpdateKYCStatus(ctx, id, status any) *
 }
 
 // UpdateProfile mocks base method.
-func (m *MockUserRepository) UpdateProfile(ctx context.Context, id uuid.UUID, firstName, lastName string) (*user.User, error) {
+func (m *MockUserRepository) UpdateProfile(ctx context.Context, id uuid.UUID, profile user.Profile) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProfile", ctx, id, firstName, lastName)
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, id, profile)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateProfile indicates an expected call of UpdateProfile.
-func (mr *MockUserRepositoryMockRecorder) UpdateProfile(ctx, id, firstName, lastName any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) UpdateProfile(ctx, id, profile any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserRepository)(nil).UpdateProfile), ctx, id, firstName, lastName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserRepository)(nil).UpdateProfile), ctx, id, profile)
 }
 
 // UpdateRole mocks base method.
@@ -57,6 +57,11 @@ type MetricsCollector struct {
 	// Audit Metrics
 	AuditLogsCreated       *prometheus.CounterVec
 	AuditLogFailures       *prometheus.CounterVec
+
+	// Outbox Metrics
+	OutboxPending        prometheus.Gauge
+	OutboxLagSeconds     prometheus.Gauge
+	OutboxPublishedTotal *prometheus.CounterVec
 }
 
 // NewMetricsCollector creates and registers all Prometheus metrics
@@ -397,6 +402,35 @@ func NewMetricsCollector(namespace, subsystem string) *MetricsCollector {
 			},
 			[]string{"error_type"},
 		),
+
+		// Outbox Metrics
+		OutboxPending: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "outbox_pending",
+				Help:      "Number of outbox events not yet published",
+			},
+		),
+
+		OutboxLagSeconds: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "outbox_lag_seconds",
+				Help:      "Age of the oldest unpublished outbox event in seconds",
+			},
+		),
+
+		OutboxPublishedTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "outbox_publish_attempts_total",
+				Help:      "Total number of outbox publish attempts",
+			},
+			[]string{"event_type", "status"}, // status: success, failure
+		),
 	}
 
 	return mc
@@ -500,6 +534,21 @@ func (mc *MetricsCollector) RecordAuditLog(eventType, severity string, success b
 	}
 }
 
+// RecordOutboxPublish records an outbox publish attempt
+func (mc *MetricsCollector) RecordOutboxPublish(eventType string, success bool) {
+	status := "success"
+	if !success {
+		status = "failure"
+	}
+	mc.OutboxPublishedTotal.WithLabelValues(eventType, status).Inc()
+}
+
+// UpdateOutboxBacklog updates the outbox backlog gauges
+func (mc *MetricsCollector) UpdateOutboxBacklog(pending int64, lag time.Duration) {
+	mc.OutboxPending.Set(float64(pending))
+	mc.OutboxLagSeconds.Set(lag.Seconds())
+}
+
 // UpdateActiveSessions updates the active sessions gauge
 func (mc *MetricsCollector) UpdateActiveSessions(count int) {
 	mc.ActiveSessionsGauge.Set(float64(count))
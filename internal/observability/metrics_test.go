@@ -115,3 +115,14 @@ func TestRecordAuditLog(t *testing.T) {
 	count := testutil.ToFloat64(testMetrics.AuditLogsCreated.WithLabelValues("user.login", "info"))
 	assert.Greater(t, count, initial)
 }
+
+func TestOutboxMetrics(t *testing.T) {
+	testMetrics.UpdateOutboxBacklog(7, 90*time.Second)
+	assert.Equal(t, float64(7), testutil.ToFloat64(testMetrics.OutboxPending))
+	assert.Equal(t, float64(90), testutil.ToFloat64(testMetrics.OutboxLagSeconds))
+
+	initial := testutil.ToFloat64(testMetrics.OutboxPublishedTotal.WithLabelValues("user.registered", "failure"))
+	testMetrics.RecordOutboxPublish("user.registered", false)
+	count := testutil.ToFloat64(testMetrics.OutboxPublishedTotal.WithLabelValues("user.registered", "failure"))
+	assert.Equal(t, initial+1, count)
+}
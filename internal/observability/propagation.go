@@ -0,0 +1,27 @@
+package observability
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// TraceParentKey is the W3C Trace Context key carrying the trace and parent span IDs
+const TraceParentKey = "traceparent"
+
+// InjectTraceContext writes the span context of ctx into carrier (W3C traceparent and
+// tracestate, plus baggage) using the global propagator. Event metadata and message headers
+// use it so a trace continues across the event bus. Nothing is written when ctx carries no
+// span or tracing is disabled.
+func InjectTraceContext(ctx context.Context, carrier map[string]string) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(carrier))
+}
+
+// ExtractTraceContext returns ctx with the remote span context found in carrier, if any
+func ExtractTraceContext(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
@@ -0,0 +1,54 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestTraceContext_RoundTrip tests that a span context survives injection into and extraction from a map carrier
+func TestTraceContext_RoundTrip(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previousPropagator) })
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "publish")
+	carrier := map[string]string{"event_id": "evt-1"}
+	InjectTraceContext(ctx, carrier)
+	span.End()
+
+	require.Contains(t, carrier, TraceParentKey)
+	assert.Equal(t, "evt-1", carrier["event_id"])
+
+	_, child := tp.Tracer("test").Start(ExtractTraceContext(context.Background(), carrier), "process")
+	child.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, spans[0].SpanContext.TraceID(), spans[1].SpanContext.TraceID())
+	assert.Equal(t, spans[0].SpanContext.SpanID(), spans[1].Parent.SpanID())
+	assert.True(t, spans[1].Parent.IsRemote())
+}
+
+// TestTraceContext_NoSpan tests that nothing is injected or extracted without a span
+func TestTraceContext_NoSpan(t *testing.T) {
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previousPropagator) })
+
+	carrier := map[string]string{}
+	InjectTraceContext(context.Background(), carrier)
+	assert.Empty(t, carrier)
+
+	ctx := ExtractTraceContext(context.Background(), nil)
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
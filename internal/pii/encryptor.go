@@ -0,0 +1,244 @@
+// Package pii encrypts personally identifiable information at the application level.
+// Every user has a random AES-256 data key that encrypts their PII columns; the data key is
+// stored next to the data, wrapped by a common.KeyManager (Vault Transit in production, the
+// local KMS in development and tests). Emails are looked up through a keyed blind index.
+package pii
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+)
+
+// Key manager providers used in configuration
+const (
+	ProviderVault = "vault"
+	ProviderLocal = "local"
+)
+
+// ciphertextPrefix marks an encrypted field value; values without it are plaintext
+const ciphertextPrefix = "enc:v1:"
+
+// dataKeySize is the size of a data key (AES-256)
+const dataKeySize = 32
+
+// MinBlindIndexKeySize is the minimum size of the blind index key
+const MinBlindIndexKeySize = 32
+
+// ErrDecryptionFailed is returned when a field value cannot be decrypted with the user's key
+var ErrDecryptionFailed = errors.New("pii: decryption failed")
+
+// Encryptor encrypts and decrypts PII field values and computes email blind indexes.
+// Unwrapped data keys are cached for cacheTTL so reading a page of users does not call the
+// key manager for every row. It is safe for concurrent use.
+type Encryptor struct {
+	keys      common.KeyManager
+	indexKey  []byte
+	cacheTTL  time.Duration
+	cacheSize int
+
+	mu    sync.Mutex
+	cache map[string]cachedKey
+}
+
+// cachedKey is an unwrapped data key and when it leaves the cache
+type cachedKey struct {
+	key       []byte
+	expiresAt time.Time
+}
+
+// NewEncryptor creates an encryptor
+//
+// Parameters:
+//   - keys: Key manager wrapping the data keys
+//   - indexKey: HMAC key of the email blind index, at least MinBlindIndexKeySize bytes
+//   - cacheTTL: How long unwrapped data keys are cached; 0 disables the cache
+//   - cacheSize: Maximum number of cached data keys
+func NewEncryptor(keys common.KeyManager, indexKey []byte, cacheTTL time.Duration, cacheSize int) (*Encryptor, error) {
+	if keys == nil {
+		return nil, fmt.Errorf("pii: key manager is required")
+	}
+	if len(indexKey) < MinBlindIndexKeySize {
+		return nil, fmt.Errorf("pii: blind index key must be at least %d bytes", MinBlindIndexKeySize)
+	}
+	return &Encryptor{
+		keys:      keys,
+		indexKey:  indexKey,
+		cacheTTL:  cacheTTL,
+		cacheSize: cacheSize,
+		cache:     make(map[string]cachedKey),
+	}, nil
+}
+
+// NewDataKey generates a data key and returns it with its wrapped form and key version
+func (e *Encryptor) NewDataKey(ctx context.Context) ([]byte, string, int, error) {
+	key := make([]byte, dataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, "", 0, fmt.Errorf("pii: failed to generate data key: %w", err)
+	}
+	wrapped, version, err := e.keys.WrapKey(ctx, key)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("pii: failed to wrap data key: %w", err)
+	}
+	e.remember(wrapped, key)
+	return key, wrapped, version, nil
+}
+
+// DataKey unwraps a data key returned by NewDataKey or RewrapDataKey
+func (e *Encryptor) DataKey(ctx context.Context, wrapped string) ([]byte, error) {
+	if key, ok := e.cached(wrapped); ok {
+		return key, nil
+	}
+	key, err := e.keys.UnwrapKey(ctx, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("pii: failed to unwrap data key: %w", err)
+	}
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("pii: unwrapped data key has %d bytes, want %d", len(key), dataKeySize)
+	}
+	e.remember(wrapped, key)
+	return key, nil
+}
+
+// Forget drops a data key from the cache, so a key destroyed by erasing its user cannot be
+// used again by this process
+func (e *Encryptor) Forget(wrapped string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.cache, wrapped)
+}
+
+// RewrapDataKey re-encrypts a wrapped data key with the current key encryption key
+func (e *Encryptor) RewrapDataKey(ctx context.Context, wrapped string) (string, int, error) {
+	rewrapped, version, err := e.keys.RewrapKey(ctx, wrapped)
+	if err != nil {
+		return "", 0, fmt.Errorf("pii: failed to rewrap data key: %w", err)
+	}
+	return rewrapped, version, nil
+}
+
+// CurrentKeyVersion returns the version new data keys are wrapped with
+func (e *Encryptor) CurrentKeyVersion(ctx context.Context) (int, error) {
+	version, err := e.keys.CurrentVersion(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pii: failed to get current key version: %w", err)
+	}
+	return version, nil
+}
+
+// Encrypt encrypts the value of field with key. Empty values stay empty.
+// field is authenticated with the value, so a ciphertext only decrypts as the field it was
+// written to.
+func (e *Encryptor) Encrypt(key []byte, field, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("pii: failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(field))
+	return ciphertextPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts the value of field with key. Plaintext values, written before encryption
+// was enabled, are returned unchanged.
+func (e *Encryptor) Decrypt(key []byte, field, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, ciphertextPrefix))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s is not valid base64", ErrDecryptionFailed, field)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("%w: %s is too short", ErrDecryptionFailed, field)
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(field))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrDecryptionFailed, field)
+	}
+	return string(plaintext), nil
+}
+
+// BlindIndex returns the keyed blind index of an email. The index is deterministic, so
+// equal emails have equal indexes, but it cannot be reversed without the index key.
+func (e *Encryptor) BlindIndex(email string) []byte {
+	mac := hmac.New(sha256.New, e.indexKey)
+	mac.Write([]byte(email))
+	return mac.Sum(nil)
+}
+
+// IsEncrypted reports whether a stored field value is encrypted
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, ciphertextPrefix)
+}
+
+// newAEAD creates the AES-GCM cipher for a data key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("pii: invalid data key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("pii: failed to create cipher: %w", err)
+	}
+	return aead, nil
+}
+
+// cached returns the cached data key for wrapped, if it has not expired
+func (e *Encryptor) cached(wrapped string) ([]byte, bool) {
+	if e.cacheTTL <= 0 {
+		return nil, false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	entry, ok := e.cache[wrapped]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.key, true
+}
+
+// remember caches an unwrapped data key. When the cache is full, expired entries are
+// evicted first and the whole cache is cleared if that is not enough.
+func (e *Encryptor) remember(wrapped string, key []byte) {
+	if e.cacheTTL <= 0 || e.cacheSize <= 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := time.Now()
+	if len(e.cache) >= e.cacheSize {
+		for k, entry := range e.cache {
+			if now.After(entry.expiresAt) {
+				delete(e.cache, k)
+			}
+		}
+		if len(e.cache) >= e.cacheSize {
+			e.cache = make(map[string]cachedKey)
+		}
+	}
+	e.cache[wrapped] = cachedKey{key: key, expiresAt: now.Add(e.cacheTTL)}
+}
@@ -0,0 +1,199 @@
+package pii
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingKeyManager counts unwrap calls of a LocalKeyManager
+type countingKeyManager struct {
+	*LocalKeyManager
+	unwraps int
+}
+
+func (m *countingKeyManager) UnwrapKey(ctx context.Context, wrapped string) ([]byte, error) {
+	m.unwraps++
+	return m.LocalKeyManager.UnwrapKey(ctx, wrapped)
+}
+
+func newTestKeyManager(t *testing.T, versions int) *LocalKeyManager {
+	t.Helper()
+	keys := make([][]byte, versions)
+	for i := range keys {
+		keys[i] = bytes.Repeat([]byte{byte(i + 1)}, 32)
+	}
+	m, err := NewLocalKeyManager(keys)
+	require.NoError(t, err)
+	return m
+}
+
+func newTestEncryptor(t *testing.T, cacheTTL time.Duration) (*Encryptor, *countingKeyManager) {
+	t.Helper()
+	keys := &countingKeyManager{LocalKeyManager: newTestKeyManager(t, 1)}
+	enc, err := NewEncryptor(keys, bytes.Repeat([]byte("i"), 32), cacheTTL, 10)
+	require.NoError(t, err)
+	return enc, keys
+}
+
+func TestNewEncryptor(t *testing.T) {
+	_, err := NewEncryptor(nil, bytes.Repeat([]byte("i"), 32), 0, 0)
+	assert.Error(t, err, "key manager is required")
+
+	_, err = NewEncryptor(newTestKeyManager(t, 1), []byte("short"), 0, 0)
+	assert.Error(t, err, "blind index key is too short")
+}
+
+func TestEncryptor_EncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+	enc, _ := newTestEncryptor(t, 0)
+
+	key, wrapped, version, err := enc.NewDataKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+	assert.NotContains(t, wrapped, string(key))
+
+	ciphertext, err := enc.Encrypt(key, "email", "alice@example.com")
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(ciphertext))
+	assert.NotContains(t, ciphertext, "alice")
+
+	again, err := enc.Encrypt(key, "email", "alice@example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, ciphertext, again, "encryption must be randomized")
+
+	unwrapped, err := enc.DataKey(ctx, wrapped)
+	require.NoError(t, err)
+	plaintext, err := enc.Decrypt(unwrapped, "email", ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", plaintext)
+
+	t.Run("empty values stay empty", func(t *testing.T) {
+		ciphertext, err := enc.Encrypt(key, "phone", "")
+		require.NoError(t, err)
+		assert.Empty(t, ciphertext)
+	})
+
+	t.Run("plaintext values pass through", func(t *testing.T) {
+		plaintext, err := enc.Decrypt(key, "email", "legacy@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "legacy@example.com", plaintext)
+	})
+
+	t.Run("ciphertext is bound to its field", func(t *testing.T) {
+		_, err := enc.Decrypt(key, "first_name", ciphertext)
+		assert.ErrorIs(t, err, ErrDecryptionFailed)
+	})
+
+	t.Run("ciphertext is bound to its key", func(t *testing.T) {
+		otherKey, _, _, err := enc.NewDataKey(ctx)
+		require.NoError(t, err)
+		_, err = enc.Decrypt(otherKey, "email", ciphertext)
+		assert.ErrorIs(t, err, ErrDecryptionFailed)
+	})
+
+	t.Run("corrupt ciphertext", func(t *testing.T) {
+		_, err := enc.Decrypt(key, "email", ciphertextPrefix+"!!!")
+		assert.ErrorIs(t, err, ErrDecryptionFailed)
+		_, err = enc.Decrypt(key, "email", ciphertextPrefix+"AAAA")
+		assert.ErrorIs(t, err, ErrDecryptionFailed)
+	})
+}
+
+func TestEncryptor_BlindIndex(t *testing.T) {
+	enc, _ := newTestEncryptor(t, 0)
+
+	index := enc.BlindIndex("alice@example.com")
+	assert.Len(t, index, 32)
+	assert.Equal(t, index, enc.BlindIndex("alice@example.com"))
+	assert.NotEqual(t, index, enc.BlindIndex("bob@example.com"))
+
+	other, err := NewEncryptor(newTestKeyManager(t, 1), bytes.Repeat([]byte("j"), 32), 0, 0)
+	require.NoError(t, err)
+	assert.NotEqual(t, index, other.BlindIndex("alice@example.com"), "index depends on the key")
+}
+
+func TestEncryptor_DataKeyCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cached keys are not unwrapped again", func(t *testing.T) {
+		enc, keys := newTestEncryptor(t, time.Minute)
+		key, wrapped, _, err := enc.NewDataKey(ctx)
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			got, err := enc.DataKey(ctx, wrapped)
+			require.NoError(t, err)
+			assert.Equal(t, key, got)
+		}
+		assert.Equal(t, 0, keys.unwraps)
+	})
+
+	t.Run("disabled cache unwraps every time", func(t *testing.T) {
+		enc, keys := newTestEncryptor(t, 0)
+		_, wrapped, _, err := enc.NewDataKey(ctx)
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			_, err := enc.DataKey(ctx, wrapped)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 3, keys.unwraps)
+	})
+
+	t.Run("full cache is bounded", func(t *testing.T) {
+		enc, _ := newTestEncryptor(t, time.Minute)
+		for i := 0; i < 25; i++ {
+			_, _, _, err := enc.NewDataKey(ctx)
+			require.NoError(t, err)
+		}
+		assert.LessOrEqual(t, len(enc.cache), 10)
+	})
+
+	t.Run("forgotten keys are unwrapped again", func(t *testing.T) {
+		enc, keys := newTestEncryptor(t, time.Minute)
+		_, wrapped, _, err := enc.NewDataKey(ctx)
+		require.NoError(t, err)
+
+		enc.Forget(wrapped)
+		_, err = enc.DataKey(ctx, wrapped)
+		require.NoError(t, err)
+		assert.Equal(t, 1, keys.unwraps)
+	})
+}
+
+func TestEncryptor_RewrapDataKey(t *testing.T) {
+	ctx := context.Background()
+	masterKeys := [][]byte{bytes.Repeat([]byte{1}, 32)}
+	v1, err := NewLocalKeyManager(masterKeys)
+	require.NoError(t, err)
+	enc, err := NewEncryptor(v1, bytes.Repeat([]byte("i"), 32), 0, 0)
+	require.NoError(t, err)
+
+	key, wrapped, version, err := enc.NewDataKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+
+	// Rotate by adding a master key
+	v2, err := NewLocalKeyManager(append(masterKeys, bytes.Repeat([]byte{2}, 32)))
+	require.NoError(t, err)
+	enc, err = NewEncryptor(v2, bytes.Repeat([]byte("i"), 32), 0, 0)
+	require.NoError(t, err)
+
+	current, err := enc.CurrentKeyVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, current)
+
+	rewrapped, version, err := enc.RewrapDataKey(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+	assert.NotEqual(t, wrapped, rewrapped)
+
+	got, err := enc.DataKey(ctx, rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, key, got, "rewrapping keeps the data key")
+}
@@ -0,0 +1,136 @@
+package pii
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+)
+
+// Compile-time check to ensure LocalKeyManager implements common.KeyManager
+var _ common.KeyManager = (*LocalKeyManager)(nil)
+
+// localWrapPrefix starts every key wrapped by LocalKeyManager ("local:v<version>:<base64>")
+const localWrapPrefix = "local:v"
+
+// LocalKeyManager is a KMS stand-in for development and tests. It wraps data keys with
+// AES-256-GCM master keys held in memory; master key i is key version i+1 and the last one
+// is current, so appending a key rotates. It must not be used in production.
+type LocalKeyManager struct {
+	masterKeys [][]byte
+}
+
+// NewLocalKeyManager creates a local key manager from 32-byte master keys, oldest first
+func NewLocalKeyManager(masterKeys [][]byte) (*LocalKeyManager, error) {
+	if len(masterKeys) == 0 {
+		return nil, fmt.Errorf("pii: at least one local master key is required")
+	}
+	for i, key := range masterKeys {
+		if len(key) != dataKeySize {
+			return nil, fmt.Errorf("pii: local master key %d must be %d bytes", i+1, dataKeySize)
+		}
+	}
+	return &LocalKeyManager{masterKeys: masterKeys}, nil
+}
+
+// ParseLocalMasterKeys decodes a comma-separated list of base64 master keys, oldest first
+func ParseLocalMasterKeys(s string) ([][]byte, error) {
+	var keys [][]byte
+	for i, encoded := range strings.Split(s, ",") {
+		encoded = strings.TrimSpace(encoded)
+		if encoded == "" {
+			continue
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("pii: local master key %d is not valid base64", i+1)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// WrapKey encrypts key with the current master key
+func (m *LocalKeyManager) WrapKey(ctx context.Context, key []byte) (string, int, error) {
+	version := len(m.masterKeys)
+	wrapped, err := m.wrap(version, key)
+	return wrapped, version, err
+}
+
+// UnwrapKey decrypts a key wrapped with any of the master keys
+func (m *LocalKeyManager) UnwrapKey(ctx context.Context, wrapped string) ([]byte, error) {
+	version, sealed, err := parseLocalWrapped(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	if version < 1 || version > len(m.masterKeys) {
+		return nil, fmt.Errorf("pii: unknown local master key version %d", version)
+	}
+	aead, err := newAEAD(m.masterKeys[version-1])
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("pii: wrapped key is too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	key, err := aead.Open(nil, nonce, ciphertext, []byte(localWrapPrefix+strconv.Itoa(version)))
+	if err != nil {
+		return nil, fmt.Errorf("pii: failed to unwrap key: %w", err)
+	}
+	return key, nil
+}
+
+// RewrapKey re-encrypts a wrapped key with the current master key
+func (m *LocalKeyManager) RewrapKey(ctx context.Context, wrapped string) (string, int, error) {
+	key, err := m.UnwrapKey(ctx, wrapped)
+	if err != nil {
+		return "", 0, err
+	}
+	return m.WrapKey(ctx, key)
+}
+
+// CurrentVersion returns the version of the last master key
+func (m *LocalKeyManager) CurrentVersion(ctx context.Context) (int, error) {
+	return len(m.masterKeys), nil
+}
+
+// wrap encrypts key with the master key of version; the version is authenticated with it
+func (m *LocalKeyManager) wrap(version int, key []byte) (string, error) {
+	aead, err := newAEAD(m.masterKeys[version-1])
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("pii: failed to generate nonce: %w", err)
+	}
+	prefix := localWrapPrefix + strconv.Itoa(version)
+	sealed := aead.Seal(nonce, nonce, key, []byte(prefix))
+	return prefix + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// parseLocalWrapped splits a locally wrapped key into its version and sealed bytes
+func parseLocalWrapped(wrapped string) (int, []byte, error) {
+	rest, ok := strings.CutPrefix(wrapped, localWrapPrefix)
+	if !ok {
+		return 0, nil, fmt.Errorf("pii: key was not wrapped by the local key manager")
+	}
+	versionStr, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return 0, nil, fmt.Errorf("pii: malformed wrapped key")
+	}
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		return 0, nil, fmt.Errorf("pii: malformed wrapped key version")
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, nil, fmt.Errorf("pii: malformed wrapped key")
+	}
+	return version, sealed, nil
+}
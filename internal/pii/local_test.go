@@ -0,0 +1,81 @@
+package pii
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLocalKeyManager(t *testing.T) {
+	_, err := NewLocalKeyManager(nil)
+	assert.Error(t, err)
+
+	_, err = NewLocalKeyManager([][]byte{[]byte("too short")})
+	assert.Error(t, err)
+}
+
+func TestParseLocalMasterKeys(t *testing.T) {
+	k1 := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	k2 := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32))
+
+	keys, err := ParseLocalMasterKeys(k1 + ", " + k2 + ",")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, bytes.Repeat([]byte{2}, 32), keys[1])
+
+	_, err = ParseLocalMasterKeys("not base64!")
+	assert.Error(t, err)
+}
+
+func TestLocalKeyManager_WrapUnwrap(t *testing.T) {
+	ctx := context.Background()
+	m := newTestKeyManager(t, 2)
+	key := bytes.Repeat([]byte{9}, 32)
+
+	wrapped, version, err := m.WrapKey(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+	assert.True(t, strings.HasPrefix(wrapped, "local:v2:"))
+
+	got, err := m.UnwrapKey(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, key, got)
+
+	t.Run("version is authenticated", func(t *testing.T) {
+		tampered := "local:v1:" + strings.TrimPrefix(wrapped, "local:v2:")
+		_, err := m.UnwrapKey(ctx, tampered)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := m.UnwrapKey(ctx, "local:v3:"+strings.TrimPrefix(wrapped, "local:v2:"))
+		assert.Error(t, err)
+	})
+
+	t.Run("foreign format", func(t *testing.T) {
+		_, err := m.UnwrapKey(ctx, "vault:v1:abc")
+		assert.Error(t, err)
+	})
+}
+
+func TestLocalKeyManager_RewrapKey(t *testing.T) {
+	ctx := context.Background()
+	old := newTestKeyManager(t, 1)
+	key := bytes.Repeat([]byte{9}, 32)
+	wrapped, _, err := old.WrapKey(ctx, key)
+	require.NoError(t, err)
+
+	rotated := newTestKeyManager(t, 3)
+	rewrapped, version, err := rotated.RewrapKey(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, 3, version)
+
+	got, err := rotated.UnwrapKey(ctx, rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, key, got)
+}
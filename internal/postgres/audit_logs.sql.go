@@ -190,8 +190,25 @@ const deleteExpiredAuditLogs = `-- name: DeleteExpiredAuditLogs :exec
 DELETE FROM audit_logs
 WHERE retention_until IS NOT NULL
   AND retention_until < NOW()
+  AND NOT EXISTS (
+      SELECT 1 FROM legal_holds h
+      WHERE h.released_at IS NULL
+        AND h.expires_at > NOW()
+        AND (h.user_id IS NULL OR h.user_id = audit_logs.user_id)
+        AND (h.event_category IS NULL OR h.event_category = audit_logs.event_category)
+        AND (h.starts_at IS NULL OR audit_logs.created_at >= h.starts_at)
+        AND (h.ends_at IS NULL OR audit_logs.created_at < h.ends_at)
+  )
+  AND NOT EXISTS (
+      SELECT 1 FROM audit_log_archives a
+      WHERE a.restored_at IS NOT NULL
+        AND audit_logs.created_at >= a.partition_month
+        AND audit_logs.created_at < a.partition_month + INTERVAL '1 month'
+  )
 `
 
+// DeleteExpiredAuditLogs removes expired audit logs that are not covered by an active legal hold
+// or part of an archived month restored for investigation (restored rows keep their old retention).
 func (q *Queries) DeleteExpiredAuditLogs(ctx context.Context) error {
 	_, err := q.db.Exec(ctx, deleteExpiredAuditLogs)
 	return err
@@ -11,7 +11,33 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-// Immutable audit trail for security, compliance, and forensic analysis
+// Security alerts raised by the alert rule engine
+type Alert struct {
+	ID         uuid.UUID `json:"id"`
+	RuleName   string    `json:"rule_name"`
+	Severity   string    `json:"severity"`
+	Summary    string    `json:"summary"`
+	GroupBy    string    `json:"group_by"`
+	GroupValue string    `json:"group_value"`
+	// Rule name and group value; repeat firings update the open alert with this key
+	DedupKey string `json:"dedup_key"`
+	// Events that triggered the alert, summed over all firings
+	EventCount int32 `json:"event_count"`
+	// Times the rule fired while this alert was open
+	FireCount   int32              `json:"fire_count"`
+	FirstSeenAt pgtype.Timestamptz `json:"first_seen_at"`
+	LastSeenAt  pgtype.Timestamptz `json:"last_seen_at"`
+	// Most recent triggering events, as evidence
+	Events         []byte             `json:"events"`
+	Status         string             `json:"status"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	AcknowledgedAt pgtype.Timestamptz `json:"acknowledged_at"`
+	AcknowledgedBy *string            `json:"acknowledged_by"`
+	AckNote        *string            `json:"ack_note"`
+}
+
+// Immutable audit trail for security, compliance, and forensic analysis (partitioned monthly by created_at)
 type AuditLog struct {
 	ID uuid.UUID `json:"id"`
 	// Specific event identifier (e.g., user.login, kyc.approved)
@@ -42,6 +68,205 @@ type AuditLog struct {
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 }
 
+// Monthly audit_logs partitions archived to cold storage
+type AuditLogArchive struct {
+	ID uuid.UUID `json:"id"`
+	// First instant (UTC) of the archived month
+	PartitionMonth pgtype.Timestamptz `json:"partition_month"`
+	PartitionName  string             `json:"partition_name"`
+	// Object store key of the gzip-compressed NDJSON export
+	ObjectKey string `json:"object_key"`
+	// Object store key of the JSON manifest describing the export
+	ManifestKey string `json:"manifest_key"`
+	RowCount    int64  `json:"row_count"`
+	SizeBytes   int64  `json:"size_bytes"`
+	// Hex SHA-256 of the compressed export, verified on restore
+	ChecksumSha256 string             `json:"checksum_sha256"`
+	ArchivedAt     pgtype.Timestamptz `json:"archived_at"`
+	// Set while the month is restored into audit_logs for investigation (NULL otherwise)
+	RestoredAt pgtype.Timestamptz `json:"restored_at"`
+	RestoredBy *string            `json:"restored_by"`
+}
+
+// GDPR data subject access exports
+type DataExportJob struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+	Status string    `json:"status"`
+	// User ID, or email of the admin who requested the export on the user's behalf
+	RequestedBy   string  `json:"requested_by"`
+	RequesterType string  `json:"requester_type"`
+	LastError     *string `json:"last_error"`
+	// Object store key of the signed ZIP archive
+	ObjectKey *string `json:"object_key"`
+	SizeBytes *int64  `json:"size_bytes"`
+	Sha256    *string `json:"sha256"`
+	// When the archive is deleted and the job moves to expired
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	// SHA-256 of the latest download link token
+	DownloadTokenHash []byte             `json:"download_token_hash"`
+	DownloadExpiresAt pgtype.Timestamptz `json:"download_expires_at"`
+	DownloadCount     int32              `json:"download_count"`
+	LastDownloadedAt  pgtype.Timestamptz `json:"last_downloaded_at"`
+	LeaseOwner        *string            `json:"lease_owner"`
+	// A running job whose lease expired is resumed by another instance
+	LeaseExpiresAt pgtype.Timestamptz `json:"lease_expires_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	StartedAt      pgtype.Timestamptz `json:"started_at"`
+	CompletedAt    pgtype.Timestamptz `json:"completed_at"`
+}
+
+// Event replay and backfill jobs
+type EventReplayJob struct {
+	ID uuid.UUID `json:"id"`
+	// snapshot (current users state), outbox or audit (history for a time range)
+	Source string `json:"source"`
+	// Event types to replay; empty replays every type the source supports
+	EventTypes   []string           `json:"event_types"`
+	FromTime     pgtype.Timestamptz `json:"from_time"`
+	ToTime       pgtype.Timestamptz `json:"to_time"`
+	TargetStream string             `json:"target_stream"`
+	// Maximum events published per second
+	RateLimit int32  `json:"rate_limit"`
+	BatchSize int32  `json:"batch_size"`
+	Status    string `json:"status"`
+	// Source position after the last replayed record; the job resumes from here
+	ResumeCursor string `json:"resume_cursor"`
+	// Source records in range when the job started, for progress reporting
+	TotalRecords     int64   `json:"total_records"`
+	ProcessedRecords int64   `json:"processed_records"`
+	PublishedEvents  int64   `json:"published_events"`
+	LastError        *string `json:"last_error"`
+	LeaseOwner       *string `json:"lease_owner"`
+	// A running job whose lease expired is resumed by another instance
+	LeaseExpiresAt pgtype.Timestamptz `json:"lease_expires_at"`
+	RequestedBy    string             `json:"requested_by"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	StartedAt      pgtype.Timestamptz `json:"started_at"`
+	CompletedAt    pgtype.Timestamptz `json:"completed_at"`
+}
+
+// KYC applications and their review
+type KycCase struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Status    string    `json:"status"`
+	RiskLevel string    `json:"risk_level"`
+	// Identity data submitted by the applicant (name, date of birth, nationality, address)
+	Applicant  []byte      `json:"applicant"`
+	ReviewerID pgtype.UUID `json:"reviewer_id"`
+	// First of the two admins approving a high-risk case (four-eyes principle)
+	FirstApproverID pgtype.UUID        `json:"first_approver_id"`
+	FirstApprovedAt pgtype.Timestamptz `json:"first_approved_at"`
+	DecidedBy       pgtype.UUID        `json:"decided_by"`
+	DecidedAt       pgtype.Timestamptz `json:"decided_at"`
+	RejectionReason *string            `json:"rejection_reason"`
+	ReviewNote      *string            `json:"review_note"`
+	SubmittedAt     pgtype.Timestamptz `json:"submitted_at"`
+	// Incremented on every update; guards against concurrent review decisions
+	Version   int32              `json:"version"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	// External KYC provider verifying the case; NULL for cases reviewed by admins only
+	Provider *string `json:"provider"`
+	// Applicant ID assigned by the provider
+	ProviderApplicantID *string `json:"provider_applicant_id"`
+}
+
+// History of actions taken on KYC cases
+type KycCaseTransition struct {
+	ID         uuid.UUID `json:"id"`
+	CaseID     uuid.UUID `json:"case_id"`
+	Action     string    `json:"action"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	// Applicant or admin who took the action; not a foreign key so history survives user removal
+	ActorID    uuid.UUID          `json:"actor_id"`
+	ReasonCode *string            `json:"reason_code"`
+	Note       *string            `json:"note"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+// Metadata of documents uploaded to KYC cases; content lives in the object store
+type KycDocument struct {
+	ID           uuid.UUID `json:"id"`
+	CaseID       uuid.UUID `json:"case_id"`
+	DocumentType string    `json:"document_type"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	SizeBytes    int64     `json:"size_bytes"`
+	// Hex SHA-256 of the content, to verify the stored object was not altered
+	Sha256     string             `json:"sha256"`
+	StorageKey string             `json:"storage_key"`
+	UploadedAt pgtype.Timestamptz `json:"uploaded_at"`
+}
+
+// Legal holds blocking deletion, archival and erasure of matching audit records and user data
+type LegalHold struct {
+	ID uuid.UUID `json:"id"`
+	// Held user; NULL matches all users
+	UserID pgtype.UUID `json:"user_id"`
+	// Inclusive start of the held created_at range; NULL is unbounded
+	StartsAt pgtype.Timestamptz `json:"starts_at"`
+	// Exclusive end of the held created_at range; NULL is unbounded
+	EndsAt pgtype.Timestamptz `json:"ends_at"`
+	// Held audit event category; NULL matches all categories
+	EventCategory *string `json:"event_category"`
+	Reason        string  `json:"reason"`
+	Owner         string  `json:"owner"`
+	// The hold lapses automatically after this instant unless extended
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+	CreatedBy     string             `json:"created_by"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	ReleasedAt    pgtype.Timestamptz `json:"released_at"`
+	ReleasedBy    *string            `json:"released_by"`
+	ReleaseReason *string            `json:"release_reason"`
+}
+
+// Domain events awaiting (or recently completed) relay to the event bus
+type Outbox struct {
+	ID            int64  `json:"id"`
+	AggregateType string `json:"aggregate_type"`
+	// Ordering key: events of one aggregate are published in id order
+	AggregateID string             `json:"aggregate_id"`
+	EventID     string             `json:"event_id"`
+	EventType   string             `json:"event_type"`
+	OccurredAt  pgtype.Timestamptz `json:"occurred_at"`
+	Attributes  []byte             `json:"attributes"`
+	Payload     []byte             `json:"payload"`
+	Metadata    []byte             `json:"metadata"`
+	// Failed publish attempts so far
+	Attempts  int32   `json:"attempts"`
+	LastError *string `json:"last_error"`
+	// Earliest time of the next publish attempt (exponential backoff after failures)
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	// When the event bus accepted the event; NULL while pending
+	PublishedAt pgtype.Timestamptz `json:"published_at"`
+	// CloudEvents source: the producer of the event
+	Source string `json:"source"`
+	// CloudEvents subject: the entity the event is about, e.g. the user ID
+	Subject string `json:"subject"`
+	// Version of the payload JSON Schema
+	SchemaVersion int32 `json:"schema_version"`
+}
+
+// Pending phone number verification codes
+type PhoneVerification struct {
+	UserID uuid.UUID `json:"user_id"`
+	// Number the code was sent to; the code only verifies this number
+	Phone string `json:"phone"`
+	// SHA-256 of the code; the code itself is never stored
+	CodeHash []byte `json:"code_hash"`
+	// Wrong codes entered so far
+	Attempts  int32              `json:"attempts"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 // Stores JWT refresh tokens for user authentication
 type RefreshToken struct {
 	// Unique refresh token string (hashed)
@@ -60,11 +285,63 @@ type RefreshToken struct {
 	UserAgent *string `json:"user_agent"`
 }
 
+// Sanctions and PEP watch list matches of users and their compliance review
+type ScreeningCase struct {
+	ID      uuid.UUID `json:"id"`
+	UserID  uuid.UUID `json:"user_id"`
+	Status  string    `json:"status"`
+	Trigger string    `json:"trigger"`
+	// Names and date of birth the user was screened with
+	Subject []byte `json:"subject"`
+	// Matched watch list entries with their similarity scores
+	Matches []byte `json:"matches"`
+	// Version of the watch lists the user was screened against
+	ListVersion string             `json:"list_version"`
+	ReviewedBy  pgtype.UUID        `json:"reviewed_by"`
+	ReviewedAt  pgtype.Timestamptz `json:"reviewed_at"`
+	ReviewNote  *string            `json:"review_note"`
+	// Incremented on every update; guards against concurrent review decisions
+	Version   int32              `json:"version"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Daily user statistics per UTC day, maintained by the stats rollup job
+type StatsDaily struct {
+	Day           pgtype.Date `json:"day"`
+	Registrations int64       `json:"registrations"`
+	// Successful logins, from user.login audit logs
+	Logins int64 `json:"logins"`
+	// Failed logins, from user.login audit logs
+	FailedLogins int64 `json:"failed_logins"`
+	KycApprovals int64 `json:"kyc_approvals"`
+	// Users active on the day (DAU)
+	ActiveUsers int64 `json:"active_users"`
+	// Users active in the 30 days ending on the day (MAU)
+	MonthlyActiveUsers int64              `json:"monthly_active_users"`
+	RefreshedAt        pgtype.Timestamptz `json:"refreshed_at"`
+}
+
+// Non-deleted users by the status of their latest KYC case
+type StatsKycFunnel struct {
+	Status      string             `json:"status"`
+	Users       int64              `json:"users"`
+	RefreshedAt pgtype.Timestamptz `json:"refreshed_at"`
+}
+
+// Active sessions by device, classified from their user agent
+type StatsSessionsByDevice struct {
+	Device      string             `json:"device"`
+	Sessions    int64              `json:"sessions"`
+	Users       int64              `json:"users"`
+	RefreshedAt pgtype.Timestamptz `json:"refreshed_at"`
+}
+
 // Stores user authentication and profile information
 type User struct {
 	// Unique user identifier (UUID v4)
 	ID uuid.UUID `json:"id"`
-	// User email address (unique, used for login)
+	// User email address (unique among active users, used for login)
 	Email string `json:"email"`
 	// Argon2id hashed password
 	HashedPassword string `json:"hashed_password"`
@@ -80,4 +357,139 @@ type User struct {
 	LastName  string             `json:"last_name"`
 	// User role for authorization: user (default) or admin
 	Role string `json:"role"`
+	// Phone number in E.164 format
+	Phone *string `json:"phone"`
+	// When the user confirmed a code sent to phone; NULL until verified and reset when phone changes
+	PhoneVerifiedAt pgtype.Timestamptz `json:"phone_verified_at"`
+	// Date of birth (YYYY-MM-DD); users must be at least 18
+	DateOfBirth       *string `json:"date_of_birth"`
+	AddressLine1      *string `json:"address_line1"`
+	AddressLine2      *string `json:"address_line2"`
+	AddressCity       *string `json:"address_city"`
+	AddressPostalCode *string `json:"address_postal_code"`
+	AddressRegion     *string `json:"address_region"`
+	// Residential address country (ISO 3166-1 alpha-2)
+	AddressCountry *string `json:"address_country"`
+	// Nationality (ISO 3166-1 alpha-2)
+	Nationality *string `json:"nationality"`
+	// Country of tax residency (ISO 3166-1 alpha-2)
+	TaxResidency *string `json:"tax_residency"`
+	// HMAC-SHA256 blind index of email; NULL until the email is encrypted
+	EmailIndex []byte `json:"email_index"`
+	// Data key encrypting the PII columns, wrapped by the key manager; NULL while the row holds plaintext
+	PiiKey *string `json:"pii_key"`
+	// Version of the key encryption key that wrapped pii_key
+	PiiKeyVersion *int32 `json:"pii_key_version"`
+	// Account status: active, suspended (no login), frozen_withdrawals or closed (no login)
+	Status string `json:"status"`
+	// Why the status was last changed; NULL if it never was
+	StatusReason *string `json:"status_reason"`
+	// When a suspension or freeze ends; NULL if it does not expire
+	StatusExpiresAt pgtype.Timestamptz `json:"status_expires_at"`
+	// Admin who last changed the status; NULL when lifted on expiry
+	StatusSetBy pgtype.UUID        `json:"status_set_by"`
+	StatusSetAt pgtype.Timestamptz `json:"status_set_at"`
+}
+
+// Users who logged in or refreshed a session on each UTC day
+type UserActivityDaily struct {
+	Day    pgtype.Date `json:"day"`
+	UserID uuid.UUID   `json:"user_id"`
+}
+
+// GDPR erasure of deleted users: deferrals and completed erasures
+type UserErasure struct {
+	UserID uuid.UUID `json:"user_id"`
+	Status string    `json:"status"`
+	// legal_hold (an active hold names the user or covers their audit history) or retention (KYC/AML records must be kept)
+	DeferralReason *string `json:"deferral_reason"`
+	// The erasure job retries the user after this instant
+	DeferredUntil pgtype.Timestamptz `json:"deferred_until"`
+	// Stable pseudonym that replaced the user's PII in users and audit_logs
+	Pseudonym *string `json:"pseudonym"`
+	// Number of audit_logs rows whose PII was replaced
+	AuditLogsRewritten int64              `json:"audit_logs_rewritten"`
+	ErasedAt           pgtype.Timestamptz `json:"erased_at"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+}
+
+// KYC tier held by each user; users without a row hold tier 0
+type UserKycTier struct {
+	UserID uuid.UUID `json:"user_id"`
+	Tier   int16     `json:"tier"`
+	// Admin who last changed the tier; NULL for automatic upgrades on KYC approval
+	UpdatedBy pgtype.UUID        `json:"updated_by"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// History of KYC tier changes
+type UserKycTierChange struct {
+	ID        uuid.UUID   `json:"id"`
+	UserID    uuid.UUID   `json:"user_id"`
+	OldTier   int16       `json:"old_tier"`
+	NewTier   int16       `json:"new_tier"`
+	Reason    string      `json:"reason"`
+	ChangedBy pgtype.UUID `json:"changed_by"`
+	// Approved KYC case behind an automatic upgrade
+	CaseID    pgtype.UUID        `json:"case_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// History of account status changes
+type UserStatusChange struct {
+	ID        uuid.UUID          `json:"id"`
+	UserID    uuid.UUID          `json:"user_id"`
+	OldStatus string             `json:"old_status"`
+	NewStatus string             `json:"new_status"`
+	Reason    string             `json:"reason"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	// Admin who changed the status; NULL when lifted on expiry
+	ChangedBy pgtype.UUID `json:"changed_by"`
+	// Whether the user's sessions were revoked with the change
+	SessionsRevoked bool               `json:"sessions_revoked"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+// Webhook delivery queue and delivery log
+type WebhookDelivery struct {
+	ID             uuid.UUID `json:"id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	EventID        string    `json:"event_id"`
+	EventType      string    `json:"event_type"`
+	// CloudEvent sent as the request body
+	Payload []byte `json:"payload"`
+	// pending until delivered (succeeded) or out of attempts (failed)
+	Status   string `json:"status"`
+	Attempts int32  `json:"attempts"`
+	// Earliest time of the next attempt (exponential backoff after failures); pushed forward while an attempt is in flight
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	LastAttemptAt  pgtype.Timestamptz `json:"last_attempt_at"`
+	LastStatusCode *int32             `json:"last_status_code"`
+	LastError      *string            `json:"last_error"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+// HTTP endpoints receiving user lifecycle events
+type WebhookSubscription struct {
+	ID          uuid.UUID `json:"id"`
+	Url         string    `json:"url"`
+	Description string    `json:"description"`
+	// Event types delivered; empty delivers every type
+	EventTypes []string `json:"event_types"`
+	// HMAC-SHA256 key signing each delivery; encrypted with secret_key when it is set
+	Secret  string `json:"secret"`
+	Enabled bool   `json:"enabled"`
+	// Failed attempts since the last successful delivery; the subscription is disabled past a threshold
+	ConsecutiveFailures int32              `json:"consecutive_failures"`
+	DisabledAt          pgtype.Timestamptz `json:"disabled_at"`
+	DisabledReason      *string            `json:"disabled_reason"`
+	CreatedBy           string             `json:"created_by"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+	// Data key encrypting secret, wrapped by the key manager; NULL while secret is plaintext
+	SecretKey *string `json:"secret_key"`
+	// Version of the key encryption key that wrapped secret_key
+	SecretKeyVersion *int32 `json:"secret_key_version"`
 }
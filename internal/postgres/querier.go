@@ -6,52 +6,221 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	// AcknowledgeAlert moves an open alert to acknowledged.
+	AcknowledgeAlert(ctx context.Context, arg AcknowledgeAlertParams) (Alert, error)
+	// AcquireOutboxRelayLock takes the relay lock for the current transaction, returning false if another relay holds it.
+	AcquireOutboxRelayLock(ctx context.Context) (bool, error)
+	// CancelEventReplayJob stops a pending or running job; it keeps its cursor and can be resumed.
+	CancelEventReplayJob(ctx context.Context, id uuid.UUID) (EventReplayJob, error)
+	// ClaimDataExportJob leases the oldest pending export, or running export whose lease expired, to owner.
+	ClaimDataExportJob(ctx context.Context, arg ClaimDataExportJobParams) (DataExportJob, error)
+	// ClaimDueWebhookDeliveries leases up to limit_count pending deliveries of enabled subscriptions
+	// due at now by moving their next attempt to lease_until, so concurrent dispatchers skip them.
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// ClaimEventReplayJob leases the oldest pending job, or running job whose lease expired, to owner.
+	ClaimEventReplayJob(ctx context.Context, arg ClaimEventReplayJobParams) (EventReplayJob, error)
+	// ClearAuditLogArchiveRestored clears the restored flag once the restored month has been released.
+	ClearAuditLogArchiveRestored(ctx context.Context, partitionMonth pgtype.Timestamptz) (AuditLogArchive, error)
+	// ClearKYCCasesForErasure clears the applicant data and review notes of a user's KYC cases.
+	// Statuses, decisions and timestamps are kept.
+	ClearKYCCasesForErasure(ctx context.Context, userID uuid.UUID) (int64, error)
+	// ClearKYCTransitionNotesForErasure clears the notes of the transitions of a user's KYC cases.
+	ClearKYCTransitionNotesForErasure(ctx context.Context, userID uuid.UUID) (int64, error)
+	// ClearScreeningCasesForErasure clears the screened names and review notes of a user's
+	// screening cases. The matched list entries and decisions are kept.
+	ClearScreeningCasesForErasure(ctx context.Context, userID uuid.UUID) (int64, error)
+	// CloseDataExportJobsForErasure expires a user's completed exports, whose archives were
+	// deleted, and fails those still pending or running.
+	CloseDataExportJobsForErasure(ctx context.Context, userID uuid.UUID) (int64, error)
+	// CompleteDataExportJob records the archive of a running export held by owner and releases the lease.
+	CompleteDataExportJob(ctx context.Context, arg CompleteDataExportJobParams) (int64, error)
+	// CountActiveLegalHoldsForRange counts active holds whose created_at range overlaps [range_start, range_end).
+	// User and category scopes are ignored: any overlapping hold counts.
+	CountActiveLegalHoldsForRange(ctx context.Context, arg CountActiveLegalHoldsForRangeParams) (int64, error)
+	// CountActiveLegalHoldsForUser counts active holds naming the given user.
+	CountActiveLegalHoldsForUser(ctx context.Context, userID uuid.UUID) (int64, error)
 	// CountAllActiveSessions returns the total count of active sessions across all users (admin only).
 	CountAllActiveSessions(ctx context.Context) (int64, error)
 	CountAuditLogsByCategory(ctx context.Context, eventCategory string) (int64, error)
 	CountAuditLogsByEventType(ctx context.Context, eventType string) (int64, error)
 	CountAuditLogsByUser(ctx context.Context, userID pgtype.UUID) (int64, error)
+	// CountAuditLogsForReplay counts audit logs of the given event types (all when empty) created in the optional time range.
+	CountAuditLogsForReplay(ctx context.Context, arg CountAuditLogsForReplayParams) (int64, error)
+	// CountKYCCases counts cases matching the optional filters.
+	CountKYCCases(ctx context.Context, arg CountKYCCasesParams) (int64, error)
+	// CountLegalHoldsForErasure counts the active legal holds covering an audit log
+	// ListAuditLogsForErasure would return, matched as DeleteExpiredAuditLogs matches them.
+	// When archived_since is set, holds on every user or on this user whose date range overlaps
+	// a month archived from archived_since on count as well.
+	CountLegalHoldsForErasure(ctx context.Context, arg CountLegalHoldsForErasureParams) (int64, error)
+	// CountOutboxForReplay counts outbox messages of the given types (all when empty) that occurred in the optional time range.
+	CountOutboxForReplay(ctx context.Context, arg CountOutboxForReplayParams) (int64, error)
+	// CountScreeningCases counts cases matching the optional filters.
+	CountScreeningCases(ctx context.Context, arg CountScreeningCasesParams) (int64, error)
 	CountSearchAuditLogs(ctx context.Context, arg CountSearchAuditLogsParams) (int64, error)
 	// CountUserActiveTokens returns the number of active sessions for a user.
 	CountUserActiveTokens(ctx context.Context, userID uuid.UUID) (int64, error)
 	// CountUsers returns the total count of active users.
 	CountUsers(ctx context.Context) (int64, error)
+	// CountUsersForPIIReencryption counts the users ListUsersForPIIReencryption would return.
+	CountUsersForPIIReencryption(ctx context.Context, currentVersion int32) (int64, error)
+	// CountUsersForReplay counts users, including deleted ones, created in the optional time range.
+	CountUsersForReplay(ctx context.Context, arg CountUsersForReplayParams) (int64, error)
 	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
+	// CreateAuditLogArchive records an archived partition.
+	// Re-archiving a month replaces the previous record.
+	CreateAuditLogArchive(ctx context.Context, arg CreateAuditLogArchiveParams) (AuditLogArchive, error)
+	// CreateAuditLogPartition creates the audit_logs partition for the month containing the given time.
+	// Returns false if the partition table already exists.
+	CreateAuditLogPartition(ctx context.Context, month time.Time) (bool, error)
+	// CreateDataExportJob stores a new export as pending.
+	// Fails with a unique violation if the user already has a pending or running export.
+	CreateDataExportJob(ctx context.Context, arg CreateDataExportJobParams) (DataExportJob, error)
+	// CreateEventReplayJob stores a new replay job as pending.
+	CreateEventReplayJob(ctx context.Context, arg CreateEventReplayJobParams) (EventReplayJob, error)
+	// CreateKYCCase opens a case. Fails with a unique violation if the user has a case in progress.
+	CreateKYCCase(ctx context.Context, arg CreateKYCCaseParams) (KycCase, error)
+	// CreateKYCCaseTransition appends an entry to a case's history.
+	CreateKYCCaseTransition(ctx context.Context, arg CreateKYCCaseTransitionParams) error
+	// CreateKYCDocument stores the metadata of an uploaded document.
+	CreateKYCDocument(ctx context.Context, arg CreateKYCDocumentParams) (KycDocument, error)
+	// CreateLegalHold places a new legal hold.
+	CreateLegalHold(ctx context.Context, arg CreateLegalHoldParams) (LegalHold, error)
 	// CreateRefreshToken stores a new refresh token for a user.
 	// Includes audit information (IP address and user agent).
 	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)
+	// CreateScreeningCase opens a case for a user's watch list matches.
+	CreateScreeningCase(ctx context.Context, arg CreateScreeningCaseParams) (ScreeningCase, error)
 	// CreateUser creates a new user with the provided email, first name, last name, and hashed password.
+	// email_index, pii_key and pii_key_version are NULL when PII encryption is disabled.
 	// Returns the created user record.
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	// CreateUserKYCTierChange appends an entry to a user's tier history.
+	CreateUserKYCTierChange(ctx context.Context, arg CreateUserKYCTierChangeParams) error
+	// CreateWebhookSubscription stores a new enabled subscription.
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
+	// DeferUserErasure records that a user's erasure waits until deferred_until.
+	// Returns no rows if the user has already been erased.
+	DeferUserErasure(ctx context.Context, arg DeferUserErasureParams) (UserErasure, error)
+	// DeleteExpiredAuditLogs removes expired audit logs that are not covered by an active legal hold
+	// or part of an archived month restored for investigation (restored rows keep their old retention).
 	DeleteExpiredAuditLogs(ctx context.Context) error
 	// DeleteExpiredTokens removes expired refresh tokens from the database.
 	// Should be run periodically as a cleanup job.
 	DeleteExpiredTokens(ctx context.Context) error
+	// DeleteFinishedWebhookDeliveries removes succeeded and failed deliveries created before the cutoff.
+	DeleteFinishedWebhookDeliveries(ctx context.Context, before pgtype.Timestamptz) (int64, error)
+	// DeleteKYCDocumentsForErasure removes the document records of a user's KYC cases.
+	DeleteKYCDocumentsForErasure(ctx context.Context, userID uuid.UUID) (int64, error)
+	// DeleteOutboxMessagesForErasure removes a user's events from the outbox, published or not,
+	// since their payloads and metadata hold the user's PII. The user.erased event queued by the
+	// erasure supersedes any that were still pending.
+	DeleteOutboxMessagesForErasure(ctx context.Context, userID uuid.UUID) (int64, error)
+	// DeletePhoneVerification removes a user's pending phone verification.
+	DeletePhoneVerification(ctx context.Context, userID uuid.UUID) error
+	// DeletePublishedOutboxMessages removes messages published before the cutoff.
+	DeletePublishedOutboxMessages(ctx context.Context, before pgtype.Timestamptz) (int64, error)
+	// DeleteRefreshTokensForErasure removes all of a user's sessions, with their IP addresses and user agents.
+	DeleteRefreshTokensForErasure(ctx context.Context, userID uuid.UUID) (int64, error)
+	// DeleteWebhookDeliveriesForErasure removes the webhook deliveries of a user's events, delivered
+	// or not, since their payloads hold the user's PII.
+	DeleteWebhookDeliveriesForErasure(ctx context.Context, userID uuid.UUID) (int64, error)
+	// DeleteWebhookSubscription removes a subscription and its delivery log.
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) (int64, error)
+	// DetachAuditLogPartition detaches the audit_logs partition for the given month, keeping its table.
+	// Returns false if the partition was not attached.
+	DetachAuditLogPartition(ctx context.Context, month time.Time) (bool, error)
+	// DropAuditLogPartition drops the partition table for the given month (detaching it first if needed).
+	// Returns false if the table does not exist.
+	DropAuditLogPartition(ctx context.Context, month time.Time) (bool, error)
+	// EnqueueWebhookDeliveries queues an event for every enabled subscription whose event types
+	// match. Enqueueing the same event again is a no-op.
+	EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error)
+	// EnsureAuditLogPartitions creates every missing monthly audit_logs partition between from_month and to_month.
+	// Returns the number of partitions created.
+	EnsureAuditLogPartitions(ctx context.Context, arg EnsureAuditLogPartitionsParams) (int32, error)
+	// FailDataExportJob moves a running export held by owner to failed and releases the lease.
+	FailDataExportJob(ctx context.Context, arg FailDataExportJobParams) (int64, error)
+	// FinishEventReplayJob moves a running job held by owner to completed or failed and releases the lease.
+	FinishEventReplayJob(ctx context.Context, arg FinishEventReplayJobParams) (int64, error)
+	// GetAlertByID retrieves an alert by ID.
+	GetAlertByID(ctx context.Context, id uuid.UUID) (Alert, error)
 	// GetAllActiveSessions retrieves all active sessions across all users (admin only).
 	GetAllActiveSessions(ctx context.Context, arg GetAllActiveSessionsParams) ([]GetAllActiveSessionsRow, error)
+	// GetAuditLogArchiveByMonth retrieves the archive record for a month.
+	GetAuditLogArchiveByMonth(ctx context.Context, partitionMonth pgtype.Timestamptz) (AuditLogArchive, error)
 	GetAuditLogByID(ctx context.Context, id uuid.UUID) (AuditLog, error)
+	// GetDataExportJob retrieves an export by ID.
+	GetDataExportJob(ctx context.Context, id uuid.UUID) (DataExportJob, error)
+	// GetDataExportJobByDownloadToken retrieves the export a download link was issued for.
+	GetDataExportJobByDownloadToken(ctx context.Context, downloadTokenHash []byte) (DataExportJob, error)
+	// GetEventReplayJob retrieves a replay job by ID.
+	GetEventReplayJob(ctx context.Context, id uuid.UUID) (EventReplayJob, error)
 	GetFailedLoginAttempts(ctx context.Context, userID pgtype.UUID) ([]AuditLog, error)
+	// GetKYCCase retrieves a case by ID.
+	GetKYCCase(ctx context.Context, id uuid.UUID) (KycCase, error)
+	// GetKYCCaseByProviderApplicant retrieves the case an external provider's applicant belongs to.
+	GetKYCCaseByProviderApplicant(ctx context.Context, arg GetKYCCaseByProviderApplicantParams) (KycCase, error)
+	// GetKYCDocument retrieves a document of a case.
+	GetKYCDocument(ctx context.Context, arg GetKYCDocumentParams) (KycDocument, error)
+	// GetLatestKYCCaseForUser retrieves the user's most recently created case.
+	GetLatestKYCCaseForUser(ctx context.Context, userID uuid.UUID) (KycCase, error)
+	// GetLatestStatsDay returns the last day with daily statistics, or NULL if there are none.
+	GetLatestStatsDay(ctx context.Context) (pgtype.Date, error)
+	// GetLegalHoldByID retrieves a legal hold by ID, released or not.
+	GetLegalHoldByID(ctx context.Context, id uuid.UUID) (LegalHold, error)
+	// GetOutboxStats returns the number of pending messages and when the oldest was written.
+	GetOutboxStats(ctx context.Context) (GetOutboxStatsRow, error)
+	// GetPhoneVerification retrieves a user's pending phone verification.
+	GetPhoneVerification(ctx context.Context, userID uuid.UUID) (PhoneVerification, error)
 	GetRecentSecurityEvents(ctx context.Context) ([]AuditLog, error)
 	// GetRefreshToken retrieves a refresh token by its value.
 	// Returns the token regardless of revoked status (caller should check IsRevoked).
 	GetRefreshToken(ctx context.Context, token string) (RefreshToken, error)
+	// GetScreeningCase retrieves a case by ID.
+	GetScreeningCase(ctx context.Context, id uuid.UUID) (ScreeningCase, error)
 	// GetUserActiveTokens retrieves all active (non-expired, non-revoked) tokens for a user.
 	// Useful for session management and "active devices" feature.
 	GetUserActiveTokens(ctx context.Context, userID uuid.UUID) ([]RefreshToken, error)
 	// GetUserByEmail retrieves a user by their email address.
 	// Returns error if user not found or soft-deleted.
 	GetUserByEmail(ctx context.Context, email string) (User, error)
+	// GetUserByEmailIndex retrieves a user by the blind index of their encrypted email.
+	// Returns error if user not found or soft-deleted.
+	GetUserByEmailIndex(ctx context.Context, emailIndex []byte) (User, error)
 	// GetUserByID retrieves a user by their unique ID.
 	// Returns error if user not found or soft-deleted.
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
 	// GetUserByIDIncludeDeleted retrieves a user by ID including soft-deleted users (admin only).
 	GetUserByIDIncludeDeleted(ctx context.Context, id uuid.UUID) (User, error)
+	// GetUserErasure retrieves the erasure record of a user.
+	GetUserErasure(ctx context.Context, userID uuid.UUID) (UserErasure, error)
+	// GetUserKYCTier retrieves the tier a user holds.
+	GetUserKYCTier(ctx context.Context, userID uuid.UUID) (UserKycTier, error)
+	// GetUserPIIKey retrieves the wrapped data key of a user, including soft-deleted users.
+	GetUserPIIKey(ctx context.Context, id uuid.UUID) (GetUserPIIKeyRow, error)
+	// GetWebhookDelivery retrieves a delivery by ID.
+	GetWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)
+	// GetWebhookSubscription retrieves a subscription by ID.
+	GetWebhookSubscription(ctx context.Context, id uuid.UUID) (WebhookSubscription, error)
+	// IncrementPhoneVerificationAttempts records a wrong code and returns the attempts so far.
+	IncrementPhoneVerificationAttempts(ctx context.Context, userID uuid.UUID) (int32, error)
+	// InsertOutboxMessage appends an event to the outbox. Run it in the transaction of the change it describes.
+	InsertOutboxMessage(ctx context.Context, arg InsertOutboxMessageParams) error
+	// ListAlerts lists alerts, newest first, optionally filtered by status.
+	ListAlerts(ctx context.Context, arg ListAlertsParams) ([]Alert, error)
+	// ListAuditLogArchives retrieves archive records, newest month first.
+	ListAuditLogArchives(ctx context.Context, arg ListAuditLogArchivesParams) ([]AuditLogArchive, error)
+	// ListAuditLogPartitions returns every monthly audit_logs partition table, attached or detached.
+	// Detached tables are left behind when an archive run is interrupted before the drop.
+	ListAuditLogPartitions(ctx context.Context) ([]ListAuditLogPartitionsRow, error)
 	ListAuditLogsByCategory(ctx context.Context, arg ListAuditLogsByCategoryParams) ([]AuditLog, error)
 	ListAuditLogsByDateRange(ctx context.Context, arg ListAuditLogsByDateRangeParams) ([]AuditLog, error)
 	ListAuditLogsByEventType(ctx context.Context, arg ListAuditLogsByEventTypeParams) ([]AuditLog, error)
@@ -59,9 +228,142 @@ type Querier interface {
 	ListAuditLogsByResource(ctx context.Context, arg ListAuditLogsByResourceParams) ([]AuditLog, error)
 	ListAuditLogsBySeverity(ctx context.Context, arg ListAuditLogsBySeverityParams) ([]AuditLog, error)
 	ListAuditLogsByUser(ctx context.Context, arg ListAuditLogsByUserParams) ([]AuditLog, error)
+	// ListAuditLogsForDataExport pages through the audit logs about a user in (created_at, id) order:
+	// logs of the user's own actions and logs whose resource is the user.
+	// Pass the created_at and id of the last log of the previous page, or NULL for the first page.
+	ListAuditLogsForDataExport(ctx context.Context, arg ListAuditLogsForDataExportParams) ([]AuditLog, error)
+	// ListAuditLogsForErasure pages through the audit logs about a user in (created_at, id) order:
+	// logs of the user's own actions, logs whose resource is the user and logs whose actor
+	// identifier is one of the user's identifiers (such as their email).
+	// Pass the created_at and id of the last log of the previous page, or NULL for the first page.
+	ListAuditLogsForErasure(ctx context.Context, arg ListAuditLogsForErasureParams) ([]AuditLog, error)
+	// ListAuditLogsForReplay pages through audit logs in (created_at, id) order.
+	// Pass the created_at and id of the last log of the previous page, or NULL for the first page.
+	ListAuditLogsForReplay(ctx context.Context, arg ListAuditLogsForReplayParams) ([]AuditLog, error)
+	// ListDailyStats lists the daily statistics from $1 to $2 inclusive in order.
+	ListDailyStats(ctx context.Context, arg ListDailyStatsParams) ([]StatsDaily, error)
+	// ListDataExportJobsForUser lists a user's exports, newest first.
+	ListDataExportJobsForUser(ctx context.Context, arg ListDataExportJobsForUserParams) ([]DataExportJob, error)
+	// ListDataExportObjectKeysForErasure lists the object keys of a user's export archives.
+	ListDataExportObjectKeysForErasure(ctx context.Context, userID uuid.UUID) ([]string, error)
+	// ListDueOutboxMessages lists pending messages due at now, oldest first. A message is skipped while
+	// an earlier pending message of the same aggregate is still backing off, preserving per-aggregate order.
+	ListDueOutboxMessages(ctx context.Context, arg ListDueOutboxMessagesParams) ([]Outbox, error)
+	// ListEventReplayJobs lists replay jobs, newest first.
+	ListEventReplayJobs(ctx context.Context, arg ListEventReplayJobsParams) ([]EventReplayJob, error)
+	// ListExpiredDataExportJobs lists completed exports whose archive expired, oldest first.
+	ListExpiredDataExportJobs(ctx context.Context, arg ListExpiredDataExportJobsParams) ([]DataExportJob, error)
+	// ListKYCCaseTransitions lists a case's history in order.
+	ListKYCCaseTransitions(ctx context.Context, caseID uuid.UUID) ([]KycCaseTransition, error)
+	// ListKYCCases lists cases matching the optional filters, oldest submission first.
+	ListKYCCases(ctx context.Context, arg ListKYCCasesParams) ([]KycCase, error)
+	// ListKYCCasesForDataExport lists all KYC cases of a user in the order they were opened.
+	ListKYCCasesForDataExport(ctx context.Context, userID uuid.UUID) ([]KycCase, error)
+	// ListKYCDocumentStorageKeysForErasure lists the object keys of the documents of a user's KYC cases.
+	ListKYCDocumentStorageKeysForErasure(ctx context.Context, userID uuid.UUID) ([]string, error)
+	// ListKYCDocuments lists a case's documents in upload order.
+	ListKYCDocuments(ctx context.Context, caseID uuid.UUID) ([]KycDocument, error)
+	// ListKYCFunnel lists non-deleted users by the status of their latest KYC case.
+	ListKYCFunnel(ctx context.Context) ([]StatsKycFunnel, error)
+	// ListLegalHolds lists legal holds, newest first.
+	// When active_only is true, released and expired holds are left out.
+	ListLegalHolds(ctx context.Context, arg ListLegalHoldsParams) ([]LegalHold, error)
+	// ListOutboxForReplay pages through outbox messages in id order, after the given id.
+	ListOutboxForReplay(ctx context.Context, arg ListOutboxForReplayParams) ([]Outbox, error)
+	// ListRefreshTokensForDataExport lists all sessions of a user still stored, including revoked and expired ones.
+	ListRefreshTokensForDataExport(ctx context.Context, userID uuid.UUID) ([]RefreshToken, error)
+	// ListScreeningCases lists cases matching the optional filters, oldest first.
+	ListScreeningCases(ctx context.Context, arg ListScreeningCasesParams) ([]ScreeningCase, error)
+	// ListScreeningCasesForUser lists every case of a user, newest first.
+	ListScreeningCasesForUser(ctx context.Context, userID uuid.UUID) ([]ScreeningCase, error)
+	// ListSessionsByDevice lists active sessions and their users by device.
+	ListSessionsByDevice(ctx context.Context) ([]StatsSessionsByDevice, error)
+	// ListUserKYCTierChanges lists a user's tier history in order.
+	ListUserKYCTierChanges(ctx context.Context, userID uuid.UUID) ([]UserKycTierChange, error)
+	// ListUserStatusChanges lists a user's account status history in order.
+	ListUserStatusChanges(ctx context.Context, userID uuid.UUID) ([]UserStatusChange, error)
 	// ListUsers retrieves paginated list of active users.
 	// Supports filtering and pagination.
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	// ListUsersDueForErasure lists users deleted at or before deleted_before that are neither
+	// erased nor deferred past now, oldest deletion first.
+	ListUsersDueForErasure(ctx context.Context, arg ListUsersDueForErasureParams) ([]ListUsersDueForErasureRow, error)
+	// ListUsersForPIIReencryption lists users, including deleted ones, that still hold plaintext PII
+	// or whose data key is wrapped with a key version older than current_version.
+	// Erased users hold no PII and are skipped.
+	ListUsersForPIIReencryption(ctx context.Context, arg ListUsersForPIIReencryptionParams) ([]User, error)
+	// ListUsersForReplay pages through users, including deleted ones, in (created_at, id) order.
+	// Pass the created_at and id of the last user of the previous page, or NULL for the first page.
+	ListUsersForReplay(ctx context.Context, arg ListUsersForReplayParams) ([]User, error)
+	// ListUsersWithExpiredStatus lists active users whose suspension or freeze expired at or
+	// before $1, earliest first.
+	ListUsersWithExpiredStatus(ctx context.Context, arg ListUsersWithExpiredStatusParams) ([]User, error)
+	// ListWebhookDeliveries lists a subscription's deliveries, newest first, optionally filtered by status.
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// ListWebhookSubscriptions lists subscriptions, newest first.
+	ListWebhookSubscriptions(ctx context.Context, arg ListWebhookSubscriptionsParams) ([]WebhookSubscription, error)
+	// ListWebhookSubscriptionsPendingEncryption lists subscriptions whose secret is plaintext or whose
+	// data key was wrapped with a key version older than current_version.
+	ListWebhookSubscriptionsPendingEncryption(ctx context.Context, currentVersion int32) ([]WebhookSubscription, error)
+	// LockDeletedUserForErasure locks a deleted user's row for the erasure transaction.
+	// Returns no rows if the user does not exist or is not deleted.
+	LockDeletedUserForErasure(ctx context.Context, id uuid.UUID) (pgtype.Timestamptz, error)
+	// MarkAuditLogArchiveRestored flags an archived month as restored into audit_logs.
+	MarkAuditLogArchiveRestored(ctx context.Context, arg MarkAuditLogArchiveRestoredParams) (AuditLogArchive, error)
+	// MarkDataExportJobExpired moves a completed export to expired once its archive was deleted
+	// and invalidates its download link.
+	MarkDataExportJobExpired(ctx context.Context, id uuid.UUID) (int64, error)
+	// MarkOutboxMessageFailed records a failed publish attempt and when to retry.
+	MarkOutboxMessageFailed(ctx context.Context, arg MarkOutboxMessageFailedParams) error
+	// MarkOutboxMessagePublished records that the event bus accepted a message.
+	MarkOutboxMessagePublished(ctx context.Context, arg MarkOutboxMessagePublishedParams) error
+	// MarkUserErased records a completed erasure.
+	// Returns no rows if the user has already been erased.
+	MarkUserErased(ctx context.Context, arg MarkUserErasedParams) (UserErasure, error)
+	// MarkWebhookDeliveryFailed records a failed attempt. The delivery stays pending until
+	// next_attempt_at, or moves to failed when it is out of attempts.
+	MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error
+	// MarkWebhookDeliverySucceeded records a delivery the endpoint accepted.
+	MarkWebhookDeliverySucceeded(ctx context.Context, arg MarkWebhookDeliverySucceededParams) error
+	// PruneUserActivity deletes the activity of days before $1.
+	PruneUserActivity(ctx context.Context, before pgtype.Date) (int64, error)
+	// PseudonymiseUser replaces a deleted user's PII with their pseudonym and destroys their
+	// data key, so ciphertexts of the row left in backups can no longer be decrypted.
+	PseudonymiseUser(ctx context.Context, arg PseudonymiseUserParams) (int64, error)
+	// RecordDataExportDownload counts a download of an export's archive.
+	RecordDataExportDownload(ctx context.Context, arg RecordDataExportDownloadParams) error
+	// RecordUserActivity marks the users who were issued a refresh token at or after $1 as
+	// active on the UTC day it was issued.
+	RecordUserActivity(ctx context.Context, since pgtype.Timestamptz) (int64, error)
+	// RecordWebhookSubscriptionFailure counts a failed attempt against an enabled subscription and
+	// disables it once disable_after consecutive attempts have failed.
+	RecordWebhookSubscriptionFailure(ctx context.Context, arg RecordWebhookSubscriptionFailureParams) (WebhookSubscription, error)
+	// RedeliverWebhookDelivery queues a finished delivery again with a fresh set of attempts.
+	RedeliverWebhookDelivery(ctx context.Context, arg RedeliverWebhookDeliveryParams) (WebhookDelivery, error)
+	// RefreshDailyStats recomputes the daily statistics of each UTC day from $1 to $2 inclusive.
+	// Activity for the days must be recorded first.
+	RefreshDailyStats(ctx context.Context, arg RefreshDailyStatsParams) (int64, error)
+	// RefreshKYCFunnel recomputes the KYC funnel without blocking readers.
+	RefreshKYCFunnel(ctx context.Context) error
+	// RefreshSessionsByDevice recomputes active sessions by device without blocking readers.
+	RefreshSessionsByDevice(ctx context.Context) error
+	// ReleaseLegalHold lifts an unreleased hold. The row is kept as a record of the hold.
+	ReleaseLegalHold(ctx context.Context, arg ReleaseLegalHoldParams) (LegalHold, error)
+	// ReplaceAuditLogArchiveObject points the archive of a month at a rewritten archive object.
+	// The restored flag is kept. Returns no rows if the archive no longer has previous_object_key,
+	// so a concurrent rewrite is never overwritten.
+	ReplaceAuditLogArchiveObject(ctx context.Context, arg ReplaceAuditLogArchiveObjectParams) (AuditLogArchive, error)
+	// ResetWebhookSubscriptionFailures clears the failure count after a successful delivery.
+	ResetWebhookSubscriptionFailures(ctx context.Context, id uuid.UUID) error
+	// RestampAuditLogRetention recomputes retention_until from created_at using a retention policy
+	// passed as parallel rule arrays; for each log the matching rule with the highest priority wins.
+	// Empty strings in the selector arrays match everything. Logs kept indefinitely (NULL) are skipped.
+	RestampAuditLogRetention(ctx context.Context, arg RestampAuditLogRetentionParams) (int64, error)
+	// RestoreUser undeletes a user deleted at deleted_at and drops a deferred erasure of them.
+	// Returns no rows if the user is not deleted at deleted_at or has been erased.
+	RestoreUser(ctx context.Context, arg RestoreUserParams) (User, error)
+	// ResumeEventReplayJob makes a failed or cancelled job pending again, continuing from its cursor.
+	ResumeEventReplayJob(ctx context.Context, id uuid.UUID) (EventReplayJob, error)
 	// RevokeAllUserTokens revokes all active refresh tokens for a user.
 	// Used when user logs out from all devices or password changes.
 	RevokeAllUserTokens(ctx context.Context, userID uuid.UUID) error
@@ -70,19 +372,87 @@ type Querier interface {
 	RevokeRefreshToken(ctx context.Context, token string) (int64, error)
 	// RevokeTokenByID revokes a specific refresh token by token value (admin only).
 	RevokeTokenByID(ctx context.Context, token string) (int64, error)
+	// SaveEventReplayJobProgress records the cursor and counters and extends the lease. No row is
+	// updated when the job was cancelled or its lease passed to another owner.
+	SaveEventReplayJobProgress(ctx context.Context, arg SaveEventReplayJobProgressParams) (int64, error)
+	// SavePhoneVerification stores a user's pending phone verification, replacing any earlier one.
+	SavePhoneVerification(ctx context.Context, arg SavePhoneVerificationParams) (PhoneVerification, error)
 	SearchAuditLogs(ctx context.Context, arg SearchAuditLogsParams) ([]AuditLog, error)
-	// SearchUsers searches users by email, first name, or last name.
-	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error)
+	// SearchUsersByRelevance searches users for admins, best match first.
+	// Takes the same query and filters as SearchUsersNewest, but the query is required. Rank adds
+	// full-text rank and trigram word similarity, plus 1 for an exact ID or email match.
+	// Pass the rank and id of the last user of the previous page, or NULL for the first page.
+	SearchUsersByRelevance(ctx context.Context, arg SearchUsersByRelevanceParams) ([]SearchUsersByRelevanceRow, error)
+	// SearchUsersNewest searches users for admins, newest first.
+	// The query matches the plaintext email and names by substring (query_pattern, a lowercased
+	// LIKE pattern) or full-text search, the exact email of encrypted users through its blind
+	// index, and the user ID; pass an empty query to list all users. Pass NULL to skip a filter.
+	// deleted is exclude, only or include. Pass the created_at and id of the last user of the
+	// previous page, or NULL for the first page.
+	SearchUsersNewest(ctx context.Context, arg SearchUsersNewestParams) ([]User, error)
+	// SearchUsersOldest searches users for admins, oldest first.
+	// The query matches the plaintext email and names by substring (query_pattern, a lowercased
+	// LIKE pattern) or full-text search, the exact email of encrypted users through its blind
+	// index, and the user ID; pass an empty query to list all users. Pass NULL to skip a filter.
+	// deleted is exclude, only or include. Pass the created_at and id of the last user of the
+	// previous page, or NULL for the first page.
+	SearchUsersOldest(ctx context.Context, arg SearchUsersOldestParams) ([]User, error)
+	// SetDataExportDownloadToken replaces the download link of a completed export.
+	SetDataExportDownloadToken(ctx context.Context, arg SetDataExportDownloadTokenParams) (DataExportJob, error)
+	// SetEventReplayJobTotal records how many source records the job covers.
+	SetEventReplayJobTotal(ctx context.Context, arg SetEventReplayJobTotalParams) (int64, error)
+	// SetUserPIIKey stores a wrapped data key for a user that has none yet.
+	// Returns no rows if the user does not exist or already has a key.
+	SetUserPIIKey(ctx context.Context, arg SetUserPIIKeyParams) (SetUserPIIKeyRow, error)
+	// SetUserStatus sets a user's account status and appends the change to their status history.
+	// Returns no rows if the user doesn't exist or is soft-deleted.
+	SetUserStatus(ctx context.Context, arg SetUserStatusParams) (User, error)
+	// SetWebhookSubscriptionSecret stores an encrypted secret or rewrapped data key. Nothing is
+	// updated if the secret changed since previous_secret was read.
+	SetWebhookSubscriptionSecret(ctx context.Context, arg SetWebhookSubscriptionSecretParams) (int64, error)
 	// SoftDeleteUser marks a user as deleted without removing the record.
 	// Sets deleted_at timestamp to current time.
 	SoftDeleteUser(ctx context.Context, id uuid.UUID) (int64, error)
+	// SummarizeExpiredAuditLogs groups audit logs past retention at @as_of by category and severity,
+	// splitting rows the cleanup job would delete from rows kept back by an active legal hold or a
+	// restored archive month. Uses the same predicates as DeleteExpiredAuditLogs.
+	SummarizeExpiredAuditLogs(ctx context.Context, asOf time.Time) ([]SummarizeExpiredAuditLogsRow, error)
+	// UpdateAuditLogPII replaces the columns of an audit log that can hold PII.
+	// Every other column, including the event type, category, user and timestamps, is kept.
+	UpdateAuditLogPII(ctx context.Context, arg UpdateAuditLogPIIParams) (int64, error)
+	// UpdateKYCCase stores a case if its version is still the one it was loaded with and
+	// increments the version. Returns no rows when the case was changed concurrently.
+	UpdateKYCCase(ctx context.Context, arg UpdateKYCCaseParams) (KycCase, error)
+	// UpdateLegalHold changes the reason, owner and expiry of an unreleased hold.
+	// The scope of a hold is immutable; place a new hold instead.
+	UpdateLegalHold(ctx context.Context, arg UpdateLegalHoldParams) (LegalHold, error)
+	// UpdateScreeningCase stores a case if its version is still the one it was loaded with and
+	// increments the version. Returns no rows when the case was changed concurrently.
+	UpdateScreeningCase(ctx context.Context, arg UpdateScreeningCaseParams) (ScreeningCase, error)
 	// UpdateUserKYCStatus updates the KYC verification status for a user.
 	// Valid statuses: pending, verified, rejected
 	UpdateUserKYCStatus(ctx context.Context, arg UpdateUserKYCStatusParams) (User, error)
-	// UpdateUserProfile updates user's profile information (first_name and last_name).
+	// UpdateUserPII rewrites a user's PII columns and data key.
+	// Only applies if the row was not updated since it was read (updated_at is unchanged).
+	UpdateUserPII(ctx context.Context, arg UpdateUserPIIParams) (int64, error)
+	// UpdateUserProfile replaces the user's profile fields; NULL clears an optional field.
 	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error)
 	// UpdateUserRole updates a user's role (admin only operation).
 	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	// UpdateWebhookSubscription replaces a subscription's settings. Re-enabling a subscription
+	// clears its failure count; disabling it records disabled_reason. A NULL secret keeps the current
+	// one along with its data key.
+	UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error)
+	// UpsertOpenAlert records a fired alert. If an open alert with the same dedup key exists,
+	// its counts, last seen time and evidence are updated instead of inserting a new row.
+	UpsertOpenAlert(ctx context.Context, arg UpsertOpenAlertParams) (Alert, error)
+	// UpsertUserKYCTier stores the tier a user holds, replacing any earlier assignment.
+	UpsertUserKYCTier(ctx context.Context, arg UpsertUserKYCTierParams) (UserKycTier, error)
+	// UserEmailExists reports whether an active user holds the plaintext email.
+	UserEmailExists(ctx context.Context, email string) (bool, error)
+	// UserHasRetainedKYCRecords reports whether a user has submitted a KYC case or been screened;
+	// these records are kept for the AML retention period.
+	UserHasRetainedKYCRecords(ctx context.Context, userID uuid.UUID) (bool, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,50 @@
+-- name: UpsertOpenAlert :one
+-- UpsertOpenAlert records a fired alert. If an open alert with the same dedup key exists,
+-- its counts, last seen time and evidence are updated instead of inserting a new row.
+INSERT INTO alerts (
+    rule_name,
+    severity,
+    summary,
+    group_by,
+    group_value,
+    dedup_key,
+    event_count,
+    first_seen_at,
+    last_seen_at,
+    events
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+ON CONFLICT (dedup_key) WHERE status = 'open' DO UPDATE
+SET severity = EXCLUDED.severity,
+    summary = EXCLUDED.summary,
+    event_count = alerts.event_count + EXCLUDED.event_count,
+    fire_count = alerts.fire_count + 1,
+    last_seen_at = GREATEST(alerts.last_seen_at, EXCLUDED.last_seen_at),
+    events = EXCLUDED.events,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetAlertByID :one
+-- GetAlertByID retrieves an alert by ID.
+SELECT * FROM alerts
+WHERE id = $1;
+
+-- name: ListAlerts :many
+-- ListAlerts lists alerts, newest first, optionally filtered by status.
+SELECT * FROM alerts
+WHERE (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: AcknowledgeAlert :one
+-- AcknowledgeAlert moves an open alert to acknowledged.
+UPDATE alerts
+SET status = 'acknowledged',
+    acknowledged_at = NOW(),
+    acknowledged_by = $2,
+    ack_note = $3,
+    updated_at = NOW()
+WHERE id = $1
+  AND status = 'open'
+RETURNING *;
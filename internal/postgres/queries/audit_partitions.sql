@@ -0,0 +1,100 @@
+-- name: EnsureAuditLogPartitions :one
+-- EnsureAuditLogPartitions creates every missing monthly audit_logs partition between from_month and to_month.
+-- Returns the number of partitions created.
+SELECT audit_logs_ensure_partitions(sqlc.arg(from_month)::timestamptz, sqlc.arg(to_month)::timestamptz)::int AS created;
+
+-- name: CreateAuditLogPartition :one
+-- CreateAuditLogPartition creates the audit_logs partition for the month containing the given time.
+-- Returns false if the partition table already exists.
+SELECT audit_logs_create_partition(sqlc.arg(month)::timestamptz)::boolean AS created;
+
+-- name: DetachAuditLogPartition :one
+-- DetachAuditLogPartition detaches the audit_logs partition for the given month, keeping its table.
+-- Returns false if the partition was not attached.
+SELECT audit_logs_detach_partition(sqlc.arg(month)::timestamptz)::boolean AS detached;
+
+-- name: DropAuditLogPartition :one
+-- DropAuditLogPartition drops the partition table for the given month (detaching it first if needed).
+-- Returns false if the table does not exist.
+SELECT audit_logs_drop_partition(sqlc.arg(month)::timestamptz)::boolean AS dropped;
+
+-- name: ListAuditLogPartitions :many
+-- ListAuditLogPartitions returns every monthly audit_logs partition table, attached or detached.
+-- Detached tables are left behind when an archive run is interrupted before the drop.
+SELECT
+    c.relname::text AS partition_name,
+    c.relispartition AS is_attached,
+    c.reltuples::bigint AS row_estimate
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE n.nspname = current_schema()
+  AND c.relkind = 'r'
+  AND c.relname ~ '^audit_logs_y[0-9]{4}m[0-9]{2}$'
+ORDER BY c.relname;
+
+-- name: CreateAuditLogArchive :one
+-- CreateAuditLogArchive records an archived partition.
+-- Re-archiving a month replaces the previous record.
+INSERT INTO audit_log_archives (
+    partition_month,
+    partition_name,
+    object_key,
+    manifest_key,
+    row_count,
+    size_bytes,
+    checksum_sha256
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (partition_month) DO UPDATE SET
+    partition_name = EXCLUDED.partition_name,
+    object_key = EXCLUDED.object_key,
+    manifest_key = EXCLUDED.manifest_key,
+    row_count = EXCLUDED.row_count,
+    size_bytes = EXCLUDED.size_bytes,
+    checksum_sha256 = EXCLUDED.checksum_sha256,
+    archived_at = NOW(),
+    restored_at = NULL,
+    restored_by = NULL
+RETURNING *;
+
+-- name: ReplaceAuditLogArchiveObject :one
+-- ReplaceAuditLogArchiveObject points the archive of a month at a rewritten archive object.
+-- The restored flag is kept. Returns no rows if the archive no longer has previous_object_key,
+-- so a concurrent rewrite is never overwritten.
+UPDATE audit_log_archives
+SET object_key = sqlc.arg(object_key),
+    manifest_key = sqlc.arg(manifest_key),
+    row_count = sqlc.arg(row_count),
+    size_bytes = sqlc.arg(size_bytes),
+    checksum_sha256 = sqlc.arg(checksum_sha256)
+WHERE partition_month = sqlc.arg(partition_month)
+  AND object_key = sqlc.arg(previous_object_key)
+RETURNING *;
+
+-- name: GetAuditLogArchiveByMonth :one
+-- GetAuditLogArchiveByMonth retrieves the archive record for a month.
+SELECT * FROM audit_log_archives
+WHERE partition_month = $1;
+
+-- name: ListAuditLogArchives :many
+-- ListAuditLogArchives retrieves archive records, newest month first.
+SELECT * FROM audit_log_archives
+ORDER BY partition_month DESC
+LIMIT $1 OFFSET $2;
+
+-- name: MarkAuditLogArchiveRestored :one
+-- MarkAuditLogArchiveRestored flags an archived month as restored into audit_logs.
+UPDATE audit_log_archives
+SET restored_at = NOW(),
+    restored_by = $2
+WHERE partition_month = $1
+RETURNING *;
+
+-- name: ClearAuditLogArchiveRestored :one
+-- ClearAuditLogArchiveRestored clears the restored flag once the restored month has been released.
+UPDATE audit_log_archives
+SET restored_at = NULL,
+    restored_by = NULL
+WHERE partition_month = $1
+RETURNING *;
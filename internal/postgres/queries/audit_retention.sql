@@ -0,0 +1,69 @@
+-- name: RestampAuditLogRetention :execrows
+-- RestampAuditLogRetention recomputes retention_until from created_at using a retention policy
+-- passed as parallel rule arrays; for each log the matching rule with the highest priority wins.
+-- Empty strings in the selector arrays match everything. Logs kept indefinitely (NULL) are skipped.
+WITH rules AS (
+    SELECT *
+    FROM unnest(
+        @event_types::text[],
+        @categories::text[],
+        @severities::text[],
+        @days::int[],
+        @priorities::int[]
+    ) AS r(event_type, category, severity, days, priority)
+),
+matched AS (
+    SELECT DISTINCT ON (l.id, l.created_at)
+        l.id,
+        l.created_at,
+        l.created_at + r.days * INTERVAL '24 hours' AS retention_until
+    FROM audit_logs l
+    JOIN rules r
+      ON (r.event_type = '' OR r.event_type = l.event_type)
+     AND (r.category = '' OR r.category = l.event_category)
+     AND (r.severity = '' OR r.severity = l.severity)
+    WHERE l.retention_until IS NOT NULL
+    ORDER BY l.id, l.created_at, r.priority DESC
+)
+UPDATE audit_logs
+SET retention_until = matched.retention_until
+FROM matched
+WHERE audit_logs.id = matched.id
+  AND audit_logs.created_at = matched.created_at
+  AND audit_logs.retention_until IS DISTINCT FROM matched.retention_until;
+
+-- name: SummarizeExpiredAuditLogs :many
+-- SummarizeExpiredAuditLogs groups audit logs past retention at @as_of by category and severity,
+-- splitting rows the cleanup job would delete from rows kept back by an active legal hold or a
+-- restored archive month. Uses the same predicates as DeleteExpiredAuditLogs.
+SELECT
+    e.event_category,
+    e.severity,
+    COUNT(*) FILTER (WHERE NOT e.held)::bigint AS deletable_count,
+    COUNT(*) FILTER (WHERE e.held)::bigint AS held_count,
+    MIN(e.created_at)::timestamptz AS oldest_created_at
+FROM (
+    SELECT
+        l.event_category,
+        l.severity,
+        l.created_at,
+        EXISTS (
+            SELECT 1 FROM legal_holds h
+            WHERE h.released_at IS NULL
+              AND h.expires_at > @as_of::timestamptz
+              AND (h.user_id IS NULL OR h.user_id = l.user_id)
+              AND (h.event_category IS NULL OR h.event_category = l.event_category)
+              AND (h.starts_at IS NULL OR l.created_at >= h.starts_at)
+              AND (h.ends_at IS NULL OR l.created_at < h.ends_at)
+        ) OR EXISTS (
+            SELECT 1 FROM audit_log_archives a
+            WHERE a.restored_at IS NOT NULL
+              AND l.created_at >= a.partition_month
+              AND l.created_at < a.partition_month + INTERVAL '1 month'
+        ) AS held
+    FROM audit_logs l
+    WHERE l.retention_until IS NOT NULL
+      AND l.retention_until < @as_of::timestamptz
+) e
+GROUP BY e.event_category, e.severity
+ORDER BY e.event_category, e.severity;
@@ -0,0 +1,137 @@
+-- name: CreateDataExportJob :one
+-- CreateDataExportJob stores a new export as pending.
+-- Fails with a unique violation if the user already has a pending or running export.
+INSERT INTO data_export_jobs (
+    user_id,
+    requested_by,
+    requester_type
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetDataExportJob :one
+-- GetDataExportJob retrieves an export by ID.
+SELECT * FROM data_export_jobs
+WHERE id = $1;
+
+-- name: ListDataExportJobsForUser :many
+-- ListDataExportJobsForUser lists a user's exports, newest first.
+SELECT * FROM data_export_jobs
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: ClaimDataExportJob :one
+-- ClaimDataExportJob leases the oldest pending export, or running export whose lease expired, to owner.
+UPDATE data_export_jobs
+SET status = 'running',
+    lease_owner = sqlc.arg(owner),
+    lease_expires_at = sqlc.arg(lease_until)::timestamptz,
+    started_at = COALESCE(started_at, sqlc.arg(now)::timestamptz),
+    updated_at = sqlc.arg(now)::timestamptz
+WHERE id = (
+    SELECT j.id FROM data_export_jobs j
+    WHERE j.status = 'pending'
+       OR (j.status = 'running' AND j.lease_expires_at < sqlc.arg(now)::timestamptz)
+    ORDER BY j.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteDataExportJob :execrows
+-- CompleteDataExportJob records the archive of a running export held by owner and releases the lease.
+UPDATE data_export_jobs
+SET status = 'completed',
+    object_key = sqlc.arg(object_key),
+    size_bytes = sqlc.arg(size_bytes),
+    sha256 = sqlc.arg(sha256),
+    expires_at = sqlc.arg(expires_at)::timestamptz,
+    last_error = NULL,
+    completed_at = sqlc.arg(completed_at)::timestamptz,
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'running'
+  AND lease_owner = sqlc.arg(owner);
+
+-- name: FailDataExportJob :execrows
+-- FailDataExportJob moves a running export held by owner to failed and releases the lease.
+UPDATE data_export_jobs
+SET status = 'failed',
+    last_error = sqlc.arg(last_error),
+    completed_at = sqlc.arg(completed_at)::timestamptz,
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'running'
+  AND lease_owner = sqlc.arg(owner);
+
+-- name: SetDataExportDownloadToken :one
+-- SetDataExportDownloadToken replaces the download link of a completed export.
+UPDATE data_export_jobs
+SET download_token_hash = sqlc.arg(download_token_hash),
+    download_expires_at = sqlc.arg(download_expires_at)::timestamptz,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'completed'
+RETURNING *;
+
+-- name: GetDataExportJobByDownloadToken :one
+-- GetDataExportJobByDownloadToken retrieves the export a download link was issued for.
+SELECT * FROM data_export_jobs
+WHERE download_token_hash = $1;
+
+-- name: RecordDataExportDownload :exec
+-- RecordDataExportDownload counts a download of an export's archive.
+UPDATE data_export_jobs
+SET download_count = download_count + 1,
+    last_downloaded_at = sqlc.arg(downloaded_at)::timestamptz,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id);
+
+-- name: ListExpiredDataExportJobs :many
+-- ListExpiredDataExportJobs lists completed exports whose archive expired, oldest first.
+SELECT * FROM data_export_jobs
+WHERE status = 'completed'
+  AND expires_at < sqlc.arg(now)::timestamptz
+ORDER BY expires_at
+LIMIT sqlc.arg(limit_count);
+
+-- name: MarkDataExportJobExpired :execrows
+-- MarkDataExportJobExpired moves a completed export to expired once its archive was deleted
+-- and invalidates its download link.
+UPDATE data_export_jobs
+SET status = 'expired',
+    download_token_hash = NULL,
+    download_expires_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+  AND status = 'completed';
+
+-- name: ListRefreshTokensForDataExport :many
+-- ListRefreshTokensForDataExport lists all sessions of a user still stored, including revoked and expired ones.
+SELECT * FROM refresh_tokens
+WHERE user_id = $1
+ORDER BY created_at ASC;
+
+-- name: ListKYCCasesForDataExport :many
+-- ListKYCCasesForDataExport lists all KYC cases of a user in the order they were opened.
+SELECT * FROM kyc_cases
+WHERE user_id = $1
+ORDER BY created_at ASC;
+
+-- name: ListAuditLogsForDataExport :many
+-- ListAuditLogsForDataExport pages through the audit logs about a user in (created_at, id) order:
+-- logs of the user's own actions and logs whose resource is the user.
+-- Pass the created_at and id of the last log of the previous page, or NULL for the first page.
+SELECT * FROM audit_logs
+WHERE (user_id = sqlc.arg(user_id)::uuid
+       OR (resource_type = 'user' AND resource_id = sqlc.arg(user_id)::uuid::text))
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+       OR (created_at, id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid))
+ORDER BY created_at, id
+LIMIT sqlc.arg(limit_count);
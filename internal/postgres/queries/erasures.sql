@@ -0,0 +1,207 @@
+-- name: ListUsersDueForErasure :many
+-- ListUsersDueForErasure lists users deleted at or before deleted_before that are neither
+-- erased nor deferred past now, oldest deletion first.
+SELECT u.id, u.deleted_at FROM users u
+LEFT JOIN user_erasures e ON e.user_id = u.id
+WHERE u.deleted_at IS NOT NULL
+  AND u.deleted_at <= sqlc.arg(deleted_before)::timestamptz
+  AND (e.user_id IS NULL OR (e.status = 'deferred' AND e.deferred_until <= sqlc.arg(now)::timestamptz))
+ORDER BY u.deleted_at
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetUserErasure :one
+-- GetUserErasure retrieves the erasure record of a user.
+SELECT * FROM user_erasures
+WHERE user_id = $1;
+
+-- name: DeferUserErasure :one
+-- DeferUserErasure records that a user's erasure waits until deferred_until.
+-- Returns no rows if the user has already been erased.
+INSERT INTO user_erasures (user_id, status, deferral_reason, deferred_until)
+VALUES (sqlc.arg(user_id), 'deferred', sqlc.arg(deferral_reason), sqlc.arg(deferred_until))
+ON CONFLICT (user_id) DO UPDATE
+SET deferral_reason = EXCLUDED.deferral_reason,
+    deferred_until = EXCLUDED.deferred_until,
+    updated_at = NOW()
+WHERE user_erasures.status = 'deferred'
+RETURNING *;
+
+-- name: MarkUserErased :one
+-- MarkUserErased records a completed erasure.
+-- Returns no rows if the user has already been erased.
+INSERT INTO user_erasures (user_id, status, pseudonym, audit_logs_rewritten, erased_at)
+VALUES (sqlc.arg(user_id), 'erased', sqlc.arg(pseudonym), sqlc.arg(audit_logs_rewritten), sqlc.arg(erased_at))
+ON CONFLICT (user_id) DO UPDATE
+SET status = 'erased',
+    deferral_reason = NULL,
+    deferred_until = NULL,
+    pseudonym = EXCLUDED.pseudonym,
+    audit_logs_rewritten = EXCLUDED.audit_logs_rewritten,
+    erased_at = EXCLUDED.erased_at,
+    updated_at = NOW()
+WHERE user_erasures.status = 'deferred'
+RETURNING *;
+
+-- name: LockDeletedUserForErasure :one
+-- LockDeletedUserForErasure locks a deleted user's row for the erasure transaction.
+-- Returns no rows if the user does not exist or is not deleted.
+SELECT deleted_at FROM users
+WHERE id = $1 AND deleted_at IS NOT NULL
+FOR UPDATE;
+
+-- name: UserHasRetainedKYCRecords :one
+-- UserHasRetainedKYCRecords reports whether a user has submitted a KYC case or been screened;
+-- these records are kept for the AML retention period.
+SELECT EXISTS (SELECT 1 FROM kyc_cases WHERE user_id = $1 AND status <> 'draft')
+    OR EXISTS (SELECT 1 FROM screening_cases WHERE user_id = $1);
+
+-- name: CountLegalHoldsForErasure :one
+-- CountLegalHoldsForErasure counts the active legal holds covering an audit log
+-- ListAuditLogsForErasure would return, matched as DeleteExpiredAuditLogs matches them.
+-- When archived_since is set, holds on every user or on this user whose date range overlaps
+-- a month archived from archived_since on count as well.
+SELECT COUNT(*) FROM legal_holds h
+WHERE h.released_at IS NULL
+  AND h.expires_at > NOW()
+  AND (
+      EXISTS (
+          SELECT 1 FROM audit_logs l
+          WHERE (l.user_id = sqlc.arg(user_id)::uuid
+                 OR (l.resource_type = 'user' AND l.resource_id = sqlc.arg(user_id)::uuid::text)
+                 OR l.actor_identifier = ANY(sqlc.arg(identifiers)::text[]))
+            AND (h.user_id IS NULL OR h.user_id = l.user_id)
+            AND (h.event_category IS NULL OR h.event_category = l.event_category)
+            AND (h.starts_at IS NULL OR l.created_at >= h.starts_at)
+            AND (h.ends_at IS NULL OR l.created_at < h.ends_at)
+      )
+      OR EXISTS (
+          SELECT 1 FROM audit_log_archives a
+          WHERE sqlc.narg(archived_since)::timestamptz IS NOT NULL
+            AND a.partition_month + INTERVAL '1 month' > sqlc.narg(archived_since)::timestamptz
+            AND (h.user_id IS NULL OR h.user_id = sqlc.arg(user_id)::uuid)
+            AND (h.starts_at IS NULL OR h.starts_at < a.partition_month + INTERVAL '1 month')
+            AND (h.ends_at IS NULL OR h.ends_at > a.partition_month)
+      )
+  );
+
+-- name: ListAuditLogsForErasure :many
+-- ListAuditLogsForErasure pages through the audit logs about a user in (created_at, id) order:
+-- logs of the user's own actions, logs whose resource is the user and logs whose actor
+-- identifier is one of the user's identifiers (such as their email).
+-- Pass the created_at and id of the last log of the previous page, or NULL for the first page.
+SELECT * FROM audit_logs
+WHERE (user_id = sqlc.arg(user_id)::uuid
+       OR (resource_type = 'user' AND resource_id = sqlc.arg(user_id)::uuid::text)
+       OR actor_identifier = ANY(sqlc.arg(identifiers)::text[]))
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+       OR (created_at, id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid))
+ORDER BY created_at, id
+LIMIT sqlc.arg(limit_count);
+
+-- name: UpdateAuditLogPII :execrows
+-- UpdateAuditLogPII replaces the columns of an audit log that can hold PII.
+-- Every other column, including the event type, category, user and timestamps, is kept.
+UPDATE audit_logs
+SET actor_identifier = sqlc.narg(actor_identifier),
+    ip_address = sqlc.narg(ip_address),
+    metadata = sqlc.narg(metadata),
+    previous_state = sqlc.narg(previous_state),
+    new_state = sqlc.narg(new_state),
+    failure_reason = sqlc.narg(failure_reason)
+WHERE id = sqlc.arg(id) AND created_at = sqlc.arg(created_at);
+
+-- name: PseudonymiseUser :execrows
+-- PseudonymiseUser replaces a deleted user's PII with their pseudonym and destroys their
+-- data key, so ciphertexts of the row left in backups can no longer be decrypted.
+UPDATE users
+SET email = sqlc.arg(email),
+    email_index = NULL,
+    first_name = '',
+    last_name = '',
+    hashed_password = '',
+    phone = NULL,
+    phone_verified_at = NULL,
+    date_of_birth = NULL,
+    address_line1 = NULL,
+    address_line2 = NULL,
+    address_city = NULL,
+    address_postal_code = NULL,
+    address_region = NULL,
+    address_country = NULL,
+    nationality = NULL,
+    tax_residency = NULL,
+    pii_key = NULL,
+    pii_key_version = NULL
+WHERE id = sqlc.arg(id) AND deleted_at IS NOT NULL;
+
+-- name: DeleteRefreshTokensForErasure :execrows
+-- DeleteRefreshTokensForErasure removes all of a user's sessions, with their IP addresses and user agents.
+DELETE FROM refresh_tokens
+WHERE user_id = $1;
+
+-- name: DeleteOutboxMessagesForErasure :execrows
+-- DeleteOutboxMessagesForErasure removes a user's events from the outbox, published or not,
+-- since their payloads and metadata hold the user's PII. The user.erased event queued by the
+-- erasure supersedes any that were still pending.
+DELETE FROM outbox
+WHERE aggregate_type = 'user' AND aggregate_id = $1::uuid::text;
+
+-- name: DeleteWebhookDeliveriesForErasure :execrows
+-- DeleteWebhookDeliveriesForErasure removes the webhook deliveries of a user's events, delivered
+-- or not, since their payloads hold the user's PII.
+DELETE FROM webhook_deliveries
+WHERE payload->>'subject' = $1::uuid::text;
+
+-- name: ListDataExportObjectKeysForErasure :many
+-- ListDataExportObjectKeysForErasure lists the object keys of a user's export archives.
+SELECT object_key::text FROM data_export_jobs
+WHERE user_id = $1 AND object_key IS NOT NULL AND status = 'completed';
+
+-- name: CloseDataExportJobsForErasure :execrows
+-- CloseDataExportJobsForErasure expires a user's completed exports, whose archives were
+-- deleted, and fails those still pending or running.
+UPDATE data_export_jobs
+SET status = CASE WHEN status = 'completed' THEN 'expired' ELSE 'failed' END,
+    last_error = CASE WHEN status = 'completed' THEN last_error ELSE 'user erased' END,
+    download_token_hash = NULL,
+    download_expires_at = NULL,
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    completed_at = COALESCE(completed_at, NOW()),
+    updated_at = NOW()
+WHERE user_id = $1 AND status IN ('pending', 'running', 'completed');
+
+-- name: ListKYCDocumentStorageKeysForErasure :many
+-- ListKYCDocumentStorageKeysForErasure lists the object keys of the documents of a user's KYC cases.
+SELECT d.storage_key FROM kyc_documents d
+JOIN kyc_cases c ON c.id = d.case_id
+WHERE c.user_id = $1;
+
+-- name: DeleteKYCDocumentsForErasure :execrows
+-- DeleteKYCDocumentsForErasure removes the document records of a user's KYC cases.
+DELETE FROM kyc_documents
+WHERE case_id IN (SELECT id FROM kyc_cases WHERE user_id = $1);
+
+-- name: ClearKYCCasesForErasure :execrows
+-- ClearKYCCasesForErasure clears the applicant data and review notes of a user's KYC cases.
+-- Statuses, decisions and timestamps are kept.
+UPDATE kyc_cases
+SET applicant = '{}'::jsonb,
+    review_note = NULL,
+    updated_at = NOW()
+WHERE user_id = $1;
+
+-- name: ClearKYCTransitionNotesForErasure :execrows
+-- ClearKYCTransitionNotesForErasure clears the notes of the transitions of a user's KYC cases.
+UPDATE kyc_case_transitions
+SET note = NULL
+WHERE case_id IN (SELECT id FROM kyc_cases WHERE user_id = $1);
+
+-- name: ClearScreeningCasesForErasure :execrows
+-- ClearScreeningCasesForErasure clears the screened names and review notes of a user's
+-- screening cases. The matched list entries and decisions are kept.
+UPDATE screening_cases
+SET subject = '{}'::jsonb,
+    review_note = NULL,
+    updated_at = NOW()
+WHERE user_id = $1;
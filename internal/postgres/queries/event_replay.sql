@@ -0,0 +1,155 @@
+-- name: CreateEventReplayJob :one
+-- CreateEventReplayJob stores a new replay job as pending.
+INSERT INTO event_replay_jobs (
+    source,
+    event_types,
+    from_time,
+    to_time,
+    target_stream,
+    rate_limit,
+    batch_size,
+    requested_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetEventReplayJob :one
+-- GetEventReplayJob retrieves a replay job by ID.
+SELECT * FROM event_replay_jobs
+WHERE id = $1;
+
+-- name: ListEventReplayJobs :many
+-- ListEventReplayJobs lists replay jobs, newest first.
+SELECT * FROM event_replay_jobs
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: ClaimEventReplayJob :one
+-- ClaimEventReplayJob leases the oldest pending job, or running job whose lease expired, to owner.
+UPDATE event_replay_jobs
+SET status = 'running',
+    lease_owner = sqlc.arg(owner),
+    lease_expires_at = sqlc.arg(lease_until)::timestamptz,
+    started_at = COALESCE(started_at, sqlc.arg(now)::timestamptz),
+    updated_at = sqlc.arg(now)::timestamptz
+WHERE id = (
+    SELECT j.id FROM event_replay_jobs j
+    WHERE j.status = 'pending'
+       OR (j.status = 'running' AND j.lease_expires_at < sqlc.arg(now)::timestamptz)
+    ORDER BY j.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: SetEventReplayJobTotal :execrows
+-- SetEventReplayJobTotal records how many source records the job covers.
+UPDATE event_replay_jobs
+SET total_records = sqlc.arg(total_records),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'running'
+  AND lease_owner = sqlc.arg(owner);
+
+-- name: SaveEventReplayJobProgress :execrows
+-- SaveEventReplayJobProgress records the cursor and counters and extends the lease. No row is
+-- updated when the job was cancelled or its lease passed to another owner.
+UPDATE event_replay_jobs
+SET resume_cursor = sqlc.arg(resume_cursor),
+    processed_records = sqlc.arg(processed_records),
+    published_events = sqlc.arg(published_events),
+    lease_expires_at = sqlc.arg(lease_until)::timestamptz,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'running'
+  AND lease_owner = sqlc.arg(owner);
+
+-- name: FinishEventReplayJob :execrows
+-- FinishEventReplayJob moves a running job held by owner to completed or failed and releases the lease.
+UPDATE event_replay_jobs
+SET status = sqlc.arg(status),
+    last_error = sqlc.narg(last_error),
+    completed_at = sqlc.arg(completed_at)::timestamptz,
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'running'
+  AND lease_owner = sqlc.arg(owner);
+
+-- name: CancelEventReplayJob :one
+-- CancelEventReplayJob stops a pending or running job; it keeps its cursor and can be resumed.
+UPDATE event_replay_jobs
+SET status = 'cancelled',
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    completed_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+  AND status IN ('pending', 'running')
+RETURNING *;
+
+-- name: ResumeEventReplayJob :one
+-- ResumeEventReplayJob makes a failed or cancelled job pending again, continuing from its cursor.
+UPDATE event_replay_jobs
+SET status = 'pending',
+    last_error = NULL,
+    completed_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+  AND status IN ('failed', 'cancelled')
+RETURNING *;
+
+-- name: CountUsersForReplay :one
+-- CountUsersForReplay counts users, including deleted ones, created in the optional time range.
+SELECT COUNT(*)::bigint FROM users
+WHERE (sqlc.narg(from_time)::timestamptz IS NULL OR created_at >= sqlc.narg(from_time)::timestamptz)
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR created_at < sqlc.narg(to_time)::timestamptz);
+
+-- name: ListUsersForReplay :many
+-- ListUsersForReplay pages through users, including deleted ones, in (created_at, id) order.
+-- Pass the created_at and id of the last user of the previous page, or NULL for the first page.
+SELECT * FROM users
+WHERE (sqlc.narg(from_time)::timestamptz IS NULL OR created_at >= sqlc.narg(from_time)::timestamptz)
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR created_at < sqlc.narg(to_time)::timestamptz)
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+       OR (created_at, id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid))
+ORDER BY created_at, id
+LIMIT sqlc.arg(limit_count);
+
+-- name: CountOutboxForReplay :one
+-- CountOutboxForReplay counts outbox messages of the given types (all when empty) that occurred in the optional time range.
+SELECT COUNT(*)::bigint FROM outbox
+WHERE (sqlc.narg(from_time)::timestamptz IS NULL OR occurred_at >= sqlc.narg(from_time)::timestamptz)
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR occurred_at < sqlc.narg(to_time)::timestamptz)
+  AND (cardinality(sqlc.arg(event_types)::text[]) = 0 OR event_type = ANY(sqlc.arg(event_types)::text[]));
+
+-- name: ListOutboxForReplay :many
+-- ListOutboxForReplay pages through outbox messages in id order, after the given id.
+SELECT * FROM outbox
+WHERE (sqlc.narg(from_time)::timestamptz IS NULL OR occurred_at >= sqlc.narg(from_time)::timestamptz)
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR occurred_at < sqlc.narg(to_time)::timestamptz)
+  AND (cardinality(sqlc.arg(event_types)::text[]) = 0 OR event_type = ANY(sqlc.arg(event_types)::text[]))
+  AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(limit_count);
+
+-- name: CountAuditLogsForReplay :one
+-- CountAuditLogsForReplay counts audit logs of the given event types (all when empty) created in the optional time range.
+SELECT COUNT(*)::bigint FROM audit_logs
+WHERE (sqlc.narg(from_time)::timestamptz IS NULL OR created_at >= sqlc.narg(from_time)::timestamptz)
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR created_at < sqlc.narg(to_time)::timestamptz)
+  AND (cardinality(sqlc.arg(event_types)::text[]) = 0 OR event_type = ANY(sqlc.arg(event_types)::text[]));
+
+-- name: ListAuditLogsForReplay :many
+-- ListAuditLogsForReplay pages through audit logs in (created_at, id) order.
+-- Pass the created_at and id of the last log of the previous page, or NULL for the first page.
+SELECT * FROM audit_logs
+WHERE (sqlc.narg(from_time)::timestamptz IS NULL OR created_at >= sqlc.narg(from_time)::timestamptz)
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR created_at < sqlc.narg(to_time)::timestamptz)
+  AND (cardinality(sqlc.arg(event_types)::text[]) = 0 OR event_type = ANY(sqlc.arg(event_types)::text[]))
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+       OR (created_at, id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid))
+ORDER BY created_at, id
+LIMIT sqlc.arg(limit_count);
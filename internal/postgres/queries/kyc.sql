@@ -0,0 +1,117 @@
+-- name: CreateKYCCase :one
+-- CreateKYCCase opens a case. Fails with a unique violation if the user has a case in progress.
+INSERT INTO kyc_cases (
+    user_id,
+    status,
+    risk_level,
+    applicant
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetKYCCase :one
+-- GetKYCCase retrieves a case by ID.
+SELECT * FROM kyc_cases
+WHERE id = $1;
+
+-- name: GetKYCCaseByProviderApplicant :one
+-- GetKYCCaseByProviderApplicant retrieves the case an external provider's applicant belongs to.
+SELECT * FROM kyc_cases
+WHERE provider = $1
+  AND provider_applicant_id = $2;
+
+-- name: GetLatestKYCCaseForUser :one
+-- GetLatestKYCCaseForUser retrieves the user's most recently created case.
+SELECT * FROM kyc_cases
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListKYCCases :many
+-- ListKYCCases lists cases matching the optional filters, oldest submission first.
+SELECT * FROM kyc_cases
+WHERE (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(reviewer_id)::uuid IS NULL OR reviewer_id = sqlc.narg(reviewer_id))
+  AND (sqlc.narg(risk_level)::varchar IS NULL OR risk_level = sqlc.narg(risk_level))
+ORDER BY submitted_at ASC NULLS LAST, created_at ASC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountKYCCases :one
+-- CountKYCCases counts cases matching the optional filters.
+SELECT COUNT(*) FROM kyc_cases
+WHERE (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(reviewer_id)::uuid IS NULL OR reviewer_id = sqlc.narg(reviewer_id))
+  AND (sqlc.narg(risk_level)::varchar IS NULL OR risk_level = sqlc.narg(risk_level));
+
+-- name: UpdateKYCCase :one
+-- UpdateKYCCase stores a case if its version is still the one it was loaded with and
+-- increments the version. Returns no rows when the case was changed concurrently.
+UPDATE kyc_cases
+SET status = sqlc.arg(status),
+    risk_level = sqlc.arg(risk_level),
+    applicant = sqlc.arg(applicant),
+    reviewer_id = sqlc.narg(reviewer_id),
+    first_approver_id = sqlc.narg(first_approver_id),
+    first_approved_at = sqlc.narg(first_approved_at),
+    decided_by = sqlc.narg(decided_by),
+    decided_at = sqlc.narg(decided_at),
+    rejection_reason = sqlc.narg(rejection_reason),
+    review_note = sqlc.narg(review_note),
+    submitted_at = sqlc.narg(submitted_at),
+    provider = sqlc.narg(provider),
+    provider_applicant_id = sqlc.narg(provider_applicant_id),
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND version = sqlc.arg(version)
+RETURNING *;
+
+-- name: CreateKYCDocument :one
+-- CreateKYCDocument stores the metadata of an uploaded document.
+INSERT INTO kyc_documents (
+    id,
+    case_id,
+    document_type,
+    file_name,
+    content_type,
+    size_bytes,
+    sha256,
+    storage_key
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: ListKYCDocuments :many
+-- ListKYCDocuments lists a case's documents in upload order.
+SELECT * FROM kyc_documents
+WHERE case_id = $1
+ORDER BY uploaded_at ASC;
+
+-- name: GetKYCDocument :one
+-- GetKYCDocument retrieves a document of a case.
+SELECT * FROM kyc_documents
+WHERE case_id = $1
+  AND id = $2;
+
+-- name: CreateKYCCaseTransition :exec
+-- CreateKYCCaseTransition appends an entry to a case's history.
+INSERT INTO kyc_case_transitions (
+    case_id,
+    action,
+    from_status,
+    to_status,
+    actor_id,
+    reason_code,
+    note,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+);
+
+-- name: ListKYCCaseTransitions :many
+-- ListKYCCaseTransitions lists a case's history in order.
+SELECT * FROM kyc_case_transitions
+WHERE case_id = $1
+ORDER BY created_at ASC, id ASC;
@@ -0,0 +1,40 @@
+-- name: GetUserKYCTier :one
+-- GetUserKYCTier retrieves the tier a user holds.
+SELECT * FROM user_kyc_tiers
+WHERE user_id = $1;
+
+-- name: UpsertUserKYCTier :one
+-- UpsertUserKYCTier stores the tier a user holds, replacing any earlier assignment.
+INSERT INTO user_kyc_tiers (
+    user_id,
+    tier,
+    updated_by,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (user_id) DO UPDATE
+SET tier = EXCLUDED.tier,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: CreateUserKYCTierChange :exec
+-- CreateUserKYCTierChange appends an entry to a user's tier history.
+INSERT INTO user_kyc_tier_changes (
+    user_id,
+    old_tier,
+    new_tier,
+    reason,
+    changed_by,
+    case_id,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: ListUserKYCTierChanges :many
+-- ListUserKYCTierChanges lists a user's tier history in order.
+SELECT * FROM user_kyc_tier_changes
+WHERE user_id = $1
+ORDER BY created_at ASC, id ASC;
@@ -0,0 +1,66 @@
+-- name: CreateLegalHold :one
+-- CreateLegalHold places a new legal hold.
+INSERT INTO legal_holds (
+    user_id,
+    starts_at,
+    ends_at,
+    event_category,
+    reason,
+    owner,
+    expires_at,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetLegalHoldByID :one
+-- GetLegalHoldByID retrieves a legal hold by ID, released or not.
+SELECT * FROM legal_holds
+WHERE id = $1;
+
+-- name: ListLegalHolds :many
+-- ListLegalHolds lists legal holds, newest first.
+-- When active_only is true, released and expired holds are left out.
+SELECT * FROM legal_holds
+WHERE (NOT sqlc.arg(active_only)::boolean OR (released_at IS NULL AND expires_at > NOW()))
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: UpdateLegalHold :one
+-- UpdateLegalHold changes the reason, owner and expiry of an unreleased hold.
+-- The scope of a hold is immutable; place a new hold instead.
+UPDATE legal_holds
+SET reason = $2,
+    owner = $3,
+    expires_at = $4,
+    updated_at = NOW()
+WHERE id = $1
+  AND released_at IS NULL
+RETURNING *;
+
+-- name: ReleaseLegalHold :one
+-- ReleaseLegalHold lifts an unreleased hold. The row is kept as a record of the hold.
+UPDATE legal_holds
+SET released_at = NOW(),
+    released_by = $2,
+    release_reason = $3,
+    updated_at = NOW()
+WHERE id = $1
+  AND released_at IS NULL
+RETURNING *;
+
+-- name: CountActiveLegalHoldsForUser :one
+-- CountActiveLegalHoldsForUser counts active holds naming the given user.
+SELECT COUNT(*) FROM legal_holds
+WHERE released_at IS NULL
+  AND expires_at > NOW()
+  AND user_id = sqlc.arg(user_id)::uuid;
+
+-- name: CountActiveLegalHoldsForRange :one
+-- CountActiveLegalHoldsForRange counts active holds whose created_at range overlaps [range_start, range_end).
+-- User and category scopes are ignored: any overlapping hold counts.
+SELECT COUNT(*) FROM legal_holds
+WHERE released_at IS NULL
+  AND expires_at > NOW()
+  AND (starts_at IS NULL OR starts_at < sqlc.arg(range_end)::timestamptz)
+  AND (ends_at IS NULL OR ends_at > sqlc.arg(range_start)::timestamptz);
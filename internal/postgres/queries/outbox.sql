@@ -0,0 +1,66 @@
+-- name: InsertOutboxMessage :exec
+-- InsertOutboxMessage appends an event to the outbox. Run it in the transaction of the change it describes.
+INSERT INTO outbox (
+    aggregate_type,
+    aggregate_id,
+    event_id,
+    event_type,
+    occurred_at,
+    attributes,
+    payload,
+    metadata,
+    source,
+    subject,
+    schema_version
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+);
+
+-- name: AcquireOutboxRelayLock :one
+-- AcquireOutboxRelayLock takes the relay lock for the current transaction, returning false if another relay holds it.
+SELECT pg_try_advisory_xact_lock(hashtext('outbox_relay')) AS acquired;
+
+-- name: ListDueOutboxMessages :many
+-- ListDueOutboxMessages lists pending messages due at now, oldest first. A message is skipped while
+-- an earlier pending message of the same aggregate is still backing off, preserving per-aggregate order.
+SELECT * FROM outbox o
+WHERE o.published_at IS NULL
+  AND o.next_attempt_at <= sqlc.arg(now)::timestamptz
+  AND NOT EXISTS (
+      SELECT 1 FROM outbox earlier
+      WHERE earlier.aggregate_type = o.aggregate_type
+        AND earlier.aggregate_id = o.aggregate_id
+        AND earlier.published_at IS NULL
+        AND earlier.id < o.id
+        AND earlier.next_attempt_at > sqlc.arg(now)::timestamptz
+  )
+ORDER BY o.id
+LIMIT sqlc.arg(limit_count);
+
+-- name: MarkOutboxMessagePublished :exec
+-- MarkOutboxMessagePublished records that the event bus accepted a message.
+UPDATE outbox
+SET published_at = sqlc.arg(published_at)::timestamptz,
+    last_error = NULL
+WHERE id = sqlc.arg(id);
+
+-- name: MarkOutboxMessageFailed :exec
+-- MarkOutboxMessageFailed records a failed publish attempt and when to retry.
+UPDATE outbox
+SET attempts = attempts + 1,
+    last_error = sqlc.arg(last_error),
+    next_attempt_at = sqlc.arg(next_attempt_at)::timestamptz
+WHERE id = sqlc.arg(id);
+
+-- name: GetOutboxStats :one
+-- GetOutboxStats returns the number of pending messages and when the oldest was written.
+SELECT COUNT(*)::bigint AS pending,
+       MIN(created_at)::timestamptz AS oldest_pending_at
+FROM outbox
+WHERE published_at IS NULL;
+
+-- name: DeletePublishedOutboxMessages :execrows
+-- DeletePublishedOutboxMessages removes messages published before the cutoff.
+DELETE FROM outbox
+WHERE published_at IS NOT NULL
+  AND published_at < sqlc.arg(before)::timestamptz;
@@ -0,0 +1,34 @@
+-- name: SavePhoneVerification :one
+-- SavePhoneVerification stores a user's pending phone verification, replacing any earlier one.
+INSERT INTO phone_verifications (
+    user_id,
+    phone,
+    code_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (user_id) DO UPDATE
+SET phone = EXCLUDED.phone,
+    code_hash = EXCLUDED.code_hash,
+    attempts = 0,
+    expires_at = EXCLUDED.expires_at,
+    created_at = NOW()
+RETURNING *;
+
+-- name: GetPhoneVerification :one
+-- GetPhoneVerification retrieves a user's pending phone verification.
+SELECT * FROM phone_verifications
+WHERE user_id = $1;
+
+-- name: IncrementPhoneVerificationAttempts :one
+-- IncrementPhoneVerificationAttempts records a wrong code and returns the attempts so far.
+UPDATE phone_verifications
+SET attempts = attempts + 1
+WHERE user_id = $1
+RETURNING attempts;
+
+-- name: DeletePhoneVerification :exec
+-- DeletePhoneVerification removes a user's pending phone verification.
+DELETE FROM phone_verifications
+WHERE user_id = $1;
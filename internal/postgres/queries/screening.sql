@@ -0,0 +1,52 @@
+-- name: CreateScreeningCase :one
+-- CreateScreeningCase opens a case for a user's watch list matches.
+INSERT INTO screening_cases (
+    user_id,
+    status,
+    trigger,
+    subject,
+    matches,
+    list_version
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetScreeningCase :one
+-- GetScreeningCase retrieves a case by ID.
+SELECT * FROM screening_cases
+WHERE id = $1;
+
+-- name: ListScreeningCases :many
+-- ListScreeningCases lists cases matching the optional filters, oldest first.
+SELECT * FROM screening_cases
+WHERE (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(user_id)::uuid IS NULL OR user_id = sqlc.narg(user_id))
+ORDER BY created_at ASC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountScreeningCases :one
+-- CountScreeningCases counts cases matching the optional filters.
+SELECT COUNT(*) FROM screening_cases
+WHERE (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(user_id)::uuid IS NULL OR user_id = sqlc.narg(user_id));
+
+-- name: ListScreeningCasesForUser :many
+-- ListScreeningCasesForUser lists every case of a user, newest first.
+SELECT * FROM screening_cases
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpdateScreeningCase :one
+-- UpdateScreeningCase stores a case if its version is still the one it was loaded with and
+-- increments the version. Returns no rows when the case was changed concurrently.
+UPDATE screening_cases
+SET status = sqlc.arg(status),
+    reviewed_by = sqlc.narg(reviewed_by),
+    reviewed_at = sqlc.narg(reviewed_at),
+    review_note = sqlc.narg(review_note),
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND version = sqlc.arg(version)
+RETURNING *;
@@ -0,0 +1,85 @@
+-- name: RecordUserActivity :execrows
+-- RecordUserActivity marks the users who were issued a refresh token at or after $1 as
+-- active on the UTC day it was issued.
+INSERT INTO user_activity_daily (day, user_id)
+SELECT DISTINCT (created_at AT TIME ZONE 'UTC')::date, user_id
+FROM refresh_tokens
+WHERE created_at >= sqlc.arg(since)::timestamptz
+ON CONFLICT DO NOTHING;
+
+-- name: RefreshDailyStats :execrows
+-- RefreshDailyStats recomputes the daily statistics of each UTC day from $1 to $2 inclusive.
+-- Activity for the days must be recorded first.
+INSERT INTO stats_daily (
+    day,
+    registrations,
+    logins,
+    failed_logins,
+    kyc_approvals,
+    active_users,
+    monthly_active_users,
+    refreshed_at
+)
+SELECT
+    d.day,
+    (SELECT COUNT(*) FROM users u
+     WHERE u.created_at >= d.starts_at AND u.created_at < d.ends_at),
+    (SELECT COUNT(*) FROM audit_logs a
+     WHERE a.event_type = 'user.login' AND a.status = 'success'
+       AND a.created_at >= d.starts_at AND a.created_at < d.ends_at),
+    (SELECT COUNT(*) FROM audit_logs a
+     WHERE a.event_type = 'user.login' AND a.status = 'failure'
+       AND a.created_at >= d.starts_at AND a.created_at < d.ends_at),
+    (SELECT COUNT(*) FROM kyc_cases k
+     WHERE k.status = 'approved' AND k.decided_at >= d.starts_at AND k.decided_at < d.ends_at),
+    (SELECT COUNT(*) FROM user_activity_daily ua WHERE ua.day = d.day),
+    (SELECT COUNT(DISTINCT ua.user_id) FROM user_activity_daily ua
+     WHERE ua.day > d.day - 30 AND ua.day <= d.day),
+    NOW()
+FROM (
+    SELECT g::date AS day,
+           g AT TIME ZONE 'UTC' AS starts_at,
+           (g + INTERVAL '1 day') AT TIME ZONE 'UTC' AS ends_at
+    FROM generate_series(sqlc.arg(from_day)::date::timestamp, sqlc.arg(to_day)::date::timestamp, INTERVAL '1 day') AS g
+) d
+ON CONFLICT (day) DO UPDATE
+SET registrations = EXCLUDED.registrations,
+    logins = EXCLUDED.logins,
+    failed_logins = EXCLUDED.failed_logins,
+    kyc_approvals = EXCLUDED.kyc_approvals,
+    active_users = EXCLUDED.active_users,
+    monthly_active_users = EXCLUDED.monthly_active_users,
+    refreshed_at = EXCLUDED.refreshed_at;
+
+-- name: GetLatestStatsDay :one
+-- GetLatestStatsDay returns the last day with daily statistics, or NULL if there are none.
+SELECT MAX(day)::date AS day FROM stats_daily;
+
+-- name: ListDailyStats :many
+-- ListDailyStats lists the daily statistics from $1 to $2 inclusive in order.
+SELECT * FROM stats_daily
+WHERE day >= sqlc.arg(from_day)::date AND day <= sqlc.arg(to_day)::date
+ORDER BY day ASC;
+
+-- name: PruneUserActivity :execrows
+-- PruneUserActivity deletes the activity of days before $1.
+DELETE FROM user_activity_daily
+WHERE day < sqlc.arg(before)::date;
+
+-- name: RefreshKYCFunnel :exec
+-- RefreshKYCFunnel recomputes the KYC funnel without blocking readers.
+REFRESH MATERIALIZED VIEW CONCURRENTLY stats_kyc_funnel;
+
+-- name: ListKYCFunnel :many
+-- ListKYCFunnel lists non-deleted users by the status of their latest KYC case.
+SELECT * FROM stats_kyc_funnel
+ORDER BY status ASC;
+
+-- name: RefreshSessionsByDevice :exec
+-- RefreshSessionsByDevice recomputes active sessions by device without blocking readers.
+REFRESH MATERIALIZED VIEW CONCURRENTLY stats_sessions_by_device;
+
+-- name: ListSessionsByDevice :many
+-- ListSessionsByDevice lists active sessions and their users by device.
+SELECT * FROM stats_sessions_by_device
+ORDER BY device ASC;
@@ -0,0 +1,43 @@
+-- name: SetUserStatus :one
+-- SetUserStatus sets a user's account status and appends the change to their status history.
+-- Returns no rows if the user doesn't exist or is soft-deleted.
+WITH previous AS (
+    SELECT id, status FROM users
+    WHERE id = $1 AND deleted_at IS NULL
+    FOR UPDATE
+), change AS (
+    INSERT INTO user_status_changes (
+        user_id,
+        old_status,
+        new_status,
+        reason,
+        expires_at,
+        changed_by,
+        sessions_revoked,
+        created_at
+    )
+    SELECT id, status, $2, $3, $4, $5, $6, $7 FROM previous
+)
+UPDATE users
+SET status = $2,
+    status_reason = $3,
+    status_expires_at = $4,
+    status_set_by = $5,
+    status_set_at = $7
+FROM previous
+WHERE users.id = previous.id
+RETURNING users.*;
+
+-- name: ListUserStatusChanges :many
+-- ListUserStatusChanges lists a user's account status history in order.
+SELECT * FROM user_status_changes
+WHERE user_id = $1
+ORDER BY created_at ASC, id ASC;
+
+-- name: ListUsersWithExpiredStatus :many
+-- ListUsersWithExpiredStatus lists active users whose suspension or freeze expired at or
+-- before $1, earliest first.
+SELECT * FROM users
+WHERE status_expires_at <= $1 AND deleted_at IS NULL
+ORDER BY status_expires_at ASC
+LIMIT $2;
@@ -1,5 +1,6 @@
 -- name: CreateUser :one
 -- CreateUser creates a new user with the provided email, first name, last name, and hashed password.
+-- email_index, pii_key and pii_key_version are NULL when PII encryption is disabled.
 -- Returns the created user record.
 INSERT INTO users (
     email,
@@ -7,9 +8,12 @@ INSERT INTO users (
     last_name,
     hashed_password,
     role,
-    kyc_status
+    kyc_status,
+    email_index,
+    pii_key,
+    pii_key_version
 ) VALUES (
-    $1, $2, $3, $4, COALESCE($5, 'user'), 'pending'
+    $1, $2, $3, $4, COALESCE($5, 'user'), 'pending', $6, $7, $8
 ) RETURNING *;
 
 -- name: GetUserByID :one
@@ -24,6 +28,16 @@ WHERE id = $1 AND deleted_at IS NULL;
 SELECT * FROM users
 WHERE email = $1 AND deleted_at IS NULL;
 
+-- name: GetUserByEmailIndex :one
+-- GetUserByEmailIndex retrieves a user by the blind index of their encrypted email.
+-- Returns error if user not found or soft-deleted.
+SELECT * FROM users
+WHERE email_index = $1 AND deleted_at IS NULL;
+
+-- name: UserEmailExists :one
+-- UserEmailExists reports whether an active user holds the plaintext email.
+SELECT EXISTS (SELECT 1 FROM users WHERE email = $1 AND deleted_at IS NULL);
+
 -- name: UpdateUserKYCStatus :one
 -- UpdateUserKYCStatus updates the KYC verification status for a user.
 -- Valid statuses: pending, verified, rejected
@@ -39,6 +53,21 @@ UPDATE users
 SET deleted_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL;
 
+-- name: RestoreUser :one
+-- RestoreUser undeletes a user deleted at deleted_at and drops a deferred erasure of them.
+-- Returns no rows if the user is not deleted at deleted_at or has been erased.
+WITH cleared AS (
+    DELETE FROM user_erasures
+    WHERE user_erasures.user_id = $1
+      AND user_erasures.status = 'deferred'
+      AND EXISTS (SELECT 1 FROM users u WHERE u.id = $1 AND u.deleted_at = $2)
+)
+UPDATE users
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at = $2
+  AND NOT EXISTS (SELECT 1 FROM user_erasures e WHERE e.user_id = users.id AND e.status = 'erased')
+RETURNING *;
+
 -- name: ListUsers :many
 -- ListUsers retrieves paginated list of active users.
 -- Supports filtering and pagination.
@@ -53,23 +82,98 @@ SELECT COUNT(*) FROM users
 WHERE deleted_at IS NULL;
 
 -- name: UpdateUserProfile :one
--- UpdateUserProfile updates user's profile information (first_name and last_name).
+-- UpdateUserProfile replaces the user's profile fields; NULL clears an optional field.
 UPDATE users
-SET first_name = $2, last_name = $3
+SET first_name = $2,
+    last_name = $3,
+    phone = $4,
+    phone_verified_at = $5,
+    date_of_birth = $6,
+    address_line1 = $7,
+    address_line2 = $8,
+    address_city = $9,
+    address_postal_code = $10,
+    address_region = $11,
+    address_country = $12,
+    nationality = $13,
+    tax_residency = $14
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
--- name: SearchUsers :many
--- SearchUsers searches users by email, first name, or last name.
+-- name: SearchUsersNewest :many
+-- SearchUsersNewest searches users for admins, newest first.
+-- The query matches the plaintext email and names by substring (query_pattern, a lowercased
+-- LIKE pattern) or full-text search, the exact email of encrypted users through its blind
+-- index, and the user ID; pass an empty query to list all users. Pass NULL to skip a filter.
+-- deleted is exclude, only or include. Pass the created_at and id of the last user of the
+-- previous page, or NULL for the first page.
 SELECT * FROM users
-WHERE deleted_at IS NULL
-AND (
-    email ILIKE '%' || $1 || '%'
-    OR first_name ILIKE '%' || $1 || '%'
-    OR last_name ILIKE '%' || $1 || '%'
-)
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
+WHERE (sqlc.arg(query)::text = ''
+       OR user_search_text(email, first_name, last_name) LIKE sqlc.arg(query_pattern)::text
+       OR to_tsvector('simple', user_search_text(email, first_name, last_name)) @@ websearch_to_tsquery('simple', sqlc.arg(query)::text)
+       OR email_index = sqlc.narg(email_index)
+       OR id = sqlc.narg(query_id)::uuid)
+  AND (sqlc.narg(role)::text IS NULL OR role = sqlc.narg(role)::text)
+  AND (sqlc.narg(kyc_status)::text IS NULL OR kyc_status = sqlc.narg(kyc_status)::text)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from)::timestamptz)
+  AND (sqlc.narg(created_to)::timestamptz IS NULL OR created_at < sqlc.narg(created_to)::timestamptz)
+  AND (sqlc.arg(deleted)::text = 'include' OR (deleted_at IS NOT NULL) = (sqlc.arg(deleted)::text = 'only'))
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+       OR (created_at, id) < (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: SearchUsersOldest :many
+-- SearchUsersOldest searches users for admins, oldest first.
+-- The query matches the plaintext email and names by substring (query_pattern, a lowercased
+-- LIKE pattern) or full-text search, the exact email of encrypted users through its blind
+-- index, and the user ID; pass an empty query to list all users. Pass NULL to skip a filter.
+-- deleted is exclude, only or include. Pass the created_at and id of the last user of the
+-- previous page, or NULL for the first page.
+SELECT * FROM users
+WHERE (sqlc.arg(query)::text = ''
+       OR user_search_text(email, first_name, last_name) LIKE sqlc.arg(query_pattern)::text
+       OR to_tsvector('simple', user_search_text(email, first_name, last_name)) @@ websearch_to_tsquery('simple', sqlc.arg(query)::text)
+       OR email_index = sqlc.narg(email_index)
+       OR id = sqlc.narg(query_id)::uuid)
+  AND (sqlc.narg(role)::text IS NULL OR role = sqlc.narg(role)::text)
+  AND (sqlc.narg(kyc_status)::text IS NULL OR kyc_status = sqlc.narg(kyc_status)::text)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from)::timestamptz)
+  AND (sqlc.narg(created_to)::timestamptz IS NULL OR created_at < sqlc.narg(created_to)::timestamptz)
+  AND (sqlc.arg(deleted)::text = 'include' OR (deleted_at IS NOT NULL) = (sqlc.arg(deleted)::text = 'only'))
+  AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+       OR (created_at, id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid))
+ORDER BY created_at, id
+LIMIT sqlc.arg(limit_count);
+
+-- name: SearchUsersByRelevance :many
+-- SearchUsersByRelevance searches users for admins, best match first.
+-- Takes the same query and filters as SearchUsersNewest, but the query is required. Rank adds
+-- full-text rank and trigram word similarity, plus 1 for an exact ID or email match.
+-- Pass the rank and id of the last user of the previous page, or NULL for the first page.
+SELECT sqlc.embed(users), r.rank
+FROM users,
+LATERAL (SELECT (
+    CASE WHEN users.id = sqlc.narg(query_id)::uuid OR users.email_index = sqlc.narg(email_index) THEN 1 ELSE 0 END
+    + ts_rank(to_tsvector('simple', user_search_text(users.email, users.first_name, users.last_name)), websearch_to_tsquery('simple', sqlc.arg(query)::text))
+    + word_similarity(sqlc.arg(query)::text, user_search_text(users.email, users.first_name, users.last_name))
+)::float8 AS rank) r
+WHERE (user_search_text(users.email, users.first_name, users.last_name) LIKE sqlc.arg(query_pattern)::text
+       OR to_tsvector('simple', user_search_text(users.email, users.first_name, users.last_name)) @@ websearch_to_tsquery('simple', sqlc.arg(query)::text)
+       OR users.email_index = sqlc.narg(email_index)
+       OR users.id = sqlc.narg(query_id)::uuid)
+  AND (sqlc.narg(role)::text IS NULL OR users.role = sqlc.narg(role)::text)
+  AND (sqlc.narg(kyc_status)::text IS NULL OR users.kyc_status = sqlc.narg(kyc_status)::text)
+  AND (sqlc.narg(status)::text IS NULL OR users.status = sqlc.narg(status)::text)
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR users.created_at >= sqlc.narg(created_from)::timestamptz)
+  AND (sqlc.narg(created_to)::timestamptz IS NULL OR users.created_at < sqlc.narg(created_to)::timestamptz)
+  AND (sqlc.arg(deleted)::text = 'include' OR (users.deleted_at IS NOT NULL) = (sqlc.arg(deleted)::text = 'only'))
+  AND (sqlc.narg(after_rank)::float8 IS NULL
+       OR (r.rank, users.id) < (sqlc.narg(after_rank)::float8, sqlc.narg(after_id)::uuid))
+ORDER BY r.rank DESC, users.id DESC
+LIMIT sqlc.arg(limit_count);
 
 -- name: UpdateUserRole :one
 -- UpdateUserRole updates a user's role (admin only operation).
@@ -82,3 +186,57 @@ RETURNING *;
 -- GetUserByIDIncludeDeleted retrieves a user by ID including soft-deleted users (admin only).
 SELECT * FROM users
 WHERE id = $1;
+
+-- name: GetUserPIIKey :one
+-- GetUserPIIKey retrieves the wrapped data key of a user, including soft-deleted users.
+SELECT pii_key, pii_key_version FROM users
+WHERE id = $1;
+
+-- name: SetUserPIIKey :one
+-- SetUserPIIKey stores a wrapped data key for a user that has none yet.
+-- Returns no rows if the user does not exist or already has a key.
+UPDATE users
+SET pii_key = $2,
+    pii_key_version = $3
+WHERE id = $1 AND pii_key IS NULL
+RETURNING pii_key, pii_key_version;
+
+-- name: ListUsersForPIIReencryption :many
+-- ListUsersForPIIReencryption lists users, including deleted ones, that still hold plaintext PII
+-- or whose data key is wrapped with a key version older than current_version.
+-- Erased users hold no PII and are skipped.
+SELECT * FROM users
+WHERE (email_index IS NULL
+       OR pii_key_version < sqlc.arg(current_version)::int)
+  AND NOT EXISTS (SELECT 1 FROM user_erasures e WHERE e.user_id = users.id AND e.status = 'erased')
+ORDER BY id
+LIMIT sqlc.arg(limit_count);
+
+-- name: CountUsersForPIIReencryption :one
+-- CountUsersForPIIReencryption counts the users ListUsersForPIIReencryption would return.
+SELECT COUNT(*) FROM users
+WHERE (email_index IS NULL
+       OR pii_key_version < sqlc.arg(current_version)::int)
+  AND NOT EXISTS (SELECT 1 FROM user_erasures e WHERE e.user_id = users.id AND e.status = 'erased');
+
+-- name: UpdateUserPII :execrows
+-- UpdateUserPII rewrites a user's PII columns and data key.
+-- Only applies if the row was not updated since it was read (updated_at is unchanged).
+UPDATE users
+SET email = sqlc.arg(email),
+    email_index = sqlc.arg(email_index),
+    first_name = sqlc.arg(first_name),
+    last_name = sqlc.arg(last_name),
+    phone = sqlc.arg(phone),
+    date_of_birth = sqlc.arg(date_of_birth),
+    address_line1 = sqlc.arg(address_line1),
+    address_line2 = sqlc.arg(address_line2),
+    address_city = sqlc.arg(address_city),
+    address_postal_code = sqlc.arg(address_postal_code),
+    address_region = sqlc.arg(address_region),
+    address_country = sqlc.arg(address_country),
+    nationality = sqlc.arg(nationality),
+    tax_residency = sqlc.arg(tax_residency),
+    pii_key = sqlc.arg(pii_key),
+    pii_key_version = sqlc.arg(pii_key_version)
+WHERE id = sqlc.arg(id) AND updated_at = sqlc.arg(read_updated_at);
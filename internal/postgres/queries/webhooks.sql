@@ -0,0 +1,176 @@
+-- name: CreateWebhookSubscription :one
+-- CreateWebhookSubscription stores a new enabled subscription.
+INSERT INTO webhook_subscriptions (
+    url,
+    description,
+    event_types,
+    secret,
+    secret_key,
+    secret_key_version,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetWebhookSubscription :one
+-- GetWebhookSubscription retrieves a subscription by ID.
+SELECT * FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: ListWebhookSubscriptions :many
+-- ListWebhookSubscriptions lists subscriptions, newest first.
+SELECT * FROM webhook_subscriptions
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: UpdateWebhookSubscription :one
+-- UpdateWebhookSubscription replaces a subscription's settings. Re-enabling a subscription
+-- clears its failure count; disabling it records disabled_reason. A NULL secret keeps the current
+-- one along with its data key.
+UPDATE webhook_subscriptions
+SET url = sqlc.arg(url),
+    description = sqlc.arg(description),
+    event_types = sqlc.arg(event_types),
+    secret = COALESCE(sqlc.narg(secret), secret),
+    secret_key = CASE WHEN sqlc.narg(secret)::text IS NULL THEN secret_key ELSE sqlc.narg(secret_key) END,
+    secret_key_version = CASE WHEN sqlc.narg(secret)::text IS NULL THEN secret_key_version ELSE sqlc.narg(secret_key_version) END,
+    consecutive_failures = CASE WHEN sqlc.arg(enabled)::boolean AND NOT enabled THEN 0 ELSE consecutive_failures END,
+    disabled_at = CASE WHEN sqlc.arg(enabled)::boolean THEN NULL WHEN enabled THEN NOW() ELSE disabled_at END,
+    disabled_reason = CASE WHEN sqlc.arg(enabled)::boolean THEN NULL WHEN enabled THEN sqlc.narg(disabled_reason) ELSE disabled_reason END,
+    enabled = sqlc.arg(enabled)::boolean,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteWebhookSubscription :execrows
+-- DeleteWebhookSubscription removes a subscription and its delivery log.
+DELETE FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: ListWebhookSubscriptionsPendingEncryption :many
+-- ListWebhookSubscriptionsPendingEncryption lists subscriptions whose secret is plaintext or whose
+-- data key was wrapped with a key version older than current_version.
+SELECT * FROM webhook_subscriptions
+WHERE secret_key IS NULL
+   OR secret_key_version < sqlc.arg(current_version)::integer
+ORDER BY created_at;
+
+-- name: SetWebhookSubscriptionSecret :execrows
+-- SetWebhookSubscriptionSecret stores an encrypted secret or rewrapped data key. Nothing is
+-- updated if the secret changed since previous_secret was read.
+UPDATE webhook_subscriptions
+SET secret = sqlc.arg(secret),
+    secret_key = sqlc.arg(secret_key),
+    secret_key_version = sqlc.arg(secret_key_version),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND secret = sqlc.arg(previous_secret);
+
+-- name: RecordWebhookSubscriptionFailure :one
+-- RecordWebhookSubscriptionFailure counts a failed attempt against an enabled subscription and
+-- disables it once disable_after consecutive attempts have failed.
+UPDATE webhook_subscriptions
+SET consecutive_failures = consecutive_failures + 1,
+    enabled = consecutive_failures + 1 < sqlc.arg(disable_after)::integer,
+    disabled_at = CASE WHEN consecutive_failures + 1 >= sqlc.arg(disable_after)::integer THEN sqlc.arg(now)::timestamptz ELSE disabled_at END,
+    disabled_reason = CASE WHEN consecutive_failures + 1 >= sqlc.arg(disable_after)::integer THEN sqlc.narg(disabled_reason) ELSE disabled_reason END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND enabled
+RETURNING *;
+
+-- name: ResetWebhookSubscriptionFailures :exec
+-- ResetWebhookSubscriptionFailures clears the failure count after a successful delivery.
+UPDATE webhook_subscriptions
+SET consecutive_failures = 0,
+    updated_at = NOW()
+WHERE id = $1
+  AND consecutive_failures > 0;
+
+-- name: EnqueueWebhookDeliveries :execrows
+-- EnqueueWebhookDeliveries queues an event for every enabled subscription whose event types
+-- match. Enqueueing the same event again is a no-op.
+INSERT INTO webhook_deliveries (
+    subscription_id,
+    event_id,
+    event_type,
+    payload,
+    next_attempt_at
+)
+SELECT s.id, sqlc.arg(event_id), sqlc.arg(event_type)::text, sqlc.arg(payload), sqlc.arg(next_attempt_at)::timestamptz
+FROM webhook_subscriptions s
+WHERE s.enabled
+  AND (cardinality(s.event_types) = 0 OR sqlc.arg(event_type)::text = ANY(s.event_types))
+ON CONFLICT (subscription_id, event_id) DO NOTHING;
+
+-- name: ClaimDueWebhookDeliveries :many
+-- ClaimDueWebhookDeliveries leases up to limit_count pending deliveries of enabled subscriptions
+-- due at now by moving their next attempt to lease_until, so concurrent dispatchers skip them.
+UPDATE webhook_deliveries
+SET next_attempt_at = sqlc.arg(lease_until)::timestamptz
+WHERE id IN (
+    SELECT d.id FROM webhook_deliveries d
+    JOIN webhook_subscriptions s ON s.id = d.subscription_id
+    WHERE d.status = 'pending'
+      AND d.next_attempt_at <= sqlc.arg(now)::timestamptz
+      AND s.enabled
+    ORDER BY d.next_attempt_at
+    LIMIT sqlc.arg(limit_count)
+    FOR UPDATE OF d SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkWebhookDeliverySucceeded :exec
+-- MarkWebhookDeliverySucceeded records a delivery the endpoint accepted.
+UPDATE webhook_deliveries
+SET status = 'succeeded',
+    attempts = attempts + 1,
+    last_attempt_at = sqlc.arg(attempted_at)::timestamptz,
+    last_status_code = sqlc.arg(status_code),
+    last_error = NULL,
+    delivered_at = sqlc.arg(attempted_at)::timestamptz
+WHERE id = sqlc.arg(id);
+
+-- name: MarkWebhookDeliveryFailed :exec
+-- MarkWebhookDeliveryFailed records a failed attempt. The delivery stays pending until
+-- next_attempt_at, or moves to failed when it is out of attempts.
+UPDATE webhook_deliveries
+SET status = sqlc.arg(status),
+    attempts = attempts + 1,
+    last_attempt_at = sqlc.arg(attempted_at)::timestamptz,
+    last_status_code = sqlc.narg(status_code),
+    last_error = sqlc.arg(last_error),
+    next_attempt_at = sqlc.arg(next_attempt_at)::timestamptz
+WHERE id = sqlc.arg(id);
+
+-- name: GetWebhookDelivery :one
+-- GetWebhookDelivery retrieves a delivery by ID.
+SELECT * FROM webhook_deliveries
+WHERE id = $1;
+
+-- name: ListWebhookDeliveries :many
+-- ListWebhookDeliveries lists a subscription's deliveries, newest first, optionally filtered by status.
+SELECT * FROM webhook_deliveries
+WHERE subscription_id = sqlc.arg(subscription_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: RedeliverWebhookDelivery :one
+-- RedeliverWebhookDelivery queues a finished delivery again with a fresh set of attempts.
+UPDATE webhook_deliveries
+SET status = 'pending',
+    attempts = 0,
+    next_attempt_at = sqlc.arg(now)::timestamptz,
+    delivered_at = NULL
+WHERE id = sqlc.arg(id)
+  AND subscription_id = sqlc.arg(subscription_id)
+  AND status <> 'pending'
+RETURNING *;
+
+-- name: DeleteFinishedWebhookDeliveries :execrows
+-- DeleteFinishedWebhookDeliveries removes succeeded and failed deliveries created before the cutoff.
+DELETE FROM webhook_deliveries
+WHERE status <> 'pending'
+  AND created_at < sqlc.arg(before)::timestamptz;
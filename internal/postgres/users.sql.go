@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const countUsers = `-- name: CountUsers :one
@@ -24,6 +25,21 @@ func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countUsersForPIIReencryption = `-- name: CountUsersForPIIReencryption :one
+SELECT COUNT(*) FROM users
+WHERE (email_index IS NULL
+       OR pii_key_version < $1::int)
+  AND NOT EXISTS (SELECT 1 FROM user_erasures e WHERE e.user_id = users.id AND e.status = 'erased')
+`
+
+// CountUsersForPIIReencryption counts the users ListUsersForPIIReencryption would return.
+func (q *Queries) CountUsersForPIIReencryption(ctx context.Context, currentVersion int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsersForPIIReencryption, currentVersion)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
     email,
@@ -31,10 +47,13 @@ INSERT INTO users (
     last_name,
     hashed_password,
     role,
-    kyc_status
+    kyc_status,
+    email_index,
+    pii_key,
+    pii_key_version
 ) VALUES (
-    $1, $2, $3, $4, COALESCE($5, 'user'), 'pending'
-) RETURNING id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role
+    $1, $2, $3, $4, COALESCE($5, 'user'), 'pending', $6, $7, $8
+) RETURNING id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role, phone, phone_verified_at, date_of_birth, address_line1, address_line2, address_city, address_postal_code, address_region, address_country, nationality, tax_residency, email_index, pii_key, pii_key_version, status, status_reason, status_expires_at, status_set_by, status_set_at
 `
 
 type CreateUserParams struct {
@@ -43,9 +62,13 @@ type CreateUserParams struct {
 	LastName       string      `json:"last_name"`
 	HashedPassword string      `json:"hashed_password"`
 	Column5        interface{} `json:"column_5"`
+	EmailIndex     []byte      `json:"email_index"`
+	PiiKey         *string     `json:"pii_key"`
+	PiiKeyVersion  *int32      `json:"pii_key_version"`
 }
 
 // CreateUser creates a new user with the provided email, first name, last name, and hashed password.
+// email_index, pii_key and pii_key_version are NULL when PII encryption is disabled.
 // Returns the created user record.
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
 	row := q.db.QueryRow(ctx, createUser,
@@ -54,6 +77,9 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.LastName,
 		arg.HashedPassword,
 		arg.Column5,
+		arg.EmailIndex,
+		arg.PiiKey,
+		arg.PiiKeyVersion,
 	)
 	var i User
 	err := row.Scan(
@@ -67,12 +93,31 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.FirstName,
 		&i.LastName,
 		&i.Role,
+		&i.Phone,
+		&i.PhoneVerifiedAt,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressPostalCode,
+		&i.AddressRegion,
+		&i.AddressCountry,
+		&i.Nationality,
+		&i.TaxResidency,
+		&i.EmailIndex,
+		&i.PiiKey,
+		&i.PiiKeyVersion,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusExpiresAt,
+		&i.StatusSetBy,
+		&i.StatusSetAt,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role FROM users
+SELECT id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role, phone, phone_verified_at, date_of_birth, address_line1, address_line2, address_city, address_postal_code, address_region, address_country, nationality, tax_residency, email_index, pii_key, pii_key_version, status, status_reason, status_expires_at, status_set_by, status_set_at FROM users
 WHERE email = $1 AND deleted_at IS NULL
 `
 
@@ -92,12 +137,75 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.FirstName,
 		&i.LastName,
 		&i.Role,
+		&i.Phone,
+		&i.PhoneVerifiedAt,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressPostalCode,
+		&i.AddressRegion,
+		&i.AddressCountry,
+		&i.Nationality,
+		&i.TaxResidency,
+		&i.EmailIndex,
+		&i.PiiKey,
+		&i.PiiKeyVersion,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusExpiresAt,
+		&i.StatusSetBy,
+		&i.StatusSetAt,
+	)
+	return i, err
+}
+
+const getUserByEmailIndex = `-- name: GetUserByEmailIndex :one
+SELECT id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role, phone, phone_verified_at, date_of_birth, address_line1, address_line2, address_city, address_postal_code, address_region, address_country, nationality, tax_residency, email_index, pii_key, pii_key_version, status, status_reason, status_expires_at, status_set_by, status_set_at FROM users
+WHERE email_index = $1 AND deleted_at IS NULL
+`
+
+// GetUserByEmailIndex retrieves a user by the blind index of their encrypted email.
+// Returns error if user not found or soft-deleted.
+func (q *Queries) GetUserByEmailIndex(ctx context.Context, emailIndex []byte) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmailIndex, emailIndex)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.HashedPassword,
+		&i.KycStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.FirstName,
+		&i.LastName,
+		&i.Role,
+		&i.Phone,
+		&i.PhoneVerifiedAt,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressPostalCode,
+		&i.AddressRegion,
+		&i.AddressCountry,
+		&i.Nationality,
+		&i.TaxResidency,
+		&i.EmailIndex,
+		&i.PiiKey,
+		&i.PiiKeyVersion,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusExpiresAt,
+		&i.StatusSetBy,
+		&i.StatusSetAt,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role FROM users
+SELECT id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role, phone, phone_verified_at, date_of_birth, address_line1, address_line2, address_city, address_postal_code, address_region, address_country, nationality, tax_residency, email_index, pii_key, pii_key_version, status, status_reason, status_expires_at, status_set_by, status_set_at FROM users
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -117,12 +225,31 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.FirstName,
 		&i.LastName,
 		&i.Role,
+		&i.Phone,
+		&i.PhoneVerifiedAt,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressPostalCode,
+		&i.AddressRegion,
+		&i.AddressCountry,
+		&i.Nationality,
+		&i.TaxResidency,
+		&i.EmailIndex,
+		&i.PiiKey,
+		&i.PiiKeyVersion,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusExpiresAt,
+		&i.StatusSetBy,
+		&i.StatusSetAt,
 	)
 	return i, err
 }
 
 const getUserByIDIncludeDeleted = `-- name: GetUserByIDIncludeDeleted :one
-SELECT id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role FROM users
+SELECT id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role, phone, phone_verified_at, date_of_birth, address_line1, address_line2, address_city, address_postal_code, address_region, address_country, nationality, tax_residency, email_index, pii_key, pii_key_version, status, status_reason, status_expires_at, status_set_by, status_set_at FROM users
 WHERE id = $1
 `
 
@@ -141,12 +268,49 @@ func (q *Queries) GetUserByIDIncludeDeleted(ctx context.Context, id uuid.UUID) (
 		&i.FirstName,
 		&i.LastName,
 		&i.Role,
+		&i.Phone,
+		&i.PhoneVerifiedAt,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressPostalCode,
+		&i.AddressRegion,
+		&i.AddressCountry,
+		&i.Nationality,
+		&i.TaxResidency,
+		&i.EmailIndex,
+		&i.PiiKey,
+		&i.PiiKeyVersion,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusExpiresAt,
+		&i.StatusSetBy,
+		&i.StatusSetAt,
 	)
 	return i, err
 }
 
+const getUserPIIKey = `-- name: GetUserPIIKey :one
+SELECT pii_key, pii_key_version FROM users
+WHERE id = $1
+`
+
+type GetUserPIIKeyRow struct {
+	PiiKey        *string `json:"pii_key"`
+	PiiKeyVersion *int32  `json:"pii_key_version"`
+}
+
+// GetUserPIIKey retrieves the wrapped data key of a user, including soft-deleted users.
+func (q *Queries) GetUserPIIKey(ctx context.Context, id uuid.UUID) (GetUserPIIKeyRow, error) {
+	row := q.db.QueryRow(ctx, getUserPIIKey, id)
+	var i GetUserPIIKeyRow
+	err := row.Scan(&i.PiiKey, &i.PiiKeyVersion)
+	return i, err
+}
+
 const listUsers = `-- name: ListUsers :many
-SELECT id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role FROM users
+SELECT id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role, phone, phone_verified_at, date_of_birth, address_line1, address_line2, address_city, address_postal_code, address_region, address_country, nationality, tax_residency, email_index, pii_key, pii_key_version, status, status_reason, status_expires_at, status_set_by, status_set_at FROM users
 WHERE deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -179,6 +343,25 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.FirstName,
 			&i.LastName,
 			&i.Role,
+			&i.Phone,
+			&i.PhoneVerifiedAt,
+			&i.DateOfBirth,
+			&i.AddressLine1,
+			&i.AddressLine2,
+			&i.AddressCity,
+			&i.AddressPostalCode,
+			&i.AddressRegion,
+			&i.AddressCountry,
+			&i.Nationality,
+			&i.TaxResidency,
+			&i.EmailIndex,
+			&i.PiiKey,
+			&i.PiiKeyVersion,
+			&i.Status,
+			&i.StatusReason,
+			&i.StatusExpiresAt,
+			&i.StatusSetBy,
+			&i.StatusSetAt,
 		); err != nil {
 			return nil, err
 		}
@@ -190,27 +373,302 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 	return items, nil
 }
 
-const searchUsers = `-- name: SearchUsers :many
-SELECT id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role FROM users
-WHERE deleted_at IS NULL
-AND (
-    email ILIKE '%' || $1 || '%'
-    OR first_name ILIKE '%' || $1 || '%'
-    OR last_name ILIKE '%' || $1 || '%'
+const listUsersForPIIReencryption = `-- name: ListUsersForPIIReencryption :many
+SELECT id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role, phone, phone_verified_at, date_of_birth, address_line1, address_line2, address_city, address_postal_code, address_region, address_country, nationality, tax_residency, email_index, pii_key, pii_key_version, status, status_reason, status_expires_at, status_set_by, status_set_at FROM users
+WHERE (email_index IS NULL
+       OR pii_key_version < $1::int)
+  AND NOT EXISTS (SELECT 1 FROM user_erasures e WHERE e.user_id = users.id AND e.status = 'erased')
+ORDER BY id
+LIMIT $2
+`
+
+type ListUsersForPIIReencryptionParams struct {
+	CurrentVersion int32 `json:"current_version"`
+	LimitCount     int32 `json:"limit_count"`
+}
+
+// ListUsersForPIIReencryption lists users, including deleted ones, that still hold plaintext PII
+// or whose data key is wrapped with a key version older than current_version.
+// Erased users hold no PII and are skipped.
+func (q *Queries) ListUsersForPIIReencryption(ctx context.Context, arg ListUsersForPIIReencryptionParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersForPIIReencryption, arg.CurrentVersion, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.HashedPassword,
+			&i.KycStatus,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.FirstName,
+			&i.LastName,
+			&i.Role,
+			&i.Phone,
+			&i.PhoneVerifiedAt,
+			&i.DateOfBirth,
+			&i.AddressLine1,
+			&i.AddressLine2,
+			&i.AddressCity,
+			&i.AddressPostalCode,
+			&i.AddressRegion,
+			&i.AddressCountry,
+			&i.Nationality,
+			&i.TaxResidency,
+			&i.EmailIndex,
+			&i.PiiKey,
+			&i.PiiKeyVersion,
+			&i.Status,
+			&i.StatusReason,
+			&i.StatusExpiresAt,
+			&i.StatusSetBy,
+			&i.StatusSetAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreUser = `-- name: RestoreUser :one
+WITH cleared AS (
+    DELETE FROM user_erasures
+    WHERE user_erasures.user_id = $1
+      AND user_erasures.status = 'deferred'
+      AND EXISTS (SELECT 1 FROM users u WHERE u.id = $1 AND u.deleted_at = $2)
 )
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3
+UPDATE users
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at = $2
+  AND NOT EXISTS (SELECT 1 FROM user_erasures e WHERE e.user_id = users.id AND e.status = 'erased')
+RETURNING id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role, phone, phone_verified_at, date_of_birth, address_line1, address_line2, address_city, address_postal_code, address_region, address_country, nationality, tax_residency, email_index, pii_key, pii_key_version, status, status_reason, status_expires_at, status_set_by, status_set_at
 `
 
-type SearchUsersParams struct {
-	Column1 *string `json:"column_1"`
-	Limit   int32   `json:"limit"`
-	Offset  int32   `json:"offset"`
+type RestoreUserParams struct {
+	ID        uuid.UUID          `json:"id"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 }
 
-// SearchUsers searches users by email, first name, or last name.
-func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error) {
-	rows, err := q.db.Query(ctx, searchUsers, arg.Column1, arg.Limit, arg.Offset)
+// RestoreUser undeletes a user deleted at deleted_at and drops a deferred erasure of them.
+// Returns no rows if the user is not deleted at deleted_at or has been erased.
+func (q *Queries) RestoreUser(ctx context.Context, arg RestoreUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, restoreUser, arg.ID, arg.DeletedAt)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.HashedPassword,
+		&i.KycStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.FirstName,
+		&i.LastName,
+		&i.Role,
+		&i.Phone,
+		&i.PhoneVerifiedAt,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressPostalCode,
+		&i.AddressRegion,
+		&i.AddressCountry,
+		&i.Nationality,
+		&i.TaxResidency,
+		&i.EmailIndex,
+		&i.PiiKey,
+		&i.PiiKeyVersion,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusExpiresAt,
+		&i.StatusSetBy,
+		&i.StatusSetAt,
+	)
+	return i, err
+}
+
+const searchUsersByRelevance = `-- name: SearchUsersByRelevance :many
+SELECT users.id, users.email, users.hashed_password, users.kyc_status, users.created_at, users.updated_at, users.deleted_at, users.first_name, users.last_name, users.role, users.phone, users.phone_verified_at, users.date_of_birth, users.address_line1, users.address_line2, users.address_city, users.address_postal_code, users.address_region, users.address_country, users.nationality, users.tax_residency, users.email_index, users.pii_key, users.pii_key_version, users.status, users.status_reason, users.status_expires_at, users.status_set_by, users.status_set_at, r.rank
+FROM users,
+LATERAL (SELECT (
+    CASE WHEN users.id = $1::uuid OR users.email_index = $2 THEN 1 ELSE 0 END
+    + ts_rank(to_tsvector('simple', user_search_text(users.email, users.first_name, users.last_name)), websearch_to_tsquery('simple', $3::text))
+    + word_similarity($3::text, user_search_text(users.email, users.first_name, users.last_name))
+)::float8 AS rank) r
+WHERE (user_search_text(users.email, users.first_name, users.last_name) LIKE $4::text
+       OR to_tsvector('simple', user_search_text(users.email, users.first_name, users.last_name)) @@ websearch_to_tsquery('simple', $3::text)
+       OR users.email_index = $2
+       OR users.id = $1::uuid)
+  AND ($5::text IS NULL OR users.role = $5::text)
+  AND ($6::text IS NULL OR users.kyc_status = $6::text)
+  AND ($7::text IS NULL OR users.status = $7::text)
+  AND ($8::timestamptz IS NULL OR users.created_at >= $8::timestamptz)
+  AND ($9::timestamptz IS NULL OR users.created_at < $9::timestamptz)
+  AND ($10::text = 'include' OR (users.deleted_at IS NOT NULL) = ($10::text = 'only'))
+  AND ($11::float8 IS NULL
+       OR (r.rank, users.id) < ($11::float8, $12::uuid))
+ORDER BY r.rank DESC, users.id DESC
+LIMIT $13
+`
+
+type SearchUsersByRelevanceParams struct {
+	QueryID      pgtype.UUID        `json:"query_id"`
+	EmailIndex   []byte             `json:"email_index"`
+	Query        string             `json:"query"`
+	QueryPattern string             `json:"query_pattern"`
+	Role         *string            `json:"role"`
+	KycStatus    *string            `json:"kyc_status"`
+	Status       *string            `json:"status"`
+	CreatedFrom  pgtype.Timestamptz `json:"created_from"`
+	CreatedTo    pgtype.Timestamptz `json:"created_to"`
+	Deleted      string             `json:"deleted"`
+	AfterRank    *float64           `json:"after_rank"`
+	AfterID      pgtype.UUID        `json:"after_id"`
+	LimitCount   int32              `json:"limit_count"`
+}
+
+type SearchUsersByRelevanceRow struct {
+	User User    `json:"user"`
+	Rank float64 `json:"rank"`
+}
+
+// SearchUsersByRelevance searches users for admins, best match first.
+// Takes the same query and filters as SearchUsersNewest, but the query is required. Rank adds
+// full-text rank and trigram word similarity, plus 1 for an exact ID or email match.
+// Pass the rank and id of the last user of the previous page, or NULL for the first page.
+func (q *Queries) SearchUsersByRelevance(ctx context.Context, arg SearchUsersByRelevanceParams) ([]SearchUsersByRelevanceRow, error) {
+	rows, err := q.db.Query(ctx, searchUsersByRelevance,
+		arg.QueryID,
+		arg.EmailIndex,
+		arg.Query,
+		arg.QueryPattern,
+		arg.Role,
+		arg.KycStatus,
+		arg.Status,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Deleted,
+		arg.AfterRank,
+		arg.AfterID,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchUsersByRelevanceRow{}
+	for rows.Next() {
+		var i SearchUsersByRelevanceRow
+		if err := rows.Scan(
+			&i.User.ID,
+			&i.User.Email,
+			&i.User.HashedPassword,
+			&i.User.KycStatus,
+			&i.User.CreatedAt,
+			&i.User.UpdatedAt,
+			&i.User.DeletedAt,
+			&i.User.FirstName,
+			&i.User.LastName,
+			&i.User.Role,
+			&i.User.Phone,
+			&i.User.PhoneVerifiedAt,
+			&i.User.DateOfBirth,
+			&i.User.AddressLine1,
+			&i.User.AddressLine2,
+			&i.User.AddressCity,
+			&i.User.AddressPostalCode,
+			&i.User.AddressRegion,
+			&i.User.AddressCountry,
+			&i.User.Nationality,
+			&i.User.TaxResidency,
+			&i.User.EmailIndex,
+			&i.User.PiiKey,
+			&i.User.PiiKeyVersion,
+			&i.User.Status,
+			&i.User.StatusReason,
+			&i.User.StatusExpiresAt,
+			&i.User.StatusSetBy,
+			&i.User.StatusSetAt,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchUsersNewest = `-- name: SearchUsersNewest :many
+SELECT id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role, phone, phone_verified_at, date_of_birth, address_line1, address_line2, address_city, address_postal_code, address_region, address_country, nationality, tax_residency, email_index, pii_key, pii_key_version, status, status_reason, status_expires_at, status_set_by, status_set_at FROM users
+WHERE ($1::text = ''
+       OR user_search_text(email, first_name, last_name) LIKE $2::text
+       OR to_tsvector('simple', user_search_text(email, first_name, last_name)) @@ websearch_to_tsquery('simple', $1::text)
+       OR email_index = $3
+       OR id = $4::uuid)
+  AND ($5::text IS NULL OR role = $5::text)
+  AND ($6::text IS NULL OR kyc_status = $6::text)
+  AND ($7::text IS NULL OR status = $7::text)
+  AND ($8::timestamptz IS NULL OR created_at >= $8::timestamptz)
+  AND ($9::timestamptz IS NULL OR created_at < $9::timestamptz)
+  AND ($10::text = 'include' OR (deleted_at IS NOT NULL) = ($10::text = 'only'))
+  AND ($11::timestamptz IS NULL
+       OR (created_at, id) < ($11::timestamptz, $12::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT $13
+`
+
+type SearchUsersNewestParams struct {
+	Query          string             `json:"query"`
+	QueryPattern   string             `json:"query_pattern"`
+	EmailIndex     []byte             `json:"email_index"`
+	QueryID        pgtype.UUID        `json:"query_id"`
+	Role           *string            `json:"role"`
+	KycStatus      *string            `json:"kyc_status"`
+	Status         *string            `json:"status"`
+	CreatedFrom    pgtype.Timestamptz `json:"created_from"`
+	CreatedTo      pgtype.Timestamptz `json:"created_to"`
+	Deleted        string             `json:"deleted"`
+	AfterCreatedAt pgtype.Timestamptz `json:"after_created_at"`
+	AfterID        pgtype.UUID        `json:"after_id"`
+	LimitCount     int32              `json:"limit_count"`
+}
+
+// SearchUsersNewest searches users for admins, newest first.
+// The query matches the plaintext email and names by substring (query_pattern, a lowercased
+// LIKE pattern) or full-text search, the exact email of encrypted users through its blind
+// index, and the user ID; pass an empty query to list all users. Pass NULL to skip a filter.
+// deleted is exclude, only or include. Pass the created_at and id of the last user of the
+// previous page, or NULL for the first page.
+func (q *Queries) SearchUsersNewest(ctx context.Context, arg SearchUsersNewestParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, searchUsersNewest,
+		arg.Query,
+		arg.QueryPattern,
+		arg.EmailIndex,
+		arg.QueryID,
+		arg.Role,
+		arg.KycStatus,
+		arg.Status,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Deleted,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.LimitCount,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -229,6 +687,25 @@ func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]Use
 			&i.FirstName,
 			&i.LastName,
 			&i.Role,
+			&i.Phone,
+			&i.PhoneVerifiedAt,
+			&i.DateOfBirth,
+			&i.AddressLine1,
+			&i.AddressLine2,
+			&i.AddressCity,
+			&i.AddressPostalCode,
+			&i.AddressRegion,
+			&i.AddressCountry,
+			&i.Nationality,
+			&i.TaxResidency,
+			&i.EmailIndex,
+			&i.PiiKey,
+			&i.PiiKeyVersion,
+			&i.Status,
+			&i.StatusReason,
+			&i.StatusExpiresAt,
+			&i.StatusSetBy,
+			&i.StatusSetAt,
 		); err != nil {
 			return nil, err
 		}
@@ -240,6 +717,139 @@ func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]Use
 	return items, nil
 }
 
+const searchUsersOldest = `-- name: SearchUsersOldest :many
+SELECT id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role, phone, phone_verified_at, date_of_birth, address_line1, address_line2, address_city, address_postal_code, address_region, address_country, nationality, tax_residency, email_index, pii_key, pii_key_version, status, status_reason, status_expires_at, status_set_by, status_set_at FROM users
+WHERE ($1::text = ''
+       OR user_search_text(email, first_name, last_name) LIKE $2::text
+       OR to_tsvector('simple', user_search_text(email, first_name, last_name)) @@ websearch_to_tsquery('simple', $1::text)
+       OR email_index = $3
+       OR id = $4::uuid)
+  AND ($5::text IS NULL OR role = $5::text)
+  AND ($6::text IS NULL OR kyc_status = $6::text)
+  AND ($7::text IS NULL OR status = $7::text)
+  AND ($8::timestamptz IS NULL OR created_at >= $8::timestamptz)
+  AND ($9::timestamptz IS NULL OR created_at < $9::timestamptz)
+  AND ($10::text = 'include' OR (deleted_at IS NOT NULL) = ($10::text = 'only'))
+  AND ($11::timestamptz IS NULL
+       OR (created_at, id) > ($11::timestamptz, $12::uuid))
+ORDER BY created_at, id
+LIMIT $13
+`
+
+type SearchUsersOldestParams struct {
+	Query          string             `json:"query"`
+	QueryPattern   string             `json:"query_pattern"`
+	EmailIndex     []byte             `json:"email_index"`
+	QueryID        pgtype.UUID        `json:"query_id"`
+	Role           *string            `json:"role"`
+	KycStatus      *string            `json:"kyc_status"`
+	Status         *string            `json:"status"`
+	CreatedFrom    pgtype.Timestamptz `json:"created_from"`
+	CreatedTo      pgtype.Timestamptz `json:"created_to"`
+	Deleted        string             `json:"deleted"`
+	AfterCreatedAt pgtype.Timestamptz `json:"after_created_at"`
+	AfterID        pgtype.UUID        `json:"after_id"`
+	LimitCount     int32              `json:"limit_count"`
+}
+
+// SearchUsersOldest searches users for admins, oldest first.
+// The query matches the plaintext email and names by substring (query_pattern, a lowercased
+// LIKE pattern) or full-text search, the exact email of encrypted users through its blind
+// index, and the user ID; pass an empty query to list all users. Pass NULL to skip a filter.
+// deleted is exclude, only or include. Pass the created_at and id of the last user of the
+// previous page, or NULL for the first page.
+func (q *Queries) SearchUsersOldest(ctx context.Context, arg SearchUsersOldestParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, searchUsersOldest,
+		arg.Query,
+		arg.QueryPattern,
+		arg.EmailIndex,
+		arg.QueryID,
+		arg.Role,
+		arg.KycStatus,
+		arg.Status,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Deleted,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.HashedPassword,
+			&i.KycStatus,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.FirstName,
+			&i.LastName,
+			&i.Role,
+			&i.Phone,
+			&i.PhoneVerifiedAt,
+			&i.DateOfBirth,
+			&i.AddressLine1,
+			&i.AddressLine2,
+			&i.AddressCity,
+			&i.AddressPostalCode,
+			&i.AddressRegion,
+			&i.AddressCountry,
+			&i.Nationality,
+			&i.TaxResidency,
+			&i.EmailIndex,
+			&i.PiiKey,
+			&i.PiiKeyVersion,
+			&i.Status,
+			&i.StatusReason,
+			&i.StatusExpiresAt,
+			&i.StatusSetBy,
+			&i.StatusSetAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setUserPIIKey = `-- name: SetUserPIIKey :one
+UPDATE users
+SET pii_key = $2,
+    pii_key_version = $3
+WHERE id = $1 AND pii_key IS NULL
+RETURNING pii_key, pii_key_version
+`
+
+type SetUserPIIKeyParams struct {
+	ID            uuid.UUID `json:"id"`
+	PiiKey        *string   `json:"pii_key"`
+	PiiKeyVersion *int32    `json:"pii_key_version"`
+}
+
+type SetUserPIIKeyRow struct {
+	PiiKey        *string `json:"pii_key"`
+	PiiKeyVersion *int32  `json:"pii_key_version"`
+}
+
+// SetUserPIIKey stores a wrapped data key for a user that has none yet.
+// Returns no rows if the user does not exist or already has a key.
+func (q *Queries) SetUserPIIKey(ctx context.Context, arg SetUserPIIKeyParams) (SetUserPIIKeyRow, error) {
+	row := q.db.QueryRow(ctx, setUserPIIKey, arg.ID, arg.PiiKey, arg.PiiKeyVersion)
+	var i SetUserPIIKeyRow
+	err := row.Scan(&i.PiiKey, &i.PiiKeyVersion)
+	return i, err
+}
+
 const softDeleteUser = `-- name: SoftDeleteUser :execrows
 UPDATE users
 SET deleted_at = NOW()
@@ -260,7 +870,7 @@ const updateUserKYCStatus = `-- name: UpdateUserKYCStatus :one
 UPDATE users
 SET kyc_status = $2
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role
+RETURNING id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role, phone, phone_verified_at, date_of_birth, address_line1, address_line2, address_city, address_postal_code, address_region, address_country, nationality, tax_residency, email_index, pii_key, pii_key_version, status, status_reason, status_expires_at, status_set_by, status_set_at
 `
 
 type UpdateUserKYCStatusParams struct {
@@ -284,26 +894,154 @@ func (q *Queries) UpdateUserKYCStatus(ctx context.Context, arg UpdateUserKYCStat
 		&i.FirstName,
 		&i.LastName,
 		&i.Role,
+		&i.Phone,
+		&i.PhoneVerifiedAt,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressPostalCode,
+		&i.AddressRegion,
+		&i.AddressCountry,
+		&i.Nationality,
+		&i.TaxResidency,
+		&i.EmailIndex,
+		&i.PiiKey,
+		&i.PiiKeyVersion,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusExpiresAt,
+		&i.StatusSetBy,
+		&i.StatusSetAt,
 	)
 	return i, err
 }
 
+const updateUserPII = `-- name: UpdateUserPII :execrows
+UPDATE users
+SET email = $1,
+    email_index = $2,
+    first_name = $3,
+    last_name = $4,
+    phone = $5,
+    date_of_birth = $6,
+    address_line1 = $7,
+    address_line2 = $8,
+    address_city = $9,
+    address_postal_code = $10,
+    address_region = $11,
+    address_country = $12,
+    nationality = $13,
+    tax_residency = $14,
+    pii_key = $15,
+    pii_key_version = $16
+WHERE id = $17 AND updated_at = $18
+`
+
+type UpdateUserPIIParams struct {
+	Email             string             `json:"email"`
+	EmailIndex        []byte             `json:"email_index"`
+	FirstName         string             `json:"first_name"`
+	LastName          string             `json:"last_name"`
+	Phone             *string            `json:"phone"`
+	DateOfBirth       *string            `json:"date_of_birth"`
+	AddressLine1      *string            `json:"address_line1"`
+	AddressLine2      *string            `json:"address_line2"`
+	AddressCity       *string            `json:"address_city"`
+	AddressPostalCode *string            `json:"address_postal_code"`
+	AddressRegion     *string            `json:"address_region"`
+	AddressCountry    *string            `json:"address_country"`
+	Nationality       *string            `json:"nationality"`
+	TaxResidency      *string            `json:"tax_residency"`
+	PiiKey            *string            `json:"pii_key"`
+	PiiKeyVersion     *int32             `json:"pii_key_version"`
+	ID                uuid.UUID          `json:"id"`
+	ReadUpdatedAt     pgtype.Timestamptz `json:"read_updated_at"`
+}
+
+// UpdateUserPII rewrites a user's PII columns and data key.
+// Only applies if the row was not updated since it was read (updated_at is unchanged).
+func (q *Queries) UpdateUserPII(ctx context.Context, arg UpdateUserPIIParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateUserPII,
+		arg.Email,
+		arg.EmailIndex,
+		arg.FirstName,
+		arg.LastName,
+		arg.Phone,
+		arg.DateOfBirth,
+		arg.AddressLine1,
+		arg.AddressLine2,
+		arg.AddressCity,
+		arg.AddressPostalCode,
+		arg.AddressRegion,
+		arg.AddressCountry,
+		arg.Nationality,
+		arg.TaxResidency,
+		arg.PiiKey,
+		arg.PiiKeyVersion,
+		arg.ID,
+		arg.ReadUpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateUserProfile = `-- name: UpdateUserProfile :one
 UPDATE users
-SET first_name = $2, last_name = $3
+SET first_name = $2,
+    last_name = $3,
+    phone = $4,
+    phone_verified_at = $5,
+    date_of_birth = $6,
+    address_line1 = $7,
+    address_line2 = $8,
+    address_city = $9,
+    address_postal_code = $10,
+    address_region = $11,
+    address_country = $12,
+    nationality = $13,
+    tax_residency = $14
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role
+RETURNING id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role, phone, phone_verified_at, date_of_birth, address_line1, address_line2, address_city, address_postal_code, address_region, address_country, nationality, tax_residency, email_index, pii_key, pii_key_version, status, status_reason, status_expires_at, status_set_by, status_set_at
 `
 
 type UpdateUserProfileParams struct {
-	ID        uuid.UUID `json:"id"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
+	ID                uuid.UUID          `json:"id"`
+	FirstName         string             `json:"first_name"`
+	LastName          string             `json:"last_name"`
+	Phone             *string            `json:"phone"`
+	PhoneVerifiedAt   pgtype.Timestamptz `json:"phone_verified_at"`
+	DateOfBirth       *string            `json:"date_of_birth"`
+	AddressLine1      *string            `json:"address_line1"`
+	AddressLine2      *string            `json:"address_line2"`
+	AddressCity       *string            `json:"address_city"`
+	AddressPostalCode *string            `json:"address_postal_code"`
+	AddressRegion     *string            `json:"address_region"`
+	AddressCountry    *string            `json:"address_country"`
+	Nationality       *string            `json:"nationality"`
+	TaxResidency      *string            `json:"tax_residency"`
 }
 
-// UpdateUserProfile updates user's profile information (first_name and last_name).
+// UpdateUserProfile replaces the user's profile fields; NULL clears an optional field.
 func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error) {
-	row := q.db.QueryRow(ctx, updateUserProfile, arg.ID, arg.FirstName, arg.LastName)
+	row := q.db.QueryRow(ctx, updateUserProfile,
+		arg.ID,
+		arg.FirstName,
+		arg.LastName,
+		arg.Phone,
+		arg.PhoneVerifiedAt,
+		arg.DateOfBirth,
+		arg.AddressLine1,
+		arg.AddressLine2,
+		arg.AddressCity,
+		arg.AddressPostalCode,
+		arg.AddressRegion,
+		arg.AddressCountry,
+		arg.Nationality,
+		arg.TaxResidency,
+	)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -316,6 +1054,25 @@ func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfilePa
 		&i.FirstName,
 		&i.LastName,
 		&i.Role,
+		&i.Phone,
+		&i.PhoneVerifiedAt,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressPostalCode,
+		&i.AddressRegion,
+		&i.AddressCountry,
+		&i.Nationality,
+		&i.TaxResidency,
+		&i.EmailIndex,
+		&i.PiiKey,
+		&i.PiiKeyVersion,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusExpiresAt,
+		&i.StatusSetBy,
+		&i.StatusSetAt,
 	)
 	return i, err
 }
@@ -324,7 +1081,7 @@ const updateUserRole = `-- name: UpdateUserRole :one
 UPDATE users
 SET role = $2
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role
+RETURNING id, email, hashed_password, kyc_status, created_at, updated_at, deleted_at, first_name, last_name, role, phone, phone_verified_at, date_of_birth, address_line1, address_line2, address_city, address_postal_code, address_region, address_country, nationality, tax_residency, email_index, pii_key, pii_key_version, status, status_reason, status_expires_at, status_set_by, status_set_at
 `
 
 type UpdateUserRoleParams struct {
@@ -347,6 +1104,37 @@ func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams)
 		&i.FirstName,
 		&i.LastName,
 		&i.Role,
+		&i.Phone,
+		&i.PhoneVerifiedAt,
+		&i.DateOfBirth,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressPostalCode,
+		&i.AddressRegion,
+		&i.AddressCountry,
+		&i.Nationality,
+		&i.TaxResidency,
+		&i.EmailIndex,
+		&i.PiiKey,
+		&i.PiiKeyVersion,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusExpiresAt,
+		&i.StatusSetBy,
+		&i.StatusSetAt,
 	)
 	return i, err
 }
+
+const userEmailExists = `-- name: UserEmailExists :one
+SELECT EXISTS (SELECT 1 FROM users WHERE email = $1 AND deleted_at IS NULL)
+`
+
+// UserEmailExists reports whether an active user holds the plaintext email.
+func (q *Queries) UserEmailExists(ctx context.Context, email string) (bool, error) {
+	row := q.db.QueryRow(ctx, userEmailExists, email)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AlertRepository implements alert.Repository using sqlc
+type AlertRepository struct {
+	pool    *pgxpool.Pool
+	queries *postgres.Queries
+	logger  *observability.Logger
+}
+
+// NewAlertRepository creates a new AlertRepository instance
+func NewAlertRepository(pool *pgxpool.Pool, logger *observability.Logger) *AlertRepository {
+	return &AlertRepository{
+		pool:    pool,
+		queries: postgres.New(pool),
+		logger:  logger,
+	}
+}
+
+// Record stores a fired alert, folding it into the open alert with the same dedup key if there is one
+func (r *AlertRepository) Record(ctx context.Context, a *alert.Alert) (*alert.Alert, error) {
+	events, err := json.Marshal(a.Events)
+	if err != nil {
+		r.logger.WithError(err).WithField("rule", a.RuleName).Error("failed to marshal alert events")
+		return nil, fmt.Errorf("failed to marshal alert events: %w", err)
+	}
+
+	row, err := r.queries.UpsertOpenAlert(ctx, postgres.UpsertOpenAlertParams{
+		RuleName:    a.RuleName,
+		Severity:    string(a.Severity),
+		Summary:     a.Summary,
+		GroupBy:     string(a.GroupBy),
+		GroupValue:  a.GroupValue,
+		DedupKey:    a.DedupKey,
+		EventCount:  int32(a.EventCount),
+		FirstSeenAt: pgtype.Timestamptz{Time: a.FirstSeenAt, Valid: true},
+		LastSeenAt:  pgtype.Timestamptz{Time: a.LastSeenAt, Valid: true},
+		Events:      events,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("dedup_key", a.DedupKey).Error("failed to record alert")
+		return nil, fmt.Errorf("failed to record alert: %w", err)
+	}
+	return toDomainAlert(&row)
+}
+
+// GetByID retrieves an alert by ID
+func (r *AlertRepository) GetByID(ctx context.Context, id uuid.UUID) (*alert.Alert, error) {
+	row, err := r.queries.GetAlertByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, alert.ErrAlertNotFound
+		}
+		r.logger.WithError(err).WithField("alert_id", id.String()).Error("failed to get alert")
+		return nil, fmt.Errorf("failed to get alert: %w", err)
+	}
+	return toDomainAlert(&row)
+}
+
+// List retrieves alerts, newest first, optionally filtered by status
+func (r *AlertRepository) List(ctx context.Context, status *alert.Status, limit, offset int32) ([]*alert.Alert, error) {
+	params := postgres.ListAlertsParams{
+		LimitCount:  limit,
+		OffsetCount: offset,
+	}
+	if status != nil {
+		s := string(*status)
+		params.Status = &s
+	}
+
+	rows, err := r.queries.ListAlerts(ctx, params)
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list alerts")
+		return nil, fmt.Errorf("failed to list alerts: %w", err)
+	}
+
+	alerts := make([]*alert.Alert, len(rows))
+	for i := range rows {
+		if alerts[i], err = toDomainAlert(&rows[i]); err != nil {
+			return nil, err
+		}
+	}
+	return alerts, nil
+}
+
+// Acknowledge moves an open alert to acknowledged.
+// Returns alert.ErrAlertNotFound if the alert does not exist and
+// alert.ErrAlreadyAcknowledged if it is no longer open.
+func (r *AlertRepository) Acknowledge(ctx context.Context, id uuid.UUID, acknowledgedBy string, note *string) (*alert.Alert, error) {
+	row, err := r.queries.AcknowledgeAlert(ctx, postgres.AcknowledgeAlertParams{
+		ID:             id,
+		AcknowledgedBy: &acknowledgedBy,
+		AckNote:        note,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if _, getErr := r.GetByID(ctx, id); getErr != nil {
+				return nil, getErr
+			}
+			return nil, alert.ErrAlreadyAcknowledged
+		}
+		r.logger.WithError(err).WithField("alert_id", id.String()).Error("failed to acknowledge alert")
+		return nil, fmt.Errorf("failed to acknowledge alert: %w", err)
+	}
+	return toDomainAlert(&row)
+}
+
+// toDomainAlert converts sqlc Alert to domain alert.Alert
+func toDomainAlert(row *postgres.Alert) (*alert.Alert, error) {
+	a := &alert.Alert{
+		ID:             row.ID,
+		RuleName:       row.RuleName,
+		Severity:       alert.Severity(row.Severity),
+		Summary:        row.Summary,
+		GroupBy:        alert.GroupBy(row.GroupBy),
+		GroupValue:     row.GroupValue,
+		DedupKey:       row.DedupKey,
+		EventCount:     int(row.EventCount),
+		FireCount:      int(row.FireCount),
+		FirstSeenAt:    row.FirstSeenAt.Time,
+		LastSeenAt:     row.LastSeenAt.Time,
+		Status:         alert.Status(row.Status),
+		CreatedAt:      row.CreatedAt.Time,
+		AcknowledgedBy: row.AcknowledgedBy,
+		AckNote:        row.AckNote,
+	}
+	if len(row.Events) > 0 {
+		if err := json.Unmarshal(row.Events, &a.Events); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal alert events: %w", err)
+		}
+	}
+	if row.AcknowledgedAt.Valid {
+		a.AcknowledgedAt = &row.AcknowledgedAt.Time
+	}
+	return a, nil
+}
@@ -0,0 +1,380 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// auditLogColumns lists audit_logs columns in table order.
+// Used for queries against detached partitions, whose names are only known at runtime.
+var auditLogColumns = []string{
+	"id", "event_type", "event_category", "severity", "user_id", "actor_type", "actor_identifier",
+	"action", "resource_type", "resource_id", "ip_address", "user_agent", "request_id", "session_id",
+	"metadata", "previous_state", "new_state", "status", "failure_reason", "retention_until",
+	"is_sensitive", "created_at",
+}
+
+// AuditPartitionRepository implements audit.PartitionRepository.
+// Partition DDL lives in SQL functions (see migration 000006) and is called through sqlc;
+// reads from detached partitions and bulk restores use the pool directly.
+type AuditPartitionRepository struct {
+	pool    *pgxpool.Pool
+	queries *postgres.Queries
+	logger  *observability.Logger
+	// converter reuses the audit log row mapping
+	converter *AuditRepository
+}
+
+// NewAuditPartitionRepository creates a new AuditPartitionRepository instance
+func NewAuditPartitionRepository(pool *pgxpool.Pool, logger *observability.Logger) *AuditPartitionRepository {
+	return &AuditPartitionRepository{
+		pool:      pool,
+		queries:   postgres.New(pool),
+		logger:    logger,
+		converter: &AuditRepository{logger: logger},
+	}
+}
+
+// EnsurePartitions creates every missing monthly partition between from and to
+func (r *AuditPartitionRepository) EnsurePartitions(ctx context.Context, from, to time.Time) (int, error) {
+	created, err := r.queries.EnsureAuditLogPartitions(ctx, postgres.EnsureAuditLogPartitionsParams{
+		FromMonth: audit.MonthStart(from),
+		ToMonth:   audit.MonthStart(to),
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to ensure audit log partitions")
+		return 0, fmt.Errorf("failed to ensure audit log partitions: %w", err)
+	}
+	return int(created), nil
+}
+
+// ListPartitions returns all partition tables, attached or detached
+func (r *AuditPartitionRepository) ListPartitions(ctx context.Context) ([]*audit.Partition, error) {
+	rows, err := r.queries.ListAuditLogPartitions(ctx)
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list audit log partitions")
+		return nil, fmt.Errorf("failed to list audit log partitions: %w", err)
+	}
+
+	partitions := make([]*audit.Partition, 0, len(rows))
+	for _, row := range rows {
+		month, err := audit.ParsePartitionName(row.PartitionName)
+		if err != nil {
+			r.logger.WithError(err).WithField("partition", row.PartitionName).Warn("skipping unrecognised audit log partition")
+			continue
+		}
+		partitions = append(partitions, &audit.Partition{
+			Name:        row.PartitionName,
+			Month:       month,
+			Attached:    row.IsAttached,
+			RowEstimate: row.RowEstimate,
+		})
+	}
+	return partitions, nil
+}
+
+// CreatePartition creates an empty partition for a month
+func (r *AuditPartitionRepository) CreatePartition(ctx context.Context, month time.Time) error {
+	if _, err := r.queries.CreateAuditLogPartition(ctx, audit.MonthStart(month)); err != nil {
+		r.logger.WithError(err).WithField("partition", audit.PartitionName(month)).Error("failed to create audit log partition")
+		return fmt.Errorf("failed to create audit log partition: %w", err)
+	}
+	return nil
+}
+
+// DetachPartition detaches a month from audit_logs; detaching an already detached month is a no-op
+func (r *AuditPartitionRepository) DetachPartition(ctx context.Context, month time.Time) error {
+	if _, err := r.queries.DetachAuditLogPartition(ctx, audit.MonthStart(month)); err != nil {
+		r.logger.WithError(err).WithField("partition", audit.PartitionName(month)).Error("failed to detach audit log partition")
+		return fmt.Errorf("failed to detach audit log partition: %w", err)
+	}
+	return nil
+}
+
+// StreamPartition reads every row of a month's partition table in created_at order
+func (r *AuditPartitionRepository) StreamPartition(ctx context.Context, month time.Time, fn func(*audit.Log) error) error {
+	name := audit.PartitionName(month)
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s ORDER BY created_at, id",
+		joinIdentifiers(auditLogColumns),
+		pgx.Identifier{name}.Sanitize(),
+	)
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		r.logger.WithError(err).WithField("partition", name).Error("failed to read audit log partition")
+		return fmt.Errorf("failed to read audit log partition: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row postgres.AuditLog
+		if err := rows.Scan(
+			&row.ID,
+			&row.EventType,
+			&row.EventCategory,
+			&row.Severity,
+			&row.UserID,
+			&row.ActorType,
+			&row.ActorIdentifier,
+			&row.Action,
+			&row.ResourceType,
+			&row.ResourceID,
+			&row.IpAddress,
+			&row.UserAgent,
+			&row.RequestID,
+			&row.SessionID,
+			&row.Metadata,
+			&row.PreviousState,
+			&row.NewState,
+			&row.Status,
+			&row.FailureReason,
+			&row.RetentionUntil,
+			&row.IsSensitive,
+			&row.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("failed to scan audit log: %w", err)
+		}
+
+		log, err := r.converter.toDomainAuditLog(&row)
+		if err != nil {
+			return err
+		}
+		if err := fn(log); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read audit log partition: %w", err)
+	}
+	return nil
+}
+
+// DropPartition drops a month's partition table
+func (r *AuditPartitionRepository) DropPartition(ctx context.Context, month time.Time) error {
+	if _, err := r.queries.DropAuditLogPartition(ctx, audit.MonthStart(month)); err != nil {
+		r.logger.WithError(err).WithField("partition", audit.PartitionName(month)).Error("failed to drop audit log partition")
+		return fmt.Errorf("failed to drop audit log partition: %w", err)
+	}
+	return nil
+}
+
+// InsertLogs bulk-inserts archived entries with COPY, preserving IDs and timestamps
+func (r *AuditPartitionRepository) InsertLogs(ctx context.Context, logs []*audit.Log) (int64, error) {
+	if len(logs) == 0 {
+		return 0, nil
+	}
+
+	copied, err := r.pool.CopyFrom(ctx, pgx.Identifier{"audit_logs"}, auditLogColumns, pgx.CopyFromSlice(len(logs), func(i int) ([]any, error) {
+		return auditLogCopyRow(logs[i])
+	}))
+	if err != nil {
+		r.logger.WithError(err).WithField("count", len(logs)).Error("failed to insert archived audit logs")
+		return 0, fmt.Errorf("failed to insert archived audit logs: %w", err)
+	}
+	return copied, nil
+}
+
+// CreateArchive records (or replaces) the archive of a month
+func (r *AuditPartitionRepository) CreateArchive(ctx context.Context, archive *audit.Archive) (*audit.Archive, error) {
+	row, err := r.queries.CreateAuditLogArchive(ctx, postgres.CreateAuditLogArchiveParams{
+		PartitionMonth: monthParam(archive.Month),
+		PartitionName:  archive.PartitionName,
+		ObjectKey:      archive.ObjectKey,
+		ManifestKey:    archive.ManifestKey,
+		RowCount:       archive.RowCount,
+		SizeBytes:      archive.SizeBytes,
+		ChecksumSha256: archive.Checksum,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("partition", archive.PartitionName).Error("failed to record audit log archive")
+		return nil, fmt.Errorf("failed to record audit log archive: %w", err)
+	}
+	return toDomainAuditArchive(&row), nil
+}
+
+// ReplaceArchiveObject points the archive of a month at a rewritten object, keeping its restored flag
+func (r *AuditPartitionRepository) ReplaceArchiveObject(ctx context.Context, archive *audit.Archive, previousObjectKey string) (*audit.Archive, error) {
+	row, err := r.queries.ReplaceAuditLogArchiveObject(ctx, postgres.ReplaceAuditLogArchiveObjectParams{
+		ObjectKey:         archive.ObjectKey,
+		ManifestKey:       archive.ManifestKey,
+		RowCount:          archive.RowCount,
+		SizeBytes:         archive.SizeBytes,
+		ChecksumSha256:    archive.Checksum,
+		PartitionMonth:    monthParam(archive.Month),
+		PreviousObjectKey: previousObjectKey,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, audit.ErrArchiveChanged
+		}
+		r.logger.WithError(err).WithField("partition", archive.PartitionName).Error("failed to replace audit log archive object")
+		return nil, fmt.Errorf("failed to replace audit log archive object: %w", err)
+	}
+	return toDomainAuditArchive(&row), nil
+}
+
+// GetArchive retrieves the archive of a month
+func (r *AuditPartitionRepository) GetArchive(ctx context.Context, month time.Time) (*audit.Archive, error) {
+	row, err := r.queries.GetAuditLogArchiveByMonth(ctx, monthParam(month))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, audit.ErrArchiveNotFound
+		}
+		r.logger.WithError(err).WithField("partition", audit.PartitionName(month)).Error("failed to get audit log archive")
+		return nil, fmt.Errorf("failed to get audit log archive: %w", err)
+	}
+	return toDomainAuditArchive(&row), nil
+}
+
+// ListArchives retrieves archive records, newest month first
+func (r *AuditPartitionRepository) ListArchives(ctx context.Context, limit, offset int32) ([]*audit.Archive, error) {
+	rows, err := r.queries.ListAuditLogArchives(ctx, postgres.ListAuditLogArchivesParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list audit log archives")
+		return nil, fmt.Errorf("failed to list audit log archives: %w", err)
+	}
+
+	archives := make([]*audit.Archive, 0, len(rows))
+	for i := range rows {
+		archives = append(archives, toDomainAuditArchive(&rows[i]))
+	}
+	return archives, nil
+}
+
+// MarkArchiveRestored flags a month as restored into audit_logs
+func (r *AuditPartitionRepository) MarkArchiveRestored(ctx context.Context, month time.Time, restoredBy string) (*audit.Archive, error) {
+	row, err := r.queries.MarkAuditLogArchiveRestored(ctx, postgres.MarkAuditLogArchiveRestoredParams{
+		PartitionMonth: monthParam(month),
+		RestoredBy:     &restoredBy,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, audit.ErrArchiveNotFound
+		}
+		r.logger.WithError(err).WithField("partition", audit.PartitionName(month)).Error("failed to mark audit log archive restored")
+		return nil, fmt.Errorf("failed to mark audit log archive restored: %w", err)
+	}
+	return toDomainAuditArchive(&row), nil
+}
+
+// ClearArchiveRestored clears the restored flag of a month
+func (r *AuditPartitionRepository) ClearArchiveRestored(ctx context.Context, month time.Time) (*audit.Archive, error) {
+	row, err := r.queries.ClearAuditLogArchiveRestored(ctx, monthParam(month))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, audit.ErrArchiveNotFound
+		}
+		r.logger.WithError(err).WithField("partition", audit.PartitionName(month)).Error("failed to clear audit log archive restore")
+		return nil, fmt.Errorf("failed to clear audit log archive restore: %w", err)
+	}
+	return toDomainAuditArchive(&row), nil
+}
+
+// monthParam converts a month to the normalised timestamptz key used by audit_log_archives
+func monthParam(month time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: audit.MonthStart(month), Valid: true}
+}
+
+// toDomainAuditArchive converts sqlc AuditLogArchive to domain audit.Archive
+func toDomainAuditArchive(row *postgres.AuditLogArchive) *audit.Archive {
+	archive := &audit.Archive{
+		ID:            row.ID,
+		Month:         row.PartitionMonth.Time.UTC(),
+		PartitionName: row.PartitionName,
+		ObjectKey:     row.ObjectKey,
+		ManifestKey:   row.ManifestKey,
+		RowCount:      row.RowCount,
+		SizeBytes:     row.SizeBytes,
+		Checksum:      row.ChecksumSha256,
+		ArchivedAt:    row.ArchivedAt.Time,
+		RestoredBy:    row.RestoredBy,
+	}
+	if row.RestoredAt.Valid {
+		archive.RestoredAt = &row.RestoredAt.Time
+	}
+	return archive
+}
+
+// auditLogCopyRow converts a domain log into COPY values matching auditLogColumns
+func auditLogCopyRow(log *audit.Log) ([]any, error) {
+	metadataJSON, err := marshalJSON(log.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	previousStateJSON, err := marshalJSON(log.PreviousState)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal previous state: %w", err)
+	}
+	newStateJSON, err := marshalJSON(log.NewState)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal new state: %w", err)
+	}
+
+	var userID pgtype.UUID
+	if log.UserID != nil {
+		userID = pgtype.UUID{Bytes: *log.UserID, Valid: true}
+	}
+
+	var ipAddress *netip.Addr
+	if log.IPAddress != nil {
+		if addr, err := netip.ParseAddr(*log.IPAddress); err == nil {
+			ipAddress = &addr
+		}
+	}
+
+	var retentionUntil pgtype.Timestamptz
+	if log.RetentionUntil != nil {
+		retentionUntil = pgtype.Timestamptz{Time: *log.RetentionUntil, Valid: true}
+	}
+
+	isSensitive := log.IsSensitive
+
+	return []any{
+		log.ID,
+		log.EventType,
+		string(log.EventCategory),
+		string(log.Severity),
+		userID,
+		string(log.ActorType),
+		log.ActorIdentifier,
+		log.Action,
+		log.ResourceType,
+		log.ResourceID,
+		ipAddress,
+		log.UserAgent,
+		log.RequestID,
+		log.SessionID,
+		metadataJSON,
+		previousStateJSON,
+		newStateJSON,
+		string(log.Status),
+		log.FailureReason,
+		retentionUntil,
+		&isSensitive,
+		pgtype.Timestamptz{Time: log.CreatedAt, Valid: true},
+	}, nil
+}
+
+// joinIdentifiers quotes and comma-separates column names
+func joinIdentifiers(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = pgx.Identifier{column}.Sanitize()
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuditRetentionRepository implements audit.RetentionRepository using sqlc
+type AuditRetentionRepository struct {
+	pool    *pgxpool.Pool
+	queries *postgres.Queries
+	logger  *observability.Logger
+}
+
+// NewAuditRetentionRepository creates a new AuditRetentionRepository instance
+func NewAuditRetentionRepository(pool *pgxpool.Pool, logger *observability.Logger) *AuditRetentionRepository {
+	return &AuditRetentionRepository{
+		pool:    pool,
+		queries: postgres.New(pool),
+		logger:  logger,
+	}
+}
+
+// Restamp recomputes retention_until for every audit log that has one.
+// The policy is flattened into rule arrays in match order, so earlier rules get higher priority,
+// and its default becomes a catch-all rule with priority 0.
+func (r *AuditRetentionRepository) Restamp(ctx context.Context, policy *audit.RetentionPolicy) (int64, error) {
+	rules := policy.Rules()
+	params := postgres.RestampAuditLogRetentionParams{
+		EventTypes: make([]string, 0, len(rules)+1),
+		Categories: make([]string, 0, len(rules)+1),
+		Severities: make([]string, 0, len(rules)+1),
+		Days:       make([]int32, 0, len(rules)+1),
+		Priorities: make([]int32, 0, len(rules)+1),
+	}
+	for i, rule := range rules {
+		params.EventTypes = append(params.EventTypes, rule.EventType)
+		params.Categories = append(params.Categories, string(rule.Category))
+		params.Severities = append(params.Severities, string(rule.Severity))
+		params.Days = append(params.Days, int32(rule.Days))                // #nosec G115 -- retention days are small positive numbers
+		params.Priorities = append(params.Priorities, int32(len(rules)-i)) // #nosec G115 -- policies hold a handful of rules
+	}
+	params.EventTypes = append(params.EventTypes, "")
+	params.Categories = append(params.Categories, "")
+	params.Severities = append(params.Severities, "")
+	params.Days = append(params.Days, int32(policy.DefaultDays())) // #nosec G115 -- retention days are small positive numbers
+	params.Priorities = append(params.Priorities, 0)
+
+	updated, err := r.queries.RestampAuditLogRetention(ctx, params)
+	if err != nil {
+		r.logger.WithError(err).Error("failed to restamp audit log retention")
+		return 0, fmt.Errorf("failed to restamp audit log retention: %w", err)
+	}
+	return updated, nil
+}
+
+// SummarizeExpired groups audit logs past retention at asOf by category and severity
+func (r *AuditRetentionRepository) SummarizeExpired(ctx context.Context, asOf time.Time) ([]*audit.ExpiryBucket, error) {
+	rows, err := r.queries.SummarizeExpiredAuditLogs(ctx, asOf)
+	if err != nil {
+		r.logger.WithError(err).WithField("as_of", asOf).Error("failed to summarize expired audit logs")
+		return nil, fmt.Errorf("failed to summarize expired audit logs: %w", err)
+	}
+
+	buckets := make([]*audit.ExpiryBucket, len(rows))
+	for i, row := range rows {
+		buckets[i] = &audit.ExpiryBucket{
+			Category:        audit.EventCategory(row.EventCategory),
+			Severity:        audit.Severity(row.Severity),
+			DeletableCount:  row.DeletableCount,
+			HeldCount:       row.HeldCount,
+			OldestCreatedAt: row.OldestCreatedAt,
+		}
+	}
+	return buckets, nil
+}
@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/dataexport"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Compile-time check to ensure DataExportRepository implements dataexport.Repository
+var _ dataexport.Repository = (*DataExportRepository)(nil)
+
+// DataExportRepository implements dataexport.Repository using sqlc
+type DataExportRepository struct {
+	queries   *postgres.Queries
+	users     *UserRepository
+	kyc       *KYCRepository
+	auditLogs *AuditRepository
+	logger    *observability.Logger
+}
+
+// NewDataExportRepository creates a new DataExportRepository instance
+func NewDataExportRepository(pool *pgxpool.Pool, logger *observability.Logger) *DataExportRepository {
+	return &DataExportRepository{
+		queries:   postgres.New(pool),
+		users:     NewUserRepository(pool, logger),
+		kyc:       NewKYCRepository(pool, logger),
+		auditLogs: NewAuditRepository(pool, logger),
+		logger:    logger,
+	}
+}
+
+// WithFieldEncryption decrypts the PII of exported profiles with enc
+func (r *DataExportRepository) WithFieldEncryption(enc *pii.Encryptor) *DataExportRepository {
+	r.users.WithFieldEncryption(enc)
+	return r
+}
+
+// Create stores a new pending export job.
+// Returns dataexport.ErrExportInProgress if the user already has a pending or running job.
+func (r *DataExportRepository) Create(ctx context.Context, userID uuid.UUID, requester dataexport.Requester) (*dataexport.Job, error) {
+	row, err := r.queries.CreateDataExportJob(ctx, postgres.CreateDataExportJobParams{
+		UserID:        userID,
+		RequestedBy:   requester.ID,
+		RequesterType: string(requester.Type),
+	})
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, dataexport.ErrExportInProgress
+		}
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to create data export job")
+		return nil, fmt.Errorf("failed to create data export job: %w", err)
+	}
+	return toDomainDataExportJob(&row), nil
+}
+
+// GetByID retrieves an export job by ID
+func (r *DataExportRepository) GetByID(ctx context.Context, id uuid.UUID) (*dataexport.Job, error) {
+	row, err := r.queries.GetDataExportJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, dataexport.ErrJobNotFound
+		}
+		r.logger.WithError(err).WithField("job_id", id.String()).Error("failed to get data export job")
+		return nil, fmt.Errorf("failed to get data export job: %w", err)
+	}
+	return toDomainDataExportJob(&row), nil
+}
+
+// ListForUser retrieves a user's export jobs, newest first
+func (r *DataExportRepository) ListForUser(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*dataexport.Job, error) {
+	rows, err := r.queries.ListDataExportJobsForUser(ctx, postgres.ListDataExportJobsForUserParams{
+		UserID:      userID,
+		LimitCount:  limit,
+		OffsetCount: offset,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to list data export jobs")
+		return nil, fmt.Errorf("failed to list data export jobs: %w", err)
+	}
+	return toDomainDataExportJobs(rows), nil
+}
+
+// Claim leases the oldest runnable export job to owner.
+// Returns dataexport.ErrNoJob when no job is pending and no running job's lease has expired.
+func (r *DataExportRepository) Claim(ctx context.Context, owner string, now, leaseUntil time.Time) (*dataexport.Job, error) {
+	row, err := r.queries.ClaimDataExportJob(ctx, postgres.ClaimDataExportJobParams{
+		Owner:      &owner,
+		LeaseUntil: pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+		Now:        pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, dataexport.ErrNoJob
+		}
+		r.logger.WithError(err).Error("failed to claim data export job")
+		return nil, fmt.Errorf("failed to claim data export job: %w", err)
+	}
+	return toDomainDataExportJob(&row), nil
+}
+
+// Complete records the archive of a running job and releases its lease.
+// Returns dataexport.ErrLeaseLost when the job is now held by another owner.
+func (r *DataExportRepository) Complete(ctx context.Context, id uuid.UUID, owner string, archive dataexport.ArchiveInfo, at time.Time) error {
+	updated, err := r.queries.CompleteDataExportJob(ctx, postgres.CompleteDataExportJobParams{
+		ObjectKey:   &archive.ObjectKey,
+		SizeBytes:   &archive.SizeBytes,
+		Sha256:      &archive.SHA256,
+		ExpiresAt:   pgtype.Timestamptz{Time: archive.ExpiresAt, Valid: true},
+		CompletedAt: pgtype.Timestamptz{Time: at, Valid: true},
+		ID:          id,
+		Owner:       &owner,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("job_id", id.String()).Error("failed to complete data export job")
+		return fmt.Errorf("failed to complete data export job: %w", err)
+	}
+	if updated == 0 {
+		return dataexport.ErrLeaseLost
+	}
+	return nil
+}
+
+// Fail moves a running job to failed and releases its lease.
+// Returns dataexport.ErrLeaseLost when the job is now held by another owner.
+func (r *DataExportRepository) Fail(ctx context.Context, id uuid.UUID, owner string, lastError string, at time.Time) error {
+	updated, err := r.queries.FailDataExportJob(ctx, postgres.FailDataExportJobParams{
+		LastError:   &lastError,
+		CompletedAt: pgtype.Timestamptz{Time: at, Valid: true},
+		ID:          id,
+		Owner:       &owner,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("job_id", id.String()).Error("failed to fail data export job")
+		return fmt.Errorf("failed to fail data export job: %w", err)
+	}
+	if updated == 0 {
+		return dataexport.ErrLeaseLost
+	}
+	return nil
+}
+
+// SetDownloadToken replaces the download link of a completed job.
+// Returns dataexport.ErrJobNotFound if the job does not exist and
+// dataexport.ErrNotReady if it is not completed.
+func (r *DataExportRepository) SetDownloadToken(ctx context.Context, id uuid.UUID, tokenHash []byte, expiresAt time.Time) (*dataexport.Job, error) {
+	row, err := r.queries.SetDataExportDownloadToken(ctx, postgres.SetDataExportDownloadTokenParams{
+		DownloadTokenHash: tokenHash,
+		DownloadExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		ID:                id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if _, getErr := r.GetByID(ctx, id); getErr != nil {
+				return nil, getErr
+			}
+			return nil, dataexport.ErrNotReady
+		}
+		r.logger.WithError(err).WithField("job_id", id.String()).Error("failed to set data export download token")
+		return nil, fmt.Errorf("failed to set data export download token: %w", err)
+	}
+	return toDomainDataExportJob(&row), nil
+}
+
+// GetByDownloadToken retrieves the job a download token was issued for.
+// Returns dataexport.ErrInvalidDownloadToken if no job has that token.
+func (r *DataExportRepository) GetByDownloadToken(ctx context.Context, tokenHash []byte) (*dataexport.Job, error) {
+	row, err := r.queries.GetDataExportJobByDownloadToken(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, dataexport.ErrInvalidDownloadToken
+		}
+		r.logger.WithError(err).Error("failed to get data export job by download token")
+		return nil, fmt.Errorf("failed to get data export job by download token: %w", err)
+	}
+	return toDomainDataExportJob(&row), nil
+}
+
+// RecordDownload counts a download of a job's archive
+func (r *DataExportRepository) RecordDownload(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := r.queries.RecordDataExportDownload(ctx, postgres.RecordDataExportDownloadParams{
+		DownloadedAt: pgtype.Timestamptz{Time: at, Valid: true},
+		ID:           id,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("job_id", id.String()).Error("failed to record data export download")
+		return fmt.Errorf("failed to record data export download: %w", err)
+	}
+	return nil
+}
+
+// ListExpired retrieves up to limit completed jobs whose archive expired before now
+func (r *DataExportRepository) ListExpired(ctx context.Context, now time.Time, limit int32) ([]*dataexport.Job, error) {
+	rows, err := r.queries.ListExpiredDataExportJobs(ctx, postgres.ListExpiredDataExportJobsParams{
+		Now:        pgtype.Timestamptz{Time: now, Valid: true},
+		LimitCount: limit,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list expired data export jobs")
+		return nil, fmt.Errorf("failed to list expired data export jobs: %w", err)
+	}
+	return toDomainDataExportJobs(rows), nil
+}
+
+// MarkExpired moves a completed job to expired once its archive was deleted
+func (r *DataExportRepository) MarkExpired(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.queries.MarkDataExportJobExpired(ctx, id); err != nil {
+		r.logger.WithError(err).WithField("job_id", id.String()).Error("failed to mark data export job expired")
+		return fmt.Errorf("failed to mark data export job expired: %w", err)
+	}
+	return nil
+}
+
+// GetSubjectData reads the profile, sessions and KYC records of a user, including a deleted one
+func (r *DataExportRepository) GetSubjectData(ctx context.Context, userID uuid.UUID) (*dataexport.SubjectData, error) {
+	profile, err := r.users.GetByIDIncludeDeleted(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	data := &dataexport.SubjectData{Profile: profile}
+
+	tokens, err := r.queries.ListRefreshTokensForDataExport(ctx, userID)
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to list sessions for data export")
+		return nil, fmt.Errorf("failed to list sessions for data export: %w", err)
+	}
+	data.Sessions = make([]*auth.RefreshToken, len(tokens))
+	for i := range tokens {
+		data.Sessions[i] = dbRefreshTokenToDomain(&tokens[i])
+	}
+
+	cases, err := r.queries.ListKYCCasesForDataExport(ctx, userID)
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to list kyc cases for data export")
+		return nil, fmt.Errorf("failed to list kyc cases for data export: %w", err)
+	}
+	data.KYCCases = make([]*kyc.Case, len(cases))
+	for i := range cases {
+		c, err := toDomainKYCCase(&cases[i])
+		if err != nil {
+			return nil, err
+		}
+		if c.Documents, err = r.kyc.ListDocuments(ctx, c.ID); err != nil {
+			return nil, err
+		}
+		transitions, err := r.kyc.ListTransitions(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		data.KYCHistory = append(data.KYCHistory, transitions...)
+		data.KYCCases[i] = c
+	}
+
+	if data.KYCTierChanges, err = r.kyc.ListTierChanges(ctx, userID); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListAuditLogs returns the next page of audit logs about a user in (created_at, id) order
+func (r *DataExportRepository) ListAuditLogs(ctx context.Context, userID uuid.UUID, after *dataexport.Position, limit int32) ([]*audit.Log, error) {
+	params := postgres.ListAuditLogsForDataExportParams{
+		UserID:     userID,
+		LimitCount: limit,
+	}
+	if after != nil {
+		params.AfterCreatedAt = pgtype.Timestamptz{Time: after.CreatedAt, Valid: true}
+		params.AfterID = pgtype.UUID{Bytes: after.ID, Valid: true}
+	}
+
+	rows, err := r.queries.ListAuditLogsForDataExport(ctx, params)
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to list audit logs for data export")
+		return nil, fmt.Errorf("failed to list audit logs for data export: %w", err)
+	}
+	return r.auditLogs.toDomainAuditLogs(rows)
+}
+
+// toDomainDataExportJobs converts sqlc DataExportJobs to domain dataexport.Jobs
+func toDomainDataExportJobs(rows []postgres.DataExportJob) []*dataexport.Job {
+	jobs := make([]*dataexport.Job, len(rows))
+	for i := range rows {
+		jobs[i] = toDomainDataExportJob(&rows[i])
+	}
+	return jobs
+}
+
+// toDomainDataExportJob converts sqlc DataExportJob to domain dataexport.Job
+func toDomainDataExportJob(row *postgres.DataExportJob) *dataexport.Job {
+	return &dataexport.Job{
+		ID:                row.ID,
+		UserID:            row.UserID,
+		Status:            dataexport.Status(row.Status),
+		RequestedBy:       row.RequestedBy,
+		RequesterType:     audit.ActorType(row.RequesterType),
+		LastError:         row.LastError,
+		ObjectKey:         row.ObjectKey,
+		SizeBytes:         row.SizeBytes,
+		SHA256:            row.Sha256,
+		ExpiresAt:         fromOptionalTimestamptz(row.ExpiresAt),
+		DownloadExpiresAt: fromOptionalTimestamptz(row.DownloadExpiresAt),
+		DownloadCount:     int(row.DownloadCount),
+		LastDownloadedAt:  fromOptionalTimestamptz(row.LastDownloadedAt),
+		LeaseOwner:        row.LeaseOwner,
+		LeaseExpiresAt:    fromOptionalTimestamptz(row.LeaseExpiresAt),
+		CreatedAt:         row.CreatedAt.Time,
+		UpdatedAt:         row.UpdatedAt.Time,
+		StartedAt:         fromOptionalTimestamptz(row.StartedAt),
+		CompletedAt:       fromOptionalTimestamptz(row.CompletedAt),
+	}
+}
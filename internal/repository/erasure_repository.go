@@ -0,0 +1,354 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/erasure"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// erasureAuditPageSize is how many audit logs Erase reads per page
+const erasureAuditPageSize = 500
+
+// Compile-time check to ensure ErasureRepository implements erasure.Repository
+var _ erasure.Repository = (*ErasureRepository)(nil)
+
+// ErasureRepository implements erasure.Repository using sqlc
+type ErasureRepository struct {
+	pool    *pgxpool.Pool
+	queries *postgres.Queries
+	logger  *observability.Logger
+	pii     *pii.Encryptor
+}
+
+// NewErasureRepository creates a new ErasureRepository instance
+func NewErasureRepository(pool *pgxpool.Pool, logger *observability.Logger) *ErasureRepository {
+	return &ErasureRepository{
+		pool:    pool,
+		queries: postgres.New(pool),
+		logger:  logger,
+	}
+}
+
+// WithFieldEncryption drops the data keys of erased users from enc's cache
+func (r *ErasureRepository) WithFieldEncryption(enc *pii.Encryptor) *ErasureRepository {
+	r.pii = enc
+	return r
+}
+
+// ListDue retrieves deleted users whose erasure is due, oldest deletion first
+func (r *ErasureRepository) ListDue(ctx context.Context, deletedBefore, now time.Time, limit int32) ([]erasure.Candidate, error) {
+	rows, err := r.queries.ListUsersDueForErasure(ctx, postgres.ListUsersDueForErasureParams{
+		DeletedBefore: pgtype.Timestamptz{Time: deletedBefore, Valid: true},
+		Now:           pgtype.Timestamptz{Time: now, Valid: true},
+		LimitCount:    limit,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list users due for erasure")
+		return nil, fmt.Errorf("failed to list users due for erasure: %w", err)
+	}
+	candidates := make([]erasure.Candidate, len(rows))
+	for i, row := range rows {
+		candidates[i] = erasure.Candidate{UserID: row.ID, DeletedAt: row.DeletedAt.Time}
+	}
+	return candidates, nil
+}
+
+// Get retrieves a user's erasure record
+func (r *ErasureRepository) Get(ctx context.Context, userID uuid.UUID) (*erasure.Record, error) {
+	row, err := r.queries.GetUserErasure(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, erasure.ErrNotFound
+		}
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to get user erasure")
+		return nil, fmt.Errorf("failed to get user erasure: %w", err)
+	}
+	return toDomainErasureRecord(&row), nil
+}
+
+// Defer records that a user's erasure waits until until.
+// Returns erasure.ErrAlreadyErased if the user has been erased.
+func (r *ErasureRepository) Defer(ctx context.Context, userID uuid.UUID, reason erasure.DeferralReason, until time.Time) (*erasure.Record, error) {
+	reasonStr := string(reason)
+	row, err := r.queries.DeferUserErasure(ctx, postgres.DeferUserErasureParams{
+		UserID:         userID,
+		DeferralReason: &reasonStr,
+		DeferredUntil:  pgtype.Timestamptz{Time: until, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, erasure.ErrAlreadyErased
+		}
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to defer user erasure")
+		return nil, fmt.Errorf("failed to defer user erasure: %w", err)
+	}
+	return toDomainErasureRecord(&row), nil
+}
+
+// HasRetainedRecords reports whether a user has submitted a KYC case or been screened
+func (r *ErasureRepository) HasRetainedRecords(ctx context.Context, userID uuid.UUID) (bool, error) {
+	retained, err := r.queries.UserHasRetainedKYCRecords(ctx, userID)
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to check retained kyc records")
+		return false, fmt.Errorf("failed to check retained kyc records: %w", err)
+	}
+	return retained, nil
+}
+
+// IsHistoryOnHold reports whether an active legal hold covers an audit log about the subject
+// or, with archivedSince, may cover one archived since then
+func (r *ErasureRepository) IsHistoryOnHold(ctx context.Context, subject *erasure.Subject, archivedSince *time.Time) (bool, error) {
+	params := postgres.CountLegalHoldsForErasureParams{
+		UserID:      subject.UserID,
+		Identifiers: subject.Identifiers(),
+	}
+	if archivedSince != nil {
+		params.ArchivedSince = pgtype.Timestamptz{Time: *archivedSince, Valid: true}
+	}
+	count, err := r.queries.CountLegalHoldsForErasure(ctx, params)
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", subject.UserID.String()).Error("failed to check legal holds for erasure")
+		return false, fmt.Errorf("failed to check legal holds for erasure: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ObjectKeys lists the object keys of a user's KYC documents and export archives
+func (r *ErasureRepository) ObjectKeys(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	documents, err := r.queries.ListKYCDocumentStorageKeysForErasure(ctx, userID)
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to list kyc documents for erasure")
+		return nil, fmt.Errorf("failed to list kyc documents for erasure: %w", err)
+	}
+	archives, err := r.queries.ListDataExportObjectKeysForErasure(ctx, userID)
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to list data exports for erasure")
+		return nil, fmt.Errorf("failed to list data exports for erasure: %w", err)
+	}
+	return append(documents, archives...), nil
+}
+
+// Erase erases the subject and enqueues event in one transaction. The user row is locked
+// first, so a concurrent erasure of the same user waits and then finds them erased.
+func (r *ErasureRepository) Erase(ctx context.Context, subject *erasure.Subject, at time.Time, event common.EventEnvelope) (*erasure.Record, error) {
+	log := r.logger.WithField("user_id", subject.UserID.String())
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		log.WithError(err).Error("failed to begin erasure transaction")
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+	queries := postgres.New(tx)
+
+	if _, err := queries.LockDeletedUserForErasure(ctx, subject.UserID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, erasure.ErrNotDeleted
+		}
+		log.WithError(err).Error("failed to lock user for erasure")
+		return nil, fmt.Errorf("failed to lock user for erasure: %w", err)
+	}
+	existing, err := queries.GetUserErasure(ctx, subject.UserID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		log.WithError(err).Error("failed to get user erasure")
+		return nil, fmt.Errorf("failed to get user erasure: %w", err)
+	}
+	if err == nil && existing.Status == string(erasure.StatusErased) {
+		return nil, erasure.ErrAlreadyErased
+	}
+	key, err := queries.GetUserPIIKey(ctx, subject.UserID)
+	if err != nil {
+		log.WithError(err).Error("failed to get user data key")
+		return nil, fmt.Errorf("failed to get user data key: %w", err)
+	}
+
+	rewritten, err := r.rewriteAuditLogs(ctx, queries, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	pseudonymised, err := queries.PseudonymiseUser(ctx, postgres.PseudonymiseUserParams{
+		Email: erasure.PseudonymEmail(subject.UserID),
+		ID:    subject.UserID,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to pseudonymise user")
+		return nil, fmt.Errorf("failed to pseudonymise user: %w", err)
+	}
+	if pseudonymised == 0 {
+		return nil, erasure.ErrNotDeleted
+	}
+
+	if err := r.clearRelatedData(ctx, queries, subject.UserID); err != nil {
+		return nil, err
+	}
+
+	row, err := queries.MarkUserErased(ctx, postgres.MarkUserErasedParams{
+		UserID:             subject.UserID,
+		Pseudonym:          &subject.Pseudonym,
+		AuditLogsRewritten: rewritten,
+		ErasedAt:           pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, erasure.ErrAlreadyErased
+		}
+		log.WithError(err).Error("failed to mark user erased")
+		return nil, fmt.Errorf("failed to mark user erased: %w", err)
+	}
+
+	events := &OutboxWriter{queries: queries, logger: r.logger}
+	if err := events.Enqueue(ctx, user.AggregateType, subject.UserID.String(), event); err != nil {
+		log.WithError(err).Error("failed to enqueue user erased event")
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.WithError(err).Error("failed to commit erasure transaction")
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// The wrapped key is gone from the database; make sure this process cannot use it either
+	if r.pii != nil && key.PiiKey != nil {
+		r.pii.Forget(*key.PiiKey)
+	}
+	return toDomainErasureRecord(&row), nil
+}
+
+// rewriteAuditLogs replaces the subject's PII in their audit history, page by page, and
+// returns how many logs changed
+func (r *ErasureRepository) rewriteAuditLogs(ctx context.Context, queries *postgres.Queries, subject *erasure.Subject) (int64, error) {
+	auditLogs := &AuditRepository{queries: queries, logger: r.logger}
+	params := postgres.ListAuditLogsForErasureParams{
+		UserID:      subject.UserID,
+		Identifiers: subject.Identifiers(),
+		LimitCount:  erasureAuditPageSize,
+	}
+
+	var rewritten int64
+	for {
+		rows, err := queries.ListAuditLogsForErasure(ctx, params)
+		if err != nil {
+			r.logger.WithError(err).WithField("user_id", subject.UserID.String()).Error("failed to list audit logs for erasure")
+			return 0, fmt.Errorf("failed to list audit logs for erasure: %w", err)
+		}
+		for i := range rows {
+			entry, err := auditLogs.toDomainAuditLog(&rows[i])
+			if err != nil {
+				return 0, err
+			}
+			if !subject.RewriteLog(entry) {
+				continue
+			}
+			if err := updateAuditLogPII(ctx, queries, entry, rows[i].CreatedAt); err != nil {
+				r.logger.WithError(err).WithField("audit_log_id", entry.ID.String()).Error("failed to rewrite audit log")
+				return 0, err
+			}
+			rewritten++
+		}
+		if len(rows) < erasureAuditPageSize {
+			return rewritten, nil
+		}
+		last := rows[len(rows)-1]
+		params.AfterCreatedAt = last.CreatedAt
+		params.AfterID = pgtype.UUID{Bytes: last.ID, Valid: true}
+	}
+}
+
+// clearRelatedData deletes a user's sessions, pending phone verification, export jobs'
+// download links, outbox messages and webhook deliveries, and clears their KYC and screening data
+func (r *ErasureRepository) clearRelatedData(ctx context.Context, queries *postgres.Queries, userID uuid.UUID) error {
+	log := r.logger.WithField("user_id", userID.String())
+	steps := []struct {
+		what string
+		run  func(context.Context, uuid.UUID) (int64, error)
+	}{
+		{"delete sessions", queries.DeleteRefreshTokensForErasure},
+		{"close data exports", queries.CloseDataExportJobsForErasure},
+		{"delete kyc documents", queries.DeleteKYCDocumentsForErasure},
+		{"clear kyc cases", queries.ClearKYCCasesForErasure},
+		{"clear kyc transition notes", queries.ClearKYCTransitionNotesForErasure},
+		{"clear screening cases", queries.ClearScreeningCasesForErasure},
+		{"delete outbox messages", queries.DeleteOutboxMessagesForErasure},
+		{"delete webhook deliveries", queries.DeleteWebhookDeliveriesForErasure},
+	}
+	for _, step := range steps {
+		if _, err := step.run(ctx, userID); err != nil {
+			log.WithError(err).Error("failed to " + step.what + " for erasure")
+			return fmt.Errorf("failed to %s for erasure: %w", step.what, err)
+		}
+	}
+	if err := queries.DeletePhoneVerification(ctx, userID); err != nil {
+		log.WithError(err).Error("failed to delete phone verification for erasure")
+		return fmt.Errorf("failed to delete phone verification for erasure: %w", err)
+	}
+	return nil
+}
+
+// updateAuditLogPII stores the rewritten PII columns of an audit log
+func updateAuditLogPII(ctx context.Context, queries *postgres.Queries, entry *audit.Log, createdAt pgtype.Timestamptz) error {
+	params := postgres.UpdateAuditLogPIIParams{
+		ActorIdentifier: entry.ActorIdentifier,
+		FailureReason:   entry.FailureReason,
+		ID:              entry.ID,
+		CreatedAt:       createdAt,
+	}
+	if entry.IPAddress != nil {
+		addr, err := netip.ParseAddr(*entry.IPAddress)
+		if err != nil {
+			return fmt.Errorf("invalid rewritten IP address: %w", err)
+		}
+		params.IpAddress = &addr
+	}
+	var err error
+	if params.Metadata, err = marshalJSON(entry.Metadata); err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	if params.PreviousState, err = marshalJSON(entry.PreviousState); err != nil {
+		return fmt.Errorf("failed to marshal previous state: %w", err)
+	}
+	if params.NewState, err = marshalJSON(entry.NewState); err != nil {
+		return fmt.Errorf("failed to marshal new state: %w", err)
+	}
+	if _, err := queries.UpdateAuditLogPII(ctx, params); err != nil {
+		return fmt.Errorf("failed to rewrite audit log: %w", err)
+	}
+	return nil
+}
+
+// toDomainErasureRecord converts sqlc UserErasure to domain erasure.Record
+func toDomainErasureRecord(row *postgres.UserErasure) *erasure.Record {
+	record := &erasure.Record{
+		UserID:             row.UserID,
+		Status:             erasure.Status(row.Status),
+		Pseudonym:          row.Pseudonym,
+		AuditLogsRewritten: row.AuditLogsRewritten,
+		CreatedAt:          row.CreatedAt.Time,
+		UpdatedAt:          row.UpdatedAt.Time,
+	}
+	if row.DeferralReason != nil {
+		reason := erasure.DeferralReason(*row.DeferralReason)
+		record.DeferralReason = &reason
+	}
+	if row.DeferredUntil.Valid {
+		record.DeferredUntil = &row.DeferredUntil.Time
+	}
+	if row.ErasedAt.Valid {
+		record.ErasedAt = &row.ErasedAt.Time
+	}
+	return record
+}
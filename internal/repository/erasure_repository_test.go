@@ -0,0 +1,196 @@
+package repository_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/erasure"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestErasureRepository_DeletesOutboxAndWebhookDeliveries tests that erasing a user deletes
+// their outbox messages and webhook deliveries, whose payloads hold their PII, and keeps those
+// of other users
+func TestErasureRepository_DeletesOutboxAndWebhookDeliveries(t *testing.T) {
+	pool := setupRepositoryTestDB(t)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	logger := observability.NewLoggerWithWriter("dev", "test-erasure", &buf)
+	users := repository.NewUserRepository(pool, logger)
+	repo := repository.NewErasureRepository(pool, logger)
+
+	createUser := func(t *testing.T) *user.User {
+		email := "erasure-" + uuid.NewString()[:8] + "@example.com"
+		u, err := users.Create(ctx, email, "Alice", "Smith", "hashed-password")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_, _ = pool.Exec(ctx, "DELETE FROM outbox WHERE aggregate_id = $1", u.ID.String())
+			_, _ = pool.Exec(ctx, "DELETE FROM user_erasures WHERE user_id = $1", u.ID)
+			_, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = $1", u.ID)
+		})
+		return u
+	}
+	erased := createUser(t)
+	kept := createUser(t)
+	require.NoError(t, users.SoftDelete(ctx, erased.ID))
+
+	var subscriptionID uuid.UUID
+	require.NoError(t, pool.QueryRow(ctx,
+		"INSERT INTO webhook_subscriptions (url, secret, created_by) VALUES ('https://partner.example.com/hooks', 'whsec_test', 'admin@test.com') RETURNING id",
+	).Scan(&subscriptionID))
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM webhook_subscriptions WHERE id = $1", subscriptionID)
+	})
+
+	for _, u := range []*user.User{erased, kept} {
+		payload := `{"email":"` + u.Email + `","first_name":"Alice"}`
+		_, err := pool.Exec(ctx,
+			"INSERT INTO outbox (aggregate_type, aggregate_id, event_id, event_type, occurred_at, payload, published_at) VALUES ('user', $1, $2, 'user.registered', NOW(), $3, NOW())",
+			u.ID.String(), uuid.NewString(), payload)
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx,
+			"INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload) VALUES ($1, $2, 'user.registered', $3)",
+			subscriptionID, uuid.NewString(), `{"subject":"`+u.ID.String()+`","data":`+payload+`}`)
+		require.NoError(t, err)
+	}
+
+	profile, err := users.GetByIDIncludeDeleted(ctx, erased.ID)
+	require.NoError(t, err)
+	subject := erasure.NewSubject(profile)
+	now := time.Now()
+	event := user.NewTypedEvent(erased.ID, user.ErasedPayload{ErasedAt: now, Pseudonym: subject.Pseudonym})
+
+	_, err = repo.Erase(ctx, subject, now, event)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"user.erased"}, outboxEventTypes(t, pool, erased.ID), "only the erasure event is left")
+	assert.Equal(t, []string{"user.registered"}, outboxEventTypes(t, pool, kept.ID))
+	assert.Equal(t, 0, deliveryCount(t, pool, erased.ID))
+	assert.Equal(t, 1, deliveryCount(t, pool, kept.ID))
+}
+
+// outboxEventTypes returns the types of the outbox messages of a user
+func outboxEventTypes(t *testing.T, pool *pgxpool.Pool, userID uuid.UUID) []string {
+	t.Helper()
+	rows, err := pool.Query(context.Background(), "SELECT event_type FROM outbox WHERE aggregate_type = 'user' AND aggregate_id = $1 ORDER BY id", userID.String())
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var types []string
+	for rows.Next() {
+		var eventType string
+		require.NoError(t, rows.Scan(&eventType))
+		types = append(types, eventType)
+	}
+	require.NoError(t, rows.Err())
+	return types
+}
+
+// deliveryCount returns how many webhook deliveries there are of a user's events
+func deliveryCount(t *testing.T, pool *pgxpool.Pool, userID uuid.UUID) int {
+	t.Helper()
+	var n int
+	require.NoError(t, pool.QueryRow(context.Background(), "SELECT COUNT(*) FROM webhook_deliveries WHERE payload->>'subject' = $1", userID.String()).Scan(&n))
+	return n
+}
+
+// TestErasureRepository_IsHistoryOnHold tests that only holds covering an audit log about the
+// subject, by user, category and date, defer their erasure
+func TestErasureRepository_IsHistoryOnHold(t *testing.T) {
+	pool := setupRepositoryTestDB(t)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	logger := observability.NewLoggerWithWriter("dev", "test-erasure", &buf)
+	users := repository.NewUserRepository(pool, logger)
+	auditRepo := repository.NewAuditRepository(pool, logger)
+	repo := repository.NewErasureRepository(pool, logger)
+
+	var ids []uuid.UUID
+	for i := 0; i < 2; i++ {
+		u, err := users.Create(ctx, "erasure-"+uuid.NewString()[:8]+"@example.com", "Alice", "Smith", "hashed-password")
+		require.NoError(t, err)
+		ids = append(ids, u.ID)
+	}
+	erased, other := ids[0], ids[1]
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM audit_logs WHERE user_id = ANY($1)", ids)
+		_, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = ANY($1)", ids)
+	})
+
+	now := time.Now()
+	for _, entry := range []struct {
+		userID   uuid.UUID
+		category audit.EventCategory
+	}{{erased, audit.CategoryAuthentication}, {other, audit.CategorySecurity}} {
+		userID := entry.userID
+		_, err := auditRepo.Create(ctx, &audit.Log{
+			EventType:     "erasure.test",
+			EventCategory: entry.category,
+			Severity:      audit.SeverityInfo,
+			UserID:        &userID,
+			ActorType:     audit.ActorUser,
+			Action:        "test",
+			Status:        audit.StatusSuccess,
+			CreatedAt:     now,
+		})
+		require.NoError(t, err)
+	}
+
+	profile, err := users.GetByID(ctx, erased)
+	require.NoError(t, err)
+	subject := erasure.NewSubject(profile)
+
+	// placeHold places a hold for the duration of a subtest
+	placeHold := func(t *testing.T, userID *uuid.UUID, category *audit.EventCategory, startsAt *time.Time) {
+		var holdID uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx,
+			"INSERT INTO legal_holds (user_id, starts_at, event_category, reason, owner, expires_at, created_by) VALUES ($1, $2, $3, 'test', 'legal', $4, 'admin@test.com') RETURNING id",
+			userID, startsAt, category, now.Add(time.Hour),
+		).Scan(&holdID))
+		t.Cleanup(func() {
+			_, _ = pool.Exec(ctx, "DELETE FROM legal_holds WHERE id = $1", holdID)
+		})
+	}
+	category := func(c audit.EventCategory) *audit.EventCategory { return &c }
+
+	t.Run("hold on another user", func(t *testing.T) {
+		placeHold(t, &other, nil, nil)
+		onHold, err := repo.IsHistoryOnHold(ctx, subject, nil)
+		require.NoError(t, err)
+		assert.False(t, onHold)
+	})
+
+	t.Run("hold on a category the user has no logs in", func(t *testing.T) {
+		placeHold(t, nil, category(audit.CategorySecurity), nil)
+		onHold, err := repo.IsHistoryOnHold(ctx, subject, nil)
+		require.NoError(t, err)
+		assert.False(t, onHold)
+	})
+
+	t.Run("hold starting after the user's logs", func(t *testing.T) {
+		later := now.Add(time.Minute)
+		placeHold(t, nil, category(audit.CategoryAuthentication), &later)
+		onHold, err := repo.IsHistoryOnHold(ctx, subject, nil)
+		require.NoError(t, err)
+		assert.False(t, onHold)
+	})
+
+	t.Run("hold covering the user's logs", func(t *testing.T) {
+		earlier := now.Add(-time.Minute)
+		placeHold(t, nil, category(audit.CategoryAuthentication), &earlier)
+		onHold, err := repo.IsHistoryOnHold(ctx, subject, nil)
+		require.NoError(t, err)
+		assert.True(t, onHold)
+	})
+}
@@ -0,0 +1,410 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Compile-time check to ensure KYCRepository implements kyc.Repository
+var _ kyc.Repository = (*KYCRepository)(nil)
+
+// KYCRepository implements kyc.Repository using sqlc
+type KYCRepository struct {
+	queries *postgres.Queries
+	logger  *observability.Logger
+}
+
+// NewKYCRepository creates a new KYCRepository instance
+func NewKYCRepository(pool *pgxpool.Pool, logger *observability.Logger) *KYCRepository {
+	return &KYCRepository{
+		queries: postgres.New(pool),
+		logger:  logger,
+	}
+}
+
+// Create stores a new case
+func (r *KYCRepository) Create(ctx context.Context, c *kyc.Case) (*kyc.Case, error) {
+	applicant, err := json.Marshal(c.Applicant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal kyc applicant: %w", err)
+	}
+
+	row, err := r.queries.CreateKYCCase(ctx, postgres.CreateKYCCaseParams{
+		UserID:    c.UserID,
+		Status:    string(c.Status),
+		RiskLevel: string(c.RiskLevel),
+		Applicant: applicant,
+	})
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, kyc.ErrCaseAlreadyOpen
+		}
+		r.logger.WithError(err).WithField("user_id", c.UserID.String()).Error("failed to create kyc case")
+		return nil, fmt.Errorf("failed to create kyc case: %w", err)
+	}
+	return toDomainKYCCase(&row)
+}
+
+// GetByID retrieves a case by ID
+func (r *KYCRepository) GetByID(ctx context.Context, id uuid.UUID) (*kyc.Case, error) {
+	row, err := r.queries.GetKYCCase(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, kyc.ErrCaseNotFound
+		}
+		r.logger.WithError(err).WithField("case_id", id.String()).Error("failed to get kyc case")
+		return nil, fmt.Errorf("failed to get kyc case: %w", err)
+	}
+	return toDomainKYCCase(&row)
+}
+
+// GetLatestForUser retrieves the user's most recently created case
+func (r *KYCRepository) GetLatestForUser(ctx context.Context, userID uuid.UUID) (*kyc.Case, error) {
+	row, err := r.queries.GetLatestKYCCaseForUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, kyc.ErrCaseNotFound
+		}
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to get latest kyc case")
+		return nil, fmt.Errorf("failed to get latest kyc case: %w", err)
+	}
+	return toDomainKYCCase(&row)
+}
+
+// GetByProviderApplicant retrieves the case an external provider's applicant belongs to
+func (r *KYCRepository) GetByProviderApplicant(ctx context.Context, provider, applicantID string) (*kyc.Case, error) {
+	row, err := r.queries.GetKYCCaseByProviderApplicant(ctx, postgres.GetKYCCaseByProviderApplicantParams{
+		Provider:            &provider,
+		ProviderApplicantID: &applicantID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, kyc.ErrCaseNotFound
+		}
+		r.logger.WithError(err).WithField("provider", provider).Error("failed to get kyc case by provider applicant")
+		return nil, fmt.Errorf("failed to get kyc case by provider applicant: %w", err)
+	}
+	return toDomainKYCCase(&row)
+}
+
+// List retrieves cases matching filter, oldest submission first
+func (r *KYCRepository) List(ctx context.Context, filter kyc.ListFilter) ([]*kyc.Case, error) {
+	status, reviewerID, riskLevel := kycFilterParams(filter)
+	rows, err := r.queries.ListKYCCases(ctx, postgres.ListKYCCasesParams{
+		Status:      status,
+		ReviewerID:  reviewerID,
+		RiskLevel:   riskLevel,
+		LimitCount:  filter.Limit,
+		OffsetCount: filter.Offset,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list kyc cases")
+		return nil, fmt.Errorf("failed to list kyc cases: %w", err)
+	}
+
+	cases := make([]*kyc.Case, len(rows))
+	for i := range rows {
+		if cases[i], err = toDomainKYCCase(&rows[i]); err != nil {
+			return nil, err
+		}
+	}
+	return cases, nil
+}
+
+// Count returns how many cases match filter
+func (r *KYCRepository) Count(ctx context.Context, filter kyc.ListFilter) (int64, error) {
+	status, reviewerID, riskLevel := kycFilterParams(filter)
+	count, err := r.queries.CountKYCCases(ctx, postgres.CountKYCCasesParams{
+		Status:     status,
+		ReviewerID: reviewerID,
+		RiskLevel:  riskLevel,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to count kyc cases")
+		return 0, fmt.Errorf("failed to count kyc cases: %w", err)
+	}
+	return count, nil
+}
+
+// Update stores c if its version is unchanged since it was loaded
+func (r *KYCRepository) Update(ctx context.Context, c *kyc.Case) (*kyc.Case, error) {
+	applicant, err := json.Marshal(c.Applicant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal kyc applicant: %w", err)
+	}
+
+	row, err := r.queries.UpdateKYCCase(ctx, postgres.UpdateKYCCaseParams{
+		Status:              string(c.Status),
+		RiskLevel:           string(c.RiskLevel),
+		Applicant:           applicant,
+		ReviewerID:          optionalUUID(c.ReviewerID),
+		FirstApproverID:     optionalUUID(c.FirstApproverID),
+		FirstApprovedAt:     optionalTimestamptz(c.FirstApprovedAt),
+		DecidedBy:           optionalUUID(c.DecidedBy),
+		DecidedAt:           optionalTimestamptz(c.DecidedAt),
+		RejectionReason:     (*string)(c.RejectionReason),
+		ReviewNote:          c.ReviewNote,
+		SubmittedAt:         optionalTimestamptz(c.SubmittedAt),
+		Provider:            c.Provider,
+		ProviderApplicantID: c.ProviderApplicantID,
+		ID:                  c.ID,
+		Version:             int32(c.Version), // #nosec G115 -- incremented once per update
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, kyc.ErrCaseConflict
+		}
+		r.logger.WithError(err).WithField("case_id", c.ID.String()).Error("failed to update kyc case")
+		return nil, fmt.Errorf("failed to update kyc case: %w", err)
+	}
+	return toDomainKYCCase(&row)
+}
+
+// AddDocument stores a document's metadata
+func (r *KYCRepository) AddDocument(ctx context.Context, d *kyc.Document) (*kyc.Document, error) {
+	row, err := r.queries.CreateKYCDocument(ctx, postgres.CreateKYCDocumentParams{
+		ID:           d.ID,
+		CaseID:       d.CaseID,
+		DocumentType: string(d.Type),
+		FileName:     d.FileName,
+		ContentType:  d.ContentType,
+		SizeBytes:    d.SizeBytes,
+		Sha256:       d.SHA256,
+		StorageKey:   d.StorageKey,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("case_id", d.CaseID.String()).Error("failed to create kyc document")
+		return nil, fmt.Errorf("failed to create kyc document: %w", err)
+	}
+	return toDomainKYCDocument(&row), nil
+}
+
+// ListDocuments retrieves a case's documents in upload order
+func (r *KYCRepository) ListDocuments(ctx context.Context, caseID uuid.UUID) ([]*kyc.Document, error) {
+	rows, err := r.queries.ListKYCDocuments(ctx, caseID)
+	if err != nil {
+		r.logger.WithError(err).WithField("case_id", caseID.String()).Error("failed to list kyc documents")
+		return nil, fmt.Errorf("failed to list kyc documents: %w", err)
+	}
+
+	documents := make([]*kyc.Document, len(rows))
+	for i := range rows {
+		documents[i] = toDomainKYCDocument(&rows[i])
+	}
+	return documents, nil
+}
+
+// GetDocument retrieves a document of a case
+func (r *KYCRepository) GetDocument(ctx context.Context, caseID, documentID uuid.UUID) (*kyc.Document, error) {
+	row, err := r.queries.GetKYCDocument(ctx, postgres.GetKYCDocumentParams{CaseID: caseID, ID: documentID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, kyc.ErrDocumentNotFound
+		}
+		r.logger.WithError(err).WithField("document_id", documentID.String()).Error("failed to get kyc document")
+		return nil, fmt.Errorf("failed to get kyc document: %w", err)
+	}
+	return toDomainKYCDocument(&row), nil
+}
+
+// AddTransition appends an entry to a case's history
+func (r *KYCRepository) AddTransition(ctx context.Context, t *kyc.Transition) error {
+	err := r.queries.CreateKYCCaseTransition(ctx, postgres.CreateKYCCaseTransitionParams{
+		CaseID:     t.CaseID,
+		Action:     string(t.Action),
+		FromStatus: string(t.FromStatus),
+		ToStatus:   string(t.ToStatus),
+		ActorID:    t.ActorID,
+		ReasonCode: (*string)(t.ReasonCode),
+		Note:       t.Note,
+		CreatedAt:  pgtype.Timestamptz{Time: t.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("case_id", t.CaseID.String()).Error("failed to create kyc case transition")
+		return fmt.Errorf("failed to create kyc case transition: %w", err)
+	}
+	return nil
+}
+
+// ListTransitions retrieves a case's history in order
+func (r *KYCRepository) ListTransitions(ctx context.Context, caseID uuid.UUID) ([]*kyc.Transition, error) {
+	rows, err := r.queries.ListKYCCaseTransitions(ctx, caseID)
+	if err != nil {
+		r.logger.WithError(err).WithField("case_id", caseID.String()).Error("failed to list kyc case transitions")
+		return nil, fmt.Errorf("failed to list kyc case transitions: %w", err)
+	}
+
+	transitions := make([]*kyc.Transition, len(rows))
+	for i, row := range rows {
+		transitions[i] = &kyc.Transition{
+			ID:         row.ID,
+			CaseID:     row.CaseID,
+			Action:     kyc.Action(row.Action),
+			FromStatus: kyc.Status(row.FromStatus),
+			ToStatus:   kyc.Status(row.ToStatus),
+			ActorID:    row.ActorID,
+			ReasonCode: (*kyc.RejectionReason)(row.ReasonCode),
+			Note:       row.Note,
+			CreatedAt:  row.CreatedAt.Time,
+		}
+	}
+	return transitions, nil
+}
+
+// GetTier retrieves the tier a user holds
+func (r *KYCRepository) GetTier(ctx context.Context, userID uuid.UUID) (*kyc.TierAssignment, error) {
+	row, err := r.queries.GetUserKYCTier(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, kyc.ErrTierNotFound
+		}
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to get kyc tier")
+		return nil, fmt.Errorf("failed to get kyc tier: %w", err)
+	}
+	return toDomainTierAssignment(&row), nil
+}
+
+// SetTier stores the tier a user holds, replacing any earlier assignment
+func (r *KYCRepository) SetTier(ctx context.Context, a *kyc.TierAssignment) (*kyc.TierAssignment, error) {
+	row, err := r.queries.UpsertUserKYCTier(ctx, postgres.UpsertUserKYCTierParams{
+		UserID:    a.UserID,
+		Tier:      int16(a.Tier), // #nosec G115 -- tiers are validated to 0..3
+		UpdatedBy: optionalUUID(a.UpdatedBy),
+		UpdatedAt: pgtype.Timestamptz{Time: a.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", a.UserID.String()).Error("failed to set kyc tier")
+		return nil, fmt.Errorf("failed to set kyc tier: %w", err)
+	}
+	return toDomainTierAssignment(&row), nil
+}
+
+// AddTierChange appends an entry to a user's tier history
+func (r *KYCRepository) AddTierChange(ctx context.Context, c *kyc.TierChange) error {
+	err := r.queries.CreateUserKYCTierChange(ctx, postgres.CreateUserKYCTierChangeParams{
+		UserID:    c.UserID,
+		OldTier:   int16(c.OldTier), // #nosec G115 -- tiers are validated to 0..3
+		NewTier:   int16(c.NewTier), // #nosec G115 -- tiers are validated to 0..3
+		Reason:    c.Reason,
+		ChangedBy: optionalUUID(c.ChangedBy),
+		CaseID:    optionalUUID(c.CaseID),
+		CreatedAt: pgtype.Timestamptz{Time: c.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", c.UserID.String()).Error("failed to create kyc tier change")
+		return fmt.Errorf("failed to create kyc tier change: %w", err)
+	}
+	return nil
+}
+
+// ListTierChanges retrieves a user's tier history in order
+func (r *KYCRepository) ListTierChanges(ctx context.Context, userID uuid.UUID) ([]*kyc.TierChange, error) {
+	rows, err := r.queries.ListUserKYCTierChanges(ctx, userID)
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to list kyc tier changes")
+		return nil, fmt.Errorf("failed to list kyc tier changes: %w", err)
+	}
+
+	changes := make([]*kyc.TierChange, len(rows))
+	for i, row := range rows {
+		changes[i] = &kyc.TierChange{
+			ID:        row.ID,
+			UserID:    row.UserID,
+			OldTier:   kyc.Tier(row.OldTier),
+			NewTier:   kyc.Tier(row.NewTier),
+			Reason:    row.Reason,
+			ChangedBy: fromOptionalUUID(row.ChangedBy),
+			CaseID:    fromOptionalUUID(row.CaseID),
+			CreatedAt: row.CreatedAt.Time,
+		}
+	}
+	return changes, nil
+}
+
+func kycFilterParams(filter kyc.ListFilter) (status *string, reviewerID pgtype.UUID, riskLevel *string) {
+	return (*string)(filter.Status), optionalUUID(filter.ReviewerID), (*string)(filter.RiskLevel)
+}
+
+func optionalUUID(id *uuid.UUID) pgtype.UUID {
+	if id == nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: *id, Valid: true}
+}
+
+func fromOptionalUUID(id pgtype.UUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	value := uuid.UUID(id.Bytes)
+	return &value
+}
+
+func fromOptionalTimestamptz(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	return &ts.Time
+}
+
+// toDomainKYCCase converts sqlc KycCase to domain kyc.Case
+func toDomainKYCCase(row *postgres.KycCase) (*kyc.Case, error) {
+	c := &kyc.Case{
+		ID:                  row.ID,
+		UserID:              row.UserID,
+		Status:              kyc.Status(row.Status),
+		RiskLevel:           kyc.RiskLevel(row.RiskLevel),
+		ReviewerID:          fromOptionalUUID(row.ReviewerID),
+		FirstApproverID:     fromOptionalUUID(row.FirstApproverID),
+		FirstApprovedAt:     fromOptionalTimestamptz(row.FirstApprovedAt),
+		DecidedBy:           fromOptionalUUID(row.DecidedBy),
+		DecidedAt:           fromOptionalTimestamptz(row.DecidedAt),
+		RejectionReason:     (*kyc.RejectionReason)(row.RejectionReason),
+		ReviewNote:          row.ReviewNote,
+		SubmittedAt:         fromOptionalTimestamptz(row.SubmittedAt),
+		Provider:            row.Provider,
+		ProviderApplicantID: row.ProviderApplicantID,
+		Version:             int(row.Version),
+		CreatedAt:           row.CreatedAt.Time,
+		UpdatedAt:           row.UpdatedAt.Time,
+	}
+	if err := json.Unmarshal(row.Applicant, &c.Applicant); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal kyc applicant: %w", err)
+	}
+	return c, nil
+}
+
+// toDomainKYCDocument converts sqlc KycDocument to domain kyc.Document
+func toDomainKYCDocument(row *postgres.KycDocument) *kyc.Document {
+	return &kyc.Document{
+		ID:          row.ID,
+		CaseID:      row.CaseID,
+		Type:        kyc.DocumentType(row.DocumentType),
+		FileName:    row.FileName,
+		ContentType: row.ContentType,
+		SizeBytes:   row.SizeBytes,
+		SHA256:      row.Sha256,
+		StorageKey:  row.StorageKey,
+		UploadedAt:  row.UploadedAt.Time,
+	}
+}
+
+func toDomainTierAssignment(row *postgres.UserKycTier) *kyc.TierAssignment {
+	return &kyc.TierAssignment{
+		UserID:    row.UserID,
+		Tier:      kyc.Tier(row.Tier),
+		UpdatedBy: fromOptionalUUID(row.UpdatedBy),
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Compile-time check to ensure KYCTransactor implements kyc.Transactor
+var _ kyc.Transactor = (*KYCTransactor)(nil)
+
+// KYCTransactor implements kyc.Transactor: case changes, the derived user KYC status and
+// their outbox events share one database transaction.
+type KYCTransactor struct {
+	pool   *pgxpool.Pool
+	logger *observability.Logger
+	pii    *pii.Encryptor
+}
+
+// NewKYCTransactor creates a new KYCTransactor instance
+func NewKYCTransactor(pool *pgxpool.Pool, logger *observability.Logger) *KYCTransactor {
+	return &KYCTransactor{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// WithFieldEncryption encrypts user PII written in transactions with enc
+func (t *KYCTransactor) WithFieldEncryption(enc *pii.Encryptor) *KYCTransactor {
+	t.pii = enc
+	return t
+}
+
+// WithinTx runs fn in a transaction, committing when it returns nil
+func (t *KYCTransactor) WithinTx(ctx context.Context, fn func(cases kyc.Repository, users user.Repository, events outbox.Writer) error) error {
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		t.logger.WithError(err).Error("failed to begin kyc transaction")
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+	queries := postgres.New(tx)
+	cases := &KYCRepository{queries: queries, logger: t.logger}
+	users := &UserRepository{queries: queries, logger: t.logger, pii: t.pii}
+	events := &OutboxWriter{queries: queries, logger: t.logger}
+
+	if err := fn(cases, users, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		t.logger.WithError(err).Error("failed to commit kyc transaction")
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LegalHoldRepository implements audit.LegalHoldRepository using sqlc
+type LegalHoldRepository struct {
+	pool    *pgxpool.Pool
+	queries *postgres.Queries
+	logger  *observability.Logger
+}
+
+// NewLegalHoldRepository creates a new LegalHoldRepository instance
+func NewLegalHoldRepository(pool *pgxpool.Pool, logger *observability.Logger) *LegalHoldRepository {
+	return &LegalHoldRepository{
+		pool:    pool,
+		queries: postgres.New(pool),
+		logger:  logger,
+	}
+}
+
+// Create places a new legal hold
+func (r *LegalHoldRepository) Create(ctx context.Context, hold *audit.LegalHold) (*audit.LegalHold, error) {
+	params := postgres.CreateLegalHoldParams{
+		StartsAt:  optionalTimestamptz(hold.StartsAt),
+		EndsAt:    optionalTimestamptz(hold.EndsAt),
+		Reason:    hold.Reason,
+		Owner:     hold.Owner,
+		ExpiresAt: pgtype.Timestamptz{Time: hold.ExpiresAt, Valid: true},
+		CreatedBy: hold.CreatedBy,
+	}
+	if hold.UserID != nil {
+		params.UserID = pgtype.UUID{Bytes: *hold.UserID, Valid: true}
+	}
+	if hold.EventCategory != nil {
+		category := string(*hold.EventCategory)
+		params.EventCategory = &category
+	}
+
+	row, err := r.queries.CreateLegalHold(ctx, params)
+	if err != nil {
+		r.logger.WithError(err).Error("failed to create legal hold")
+		return nil, fmt.Errorf("failed to create legal hold: %w", err)
+	}
+	return toDomainLegalHold(&row), nil
+}
+
+// GetByID retrieves a legal hold by ID
+func (r *LegalHoldRepository) GetByID(ctx context.Context, id uuid.UUID) (*audit.LegalHold, error) {
+	row, err := r.queries.GetLegalHoldByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, audit.ErrLegalHoldNotFound
+		}
+		r.logger.WithError(err).WithField("hold_id", id.String()).Error("failed to get legal hold")
+		return nil, fmt.Errorf("failed to get legal hold: %w", err)
+	}
+	return toDomainLegalHold(&row), nil
+}
+
+// List retrieves legal holds, newest first
+func (r *LegalHoldRepository) List(ctx context.Context, activeOnly bool, limit, offset int32) ([]*audit.LegalHold, error) {
+	rows, err := r.queries.ListLegalHolds(ctx, postgres.ListLegalHoldsParams{
+		ActiveOnly:  activeOnly,
+		LimitCount:  limit,
+		OffsetCount: offset,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list legal holds")
+		return nil, fmt.Errorf("failed to list legal holds: %w", err)
+	}
+
+	holds := make([]*audit.LegalHold, len(rows))
+	for i := range rows {
+		holds[i] = toDomainLegalHold(&rows[i])
+	}
+	return holds, nil
+}
+
+// UpdateTerms changes the reason, owner and expiry of an unreleased hold.
+// Returns audit.ErrLegalHoldNotFound if no unreleased hold has this ID.
+func (r *LegalHoldRepository) UpdateTerms(ctx context.Context, id uuid.UUID, reason, owner string, expiresAt time.Time) (*audit.LegalHold, error) {
+	row, err := r.queries.UpdateLegalHold(ctx, postgres.UpdateLegalHoldParams{
+		ID:        id,
+		Reason:    reason,
+		Owner:     owner,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, audit.ErrLegalHoldNotFound
+		}
+		r.logger.WithError(err).WithField("hold_id", id.String()).Error("failed to update legal hold")
+		return nil, fmt.Errorf("failed to update legal hold: %w", err)
+	}
+	return toDomainLegalHold(&row), nil
+}
+
+// Release lifts an unreleased hold.
+// Returns audit.ErrLegalHoldNotFound if no unreleased hold has this ID.
+func (r *LegalHoldRepository) Release(ctx context.Context, id uuid.UUID, releasedBy, reason string) (*audit.LegalHold, error) {
+	row, err := r.queries.ReleaseLegalHold(ctx, postgres.ReleaseLegalHoldParams{
+		ID:            id,
+		ReleasedBy:    &releasedBy,
+		ReleaseReason: &reason,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, audit.ErrLegalHoldNotFound
+		}
+		r.logger.WithError(err).WithField("hold_id", id.String()).Error("failed to release legal hold")
+		return nil, fmt.Errorf("failed to release legal hold: %w", err)
+	}
+	return toDomainLegalHold(&row), nil
+}
+
+// IsUserOnHold reports whether an active hold names the user
+func (r *LegalHoldRepository) IsUserOnHold(ctx context.Context, userID uuid.UUID) (bool, error) {
+	count, err := r.queries.CountActiveLegalHoldsForUser(ctx, userID)
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to check legal holds for user")
+		return false, fmt.Errorf("failed to check legal holds for user: %w", err)
+	}
+	return count > 0, nil
+}
+
+// IsRangeOnHold reports whether an active hold's date range overlaps [start, end)
+func (r *LegalHoldRepository) IsRangeOnHold(ctx context.Context, start, end time.Time) (bool, error) {
+	count, err := r.queries.CountActiveLegalHoldsForRange(ctx, postgres.CountActiveLegalHoldsForRangeParams{
+		RangeEnd:   end,
+		RangeStart: start,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to check legal holds for range")
+		return false, fmt.Errorf("failed to check legal holds for range: %w", err)
+	}
+	return count > 0, nil
+}
+
+// toDomainLegalHold converts sqlc LegalHold to domain audit.LegalHold
+func toDomainLegalHold(row *postgres.LegalHold) *audit.LegalHold {
+	hold := &audit.LegalHold{
+		ID:            row.ID,
+		Reason:        row.Reason,
+		Owner:         row.Owner,
+		ExpiresAt:     row.ExpiresAt.Time,
+		CreatedBy:     row.CreatedBy,
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+		ReleasedBy:    row.ReleasedBy,
+		ReleaseReason: row.ReleaseReason,
+	}
+	if row.UserID.Valid {
+		userID := uuid.UUID(row.UserID.Bytes)
+		hold.UserID = &userID
+	}
+	if row.StartsAt.Valid {
+		hold.StartsAt = &row.StartsAt.Time
+	}
+	if row.EndsAt.Valid {
+		hold.EndsAt = &row.EndsAt.Time
+	}
+	if row.EventCategory != nil {
+		category := audit.EventCategory(*row.EventCategory)
+		hold.EventCategory = &category
+	}
+	if row.ReleasedAt.Valid {
+		hold.ReleasedAt = &row.ReleasedAt.Time
+	}
+	return hold
+}
+
+// optionalTimestamptz converts an optional time to a nullable timestamptz
+func optionalTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
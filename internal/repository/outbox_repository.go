@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Compile-time checks for the outbox implementations
+var (
+	_ outbox.Repository = (*OutboxRepository)(nil)
+	_ outbox.RelayTx    = (*outboxRelayTx)(nil)
+	_ outbox.Writer     = (*OutboxWriter)(nil)
+)
+
+// OutboxRepository implements outbox.Repository using sqlc
+type OutboxRepository struct {
+	pool    *pgxpool.Pool
+	queries *postgres.Queries
+	logger  *observability.Logger
+}
+
+// NewOutboxRepository creates a new OutboxRepository instance
+func NewOutboxRepository(pool *pgxpool.Pool, logger *observability.Logger) *OutboxRepository {
+	return &OutboxRepository{
+		pool:    pool,
+		queries: postgres.New(pool),
+		logger:  logger,
+	}
+}
+
+// WithRelayLock runs fn in a transaction holding a transaction-scoped advisory lock.
+// The lock is released on commit or rollback, so a crashed relay never blocks the others.
+func (r *OutboxRepository) WithRelayLock(ctx context.Context, fn func(tx outbox.RelayTx) error) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		r.logger.WithError(err).Error("failed to begin outbox relay transaction")
+		return false, fmt.Errorf("failed to begin outbox relay transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+	queries := r.queries.WithTx(tx)
+	acquired, err := queries.AcquireOutboxRelayLock(ctx)
+	if err != nil {
+		r.logger.WithError(err).Error("failed to acquire outbox relay lock")
+		return false, fmt.Errorf("failed to acquire outbox relay lock: %w", err)
+	}
+	if !acquired {
+		return false, nil
+	}
+
+	if err := fn(&outboxRelayTx{queries: queries, logger: r.logger}); err != nil {
+		return true, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.logger.WithError(err).Error("failed to commit outbox relay transaction")
+		return true, fmt.Errorf("failed to commit outbox relay transaction: %w", err)
+	}
+	return true, nil
+}
+
+// Stats returns the number of pending messages and the age of the oldest
+func (r *OutboxRepository) Stats(ctx context.Context) (outbox.Stats, error) {
+	row, err := r.queries.GetOutboxStats(ctx)
+	if err != nil {
+		r.logger.WithError(err).Error("failed to get outbox stats")
+		return outbox.Stats{}, fmt.Errorf("failed to get outbox stats: %w", err)
+	}
+
+	stats := outbox.Stats{Pending: row.Pending}
+	if row.OldestPendingAt.Valid {
+		stats.OldestPendingAt = &row.OldestPendingAt.Time
+	}
+	return stats, nil
+}
+
+// DeletePublishedBefore removes messages published before the cutoff
+func (r *OutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := r.queries.DeletePublishedOutboxMessages(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to delete published outbox messages")
+		return 0, fmt.Errorf("failed to delete published outbox messages: %w", err)
+	}
+	return deleted, nil
+}
+
+// outboxRelayTx implements outbox.RelayTx on queries bound to the relay transaction
+type outboxRelayTx struct {
+	queries *postgres.Queries
+	logger  *observability.Logger
+}
+
+// ListDue returns pending messages due at now, oldest first
+func (t *outboxRelayTx) ListDue(ctx context.Context, now time.Time, limit int32) ([]*outbox.Message, error) {
+	rows, err := t.queries.ListDueOutboxMessages(ctx, postgres.ListDueOutboxMessagesParams{
+		Now:        pgtype.Timestamptz{Time: now, Valid: true},
+		LimitCount: limit,
+	})
+	if err != nil {
+		t.logger.WithError(err).Error("failed to list due outbox messages")
+		return nil, fmt.Errorf("failed to list due outbox messages: %w", err)
+	}
+
+	messages := make([]*outbox.Message, len(rows))
+	for i := range rows {
+		message, err := toDomainOutboxMessage(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		messages[i] = message
+	}
+	return messages, nil
+}
+
+// MarkPublished records a successful publish
+func (t *outboxRelayTx) MarkPublished(ctx context.Context, id int64, publishedAt time.Time) error {
+	err := t.queries.MarkOutboxMessagePublished(ctx, postgres.MarkOutboxMessagePublishedParams{
+		PublishedAt: pgtype.Timestamptz{Time: publishedAt, Valid: true},
+		ID:          id,
+	})
+	if err != nil {
+		t.logger.WithError(err).WithField("outbox_id", id).Error("failed to mark outbox message published")
+		return fmt.Errorf("failed to mark outbox message published: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed publish attempt and schedules the next one
+func (t *outboxRelayTx) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	err := t.queries.MarkOutboxMessageFailed(ctx, postgres.MarkOutboxMessageFailedParams{
+		LastError:     &lastError,
+		NextAttemptAt: pgtype.Timestamptz{Time: nextAttemptAt, Valid: true},
+		ID:            id,
+	})
+	if err != nil {
+		t.logger.WithError(err).WithField("outbox_id", id).Error("failed to mark outbox message failed")
+		return fmt.Errorf("failed to mark outbox message failed: %w", err)
+	}
+	return nil
+}
+
+// OutboxWriter implements outbox.Writer. Build it on queries bound to the transaction
+// of the change being described; see UserTransactor.
+type OutboxWriter struct {
+	queries *postgres.Queries
+	logger  *observability.Logger
+}
+
+// Enqueue appends event to the outbox
+func (w *OutboxWriter) Enqueue(ctx context.Context, aggregateType, aggregateID string, event common.EventEnvelope) error {
+	attributes, err := marshalStringMap(event.Attributes())
+	if err != nil {
+		return fmt.Errorf("failed to marshal event attributes: %w", err)
+	}
+	payload, err := marshalJSON(event.EventPayload())
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+	metadata, err := marshalStringMap(event.EventMetadata())
+	if err != nil {
+		return fmt.Errorf("failed to marshal event metadata: %w", err)
+	}
+	source, subject, schemaVersion := common.EventContext(event)
+
+	err = w.queries.InsertOutboxMessage(ctx, postgres.InsertOutboxMessageParams{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventID:       event.EventID(),
+		EventType:     event.EventType(),
+		OccurredAt:    pgtype.Timestamptz{Time: event.OccurredAt(), Valid: true},
+		Attributes:    attributes,
+		Payload:       payload,
+		Metadata:      metadata,
+		Source:        source,
+		Subject:       subject,
+		SchemaVersion: int32(schemaVersion),
+	})
+	if err != nil {
+		w.logger.WithError(err).WithFields(map[string]interface{}{
+			"event_id":   event.EventID(),
+			"event_type": event.EventType(),
+		}).Error("failed to insert outbox message")
+		return fmt.Errorf("failed to insert outbox message: %w", err)
+	}
+	return nil
+}
+
+// toDomainOutboxMessage converts sqlc Outbox to domain outbox.Message
+func toDomainOutboxMessage(row *postgres.Outbox) (*outbox.Message, error) {
+	message := &outbox.Message{
+		ID:            row.ID,
+		AggregateType: row.AggregateType,
+		AggregateID:   row.AggregateID,
+		EventID:       row.EventID,
+		EventType:     row.EventType,
+		OccurredAt:    row.OccurredAt.Time,
+		Source:        row.Source,
+		Subject:       row.Subject,
+		SchemaVersion: int(row.SchemaVersion),
+		Attempts:      row.Attempts,
+		LastError:     row.LastError,
+		NextAttemptAt: row.NextAttemptAt.Time,
+		CreatedAt:     row.CreatedAt.Time,
+	}
+	if err := json.Unmarshal(row.Attributes, &message.Attributes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal outbox attributes: %w", err)
+	}
+	if err := json.Unmarshal(row.Payload, &message.Payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal outbox payload: %w", err)
+	}
+	if err := json.Unmarshal(row.Metadata, &message.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal outbox metadata: %w", err)
+	}
+	if row.PublishedAt.Valid {
+		message.PublishedAt = &row.PublishedAt.Time
+	}
+	return message, nil
+}
+
+// marshalStringMap marshals a string map to JSON, returning empty JSON object for nil
+func marshalStringMap(data map[string]string) ([]byte, error) {
+	if data == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(data)
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Compile-time check to ensure PhoneVerificationRepository implements user.PhoneVerificationRepository
+var _ user.PhoneVerificationRepository = (*PhoneVerificationRepository)(nil)
+
+// PhoneVerificationRepository implements user.PhoneVerificationRepository using sqlc
+type PhoneVerificationRepository struct {
+	queries *postgres.Queries
+	logger  *observability.Logger
+}
+
+// NewPhoneVerificationRepository creates a new PhoneVerificationRepository instance
+func NewPhoneVerificationRepository(pool *pgxpool.Pool, logger *observability.Logger) *PhoneVerificationRepository {
+	return &PhoneVerificationRepository{
+		queries: postgres.New(pool),
+		logger:  logger,
+	}
+}
+
+// Save stores v, replacing any pending verification of the user
+func (r *PhoneVerificationRepository) Save(ctx context.Context, v *user.PhoneVerification) (*user.PhoneVerification, error) {
+	row, err := r.queries.SavePhoneVerification(ctx, postgres.SavePhoneVerificationParams{
+		UserID:    v.UserID,
+		Phone:     v.Phone,
+		CodeHash:  v.CodeHash,
+		ExpiresAt: pgtype.Timestamptz{Time: v.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", v.UserID.String()).Error("failed to save phone verification")
+		return nil, fmt.Errorf("failed to save phone verification: %w", err)
+	}
+	return toDomainPhoneVerification(&row), nil
+}
+
+// Get retrieves the user's pending verification
+func (r *PhoneVerificationRepository) Get(ctx context.Context, userID uuid.UUID) (*user.PhoneVerification, error) {
+	row, err := r.queries.GetPhoneVerification(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, user.ErrPhoneVerificationNotFound
+		}
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to get phone verification")
+		return nil, fmt.Errorf("failed to get phone verification: %w", err)
+	}
+	return toDomainPhoneVerification(&row), nil
+}
+
+// IncrementAttempts records a wrong code and returns the number of attempts so far
+func (r *PhoneVerificationRepository) IncrementAttempts(ctx context.Context, userID uuid.UUID) (int, error) {
+	attempts, err := r.queries.IncrementPhoneVerificationAttempts(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, user.ErrPhoneVerificationNotFound
+		}
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to increment phone verification attempts")
+		return 0, fmt.Errorf("failed to increment phone verification attempts: %w", err)
+	}
+	return int(attempts), nil
+}
+
+// Delete removes the user's pending verification
+func (r *PhoneVerificationRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.DeletePhoneVerification(ctx, userID); err != nil {
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to delete phone verification")
+		return fmt.Errorf("failed to delete phone verification: %w", err)
+	}
+	return nil
+}
+
+// toDomainPhoneVerification converts sqlc PhoneVerification to domain user.PhoneVerification
+func toDomainPhoneVerification(row *postgres.PhoneVerification) *user.PhoneVerification {
+	return &user.PhoneVerification{
+		UserID:    row.UserID,
+		Phone:     row.Phone,
+		CodeHash:  row.CodeHash,
+		Attempts:  int(row.Attempts),
+		ExpiresAt: row.ExpiresAt.Time,
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
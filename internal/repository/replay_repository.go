@@ -0,0 +1,370 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/replay"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReplayRepository implements replay.Repository using sqlc
+type ReplayRepository struct {
+	queries   *postgres.Queries
+	auditLogs *AuditRepository
+	logger    *observability.Logger
+	pii       *pii.Encryptor
+}
+
+// NewReplayRepository creates a new ReplayRepository instance
+func NewReplayRepository(pool *pgxpool.Pool, logger *observability.Logger) *ReplayRepository {
+	return &ReplayRepository{
+		queries:   postgres.New(pool),
+		auditLogs: NewAuditRepository(pool, logger),
+		logger:    logger,
+	}
+}
+
+// WithFieldEncryption decrypts the PII of replayed users with enc
+func (r *ReplayRepository) WithFieldEncryption(enc *pii.Encryptor) *ReplayRepository {
+	r.pii = enc
+	return r
+}
+
+// Create stores a new pending replay job
+func (r *ReplayRepository) Create(ctx context.Context, req *replay.Request) (*replay.Job, error) {
+	eventTypes := req.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	row, err := r.queries.CreateEventReplayJob(ctx, postgres.CreateEventReplayJobParams{
+		Source:       string(req.Source),
+		EventTypes:   eventTypes,
+		FromTime:     optionalTimestamptz(req.From),
+		ToTime:       optionalTimestamptz(req.To),
+		TargetStream: req.TargetStream,
+		RateLimit:    int32(req.RateLimit),
+		BatchSize:    int32(req.BatchSize),
+		RequestedBy:  req.RequestedBy,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("source", string(req.Source)).Error("failed to create replay job")
+		return nil, fmt.Errorf("failed to create replay job: %w", err)
+	}
+	return toDomainReplayJob(&row), nil
+}
+
+// GetByID retrieves a replay job by ID
+func (r *ReplayRepository) GetByID(ctx context.Context, id uuid.UUID) (*replay.Job, error) {
+	row, err := r.queries.GetEventReplayJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, replay.ErrJobNotFound
+		}
+		r.logger.WithError(err).WithField("job_id", id.String()).Error("failed to get replay job")
+		return nil, fmt.Errorf("failed to get replay job: %w", err)
+	}
+	return toDomainReplayJob(&row), nil
+}
+
+// List retrieves replay jobs, newest first
+func (r *ReplayRepository) List(ctx context.Context, limit, offset int32) ([]*replay.Job, error) {
+	rows, err := r.queries.ListEventReplayJobs(ctx, postgres.ListEventReplayJobsParams{
+		LimitCount:  limit,
+		OffsetCount: offset,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list replay jobs")
+		return nil, fmt.Errorf("failed to list replay jobs: %w", err)
+	}
+
+	jobs := make([]*replay.Job, len(rows))
+	for i := range rows {
+		jobs[i] = toDomainReplayJob(&rows[i])
+	}
+	return jobs, nil
+}
+
+// Claim leases the oldest runnable job to owner.
+// Returns replay.ErrNoJob when no job is pending and no running job's lease has expired.
+func (r *ReplayRepository) Claim(ctx context.Context, owner string, now, leaseUntil time.Time) (*replay.Job, error) {
+	row, err := r.queries.ClaimEventReplayJob(ctx, postgres.ClaimEventReplayJobParams{
+		Owner:      &owner,
+		LeaseUntil: pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+		Now:        pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, replay.ErrNoJob
+		}
+		r.logger.WithError(err).Error("failed to claim replay job")
+		return nil, fmt.Errorf("failed to claim replay job: %w", err)
+	}
+	return toDomainReplayJob(&row), nil
+}
+
+// SetTotal records how many source records a running job covers
+func (r *ReplayRepository) SetTotal(ctx context.Context, id uuid.UUID, owner string, total int64) error {
+	updated, err := r.queries.SetEventReplayJobTotal(ctx, postgres.SetEventReplayJobTotalParams{
+		TotalRecords: total,
+		ID:           id,
+		Owner:        &owner,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("job_id", id.String()).Error("failed to set replay job total")
+		return fmt.Errorf("failed to set replay job total: %w", err)
+	}
+	if updated == 0 {
+		return replay.ErrLeaseLost
+	}
+	return nil
+}
+
+// SaveProgress checkpoints a running job and extends its lease.
+// Returns replay.ErrLeaseLost when the job was cancelled or is now held by another owner.
+func (r *ReplayRepository) SaveProgress(ctx context.Context, job *replay.Job, owner string, leaseUntil time.Time) error {
+	updated, err := r.queries.SaveEventReplayJobProgress(ctx, postgres.SaveEventReplayJobProgressParams{
+		ResumeCursor:     string(job.Cursor),
+		ProcessedRecords: job.ProcessedRecords,
+		PublishedEvents:  job.PublishedEvents,
+		LeaseUntil:       pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+		ID:               job.ID,
+		Owner:            &owner,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("job_id", job.ID.String()).Error("failed to save replay job progress")
+		return fmt.Errorf("failed to save replay job progress: %w", err)
+	}
+	if updated == 0 {
+		return replay.ErrLeaseLost
+	}
+	return nil
+}
+
+// Finish moves a running job to completed or failed and releases its lease.
+// Returns replay.ErrLeaseLost when the job was cancelled or is now held by another owner.
+func (r *ReplayRepository) Finish(ctx context.Context, id uuid.UUID, owner string, status replay.Status, lastError *string, at time.Time) error {
+	updated, err := r.queries.FinishEventReplayJob(ctx, postgres.FinishEventReplayJobParams{
+		Status:      string(status),
+		LastError:   lastError,
+		CompletedAt: pgtype.Timestamptz{Time: at, Valid: true},
+		ID:          id,
+		Owner:       &owner,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("job_id", id.String()).Error("failed to finish replay job")
+		return fmt.Errorf("failed to finish replay job: %w", err)
+	}
+	if updated == 0 {
+		return replay.ErrLeaseLost
+	}
+	return nil
+}
+
+// Cancel stops a pending or running job.
+// Returns replay.ErrJobNotFound if the job does not exist and
+// replay.ErrJobNotCancellable if it already finished.
+func (r *ReplayRepository) Cancel(ctx context.Context, id uuid.UUID) (*replay.Job, error) {
+	row, err := r.queries.CancelEventReplayJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if _, getErr := r.GetByID(ctx, id); getErr != nil {
+				return nil, getErr
+			}
+			return nil, replay.ErrJobNotCancellable
+		}
+		r.logger.WithError(err).WithField("job_id", id.String()).Error("failed to cancel replay job")
+		return nil, fmt.Errorf("failed to cancel replay job: %w", err)
+	}
+	return toDomainReplayJob(&row), nil
+}
+
+// Resume makes a failed or cancelled job pending again.
+// Returns replay.ErrJobNotFound if the job does not exist and
+// replay.ErrJobNotResumable if it is pending, running or completed.
+func (r *ReplayRepository) Resume(ctx context.Context, id uuid.UUID) (*replay.Job, error) {
+	row, err := r.queries.ResumeEventReplayJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if _, getErr := r.GetByID(ctx, id); getErr != nil {
+				return nil, getErr
+			}
+			return nil, replay.ErrJobNotResumable
+		}
+		r.logger.WithError(err).WithField("job_id", id.String()).Error("failed to resume replay job")
+		return nil, fmt.Errorf("failed to resume replay job: %w", err)
+	}
+	return toDomainReplayJob(&row), nil
+}
+
+// CountUsers counts users, including deleted ones, created in window
+func (r *ReplayRepository) CountUsers(ctx context.Context, window replay.Window) (int64, error) {
+	count, err := r.queries.CountUsersForReplay(ctx, postgres.CountUsersForReplayParams{
+		FromTime: optionalTimestamptz(window.From),
+		ToTime:   optionalTimestamptz(window.To),
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to count users for replay")
+		return 0, fmt.Errorf("failed to count users for replay: %w", err)
+	}
+	return count, nil
+}
+
+// ListUsers returns the next page of users, including deleted ones, in (created_at, id) order
+func (r *ReplayRepository) ListUsers(ctx context.Context, window replay.Window, after *replay.Position, limit int32) ([]*user.User, error) {
+	params := postgres.ListUsersForReplayParams{
+		FromTime:   optionalTimestamptz(window.From),
+		ToTime:     optionalTimestamptz(window.To),
+		LimitCount: limit,
+	}
+	if after != nil {
+		params.AfterCreatedAt = pgtype.Timestamptz{Time: after.CreatedAt, Valid: true}
+		params.AfterID = pgtype.UUID{Bytes: after.ID, Valid: true}
+	}
+
+	rows, err := r.queries.ListUsersForReplay(ctx, params)
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list users for replay")
+		return nil, fmt.Errorf("failed to list users for replay: %w", err)
+	}
+
+	users := make([]*user.User, len(rows))
+	for i := range rows {
+		if err := decryptUserRow(ctx, r.pii, &rows[i]); err != nil {
+			r.logger.WithError(err).Error("failed to decrypt user for replay")
+			return nil, err
+		}
+		users[i] = dbUserToDomain(&rows[i])
+	}
+	return users, nil
+}
+
+// CountOutbox counts outbox messages of eventTypes (all when empty) that occurred in window
+func (r *ReplayRepository) CountOutbox(ctx context.Context, window replay.Window, eventTypes []string) (int64, error) {
+	count, err := r.queries.CountOutboxForReplay(ctx, postgres.CountOutboxForReplayParams{
+		FromTime:   optionalTimestamptz(window.From),
+		ToTime:     optionalTimestamptz(window.To),
+		EventTypes: nonNilStrings(eventTypes),
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to count outbox messages for replay")
+		return 0, fmt.Errorf("failed to count outbox messages for replay: %w", err)
+	}
+	return count, nil
+}
+
+// ListOutbox returns the next page of outbox messages in ID order
+func (r *ReplayRepository) ListOutbox(ctx context.Context, window replay.Window, eventTypes []string, afterID int64, limit int32) ([]*outbox.Message, error) {
+	rows, err := r.queries.ListOutboxForReplay(ctx, postgres.ListOutboxForReplayParams{
+		FromTime:   optionalTimestamptz(window.From),
+		ToTime:     optionalTimestamptz(window.To),
+		EventTypes: nonNilStrings(eventTypes),
+		AfterID:    afterID,
+		LimitCount: limit,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list outbox messages for replay")
+		return nil, fmt.Errorf("failed to list outbox messages for replay: %w", err)
+	}
+
+	messages := make([]*outbox.Message, len(rows))
+	for i := range rows {
+		if messages[i], err = toDomainOutboxMessage(&rows[i]); err != nil {
+			return nil, err
+		}
+	}
+	return messages, nil
+}
+
+// CountAuditLogs counts audit logs of eventTypes (all when empty) created in window
+func (r *ReplayRepository) CountAuditLogs(ctx context.Context, window replay.Window, eventTypes []string) (int64, error) {
+	count, err := r.queries.CountAuditLogsForReplay(ctx, postgres.CountAuditLogsForReplayParams{
+		FromTime:   optionalTimestamptz(window.From),
+		ToTime:     optionalTimestamptz(window.To),
+		EventTypes: nonNilStrings(eventTypes),
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to count audit logs for replay")
+		return 0, fmt.Errorf("failed to count audit logs for replay: %w", err)
+	}
+	return count, nil
+}
+
+// ListAuditLogs returns the next page of audit logs in (created_at, id) order
+func (r *ReplayRepository) ListAuditLogs(ctx context.Context, window replay.Window, eventTypes []string, after *replay.Position, limit int32) ([]*audit.Log, error) {
+	params := postgres.ListAuditLogsForReplayParams{
+		FromTime:   optionalTimestamptz(window.From),
+		ToTime:     optionalTimestamptz(window.To),
+		EventTypes: nonNilStrings(eventTypes),
+		LimitCount: limit,
+	}
+	if after != nil {
+		params.AfterCreatedAt = pgtype.Timestamptz{Time: after.CreatedAt, Valid: true}
+		params.AfterID = pgtype.UUID{Bytes: after.ID, Valid: true}
+	}
+
+	rows, err := r.queries.ListAuditLogsForReplay(ctx, params)
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list audit logs for replay")
+		return nil, fmt.Errorf("failed to list audit logs for replay: %w", err)
+	}
+	return r.auditLogs.toDomainAuditLogs(rows)
+}
+
+// toDomainReplayJob converts sqlc EventReplayJob to domain replay.Job
+func toDomainReplayJob(row *postgres.EventReplayJob) *replay.Job {
+	job := &replay.Job{
+		ID:               row.ID,
+		Source:           replay.Source(row.Source),
+		EventTypes:       row.EventTypes,
+		TargetStream:     row.TargetStream,
+		RateLimit:        int(row.RateLimit),
+		BatchSize:        int(row.BatchSize),
+		Status:           replay.Status(row.Status),
+		Cursor:           replay.Cursor(row.ResumeCursor),
+		TotalRecords:     row.TotalRecords,
+		ProcessedRecords: row.ProcessedRecords,
+		PublishedEvents:  row.PublishedEvents,
+		LastError:        row.LastError,
+		LeaseOwner:       row.LeaseOwner,
+		RequestedBy:      row.RequestedBy,
+		CreatedAt:        row.CreatedAt.Time,
+		UpdatedAt:        row.UpdatedAt.Time,
+	}
+	if row.FromTime.Valid {
+		job.From = &row.FromTime.Time
+	}
+	if row.ToTime.Valid {
+		job.To = &row.ToTime.Time
+	}
+	if row.LeaseExpiresAt.Valid {
+		job.LeaseExpiresAt = &row.LeaseExpiresAt.Time
+	}
+	if row.StartedAt.Valid {
+		job.StartedAt = &row.StartedAt.Time
+	}
+	if row.CompletedAt.Valid {
+		job.CompletedAt = &row.CompletedAt.Time
+	}
+	return job
+}
+
+// nonNilStrings returns values, or an empty slice for nil so it binds as '{}' rather than NULL
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
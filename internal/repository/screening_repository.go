@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Compile-time check to ensure ScreeningRepository implements screening.Repository
+var _ screening.Repository = (*ScreeningRepository)(nil)
+
+// ScreeningRepository implements screening.Repository using sqlc
+type ScreeningRepository struct {
+	queries *postgres.Queries
+	logger  *observability.Logger
+}
+
+// NewScreeningRepository creates a new ScreeningRepository instance
+func NewScreeningRepository(pool *pgxpool.Pool, logger *observability.Logger) *ScreeningRepository {
+	return &ScreeningRepository{
+		queries: postgres.New(pool),
+		logger:  logger,
+	}
+}
+
+// Create stores a new case
+func (r *ScreeningRepository) Create(ctx context.Context, c *screening.Case) (*screening.Case, error) {
+	subject, err := json.Marshal(c.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal screening subject: %w", err)
+	}
+	matches, err := json.Marshal(c.Matches)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal screening matches: %w", err)
+	}
+
+	row, err := r.queries.CreateScreeningCase(ctx, postgres.CreateScreeningCaseParams{
+		UserID:      c.UserID,
+		Status:      string(c.Status),
+		Trigger:     string(c.Trigger),
+		Subject:     subject,
+		Matches:     matches,
+		ListVersion: c.ListVersion,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", c.UserID.String()).Error("failed to create screening case")
+		return nil, fmt.Errorf("failed to create screening case: %w", err)
+	}
+	return toDomainScreeningCase(&row)
+}
+
+// GetByID retrieves a case by ID
+func (r *ScreeningRepository) GetByID(ctx context.Context, id uuid.UUID) (*screening.Case, error) {
+	row, err := r.queries.GetScreeningCase(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, screening.ErrCaseNotFound
+		}
+		r.logger.WithError(err).WithField("case_id", id.String()).Error("failed to get screening case")
+		return nil, fmt.Errorf("failed to get screening case: %w", err)
+	}
+	return toDomainScreeningCase(&row)
+}
+
+// List retrieves cases matching filter, oldest first
+func (r *ScreeningRepository) List(ctx context.Context, filter screening.ListFilter) ([]*screening.Case, error) {
+	rows, err := r.queries.ListScreeningCases(ctx, postgres.ListScreeningCasesParams{
+		Status:      (*string)(filter.Status),
+		UserID:      optionalUUID(filter.UserID),
+		LimitCount:  filter.Limit,
+		OffsetCount: filter.Offset,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list screening cases")
+		return nil, fmt.Errorf("failed to list screening cases: %w", err)
+	}
+	return toDomainScreeningCases(rows)
+}
+
+// Count returns how many cases match filter
+func (r *ScreeningRepository) Count(ctx context.Context, filter screening.ListFilter) (int64, error) {
+	count, err := r.queries.CountScreeningCases(ctx, postgres.CountScreeningCasesParams{
+		Status: (*string)(filter.Status),
+		UserID: optionalUUID(filter.UserID),
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to count screening cases")
+		return 0, fmt.Errorf("failed to count screening cases: %w", err)
+	}
+	return count, nil
+}
+
+// ListForUser retrieves every case of a user, newest first
+func (r *ScreeningRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]*screening.Case, error) {
+	rows, err := r.queries.ListScreeningCasesForUser(ctx, userID)
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("failed to list screening cases for user")
+		return nil, fmt.Errorf("failed to list screening cases for user: %w", err)
+	}
+	return toDomainScreeningCases(rows)
+}
+
+// Update stores c if its version is unchanged since it was loaded
+func (r *ScreeningRepository) Update(ctx context.Context, c *screening.Case) (*screening.Case, error) {
+	row, err := r.queries.UpdateScreeningCase(ctx, postgres.UpdateScreeningCaseParams{
+		Status:     string(c.Status),
+		ReviewedBy: optionalUUID(c.ReviewedBy),
+		ReviewedAt: optionalTimestamptz(c.ReviewedAt),
+		ReviewNote: c.ReviewNote,
+		ID:         c.ID,
+		Version:    int32(c.Version), // #nosec G115 -- incremented once per update
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, screening.ErrCaseConflict
+		}
+		r.logger.WithError(err).WithField("case_id", c.ID.String()).Error("failed to update screening case")
+		return nil, fmt.Errorf("failed to update screening case: %w", err)
+	}
+	return toDomainScreeningCase(&row)
+}
+
+func toDomainScreeningCases(rows []postgres.ScreeningCase) ([]*screening.Case, error) {
+	cases := make([]*screening.Case, len(rows))
+	for i := range rows {
+		c, err := toDomainScreeningCase(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		cases[i] = c
+	}
+	return cases, nil
+}
+
+// toDomainScreeningCase converts sqlc ScreeningCase to domain screening.Case
+func toDomainScreeningCase(row *postgres.ScreeningCase) (*screening.Case, error) {
+	c := &screening.Case{
+		ID:          row.ID,
+		UserID:      row.UserID,
+		Status:      screening.Status(row.Status),
+		Trigger:     screening.Trigger(row.Trigger),
+		ListVersion: row.ListVersion,
+		ReviewedBy:  fromOptionalUUID(row.ReviewedBy),
+		ReviewedAt:  fromOptionalTimestamptz(row.ReviewedAt),
+		ReviewNote:  row.ReviewNote,
+		Version:     int(row.Version),
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+	if err := json.Unmarshal(row.Subject, &c.Subject); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal screening subject: %w", err)
+	}
+	if err := json.Unmarshal(row.Matches, &c.Matches); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal screening matches: %w", err)
+	}
+	return c, nil
+}
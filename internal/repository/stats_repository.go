@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StatsRepository implements stats.Repository using sqlc. Daily statistics are kept in
+// stats_daily and the snapshot in the stats_kyc_funnel and stats_sessions_by_device
+// materialized views.
+type StatsRepository struct {
+	queries *postgres.Queries
+	logger  *observability.Logger
+}
+
+var _ stats.Repository = (*StatsRepository)(nil)
+
+// NewStatsRepository creates a new StatsRepository instance
+func NewStatsRepository(pool *pgxpool.Pool, logger *observability.Logger) *StatsRepository {
+	return &StatsRepository{
+		queries: postgres.New(pool),
+		logger:  logger,
+	}
+}
+
+// RecordActivity marks the users issued a refresh token at or after since as active on that UTC day
+func (r *StatsRepository) RecordActivity(ctx context.Context, since time.Time) (int64, error) {
+	n, err := r.queries.RecordUserActivity(ctx, pgtype.Timestamptz{Time: since, Valid: true})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to record user activity")
+		return 0, fmt.Errorf("failed to record user activity: %w", err)
+	}
+	return n, nil
+}
+
+// RefreshDaily recomputes the daily statistics of the UTC days from from to to inclusive
+func (r *StatsRepository) RefreshDaily(ctx context.Context, from, to time.Time) (int64, error) {
+	n, err := r.queries.RefreshDailyStats(ctx, postgres.RefreshDailyStatsParams{
+		FromDay: toDate(from),
+		ToDay:   toDate(to),
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to refresh daily statistics")
+		return 0, fmt.Errorf("failed to refresh daily statistics: %w", err)
+	}
+	return n, nil
+}
+
+// RefreshSnapshot recomputes the KYC funnel and sessions by device
+func (r *StatsRepository) RefreshSnapshot(ctx context.Context) error {
+	if err := r.queries.RefreshKYCFunnel(ctx); err != nil {
+		r.logger.WithError(err).Error("failed to refresh KYC funnel")
+		return fmt.Errorf("failed to refresh KYC funnel: %w", err)
+	}
+	if err := r.queries.RefreshSessionsByDevice(ctx); err != nil {
+		r.logger.WithError(err).Error("failed to refresh sessions by device")
+		return fmt.Errorf("failed to refresh sessions by device: %w", err)
+	}
+	return nil
+}
+
+// PruneActivity deletes the activity of days before before
+func (r *StatsRepository) PruneActivity(ctx context.Context, before time.Time) (int64, error) {
+	n, err := r.queries.PruneUserActivity(ctx, toDate(before))
+	if err != nil {
+		r.logger.WithError(err).Error("failed to prune user activity")
+		return 0, fmt.Errorf("failed to prune user activity: %w", err)
+	}
+	return n, nil
+}
+
+// LatestDay returns the last day with daily statistics, or nil if there are none
+func (r *StatsRepository) LatestDay(ctx context.Context) (*time.Time, error) {
+	day, err := r.queries.GetLatestStatsDay(ctx)
+	if err != nil {
+		r.logger.WithError(err).Error("failed to get latest statistics day")
+		return nil, fmt.Errorf("failed to get latest statistics day: %w", err)
+	}
+	if !day.Valid {
+		return nil, nil
+	}
+	latest := stats.Day(day.Time)
+	return &latest, nil
+}
+
+// ListDaily lists the daily statistics from from to to inclusive in order
+func (r *StatsRepository) ListDaily(ctx context.Context, from, to time.Time) ([]stats.Daily, error) {
+	rows, err := r.queries.ListDailyStats(ctx, postgres.ListDailyStatsParams{
+		FromDay: toDate(from),
+		ToDay:   toDate(to),
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list daily statistics")
+		return nil, fmt.Errorf("failed to list daily statistics: %w", err)
+	}
+
+	days := make([]stats.Daily, 0, len(rows))
+	for _, row := range rows {
+		days = append(days, stats.Daily{
+			Day:                stats.Day(row.Day.Time),
+			Registrations:      row.Registrations,
+			Logins:             row.Logins,
+			FailedLogins:       row.FailedLogins,
+			KYCApprovals:       row.KycApprovals,
+			ActiveUsers:        row.ActiveUsers,
+			MonthlyActiveUsers: row.MonthlyActiveUsers,
+			RefreshedAt:        row.RefreshedAt.Time,
+		})
+	}
+	return days, nil
+}
+
+// GetSnapshot returns the KYC funnel and sessions by device as of their last refresh
+func (r *StatsRepository) GetSnapshot(ctx context.Context) (*stats.Snapshot, error) {
+	funnel, err := r.queries.ListKYCFunnel(ctx)
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list KYC funnel")
+		return nil, fmt.Errorf("failed to list KYC funnel: %w", err)
+	}
+	devices, err := r.queries.ListSessionsByDevice(ctx)
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list sessions by device")
+		return nil, fmt.Errorf("failed to list sessions by device: %w", err)
+	}
+
+	snapshot := &stats.Snapshot{
+		KYCFunnel:        make([]stats.FunnelStage, 0, len(funnel)),
+		SessionsByDevice: make([]stats.DeviceSessions, 0, len(devices)),
+	}
+	// Both views are refreshed together; an empty view has no rows to tell when
+	observe := func(at pgtype.Timestamptz) {
+		if at.Valid && (snapshot.RefreshedAt == nil || at.Time.Before(*snapshot.RefreshedAt)) {
+			refreshedAt := at.Time
+			snapshot.RefreshedAt = &refreshedAt
+		}
+	}
+	for _, row := range funnel {
+		snapshot.KYCFunnel = append(snapshot.KYCFunnel, stats.FunnelStage{Status: row.Status, Users: row.Users})
+		observe(row.RefreshedAt)
+	}
+	for _, row := range devices {
+		snapshot.SessionsByDevice = append(snapshot.SessionsByDevice, stats.DeviceSessions{
+			Device:   stats.Device(row.Device),
+			Sessions: row.Sessions,
+			Users:    row.Users,
+		})
+		observe(row.RefreshedAt)
+	}
+	return snapshot, nil
+}
+
+// toDate converts the UTC day of t to a date parameter
+func toDate(t time.Time) pgtype.Date {
+	return pgtype.Date{Time: stats.Day(t), Valid: true}
+}
@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Compile-time check to ensure UserRepository implements user.PIIReencryptor
+var _ user.PIIReencryptor = (*UserRepository)(nil)
+
+// piiColumn is one PII column of a row or query parameters; the column name is
+// authenticated with the encrypted value
+type piiColumn struct {
+	name  string
+	value *string
+}
+
+// optionalPIIColumns returns the columns of the non-NULL optional values
+func optionalPIIColumns(columns []piiColumn, optional ...piiColumn) []piiColumn {
+	for _, column := range optional {
+		if column.value != nil {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// userRowPIIColumns returns the PII columns of a users row
+func userRowPIIColumns(row *postgres.User) []piiColumn {
+	return optionalPIIColumns(
+		[]piiColumn{
+			{"email", &row.Email},
+			{"first_name", &row.FirstName},
+			{"last_name", &row.LastName},
+		},
+		piiColumn{"phone", row.Phone},
+		piiColumn{"date_of_birth", row.DateOfBirth},
+		piiColumn{"address_line1", row.AddressLine1},
+		piiColumn{"address_line2", row.AddressLine2},
+		piiColumn{"address_city", row.AddressCity},
+		piiColumn{"address_postal_code", row.AddressPostalCode},
+		piiColumn{"address_region", row.AddressRegion},
+		piiColumn{"address_country", row.AddressCountry},
+		piiColumn{"nationality", row.Nationality},
+		piiColumn{"tax_residency", row.TaxResidency},
+	)
+}
+
+// profilePIIColumns returns the PII columns written by UpdateUserProfile
+func profilePIIColumns(params *postgres.UpdateUserProfileParams) []piiColumn {
+	return optionalPIIColumns(
+		[]piiColumn{
+			{"first_name", &params.FirstName},
+			{"last_name", &params.LastName},
+		},
+		piiColumn{"phone", params.Phone},
+		piiColumn{"date_of_birth", params.DateOfBirth},
+		piiColumn{"address_line1", params.AddressLine1},
+		piiColumn{"address_line2", params.AddressLine2},
+		piiColumn{"address_city", params.AddressCity},
+		piiColumn{"address_postal_code", params.AddressPostalCode},
+		piiColumn{"address_region", params.AddressRegion},
+		piiColumn{"address_country", params.AddressCountry},
+		piiColumn{"nationality", params.Nationality},
+		piiColumn{"tax_residency", params.TaxResidency},
+	)
+}
+
+// encryptPIIColumns encrypts the plaintext values of columns in place with key
+func encryptPIIColumns(enc *pii.Encryptor, key []byte, columns []piiColumn) error {
+	for _, column := range columns {
+		if pii.IsEncrypted(*column.value) {
+			continue
+		}
+		ciphertext, err := enc.Encrypt(key, column.name, *column.value)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt %s: %w", column.name, err)
+		}
+		*column.value = ciphertext
+	}
+	return nil
+}
+
+// decryptUserRow decrypts the PII columns of row in place. Rows without a data key hold
+// plaintext and are left unchanged.
+func decryptUserRow(ctx context.Context, enc *pii.Encryptor, row *postgres.User) error {
+	if row.PiiKey == nil {
+		return nil
+	}
+	if enc == nil {
+		return fmt.Errorf("user %s has encrypted PII but PII encryption is not configured", row.ID)
+	}
+
+	key, err := enc.DataKey(ctx, *row.PiiKey)
+	if err != nil {
+		return fmt.Errorf("failed to get data key of user %s: %w", row.ID, err)
+	}
+	for _, column := range userRowPIIColumns(row) {
+		plaintext, err := enc.Decrypt(key, column.name, *column.value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt PII of user %s: %w", row.ID, err)
+		}
+		*column.value = plaintext
+	}
+	return nil
+}
+
+// toDomain decrypts a users row and converts it to a domain User
+func (r *UserRepository) toDomain(ctx context.Context, row *postgres.User) (*user.User, error) {
+	if err := decryptUserRow(ctx, r.pii, row); err != nil {
+		r.logger.WithFields(map[string]interface{}{
+			"user_id": row.ID,
+			"error":   err.Error(),
+		}).Error("Failed to decrypt user PII")
+		return nil, err
+	}
+	return dbUserToDomain(row), nil
+}
+
+// toDomainList decrypts users rows and converts them to domain Users
+func (r *UserRepository) toDomainList(ctx context.Context, rows []postgres.User) ([]*user.User, error) {
+	users := make([]*user.User, len(rows))
+	for i := range rows {
+		u, err := r.toDomain(ctx, &rows[i])
+		if err != nil {
+			return nil, err
+		}
+		users[i] = u
+	}
+	return users, nil
+}
+
+// newDataKeyParams generates a data key and returns it with its stored form
+func (r *UserRepository) newDataKeyParams(ctx context.Context) ([]byte, *string, *int32, error) {
+	key, wrapped, version, err := r.pii.NewDataKey(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	storedVersion := int32(version) // #nosec G115 -- key versions are small counters
+	return key, &wrapped, &storedVersion, nil
+}
+
+// encryptNewUser gives a user being created a data key, encrypts its PII and sets the
+// email blind index. Returns user.ErrAlreadyExists if a user not re-encrypted yet has the email.
+func (r *UserRepository) encryptNewUser(ctx context.Context, params *postgres.CreateUserParams) error {
+	// The unique blind index can't see plaintext emails of users not re-encrypted yet
+	exists, err := r.queries.UserEmailExists(ctx, params.Email)
+	if err != nil {
+		return fmt.Errorf("failed to check email: %w", err)
+	}
+	if exists {
+		return user.ErrAlreadyExists
+	}
+
+	key, wrapped, version, err := r.newDataKeyParams(ctx)
+	if err != nil {
+		return err
+	}
+	params.EmailIndex = r.pii.BlindIndex(params.Email)
+	params.PiiKey = wrapped
+	params.PiiKeyVersion = version
+	return encryptPIIColumns(r.pii, key, []piiColumn{
+		{"email", &params.Email},
+		{"first_name", &params.FirstName},
+		{"last_name", &params.LastName},
+	})
+}
+
+// userDataKey returns the data key of a user, creating one if the user has none yet.
+// Returns user.ErrNotFound if the user doesn't exist.
+func (r *UserRepository) userDataKey(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	row, err := r.queries.GetUserPIIKey(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, user.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user data key: %w", err)
+	}
+	if row.PiiKey != nil {
+		return r.pii.DataKey(ctx, *row.PiiKey)
+	}
+
+	key, wrapped, version, err := r.newDataKeyParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, err = r.queries.SetUserPIIKey(ctx, postgres.SetUserPIIKeyParams{
+		ID:            id,
+		PiiKey:        wrapped,
+		PiiKeyVersion: version,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Another writer stored a key first; use theirs
+		return r.userDataKey(ctx, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store user data key: %w", err)
+	}
+	return key, nil
+}
+
+// CountPendingPIIReencryption counts users, including deleted ones, that still hold
+// plaintext PII or whose data key is wrapped with an older key version.
+func (r *UserRepository) CountPendingPIIReencryption(ctx context.Context) (int64, error) {
+	if r.pii == nil {
+		return 0, user.ErrPIIEncryptionDisabled
+	}
+	current, err := r.pii.CurrentKeyVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := r.queries.CountUsersForPIIReencryption(ctx, int32(current)) // #nosec G115 -- key versions are small counters
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to count users pending PII re-encryption")
+		return 0, fmt.Errorf("failed to count users pending PII re-encryption: %w", err)
+	}
+	return count, nil
+}
+
+// ReencryptPII processes up to limit pending users: plaintext values are encrypted, users
+// without a data key get one and data keys wrapped with an older key version are rewrapped.
+// Users changed while they were processed are skipped and picked up by the next call.
+// Returns the number of users updated.
+func (r *UserRepository) ReencryptPII(ctx context.Context, limit int32) (int, error) {
+	if r.pii == nil {
+		return 0, user.ErrPIIEncryptionDisabled
+	}
+	current, err := r.pii.CurrentKeyVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := r.queries.ListUsersForPIIReencryption(ctx, postgres.ListUsersForPIIReencryptionParams{
+		CurrentVersion: int32(current), // #nosec G115 -- key versions are small counters
+		LimitCount:     limit,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to list users pending PII re-encryption")
+		return 0, fmt.Errorf("failed to list users pending PII re-encryption: %w", err)
+	}
+
+	updated := 0
+	for i := range rows {
+		ok, err := r.reencryptUser(ctx, &rows[i], current)
+		if err != nil {
+			r.logger.WithFields(map[string]interface{}{
+				"user_id": rows[i].ID,
+				"error":   err.Error(),
+			}).Error("Failed to re-encrypt user PII")
+			return updated, fmt.Errorf("failed to re-encrypt PII of user %s: %w", rows[i].ID, err)
+		}
+		if ok {
+			updated++
+		}
+	}
+
+	r.logger.WithFields(map[string]interface{}{
+		"pending": len(rows),
+		"updated": updated,
+	}).Debug("PII re-encryption batch completed")
+	return updated, nil
+}
+
+// reencryptUser encrypts and rewraps one users row; false if the row changed concurrently
+func (r *UserRepository) reencryptUser(ctx context.Context, row *postgres.User, currentVersion int) (bool, error) {
+	var (
+		key     []byte
+		wrapped *string
+		version *int32
+		err     error
+	)
+	if row.PiiKey == nil {
+		if key, wrapped, version, err = r.newDataKeyParams(ctx); err != nil {
+			return false, err
+		}
+	} else {
+		if key, err = r.pii.DataKey(ctx, *row.PiiKey); err != nil {
+			return false, err
+		}
+		wrapped, version = row.PiiKey, row.PiiKeyVersion
+		if int(*version) < currentVersion {
+			rewrapped, newVersion, err := r.pii.RewrapDataKey(ctx, *wrapped)
+			if err != nil {
+				return false, err
+			}
+			storedVersion := int32(newVersion) // #nosec G115 -- key versions are small counters
+			wrapped, version = &rewrapped, &storedVersion
+		}
+	}
+
+	email, err := r.pii.Decrypt(key, "email", row.Email)
+	if err != nil {
+		return false, err
+	}
+	if err := encryptPIIColumns(r.pii, key, userRowPIIColumns(row)); err != nil {
+		return false, err
+	}
+
+	n, err := r.queries.UpdateUserPII(ctx, postgres.UpdateUserPIIParams{
+		Email:             row.Email,
+		EmailIndex:        r.pii.BlindIndex(email),
+		FirstName:         row.FirstName,
+		LastName:          row.LastName,
+		Phone:             row.Phone,
+		DateOfBirth:       row.DateOfBirth,
+		AddressLine1:      row.AddressLine1,
+		AddressLine2:      row.AddressLine2,
+		AddressCity:       row.AddressCity,
+		AddressPostalCode: row.AddressPostalCode,
+		AddressRegion:     row.AddressRegion,
+		AddressCountry:    row.AddressCountry,
+		Nationality:       row.Nationality,
+		TaxResidency:      row.TaxResidency,
+		PiiKey:            wrapped,
+		PiiKeyVersion:     version,
+		ID:                row.ID,
+		ReadUpdatedAt:     row.UpdatedAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to store re-encrypted PII: %w", err)
+	}
+	return n == 1, nil
+}
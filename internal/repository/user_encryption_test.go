@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPIIEncryptor(t *testing.T) *pii.Encryptor {
+	t.Helper()
+	keys, err := pii.NewLocalKeyManager([][]byte{bytes.Repeat([]byte{1}, 32)})
+	require.NoError(t, err)
+	enc, err := pii.NewEncryptor(keys, bytes.Repeat([]byte("i"), 32), 0, 0)
+	require.NoError(t, err)
+	return enc
+}
+
+func TestUserRowPIIEncryption(t *testing.T) {
+	ctx := context.Background()
+	enc := newTestPIIEncryptor(t)
+	key, wrapped, _, err := enc.NewDataKey(ctx)
+	require.NoError(t, err)
+
+	phone, city := "+40712345678", "Bucharest"
+	row := postgres.User{
+		ID:          uuid.New(),
+		Email:       "alice@example.com",
+		FirstName:   "Alice",
+		LastName:    "Smith",
+		Phone:       &phone,
+		AddressCity: &city,
+		PiiKey:      &wrapped,
+	}
+	plaintext := row
+
+	encrypted := row
+	encryptedPhone, encryptedCity := phone, city
+	encrypted.Phone, encrypted.AddressCity = &encryptedPhone, &encryptedCity
+	require.NoError(t, encryptPIIColumns(enc, key, userRowPIIColumns(&encrypted)))
+
+	for _, column := range userRowPIIColumns(&encrypted) {
+		assert.True(t, pii.IsEncrypted(*column.value), column.name)
+	}
+	assert.Nil(t, encrypted.DateOfBirth, "NULL columns stay NULL")
+
+	t.Run("encrypting again keeps ciphertext", func(t *testing.T) {
+		email := encrypted.Email
+		require.NoError(t, encryptPIIColumns(enc, key, userRowPIIColumns(&encrypted)))
+		assert.Equal(t, email, encrypted.Email)
+	})
+
+	t.Run("decrypts in place", func(t *testing.T) {
+		decrypted := encrypted
+		decryptedPhone, decryptedCity := *encrypted.Phone, *encrypted.AddressCity
+		decrypted.Phone, decrypted.AddressCity = &decryptedPhone, &decryptedCity
+
+		require.NoError(t, decryptUserRow(ctx, enc, &decrypted))
+		assert.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("rows without data key are plaintext", func(t *testing.T) {
+		legacy := plaintext
+		legacy.PiiKey = nil
+		require.NoError(t, decryptUserRow(ctx, nil, &legacy))
+		assert.Equal(t, "alice@example.com", legacy.Email)
+	})
+
+	t.Run("encrypted rows need an encryptor", func(t *testing.T) {
+		row := encrypted
+		assert.Error(t, decryptUserRow(ctx, nil, &row))
+	})
+
+	t.Run("wrong data key", func(t *testing.T) {
+		_, otherWrapped, _, err := enc.NewDataKey(ctx)
+		require.NoError(t, err)
+		row := encrypted
+		row.PiiKey = &otherWrapped
+		assert.ErrorIs(t, decryptUserRow(ctx, enc, &row), pii.ErrDecryptionFailed)
+	})
+}
+
+func TestSearchUsers_EncryptedPIIRequiresExactQuery(t *testing.T) {
+	// Rejected before the database is queried, so no pool is needed
+	repo := NewUserRepository(nil, observability.NewLogger("dev", "test-repository")).WithFieldEncryption(newTestPIIEncryptor(t))
+
+	for _, query := range []string{"alice", "Alice Smith", "@example.com"} {
+		search := user.UserSearch{Query: query}
+		require.NoError(t, search.Normalize())
+
+		_, err := repo.SearchUsers(context.Background(), search)
+		assert.ErrorIs(t, err, user.ErrInvalidSearch, query)
+		assert.ErrorContains(t, err, "exact email or user ID", query)
+	}
+}
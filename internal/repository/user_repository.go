@@ -13,6 +13,7 @@ import (
 
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
 	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
 	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -21,9 +22,12 @@ import (
 
 // UserRepository implements user.Repository using sqlc-generated queries.
 // Never exposes sqlc types to the domain layer - all conversions happen here.
+// With field encryption enabled, PII columns are encrypted with a per-user data key and
+// emails are looked up through a blind index.
 type UserRepository struct {
 	queries *postgres.Queries
 	logger  *observability.Logger
+	pii     *pii.Encryptor
 }
 
 // NewUserRepository creates a new UserRepository instance.
@@ -35,19 +39,38 @@ func NewUserRepository(db postgres.DBTX, logger *observability.Logger) *UserRepo
 	}
 }
 
+// WithFieldEncryption enables encryption of PII columns with enc.
+// Rows written before encryption was enabled stay readable and are encrypted by ReencryptPII.
+func (r *UserRepository) WithFieldEncryption(enc *pii.Encryptor) *UserRepository {
+	r.pii = enc
+	return r
+}
+
 // Create creates a new user with the provided email, first name, last name, and hashed password.
 // Returns user.ErrAlreadyExists if email already exists.
 func (r *UserRepository) Create(ctx context.Context, email, firstName, lastName, hashedPassword string) (*user.User, error) {
 	r.logger.WithField("email", email).Debug("Creating user")
 
 	// Default role is 'user' (handled by SQL query COALESCE)
-	dbUser, err := r.queries.CreateUser(ctx, postgres.CreateUserParams{
+	params := postgres.CreateUserParams{
 		Email:          email,
 		FirstName:      firstName,
 		LastName:       lastName,
 		HashedPassword: hashedPassword,
 		Column5:        "user", // role column
-	})
+	}
+	if r.pii != nil {
+		if err := r.encryptNewUser(ctx, &params); err != nil {
+			if errors.Is(err, user.ErrAlreadyExists) {
+				r.logger.WithField("email", email).Warn("User creation failed: email already exists")
+			} else {
+				r.logger.WithError(err).Error("Failed to encrypt new user")
+			}
+			return nil, err
+		}
+	}
+
+	dbUser, err := r.queries.CreateUser(ctx, params)
 	if err != nil {
 		// Check for unique constraint violation (duplicate email)
 		if isDuplicateKeyError(err) {
@@ -65,7 +88,7 @@ func (r *UserRepository) Create(ctx context.Context, email, firstName, lastName,
 		"user_id": dbUser.ID,
 		"email":   email,
 	}).Info("User created successfully")
-	return dbUserToDomain(&dbUser), nil
+	return r.toDomain(ctx, &dbUser)
 }
 
 // GetByID retrieves a user by their unique ID.
@@ -86,15 +109,27 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User,
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
 	}
 
-	return dbUserToDomain(&dbUser), nil
+	return r.toDomain(ctx, &dbUser)
 }
 
 // GetByEmail retrieves a user by their email address.
 // Returns user.ErrNotFound if user doesn't exist or is soft-deleted.
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*user.User, error) {
 	r.logger.WithField("email", email).Debug("Getting user by email")
-	
-	dbUser, err := r.queries.GetUserByEmail(ctx, email)
+
+	var (
+		dbUser postgres.User
+		err    error
+	)
+	if r.pii != nil {
+		dbUser, err = r.queries.GetUserByEmailIndex(ctx, r.pii.BlindIndex(email))
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Users not re-encrypted yet have no blind index
+			dbUser, err = r.queries.GetUserByEmail(ctx, email)
+		}
+	} else {
+		dbUser, err = r.queries.GetUserByEmail(ctx, email)
+	}
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			r.logger.WithField("email", email).Debug("User not found")
@@ -107,7 +142,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*user.Us
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
 
-	return dbUserToDomain(&dbUser), nil
+	return r.toDomain(ctx, &dbUser)
 }
 
 // UpdateKYCStatus updates the KYC verification status for a user.
@@ -148,19 +183,53 @@ func (r *UserRepository) UpdateKYCStatus(ctx context.Context, id uuid.UUID, stat
 		"user_id": id,
 		"status":  status,
 	}).Info("KYC status updated successfully")
-	return dbUserToDomain(&dbUser), nil
+	return r.toDomain(ctx, &dbUser)
 }
 
-// UpdateProfile updates the user's profile information (first name and last name).
+// UpdateProfile replaces the user's profile fields with profile; empty optional fields
+// are stored as NULL.
 // Returns user.ErrNotFound if user doesn't exist.
-func (r *UserRepository) UpdateProfile(ctx context.Context, id uuid.UUID, firstName, lastName string) (*user.User, error) {
+func (r *UserRepository) UpdateProfile(ctx context.Context, id uuid.UUID, profile user.Profile) (*user.User, error) {
 	r.logger.WithField("user_id", id).Debug("Updating user profile")
 
-	dbUser, err := r.queries.UpdateUserProfile(ctx, postgres.UpdateUserProfileParams{
-		ID:        id,
-		FirstName: firstName,
-		LastName:  lastName,
-	})
+	params := postgres.UpdateUserProfileParams{
+		ID:                id,
+		FirstName:         profile.FirstName,
+		LastName:          profile.LastName,
+		Phone:             optionalText(profile.Phone),
+		PhoneVerifiedAt:   optionalTimestamptz(profile.PhoneVerifiedAt),
+		AddressLine1:      optionalText(profile.Address.Line1),
+		AddressLine2:      optionalText(profile.Address.Line2),
+		AddressCity:       optionalText(profile.Address.City),
+		AddressPostalCode: optionalText(profile.Address.PostalCode),
+		AddressRegion:     optionalText(profile.Address.Region),
+		AddressCountry:    optionalText(profile.Address.Country),
+		Nationality:       optionalText(profile.Nationality),
+		TaxResidency:      optionalText(profile.TaxResidency),
+	}
+	if profile.DateOfBirth != "" {
+		if _, err := time.Parse(dateOfBirthLayout, profile.DateOfBirth); err != nil {
+			return nil, fmt.Errorf("invalid date of birth %q: %w", profile.DateOfBirth, err)
+		}
+		params.DateOfBirth = &profile.DateOfBirth
+	}
+	if r.pii != nil {
+		key, err := r.userDataKey(ctx, id)
+		if err != nil {
+			if !errors.Is(err, user.ErrNotFound) {
+				r.logger.WithFields(map[string]interface{}{
+					"user_id": id,
+					"error":   err.Error(),
+				}).Error("Failed to get user data key")
+			}
+			return nil, err
+		}
+		if err := encryptPIIColumns(r.pii, key, profilePIIColumns(&params)); err != nil {
+			return nil, err
+		}
+	}
+
+	dbUser, err := r.queries.UpdateUserProfile(ctx, params)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			r.logger.WithField("user_id", id).Debug("User not found for profile update")
@@ -174,7 +243,7 @@ func (r *UserRepository) UpdateProfile(ctx context.Context, id uuid.UUID, firstN
 	}
 
 	r.logger.WithField("user_id", id).Info("User profile updated successfully")
-	return dbUserToDomain(&dbUser), nil
+	return r.toDomain(ctx, &dbUser)
 }
 
 // SoftDelete marks a user as deleted without removing the record.
@@ -204,6 +273,98 @@ func (r *UserRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// Restore undeletes the user deleted at deletedAt and drops any deferred erasure of them.
+// Returns user.ErrNotFound if the user is not deleted at deletedAt or has been erased, and
+// user.ErrAlreadyExists if an active user has taken their email since.
+func (r *UserRepository) Restore(ctx context.Context, id uuid.UUID, deletedAt time.Time) (*user.User, error) {
+	r.logger.WithField("user_id", id).Debug("Restoring user")
+
+	dbUser, err := r.queries.RestoreUser(ctx, postgres.RestoreUserParams{
+		ID:        id,
+		DeletedAt: pgtype.Timestamptz{Time: deletedAt, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.logger.WithField("user_id", id).Debug("User not found for restore")
+			return nil, user.ErrNotFound
+		}
+		if isDuplicateKeyError(err) {
+			r.logger.WithField("user_id", id).Warn("User restore failed: email taken by another user")
+			return nil, user.ErrAlreadyExists
+		}
+		r.logger.WithFields(map[string]interface{}{
+			"user_id": id,
+			"error":   err.Error(),
+		}).Error("Failed to restore user")
+		return nil, fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	r.logger.WithField("user_id", id).Info("User restored successfully")
+	return r.toDomain(ctx, &dbUser)
+}
+
+// SetStatus sets the user's account status as change describes and appends change to their
+// status history. The old status of the history entry is the status the row held.
+// Returns user.ErrNotFound if user doesn't exist or is soft-deleted.
+func (r *UserRepository) SetStatus(ctx context.Context, change *user.AccountStatusChange) (*user.User, error) {
+	r.logger.WithFields(map[string]interface{}{
+		"user_id": change.UserID,
+		"status":  change.NewStatus,
+	}).Debug("Setting user status")
+
+	dbUser, err := r.queries.SetUserStatus(ctx, postgres.SetUserStatusParams{
+		ID:              change.UserID,
+		Status:          string(change.NewStatus),
+		StatusReason:    &change.Reason,
+		StatusExpiresAt: optionalTimestamptz(change.ExpiresAt),
+		StatusSetBy:     optionalUUID(change.ChangedBy),
+		SessionsRevoked: change.SessionsRevoked,
+		StatusSetAt:     pgtype.Timestamptz{Time: change.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.logger.WithField("user_id", change.UserID).Debug("User not found for status change")
+			return nil, user.ErrNotFound
+		}
+		r.logger.WithFields(map[string]interface{}{
+			"user_id": change.UserID,
+			"error":   err.Error(),
+		}).Error("Failed to set user status")
+		return nil, fmt.Errorf("failed to set user status: %w", err)
+	}
+
+	return r.toDomain(ctx, &dbUser)
+}
+
+// ListStatusChanges retrieves a user's account status history in order.
+func (r *UserRepository) ListStatusChanges(ctx context.Context, userID uuid.UUID) ([]*user.AccountStatusChange, error) {
+	rows, err := r.queries.ListUserStatusChanges(ctx, userID)
+	if err != nil {
+		r.logger.WithError(err).WithField("user_id", userID.String()).Error("Failed to list user status changes")
+		return nil, fmt.Errorf("failed to list user status changes: %w", err)
+	}
+
+	changes := make([]*user.AccountStatusChange, len(rows))
+	for i := range rows {
+		changes[i] = toDomainAccountStatusChange(&rows[i])
+	}
+	return changes, nil
+}
+
+// ListExpiredStatuses retrieves up to limit active users whose suspension or freeze expired
+// at or before now, earliest first.
+func (r *UserRepository) ListExpiredStatuses(ctx context.Context, now time.Time, limit int) ([]*user.User, error) {
+	dbUsers, err := r.queries.ListUsersWithExpiredStatus(ctx, postgres.ListUsersWithExpiredStatusParams{
+		StatusExpiresAt: pgtype.Timestamptz{Time: now, Valid: true},
+		Limit:           int32(limit), // #nosec G115 -- bounded by config validation (max 1000)
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to list users with expired status")
+		return nil, fmt.Errorf("failed to list users with expired status: %w", err)
+	}
+	return r.toDomainList(ctx, dbUsers)
+}
+
 // List retrieves a paginated list of active users.
 // Returns empty slice if no users found.
 func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]*user.User, error) {
@@ -233,9 +394,9 @@ func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]*user.U
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
 
-	users := make([]*user.User, len(dbUsers))
-	for i, dbUser := range dbUsers {
-		users[i] = dbUserToDomain(&dbUser)
+	users, err := r.toDomainList(ctx, dbUsers)
+	if err != nil {
+		return nil, err
 	}
 
 	r.logger.WithFields(map[string]interface{}{
@@ -271,6 +432,7 @@ func dbUserToDomain(dbUser *postgres.User) *user.User {
 		HashedPassword: dbUser.HashedPassword,
 		Role:           user.Role(dbUser.Role),
 		KYCStatus:      user.KYCStatus(dbUser.KycStatus),
+		Status:         user.AccountStatus(dbUser.Status),
 		CreatedAt:      pgTimestampToTime(dbUser.CreatedAt),
 		UpdatedAt:      pgTimestampToTime(dbUser.UpdatedAt),
 	}
@@ -281,9 +443,54 @@ func dbUserToDomain(dbUser *postgres.User) *user.User {
 		user.DeletedAt = &deletedAt
 	}
 
+	// Optional profile fields are NULL until the user provides them
+	user.Phone = getStringValue(dbUser.Phone)
+	user.PhoneVerifiedAt = fromOptionalTimestamptz(dbUser.PhoneVerifiedAt)
+	user.DateOfBirth = getStringValue(dbUser.DateOfBirth)
+	user.Address.Line1 = getStringValue(dbUser.AddressLine1)
+	user.Address.Line2 = getStringValue(dbUser.AddressLine2)
+	user.Address.City = getStringValue(dbUser.AddressCity)
+	user.Address.PostalCode = getStringValue(dbUser.AddressPostalCode)
+	user.Address.Region = getStringValue(dbUser.AddressRegion)
+	user.Address.Country = getStringValue(dbUser.AddressCountry)
+	user.Nationality = getStringValue(dbUser.Nationality)
+	user.TaxResidency = getStringValue(dbUser.TaxResidency)
+
+	// Status details are NULL until the status is first changed
+	user.StatusReason = getStringValue(dbUser.StatusReason)
+	user.StatusExpiresAt = fromOptionalTimestamptz(dbUser.StatusExpiresAt)
+	user.StatusSetBy = fromOptionalUUID(dbUser.StatusSetBy)
+	user.StatusSetAt = fromOptionalTimestamptz(dbUser.StatusSetAt)
+
 	return user
 }
 
+// toDomainAccountStatusChange converts a status history row to a domain AccountStatusChange
+func toDomainAccountStatusChange(row *postgres.UserStatusChange) *user.AccountStatusChange {
+	return &user.AccountStatusChange{
+		ID:              row.ID,
+		UserID:          row.UserID,
+		OldStatus:       user.AccountStatus(row.OldStatus),
+		NewStatus:       user.AccountStatus(row.NewStatus),
+		Reason:          row.Reason,
+		ExpiresAt:       fromOptionalTimestamptz(row.ExpiresAt),
+		ChangedBy:       fromOptionalUUID(row.ChangedBy),
+		SessionsRevoked: row.SessionsRevoked,
+		CreatedAt:       pgTimestampToTime(row.CreatedAt),
+	}
+}
+
+// dateOfBirthLayout is the format of user.User.DateOfBirth
+const dateOfBirthLayout = "2006-01-02"
+
+// optionalText converts an optional string to a nullable text value, empty meaning NULL
+func optionalText(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 // pgTimestampToTime converts pgtype.Timestamptz to time.Time.
 func pgTimestampToTime(ts pgtype.Timestamptz) time.Time {
 	if ts.Valid {
@@ -305,40 +512,117 @@ func isDuplicateKeyError(err error) bool {
 		strings.Contains(errMsg, "23505")
 }
 
-// SearchUsers searches users by email, first name, or last name with pagination.
-// Admin-only operation for user management.
-func (r *UserRepository) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*user.User, error) {
+// SearchUsers returns a page of users matching an admin search, which must be normalized.
+// It fetches one user more than the limit to tell whether there is a next page.
+// With field encryption, substring and full-text queries are rejected with ErrInvalidSearch:
+// they would silently miss every encrypted user.
+func (r *UserRepository) SearchUsers(ctx context.Context, search user.UserSearch) (*user.UserSearchPage, error) {
 	r.logger.WithFields(map[string]interface{}{
-		"query":  query,
-		"limit":  limit,
-		"offset": offset,
+		"query":  search.Query,
+		"sort":   search.Sort,
+		"limit":  search.Limit,
+		"cursor": search.Cursor != "",
 	}).Debug("Searching users")
 
-	// Validate pagination parameters to prevent integer overflow
-	if limit < 0 || limit > 1000 {
-		return nil, fmt.Errorf("invalid limit: must be between 0 and 1000")
+	// Validate the limit to prevent integer overflow
+	if search.Limit < 1 || search.Limit > user.MaxSearchLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", user.ErrInvalidSearch, user.MaxSearchLimit)
 	}
-	if offset < 0 {
-		return nil, fmt.Errorf("invalid offset: must be non-negative")
+	if r.pii != nil && !search.IsExactQuery() {
+		return nil, fmt.Errorf("%w: PII is encrypted, so the query must be an exact email or user ID", user.ErrInvalidSearch)
+	}
+	cursor, err := search.DecodeCursor()
+	if err != nil {
+		return nil, err
 	}
 
-	dbUsers, err := r.queries.SearchUsers(ctx, postgres.SearchUsersParams{
-		Column1: &query,
-		Limit:   int32(limit),  // #nosec G115 -- validated above
-		Offset:  int32(offset), // #nosec G115 -- validated above
-	})
+	filter := postgres.SearchUsersNewestParams{
+		Query:        search.Query,
+		QueryPattern: "%" + escapeLikePattern(strings.ToLower(search.Query)) + "%",
+		Role:         optionalText(string(search.Role)),
+		KycStatus:    optionalText(string(search.KYCStatus)),
+		Status:       optionalText(string(search.Status)),
+		CreatedFrom:  optionalTimestamp(search.CreatedFrom),
+		CreatedTo:    optionalTimestamp(search.CreatedTo),
+		Deleted:      string(search.Deleted),
+		LimitCount:   int32(search.Limit + 1), // #nosec G115 -- validated above
+	}
+	if id, err := uuid.Parse(search.Query); err == nil {
+		filter.QueryID = pgtype.UUID{Bytes: id, Valid: true}
+	}
+	if r.pii != nil && search.Query != "" {
+		// Encrypted emails can't be matched by pattern; the exact email matches its blind index
+		filter.EmailIndex = r.pii.BlindIndex(search.Query)
+	}
+	if cursor != nil {
+		filter.AfterCreatedAt = pgtype.Timestamptz{Time: cursor.CreatedAt, Valid: true}
+		filter.AfterID = pgtype.UUID{Bytes: cursor.ID, Valid: true}
+	}
+
+	var dbUsers []postgres.User
+	ranks := map[uuid.UUID]float64{}
+	switch search.Sort {
+	case user.SearchSortNewest:
+		dbUsers, err = r.queries.SearchUsersNewest(ctx, filter)
+	case user.SearchSortOldest:
+		dbUsers, err = r.queries.SearchUsersOldest(ctx, postgres.SearchUsersOldestParams(filter))
+	case user.SearchSortRelevance:
+		params := postgres.SearchUsersByRelevanceParams{
+			QueryID:      filter.QueryID,
+			EmailIndex:   filter.EmailIndex,
+			Query:        filter.Query,
+			QueryPattern: filter.QueryPattern,
+			Role:         filter.Role,
+			KycStatus:    filter.KycStatus,
+			Status:       filter.Status,
+			CreatedFrom:  filter.CreatedFrom,
+			CreatedTo:    filter.CreatedTo,
+			Deleted:      filter.Deleted,
+			AfterID:      filter.AfterID,
+			LimitCount:   filter.LimitCount,
+		}
+		if cursor != nil {
+			params.AfterRank = &cursor.Rank
+		}
+		var rows []postgres.SearchUsersByRelevanceRow
+		rows, err = r.queries.SearchUsersByRelevance(ctx, params)
+		for _, row := range rows {
+			dbUsers = append(dbUsers, row.User)
+			ranks[row.User.ID] = row.Rank
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown sort %q", user.ErrInvalidSearch, search.Sort)
+	}
 	if err != nil {
 		r.logger.WithError(err).Error("Failed to search users")
 		return nil, fmt.Errorf("failed to search users: %w", err)
 	}
 
-	users := make([]*user.User, len(dbUsers))
-	for i, dbUser := range dbUsers {
-		users[i] = dbUserToDomain(&dbUser)
+	page := &user.UserSearchPage{}
+	if len(dbUsers) > search.Limit {
+		dbUsers = dbUsers[:search.Limit]
+		last := dbUserToDomain(&dbUsers[len(dbUsers)-1])
+		page.NextCursor = search.NextCursor(last, ranks[last.ID])
+	}
+	if page.Users, err = r.toDomainList(ctx, dbUsers); err != nil {
+		return nil, err
 	}
 
-	r.logger.WithField("count", len(users)).Debug("Users search completed")
-	return users, nil
+	r.logger.WithField("count", len(page.Users)).Debug("Users search completed")
+	return page, nil
+}
+
+// escapeLikePattern escapes the LIKE wildcards in s so it matches literally
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// optionalTimestamp converts an optional time to a nullable timestamp, nil meaning NULL
+func optionalTimestamp(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
 }
 
 // UpdateRole updates a user's role (admin-only operation).
@@ -371,7 +655,7 @@ func (r *UserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role user
 	}
 
 	r.logger.WithField("user_id", id).Info("User role updated successfully")
-	return dbUserToDomain(&dbUser), nil
+	return r.toDomain(ctx, &dbUser)
 }
 
 // GetByIDIncludeDeleted retrieves a user by ID including soft-deleted users.
@@ -389,5 +673,5 @@ func (r *UserRepository) GetByIDIncludeDeleted(ctx context.Context, id uuid.UUID
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	return dbUserToDomain(&dbUser), nil
+	return r.toDomain(ctx, &dbUser)
 }
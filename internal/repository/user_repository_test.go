@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain"
 	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
@@ -237,19 +238,47 @@ func TestUserRepository_UpdateProfile(t *testing.T) {
 		created, err := repo.Create(ctx, email, "Old", "Name", "pass")
 		require.NoError(t, err)
 
-		updated, err := repo.UpdateProfile(ctx, created.ID, "New", "Name")
+		updated, err := repo.UpdateProfile(ctx, created.ID, domain.Profile{FirstName: "New", LastName: "Name"})
 		require.NoError(t, err)
 		assert.Equal(t, "New", updated.FirstName)
 		assert.Equal(t, "Name", updated.LastName)
 		assert.True(t, updated.UpdatedAt.After(created.UpdatedAt))
 	})
 
+	t.Run("update extended profile", func(t *testing.T) {
+		email := generateTestEmail()
+		created, err := repo.Create(ctx, email, "Ana", "Pop", "pass")
+		require.NoError(t, err)
+
+		profile := domain.Profile{
+			FirstName:   "Ana",
+			LastName:    "Pop",
+			Phone:       "+40721234567",
+			DateOfBirth: "1990-04-12",
+			Address: domain.Address{
+				Line1:      "Strada Lipscani 10",
+				City:       "Bucharest",
+				PostalCode: "030031",
+				Country:    "RO",
+			},
+			Nationality:  "RO",
+			TaxResidency: "RO",
+		}
+		updated, err := repo.UpdateProfile(ctx, created.ID, profile)
+		require.NoError(t, err)
+		assert.Equal(t, profile, updated.Profile())
+
+		cleared, err := repo.UpdateProfile(ctx, created.ID, domain.Profile{FirstName: "Ana", LastName: "Pop"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.Profile{FirstName: "Ana", LastName: "Pop"}, cleared.Profile())
+	})
+
 	t.Run("update to empty full name", func(t *testing.T) {
 		email := generateTestEmail()
 		created, err := repo.Create(ctx, email, "Has", "Name", "pass")
 		require.NoError(t, err)
 
-		updated, err := repo.UpdateProfile(ctx, created.ID, "", "")
+		updated, err := repo.UpdateProfile(ctx, created.ID, domain.Profile{})
 		require.NoError(t, err)
 		assert.Equal(t, "", updated.FirstName)
 		assert.Equal(t, "", updated.LastName)
@@ -257,7 +286,7 @@ func TestUserRepository_UpdateProfile(t *testing.T) {
 
 	t.Run("update non-existent user returns error", func(t *testing.T) {
 		randomID := uuid.New()
-		_, err := repo.UpdateProfile(ctx, randomID, "New", "Name")
+		_, err := repo.UpdateProfile(ctx, randomID, domain.Profile{FirstName: "New", LastName: "Name"})
 		assert.ErrorIs(t, err, domain.ErrUserNotFound)
 	})
 }
@@ -435,6 +464,13 @@ func TestUserRepository_SearchUsers(t *testing.T) {
 	repo := repository.NewUserRepository(pool, getTestLogger())
 	ctx := context.Background()
 
+	search := func(t *testing.T, s domain.UserSearch) *domain.UserSearchPage {
+		require.NoError(t, s.Normalize())
+		page, err := repo.SearchUsers(ctx, s)
+		require.NoError(t, err)
+		return page
+	}
+
 	t.Run("search users by email", func(t *testing.T) {
 		// Create test users with distinctive emails
 		email1 := "alice.smith.test." + uuid.New().String() + "@example.com"
@@ -449,8 +485,7 @@ func TestUserRepository_SearchUsers(t *testing.T) {
 		require.NoError(t, err)
 
 		// Search for "alice"
-		users, err := repo.SearchUsers(ctx, "alice", 10, 0)
-		require.NoError(t, err)
+		users := search(t, domain.UserSearch{Query: "alice", Limit: 10}).Users
 		assert.GreaterOrEqual(t, len(users), 2, "should find at least 2 users with 'alice'")
 
 		// Verify all returned users contain "alice" in email or name
@@ -467,8 +502,7 @@ func TestUserRepository_SearchUsers(t *testing.T) {
 		created, err := repo.Create(ctx, email, "Charlie", "Brown", "pass")
 		require.NoError(t, err)
 
-		users, err := repo.SearchUsers(ctx, "Charlie", 10, 0)
-		require.NoError(t, err)
+		users := search(t, domain.UserSearch{Query: "Charlie", Limit: 10}).Users
 
 		found := false
 		for _, user := range users {
@@ -485,8 +519,7 @@ func TestUserRepository_SearchUsers(t *testing.T) {
 		created, err := repo.Create(ctx, email, "David", "Wilson", "pass")
 		require.NoError(t, err)
 
-		users, err := repo.SearchUsers(ctx, "Wilson", 10, 0)
-		require.NoError(t, err)
+		users := search(t, domain.UserSearch{Query: "Wilson", Limit: 10}).Users
 
 		found := false
 		for _, user := range users {
@@ -507,15 +540,60 @@ func TestUserRepository_SearchUsers(t *testing.T) {
 			require.NoError(t, err)
 		}
 
-		// Get first page
-		users1, err := repo.SearchUsers(ctx, "pagination.test", 2, 0)
-		require.NoError(t, err)
-		assert.LessOrEqual(t, len(users1), 2)
+		// Page through the matches by cursor, oldest first
+		query := "pagination.test." + baseID
+		page1 := search(t, domain.UserSearch{Query: query, Sort: domain.SearchSortOldest, Limit: 2})
+		require.Len(t, page1.Users, 2)
+		require.NotEmpty(t, page1.NextCursor)
+
+		page2 := search(t, domain.UserSearch{Query: query, Sort: domain.SearchSortOldest, Limit: 2, Cursor: page1.NextCursor})
+		require.Len(t, page2.Users, 2)
+		assert.True(t, page2.Users[0].CreatedAt.After(page1.Users[1].CreatedAt) ||
+			page2.Users[0].CreatedAt.Equal(page1.Users[1].CreatedAt))
+
+		page3 := search(t, domain.UserSearch{Query: query, Sort: domain.SearchSortOldest, Limit: 2, Cursor: page2.NextCursor})
+		assert.Len(t, page3.Users, 1)
+		assert.Empty(t, page3.NextCursor)
+
+		seen := map[uuid.UUID]bool{}
+		for _, page := range []*domain.UserSearchPage{page1, page2, page3} {
+			for _, u := range page.Users {
+				assert.False(t, seen[u.ID], "users must not repeat across pages")
+				seen[u.ID] = true
+			}
+		}
+
+		// Relevance pages continue where the previous page stopped too
+		rel1 := search(t, domain.UserSearch{Query: query, Limit: 3})
+		rel2 := search(t, domain.UserSearch{Query: query, Limit: 3, Cursor: rel1.NextCursor})
+		assert.Len(t, append(rel1.Users, rel2.Users...), 5)
+
+		// A cursor is only valid for the search it was returned for
+		_, err := repo.SearchUsers(ctx, domain.UserSearch{Query: "other", Sort: domain.SearchSortOldest, Deleted: domain.DeletedExclude, Limit: 2, Cursor: page1.NextCursor})
+		assert.ErrorIs(t, err, domain.ErrInvalidSearchCursor)
+	})
 
-		// Get second page
-		users2, err := repo.SearchUsers(ctx, "pagination.test", 2, 2)
+	t.Run("search filters", func(t *testing.T) {
+		email := generateTestEmail()
+		created, err := repo.Create(ctx, email, "Filtered", "Search", "pass")
+		require.NoError(t, err)
+		_, err = repo.UpdateKYCStatus(ctx, created.ID, domain.KYCStatusVerified)
 		require.NoError(t, err)
-		assert.LessOrEqual(t, len(users2), 2)
+
+		users := search(t, domain.UserSearch{Query: email, KYCStatus: domain.KYCStatusVerified}).Users
+		require.Len(t, users, 1)
+		assert.Equal(t, created.ID, users[0].ID)
+
+		assert.Empty(t, search(t, domain.UserSearch{Query: email, KYCStatus: domain.KYCStatusRejected}).Users)
+		assert.Empty(t, search(t, domain.UserSearch{Query: email, Role: domain.RoleAdmin}).Users)
+
+		future := time.Now().Add(time.Hour)
+		assert.Empty(t, search(t, domain.UserSearch{Query: email, CreatedFrom: &future}).Users)
+
+		// Exact ID matches rank first
+		users = search(t, domain.UserSearch{Query: created.ID.String()}).Users
+		require.NotEmpty(t, users)
+		assert.Equal(t, created.ID, users[0].ID)
 	})
 
 	t.Run("search excludes soft-deleted users", func(t *testing.T) {
@@ -524,8 +602,7 @@ func TestUserRepository_SearchUsers(t *testing.T) {
 		require.NoError(t, err)
 
 		// Verify user is found before deletion
-		users, err := repo.SearchUsers(ctx, email, 10, 0)
-		require.NoError(t, err)
+		users := search(t, domain.UserSearch{Query: email}).Users
 		assert.Greater(t, len(users), 0)
 
 		// Delete user
@@ -533,18 +610,21 @@ func TestUserRepository_SearchUsers(t *testing.T) {
 		require.NoError(t, err)
 
 		// Search should not include deleted user
-		users, err = repo.SearchUsers(ctx, email, 10, 0)
-		require.NoError(t, err)
-
+		users = search(t, domain.UserSearch{Query: email}).Users
 		for _, user := range users {
 			assert.NotEqual(t, created.ID, user.ID)
 		}
+
+		// Unless asked for
+		users = search(t, domain.UserSearch{Query: email, Deleted: domain.DeletedOnly}).Users
+		require.Len(t, users, 1)
+		assert.Equal(t, created.ID, users[0].ID)
 	})
 
 	t.Run("search with no results", func(t *testing.T) {
-		users, err := repo.SearchUsers(ctx, "nonexistentquery12345xyz", 10, 0)
-		require.NoError(t, err)
-		assert.Empty(t, users)
+		page := search(t, domain.UserSearch{Query: "nonexistentquery12345xyz"})
+		assert.Empty(t, page.Users)
+		assert.Empty(t, page.NextCursor)
 	})
 }
 
@@ -0,0 +1,66 @@
+package repository_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
+	"github.com/alex-necsoiu/pandora-exchange/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUserRepository_SearchEncryptedUsers tests that admin search finds users with encrypted
+// PII by exact email and ID, and rejects queries that could only match plaintext
+func TestUserRepository_SearchEncryptedUsers(t *testing.T) {
+	pool := setupRepositoryTestDB(t)
+	ctx := context.Background()
+
+	keys, err := pii.NewLocalKeyManager([][]byte{bytes.Repeat([]byte{1}, 32)})
+	require.NoError(t, err)
+	enc, err := pii.NewEncryptor(keys, bytes.Repeat([]byte("i"), 32), 0, 0)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	logger := observability.NewLoggerWithWriter("dev", "test-search", &buf)
+	repo := repository.NewUserRepository(pool, logger).WithFieldEncryption(enc)
+
+	email := "search-" + uuid.NewString()[:8] + "@example.com"
+	created, err := repo.Create(ctx, email, "Encrypted", "Searcher", "hashed-password")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = $1", created.ID)
+	})
+
+	var stored string
+	require.NoError(t, pool.QueryRow(ctx, "SELECT email FROM users WHERE id = $1", created.ID).Scan(&stored))
+	require.True(t, pii.IsEncrypted(stored), "the row under test must hold encrypted PII")
+
+	search := func(t *testing.T, query string) (*user.UserSearchPage, error) {
+		s := user.UserSearch{Query: query}
+		require.NoError(t, s.Normalize())
+		return repo.SearchUsers(ctx, s)
+	}
+
+	for _, query := range []string{email, created.ID.String()} {
+		page, err := search(t, query)
+		require.NoError(t, err, query)
+		require.Len(t, page.Users, 1, query)
+		assert.Equal(t, created.ID, page.Users[0].ID)
+		assert.Equal(t, "Encrypted", page.Users[0].FirstName, "results are decrypted")
+	}
+
+	for _, query := range []string{"Encrypted", "Searcher", "search-", "example.com"} {
+		_, err := search(t, query)
+		assert.ErrorIs(t, err, user.ErrInvalidSearch, query)
+	}
+
+	// Filters without a query still list encrypted users
+	page, err := repo.SearchUsers(ctx, user.UserSearch{Sort: user.SearchSortNewest, Deleted: user.DeletedExclude, Limit: user.MaxSearchLimit})
+	require.NoError(t, err)
+	assert.NotEmpty(t, page.Users)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Compile-time check to ensure UserTransactor implements user.Transactor
+var _ user.Transactor = (*UserTransactor)(nil)
+
+// UserTransactor implements user.Transactor: user writes and their outbox events
+// share one database transaction.
+type UserTransactor struct {
+	pool   *pgxpool.Pool
+	logger *observability.Logger
+	pii    *pii.Encryptor
+}
+
+// NewUserTransactor creates a new UserTransactor instance
+func NewUserTransactor(pool *pgxpool.Pool, logger *observability.Logger) *UserTransactor {
+	return &UserTransactor{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// WithFieldEncryption encrypts user PII written in transactions with enc
+func (t *UserTransactor) WithFieldEncryption(enc *pii.Encryptor) *UserTransactor {
+	t.pii = enc
+	return t
+}
+
+// WithinTx runs fn in a transaction, committing when it returns nil
+func (t *UserTransactor) WithinTx(ctx context.Context, fn func(repo user.Repository, events outbox.Writer) error) error {
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		t.logger.WithError(err).Error("failed to begin user transaction")
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+	queries := postgres.New(tx)
+	users := &UserRepository{queries: queries, logger: t.logger, pii: t.pii}
+	events := &OutboxWriter{queries: queries, logger: t.logger}
+
+	if err := fn(users, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		t.logger.WithError(err).Error("failed to commit user transaction")
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+)
+
+// webhookSecretField is authenticated with an encrypted subscription secret
+const webhookSecretField = "webhook_secret"
+
+// WithFieldEncryption encrypts subscription secrets at rest with enc, each under a data key of
+// its own. Without it secrets are stored in plaintext.
+func (r *WebhookRepository) WithFieldEncryption(enc *pii.Encryptor) *WebhookRepository {
+	r.pii = enc
+	return r
+}
+
+// encryptWebhookSecret encrypts secret with a new data key and returns the ciphertext with the
+// wrapped data key and its version. Without an encryptor the secret is returned as it is.
+func encryptWebhookSecret(ctx context.Context, enc *pii.Encryptor, secret string) (string, *string, *int32, error) {
+	if enc == nil {
+		return secret, nil, nil, nil
+	}
+	key, wrapped, version, err := enc.NewDataKey(ctx)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	ciphertext, err := enc.Encrypt(key, webhookSecretField, secret)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+	keyVersion := int32(version) // #nosec G115 -- key versions are small counters
+	return ciphertext, &wrapped, &keyVersion, nil
+}
+
+// decryptWebhookSecret decrypts the secret of row in place. Rows without a data key hold a
+// plaintext secret and are left unchanged.
+func decryptWebhookSecret(ctx context.Context, enc *pii.Encryptor, row *postgres.WebhookSubscription) error {
+	if row.SecretKey == nil {
+		return nil
+	}
+	if enc == nil {
+		return fmt.Errorf("webhook subscription %s has an encrypted secret but PII encryption is not configured", row.ID)
+	}
+
+	key, err := enc.DataKey(ctx, *row.SecretKey)
+	if err != nil {
+		return fmt.Errorf("failed to get data key of webhook subscription %s: %w", row.ID, err)
+	}
+	secret, err := enc.Decrypt(key, webhookSecretField, row.Secret)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt secret of webhook subscription %s: %w", row.ID, err)
+	}
+	row.Secret = secret
+	return nil
+}
+
+// toDomainSubscription decrypts a webhook_subscriptions row and converts it to a domain Subscription
+func (r *WebhookRepository) toDomainSubscription(ctx context.Context, row *postgres.WebhookSubscription) (*webhook.Subscription, error) {
+	if err := decryptWebhookSecret(ctx, r.pii, row); err != nil {
+		r.logger.WithError(err).WithField("subscription_id", row.ID.String()).Error("failed to decrypt webhook secret")
+		return nil, err
+	}
+	return toDomainWebhookSubscription(row), nil
+}
+
+// EncryptSecrets encrypts the secrets of subscriptions created before encryption was enabled
+// and rewraps data keys wrapped with an older key version. Subscriptions whose secret changed
+// meanwhile are skipped. Returns the number of subscriptions updated.
+func (r *WebhookRepository) EncryptSecrets(ctx context.Context) (int, error) {
+	if r.pii == nil {
+		return 0, nil
+	}
+	current, err := r.pii.CurrentKeyVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := r.queries.ListWebhookSubscriptionsPendingEncryption(ctx, int32(current)) // #nosec G115 -- key versions are small counters
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list webhook subscriptions pending encryption")
+		return 0, fmt.Errorf("failed to list webhook subscriptions pending encryption: %w", err)
+	}
+
+	updated := 0
+	for i := range rows {
+		row := &rows[i]
+		params := postgres.SetWebhookSubscriptionSecretParams{
+			ID:             row.ID,
+			Secret:         row.Secret,
+			PreviousSecret: row.Secret,
+		}
+		if row.SecretKey == nil {
+			params.Secret, params.SecretKey, params.SecretKeyVersion, err = encryptWebhookSecret(ctx, r.pii, row.Secret)
+		} else {
+			var rewrapped string
+			var version int
+			rewrapped, version, err = r.pii.RewrapDataKey(ctx, *row.SecretKey)
+			keyVersion := int32(version) // #nosec G115 -- key versions are small counters
+			params.SecretKey, params.SecretKeyVersion = &rewrapped, &keyVersion
+		}
+		if err != nil {
+			return updated, fmt.Errorf("failed to encrypt secret of webhook subscription %s: %w", row.ID, err)
+		}
+
+		n, err := r.queries.SetWebhookSubscriptionSecret(ctx, params)
+		if err != nil {
+			r.logger.WithError(err).WithField("subscription_id", row.ID.String()).Error("failed to store encrypted webhook secret")
+			return updated, fmt.Errorf("failed to store encrypted webhook secret: %w", err)
+		}
+		updated += int(n)
+	}
+	return updated, nil
+}
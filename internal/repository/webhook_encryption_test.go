@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSecretEncryption(t *testing.T) {
+	ctx := context.Background()
+	enc := newTestPIIEncryptor(t)
+	const secret = "whsec_0123456789abcdef"
+
+	ciphertext, wrapped, version, err := encryptWebhookSecret(ctx, enc, secret)
+	require.NoError(t, err)
+	assert.True(t, pii.IsEncrypted(ciphertext))
+	assert.NotContains(t, ciphertext, secret)
+	require.NotNil(t, wrapped)
+	require.NotNil(t, version)
+	assert.Equal(t, int32(1), *version)
+
+	row := postgres.WebhookSubscription{ID: uuid.New(), Secret: ciphertext, SecretKey: wrapped, SecretKeyVersion: version}
+
+	t.Run("decrypts in place", func(t *testing.T) {
+		decrypted := row
+		require.NoError(t, decryptWebhookSecret(ctx, enc, &decrypted))
+		assert.Equal(t, secret, decrypted.Secret)
+	})
+
+	t.Run("each secret has its own data key", func(t *testing.T) {
+		_, otherWrapped, _, err := encryptWebhookSecret(ctx, enc, secret)
+		require.NoError(t, err)
+		assert.NotEqual(t, *wrapped, *otherWrapped)
+
+		swapped := row
+		swapped.SecretKey = otherWrapped
+		assert.ErrorIs(t, decryptWebhookSecret(ctx, enc, &swapped), pii.ErrDecryptionFailed)
+	})
+
+	t.Run("ciphertext of another field does not decrypt", func(t *testing.T) {
+		key, err := enc.DataKey(ctx, *wrapped)
+		require.NoError(t, err)
+		other, err := enc.Encrypt(key, "email", secret)
+		require.NoError(t, err)
+
+		moved := row
+		moved.Secret = other
+		assert.ErrorIs(t, decryptWebhookSecret(ctx, enc, &moved), pii.ErrDecryptionFailed)
+	})
+
+	t.Run("rows without data key are plaintext", func(t *testing.T) {
+		legacy := postgres.WebhookSubscription{ID: uuid.New(), Secret: secret}
+		require.NoError(t, decryptWebhookSecret(ctx, nil, &legacy))
+		assert.Equal(t, secret, legacy.Secret)
+	})
+
+	t.Run("encrypted rows need an encryptor", func(t *testing.T) {
+		encrypted := row
+		assert.Error(t, decryptWebhookSecret(ctx, nil, &encrypted))
+	})
+
+	t.Run("without an encryptor secrets stay plaintext", func(t *testing.T) {
+		plaintext, wrapped, version, err := encryptWebhookSecret(ctx, nil, secret)
+		require.NoError(t, err)
+		assert.Equal(t, secret, plaintext)
+		assert.Nil(t, wrapped)
+		assert.Nil(t, version)
+	})
+}
@@ -0,0 +1,360 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/pii"
+	"github.com/alex-necsoiu/pandora-exchange/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WebhookRepository implements webhook.Repository using sqlc
+type WebhookRepository struct {
+	queries *postgres.Queries
+	logger  *observability.Logger
+	pii     *pii.Encryptor
+}
+
+// NewWebhookRepository creates a new WebhookRepository instance
+func NewWebhookRepository(pool *pgxpool.Pool, logger *observability.Logger) *WebhookRepository {
+	return &WebhookRepository{
+		queries: postgres.New(pool),
+		logger:  logger,
+	}
+}
+
+// Create stores a new enabled subscription
+func (r *WebhookRepository) Create(ctx context.Context, sub *webhook.Subscription) (*webhook.Subscription, error) {
+	secret, secretKey, secretKeyVersion, err := encryptWebhookSecret(ctx, r.pii, sub.Secret)
+	if err != nil {
+		r.logger.WithError(err).WithField("url", sub.URL).Error("failed to encrypt webhook secret")
+		return nil, err
+	}
+
+	row, err := r.queries.CreateWebhookSubscription(ctx, postgres.CreateWebhookSubscriptionParams{
+		Url:              sub.URL,
+		Description:      sub.Description,
+		EventTypes:       nonNilStrings(sub.EventTypes),
+		Secret:           secret,
+		SecretKey:        secretKey,
+		SecretKeyVersion: secretKeyVersion,
+		CreatedBy:        sub.CreatedBy,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("url", sub.URL).Error("failed to create webhook subscription")
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return r.toDomainSubscription(ctx, &row)
+}
+
+// GetByID retrieves a subscription by ID
+func (r *WebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*webhook.Subscription, error) {
+	row, err := r.queries.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, webhook.ErrSubscriptionNotFound
+		}
+		r.logger.WithError(err).WithField("subscription_id", id.String()).Error("failed to get webhook subscription")
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	return r.toDomainSubscription(ctx, &row)
+}
+
+// List retrieves subscriptions, newest first
+func (r *WebhookRepository) List(ctx context.Context, limit, offset int32) ([]*webhook.Subscription, error) {
+	rows, err := r.queries.ListWebhookSubscriptions(ctx, postgres.ListWebhookSubscriptionsParams{
+		LimitCount:  limit,
+		OffsetCount: offset,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to list webhook subscriptions")
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	subs := make([]*webhook.Subscription, len(rows))
+	for i := range rows {
+		if subs[i], err = r.toDomainSubscription(ctx, &rows[i]); err != nil {
+			return nil, err
+		}
+	}
+	return subs, nil
+}
+
+// Update replaces a subscription's settings
+func (r *WebhookRepository) Update(ctx context.Context, id uuid.UUID, update webhook.SubscriptionUpdate) (*webhook.Subscription, error) {
+	params := postgres.UpdateWebhookSubscriptionParams{
+		Url:            update.URL,
+		Description:    update.Description,
+		EventTypes:     nonNilStrings(update.EventTypes),
+		Enabled:        update.Enabled,
+		DisabledReason: update.DisabledReason,
+		ID:             id,
+	}
+	if update.Secret != nil {
+		secret, secretKey, secretKeyVersion, err := encryptWebhookSecret(ctx, r.pii, *update.Secret)
+		if err != nil {
+			r.logger.WithError(err).WithField("subscription_id", id.String()).Error("failed to encrypt webhook secret")
+			return nil, err
+		}
+		params.Secret, params.SecretKey, params.SecretKeyVersion = &secret, secretKey, secretKeyVersion
+	}
+
+	row, err := r.queries.UpdateWebhookSubscription(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, webhook.ErrSubscriptionNotFound
+		}
+		r.logger.WithError(err).WithField("subscription_id", id.String()).Error("failed to update webhook subscription")
+		return nil, fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	return r.toDomainSubscription(ctx, &row)
+}
+
+// Delete removes a subscription and its deliveries
+func (r *WebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	deleted, err := r.queries.DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		r.logger.WithError(err).WithField("subscription_id", id.String()).Error("failed to delete webhook subscription")
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	if deleted == 0 {
+		return webhook.ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// RecordFailure counts a failed attempt and reports whether it disabled the subscription.
+// Failures against a subscription that is already disabled are not counted.
+func (r *WebhookRepository) RecordFailure(ctx context.Context, id uuid.UUID, disableAfter int, reason string, at time.Time) (bool, error) {
+	row, err := r.queries.RecordWebhookSubscriptionFailure(ctx, postgres.RecordWebhookSubscriptionFailureParams{
+		DisableAfter:   int32(disableAfter), // #nosec G115 -- bounded by config
+		Now:            pgtype.Timestamptz{Time: at, Valid: true},
+		DisabledReason: &reason,
+		ID:             id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		r.logger.WithError(err).WithField("subscription_id", id.String()).Error("failed to record webhook failure")
+		return false, fmt.Errorf("failed to record webhook failure: %w", err)
+	}
+	return !row.Enabled, nil
+}
+
+// ResetFailures clears a subscription's failure count
+func (r *WebhookRepository) ResetFailures(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.ResetWebhookSubscriptionFailures(ctx, id); err != nil {
+		r.logger.WithError(err).WithField("subscription_id", id.String()).Error("failed to reset webhook failures")
+		return fmt.Errorf("failed to reset webhook failures: %w", err)
+	}
+	return nil
+}
+
+// Enqueue queues an event for every enabled subscription matching eventType
+func (r *WebhookRepository) Enqueue(ctx context.Context, eventID, eventType string, payload []byte, at time.Time) (int64, error) {
+	queued, err := r.queries.EnqueueWebhookDeliveries(ctx, postgres.EnqueueWebhookDeliveriesParams{
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       payload,
+		NextAttemptAt: pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		r.logger.WithError(err).WithFields(map[string]interface{}{
+			"event_id":   eventID,
+			"event_type": eventType,
+		}).Error("failed to enqueue webhook deliveries")
+		return 0, fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+	return queued, nil
+}
+
+// ClaimDue leases due pending deliveries until leaseUntil
+func (r *WebhookRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int32) ([]*webhook.Delivery, error) {
+	rows, err := r.queries.ClaimDueWebhookDeliveries(ctx, postgres.ClaimDueWebhookDeliveriesParams{
+		LeaseUntil: pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+		Now:        pgtype.Timestamptz{Time: now, Valid: true},
+		LimitCount: limit,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to claim webhook deliveries")
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	return toDomainWebhookDeliveries(rows), nil
+}
+
+// MarkSucceeded records an accepted delivery
+func (r *WebhookRepository) MarkSucceeded(ctx context.Context, id uuid.UUID, attempt webhook.Attempt) error {
+	statusCode := int32(attempt.StatusCode) // #nosec G115 -- HTTP status code
+	err := r.queries.MarkWebhookDeliverySucceeded(ctx, postgres.MarkWebhookDeliverySucceededParams{
+		AttemptedAt: pgtype.Timestamptz{Time: attempt.At, Valid: true},
+		StatusCode:  &statusCode,
+		ID:          id,
+	})
+	if err != nil {
+		r.logger.WithError(err).WithField("delivery_id", id.String()).Error("failed to mark webhook delivery succeeded")
+		return fmt.Errorf("failed to mark webhook delivery succeeded: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt; a nil nextAttemptAt marks the delivery failed
+func (r *WebhookRepository) MarkFailed(ctx context.Context, id uuid.UUID, attempt webhook.Attempt, nextAttemptAt *time.Time) error {
+	params := postgres.MarkWebhookDeliveryFailedParams{
+		Status:        string(webhook.DeliveryFailed),
+		AttemptedAt:   pgtype.Timestamptz{Time: attempt.At, Valid: true},
+		NextAttemptAt: pgtype.Timestamptz{Time: attempt.At, Valid: true},
+		ID:            id,
+	}
+	if nextAttemptAt != nil {
+		params.Status = string(webhook.DeliveryPending)
+		params.NextAttemptAt = pgtype.Timestamptz{Time: *nextAttemptAt, Valid: true}
+	}
+	if attempt.StatusCode != 0 {
+		statusCode := int32(attempt.StatusCode) // #nosec G115 -- HTTP status code
+		params.StatusCode = &statusCode
+	}
+	if attempt.Err != nil {
+		lastError := attempt.Err.Error()
+		params.LastError = &lastError
+	}
+
+	if err := r.queries.MarkWebhookDeliveryFailed(ctx, params); err != nil {
+		r.logger.WithError(err).WithField("delivery_id", id.String()).Error("failed to mark webhook delivery failed")
+		return fmt.Errorf("failed to mark webhook delivery failed: %w", err)
+	}
+	return nil
+}
+
+// GetDelivery retrieves a delivery by ID
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*webhook.Delivery, error) {
+	row, err := r.queries.GetWebhookDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, webhook.ErrDeliveryNotFound
+		}
+		r.logger.WithError(err).WithField("delivery_id", id.String()).Error("failed to get webhook delivery")
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return toDomainWebhookDelivery(&row), nil
+}
+
+// ListDeliveries retrieves a subscription's deliveries, newest first, optionally filtered by status
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, status *webhook.DeliveryStatus, limit, offset int32) ([]*webhook.Delivery, error) {
+	params := postgres.ListWebhookDeliveriesParams{
+		SubscriptionID: subscriptionID,
+		LimitCount:     limit,
+		OffsetCount:    offset,
+	}
+	if status != nil {
+		s := string(*status)
+		params.Status = &s
+	}
+
+	rows, err := r.queries.ListWebhookDeliveries(ctx, params)
+	if err != nil {
+		r.logger.WithError(err).WithField("subscription_id", subscriptionID.String()).Error("failed to list webhook deliveries")
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return toDomainWebhookDeliveries(rows), nil
+}
+
+// Redeliver queues a finished delivery again.
+// Returns webhook.ErrDeliveryNotFound if the subscription has no such delivery and
+// webhook.ErrDeliveryPending if it has not finished yet.
+func (r *WebhookRepository) Redeliver(ctx context.Context, subscriptionID, deliveryID uuid.UUID, now time.Time) (*webhook.Delivery, error) {
+	row, err := r.queries.RedeliverWebhookDelivery(ctx, postgres.RedeliverWebhookDeliveryParams{
+		Now:            pgtype.Timestamptz{Time: now, Valid: true},
+		ID:             deliveryID,
+		SubscriptionID: subscriptionID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			delivery, getErr := r.GetDelivery(ctx, deliveryID)
+			if getErr != nil {
+				return nil, getErr
+			}
+			if delivery.SubscriptionID != subscriptionID {
+				return nil, webhook.ErrDeliveryNotFound
+			}
+			return nil, webhook.ErrDeliveryPending
+		}
+		r.logger.WithError(err).WithField("delivery_id", deliveryID.String()).Error("failed to redeliver webhook delivery")
+		return nil, fmt.Errorf("failed to redeliver webhook delivery: %w", err)
+	}
+	return toDomainWebhookDelivery(&row), nil
+}
+
+// DeleteFinishedBefore removes succeeded and failed deliveries created before the cutoff
+func (r *WebhookRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := r.queries.DeleteFinishedWebhookDeliveries(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		r.logger.WithError(err).Error("failed to delete finished webhook deliveries")
+		return 0, fmt.Errorf("failed to delete finished webhook deliveries: %w", err)
+	}
+	return deleted, nil
+}
+
+// toDomainWebhookSubscription converts sqlc WebhookSubscription to domain webhook.Subscription
+func toDomainWebhookSubscription(row *postgres.WebhookSubscription) *webhook.Subscription {
+	sub := &webhook.Subscription{
+		ID:                  row.ID,
+		URL:                 row.Url,
+		Description:         row.Description,
+		EventTypes:          row.EventTypes,
+		Secret:              row.Secret,
+		Enabled:             row.Enabled,
+		ConsecutiveFailures: int(row.ConsecutiveFailures),
+		DisabledReason:      row.DisabledReason,
+		CreatedBy:           row.CreatedBy,
+		CreatedAt:           row.CreatedAt.Time,
+		UpdatedAt:           row.UpdatedAt.Time,
+	}
+	if row.DisabledAt.Valid {
+		sub.DisabledAt = &row.DisabledAt.Time
+	}
+	return sub
+}
+
+// toDomainWebhookDelivery converts sqlc WebhookDelivery to domain webhook.Delivery
+func toDomainWebhookDelivery(row *postgres.WebhookDelivery) *webhook.Delivery {
+	delivery := &webhook.Delivery{
+		ID:             row.ID,
+		SubscriptionID: row.SubscriptionID,
+		EventID:        row.EventID,
+		EventType:      row.EventType,
+		Payload:        row.Payload,
+		Status:         webhook.DeliveryStatus(row.Status),
+		Attempts:       int(row.Attempts),
+		NextAttemptAt:  row.NextAttemptAt.Time,
+		LastError:      row.LastError,
+		CreatedAt:      row.CreatedAt.Time,
+	}
+	if row.LastAttemptAt.Valid {
+		delivery.LastAttemptAt = &row.LastAttemptAt.Time
+	}
+	if row.LastStatusCode != nil {
+		statusCode := int(*row.LastStatusCode)
+		delivery.LastStatusCode = &statusCode
+	}
+	if row.DeliveredAt.Valid {
+		delivery.DeliveredAt = &row.DeliveredAt.Time
+	}
+	return delivery
+}
+
+func toDomainWebhookDeliveries(rows []postgres.WebhookDelivery) []*webhook.Delivery {
+	deliveries := make([]*webhook.Delivery, len(rows))
+	for i := range rows {
+		deliveries[i] = toDomainWebhookDelivery(&rows[i])
+	}
+	return deliveries
+}
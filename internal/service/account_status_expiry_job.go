@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+)
+
+// defaultStatusExpiryBatchSize applies when the config leaves the batch size unset
+const defaultStatusExpiryBatchSize = 100
+
+// AccountStatusExpirer lifts suspensions and freezes whose expiry has passed.
+// Implemented by UserService.
+type AccountStatusExpirer interface {
+	// ExpireAccountStatuses makes up to limit users with an expired status active again and
+	// returns how many were.
+	ExpireAccountStatuses(ctx context.Context, limit int) (int, error)
+}
+
+// AccountStatusExpiryJob periodically makes users whose suspension or freeze has expired
+// active again. Expired statuses are no longer enforced either way; lifting them records the
+// change in the user's status history and emits its user.status.changed event.
+type AccountStatusExpiryJob struct {
+	expirer   AccountStatusExpirer
+	logger    *observability.Logger
+	interval  time.Duration
+	batchSize int
+	stopChan  chan struct{}
+	doneChan  chan struct{}
+}
+
+// NewAccountStatusExpiryJob creates a new account status expiry job
+func NewAccountStatusExpiryJob(
+	expirer AccountStatusExpirer,
+	logger *observability.Logger,
+	interval time.Duration,
+	batchSize int,
+) *AccountStatusExpiryJob {
+	if batchSize <= 0 {
+		batchSize = defaultStatusExpiryBatchSize
+	}
+	return &AccountStatusExpiryJob{
+		expirer:   expirer,
+		logger:    logger,
+		interval:  interval,
+		batchSize: batchSize,
+		stopChan:  make(chan struct{}),
+		doneChan:  make(chan struct{}),
+	}
+}
+
+// Start begins the periodic expiry job
+// Runs in a goroutine and can be stopped with Stop()
+func (j *AccountStatusExpiryJob) Start(ctx context.Context) {
+	j.logger.WithField("interval", j.interval.String()).Info("Starting account status expiry job")
+
+	ticker := time.NewTicker(j.interval)
+
+	go func() {
+		defer close(j.doneChan)
+		defer ticker.Stop()
+
+		// Run immediately on start without delaying startup
+		if _, err := j.RunOnce(ctx); err != nil {
+			j.logger.WithError(err).Error("Initial account status expiry failed")
+		}
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := j.RunOnce(ctx); err != nil {
+					j.logger.WithError(err).Error("Scheduled account status expiry failed")
+				}
+			case <-j.stopChan:
+				j.logger.Info("Account status expiry job stopped")
+				return
+			case <-ctx.Done():
+				j.logger.Info("Account status expiry job context cancelled")
+				return
+			}
+		}
+	}()
+}
+
+// Stop gracefully stops the expiry job
+func (j *AccountStatusExpiryJob) Stop() {
+	j.logger.Info("Stopping account status expiry job")
+	close(j.stopChan)
+	<-j.doneChan
+	j.logger.Info("Account status expiry job stopped successfully")
+}
+
+// RunOnce lifts expired statuses batch by batch until a batch lifts none or ctx is done,
+// returning the number of users made active again
+func (j *AccountStatusExpiryJob) RunOnce(ctx context.Context) (int, error) {
+	startTime := time.Now()
+	total := 0
+
+	for {
+		select {
+		case <-j.stopChan:
+			return total, nil
+		default:
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		expired, err := j.expirer.ExpireAccountStatuses(ctx, j.batchSize)
+		total += expired
+		if err != nil {
+			return total, fmt.Errorf("failed to expire account statuses: %w", err)
+		}
+		if expired == 0 {
+			break
+		}
+	}
+
+	if total > 0 {
+		j.logger.WithFields(map[string]interface{}{
+			"users":       total,
+			"duration_ms": time.Since(startTime).Milliseconds(),
+		}).Info("Expired account statuses lifted")
+	}
+	return total, nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStatusExpirer lifts up to limit statuses per call from expired
+type fakeStatusExpirer struct {
+	mu      sync.Mutex
+	expired int
+	calls   int
+	err     error
+}
+
+func (f *fakeStatusExpirer) ExpireAccountStatuses(ctx context.Context, limit int) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return 0, f.err
+	}
+	n := min(f.expired, limit)
+	f.expired -= n
+	return n, nil
+}
+
+func (f *fakeStatusExpirer) remaining() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.expired
+}
+
+func TestAccountStatusExpiryJob_RunOnce(t *testing.T) {
+	logger := observability.NewLogger("dev", "test-service")
+
+	t.Run("lifts all expired statuses in batches", func(t *testing.T) {
+		expirer := &fakeStatusExpirer{expired: 25}
+		job := NewAccountStatusExpiryJob(expirer, logger, time.Hour, 10)
+
+		total, err := job.RunOnce(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 25, total)
+		assert.Equal(t, 4, expirer.calls, "three batches and an empty one")
+	})
+
+	t.Run("error stops the run", func(t *testing.T) {
+		expirer := &fakeStatusExpirer{expired: 5, err: errors.New("database unavailable")}
+		job := NewAccountStatusExpiryJob(expirer, logger, time.Hour, 10)
+
+		_, err := job.RunOnce(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to expire account statuses")
+		assert.Equal(t, 1, expirer.calls)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		expirer := &fakeStatusExpirer{expired: 5}
+		job := NewAccountStatusExpiryJob(expirer, logger, time.Hour, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := job.RunOnce(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, expirer.calls)
+	})
+}
+
+func TestAccountStatusExpiryJob_StartStop(t *testing.T) {
+	logger := observability.NewLogger("dev", "test-service")
+	expirer := &fakeStatusExpirer{expired: 3}
+	job := NewAccountStatusExpiryJob(expirer, logger, time.Hour, 10)
+
+	job.Start(context.Background())
+	require.Eventually(t, func() bool {
+		return expirer.remaining() == 0
+	}, time.Second, 10*time.Millisecond)
+	job.Stop()
+}
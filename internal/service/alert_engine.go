@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+)
+
+// maxAlertEvidence caps how many triggering events are attached to an alert
+const maxAlertEvidence = 20
+
+// Compile-time check to ensure AlertEngine implements alert.Observer
+var _ alert.Observer = (*AlertEngine)(nil)
+
+// AlertEngine evaluates audit and security events against alert rules and sends fired
+// alerts to notifiers. Windows are measured on event time, so the engine can be driven
+// by a synthetic clock in tests. Rule state is in memory and per instance.
+//
+// Notifiers are called synchronously from Observe once a rule fires; callers on a hot
+// path should observe from a goroutine (the audit middleware already does).
+type AlertEngine struct {
+	mu        sync.Mutex
+	rules     []alert.Rule
+	state     map[string]*ruleState
+	notifiers []alert.Notifier
+	logger    *observability.Logger
+	now       func() time.Time
+}
+
+// ruleState is the evaluation state of one rule, per group value
+type ruleState struct {
+	pending   map[string][]alert.Event
+	lastFired map[string]time.Time
+}
+
+// NewAlertEngine creates an engine with no rules; load them with SetRules
+func NewAlertEngine(notifiers []alert.Notifier, logger *observability.Logger) *AlertEngine {
+	return &AlertEngine{
+		state:     make(map[string]*ruleState),
+		notifiers: notifiers,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// SetRules validates and installs a new rule set. State is kept for rules whose
+// definition did not change, so a reload does not reset windows in progress.
+// On a validation error the current rules stay in place.
+func (e *AlertEngine) SetRules(rules []alert.Rule) error {
+	next := make([]alert.Rule, len(rules))
+	copy(next, rules)
+	if err := alert.ValidateRules(next); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	previous := make(map[string]alert.Rule, len(e.rules))
+	for _, rule := range e.rules {
+		previous[rule.Name] = rule
+	}
+
+	state := make(map[string]*ruleState, len(next))
+	for _, rule := range next {
+		if old, ok := previous[rule.Name]; ok && reflect.DeepEqual(old, rule) {
+			if existing, ok := e.state[rule.Name]; ok {
+				state[rule.Name] = existing
+				continue
+			}
+		}
+		state[rule.Name] = newRuleState()
+	}
+
+	e.rules = next
+	e.state = state
+	return nil
+}
+
+// Rules returns a copy of the installed rules
+func (e *AlertEngine) Rules() []alert.Rule {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rules := make([]alert.Rule, len(e.rules))
+	copy(rules, e.rules)
+	return rules
+}
+
+// Observe evaluates an event against every enabled rule and notifies on any that fire
+func (e *AlertEngine) Observe(ctx context.Context, event alert.Event) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = e.now()
+	}
+
+	e.mu.Lock()
+	var fired []firedAlert
+	for i := range e.rules {
+		rule := &e.rules[i]
+		if rule.Disabled {
+			continue
+		}
+		if a := e.evaluate(rule, e.state[rule.Name], event); a != nil {
+			fired = append(fired, firedAlert{rule: rule, alert: a})
+		}
+	}
+	e.mu.Unlock()
+
+	for _, f := range fired {
+		e.notify(ctx, f.rule, f.alert)
+	}
+}
+
+// Sweep drops evaluation state that can no longer affect any rule as of now:
+// windows that have fully elapsed and cooldowns that have expired
+func (e *AlertEngine) Sweep(now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, rule := range e.rules {
+		state := e.state[rule.Name]
+		for group, events := range state.pending {
+			if len(events) == 0 || now.Sub(events[len(events)-1].OccurredAt) > rule.Window {
+				delete(state.pending, group)
+			}
+		}
+		for group, at := range state.lastFired {
+			if now.Sub(at) >= rule.Cooldown {
+				delete(state.lastFired, group)
+			}
+		}
+	}
+}
+
+// RunSweeper sweeps stale state every interval until ctx is cancelled
+func (e *AlertEngine) RunSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.Sweep(e.now())
+		}
+	}
+}
+
+type firedAlert struct {
+	rule  *alert.Rule
+	alert *alert.Alert
+}
+
+func newRuleState() *ruleState {
+	return &ruleState{
+		pending:   make(map[string][]alert.Event),
+		lastFired: make(map[string]time.Time),
+	}
+}
+
+// evaluate advances one rule with one event and returns the alert if it fired.
+// Must be called with e.mu held.
+func (e *AlertEngine) evaluate(rule *alert.Rule, state *ruleState, event alert.Event) *alert.Alert {
+	group, ok := rule.GroupValue(event)
+	if !ok {
+		return nil
+	}
+
+	var triggered []alert.Event
+	switch rule.Type {
+	case alert.RuleThreshold:
+		triggered = advanceThreshold(rule, state, group, event)
+	case alert.RuleSequence:
+		triggered = advanceSequence(rule, state, group, event)
+	}
+	if triggered == nil {
+		return nil
+	}
+
+	if last, ok := state.lastFired[group]; ok && event.OccurredAt.Sub(last) < rule.Cooldown {
+		e.logger.WithFields(map[string]interface{}{
+			"rule":        rule.Name,
+			"group_value": group,
+		}).Debug("Alert suppressed by cooldown")
+		return nil
+	}
+	state.lastFired[group] = event.OccurredAt
+
+	return e.newAlert(rule, group, triggered)
+}
+
+// advanceThreshold counts the event and returns the window's events once the threshold is reached
+func advanceThreshold(rule *alert.Rule, state *ruleState, group string, event alert.Event) []alert.Event {
+	if !rule.Counts(event) {
+		return nil
+	}
+
+	cutoff := event.OccurredAt.Add(-rule.Window)
+	events := state.pending[group]
+	kept := events[:0]
+	for _, e := range events {
+		if e.OccurredAt.After(cutoff) {
+			kept = append(kept, e)
+		}
+	}
+	kept = append(kept, event)
+
+	if len(kept) < rule.Threshold {
+		state.pending[group] = kept
+		return nil
+	}
+
+	delete(state.pending, group)
+	return kept
+}
+
+// advanceSequence moves the group along the rule's sequence and returns the matched
+// events once the last step is reached. A sequence not completed within the window
+// starts over; an event matching the first step restarts an expired sequence.
+func advanceSequence(rule *alert.Rule, state *ruleState, group string, event alert.Event) []alert.Event {
+	if !rule.MatchesAttributes(event) {
+		return nil
+	}
+
+	events := state.pending[group]
+	if len(events) > 0 && event.OccurredAt.Sub(events[0].OccurredAt) > rule.Window {
+		events = nil
+	}
+
+	switch {
+	case event.Type == rule.Sequence[len(events)]:
+		events = append(events, event)
+	case event.Type == rule.Sequence[0]:
+		// Out of order: the first step restarts the sequence from here
+		events = []alert.Event{event}
+	default:
+		if len(events) == 0 {
+			delete(state.pending, group)
+		} else {
+			state.pending[group] = events
+		}
+		return nil
+	}
+
+	if len(events) < len(rule.Sequence) {
+		state.pending[group] = events
+		return nil
+	}
+
+	delete(state.pending, group)
+	return events
+}
+
+// newAlert builds an alert from the events that triggered it
+func (e *AlertEngine) newAlert(rule *alert.Rule, group string, events []alert.Event) *alert.Alert {
+	evidence := events
+	if len(evidence) > maxAlertEvidence {
+		evidence = evidence[len(evidence)-maxAlertEvidence:]
+	}
+
+	return &alert.Alert{
+		ID:          uuid.New(),
+		RuleName:    rule.Name,
+		Severity:    rule.Severity,
+		Summary:     alertSummary(rule, group, len(events)),
+		GroupBy:     rule.GroupBy,
+		GroupValue:  group,
+		DedupKey:    rule.DedupKey(group),
+		EventCount:  len(events),
+		FireCount:   1,
+		FirstSeenAt: events[0].OccurredAt,
+		LastSeenAt:  events[len(events)-1].OccurredAt,
+		Events:      append([]alert.Event(nil), evidence...),
+		Status:      alert.StatusOpen,
+		CreatedAt:   e.now(),
+	}
+}
+
+func alertSummary(rule *alert.Rule, group string, count int) string {
+	var summary string
+	if rule.Type == alert.RuleSequence {
+		summary = fmt.Sprintf("%s: sequence %s completed within %s", rule.Name, strings.Join(rule.Sequence, " -> "), rule.Window)
+	} else {
+		summary = fmt.Sprintf("%s: %d %s events within %s", rule.Name, count, strings.Join(rule.EventTypes, "/"), rule.Window)
+	}
+	if rule.GroupBy != alert.GroupByNone {
+		summary += fmt.Sprintf(" for %s %s", rule.GroupBy, group)
+	}
+	return summary
+}
+
+// notify delivers an alert to the rule's notifiers. A failing notifier does not stop the others.
+// Delivery is detached from the caller's cancellation, which is often a finished request.
+func (e *AlertEngine) notify(ctx context.Context, rule *alert.Rule, a *alert.Alert) {
+	ctx = context.WithoutCancel(ctx)
+	e.logger.WithFields(map[string]interface{}{
+		"alert_id":    a.ID.String(),
+		"rule":        a.RuleName,
+		"severity":    string(a.Severity),
+		"group_value": a.GroupValue,
+		"event_count": a.EventCount,
+	}).Warn("Security alert fired")
+
+	for _, notifier := range e.notifiers {
+		if !rule.NotifiesVia(notifier.Name()) {
+			continue
+		}
+		if err := notifier.Notify(ctx, a); err != nil {
+			e.logger.WithError(err).WithFields(map[string]interface{}{
+				"alert_id": a.ID.String(),
+				"rule":     a.RuleName,
+				"notifier": notifier.Name(),
+			}).Error("Failed to deliver security alert")
+		}
+	}
+}
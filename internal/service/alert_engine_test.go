@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotifier collects the alerts it is asked to deliver
+type recordingNotifier struct {
+	mu     sync.Mutex
+	name   string
+	err    error
+	alerts []*alert.Alert
+}
+
+func (n *recordingNotifier) Name() string { return n.name }
+
+func (n *recordingNotifier) Notify(ctx context.Context, a *alert.Alert) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.alerts = append(n.alerts, a)
+	return n.err
+}
+
+func (n *recordingNotifier) received() []*alert.Alert {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*alert.Alert(nil), n.alerts...)
+}
+
+// syntheticClock drives the engine and event timestamps in tests
+type syntheticClock struct {
+	now time.Time
+}
+
+func (c *syntheticClock) Now() time.Time { return c.now }
+
+func (c *syntheticClock) Advance(d time.Duration) time.Time {
+	c.now = c.now.Add(d)
+	return c.now
+}
+
+func newTestAlertEngine(t *testing.T, rules []alert.Rule, notifiers ...alert.Notifier) (*AlertEngine, *syntheticClock) {
+	clock := &syntheticClock{now: time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)}
+	engine := NewAlertEngine(notifiers, observability.NewLogger("dev", "test-service"))
+	engine.now = clock.Now
+	require.NoError(t, engine.SetRules(rules))
+	return engine, clock
+}
+
+func adminBruteforceRule() alert.Rule {
+	return alert.Rule{
+		Name:       "admin-login-bruteforce",
+		Type:       alert.RuleThreshold,
+		Severity:   alert.SeverityCritical,
+		EventTypes: []string{"admin.login.failed"},
+		GroupBy:    alert.GroupByIP,
+		Threshold:  3,
+		Window:     time.Minute,
+		Cooldown:   5 * time.Minute,
+	}
+}
+
+func failedAdminLogin(ip string, at time.Time) alert.Event {
+	return alert.Event{Type: "admin.login.failed", IPAddress: ip, OccurredAt: at}
+}
+
+func TestAlertEngine_Threshold(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("fires when threshold is reached within the window", func(t *testing.T) {
+		notifier := &recordingNotifier{name: "log"}
+		engine, clock := newTestAlertEngine(t, []alert.Rule{adminBruteforceRule()}, notifier)
+
+		first := clock.Now()
+		engine.Observe(ctx, failedAdminLogin("10.0.0.1", first))
+		engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Advance(10*time.Second)))
+		assert.Empty(t, notifier.received())
+
+		last := clock.Advance(10 * time.Second)
+		engine.Observe(ctx, failedAdminLogin("10.0.0.1", last))
+
+		alerts := notifier.received()
+		require.Len(t, alerts, 1)
+		fired := alerts[0]
+		assert.Equal(t, "admin-login-bruteforce", fired.RuleName)
+		assert.Equal(t, alert.SeverityCritical, fired.Severity)
+		assert.Equal(t, "10.0.0.1", fired.GroupValue)
+		assert.Equal(t, "admin-login-bruteforce|ip:10.0.0.1", fired.DedupKey)
+		assert.Equal(t, 3, fired.EventCount)
+		assert.Equal(t, first, fired.FirstSeenAt)
+		assert.Equal(t, last, fired.LastSeenAt)
+		assert.Equal(t, last, fired.CreatedAt)
+		assert.Equal(t, alert.StatusOpen, fired.Status)
+		assert.Len(t, fired.Events, 3)
+	})
+
+	t.Run("events outside the window do not count", func(t *testing.T) {
+		notifier := &recordingNotifier{name: "log"}
+		engine, clock := newTestAlertEngine(t, []alert.Rule{adminBruteforceRule()}, notifier)
+
+		engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Now()))
+		engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Advance(30*time.Second)))
+		engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Advance(31*time.Second)))
+
+		assert.Empty(t, notifier.received())
+	})
+
+	t.Run("groups are counted separately", func(t *testing.T) {
+		notifier := &recordingNotifier{name: "log"}
+		engine, clock := newTestAlertEngine(t, []alert.Rule{adminBruteforceRule()}, notifier)
+
+		for i := 0; i < 2; i++ {
+			engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Advance(time.Second)))
+			engine.Observe(ctx, failedAdminLogin("10.0.0.2", clock.Advance(time.Second)))
+		}
+		engine.Observe(ctx, alert.Event{Type: "admin.login.failed", OccurredAt: clock.Advance(time.Second)})
+
+		assert.Empty(t, notifier.received())
+	})
+
+	t.Run("cooldown deduplicates repeat alerts", func(t *testing.T) {
+		notifier := &recordingNotifier{name: "log"}
+		engine, clock := newTestAlertEngine(t, []alert.Rule{adminBruteforceRule()}, notifier)
+
+		for i := 0; i < 6; i++ {
+			engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Advance(time.Second)))
+		}
+		assert.Len(t, notifier.received(), 1)
+
+		clock.Advance(5 * time.Minute)
+		for i := 0; i < 3; i++ {
+			engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Advance(time.Second)))
+		}
+		assert.Len(t, notifier.received(), 2)
+	})
+
+	t.Run("attribute filters and disabled rules", func(t *testing.T) {
+		notifier := &recordingNotifier{name: "log"}
+		filtered := adminBruteforceRule()
+		filtered.Match = map[string]string{"reason": "invalid_password"}
+		filtered.Threshold = 1
+		disabled := adminBruteforceRule()
+		disabled.Name = "disabled"
+		disabled.Threshold = 1
+		disabled.Disabled = true
+		engine, clock := newTestAlertEngine(t, []alert.Rule{filtered, disabled}, notifier)
+
+		event := failedAdminLogin("10.0.0.1", clock.Now())
+		event.Attributes = map[string]string{"reason": "user_not_found"}
+		engine.Observe(ctx, event)
+		assert.Empty(t, notifier.received())
+
+		event.Attributes["reason"] = "invalid_password"
+		engine.Observe(ctx, event)
+		require.Len(t, notifier.received(), 1)
+		assert.Equal(t, "admin-login-bruteforce", notifier.received()[0].RuleName)
+	})
+}
+
+func TestAlertEngine_Sequence(t *testing.T) {
+	ctx := context.Background()
+	rule := alert.Rule{
+		Name:     "revoked-token-then-password-change",
+		Type:     alert.RuleSequence,
+		Severity: alert.SeverityHigh,
+		Sequence: []string{"token.refresh.revoked", "user.password.changed"},
+		GroupBy:  alert.GroupByUser,
+		Window:   10 * time.Minute,
+	}
+	event := func(eventType, userID string, at time.Time) alert.Event {
+		return alert.Event{Type: eventType, UserID: userID, OccurredAt: at}
+	}
+
+	t.Run("fires when steps occur in order", func(t *testing.T) {
+		notifier := &recordingNotifier{name: "log"}
+		engine, clock := newTestAlertEngine(t, []alert.Rule{rule}, notifier)
+
+		engine.Observe(ctx, event("user.password.changed", "u1", clock.Now()))
+		engine.Observe(ctx, event("token.refresh.revoked", "u1", clock.Advance(time.Minute)))
+		engine.Observe(ctx, event("token.refresh.revoked", "u2", clock.Advance(time.Minute)))
+		engine.Observe(ctx, event("user.password.changed", "u1", clock.Advance(time.Minute)))
+
+		alerts := notifier.received()
+		require.Len(t, alerts, 1)
+		assert.Equal(t, "u1", alerts[0].GroupValue)
+		assert.Equal(t, 2, alerts[0].EventCount)
+		assert.Contains(t, alerts[0].Summary, "token.refresh.revoked -> user.password.changed")
+	})
+
+	t.Run("does not fire when the window expires", func(t *testing.T) {
+		notifier := &recordingNotifier{name: "log"}
+		engine, clock := newTestAlertEngine(t, []alert.Rule{rule}, notifier)
+
+		engine.Observe(ctx, event("token.refresh.revoked", "u1", clock.Now()))
+		engine.Observe(ctx, event("user.password.changed", "u1", clock.Advance(11*time.Minute)))
+
+		assert.Empty(t, notifier.received())
+	})
+}
+
+func TestAlertEngine_Notifiers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rule selects notifiers and failures do not stop delivery", func(t *testing.T) {
+		webhook := &recordingNotifier{name: "webhook", err: errors.New("connection refused")}
+		table := &recordingNotifier{name: "table"}
+		logNotifier := &recordingNotifier{name: "log"}
+		rule := adminBruteforceRule()
+		rule.Threshold = 1
+		rule.Notifiers = []string{"webhook", "table"}
+		engine, clock := newTestAlertEngine(t, []alert.Rule{rule}, webhook, table, logNotifier)
+
+		engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Now()))
+
+		assert.Len(t, webhook.received(), 1)
+		assert.Len(t, table.received(), 1)
+		assert.Empty(t, logNotifier.received())
+	})
+}
+
+func TestAlertEngine_SetRules(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reload keeps state of unchanged rules", func(t *testing.T) {
+		notifier := &recordingNotifier{name: "log"}
+		rule := adminBruteforceRule()
+		engine, clock := newTestAlertEngine(t, []alert.Rule{rule}, notifier)
+
+		engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Advance(time.Second)))
+		engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Advance(time.Second)))
+
+		other := adminBruteforceRule()
+		other.Name = "other"
+		require.NoError(t, engine.SetRules([]alert.Rule{rule, other}))
+
+		engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Advance(time.Second)))
+		alerts := notifier.received()
+		require.Len(t, alerts, 1)
+		assert.Equal(t, "admin-login-bruteforce", alerts[0].RuleName)
+	})
+
+	t.Run("changed rule starts over", func(t *testing.T) {
+		notifier := &recordingNotifier{name: "log"}
+		rule := adminBruteforceRule()
+		engine, clock := newTestAlertEngine(t, []alert.Rule{rule}, notifier)
+
+		engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Advance(time.Second)))
+		engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Advance(time.Second)))
+
+		rule.Window = 2 * time.Minute
+		require.NoError(t, engine.SetRules([]alert.Rule{rule}))
+
+		engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Advance(time.Second)))
+		assert.Empty(t, notifier.received())
+	})
+
+	t.Run("invalid rules keep the current set", func(t *testing.T) {
+		engine, _ := newTestAlertEngine(t, []alert.Rule{adminBruteforceRule()})
+
+		err := engine.SetRules([]alert.Rule{{Name: "broken", Type: alert.RuleThreshold}})
+
+		require.ErrorIs(t, err, alert.ErrInvalidRule)
+		rules := engine.Rules()
+		require.Len(t, rules, 1)
+		assert.Equal(t, "admin-login-bruteforce", rules[0].Name)
+	})
+}
+
+func TestAlertEngine_Sweep(t *testing.T) {
+	ctx := context.Background()
+	notifier := &recordingNotifier{name: "log"}
+	engine, clock := newTestAlertEngine(t, []alert.Rule{adminBruteforceRule()}, notifier)
+
+	engine.Observe(ctx, failedAdminLogin("10.0.0.1", clock.Now()))
+	engine.Observe(ctx, failedAdminLogin("10.0.0.2", clock.Advance(50*time.Second)))
+
+	engine.Sweep(clock.Advance(20 * time.Second))
+
+	state := engine.state["admin-login-bruteforce"]
+	assert.NotContains(t, state.pending, "10.0.0.1")
+	assert.Contains(t, state.pending, "10.0.0.2")
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+)
+
+// EventAlertAcknowledged is the audit event recorded when an admin acknowledges an alert
+const EventAlertAcknowledged = "alert.acknowledged"
+
+// Compile-time check to ensure AlertService implements alert.Service
+var _ alert.Service = (*AlertService)(nil)
+
+// AlertService exposes stored security alerts to admins for triage
+type AlertService struct {
+	alertRepo alert.Repository
+	auditRepo audit.Repository
+	logger    *observability.Logger
+}
+
+// NewAlertService creates a new alert service
+func NewAlertService(alertRepo alert.Repository, auditRepo audit.Repository, logger *observability.Logger) *AlertService {
+	return &AlertService{
+		alertRepo: alertRepo,
+		auditRepo: auditRepo,
+		logger:    logger,
+	}
+}
+
+// ListAlerts retrieves alerts, newest first, optionally filtered by status
+func (s *AlertService) ListAlerts(ctx context.Context, status *alert.Status, limit, offset int32) ([]*alert.Alert, error) {
+	return s.alertRepo.List(ctx, status, limit, offset)
+}
+
+// GetAlert retrieves an alert
+func (s *AlertService) GetAlert(ctx context.Context, id uuid.UUID) (*alert.Alert, error) {
+	return s.alertRepo.GetByID(ctx, id)
+}
+
+// AcknowledgeAlert acknowledges an open alert on behalf of actor and records who did it
+func (s *AlertService) AcknowledgeAlert(ctx context.Context, id uuid.UUID, actor string, note *string) (*alert.Alert, error) {
+	acknowledged, err := s.alertRepo.Acknowledge(ctx, id, actor, note)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"alert_id": id.String(),
+		"rule":     acknowledged.RuleName,
+		"actor":    actor,
+	}).Info("Security alert acknowledged")
+
+	s.recordAcknowledgement(ctx, acknowledged, actor)
+	return acknowledged, nil
+}
+
+// recordAcknowledgement writes the acknowledgement to audit_logs.
+// The alert is already acknowledged, so a failure here is logged rather than returned.
+func (s *AlertService) recordAcknowledgement(ctx context.Context, a *alert.Alert, actor string) {
+	resourceType := "alert"
+	resourceID := a.ID.String()
+
+	metadata := map[string]interface{}{
+		"rule":        a.RuleName,
+		"severity":    string(a.Severity),
+		"dedup_key":   a.DedupKey,
+		"event_count": a.EventCount,
+		"fire_count":  a.FireCount,
+	}
+	if a.AckNote != nil {
+		metadata["note"] = *a.AckNote
+	}
+
+	entry := &audit.Log{
+		EventType:       EventAlertAcknowledged,
+		EventCategory:   audit.CategorySecurity,
+		Severity:        audit.SeverityWarning,
+		ActorType:       audit.ActorAdmin,
+		ActorIdentifier: &actor,
+		Action:          "acknowledge",
+		ResourceType:    &resourceType,
+		ResourceID:      &resourceID,
+		Metadata:        metadata,
+		Status:          audit.StatusSuccess,
+	}
+
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		s.logger.WithError(err).WithField("alert_id", resourceID).Error("Failed to record alert acknowledgement in audit log")
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAlertService() (*AlertService, *mocks.MockAlertRepository, *mocks.MockAuditRepository) {
+	alertRepo := new(mocks.MockAlertRepository)
+	auditRepo := new(mocks.MockAuditRepository)
+	return NewAlertService(alertRepo, auditRepo, observability.NewLogger("dev", "test-service")), alertRepo, auditRepo
+}
+
+func TestAlertService_AcknowledgeAlert(t *testing.T) {
+	ctx := context.Background()
+	note := "blocked the IP at the WAF"
+
+	t.Run("acknowledges and records the acknowledgement", func(t *testing.T) {
+		svc, alertRepo, auditRepo := newTestAlertService()
+		id := uuid.New()
+		acknowledged := &alert.Alert{ID: id, RuleName: "admin-login-bruteforce", Status: alert.StatusAcknowledged, AckNote: &note}
+
+		alertRepo.On("Acknowledge", mock.Anything, id, "admin@test.com", &note).Return(acknowledged, nil).Once()
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.EventType == EventAlertAcknowledged &&
+				l.EventCategory == audit.CategorySecurity &&
+				*l.ActorIdentifier == "admin@test.com" &&
+				*l.ResourceID == id.String() &&
+				l.Metadata["note"] == note
+		})).Return(&audit.Log{}, nil).Once()
+
+		result, err := svc.AcknowledgeAlert(ctx, id, "admin@test.com", &note)
+
+		require.NoError(t, err)
+		assert.Equal(t, alert.StatusAcknowledged, result.Status)
+		alertRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("already acknowledged is not recorded", func(t *testing.T) {
+		svc, alertRepo, auditRepo := newTestAlertService()
+		id := uuid.New()
+
+		alertRepo.On("Acknowledge", mock.Anything, id, "admin@test.com", (*string)(nil)).Return(nil, alert.ErrAlreadyAcknowledged).Once()
+
+		_, err := svc.AcknowledgeAlert(ctx, id, "admin@test.com", nil)
+
+		assert.ErrorIs(t, err, alert.ErrAlreadyAcknowledged)
+		auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("audit record failure does not fail the acknowledgement", func(t *testing.T) {
+		svc, alertRepo, auditRepo := newTestAlertService()
+		id := uuid.New()
+
+		alertRepo.On("Acknowledge", mock.Anything, id, "admin@test.com", (*string)(nil)).
+			Return(&alert.Alert{ID: id, Status: alert.StatusAcknowledged}, nil).Once()
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+		_, err := svc.AcknowledgeAlert(ctx, id, "admin@test.com", nil)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestAlertService_ListAlerts(t *testing.T) {
+	svc, alertRepo, _ := newTestAlertService()
+	open := alert.StatusOpen
+
+	alertRepo.On("List", mock.Anything, &open, int32(50), int32(0)).Return([]*alert.Alert{{ID: uuid.New()}}, nil).Once()
+
+	alerts, err := svc.ListAlerts(context.Background(), &open, 50, 0)
+
+	require.NoError(t, err)
+	assert.Len(t, alerts, 1)
+	alertRepo.AssertExpectations(t)
+}
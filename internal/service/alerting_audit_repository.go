@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+)
+
+// AlertingAuditRepository decorates an audit.Repository so that every stored entry is
+// evaluated by the alert rule engine. Only entries that were written are observed, so
+// alerts always have an audit_logs row behind them.
+type AlertingAuditRepository struct {
+	audit.Repository
+	observer alert.Observer
+}
+
+// NewAlertingAuditRepository wraps repo so stored entries are passed to observer
+func NewAlertingAuditRepository(repo audit.Repository, observer alert.Observer) *AlertingAuditRepository {
+	return &AlertingAuditRepository{
+		Repository: repo,
+		observer:   observer,
+	}
+}
+
+// Create stores the entry and hands it to the observer
+func (r *AlertingAuditRepository) Create(ctx context.Context, log *audit.Log) (*audit.Log, error) {
+	created, err := r.Repository.Create(ctx, log)
+	if err != nil {
+		return nil, err
+	}
+
+	r.observer.Observe(ctx, alertEventFromAuditLog(created))
+	return created, nil
+}
+
+// alertEventFromAuditLog maps an audit entry to the fields alert rules match on
+func alertEventFromAuditLog(log *audit.Log) alert.Event {
+	event := alert.Event{
+		Type:       log.EventType,
+		Severity:   string(log.Severity),
+		OccurredAt: log.CreatedAt,
+		Attributes: map[string]string{
+			"status":     string(log.Status),
+			"category":   string(log.EventCategory),
+			"severity":   string(log.Severity),
+			"action":     log.Action,
+			"actor_type": string(log.ActorType),
+		},
+	}
+	if log.UserID != nil {
+		event.UserID = log.UserID.String()
+	}
+	if log.IPAddress != nil {
+		event.IPAddress = *log.IPAddress
+	}
+	if log.FailureReason != nil {
+		event.Attributes["reason"] = *log.FailureReason
+	}
+	return event
+}
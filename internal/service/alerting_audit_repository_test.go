@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// collectingObserver records observed events
+type collectingObserver struct {
+	events chan alert.Event
+}
+
+func newCollectingObserver() *collectingObserver {
+	return &collectingObserver{events: make(chan alert.Event, 10)}
+}
+
+func (o *collectingObserver) Observe(ctx context.Context, event alert.Event) {
+	o.events <- event
+}
+
+func (o *collectingObserver) next(t *testing.T) alert.Event {
+	t.Helper()
+	select {
+	case event := <-o.events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("expected an observed event")
+		return alert.Event{}
+	}
+}
+
+func TestAlertingAuditRepository_Create(t *testing.T) {
+	t.Run("observes stored entries", func(t *testing.T) {
+		auditRepo := new(mocks.MockAuditRepository)
+		observer := newCollectingObserver()
+		repo := NewAlertingAuditRepository(auditRepo, observer)
+
+		userID := uuid.New()
+		ip := "10.0.0.1"
+		stored := &audit.Log{
+			ID:            uuid.New(),
+			EventType:     "user.login",
+			EventCategory: audit.CategoryAuthentication,
+			Severity:      audit.SeverityWarning,
+			UserID:        &userID,
+			ActorType:     audit.ActorAPI,
+			Action:        "POST /api/v1/auth/login",
+			IPAddress:     &ip,
+			Status:        audit.StatusFailure,
+			CreatedAt:     time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC),
+		}
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(stored, nil).Once()
+
+		_, err := repo.Create(context.Background(), &audit.Log{})
+		require.NoError(t, err)
+
+		event := observer.next(t)
+		assert.Equal(t, "user.login", event.Type)
+		assert.Equal(t, userID.String(), event.UserID)
+		assert.Equal(t, ip, event.IPAddress)
+		assert.Equal(t, stored.CreatedAt, event.OccurredAt)
+		assert.Equal(t, "failure", event.Attributes["status"])
+		assert.Equal(t, "authentication", event.Attributes["category"])
+	})
+
+	t.Run("failed writes are not observed", func(t *testing.T) {
+		auditRepo := new(mocks.MockAuditRepository)
+		observer := newCollectingObserver()
+		repo := NewAlertingAuditRepository(auditRepo, observer)
+
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+		_, err := repo.Create(context.Background(), &audit.Log{})
+
+		require.Error(t, err)
+		assert.Empty(t, observer.events)
+	})
+}
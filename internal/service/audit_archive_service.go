@@ -0,0 +1,623 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultAuditArchivePrefix is the object store prefix for audit log archives
+	DefaultAuditArchivePrefix = "audit-logs"
+
+	// restoreBatchSize is the number of archived entries inserted per COPY during restore
+	restoreBatchSize = 1000
+
+	// archiveListPageSize is the number of archive records read per page when rewriting archives
+	archiveListPageSize = 100
+)
+
+// AuditArchiveService maintains the monthly partitions of audit_logs.
+// It pre-creates future partitions, archives partitions that fall entirely outside the
+// retention window to the object store (gzip NDJSON + JSON manifest) before dropping them,
+// and restores archived months on request for investigations.
+type AuditArchiveService struct {
+	partitionRepo audit.PartitionRepository
+	legalHolds    audit.LegalHoldChecker
+	store         common.ObjectStore
+	logger        *observability.Logger
+	auditLogger   *observability.AuditLogger
+	retentionDays int
+	monthsAhead   int
+	prefix        string
+	now           func() time.Time
+}
+
+// NewAuditArchiveService creates a new audit archive service
+//
+// Parameters:
+//   - partitionRepo: Partition and archive record persistence
+//   - store: Object store receiving the archives
+//   - logger: Structured logger
+//   - retentionDays: Partitions whose whole month is older than this are archived
+//   - monthsAhead: Number of future monthly partitions kept pre-created
+//   - prefix: Object key prefix (defaults to DefaultAuditArchivePrefix when empty)
+func NewAuditArchiveService(
+	partitionRepo audit.PartitionRepository,
+	store common.ObjectStore,
+	logger *observability.Logger,
+	retentionDays int,
+	monthsAhead int,
+	prefix string,
+) *AuditArchiveService {
+	if prefix == "" {
+		prefix = DefaultAuditArchivePrefix
+	}
+	return &AuditArchiveService{
+		partitionRepo: partitionRepo,
+		store:         store,
+		logger:        logger,
+		auditLogger:   observability.NewAuditLogger(logger),
+		retentionDays: retentionDays,
+		monthsAhead:   monthsAhead,
+		prefix:        prefix,
+		now:           time.Now,
+	}
+}
+
+// WithLegalHolds makes archival skip months covered by an active legal hold
+func (s *AuditArchiveService) WithLegalHolds(checker audit.LegalHoldChecker) *AuditArchiveService {
+	s.legalHolds = checker
+	return s
+}
+
+// EnsurePartitions creates any missing partitions from the current month up to monthsAhead months ahead
+func (s *AuditArchiveService) EnsurePartitions(ctx context.Context) (int, error) {
+	now := s.now().UTC()
+	created, err := s.partitionRepo.EnsurePartitions(ctx, now, now.AddDate(0, s.monthsAhead, 0))
+	if err != nil {
+		return 0, err
+	}
+
+	if created > 0 {
+		s.logger.WithField("created", created).Info("Created audit log partitions")
+	}
+	return created, nil
+}
+
+// ArchiveExpiredPartitions archives and drops every partition whose month ended before the
+// retention cutoff. Months that are currently restored for investigation or covered by an
+// active legal hold are skipped.
+// Returns the archives written during this run.
+func (s *AuditArchiveService) ArchiveExpiredPartitions(ctx context.Context) ([]*audit.Archive, error) {
+	partitions, err := s.partitionRepo.ListPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := s.now().UTC().AddDate(0, 0, -s.retentionDays)
+	archived := make([]*audit.Archive, 0)
+
+	for _, partition := range partitions {
+		if partition.End().After(cutoff) {
+			continue
+		}
+
+		held, err := s.isOnHold(ctx, partition)
+		if err != nil {
+			return archived, err
+		}
+		if held {
+			continue
+		}
+
+		existing, err := s.partitionRepo.GetArchive(ctx, partition.Month)
+		switch {
+		case err == nil && existing.IsRestored():
+			s.logger.WithField("partition", partition.Name).Debug("Skipping restored audit log partition")
+			continue
+		case err == nil:
+			// Archived by an earlier run that stopped before the drop
+			if err := s.partitionRepo.DropPartition(ctx, partition.Month); err != nil {
+				return archived, err
+			}
+			s.logger.WithField("partition", partition.Name).Info("Dropped already archived audit log partition")
+			continue
+		case !errors.Is(err, audit.ErrArchiveNotFound):
+			return archived, err
+		}
+
+		archive, err := s.archivePartition(ctx, partition)
+		if err != nil {
+			return archived, fmt.Errorf("failed to archive partition %s: %w", partition.Name, err)
+		}
+		archived = append(archived, archive)
+	}
+
+	return archived, nil
+}
+
+// isOnHold reports whether an active legal hold covers the partition's month
+func (s *AuditArchiveService) isOnHold(ctx context.Context, partition *audit.Partition) (bool, error) {
+	if s.legalHolds == nil {
+		return false, nil
+	}
+
+	held, err := s.legalHolds.IsRangeOnHold(ctx, partition.Month, partition.End())
+	if err != nil {
+		return false, fmt.Errorf("failed to check legal holds for partition %s: %w", partition.Name, err)
+	}
+	if !held {
+		return false, nil
+	}
+
+	entry := s.logger.WithField("partition", partition.Name)
+	if partition.Attached {
+		entry.Info("Skipping audit log partition under legal hold")
+	} else {
+		// Left detached by an interrupted archive run; its rows are not visible through audit_logs
+		entry.Warn("Detached audit log partition is under legal hold and will not be archived")
+	}
+	return true, nil
+}
+
+// archivePartition detaches a partition, exports it, records the archive and drops the table
+func (s *AuditArchiveService) archivePartition(ctx context.Context, partition *audit.Partition) (*audit.Archive, error) {
+	startTime := time.Now()
+
+	if partition.Attached {
+		if err := s.partitionRepo.DetachPartition(ctx, partition.Month); err != nil {
+			return nil, err
+		}
+	}
+
+	objectKey, manifestKey := s.objectKeys(partition.Month)
+	manifest, err := s.exportPartition(ctx, partition, objectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal archive manifest: %w", err)
+	}
+	if err := s.store.Put(ctx, manifestKey, bytes.NewReader(manifestJSON)); err != nil {
+		return nil, fmt.Errorf("failed to write archive manifest: %w", err)
+	}
+
+	archive, err := s.partitionRepo.CreateArchive(ctx, &audit.Archive{
+		Month:         partition.Month,
+		PartitionName: partition.Name,
+		ObjectKey:     objectKey,
+		ManifestKey:   manifestKey,
+		RowCount:      manifest.RowCount,
+		SizeBytes:     manifest.SizeBytes,
+		Checksum:      manifest.Checksum,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.partitionRepo.DropPartition(ctx, partition.Month); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"partition":   partition.Name,
+		"object_key":  objectKey,
+		"row_count":   manifest.RowCount,
+		"size_bytes":  manifest.SizeBytes,
+		"duration_ms": time.Since(startTime).Milliseconds(),
+	}).Info("Archived audit log partition")
+
+	s.auditLogger.LogEvent("audit.partition.archived", map[string]interface{}{
+		"partition":  partition.Name,
+		"object_key": objectKey,
+		"row_count":  manifest.RowCount,
+	})
+
+	return archive, nil
+}
+
+// exportPartition streams a partition into the object store as gzip-compressed NDJSON
+func (s *AuditArchiveService) exportPartition(ctx context.Context, partition *audit.Partition, objectKey string) (*audit.Manifest, error) {
+	manifest := &audit.Manifest{
+		Version:       audit.ArchiveManifestVersion,
+		Table:         "audit_logs",
+		PartitionName: partition.Name,
+		RangeStart:    partition.Month,
+		RangeEnd:      partition.End(),
+		Format:        audit.ArchiveFormat,
+		ObjectKey:     objectKey,
+	}
+
+	size, checksum, err := s.writeArchiveObject(ctx, objectKey, func(enc *json.Encoder) error {
+		return s.partitionRepo.StreamPartition(ctx, partition.Month, func(log *audit.Log) error {
+			if manifest.FirstCreatedAt == nil {
+				first := log.CreatedAt
+				manifest.FirstCreatedAt = &first
+			}
+			last := log.CreatedAt
+			manifest.LastCreatedAt = &last
+			manifest.RowCount++
+			return enc.Encode(log)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	manifest.SizeBytes = size
+	manifest.Checksum = checksum
+	manifest.CreatedAt = s.now().UTC()
+	return manifest, nil
+}
+
+// writeArchiveObject stores the entries encoded by produce under objectKey as gzip-compressed
+// NDJSON and returns the object's size and SHA-256 checksum. If produce fails, the object is
+// not stored.
+func (s *AuditArchiveService) writeArchiveObject(ctx context.Context, objectKey string, produce func(*json.Encoder) error) (int64, string, error) {
+	pr, pw := io.Pipe()
+	hasher := sha256.New()
+	counter := &countingWriter{}
+	done := make(chan error, 1)
+
+	go func() {
+		gz := gzip.NewWriter(io.MultiWriter(pw, hasher, counter))
+		err := produce(json.NewEncoder(gz))
+		if err == nil {
+			err = gz.Close()
+		}
+		pw.CloseWithError(err)
+		done <- err
+	}()
+
+	if err := s.store.Put(ctx, objectKey, pr); err != nil {
+		pr.CloseWithError(err)
+		<-done
+		return 0, "", fmt.Errorf("failed to write archive object: %w", err)
+	}
+	if err := <-done; err != nil {
+		return 0, "", fmt.Errorf("failed to export partition: %w", err)
+	}
+	return counter.n, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// ListArchives returns archived months, newest first
+func (s *AuditArchiveService) ListArchives(ctx context.Context, limit, offset int32) ([]*audit.Archive, error) {
+	return s.partitionRepo.ListArchives(ctx, limit, offset)
+}
+
+// RestoreArchive loads an archived month back into audit_logs.
+// The archive is verified against its manifest; on mismatch the partially restored month is dropped.
+func (s *AuditArchiveService) RestoreArchive(ctx context.Context, month time.Time, restoredBy string) (*audit.Archive, error) {
+	month = audit.MonthStart(month)
+
+	archive, err := s.partitionRepo.GetArchive(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+	if archive.IsRestored() {
+		return nil, audit.ErrArchiveAlreadyRestored
+	}
+
+	manifest, err := s.readManifest(ctx, archive.ManifestKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start from an empty partition so an interrupted restore can simply be retried
+	if err := s.partitionRepo.DropPartition(ctx, month); err != nil {
+		return nil, err
+	}
+	if err := s.partitionRepo.CreatePartition(ctx, month); err != nil {
+		return nil, err
+	}
+
+	restored, err := s.loadArchive(ctx, archive, manifest)
+	if err != nil {
+		if dropErr := s.partitionRepo.DropPartition(ctx, month); dropErr != nil {
+			s.logger.WithError(dropErr).WithField("partition", archive.PartitionName).Error("Failed to drop partially restored audit log partition")
+		}
+		return nil, err
+	}
+
+	archive, err = s.partitionRepo.MarkArchiveRestored(ctx, month, restoredBy)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"partition":   archive.PartitionName,
+		"row_count":   restored,
+		"restored_by": restoredBy,
+	}).Info("Restored audit log archive")
+
+	s.auditLogger.LogSecurityEvent("audit.archive.restored", "high", map[string]interface{}{
+		"partition":   archive.PartitionName,
+		"row_count":   restored,
+		"restored_by": restoredBy,
+	})
+
+	return archive, nil
+}
+
+// ReleaseArchive drops a restored month from audit_logs; the archive object is kept
+func (s *AuditArchiveService) ReleaseArchive(ctx context.Context, month time.Time) (*audit.Archive, error) {
+	month = audit.MonthStart(month)
+
+	archive, err := s.partitionRepo.GetArchive(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+	if !archive.IsRestored() {
+		return nil, audit.ErrArchiveNotRestored
+	}
+
+	if err := s.partitionRepo.DropPartition(ctx, month); err != nil {
+		return nil, err
+	}
+
+	archive, err = s.partitionRepo.ClearArchiveRestored(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithField("partition", archive.PartitionName).Info("Released restored audit log archive")
+	return archive, nil
+}
+
+// RewriteArchives rewrites the entries of archived months, from the month of since onwards, for
+// which rewrite reports a change; rewrite changes an entry in place. Used by erasure, whose
+// rewritten database rows would otherwise survive in the archives. Returns how many archived
+// entries changed.
+func (s *AuditArchiveService) RewriteArchives(ctx context.Context, since time.Time, rewrite func(*audit.Log) bool) (int64, error) {
+	since = audit.MonthStart(since)
+
+	var archives []*audit.Archive
+	for offset := int32(0); ; offset += archiveListPageSize {
+		page, err := s.partitionRepo.ListArchives(ctx, archiveListPageSize, offset)
+		if err != nil {
+			return 0, err
+		}
+		for _, archive := range page {
+			if !archive.Month.Before(since) {
+				archives = append(archives, archive)
+			}
+		}
+		// Newest month first, so the rest of the archives are older
+		if len(page) < archiveListPageSize || page[len(page)-1].Month.Before(since) {
+			break
+		}
+	}
+
+	var rewritten int64
+	for _, archive := range archives {
+		n, err := s.rewriteArchive(ctx, archive, rewrite)
+		if err != nil {
+			return rewritten, fmt.Errorf("failed to rewrite archive %s: %w", archive.PartitionName, err)
+		}
+		rewritten += n
+	}
+	return rewritten, nil
+}
+
+// rewriteArchive re-exports an archive whose entries rewrite changes to a new object and
+// manifest, points the archive record at them and deletes the old ones. The archive is read
+// twice, first to find out whether anything changes, so untouched months are never written.
+func (s *AuditArchiveService) rewriteArchive(ctx context.Context, archive *audit.Archive, rewrite func(*audit.Log) bool) (int64, error) {
+	manifest, err := s.readManifest(ctx, archive.ManifestKey)
+	if err != nil {
+		return 0, err
+	}
+
+	var changed int64
+	if err := s.scanArchive(ctx, archive.ObjectKey, manifest, func(log *audit.Log) error {
+		if rewrite(log) {
+			changed++
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	if changed == 0 {
+		return 0, nil
+	}
+
+	objectKey, manifestKey := s.rewrittenObjectKeys(archive.Month)
+	size, checksum, err := s.writeArchiveObject(ctx, objectKey, func(enc *json.Encoder) error {
+		return s.scanArchive(ctx, archive.ObjectKey, manifest, func(log *audit.Log) error {
+			rewrite(log)
+			return enc.Encode(log)
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rewrittenAt := s.now().UTC()
+	rewritten := *manifest
+	rewritten.ObjectKey = objectKey
+	rewritten.SizeBytes = size
+	rewritten.Checksum = checksum
+	rewritten.RewrittenAt = &rewrittenAt
+	manifestJSON, err := json.MarshalIndent(&rewritten, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal archive manifest: %w", err)
+	}
+	if err := s.store.Put(ctx, manifestKey, bytes.NewReader(manifestJSON)); err != nil {
+		s.deleteObjects(ctx, objectKey)
+		return 0, fmt.Errorf("failed to write archive manifest: %w", err)
+	}
+
+	if _, err := s.partitionRepo.ReplaceArchiveObject(ctx, &audit.Archive{
+		Month:         archive.Month,
+		PartitionName: archive.PartitionName,
+		ObjectKey:     objectKey,
+		ManifestKey:   manifestKey,
+		RowCount:      rewritten.RowCount,
+		SizeBytes:     size,
+		Checksum:      checksum,
+	}, archive.ObjectKey); err != nil {
+		s.deleteObjects(ctx, objectKey, manifestKey)
+		return 0, err
+	}
+
+	// The old objects still hold the entries as they were; they must not outlive the rewrite
+	for _, key := range []string{archive.ObjectKey, archive.ManifestKey} {
+		if err := s.store.Delete(ctx, key); err != nil {
+			s.logger.WithError(err).WithField("object_key", key).Error("Failed to delete replaced audit archive object")
+			return changed, fmt.Errorf("failed to delete replaced archive object %s: %w", key, err)
+		}
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"partition":  archive.PartitionName,
+		"object_key": objectKey,
+		"rewritten":  changed,
+	}).Info("Rewrote audit log archive")
+	return changed, nil
+}
+
+// deleteObjects removes objects written by a rewrite that did not complete
+func (s *AuditArchiveService) deleteObjects(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			s.logger.WithError(err).WithField("object_key", key).Warn("Failed to delete unused audit archive object")
+		}
+	}
+}
+
+// readManifest fetches and decodes an archive manifest
+func (s *AuditArchiveService) readManifest(ctx context.Context, key string) (*audit.Manifest, error) {
+	rc, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive manifest: %w", err)
+	}
+	defer rc.Close()
+
+	var manifest audit.Manifest
+	if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode archive manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// loadArchive decodes an archive object and inserts its entries in batches,
+// verifying checksum and row count against the manifest
+func (s *AuditArchiveService) loadArchive(ctx context.Context, archive *audit.Archive, manifest *audit.Manifest) (int64, error) {
+	var restored int64
+	batch := make([]*audit.Log, 0, restoreBatchSize)
+	flush := func() error {
+		n, err := s.partitionRepo.InsertLogs(ctx, batch)
+		if err != nil {
+			return err
+		}
+		restored += n
+		batch = batch[:0]
+		return nil
+	}
+
+	err := s.scanArchive(ctx, archive.ObjectKey, manifest, func(log *audit.Log) error {
+		batch = append(batch, log)
+		if len(batch) == restoreBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return restored, err
+	}
+	if err := flush(); err != nil {
+		return restored, err
+	}
+
+	if restored != manifest.RowCount {
+		return restored, fmt.Errorf("%w: expected %d rows, restored %d", audit.ErrArchiveCorrupted, manifest.RowCount, restored)
+	}
+	return restored, nil
+}
+
+// scanArchive decodes the archive object stored under objectKey and calls fn for every entry.
+// Returns audit.ErrArchiveCorrupted once the whole object has been read if its checksum or row
+// count does not match the manifest, so callers must not commit anything before it returns.
+func (s *AuditArchiveService) scanArchive(ctx context.Context, objectKey string, manifest *audit.Manifest, fn func(*audit.Log) error) error {
+	rc, err := s.store.Get(ctx, objectKey)
+	if err != nil {
+		return fmt.Errorf("failed to read archive object: %w", err)
+	}
+	defer rc.Close()
+
+	hasher := sha256.New()
+	gz, err := gzip.NewReader(io.TeeReader(rc, hasher))
+	if err != nil {
+		return fmt.Errorf("%w: %v", audit.ErrArchiveCorrupted, err)
+	}
+	defer gz.Close()
+
+	var rows int64
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var log audit.Log
+		if err := json.Unmarshal(scanner.Bytes(), &log); err != nil {
+			return fmt.Errorf("%w: %v", audit.ErrArchiveCorrupted, err)
+		}
+		rows++
+		if err := fn(&log); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: %v", audit.ErrArchiveCorrupted, err)
+	}
+
+	// Drain any trailing bytes so the checksum covers the whole object
+	if _, err := io.Copy(io.Discard, rc); err != nil {
+		return fmt.Errorf("failed to read archive object: %w", err)
+	}
+
+	if checksum := hex.EncodeToString(hasher.Sum(nil)); checksum != manifest.Checksum {
+		return fmt.Errorf("%w: checksum mismatch", audit.ErrArchiveCorrupted)
+	}
+	if rows != manifest.RowCount {
+		return fmt.Errorf("%w: expected %d rows, found %d", audit.ErrArchiveCorrupted, manifest.RowCount, rows)
+	}
+	return nil
+}
+
+// objectKeys returns the data and manifest keys for a month
+func (s *AuditArchiveService) objectKeys(month time.Time) (string, string) {
+	base := fmt.Sprintf("%s/%s/%s", s.prefix, month.Format("2006/01"), audit.PartitionName(month))
+	return base + ".ndjson.gz", base + ".manifest.json"
+}
+
+// rewrittenObjectKeys returns new data and manifest keys for a rewrite of a month's archive.
+// Each rewrite gets keys of its own, so the archive record only ever points at complete objects.
+func (s *AuditArchiveService) rewrittenObjectKeys(month time.Time) (string, string) {
+	base := fmt.Sprintf("%s/%s/%s.%s", s.prefix, month.Format("2006/01"), audit.PartitionName(month), uuid.NewString())
+	return base + ".ndjson.gz", base + ".manifest.json"
+}
+
+// countingWriter counts bytes written through it
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,483 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestArchiveService(t *testing.T, now time.Time) (*AuditArchiveService, *mocks.MockAuditPartitionRepository, *storage.LocalObjectStore) {
+	t.Helper()
+	logger := observability.NewLogger("dev", "test-service")
+	repo := new(mocks.MockAuditPartitionRepository)
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	require.NoError(t, err)
+
+	svc := NewAuditArchiveService(repo, store, logger, 90, 3, "")
+	svc.now = func() time.Time { return now }
+	return svc, repo, store
+}
+
+func testArchiveLogs(month time.Time, n int) []*audit.Log {
+	logs := make([]*audit.Log, n)
+	for i := range logs {
+		ip := "10.0.0.1"
+		logs[i] = &audit.Log{
+			ID:            uuid.New(),
+			EventType:     "user.login",
+			EventCategory: audit.CategoryAuthentication,
+			Severity:      audit.SeverityInfo,
+			ActorType:     audit.ActorUser,
+			Action:        "login",
+			IPAddress:     &ip,
+			Status:        audit.StatusSuccess,
+			Metadata:      map[string]interface{}{"attempt": float64(i)},
+			CreatedAt:     month.Add(time.Duration(i) * time.Hour),
+		}
+	}
+	return logs
+}
+
+func TestAuditArchiveService_EnsurePartitions(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, repo, _ := newTestArchiveService(t, now)
+
+	repo.On("EnsurePartitions", mock.Anything, now, time.Date(2026, 2, 8, 12, 0, 0, 0, time.UTC)).Return(2, nil).Once()
+
+	created, err := svc.EnsurePartitions(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, created)
+	repo.AssertExpectations(t)
+}
+
+func TestAuditArchiveService_ArchiveExpiredPartitions(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, repo, store := newTestArchiveService(t, now)
+	ctx := context.Background()
+
+	expired := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)  // ends Aug 1, before the Aug 10 cutoff
+	straddle := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC) // ends Sep 1, after the cutoff
+	logs := testArchiveLogs(expired, 3)
+
+	repo.On("ListPartitions", mock.Anything).Return([]*audit.Partition{
+		{Name: audit.PartitionName(expired), Month: expired, Attached: true},
+		{Name: audit.PartitionName(straddle), Month: straddle, Attached: true},
+	}, nil).Once()
+	repo.On("GetArchive", mock.Anything, expired).Return(nil, audit.ErrArchiveNotFound).Once()
+	repo.On("DetachPartition", mock.Anything, expired).Return(nil).Once()
+	repo.On("StreamPartition", mock.Anything, expired, mock.Anything).Return(logs, nil).Once()
+	repo.On("CreateArchive", mock.Anything, mock.MatchedBy(func(a *audit.Archive) bool {
+		return a.Month.Equal(expired) && a.RowCount == 3 && len(a.Checksum) == 64 &&
+			a.ObjectKey == "audit-logs/2025/07/audit_logs_y2025m07.ndjson.gz" &&
+			a.ManifestKey == "audit-logs/2025/07/audit_logs_y2025m07.manifest.json"
+	})).Return(func(_ context.Context, a *audit.Archive) *audit.Archive { return a }, nil).Once()
+	repo.On("DropPartition", mock.Anything, expired).Return(nil).Once()
+
+	archived, err := svc.ArchiveExpiredPartitions(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, archived, 1)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "DetachPartition", mock.Anything, straddle)
+
+	exists, err := store.Exists(ctx, archived[0].ObjectKey)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	manifest, err := svc.readManifest(ctx, archived[0].ManifestKey)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), manifest.RowCount)
+	assert.Equal(t, audit.ArchiveFormat, manifest.Format)
+	assert.Equal(t, archived[0].Checksum, manifest.Checksum)
+	assert.True(t, manifest.FirstCreatedAt.Equal(logs[0].CreatedAt))
+	assert.True(t, manifest.LastCreatedAt.Equal(logs[2].CreatedAt))
+}
+
+func TestAuditArchiveService_ArchiveExpiredPartitions_SkipsRestoredAndResumes(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, repo, _ := newTestArchiveService(t, now)
+
+	restoredMonth := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	leftoverMonth := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	restoredAt := now.Add(-time.Hour)
+
+	repo.On("ListPartitions", mock.Anything).Return([]*audit.Partition{
+		{Name: audit.PartitionName(restoredMonth), Month: restoredMonth, Attached: true},
+		{Name: audit.PartitionName(leftoverMonth), Month: leftoverMonth, Attached: false},
+	}, nil).Once()
+	repo.On("GetArchive", mock.Anything, restoredMonth).Return(&audit.Archive{Month: restoredMonth, RestoredAt: &restoredAt}, nil).Once()
+	repo.On("GetArchive", mock.Anything, leftoverMonth).Return(&audit.Archive{Month: leftoverMonth}, nil).Once()
+	repo.On("DropPartition", mock.Anything, leftoverMonth).Return(nil).Once()
+
+	archived, err := svc.ArchiveExpiredPartitions(context.Background())
+
+	require.NoError(t, err)
+	assert.Empty(t, archived)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "DropPartition", mock.Anything, restoredMonth)
+}
+
+func TestAuditArchiveService_ArchiveExpiredPartitions_SkipsLegalHold(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, repo, _ := newTestArchiveService(t, now)
+	holds := new(mocks.MockLegalHoldRepository)
+	svc.WithLegalHolds(holds)
+
+	heldMonth := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	freeMonth := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	repo.On("ListPartitions", mock.Anything).Return([]*audit.Partition{
+		{Name: audit.PartitionName(heldMonth), Month: heldMonth, Attached: true},
+		{Name: audit.PartitionName(freeMonth), Month: freeMonth, Attached: false},
+	}, nil).Once()
+	holds.On("IsRangeOnHold", mock.Anything, heldMonth, freeMonth).Return(true, nil).Once()
+	holds.On("IsRangeOnHold", mock.Anything, freeMonth, freeMonth.AddDate(0, 1, 0)).Return(false, nil).Once()
+	repo.On("GetArchive", mock.Anything, freeMonth).Return(&audit.Archive{Month: freeMonth}, nil).Once()
+	repo.On("DropPartition", mock.Anything, freeMonth).Return(nil).Once()
+
+	_, err := svc.ArchiveExpiredPartitions(context.Background())
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	holds.AssertExpectations(t)
+	repo.AssertNotCalled(t, "GetArchive", mock.Anything, heldMonth)
+	repo.AssertNotCalled(t, "DetachPartition", mock.Anything, heldMonth)
+}
+
+func TestAuditArchiveService_ArchiveExpiredPartitions_LegalHoldCheckError(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, repo, _ := newTestArchiveService(t, now)
+	holds := new(mocks.MockLegalHoldRepository)
+	svc.WithLegalHolds(holds)
+
+	month := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	repo.On("ListPartitions", mock.Anything).Return([]*audit.Partition{
+		{Name: audit.PartitionName(month), Month: month, Attached: true},
+	}, nil).Once()
+	holds.On("IsRangeOnHold", mock.Anything, month, month.AddDate(0, 1, 0)).Return(false, errors.New("db down")).Once()
+
+	_, err := svc.ArchiveExpiredPartitions(context.Background())
+
+	require.Error(t, err)
+	repo.AssertNotCalled(t, "DetachPartition", mock.Anything, month)
+}
+
+func TestAuditArchiveService_ArchiveExpiredPartitions_StreamError(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, repo, _ := newTestArchiveService(t, now)
+
+	month := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.On("ListPartitions", mock.Anything).Return([]*audit.Partition{
+		{Name: audit.PartitionName(month), Month: month, Attached: false},
+	}, nil).Once()
+	repo.On("GetArchive", mock.Anything, month).Return(nil, audit.ErrArchiveNotFound).Once()
+	repo.On("StreamPartition", mock.Anything, month, mock.Anything).Return(nil, errors.New("connection reset")).Once()
+
+	_, err := svc.ArchiveExpiredPartitions(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection reset")
+	repo.AssertNotCalled(t, "CreateArchive", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "DropPartition", mock.Anything, mock.Anything)
+}
+
+func TestAuditArchiveService_RestoreArchive_RoundTrip(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, repo, _ := newTestArchiveService(t, now)
+	ctx := context.Background()
+
+	month := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	logs := testArchiveLogs(month, 5)
+
+	var recorded *audit.Archive
+	repo.On("ListPartitions", mock.Anything).Return([]*audit.Partition{
+		{Name: audit.PartitionName(month), Month: month, Attached: true},
+	}, nil).Once()
+	repo.On("GetArchive", mock.Anything, month).Return(nil, audit.ErrArchiveNotFound).Once()
+	repo.On("DetachPartition", mock.Anything, month).Return(nil).Once()
+	repo.On("StreamPartition", mock.Anything, month, mock.Anything).Return(logs, nil).Once()
+	repo.On("CreateArchive", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recorded = args.Get(1).(*audit.Archive)
+	}).Return(func(_ context.Context, a *audit.Archive) *audit.Archive { return a }, nil).Once()
+	repo.On("DropPartition", mock.Anything, month).Return(nil)
+
+	_, err := svc.ArchiveExpiredPartitions(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, recorded)
+
+	// Restore the month from the archive written above
+	var inserted []*audit.Log
+	restoredAt := now
+	repo.On("GetArchive", mock.Anything, month).Return(recorded, nil).Once()
+	repo.On("CreatePartition", mock.Anything, month).Return(nil).Once()
+	repo.On("InsertLogs", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		inserted = append(inserted, args.Get(1).([]*audit.Log)...)
+	}).Return(int64(5), nil).Once()
+	repo.On("MarkArchiveRestored", mock.Anything, month, "admin@pandora.test").
+		Return(&audit.Archive{Month: month, PartitionName: recorded.PartitionName, RestoredAt: &restoredAt}, nil).Once()
+
+	archive, err := svc.RestoreArchive(ctx, month.Add(10*24*time.Hour), "admin@pandora.test")
+
+	require.NoError(t, err)
+	assert.True(t, archive.IsRestored())
+	require.Len(t, inserted, 5)
+	for i := range logs {
+		assert.Equal(t, logs[i].ID, inserted[i].ID)
+		assert.True(t, logs[i].CreatedAt.Equal(inserted[i].CreatedAt))
+		assert.Equal(t, logs[i].Metadata, inserted[i].Metadata)
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestAuditArchiveService_RestoreArchive_Corrupted(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, repo, store := newTestArchiveService(t, now)
+	ctx := context.Background()
+
+	month := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	objectKey, manifestKey := svc.objectKeys(month)
+	require.NoError(t, store.Put(ctx, objectKey, strings.NewReader("not gzip")))
+	require.NoError(t, store.Put(ctx, manifestKey, strings.NewReader(`{"row_count":1,"checksum_sha256":"00"}`)))
+
+	repo.On("GetArchive", mock.Anything, month).Return(&audit.Archive{
+		Month: month, ObjectKey: objectKey, ManifestKey: manifestKey,
+	}, nil).Once()
+	repo.On("DropPartition", mock.Anything, month).Return(nil).Twice()
+	repo.On("CreatePartition", mock.Anything, month).Return(nil).Once()
+
+	_, err := svc.RestoreArchive(ctx, month, "admin@pandora.test")
+
+	assert.ErrorIs(t, err, audit.ErrArchiveCorrupted)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "MarkArchiveRestored", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuditArchiveService_RestoreArchive_Errors(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	month := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("not found", func(t *testing.T) {
+		svc, repo, _ := newTestArchiveService(t, now)
+		repo.On("GetArchive", mock.Anything, month).Return(nil, audit.ErrArchiveNotFound).Once()
+
+		_, err := svc.RestoreArchive(context.Background(), month, "admin")
+		assert.ErrorIs(t, err, audit.ErrArchiveNotFound)
+	})
+
+	t.Run("already restored", func(t *testing.T) {
+		svc, repo, _ := newTestArchiveService(t, now)
+		repo.On("GetArchive", mock.Anything, month).Return(&audit.Archive{Month: month, RestoredAt: &now}, nil).Once()
+
+		_, err := svc.RestoreArchive(context.Background(), month, "admin")
+		assert.ErrorIs(t, err, audit.ErrArchiveAlreadyRestored)
+	})
+}
+
+func TestAuditArchiveService_ReleaseArchive(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	month := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("releases restored month", func(t *testing.T) {
+		svc, repo, _ := newTestArchiveService(t, now)
+		repo.On("GetArchive", mock.Anything, month).Return(&audit.Archive{Month: month, RestoredAt: &now}, nil).Once()
+		repo.On("DropPartition", mock.Anything, month).Return(nil).Once()
+		repo.On("ClearArchiveRestored", mock.Anything, month).Return(&audit.Archive{Month: month}, nil).Once()
+
+		archive, err := svc.ReleaseArchive(context.Background(), month)
+
+		require.NoError(t, err)
+		assert.False(t, archive.IsRestored())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("not restored", func(t *testing.T) {
+		svc, repo, _ := newTestArchiveService(t, now)
+		repo.On("GetArchive", mock.Anything, month).Return(&audit.Archive{Month: month}, nil).Once()
+
+		_, err := svc.ReleaseArchive(context.Background(), month)
+
+		assert.ErrorIs(t, err, audit.ErrArchiveNotRestored)
+		repo.AssertNotCalled(t, "DropPartition", mock.Anything, mock.Anything)
+	})
+}
+
+// putTestArchive exports logs as the archive of month, as archival would
+func putTestArchive(t *testing.T, svc *AuditArchiveService, repo *mocks.MockAuditPartitionRepository, month time.Time, logs []*audit.Log) *audit.Archive {
+	t.Helper()
+	ctx := context.Background()
+	partition := &audit.Partition{Name: audit.PartitionName(month), Month: month}
+	objectKey, manifestKey := svc.objectKeys(month)
+
+	repo.On("StreamPartition", mock.Anything, month, mock.Anything).Return(logs, nil).Once()
+	manifest, err := svc.exportPartition(ctx, partition, objectKey)
+	require.NoError(t, err)
+	manifestJSON, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, svc.store.Put(ctx, manifestKey, bytes.NewReader(manifestJSON)))
+
+	return &audit.Archive{
+		Month:         month,
+		PartitionName: partition.Name,
+		ObjectKey:     objectKey,
+		ManifestKey:   manifestKey,
+		RowCount:      manifest.RowCount,
+		SizeBytes:     manifest.SizeBytes,
+		Checksum:      manifest.Checksum,
+	}
+}
+
+func TestAuditArchiveService_RewriteArchives(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	june := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	august := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	// rewrite replaces alice's email wherever she is the actor
+	rewrite := func(log *audit.Log) bool {
+		if log.ActorIdentifier == nil || *log.ActorIdentifier != "alice@example.com" {
+			return false
+		}
+		pseudonym := "erased-0123456789abcdef"
+		log.ActorIdentifier = &pseudonym
+		return true
+	}
+	monthLogs := func(month time.Time, actors ...string) []*audit.Log {
+		logs := testArchiveLogs(month, len(actors))
+		for i := range actors {
+			logs[i].ActorIdentifier = &actors[i]
+		}
+		return logs
+	}
+
+	t.Run("rewrites only the months with changed entries", func(t *testing.T) {
+		svc, repo, store := newTestArchiveService(t, now)
+		julyArchive := putTestArchive(t, svc, repo, july, monthLogs(july, "alice@example.com", "bob@example.com"))
+		augustArchive := putTestArchive(t, svc, repo, august, monthLogs(august, "bob@example.com"))
+		// June is before since; it is not even read
+		juneArchive := &audit.Archive{Month: june, PartitionName: audit.PartitionName(june), ObjectKey: "missing", ManifestKey: "missing"}
+
+		var replaced *audit.Archive
+		repo.On("ListArchives", mock.Anything, int32(archiveListPageSize), int32(0)).
+			Return([]*audit.Archive{augustArchive, julyArchive, juneArchive}, nil).Once()
+		repo.On("ReplaceArchiveObject", mock.Anything, mock.Anything, julyArchive.ObjectKey).Run(func(args mock.Arguments) {
+			replaced = args.Get(1).(*audit.Archive)
+		}).Return(func(_ context.Context, a *audit.Archive) *audit.Archive { return a }, nil).Once()
+
+		rewritten, err := svc.RewriteArchives(ctx, july.Add(12*24*time.Hour), rewrite)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), rewritten)
+		repo.AssertExpectations(t)
+		require.NotNil(t, replaced)
+		assert.Equal(t, july, replaced.Month)
+		assert.NotEqual(t, julyArchive.ObjectKey, replaced.ObjectKey)
+		assert.Equal(t, int64(2), replaced.RowCount)
+
+		for _, key := range []string{julyArchive.ObjectKey, julyArchive.ManifestKey} {
+			exists, err := store.Exists(ctx, key)
+			require.NoError(t, err)
+			assert.False(t, exists, "replaced objects are deleted: %s", key)
+		}
+		exists, err := store.Exists(ctx, augustArchive.ObjectKey)
+		require.NoError(t, err)
+		assert.True(t, exists, "months without changes are kept as they are")
+
+		manifest, err := svc.readManifest(ctx, replaced.ManifestKey)
+		require.NoError(t, err)
+		require.NotNil(t, manifest.RewrittenAt)
+		assert.Equal(t, replaced.Checksum, manifest.Checksum)
+		var actors []string
+		require.NoError(t, svc.scanArchive(ctx, replaced.ObjectKey, manifest, func(log *audit.Log) error {
+			actors = append(actors, *log.ActorIdentifier)
+			return nil
+		}))
+		assert.Equal(t, []string{"erased-0123456789abcdef", "bob@example.com"}, actors)
+	})
+
+	t.Run("concurrent rewrite is not overwritten", func(t *testing.T) {
+		svc, repo, store := newTestArchiveService(t, now)
+		julyArchive := putTestArchive(t, svc, repo, july, monthLogs(july, "alice@example.com"))
+
+		var attempted *audit.Archive
+		repo.On("ListArchives", mock.Anything, int32(archiveListPageSize), int32(0)).Return([]*audit.Archive{julyArchive}, nil).Once()
+		repo.On("ReplaceArchiveObject", mock.Anything, mock.Anything, julyArchive.ObjectKey).Run(func(args mock.Arguments) {
+			attempted = args.Get(1).(*audit.Archive)
+		}).Return(nil, audit.ErrArchiveChanged).Once()
+
+		_, err := svc.RewriteArchives(ctx, july, rewrite)
+
+		assert.ErrorIs(t, err, audit.ErrArchiveChanged)
+		require.NotNil(t, attempted)
+		for key, want := range map[string]bool{
+			julyArchive.ObjectKey: true,
+			attempted.ObjectKey:   false,
+			attempted.ManifestKey: false,
+		} {
+			exists, err := store.Exists(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, want, exists, key)
+		}
+	})
+
+	t.Run("corrupted archive is not rewritten", func(t *testing.T) {
+		svc, repo, store := newTestArchiveService(t, now)
+		julyArchive := putTestArchive(t, svc, repo, july, monthLogs(july, "alice@example.com"))
+		require.NoError(t, store.Put(ctx, julyArchive.ManifestKey, strings.NewReader(`{"row_count":1,"checksum_sha256":"00"}`)))
+		repo.On("ListArchives", mock.Anything, int32(archiveListPageSize), int32(0)).Return([]*audit.Archive{julyArchive}, nil).Once()
+
+		_, err := svc.RewriteArchives(ctx, july, rewrite)
+
+		assert.ErrorIs(t, err, audit.ErrArchiveCorrupted)
+		repo.AssertNotCalled(t, "ReplaceArchiveObject", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuditCleanupJob_RunOnce_WithArchiver(t *testing.T) {
+	logger := observability.NewLogger("dev", "test-service")
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, partitionRepo, _ := newTestArchiveService(t, now)
+	auditRepo := new(mocks.MockAuditRepository)
+
+	partitionRepo.On("EnsurePartitions", mock.Anything, mock.Anything, mock.Anything).Return(0, nil).Once()
+	partitionRepo.On("ListPartitions", mock.Anything).Return([]*audit.Partition{}, nil).Once()
+	auditRepo.On("DeleteExpired", mock.Anything).Return(nil).Once()
+
+	job := NewAuditCleanupJob(auditRepo, logger, time.Hour).WithArchiver(svc)
+	err := job.RunOnce(context.Background())
+
+	require.NoError(t, err)
+	partitionRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestAuditCleanupJob_RunOnce_ArchiverError(t *testing.T) {
+	logger := observability.NewLogger("dev", "test-service")
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, partitionRepo, _ := newTestArchiveService(t, now)
+	auditRepo := new(mocks.MockAuditRepository)
+
+	partitionRepo.On("EnsurePartitions", mock.Anything, mock.Anything, mock.Anything).Return(0, io.ErrUnexpectedEOF).Once()
+
+	job := NewAuditCleanupJob(auditRepo, logger, time.Hour).WithArchiver(svc)
+	err := job.RunOnce(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to ensure audit log partitions")
+	auditRepo.AssertNotCalled(t, "DeleteExpired", mock.Anything)
+}
@@ -9,9 +9,15 @@ import (
 	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
 )
 
-// AuditCleanupJob handles periodic cleanup of expired audit logs
+// archiveTimeout bounds a single partition archival run (exports can be large)
+const archiveTimeout = time.Hour
+
+// AuditCleanupJob handles periodic cleanup of expired audit logs.
+// When an archiver is attached it also maintains audit_logs partitions:
+// future months are pre-created and months past retention are archived and dropped.
 type AuditCleanupJob struct {
 	auditRepo       audit.Repository
+	archiver        *AuditArchiveService
 	logger          *observability.Logger
 	cleanupInterval time.Duration
 	stopChan        chan struct{}
@@ -33,6 +39,12 @@ func NewAuditCleanupJob(
 	}
 }
 
+// WithArchiver enables partition maintenance and archival on every run
+func (j *AuditCleanupJob) WithArchiver(archiver *AuditArchiveService) *AuditCleanupJob {
+	j.archiver = archiver
+	return j
+}
+
 // Start begins the periodic cleanup job
 // Runs in a goroutine and can be stopped with Stop()
 func (j *AuditCleanupJob) Start(ctx context.Context) {
@@ -45,10 +57,10 @@ func (j *AuditCleanupJob) Start(ctx context.Context) {
 
 	// Start ticker for periodic cleanup
 	ticker := time.NewTicker(j.cleanupInterval)
-	defer ticker.Stop()
 
 	go func() {
 		defer close(j.doneChan)
+		defer ticker.Stop()
 
 		for {
 			select {
@@ -80,6 +92,12 @@ func (j *AuditCleanupJob) runCleanup(ctx context.Context) error {
 	startTime := time.Now()
 	j.logger.Debug("Running audit log cleanup")
 
+	if j.archiver != nil {
+		if err := j.runArchival(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Create a timeout context for the cleanup operation
 	cleanupCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
@@ -98,6 +116,26 @@ func (j *AuditCleanupJob) runCleanup(ctx context.Context) error {
 	return nil
 }
 
+// runArchival creates upcoming partitions and archives partitions past retention
+func (j *AuditCleanupJob) runArchival(ctx context.Context) error {
+	archiveCtx, cancel := context.WithTimeout(ctx, archiveTimeout)
+	defer cancel()
+
+	if _, err := j.archiver.EnsurePartitions(archiveCtx); err != nil {
+		return fmt.Errorf("failed to ensure audit log partitions: %w", err)
+	}
+
+	archived, err := j.archiver.ArchiveExpiredPartitions(archiveCtx)
+	if err != nil {
+		return fmt.Errorf("failed to archive expired audit log partitions: %w", err)
+	}
+
+	if len(archived) > 0 {
+		j.logger.WithField("archived_partitions", len(archived)).Info("Audit log partitions archived")
+	}
+	return nil
+}
+
 // RunOnce executes a single cleanup operation (useful for testing)
 func (j *AuditCleanupJob) RunOnce(ctx context.Context) error {
 	return j.runCleanup(ctx)
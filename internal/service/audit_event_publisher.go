@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+)
+
+// PublishingAuditRepository decorates an audit.Repository so that every high and critical
+// severity entry is published as an audit.Event once it is stored. Fraud and alerting
+// services consume these from a dedicated stream instead of polling audit_logs.
+//
+// Publishing is best effort: the audit log row is the source of truth, so a publish
+// failure is logged and never fails the write.
+type PublishingAuditRepository struct {
+	audit.Repository
+	publisher common.EventPublisher
+	logger    *observability.Logger
+}
+
+// NewPublishingAuditRepository wraps repo so high and critical entries are sent to publisher
+func NewPublishingAuditRepository(repo audit.Repository, publisher common.EventPublisher, logger *observability.Logger) *PublishingAuditRepository {
+	return &PublishingAuditRepository{
+		Repository: repo,
+		publisher:  publisher,
+		logger:     logger,
+	}
+}
+
+// Create stores the entry and publishes it if it is high or critical severity
+func (r *PublishingAuditRepository) Create(ctx context.Context, log *audit.Log) (*audit.Log, error) {
+	created, err := r.Repository.Create(ctx, log)
+	if err != nil {
+		return nil, err
+	}
+
+	if created.IsHighSeverity() {
+		event := audit.NewLoggedEvent(created)
+		if err := r.publisher.Publish(event); err != nil {
+			r.logger.WithError(err).WithFields(map[string]interface{}{
+				"audit_id":   created.ID.String(),
+				"event_type": created.EventType,
+				"severity":   string(created.Severity),
+			}).Warn("Failed to publish audit event")
+		}
+	}
+
+	return created, nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPublishingAuditRepository() (*PublishingAuditRepository, *mocks.MockAuditRepository, *mocks.MockEventPublisher) {
+	logger := observability.NewLogger("dev", "test-service")
+	auditRepo := new(mocks.MockAuditRepository)
+	publisher := new(mocks.MockEventPublisher)
+	return NewPublishingAuditRepository(auditRepo, publisher, logger), auditRepo, publisher
+}
+
+func storedLog(severity audit.Severity) *audit.Log {
+	userID := uuid.New()
+	requestID := "req-123"
+	return &audit.Log{
+		ID:            uuid.New(),
+		EventType:     "admin.put",
+		EventCategory: audit.CategorySecurity,
+		Severity:      severity,
+		UserID:        &userID,
+		ActorType:     audit.ActorAdmin,
+		Action:        "PUT /admin/users/:id/role",
+		RequestID:     &requestID,
+		Metadata:      map[string]interface{}{"status_code": 200},
+		PreviousState: map[string]interface{}{"role": "user"},
+		Status:        audit.StatusSuccess,
+		CreatedAt:     time.Now(),
+	}
+}
+
+func TestPublishingAuditRepository_Create(t *testing.T) {
+	t.Run("publishes high and critical entries", func(t *testing.T) {
+		for _, severity := range []audit.Severity{audit.SeverityHigh, audit.SeverityCritical} {
+			repo, auditRepo, publisher := newTestPublishingAuditRepository()
+			stored := storedLog(severity)
+
+			auditRepo.On("Create", mock.Anything, mock.Anything).Return(stored, nil).Once()
+			publisher.On("Publish", mock.MatchedBy(func(e *audit.Event) bool {
+				attrs := e.Attributes()
+				return e.Type == audit.EventTypeAuditLogged &&
+					e.AuditID == stored.ID &&
+					attrs["severity"] == string(severity) &&
+					attrs["user_id"] == stored.UserID.String() &&
+					e.Metadata["request_id"] == "req-123" &&
+					e.Payload["metadata"] != nil &&
+					e.Payload["previous_state"] == nil
+			})).Return(nil).Once()
+
+			created, err := repo.Create(context.Background(), &audit.Log{})
+
+			require.NoError(t, err)
+			assert.Equal(t, stored, created)
+			publisher.AssertExpectations(t)
+		}
+	})
+
+	t.Run("does not publish info and warning entries", func(t *testing.T) {
+		for _, severity := range []audit.Severity{audit.SeverityInfo, audit.SeverityWarning} {
+			repo, auditRepo, publisher := newTestPublishingAuditRepository()
+
+			auditRepo.On("Create", mock.Anything, mock.Anything).Return(storedLog(severity), nil).Once()
+
+			_, err := repo.Create(context.Background(), &audit.Log{})
+
+			require.NoError(t, err)
+			publisher.AssertNotCalled(t, "Publish", mock.Anything)
+		}
+	})
+
+	t.Run("drops metadata of sensitive entries", func(t *testing.T) {
+		repo, auditRepo, publisher := newTestPublishingAuditRepository()
+		stored := storedLog(audit.SeverityHigh)
+		stored.IsSensitive = true
+
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(stored, nil).Once()
+		publisher.On("Publish", mock.MatchedBy(func(e *audit.Event) bool {
+			_, hasMetadata := e.Payload["metadata"]
+			return !hasMetadata && e.Payload["is_sensitive"] == true
+		})).Return(nil).Once()
+
+		_, err := repo.Create(context.Background(), &audit.Log{})
+
+		require.NoError(t, err)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("publish failure does not fail the write", func(t *testing.T) {
+		repo, auditRepo, publisher := newTestPublishingAuditRepository()
+		stored := storedLog(audit.SeverityCritical)
+
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(stored, nil).Once()
+		publisher.On("Publish", mock.Anything).Return(errors.New("redis down")).Once()
+
+		created, err := repo.Create(context.Background(), &audit.Log{})
+
+		require.NoError(t, err)
+		assert.Equal(t, stored, created)
+	})
+
+	t.Run("store failure is returned without publishing", func(t *testing.T) {
+		repo, auditRepo, publisher := newTestPublishingAuditRepository()
+
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+		_, err := repo.Create(context.Background(), &audit.Log{Severity: audit.SeverityCritical})
+
+		require.Error(t, err)
+		publisher.AssertNotCalled(t, "Publish", mock.Anything)
+	})
+}
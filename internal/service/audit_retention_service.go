@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+)
+
+// EventAuditRetentionRestamped is the audit event recorded when existing logs are re-stamped
+const EventAuditRetentionRestamped = "audit.retention_restamped"
+
+// AuditRetentionService applies the configured retention policy to audit logs that
+// already exist and previews what the cleanup job would remove.
+type AuditRetentionService struct {
+	retentionRepo audit.RetentionRepository
+	auditRepo     audit.Repository
+	policy        *audit.RetentionPolicy
+	logger        *observability.Logger
+	auditLogger   *observability.AuditLogger
+	now           func() time.Time
+}
+
+// NewAuditRetentionService creates a new audit retention service
+func NewAuditRetentionService(
+	retentionRepo audit.RetentionRepository,
+	auditRepo audit.Repository,
+	policy *audit.RetentionPolicy,
+	logger *observability.Logger,
+) *AuditRetentionService {
+	return &AuditRetentionService{
+		retentionRepo: retentionRepo,
+		auditRepo:     auditRepo,
+		policy:        policy,
+		logger:        logger,
+		auditLogger:   observability.NewAuditLogger(logger),
+		now:           time.Now,
+	}
+}
+
+// Policy returns the policy used for new audit logs
+func (s *AuditRetentionService) Policy() *audit.RetentionPolicy {
+	return s.policy
+}
+
+// RestampRetention recomputes retention_until of existing logs from the current policy.
+// Logs kept indefinitely are not touched. Shortened retention takes effect on the next cleanup run.
+func (s *AuditRetentionService) RestampRetention(ctx context.Context, actor string) (int64, error) {
+	updated, err := s.retentionRepo.Restamp(ctx, s.policy)
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"updated": updated,
+		"actor":   actor,
+	}).Info("Audit log retention re-stamped")
+
+	s.recordRestamp(ctx, actor, updated)
+	s.auditLogger.LogSecurityEvent(EventAuditRetentionRestamped, "high", map[string]interface{}{
+		"updated": updated,
+		"actor":   actor,
+	})
+
+	return updated, nil
+}
+
+// DryRun reports what the cleanup job would delete at asOf, without deleting anything
+func (s *AuditRetentionService) DryRun(ctx context.Context, asOf time.Time) (*audit.RetentionReport, error) {
+	if asOf.IsZero() {
+		asOf = s.now()
+	}
+
+	buckets, err := s.retentionRepo.SummarizeExpired(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &audit.RetentionReport{
+		AsOf:    asOf,
+		Buckets: buckets,
+	}
+	for _, bucket := range buckets {
+		report.TotalDeletable += bucket.DeletableCount
+		report.TotalHeld += bucket.HeldCount
+	}
+	return report, nil
+}
+
+// recordRestamp writes the re-stamp to audit_logs with the policy that was applied.
+// Failures are logged but do not fail the re-stamp, which has already been committed.
+func (s *AuditRetentionService) recordRestamp(ctx context.Context, actor string, updated int64) {
+	entry := &audit.Log{
+		EventType:       EventAuditRetentionRestamped,
+		EventCategory:   audit.CategoryCompliance,
+		Severity:        audit.SeverityHigh,
+		ActorType:       audit.ActorAdmin,
+		ActorIdentifier: &actor,
+		Action:          "update",
+		Metadata: map[string]interface{}{
+			"updated": updated,
+		},
+		NewState: retentionPolicyState(s.policy),
+		Status:   audit.StatusSuccess,
+	}
+
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		s.logger.WithError(err).Error("Failed to record retention re-stamp in audit log")
+	}
+}
+
+// retentionPolicyState flattens a policy for the new_state column
+func retentionPolicyState(policy *audit.RetentionPolicy) map[string]interface{} {
+	rules := make([]map[string]interface{}, 0, len(policy.Rules()))
+	for _, rule := range policy.Rules() {
+		entry := map[string]interface{}{"days": rule.Days}
+		if rule.EventType != "" {
+			entry["event_type"] = rule.EventType
+		}
+		if rule.Category != "" {
+			entry["category"] = string(rule.Category)
+		}
+		if rule.Severity != "" {
+			entry["severity"] = string(rule.Severity)
+		}
+		rules = append(rules, entry)
+	}
+
+	return map[string]interface{}{
+		"default_days": policy.DefaultDays(),
+		"rules":        rules,
+	}
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAuditRetentionService(t *testing.T, now time.Time) (*AuditRetentionService, *mocks.MockAuditRetentionRepository, *mocks.MockAuditRepository) {
+	logger := observability.NewLogger("dev", "test-service")
+	retentionRepo := new(mocks.MockAuditRetentionRepository)
+	auditRepo := new(mocks.MockAuditRepository)
+
+	policy, err := audit.NewRetentionPolicy(90, []audit.RetentionRule{
+		{Category: audit.CategorySecurity, Days: 730},
+		{EventType: "user.login.failed", Days: 365},
+	})
+	require.NoError(t, err)
+
+	svc := NewAuditRetentionService(retentionRepo, auditRepo, policy, logger)
+	svc.now = func() time.Time { return now }
+	return svc, retentionRepo, auditRepo
+}
+
+func TestAuditRetentionService_RestampRetention(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+
+	t.Run("applies policy and records the change", func(t *testing.T) {
+		svc, retentionRepo, auditRepo := newTestAuditRetentionService(t, now)
+
+		retentionRepo.On("Restamp", mock.Anything, svc.Policy()).Return(int64(42), nil).Once()
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			rules := l.NewState["rules"].([]map[string]interface{})
+			return l.EventType == EventAuditRetentionRestamped &&
+				l.EventCategory == audit.CategoryCompliance &&
+				*l.ActorIdentifier == "admin@test.com" &&
+				l.Metadata["updated"] == int64(42) &&
+				l.NewState["default_days"] == 90 &&
+				len(rules) == 2 && rules[0]["event_type"] == "user.login.failed" &&
+				l.RetentionUntil == nil
+		})).Return(&audit.Log{}, nil).Once()
+
+		updated, err := svc.RestampRetention(context.Background(), "admin@test.com")
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), updated)
+		retentionRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error is returned without audit record", func(t *testing.T) {
+		svc, retentionRepo, auditRepo := newTestAuditRetentionService(t, now)
+
+		retentionRepo.On("Restamp", mock.Anything, svc.Policy()).Return(int64(0), errors.New("db down")).Once()
+
+		_, err := svc.RestampRetention(context.Background(), "admin@test.com")
+
+		require.Error(t, err)
+		auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("audit record failure does not fail the restamp", func(t *testing.T) {
+		svc, retentionRepo, auditRepo := newTestAuditRetentionService(t, now)
+
+		retentionRepo.On("Restamp", mock.Anything, svc.Policy()).Return(int64(3), nil).Once()
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+		updated, err := svc.RestampRetention(context.Background(), "admin@test.com")
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), updated)
+	})
+}
+
+func TestAuditRetentionService_DryRun(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+
+	t.Run("totals buckets at now by default", func(t *testing.T) {
+		svc, retentionRepo, _ := newTestAuditRetentionService(t, now)
+
+		retentionRepo.On("SummarizeExpired", mock.Anything, now).Return([]*audit.ExpiryBucket{
+			{Category: audit.CategoryAuthentication, Severity: audit.SeverityInfo, DeletableCount: 100, HeldCount: 5},
+			{Category: audit.CategoryDataAccess, Severity: audit.SeverityInfo, DeletableCount: 40},
+		}, nil).Once()
+
+		report, err := svc.DryRun(context.Background(), time.Time{})
+
+		require.NoError(t, err)
+		assert.Equal(t, now, report.AsOf)
+		assert.Len(t, report.Buckets, 2)
+		assert.Equal(t, int64(140), report.TotalDeletable)
+		assert.Equal(t, int64(5), report.TotalHeld)
+		retentionRepo.AssertExpectations(t)
+	})
+
+	t.Run("previews a future date", func(t *testing.T) {
+		svc, retentionRepo, _ := newTestAuditRetentionService(t, now)
+		future := now.AddDate(0, 1, 0)
+
+		retentionRepo.On("SummarizeExpired", mock.Anything, future).Return([]*audit.ExpiryBucket{}, nil).Once()
+
+		report, err := svc.DryRun(context.Background(), future)
+
+		require.NoError(t, err)
+		assert.Equal(t, future, report.AsOf)
+		assert.Zero(t, report.TotalDeletable)
+		retentionRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		svc, retentionRepo, _ := newTestAuditRetentionService(t, now)
+
+		retentionRepo.On("SummarizeExpired", mock.Anything, now).Return(nil, errors.New("db down")).Once()
+
+		_, err := svc.DryRun(context.Background(), time.Time{})
+
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,533 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/dataexport"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+)
+
+// Audit events recorded for data exports
+const (
+	EventDataExportRequested   = "data_export.requested"
+	EventDataExportCompleted   = "data_export.completed"
+	EventDataExportFailed      = "data_export.failed"
+	EventDataExportLinkCreated = "data_export.link_created"
+	EventDataExportDownloaded  = "data_export.downloaded"
+)
+
+const (
+	// DefaultDataExportPrefix is the object store prefix for export archives
+	DefaultDataExportPrefix = "data-exports"
+	// DefaultDataExportRetention is how long an archive is kept once built
+	DefaultDataExportRetention = 7 * 24 * time.Hour
+	// DefaultDataExportLinkTTL is how long a download link stays valid
+	DefaultDataExportLinkTTL = 15 * time.Minute
+
+	// defaultDataExportPollInterval is how often the worker looks for an export to build
+	defaultDataExportPollInterval = 10 * time.Second
+	// defaultDataExportLease is how long an export stays leased while its archive is built
+	defaultDataExportLease = 15 * time.Minute
+	// dataExportAuditPageSize is how many audit logs are read per query
+	dataExportAuditPageSize = 500
+	// dataExportPurgeBatch bounds the expired archives deleted per tick
+	dataExportPurgeBatch = 100
+)
+
+// Compile-time check to ensure DataExportService implements dataexport.Service
+var _ dataexport.Service = (*DataExportService)(nil)
+
+// DataExportService handles data subject access requests.
+// Users, or admins on their behalf, queue an export; a worker on any instance leases it,
+// collects the user's profile, sessions, KYC records (with document content) and the audit
+// logs about them, and streams a signed ZIP archive to the object store. The user then
+// downloads the archive through short-lived links until it expires and is deleted.
+// Requests, links and downloads are audited.
+type DataExportService struct {
+	repo       dataexport.Repository
+	users      userDomain.Repository
+	store      common.ObjectStore
+	auditRepo  audit.Repository
+	logger     *observability.Logger
+	signingKey ed25519.PrivateKey
+	owner      string
+	prefix     string
+	retention  time.Duration
+	linkTTL    time.Duration
+	interval   time.Duration
+	lease      time.Duration
+	now        func() time.Time
+	stopChan   chan struct{}
+	doneChan   chan struct{}
+}
+
+// NewDataExportService creates a new data export service
+//
+// Parameters:
+//   - repo: Export jobs and the data they collect
+//   - users: User lookups for requests made by admins
+//   - store: Object store holding archives and KYC document content
+//   - auditRepo: Audit log receiving requests and downloads
+//   - logger: Structured logger
+//   - signingKey: Key archive manifests are signed with
+//   - owner: Identifies this instance in job leases
+func NewDataExportService(
+	repo dataexport.Repository,
+	users userDomain.Repository,
+	store common.ObjectStore,
+	auditRepo audit.Repository,
+	logger *observability.Logger,
+	signingKey ed25519.PrivateKey,
+	owner string,
+) *DataExportService {
+	return &DataExportService{
+		repo:       repo,
+		users:      users,
+		store:      store,
+		auditRepo:  auditRepo,
+		logger:     logger,
+		signingKey: signingKey,
+		owner:      owner,
+		prefix:     DefaultDataExportPrefix,
+		retention:  DefaultDataExportRetention,
+		linkTTL:    DefaultDataExportLinkTTL,
+		interval:   defaultDataExportPollInterval,
+		lease:      defaultDataExportLease,
+		now:        time.Now,
+		stopChan:   make(chan struct{}),
+		doneChan:   make(chan struct{}),
+	}
+}
+
+// WithPrefix sets the object store prefix for archives
+func (s *DataExportService) WithPrefix(prefix string) *DataExportService {
+	if prefix != "" {
+		s.prefix = prefix
+	}
+	return s
+}
+
+// WithRetention sets how long archives are kept and how long download links stay valid
+func (s *DataExportService) WithRetention(retention, linkTTL time.Duration) *DataExportService {
+	if retention > 0 {
+		s.retention = retention
+	}
+	if linkTTL > 0 {
+		s.linkTTL = linkTTL
+	}
+	return s
+}
+
+// WithPollInterval sets how often the worker looks for an export to build
+func (s *DataExportService) WithPollInterval(interval time.Duration) *DataExportService {
+	if interval > 0 {
+		s.interval = interval
+	}
+	return s
+}
+
+// PublicKey is the key archive manifests are signed with
+func (s *DataExportService) PublicKey() ed25519.PublicKey {
+	return s.signingKey.Public().(ed25519.PublicKey)
+}
+
+// RequestExport queues an export of userID's data. Deleted users can still be exported.
+func (s *DataExportService) RequestExport(ctx context.Context, userID uuid.UUID, requester dataexport.Requester) (*dataexport.Job, error) {
+	if _, err := s.users.GetByIDIncludeDeleted(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	job, err := s.repo.Create(ctx, userID, requester)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"job_id":         job.ID.String(),
+		"user_id":        userID.String(),
+		"requester_type": string(requester.Type),
+	}).Info("Data export queued")
+
+	s.recordAction(ctx, EventDataExportRequested, "request", job, requester, nil)
+	return job, nil
+}
+
+// GetExport retrieves an export of userID
+func (s *DataExportService) GetExport(ctx context.Context, userID, jobID uuid.UUID) (*dataexport.Job, error) {
+	job, err := s.repo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.UserID != userID {
+		return nil, dataexport.ErrJobNotFound
+	}
+	return job, nil
+}
+
+// ListExports retrieves userID's exports, newest first
+func (s *DataExportService) ListExports(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*dataexport.Job, error) {
+	return s.repo.ListForUser(ctx, userID, limit, offset)
+}
+
+// CreateDownloadLink issues a download link to a completed export, invalidating earlier links
+func (s *DataExportService) CreateDownloadLink(ctx context.Context, userID, jobID uuid.UUID) (*dataexport.DownloadLink, error) {
+	job, err := s.GetExport(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	switch {
+	case job.Status == dataexport.StatusExpired:
+		return nil, dataexport.ErrExpired
+	case job.Status != dataexport.StatusCompleted:
+		return nil, dataexport.ErrNotReady
+	case !job.IsDownloadable(now):
+		return nil, dataexport.ErrExpired
+	}
+
+	token, hash, err := dataexport.NewDownloadToken()
+	if err != nil {
+		return nil, err
+	}
+	// A link never outlives the archive
+	expiresAt := now.Add(s.linkTTL)
+	if job.ExpiresAt != nil && job.ExpiresAt.Before(expiresAt) {
+		expiresAt = *job.ExpiresAt
+	}
+	if job, err = s.repo.SetDownloadToken(ctx, job.ID, hash, expiresAt); err != nil {
+		return nil, err
+	}
+
+	s.recordAction(ctx, EventDataExportLinkCreated, "create_download_link", job,
+		dataexport.Requester{Type: audit.ActorUser, ID: userID.String()},
+		map[string]interface{}{"link_expires_at": expiresAt.UTC().Format(time.RFC3339)})
+
+	return &dataexport.DownloadLink{JobID: job.ID, Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// OpenDownload opens the archive a download token was issued for and counts the download
+func (s *DataExportService) OpenDownload(ctx context.Context, token string) (*dataexport.Job, io.ReadCloser, error) {
+	if token == "" {
+		return nil, nil, dataexport.ErrInvalidDownloadToken
+	}
+	job, err := s.repo.GetByDownloadToken(ctx, dataexport.HashDownloadToken(token))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := s.now()
+	if job.DownloadExpiresAt == nil || !now.Before(*job.DownloadExpiresAt) || !job.IsDownloadable(now) {
+		return nil, nil, dataexport.ErrInvalidDownloadToken
+	}
+
+	content, err := s.store.Get(ctx, *job.ObjectKey)
+	if err != nil {
+		if errors.Is(err, common.ErrObjectNotFound) {
+			s.logger.WithField("job_id", job.ID.String()).Error("Data export archive is missing from the object store")
+			return nil, nil, dataexport.ErrExpired
+		}
+		return nil, nil, fmt.Errorf("failed to open data export archive: %w", err)
+	}
+
+	if err := s.repo.RecordDownload(ctx, job.ID, now); err != nil {
+		s.logger.WithError(err).WithField("job_id", job.ID.String()).Warn("Failed to count data export download")
+	}
+	s.recordAction(ctx, EventDataExportDownloaded, "download", job,
+		dataexport.Requester{Type: audit.ActorUser, ID: job.UserID.String()}, nil)
+
+	return job, content, nil
+}
+
+// recordAction files a step of an export as a sensitive audit log of the exported user
+func (s *DataExportService) recordAction(ctx context.Context, eventType, action string, job *dataexport.Job, actor dataexport.Requester, metadata map[string]interface{}) {
+	resourceType := "data_export_job"
+	resourceID := job.ID.String()
+	userID := job.UserID
+
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadata["status"] = string(job.Status)
+	metadata["requester_type"] = string(job.RequesterType)
+
+	entry := &audit.Log{
+		EventType:       eventType,
+		EventCategory:   audit.CategoryDataAccess,
+		Severity:        audit.SeverityInfo,
+		UserID:          &userID,
+		ActorType:       actor.Type,
+		ActorIdentifier: &actor.ID,
+		Action:          action,
+		ResourceType:    &resourceType,
+		ResourceID:      &resourceID,
+		Metadata:        metadata,
+		Status:          audit.StatusSuccess,
+		IsSensitive:     true,
+	}
+	if actor.Type == audit.ActorAdmin {
+		entry.Severity = audit.SeverityWarning
+	}
+
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		s.logger.WithError(err).WithField("job_id", resourceID).Error("Failed to record data export action in audit log")
+	}
+}
+
+// Start begins building queued exports and purging expired archives in a goroutine;
+// stop it with Stop()
+func (s *DataExportService) Start(ctx context.Context) {
+	s.logger.WithFields(map[string]interface{}{
+		"interval": s.interval.String(),
+		"owner":    s.owner,
+	}).Info("Starting data export worker")
+
+	ctx, cancel := context.WithCancel(ctx)
+	ticker := time.NewTicker(s.interval)
+
+	go func() {
+		defer close(s.doneChan)
+		defer ticker.Stop()
+		defer cancel()
+
+		// Stop interrupts a running export; it is built again once its lease expires
+		go func() {
+			select {
+			case <-s.stopChan:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		for {
+			if _, err := s.PurgeExpired(ctx); err != nil && ctx.Err() == nil {
+				s.logger.WithError(err).Error("Failed to purge expired data exports")
+			}
+			if _, err := s.RunNext(ctx); err != nil && ctx.Err() == nil {
+				s.logger.WithError(err).Error("Data export failed")
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				s.logger.Info("Data export worker stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop interrupts the running export and stops the worker
+func (s *DataExportService) Stop() {
+	s.logger.Info("Stopping data export worker")
+	close(s.stopChan)
+	<-s.doneChan
+	s.logger.Info("Data export worker stopped successfully")
+}
+
+// RunNext claims the oldest runnable export and builds its archive.
+// It returns nil and no error when no export is waiting.
+func (s *DataExportService) RunNext(ctx context.Context) (*dataexport.Job, error) {
+	now := s.now()
+	job, err := s.repo.Claim(ctx, s.owner, now, now.Add(s.lease))
+	if err != nil {
+		if errors.Is(err, dataexport.ErrNoJob) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return job, s.run(ctx, job)
+}
+
+// run builds a claimed export's archive and records how it ended
+func (s *DataExportService) run(ctx context.Context, job *dataexport.Job) error {
+	log := s.logger.WithFields(map[string]interface{}{
+		"job_id":  job.ID.String(),
+		"user_id": job.UserID.String(),
+	})
+	log.Info("Building data export")
+
+	system := dataexport.Requester{Type: audit.ActorSystem, ID: s.owner}
+	archive, err := s.build(ctx, job)
+	switch {
+	case err == nil:
+		completedAt := s.now()
+		if err := s.repo.Complete(ctx, job.ID, s.owner, *archive, completedAt); err != nil {
+			return s.leaseEnded(log, err)
+		}
+		job.Status = dataexport.StatusCompleted
+		job.ObjectKey = &archive.ObjectKey
+		job.SizeBytes = &archive.SizeBytes
+		job.SHA256 = &archive.SHA256
+		job.ExpiresAt = &archive.ExpiresAt
+		job.CompletedAt = &completedAt
+		log.WithFields(map[string]interface{}{
+			"size_bytes": archive.SizeBytes,
+			"expires_at": archive.ExpiresAt.UTC().Format(time.RFC3339),
+		}).Info("Data export completed")
+		s.recordAction(ctx, EventDataExportCompleted, "complete", job, system,
+			map[string]interface{}{"size_bytes": archive.SizeBytes, "sha256": archive.SHA256})
+		return nil
+
+	case ctx.Err() != nil:
+		// Shutting down: the export keeps its lease and is built again once the lease expires
+		log.Info("Data export interrupted")
+		return nil
+	}
+
+	lastError := err.Error()
+	if failErr := s.repo.Fail(ctx, job.ID, s.owner, lastError, s.now()); failErr != nil {
+		return s.leaseEnded(log, failErr)
+	}
+	job.Status = dataexport.StatusFailed
+	job.LastError = &lastError
+	s.recordAction(ctx, EventDataExportFailed, "fail", job, system, nil)
+	return fmt.Errorf("data export %s failed: %w", job.ID, err)
+}
+
+// leaseEnded handles an export that was taken over while it ran
+func (s *DataExportService) leaseEnded(log *observability.Logger, err error) error {
+	if errors.Is(err, dataexport.ErrLeaseLost) {
+		log.Info("Data export taken over by another instance, stopping")
+		return nil
+	}
+	return err
+}
+
+// build collects a user's data and streams the signed archive into the object store
+func (s *DataExportService) build(ctx context.Context, job *dataexport.Job) (*dataexport.ArchiveInfo, error) {
+	data, err := s.repo.GetSubjectData(ctx, job.UserID)
+	if err != nil {
+		return nil, err
+	}
+	auditLogs, err := s.collectAuditLogs(ctx, job.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	objectKey := path.Join(s.prefix, job.UserID.String(), job.ID.String()+".zip")
+	generatedAt := s.now()
+
+	pr, pw := io.Pipe()
+	hasher := sha256.New()
+	counter := &countingWriter{}
+	done := make(chan error, 1)
+
+	go func() {
+		err := s.writeArchive(ctx, io.MultiWriter(pw, hasher, counter), job, generatedAt, data, auditLogs)
+		pw.CloseWithError(err)
+		done <- err
+	}()
+
+	if err := s.store.Put(ctx, objectKey, pr); err != nil {
+		pr.CloseWithError(err)
+		<-done
+		return nil, fmt.Errorf("failed to write data export archive: %w", err)
+	}
+	if err := <-done; err != nil {
+		return nil, fmt.Errorf("failed to build data export archive: %w", err)
+	}
+
+	return &dataexport.ArchiveInfo{
+		ObjectKey: objectKey,
+		SizeBytes: counter.n,
+		SHA256:    hex.EncodeToString(hasher.Sum(nil)),
+		ExpiresAt: generatedAt.Add(s.retention),
+	}, nil
+}
+
+// collectAuditLogs reads every audit log about a user
+func (s *DataExportService) collectAuditLogs(ctx context.Context, userID uuid.UUID) ([]*audit.Log, error) {
+	var logs []*audit.Log
+	var after *dataexport.Position
+	for {
+		page, err := s.repo.ListAuditLogs(ctx, userID, after, dataExportAuditPageSize)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, page...)
+		if len(page) < dataExportAuditPageSize {
+			return logs, nil
+		}
+		last := page[len(page)-1]
+		after = &dataexport.Position{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// writeArchive writes the JSON and CSV file of every dataset, then the content of the KYC
+// documents, and signs the manifest
+func (s *DataExportService) writeArchive(ctx context.Context, w io.Writer, job *dataexport.Job, generatedAt time.Time, data *dataexport.SubjectData, auditLogs []*audit.Log) error {
+	archive := dataexport.NewArchiveWriter(w, job.ID, job.UserID, generatedAt)
+
+	for _, dataset := range dataexport.Datasets(data, auditLogs) {
+		if err := archive.AddJSON(dataset.Name+".json", dataset.Records); err != nil {
+			return err
+		}
+		if err := archive.AddCSV(dataset.Name+".csv", dataset.Header, dataset.Rows); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range data.KYCCases {
+		for _, doc := range c.Documents {
+			content, err := s.store.Get(ctx, doc.StorageKey)
+			if err != nil {
+				if errors.Is(err, common.ErrObjectNotFound) {
+					s.logger.WithFields(map[string]interface{}{
+						"job_id":      job.ID.String(),
+						"document_id": doc.ID.String(),
+					}).Warn("KYC document content is missing from the object store, leaving it out of the export")
+					continue
+				}
+				return fmt.Errorf("failed to open kyc document %s: %w", doc.ID, err)
+			}
+			err = archive.AddFile(dataexport.DocumentPath(doc), content)
+			content.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := archive.Close(s.signingKey)
+	return err
+}
+
+// PurgeExpired deletes archives past their retention and marks their exports expired.
+// It returns how many exports expired.
+func (s *DataExportService) PurgeExpired(ctx context.Context) (int, error) {
+	jobs, err := s.repo.ListExpired(ctx, s.now(), dataExportPurgeBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, job := range jobs {
+		if job.ObjectKey != nil {
+			if err := s.store.Delete(ctx, *job.ObjectKey); err != nil {
+				return purged, fmt.Errorf("failed to delete data export archive: %w", err)
+			}
+		}
+		if err := s.repo.MarkExpired(ctx, job.ID); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	if purged > 0 {
+		s.logger.WithField("count", purged).Info("Purged expired data exports")
+	}
+	return purged, nil
+}
@@ -0,0 +1,493 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/dataexport"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryDataExports is an in-memory dataexport.Repository with the same lease and token rules as the SQL queries
+type memoryDataExports struct {
+	mu        sync.Mutex
+	jobs      []*dataexport.Job
+	tokens    map[uuid.UUID][]byte
+	subjects  map[uuid.UUID]*dataexport.SubjectData
+	auditLogs []*audit.Log
+	pages     int
+}
+
+func newMemoryDataExports() *memoryDataExports {
+	return &memoryDataExports{
+		tokens:   map[uuid.UUID][]byte{},
+		subjects: map[uuid.UUID]*dataexport.SubjectData{},
+	}
+}
+
+func (r *memoryDataExports) find(id uuid.UUID) *dataexport.Job {
+	for _, job := range r.jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+func (r *memoryDataExports) Create(ctx context.Context, userID uuid.UUID, requester dataexport.Requester) (*dataexport.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range r.jobs {
+		if job.UserID == userID && job.Status.IsActive() {
+			return nil, dataexport.ErrExportInProgress
+		}
+	}
+	job := &dataexport.Job{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Status:        dataexport.StatusPending,
+		RequestedBy:   requester.ID,
+		RequesterType: requester.Type,
+		CreatedAt:     time.Now(),
+	}
+	r.jobs = append(r.jobs, job)
+	copied := *job
+	return &copied, nil
+}
+
+func (r *memoryDataExports) GetByID(ctx context.Context, id uuid.UUID) (*dataexport.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.find(id)
+	if job == nil {
+		return nil, dataexport.ErrJobNotFound
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (r *memoryDataExports) ListForUser(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*dataexport.Job, error) {
+	return nil, nil
+}
+
+func (r *memoryDataExports) Claim(ctx context.Context, owner string, now, leaseUntil time.Time) (*dataexport.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range r.jobs {
+		expired := job.Status == dataexport.StatusRunning && job.LeaseExpiresAt.Before(now)
+		if job.Status != dataexport.StatusPending && !expired {
+			continue
+		}
+		job.Status = dataexport.StatusRunning
+		job.LeaseOwner = &owner
+		job.LeaseExpiresAt = &leaseUntil
+		copied := *job
+		return &copied, nil
+	}
+	return nil, dataexport.ErrNoJob
+}
+
+func (r *memoryDataExports) held(id uuid.UUID, owner string) *dataexport.Job {
+	job := r.find(id)
+	if job == nil || job.Status != dataexport.StatusRunning || job.LeaseOwner == nil || *job.LeaseOwner != owner {
+		return nil
+	}
+	return job
+}
+
+func (r *memoryDataExports) Complete(ctx context.Context, id uuid.UUID, owner string, archive dataexport.ArchiveInfo, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.held(id, owner)
+	if job == nil {
+		return dataexport.ErrLeaseLost
+	}
+	job.Status = dataexport.StatusCompleted
+	job.ObjectKey = &archive.ObjectKey
+	job.SizeBytes = &archive.SizeBytes
+	job.SHA256 = &archive.SHA256
+	job.ExpiresAt = &archive.ExpiresAt
+	job.CompletedAt = &at
+	job.LeaseOwner = nil
+	return nil
+}
+
+func (r *memoryDataExports) Fail(ctx context.Context, id uuid.UUID, owner string, lastError string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.held(id, owner)
+	if job == nil {
+		return dataexport.ErrLeaseLost
+	}
+	job.Status = dataexport.StatusFailed
+	job.LastError = &lastError
+	job.LeaseOwner = nil
+	return nil
+}
+
+func (r *memoryDataExports) SetDownloadToken(ctx context.Context, id uuid.UUID, tokenHash []byte, expiresAt time.Time) (*dataexport.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.find(id)
+	if job == nil {
+		return nil, dataexport.ErrJobNotFound
+	}
+	if job.Status != dataexport.StatusCompleted {
+		return nil, dataexport.ErrNotReady
+	}
+	r.tokens[id] = tokenHash
+	job.DownloadExpiresAt = &expiresAt
+	copied := *job
+	return &copied, nil
+}
+
+func (r *memoryDataExports) GetByDownloadToken(ctx context.Context, tokenHash []byte) (*dataexport.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, hash := range r.tokens {
+		if bytes.Equal(hash, tokenHash) {
+			copied := *r.find(id)
+			return &copied, nil
+		}
+	}
+	return nil, dataexport.ErrInvalidDownloadToken
+}
+
+func (r *memoryDataExports) RecordDownload(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.find(id)
+	job.DownloadCount++
+	job.LastDownloadedAt = &at
+	return nil
+}
+
+func (r *memoryDataExports) ListExpired(ctx context.Context, now time.Time, limit int32) ([]*dataexport.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var expired []*dataexport.Job
+	for _, job := range r.jobs {
+		if job.Status == dataexport.StatusCompleted && job.ExpiresAt.Before(now) {
+			copied := *job
+			expired = append(expired, &copied)
+		}
+	}
+	return expired, nil
+}
+
+func (r *memoryDataExports) MarkExpired(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.find(id)
+	job.Status = dataexport.StatusExpired
+	job.DownloadExpiresAt = nil
+	delete(r.tokens, id)
+	return nil
+}
+
+func (r *memoryDataExports) GetSubjectData(ctx context.Context, userID uuid.UUID) (*dataexport.SubjectData, error) {
+	data, ok := r.subjects[userID]
+	if !ok {
+		return nil, user.ErrNotFound
+	}
+	return data, nil
+}
+
+func (r *memoryDataExports) ListAuditLogs(ctx context.Context, userID uuid.UUID, after *dataexport.Position, limit int32) ([]*audit.Log, error) {
+	r.pages++
+	var page []*audit.Log
+	for _, l := range r.auditLogs {
+		if l.UserID != nil && *l.UserID == userID && afterExportPosition(after, l) && len(page) < int(limit) {
+			page = append(page, l)
+		}
+	}
+	return page, nil
+}
+
+func afterExportPosition(after *dataexport.Position, l *audit.Log) bool {
+	if after == nil {
+		return true
+	}
+	if !l.CreatedAt.Equal(after.CreatedAt) {
+		return l.CreatedAt.After(after.CreatedAt)
+	}
+	return l.ID.String() > after.ID.String()
+}
+
+// dataExportFixture is a data export service with one user, a signing key and a fixed clock
+type dataExportFixture struct {
+	svc       *DataExportService
+	repo      *memoryDataExports
+	auditRepo *mocks.MockAuditRepository
+	store     *storage.LocalObjectStore
+	key       ed25519.PrivateKey
+	now       time.Time
+	userID    uuid.UUID
+}
+
+func newDataExportFixture(t *testing.T) *dataExportFixture {
+	t.Helper()
+	f := &dataExportFixture{
+		repo:      newMemoryDataExports(),
+		auditRepo: newStrictAuditRepo(t),
+		store:     newTestObjectStore(t),
+		key:       ed25519.NewKeyFromSeed(bytes.Repeat([]byte{7}, ed25519.SeedSize)),
+		now:       time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC),
+		userID:    uuid.New(),
+	}
+	users := newUserDirectory(t, map[uuid.UUID]*user.User{f.userID: {ID: f.userID}})
+
+	f.svc = NewDataExportService(f.repo, users, f.store, f.auditRepo, observability.NewLogger("dev", "test-service"), f.key, "worker-1")
+	f.svc.now = func() time.Time { return f.now }
+	return f
+}
+
+// seed stores a subject with a KYC case, a document and audit logs
+func (f *dataExportFixture) seed(t *testing.T, auditLogs int) *kyc.Document {
+	t.Helper()
+	caseID := uuid.New()
+	doc := &kyc.Document{
+		ID:         uuid.New(),
+		CaseID:     caseID,
+		Type:       kyc.DocumentPassport,
+		FileName:   "passport.pdf",
+		StorageKey: "kyc-documents/" + f.userID.String() + "/" + caseID.String() + "/passport",
+	}
+	require.NoError(t, f.store.Put(context.Background(), doc.StorageKey, strings.NewReader("%PDF-1.4 passport")))
+
+	f.repo.subjects[f.userID] = &dataexport.SubjectData{
+		Profile:  &user.User{ID: f.userID, Email: "alice@example.com", HashedPassword: "$argon2id$secret", CreatedAt: f.now},
+		Sessions: []*auth.RefreshToken{{Token: "refresh-secret", UserID: f.userID, CreatedAt: f.now, ExpiresAt: f.now.Add(time.Hour)}},
+		KYCCases: []*kyc.Case{{ID: caseID, UserID: f.userID, Status: kyc.StatusApproved, Documents: []*kyc.Document{doc}}},
+	}
+	for i := 0; i < auditLogs; i++ {
+		f.repo.auditLogs = append(f.repo.auditLogs, &audit.Log{
+			ID:        uuid.New(),
+			EventType: "user.login",
+			UserID:    &f.userID,
+			CreatedAt: f.now.Add(time.Duration(i) * time.Second),
+		})
+	}
+	return doc
+}
+
+// readArchive reads a completed job's archive from the store
+func (f *dataExportFixture) readArchive(t *testing.T, job *dataexport.Job) []byte {
+	t.Helper()
+	rc, err := f.store.Get(context.Background(), *job.ObjectKey)
+	require.NoError(t, err)
+	defer rc.Close()
+	content, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	return content
+}
+
+func TestDataExportService_RequestExport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("queues the export and records the request", func(t *testing.T) {
+		f := newDataExportFixture(t)
+		f.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.EventType == EventDataExportRequested &&
+				l.ActorType == audit.ActorAdmin &&
+				*l.ActorIdentifier == "admin@test.com" &&
+				*l.UserID == f.userID &&
+				l.Metadata["requester_type"] == "admin"
+		})).Return(&audit.Log{}, nil).Once()
+
+		job, err := f.svc.RequestExport(ctx, f.userID, dataexport.Requester{Type: audit.ActorAdmin, ID: "admin@test.com"})
+
+		require.NoError(t, err)
+		assert.Equal(t, dataexport.StatusPending, job.Status)
+		assert.Equal(t, "admin@test.com", job.RequestedBy)
+		f.auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("one export at a time", func(t *testing.T) {
+		f := newDataExportFixture(t)
+		requester := dataexport.Requester{Type: audit.ActorUser, ID: f.userID.String()}
+		expectAudited(f.auditRepo, EventDataExportRequested)
+		_, err := f.svc.RequestExport(ctx, f.userID, requester)
+		require.NoError(t, err)
+
+		_, err = f.svc.RequestExport(ctx, f.userID, requester)
+		assert.ErrorIs(t, err, dataexport.ErrExportInProgress)
+		f.auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		f := newDataExportFixture(t)
+		_, err := f.svc.RequestExport(ctx, uuid.New(), dataexport.Requester{Type: audit.ActorAdmin, ID: "admin@test.com"})
+		assert.ErrorIs(t, err, user.ErrNotFound)
+		assert.Empty(t, f.repo.jobs)
+		f.auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDataExportService_BuildsSignedArchive(t *testing.T) {
+	ctx := context.Background()
+	f := newDataExportFixture(t)
+	doc := f.seed(t, dataExportAuditPageSize+1)
+	expectAudited(f.auditRepo, EventDataExportRequested)
+	f.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		return l.EventType == EventDataExportCompleted && l.ActorType == audit.ActorSystem &&
+			*l.ActorIdentifier == "worker-1" && *l.UserID == f.userID
+	})).Return(&audit.Log{}, nil).Once()
+	queued, err := f.svc.RequestExport(ctx, f.userID, dataexport.Requester{Type: audit.ActorUser, ID: f.userID.String()})
+	require.NoError(t, err)
+
+	_, err = f.svc.RunNext(ctx)
+	require.NoError(t, err)
+
+	job, err := f.svc.GetExport(ctx, f.userID, queued.ID)
+	require.NoError(t, err)
+	require.Equal(t, dataexport.StatusCompleted, job.Status)
+	assert.Equal(t, "data-exports/"+f.userID.String()+"/"+job.ID.String()+".zip", *job.ObjectKey)
+	assert.Equal(t, f.now.Add(DefaultDataExportRetention), *job.ExpiresAt)
+	assert.Equal(t, 2, f.repo.pages, "audit logs are read page by page")
+
+	archive := f.readArchive(t, job)
+	assert.Equal(t, int64(len(archive)), *job.SizeBytes)
+	manifest, err := dataexport.VerifyArchive(bytes.NewReader(archive), int64(len(archive)), f.svc.PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, job.ID, manifest.JobID)
+
+	names := map[string]int64{}
+	for _, file := range manifest.Files {
+		names[file.Name] = file.SizeBytes
+	}
+	for _, name := range []string{"profile.json", "profile.csv", "sessions.json", "kyc_cases.csv", "audit_logs.json", "audit_logs.csv"} {
+		assert.Contains(t, names, name)
+	}
+	assert.Equal(t, int64(len("%PDF-1.4 passport")), names[dataexport.DocumentPath(doc)])
+	assert.NotContains(t, string(archive), "argon2id")
+	f.auditRepo.AssertExpectations(t)
+
+	t.Run("nothing left to run", func(t *testing.T) {
+		job, err := f.svc.RunNext(ctx)
+		assert.NoError(t, err)
+		assert.Nil(t, job)
+	})
+}
+
+func TestDataExportService_FailsWhenDataCannotBeRead(t *testing.T) {
+	ctx := context.Background()
+	f := newDataExportFixture(t)
+	expectAudited(f.auditRepo, EventDataExportRequested, EventDataExportFailed, EventDataExportRequested)
+	queued, err := f.svc.RequestExport(ctx, f.userID, dataexport.Requester{Type: audit.ActorUser, ID: f.userID.String()})
+	require.NoError(t, err)
+
+	_, err = f.svc.RunNext(ctx)
+	require.Error(t, err)
+
+	job, err := f.svc.GetExport(ctx, f.userID, queued.ID)
+	require.NoError(t, err)
+	assert.Equal(t, dataexport.StatusFailed, job.Status)
+	require.NotNil(t, job.LastError)
+
+	_, err = f.svc.RequestExport(ctx, f.userID, dataexport.Requester{Type: audit.ActorUser, ID: f.userID.String()})
+	assert.NoError(t, err, "a failed export can be requested again")
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestDataExportService_DownloadLinks(t *testing.T) {
+	ctx := context.Background()
+	f := newDataExportFixture(t)
+	f.seed(t, 1)
+	expectAudited(f.auditRepo, EventDataExportRequested, EventDataExportCompleted,
+		EventDataExportLinkCreated, EventDataExportDownloaded, EventDataExportLinkCreated)
+	queued, err := f.svc.RequestExport(ctx, f.userID, dataexport.Requester{Type: audit.ActorUser, ID: f.userID.String()})
+	require.NoError(t, err)
+
+	_, err = f.svc.CreateDownloadLink(ctx, f.userID, queued.ID)
+	assert.ErrorIs(t, err, dataexport.ErrNotReady)
+
+	_, err = f.svc.RunNext(ctx)
+	require.NoError(t, err)
+
+	_, err = f.svc.CreateDownloadLink(ctx, uuid.New(), queued.ID)
+	assert.ErrorIs(t, err, dataexport.ErrJobNotFound, "exports of other users are hidden")
+
+	first, err := f.svc.CreateDownloadLink(ctx, f.userID, queued.ID)
+	require.NoError(t, err)
+	assert.Equal(t, f.now.Add(DefaultDataExportLinkTTL), first.ExpiresAt)
+
+	job, content, err := f.svc.OpenDownload(ctx, first.Token)
+	require.NoError(t, err)
+	downloaded, err := io.ReadAll(content)
+	require.NoError(t, err)
+	content.Close()
+	assert.Equal(t, f.readArchive(t, job), downloaded)
+	assert.Equal(t, 1, f.repo.find(queued.ID).DownloadCount)
+
+	second, err := f.svc.CreateDownloadLink(ctx, f.userID, queued.ID)
+	require.NoError(t, err)
+	_, _, err = f.svc.OpenDownload(ctx, first.Token)
+	assert.ErrorIs(t, err, dataexport.ErrInvalidDownloadToken, "a new link invalidates earlier ones")
+	_, _, err = f.svc.OpenDownload(ctx, "")
+	assert.ErrorIs(t, err, dataexport.ErrInvalidDownloadToken)
+
+	f.now = second.ExpiresAt
+	_, _, err = f.svc.OpenDownload(ctx, second.Token)
+	assert.ErrorIs(t, err, dataexport.ErrInvalidDownloadToken, "links are short-lived")
+	f.auditRepo.AssertExpectations(t)
+
+	t.Run("links never outlive the archive", func(t *testing.T) {
+		f.now = job.ExpiresAt.Add(-time.Minute)
+		expectAudited(f.auditRepo, EventDataExportLinkCreated)
+		link, err := f.svc.CreateDownloadLink(ctx, f.userID, queued.ID)
+		require.NoError(t, err)
+		assert.Equal(t, *job.ExpiresAt, link.ExpiresAt)
+		f.auditRepo.AssertExpectations(t)
+	})
+}
+
+func TestDataExportService_PurgeExpired(t *testing.T) {
+	ctx := context.Background()
+	f := newDataExportFixture(t)
+	f.seed(t, 1)
+	expectAudited(f.auditRepo, EventDataExportRequested, EventDataExportCompleted, EventDataExportLinkCreated)
+	queued, err := f.svc.RequestExport(ctx, f.userID, dataexport.Requester{Type: audit.ActorUser, ID: f.userID.String()})
+	require.NoError(t, err)
+	_, err = f.svc.RunNext(ctx)
+	require.NoError(t, err)
+	link, err := f.svc.CreateDownloadLink(ctx, f.userID, queued.ID)
+	require.NoError(t, err)
+
+	purged, err := f.svc.PurgeExpired(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+
+	f.now = f.now.Add(DefaultDataExportRetention + time.Minute)
+	purged, err = f.svc.PurgeExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	job := f.repo.find(queued.ID)
+	assert.Equal(t, dataexport.StatusExpired, job.Status)
+	exists, err := f.store.Exists(ctx, *job.ObjectKey)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, _, err = f.svc.OpenDownload(ctx, link.Token)
+	assert.ErrorIs(t, err, dataexport.ErrInvalidDownloadToken)
+	_, err = f.svc.CreateDownloadLink(ctx, f.userID, queued.ID)
+	assert.ErrorIs(t, err, dataexport.ErrExpired)
+	f.auditRepo.AssertExpectations(t)
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/erasure"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+)
+
+// Audit events recorded for erasures
+const (
+	EventUserErasureDeferred = "user.erasure_deferred"
+	EventUserErased          = "user.erased"
+)
+
+const (
+	// DefaultErasureCoolingOff is how long a deleted account waits before it is erased
+	DefaultErasureCoolingOff = 30 * 24 * time.Hour
+	// DefaultErasureAMLRetention is how long KYC and screening records are kept after an
+	// account is deleted (5 years, as required by AMLD5 Art. 40)
+	DefaultErasureAMLRetention = 5 * 365 * 24 * time.Hour
+
+	// defaultErasureBatchSize applies when the config leaves the batch size unset
+	defaultErasureBatchSize = 50
+	// erasureHoldRecheck is how long an erasure blocked by a legal hold waits before the
+	// holds are checked again
+	erasureHoldRecheck = 24 * time.Hour
+)
+
+// ErasureJob periodically erases deleted users once their cooling-off period has passed
+// (GDPR Art. 17). A user is erased unless a legal hold names them or covers their audit
+// history, or their KYC and screening records are still within the AML retention period;
+// such erasures are deferred and retried later. Erasing deletes the user's KYC documents
+// and export archives from the object store and rewrites their entries in archived audit
+// months, then pseudonymises the user, destroys their data key, rewrites their audit history
+// and deletes their outbox messages and webhook deliveries in one transaction (see
+// erasure.Repository).
+type ErasureJob struct {
+	repo         erasure.Repository
+	users        userDomain.Repository
+	holds        audit.LegalHoldChecker
+	store        common.ObjectStore
+	archives     *AuditArchiveService
+	auditRepo    audit.Repository
+	logger       *observability.Logger
+	interval     time.Duration
+	batchSize    int32
+	coolingOff   time.Duration
+	amlRetention time.Duration
+	now          func() time.Time
+	stopChan     chan struct{}
+	doneChan     chan struct{}
+}
+
+// NewErasureJob creates a new erasure job
+//
+// Parameters:
+//   - repo: Erasure records and the erasure itself
+//   - users: Reads the decrypted PII of the users being erased
+//   - holds: Legal holds that defer erasure
+//   - store: Object store holding KYC documents and export archives
+//   - auditRepo: Audit log receiving deferrals and erasures
+//   - logger: Structured logger
+//   - interval: How often due erasures are looked for
+//   - batchSize: How many users are considered per batch
+func NewErasureJob(
+	repo erasure.Repository,
+	users userDomain.Repository,
+	holds audit.LegalHoldChecker,
+	store common.ObjectStore,
+	auditRepo audit.Repository,
+	logger *observability.Logger,
+	interval time.Duration,
+	batchSize int32,
+) *ErasureJob {
+	if batchSize <= 0 {
+		batchSize = defaultErasureBatchSize
+	}
+	return &ErasureJob{
+		repo:         repo,
+		users:        users,
+		holds:        holds,
+		store:        store,
+		auditRepo:    auditRepo,
+		logger:       logger,
+		interval:     interval,
+		batchSize:    batchSize,
+		coolingOff:   DefaultErasureCoolingOff,
+		amlRetention: DefaultErasureAMLRetention,
+		now:          time.Now,
+		stopChan:     make(chan struct{}),
+		doneChan:     make(chan struct{}),
+	}
+}
+
+// WithCoolingOff sets how long a deleted account waits before it is erased
+func (j *ErasureJob) WithCoolingOff(coolingOff time.Duration) *ErasureJob {
+	if coolingOff >= 0 {
+		j.coolingOff = coolingOff
+	}
+	return j
+}
+
+// WithAMLRetention sets how long KYC and screening records are kept after deletion;
+// 0 erases them with the rest of the user's data
+func (j *ErasureJob) WithAMLRetention(retention time.Duration) *ErasureJob {
+	if retention >= 0 {
+		j.amlRetention = retention
+	}
+	return j
+}
+
+// WithArchives makes erasure also rewrite the user's entries in archived audit months, from
+// the month they registered on. Archived months are then covered by the legal hold check too.
+func (j *ErasureJob) WithArchives(archives *AuditArchiveService) *ErasureJob {
+	j.archives = archives
+	return j
+}
+
+// Start begins the periodic erasure job
+// Runs in a goroutine and can be stopped with Stop()
+func (j *ErasureJob) Start(ctx context.Context) {
+	j.logger.WithFields(map[string]interface{}{
+		"interval":    j.interval.String(),
+		"cooling_off": j.coolingOff.String(),
+	}).Info("Starting erasure job")
+
+	ticker := time.NewTicker(j.interval)
+
+	go func() {
+		defer close(j.doneChan)
+		defer ticker.Stop()
+
+		// Run immediately on start without delaying startup
+		if _, err := j.RunOnce(ctx); err != nil {
+			j.logger.WithError(err).Error("Initial erasure run failed")
+		}
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := j.RunOnce(ctx); err != nil {
+					j.logger.WithError(err).Error("Scheduled erasure run failed")
+				}
+			case <-j.stopChan:
+				j.logger.Info("Erasure job stopped")
+				return
+			case <-ctx.Done():
+				j.logger.Info("Erasure job context cancelled")
+				return
+			}
+		}
+	}()
+}
+
+// Stop gracefully stops the erasure job
+func (j *ErasureJob) Stop() {
+	j.logger.Info("Stopping erasure job")
+	close(j.stopChan)
+	<-j.doneChan
+	j.logger.Info("Erasure job stopped successfully")
+}
+
+// RunOnce processes due erasures batch by batch until none are left or ctx is done,
+// returning the number of users erased. Every due user is either erased or deferred, so
+// each batch makes progress; a user that fails is logged and retried on the next run.
+func (j *ErasureJob) RunOnce(ctx context.Context) (int, error) {
+	startTime := time.Now()
+	erased, deferred, failed := 0, 0, 0
+	var lastErr error
+
+	for {
+		select {
+		case <-j.stopChan:
+			return erased, nil
+		default:
+		}
+		if err := ctx.Err(); err != nil {
+			return erased, err
+		}
+
+		now := j.now()
+		candidates, err := j.repo.ListDue(ctx, now.Add(-j.coolingOff), now, j.batchSize)
+		if err != nil {
+			return erased, fmt.Errorf("failed to list users due for erasure: %w", err)
+		}
+
+		progressed := false
+		for _, candidate := range candidates {
+			done, err := j.process(ctx, candidate, now)
+			switch {
+			case err != nil:
+				failed++
+				lastErr = err
+				j.logger.WithError(err).WithField("user_id", candidate.UserID.String()).Error("Failed to erase user")
+			case done:
+				erased++
+				progressed = true
+			default:
+				deferred++
+				progressed = true
+			}
+		}
+		// A batch where every user failed would come back unchanged
+		if len(candidates) < int(j.batchSize) || !progressed {
+			break
+		}
+	}
+
+	if erased > 0 || deferred > 0 || failed > 0 {
+		j.logger.WithFields(map[string]interface{}{
+			"erased":      erased,
+			"deferred":    deferred,
+			"failed":      failed,
+			"duration_ms": time.Since(startTime).Milliseconds(),
+		}).Info("Erasure run completed")
+	}
+	if failed > 0 {
+		return erased, fmt.Errorf("failed to erase %d users: %w", failed, lastErr)
+	}
+	return erased, nil
+}
+
+// process erases a due user or defers their erasure, reporting whether they were erased
+func (j *ErasureJob) process(ctx context.Context, candidate erasure.Candidate, now time.Time) (bool, error) {
+	profile, err := j.users.GetByIDIncludeDeleted(ctx, candidate.UserID)
+	if err != nil {
+		return false, fmt.Errorf("failed to read user: %w", err)
+	}
+	subject := erasure.NewSubject(profile)
+
+	onHold, err := j.isOnHold(ctx, subject, profile.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+	if onHold {
+		return false, j.deferErasure(ctx, subject, erasure.ReasonLegalHold, now.Add(erasureHoldRecheck))
+	}
+
+	if retainUntil := candidate.DeletedAt.Add(j.amlRetention); j.amlRetention > 0 && now.Before(retainUntil) {
+		retained, err := j.repo.HasRetainedRecords(ctx, subject.UserID)
+		if err != nil {
+			return false, err
+		}
+		if retained {
+			return false, j.deferErasure(ctx, subject, erasure.ReasonRetention, retainUntil)
+		}
+	}
+
+	keys, err := j.repo.ObjectKeys(ctx, subject.UserID)
+	if err != nil {
+		return false, err
+	}
+	for _, key := range keys {
+		if err := j.store.Delete(ctx, key); err != nil {
+			return false, fmt.Errorf("failed to delete object: %w", err)
+		}
+	}
+
+	// Rewritten before the database, whose PII the subject is built from
+	var archivedRewritten int64
+	if j.archives != nil {
+		archivedRewritten, err = j.archives.RewriteArchives(ctx, profile.CreatedAt, func(log *audit.Log) bool {
+			return subject.IsAbout(log) && subject.RewriteLog(log)
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to rewrite audit archives: %w", err)
+		}
+	}
+
+	event := userDomain.NewTypedEvent(subject.UserID, userDomain.ErasedPayload{
+		ErasedAt:  now,
+		Pseudonym: subject.Pseudonym,
+	})
+	record, err := j.repo.Erase(ctx, subject, now, event)
+	if err != nil {
+		if errors.Is(err, erasure.ErrAlreadyErased) || errors.Is(err, erasure.ErrNotDeleted) {
+			// Erased by another instance, or restored, since it was listed
+			return false, nil
+		}
+		return false, err
+	}
+
+	j.record(ctx, EventUserErased, "erase", subject, map[string]interface{}{
+		"pseudonym":               subject.Pseudonym,
+		"audit_logs_rewritten":    record.AuditLogsRewritten,
+		"archived_logs_rewritten": archivedRewritten,
+		"objects_deleted":         len(keys),
+	})
+	j.logger.WithFields(map[string]interface{}{
+		"user_id":              subject.UserID.String(),
+		"audit_logs_rewritten": record.AuditLogsRewritten,
+	}).Info("User erased")
+	return true, nil
+}
+
+// isOnHold reports whether an active legal hold names the user or covers an audit entry about
+// them. With archives, holds on the months archived since registered count too, as those
+// months are rewritten as well.
+func (j *ErasureJob) isOnHold(ctx context.Context, subject *erasure.Subject, registered time.Time) (bool, error) {
+	if j.holds == nil {
+		return false, nil
+	}
+	onHold, err := j.holds.IsUserOnHold(ctx, subject.UserID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check legal holds: %w", err)
+	}
+	if onHold {
+		return true, nil
+	}
+	var archivedSince *time.Time
+	if j.archives != nil {
+		archivedSince = &registered
+	}
+	return j.repo.IsHistoryOnHold(ctx, subject, archivedSince)
+}
+
+// deferErasure records that a user's erasure waits until until
+func (j *ErasureJob) deferErasure(ctx context.Context, subject *erasure.Subject, reason erasure.DeferralReason, until time.Time) error {
+	if _, err := j.repo.Defer(ctx, subject.UserID, reason, until); err != nil {
+		if errors.Is(err, erasure.ErrAlreadyErased) {
+			return nil
+		}
+		return err
+	}
+	j.record(ctx, EventUserErasureDeferred, "defer_erasure", subject, map[string]interface{}{
+		"reason":         string(reason),
+		"deferred_until": until.UTC().Format(time.RFC3339),
+	})
+	return nil
+}
+
+// record audits an erasure step under the user's ID, the only identifier erasure leaves
+func (j *ErasureJob) record(ctx context.Context, eventType, action string, subject *erasure.Subject, metadata map[string]interface{}) {
+	resourceType := "user"
+	resourceID := subject.UserID.String()
+	actor := "erasure-job"
+
+	entry := &audit.Log{
+		EventType:       eventType,
+		EventCategory:   audit.CategoryCompliance,
+		Severity:        audit.SeverityInfo,
+		ActorType:       audit.ActorSystem,
+		ActorIdentifier: &actor,
+		Action:          action,
+		ResourceType:    &resourceType,
+		ResourceID:      &resourceID,
+		Metadata:        metadata,
+		Status:          audit.StatusSuccess,
+	}
+	if _, err := j.auditRepo.Create(ctx, entry); err != nil {
+		j.logger.WithError(err).WithField("user_id", resourceID).Error("Failed to record erasure in audit log")
+	}
+}
@@ -0,0 +1,420 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/erasure"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryErasures is an in-memory erasure.Repository over a set of deleted users
+type memoryErasures struct {
+	mu         sync.Mutex
+	deleted    map[uuid.UUID]time.Time
+	records    map[uuid.UUID]*erasure.Record
+	retained   map[uuid.UUID]bool
+	objectKeys map[uuid.UUID][]string
+	auditLogs  []*audit.Log
+	holds      []*audit.LegalHold
+	events     []common.EventEnvelope
+	eraseErr   error
+
+	archivedSince *time.Time
+}
+
+func newMemoryErasures() *memoryErasures {
+	return &memoryErasures{
+		deleted:    make(map[uuid.UUID]time.Time),
+		records:    make(map[uuid.UUID]*erasure.Record),
+		retained:   make(map[uuid.UUID]bool),
+		objectKeys: make(map[uuid.UUID][]string),
+	}
+}
+
+func (r *memoryErasures) ListDue(ctx context.Context, deletedBefore, now time.Time, limit int32) ([]erasure.Candidate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []erasure.Candidate
+	for id, deletedAt := range r.deleted {
+		if deletedAt.After(deletedBefore) {
+			continue
+		}
+		if rec, ok := r.records[id]; ok && (rec.Status == erasure.StatusErased || rec.DeferredUntil.After(now)) {
+			continue
+		}
+		due = append(due, erasure.Candidate{UserID: id, DeletedAt: deletedAt})
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].DeletedAt.Before(due[j].DeletedAt) })
+	if len(due) > int(limit) {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (r *memoryErasures) Get(ctx context.Context, userID uuid.UUID) (*erasure.Record, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, ok := r.records[userID]
+	if !ok {
+		return nil, erasure.ErrNotFound
+	}
+	return rec, nil
+}
+
+func (r *memoryErasures) Defer(ctx context.Context, userID uuid.UUID, reason erasure.DeferralReason, until time.Time) (*erasure.Record, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rec, ok := r.records[userID]; ok && rec.Status == erasure.StatusErased {
+		return nil, erasure.ErrAlreadyErased
+	}
+	rec := &erasure.Record{UserID: userID, Status: erasure.StatusDeferred, DeferralReason: &reason, DeferredUntil: &until}
+	r.records[userID] = rec
+	return rec, nil
+}
+
+func (r *memoryErasures) HasRetainedRecords(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return r.retained[userID], nil
+}
+
+// IsHistoryOnHold matches the active holds against the audit logs about the subject and
+// keeps archivedSince for the test to check
+func (r *memoryErasures) IsHistoryOnHold(ctx context.Context, subject *erasure.Subject, archivedSince *time.Time) (bool, error) {
+	r.archivedSince = archivedSince
+	for _, h := range r.holds {
+		for _, l := range r.auditLogs {
+			if subject.IsAbout(l) && h.Matches(l) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryErasures) ObjectKeys(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	return r.objectKeys[userID], nil
+}
+
+func (r *memoryErasures) Erase(ctx context.Context, subject *erasure.Subject, at time.Time, event common.EventEnvelope) (*erasure.Record, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.eraseErr != nil {
+		return nil, r.eraseErr
+	}
+	if _, ok := r.deleted[subject.UserID]; !ok {
+		return nil, erasure.ErrNotDeleted
+	}
+	if rec, ok := r.records[subject.UserID]; ok && rec.Status == erasure.StatusErased {
+		return nil, erasure.ErrAlreadyErased
+	}
+	var rewritten int64
+	for _, l := range r.auditLogs {
+		if subject.RewriteLog(l) {
+			rewritten++
+		}
+	}
+	rec := &erasure.Record{UserID: subject.UserID, Status: erasure.StatusErased, Pseudonym: &subject.Pseudonym, AuditLogsRewritten: rewritten, ErasedAt: &at}
+	r.records[subject.UserID] = rec
+	r.events = append(r.events, event)
+	return rec, nil
+}
+
+// erasureFixture is an erasure job over in-memory deleted users with a fixed clock
+type erasureFixture struct {
+	job       *ErasureJob
+	repo      *memoryErasures
+	users     map[uuid.UUID]*user.User
+	holds     *mocks.MockLegalHoldRepository
+	auditRepo *mocks.MockAuditRepository
+	store     *storage.LocalObjectStore
+	now       time.Time
+}
+
+func newErasureFixture(t *testing.T) *erasureFixture {
+	t.Helper()
+	f := &erasureFixture{
+		repo:      newMemoryErasures(),
+		users:     make(map[uuid.UUID]*user.User),
+		holds:     new(mocks.MockLegalHoldRepository),
+		auditRepo: newStrictAuditRepo(t),
+		store:     newTestObjectStore(t),
+		now:       time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC),
+	}
+	users := newUserDirectory(t, f.users)
+
+	f.job = NewErasureJob(f.repo, users, f.holds, f.store, f.auditRepo, observability.NewLogger("dev", "test-service"), time.Hour, 10).
+		WithCoolingOff(30 * 24 * time.Hour).
+		WithAMLRetention(365 * 24 * time.Hour)
+	f.job.now = func() time.Time { return f.now }
+	return f
+}
+
+// deleteUser adds a user registered 90 days before being deleted age ago, with one audit log
+// of their own login
+func (f *erasureFixture) deleteUser(age time.Duration) uuid.UUID {
+	id := uuid.New()
+	deletedAt := f.now.Add(-age)
+	f.repo.deleted[id] = deletedAt
+	f.users[id] = &user.User{ID: id, Email: "alice@example.com", FirstName: "Alice", LastName: "Smith", CreatedAt: deletedAt.Add(-90 * 24 * time.Hour), DeletedAt: &deletedAt}
+	email := "alice@example.com"
+	ip := "203.0.113.77"
+	f.repo.auditLogs = append(f.repo.auditLogs, &audit.Log{
+		ID:              uuid.New(),
+		EventType:       "user.login",
+		UserID:          &id,
+		ActorType:       audit.ActorUser,
+		ActorIdentifier: &email,
+		IPAddress:       &ip,
+		CreatedAt:       f.now.Add(-age - time.Hour),
+	})
+	return id
+}
+
+// expectAudit expects the job to audit eventType once for user id, with metadata holding
+// the given entries
+func (f *erasureFixture) expectAudit(eventType string, id uuid.UUID, metadata map[string]interface{}) {
+	f.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		if l.EventType != eventType || l.ActorType != audit.ActorSystem || *l.ResourceID != id.String() || l.UserID != nil {
+			return false
+		}
+		for key, value := range metadata {
+			if l.Metadata[key] != value {
+				return false
+			}
+		}
+		return true
+	})).Return(&audit.Log{}, nil).Once()
+}
+
+func TestErasureJob_ErasesAfterCoolingOff(t *testing.T) {
+	f := newErasureFixture(t)
+	due := f.deleteUser(31 * 24 * time.Hour)
+	cooling := f.deleteUser(5 * 24 * time.Hour)
+	f.holds.On("IsUserOnHold", mock.Anything, due).Return(false, nil)
+
+	docKey := "kyc-documents/" + due.String() + "/passport"
+	require.NoError(t, f.store.Put(context.Background(), docKey, strings.NewReader("%PDF-1.4")))
+	f.repo.objectKeys[due] = []string{docKey}
+	f.expectAudit(EventUserErased, due, map[string]interface{}{"pseudonym": erasure.Pseudonym(due), "objects_deleted": 1})
+
+	erased, err := f.job.RunOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, erased)
+	assert.Equal(t, erasure.StatusErased, f.repo.records[due].Status)
+	assert.NotContains(t, f.repo.records, cooling, "still in its cooling-off period")
+
+	exists, err := f.store.Exists(context.Background(), docKey)
+	require.NoError(t, err)
+	assert.False(t, exists, "KYC documents are deleted from the object store")
+
+	require.Len(t, f.repo.events, 1)
+	assert.Equal(t, string(user.EventTypeUserErased), f.repo.events[0].EventType())
+	assert.Equal(t, erasure.Pseudonym(due), f.repo.events[0].EventPayload()["pseudonym"])
+
+	login := f.repo.auditLogs[0]
+	assert.Equal(t, erasure.Pseudonym(due), *login.ActorIdentifier)
+	assert.Equal(t, "203.0.113.0", *login.IPAddress)
+
+	erased, err = f.job.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, erased, "erased users are not erased again")
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestErasureJob_DefersUnderLegalHold(t *testing.T) {
+	t.Run("hold names the user", func(t *testing.T) {
+		f := newErasureFixture(t)
+		id := f.deleteUser(40 * 24 * time.Hour)
+		f.holds.On("IsUserOnHold", mock.Anything, id).Return(true, nil)
+		f.expectAudit(EventUserErasureDeferred, id, map[string]interface{}{"reason": string(erasure.ReasonLegalHold)})
+
+		erased, err := f.job.RunOnce(context.Background())
+
+		require.NoError(t, err)
+		assert.Zero(t, erased)
+		rec := f.repo.records[id]
+		assert.Equal(t, erasure.StatusDeferred, rec.Status)
+		assert.Equal(t, erasure.ReasonLegalHold, *rec.DeferralReason)
+		assert.Equal(t, f.now.Add(erasureHoldRecheck), *rec.DeferredUntil)
+		assert.Empty(t, f.repo.events)
+		f.auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("hold covers the audit history", func(t *testing.T) {
+		f := newErasureFixture(t)
+		id := f.deleteUser(40 * 24 * time.Hour)
+		f.holds.On("IsUserOnHold", mock.Anything, id).Return(false, nil)
+		category := audit.CategoryAuthentication
+		f.repo.auditLogs[0].EventCategory = category
+		f.repo.holds = []*audit.LegalHold{{EventCategory: &category, StartsAt: &f.repo.auditLogs[0].CreatedAt}}
+		f.expectAudit(EventUserErasureDeferred, id, map[string]interface{}{"reason": string(erasure.ReasonLegalHold)})
+
+		_, err := f.job.RunOnce(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, erasure.ReasonLegalHold, *f.repo.records[id].DeferralReason)
+		f.auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("hold on another user does not defer", func(t *testing.T) {
+		f := newErasureFixture(t)
+		id := f.deleteUser(40 * 24 * time.Hour)
+		other := uuid.New()
+		bob := "bob@example.com"
+		f.repo.auditLogs = append(f.repo.auditLogs, &audit.Log{
+			ID: uuid.New(), EventType: "user.login", UserID: &other, ActorType: audit.ActorUser, ActorIdentifier: &bob, CreatedAt: f.now,
+		})
+		f.repo.holds = []*audit.LegalHold{{UserID: &other}}
+		f.holds.On("IsUserOnHold", mock.Anything, id).Return(false, nil)
+		f.expectAudit(EventUserErased, id, nil)
+
+		erased, err := f.job.RunOnce(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, erased)
+		assert.Nil(t, f.repo.archivedSince, "without archives only logs in the database are checked")
+		f.auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("deferred users are retried once the deferral ends", func(t *testing.T) {
+		f := newErasureFixture(t)
+		id := f.deleteUser(40 * 24 * time.Hour)
+		f.holds.On("IsUserOnHold", mock.Anything, id).Return(true, nil).Once()
+		f.holds.On("IsUserOnHold", mock.Anything, id).Return(false, nil)
+		f.expectAudit(EventUserErasureDeferred, id, nil)
+		f.expectAudit(EventUserErased, id, nil)
+
+		_, err := f.job.RunOnce(context.Background())
+		require.NoError(t, err)
+		f.now = f.now.Add(erasureHoldRecheck)
+		erased, err := f.job.RunOnce(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, erased)
+		assert.Equal(t, erasure.StatusErased, f.repo.records[id].Status)
+		f.auditRepo.AssertExpectations(t)
+	})
+}
+
+func TestErasureJob_DefersForAMLRetention(t *testing.T) {
+	f := newErasureFixture(t)
+	retained := f.deleteUser(40 * 24 * time.Hour)
+	expired := f.deleteUser(400 * 24 * time.Hour)
+	f.repo.retained[retained] = true
+	f.repo.retained[expired] = true
+	f.holds.On("IsUserOnHold", mock.Anything, mock.Anything).Return(false, nil)
+	f.expectAudit(EventUserErasureDeferred, retained, map[string]interface{}{"reason": string(erasure.ReasonRetention)})
+	f.expectAudit(EventUserErased, expired, nil)
+
+	erased, err := f.job.RunOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, erased)
+	assert.Equal(t, erasure.StatusErased, f.repo.records[expired].Status, "retention period has passed")
+	rec := f.repo.records[retained]
+	assert.Equal(t, erasure.ReasonRetention, *rec.DeferralReason)
+	assert.Equal(t, f.repo.deleted[retained].Add(365*24*time.Hour), *rec.DeferredUntil)
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestErasureJob_ReportsFailures(t *testing.T) {
+	f := newErasureFixture(t)
+	f.deleteUser(40 * 24 * time.Hour)
+	f.holds.On("IsUserOnHold", mock.Anything, mock.Anything).Return(false, nil)
+	f.repo.eraseErr = errors.New("connection reset")
+
+	erased, err := f.job.RunOnce(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection reset")
+	assert.Zero(t, erased)
+	f.auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestErasureJob_RewritesArchivedAuditMonths(t *testing.T) {
+	ctx := context.Background()
+
+	// withArchive attaches archives holding one archived month with a login of id and one of
+	// another user, and returns the month's archive
+	withArchive := func(t *testing.T, f *erasureFixture, id uuid.UUID) (*mocks.MockAuditPartitionRepository, *audit.Archive) {
+		partitionRepo := new(mocks.MockAuditPartitionRepository)
+		archives := NewAuditArchiveService(partitionRepo, f.store, observability.NewLogger("dev", "test-service"), 90, 3, "")
+		f.job.WithArchives(archives)
+
+		month := audit.MonthStart(f.now.AddDate(0, -2, 0))
+		other := uuid.New()
+		alice, bob := "alice@example.com", "bob@example.com"
+		logs := testArchiveLogs(month, 2)
+		logs[0].UserID, logs[0].ActorIdentifier = &id, &alice
+		logs[1].UserID, logs[1].ActorIdentifier = &other, &bob
+		archive := putTestArchive(t, archives, partitionRepo, month, logs)
+		return partitionRepo, archive
+	}
+
+	t.Run("archived entries are rewritten before the database", func(t *testing.T) {
+		f := newErasureFixture(t)
+		id := f.deleteUser(31 * 24 * time.Hour)
+		partitionRepo, archive := withArchive(t, f, id)
+		registered := f.users[id].CreatedAt
+		f.holds.On("IsUserOnHold", mock.Anything, id).Return(false, nil)
+
+		var replaced *audit.Archive
+		partitionRepo.On("ListArchives", mock.Anything, int32(archiveListPageSize), int32(0)).Return([]*audit.Archive{archive}, nil).Once()
+		partitionRepo.On("ReplaceArchiveObject", mock.Anything, mock.Anything, archive.ObjectKey).Run(func(args mock.Arguments) {
+			replaced = args.Get(1).(*audit.Archive)
+		}).Return(func(_ context.Context, a *audit.Archive) *audit.Archive { return a }, nil).Once()
+		f.expectAudit(EventUserErased, id, map[string]interface{}{"archived_logs_rewritten": int64(1)})
+
+		erased, err := f.job.RunOnce(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, erased)
+		f.holds.AssertExpectations(t)
+		partitionRepo.AssertExpectations(t)
+		f.auditRepo.AssertExpectations(t)
+		require.NotNil(t, f.repo.archivedSince)
+		assert.Equal(t, registered, *f.repo.archivedSince, "holds on months archived since registration are checked")
+
+		require.NotNil(t, replaced)
+		manifest, err := f.job.archives.readManifest(ctx, replaced.ManifestKey)
+		require.NoError(t, err)
+		var actors []string
+		require.NoError(t, f.job.archives.scanArchive(ctx, replaced.ObjectKey, manifest, func(log *audit.Log) error {
+			actors = append(actors, *log.ActorIdentifier)
+			return nil
+		}))
+		assert.Equal(t, []string{erasure.Pseudonym(id), "bob@example.com"}, actors)
+	})
+
+	t.Run("failed archive rewrite leaves the user unerased", func(t *testing.T) {
+		f := newErasureFixture(t)
+		id := f.deleteUser(31 * 24 * time.Hour)
+		partitionRepo, archive := withArchive(t, f, id)
+		f.holds.On("IsUserOnHold", mock.Anything, id).Return(false, nil)
+		partitionRepo.On("ListArchives", mock.Anything, int32(archiveListPageSize), int32(0)).Return([]*audit.Archive{archive}, nil).Once()
+		partitionRepo.On("ReplaceArchiveObject", mock.Anything, mock.Anything, archive.ObjectKey).Return(nil, audit.ErrArchiveChanged).Once()
+
+		erased, err := f.job.RunOnce(ctx)
+
+		assert.ErrorIs(t, err, audit.ErrArchiveChanged)
+		assert.Zero(t, erased)
+		assert.NotContains(t, f.repo.records, id, "retried on the next run")
+		assert.Empty(t, f.repo.events)
+		f.auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/google/uuid"
+)
+
+// Audit events recorded for external verification
+const (
+	EventKYCVerificationStarted = "kyc.case.verification_started"
+	EventKYCProviderDecision    = "kyc.case.provider_decision"
+)
+
+// DefaultKYCProviderTimeout bounds each call to the external provider when none is configured
+const DefaultKYCProviderTimeout = 15 * time.Second
+
+// WithProvider enables external verification with provider. timeout bounds each call to the
+// provider (defaults to DefaultKYCProviderTimeout when zero).
+func (s *KYCService) WithProvider(provider kyc.Provider, timeout time.Duration) *KYCService {
+	if timeout <= 0 {
+		timeout = DefaultKYCProviderTimeout
+	}
+	s.provider = provider
+	s.providerTimeout = timeout
+	return s
+}
+
+// StartVerification registers the case's applicant with the provider, submits the case and
+// returns the provider's verification link. Calling it again for a case already waiting on
+// the provider returns a fresh link.
+func (s *KYCService) StartVerification(ctx context.Context, userID, caseID uuid.UUID) (*kyc.VerificationLink, error) {
+	if s.provider == nil {
+		return nil, kyc.ErrProviderNotConfigured
+	}
+	name := s.provider.Name()
+
+	c, err := s.cases.GetByID(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+	if c.UserID != userID {
+		return nil, kyc.ErrCaseNotFound
+	}
+	if c.Provider != nil && *c.Provider != name {
+		return nil, fmt.Errorf("%w: case is verified by %s", kyc.ErrProviderNotConfigured, *c.Provider)
+	}
+
+	if c.Provider == nil || c.Status != kyc.StatusSubmitted {
+		// Check the case before registering anything with the provider
+		if !c.Status.IsEditable() {
+			return nil, fmt.Errorf("%w: case is %s", kyc.ErrCaseNotEditable, c.Status)
+		}
+		if err := c.Applicant.ValidateComplete(s.now()); err != nil {
+			return nil, err
+		}
+
+		applicantID := ""
+		if c.ProviderApplicantID != nil {
+			applicantID = *c.ProviderApplicantID
+		} else {
+			owner, err := s.users.GetByID(ctx, userID)
+			if err != nil {
+				return nil, err
+			}
+			err = s.callProvider(ctx, "create applicant", func(ctx context.Context) error {
+				applicantID, err = s.provider.CreateApplicant(ctx, kyc.ProviderApplicantRequest{
+					CaseID:    c.ID,
+					UserID:    userID,
+					Email:     owner.Email,
+					Applicant: c.Applicant,
+				})
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var transition *kyc.Transition
+		c, err = s.change(ctx, caseID, func(_ kyc.Repository, c *kyc.Case) (*kyc.Transition, error) {
+			t, err := c.StartProviderVerification(name, applicantID, s.now())
+			transition = t
+			return t, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.recordCaseAction(ctx, EventKYCVerificationStarted, "start_verification", c, audit.ActorUser, userID.String(), transitionMetadata(transition))
+		s.logger.WithFields(map[string]interface{}{
+			"case_id":  caseID.String(),
+			"provider": name,
+		}).Info("KYC verification started with provider")
+	}
+
+	var link *kyc.VerificationLink
+	err = s.callProvider(ctx, "get verification link", func(ctx context.Context) error {
+		link, err = s.provider.VerificationLink(ctx, *c.ProviderApplicantID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// HandleProviderWebhook verifies a decision webhook from provider and applies the decision
+// to the applicant's case. An approval of a user with uncleared screening matches is recorded
+// but leaves the case to admins. Decisions for unknown applicants and for cases no longer
+// awaiting a decision, such as redeliveries, are logged and acknowledged so the provider
+// stops retrying them.
+func (s *KYCService) HandleProviderWebhook(ctx context.Context, provider string, header http.Header, body []byte) error {
+	if s.provider == nil || s.provider.Name() != provider {
+		return kyc.ErrProviderNotConfigured
+	}
+	decision, err := s.provider.ParseDecision(header, body)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]interface{}{
+		"provider":          provider,
+		"provider_event_id": decision.EventID,
+		"outcome":           decision.Outcome,
+	}
+	c, err := s.cases.GetByProviderApplicant(ctx, provider, decision.ApplicantID)
+	if err != nil {
+		if errors.Is(err, kyc.ErrCaseNotFound) {
+			s.logger.WithFields(fields).Warn("Ignoring KYC provider decision for unknown applicant")
+			return nil
+		}
+		return err
+	}
+	fields["case_id"] = c.ID.String()
+
+	var transition *kyc.Transition
+	updated, err := s.change(ctx, c.ID, func(_ kyc.Repository, c *kyc.Case) (*kyc.Transition, error) {
+		hold := false
+		if decision.Outcome == kyc.ProviderApproved {
+			blocked, err := s.approvalBlocked(ctx, c.UserID)
+			if err != nil {
+				return nil, err
+			}
+			hold = blocked
+		}
+
+		var t *kyc.Transition
+		var err error
+		if hold {
+			t, err = c.HoldProviderApproval(decision, "approved by provider; held until screening matches are cleared", s.now())
+		} else {
+			t, err = c.ApplyProviderDecision(decision, s.now())
+		}
+		transition = t
+		return t, err
+	})
+	if err != nil {
+		if errors.Is(err, kyc.ErrInvalidTransition) {
+			s.logger.WithFields(fields).Info("Ignoring KYC provider decision for case no longer awaiting one")
+			return nil
+		}
+		return err
+	}
+
+	fields["from_status"] = transition.FromStatus
+	fields["to_status"] = transition.ToStatus
+	s.logger.WithFields(fields).Info("KYC case updated by provider decision")
+
+	extra := transitionMetadata(transition)
+	extra["outcome"] = decision.Outcome
+	extra["provider"] = provider
+	extra["provider_event_id"] = decision.EventID
+	s.recordCaseAction(ctx, EventKYCProviderDecision, "provider_decision", updated, audit.ActorSystem, "kyc-provider:"+provider, extra)
+	return nil
+}
+
+// GetProviderReport retrieves the configured provider's report on a case it verified
+func (s *KYCService) GetProviderReport(ctx context.Context, caseID uuid.UUID) (*kyc.ProviderReport, error) {
+	if s.provider == nil {
+		return nil, kyc.ErrProviderNotConfigured
+	}
+	c, err := s.cases.GetByID(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Provider == nil || *c.Provider != s.provider.Name() {
+		return nil, fmt.Errorf("%w: case was not verified by %s", kyc.ErrProviderNotConfigured, s.provider.Name())
+	}
+
+	var report *kyc.ProviderReport
+	err = s.callProvider(ctx, "fetch report", func(ctx context.Context) error {
+		report, err = s.provider.FetchReport(ctx, *c.ProviderApplicantID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// callProvider runs call with the provider timeout. Failures, including timeouts, are
+// reported as kyc.ErrProviderUnavailable.
+func (s *KYCService) callProvider(ctx context.Context, operation string, call func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, s.providerTimeout)
+	defer cancel()
+
+	if err := call(ctx); err != nil {
+		s.logger.WithError(err).WithFields(map[string]interface{}{
+			"provider":  s.provider.Name(),
+			"operation": operation,
+		}).Error("KYC provider call failed")
+		return fmt.Errorf("%w: %s: %v", kyc.ErrProviderUnavailable, operation, err)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/kycprovider"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// withSimulator enables external verification with a simulator that decides on demand
+func (f *kycFixture) withSimulator(t *testing.T, scenario kycprovider.Scenario) *kycprovider.Simulator {
+	t.Helper()
+	sim, err := kycprovider.NewSimulator(kycprovider.SimulatorOptions{Secret: "simulator-secret", Scenario: scenario})
+	require.NoError(t, err)
+	t.Cleanup(sim.Close)
+	f.svc.WithProvider(sim, 50*time.Millisecond)
+	return sim
+}
+
+// startVerification opens a case for the applicant and starts verification with the provider,
+// expecting the start to be audited
+func (f *kycFixture) startVerification(t *testing.T) *kyc.Case {
+	t.Helper()
+	ctx := context.Background()
+	expectAudited(f.auditRepo, EventKYCVerificationStarted)
+	c, err := f.svc.CreateCase(ctx, f.applicant.ID, kycApplicant())
+	require.NoError(t, err)
+	link, err := f.svc.StartVerification(ctx, f.applicant.ID, c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kycprovider.ProviderSimulator, link.Provider)
+
+	c, err = f.svc.GetCase(ctx, c.ID)
+	require.NoError(t, err)
+	return c
+}
+
+// deliverDecision hands the simulator's decision webhook for c to the service
+func (f *kycFixture) deliverDecision(t *testing.T, sim *kycprovider.Simulator, c *kyc.Case) {
+	t.Helper()
+	header, body, err := sim.DecisionWebhook(*c.ProviderApplicantID)
+	require.NoError(t, err)
+	require.NoError(t, f.svc.HandleProviderWebhook(context.Background(), kycprovider.ProviderSimulator, header, body))
+}
+
+func TestKYCService_ProviderApproval(t *testing.T) {
+	f := newKYCFixture(t)
+	sim := f.withSimulator(t, kycprovider.ScenarioApprove)
+
+	c := f.startVerification(t)
+	assert.Equal(t, kyc.StatusSubmitted, c.Status, "starting verification submits the case without documents")
+	require.NotNil(t, c.ProviderApplicantID)
+	assert.Equal(t, kycprovider.ProviderSimulator, *c.Provider)
+
+	again, err := f.svc.StartVerification(context.Background(), f.applicant.ID, c.ID)
+	require.NoError(t, err, "a started verification returns a fresh link")
+	assert.Contains(t, again.URL, *c.ProviderApplicantID)
+
+	f.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		return l.EventType == EventKYCProviderDecision && l.ActorType == audit.ActorSystem &&
+			*l.ActorIdentifier == "kyc-provider:simulator" && l.Metadata["outcome"] == kyc.ProviderApproved
+	})).Return(&audit.Log{}, nil).Once()
+	f.deliverDecision(t, sim, c)
+	approved, err := f.svc.GetCase(context.Background(), c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusApproved, approved.Status)
+	assert.Nil(t, approved.DecidedBy)
+	assert.Equal(t, userDomain.KYCStatusVerified, f.users[f.applicant.ID].KYCStatus)
+	require.Len(t, f.kycEvents(), 1)
+
+	// A redelivered decision is acknowledged without changing or auditing the case again
+	f.deliverDecision(t, sim, c)
+	history, err := f.svc.GetHistory(context.Background(), c.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 3)
+	f.auditRepo.AssertExpectations(t)
+
+	report, err := f.svc.GetProviderReport(context.Background(), c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.ProviderApproved, report.Outcome)
+}
+
+func TestKYCService_ProviderRejection(t *testing.T) {
+	f := newKYCFixture(t)
+	sim := f.withSimulator(t, kycprovider.ScenarioReject)
+	c := f.startVerification(t)
+	expectAudited(f.auditRepo, EventKYCProviderDecision)
+
+	f.deliverDecision(t, sim, c)
+
+	rejected, err := f.svc.GetCase(context.Background(), c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusRejected, rejected.Status)
+	assert.Equal(t, kyc.ReasonDocumentMismatch, *rejected.RejectionReason)
+	assert.Equal(t, userDomain.KYCStatusRejected, f.users[f.applicant.ID].KYCStatus)
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestKYCService_ProviderResubmission(t *testing.T) {
+	f := newKYCFixture(t)
+	sim := f.withSimulator(t, kycprovider.ScenarioResubmit)
+	c := f.startVerification(t)
+	expectAudited(f.auditRepo, EventKYCProviderDecision, EventKYCVerificationStarted, EventKYCProviderDecision)
+
+	f.deliverDecision(t, sim, c)
+	c, err := f.svc.GetCase(context.Background(), c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusNeedsMoreInfo, c.Status)
+	assert.NotNil(t, c.ReviewNote)
+
+	_, err = f.svc.StartVerification(context.Background(), f.applicant.ID, c.ID)
+	require.NoError(t, err)
+	f.deliverDecision(t, sim, c)
+	c, err = f.svc.GetCase(context.Background(), c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusApproved, c.Status, "the second attempt reuses the provider's applicant")
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestKYCService_ProviderApprovalOfHighRiskCase(t *testing.T) {
+	f := newKYCFixture(t)
+	sim := f.withSimulator(t, kycprovider.ScenarioApprove)
+	c := f.startVerification(t)
+	expectAudited(f.auditRepo, EventKYCCaseAssigned, EventKYCCaseRiskUpdated, EventKYCProviderDecision)
+	_, err := f.svc.Assign(context.Background(), c.ID, f.reviewer.ID, f.reviewer)
+	require.NoError(t, err)
+	_, err = f.svc.SetRiskLevel(context.Background(), c.ID, kyc.RiskHigh, f.reviewer)
+	require.NoError(t, err)
+
+	f.deliverDecision(t, sim, c)
+
+	c, err = f.svc.GetCase(context.Background(), c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusInReview, c.Status, "high-risk cases still need two admins")
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestKYCService_ProviderTimeout(t *testing.T) {
+	f := newKYCFixture(t)
+	f.withSimulator(t, kycprovider.ScenarioTimeout)
+	c, err := f.svc.CreateCase(context.Background(), f.applicant.ID, kycApplicant())
+	require.NoError(t, err)
+
+	_, err = f.svc.StartVerification(context.Background(), f.applicant.ID, c.ID)
+
+	assert.ErrorIs(t, err, kyc.ErrProviderUnavailable)
+	c, err = f.svc.GetCase(context.Background(), c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusDraft, c.Status)
+	assert.Nil(t, c.Provider)
+	f.auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestKYCService_ProviderWebhookValidation(t *testing.T) {
+	f := newKYCFixture(t)
+	ctx := context.Background()
+
+	err := f.svc.HandleProviderWebhook(ctx, kycprovider.ProviderSimulator, http.Header{}, []byte("{}"))
+	assert.ErrorIs(t, err, kyc.ErrProviderNotConfigured)
+	_, err = f.svc.StartVerification(ctx, f.applicant.ID, uuid.New())
+	assert.ErrorIs(t, err, kyc.ErrProviderNotConfigured)
+
+	sim := f.withSimulator(t, kycprovider.ScenarioApprove)
+	err = f.svc.HandleProviderWebhook(ctx, "acme", http.Header{}, []byte("{}"))
+	assert.ErrorIs(t, err, kyc.ErrProviderNotConfigured)
+	err = f.svc.HandleProviderWebhook(ctx, kycprovider.ProviderSimulator, http.Header{}, []byte("{}"))
+	assert.ErrorIs(t, err, kyc.ErrInvalidProviderWebhook)
+
+	// Decisions for applicants we do not know are acknowledged
+	applicantID, err := sim.CreateApplicant(ctx, kyc.ProviderApplicantRequest{CaseID: uuid.New()})
+	require.NoError(t, err)
+	header, body, err := sim.DecisionWebhook(applicantID)
+	require.NoError(t, err)
+	assert.NoError(t, f.svc.HandleProviderWebhook(ctx, kycprovider.ProviderSimulator, header, body))
+
+	c, err := f.svc.CreateCase(ctx, f.applicant.ID, kycApplicant())
+	require.NoError(t, err)
+	_, err = f.svc.StartVerification(ctx, uuid.New(), c.ID)
+	assert.ErrorIs(t, err, kyc.ErrCaseNotFound)
+	_, err = f.svc.GetProviderReport(ctx, c.ID)
+	assert.ErrorIs(t, err, kyc.ErrProviderNotConfigured, "the case was not verified by the provider")
+	f.auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestKYCService_ProviderApprovalHeldByScreening(t *testing.T) {
+	f := newKYCFixture(t)
+	sim := f.withSimulator(t, kycprovider.ScenarioApprove)
+	gate := &stubGate{blocked: map[uuid.UUID]bool{f.applicant.ID: true}, repo: f.repo}
+	f.svc.WithScreening(gate)
+
+	c := f.startVerification(t)
+	expectAudited(f.auditRepo, EventKYCProviderDecision)
+	f.deliverDecision(t, sim, c)
+
+	held, err := f.svc.GetCase(context.Background(), c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusSubmitted, held.Status, "the approval waits for the screening review")
+	assert.Equal(t, userDomain.KYCStatusPending, f.users[f.applicant.ID].KYCStatus)
+
+	history, err := f.svc.GetHistory(context.Background(), c.ID)
+	require.NoError(t, err)
+	last := history[len(history)-1]
+	assert.Equal(t, kyc.ActionProviderDecision, last.Action)
+	assert.Contains(t, *last.Note, "screening")
+	assert.Zero(t, gate.outsideTx, "screening is checked within the decision's transaction")
+	f.auditRepo.AssertExpectations(t)
+}
@@ -0,0 +1,571 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+)
+
+// Audit events recorded when admins act on KYC cases
+const (
+	EventKYCCaseAssigned      = "kyc.case.assigned"
+	EventKYCCaseRiskUpdated   = "kyc.case.risk_updated"
+	EventKYCCaseApproved      = "kyc.case.approved"
+	EventKYCCaseRejected      = "kyc.case.rejected"
+	EventKYCCaseInfoRequested = "kyc.case.info_requested"
+	EventKYCDocumentViewed    = "kyc.document.viewed"
+)
+
+const (
+	// DefaultKYCDocumentPrefix is the object store prefix for KYC documents
+	DefaultKYCDocumentPrefix = "kyc-documents"
+	// DefaultKYCMaxDocumentBytes is the upload size limit used when none is configured
+	DefaultKYCMaxDocumentBytes = 10 << 20
+
+	// sniffLength is how much of an upload is inspected to detect its content type
+	sniffLength = 512
+	// maxDocumentFileNameLength bounds stored file names
+	maxDocumentFileNameLength = 255
+)
+
+// Compile-time check to ensure KYCService implements kyc.Service
+var _ kyc.Service = (*KYCService)(nil)
+
+// KYCService runs the KYC case workflow. Every change to a case is stored together with its
+// history entry, the user's derived kyc_status and, when that status changes, a
+// user.kyc.updated event, in one transaction. Admin decisions and document views are audited.
+// It also keeps each user's KYC tier, which sets their entitlements (see kyc_tier_service.go),
+// and can hand cases to an external verification provider (see kyc_provider_service.go).
+// With screening enabled, users with uncleared sanctions or PEP matches cannot be approved.
+type KYCService struct {
+	transactor       kyc.Transactor
+	cases            kyc.Repository
+	users            userDomain.Repository
+	store            common.ObjectStore
+	auditRepo        audit.Repository
+	logger           *observability.Logger
+	prefix           string
+	maxDocumentBytes int64
+	tiers            *kyc.TierPolicy
+	provider         kyc.Provider
+	providerTimeout  time.Duration
+	screening        screening.Gate
+	now              func() time.Time
+}
+
+// NewKYCService creates a new KYC service
+//
+// Parameters:
+//   - transactor: Runs case, user status and outbox writes atomically
+//   - cases: Case reads outside transactions
+//   - users: User lookups (verification status, reviewer role)
+//   - store: Object store holding document content
+//   - auditRepo: Audit log receiving admin decisions
+//   - logger: Structured logger
+//   - prefix: Object key prefix (defaults to DefaultKYCDocumentPrefix when empty)
+//   - maxDocumentBytes: Upload size limit (defaults to DefaultKYCMaxDocumentBytes when zero)
+func NewKYCService(
+	transactor kyc.Transactor,
+	cases kyc.Repository,
+	users userDomain.Repository,
+	store common.ObjectStore,
+	auditRepo audit.Repository,
+	logger *observability.Logger,
+	prefix string,
+	maxDocumentBytes int64,
+) *KYCService {
+	if prefix == "" {
+		prefix = DefaultKYCDocumentPrefix
+	}
+	if maxDocumentBytes <= 0 {
+		maxDocumentBytes = DefaultKYCMaxDocumentBytes
+	}
+	return &KYCService{
+		transactor:       transactor,
+		cases:            cases,
+		users:            users,
+		store:            store,
+		auditRepo:        auditRepo,
+		logger:           logger,
+		prefix:           prefix,
+		maxDocumentBytes: maxDocumentBytes,
+		tiers:            kyc.DefaultTierPolicy(),
+		now:              time.Now,
+	}
+}
+
+// CreateCase opens a draft case for the user
+func (s *KYCService) CreateCase(ctx context.Context, userID uuid.UUID, applicant kyc.Applicant) (*kyc.Case, error) {
+	owner, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if owner.KYCStatus == userDomain.KYCStatusVerified {
+		return nil, kyc.ErrAlreadyVerified
+	}
+
+	c, transition, err := kyc.NewCase(userID, applicant, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	var created *kyc.Case
+	err = s.transactor.WithinTx(ctx, func(cases kyc.Repository, users userDomain.Repository, events outbox.Writer) error {
+		created, err = cases.Create(ctx, c)
+		if err != nil {
+			return err
+		}
+		transition.CaseID = created.ID
+		if err := cases.AddTransition(ctx, transition); err != nil {
+			return err
+		}
+		return s.syncUserStatus(ctx, cases, users, events, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"user_id": userID.String(),
+		"case_id": created.ID.String(),
+	}).Info("KYC case opened")
+	return created, nil
+}
+
+// GetMyCase retrieves the user's latest case with its documents
+func (s *KYCService) GetMyCase(ctx context.Context, userID uuid.UUID) (*kyc.Case, error) {
+	c, err := s.cases.GetLatestForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withDocuments(ctx, c)
+}
+
+// UpdateApplicant replaces the applicant data of the user's editable case
+func (s *KYCService) UpdateApplicant(ctx context.Context, userID, caseID uuid.UUID, applicant kyc.Applicant) (*kyc.Case, error) {
+	return s.change(ctx, caseID, func(_ kyc.Repository, c *kyc.Case) (*kyc.Transition, error) {
+		if c.UserID != userID {
+			return nil, kyc.ErrCaseNotFound
+		}
+		return nil, c.UpdateApplicant(applicant, s.now())
+	})
+}
+
+// UploadDocument checks the document's size and content type, stores its content in the
+// object store and records its metadata. Content that is not a JPEG, PNG or PDF is refused.
+func (s *KYCService) UploadDocument(ctx context.Context, userID, caseID uuid.UUID, upload kyc.DocumentUpload) (*kyc.Document, error) {
+	if !upload.Type.IsValid() {
+		return nil, fmt.Errorf("%w: unknown document type %q", kyc.ErrInvalidDocument, upload.Type)
+	}
+	c, err := s.cases.GetByID(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+	if c.UserID != userID {
+		return nil, kyc.ErrCaseNotFound
+	}
+	if !c.Status.IsEditable() {
+		return nil, fmt.Errorf("%w: case is %s", kyc.ErrCaseNotEditable, c.Status)
+	}
+
+	// Read one byte past the limit so oversized uploads are detected without buffering them
+	content := bufio.NewReaderSize(io.LimitReader(upload.Content, s.maxDocumentBytes+1), sniffLength)
+	head, err := content.Peek(sniffLength)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read kyc document: %w", err)
+	}
+	if len(head) == 0 {
+		return nil, fmt.Errorf("%w: document is empty", kyc.ErrInvalidDocument)
+	}
+	contentType := http.DetectContentType(head)
+	if !kyc.AllowedContentTypes[contentType] {
+		return nil, fmt.Errorf("%w: content type %s is not accepted", kyc.ErrInvalidDocument, contentType)
+	}
+
+	doc := &kyc.Document{
+		ID:          uuid.New(),
+		CaseID:      c.ID,
+		Type:        upload.Type,
+		FileName:    documentFileName(upload.FileName),
+		ContentType: contentType,
+	}
+	doc.StorageKey = path.Join(s.prefix, userID.String(), c.ID.String(), doc.ID.String())
+
+	hash := sha256.New()
+	size := &byteCounter{}
+	if err := s.store.Put(ctx, doc.StorageKey, io.TeeReader(content, io.MultiWriter(hash, size))); err != nil {
+		s.logger.WithError(err).WithField("case_id", c.ID.String()).Error("failed to store kyc document")
+		return nil, fmt.Errorf("failed to store kyc document: %w", err)
+	}
+	if size.n > s.maxDocumentBytes {
+		s.deleteDocumentContent(ctx, doc.StorageKey)
+		return nil, fmt.Errorf("%w: limit is %d bytes", kyc.ErrDocumentTooLarge, s.maxDocumentBytes)
+	}
+	doc.SizeBytes = size.n
+	doc.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	stored, err := s.cases.AddDocument(ctx, doc)
+	if err != nil {
+		s.deleteDocumentContent(ctx, doc.StorageKey)
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"case_id":       c.ID.String(),
+		"document_id":   stored.ID.String(),
+		"document_type": stored.Type,
+		"size_bytes":    stored.SizeBytes,
+	}).Info("KYC document uploaded")
+	return stored, nil
+}
+
+// Submit hands the user's case to reviewers
+func (s *KYCService) Submit(ctx context.Context, userID, caseID uuid.UUID) (*kyc.Case, error) {
+	c, err := s.change(ctx, caseID, func(cases kyc.Repository, c *kyc.Case) (*kyc.Transition, error) {
+		if c.UserID != userID {
+			return nil, kyc.ErrCaseNotFound
+		}
+		documents, err := cases.ListDocuments(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		return c.Submit(documents, s.now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"user_id": userID.String(),
+		"case_id": caseID.String(),
+	}).Info("KYC case submitted")
+	return c, nil
+}
+
+// ListCases retrieves the review queue and the number of matching cases
+func (s *KYCService) ListCases(ctx context.Context, filter kyc.ListFilter) ([]*kyc.Case, int64, error) {
+	cases, err := s.cases.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.cases.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return cases, total, nil
+}
+
+// GetCase retrieves a case with its documents
+func (s *KYCService) GetCase(ctx context.Context, caseID uuid.UUID) (*kyc.Case, error) {
+	c, err := s.cases.GetByID(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withDocuments(ctx, c)
+}
+
+// GetHistory retrieves a case's transitions in order
+func (s *KYCService) GetHistory(ctx context.Context, caseID uuid.UUID) ([]*kyc.Transition, error) {
+	if _, err := s.cases.GetByID(ctx, caseID); err != nil {
+		return nil, err
+	}
+	return s.cases.ListTransitions(ctx, caseID)
+}
+
+// OpenDocument opens a document's content for a reviewer. Documents hold identity data, so
+// every view is audited.
+func (s *KYCService) OpenDocument(ctx context.Context, caseID, documentID uuid.UUID, actor kyc.Actor) (*kyc.Document, io.ReadCloser, error) {
+	c, err := s.cases.GetByID(ctx, caseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, err := s.cases.GetDocument(ctx, caseID, documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := s.store.Get(ctx, doc.StorageKey)
+	if err != nil {
+		if errors.Is(err, common.ErrObjectNotFound) {
+			s.logger.WithField("document_id", documentID.String()).Error("KYC document content is missing from the object store")
+			return nil, nil, kyc.ErrDocumentNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to open kyc document: %w", err)
+	}
+
+	s.recordDecision(ctx, EventKYCDocumentViewed, "view_document", c, actor, map[string]interface{}{
+		"document_id":   doc.ID.String(),
+		"document_type": doc.Type,
+	})
+	return doc, content, nil
+}
+
+// Assign makes reviewerID the case's reviewer; the reviewer must be an admin
+func (s *KYCService) Assign(ctx context.Context, caseID, reviewerID uuid.UUID, actor kyc.Actor) (*kyc.Case, error) {
+	reviewer, err := s.users.GetByID(ctx, reviewerID)
+	if err != nil {
+		if errors.Is(err, userDomain.ErrNotFound) {
+			return nil, kyc.ErrInvalidReviewer
+		}
+		return nil, err
+	}
+	if !reviewer.IsAdmin() {
+		return nil, kyc.ErrInvalidReviewer
+	}
+
+	return s.decide(ctx, caseID, actor, EventKYCCaseAssigned, "assign", func(c *kyc.Case) (*kyc.Transition, error) {
+		return c.Assign(reviewerID, actor.ID, s.now())
+	})
+}
+
+// SetRiskLevel records the risk assessment of a case
+func (s *KYCService) SetRiskLevel(ctx context.Context, caseID uuid.UUID, level kyc.RiskLevel, actor kyc.Actor) (*kyc.Case, error) {
+	return s.decide(ctx, caseID, actor, EventKYCCaseRiskUpdated, "set_risk_level", func(c *kyc.Case) (*kyc.Transition, error) {
+		return c.SetRiskLevel(level, actor.ID, s.now())
+	})
+}
+
+// WithScreening makes approval wait until the user's screening matches are cleared
+func (s *KYCService) WithScreening(gate screening.Gate) *KYCService {
+	s.screening = gate
+	return s
+}
+
+// Approve approves a case, or records the first approval of a high-risk case. Fails with
+// kyc.ErrScreeningHitsOpen while the user has uncleared screening matches; the screening is
+// checked within the transaction that stores the approval.
+func (s *KYCService) Approve(ctx context.Context, caseID uuid.UUID, note string, actor kyc.Actor) (*kyc.Case, error) {
+	return s.decide(ctx, caseID, actor, EventKYCCaseApproved, "approve", func(c *kyc.Case) (*kyc.Transition, error) {
+		blocked, err := s.approvalBlocked(ctx, c.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			return nil, kyc.ErrScreeningHitsOpen
+		}
+		return c.Approve(actor.ID, note, s.now())
+	})
+}
+
+// Reject turns a case down with a reason code
+func (s *KYCService) Reject(ctx context.Context, caseID uuid.UUID, reason kyc.RejectionReason, note string, actor kyc.Actor) (*kyc.Case, error) {
+	return s.decide(ctx, caseID, actor, EventKYCCaseRejected, "reject", func(c *kyc.Case) (*kyc.Transition, error) {
+		return c.Reject(actor.ID, reason, note, s.now())
+	})
+}
+
+// RequestMoreInfo returns a case to the applicant
+func (s *KYCService) RequestMoreInfo(ctx context.Context, caseID uuid.UUID, note string, actor kyc.Actor) (*kyc.Case, error) {
+	return s.decide(ctx, caseID, actor, EventKYCCaseInfoRequested, "request_info", func(c *kyc.Case) (*kyc.Transition, error) {
+		return c.RequestMoreInfo(actor.ID, note, s.now())
+	})
+}
+
+// decide applies an admin action to a case and audits it
+func (s *KYCService) decide(ctx context.Context, caseID uuid.UUID, actor kyc.Actor, eventType, action string, apply func(c *kyc.Case) (*kyc.Transition, error)) (*kyc.Case, error) {
+	var transition *kyc.Transition
+	c, err := s.change(ctx, caseID, func(_ kyc.Repository, c *kyc.Case) (*kyc.Transition, error) {
+		t, err := apply(c)
+		transition = t
+		return t, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{
+		"case_id":     c.ID.String(),
+		"action":      transition.Action,
+		"from_status": transition.FromStatus,
+		"to_status":   transition.ToStatus,
+		"actor":       actor.Email,
+	}
+	s.logger.WithFields(fields).Info("KYC case updated by reviewer")
+
+	s.recordDecision(ctx, eventType, action, c, actor, transitionMetadata(transition))
+	return c, nil
+}
+
+// approvalBlocked screens the user and reports whether open or confirmed matches block their
+// approval; without screening nothing is blocked
+func (s *KYCService) approvalBlocked(ctx context.Context, userID uuid.UUID) (bool, error) {
+	if s.screening == nil {
+		return false, nil
+	}
+	blocked, err := s.screening.ApprovalBlocked(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if blocked {
+		s.logger.WithField("user_id", userID.String()).Warn("KYC approval blocked by screening matches")
+	}
+	return blocked, nil
+}
+
+// transitionMetadata describes a history entry in audit metadata
+func transitionMetadata(t *kyc.Transition) map[string]interface{} {
+	extra := map[string]interface{}{
+		"transition":  t.Action,
+		"from_status": t.FromStatus,
+		"to_status":   t.ToStatus,
+	}
+	if t.ReasonCode != nil {
+		extra["reason_code"] = *t.ReasonCode
+	}
+	if t.Note != nil {
+		extra["note"] = *t.Note
+	}
+	return extra
+}
+
+// change loads a case in a transaction, applies fn to it and stores the result with its
+// history entry and the user's derived KYC status. A case changed concurrently fails with
+// kyc.ErrCaseConflict rather than overwriting the other change.
+func (s *KYCService) change(ctx context.Context, caseID uuid.UUID, fn func(cases kyc.Repository, c *kyc.Case) (*kyc.Transition, error)) (*kyc.Case, error) {
+	var updated *kyc.Case
+	err := s.transactor.WithinTx(ctx, func(cases kyc.Repository, users userDomain.Repository, events outbox.Writer) error {
+		c, err := cases.GetByID(ctx, caseID)
+		if err != nil {
+			return err
+		}
+		transition, err := fn(cases, c)
+		if err != nil {
+			return err
+		}
+		if updated, err = cases.Update(ctx, c); err != nil {
+			return err
+		}
+		if transition != nil {
+			if err := cases.AddTransition(ctx, transition); err != nil {
+				return err
+			}
+		}
+		return s.syncUserStatus(ctx, cases, users, events, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// syncUserStatus derives the user's kyc_status from c, their latest case, and records a
+// user.kyc.updated event when it changes. The user's entitlements follow the new status.
+func (s *KYCService) syncUserStatus(ctx context.Context, cases kyc.Repository, users userDomain.Repository, events outbox.Writer, c *kyc.Case) error {
+	owner, err := users.GetByID(ctx, c.UserID)
+	if err != nil {
+		return err
+	}
+	status := c.Status.UserKYCStatus()
+	if owner.KYCStatus == status {
+		return nil
+	}
+
+	updated, err := users.UpdateKYCStatus(ctx, owner.ID, status)
+	if err != nil {
+		return err
+	}
+	event := userDomain.NewTypedEvent(updated.ID, userDomain.KYCUpdatedPayload{
+		Email:     updated.Email,
+		KYCStatus: status,
+		OldStatus: owner.KYCStatus,
+	})
+	if err := events.Enqueue(ctx, userDomain.AggregateType, updated.ID.String(), withRequestContext(ctx, event)); err != nil {
+		return err
+	}
+	return s.syncEntitlements(ctx, cases, events, owner, updated, c)
+}
+
+func (s *KYCService) withDocuments(ctx context.Context, c *kyc.Case) (*kyc.Case, error) {
+	documents, err := s.cases.ListDocuments(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	c.Documents = documents
+	return c, nil
+}
+
+func (s *KYCService) deleteDocumentContent(ctx context.Context, key string) {
+	if err := s.store.Delete(context.WithoutCancel(ctx), key); err != nil {
+		s.logger.WithError(err).WithField("storage_key", key).Error("failed to delete orphaned kyc document")
+	}
+}
+
+// recordDecision writes an admin action on a case to the audit log; failures are logged and
+// do not fail the action
+func (s *KYCService) recordDecision(ctx context.Context, eventType, action string, c *kyc.Case, actor kyc.Actor, extra map[string]interface{}) {
+	actorID := actor.Email
+	if actorID == "" {
+		actorID = actor.ID.String()
+	}
+	s.recordCaseAction(ctx, eventType, action, c, audit.ActorAdmin, actorID, extra)
+}
+
+// recordCaseAction writes an action on a case by any actor to the audit log
+func (s *KYCService) recordCaseAction(ctx context.Context, eventType, action string, c *kyc.Case, actorType audit.ActorType, actorID string, extra map[string]interface{}) {
+	resourceType := "kyc_case"
+	resourceID := c.ID.String()
+
+	metadata := map[string]interface{}{
+		"status":     c.Status,
+		"risk_level": c.RiskLevel,
+	}
+	for k, v := range extra {
+		metadata[k] = v
+	}
+
+	entry := &audit.Log{
+		EventType:       eventType,
+		EventCategory:   audit.CategoryCompliance,
+		Severity:        audit.SeverityInfo,
+		UserID:          &c.UserID,
+		ActorType:       actorType,
+		ActorIdentifier: &actorID,
+		Action:          action,
+		ResourceType:    &resourceType,
+		ResourceID:      &resourceID,
+		Metadata:        metadata,
+		Status:          audit.StatusSuccess,
+	}
+
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		s.logger.WithError(err).WithField("case_id", resourceID).Error("Failed to record KYC case action in audit log")
+	}
+}
+
+// documentFileName keeps the base name of an uploaded file, bounded in length
+func documentFileName(name string) string {
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "document"
+	}
+	if len(name) > maxDocumentFileNameLength {
+		name = name[:maxDocumentFileNameLength]
+	}
+	return name
+}
+
+// byteCounter counts the bytes written to it
+type byteCounter struct {
+	n int64
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,648 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// memoryKYC is an in-memory kyc.Repository and kyc.Transactor. A failed transaction restores
+// the cases, tiers and history it started with and drops its events.
+type memoryKYC struct {
+	users       userDomain.Repository
+	cases       map[uuid.UUID]kyc.Case
+	documents   []*kyc.Document
+	transitions []*kyc.Transition
+	tiers       map[uuid.UUID]kyc.TierAssignment
+	tierChanges []*kyc.TierChange
+	committed   []outboxEntry
+	inTx        bool
+}
+
+func newMemoryKYC(users userDomain.Repository) *memoryKYC {
+	return &memoryKYC{users: users, cases: make(map[uuid.UUID]kyc.Case), tiers: make(map[uuid.UUID]kyc.TierAssignment)}
+}
+
+func (r *memoryKYC) WithinTx(ctx context.Context, fn func(cases kyc.Repository, users userDomain.Repository, events outbox.Writer) error) error {
+	cases := make(map[uuid.UUID]kyc.Case, len(r.cases))
+	for id, c := range r.cases {
+		cases[id] = c
+	}
+	tiers := make(map[uuid.UUID]kyc.TierAssignment, len(r.tiers))
+	for id, a := range r.tiers {
+		tiers[id] = a
+	}
+	transitions := len(r.transitions)
+	tierChanges := len(r.tierChanges)
+
+	writer := &fakeOutboxWriter{}
+	r.inTx = true
+	defer func() { r.inTx = false }()
+	if err := fn(r, r.users, writer); err != nil {
+		r.cases = cases
+		r.tiers = tiers
+		r.transitions = r.transitions[:transitions]
+		r.tierChanges = r.tierChanges[:tierChanges]
+		return err
+	}
+	r.committed = append(r.committed, writer.entries...)
+	return nil
+}
+
+func (r *memoryKYC) Create(ctx context.Context, c *kyc.Case) (*kyc.Case, error) {
+	for _, existing := range r.cases {
+		if existing.UserID == c.UserID && !existing.Status.IsFinal() {
+			return nil, kyc.ErrCaseAlreadyOpen
+		}
+	}
+	created := *c
+	created.ID = uuid.New()
+	created.Version = 1
+	created.CreatedAt = c.CreatedAt.Add(time.Duration(len(r.cases)) * time.Millisecond)
+	r.cases[created.ID] = created
+	return &created, nil
+}
+
+func (r *memoryKYC) GetByID(ctx context.Context, id uuid.UUID) (*kyc.Case, error) {
+	c, ok := r.cases[id]
+	if !ok {
+		return nil, kyc.ErrCaseNotFound
+	}
+	return &c, nil
+}
+
+func (r *memoryKYC) GetByProviderApplicant(ctx context.Context, provider, applicantID string) (*kyc.Case, error) {
+	for _, c := range r.cases {
+		if c.Provider != nil && *c.Provider == provider && *c.ProviderApplicantID == applicantID {
+			return &c, nil
+		}
+	}
+	return nil, kyc.ErrCaseNotFound
+}
+
+func (r *memoryKYC) GetLatestForUser(ctx context.Context, userID uuid.UUID) (*kyc.Case, error) {
+	var latest *kyc.Case
+	for _, c := range r.cases {
+		if c.UserID == userID && (latest == nil || c.CreatedAt.After(latest.CreatedAt)) {
+			c := c
+			latest = &c
+		}
+	}
+	if latest == nil {
+		return nil, kyc.ErrCaseNotFound
+	}
+	return latest, nil
+}
+
+func (r *memoryKYC) List(ctx context.Context, filter kyc.ListFilter) ([]*kyc.Case, error) {
+	var cases []*kyc.Case
+	for _, c := range r.cases {
+		if filter.Status == nil || c.Status == *filter.Status {
+			c := c
+			cases = append(cases, &c)
+		}
+	}
+	return cases, nil
+}
+
+func (r *memoryKYC) Count(ctx context.Context, filter kyc.ListFilter) (int64, error) {
+	cases, _ := r.List(ctx, filter)
+	return int64(len(cases)), nil
+}
+
+func (r *memoryKYC) Update(ctx context.Context, c *kyc.Case) (*kyc.Case, error) {
+	stored, ok := r.cases[c.ID]
+	if !ok || stored.Version != c.Version {
+		return nil, kyc.ErrCaseConflict
+	}
+	updated := *c
+	updated.Version++
+	r.cases[c.ID] = updated
+	return &updated, nil
+}
+
+func (r *memoryKYC) AddDocument(ctx context.Context, d *kyc.Document) (*kyc.Document, error) {
+	r.documents = append(r.documents, d)
+	return d, nil
+}
+
+func (r *memoryKYC) ListDocuments(ctx context.Context, caseID uuid.UUID) ([]*kyc.Document, error) {
+	var documents []*kyc.Document
+	for _, d := range r.documents {
+		if d.CaseID == caseID {
+			documents = append(documents, d)
+		}
+	}
+	return documents, nil
+}
+
+func (r *memoryKYC) GetDocument(ctx context.Context, caseID, documentID uuid.UUID) (*kyc.Document, error) {
+	for _, d := range r.documents {
+		if d.CaseID == caseID && d.ID == documentID {
+			return d, nil
+		}
+	}
+	return nil, kyc.ErrDocumentNotFound
+}
+
+func (r *memoryKYC) AddTransition(ctx context.Context, t *kyc.Transition) error {
+	r.transitions = append(r.transitions, t)
+	return nil
+}
+
+func (r *memoryKYC) ListTransitions(ctx context.Context, caseID uuid.UUID) ([]*kyc.Transition, error) {
+	var transitions []*kyc.Transition
+	for _, t := range r.transitions {
+		if t.CaseID == caseID {
+			transitions = append(transitions, t)
+		}
+	}
+	return transitions, nil
+}
+
+func (r *memoryKYC) GetTier(ctx context.Context, userID uuid.UUID) (*kyc.TierAssignment, error) {
+	a, ok := r.tiers[userID]
+	if !ok {
+		return nil, kyc.ErrTierNotFound
+	}
+	return &a, nil
+}
+
+func (r *memoryKYC) SetTier(ctx context.Context, a *kyc.TierAssignment) (*kyc.TierAssignment, error) {
+	r.tiers[a.UserID] = *a
+	stored := *a
+	return &stored, nil
+}
+
+func (r *memoryKYC) AddTierChange(ctx context.Context, c *kyc.TierChange) error {
+	c.ID = uuid.New()
+	r.tierChanges = append(r.tierChanges, c)
+	return nil
+}
+
+func (r *memoryKYC) ListTierChanges(ctx context.Context, userID uuid.UUID) ([]*kyc.TierChange, error) {
+	var changes []*kyc.TierChange
+	for _, c := range r.tierChanges {
+		if c.UserID == userID {
+			changes = append(changes, c)
+		}
+	}
+	return changes, nil
+}
+
+// kycFixture is a KYC service with an applicant and two admins
+type kycFixture struct {
+	svc       *KYCService
+	repo      *memoryKYC
+	store     *storage.LocalObjectStore
+	auditRepo *mocks.MockAuditRepository
+	users     map[uuid.UUID]*userDomain.User
+	applicant *userDomain.User
+	reviewer  kyc.Actor
+	second    kyc.Actor
+}
+
+func newKYCFixture(t *testing.T) *kycFixture {
+	t.Helper()
+	f := &kycFixture{users: make(map[uuid.UUID]*userDomain.User)}
+	f.applicant = f.addUser(userDomain.RoleUser, "jane@example.com")
+	f.reviewer = f.admin("reviewer@example.com")
+	f.second = f.admin("compliance@example.com")
+
+	// Case decisions write the derived KYC status back to the user
+	userRepo := newUserDirectory(t, f.users)
+	userRepo.EXPECT().UpdateKYCStatus(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id uuid.UUID, status userDomain.KYCStatus) (*userDomain.User, error) {
+		f.users[id].KYCStatus = status
+		copied := *f.users[id]
+		return &copied, nil
+	}).AnyTimes()
+
+	f.store = newTestObjectStore(t)
+	f.repo = newMemoryKYC(userRepo)
+	f.auditRepo = newStrictAuditRepo(t)
+	f.svc = NewKYCService(f.repo, f.repo, userRepo, f.store, f.auditRepo, observability.NewLogger("dev", "test-service"), "", 4096)
+	return f
+}
+
+// newUserDirectory returns a user repository that looks users up in users when called, so
+// tests may add or change users after building it. Deleted users are only found including
+// deleted ones.
+func newUserDirectory(t *testing.T, users map[uuid.UUID]*userDomain.User) *mocks.MockUserRepository {
+	lookup := func(includeDeleted bool) func(ctx context.Context, id uuid.UUID) (*userDomain.User, error) {
+		return func(ctx context.Context, id uuid.UUID) (*userDomain.User, error) {
+			u, ok := users[id]
+			if !ok || (u.DeletedAt != nil && !includeDeleted) {
+				return nil, userDomain.ErrNotFound
+			}
+			copied := *u
+			return &copied, nil
+		}
+	}
+	repo := mocks.NewMockUserRepository(gomock.NewController(t))
+	repo.EXPECT().GetByID(gomock.Any(), gomock.Any()).DoAndReturn(lookup(false)).AnyTimes()
+	repo.EXPECT().GetByIDIncludeDeleted(gomock.Any(), gomock.Any()).DoAndReturn(lookup(true)).AnyTimes()
+	return repo
+}
+
+// newStrictAuditRepo returns an audit repository that fails t on any write not expected
+func newStrictAuditRepo(t *testing.T) *mocks.MockAuditRepository {
+	auditRepo := new(mocks.MockAuditRepository)
+	auditRepo.Test(t)
+	return auditRepo
+}
+
+func newTestObjectStore(t *testing.T) *storage.LocalObjectStore {
+	t.Helper()
+	store, err := storage.NewLocalObjectStore(t.TempDir())
+	require.NoError(t, err)
+	return store
+}
+
+// expectAudited expects one audit write of each of eventTypes
+func expectAudited(auditRepo *mocks.MockAuditRepository, eventTypes ...string) {
+	for _, eventType := range eventTypes {
+		auditRepo.On("Create", mock.Anything, auditedEvent(eventType)).Return(&audit.Log{}, nil).Once()
+	}
+}
+
+func auditedEvent(eventType string) interface{} {
+	return mock.MatchedBy(func(l *audit.Log) bool { return l.EventType == eventType })
+}
+
+func (f *kycFixture) addUser(role userDomain.Role, email string) *userDomain.User {
+	u := &userDomain.User{ID: uuid.New(), Email: email, Role: role, KYCStatus: userDomain.KYCStatusPending}
+	f.users[u.ID] = u
+	return u
+}
+
+func (f *kycFixture) admin(email string) kyc.Actor {
+	return kyc.Actor{ID: f.addUser(userDomain.RoleAdmin, email).ID, Email: email}
+}
+
+var pngContent = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...)
+
+func kycApplicant() kyc.Applicant {
+	return kyc.Applicant{
+		FirstName:          "Jane",
+		LastName:           "Doe",
+		DateOfBirth:        "1990-04-12",
+		Nationality:        "DE",
+		CountryOfResidence: "DE",
+		AddressLine1:       "Hauptstrasse 1",
+		City:               "Berlin",
+		PostalCode:         "10115",
+	}
+}
+
+// inReview opens, fills in and submits a case for the applicant and assigns it to the reviewer,
+// expecting the assignment and risk assessment to be audited
+func (f *kycFixture) inReview(t *testing.T, risk kyc.RiskLevel) *kyc.Case {
+	t.Helper()
+	ctx := context.Background()
+	expectAudited(f.auditRepo, EventKYCCaseAssigned, EventKYCCaseRiskUpdated)
+	c, err := f.svc.CreateCase(ctx, f.applicant.ID, kycApplicant())
+	require.NoError(t, err)
+	_, err = f.svc.UploadDocument(ctx, f.applicant.ID, c.ID, kyc.DocumentUpload{Type: kyc.DocumentPassport, FileName: "passport.png", Content: bytes.NewReader(pngContent)})
+	require.NoError(t, err)
+	_, err = f.svc.Submit(ctx, f.applicant.ID, c.ID)
+	require.NoError(t, err)
+	_, err = f.svc.Assign(ctx, c.ID, f.reviewer.ID, f.reviewer)
+	require.NoError(t, err)
+	c, err = f.svc.SetRiskLevel(ctx, c.ID, risk, f.reviewer)
+	require.NoError(t, err)
+	return c
+}
+
+// kycEvents returns the user.kyc.updated events committed so far
+func (f *kycFixture) kycEvents() []*userDomain.Event {
+	return f.committedEvents(userDomain.EventTypeUserKYCUpdated)
+}
+
+// entitlementEvents returns the user.entitlements.changed events committed so far
+func (f *kycFixture) entitlementEvents() []*userDomain.Event {
+	return f.committedEvents(userDomain.EventTypeUserEntitlementsChanged)
+}
+
+func (f *kycFixture) committedEvents(eventType userDomain.EventType) []*userDomain.Event {
+	var events []*userDomain.Event
+	for _, entry := range f.repo.committed {
+		if event := entry.event.(*userDomain.Event); event.Type == eventType {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func TestKYCService_Workflow(t *testing.T) {
+	f := newKYCFixture(t)
+	c := f.inReview(t, kyc.RiskLow)
+	assert.Equal(t, kyc.StatusInReview, c.Status)
+	assert.Empty(t, f.kycEvents(), "kyc_status stays pending while the case is open")
+	f.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		return l.EventType == EventKYCCaseApproved && l.EventCategory == audit.CategoryCompliance &&
+			*l.ActorIdentifier == "reviewer@example.com" && *l.UserID == f.applicant.ID
+	})).Return(&audit.Log{}, nil).Once()
+
+	approved, err := f.svc.Approve(requestContext(), c.ID, "documents match", f.reviewer)
+
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusApproved, approved.Status)
+	assert.Equal(t, userDomain.KYCStatusVerified, f.users[f.applicant.ID].KYCStatus)
+
+	events := f.kycEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, userDomain.EventTypeUserKYCUpdated, events[0].Type)
+	assert.Equal(t, "verified", events[0].Payload["kyc_status"])
+	assert.Equal(t, "pending", events[0].Payload["old_status"])
+	assert.Equal(t, "req-42", events[0].Metadata["request_id"])
+
+	history, err := f.svc.GetHistory(context.Background(), c.ID)
+	require.NoError(t, err)
+	var actions []kyc.Action
+	for _, tr := range history {
+		actions = append(actions, tr.Action)
+	}
+	assert.Equal(t, []kyc.Action{kyc.ActionCreate, kyc.ActionSubmit, kyc.ActionAssign, kyc.ActionSetRiskLevel, kyc.ActionApprove}, actions)
+	f.auditRepo.AssertExpectations(t)
+
+	_, err = f.svc.CreateCase(context.Background(), f.applicant.ID, kycApplicant())
+	assert.ErrorIs(t, err, kyc.ErrAlreadyVerified)
+}
+
+func TestKYCService_FourEyes(t *testing.T) {
+	f := newKYCFixture(t)
+	c := f.inReview(t, kyc.RiskHigh)
+	ctx := context.Background()
+	expectAudited(f.auditRepo, EventKYCCaseApproved, EventKYCCaseApproved)
+
+	c, err := f.svc.Approve(ctx, c.ID, "", f.reviewer)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusInReview, c.Status)
+	assert.Equal(t, userDomain.KYCStatusPending, f.users[f.applicant.ID].KYCStatus)
+
+	_, err = f.svc.Approve(ctx, c.ID, "", f.reviewer)
+	assert.ErrorIs(t, err, kyc.ErrFourEyesRequired)
+
+	c, err = f.svc.Approve(ctx, c.ID, "", f.second)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusApproved, c.Status)
+	assert.Equal(t, f.second.ID, *c.DecidedBy)
+	assert.Equal(t, userDomain.KYCStatusVerified, f.users[f.applicant.ID].KYCStatus)
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestKYCService_RejectAndReopen(t *testing.T) {
+	f := newKYCFixture(t)
+	c := f.inReview(t, kyc.RiskMedium)
+	ctx := context.Background()
+	expectAudited(f.auditRepo, EventKYCCaseRejected)
+
+	_, err := f.svc.Reject(ctx, c.ID, kyc.ReasonDocumentExpired, "", f.second)
+	assert.ErrorIs(t, err, kyc.ErrNotAssignedReviewer)
+
+	c, err = f.svc.Reject(ctx, c.ID, kyc.ReasonDocumentExpired, "passport expired", f.reviewer)
+	require.NoError(t, err)
+	assert.Equal(t, userDomain.KYCStatusRejected, f.users[f.applicant.ID].KYCStatus)
+
+	reopened, err := f.svc.CreateCase(ctx, f.applicant.ID, kycApplicant())
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusDraft, reopened.Status)
+	assert.Equal(t, userDomain.KYCStatusPending, f.users[f.applicant.ID].KYCStatus, "a new case puts the user back to pending")
+
+	_, err = f.svc.CreateCase(ctx, f.applicant.ID, kycApplicant())
+	assert.ErrorIs(t, err, kyc.ErrCaseAlreadyOpen)
+
+	latest, err := f.svc.GetMyCase(ctx, f.applicant.ID)
+	require.NoError(t, err)
+	assert.Equal(t, reopened.ID, latest.ID)
+	assert.Len(t, f.kycEvents(), 2)
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestKYCService_UploadDocument(t *testing.T) {
+	f := newKYCFixture(t)
+	ctx := context.Background()
+	c, err := f.svc.CreateCase(ctx, f.applicant.ID, kycApplicant())
+	require.NoError(t, err)
+
+	t.Run("stores content and checksum", func(t *testing.T) {
+		doc, err := f.svc.UploadDocument(ctx, f.applicant.ID, c.ID, kyc.DocumentUpload{
+			Type: kyc.DocumentPassport, FileName: `C:\scans\passport.png`, Content: bytes.NewReader(pngContent),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", doc.ContentType)
+		assert.Equal(t, "passport.png", doc.FileName)
+		assert.Equal(t, int64(len(pngContent)), doc.SizeBytes)
+		assert.Len(t, doc.SHA256, 64)
+		assert.Equal(t, "kyc-documents/"+f.applicant.ID.String()+"/"+c.ID.String()+"/"+doc.ID.String(), doc.StorageKey)
+
+		f.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.EventType == EventKYCDocumentViewed && l.Metadata["document_id"] == doc.ID.String()
+		})).Return(&audit.Log{}, nil).Once()
+		opened, content, err := f.svc.OpenDocument(ctx, c.ID, doc.ID, f.reviewer)
+		require.NoError(t, err)
+		defer content.Close()
+		stored, err := io.ReadAll(content)
+		require.NoError(t, err)
+		assert.Equal(t, pngContent, stored)
+		assert.Equal(t, doc.ID, opened.ID)
+		f.auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("refuses unsupported content", func(t *testing.T) {
+		_, err := f.svc.UploadDocument(ctx, f.applicant.ID, c.ID, kyc.DocumentUpload{
+			Type: kyc.DocumentPassport, FileName: "passport.png", Content: bytes.NewReader([]byte("<html>not an image</html>")),
+		})
+		assert.ErrorIs(t, err, kyc.ErrInvalidDocument)
+	})
+
+	t.Run("refuses oversized content and removes it", func(t *testing.T) {
+		big := append(append([]byte{}, pngContent...), bytes.Repeat([]byte{1}, 4096)...)
+		_, err := f.svc.UploadDocument(ctx, f.applicant.ID, c.ID, kyc.DocumentUpload{
+			Type: kyc.DocumentSelfie, FileName: "selfie.png", Content: bytes.NewReader(big),
+		})
+		assert.ErrorIs(t, err, kyc.ErrDocumentTooLarge)
+		documents, _ := f.repo.ListDocuments(ctx, c.ID)
+		assert.Len(t, documents, 1)
+	})
+
+	t.Run("hides other users' cases", func(t *testing.T) {
+		_, err := f.svc.UploadDocument(ctx, uuid.New(), c.ID, kyc.DocumentUpload{
+			Type: kyc.DocumentPassport, Content: bytes.NewReader(pngContent),
+		})
+		assert.ErrorIs(t, err, kyc.ErrCaseNotFound)
+	})
+}
+
+func TestKYCService_Assign(t *testing.T) {
+	f := newKYCFixture(t)
+	ctx := context.Background()
+	c, err := f.svc.CreateCase(ctx, f.applicant.ID, kycApplicant())
+	require.NoError(t, err)
+
+	_, err = f.svc.Submit(ctx, f.applicant.ID, c.ID)
+	assert.ErrorIs(t, err, kyc.ErrMissingDocuments)
+
+	_, err = f.svc.Assign(ctx, c.ID, f.applicant.ID, f.reviewer)
+	assert.ErrorIs(t, err, kyc.ErrInvalidReviewer, "applicants cannot review")
+	_, err = f.svc.Assign(ctx, c.ID, uuid.New(), f.reviewer)
+	assert.ErrorIs(t, err, kyc.ErrInvalidReviewer)
+
+	_, err = f.svc.Assign(ctx, c.ID, f.reviewer.ID, f.reviewer)
+	assert.ErrorIs(t, err, kyc.ErrInvalidTransition, "drafts are not reviewed")
+	history, _ := f.repo.ListTransitions(ctx, c.ID)
+	assert.Len(t, history, 1, "a failed action leaves no history")
+	f.auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestKYCService_SelfReview(t *testing.T) {
+	f := newKYCFixture(t)
+	ctx := context.Background()
+	f.applicant.Role = userDomain.RoleAdmin
+	subject := kyc.Actor{ID: f.applicant.ID, Email: f.applicant.Email}
+	c := f.inReview(t, kyc.RiskHigh)
+
+	_, err := f.svc.Assign(ctx, c.ID, subject.ID, f.reviewer)
+	assert.ErrorIs(t, err, kyc.ErrSelfReview, "an admin cannot be assigned their own case")
+	_, err = f.svc.Assign(ctx, c.ID, f.second.ID, subject)
+	assert.ErrorIs(t, err, kyc.ErrSelfReview, "an admin cannot assign their own case")
+
+	expectAudited(f.auditRepo, EventKYCCaseApproved)
+	_, err = f.svc.Approve(ctx, c.ID, "", f.reviewer)
+	require.NoError(t, err)
+	_, err = f.svc.Approve(ctx, c.ID, "", subject)
+	assert.ErrorIs(t, err, kyc.ErrSelfReview, "an admin cannot give the second approval of their own case")
+
+	stored, err := f.repo.GetByID(ctx, c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusInReview, stored.Status)
+	assert.Equal(t, f.reviewer.ID, *stored.ReviewerID)
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestKYCService_StaleUpdateConflicts(t *testing.T) {
+	f := newKYCFixture(t)
+	ctx := context.Background()
+	c, err := f.svc.CreateCase(ctx, f.applicant.ID, kycApplicant())
+	require.NoError(t, err)
+
+	stale := *c
+	_, err = f.svc.UpdateApplicant(ctx, f.applicant.ID, c.ID, kycApplicant())
+	require.NoError(t, err)
+
+	_, err = f.repo.Update(ctx, &stale)
+	assert.True(t, errors.Is(err, kyc.ErrCaseConflict))
+}
+
+func TestKYCService_ApprovalGrantsBasicTier(t *testing.T) {
+	f := newKYCFixture(t)
+	ctx := context.Background()
+
+	before, err := f.svc.GetEntitlements(ctx, f.applicant.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.TierNone, before.Tier)
+	assert.Empty(t, before.Products)
+
+	c := f.inReview(t, kyc.RiskLow)
+	expectAudited(f.auditRepo, EventKYCCaseApproved)
+	_, err = f.svc.Approve(ctx, c.ID, "", f.reviewer)
+	require.NoError(t, err)
+
+	after, err := f.svc.GetEntitlements(ctx, f.applicant.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.TierBasic, after.Tier)
+	assert.Equal(t, "basic", after.TierName)
+	assert.Equal(t, []string{"spot"}, after.Products)
+	assert.Equal(t, "EUR", after.Currency)
+
+	events := f.entitlementEvents()
+	require.Len(t, events, 1)
+	assert.EqualValues(t, 1, events[0].Payload["tier"])
+	assert.EqualValues(t, 0, events[0].Payload["old_tier"])
+	assert.Equal(t, kyc.TierReasonKYCApproved, events[0].Payload["reason"])
+
+	history, err := f.svc.GetTierHistory(ctx, f.applicant.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, kyc.TierBasic, history[0].NewTier)
+	assert.Equal(t, c.ID, *history[0].CaseID)
+	assert.Nil(t, history[0].ChangedBy)
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestKYCService_SetTier(t *testing.T) {
+	f := newKYCFixture(t)
+	ctx := context.Background()
+
+	_, err := f.svc.SetTier(ctx, f.applicant.ID, 2, "volume", f.reviewer)
+	assert.ErrorIs(t, err, kyc.ErrTierRequiresVerification, "unverified users cannot hold a tier")
+	_, err = f.svc.SetTier(ctx, f.applicant.ID, 4, "volume", f.reviewer)
+	assert.ErrorIs(t, err, kyc.ErrInvalidTier)
+	_, err = f.svc.SetTier(ctx, f.applicant.ID, 0, " ", f.reviewer)
+	assert.ErrorIs(t, err, kyc.ErrInvalidTier, "a reason is required")
+	_, err = f.svc.SetTier(ctx, uuid.New(), 0, "volume", f.reviewer)
+	assert.ErrorIs(t, err, userDomain.ErrNotFound)
+
+	c := f.inReview(t, kyc.RiskLow)
+	expectAudited(f.auditRepo, EventKYCCaseApproved)
+	_, err = f.svc.Approve(ctx, c.ID, "", f.reviewer)
+	require.NoError(t, err)
+
+	f.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		return l.EventType == EventKYCTierChanged && l.Metadata["new_tier"] == 3 && *l.UserID == f.applicant.ID
+	})).Return(&audit.Log{}, nil).Twice()
+	entitlements, err := f.svc.SetTier(ctx, f.applicant.ID, 3, "institutional onboarding", f.reviewer)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.Tier(3), entitlements.Tier)
+	assert.Empty(t, entitlements.DailyDepositLimit, "tier 3 is unlimited")
+
+	_, err = f.svc.SetTier(ctx, f.applicant.ID, 3, "again", f.reviewer)
+	require.NoError(t, err)
+
+	events := f.entitlementEvents()
+	require.Len(t, events, 2, "setting the held tier again changes nothing")
+	assert.EqualValues(t, 3, events[1].Payload["tier"])
+	assert.Equal(t, "institutional onboarding", events[1].Payload["reason"])
+
+	history, err := f.svc.GetTierHistory(ctx, f.applicant.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, kyc.TierBasic, history[1].OldTier)
+	assert.Equal(t, f.reviewer.ID, *history[1].ChangedBy)
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestKYCService_RejectionDropsEntitlements(t *testing.T) {
+	f := newKYCFixture(t)
+	ctx := context.Background()
+	c := f.inReview(t, kyc.RiskLow)
+	expectAudited(f.auditRepo, EventKYCCaseApproved, EventKYCTierChanged)
+	_, err := f.svc.Approve(ctx, c.ID, "", f.reviewer)
+	require.NoError(t, err)
+	_, err = f.svc.SetTier(ctx, f.applicant.ID, 2, "volume", f.reviewer)
+	require.NoError(t, err)
+
+	f.users[f.applicant.ID].KYCStatus = userDomain.KYCStatusRejected
+
+	entitlements, err := f.svc.GetEntitlements(ctx, f.applicant.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.TierNone, entitlements.Tier, "tiers only apply to verified users")
+	assert.Equal(t, kyc.Tier(2), entitlements.HeldTier)
+	f.auditRepo.AssertExpectations(t)
+}
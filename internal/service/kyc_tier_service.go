@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// EventKYCTierChanged is the audit event recorded when an admin changes a user's tier
+const EventKYCTierChanged = "kyc.tier.changed"
+
+// maxTierReasonLength bounds the reason an admin gives for a tier change
+const maxTierReasonLength = 500
+
+// WithTierPolicy sets the limits of each KYC tier; kyc.DefaultTierPolicy is used otherwise
+func (s *KYCService) WithTierPolicy(policy *kyc.TierPolicy) *KYCService {
+	s.tiers = policy
+	return s
+}
+
+// GetEntitlements computes what the user is allowed to do from their tier and KYC status
+func (s *KYCService) GetEntitlements(ctx context.Context, userID uuid.UUID) (*kyc.Entitlements, error) {
+	owner, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	assignment, err := heldTier(ctx, s.cases, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.tiers.Entitlements(owner, assignment), nil
+}
+
+// SetTier assigns a tier to a user, records the change in their tier history and, when the
+// user's entitlements change, a user.entitlements.changed event. The change is audited.
+func (s *KYCService) SetTier(ctx context.Context, userID uuid.UUID, tier kyc.Tier, reason string, actor kyc.Actor) (*kyc.Entitlements, error) {
+	reason = strings.TrimSpace(reason)
+	if !tier.IsValid() {
+		return nil, fmt.Errorf("%w: tier must be between %d and %d", kyc.ErrInvalidTier, kyc.TierNone, kyc.MaxTier)
+	}
+	if reason == "" || len(reason) > maxTierReasonLength {
+		return nil, fmt.Errorf("%w: a reason of at most %d characters is required", kyc.ErrInvalidTier, maxTierReasonLength)
+	}
+
+	var (
+		oldTier      kyc.Tier
+		entitlements *kyc.Entitlements
+	)
+	err := s.transactor.WithinTx(ctx, func(cases kyc.Repository, users userDomain.Repository, events outbox.Writer) error {
+		owner, err := users.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if tier > kyc.TierNone && owner.KYCStatus != userDomain.KYCStatusVerified {
+			return kyc.ErrTierRequiresVerification
+		}
+		assignment, err := heldTier(ctx, cases, userID)
+		if err != nil {
+			return err
+		}
+		before := s.tiers.Entitlements(owner, assignment)
+		oldTier = before.HeldTier
+
+		after, err := s.changeTier(ctx, cases, owner, assignment, tier, reason, &actor.ID, nil)
+		if err != nil {
+			return err
+		}
+		entitlements = after
+		return s.publishEntitlements(ctx, events, before, after, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordTierChange(ctx, userID, oldTier, tier, reason, actor)
+	s.logger.WithFields(map[string]interface{}{
+		"user_id":  userID.String(),
+		"old_tier": oldTier,
+		"new_tier": tier,
+		"actor":    actor.Email,
+	}).Info("KYC tier changed by admin")
+	return entitlements, nil
+}
+
+// GetTierHistory retrieves a user's tier changes in order
+func (s *KYCService) GetTierHistory(ctx context.Context, userID uuid.UUID) ([]*kyc.TierChange, error) {
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.cases.ListTierChanges(ctx, userID)
+}
+
+// syncEntitlements runs after the user's KYC status was derived from c. A user verified by
+// an approved case who holds no tier is upgraded to kyc.TierBasic; whenever the status or
+// tier change shifts what the user may do, a user.entitlements.changed event is recorded.
+func (s *KYCService) syncEntitlements(ctx context.Context, cases kyc.Repository, events outbox.Writer, before, after *userDomain.User, c *kyc.Case) error {
+	assignment, err := heldTier(ctx, cases, after.ID)
+	if err != nil {
+		return err
+	}
+	old := s.tiers.Entitlements(before, assignment)
+
+	updated := s.tiers.Entitlements(after, assignment)
+	reason := "kyc_status_" + string(after.KYCStatus)
+	if after.KYCStatus == userDomain.KYCStatusVerified && updated.HeldTier == kyc.TierNone {
+		reason = kyc.TierReasonKYCApproved
+		updated, err = s.changeTier(ctx, cases, after, assignment, kyc.TierBasic, reason, nil, &c.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return s.publishEntitlements(ctx, events, old, updated, reason)
+}
+
+// changeTier stores the user's new tier and its history entry and returns the resulting
+// entitlements. Assigning the tier the user already holds changes nothing.
+func (s *KYCService) changeTier(ctx context.Context, cases kyc.Repository, owner *userDomain.User, assignment *kyc.TierAssignment, tier kyc.Tier, reason string, changedBy, caseID *uuid.UUID) (*kyc.Entitlements, error) {
+	oldTier := kyc.TierNone
+	if assignment != nil {
+		oldTier = assignment.Tier
+	}
+	if oldTier == tier {
+		return s.tiers.Entitlements(owner, assignment), nil
+	}
+
+	now := s.now()
+	stored, err := cases.SetTier(ctx, &kyc.TierAssignment{
+		UserID:    owner.ID,
+		Tier:      tier,
+		UpdatedBy: changedBy,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := cases.AddTierChange(ctx, &kyc.TierChange{
+		UserID:    owner.ID,
+		OldTier:   oldTier,
+		NewTier:   tier,
+		Reason:    reason,
+		ChangedBy: changedBy,
+		CaseID:    caseID,
+		CreatedAt: now,
+	}); err != nil {
+		return nil, err
+	}
+	return s.tiers.Entitlements(owner, stored), nil
+}
+
+// publishEntitlements records a user.entitlements.changed event if after differs from before
+func (s *KYCService) publishEntitlements(ctx context.Context, events outbox.Writer, before, after *kyc.Entitlements, reason string) error {
+	if before.Equal(after) {
+		return nil
+	}
+	event := userDomain.NewTypedEvent(after.UserID, userDomain.EntitlementsChangedPayload{
+		Tier:                 int(after.Tier),
+		OldTier:              int(before.Tier),
+		TierName:             after.TierName,
+		Currency:             after.Currency,
+		DailyDepositLimit:    after.DailyDepositLimit,
+		DailyWithdrawalLimit: after.DailyWithdrawalLimit,
+		Products:             after.Products,
+		Jurisdictions:        after.Jurisdictions,
+		Reason:               reason,
+	})
+	return events.Enqueue(ctx, userDomain.AggregateType, after.UserID.String(), withRequestContext(ctx, event))
+}
+
+// recordTierChange writes an admin's tier change to the audit log; failures are logged and
+// do not fail the change
+func (s *KYCService) recordTierChange(ctx context.Context, userID uuid.UUID, oldTier, newTier kyc.Tier, reason string, actor kyc.Actor) {
+	resourceType := "user"
+	resourceID := userID.String()
+	actorID := actor.Email
+	if actorID == "" {
+		actorID = actor.ID.String()
+	}
+
+	entry := &audit.Log{
+		EventType:       EventKYCTierChanged,
+		EventCategory:   audit.CategoryCompliance,
+		Severity:        audit.SeverityInfo,
+		UserID:          &userID,
+		ActorType:       audit.ActorAdmin,
+		ActorIdentifier: &actorID,
+		Action:          "set_tier",
+		ResourceType:    &resourceType,
+		ResourceID:      &resourceID,
+		Metadata: map[string]interface{}{
+			"old_tier": int(oldTier),
+			"new_tier": int(newTier),
+			"reason":   reason,
+		},
+		Status: audit.StatusSuccess,
+	}
+
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		s.logger.WithError(err).WithField("user_id", resourceID).Error("Failed to record KYC tier change in audit log")
+	}
+}
+
+// heldTier retrieves the user's tier assignment; nil when they were never assigned one
+func heldTier(ctx context.Context, cases kyc.Repository, userID uuid.UUID) (*kyc.TierAssignment, error) {
+	assignment, err := cases.GetTier(ctx, userID)
+	if errors.Is(err, kyc.ErrTierNotFound) {
+		return nil, nil
+	}
+	return assignment, err
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+)
+
+// Audit event types recorded for legal hold changes
+const (
+	EventLegalHoldPlaced   = "legal_hold.placed"
+	EventLegalHoldUpdated  = "legal_hold.updated"
+	EventLegalHoldReleased = "legal_hold.released"
+)
+
+// LegalHoldService manages legal holds on audit records and user data.
+// Every change is written to audit_logs as a compliance event with no retention
+// expiry, so the trail of a hold outlives the hold itself.
+type LegalHoldService struct {
+	holdRepo    audit.LegalHoldRepository
+	auditRepo   audit.Repository
+	logger      *observability.Logger
+	auditLogger *observability.AuditLogger
+	now         func() time.Time
+}
+
+// NewLegalHoldService creates a new legal hold service
+func NewLegalHoldService(
+	holdRepo audit.LegalHoldRepository,
+	auditRepo audit.Repository,
+	logger *observability.Logger,
+) *LegalHoldService {
+	return &LegalHoldService{
+		holdRepo:    holdRepo,
+		auditRepo:   auditRepo,
+		logger:      logger,
+		auditLogger: observability.NewAuditLogger(logger),
+		now:         time.Now,
+	}
+}
+
+// PlaceHold validates and creates a hold on behalf of actor
+func (s *LegalHoldService) PlaceHold(ctx context.Context, hold *audit.LegalHold, actor string) (*audit.LegalHold, error) {
+	if err := hold.Validate(s.now()); err != nil {
+		return nil, err
+	}
+	hold.CreatedBy = actor
+
+	created, err := s.holdRepo.Create(ctx, hold)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordChange(ctx, EventLegalHoldPlaced, "create", actor, created, nil, created)
+	s.auditLogger.LogSecurityEvent(EventLegalHoldPlaced, "high", map[string]interface{}{
+		"hold_id":    created.ID.String(),
+		"owner":      created.Owner,
+		"expires_at": created.ExpiresAt,
+		"actor":      actor,
+	})
+
+	return created, nil
+}
+
+// GetHold retrieves a hold by ID
+func (s *LegalHoldService) GetHold(ctx context.Context, id uuid.UUID) (*audit.LegalHold, error) {
+	return s.holdRepo.GetByID(ctx, id)
+}
+
+// ListHolds retrieves holds, newest first
+func (s *LegalHoldService) ListHolds(ctx context.Context, activeOnly bool, limit, offset int32) ([]*audit.LegalHold, error) {
+	return s.holdRepo.List(ctx, activeOnly, limit, offset)
+}
+
+// UpdateHold changes the reason, owner and expiry of an unreleased hold
+func (s *LegalHoldService) UpdateHold(ctx context.Context, id uuid.UUID, reason, owner string, expiresAt time.Time, actor string) (*audit.LegalHold, error) {
+	if err := audit.ValidateLegalHoldTerms(reason, owner, expiresAt, s.now()); err != nil {
+		return nil, err
+	}
+
+	previous, err := s.getUnreleased(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.holdRepo.UpdateTerms(ctx, id, reason, owner, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordChange(ctx, EventLegalHoldUpdated, "update", actor, updated, previous, updated)
+	s.auditLogger.LogSecurityEvent(EventLegalHoldUpdated, "high", map[string]interface{}{
+		"hold_id":    id.String(),
+		"owner":      updated.Owner,
+		"expires_at": updated.ExpiresAt,
+		"actor":      actor,
+	})
+
+	return updated, nil
+}
+
+// ReleaseHold lifts a hold. Data it protected becomes subject to retention again.
+func (s *LegalHoldService) ReleaseHold(ctx context.Context, id uuid.UUID, reason, actor string) (*audit.LegalHold, error) {
+	if reason == "" {
+		return nil, fmt.Errorf("%w: release reason is required", audit.ErrInvalidLegalHold)
+	}
+
+	previous, err := s.getUnreleased(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	released, err := s.holdRepo.Release(ctx, id, actor, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordChange(ctx, EventLegalHoldReleased, "release", actor, released, previous, released)
+	s.auditLogger.LogSecurityEvent(EventLegalHoldReleased, "high", map[string]interface{}{
+		"hold_id": id.String(),
+		"reason":  reason,
+		"actor":   actor,
+	})
+
+	return released, nil
+}
+
+// IsUserOnHold reports whether an active hold names the user
+func (s *LegalHoldService) IsUserOnHold(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return s.holdRepo.IsUserOnHold(ctx, userID)
+}
+
+// IsRangeOnHold reports whether an active hold's date range overlaps [start, end)
+func (s *LegalHoldService) IsRangeOnHold(ctx context.Context, start, end time.Time) (bool, error) {
+	return s.holdRepo.IsRangeOnHold(ctx, start, end)
+}
+
+// getUnreleased loads a hold and rejects released ones
+func (s *LegalHoldService) getUnreleased(ctx context.Context, id uuid.UUID) (*audit.LegalHold, error) {
+	hold, err := s.holdRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if hold.IsReleased() {
+		return nil, audit.ErrLegalHoldReleased
+	}
+	return hold, nil
+}
+
+// recordChange writes a legal hold change to audit_logs.
+// The change has already been committed, so a failure here is logged rather than returned.
+func (s *LegalHoldService) recordChange(ctx context.Context, eventType, action, actor string, hold, previous, current *audit.LegalHold) {
+	resourceType := "legal_hold"
+	resourceID := hold.ID.String()
+
+	entry := &audit.Log{
+		EventType:       eventType,
+		EventCategory:   audit.CategoryCompliance,
+		Severity:        audit.SeverityHigh,
+		UserID:          hold.UserID,
+		ActorType:       audit.ActorAdmin,
+		ActorIdentifier: &actor,
+		Action:          action,
+		ResourceType:    &resourceType,
+		ResourceID:      &resourceID,
+		Metadata: map[string]interface{}{
+			"reason": hold.Reason,
+			"owner":  hold.Owner,
+		},
+		PreviousState: legalHoldState(previous),
+		NewState:      legalHoldState(current),
+		Status:        audit.StatusSuccess,
+	}
+
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		s.logger.WithError(err).WithFields(map[string]interface{}{
+			"event_type": eventType,
+			"hold_id":    resourceID,
+		}).Error("Failed to record legal hold change in audit log")
+	}
+}
+
+// legalHoldState flattens a hold for the previous/new state columns
+func legalHoldState(hold *audit.LegalHold) map[string]interface{} {
+	if hold == nil {
+		return nil
+	}
+
+	state := map[string]interface{}{
+		"reason":     hold.Reason,
+		"owner":      hold.Owner,
+		"expires_at": hold.ExpiresAt,
+		"released":   hold.IsReleased(),
+	}
+	if hold.UserID != nil {
+		state["user_id"] = hold.UserID.String()
+	}
+	if hold.StartsAt != nil {
+		state["starts_at"] = *hold.StartsAt
+	}
+	if hold.EndsAt != nil {
+		state["ends_at"] = *hold.EndsAt
+	}
+	if hold.EventCategory != nil {
+		state["event_category"] = string(*hold.EventCategory)
+	}
+	if hold.ReleaseReason != nil {
+		state["release_reason"] = *hold.ReleaseReason
+	}
+	return state
+}
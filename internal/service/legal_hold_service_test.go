@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLegalHoldService(now time.Time) (*LegalHoldService, *mocks.MockLegalHoldRepository, *mocks.MockAuditRepository) {
+	logger := observability.NewLogger("dev", "test-service")
+	holdRepo := new(mocks.MockLegalHoldRepository)
+	auditRepo := new(mocks.MockAuditRepository)
+
+	svc := NewLegalHoldService(holdRepo, auditRepo, logger)
+	svc.now = func() time.Time { return now }
+	return svc, holdRepo, auditRepo
+}
+
+func TestLegalHoldService_PlaceHold(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, holdRepo, auditRepo := newTestLegalHoldService(now)
+	userID := uuid.New()
+
+	hold := &audit.LegalHold{
+		UserID:    &userID,
+		Reason:    "Regulator inquiry",
+		Owner:     "legal@pandora-exchange.com",
+		ExpiresAt: now.AddDate(1, 0, 0),
+	}
+
+	holdRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *audit.LegalHold) bool {
+		return h.CreatedBy == "admin@test.com"
+	})).Return(func(_ context.Context, h *audit.LegalHold) *audit.LegalHold {
+		h.ID = uuid.New()
+		return h
+	}, nil).Once()
+	auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		return l.EventType == EventLegalHoldPlaced &&
+			l.EventCategory == audit.CategoryCompliance &&
+			l.ActorType == audit.ActorAdmin &&
+			*l.ActorIdentifier == "admin@test.com" &&
+			*l.ResourceType == "legal_hold" &&
+			*l.UserID == userID &&
+			l.PreviousState == nil &&
+			l.NewState["user_id"] == userID.String() &&
+			l.RetentionUntil == nil
+	})).Return(&audit.Log{}, nil).Once()
+
+	created, err := svc.PlaceHold(context.Background(), hold, "admin@test.com")
+
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, created.ID)
+	holdRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestLegalHoldService_PlaceHold_Invalid(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, holdRepo, auditRepo := newTestLegalHoldService(now)
+
+	_, err := svc.PlaceHold(context.Background(), &audit.LegalHold{
+		Reason:    "No scope",
+		Owner:     "legal",
+		ExpiresAt: now.AddDate(1, 0, 0),
+	}, "admin@test.com")
+
+	assert.ErrorIs(t, err, audit.ErrInvalidLegalHold)
+	holdRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestLegalHoldService_PlaceHold_AuditFailureDoesNotFail(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, holdRepo, auditRepo := newTestLegalHoldService(now)
+	category := audit.CategorySecurity
+
+	holdRepo.On("Create", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, h *audit.LegalHold) *audit.LegalHold { return h }, nil).Once()
+	auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	_, err := svc.PlaceHold(context.Background(), &audit.LegalHold{
+		EventCategory: &category,
+		Reason:        "Incident 42",
+		Owner:         "security",
+		ExpiresAt:     now.AddDate(0, 6, 0),
+	}, "admin@test.com")
+
+	require.NoError(t, err)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestLegalHoldService_UpdateHold(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, holdRepo, auditRepo := newTestLegalHoldService(now)
+	id := uuid.New()
+	newExpiry := now.AddDate(2, 0, 0)
+
+	existing := &audit.LegalHold{ID: id, Reason: "Inquiry", Owner: "legal", ExpiresAt: now.AddDate(1, 0, 0)}
+	updated := &audit.LegalHold{ID: id, Reason: "Inquiry extended", Owner: "legal", ExpiresAt: newExpiry}
+
+	holdRepo.On("GetByID", mock.Anything, id).Return(existing, nil).Once()
+	holdRepo.On("UpdateTerms", mock.Anything, id, "Inquiry extended", "legal", newExpiry).Return(updated, nil).Once()
+	auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		return l.EventType == EventLegalHoldUpdated &&
+			l.PreviousState["reason"] == "Inquiry" &&
+			l.NewState["reason"] == "Inquiry extended"
+	})).Return(&audit.Log{}, nil).Once()
+
+	result, err := svc.UpdateHold(context.Background(), id, "Inquiry extended", "legal", newExpiry, "admin@test.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, newExpiry, result.ExpiresAt)
+	holdRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestLegalHoldService_UpdateHold_Released(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, holdRepo, _ := newTestLegalHoldService(now)
+	id := uuid.New()
+	releasedAt := now.Add(-time.Hour)
+
+	holdRepo.On("GetByID", mock.Anything, id).Return(&audit.LegalHold{ID: id, ReleasedAt: &releasedAt}, nil).Once()
+
+	_, err := svc.UpdateHold(context.Background(), id, "Inquiry", "legal", now.AddDate(1, 0, 0), "admin@test.com")
+
+	assert.ErrorIs(t, err, audit.ErrLegalHoldReleased)
+	holdRepo.AssertNotCalled(t, "UpdateTerms", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLegalHoldService_UpdateHold_PastExpiry(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, holdRepo, _ := newTestLegalHoldService(now)
+
+	_, err := svc.UpdateHold(context.Background(), uuid.New(), "Inquiry", "legal", now.Add(-time.Minute), "admin@test.com")
+
+	assert.ErrorIs(t, err, audit.ErrInvalidLegalHold)
+	holdRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestLegalHoldService_ReleaseHold(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	svc, holdRepo, auditRepo := newTestLegalHoldService(now)
+	id := uuid.New()
+	releasedAt := now
+	releasedBy := "admin@test.com"
+	releaseReason := "Inquiry closed"
+
+	holdRepo.On("GetByID", mock.Anything, id).Return(&audit.LegalHold{ID: id, ExpiresAt: now.AddDate(1, 0, 0)}, nil).Once()
+	holdRepo.On("Release", mock.Anything, id, releasedBy, releaseReason).Return(&audit.LegalHold{
+		ID:            id,
+		ExpiresAt:     now.AddDate(1, 0, 0),
+		ReleasedAt:    &releasedAt,
+		ReleasedBy:    &releasedBy,
+		ReleaseReason: &releaseReason,
+	}, nil).Once()
+	auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		return l.EventType == EventLegalHoldReleased &&
+			l.Action == "release" &&
+			l.PreviousState["released"] == false &&
+			l.NewState["released"] == true &&
+			l.NewState["release_reason"] == releaseReason
+	})).Return(&audit.Log{}, nil).Once()
+
+	released, err := svc.ReleaseHold(context.Background(), id, releaseReason, releasedBy)
+
+	require.NoError(t, err)
+	assert.True(t, released.IsReleased())
+	holdRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestLegalHoldService_ReleaseHold_Errors(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+
+	t.Run("reason required", func(t *testing.T) {
+		svc, _, _ := newTestLegalHoldService(now)
+		_, err := svc.ReleaseHold(context.Background(), uuid.New(), "", "admin@test.com")
+		assert.ErrorIs(t, err, audit.ErrInvalidLegalHold)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc, holdRepo, _ := newTestLegalHoldService(now)
+		id := uuid.New()
+		holdRepo.On("GetByID", mock.Anything, id).Return(nil, audit.ErrLegalHoldNotFound).Once()
+
+		_, err := svc.ReleaseHold(context.Background(), id, "done", "admin@test.com")
+		assert.ErrorIs(t, err, audit.ErrLegalHoldNotFound)
+	})
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+)
+
+const (
+	// outboxBaseBackoff is the delay after the first failed publish; it doubles per attempt
+	outboxBaseBackoff = time.Second
+	// outboxMaxBackoff caps the retry delay. Messages are retried until they are published.
+	outboxMaxBackoff = 5 * time.Minute
+	// outboxCleanupInterval is how often published messages past retention are deleted
+	outboxCleanupInterval = 10 * time.Minute
+	// defaultOutboxRelayInterval and defaultOutboxBatchSize apply when the config leaves them unset
+	defaultOutboxRelayInterval = time.Second
+	defaultOutboxBatchSize     = 100
+)
+
+// OutboxMetrics receives outbox backlog and delivery measurements
+type OutboxMetrics interface {
+	RecordOutboxPublish(eventType string, success bool)
+	UpdateOutboxBacklog(pending int64, lag time.Duration)
+}
+
+// OutboxRelay publishes outbox messages to the event bus.
+// Messages are published oldest first and only marked published once the bus accepted
+// them, so delivery is at-least-once. A failed message is retried with exponential
+// backoff and holds back later messages of the same aggregate until it succeeds.
+// Replicas coordinate through the repository's relay lock; only one relays at a time.
+type OutboxRelay struct {
+	repo        outbox.Repository
+	publisher   common.EventPublisher
+	metrics     OutboxMetrics
+	logger      *observability.Logger
+	interval    time.Duration
+	batchSize   int32
+	retention   time.Duration
+	lastCleanup time.Time
+	now         func() time.Time
+	stopChan    chan struct{}
+	doneChan    chan struct{}
+}
+
+// NewOutboxRelay creates a new outbox relay.
+// Published messages are kept for retention before cleanup deletes them;
+// a zero interval or batch size selects the default.
+func NewOutboxRelay(
+	repo outbox.Repository,
+	publisher common.EventPublisher,
+	logger *observability.Logger,
+	interval time.Duration,
+	batchSize int32,
+	retention time.Duration,
+) *OutboxRelay {
+	if interval <= 0 {
+		interval = defaultOutboxRelayInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultOutboxBatchSize
+	}
+	return &OutboxRelay{
+		repo:      repo,
+		publisher: publisher,
+		logger:    logger,
+		interval:  interval,
+		batchSize: batchSize,
+		retention: retention,
+		now:       time.Now,
+		stopChan:  make(chan struct{}),
+		doneChan:  make(chan struct{}),
+	}
+}
+
+// WithMetrics exports backlog size, lag and publish outcomes
+func (r *OutboxRelay) WithMetrics(metrics OutboxMetrics) *OutboxRelay {
+	r.metrics = metrics
+	return r
+}
+
+// Start begins relaying in a goroutine; stop it with Stop()
+func (r *OutboxRelay) Start(ctx context.Context) {
+	r.logger.WithFields(map[string]interface{}{
+		"interval":   r.interval.String(),
+		"batch_size": r.batchSize,
+	}).Info("Starting outbox relay")
+
+	ticker := time.NewTicker(r.interval)
+
+	go func() {
+		defer close(r.doneChan)
+		defer ticker.Stop()
+
+		for {
+			r.tick(ctx)
+
+			select {
+			case <-ticker.C:
+			case <-r.stopChan:
+				r.logger.Info("Outbox relay stopped")
+				return
+			case <-ctx.Done():
+				r.logger.Info("Outbox relay context cancelled")
+				return
+			}
+		}
+	}()
+}
+
+// Stop waits for the current batch to finish and stops the relay
+func (r *OutboxRelay) Stop() {
+	r.logger.Info("Stopping outbox relay")
+	close(r.stopChan)
+	<-r.doneChan
+	r.logger.Info("Outbox relay stopped successfully")
+}
+
+// tick relays one batch, periodically cleans up and refreshes the metrics
+func (r *OutboxRelay) tick(ctx context.Context) {
+	if _, err := r.RunOnce(ctx); err != nil {
+		r.logger.WithError(err).Error("Outbox relay batch failed")
+	}
+
+	if r.now().Sub(r.lastCleanup) >= outboxCleanupInterval {
+		if _, err := r.Cleanup(ctx); err != nil {
+			r.logger.WithError(err).Error("Outbox cleanup failed")
+		} else {
+			r.lastCleanup = r.now()
+		}
+	}
+
+	if r.metrics != nil {
+		stats, err := r.repo.Stats(ctx)
+		if err != nil {
+			r.logger.WithError(err).Warn("Failed to read outbox stats")
+			return
+		}
+		r.metrics.UpdateOutboxBacklog(stats.Pending, stats.Lag(r.now()))
+	}
+}
+
+// RunOnce publishes up to one batch of due messages and returns how many were published.
+// It returns 0 without error when another relay holds the lock.
+func (r *OutboxRelay) RunOnce(ctx context.Context) (int, error) {
+	published := 0
+	ran, err := r.repo.WithRelayLock(ctx, func(tx outbox.RelayTx) error {
+		messages, err := tx.ListDue(ctx, r.now(), r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			if err := r.publisher.Publish(message.Envelope()); err != nil {
+				r.recordPublish(message, false)
+				nextAttemptAt := r.now().Add(outboxBackoff(message.Attempts))
+				r.logger.WithError(err).WithFields(map[string]interface{}{
+					"outbox_id":       message.ID,
+					"event_id":        message.EventID,
+					"event_type":      message.EventType,
+					"attempts":        message.Attempts + 1,
+					"next_attempt_at": nextAttemptAt,
+				}).Warn("Failed to publish outbox message, will retry")
+
+				if err := tx.MarkFailed(ctx, message.ID, err.Error(), nextAttemptAt); err != nil {
+					return err
+				}
+				// The bus is most likely unavailable; the rest of the batch waits for the next tick
+				return nil
+			}
+
+			r.recordPublish(message, true)
+			if err := tx.MarkPublished(ctx, message.ID, r.now()); err != nil {
+				return err
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		// The transaction rolled back, so messages published in this batch will be published again
+		return 0, fmt.Errorf("failed to relay outbox batch: %w", err)
+	}
+	if !ran {
+		r.logger.Debug("Outbox relay lock held by another instance")
+		return 0, nil
+	}
+
+	if published > 0 {
+		r.logger.WithField("published", published).Debug("Outbox batch relayed")
+	}
+	return published, nil
+}
+
+// Cleanup deletes messages published longer ago than the retention period
+func (r *OutboxRelay) Cleanup(ctx context.Context) (int64, error) {
+	deleted, err := r.repo.DeletePublishedBefore(ctx, r.now().Add(-r.retention))
+	if err != nil {
+		return 0, err
+	}
+	if deleted > 0 {
+		r.logger.WithField("deleted", deleted).Info("Published outbox messages cleaned up")
+	}
+	return deleted, nil
+}
+
+// recordPublish reports a publish attempt to the metrics, if configured
+func (r *OutboxRelay) recordPublish(message *outbox.Message, success bool) {
+	if r.metrics != nil {
+		r.metrics.RecordOutboxPublish(message.EventType, success)
+	}
+}
+
+// outboxBackoff returns the retry delay after a message has failed attempts+1 times
+func outboxBackoff(attempts int32) time.Duration {
+	if attempts < 0 {
+		attempts = 0
+	}
+	if attempts >= 20 {
+		return outboxMaxBackoff
+	}
+	delay := outboxBaseBackoff << attempts
+	if delay > outboxMaxBackoff {
+		return outboxMaxBackoff
+	}
+	return delay
+}
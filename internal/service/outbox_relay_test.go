@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryOutbox is an in-memory outbox.Repository with the same due/ordering rules as the SQL queries
+type memoryOutbox struct {
+	mu       sync.Mutex
+	messages []*outbox.Message
+	locked   bool
+}
+
+func (o *memoryOutbox) add(aggregateID, eventType string, createdAt time.Time) *outbox.Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	m := &outbox.Message{
+		ID:            int64(len(o.messages) + 1),
+		AggregateType: "user",
+		AggregateID:   aggregateID,
+		EventID:       eventType + "-" + aggregateID,
+		EventType:     eventType,
+		OccurredAt:    createdAt,
+		NextAttemptAt: createdAt,
+		CreatedAt:     createdAt,
+	}
+	o.messages = append(o.messages, m)
+	return m
+}
+
+func (o *memoryOutbox) WithRelayLock(ctx context.Context, fn func(tx outbox.RelayTx) error) (bool, error) {
+	o.mu.Lock()
+	if o.locked {
+		o.mu.Unlock()
+		return false, nil
+	}
+	o.locked = true
+	o.mu.Unlock()
+
+	defer func() {
+		o.mu.Lock()
+		o.locked = false
+		o.mu.Unlock()
+	}()
+	return true, fn(o)
+}
+
+func (o *memoryOutbox) Stats(ctx context.Context) (outbox.Stats, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var stats outbox.Stats
+	for _, m := range o.messages {
+		if m.PublishedAt == nil {
+			stats.Pending++
+			if stats.OldestPendingAt == nil || m.CreatedAt.Before(*stats.OldestPendingAt) {
+				created := m.CreatedAt
+				stats.OldestPendingAt = &created
+			}
+		}
+	}
+	return stats, nil
+}
+
+func (o *memoryOutbox) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	kept := o.messages[:0]
+	var deleted int64
+	for _, m := range o.messages {
+		if m.PublishedAt != nil && m.PublishedAt.Before(before) {
+			deleted++
+			continue
+		}
+		kept = append(kept, m)
+	}
+	o.messages = kept
+	return deleted, nil
+}
+
+func (o *memoryOutbox) ListDue(ctx context.Context, now time.Time, limit int32) ([]*outbox.Message, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	sort.Slice(o.messages, func(i, j int) bool { return o.messages[i].ID < o.messages[j].ID })
+
+	var due []*outbox.Message
+	blocked := map[string]bool{}
+	for _, m := range o.messages {
+		if m.PublishedAt != nil {
+			continue
+		}
+		if !m.NextAttemptAt.After(now) && !blocked[m.AggregateID] {
+			due = append(due, m)
+		}
+		if m.NextAttemptAt.After(now) {
+			blocked[m.AggregateID] = true
+		}
+		if int32(len(due)) == limit {
+			break
+		}
+	}
+	return due, nil
+}
+
+func (o *memoryOutbox) MarkPublished(ctx context.Context, id int64, publishedAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.byID(id).PublishedAt = &publishedAt
+	return nil
+}
+
+func (o *memoryOutbox) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	m := o.byID(id)
+	m.Attempts++
+	m.LastError = &lastError
+	m.NextAttemptAt = nextAttemptAt
+	return nil
+}
+
+// byID finds a message; callers hold mu
+func (o *memoryOutbox) byID(id int64) *outbox.Message {
+	for _, m := range o.messages {
+		if m.ID == id {
+			return m
+		}
+	}
+	panic("unknown outbox message")
+}
+
+// scriptedPublisher records published event IDs and fails while fail returns true
+type scriptedPublisher struct {
+	published []string
+	fail      func(event common.EventEnvelope) bool
+}
+
+func (p *scriptedPublisher) Publish(event interface{}) error {
+	envelope := event.(common.EventEnvelope)
+	if p.fail != nil && p.fail(envelope) {
+		return errors.New("redis unavailable")
+	}
+	p.published = append(p.published, envelope.EventID())
+	return nil
+}
+
+func (p *scriptedPublisher) PublishBatch(events []interface{}) error { return nil }
+func (p *scriptedPublisher) Close() error                            { return nil }
+
+// recordingOutboxMetrics captures the last backlog update and publish outcomes
+type recordingOutboxMetrics struct {
+	pending  int64
+	lag      time.Duration
+	outcomes map[bool]int
+}
+
+func (m *recordingOutboxMetrics) RecordOutboxPublish(eventType string, success bool) {
+	if m.outcomes == nil {
+		m.outcomes = map[bool]int{}
+	}
+	m.outcomes[success]++
+}
+
+func (m *recordingOutboxMetrics) UpdateOutboxBacklog(pending int64, lag time.Duration) {
+	m.pending = pending
+	m.lag = lag
+}
+
+func newTestOutboxRelay(repo outbox.Repository, publisher common.EventPublisher, clock *time.Time) *OutboxRelay {
+	relay := NewOutboxRelay(repo, publisher, observability.NewLogger("dev", "test-service"), time.Second, 10, time.Hour)
+	relay.now = func() time.Time { return *clock }
+	return relay
+}
+
+func TestOutboxRelay_RunOnce_PublishesInOrder(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	repo := &memoryOutbox{}
+	repo.add("u1", "user.registered", now.Add(-3*time.Second))
+	repo.add("u2", "user.registered", now.Add(-2*time.Second))
+	repo.add("u1", "user.kyc.updated", now.Add(-time.Second))
+	publisher := &scriptedPublisher{}
+
+	published, err := newTestOutboxRelay(repo, publisher, &now).RunOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, published)
+	assert.Equal(t, []string{"user.registered-u1", "user.registered-u2", "user.kyc.updated-u1"}, publisher.published)
+
+	stats, _ := repo.Stats(context.Background())
+	assert.Zero(t, stats.Pending)
+}
+
+func TestOutboxRelay_RunOnce_FailureHoldsBackAggregate(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	repo := &memoryOutbox{}
+	first := repo.add("u1", "user.registered", now.Add(-3*time.Second))
+	repo.add("u2", "user.registered", now.Add(-2*time.Second))
+	repo.add("u1", "user.kyc.updated", now.Add(-time.Second))
+
+	failing := true
+	publisher := &scriptedPublisher{fail: func(event common.EventEnvelope) bool {
+		return failing && event.EventID() == "user.registered-u1"
+	}}
+	relay := newTestOutboxRelay(repo, publisher, &now)
+
+	// The failure stops the batch and schedules a retry
+	published, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	assert.Equal(t, int32(1), first.Attempts)
+	assert.Equal(t, now.Add(outboxBaseBackoff), first.NextAttemptAt)
+	require.NotNil(t, first.LastError)
+
+	// While u1's first event backs off, u2 proceeds and u1's later event waits
+	published, err = relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []string{"user.registered-u2"}, publisher.published)
+
+	// After the backoff u1's events go out in order
+	failing = false
+	now = now.Add(outboxBaseBackoff)
+	published, err = relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	assert.Equal(t, []string{"user.registered-u2", "user.registered-u1", "user.kyc.updated-u1"}, publisher.published)
+}
+
+func TestOutboxRelay_RunOnce_LockHeldElsewhere(t *testing.T) {
+	now := time.Now()
+	repo := &memoryOutbox{locked: true}
+	repo.add("u1", "user.registered", now)
+	publisher := &scriptedPublisher{}
+
+	published, err := newTestOutboxRelay(repo, publisher, &now).RunOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	assert.Empty(t, publisher.published)
+}
+
+func TestOutboxRelay_TickCleansUpAndReportsMetrics(t *testing.T) {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	repo := &memoryOutbox{}
+	old := repo.add("u1", "user.registered", now.Add(-2*time.Hour))
+	publishedAt := now.Add(-90 * time.Minute)
+	old.PublishedAt = &publishedAt
+	repo.add("u2", "user.registered", now.Add(-time.Minute))
+
+	metrics := &recordingOutboxMetrics{}
+	publisher := &scriptedPublisher{fail: func(common.EventEnvelope) bool { return true }}
+	relay := newTestOutboxRelay(repo, publisher, &now).WithMetrics(metrics)
+
+	relay.tick(context.Background())
+
+	assert.Len(t, repo.messages, 1, "message published past retention is deleted")
+	assert.Equal(t, int64(1), metrics.pending)
+	assert.Equal(t, time.Minute, metrics.lag)
+	assert.Equal(t, 1, metrics.outcomes[false])
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, outboxBackoff(0))
+	assert.Equal(t, 8*time.Second, outboxBackoff(3))
+	assert.Equal(t, outboxMaxBackoff, outboxBackoff(12))
+	assert.Equal(t, outboxMaxBackoff, outboxBackoff(1000))
+}
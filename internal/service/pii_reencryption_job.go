@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+)
+
+// defaultPIIReencryptBatchSize applies when the config leaves the batch size unset
+const defaultPIIReencryptBatchSize = 100
+
+// PIIReencryptionJob periodically encrypts user PII stored before field encryption was
+// enabled and rewraps data keys after the key encryption key was rotated (e.g. with
+// "vault write -f transit/keys/user-pii/rotate"). Each run works through all pending users
+// in batches.
+type PIIReencryptionJob struct {
+	reencryptor user.PIIReencryptor
+	logger      *observability.Logger
+	interval    time.Duration
+	batchSize   int32
+	stopChan    chan struct{}
+	doneChan    chan struct{}
+}
+
+// NewPIIReencryptionJob creates a new PII re-encryption job
+func NewPIIReencryptionJob(
+	reencryptor user.PIIReencryptor,
+	logger *observability.Logger,
+	interval time.Duration,
+	batchSize int32,
+) *PIIReencryptionJob {
+	if batchSize <= 0 {
+		batchSize = defaultPIIReencryptBatchSize
+	}
+	return &PIIReencryptionJob{
+		reencryptor: reencryptor,
+		logger:      logger,
+		interval:    interval,
+		batchSize:   batchSize,
+		stopChan:    make(chan struct{}),
+		doneChan:    make(chan struct{}),
+	}
+}
+
+// Start begins the periodic re-encryption job
+// Runs in a goroutine and can be stopped with Stop()
+func (j *PIIReencryptionJob) Start(ctx context.Context) {
+	j.logger.WithField("interval", j.interval.String()).Info("Starting PII re-encryption job")
+
+	ticker := time.NewTicker(j.interval)
+
+	go func() {
+		defer close(j.doneChan)
+		defer ticker.Stop()
+
+		// Run immediately on start without delaying startup
+		if _, err := j.RunOnce(ctx); err != nil {
+			j.logger.WithError(err).Error("Initial PII re-encryption failed")
+		}
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := j.RunOnce(ctx); err != nil {
+					j.logger.WithError(err).Error("Scheduled PII re-encryption failed")
+				}
+			case <-j.stopChan:
+				j.logger.Info("PII re-encryption job stopped")
+				return
+			case <-ctx.Done():
+				j.logger.Info("PII re-encryption job context cancelled")
+				return
+			}
+		}
+	}()
+}
+
+// Stop gracefully stops the re-encryption job
+func (j *PIIReencryptionJob) Stop() {
+	j.logger.Info("Stopping PII re-encryption job")
+	close(j.stopChan)
+	<-j.doneChan
+	j.logger.Info("PII re-encryption job stopped successfully")
+}
+
+// RunOnce re-encrypts pending users batch by batch until a batch updates none or ctx is
+// done, returning the number of users updated
+func (j *PIIReencryptionJob) RunOnce(ctx context.Context) (int, error) {
+	startTime := time.Now()
+	total := 0
+
+	for {
+		select {
+		case <-j.stopChan:
+			return total, nil
+		default:
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		updated, err := j.reencryptor.ReencryptPII(ctx, j.batchSize)
+		total += updated
+		if err != nil {
+			return total, fmt.Errorf("failed to re-encrypt user PII: %w", err)
+		}
+		if updated == 0 {
+			break
+		}
+	}
+
+	if total > 0 {
+		j.logger.WithFields(map[string]interface{}{
+			"users":       total,
+			"duration_ms": time.Since(startTime).Milliseconds(),
+		}).Info("User PII re-encrypted")
+	}
+	return total, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePIIReencryptor updates up to limit users per call from pending
+type fakePIIReencryptor struct {
+	mu      sync.Mutex
+	pending int
+	calls   int
+	err     error
+}
+
+func (f *fakePIIReencryptor) CountPendingPIIReencryption(ctx context.Context) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return int64(f.pending), nil
+}
+
+func (f *fakePIIReencryptor) ReencryptPII(ctx context.Context, limit int32) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return 0, f.err
+	}
+	n := min(f.pending, int(limit))
+	f.pending -= n
+	return n, nil
+}
+
+func TestPIIReencryptionJob_RunOnce(t *testing.T) {
+	logger := observability.NewLogger("dev", "test-service")
+
+	t.Run("processes all pending users in batches", func(t *testing.T) {
+		reencryptor := &fakePIIReencryptor{pending: 25}
+		job := NewPIIReencryptionJob(reencryptor, logger, time.Hour, 10)
+
+		total, err := job.RunOnce(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 25, total)
+		assert.Equal(t, 0, reencryptor.pending)
+		assert.Equal(t, 4, reencryptor.calls, "three batches and an empty one")
+	})
+
+	t.Run("nothing pending", func(t *testing.T) {
+		reencryptor := &fakePIIReencryptor{}
+		job := NewPIIReencryptionJob(reencryptor, logger, time.Hour, 10)
+
+		total, err := job.RunOnce(context.Background())
+
+		require.NoError(t, err)
+		assert.Zero(t, total)
+		assert.Equal(t, 1, reencryptor.calls)
+	})
+
+	t.Run("error stops the run", func(t *testing.T) {
+		reencryptor := &fakePIIReencryptor{pending: 5, err: errors.New("vault unavailable")}
+		job := NewPIIReencryptionJob(reencryptor, logger, time.Hour, 10)
+
+		_, err := job.RunOnce(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to re-encrypt user PII")
+		assert.Equal(t, 1, reencryptor.calls)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		reencryptor := &fakePIIReencryptor{pending: 5}
+		job := NewPIIReencryptionJob(reencryptor, logger, time.Hour, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := job.RunOnce(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, reencryptor.calls)
+	})
+}
+
+func TestPIIReencryptionJob_StartStop(t *testing.T) {
+	logger := observability.NewLogger("dev", "test-service")
+	reencryptor := &fakePIIReencryptor{pending: 3}
+	job := NewPIIReencryptionJob(reencryptor, logger, time.Hour, 10)
+
+	job.Start(context.Background())
+	require.Eventually(t, func() bool {
+		pending, _ := reencryptor.CountPendingPIIReencryption(context.Background())
+		return pending == 0
+	}, time.Second, 10*time.Millisecond)
+	job.Stop()
+}
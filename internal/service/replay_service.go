@@ -0,0 +1,482 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/replay"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+)
+
+// Audit events recorded when admins manage replay jobs
+const (
+	EventReplayJobCreated   = "event_replay.created"
+	EventReplayJobCancelled = "event_replay.cancelled"
+	EventReplayJobResumed   = "event_replay.resumed"
+)
+
+const (
+	// defaultReplayPollInterval is how often the worker looks for a job to run
+	defaultReplayPollInterval = 5 * time.Second
+	// defaultReplayLease is how long a job stays leased without a checkpoint, on top of the
+	// time a page takes at the job's rate limit
+	defaultReplayLease = 2 * time.Minute
+	// snapshotEventsPerUser bounds the events a snapshot builds for one user
+	snapshotEventsPerUser = 3
+)
+
+// replayNamespace derives the IDs of regenerated events. Snapshot and audit replays build new
+// events, and deterministic IDs let consumers deduplicate when a page is replayed twice.
+var replayNamespace = uuid.MustParse("6f1c9a52-3d2e-4b8a-9f1e-5a7c0d4e8b21")
+
+// ReplayPublisherFactory returns the publisher for a replay job's target stream
+type ReplayPublisherFactory func(targetStream string) (common.EventPublisher, error)
+
+// Compile-time check to ensure ReplayService implements replay.Service
+var _ replay.Service = (*ReplayService)(nil)
+
+// ReplayService manages event replay jobs and runs them.
+// Jobs are queued by admins and run by a worker on any instance: the worker leases the
+// oldest runnable job, reads its source one page at a time, publishes the page's events,
+// marked as replays, to the job's target stream at the job's rate limit, and checkpoints the
+// cursor after each page. A job interrupted by a restart is resumed from its last checkpoint
+// once its lease expires; a page may then be published twice, with the same event IDs.
+type ReplayService struct {
+	repo       replay.Repository
+	auditRepo  audit.Repository
+	publishers ReplayPublisherFactory
+	logger     *observability.Logger
+	owner      string
+	interval   time.Duration
+	lease      time.Duration
+	now        func() time.Time
+	stopChan   chan struct{}
+	doneChan   chan struct{}
+}
+
+// NewReplayService creates a new replay service. owner identifies this instance in job leases.
+func NewReplayService(
+	repo replay.Repository,
+	auditRepo audit.Repository,
+	publishers ReplayPublisherFactory,
+	logger *observability.Logger,
+	owner string,
+) *ReplayService {
+	return &ReplayService{
+		repo:       repo,
+		auditRepo:  auditRepo,
+		publishers: publishers,
+		logger:     logger,
+		owner:      owner,
+		interval:   defaultReplayPollInterval,
+		lease:      defaultReplayLease,
+		now:        time.Now,
+		stopChan:   make(chan struct{}),
+		doneChan:   make(chan struct{}),
+	}
+}
+
+// WithPollInterval sets how often the worker looks for a job to run
+func (s *ReplayService) WithPollInterval(interval time.Duration) *ReplayService {
+	if interval > 0 {
+		s.interval = interval
+	}
+	return s
+}
+
+// CreateJob validates and queues a replay job
+func (s *ReplayService) CreateJob(ctx context.Context, req *replay.Request) (*replay.Job, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	job, err := s.repo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"job_id":        job.ID.String(),
+		"source":        string(job.Source),
+		"target_stream": job.TargetStream,
+		"actor":         req.RequestedBy,
+	}).Info("Event replay job queued")
+
+	s.recordAction(ctx, EventReplayJobCreated, "create", job, req.RequestedBy)
+	return job, nil
+}
+
+// GetJob retrieves a job and its progress
+func (s *ReplayService) GetJob(ctx context.Context, id uuid.UUID) (*replay.Job, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListJobs retrieves jobs, newest first
+func (s *ReplayService) ListJobs(ctx context.Context, limit, offset int32) ([]*replay.Job, error) {
+	return s.repo.List(ctx, limit, offset)
+}
+
+// CancelJob stops a pending or running job. A running job stops at its next checkpoint.
+func (s *ReplayService) CancelJob(ctx context.Context, id uuid.UUID, actor string) (*replay.Job, error) {
+	job, err := s.repo.Cancel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"job_id": id.String(),
+		"actor":  actor,
+	}).Info("Event replay job cancelled")
+
+	s.recordAction(ctx, EventReplayJobCancelled, "cancel", job, actor)
+	return job, nil
+}
+
+// ResumeJob queues a failed or cancelled job again, continuing from its cursor
+func (s *ReplayService) ResumeJob(ctx context.Context, id uuid.UUID, actor string) (*replay.Job, error) {
+	job, err := s.repo.Resume(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"job_id": id.String(),
+		"cursor": string(job.Cursor),
+		"actor":  actor,
+	}).Info("Event replay job resumed")
+
+	s.recordAction(ctx, EventReplayJobResumed, "resume", job, actor)
+	return job, nil
+}
+
+// recordAction audits an admin's action on a replay job along with its source, filter and cursor
+func (s *ReplayService) recordAction(ctx context.Context, eventType, action string, job *replay.Job, actor string) {
+	resourceType := "event_replay_job"
+	resourceID := job.ID.String()
+
+	metadata := map[string]interface{}{
+		"source":        string(job.Source),
+		"event_types":   job.EventTypes,
+		"target_stream": job.TargetStream,
+		"rate_limit":    job.RateLimit,
+		"cursor":        string(job.Cursor),
+	}
+	if job.From != nil {
+		metadata["from"] = job.From.UTC().Format(time.RFC3339)
+	}
+	if job.To != nil {
+		metadata["to"] = job.To.UTC().Format(time.RFC3339)
+	}
+
+	entry := &audit.Log{
+		EventType:       eventType,
+		EventCategory:   audit.CategoryDataAccess,
+		Severity:        audit.SeverityWarning,
+		ActorType:       audit.ActorAdmin,
+		ActorIdentifier: &actor,
+		Action:          action,
+		ResourceType:    &resourceType,
+		ResourceID:      &resourceID,
+		Metadata:        metadata,
+		Status:          audit.StatusSuccess,
+	}
+
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		s.logger.WithError(err).WithField("job_id", resourceID).Error("Failed to record replay job action in audit log")
+	}
+}
+
+// Start begins running queued jobs in a goroutine; stop it with Stop()
+func (s *ReplayService) Start(ctx context.Context) {
+	s.logger.WithFields(map[string]interface{}{
+		"interval": s.interval.String(),
+		"owner":    s.owner,
+	}).Info("Starting event replay worker")
+
+	ctx, cancel := context.WithCancel(ctx)
+	ticker := time.NewTicker(s.interval)
+
+	go func() {
+		defer close(s.doneChan)
+		defer ticker.Stop()
+		defer cancel()
+
+		// Stop interrupts a running job; it resumes from its last checkpoint
+		go func() {
+			select {
+			case <-s.stopChan:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		for {
+			if _, err := s.RunNext(ctx); err != nil && ctx.Err() == nil {
+				s.logger.WithError(err).Error("Event replay job failed")
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				s.logger.Info("Event replay worker stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop interrupts the running job at its next event and stops the worker
+func (s *ReplayService) Stop() {
+	s.logger.Info("Stopping event replay worker")
+	close(s.stopChan)
+	<-s.doneChan
+	s.logger.Info("Event replay worker stopped successfully")
+}
+
+// RunNext claims the oldest runnable job and runs it to the end.
+// It returns nil and no error when no job is waiting.
+func (s *ReplayService) RunNext(ctx context.Context) (*replay.Job, error) {
+	now := s.now()
+	job, err := s.repo.Claim(ctx, s.owner, now, now.Add(s.lease))
+	if err != nil {
+		if errors.Is(err, replay.ErrNoJob) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return job, s.run(ctx, job)
+}
+
+// run replays a claimed job page by page and records how it ended
+func (s *ReplayService) run(ctx context.Context, job *replay.Job) error {
+	log := s.logger.WithFields(map[string]interface{}{
+		"job_id":        job.ID.String(),
+		"source":        string(job.Source),
+		"target_stream": job.TargetStream,
+		"cursor":        string(job.Cursor),
+	})
+	log.Info("Running event replay job")
+
+	err := s.replay(ctx, job)
+	switch {
+	case err == nil:
+		if err := s.repo.Finish(ctx, job.ID, s.owner, replay.StatusCompleted, nil, s.now()); err != nil {
+			return s.leaseEnded(log, err)
+		}
+		job.Status = replay.StatusCompleted
+		log.WithFields(map[string]interface{}{
+			"processed_records": job.ProcessedRecords,
+			"published_events":  job.PublishedEvents,
+		}).Info("Event replay job completed")
+		return nil
+
+	case errors.Is(err, replay.ErrLeaseLost):
+		return s.leaseEnded(log, err)
+
+	case ctx.Err() != nil:
+		// Shutting down: the job keeps its lease and checkpoint and is resumed once the lease expires
+		log.Info("Event replay job interrupted")
+		return nil
+	}
+
+	lastError := err.Error()
+	if finishErr := s.repo.Finish(ctx, job.ID, s.owner, replay.StatusFailed, &lastError, s.now()); finishErr != nil {
+		log.WithError(finishErr).Error("Failed to mark event replay job failed")
+	}
+	job.Status = replay.StatusFailed
+	job.LastError = &lastError
+	return fmt.Errorf("replay job %s failed: %w", job.ID, err)
+}
+
+// leaseEnded handles a job that was cancelled or taken over while it ran
+func (s *ReplayService) leaseEnded(log *observability.Logger, err error) error {
+	if errors.Is(err, replay.ErrLeaseLost) {
+		log.Info("Event replay job cancelled or taken over, stopping")
+		return nil
+	}
+	return err
+}
+
+// replay publishes every remaining page of a job's source
+func (s *ReplayService) replay(ctx context.Context, job *replay.Job) error {
+	publisher, err := s.publishers(job.TargetStream)
+	if err != nil {
+		return fmt.Errorf("failed to create publisher for %s: %w", job.TargetStream, err)
+	}
+	defer publisher.Close()
+
+	if job.Cursor == "" {
+		total, err := s.countSource(ctx, job)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SetTotal(ctx, job.ID, s.owner, total); err != nil {
+			return err
+		}
+		job.TotalRecords = total
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(job.RateLimit), job.RateLimit)
+	for {
+		events, processed, next, err := s.readPage(ctx, job)
+		if err != nil {
+			return err
+		}
+		if processed == 0 {
+			return nil
+		}
+
+		for _, event := range events {
+			if err := limiter.Wait(ctx); err != nil {
+				return err
+			}
+			if err := publisher.Publish(replay.Mark(event, job.ID)); err != nil {
+				return fmt.Errorf("failed to publish %s %s: %w", event.EventType(), event.EventID(), err)
+			}
+		}
+
+		job.Cursor = next
+		job.ProcessedRecords += int64(processed)
+		job.PublishedEvents += int64(len(events))
+		if err := s.repo.SaveProgress(ctx, job, s.owner, s.now().Add(s.leaseFor(job))); err != nil {
+			return err
+		}
+
+		if processed < job.BatchSize {
+			return nil
+		}
+	}
+}
+
+// leaseFor returns how long a job's lease is extended at each checkpoint: long enough to
+// publish a full page at the job's rate limit, plus the default lease
+func (s *ReplayService) leaseFor(job *replay.Job) time.Duration {
+	perPage := time.Duration(job.BatchSize*snapshotEventsPerUser) * time.Second / time.Duration(job.RateLimit)
+	return s.lease + perPage
+}
+
+// countSource counts the source records a job covers, for progress reporting
+func (s *ReplayService) countSource(ctx context.Context, job *replay.Job) (int64, error) {
+	switch job.Source {
+	case replay.SourceSnapshot:
+		return s.repo.CountUsers(ctx, job.Window())
+	case replay.SourceOutbox:
+		return s.repo.CountOutbox(ctx, job.Window(), job.EventTypes)
+	case replay.SourceAudit:
+		return s.repo.CountAuditLogs(ctx, job.Window(), job.EventTypes)
+	}
+	return 0, fmt.Errorf("%w: unknown source %q", replay.ErrInvalidJob, job.Source)
+}
+
+// readPage reads the next page of a job's source. It returns the events to publish, the
+// number of source records read and the cursor after them.
+func (s *ReplayService) readPage(ctx context.Context, job *replay.Job) ([]common.EventEnvelope, int, replay.Cursor, error) {
+	limit := int32(job.BatchSize)
+
+	switch job.Source {
+	case replay.SourceSnapshot:
+		after, err := job.Cursor.Position()
+		if err != nil {
+			return nil, 0, "", err
+		}
+		users, err := s.repo.ListUsers(ctx, job.Window(), after, limit)
+		if err != nil || len(users) == 0 {
+			return nil, 0, job.Cursor, err
+		}
+		var events []common.EventEnvelope
+		for _, u := range users {
+			events = append(events, snapshotEvents(job, u)...)
+		}
+		last := users[len(users)-1]
+		return events, len(users), replay.PositionCursor(replay.Position{CreatedAt: last.CreatedAt, ID: last.ID}), nil
+
+	case replay.SourceOutbox:
+		afterID, err := job.Cursor.OutboxID()
+		if err != nil {
+			return nil, 0, "", err
+		}
+		messages, err := s.repo.ListOutbox(ctx, job.Window(), job.EventTypes, afterID, limit)
+		if err != nil || len(messages) == 0 {
+			return nil, 0, job.Cursor, err
+		}
+		events := make([]common.EventEnvelope, len(messages))
+		for i, message := range messages {
+			events[i] = message.Envelope()
+		}
+		return events, len(messages), replay.OutboxCursor(messages[len(messages)-1].ID), nil
+
+	case replay.SourceAudit:
+		after, err := job.Cursor.Position()
+		if err != nil {
+			return nil, 0, "", err
+		}
+		logs, err := s.repo.ListAuditLogs(ctx, job.Window(), job.EventTypes, after, limit)
+		if err != nil || len(logs) == 0 {
+			return nil, 0, job.Cursor, err
+		}
+		events := make([]common.EventEnvelope, len(logs))
+		for i, log := range logs {
+			event := audit.NewLoggedEvent(log)
+			event.ID = replayEventID(string(audit.EventTypeAuditLogged), log.ID.String())
+			event.Timestamp = log.CreatedAt
+			events[i] = event
+		}
+		last := logs[len(logs)-1]
+		return events, len(logs), replay.PositionCursor(replay.Position{CreatedAt: last.CreatedAt, ID: last.ID}), nil
+	}
+	return nil, 0, "", fmt.Errorf("%w: unknown source %q", replay.ErrInvalidJob, job.Source)
+}
+
+// snapshotEvents builds the events describing a user's current state, timed when each
+// change happened: registration at creation, the KYC decision at the last update and the
+// deletion. Users whose KYC is still pending get no user.kyc.updated event.
+func snapshotEvents(job *replay.Job, u *user.User) []common.EventEnvelope {
+	var events []common.EventEnvelope
+
+	if job.Includes(string(user.EventTypeUserRegistered)) {
+		event := user.NewTypedEvent(u.ID, user.RegisteredPayload{
+			Email:     u.Email,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Role:      u.Role,
+		})
+		event.ID = replayEventID(event.EventType(), u.ID.String())
+		event.Timestamp = u.CreatedAt
+		events = append(events, event)
+	}
+
+	if u.KYCStatus != user.KYCStatusPending && job.Includes(string(user.EventTypeUserKYCUpdated)) {
+		event := user.NewTypedEvent(u.ID, user.KYCUpdatedPayload{
+			Email:     u.Email,
+			KYCStatus: u.KYCStatus,
+		})
+		event.ID = replayEventID(event.EventType(), u.ID.String(), string(u.KYCStatus))
+		event.Timestamp = u.UpdatedAt
+		events = append(events, event)
+	}
+
+	if u.DeletedAt != nil && job.Includes(string(user.EventTypeUserDeleted)) {
+		event := user.NewTypedEvent(u.ID, user.DeletedPayload{DeletedAt: *u.DeletedAt})
+		event.ID = replayEventID(event.EventType(), u.ID.String())
+		event.Timestamp = *u.DeletedAt
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// replayEventID derives a stable event ID from the event type and the record it describes
+func replayEventID(eventType string, keys ...string) string {
+	name := eventType
+	for _, key := range keys {
+		name += ":" + key
+	}
+	return uuid.NewSHA1(replayNamespace, []byte(name)).String()
+}
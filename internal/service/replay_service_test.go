@@ -0,0 +1,646 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/replay"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryReplay is an in-memory replay.Repository with the same lease and paging rules as the SQL queries
+type memoryReplay struct {
+	mu        sync.Mutex
+	jobs      []*replay.Job
+	users     []*user.User
+	messages  []*outbox.Message
+	auditLogs []*audit.Log
+}
+
+func (r *memoryReplay) Create(ctx context.Context, req *replay.Request) (*replay.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := &replay.Job{
+		ID:           uuid.New(),
+		Source:       req.Source,
+		EventTypes:   req.EventTypes,
+		From:         req.From,
+		To:           req.To,
+		TargetStream: req.TargetStream,
+		RateLimit:    req.RateLimit,
+		BatchSize:    req.BatchSize,
+		Status:       replay.StatusPending,
+		RequestedBy:  req.RequestedBy,
+		CreatedAt:    time.Now().Add(time.Duration(len(r.jobs)) * time.Millisecond),
+	}
+	r.jobs = append(r.jobs, job)
+	copied := *job
+	return &copied, nil
+}
+
+func (r *memoryReplay) find(id uuid.UUID) *replay.Job {
+	for _, job := range r.jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+func (r *memoryReplay) GetByID(ctx context.Context, id uuid.UUID) (*replay.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.find(id)
+	if job == nil {
+		return nil, replay.ErrJobNotFound
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (r *memoryReplay) List(ctx context.Context, limit, offset int32) ([]*replay.Job, error) {
+	return nil, nil
+}
+
+func (r *memoryReplay) Claim(ctx context.Context, owner string, now, leaseUntil time.Time) (*replay.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range r.jobs {
+		expired := job.Status == replay.StatusRunning && job.LeaseExpiresAt.Before(now)
+		if job.Status != replay.StatusPending && !expired {
+			continue
+		}
+		job.Status = replay.StatusRunning
+		job.LeaseOwner = &owner
+		job.LeaseExpiresAt = &leaseUntil
+		copied := *job
+		return &copied, nil
+	}
+	return nil, replay.ErrNoJob
+}
+
+// held returns the job if owner holds its lease
+func (r *memoryReplay) held(id uuid.UUID, owner string) *replay.Job {
+	job := r.find(id)
+	if job == nil || job.Status != replay.StatusRunning || job.LeaseOwner == nil || *job.LeaseOwner != owner {
+		return nil
+	}
+	return job
+}
+
+func (r *memoryReplay) SetTotal(ctx context.Context, id uuid.UUID, owner string, total int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.held(id, owner)
+	if job == nil {
+		return replay.ErrLeaseLost
+	}
+	job.TotalRecords = total
+	return nil
+}
+
+func (r *memoryReplay) SaveProgress(ctx context.Context, progress *replay.Job, owner string, leaseUntil time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.held(progress.ID, owner)
+	if job == nil {
+		return replay.ErrLeaseLost
+	}
+	job.Cursor = progress.Cursor
+	job.ProcessedRecords = progress.ProcessedRecords
+	job.PublishedEvents = progress.PublishedEvents
+	job.LeaseExpiresAt = &leaseUntil
+	return nil
+}
+
+func (r *memoryReplay) Finish(ctx context.Context, id uuid.UUID, owner string, status replay.Status, lastError *string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.held(id, owner)
+	if job == nil {
+		return replay.ErrLeaseLost
+	}
+	job.Status = status
+	job.LastError = lastError
+	job.CompletedAt = &at
+	job.LeaseOwner, job.LeaseExpiresAt = nil, nil
+	return nil
+}
+
+func (r *memoryReplay) Cancel(ctx context.Context, id uuid.UUID) (*replay.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.find(id)
+	if job == nil {
+		return nil, replay.ErrJobNotFound
+	}
+	if job.Status != replay.StatusPending && job.Status != replay.StatusRunning {
+		return nil, replay.ErrJobNotCancellable
+	}
+	job.Status = replay.StatusCancelled
+	job.LeaseOwner, job.LeaseExpiresAt = nil, nil
+	copied := *job
+	return &copied, nil
+}
+
+func (r *memoryReplay) Resume(ctx context.Context, id uuid.UUID) (*replay.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.find(id)
+	if job == nil {
+		return nil, replay.ErrJobNotFound
+	}
+	if job.Status != replay.StatusFailed && job.Status != replay.StatusCancelled {
+		return nil, replay.ErrJobNotResumable
+	}
+	job.Status = replay.StatusPending
+	job.LastError = nil
+	copied := *job
+	return &copied, nil
+}
+
+func inWindow(window replay.Window, t time.Time) bool {
+	return (window.From == nil || !t.Before(*window.From)) && (window.To == nil || t.Before(*window.To))
+}
+
+func afterPosition(after *replay.Position, createdAt time.Time, id uuid.UUID) bool {
+	if after == nil {
+		return true
+	}
+	if !createdAt.Equal(after.CreatedAt) {
+		return createdAt.After(after.CreatedAt)
+	}
+	return id.String() > after.ID.String()
+}
+
+func matchesTypes(eventTypes []string, eventType string) bool {
+	if len(eventTypes) == 0 {
+		return true
+	}
+	for _, t := range eventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryReplay) sortedUsers() []*user.User {
+	users := append([]*user.User(nil), r.users...)
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].CreatedAt.Before(users[j].CreatedAt)
+		}
+		return users[i].ID.String() < users[j].ID.String()
+	})
+	return users
+}
+
+func (r *memoryReplay) CountUsers(ctx context.Context, window replay.Window) (int64, error) {
+	var count int64
+	for _, u := range r.users {
+		if inWindow(window, u.CreatedAt) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryReplay) ListUsers(ctx context.Context, window replay.Window, after *replay.Position, limit int32) ([]*user.User, error) {
+	var page []*user.User
+	for _, u := range r.sortedUsers() {
+		if inWindow(window, u.CreatedAt) && afterPosition(after, u.CreatedAt, u.ID) && len(page) < int(limit) {
+			page = append(page, u)
+		}
+	}
+	return page, nil
+}
+
+func (r *memoryReplay) CountOutbox(ctx context.Context, window replay.Window, eventTypes []string) (int64, error) {
+	var count int64
+	for _, m := range r.messages {
+		if inWindow(window, m.OccurredAt) && matchesTypes(eventTypes, m.EventType) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryReplay) ListOutbox(ctx context.Context, window replay.Window, eventTypes []string, afterID int64, limit int32) ([]*outbox.Message, error) {
+	var page []*outbox.Message
+	for _, m := range r.messages {
+		if inWindow(window, m.OccurredAt) && matchesTypes(eventTypes, m.EventType) && m.ID > afterID && len(page) < int(limit) {
+			page = append(page, m)
+		}
+	}
+	return page, nil
+}
+
+func (r *memoryReplay) CountAuditLogs(ctx context.Context, window replay.Window, eventTypes []string) (int64, error) {
+	var count int64
+	for _, l := range r.auditLogs {
+		if inWindow(window, l.CreatedAt) && matchesTypes(eventTypes, l.EventType) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryReplay) ListAuditLogs(ctx context.Context, window replay.Window, eventTypes []string, after *replay.Position, limit int32) ([]*audit.Log, error) {
+	var page []*audit.Log
+	for _, l := range r.auditLogs {
+		if inWindow(window, l.CreatedAt) && matchesTypes(eventTypes, l.EventType) && afterPosition(after, l.CreatedAt, l.ID) && len(page) < int(limit) {
+			page = append(page, l)
+		}
+	}
+	return page, nil
+}
+
+// replayPublisher records the events published to each target stream
+type replayPublisher struct {
+	mu        sync.Mutex
+	published map[string][]common.EventEnvelope
+	// fail, when set, is called before each publish; a non-nil error fails the publish
+	fail func(n int) error
+}
+
+func (p *replayPublisher) factory(target string) (common.EventPublisher, error) {
+	return &replayStreamPublisher{parent: p, target: target}, nil
+}
+
+func (p *replayPublisher) events(target string) []common.EventEnvelope {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]common.EventEnvelope(nil), p.published[target]...)
+}
+
+type replayStreamPublisher struct {
+	parent *replayPublisher
+	target string
+}
+
+func (s *replayStreamPublisher) Publish(event interface{}) error {
+	p := s.parent
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.fail != nil {
+		if err := p.fail(len(p.published[s.target])); err != nil {
+			return err
+		}
+	}
+	if p.published == nil {
+		p.published = map[string][]common.EventEnvelope{}
+	}
+	p.published[s.target] = append(p.published[s.target], event.(common.EventEnvelope))
+	return nil
+}
+
+func (s *replayStreamPublisher) PublishBatch(events []interface{}) error { return nil }
+func (s *replayStreamPublisher) Close() error                            { return nil }
+
+// newTestReplayService returns a replay service whose audit mock fails the test on any write
+// not registered with expectAudited
+func newTestReplayService(t *testing.T, repo *memoryReplay, publisher *replayPublisher) (*ReplayService, *mocks.MockAuditRepository) {
+	auditRepo := new(mocks.MockAuditRepository)
+	auditRepo.Test(t)
+	svc := NewReplayService(repo, auditRepo, publisher.factory, observability.NewLogger("dev", "test-service"), "worker-1")
+	return svc, auditRepo
+}
+
+func replayUsers(base time.Time) []*user.User {
+	deletedAt := base.Add(48 * time.Hour)
+	return []*user.User{
+		{ID: uuid.New(), Email: "a@example.com", Role: user.RoleUser, KYCStatus: user.KYCStatusVerified, CreatedAt: base, UpdatedAt: base.Add(time.Hour)},
+		{ID: uuid.New(), Email: "b@example.com", Role: user.RoleUser, KYCStatus: user.KYCStatusPending, CreatedAt: base.Add(time.Minute), UpdatedAt: base.Add(time.Minute)},
+		{ID: uuid.New(), Email: "c@example.com", Role: user.RoleUser, KYCStatus: user.KYCStatusRejected, CreatedAt: base.Add(2 * time.Minute), UpdatedAt: base.Add(3 * time.Minute), DeletedAt: &deletedAt},
+	}
+}
+
+func eventTypes(events []common.EventEnvelope) []string {
+	types := make([]string, len(events))
+	for i, event := range events {
+		types[i] = event.EventType()
+	}
+	return types
+}
+
+func TestReplayService_CreateJob(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("queues the job and records it", func(t *testing.T) {
+		repo := &memoryReplay{}
+		auditRepo := new(mocks.MockAuditRepository)
+		svc := NewReplayService(repo, auditRepo, (&replayPublisher{}).factory, observability.NewLogger("dev", "test-service"), "worker-1")
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.EventType == EventReplayJobCreated &&
+				l.ActorType == audit.ActorAdmin &&
+				*l.ActorIdentifier == "admin@test.com" &&
+				l.Metadata["target_stream"] == "replay:billing"
+		})).Return(&audit.Log{}, nil).Once()
+
+		job, err := svc.CreateJob(ctx, &replay.Request{Source: replay.SourceSnapshot, TargetStream: "replay:billing", RequestedBy: "admin@test.com"})
+
+		require.NoError(t, err)
+		assert.Equal(t, replay.StatusPending, job.Status)
+		assert.Equal(t, replay.DefaultRateLimit, job.RateLimit)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		repo := &memoryReplay{}
+		svc, auditRepo := newTestReplayService(t, repo, &replayPublisher{})
+
+		_, err := svc.CreateJob(ctx, &replay.Request{Source: "kafka", TargetStream: "replay:billing", RequestedBy: "admin@test.com"})
+
+		assert.ErrorIs(t, err, replay.ErrInvalidJob)
+		assert.Empty(t, repo.jobs)
+		auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReplayService_SnapshotReplay(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &memoryReplay{users: replayUsers(base)}
+	publisher := &replayPublisher{}
+	svc, auditRepo := newTestReplayService(t, repo, publisher)
+	expectAudited(auditRepo, EventReplayJobCreated, EventReplayJobCreated)
+
+	created, err := svc.CreateJob(ctx, &replay.Request{Source: replay.SourceSnapshot, TargetStream: "replay:billing", BatchSize: 2, RateLimit: 1000, RequestedBy: "admin@test.com"})
+	require.NoError(t, err)
+
+	job, err := svc.RunNext(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+
+	events := publisher.events("replay:billing")
+	assert.Equal(t, []string{
+		"user.registered", "user.kyc.updated",
+		"user.registered",
+		"user.registered", "user.kyc.updated", "user.deleted",
+	}, eventTypes(events))
+
+	for _, event := range events {
+		assert.True(t, replay.IsReplay(event))
+		assert.Equal(t, created.ID.String(), event.EventMetadata()[replay.AttributeReplayJobID])
+	}
+	assert.Equal(t, base, events[0].OccurredAt(), "registration is dated at account creation")
+	assert.Equal(t, base.Add(time.Hour), events[1].OccurredAt(), "the KYC decision is dated at the last update")
+	assert.Equal(t, string(user.KYCStatusRejected), events[4].EventPayload()["kyc_status"])
+
+	stored, err := svc.GetJob(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, replay.StatusCompleted, stored.Status)
+	assert.Equal(t, int64(3), stored.TotalRecords)
+	assert.Equal(t, int64(3), stored.ProcessedRecords)
+	assert.Equal(t, int64(6), stored.PublishedEvents)
+	assert.Equal(t, 1.0, stored.Progress())
+
+	// A second snapshot regenerates the same event IDs, so consumers can deduplicate
+	_, err = svc.CreateJob(ctx, &replay.Request{Source: replay.SourceSnapshot, TargetStream: "replay:again", RequestedBy: "admin@test.com"})
+	require.NoError(t, err)
+	_, err = svc.RunNext(ctx)
+	require.NoError(t, err)
+	again := publisher.events("replay:again")
+	require.Len(t, again, len(events))
+	for i := range events {
+		assert.Equal(t, events[i].EventID(), again[i].EventID())
+	}
+	auditRepo.AssertExpectations(t)
+}
+
+func TestReplayService_SnapshotReplayFiltersTypesAndRange(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &memoryReplay{users: replayUsers(base)}
+	publisher := &replayPublisher{}
+	svc, auditRepo := newTestReplayService(t, repo, publisher)
+	expectAudited(auditRepo, EventReplayJobCreated)
+
+	from := base.Add(time.Minute)
+	_, err := svc.CreateJob(ctx, &replay.Request{
+		Source:       replay.SourceSnapshot,
+		EventTypes:   []string{string(user.EventTypeUserKYCUpdated)},
+		From:         &from,
+		TargetStream: "replay:kyc",
+		RequestedBy:  "admin@test.com",
+	})
+	require.NoError(t, err)
+
+	_, err = svc.RunNext(ctx)
+	require.NoError(t, err)
+
+	events := publisher.events("replay:kyc")
+	require.Len(t, events, 1)
+	assert.Equal(t, "user.kyc.updated", events[0].EventType())
+	assert.Equal(t, repo.users[2].ID.String(), events[0].Attributes()["user_id"])
+	auditRepo.AssertExpectations(t)
+}
+
+func TestReplayService_OutboxReplayKeepsEventIDs(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &memoryReplay{}
+	for i, eventType := range []string{"user.registered", "user.logged_in", "user.kyc.updated", "user.registered"} {
+		repo.messages = append(repo.messages, &outbox.Message{
+			ID:         int64(i + 1),
+			EventID:    uuid.NewString(),
+			EventType:  eventType,
+			OccurredAt: base.Add(time.Duration(i) * time.Hour),
+			Attributes: map[string]string{"user_id": "u1"},
+			Payload:    map[string]interface{}{},
+		})
+	}
+	publisher := &replayPublisher{}
+	svc, auditRepo := newTestReplayService(t, repo, publisher)
+	expectAudited(auditRepo, EventReplayJobCreated)
+
+	to := base.Add(3 * time.Hour)
+	_, err := svc.CreateJob(ctx, &replay.Request{
+		Source:       replay.SourceOutbox,
+		EventTypes:   []string{"user.registered", "user.kyc.updated"},
+		To:           &to,
+		TargetStream: "replay:history",
+		RequestedBy:  "admin@test.com",
+	})
+	require.NoError(t, err)
+
+	job, err := svc.RunNext(ctx)
+	require.NoError(t, err)
+
+	events := publisher.events("replay:history")
+	require.Len(t, events, 2)
+	assert.Equal(t, repo.messages[0].EventID, events[0].EventID())
+	assert.Equal(t, repo.messages[2].EventID, events[1].EventID())
+	assert.Equal(t, "u1", events[1].Attributes()["user_id"])
+	assert.Equal(t, "true", events[1].Attributes()[replay.AttributeReplay])
+
+	stored, _ := svc.GetJob(ctx, job.ID)
+	assert.Equal(t, replay.Cursor("3"), stored.Cursor)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestReplayService_AuditReplay(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	log := &audit.Log{ID: uuid.New(), EventType: "admin.login.failed", Severity: audit.SeverityHigh, CreatedAt: base}
+	repo := &memoryReplay{auditLogs: []*audit.Log{log}}
+	publisher := &replayPublisher{}
+	svc, auditRepo := newTestReplayService(t, repo, publisher)
+	expectAudited(auditRepo, EventReplayJobCreated)
+
+	_, err := svc.CreateJob(ctx, &replay.Request{Source: replay.SourceAudit, TargetStream: "replay:audit", RequestedBy: "admin@test.com"})
+	require.NoError(t, err)
+	_, err = svc.RunNext(ctx)
+	require.NoError(t, err)
+
+	events := publisher.events("replay:audit")
+	require.Len(t, events, 1)
+	assert.Equal(t, "audit.logged", events[0].EventType())
+	assert.Equal(t, base, events[0].OccurredAt())
+	assert.Equal(t, "admin.login.failed", events[0].EventPayload()["event_type"])
+	assert.Equal(t, replayEventID("audit.logged", log.ID.String()), events[0].EventID())
+	auditRepo.AssertExpectations(t)
+}
+
+func TestReplayService_ResumesFailedJobFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &memoryReplay{}
+	for i := 0; i < 5; i++ {
+		repo.messages = append(repo.messages, &outbox.Message{ID: int64(i + 1), EventID: uuid.NewString(), EventType: "user.registered", OccurredAt: base})
+	}
+	publisher := &replayPublisher{}
+	publisher.fail = func(n int) error {
+		if n == 3 {
+			return errors.New("stream unavailable")
+		}
+		return nil
+	}
+	svc, auditRepo := newTestReplayService(t, repo, publisher)
+	expectAudited(auditRepo, EventReplayJobCreated, EventReplayJobResumed)
+
+	created, err := svc.CreateJob(ctx, &replay.Request{Source: replay.SourceOutbox, TargetStream: "replay:history", BatchSize: 2, RequestedBy: "admin@test.com"})
+	require.NoError(t, err)
+
+	_, err = svc.RunNext(ctx)
+	require.Error(t, err)
+
+	failed, _ := svc.GetJob(ctx, created.ID)
+	assert.Equal(t, replay.StatusFailed, failed.Status)
+	assert.Contains(t, *failed.LastError, "stream unavailable")
+	assert.Equal(t, replay.Cursor("2"), failed.Cursor, "the checkpoint is the last completed page")
+	assert.Equal(t, int64(2), failed.ProcessedRecords)
+	assert.Equal(t, 0.4, failed.Progress())
+
+	publisher.fail = nil
+	_, err = svc.ResumeJob(ctx, created.ID, "admin@test.com")
+	require.NoError(t, err)
+	_, err = svc.RunNext(ctx)
+	require.NoError(t, err)
+
+	completed, _ := svc.GetJob(ctx, created.ID)
+	assert.Equal(t, replay.StatusCompleted, completed.Status)
+	assert.Equal(t, int64(5), completed.ProcessedRecords)
+	assert.Equal(t, int64(5), completed.TotalRecords, "resuming keeps the original total")
+
+	var ids []string
+	for _, event := range publisher.events("replay:history") {
+		ids = append(ids, event.EventID())
+	}
+	assert.Len(t, ids, 6, "the interrupted page is published again")
+	assert.Equal(t, repo.messages[2].EventID, ids[2])
+	assert.Equal(t, repo.messages[2].EventID, ids[3])
+	auditRepo.AssertExpectations(t)
+}
+
+func TestReplayService_StopsWhenCancelled(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &memoryReplay{}
+	for i := 0; i < 6; i++ {
+		repo.messages = append(repo.messages, &outbox.Message{ID: int64(i + 1), EventID: uuid.NewString(), EventType: "user.registered", OccurredAt: base})
+	}
+	publisher := &replayPublisher{}
+	svc, auditRepo := newTestReplayService(t, repo, publisher)
+	expectAudited(auditRepo, EventReplayJobCreated, EventReplayJobCancelled)
+
+	created, err := svc.CreateJob(ctx, &replay.Request{Source: replay.SourceOutbox, TargetStream: "replay:history", BatchSize: 2, RequestedBy: "admin@test.com"})
+	require.NoError(t, err)
+
+	publisher.fail = func(n int) error {
+		if n == 1 {
+			_, _ = svc.CancelJob(ctx, created.ID, "admin@test.com")
+		}
+		return nil
+	}
+
+	_, err = svc.RunNext(ctx)
+	require.NoError(t, err)
+
+	cancelled, _ := svc.GetJob(ctx, created.ID)
+	assert.Equal(t, replay.StatusCancelled, cancelled.Status)
+	assert.Len(t, publisher.events("replay:history"), 2, "the job stops at the next checkpoint")
+
+	_, err = svc.CancelJob(ctx, created.ID, "admin@test.com")
+	assert.ErrorIs(t, err, replay.ErrJobNotCancellable)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestReplayService_RateLimit(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &memoryReplay{}
+	for i := 0; i < 12; i++ {
+		repo.messages = append(repo.messages, &outbox.Message{ID: int64(i + 1), EventID: uuid.NewString(), EventType: "user.registered", OccurredAt: base})
+	}
+	publisher := &replayPublisher{}
+	svc, auditRepo := newTestReplayService(t, repo, publisher)
+	expectAudited(auditRepo, EventReplayJobCreated)
+
+	_, err := svc.CreateJob(ctx, &replay.Request{Source: replay.SourceOutbox, TargetStream: "replay:history", RateLimit: 10, RequestedBy: "admin@test.com"})
+	require.NoError(t, err)
+
+	// One second's allowance goes out at once; the two events beyond it wait 100ms each
+	started := time.Now()
+	_, err = svc.RunNext(ctx)
+	require.NoError(t, err)
+
+	assert.Len(t, publisher.events("replay:history"), 12)
+	assert.GreaterOrEqual(t, time.Since(started), 150*time.Millisecond)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestReplayService_WorkerRunsQueuedJobs(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryReplay{users: replayUsers(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))}
+	publisher := &replayPublisher{}
+	svc, auditRepo := newTestReplayService(t, repo, publisher)
+	expectAudited(auditRepo, EventReplayJobCreated)
+	svc.WithPollInterval(10 * time.Millisecond)
+
+	created, err := svc.CreateJob(ctx, &replay.Request{Source: replay.SourceSnapshot, TargetStream: "replay:billing", RequestedBy: "admin@test.com"})
+	require.NoError(t, err)
+
+	svc.Start(ctx)
+	defer svc.Stop()
+
+	require.Eventually(t, func() bool {
+		job, err := svc.GetJob(ctx, created.ID)
+		return err == nil && job.Status == replay.StatusCompleted
+	}, 2*time.Second, 10*time.Millisecond)
+	auditRepo.AssertExpectations(t)
+}
@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+)
+
+// Audit events recorded for screening
+const (
+	EventScreeningCaseOpened        = "screening.case.opened"
+	EventScreeningCaseCleared       = "screening.case.cleared"
+	EventScreeningCaseConfirmed     = "screening.case.confirmed"
+	EventScreeningRescreenCompleted = "screening.rescreen.completed"
+)
+
+// screeningActor identifies the screening subsystem in audit logs
+const screeningActor = "screening"
+
+// rescreenPageSize is how many users RescreenAll loads at a time
+const rescreenPageSize = 500
+
+// Compile-time check to ensure ScreeningService implements screening.Service
+var _ screening.Service = (*ScreeningService)(nil)
+
+// ScreeningService screens users against the sanctions and PEP watch lists and runs the
+// review of their matches. Users are screened with their profile name and, once they applied
+// for KYC, their legal name and date of birth. Opening and deciding cases is audited.
+type ScreeningService struct {
+	cases      screening.Repository
+	users      userDomain.Repository
+	kycCases   kyc.Repository
+	auditRepo  audit.Repository
+	logger     *observability.Logger
+	thresholds screening.Thresholds
+	now        func() time.Time
+
+	listMu sync.RWMutex
+	list   *screening.WatchList
+
+	// screenMu serializes screenings so two of them cannot open cases for the same entries
+	screenMu sync.Mutex
+}
+
+// NewScreeningService creates a new screening service. It screens nobody until lists are
+// installed with SetWatchList.
+//
+// Parameters:
+//   - cases: Screening case storage
+//   - users: User lookups (profile names, paging through users to re-screen)
+//   - kycCases: KYC cases holding the applicant's legal name and date of birth
+//   - auditRepo: Audit log receiving case openings and decisions
+//   - logger: Structured logger
+//   - thresholds: Matching thresholds (see screening.DefaultThresholds)
+func NewScreeningService(
+	cases screening.Repository,
+	users userDomain.Repository,
+	kycCases kyc.Repository,
+	auditRepo audit.Repository,
+	logger *observability.Logger,
+	thresholds screening.Thresholds,
+) *ScreeningService {
+	return &ScreeningService{
+		cases:      cases,
+		users:      users,
+		kycCases:   kycCases,
+		auditRepo:  auditRepo,
+		logger:     logger,
+		thresholds: thresholds,
+		now:        time.Now,
+	}
+}
+
+// SetWatchList replaces the lists users are screened against
+func (s *ScreeningService) SetWatchList(l *screening.WatchList) {
+	s.listMu.Lock()
+	defer s.listMu.Unlock()
+	s.list = l
+}
+
+// WatchList returns the lists currently screened against, or nil before the first load
+func (s *ScreeningService) WatchList() *screening.WatchList {
+	s.listMu.RLock()
+	defer s.listMu.RUnlock()
+	return s.list
+}
+
+// ScreenUser matches the user against the lists and opens a case for entries not reviewed
+// for them before
+func (s *ScreeningService) ScreenUser(ctx context.Context, userID uuid.UUID, trigger screening.Trigger) (*screening.Case, error) {
+	list := s.WatchList()
+	if list == nil {
+		return nil, screening.ErrListsNotLoaded
+	}
+	subject, err := s.subject(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	matches := screening.Screen(subject, list, s.thresholds)
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	s.screenMu.Lock()
+	defer s.screenMu.Unlock()
+
+	previous, err := s.cases.ListForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	reviewed := make(map[string]bool)
+	for _, c := range previous {
+		for _, m := range c.Matches {
+			reviewed[m.Key()] = true
+		}
+	}
+	fresh := matches[:0]
+	for _, m := range matches {
+		if !reviewed[m.Key()] {
+			fresh = append(fresh, m)
+		}
+	}
+	if len(fresh) == 0 {
+		return nil, nil
+	}
+
+	created, err := s.cases.Create(ctx, screening.NewCase(subject, fresh, trigger, list.Version, s.now()))
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"case_id": created.ID.String(),
+		"user_id": userID.String(),
+		"trigger": trigger,
+		"matches": len(fresh),
+	}).Warn("Screening matched user against watch lists")
+	s.recordCaseAction(ctx, EventScreeningCaseOpened, "open", created, audit.ActorSystem, screeningActor, map[string]interface{}{
+		"trigger":      trigger,
+		"list_version": created.ListVersion,
+		"entries":      matchKeys(created.Matches),
+	})
+	return created, nil
+}
+
+// ApprovalBlocked screens the user with their current data and reports whether an open or
+// confirmed case blocks approval
+func (s *ScreeningService) ApprovalBlocked(ctx context.Context, userID uuid.UUID) (bool, error) {
+	if _, err := s.ScreenUser(ctx, userID, screening.TriggerKYCApproval); err != nil {
+		return false, err
+	}
+	cases, err := s.cases.ListForUser(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range cases {
+		if c.Status.BlocksApproval() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RescreenAll screens every user against the current lists, as after the lists changed.
+// Failures for single users are logged and do not stop the run.
+func (s *ScreeningService) RescreenAll(ctx context.Context) error {
+	list := s.WatchList()
+	if list == nil {
+		return screening.ErrListsNotLoaded
+	}
+
+	started := s.now()
+	screened, opened, failed := 0, 0, 0
+	for offset := 0; ; offset += rescreenPageSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		users, err := s.users.List(ctx, rescreenPageSize, offset)
+		if err != nil {
+			return err
+		}
+		for _, u := range users {
+			c, err := s.ScreenUser(ctx, u.ID, screening.TriggerRescreen)
+			if err != nil {
+				failed++
+				s.logger.WithError(err).WithField("user_id", u.ID.String()).Error("Failed to re-screen user")
+				continue
+			}
+			screened++
+			if c != nil {
+				opened++
+			}
+		}
+		if len(users) < rescreenPageSize {
+			break
+		}
+	}
+
+	fields := map[string]interface{}{
+		"list_version": list.Version,
+		"screened":     screened,
+		"cases_opened": opened,
+		"failed":       failed,
+		"duration_ms":  s.now().Sub(started).Milliseconds(),
+	}
+	s.logger.WithFields(fields).Info("Re-screened users against watch lists")
+	actorID := screeningActor
+	s.record(ctx, &audit.Log{
+		EventType:       EventScreeningRescreenCompleted,
+		ActorType:       audit.ActorSystem,
+		ActorIdentifier: &actorID,
+		Action:          "rescreen",
+		Metadata:        fields,
+		Status:          audit.StatusSuccess,
+	})
+	return nil
+}
+
+// ListCases retrieves the review queue and the number of matching cases
+func (s *ScreeningService) ListCases(ctx context.Context, filter screening.ListFilter) ([]*screening.Case, int64, error) {
+	cases, err := s.cases.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.cases.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return cases, total, nil
+}
+
+// GetCase retrieves a case
+func (s *ScreeningService) GetCase(ctx context.Context, caseID uuid.UUID) (*screening.Case, error) {
+	return s.cases.GetByID(ctx, caseID)
+}
+
+// Clear closes a case as a false positive
+func (s *ScreeningService) Clear(ctx context.Context, caseID uuid.UUID, note string, actor screening.Actor) (*screening.Case, error) {
+	return s.decide(ctx, caseID, actor, EventScreeningCaseCleared, "clear", func(c *screening.Case) error {
+		return c.Clear(actor.ID, note, s.now())
+	})
+}
+
+// Confirm closes a case as a true match
+func (s *ScreeningService) Confirm(ctx context.Context, caseID uuid.UUID, note string, actor screening.Actor) (*screening.Case, error) {
+	return s.decide(ctx, caseID, actor, EventScreeningCaseConfirmed, "confirm", func(c *screening.Case) error {
+		return c.Confirm(actor.ID, note, s.now())
+	})
+}
+
+func (s *ScreeningService) decide(ctx context.Context, caseID uuid.UUID, actor screening.Actor, eventType, action string, apply func(c *screening.Case) error) (*screening.Case, error) {
+	c, err := s.cases.GetByID(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := apply(c); err != nil {
+		return nil, err
+	}
+	updated, err := s.cases.Update(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"case_id":  caseID.String(),
+		"user_id":  updated.UserID.String(),
+		"status":   updated.Status,
+		"admin_id": actor.ID.String(),
+	}).Info("Screening case decided")
+
+	actorID := actor.Email
+	if actorID == "" {
+		actorID = actor.ID.String()
+	}
+	s.recordCaseAction(ctx, eventType, action, updated, audit.ActorAdmin, actorID, map[string]interface{}{
+		"note":    *updated.ReviewNote,
+		"entries": matchKeys(updated.Matches),
+	})
+	return updated, nil
+}
+
+// subject collects what the user is screened with: their profile name and date of birth
+// and, from their latest KYC case, their legal name and date of birth
+func (s *ScreeningService) subject(ctx context.Context, userID uuid.UUID) (screening.Subject, error) {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return screening.Subject{}, err
+	}
+	subject := screening.Subject{UserID: userID, DateOfBirth: u.DateOfBirth}
+	subject.Names = appendName(subject.Names, u.FirstName, u.LastName)
+
+	c, err := s.kycCases.GetLatestForUser(ctx, userID)
+	switch {
+	case errors.Is(err, kyc.ErrCaseNotFound):
+	case err != nil:
+		return screening.Subject{}, err
+	default:
+		subject.Names = appendName(subject.Names, c.Applicant.FirstName, c.Applicant.LastName)
+		// The date of birth checked during KYC takes precedence over the one in the profile
+		if c.Applicant.DateOfBirth != "" {
+			subject.DateOfBirth = c.Applicant.DateOfBirth
+		}
+	}
+	return subject, nil
+}
+
+// appendName adds a full name to names unless it is empty or already there
+func appendName(names []string, first, last string) []string {
+	name := strings.TrimSpace(strings.TrimSpace(first) + " " + strings.TrimSpace(last))
+	if name == "" {
+		return names
+	}
+	for _, existing := range names {
+		if strings.EqualFold(existing, name) {
+			return names
+		}
+	}
+	return append(names, name)
+}
+
+func matchKeys(matches []screening.Match) []string {
+	keys := make([]string, len(matches))
+	for i, m := range matches {
+		keys[i] = m.Key()
+	}
+	return keys
+}
+
+// recordCaseAction writes an action on a case to the audit log
+func (s *ScreeningService) recordCaseAction(ctx context.Context, eventType, action string, c *screening.Case, actorType audit.ActorType, actorID string, extra map[string]interface{}) {
+	resourceType := "screening_case"
+	resourceID := c.ID.String()
+
+	metadata := map[string]interface{}{
+		"status": c.Status,
+	}
+	for k, v := range extra {
+		metadata[k] = v
+	}
+
+	s.record(ctx, &audit.Log{
+		EventType:       eventType,
+		UserID:          &c.UserID,
+		ActorType:       actorType,
+		ActorIdentifier: &actorID,
+		Action:          action,
+		ResourceType:    &resourceType,
+		ResourceID:      &resourceID,
+		Metadata:        metadata,
+		Status:          audit.StatusSuccess,
+	})
+}
+
+// record writes entry to the audit log under the compliance category; failures are logged
+// and do not fail the action
+func (s *ScreeningService) record(ctx context.Context, entry *audit.Log) {
+	entry.EventCategory = audit.CategoryCompliance
+	entry.Severity = audit.SeverityInfo
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		s.logger.WithError(err).WithField("event_type", entry.EventType).Error("Failed to record screening action in audit log")
+	}
+}
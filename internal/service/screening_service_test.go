@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// memoryScreening is an in-memory screening.Repository
+type memoryScreening struct {
+	mu    sync.Mutex
+	cases map[uuid.UUID]screening.Case
+}
+
+func newMemoryScreening() *memoryScreening {
+	return &memoryScreening{cases: make(map[uuid.UUID]screening.Case)}
+}
+
+func (r *memoryScreening) Create(ctx context.Context, c *screening.Case) (*screening.Case, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	created := *c
+	created.ID = uuid.New()
+	created.Version = 1
+	created.CreatedAt = c.CreatedAt.Add(time.Duration(len(r.cases)) * time.Millisecond)
+	r.cases[created.ID] = created
+	return &created, nil
+}
+
+func (r *memoryScreening) GetByID(ctx context.Context, id uuid.UUID) (*screening.Case, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.cases[id]
+	if !ok {
+		return nil, screening.ErrCaseNotFound
+	}
+	return &c, nil
+}
+
+func (r *memoryScreening) List(ctx context.Context, filter screening.ListFilter) ([]*screening.Case, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var cases []*screening.Case
+	for _, c := range r.cases {
+		if (filter.Status == nil || c.Status == *filter.Status) && (filter.UserID == nil || c.UserID == *filter.UserID) {
+			copied := c
+			cases = append(cases, &copied)
+		}
+	}
+	sort.Slice(cases, func(i, j int) bool { return cases[i].CreatedAt.Before(cases[j].CreatedAt) })
+	return cases, nil
+}
+
+func (r *memoryScreening) Count(ctx context.Context, filter screening.ListFilter) (int64, error) {
+	cases, err := r.List(ctx, filter)
+	return int64(len(cases)), err
+}
+
+func (r *memoryScreening) ListForUser(ctx context.Context, userID uuid.UUID) ([]*screening.Case, error) {
+	cases, err := r.List(ctx, screening.ListFilter{UserID: &userID})
+	for i, j := 0, len(cases)-1; i < j; i, j = i+1, j-1 {
+		cases[i], cases[j] = cases[j], cases[i]
+	}
+	return cases, err
+}
+
+func (r *memoryScreening) Update(ctx context.Context, c *screening.Case) (*screening.Case, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cases[c.ID].Version != c.Version {
+		return nil, screening.ErrCaseConflict
+	}
+	updated := *c
+	updated.Version++
+	r.cases[c.ID] = updated
+	return &updated, nil
+}
+
+// screeningFixture is a screening service with watch lists loaded
+type screeningFixture struct {
+	svc       *ScreeningService
+	repo      *memoryScreening
+	kycCases  *memoryKYC
+	auditRepo *mocks.MockAuditRepository
+	users     map[uuid.UUID]*userDomain.User
+	admin     screening.Actor
+}
+
+func newScreeningFixture(t *testing.T) *screeningFixture {
+	t.Helper()
+	f := &screeningFixture{users: make(map[uuid.UUID]*userDomain.User)}
+	f.admin = screening.Actor{ID: uuid.New(), Email: "compliance@example.com"}
+
+	// Re-screening pages through every user in email order
+	userRepo := newUserDirectory(t, f.users)
+	userRepo.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, limit, offset int) ([]*userDomain.User, error) {
+		var all []*userDomain.User
+		for _, u := range f.users {
+			all = append(all, u)
+		}
+		sort.Slice(all, func(i, j int) bool { return all[i].Email < all[j].Email })
+		if offset >= len(all) {
+			return nil, nil
+		}
+		return all[offset:min(offset+limit, len(all))], nil
+	}).AnyTimes()
+
+	f.repo = newMemoryScreening()
+	f.kycCases = newMemoryKYC(userRepo)
+	f.auditRepo = newStrictAuditRepo(t)
+	f.svc = NewScreeningService(f.repo, userRepo, f.kycCases, f.auditRepo, observability.NewLogger("dev", "test-service"), screening.DefaultThresholds())
+	f.svc.SetWatchList(testWatchList("v1"))
+	return f
+}
+
+func testWatchList(version string) *screening.WatchList {
+	return screening.NewWatchList([]screening.Entry{
+		{Source: screening.SourceOFACSDN, ID: "9647", Kind: screening.KindSanctions, Names: []string{"IVANOV, Sergei Borisovich"}, DatesOfBirth: []string{"1953"}},
+		{Source: screening.SourcePEP, ID: "pep-1", Kind: screening.KindPEP, Names: []string{"Maria Popescu"}},
+	}, version, time.Now())
+}
+
+func (f *screeningFixture) addUser(first, last, email string) *userDomain.User {
+	u := &userDomain.User{ID: uuid.New(), Email: email, FirstName: first, LastName: last, Role: userDomain.RoleUser}
+	f.users[u.ID] = u
+	return u
+}
+
+func TestScreeningService_ScreenUser(t *testing.T) {
+	f := newScreeningFixture(t)
+	ctx := context.Background()
+
+	clean := f.addUser("Jane", "Doe", "jane@example.com")
+	c, err := f.svc.ScreenUser(ctx, clean.ID, screening.TriggerRegistration)
+	require.NoError(t, err)
+	assert.Nil(t, c, "no match, no case")
+
+	listed := f.addUser("Sergey", "Ivanov", "sergey@example.com")
+	f.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		return l.EventType == EventScreeningCaseOpened && l.EventCategory == audit.CategoryCompliance &&
+			l.ActorType == audit.ActorSystem && *l.UserID == listed.ID
+	})).Return(&audit.Log{}, nil).Once()
+	c, err = f.svc.ScreenUser(ctx, listed.ID, screening.TriggerRegistration)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	assert.Equal(t, screening.StatusOpen, c.Status)
+	assert.Equal(t, screening.TriggerRegistration, c.Trigger)
+	assert.Equal(t, "v1", c.ListVersion)
+	require.Len(t, c.Matches, 1)
+	assert.Equal(t, "ofac_sdn:9647", c.Matches[0].Key())
+	assert.Equal(t, []string{"Sergey Ivanov"}, c.Subject.Names)
+
+	again, err := f.svc.ScreenUser(ctx, listed.ID, screening.TriggerProfileChange)
+	require.NoError(t, err)
+	assert.Nil(t, again, "entries already in a case do not open another")
+
+	_, err = f.svc.ScreenUser(ctx, uuid.New(), screening.TriggerManual)
+	assert.ErrorIs(t, err, userDomain.ErrNotFound)
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestScreeningService_UsesKYCLegalNameAndDateOfBirth(t *testing.T) {
+	f := newScreeningFixture(t)
+	ctx := context.Background()
+	u := f.addUser("Sam", "Smith", "sam@example.com")
+
+	applicant := kycApplicant()
+	applicant.FirstName, applicant.LastName, applicant.DateOfBirth = "Sergei", "Ivanov", "1990-01-01"
+	_, err := f.kycCases.Create(ctx, &kyc.Case{UserID: u.ID, Status: kyc.StatusDraft, Applicant: applicant})
+	require.NoError(t, err)
+
+	c, err := f.svc.ScreenUser(ctx, u.ID, screening.TriggerKYCApproval)
+	require.NoError(t, err)
+	assert.Nil(t, c, "a date of birth far from the listed one rules the entry out")
+
+	f.kycCases.cases = make(map[uuid.UUID]kyc.Case)
+	applicant.DateOfBirth = "1953-07-01"
+	expectAudited(f.auditRepo, EventScreeningCaseOpened)
+	_, err = f.kycCases.Create(ctx, &kyc.Case{UserID: u.ID, Status: kyc.StatusDraft, Applicant: applicant})
+	require.NoError(t, err)
+
+	c, err = f.svc.ScreenUser(ctx, u.ID, screening.TriggerKYCApproval)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	assert.Equal(t, []string{"Sam Smith", "Sergei Ivanov"}, c.Subject.Names)
+	assert.Equal(t, "1953-07-01", c.Subject.DateOfBirth)
+	assert.True(t, c.Matches[0].DOBMatched)
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestScreeningService_Decisions(t *testing.T) {
+	f := newScreeningFixture(t)
+	ctx := context.Background()
+	u := f.addUser("Maria", "Popescu", "maria@example.com")
+	expectAudited(f.auditRepo, EventScreeningCaseOpened)
+
+	blocked, err := f.svc.ApprovalBlocked(ctx, u.ID)
+	require.NoError(t, err)
+	assert.True(t, blocked, "approval screens the user and the new case blocks it")
+
+	open := screening.StatusOpen
+	cases, total, err := f.svc.ListCases(ctx, screening.ListFilter{Status: &open, Limit: 20})
+	require.NoError(t, err)
+	require.Len(t, cases, 1)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, screening.TriggerKYCApproval, cases[0].Trigger)
+
+	_, err = f.svc.Clear(ctx, cases[0].ID, "", f.admin)
+	assert.ErrorIs(t, err, screening.ErrInvalidDecision)
+
+	f.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		return l.EventType == EventScreeningCaseCleared && l.EventCategory == audit.CategoryCompliance &&
+			l.ActorType == audit.ActorAdmin && *l.ActorIdentifier == "compliance@example.com"
+	})).Return(&audit.Log{}, nil).Once()
+	cleared, err := f.svc.Clear(ctx, cases[0].ID, "different person, date of birth differs", f.admin)
+	require.NoError(t, err)
+	assert.Equal(t, screening.StatusCleared, cleared.Status)
+	assert.Equal(t, f.admin.ID, *cleared.ReviewedBy)
+
+	blocked, err = f.svc.ApprovalBlocked(ctx, u.ID)
+	require.NoError(t, err)
+	assert.False(t, blocked, "cleared entries are not raised again")
+
+	_, err = f.svc.Confirm(ctx, cases[0].ID, "second thoughts", f.admin)
+	assert.ErrorIs(t, err, screening.ErrCaseAlreadyDecided)
+
+	_, err = f.svc.GetCase(ctx, uuid.New())
+	assert.ErrorIs(t, err, screening.ErrCaseNotFound)
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestScreeningService_ConfirmedCaseKeepsBlocking(t *testing.T) {
+	f := newScreeningFixture(t)
+	ctx := context.Background()
+	u := f.addUser("Maria", "Popescu", "maria@example.com")
+
+	expectAudited(f.auditRepo, EventScreeningCaseOpened)
+	f.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		return l.EventType == EventScreeningCaseConfirmed && l.Metadata["note"] == "matches the listed minister"
+	})).Return(&audit.Log{}, nil).Once()
+
+	c, err := f.svc.ScreenUser(ctx, u.ID, screening.TriggerManual)
+	require.NoError(t, err)
+	confirmed, err := f.svc.Confirm(ctx, c.ID, "matches the listed minister", f.admin)
+	require.NoError(t, err)
+	assert.Equal(t, screening.StatusConfirmed, confirmed.Status)
+
+	blocked, err := f.svc.ApprovalBlocked(ctx, u.ID)
+	require.NoError(t, err)
+	assert.True(t, blocked)
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestScreeningService_RescreenAll(t *testing.T) {
+	f := newScreeningFixture(t)
+	ctx := context.Background()
+	f.addUser("Jane", "Doe", "jane@example.com")
+	listed := f.addUser("Olga", "Petrenko", "olga@example.com")
+
+	f.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		return l.EventType == EventScreeningRescreenCompleted && l.Metadata["cases_opened"] == 0 && l.Metadata["screened"] == 2
+	})).Return(&audit.Log{}, nil).Once()
+	require.NoError(t, f.svc.RescreenAll(ctx))
+	total, err := f.repo.Count(ctx, screening.ListFilter{})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	list := testWatchList("v2")
+	list = screening.NewWatchList(append(list.Entries, screening.Entry{
+		Source: screening.SourceEUConsolidated, ID: "EU.1.1", Kind: screening.KindSanctions, Names: []string{"Ольга Петренко"},
+	}), "v2", time.Now())
+	f.svc.SetWatchList(list)
+	assert.Equal(t, "v2", f.svc.WatchList().Version)
+
+	expectAudited(f.auditRepo, EventScreeningCaseOpened)
+	f.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		return l.EventType == EventScreeningRescreenCompleted && l.Metadata["cases_opened"] == 1 && l.Metadata["screened"] == 2
+	})).Return(&audit.Log{}, nil).Once()
+	require.NoError(t, f.svc.RescreenAll(ctx))
+	cases, err := f.repo.ListForUser(ctx, listed.ID)
+	require.NoError(t, err)
+	require.Len(t, cases, 1)
+	assert.Equal(t, screening.TriggerRescreen, cases[0].Trigger)
+	assert.Equal(t, "v2", cases[0].ListVersion)
+	f.auditRepo.AssertExpectations(t)
+}
+
+func TestScreeningService_ListsNotLoaded(t *testing.T) {
+	f := newScreeningFixture(t)
+	f.svc.SetWatchList(nil)
+	u := f.addUser("Jane", "Doe", "jane@example.com")
+
+	_, err := f.svc.ScreenUser(context.Background(), u.ID, screening.TriggerRegistration)
+	assert.ErrorIs(t, err, screening.ErrListsNotLoaded)
+	_, err = f.svc.ApprovalBlocked(context.Background(), u.ID)
+	assert.ErrorIs(t, err, screening.ErrListsNotLoaded, "approval fails closed without lists")
+	assert.ErrorIs(t, f.svc.RescreenAll(context.Background()), screening.ErrListsNotLoaded)
+	f.auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// stubGate blocks approval of the users in blocked. With repo set it counts the checks made
+// outside repo's transactions.
+type stubGate struct {
+	blocked   map[uuid.UUID]bool
+	err       error
+	repo      *memoryKYC
+	outsideTx int
+}
+
+func (g *stubGate) ApprovalBlocked(ctx context.Context, userID uuid.UUID) (bool, error) {
+	if g.repo != nil && !g.repo.inTx {
+		g.outsideTx++
+	}
+	return g.blocked[userID], g.err
+}
+
+func TestKYCService_ApprovalWaitsForScreening(t *testing.T) {
+	f := newKYCFixture(t)
+	gate := &stubGate{blocked: map[uuid.UUID]bool{f.applicant.ID: true}, repo: f.repo}
+	f.svc.WithScreening(gate)
+	c := f.inReview(t, kyc.RiskLow)
+
+	_, err := f.svc.Approve(context.Background(), c.ID, "documents match", f.reviewer)
+	assert.ErrorIs(t, err, kyc.ErrScreeningHitsOpen)
+	unchanged, err := f.svc.GetCase(context.Background(), c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusInReview, unchanged.Status)
+
+	gate.err = errors.New("screening unavailable")
+	_, err = f.svc.Approve(context.Background(), c.ID, "documents match", f.reviewer)
+	assert.Error(t, err, "approval fails closed when screening fails")
+
+	gate.err = nil
+	gate.blocked[f.applicant.ID] = false
+	expectAudited(f.auditRepo, EventKYCCaseApproved)
+	approved, err := f.svc.Approve(context.Background(), c.ID, "documents match", f.reviewer)
+	require.NoError(t, err)
+	assert.Equal(t, kyc.StatusApproved, approved.Status)
+	assert.Zero(t, gate.outsideTx, "screening is checked within the approval's transaction")
+	f.auditRepo.AssertExpectations(t)
+}
+
+// recordingScreener records the screenings it is asked for
+type recordingScreener struct {
+	triggers []screening.Trigger
+	err      error
+}
+
+func (s *recordingScreener) ScreenUser(ctx context.Context, userID uuid.UUID, trigger screening.Trigger) (*screening.Case, error) {
+	s.triggers = append(s.triggers, trigger)
+	return nil, s.err
+}
+
+func TestUserService_ScreensOnRegistrationAndProfileChange(t *testing.T) {
+	svc, userRepo, _, _, _ := newTestUserServiceWithOutbox(t)
+	screener := &recordingScreener{err: screening.ErrListsNotLoaded}
+	svc.WithScreener(screener)
+
+	created := &userDomain.User{ID: uuid.New(), Email: "jane@example.com", FirstName: "Jane", LastName: "Doe"}
+	userRepo.EXPECT().Create(gomock.Any(), "jane@example.com", "Jane", "Doe", gomock.Any()).Return(created, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), created.ID).Return(created, nil).Times(2)
+	userRepo.EXPECT().UpdateProfile(gomock.Any(), created.ID, userDomain.Profile{FirstName: "Jane", LastName: "Smith"}).Return(created, nil)
+	userRepo.EXPECT().UpdateProfile(gomock.Any(), created.ID, userDomain.Profile{FirstName: "Jane", LastName: "Doe", Nationality: "RO"}).Return(created, nil)
+
+	_, err := svc.Register(context.Background(), "jane@example.com", "SecureP@ssw0rd!", "Jane", "Doe")
+	require.NoError(t, err, "screening failures do not fail registration")
+	lastName := "Smith"
+	_, err = svc.UpdateProfile(context.Background(), created.ID, userDomain.ProfileUpdate{LastName: &lastName})
+	require.NoError(t, err)
+	nationality := "ro"
+	_, err = svc.UpdateProfile(context.Background(), created.ID, userDomain.ProfileUpdate{Nationality: &nationality})
+	require.NoError(t, err)
+
+	assert.Equal(t, []screening.Trigger{screening.TriggerRegistration, screening.TriggerProfileChange}, screener.triggers,
+		"only name and date of birth changes are screened")
+}
+
+func TestScreeningService_UsesProfileDateOfBirth(t *testing.T) {
+	f := newScreeningFixture(t)
+	ctx := context.Background()
+	u := f.addUser("Sergei", "Ivanov", "sergei@example.com")
+	u.DateOfBirth = "1990-01-01"
+
+	c, err := f.svc.ScreenUser(ctx, u.ID, screening.TriggerProfileChange)
+	require.NoError(t, err)
+	assert.Nil(t, c, "the profile date of birth rules the entry out without a KYC case")
+
+	applicant := kycApplicant()
+	applicant.FirstName, applicant.LastName, applicant.DateOfBirth = "Sergei", "Ivanov", "1953-07-01"
+	_, err = f.kycCases.Create(ctx, &kyc.Case{UserID: u.ID, Status: kyc.StatusDraft, Applicant: applicant})
+	require.NoError(t, err)
+
+	expectAudited(f.auditRepo, EventScreeningCaseOpened)
+	c, err = f.svc.ScreenUser(ctx, u.ID, screening.TriggerKYCApproval)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	assert.Equal(t, "1953-07-01", c.Subject.DateOfBirth, "the KYC date of birth takes precedence")
+	f.auditRepo.AssertExpectations(t)
+}
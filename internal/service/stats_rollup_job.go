@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+)
+
+// StatsRefresher brings the admin statistics rollups up to date.
+// Implemented by UserService.
+type StatsRefresher interface {
+	RefreshStatistics(ctx context.Context) error
+}
+
+// StatsRollupJob periodically refreshes the daily statistics, KYC funnel and sessions by
+// device the admin dashboard reads, so dashboards never scan the underlying tables.
+type StatsRollupJob struct {
+	refresher StatsRefresher
+	logger    *observability.Logger
+	interval  time.Duration
+	stopChan  chan struct{}
+	doneChan  chan struct{}
+}
+
+// NewStatsRollupJob creates a new statistics rollup job
+func NewStatsRollupJob(refresher StatsRefresher, logger *observability.Logger, interval time.Duration) *StatsRollupJob {
+	return &StatsRollupJob{
+		refresher: refresher,
+		logger:    logger,
+		interval:  interval,
+		stopChan:  make(chan struct{}),
+		doneChan:  make(chan struct{}),
+	}
+}
+
+// Start begins the periodic rollup job
+// Runs in a goroutine and can be stopped with Stop()
+func (j *StatsRollupJob) Start(ctx context.Context) {
+	j.logger.WithField("interval", j.interval.String()).Info("Starting statistics rollup job")
+
+	ticker := time.NewTicker(j.interval)
+
+	go func() {
+		defer close(j.doneChan)
+		defer ticker.Stop()
+
+		// Run immediately on start without delaying startup
+		if err := j.RunOnce(ctx); err != nil {
+			j.logger.WithError(err).Error("Initial statistics rollup failed")
+		}
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := j.RunOnce(ctx); err != nil {
+					j.logger.WithError(err).Error("Scheduled statistics rollup failed")
+				}
+			case <-j.stopChan:
+				j.logger.Info("Statistics rollup job stopped")
+				return
+			case <-ctx.Done():
+				j.logger.Info("Statistics rollup job context cancelled")
+				return
+			}
+		}
+	}()
+}
+
+// Stop gracefully stops the rollup job
+func (j *StatsRollupJob) Stop() {
+	j.logger.Info("Stopping statistics rollup job")
+	close(j.stopChan)
+	<-j.doneChan
+	j.logger.Info("Statistics rollup job stopped successfully")
+}
+
+// RunOnce refreshes the statistics rollups once
+func (j *StatsRollupJob) RunOnce(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	if err := j.refresher.RefreshStatistics(ctx); err != nil {
+		return fmt.Errorf("failed to refresh statistics: %w", err)
+	}
+
+	j.logger.WithField("duration_ms", time.Since(startTime).Milliseconds()).Debug("Statistics rollups refreshed")
+	return nil
+}
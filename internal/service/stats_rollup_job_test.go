@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStatsRefresher counts statistics refreshes
+type fakeStatsRefresher struct {
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (f *fakeStatsRefresher) RefreshStatistics(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return f.err
+}
+
+func (f *fakeStatsRefresher) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestStatsRollupJob_RunOnce(t *testing.T) {
+	logger := observability.NewLogger("dev", "test-service")
+
+	t.Run("refreshes the rollups", func(t *testing.T) {
+		refresher := &fakeStatsRefresher{}
+		job := NewStatsRollupJob(refresher, logger, time.Hour)
+
+		require.NoError(t, job.RunOnce(context.Background()))
+		assert.Equal(t, 1, refresher.calls)
+	})
+
+	t.Run("refresh error", func(t *testing.T) {
+		refresher := &fakeStatsRefresher{err: errors.New("database unavailable")}
+		job := NewStatsRollupJob(refresher, logger, time.Hour)
+
+		err := job.RunOnce(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to refresh statistics")
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		refresher := &fakeStatsRefresher{}
+		job := NewStatsRollupJob(refresher, logger, time.Hour)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, job.RunOnce(ctx), context.Canceled)
+		assert.Zero(t, refresher.calls)
+	})
+}
+
+func TestStatsRollupJob_StartStop(t *testing.T) {
+	logger := observability.NewLogger("dev", "test-service")
+	refresher := &fakeStatsRefresher{}
+	job := NewStatsRollupJob(refresher, logger, time.Hour)
+
+	job.Start(context.Background())
+	require.Eventually(t, func() bool {
+		return refresher.callCount() == 1
+	}, time.Second, 10*time.Millisecond)
+	job.Stop()
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// EventUserRestored is the audit event recorded when a deleted account is restored
+const EventUserRestored = "user.restored"
+
+// DefaultAccountRestoreGracePeriod is how long a deleted account can be restored when no
+// grace period is configured
+const DefaultAccountRestoreGracePeriod = 30 * 24 * time.Hour
+
+// Who restored an account, as reported in user.restored events
+const (
+	restoredByUser  = "user"
+	restoredByAdmin = "admin"
+)
+
+// WithAccountRestore lets deleted accounts be restored for grace after their deletion by an
+// admin and, with WithRestoreLinks, by the user. Restores are recorded in auditRepo.
+// The erasure cooling-off period must not be shorter than grace, or accounts would be
+// erased while they can still be restored.
+func (s *UserService) WithAccountRestore(grace time.Duration, auditRepo audit.Repository) *UserService {
+	if grace < 0 {
+		grace = 0
+	}
+	s.restoreGrace = grace
+	s.restoreAudit = auditRepo
+	return s
+}
+
+// WithRestoreLinks sends users deleting their account a link that restores it until the end
+// of the grace period. Links are signed with signer, delivered by sender and point at
+// linkURL with the token in the token query parameter.
+func (s *UserService) WithRestoreLinks(signer *userDomain.RestoreTokenSigner, sender userDomain.RestoreLinkSender, linkURL string) *UserService {
+	s.restoreSigner = signer
+	s.restoreSender = sender
+	s.restoreLinkURL = linkURL
+	return s
+}
+
+// sendRestoreLink sends a deleted user their restore link. The account is already deleted,
+// so failures are logged rather than returned; an admin can still restore it.
+func (s *UserService) sendRestoreLink(ctx context.Context, id uuid.UUID) {
+	if s.restoreSender == nil || s.restoreGrace <= 0 {
+		return
+	}
+
+	user, err := s.userRepo.GetByIDIncludeDeleted(ctx, id)
+	if err != nil || user.DeletedAt == nil {
+		s.logger.WithError(err).WithField("user_id", id.String()).Error("failed to read deleted user for restore link")
+		return
+	}
+
+	expiresAt := user.DeletedAt.Add(s.restoreGrace)
+	link, err := url.Parse(s.restoreLinkURL)
+	if err != nil {
+		s.logger.WithError(err).Error("invalid account restore link URL")
+		return
+	}
+	query := link.Query()
+	query.Set("token", s.restoreSigner.Sign(userDomain.RestoreToken{
+		UserID:    id,
+		DeletedAt: *user.DeletedAt,
+		ExpiresAt: expiresAt,
+	}))
+	link.RawQuery = query.Encode()
+
+	if err := s.restoreSender.SendRestoreLink(ctx, user.Email, link.String(), expiresAt); err != nil {
+		s.logger.WithError(err).WithField("user_id", id.String()).Error("failed to send account restore link")
+		return
+	}
+	s.auditLogger.LogEvent("user.restore_link_sent", map[string]interface{}{
+		"user_id":    id.String(),
+		"expires_at": expiresAt,
+	})
+}
+
+// RestoreAccount restores the account a restore link was sent for. The link only restores
+// the deletion it was sent for, and only within the grace period.
+func (s *UserService) RestoreAccount(ctx context.Context, token string) (*userDomain.User, error) {
+	if s.restoreSigner == nil || s.restoreGrace <= 0 {
+		return nil, userDomain.ErrAccountRestoreUnavailable
+	}
+
+	claims, err := s.restoreSigner.Verify(token, time.Now())
+	if err != nil {
+		s.logSecurityEvent(ctx, "user.account_restore_failed", "medium", map[string]interface{}{
+			"reason": "invalid_token",
+		})
+		return nil, err
+	}
+
+	return s.restore(ctx, claims.UserID, &claims.DeletedAt, audit.ActorUser, claims.UserID.String())
+}
+
+// AdminRestoreAccount restores a deleted account on behalf of its user within the grace period
+func (s *UserService) AdminRestoreAccount(ctx context.Context, id, adminID uuid.UUID) (*userDomain.User, error) {
+	if s.restoreGrace <= 0 {
+		return nil, userDomain.ErrAccountRestoreUnavailable
+	}
+	return s.restore(ctx, id, nil, audit.ActorAdmin, adminID.String())
+}
+
+// restore undeletes a user still within the grace period, optionally only if they were
+// deleted at deletedAt. Sessions revoked on deletion stay revoked.
+func (s *UserService) restore(ctx context.Context, id uuid.UUID, deletedAt *time.Time, actorType audit.ActorType, actor string) (*userDomain.User, error) {
+	s.logger.WithFields(map[string]interface{}{
+		"user_id":    id.String(),
+		"actor_type": string(actorType),
+	}).Info("account restore attempt")
+
+	current, err := s.userRepo.GetByIDIncludeDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.DeletedAt == nil {
+		return nil, userDomain.ErrNotDeleted
+	}
+	if deletedAt != nil && !current.DeletedAt.Equal(*deletedAt) {
+		// The link was sent for an earlier deletion
+		return nil, userDomain.ErrInvalidRestoreToken
+	}
+	now := time.Now()
+	if !now.Before(current.DeletedAt.Add(s.restoreGrace)) {
+		return nil, userDomain.ErrRestoreWindowExpired
+	}
+
+	restoredBy := restoredByUser
+	if actorType == audit.ActorAdmin {
+		restoredBy = restoredByAdmin
+	}
+
+	var restored *userDomain.User
+	err = s.writeWithEvent(ctx, func(repo userDomain.Repository) (*userDomain.Event, error) {
+		user, err := repo.Restore(ctx, id, *current.DeletedAt)
+		if err != nil {
+			if errors.Is(err, userDomain.ErrNotFound) {
+				// Restored, deleted again or erased since it was read
+				return nil, userDomain.ErrRestoreWindowExpired
+			}
+			return nil, err
+		}
+		restored = user
+		return userDomain.NewTypedEvent(id, userDomain.RestoredPayload{
+			DeletedAt:  current.DeletedAt.UTC(),
+			RestoredAt: now.UTC(),
+			RestoredBy: restoredBy,
+		}), nil
+	})
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", id.String()).Error("failed to restore user account")
+		return nil, err
+	}
+
+	s.recordRestore(ctx, restored, *current.DeletedAt, actorType, actor)
+	s.auditLogger.LogEvent("user.account_restored", map[string]interface{}{
+		"user_id":     id.String(),
+		"restored_by": restoredBy,
+	})
+
+	s.logger.WithField("user_id", id.String()).Info("account restored successfully")
+	return restored, nil
+}
+
+// recordRestore writes a restore to audit_logs.
+// The account is already restored, so a failure here is logged rather than returned.
+func (s *UserService) recordRestore(ctx context.Context, user *userDomain.User, deletedAt time.Time, actorType audit.ActorType, actor string) {
+	if s.restoreAudit == nil {
+		return
+	}
+
+	resourceType := "user"
+	resourceID := user.ID.String()
+	userID := user.ID
+
+	entry := &audit.Log{
+		EventType:       EventUserRestored,
+		EventCategory:   audit.CategoryDataModification,
+		Severity:        audit.SeverityInfo,
+		UserID:          &userID,
+		ActorType:       actorType,
+		ActorIdentifier: &actor,
+		Action:          "restore",
+		ResourceType:    &resourceType,
+		ResourceID:      &resourceID,
+		PreviousState:   map[string]interface{}{"deleted_at": deletedAt.UTC().Format(time.RFC3339Nano)},
+		NewState:        map[string]interface{}{"deleted_at": nil},
+		Status:          audit.StatusSuccess,
+	}
+	if actorType == audit.ActorAdmin {
+		entry.Severity = audit.SeverityWarning
+	}
+
+	if _, err := s.restoreAudit.Create(ctx, entry); err != nil {
+		s.logger.WithError(err).WithField("user_id", resourceID).Error("failed to record account restore in audit log")
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// recordingLinkSender keeps the restore links it was asked to send
+type recordingLinkSender struct {
+	links []string
+	err   error
+}
+
+func (s *recordingLinkSender) SendRestoreLink(ctx context.Context, email, link string, expiresAt time.Time) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.links = append(s.links, link)
+	return nil
+}
+
+// restoreToken extracts the token from a restore link
+func restoreToken(t *testing.T, link string) string {
+	t.Helper()
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	return parsed.Query().Get("token")
+}
+
+func TestUserService_AccountRestore(t *testing.T) {
+	ctx := context.Background()
+	grace := 7 * 24 * time.Hour
+
+	setup := func(t *testing.T) (*UserService, *mocks.MockUserRepository, *mocks.MockRefreshTokenRepository, *mocks.MockAuditRepository, *recordingLinkSender, *fakeTransactor) {
+		svc, userRepo, tokenRepo, _, transactor := newTestUserServiceWithOutbox(t)
+		auditRepo := new(mocks.MockAuditRepository)
+		signer, err := userDomain.NewRestoreTokenSigner(bytes.Repeat([]byte("r"), 32))
+		require.NoError(t, err)
+		sender := &recordingLinkSender{}
+		svc.WithAccountRestore(grace, auditRepo).WithRestoreLinks(signer, sender, "https://app.example.com/account/restore")
+		return svc, userRepo, tokenRepo, auditRepo, sender, transactor
+	}
+
+	deletedUser := func(deletedAgo time.Duration) *userDomain.User {
+		deletedAt := time.Now().Add(-deletedAgo).Truncate(time.Microsecond)
+		return &userDomain.User{ID: uuid.New(), Email: "jane@example.com", DeletedAt: &deletedAt}
+	}
+
+	t.Run("deletion sends a link that restores the account", func(t *testing.T) {
+		svc, userRepo, tokenRepo, auditRepo, sender, transactor := setup(t)
+		deleted := deletedUser(time.Minute)
+		restored := &userDomain.User{ID: deleted.ID, Email: deleted.Email}
+		tokenRepo.EXPECT().RevokeAllForUser(gomock.Any(), deleted.ID).Return(nil)
+		userRepo.EXPECT().SoftDelete(gomock.Any(), deleted.ID).Return(nil)
+		userRepo.EXPECT().GetByIDIncludeDeleted(gomock.Any(), deleted.ID).Return(deleted, nil).Times(2)
+		userRepo.EXPECT().Restore(gomock.Any(), deleted.ID, *deleted.DeletedAt).Return(restored, nil)
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.EventType == EventUserRestored && l.ActorType == audit.ActorUser && *l.UserID == deleted.ID
+		})).Return(&audit.Log{}, nil).Once()
+
+		require.NoError(t, svc.DeleteAccount(ctx, deleted.ID))
+		require.Len(t, sender.links, 1)
+		assert.Contains(t, sender.links[0], "https://app.example.com/account/restore?token=")
+
+		user, err := svc.RestoreAccount(ctx, restoreToken(t, sender.links[0]))
+		require.NoError(t, err)
+		assert.Equal(t, restored, user)
+
+		require.Len(t, transactor.committed, 2)
+		event := transactor.committed[1].event
+		assert.Equal(t, string(userDomain.EventTypeUserRestored), event.EventType())
+		assert.Equal(t, "user", event.EventPayload()["restored_by"])
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("link sending failure does not fail the deletion", func(t *testing.T) {
+		svc, userRepo, tokenRepo, _, sender, _ := setup(t)
+		deleted := deletedUser(0)
+		sender.err = errors.New("smtp down")
+		tokenRepo.EXPECT().RevokeAllForUser(gomock.Any(), deleted.ID).Return(nil)
+		userRepo.EXPECT().SoftDelete(gomock.Any(), deleted.ID).Return(nil)
+		userRepo.EXPECT().GetByIDIncludeDeleted(gomock.Any(), deleted.ID).Return(deleted, nil)
+
+		assert.NoError(t, svc.DeleteAccount(ctx, deleted.ID))
+	})
+
+	t.Run("link for an earlier deletion is rejected", func(t *testing.T) {
+		svc, userRepo, _, _, _, transactor := setup(t)
+		deleted := deletedUser(time.Minute)
+		earlier := deleted.DeletedAt.Add(-time.Hour)
+		token := svc.restoreSigner.Sign(userDomain.RestoreToken{UserID: deleted.ID, DeletedAt: earlier, ExpiresAt: time.Now().Add(time.Hour)})
+		userRepo.EXPECT().GetByIDIncludeDeleted(gomock.Any(), deleted.ID).Return(deleted, nil)
+
+		_, err := svc.RestoreAccount(ctx, token)
+		assert.ErrorIs(t, err, userDomain.ErrInvalidRestoreToken)
+		assert.Empty(t, transactor.committed)
+	})
+
+	t.Run("forged link is rejected", func(t *testing.T) {
+		svc, _, _, _, _, _ := setup(t)
+
+		_, err := svc.RestoreAccount(ctx, "forged")
+		assert.ErrorIs(t, err, userDomain.ErrInvalidRestoreToken)
+	})
+
+	t.Run("admin restore", func(t *testing.T) {
+		svc, userRepo, _, auditRepo, _, transactor := setup(t)
+		deleted := deletedUser(24 * time.Hour)
+		adminID := uuid.New()
+		userRepo.EXPECT().GetByIDIncludeDeleted(gomock.Any(), deleted.ID).Return(deleted, nil)
+		userRepo.EXPECT().Restore(gomock.Any(), deleted.ID, *deleted.DeletedAt).Return(&userDomain.User{ID: deleted.ID}, nil)
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.ActorType == audit.ActorAdmin && *l.ActorIdentifier == adminID.String() && l.Severity == audit.SeverityWarning
+		})).Return(&audit.Log{}, nil).Once()
+
+		_, err := svc.AdminRestoreAccount(ctx, deleted.ID, adminID)
+		require.NoError(t, err)
+		require.Len(t, transactor.committed, 1)
+		assert.Equal(t, "admin", transactor.committed[0].event.EventPayload()["restored_by"])
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("grace period over", func(t *testing.T) {
+		svc, userRepo, _, _, _, _ := setup(t)
+		deleted := deletedUser(grace + time.Minute)
+		userRepo.EXPECT().GetByIDIncludeDeleted(gomock.Any(), deleted.ID).Return(deleted, nil)
+
+		_, err := svc.AdminRestoreAccount(ctx, deleted.ID, uuid.New())
+		assert.ErrorIs(t, err, userDomain.ErrRestoreWindowExpired)
+	})
+
+	t.Run("erased or restored concurrently", func(t *testing.T) {
+		svc, userRepo, _, _, _, transactor := setup(t)
+		deleted := deletedUser(time.Minute)
+		userRepo.EXPECT().GetByIDIncludeDeleted(gomock.Any(), deleted.ID).Return(deleted, nil)
+		userRepo.EXPECT().Restore(gomock.Any(), deleted.ID, *deleted.DeletedAt).Return(nil, userDomain.ErrNotFound)
+
+		_, err := svc.AdminRestoreAccount(ctx, deleted.ID, uuid.New())
+		assert.ErrorIs(t, err, userDomain.ErrRestoreWindowExpired)
+		assert.Empty(t, transactor.committed)
+	})
+
+	t.Run("email taken since the deletion", func(t *testing.T) {
+		svc, userRepo, _, _, _, _ := setup(t)
+		deleted := deletedUser(time.Minute)
+		userRepo.EXPECT().GetByIDIncludeDeleted(gomock.Any(), deleted.ID).Return(deleted, nil)
+		userRepo.EXPECT().Restore(gomock.Any(), deleted.ID, *deleted.DeletedAt).Return(nil, userDomain.ErrAlreadyExists)
+
+		_, err := svc.AdminRestoreAccount(ctx, deleted.ID, uuid.New())
+		assert.ErrorIs(t, err, userDomain.ErrAlreadyExists)
+	})
+
+	t.Run("user not deleted", func(t *testing.T) {
+		svc, userRepo, _, _, _, _ := setup(t)
+		active := &userDomain.User{ID: uuid.New()}
+		userRepo.EXPECT().GetByIDIncludeDeleted(gomock.Any(), active.ID).Return(active, nil)
+
+		_, err := svc.AdminRestoreAccount(ctx, active.ID, uuid.New())
+		assert.ErrorIs(t, err, userDomain.ErrNotDeleted)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		svc, _, _, _, _ := newTestUserServiceWithOutbox(t)
+
+		_, err := svc.AdminRestoreAccount(ctx, uuid.New(), uuid.New())
+		assert.ErrorIs(t, err, userDomain.ErrAccountRestoreUnavailable)
+		_, err = svc.RestoreAccount(ctx, "token")
+		assert.ErrorIs(t, err, userDomain.ErrAccountRestoreUnavailable)
+	})
+}
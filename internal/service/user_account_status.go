@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+// EventUserStatusChanged is the audit event recorded when a user's account status changes
+const EventUserStatusChanged = "user.status.changed"
+
+// statusExpiryActor identifies the expiry job in audit entries of statuses it lifts
+const statusExpiryActor = "account-status-expiry"
+
+// DefaultStatusRevokeSessionsOn are the statuses whose setting revokes the user's sessions
+// when no others are configured
+var DefaultStatusRevokeSessionsOn = []userDomain.AccountStatus{
+	userDomain.AccountStatusSuspended,
+	userDomain.AccountStatusClosed,
+}
+
+// errStatusNoLongerExpired reports that a status was changed after the expiry job listed it
+var errStatusNoLongerExpired = errors.New("account status no longer expired")
+
+// WithAccountStatuses configures account status changes: setting one of revokeSessionsOn
+// revokes the user's sessions, and changes are recorded in auditRepo. Without it,
+// DefaultStatusRevokeSessionsOn applies and changes are not written to audit_logs.
+func (s *UserService) WithAccountStatuses(revokeSessionsOn []userDomain.AccountStatus, auditRepo audit.Repository) *UserService {
+	s.statusRevokeOn = make(map[userDomain.AccountStatus]bool, len(revokeSessionsOn))
+	for _, status := range revokeSessionsOn {
+		s.statusRevokeOn[status] = true
+	}
+	s.statusAudit = auditRepo
+	return s
+}
+
+// revokesSessions reports whether setting status revokes the user's sessions
+func (s *UserService) revokesSessions(status userDomain.AccountStatus) bool {
+	if s.statusRevokeOn == nil {
+		for _, revoking := range DefaultStatusRevokeSessionsOn {
+			if revoking == status {
+				return true
+			}
+		}
+		return false
+	}
+	return s.statusRevokeOn[status]
+}
+
+// CheckAccountAccess returns ErrAccountSuspended or ErrAccountClosed if the user's account
+// status does not let them use their sessions, and ErrNotFound if they are deleted
+func (s *UserService) CheckAccountAccess(ctx context.Context, id uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return user.CheckAccess(time.Now())
+}
+
+// refuseBlockedAccount returns the error of the user's account status if it blocks action,
+// recording the refused attempt as a security event
+func (s *UserService) refuseBlockedAccount(ctx context.Context, user *userDomain.User, action, ipAddress string) error {
+	err := user.CheckAccess(time.Now())
+	if err == nil {
+		return nil
+	}
+
+	status := user.EffectiveStatus(time.Now())
+	s.logger.WithFields(map[string]interface{}{
+		"user_id": user.ID.String(),
+		"status":  string(status),
+		"action":  action,
+	}).Warn("access refused by account status")
+	s.logSecurityEvent(ctx, action+".blocked", "medium", map[string]interface{}{
+		"user_id":    user.ID.String(),
+		"ip_address": ipAddress,
+		"reason":     "account_" + string(status),
+	})
+	return err
+}
+
+// SetAccountStatus changes a user's account status on behalf of an admin. The change is
+// recorded in the user's status history together with a user.status.changed event; the
+// user's sessions are revoked first when the new status is configured to revoke them.
+// Admins cannot change their own status.
+func (s *UserService) SetAccountStatus(ctx context.Context, id uuid.UUID, update userDomain.AccountStatusUpdate, adminID uuid.UUID) (*userDomain.User, error) {
+	now := time.Now()
+	update.Reason = strings.TrimSpace(update.Reason)
+	if err := update.Validate(now); err != nil {
+		return nil, err
+	}
+	if id == adminID {
+		return nil, fmt.Errorf("%w: admins cannot change their own status", userDomain.ErrInvalidAccountStatus)
+	}
+
+	current, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	revoke := s.revokesSessions(update.Status)
+	if revoke {
+		if err := s.refreshTokenRepo.RevokeAllForUser(ctx, id); err != nil {
+			s.logger.WithError(err).WithField("user_id", id.String()).Error("failed to revoke tokens during status change")
+			return nil, fmt.Errorf("failed to revoke tokens: %w", err)
+		}
+	}
+
+	change := &userDomain.AccountStatusChange{
+		UserID:          id,
+		OldStatus:       current.EffectiveStatus(now),
+		NewStatus:       update.Status,
+		Reason:          update.Reason,
+		ExpiresAt:       update.ExpiresAt,
+		ChangedBy:       &adminID,
+		SessionsRevoked: revoke,
+		CreatedAt:       now,
+	}
+	updated, err := s.writeStatusChange(ctx, change)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", id.String()).Error("failed to change account status")
+		return nil, err
+	}
+
+	s.recordStatusChange(ctx, change, audit.ActorAdmin, adminID.String())
+	s.logger.WithFields(map[string]interface{}{
+		"user_id":          id.String(),
+		"old_status":       string(change.OldStatus),
+		"new_status":       string(change.NewStatus),
+		"sessions_revoked": revoke,
+		"admin_id":         adminID.String(),
+	}).Info("account status changed by admin")
+	return updated, nil
+}
+
+// GetAccountStatusHistory retrieves a user's account status changes in order
+func (s *UserService) GetAccountStatusHistory(ctx context.Context, id uuid.UUID) ([]*userDomain.AccountStatusChange, error) {
+	if _, err := s.userRepo.GetByIDIncludeDeleted(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.userRepo.ListStatusChanges(ctx, id)
+}
+
+// ExpireAccountStatuses makes up to limit users whose suspension or freeze has expired active
+// again and returns how many were. Users whose status was changed since they were listed are
+// skipped.
+func (s *UserService) ExpireAccountStatuses(ctx context.Context, limit int) (int, error) {
+	now := time.Now()
+	users, err := s.userRepo.ListExpiredStatuses(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, user := range users {
+		change := &userDomain.AccountStatusChange{
+			UserID:    user.ID,
+			OldStatus: user.Status,
+			NewStatus: userDomain.AccountStatusActive,
+			Reason:    userDomain.StatusReasonExpired,
+			CreatedAt: now,
+		}
+		err := s.writeWithEvent(ctx, func(repo userDomain.Repository) (*userDomain.Event, error) {
+			// An admin may have changed the status since it was listed
+			current, err := repo.GetByID(ctx, user.ID)
+			if err != nil {
+				return nil, err
+			}
+			if current.StatusExpiresAt == nil || now.Before(*current.StatusExpiresAt) {
+				return nil, errStatusNoLongerExpired
+			}
+			change.OldStatus = current.Status
+			if _, err := repo.SetStatus(ctx, change); err != nil {
+				return nil, err
+			}
+			return statusChangedEvent(change), nil
+		})
+		if errors.Is(err, errStatusNoLongerExpired) || errors.Is(err, userDomain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return expired, fmt.Errorf("failed to lift expired status of user %s: %w", user.ID, err)
+		}
+
+		s.recordStatusChange(ctx, change, audit.ActorSystem, statusExpiryActor)
+		expired++
+	}
+	return expired, nil
+}
+
+// writeStatusChange stores change with its user.status.changed event
+func (s *UserService) writeStatusChange(ctx context.Context, change *userDomain.AccountStatusChange) (*userDomain.User, error) {
+	var updated *userDomain.User
+	err := s.writeWithEvent(ctx, func(repo userDomain.Repository) (*userDomain.Event, error) {
+		user, err := repo.SetStatus(ctx, change)
+		if err != nil {
+			return nil, err
+		}
+		updated = user
+		return statusChangedEvent(change), nil
+	})
+	return updated, err
+}
+
+// statusChangedEvent builds the user.status.changed event of change
+func statusChangedEvent(change *userDomain.AccountStatusChange) *userDomain.Event {
+	payload := userDomain.StatusChangedPayload{
+		Status:          change.NewStatus,
+		OldStatus:       change.OldStatus,
+		Reason:          change.Reason,
+		SessionsRevoked: change.SessionsRevoked,
+	}
+	if change.ExpiresAt != nil {
+		expiresAt := change.ExpiresAt.UTC()
+		payload.ExpiresAt = &expiresAt
+	}
+	if change.ChangedBy != nil {
+		payload.ChangedBy = change.ChangedBy.String()
+	}
+	return userDomain.NewTypedEvent(change.UserID, payload)
+}
+
+// recordStatusChange writes a status change to audit_logs.
+// The status has already changed, so a failure here is logged rather than returned.
+func (s *UserService) recordStatusChange(ctx context.Context, change *userDomain.AccountStatusChange, actorType audit.ActorType, actor string) {
+	s.auditLogger.LogEvent("user.status_changed", map[string]interface{}{
+		"user_id":    change.UserID.String(),
+		"old_status": string(change.OldStatus),
+		"new_status": string(change.NewStatus),
+	})
+	if s.statusAudit == nil {
+		return
+	}
+
+	resourceType := "user"
+	resourceID := change.UserID.String()
+	userID := change.UserID
+	newState := map[string]interface{}{
+		"status": string(change.NewStatus),
+		"reason": change.Reason,
+	}
+	if change.ExpiresAt != nil {
+		newState["expires_at"] = change.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	entry := &audit.Log{
+		EventType:       EventUserStatusChanged,
+		EventCategory:   audit.CategoryCompliance,
+		Severity:        audit.SeverityInfo,
+		UserID:          &userID,
+		ActorType:       actorType,
+		ActorIdentifier: &actor,
+		Action:          "set_status",
+		ResourceType:    &resourceType,
+		ResourceID:      &resourceID,
+		PreviousState:   map[string]interface{}{"status": string(change.OldStatus)},
+		NewState:        newState,
+		Metadata:        map[string]interface{}{"sessions_revoked": change.SessionsRevoked},
+		Status:          audit.StatusSuccess,
+	}
+	if actorType == audit.ActorAdmin && change.NewStatus != userDomain.AccountStatusActive {
+		entry.Severity = audit.SeverityWarning
+	}
+
+	if _, err := s.statusAudit.Create(ctx, entry); err != nil {
+		s.logger.WithError(err).WithField("user_id", resourceID).Error("failed to record account status change in audit log")
+	}
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserService_AccountStatus(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.New()
+
+	setup := func(t *testing.T) (*UserService, *mocks.MockUserRepository, *mocks.MockRefreshTokenRepository, *mocks.MockAuditRepository, *fakeTransactor) {
+		svc, userRepo, tokenRepo, _, transactor := newTestUserServiceWithOutbox(t)
+		auditRepo := new(mocks.MockAuditRepository)
+		auditRepo.Test(t)
+		svc.WithAccountStatuses(DefaultStatusRevokeSessionsOn, auditRepo)
+		return svc, userRepo, tokenRepo, auditRepo, transactor
+	}
+
+	// statusAudited matches the audit entry of an admin setting userID's status
+	statusAudited := func(userID uuid.UUID, status userDomain.AccountStatus, sessionsRevoked bool) interface{} {
+		return mock.MatchedBy(func(l *audit.Log) bool {
+			return l.EventType == EventUserStatusChanged && l.ActorType == audit.ActorAdmin &&
+				*l.ResourceType == "user" && *l.ResourceID == userID.String() &&
+				l.NewState["status"] == string(status) && l.Metadata["sessions_revoked"] == sessionsRevoked
+		})
+	}
+
+	t.Run("suspension revokes sessions and emits an event", func(t *testing.T) {
+		svc, userRepo, tokenRepo, auditRepo, transactor := setup(t)
+		current := &userDomain.User{ID: uuid.New(), Status: userDomain.AccountStatusActive}
+		expiresAt := time.Now().Add(24 * time.Hour)
+		update := userDomain.AccountStatusUpdate{Status: userDomain.AccountStatusSuspended, Reason: " chargeback ", ExpiresAt: &expiresAt}
+		suspended := &userDomain.User{ID: current.ID, Status: userDomain.AccountStatusSuspended, StatusExpiresAt: &expiresAt}
+
+		userRepo.EXPECT().GetByID(gomock.Any(), current.ID).Return(current, nil)
+		tokenRepo.EXPECT().RevokeAllForUser(gomock.Any(), current.ID).Return(nil)
+		userRepo.EXPECT().SetStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, change *userDomain.AccountStatusChange) (*userDomain.User, error) {
+				assert.Equal(t, userDomain.AccountStatusActive, change.OldStatus)
+				assert.Equal(t, "chargeback", change.Reason)
+				assert.Equal(t, adminID, *change.ChangedBy)
+				assert.True(t, change.SessionsRevoked)
+				return suspended, nil
+			})
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.EventType == EventUserStatusChanged && l.ActorType == audit.ActorAdmin &&
+				l.Severity == audit.SeverityWarning && l.NewState["status"] == "suspended"
+		})).Return(&audit.Log{}, nil).Once()
+
+		user, err := svc.SetAccountStatus(ctx, current.ID, update, adminID)
+		require.NoError(t, err)
+		assert.Equal(t, suspended, user)
+
+		require.Len(t, transactor.committed, 1)
+		event := transactor.committed[0].event
+		assert.Equal(t, string(userDomain.EventTypeUserStatusChanged), event.EventType())
+		payload := event.EventPayload()
+		assert.Equal(t, "suspended", payload["status"])
+		assert.Equal(t, "active", payload["old_status"])
+		assert.Equal(t, adminID.String(), payload["changed_by"])
+		assert.Equal(t, true, payload["sessions_revoked"])
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("withdrawal freeze keeps sessions", func(t *testing.T) {
+		svc, userRepo, _, auditRepo, transactor := setup(t)
+		current := &userDomain.User{ID: uuid.New()}
+		update := userDomain.AccountStatusUpdate{Status: userDomain.AccountStatusFrozenWithdrawals, Reason: "source of funds review"}
+
+		userRepo.EXPECT().GetByID(gomock.Any(), current.ID).Return(current, nil)
+		userRepo.EXPECT().SetStatus(gomock.Any(), gomock.Any()).Return(&userDomain.User{ID: current.ID}, nil)
+		auditRepo.On("Create", mock.Anything, statusAudited(current.ID, userDomain.AccountStatusFrozenWithdrawals, false)).Return(&audit.Log{}, nil).Once()
+
+		_, err := svc.SetAccountStatus(ctx, current.ID, update, adminID)
+		require.NoError(t, err)
+		require.Len(t, transactor.committed, 1)
+		assert.Equal(t, false, transactor.committed[0].event.EventPayload()["sessions_revoked"])
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("configured statuses revoke sessions", func(t *testing.T) {
+		svc, userRepo, tokenRepo, auditRepo, _ := setup(t)
+		svc.WithAccountStatuses([]userDomain.AccountStatus{userDomain.AccountStatusFrozenWithdrawals}, auditRepo)
+		current := &userDomain.User{ID: uuid.New()}
+		update := userDomain.AccountStatusUpdate{Status: userDomain.AccountStatusFrozenWithdrawals, Reason: "source of funds review"}
+
+		userRepo.EXPECT().GetByID(gomock.Any(), current.ID).Return(current, nil)
+		tokenRepo.EXPECT().RevokeAllForUser(gomock.Any(), current.ID).Return(nil)
+		userRepo.EXPECT().SetStatus(gomock.Any(), gomock.Any()).Return(&userDomain.User{ID: current.ID}, nil)
+		auditRepo.On("Create", mock.Anything, statusAudited(current.ID, userDomain.AccountStatusFrozenWithdrawals, true)).Return(&audit.Log{}, nil).Once()
+
+		_, err := svc.SetAccountStatus(ctx, current.ID, update, adminID)
+		require.NoError(t, err)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid update", func(t *testing.T) {
+		svc, _, _, _, transactor := setup(t)
+		expiresAt := time.Now().Add(time.Hour)
+
+		for _, update := range []userDomain.AccountStatusUpdate{
+			{Status: "banned", Reason: "spam"},
+			{Status: userDomain.AccountStatusSuspended, Reason: "  "},
+			{Status: userDomain.AccountStatusClosed, Reason: "requested", ExpiresAt: &expiresAt},
+		} {
+			_, err := svc.SetAccountStatus(ctx, uuid.New(), update, adminID)
+			assert.ErrorIs(t, err, userDomain.ErrInvalidAccountStatus)
+		}
+		assert.Empty(t, transactor.committed)
+	})
+
+	t.Run("admins cannot change their own status", func(t *testing.T) {
+		svc, _, _, _, _ := setup(t)
+		update := userDomain.AccountStatusUpdate{Status: userDomain.AccountStatusClosed, Reason: "leaving"}
+
+		_, err := svc.SetAccountStatus(ctx, adminID, update, adminID)
+		assert.ErrorIs(t, err, userDomain.ErrInvalidAccountStatus)
+	})
+
+	t.Run("check account access", func(t *testing.T) {
+		svc, userRepo, _, _, _ := setup(t)
+		expired := time.Now().Add(-time.Minute)
+		users := map[*userDomain.User]error{
+			{ID: uuid.New()}: nil,
+			{ID: uuid.New(), Status: userDomain.AccountStatusFrozenWithdrawals}:                    nil,
+			{ID: uuid.New(), Status: userDomain.AccountStatusSuspended}:                            userDomain.ErrAccountSuspended,
+			{ID: uuid.New(), Status: userDomain.AccountStatusSuspended, StatusExpiresAt: &expired}: nil,
+			{ID: uuid.New(), Status: userDomain.AccountStatusClosed}:                               userDomain.ErrAccountClosed,
+		}
+		for user, want := range users {
+			userRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+			err := svc.CheckAccountAccess(ctx, user.ID)
+			if want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, want)
+			}
+		}
+	})
+
+	t.Run("suspended user cannot log in or refresh", func(t *testing.T) {
+		svc, userRepo, tokenRepo, _, _ := setup(t)
+		hashed, err := auth.HashPassword("SecureP@ssw0rd!")
+		require.NoError(t, err)
+		suspended := &userDomain.User{ID: uuid.New(), Email: "jane@example.com", HashedPassword: hashed, Status: userDomain.AccountStatusSuspended}
+
+		userRepo.EXPECT().GetByEmail(gomock.Any(), suspended.Email).Return(suspended, nil)
+		_, err = svc.Login(ctx, suspended.Email, "SecureP@ssw0rd!", "127.0.0.1", "test")
+		assert.ErrorIs(t, err, userDomain.ErrAccountSuspended)
+
+		tokenRepo.EXPECT().GetByToken(gomock.Any(), "refresh").Return(&auth.RefreshToken{UserID: suspended.ID, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+		userRepo.EXPECT().GetByID(gomock.Any(), suspended.ID).Return(suspended, nil)
+		_, err = svc.RefreshToken(ctx, "refresh", "127.0.0.1", "test")
+		assert.ErrorIs(t, err, userDomain.ErrAccountSuspended)
+	})
+
+	t.Run("history", func(t *testing.T) {
+		svc, userRepo, _, _, _ := setup(t)
+		userID := uuid.New()
+		changes := []*userDomain.AccountStatusChange{{UserID: userID, NewStatus: userDomain.AccountStatusClosed}}
+		userRepo.EXPECT().GetByIDIncludeDeleted(gomock.Any(), userID).Return(&userDomain.User{ID: userID}, nil)
+		userRepo.EXPECT().ListStatusChanges(gomock.Any(), userID).Return(changes, nil)
+
+		history, err := svc.GetAccountStatusHistory(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, changes, history)
+	})
+
+	t.Run("expiry lifts expired statuses", func(t *testing.T) {
+		svc, userRepo, _, auditRepo, transactor := setup(t)
+		expired := time.Now().Add(-time.Minute)
+		later := time.Now().Add(time.Hour)
+		lifted := &userDomain.User{ID: uuid.New(), Status: userDomain.AccountStatusFrozenWithdrawals, StatusExpiresAt: &expired}
+		extended := &userDomain.User{ID: uuid.New(), Status: userDomain.AccountStatusSuspended, StatusExpiresAt: &expired}
+
+		userRepo.EXPECT().ListExpiredStatuses(gomock.Any(), gomock.Any(), 10).Return([]*userDomain.User{lifted, extended}, nil)
+		userRepo.EXPECT().GetByID(gomock.Any(), lifted.ID).Return(lifted, nil)
+		userRepo.EXPECT().SetStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, change *userDomain.AccountStatusChange) (*userDomain.User, error) {
+				assert.Equal(t, lifted.ID, change.UserID)
+				assert.Equal(t, userDomain.AccountStatusActive, change.NewStatus)
+				assert.Equal(t, userDomain.StatusReasonExpired, change.Reason)
+				assert.Nil(t, change.ChangedBy)
+				return &userDomain.User{ID: lifted.ID}, nil
+			})
+		// Extended by an admin after it was listed
+		userRepo.EXPECT().GetByID(gomock.Any(), extended.ID).Return(
+			&userDomain.User{ID: extended.ID, Status: userDomain.AccountStatusSuspended, StatusExpiresAt: &later}, nil)
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.ActorType == audit.ActorSystem && l.Severity == audit.SeverityInfo
+		})).Return(&audit.Log{}, nil).Once()
+
+		n, err := svc.ExpireAccountStatuses(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		require.Len(t, transactor.committed, 1)
+		payload := transactor.committed[0].event.EventPayload()
+		assert.Equal(t, "active", payload["status"])
+		assert.Equal(t, "frozen_withdrawals", payload["old_status"])
+		assert.Equal(t, 1, transactor.rollbacks)
+		auditRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultPhoneCodeTTL is how long a phone verification code can be confirmed when no TTL
+	// is configured
+	DefaultPhoneCodeTTL = 10 * time.Minute
+	// DefaultPhoneMaxAttempts is how many wrong codes are accepted before a new code has to be
+	// requested, when no limit is configured
+	DefaultPhoneMaxAttempts = 5
+	// phoneCodeResendInterval is how long a user waits before another code is sent
+	phoneCodeResendInterval = time.Minute
+	// phoneCodeDigits is the length of a verification code
+	phoneCodeDigits = 6
+)
+
+// WithPhoneVerification enables phone number verification: codes are stored in repo and
+// delivered by sender. Codes expire after ttl and stop being accepted after maxAttempts wrong
+// tries; zero values select DefaultPhoneCodeTTL and DefaultPhoneMaxAttempts.
+func (s *UserService) WithPhoneVerification(repo userDomain.PhoneVerificationRepository, sender userDomain.PhoneCodeSender, ttl time.Duration, maxAttempts int) *UserService {
+	if ttl <= 0 {
+		ttl = DefaultPhoneCodeTTL
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultPhoneMaxAttempts
+	}
+	s.phoneVerifications = repo
+	s.phoneCodeSender = sender
+	s.phoneCodeTTL = ttl
+	s.phoneMaxAttempts = maxAttempts
+	return s
+}
+
+// StartPhoneVerification sends a new code to the user's phone number, replacing any code sent
+// before. Codes are sent at most once per phoneCodeResendInterval.
+func (s *UserService) StartPhoneVerification(ctx context.Context, id uuid.UUID) (*userDomain.PhoneVerification, error) {
+	if s.phoneVerifications == nil {
+		return nil, userDomain.ErrPhoneVerificationUnavailable
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Phone == "" {
+		return nil, userDomain.ErrPhoneNotSet
+	}
+	if user.IsPhoneVerified() {
+		return nil, userDomain.ErrPhoneAlreadyVerified
+	}
+
+	now := time.Now()
+	pending, err := s.phoneVerifications.Get(ctx, id)
+	switch {
+	case err == nil:
+		if pending.Phone == user.Phone && now.Sub(pending.CreatedAt) < phoneCodeResendInterval {
+			return nil, userDomain.ErrPhoneVerificationThrottled
+		}
+	case !errors.Is(err, userDomain.ErrPhoneVerificationNotFound):
+		return nil, err
+	}
+
+	code, err := generatePhoneCode()
+	if err != nil {
+		return nil, err
+	}
+	verification, err := s.phoneVerifications.Save(ctx, &userDomain.PhoneVerification{
+		UserID:    id,
+		Phone:     user.Phone,
+		CodeHash:  userDomain.HashPhoneCode(code),
+		ExpiresAt: now.Add(s.phoneCodeTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.phoneCodeSender.SendPhoneCode(ctx, user.Phone, code); err != nil {
+		s.logger.WithError(err).WithField("user_id", id.String()).Error("failed to send phone verification code")
+		// Drop the code nobody received so the user can ask again right away
+		if delErr := s.phoneVerifications.Delete(ctx, id); delErr != nil {
+			s.logger.WithError(delErr).WithField("user_id", id.String()).Error("failed to delete unsent phone verification")
+		}
+		return nil, fmt.Errorf("failed to send phone verification code: %w", err)
+	}
+
+	s.auditLogger.LogEvent("user.phone_verification_started", map[string]interface{}{
+		"user_id":    id.String(),
+		"expires_at": verification.ExpiresAt,
+	})
+	return verification, nil
+}
+
+// ConfirmPhone marks the user's phone number verified if code is the code last sent to it.
+// Each wrong code counts against the attempt limit; once reached, a new code has to be
+// requested.
+func (s *UserService) ConfirmPhone(ctx context.Context, id uuid.UUID, code string) (*userDomain.User, error) {
+	if s.phoneVerifications == nil {
+		return nil, userDomain.ErrPhoneVerificationUnavailable
+	}
+
+	pending, err := s.phoneVerifications.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pending.Attempts >= s.phoneMaxAttempts {
+		return nil, userDomain.ErrPhoneVerificationThrottled
+	}
+	now := time.Now()
+	if pending.IsExpired(now) {
+		return nil, userDomain.ErrInvalidPhoneCode
+	}
+	if !pending.Matches(code) {
+		attempts, err := s.phoneVerifications.IncrementAttempts(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		s.logSecurityEvent(ctx, "user.phone_verification_failed", "low", map[string]interface{}{
+			"user_id":  id.String(),
+			"attempts": attempts,
+		})
+		return nil, userDomain.ErrInvalidPhoneCode
+	}
+
+	var user *userDomain.User
+	err = s.writeWithEvent(ctx, func(repo userDomain.Repository) (*userDomain.Event, error) {
+		current, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		// The code only verifies the number it was sent to
+		if current.Phone != pending.Phone {
+			return nil, userDomain.ErrInvalidPhoneCode
+		}
+		if current.IsPhoneVerified() {
+			return nil, userDomain.ErrPhoneAlreadyVerified
+		}
+
+		profile := current.Profile()
+		profile.PhoneVerifiedAt = &now
+		updated, err := repo.UpdateProfile(ctx, id, profile)
+		if err != nil {
+			return nil, err
+		}
+		user = updated
+		return userDomain.NewTypedEvent(updated.ID, userDomain.ProfileUpdatedPayload{
+			Email:         updated.Email,
+			FirstName:     updated.FirstName,
+			LastName:      updated.LastName,
+			ChangedFields: []string{userDomain.FieldPhoneVerified},
+		}), nil
+	})
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", id.String()).Error("failed to confirm phone number")
+		return nil, err
+	}
+
+	if err := s.phoneVerifications.Delete(ctx, id); err != nil {
+		s.logger.WithError(err).WithField("user_id", id.String()).Error("failed to delete confirmed phone verification")
+	}
+	s.auditLogger.LogEvent("user.phone_verified", map[string]interface{}{
+		"user_id": id.String(),
+	})
+	return user, nil
+}
+
+// generatePhoneCode returns a random code of phoneCodeDigits decimal digits
+func generatePhoneCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate phone verification code: %w", err)
+	}
+	return fmt.Sprintf("%0*d", phoneCodeDigits, n.Int64()), nil
+}
@@ -6,8 +6,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
 	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
 	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
 	"github.com/google/uuid"
@@ -26,6 +31,23 @@ type UserService struct {
 	logger             *observability.Logger
 	auditLogger        *observability.AuditLogger
 	eventPublisher     common.EventPublisher
+	legalHolds         audit.LegalHoldChecker
+	alertObserver      alert.Observer
+	transactor         userDomain.Transactor
+	screener           screening.Screener
+	phoneVerifications userDomain.PhoneVerificationRepository
+	phoneCodeSender    userDomain.PhoneCodeSender
+	phoneCodeTTL       time.Duration
+	phoneMaxAttempts   int
+	restoreGrace       time.Duration
+	restoreAudit       audit.Repository
+	restoreSigner      *userDomain.RestoreTokenSigner
+	restoreSender      userDomain.RestoreLinkSender
+	restoreLinkURL     string
+	statusRevokeOn     map[userDomain.AccountStatus]bool
+	statusAudit        audit.Repository
+	statsRepo          stats.Repository
+	statsBackfillDays  int
 }
 
 // NewUserService creates a new UserService instance
@@ -60,6 +82,136 @@ func NewUserService(
 	}, nil
 }
 
+// WithLegalHolds makes account deletion refuse users named by an active legal hold
+func (s *UserService) WithLegalHolds(checker audit.LegalHoldChecker) *UserService {
+	s.legalHolds = checker
+	return s
+}
+
+// WithAlertObserver passes security events (failed admin logins, revoked token reuse, ...)
+// to the alert rule engine in addition to the security log
+func (s *UserService) WithAlertObserver(observer alert.Observer) *UserService {
+	s.alertObserver = observer
+	return s
+}
+
+// WithOutbox makes user changes store their domain events in the transactional outbox
+// instead of publishing them directly, so an event bus outage cannot lose them
+func (s *UserService) WithOutbox(transactor userDomain.Transactor) *UserService {
+	s.transactor = transactor
+	return s
+}
+
+// WithScreener screens users against the sanctions and PEP watch lists when they register
+// and when they change their name or date of birth
+func (s *UserService) WithScreener(screener screening.Screener) *UserService {
+	s.screener = screener
+	return s
+}
+
+// screen runs screening after a change to the user's name. Failures are logged and do not
+// fail the change, since the user is screened again before any KYC approval.
+func (s *UserService) screen(ctx context.Context, userID uuid.UUID, trigger screening.Trigger) {
+	if s.screener == nil {
+		return
+	}
+	if _, err := s.screener.ScreenUser(ctx, userID, trigger); err != nil {
+		s.logger.WithError(err).WithFields(map[string]interface{}{
+			"user_id": userID.String(),
+			"trigger": trigger,
+		}).Error("failed to screen user")
+	}
+}
+
+// writeWithEvent applies change and records the event it returns. With an outbox the event
+// is stored in the same transaction as the change; without one the change runs on the plain
+// repository and the event is published on a best-effort basis.
+func (s *UserService) writeWithEvent(ctx context.Context, change func(repo userDomain.Repository) (*userDomain.Event, error)) error {
+	if s.transactor == nil {
+		event, err := change(s.userRepo)
+		if err != nil {
+			return err
+		}
+		s.publishEvent(withRequestContext(ctx, event))
+		return nil
+	}
+
+	return s.transactor.WithinTx(ctx, func(repo userDomain.Repository, events outbox.Writer) error {
+		event, err := change(repo)
+		if err != nil {
+			return err
+		}
+		return events.Enqueue(ctx, userDomain.AggregateType, event.UserID.String(), withRequestContext(ctx, event))
+	})
+}
+
+// withRequestContext records the client request of ctx (IP address, user agent, request ID)
+// and its trace in the event metadata. The publisher continues the trace, even when the outbox
+// relay publishes the event later, so one trace covers the request, the publish and the
+// consumers' handling of the event.
+func withRequestContext(ctx context.Context, event *userDomain.Event) *userDomain.Event {
+	if event.Metadata == nil {
+		event.Metadata = make(map[string]string)
+	}
+	for key, value := range common.RequestMetadataFromContext(ctx).Fields() {
+		event.Metadata[key] = value
+	}
+	observability.InjectTraceContext(ctx, event.Metadata)
+	return event
+}
+
+// publishUserEvent publishes an event about userID that records an action rather than a
+// stored change, such as a login or a session revocation
+func (s *UserService) publishUserEvent(ctx context.Context, userID uuid.UUID, data common.EventData) {
+	if s.eventPublisher == nil {
+		return
+	}
+	s.publishEvent(withRequestContext(ctx, userDomain.NewTypedEvent(userID, data)))
+}
+
+// publishEvent publishes event directly; failures are logged and do not fail the request
+func (s *UserService) publishEvent(event *userDomain.Event) {
+	if s.eventPublisher == nil {
+		return
+	}
+	if err := s.eventPublisher.Publish(event); err != nil {
+		s.logger.WithError(err).WithFields(map[string]interface{}{
+			"user_id":    event.UserID.String(),
+			"event_type": event.EventType(),
+		}).Warn("failed to publish user event")
+	}
+}
+
+// logSecurityEvent writes a security event to the audit log output and, when alerting is
+// enabled, hands it to the alert observer. Observation runs in the background so alert
+// delivery never delays the request that triggered it.
+func (s *UserService) logSecurityEvent(ctx context.Context, eventType, severity string, data map[string]interface{}) {
+	s.auditLogger.LogSecurityEvent(eventType, severity, data)
+	if s.alertObserver == nil {
+		return
+	}
+
+	event := alert.Event{
+		Type:       eventType,
+		Severity:   severity,
+		OccurredAt: time.Now(),
+		Attributes: map[string]string{"severity": severity},
+	}
+	if userID, ok := data["user_id"].(string); ok {
+		event.UserID = userID
+	}
+	if ipAddress, ok := data["ip_address"].(string); ok {
+		event.IPAddress = ipAddress
+	}
+	for _, key := range []string{"reason", "role"} {
+		if value, ok := data[key].(string); ok {
+			event.Attributes[key] = value
+		}
+	}
+
+	go s.alertObserver.Observe(context.WithoutCancel(ctx), event)
+}
+
 // Register creates a new user account
 func (s *UserService) Register(ctx context.Context, email, password, firstName, lastName string) (*userDomain.User, error) {
 	s.logger.WithField("email", email).Info("user registration started")
@@ -88,8 +240,21 @@ func (s *UserService) Register(ctx context.Context, email, password, firstName,
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	// Create user in repository
-	user, err := s.userRepo.Create(ctx, email, firstName, lastName, hashedPassword)
+	// Create user in repository together with the user registered event
+	var user *userDomain.User
+	err = s.writeWithEvent(ctx, func(repo userDomain.Repository) (*userDomain.Event, error) {
+		created, err := repo.Create(ctx, email, firstName, lastName, hashedPassword)
+		if err != nil {
+			return nil, err
+		}
+		user = created
+		return userDomain.NewTypedEvent(created.ID, userDomain.RegisteredPayload{
+			Email:     created.Email,
+			FirstName: created.FirstName,
+			LastName:  created.LastName,
+			Role:      created.Role,
+		}), nil
+	})
 	if err != nil {
 		if errors.Is(err, userDomain.ErrAlreadyExists) {
 			s.logger.WithField("email", email).Warn("registration failed: user already exists")
@@ -99,20 +264,6 @@ func (s *UserService) Register(ctx context.Context, email, password, firstName,
 		return nil, err
 	}
 
-	// Publish user registered event
-	if s.eventPublisher != nil {
-		event := userDomain.NewEvent(userDomain.EventTypeUserRegistered, user.ID, map[string]interface{}{
-			"email":      user.Email,
-			"first_name": user.FirstName,
-			"last_name":  user.LastName,
-			"role":       user.Role,
-		})
-		if err := s.eventPublisher.Publish(event); err != nil {
-			// Log error but don't fail the registration
-			s.logger.WithError(err).WithField("user_id", user.ID.String()).Warn("failed to publish user registered event")
-		}
-	}
-
 	// Log audit event for user registration
 	s.auditLogger.LogEvent("user.registered", map[string]interface{}{
 		"user_id":    user.ID.String(),
@@ -126,6 +277,7 @@ func (s *UserService) Register(ctx context.Context, email, password, firstName,
 		"email":   user.Email,
 	}).Info("user registered successfully")
 
+	s.screen(ctx, user.ID, screening.TriggerRegistration)
 	return user, nil
 }
 
@@ -157,7 +309,7 @@ func (s *UserService) Login(ctx context.Context, email, password, ipAddress, use
 			}).Warn("login failed: invalid password")
 			
 			// Log security event for failed login
-			s.auditLogger.LogSecurityEvent("login.failed", "medium", map[string]interface{}{
+			s.logSecurityEvent(ctx, "login.failed", "medium", map[string]interface{}{
 				"user_id":    user.ID.String(),
 				"email":      email,
 				"ip_address": ipAddress,
@@ -170,6 +322,11 @@ func (s *UserService) Login(ctx context.Context, email, password, ipAddress, use
 		return nil, fmt.Errorf("failed to verify password: %w", err)
 	}
 
+	// Suspended and closed accounts cannot log in
+	if err := s.refuseBlockedAccount(ctx, user, "login", ipAddress); err != nil {
+		return nil, err
+	}
+
 	// Generate access token
 	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Role.String())
 	if err != nil {
@@ -193,16 +350,11 @@ func (s *UserService) Login(ctx context.Context, email, password, ipAddress, use
 	}
 
 	// Publish user logged in event
-	if s.eventPublisher != nil {
-		event := userDomain.NewEvent(userDomain.EventTypeUserLoggedIn, user.ID, map[string]interface{}{
-			"email":      user.Email,
-			"ip_address": ipAddress,
-			"user_agent": userAgent,
-		})
-		if err := s.eventPublisher.Publish(event); err != nil {
-			s.logger.WithError(err).WithField("user_id", user.ID.String()).Warn("failed to publish user logged in event")
-		}
-	}
+	s.publishUserEvent(ctx, user.ID, userDomain.LoggedInPayload{
+		Email:     user.Email,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	})
 
 	// Log successful login as audit event
 	s.auditLogger.LogEvent("user.login", map[string]interface{}{
@@ -246,7 +398,7 @@ func (s *UserService) AdminLogin(ctx context.Context, email, password, ipAddress
 			s.logger.WithField("email", email).Warn("admin login failed: user not found")
 			
 			// Log security event for failed admin login attempt
-			s.auditLogger.LogSecurityEvent("admin.login.failed", "high", map[string]interface{}{
+			s.logSecurityEvent(ctx, "admin.login.failed", "high", map[string]interface{}{
 				"email":      email,
 				"ip_address": ipAddress,
 				"reason":     "user_not_found",
@@ -265,7 +417,7 @@ func (s *UserService) AdminLogin(ctx context.Context, email, password, ipAddress
 			"email":   email,
 		}).Warn("admin login failed: account is deleted")
 		
-		s.auditLogger.LogSecurityEvent("admin.login.deleted_account", "high", map[string]interface{}{
+		s.logSecurityEvent(ctx, "admin.login.deleted_account", "high", map[string]interface{}{
 			"user_id":    user.ID.String(),
 			"email":      email,
 			"ip_address": ipAddress,
@@ -283,7 +435,7 @@ func (s *UserService) AdminLogin(ctx context.Context, email, password, ipAddress
 			}).Warn("admin login failed: invalid password")
 			
 			// Log security event for failed admin login
-			s.auditLogger.LogSecurityEvent("admin.login.failed", "high", map[string]interface{}{
+			s.logSecurityEvent(ctx, "admin.login.failed", "high", map[string]interface{}{
 				"user_id":    user.ID.String(),
 				"email":      email,
 				"ip_address": ipAddress,
@@ -305,7 +457,7 @@ func (s *UserService) AdminLogin(ctx context.Context, email, password, ipAddress
 		}).Warn("admin login failed: user is not an admin")
 		
 		// Log high-severity security event - non-admin attempting admin access
-		s.auditLogger.LogSecurityEvent("admin.login.unauthorized", "high", map[string]interface{}{
+		s.logSecurityEvent(ctx, "admin.login.unauthorized", "high", map[string]interface{}{
 			"user_id":    user.ID.String(),
 			"email":      email,
 			"role":       user.Role.String(),
@@ -315,6 +467,10 @@ func (s *UserService) AdminLogin(ctx context.Context, email, password, ipAddress
 		return nil, fmt.Errorf("admin access required")
 	}
 
+	if err := s.refuseBlockedAccount(ctx, user, "admin.login", ipAddress); err != nil {
+		return nil, err
+	}
+
 	// Generate access token with admin role
 	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Role.String())
 	if err != nil {
@@ -337,6 +493,13 @@ func (s *UserService) AdminLogin(ctx context.Context, email, password, ipAddress
 		return nil, fmt.Errorf("failed to store refresh token: %w", err)
 	}
 
+	s.publishUserEvent(ctx, user.ID, userDomain.AdminLoggedInPayload{
+		Email:     user.Email,
+		Role:      user.Role,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	})
+
 	// Log successful admin login as critical audit event
 	s.auditLogger.LogEvent("admin.login.success", map[string]interface{}{
 		"user_id":    user.ID.String(),
@@ -379,7 +542,7 @@ func (s *UserService) RefreshToken(ctx context.Context, refreshToken, ipAddress,
 			"revoked_at": tokenRecord.RevokedAt,
 		}).Warn("refresh token is revoked")
 		
-		s.auditLogger.LogSecurityEvent("token.refresh.revoked", "medium", map[string]interface{}{
+		s.logSecurityEvent(ctx, "token.refresh.revoked", "medium", map[string]interface{}{
 			"user_id":    tokenRecord.UserID.String(),
 			"ip_address": ipAddress,
 		})
@@ -403,6 +566,11 @@ func (s *UserService) RefreshToken(ctx context.Context, refreshToken, ipAddress,
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	// Suspended and closed accounts cannot renew their sessions
+	if err := s.refuseBlockedAccount(ctx, user, "token.refresh", ipAddress); err != nil {
+		return nil, err
+	}
+
 	// Revoke the old refresh token
 	err = s.refreshTokenRepo.Revoke(ctx, refreshToken)
 	if err != nil {
@@ -432,6 +600,12 @@ func (s *UserService) RefreshToken(ctx context.Context, refreshToken, ipAddress,
 		return nil, fmt.Errorf("failed to store refresh token: %w", err)
 	}
 
+	s.publishUserEvent(ctx, user.ID, userDomain.TokenRefreshedPayload{
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		ExpiresAt: expiresAt,
+	})
+
 	s.auditLogger.LogEvent("token.refreshed", map[string]interface{}{
 		"user_id":    user.ID.String(),
 		"ip_address": ipAddress,
@@ -460,6 +634,16 @@ func (s *UserService) Logout(ctx context.Context, refreshToken string) error {
 		return err
 	}
 
+	// The token is looked up after revocation to find whose session ended
+	if s.eventPublisher != nil {
+		session, err := s.refreshTokenRepo.GetByToken(ctx, refreshToken)
+		if err != nil {
+			s.logger.WithError(err).Warn("failed to look up revoked refresh token for logout event")
+		} else {
+			s.publishUserEvent(ctx, session.UserID, userDomain.LoggedOutPayload{LoggedOutAt: time.Now().UTC()})
+		}
+	}
+
 	s.auditLogger.LogEvent("user.logout", map[string]interface{}{
 		"token_revoked": true,
 	})
@@ -478,6 +662,8 @@ func (s *UserService) LogoutAll(ctx context.Context, userID uuid.UUID) error {
 		return err
 	}
 
+	s.publishUserEvent(ctx, userID, userDomain.SessionsRevokedPayload{RevokedAt: time.Now().UTC()})
+
 	s.auditLogger.LogEvent("user.logout_all", map[string]interface{}{
 		"user_id": userID.String(),
 	})
@@ -522,94 +708,95 @@ func (s *UserService) GetByEmail(ctx context.Context, email string) (*userDomain
 	return user, nil
 }
 
-// UpdateKYC updates a user's KYC status
-func (s *UserService) UpdateKYC(ctx context.Context, id uuid.UUID, status userDomain.KYCStatus) (*userDomain.User, error) {
-	s.logger.WithFields(map[string]interface{}{
-		"user_id":    id.String(),
-		"kyc_status": status,
-	}).Info("KYC status update attempt")
+// errProfileUnchanged aborts a profile write whose update changes nothing
+var errProfileUnchanged = errors.New("profile unchanged")
 
-	user, err := s.userRepo.UpdateKYCStatus(ctx, id, status)
-	if err != nil {
-		s.logger.WithError(err).WithFields(map[string]interface{}{
-			"user_id":    id.String(),
-			"kyc_status": status,
-		}).Error("failed to update KYC status")
-		return nil, err
-	}
+// UpdateProfile applies a partial update to a user's profile. Only the changed fields are
+// written and reported in the user.profile.updated event; an update changing nothing returns
+// the user without an event.
+func (s *UserService) UpdateProfile(ctx context.Context, id uuid.UUID, update userDomain.ProfileUpdate) (*userDomain.User, error) {
+	s.logger.WithField("user_id", id.String()).Info("profile update attempt")
 
-	// Publish KYC updated event
-	if s.eventPublisher != nil {
-		event := userDomain.NewEvent(userDomain.EventTypeUserKYCUpdated, user.ID, map[string]interface{}{
-			"email":      user.Email,
-			"kyc_status": string(status),
-			"old_status": "", // Could track old status if needed
-		})
-		if err := s.eventPublisher.Publish(event); err != nil {
-			s.logger.WithError(err).WithField("user_id", user.ID.String()).Warn("failed to publish KYC updated event")
+	var user *userDomain.User
+	var changed []string
+	err := s.writeWithEvent(ctx, func(repo userDomain.Repository) (*userDomain.Event, error) {
+		current, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		profile, fields, err := current.Profile().Apply(update, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			user = current
+			return nil, errProfileUnchanged
 		}
-	}
 
-	// Log audit event for KYC status change
-	s.auditLogger.LogEvent("user.kyc_updated", map[string]interface{}{
-		"user_id":        id.String(),
-		"new_kyc_status": status,
+		updated, err := repo.UpdateProfile(ctx, id, profile)
+		if err != nil {
+			return nil, err
+		}
+		user, changed = updated, fields
+		return userDomain.NewTypedEvent(updated.ID, userDomain.ProfileUpdatedPayload{
+			Email:         updated.Email,
+			FirstName:     updated.FirstName,
+			LastName:      updated.LastName,
+			ChangedFields: fields,
+		}), nil
 	})
-
-	s.logger.WithFields(map[string]interface{}{
-		"user_id":    id.String(),
-		"kyc_status": status,
-	}).Info("KYC status updated successfully")
-
-	return user, nil
-}
-
-// UpdateProfile updates a user's profile information
-func (s *UserService) UpdateProfile(ctx context.Context, id uuid.UUID, firstName, lastName string) (*userDomain.User, error) {
-	s.logger.WithField("user_id", id.String()).Info("profile update attempt")
-
-	if firstName == "" {
-		s.logger.WithField("user_id", id.String()).Error("profile update failed: first name cannot be empty")
-		return nil, errors.New("first name cannot be empty")
-	}
-
-	if lastName == "" {
-		s.logger.WithField("user_id", id.String()).Error("profile update failed: last name cannot be empty")
-		return nil, errors.New("last name cannot be empty")
+	if errors.Is(err, errProfileUnchanged) {
+		s.logger.WithField("user_id", id.String()).Debug("profile update changed nothing")
+		return user, nil
 	}
-
-	user, err := s.userRepo.UpdateProfile(ctx, id, firstName, lastName)
 	if err != nil {
 		s.logger.WithError(err).WithField("user_id", id.String()).Error("failed to update profile")
 		return nil, err
 	}
 
-	// Publish profile updated event
-	if s.eventPublisher != nil {
-		event := userDomain.NewEvent(userDomain.EventTypeUserProfileUpdated, user.ID, map[string]interface{}{
-			"email":      user.Email,
-			"first_name": firstName,
-			"last_name":  lastName,
-		})
-		if err := s.eventPublisher.Publish(event); err != nil {
-			s.logger.WithError(err).WithField("user_id", user.ID.String()).Warn("failed to publish profile updated event")
-		}
-	}
-
 	s.auditLogger.LogEvent("user.profile_updated", map[string]interface{}{
-		"user_id":    id.String(),
-		"first_name": firstName,
-		"last_name":  lastName,
+		"user_id":        id.String(),
+		"changed_fields": changed,
 	})
 
 	s.logger.WithField("user_id", id.String()).Info("profile updated successfully")
+	if screeningRelevant(changed) {
+		s.screen(ctx, id, screening.TriggerProfileChange)
+	}
 	return user, nil
 }
 
-// DeleteAccount soft deletes a user account and revokes all tokens
+// screeningRelevant reports whether changed includes a field users are screened on
+func screeningRelevant(changed []string) bool {
+	for _, field := range changed {
+		switch field {
+		case userDomain.FieldFirstName, userDomain.FieldLastName, userDomain.FieldDateOfBirth:
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteAccount soft deletes a user account and revokes all tokens. With restore links
+// enabled the user is sent a link restoring the account within the grace period.
 func (s *UserService) DeleteAccount(ctx context.Context, id uuid.UUID) error {
 	s.logger.WithField("user_id", id.String()).Info("account deletion attempt")
 
+	if s.legalHolds != nil {
+		held, err := s.legalHolds.IsUserOnHold(ctx, id)
+		if err != nil {
+			s.logger.WithError(err).WithField("user_id", id.String()).Error("failed to check legal holds during account deletion")
+			return fmt.Errorf("failed to check legal holds: %w", err)
+		}
+		if held {
+			s.logSecurityEvent(ctx, "user.account_deletion_blocked", "high", map[string]interface{}{
+				"user_id": id.String(),
+				"reason":  "legal_hold",
+			})
+			return audit.ErrLegalHoldActive
+		}
+	}
+
 	// Revoke all refresh tokens first
 	err := s.refreshTokenRepo.RevokeAllForUser(ctx, id)
 	if err != nil {
@@ -617,26 +804,24 @@ func (s *UserService) DeleteAccount(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("failed to revoke tokens: %w", err)
 	}
 
-	// Soft delete the user
-	err = s.userRepo.SoftDelete(ctx, id)
+	// Soft delete the user together with the user deleted event
+	err = s.writeWithEvent(ctx, func(repo userDomain.Repository) (*userDomain.Event, error) {
+		if err := repo.SoftDelete(ctx, id); err != nil {
+			return nil, err
+		}
+		return userDomain.NewTypedEvent(id, userDomain.DeletedPayload{
+			DeletedAt: time.Now().UTC(),
+		}), nil
+	})
 	if err != nil {
 		s.logger.WithError(err).WithField("user_id", id.String()).Error("failed to soft delete user account")
 		return err
 	}
 
-	// Publish user deleted event
-	if s.eventPublisher != nil {
-		event := userDomain.NewEvent(userDomain.EventTypeUserDeleted, id, map[string]interface{}{
-			"deleted_at": time.Now().UTC(),
-		})
-		if err := s.eventPublisher.Publish(event); err != nil {
-			s.logger.WithError(err).WithField("user_id", id.String()).Warn("failed to publish user deleted event")
-		}
-	}
-
 	s.auditLogger.LogEvent("user.account_deleted", map[string]interface{}{
 		"user_id": id.String(),
 	})
+	s.sendRestoreLink(ctx, id)
 
 	s.logger.WithField("user_id", id.String()).Info("account deleted successfully")
 	return nil
@@ -687,22 +872,26 @@ func (s *UserService) ListUsers(ctx context.Context, limit, offset int) ([]*user
 	return users, total, nil
 }
 
-// SearchUsers searches for users by email, first name, or last name (admin only).
-func (s *UserService) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*userDomain.User, error) {
+// SearchUsers searches users with filters, sorting and cursor pagination (admin only).
+// Returns ErrInvalidSearch or ErrInvalidSearchCursor if the search is invalid.
+func (s *UserService) SearchUsers(ctx context.Context, search userDomain.UserSearch) (*userDomain.UserSearchPage, error) {
+	if err := search.Normalize(); err != nil {
+		return nil, err
+	}
 	s.logger.WithFields(map[string]interface{}{
-		"query":  query,
-		"limit":  limit,
-		"offset": offset,
+		"query": search.Query,
+		"sort":  search.Sort,
+		"limit": search.Limit,
 	}).Debug("Admin: searching users")
 
-	users, err := s.userRepo.SearchUsers(ctx, query, limit, offset)
+	page, err := s.userRepo.SearchUsers(ctx, search)
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to search users")
 		return nil, err
 	}
 
-	s.logger.WithField("count", len(users)).Info("Admin: users search completed")
-	return users, nil
+	s.logger.WithField("count", len(page.Users)).Info("Admin: users search completed")
+	return page, nil
 }
 
 // GetUserByIDAdmin retrieves a user by ID including deleted users (admin only).
@@ -731,7 +920,19 @@ func (s *UserService) UpdateUserRole(ctx context.Context, id uuid.UUID, role use
 		return nil, userDomain.ErrInvalidRole
 	}
 
-	user, err := s.userRepo.UpdateRole(ctx, id, role)
+	// The role change and its event are written together, so consumers never miss a privilege change
+	var user *userDomain.User
+	err := s.writeWithEvent(ctx, func(repo userDomain.Repository) (*userDomain.Event, error) {
+		updated, err := repo.UpdateRole(ctx, id, role)
+		if err != nil {
+			return nil, err
+		}
+		user = updated
+		return userDomain.NewTypedEvent(updated.ID, userDomain.RoleChangedPayload{
+			Email: updated.Email,
+			Role:  updated.Role,
+		}), nil
+	})
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to update user role")
 		return nil, err
@@ -783,6 +984,20 @@ func (s *UserService) ForceLogout(ctx context.Context, token string) error {
 		return err
 	}
 
+	// The token is looked up after revocation to find whose session ended
+	if s.eventPublisher != nil {
+		session, err := s.refreshTokenRepo.GetByToken(ctx, token)
+		if err != nil {
+			s.logger.WithError(err).Warn("failed to look up revoked refresh token for force logout event")
+		} else {
+			s.publishUserEvent(ctx, session.UserID, userDomain.SessionForceRevokedPayload{
+				RevokedAt:        time.Now().UTC(),
+				SessionIPAddress: session.IPAddress,
+				SessionUserAgent: session.UserAgent,
+			})
+		}
+	}
+
 	s.auditLogger.LogEvent("admin.force_logout", map[string]interface{}{
 		"token": "[REDACTED]",
 	})
@@ -790,28 +1005,3 @@ func (s *UserService) ForceLogout(ctx context.Context, token string) error {
 	s.logger.Info("Admin: token revoked successfully (force logout)")
 	return nil
 }
-
-// GetSystemStats retrieves system statistics for admin dashboard (admin only).
-func (s *UserService) GetSystemStats(ctx context.Context) (map[string]interface{}, error) {
-	s.logger.Debug("Admin: getting system statistics")
-
-	totalUsers, err := s.userRepo.Count(ctx)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to count users")
-		return nil, err
-	}
-
-	activeSessions, err := s.refreshTokenRepo.CountAllActiveSessions(ctx)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to count active sessions")
-		return nil, err
-	}
-
-	stats := map[string]interface{}{
-		"total_users":     totalUsers,
-		"active_sessions": activeSessions,
-	}
-
-	s.logger.WithFields(stats).Info("Admin: system statistics retrieved")
-	return stats, nil
-}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserService_AdminLogin_ObservesFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	tokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	observer := newCollectingObserver()
+
+	svc, err := NewUserService(userRepo, tokenRepo, "test-secret-key-min-32-characters", 15*time.Minute, 7*24*time.Hour,
+		observability.NewLogger("dev", "test-service"), nil)
+	require.NoError(t, err)
+	svc.WithAlertObserver(observer)
+
+	userRepo.EXPECT().GetByEmail(gomock.Any(), "attacker@test.com").Return(nil, userDomain.ErrNotFound)
+
+	_, err = svc.AdminLogin(context.Background(), "attacker@test.com", "guess", "203.0.113.7", "curl/8.0")
+	require.ErrorIs(t, err, userDomain.ErrInvalidCredentials)
+
+	event := observer.next(t)
+	assert.Equal(t, "admin.login.failed", event.Type)
+	assert.Equal(t, "203.0.113.7", event.IPAddress)
+	assert.Equal(t, "user_not_found", event.Attributes["reason"])
+	assert.Equal(t, "high", event.Severity)
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestUserServiceWithPublisher(t *testing.T, publisher common.EventPublisher) (*UserService, *mocks.MockUserRepository, *mocks.MockRefreshTokenRepository) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	tokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+
+	svc, err := NewUserService(userRepo, tokenRepo, "test-secret-key-min-32-characters", 15*time.Minute, 7*24*time.Hour,
+		observability.NewLogger("dev", "test-service"), publisher)
+	require.NoError(t, err)
+	return svc, userRepo, tokenRepo
+}
+
+// requestContext is the context of a request from the admin console
+func requestContext() context.Context {
+	return common.ContextWithRequestMetadata(context.Background(), common.RequestMetadata{
+		IPAddress: "203.0.113.7",
+		UserAgent: "pandora-admin/1.4",
+		RequestID: "req-42",
+	})
+}
+
+// publishedEvent returns the only event published to publisher
+func publishedEvent(t *testing.T, publisher *mocks.MockEventPublisher) *userDomain.Event {
+	t.Helper()
+	publisher.AssertNumberOfCalls(t, "Publish", 1)
+	event, ok := publisher.Calls[0].Arguments.Get(0).(*userDomain.Event)
+	require.True(t, ok, "published %T", publisher.Calls[0].Arguments.Get(0))
+	return event
+}
+
+func TestUserService_SecurityEvents(t *testing.T) {
+	requestMetadata := map[string]string{
+		"ip_address": "203.0.113.7",
+		"user_agent": "pandora-admin/1.4",
+		"request_id": "req-42",
+	}
+
+	t.Run("admin login publishes admin logged in", func(t *testing.T) {
+		publisher := new(mocks.MockEventPublisher)
+		publisher.On("Publish", mock.Anything).Return(nil)
+		svc, userRepo, tokenRepo := newTestUserServiceWithPublisher(t, publisher)
+		hashed, err := auth.HashPassword("SecureP@ssw0rd!")
+		require.NoError(t, err)
+		admin := &userDomain.User{ID: uuid.New(), Email: "admin@example.com", Role: userDomain.RoleAdmin, HashedPassword: hashed}
+		userRepo.EXPECT().GetByEmail(gomock.Any(), admin.Email).Return(admin, nil)
+		tokenRepo.EXPECT().Create(gomock.Any(), gomock.Any(), admin.ID, gomock.Any(), "203.0.113.7", "pandora-admin/1.4").Return(&auth.RefreshToken{}, nil)
+
+		_, err = svc.AdminLogin(requestContext(), admin.Email, "SecureP@ssw0rd!", "203.0.113.7", "pandora-admin/1.4")
+
+		require.NoError(t, err)
+		event := publishedEvent(t, publisher)
+		assert.Equal(t, userDomain.EventTypeAdminLoggedIn, event.Type)
+		assert.Equal(t, admin.ID, event.UserID)
+		assert.Equal(t, "admin@example.com", event.Payload["email"])
+		assert.Equal(t, "admin", event.Payload["role"])
+		assert.Equal(t, requestMetadata, event.Metadata)
+	})
+
+	t.Run("refresh publishes token refreshed", func(t *testing.T) {
+		publisher := new(mocks.MockEventPublisher)
+		publisher.On("Publish", mock.Anything).Return(nil)
+		svc, userRepo, tokenRepo := newTestUserServiceWithPublisher(t, publisher)
+		owner := &userDomain.User{ID: uuid.New(), Email: "jane@example.com", Role: userDomain.RoleUser}
+		tokenRepo.EXPECT().GetByToken(gomock.Any(), "old-token").Return(&auth.RefreshToken{UserID: owner.ID, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+		userRepo.EXPECT().GetByID(gomock.Any(), owner.ID).Return(owner, nil)
+		tokenRepo.EXPECT().Revoke(gomock.Any(), "old-token").Return(nil)
+		tokenRepo.EXPECT().Create(gomock.Any(), gomock.Any(), owner.ID, gomock.Any(), "203.0.113.7", "pandora-admin/1.4").Return(&auth.RefreshToken{}, nil)
+
+		pair, err := svc.RefreshToken(requestContext(), "old-token", "203.0.113.7", "pandora-admin/1.4")
+
+		require.NoError(t, err)
+		event := publishedEvent(t, publisher)
+		assert.Equal(t, userDomain.EventTypeTokenRefreshed, event.Type)
+		assert.Equal(t, owner.ID, event.UserID)
+		assert.Equal(t, pair.ExpiresAt.UTC().Format(time.RFC3339Nano), event.Payload["expires_at"])
+	})
+
+	t.Run("logout publishes logged out for the token owner", func(t *testing.T) {
+		publisher := new(mocks.MockEventPublisher)
+		publisher.On("Publish", mock.Anything).Return(nil)
+		svc, _, tokenRepo := newTestUserServiceWithPublisher(t, publisher)
+		ownerID := uuid.New()
+		tokenRepo.EXPECT().Revoke(gomock.Any(), "session-token").Return(nil)
+		tokenRepo.EXPECT().GetByToken(gomock.Any(), "session-token").Return(&auth.RefreshToken{UserID: ownerID}, nil)
+
+		require.NoError(t, svc.Logout(requestContext(), "session-token"))
+
+		event := publishedEvent(t, publisher)
+		assert.Equal(t, userDomain.EventTypeUserLoggedOut, event.Type)
+		assert.Equal(t, ownerID, event.UserID)
+		assert.Contains(t, event.Payload, "logged_out_at")
+		assert.Equal(t, requestMetadata, event.Metadata)
+	})
+
+	t.Run("logout succeeds when the owner cannot be found", func(t *testing.T) {
+		publisher := new(mocks.MockEventPublisher)
+		svc, _, tokenRepo := newTestUserServiceWithPublisher(t, publisher)
+		tokenRepo.EXPECT().Revoke(gomock.Any(), "session-token").Return(nil)
+		tokenRepo.EXPECT().GetByToken(gomock.Any(), "session-token").Return(nil, errors.New("connection reset"))
+
+		require.NoError(t, svc.Logout(requestContext(), "session-token"))
+		publisher.AssertNotCalled(t, "Publish", mock.Anything)
+	})
+
+	t.Run("logout all publishes sessions revoked", func(t *testing.T) {
+		publisher := new(mocks.MockEventPublisher)
+		publisher.On("Publish", mock.Anything).Return(nil)
+		svc, _, tokenRepo := newTestUserServiceWithPublisher(t, publisher)
+		userID := uuid.New()
+		tokenRepo.EXPECT().RevokeAllForUser(gomock.Any(), userID).Return(nil)
+
+		require.NoError(t, svc.LogoutAll(requestContext(), userID))
+
+		event := publishedEvent(t, publisher)
+		assert.Equal(t, userDomain.EventTypeUserSessionsRevoked, event.Type)
+		assert.Equal(t, userID, event.UserID)
+		assert.Contains(t, event.Payload, "revoked_at")
+	})
+
+	t.Run("role update publishes role changed", func(t *testing.T) {
+		publisher := new(mocks.MockEventPublisher)
+		publisher.On("Publish", mock.Anything).Return(nil)
+		svc, userRepo, _ := newTestUserServiceWithPublisher(t, publisher)
+		userID := uuid.New()
+		userRepo.EXPECT().UpdateRole(gomock.Any(), userID, userDomain.RoleAdmin).
+			Return(&userDomain.User{ID: userID, Email: "jane@example.com", Role: userDomain.RoleAdmin}, nil)
+
+		_, err := svc.UpdateUserRole(requestContext(), userID, userDomain.RoleAdmin)
+
+		require.NoError(t, err)
+		event := publishedEvent(t, publisher)
+		assert.Equal(t, userDomain.EventTypeUserRoleChanged, event.Type)
+		assert.Equal(t, map[string]interface{}{"email": "jane@example.com", "role": "admin"}, event.Payload)
+		assert.Equal(t, requestMetadata, event.Metadata)
+	})
+
+	t.Run("force logout publishes session force revoked", func(t *testing.T) {
+		publisher := new(mocks.MockEventPublisher)
+		publisher.On("Publish", mock.Anything).Return(nil)
+		svc, _, tokenRepo := newTestUserServiceWithPublisher(t, publisher)
+		ownerID := uuid.New()
+		tokenRepo.EXPECT().RevokeToken(gomock.Any(), "session-token").Return(nil)
+		tokenRepo.EXPECT().GetByToken(gomock.Any(), "session-token").
+			Return(&auth.RefreshToken{UserID: ownerID, IPAddress: "198.51.100.20", UserAgent: "pandora-android/3.0"}, nil)
+
+		require.NoError(t, svc.ForceLogout(requestContext(), "session-token"))
+
+		event := publishedEvent(t, publisher)
+		assert.Equal(t, userDomain.EventTypeSessionForceRevoked, event.Type)
+		assert.Equal(t, ownerID, event.UserID)
+		assert.Equal(t, "198.51.100.20", event.Payload["session_ip_address"])
+		assert.Equal(t, "pandora-android/3.0", event.Payload["session_user_agent"])
+		// The metadata describes the admin's request, not the revoked session
+		assert.Equal(t, requestMetadata, event.Metadata)
+	})
+
+	t.Run("failed action publishes nothing", func(t *testing.T) {
+		publisher := new(mocks.MockEventPublisher)
+		svc, _, tokenRepo := newTestUserServiceWithPublisher(t, publisher)
+		tokenRepo.EXPECT().RevokeToken(gomock.Any(), "session-token").Return(auth.ErrRefreshTokenNotFound)
+
+		require.Error(t, svc.ForceLogout(requestContext(), "session-token"))
+		publisher.AssertNotCalled(t, "Publish", mock.Anything)
+	})
+
+	t.Run("without a publisher the session owner is not looked up", func(t *testing.T) {
+		svc, _, tokenRepo := newTestUserServiceWithPublisher(t, nil)
+		tokenRepo.EXPECT().Revoke(gomock.Any(), "session-token").Return(nil)
+
+		require.NoError(t, svc.Logout(context.Background(), "session-token"))
+	})
+}
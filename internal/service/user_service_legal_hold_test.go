@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestUserServiceWithHolds(t *testing.T) (*UserService, *mocks.MockUserRepository, *mocks.MockRefreshTokenRepository, *mocks.MockLegalHoldRepository) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	tokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	holds := new(mocks.MockLegalHoldRepository)
+
+	svc, err := NewUserService(userRepo, tokenRepo, "test-secret-key-min-32-characters", 15*time.Minute, 7*24*time.Hour,
+		observability.NewLogger("dev", "test-service"), nil)
+	require.NoError(t, err)
+	svc.WithLegalHolds(holds)
+	return svc, userRepo, tokenRepo, holds
+}
+
+func TestUserService_DeleteAccount_LegalHold(t *testing.T) {
+	t.Run("blocked while user is on hold", func(t *testing.T) {
+		svc, _, _, holds := newTestUserServiceWithHolds(t)
+		userID := uuid.New()
+		holds.On("IsUserOnHold", mock.Anything, userID).Return(true, nil).Once()
+
+		err := svc.DeleteAccount(context.Background(), userID)
+
+		assert.ErrorIs(t, err, audit.ErrLegalHoldActive)
+		holds.AssertExpectations(t)
+	})
+
+	t.Run("allowed without hold", func(t *testing.T) {
+		svc, userRepo, tokenRepo, holds := newTestUserServiceWithHolds(t)
+		userID := uuid.New()
+		holds.On("IsUserOnHold", mock.Anything, userID).Return(false, nil).Once()
+		tokenRepo.EXPECT().RevokeAllForUser(gomock.Any(), userID).Return(nil)
+		userRepo.EXPECT().SoftDelete(gomock.Any(), userID).Return(nil)
+
+		err := svc.DeleteAccount(context.Background(), userID)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("check failure aborts deletion", func(t *testing.T) {
+		svc, _, _, holds := newTestUserServiceWithHolds(t)
+		userID := uuid.New()
+		holds.On("IsUserOnHold", mock.Anything, userID).Return(false, errors.New("db down")).Once()
+
+		err := svc.DeleteAccount(context.Background(), userID)
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, audit.ErrLegalHoldActive)
+	})
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/outbox"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+// outboxEntry is an event captured by fakeTransactor
+type outboxEntry struct {
+	aggregateType string
+	aggregateID   string
+	event         common.EventEnvelope
+}
+
+// fakeTransactor runs fn against repo and keeps enqueued events only when fn succeeds
+type fakeTransactor struct {
+	repo       userDomain.Repository
+	enqueueErr error
+	committed  []outboxEntry
+	rollbacks  int
+}
+
+func (t *fakeTransactor) WithinTx(ctx context.Context, fn func(repo userDomain.Repository, events outbox.Writer) error) error {
+	writer := &fakeOutboxWriter{err: t.enqueueErr}
+	if err := fn(t.repo, writer); err != nil {
+		t.rollbacks++
+		return err
+	}
+	t.committed = append(t.committed, writer.entries...)
+	return nil
+}
+
+type fakeOutboxWriter struct {
+	err     error
+	entries []outboxEntry
+}
+
+func (w *fakeOutboxWriter) Enqueue(ctx context.Context, aggregateType, aggregateID string, event common.EventEnvelope) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.entries = append(w.entries, outboxEntry{aggregateType: aggregateType, aggregateID: aggregateID, event: event})
+	return nil
+}
+
+func newTestUserServiceWithOutbox(t *testing.T) (*UserService, *mocks.MockUserRepository, *mocks.MockRefreshTokenRepository, *mocks.MockEventPublisher, *fakeTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	tokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	publisher := new(mocks.MockEventPublisher)
+	transactor := &fakeTransactor{repo: userRepo}
+
+	svc, err := NewUserService(userRepo, tokenRepo, "test-secret-key-min-32-characters", 15*time.Minute, 7*24*time.Hour,
+		observability.NewLogger("dev", "test-service"), publisher)
+	require.NoError(t, err)
+	svc.WithOutbox(transactor)
+	return svc, userRepo, tokenRepo, publisher, transactor
+}
+
+func TestUserService_Outbox(t *testing.T) {
+	t.Run("register stores event in the outbox instead of publishing", func(t *testing.T) {
+		svc, userRepo, _, publisher, transactor := newTestUserServiceWithOutbox(t)
+		created := &userDomain.User{ID: uuid.New(), Email: "jane@example.com", FirstName: "Jane", LastName: "Doe", Role: userDomain.RoleUser}
+		userRepo.EXPECT().Create(gomock.Any(), "jane@example.com", "Jane", "Doe", gomock.Any()).Return(created, nil)
+
+		user, err := svc.Register(context.Background(), "jane@example.com", "SecureP@ssw0rd!", "Jane", "Doe")
+
+		require.NoError(t, err)
+		assert.Equal(t, created, user)
+		require.Len(t, transactor.committed, 1)
+		entry := transactor.committed[0]
+		assert.Equal(t, userDomain.AggregateType, entry.aggregateType)
+		assert.Equal(t, created.ID.String(), entry.aggregateID)
+		assert.Equal(t, string(userDomain.EventTypeUserRegistered), entry.event.EventType())
+		assert.Equal(t, "jane@example.com", entry.event.EventPayload()["email"])
+		publisher.AssertNotCalled(t, "Publish")
+	})
+
+	t.Run("outbox failure fails the change", func(t *testing.T) {
+		svc, userRepo, _, _, transactor := newTestUserServiceWithOutbox(t)
+		transactor.enqueueErr = errors.New("insert failed")
+		id := uuid.New()
+		userRepo.EXPECT().UpdateRole(gomock.Any(), id, userDomain.RoleAdmin).Return(&userDomain.User{ID: id, Role: userDomain.RoleAdmin}, nil)
+
+		_, err := svc.UpdateUserRole(context.Background(), id, userDomain.RoleAdmin)
+
+		require.Error(t, err)
+		assert.Equal(t, 1, transactor.rollbacks)
+		assert.Empty(t, transactor.committed)
+	})
+
+	t.Run("repository failure writes no event", func(t *testing.T) {
+		svc, userRepo, _, _, transactor := newTestUserServiceWithOutbox(t)
+		id := uuid.New()
+		lastName := "Smith"
+		userRepo.EXPECT().GetByID(gomock.Any(), id).Return(&userDomain.User{ID: id, FirstName: "Jane", LastName: "Doe"}, nil)
+		userRepo.EXPECT().UpdateProfile(gomock.Any(), id, userDomain.Profile{FirstName: "Jane", LastName: "Smith"}).Return(nil, userDomain.ErrNotFound)
+
+		_, err := svc.UpdateProfile(context.Background(), id, userDomain.ProfileUpdate{LastName: &lastName})
+
+		assert.ErrorIs(t, err, userDomain.ErrNotFound)
+		assert.Empty(t, transactor.committed)
+	})
+
+	t.Run("delete account stores user deleted event", func(t *testing.T) {
+		svc, userRepo, tokenRepo, _, transactor := newTestUserServiceWithOutbox(t)
+		id := uuid.New()
+		tokenRepo.EXPECT().RevokeAllForUser(gomock.Any(), id).Return(nil)
+		userRepo.EXPECT().SoftDelete(gomock.Any(), id).Return(nil)
+
+		require.NoError(t, svc.DeleteAccount(context.Background(), id))
+
+		require.Len(t, transactor.committed, 1)
+		assert.Equal(t, string(userDomain.EventTypeUserDeleted), transactor.committed[0].event.EventType())
+		assert.Equal(t, id.String(), transactor.committed[0].aggregateID)
+	})
+
+	t.Run("role update stores role changed event", func(t *testing.T) {
+		svc, userRepo, _, publisher, transactor := newTestUserServiceWithOutbox(t)
+		id := uuid.New()
+		userRepo.EXPECT().UpdateRole(gomock.Any(), id, userDomain.RoleAdmin).
+			Return(&userDomain.User{ID: id, Email: "jane@example.com", Role: userDomain.RoleAdmin}, nil)
+
+		user, err := svc.UpdateUserRole(context.Background(), id, userDomain.RoleAdmin)
+
+		require.NoError(t, err)
+		assert.Equal(t, userDomain.RoleAdmin, user.Role)
+		require.Len(t, transactor.committed, 1)
+		entry := transactor.committed[0]
+		assert.Equal(t, id.String(), entry.aggregateID)
+		assert.Equal(t, string(userDomain.EventTypeUserRoleChanged), entry.event.EventType())
+		assert.Equal(t, "admin", entry.event.EventPayload()["role"])
+		publisher.AssertNotCalled(t, "Publish")
+	})
+
+	t.Run("role update fails when its event cannot be stored", func(t *testing.T) {
+		svc, userRepo, _, _, transactor := newTestUserServiceWithOutbox(t)
+		transactor.enqueueErr = errors.New("insert failed")
+		id := uuid.New()
+		userRepo.EXPECT().UpdateRole(gomock.Any(), id, userDomain.RoleAdmin).Return(&userDomain.User{ID: id, Role: userDomain.RoleAdmin}, nil)
+
+		_, err := svc.UpdateUserRole(context.Background(), id, userDomain.RoleAdmin)
+
+		require.Error(t, err)
+		assert.Equal(t, 1, transactor.rollbacks)
+		assert.Empty(t, transactor.committed)
+	})
+
+	t.Run("outbox event carries the request trace context", func(t *testing.T) {
+		exporter := tracetest.NewInMemoryExporter()
+		previousPropagator := otel.GetTextMapPropagator()
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		t.Cleanup(func() { otel.SetTextMapPropagator(previousPropagator) })
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+		svc, userRepo, _, _, transactor := newTestUserServiceWithOutbox(t)
+		id := uuid.New()
+		userRepo.EXPECT().UpdateRole(gomock.Any(), id, userDomain.RoleAdmin).Return(&userDomain.User{ID: id, Role: userDomain.RoleAdmin}, nil)
+
+		ctx, span := tp.Tracer("test").Start(context.Background(), "PUT /admin/users/:id/role")
+		_, err := svc.UpdateUserRole(ctx, id, userDomain.RoleAdmin)
+		span.End()
+
+		require.NoError(t, err)
+		require.Len(t, transactor.committed, 1)
+		metadata := transactor.committed[0].event.EventMetadata()
+		require.Contains(t, metadata, observability.TraceParentKey)
+		remote := trace.SpanContextFromContext(observability.ExtractTraceContext(context.Background(), metadata))
+		assert.Equal(t, span.SpanContext().TraceID(), remote.TraceID())
+		assert.Equal(t, span.SpanContext().SpanID(), remote.SpanID())
+		assert.Len(t, exporter.GetSpans(), 1)
+	})
+
+	t.Run("untraced request adds no trace context", func(t *testing.T) {
+		svc, userRepo, _, _, transactor := newTestUserServiceWithOutbox(t)
+		id := uuid.New()
+		userRepo.EXPECT().UpdateRole(gomock.Any(), id, userDomain.RoleAdmin).Return(&userDomain.User{ID: id, Role: userDomain.RoleAdmin}, nil)
+
+		_, err := svc.UpdateUserRole(context.Background(), id, userDomain.RoleAdmin)
+
+		require.NoError(t, err)
+		require.Len(t, transactor.committed, 1)
+		assert.NotContains(t, transactor.committed[0].event.EventMetadata(), observability.TraceParentKey)
+	})
+}
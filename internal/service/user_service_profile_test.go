@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// memoryPhoneVerifications is an in-memory user.PhoneVerificationRepository
+type memoryPhoneVerifications struct {
+	pending map[uuid.UUID]userDomain.PhoneVerification
+	now     time.Time
+}
+
+func newMemoryPhoneVerifications() *memoryPhoneVerifications {
+	return &memoryPhoneVerifications{pending: make(map[uuid.UUID]userDomain.PhoneVerification), now: time.Now()}
+}
+
+func (r *memoryPhoneVerifications) Save(ctx context.Context, v *userDomain.PhoneVerification) (*userDomain.PhoneVerification, error) {
+	saved := *v
+	saved.Attempts = 0
+	saved.CreatedAt = r.now
+	r.pending[v.UserID] = saved
+	return &saved, nil
+}
+
+func (r *memoryPhoneVerifications) Get(ctx context.Context, userID uuid.UUID) (*userDomain.PhoneVerification, error) {
+	v, ok := r.pending[userID]
+	if !ok {
+		return nil, userDomain.ErrPhoneVerificationNotFound
+	}
+	return &v, nil
+}
+
+func (r *memoryPhoneVerifications) IncrementAttempts(ctx context.Context, userID uuid.UUID) (int, error) {
+	v, ok := r.pending[userID]
+	if !ok {
+		return 0, userDomain.ErrPhoneVerificationNotFound
+	}
+	v.Attempts++
+	r.pending[userID] = v
+	return v.Attempts, nil
+}
+
+func (r *memoryPhoneVerifications) Delete(ctx context.Context, userID uuid.UUID) error {
+	delete(r.pending, userID)
+	return nil
+}
+
+// recordingSender keeps the codes it is asked to send
+type recordingSender struct {
+	codes []string
+	err   error
+}
+
+func (s *recordingSender) SendPhoneCode(ctx context.Context, phone, code string) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.codes = append(s.codes, code)
+	return nil
+}
+
+func TestUserService_UpdateProfile(t *testing.T) {
+	ctx := context.Background()
+	str := func(s string) *string { return &s }
+
+	t.Run("writes the merged profile and lists changed fields", func(t *testing.T) {
+		svc, userRepo, _, _, transactor := newTestUserServiceWithOutbox(t)
+		verifiedAt := time.Now().Add(-time.Hour)
+		current := &userDomain.User{ID: uuid.New(), Email: "jane@example.com", FirstName: "Jane", LastName: "Doe",
+			Phone: "+40721234567", PhoneVerifiedAt: &verifiedAt}
+		userRepo.EXPECT().GetByID(gomock.Any(), current.ID).Return(current, nil)
+		userRepo.EXPECT().UpdateProfile(gomock.Any(), current.ID, userDomain.Profile{
+			FirstName:   "Jane",
+			LastName:    "Doe",
+			Phone:       "+40729999999",
+			DateOfBirth: "1990-04-12",
+		}).Return(current, nil)
+
+		_, err := svc.UpdateProfile(ctx, current.ID, userDomain.ProfileUpdate{
+			FirstName:   str("Jane"),
+			Phone:       str("+40 729 999 999"),
+			DateOfBirth: str("1990-04-12"),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, transactor.committed, 1)
+		event := transactor.committed[0].event
+		assert.Equal(t, string(userDomain.EventTypeUserProfileUpdated), event.EventType())
+		assert.Equal(t, []interface{}{"phone", "phone_verified", "date_of_birth"}, event.EventPayload()["changed_fields"])
+		assert.NotContains(t, event.EventPayload(), "date_of_birth", "the event carries no personal data beyond names")
+	})
+
+	t.Run("an update changing nothing writes nothing", func(t *testing.T) {
+		svc, userRepo, _, _, transactor := newTestUserServiceWithOutbox(t)
+		current := &userDomain.User{ID: uuid.New(), FirstName: "Jane", LastName: "Doe"}
+		userRepo.EXPECT().GetByID(gomock.Any(), current.ID).Return(current, nil)
+
+		user, err := svc.UpdateProfile(ctx, current.ID, userDomain.ProfileUpdate{LastName: str(" Doe ")})
+
+		require.NoError(t, err)
+		assert.Equal(t, current, user)
+		assert.Empty(t, transactor.committed)
+	})
+
+	t.Run("invalid fields are rejected", func(t *testing.T) {
+		svc, userRepo, _, _, transactor := newTestUserServiceWithOutbox(t)
+		current := &userDomain.User{ID: uuid.New(), FirstName: "Jane", LastName: "Doe"}
+		userRepo.EXPECT().GetByID(gomock.Any(), current.ID).Return(current, nil).Times(2)
+
+		_, err := svc.UpdateProfile(ctx, current.ID, userDomain.ProfileUpdate{Phone: str("0721234567")})
+		assert.ErrorIs(t, err, userDomain.ErrInvalidProfile)
+
+		dob := time.Now().AddDate(-17, 0, 0).Format("2006-01-02")
+		_, err = svc.UpdateProfile(ctx, current.ID, userDomain.ProfileUpdate{DateOfBirth: &dob})
+		assert.ErrorIs(t, err, userDomain.ErrUnderage)
+		assert.Empty(t, transactor.committed)
+	})
+}
+
+func TestUserService_PhoneVerification(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*UserService, *mocks.MockUserRepository, *memoryPhoneVerifications, *recordingSender, *fakeTransactor) {
+		svc, userRepo, _, _, transactor := newTestUserServiceWithOutbox(t)
+		verifications := newMemoryPhoneVerifications()
+		sender := &recordingSender{}
+		svc.WithPhoneVerification(verifications, sender, 0, 3)
+		return svc, userRepo, verifications, sender, transactor
+	}
+
+	t.Run("not configured", func(t *testing.T) {
+		svc, _, _, _, _ := newTestUserServiceWithOutbox(t)
+
+		_, err := svc.StartPhoneVerification(ctx, uuid.New())
+		assert.ErrorIs(t, err, userDomain.ErrPhoneVerificationUnavailable)
+		_, err = svc.ConfirmPhone(ctx, uuid.New(), "123456")
+		assert.ErrorIs(t, err, userDomain.ErrPhoneVerificationUnavailable)
+	})
+
+	t.Run("no phone number", func(t *testing.T) {
+		svc, userRepo, _, _, _ := setup(t)
+		user := &userDomain.User{ID: uuid.New()}
+		userRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+
+		_, err := svc.StartPhoneVerification(ctx, user.ID)
+		assert.ErrorIs(t, err, userDomain.ErrPhoneNotSet)
+	})
+
+	t.Run("send and confirm a code", func(t *testing.T) {
+		svc, userRepo, verifications, sender, transactor := setup(t)
+		user := &userDomain.User{ID: uuid.New(), Email: "jane@example.com", FirstName: "Jane", LastName: "Doe", Phone: "+40721234567"}
+		userRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil).AnyTimes()
+		userRepo.EXPECT().UpdateProfile(gomock.Any(), user.ID, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, id uuid.UUID, profile userDomain.Profile) (*userDomain.User, error) {
+				require.NotNil(t, profile.PhoneVerifiedAt)
+				verified := *user
+				verified.PhoneVerifiedAt = profile.PhoneVerifiedAt
+				return &verified, nil
+			})
+
+		pending, err := svc.StartPhoneVerification(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "+40721234567", pending.Phone)
+		assert.WithinDuration(t, time.Now().Add(DefaultPhoneCodeTTL), pending.ExpiresAt, time.Minute)
+		require.Len(t, sender.codes, 1)
+		assert.Len(t, sender.codes[0], 6)
+
+		_, err = svc.StartPhoneVerification(ctx, user.ID)
+		assert.ErrorIs(t, err, userDomain.ErrPhoneVerificationThrottled, "codes are not resent right away")
+
+		_, err = svc.ConfirmPhone(ctx, user.ID, wrongCode(sender.codes[0]))
+		assert.ErrorIs(t, err, userDomain.ErrInvalidPhoneCode)
+
+		verified, err := svc.ConfirmPhone(ctx, user.ID, sender.codes[0])
+		require.NoError(t, err)
+		assert.True(t, verified.IsPhoneVerified())
+		assert.Empty(t, verifications.pending, "a confirmed code cannot be used again")
+		require.Len(t, transactor.committed, 1)
+		assert.Equal(t, []interface{}{"phone_verified"}, transactor.committed[0].event.EventPayload()["changed_fields"])
+	})
+
+	t.Run("wrong codes use up the attempts", func(t *testing.T) {
+		svc, userRepo, _, sender, _ := setup(t)
+		user := &userDomain.User{ID: uuid.New(), Phone: "+40721234567"}
+		userRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+
+		_, err := svc.StartPhoneVerification(ctx, user.ID)
+		require.NoError(t, err)
+		for i := 0; i < 3; i++ {
+			_, err = svc.ConfirmPhone(ctx, user.ID, wrongCode(sender.codes[0]))
+			assert.ErrorIs(t, err, userDomain.ErrInvalidPhoneCode)
+		}
+
+		_, err = svc.ConfirmPhone(ctx, user.ID, sender.codes[0])
+		assert.ErrorIs(t, err, userDomain.ErrPhoneVerificationThrottled)
+	})
+
+	t.Run("expired code", func(t *testing.T) {
+		svc, userRepo, verifications, sender, _ := setup(t)
+		user := &userDomain.User{ID: uuid.New(), Phone: "+40721234567"}
+		userRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+
+		_, err := svc.StartPhoneVerification(ctx, user.ID)
+		require.NoError(t, err)
+		v := verifications.pending[user.ID]
+		v.ExpiresAt = time.Now().Add(-time.Second)
+		verifications.pending[user.ID] = v
+
+		_, err = svc.ConfirmPhone(ctx, user.ID, sender.codes[0])
+		assert.ErrorIs(t, err, userDomain.ErrInvalidPhoneCode)
+	})
+
+	t.Run("a code only verifies the number it was sent to", func(t *testing.T) {
+		svc, userRepo, _, sender, _ := setup(t)
+		user := &userDomain.User{ID: uuid.New(), Phone: "+40721234567"}
+		changed := &userDomain.User{ID: user.ID, Phone: "+40729999999"}
+		gomock.InOrder(
+			userRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil),
+			userRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(changed, nil),
+		)
+
+		_, err := svc.StartPhoneVerification(ctx, user.ID)
+		require.NoError(t, err)
+		_, err = svc.ConfirmPhone(ctx, user.ID, sender.codes[0])
+		assert.ErrorIs(t, err, userDomain.ErrInvalidPhoneCode)
+	})
+
+	t.Run("send failure drops the code", func(t *testing.T) {
+		svc, userRepo, verifications, sender, _ := setup(t)
+		sender.err = errors.New("gateway down")
+		user := &userDomain.User{ID: uuid.New(), Phone: "+40721234567"}
+		userRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+
+		_, err := svc.StartPhoneVerification(ctx, user.ID)
+		require.Error(t, err)
+		assert.Empty(t, verifications.pending)
+	})
+}
+
+// wrongCode returns a six digit code different from code
+func wrongCode(code string) string {
+	if code == "000000" {
+		return "000001"
+	}
+	return "000000"
+}
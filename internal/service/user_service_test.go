@@ -8,6 +8,7 @@ import (
 
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain"
 	domainAuth "github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
 	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
 	"github.com/alex-necsoiu/pandora-exchange/internal/service"
 	"github.com/google/uuid"
@@ -59,8 +60,8 @@ func (m *MockUserRepository) UpdateKYCStatus(ctx context.Context, id uuid.UUID,
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
-func (m *MockUserRepository) UpdateProfile(ctx context.Context, id uuid.UUID, firstName, lastName string) (*domain.User, error) {
-	args := m.Called(ctx, id, firstName, lastName)
+func (m *MockUserRepository) UpdateProfile(ctx context.Context, id uuid.UUID, profile domain.Profile) (*domain.User, error) {
+	args := m.Called(ctx, id, profile)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -72,6 +73,38 @@ func (m *MockUserRepository) SoftDelete(ctx context.Context, id uuid.UUID) error
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) Restore(ctx context.Context, id uuid.UUID, deletedAt time.Time) (*domain.User, error) {
+	args := m.Called(ctx, id, deletedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) SetStatus(ctx context.Context, change *domain.AccountStatusChange) (*domain.User, error) {
+	args := m.Called(ctx, change)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) ListStatusChanges(ctx context.Context, userID uuid.UUID) ([]*domain.AccountStatusChange, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AccountStatusChange), args.Error(1)
+}
+
+func (m *MockUserRepository) ListExpiredStatuses(ctx context.Context, now time.Time, limit int) ([]*domain.User, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
 func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*domain.User, error) {
 	args := m.Called(ctx, limit, offset)
 	if args.Get(0) == nil {
@@ -85,12 +118,12 @@ func (m *MockUserRepository) Count(ctx context.Context) (int64, error) {
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockUserRepository) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*domain.User, error) {
-	args := m.Called(ctx, query, limit, offset)
+func (m *MockUserRepository) SearchUsers(ctx context.Context, search domain.UserSearch) (*domain.UserSearchPage, error) {
+	args := m.Called(ctx, search)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*domain.User), args.Error(1)
+	return args.Get(0).(*domain.UserSearchPage), args.Error(1)
 }
 
 func (m *MockUserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role domain.Role) (*domain.User, error) {
@@ -624,50 +657,6 @@ func TestGetByID(t *testing.T) {
 	})
 }
 
-// TestUpdateKYC tests KYC status updates
-func TestUpdateKYC(t *testing.T) {
-	t.Run("update KYC successfully", func(t *testing.T) {
-		userRepo := new(MockUserRepository)
-		tokenRepo := new(MockRefreshTokenRepository)
-		
-		svc, err := service.NewUserService(userRepo, tokenRepo, "test-secret-key-min-32-characters", 15*time.Minute, 7*24*time.Hour, getTestLogger(), nil)
-		require.NoError(t, err)
-
-		ctx := context.Background()
-		userID := uuid.New()
-		updatedUser := &domain.User{
-			ID:        userID,
-			KYCStatus: domain.KYCStatusVerified,
-		}
-
-		userRepo.On("UpdateKYCStatus", ctx, userID, domain.KYCStatusVerified).
-			Return(updatedUser, nil)
-
-		user, err := svc.UpdateKYC(ctx, userID, domain.KYCStatusVerified)
-		require.NoError(t, err)
-		assert.Equal(t, domain.KYCStatusVerified, user.KYCStatus)
-
-		userRepo.AssertExpectations(t)
-	})
-
-	t.Run("update KYC with invalid status fails", func(t *testing.T) {
-		userRepo := new(MockUserRepository)
-		tokenRepo := new(MockRefreshTokenRepository)
-		
-		svc, err := service.NewUserService(userRepo, tokenRepo, "test-secret-key-min-32-characters", 15*time.Minute, 7*24*time.Hour, getTestLogger(), nil)
-		require.NoError(t, err)
-
-		ctx := context.Background()
-		userID := uuid.New()
-
-		userRepo.On("UpdateKYCStatus", ctx, userID, domain.KYCStatus("invalid")).
-			Return(nil, domain.ErrInvalidKYCStatus)
-
-		_, err = svc.UpdateKYC(ctx, userID, domain.KYCStatus("invalid"))
-		assert.ErrorIs(t, err, domain.ErrInvalidKYCStatus)
-	})
-}
-
 // TestUpdateProfile tests profile updates
 func TestUpdateProfile(t *testing.T) {
 	t.Run("update profile successfully", func(t *testing.T) {
@@ -687,9 +676,10 @@ func TestUpdateProfile(t *testing.T) {
 			LastName:  newLastName,
 		}
 
-		userRepo.On("UpdateProfile", ctx, userID, newFirstName, newLastName).Return(updatedUser, nil)
+		userRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, FirstName: "Old", LastName: "Name"}, nil)
+		userRepo.On("UpdateProfile", ctx, userID, domain.Profile{FirstName: newFirstName, LastName: newLastName}).Return(updatedUser, nil)
 
-		user, err := svc.UpdateProfile(ctx, userID, newFirstName, newLastName)
+		user, err := svc.UpdateProfile(ctx, userID, domain.ProfileUpdate{FirstName: &newFirstName, LastName: &newLastName})
 		require.NoError(t, err)
 		assert.Equal(t, newFirstName, user.FirstName)
 		assert.Equal(t, newLastName, user.LastName)
@@ -848,11 +838,13 @@ func TestSearchUsers(t *testing.T) {
 			{ID: uuid.New(), Email: "alice.jones@example.com", FirstName: "Alice", LastName: "Jones"},
 		}
 
-		userRepo.On("SearchUsers", ctx, query, 10, 0).Return(users, nil)
+		userRepo.On("SearchUsers", ctx, mock.MatchedBy(func(s domain.UserSearch) bool {
+			return s.Query == query && s.Sort == domain.SearchSortRelevance && s.Deleted == domain.DeletedExclude && s.Limit == 10
+		})).Return(&domain.UserSearchPage{Users: users}, nil)
 
-		result, err := svc.SearchUsers(ctx, query, 10, 0)
+		result, err := svc.SearchUsers(ctx, domain.UserSearch{Query: " " + query + " ", Limit: 10})
 		require.NoError(t, err)
-		assert.Len(t, result, 2)
+		assert.Len(t, result.Users, 2)
 
 		userRepo.AssertExpectations(t)
 	})
@@ -867,11 +859,12 @@ func TestSearchUsers(t *testing.T) {
 		ctx := context.Background()
 		query := "nonexistent"
 
-		userRepo.On("SearchUsers", ctx, query, 10, 0).Return([]*domain.User{}, nil)
+		userRepo.On("SearchUsers", ctx, mock.Anything).Return(&domain.UserSearchPage{Users: []*domain.User{}}, nil)
 
-		result, err := svc.SearchUsers(ctx, query, 10, 0)
+		result, err := svc.SearchUsers(ctx, domain.UserSearch{Query: query})
 		require.NoError(t, err)
-		assert.Empty(t, result)
+		assert.Empty(t, result.Users)
+		assert.Empty(t, result.NextCursor)
 
 		userRepo.AssertExpectations(t)
 	})
@@ -887,13 +880,30 @@ func TestSearchUsers(t *testing.T) {
 		query := "test"
 		expectedErr := assert.AnError
 
-		userRepo.On("SearchUsers", ctx, query, 10, 0).Return(nil, expectedErr)
+		userRepo.On("SearchUsers", ctx, mock.Anything).Return(nil, expectedErr)
 
-		_, err = svc.SearchUsers(ctx, query, 10, 0)
+		_, err = svc.SearchUsers(ctx, domain.UserSearch{Query: query})
 		assert.ErrorIs(t, err, expectedErr)
 
 		userRepo.AssertExpectations(t)
 	})
+
+	t.Run("invalid search is rejected before the repository", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		tokenRepo := new(MockRefreshTokenRepository)
+
+		svc, err := service.NewUserService(userRepo, tokenRepo, "test-secret-key-min-32-characters", 15*time.Minute, 7*24*time.Hour, getTestLogger(), nil)
+		require.NoError(t, err)
+
+		ctx := context.Background()
+		_, err = svc.SearchUsers(ctx, domain.UserSearch{Sort: domain.SearchSortRelevance})
+		assert.ErrorIs(t, err, domain.ErrInvalidSearch)
+
+		_, err = svc.SearchUsers(ctx, domain.UserSearch{Query: "alice", Cursor: "not-a-cursor"})
+		assert.ErrorIs(t, err, domain.ErrInvalidSearchCursor)
+
+		userRepo.AssertNotCalled(t, "SearchUsers", mock.Anything, mock.Anything)
+	})
 }
 
 // TestGetUserByIDAdmin tests getting user by ID including deleted (admin operation)
@@ -1210,8 +1220,9 @@ func TestForceLogout(t *testing.T) {
 }
 
 // TestGetSystemStats tests getting system statistics (admin operation)
+// Dashboards built from the rollups are covered by TestUserService_Statistics.
 func TestGetSystemStats(t *testing.T) {
-	t.Run("get system stats successfully", func(t *testing.T) {
+	t.Run("get stats without statistics configured", func(t *testing.T) {
 		userRepo := new(MockUserRepository)
 		tokenRepo := new(MockRefreshTokenRepository)
 		
@@ -1219,55 +1230,9 @@ func TestGetSystemStats(t *testing.T) {
 		require.NoError(t, err)
 
 		ctx := context.Background()
-		totalCount := int64(10)
-		activeSessionCount := int64(5)
-
-		userRepo.On("Count", ctx).Return(totalCount, nil)
-		tokenRepo.On("CountAllActiveSessions", ctx).Return(activeSessionCount, nil)
 
-		stats, err := svc.GetSystemStats(ctx)
-		require.NoError(t, err)
-		assert.Equal(t, totalCount, stats["total_users"])
-		assert.Equal(t, activeSessionCount, stats["active_sessions"])
-
-		userRepo.AssertExpectations(t)
-		tokenRepo.AssertExpectations(t)
-	})
-
-	t.Run("get stats with repository error on count", func(t *testing.T) {
-		userRepo := new(MockUserRepository)
-		tokenRepo := new(MockRefreshTokenRepository)
-		
-		svc, err := service.NewUserService(userRepo, tokenRepo, "test-secret-key-min-32-characters", 15*time.Minute, 7*24*time.Hour, getTestLogger(), nil)
-		require.NoError(t, err)
-
-		ctx := context.Background()
-		expectedErr := assert.AnError
-
-		userRepo.On("Count", ctx).Return(int64(0), expectedErr)
-
-		_, err = svc.GetSystemStats(ctx)
-		assert.ErrorIs(t, err, expectedErr)
-
-		userRepo.AssertExpectations(t)
-	})
-
-	t.Run("get stats with repository error on session count", func(t *testing.T) {
-		userRepo := new(MockUserRepository)
-		tokenRepo := new(MockRefreshTokenRepository)
-		
-		svc, err := service.NewUserService(userRepo, tokenRepo, "test-secret-key-min-32-characters", 15*time.Minute, 7*24*time.Hour, getTestLogger(), nil)
-		require.NoError(t, err)
-
-		ctx := context.Background()
-		totalCount := int64(10)
-		expectedErr := assert.AnError
-
-		userRepo.On("Count", ctx).Return(totalCount, nil)
-		tokenRepo.On("CountAllActiveSessions", ctx).Return(int64(0), expectedErr)
-
-		_, err = svc.GetSystemStats(ctx)
-		assert.ErrorIs(t, err, expectedErr)
+		_, err = svc.GetSystemStats(ctx, stats.Query{})
+		assert.ErrorIs(t, err, stats.ErrUnavailable)
 
 		userRepo.AssertExpectations(t)
 		tokenRepo.AssertExpectations(t)
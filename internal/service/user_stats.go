@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
+)
+
+// DefaultStatsBackfillDays is how many days of statistics are rolled up on the first refresh
+// when no backfill is configured
+const DefaultStatsBackfillDays = 90
+
+// WithStatistics serves admin statistics from the rollups in repo. The first refresh rolls
+// up the last backfillDays days; later refreshes only the days since the last one.
+func (s *UserService) WithStatistics(repo stats.Repository, backfillDays int) *UserService {
+	if backfillDays < 1 {
+		backfillDays = 1
+	}
+	s.statsRepo = repo
+	s.statsBackfillDays = backfillDays
+	return s
+}
+
+// GetSystemStats builds the admin statistics dashboard of the days selected by query from the
+// rollups, which are as fresh as their last refresh (admin only).
+// Returns stats.ErrInvalidQuery or stats.ErrUnavailable.
+func (s *UserService) GetSystemStats(ctx context.Context, query stats.Query) (*stats.Dashboard, error) {
+	if s.statsRepo == nil {
+		return nil, stats.ErrUnavailable
+	}
+	if err := query.Normalize(time.Now()); err != nil {
+		return nil, err
+	}
+
+	days, err := s.statsRepo.ListDaily(ctx, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := s.statsRepo.GetSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"from":        query.From.Format(time.DateOnly),
+		"to":          query.To.Format(time.DateOnly),
+		"granularity": string(query.Granularity),
+	}).Debug("Admin: system statistics retrieved")
+	return stats.BuildDashboard(query, days, snapshot), nil
+}
+
+// RefreshStatistics brings the statistics rollups up to date. The day before the last rolled
+// up day is recomputed as well, to count events logged around midnight. Activity is kept only
+// as long as the monthly active users of the refreshed days need it.
+func (s *UserService) RefreshStatistics(ctx context.Context) error {
+	if s.statsRepo == nil {
+		return stats.ErrUnavailable
+	}
+
+	today := stats.Day(time.Now())
+	from := today.AddDate(0, 0, 1-s.statsBackfillDays)
+	// Activity from before the backfill counts towards its monthly active users
+	since := from.AddDate(0, 0, 1-stats.MAUWindowDays)
+
+	latest, err := s.statsRepo.LatestDay(ctx)
+	if err != nil {
+		return err
+	}
+	if latest != nil && latest.After(from) {
+		from = latest.AddDate(0, 0, -1)
+		since = from
+	}
+
+	recorded, err := s.statsRepo.RecordActivity(ctx, since)
+	if err != nil {
+		return err
+	}
+	days, err := s.statsRepo.RefreshDaily(ctx, from, today)
+	if err != nil {
+		return err
+	}
+	if err := s.statsRepo.RefreshSnapshot(ctx); err != nil {
+		return err
+	}
+	pruned, err := s.statsRepo.PruneActivity(ctx, from.AddDate(0, 0, 1-stats.MAUWindowDays))
+	if err != nil {
+		return err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"from":              from.Format(time.DateOnly),
+		"days":              days,
+		"activity_recorded": recorded,
+		"activity_pruned":   pruned,
+	}).Debug("Statistics refreshed")
+	return nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStatsRepository records the rollup calls made to it
+type fakeStatsRepository struct {
+	latest      *time.Time
+	days        []stats.Daily
+	snapshot    *stats.Snapshot
+	err         error
+	since       time.Time
+	refreshFrom time.Time
+	refreshTo   time.Time
+	pruneBefore time.Time
+	listFrom    time.Time
+	listTo      time.Time
+	snapshots   int
+}
+
+func (f *fakeStatsRepository) RecordActivity(ctx context.Context, since time.Time) (int64, error) {
+	f.since = since
+	return 3, f.err
+}
+
+func (f *fakeStatsRepository) RefreshDaily(ctx context.Context, from, to time.Time) (int64, error) {
+	f.refreshFrom, f.refreshTo = from, to
+	return 2, nil
+}
+
+func (f *fakeStatsRepository) RefreshSnapshot(ctx context.Context) error {
+	f.snapshots++
+	return nil
+}
+
+func (f *fakeStatsRepository) PruneActivity(ctx context.Context, before time.Time) (int64, error) {
+	f.pruneBefore = before
+	return 0, nil
+}
+
+func (f *fakeStatsRepository) LatestDay(ctx context.Context) (*time.Time, error) {
+	return f.latest, nil
+}
+
+func (f *fakeStatsRepository) ListDaily(ctx context.Context, from, to time.Time) ([]stats.Daily, error) {
+	f.listFrom, f.listTo = from, to
+	return f.days, f.err
+}
+
+func (f *fakeStatsRepository) GetSnapshot(ctx context.Context) (*stats.Snapshot, error) {
+	return f.snapshot, nil
+}
+
+func TestUserService_Statistics(t *testing.T) {
+	ctx := context.Background()
+	today := stats.Day(time.Now())
+
+	setup := func(t *testing.T, repo *fakeStatsRepository) *UserService {
+		svc, _, _ := newTestUserServiceWithPublisher(t, nil)
+		return svc.WithStatistics(repo, 10)
+	}
+
+	t.Run("dashboard", func(t *testing.T) {
+		repo := &fakeStatsRepository{
+			days:     []stats.Daily{{Day: today, Logins: 4, ActiveUsers: 2, MonthlyActiveUsers: 7}},
+			snapshot: &stats.Snapshot{KYCFunnel: []stats.FunnelStage{{Status: "approved", Users: 5}}},
+		}
+		svc := setup(t, repo)
+
+		dashboard, err := svc.GetSystemStats(ctx, stats.Query{Granularity: stats.GranularityWeek})
+		require.NoError(t, err)
+		assert.Equal(t, today, repo.listTo)
+		assert.Equal(t, today.AddDate(0, 0, 1-stats.DefaultRangeDays), repo.listFrom)
+		assert.Equal(t, int64(4), dashboard.Totals.Logins)
+		assert.Equal(t, int64(7), dashboard.MonthlyActiveUsers)
+		assert.Equal(t, int64(5), dashboard.TotalUsers)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		svc := setup(t, &fakeStatsRepository{})
+		_, err := svc.GetSystemStats(ctx, stats.Query{From: today, To: today.AddDate(0, 0, -1)})
+		assert.ErrorIs(t, err, stats.ErrInvalidQuery)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		svc, _, _ := newTestUserServiceWithPublisher(t, nil)
+		_, err := svc.GetSystemStats(ctx, stats.Query{})
+		assert.ErrorIs(t, err, stats.ErrUnavailable)
+		assert.ErrorIs(t, svc.RefreshStatistics(ctx), stats.ErrUnavailable)
+	})
+
+	t.Run("first refresh backfills", func(t *testing.T) {
+		repo := &fakeStatsRepository{}
+		svc := setup(t, repo)
+
+		require.NoError(t, svc.RefreshStatistics(ctx))
+		from := today.AddDate(0, 0, -9)
+		assert.Equal(t, from, repo.refreshFrom)
+		assert.Equal(t, today, repo.refreshTo)
+		assert.Equal(t, from.AddDate(0, 0, 1-stats.MAUWindowDays), repo.since)
+		assert.Equal(t, from.AddDate(0, 0, 1-stats.MAUWindowDays), repo.pruneBefore)
+		assert.Equal(t, 1, repo.snapshots)
+	})
+
+	t.Run("later refreshes resume from the last day", func(t *testing.T) {
+		latest := today
+		repo := &fakeStatsRepository{latest: &latest}
+		svc := setup(t, repo)
+
+		require.NoError(t, svc.RefreshStatistics(ctx))
+		yesterday := today.AddDate(0, 0, -1)
+		assert.Equal(t, yesterday, repo.refreshFrom)
+		assert.Equal(t, yesterday, repo.since)
+		assert.Equal(t, yesterday.AddDate(0, 0, 1-stats.MAUWindowDays), repo.pruneBefore)
+	})
+
+	t.Run("refresh error", func(t *testing.T) {
+		repo := &fakeStatsRepository{err: errors.New("database unavailable")}
+		svc := setup(t, repo)
+
+		assert.Error(t, svc.RefreshStatistics(ctx))
+		assert.Zero(t, repo.snapshots)
+	})
+}
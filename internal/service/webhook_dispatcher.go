@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+)
+
+const (
+	// webhookBaseBackoff is the delay after the first failed attempt; it doubles per attempt
+	webhookBaseBackoff = 10 * time.Second
+	// webhookMaxBackoff caps the retry delay
+	webhookMaxBackoff = time.Hour
+	// webhookLease is how long a claimed delivery is hidden from other dispatchers. It is far
+	// longer than a send, so a delivery is only picked up again if its dispatcher died.
+	webhookLease = 5 * time.Minute
+	// webhookConcurrency bounds how many deliveries of a batch are sent at once
+	webhookConcurrency = 10
+	// webhookCleanupInterval is how often finished deliveries past retention are deleted
+	webhookCleanupInterval = 10 * time.Minute
+	// defaults applied when the config leaves a setting unset
+	defaultWebhookDeliveryInterval = time.Second
+	defaultWebhookBatchSize        = 50
+	defaultWebhookMaxAttempts      = 12
+	defaultWebhookDisableAfter     = 20
+)
+
+// WebhookDispatcher sends queued webhook deliveries to their subscriptions.
+// A failed attempt is retried with exponential backoff until MaxAttempts is reached, when
+// the delivery is marked failed and kept in the log for a manual redeliver. Consecutive
+// failures are counted per subscription, which is disabled once DisableAfter is reached so
+// a dead endpoint stops consuming attempts. Replicas share the queue by leasing deliveries.
+type WebhookDispatcher struct {
+	repo         webhook.Repository
+	sender       webhook.Sender
+	logger       *observability.Logger
+	interval     time.Duration
+	batchSize    int32
+	maxAttempts  int
+	disableAfter int
+	retention    time.Duration
+	lastCleanup  time.Time
+	now          func() time.Time
+	stopChan     chan struct{}
+	doneChan     chan struct{}
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher.
+// Finished deliveries are kept for retention before cleanup deletes them;
+// a zero interval, batch size, attempt limit or disable threshold selects the default.
+func NewWebhookDispatcher(
+	repo webhook.Repository,
+	sender webhook.Sender,
+	logger *observability.Logger,
+	interval time.Duration,
+	batchSize int32,
+	maxAttempts int,
+	disableAfter int,
+	retention time.Duration,
+) *WebhookDispatcher {
+	if interval <= 0 {
+		interval = defaultWebhookDeliveryInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultWebhookBatchSize
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = defaultWebhookMaxAttempts
+	}
+	if disableAfter <= 0 {
+		disableAfter = defaultWebhookDisableAfter
+	}
+	return &WebhookDispatcher{
+		repo:         repo,
+		sender:       sender,
+		logger:       logger,
+		interval:     interval,
+		batchSize:    batchSize,
+		maxAttempts:  maxAttempts,
+		disableAfter: disableAfter,
+		retention:    retention,
+		now:          time.Now,
+		stopChan:     make(chan struct{}),
+		doneChan:     make(chan struct{}),
+	}
+}
+
+// Start begins dispatching in a goroutine; stop it with Stop()
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	d.logger.WithFields(map[string]interface{}{
+		"interval":      d.interval.String(),
+		"batch_size":    d.batchSize,
+		"max_attempts":  d.maxAttempts,
+		"disable_after": d.disableAfter,
+	}).Info("Starting webhook dispatcher")
+
+	ticker := time.NewTicker(d.interval)
+
+	go func() {
+		defer close(d.doneChan)
+		defer ticker.Stop()
+
+		for {
+			d.tick(ctx)
+
+			select {
+			case <-ticker.C:
+			case <-d.stopChan:
+				d.logger.Info("Webhook dispatcher stopped")
+				return
+			case <-ctx.Done():
+				d.logger.Info("Webhook dispatcher context cancelled")
+				return
+			}
+		}
+	}()
+}
+
+// Stop waits for the current batch to finish and stops the dispatcher
+func (d *WebhookDispatcher) Stop() {
+	d.logger.Info("Stopping webhook dispatcher")
+	close(d.stopChan)
+	<-d.doneChan
+	d.logger.Info("Webhook dispatcher stopped successfully")
+}
+
+// tick dispatches one batch and periodically cleans up the delivery log
+func (d *WebhookDispatcher) tick(ctx context.Context) {
+	if _, err := d.RunOnce(ctx); err != nil {
+		d.logger.WithError(err).Error("Webhook dispatch batch failed")
+	}
+
+	if d.now().Sub(d.lastCleanup) >= webhookCleanupInterval {
+		if _, err := d.Cleanup(ctx); err != nil {
+			d.logger.WithError(err).Error("Webhook delivery cleanup failed")
+		} else {
+			d.lastCleanup = d.now()
+		}
+	}
+}
+
+// RunOnce sends up to one batch of due deliveries and returns how many were accepted
+func (d *WebhookDispatcher) RunOnce(ctx context.Context) (int, error) {
+	now := d.now()
+	deliveries, err := d.repo.ClaimDue(ctx, now, now.Add(webhookLease), d.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	subscriptions := make(map[uuid.UUID]*webhook.Subscription)
+	for _, delivery := range deliveries {
+		if _, ok := subscriptions[delivery.SubscriptionID]; ok {
+			continue
+		}
+		sub, err := d.repo.GetByID(ctx, delivery.SubscriptionID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load webhook subscription: %w", err)
+		}
+		subscriptions[sub.ID] = sub
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		delivered int
+	)
+	sem := make(chan struct{}, webhookConcurrency)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(delivery *webhook.Delivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if d.deliver(ctx, subscriptions[delivery.SubscriptionID], delivery) {
+				mu.Lock()
+				delivered++
+				mu.Unlock()
+			}
+		}(delivery)
+	}
+	wg.Wait()
+
+	d.logger.WithFields(map[string]interface{}{
+		"claimed":   len(deliveries),
+		"delivered": delivered,
+	}).Debug("Webhook batch dispatched")
+	return delivered, nil
+}
+
+// Cleanup deletes finished deliveries created longer ago than the retention period
+func (d *WebhookDispatcher) Cleanup(ctx context.Context) (int64, error) {
+	deleted, err := d.repo.DeleteFinishedBefore(ctx, d.now().Add(-d.retention))
+	if err != nil {
+		return 0, err
+	}
+	if deleted > 0 {
+		d.logger.WithField("deleted", deleted).Info("Finished webhook deliveries cleaned up")
+	}
+	return deleted, nil
+}
+
+// deliver sends a delivery once and records the outcome; it reports whether it was accepted
+func (d *WebhookDispatcher) deliver(ctx context.Context, sub *webhook.Subscription, delivery *webhook.Delivery) bool {
+	attempt := d.sender.Send(ctx, sub, delivery)
+	fields := map[string]interface{}{
+		"subscription_id": sub.ID.String(),
+		"delivery_id":     delivery.ID.String(),
+		"event_id":        delivery.EventID,
+		"event_type":      delivery.EventType,
+		"attempts":        delivery.Attempts + 1,
+		"status_code":     attempt.StatusCode,
+	}
+
+	if attempt.Err == nil {
+		if err := d.repo.MarkSucceeded(ctx, delivery.ID, attempt); err != nil {
+			d.logger.WithError(err).WithFields(fields).Error("Failed to record webhook delivery")
+			return false
+		}
+		if err := d.repo.ResetFailures(ctx, sub.ID); err != nil {
+			d.logger.WithError(err).WithFields(fields).Warn("Failed to reset webhook subscription failures")
+		}
+		return true
+	}
+
+	var nextAttemptAt *time.Time
+	if delivery.Attempts+1 < d.maxAttempts {
+		next := d.now().Add(webhookBackoff(delivery.Attempts))
+		nextAttemptAt = &next
+		fields["next_attempt_at"] = next
+		d.logger.WithError(attempt.Err).WithFields(fields).Warn("Webhook delivery failed, will retry")
+	} else {
+		d.logger.WithError(attempt.Err).WithFields(fields).Error("Webhook delivery failed, giving up")
+	}
+
+	if err := d.repo.MarkFailed(ctx, delivery.ID, attempt, nextAttemptAt); err != nil {
+		d.logger.WithError(err).WithFields(fields).Error("Failed to record failed webhook delivery")
+		return false
+	}
+
+	reason := fmt.Sprintf("disabled after %d consecutive failed deliveries: %v", d.disableAfter, attempt.Err)
+	disabled, err := d.repo.RecordFailure(ctx, sub.ID, d.disableAfter, reason, attempt.At)
+	if err != nil {
+		d.logger.WithError(err).WithFields(fields).Error("Failed to record webhook subscription failure")
+		return false
+	}
+	if disabled {
+		d.logger.WithFields(map[string]interface{}{
+			"subscription_id": sub.ID.String(),
+			"url":             sub.URL,
+			"failures":        d.disableAfter,
+		}).Warn("Webhook subscription disabled after repeated failures")
+	}
+	return false
+}
+
+// webhookBackoff returns the retry delay after a delivery has failed attempts+1 times
+func webhookBackoff(attempts int) time.Duration {
+	if attempts < 0 {
+		attempts = 0
+	}
+	if attempts >= 20 {
+		return webhookMaxBackoff
+	}
+	delay := webhookBaseBackoff << attempts
+	if delay > webhookMaxBackoff {
+		return webhookMaxBackoff
+	}
+	return delay
+}
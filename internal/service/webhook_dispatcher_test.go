@@ -0,0 +1,525 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/alex-necsoiu/pandora-exchange/internal/webhooks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryWebhooks is an in-memory webhook.Repository with the same claim and failure rules as the SQL queries
+type memoryWebhooks struct {
+	mu            sync.Mutex
+	subscriptions map[uuid.UUID]*webhook.Subscription
+	deliveries    []*webhook.Delivery
+}
+
+func newMemoryWebhooks() *memoryWebhooks {
+	return &memoryWebhooks{subscriptions: map[uuid.UUID]*webhook.Subscription{}}
+}
+
+func (m *memoryWebhooks) Create(ctx context.Context, sub *webhook.Subscription) (*webhook.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	created := *sub
+	created.ID = uuid.New()
+	created.Enabled = true
+	created.CreatedAt = time.Now()
+	created.UpdatedAt = created.CreatedAt
+	m.subscriptions[created.ID] = &created
+	result := created
+	return &result, nil
+}
+
+func (m *memoryWebhooks) GetByID(ctx context.Context, id uuid.UUID) (*webhook.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub, ok := m.subscriptions[id]
+	if !ok {
+		return nil, webhook.ErrSubscriptionNotFound
+	}
+	result := *sub
+	return &result, nil
+}
+
+func (m *memoryWebhooks) List(ctx context.Context, limit, offset int32) ([]*webhook.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var subs []*webhook.Subscription
+	for _, sub := range m.subscriptions {
+		result := *sub
+		subs = append(subs, &result)
+	}
+	return subs, nil
+}
+
+func (m *memoryWebhooks) Update(ctx context.Context, id uuid.UUID, update webhook.SubscriptionUpdate) (*webhook.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub, ok := m.subscriptions[id]
+	if !ok {
+		return nil, webhook.ErrSubscriptionNotFound
+	}
+	sub.URL = update.URL
+	sub.Description = update.Description
+	sub.EventTypes = update.EventTypes
+	if update.Secret != nil {
+		sub.Secret = *update.Secret
+	}
+	if update.Enabled && !sub.Enabled {
+		sub.ConsecutiveFailures = 0
+		sub.DisabledAt = nil
+		sub.DisabledReason = nil
+	} else if !update.Enabled && sub.Enabled {
+		now := time.Now()
+		sub.DisabledAt = &now
+		sub.DisabledReason = update.DisabledReason
+	}
+	sub.Enabled = update.Enabled
+	result := *sub
+	return &result, nil
+}
+
+func (m *memoryWebhooks) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.subscriptions[id]; !ok {
+		return webhook.ErrSubscriptionNotFound
+	}
+	delete(m.subscriptions, id)
+	return nil
+}
+
+func (m *memoryWebhooks) RecordFailure(ctx context.Context, id uuid.UUID, disableAfter int, reason string, at time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub, ok := m.subscriptions[id]
+	if !ok || !sub.Enabled {
+		return false, nil
+	}
+	sub.ConsecutiveFailures++
+	if sub.ConsecutiveFailures >= disableAfter {
+		sub.Enabled = false
+		sub.DisabledAt = &at
+		sub.DisabledReason = &reason
+		return true, nil
+	}
+	return false, nil
+}
+
+func (m *memoryWebhooks) ResetFailures(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if sub, ok := m.subscriptions[id]; ok {
+		sub.ConsecutiveFailures = 0
+	}
+	return nil
+}
+
+func (m *memoryWebhooks) Enqueue(ctx context.Context, eventID, eventType string, payload []byte, at time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var queued int64
+	for _, sub := range m.subscriptions {
+		if !sub.Enabled || !sub.Matches(eventType) || m.find(sub.ID, eventID) != nil {
+			continue
+		}
+		m.deliveries = append(m.deliveries, &webhook.Delivery{
+			ID:             uuid.New(),
+			SubscriptionID: sub.ID,
+			EventID:        eventID,
+			EventType:      eventType,
+			Payload:        payload,
+			Status:         webhook.DeliveryPending,
+			NextAttemptAt:  at,
+			CreatedAt:      at,
+		})
+		queued++
+	}
+	return queued, nil
+}
+
+func (m *memoryWebhooks) find(subscriptionID uuid.UUID, eventID string) *webhook.Delivery {
+	for _, d := range m.deliveries {
+		if d.SubscriptionID == subscriptionID && d.EventID == eventID {
+			return d
+		}
+	}
+	return nil
+}
+
+func (m *memoryWebhooks) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int32) ([]*webhook.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var due []*webhook.Delivery
+	for _, d := range m.deliveries {
+		sub := m.subscriptions[d.SubscriptionID]
+		if d.Status == webhook.DeliveryPending && !d.NextAttemptAt.After(now) && sub != nil && sub.Enabled {
+			due = append(due, d)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	if len(due) > int(limit) {
+		due = due[:limit]
+	}
+	claimed := make([]*webhook.Delivery, 0, len(due))
+	for _, d := range due {
+		d.NextAttemptAt = leaseUntil
+		result := *d
+		claimed = append(claimed, &result)
+	}
+	return claimed, nil
+}
+
+func (m *memoryWebhooks) MarkSucceeded(ctx context.Context, id uuid.UUID, attempt webhook.Attempt) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d := m.delivery(id)
+	d.Status = webhook.DeliverySucceeded
+	d.Attempts++
+	d.LastAttemptAt = &attempt.At
+	d.LastStatusCode = &attempt.StatusCode
+	d.LastError = nil
+	d.DeliveredAt = &attempt.At
+	return nil
+}
+
+func (m *memoryWebhooks) MarkFailed(ctx context.Context, id uuid.UUID, attempt webhook.Attempt, nextAttemptAt *time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d := m.delivery(id)
+	d.Attempts++
+	d.LastAttemptAt = &attempt.At
+	if attempt.StatusCode != 0 {
+		d.LastStatusCode = &attempt.StatusCode
+	}
+	lastError := attempt.Err.Error()
+	d.LastError = &lastError
+	if nextAttemptAt == nil {
+		d.Status = webhook.DeliveryFailed
+		d.NextAttemptAt = attempt.At
+	} else {
+		d.NextAttemptAt = *nextAttemptAt
+	}
+	return nil
+}
+
+func (m *memoryWebhooks) delivery(id uuid.UUID) *webhook.Delivery {
+	for _, d := range m.deliveries {
+		if d.ID == id {
+			return d
+		}
+	}
+	return nil
+}
+
+func (m *memoryWebhooks) GetDelivery(ctx context.Context, id uuid.UUID) (*webhook.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d := m.delivery(id)
+	if d == nil {
+		return nil, webhook.ErrDeliveryNotFound
+	}
+	result := *d
+	return &result, nil
+}
+
+func (m *memoryWebhooks) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, status *webhook.DeliveryStatus, limit, offset int32) ([]*webhook.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deliveries []*webhook.Delivery
+	for _, d := range m.deliveries {
+		if d.SubscriptionID == subscriptionID && (status == nil || d.Status == *status) {
+			result := *d
+			deliveries = append(deliveries, &result)
+		}
+	}
+	return deliveries, nil
+}
+
+func (m *memoryWebhooks) Redeliver(ctx context.Context, subscriptionID, deliveryID uuid.UUID, now time.Time) (*webhook.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d := m.delivery(deliveryID)
+	if d == nil || d.SubscriptionID != subscriptionID {
+		return nil, webhook.ErrDeliveryNotFound
+	}
+	if d.Status == webhook.DeliveryPending {
+		return nil, webhook.ErrDeliveryPending
+	}
+	d.Status = webhook.DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.DeliveredAt = nil
+	result := *d
+	return &result, nil
+}
+
+func (m *memoryWebhooks) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := m.deliveries[:0]
+	var deleted int64
+	for _, d := range m.deliveries {
+		if d.Status != webhook.DeliveryPending && d.CreatedAt.Before(before) {
+			deleted++
+			continue
+		}
+		kept = append(kept, d)
+	}
+	m.deliveries = kept
+	return deleted, nil
+}
+
+func (m *memoryWebhooks) subscribe(t *testing.T, url string, eventTypes ...string) *webhook.Subscription {
+	t.Helper()
+	sub, err := m.Create(context.Background(), &webhook.Subscription{URL: url, EventTypes: eventTypes, Secret: "whsec_0123456789abcdef"})
+	require.NoError(t, err)
+	return sub
+}
+
+// webhookEndpoint is an httptest server answering with the next scripted status code and
+// recording every request body whose signature verified
+type webhookEndpoint struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	received []string
+	calls    atomic.Int32
+}
+
+func newWebhookEndpoint(t *testing.T, secret string, statuses ...int) *webhookEndpoint {
+	e := &webhookEndpoint{statuses: statuses}
+	e.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e.calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		if err := webhook.Verify(secret, r.Header.Get(webhook.HeaderSignature), r.Header.Get(webhook.HeaderTimestamp), body, time.Now(), time.Minute); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		e.mu.Lock()
+		status := http.StatusOK
+		if len(e.statuses) > 0 {
+			status = e.statuses[0]
+			e.statuses = e.statuses[1:]
+		}
+		if status < 300 {
+			e.received = append(e.received, r.Header.Get(webhook.HeaderEventID))
+		}
+		e.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(e.Close)
+	return e
+}
+
+func newTestWebhookDispatcher(repo webhook.Repository, clock *time.Time, maxAttempts, disableAfter int) *WebhookDispatcher {
+	dispatcher := NewWebhookDispatcher(repo, webhooks.NewHTTPSender(nil, time.Second).WithPrivateNetworks(true), observability.NewLogger("dev", "test-service"), time.Second, 10, maxAttempts, disableAfter, time.Hour)
+	dispatcher.now = func() time.Time { return *clock }
+	return dispatcher
+}
+
+func TestWebhookDispatcher_RunOnce_DeliversSignedEvents(t *testing.T) {
+	ctx := context.Background()
+	clock := time.Now()
+	repo := newMemoryWebhooks()
+	endpoint := newWebhookEndpoint(t, "whsec_0123456789abcdef")
+	registered := repo.subscribe(t, endpoint.URL, "user.registered")
+	everything := repo.subscribe(t, endpoint.URL)
+
+	_, err := repo.Enqueue(ctx, "evt-1", "user.registered", []byte(`{"id":"evt-1"}`), clock)
+	require.NoError(t, err)
+	_, err = repo.Enqueue(ctx, "evt-2", "user.deleted", []byte(`{"id":"evt-2"}`), clock)
+	require.NoError(t, err)
+
+	delivered, err := newTestWebhookDispatcher(repo, &clock, 3, 5).RunOnce(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, delivered)
+	assert.ElementsMatch(t, []string{"evt-1", "evt-1", "evt-2"}, endpoint.received)
+
+	deliveries, err := repo.ListDeliveries(ctx, registered.ID, nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, webhook.DeliverySucceeded, deliveries[0].Status)
+	assert.Equal(t, http.StatusOK, *deliveries[0].LastStatusCode)
+
+	deliveries, err = repo.ListDeliveries(ctx, everything.ID, nil, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 2)
+}
+
+func TestWebhookDispatcher_RunOnce_RetriesWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	clock := time.Now()
+	repo := newMemoryWebhooks()
+	endpoint := newWebhookEndpoint(t, "whsec_0123456789abcdef", http.StatusInternalServerError, http.StatusBadGateway)
+	sub := repo.subscribe(t, endpoint.URL)
+	_, err := repo.Enqueue(ctx, "evt-1", "user.registered", []byte(`{}`), clock)
+	require.NoError(t, err)
+	dispatcher := newTestWebhookDispatcher(repo, &clock, 5, 10)
+
+	delivered, err := dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+
+	deliveries, _ := repo.ListDeliveries(ctx, sub.ID, nil, 10, 0)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, webhook.DeliveryPending, deliveries[0].Status)
+	assert.Equal(t, clock.Add(webhookBaseBackoff), deliveries[0].NextAttemptAt)
+	assert.Contains(t, *deliveries[0].LastError, "status 500")
+
+	// Not due yet
+	delivered, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+	assert.Equal(t, int32(1), endpoint.calls.Load())
+
+	clock = clock.Add(webhookBaseBackoff)
+	_, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	deliveries, _ = repo.ListDeliveries(ctx, sub.ID, nil, 10, 0)
+	assert.Equal(t, clock.Add(2*webhookBaseBackoff), deliveries[0].NextAttemptAt, "backoff doubles")
+
+	clock = clock.Add(2 * webhookBaseBackoff)
+	delivered, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	deliveries, _ = repo.ListDeliveries(ctx, sub.ID, nil, 10, 0)
+	assert.Equal(t, webhook.DeliverySucceeded, deliveries[0].Status)
+	assert.Equal(t, 3, deliveries[0].Attempts)
+
+	current, _ := repo.GetByID(ctx, sub.ID)
+	assert.Zero(t, current.ConsecutiveFailures, "success resets the failure count")
+}
+
+func TestWebhookDispatcher_RunOnce_GivesUpAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	clock := time.Now()
+	repo := newMemoryWebhooks()
+	endpoint := newWebhookEndpoint(t, "whsec_0123456789abcdef", http.StatusGone, http.StatusGone)
+	sub := repo.subscribe(t, endpoint.URL)
+	_, err := repo.Enqueue(ctx, "evt-1", "user.registered", []byte(`{}`), clock)
+	require.NoError(t, err)
+	dispatcher := newTestWebhookDispatcher(repo, &clock, 2, 10)
+
+	_, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	clock = clock.Add(webhookMaxBackoff)
+	_, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+
+	deliveries, _ := repo.ListDeliveries(ctx, sub.ID, nil, 10, 0)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, webhook.DeliveryFailed, deliveries[0].Status)
+	assert.Equal(t, 2, deliveries[0].Attempts)
+
+	clock = clock.Add(webhookMaxBackoff)
+	_, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), endpoint.calls.Load(), "failed deliveries are not retried")
+}
+
+func TestWebhookDispatcher_RunOnce_DisablesFailingSubscription(t *testing.T) {
+	ctx := context.Background()
+	clock := time.Now()
+	repo := newMemoryWebhooks()
+	endpoint := newWebhookEndpoint(t, "whsec_0123456789abcdef", http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	sub := repo.subscribe(t, endpoint.URL)
+	for _, id := range []string{"evt-1", "evt-2", "evt-3"} {
+		_, err := repo.Enqueue(ctx, id, "user.registered", []byte(`{}`), clock)
+		require.NoError(t, err)
+	}
+	dispatcher := newTestWebhookDispatcher(repo, &clock, 10, 3)
+
+	_, err := dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+
+	current, _ := repo.GetByID(ctx, sub.ID)
+	assert.False(t, current.Enabled)
+	require.NotNil(t, current.DisabledReason)
+	assert.Contains(t, *current.DisabledReason, "3 consecutive failed deliveries")
+
+	// Deliveries of a disabled subscription are held, not sent
+	clock = clock.Add(webhookMaxBackoff)
+	_, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), endpoint.calls.Load())
+}
+
+func TestWebhookDispatcher_Cleanup(t *testing.T) {
+	ctx := context.Background()
+	clock := time.Now()
+	repo := newMemoryWebhooks()
+	endpoint := newWebhookEndpoint(t, "whsec_0123456789abcdef")
+	repo.subscribe(t, endpoint.URL)
+	_, err := repo.Enqueue(ctx, "evt-old", "user.registered", []byte(`{}`), clock.Add(-2*time.Hour))
+	require.NoError(t, err)
+	dispatcher := newTestWebhookDispatcher(repo, &clock, 3, 5)
+	_, err = dispatcher.RunOnce(ctx)
+	require.NoError(t, err)
+	_, err = repo.Enqueue(ctx, "evt-new", "user.registered", []byte(`{}`), clock)
+	require.NoError(t, err)
+
+	deleted, err := dispatcher.Cleanup(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.Len(t, repo.deliveries, 1)
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, webhookBackoff(0))
+	assert.Equal(t, 20*time.Second, webhookBackoff(1))
+	assert.Equal(t, 2560*time.Second, webhookBackoff(8))
+	assert.Equal(t, webhookMaxBackoff, webhookBackoff(9))
+	assert.Equal(t, webhookMaxBackoff, webhookBackoff(100))
+}
+
+func TestWebhookPublisher(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryWebhooks()
+	sub := repo.subscribe(t, "https://partner.example.com/hooks", "user.registered")
+	bus := &scriptedPublisher{}
+	publisher := NewWebhookPublisher(bus, repo, observability.NewLogger("dev", "test-service"))
+
+	event := user.NewTypedEvent(uuid.New(), user.RegisteredPayload{Email: "alice@example.com", FirstName: "Alice", LastName: "Smith"})
+	require.NoError(t, publisher.Publish(event))
+	require.NoError(t, publisher.Publish(event), "publishing again queues no duplicate")
+	require.NoError(t, publisher.Publish(user.NewEvent(user.EventTypeUserDeleted, uuid.New(), nil)))
+
+	assert.Len(t, bus.published, 3)
+	deliveries, err := repo.ListDeliveries(ctx, sub.ID, nil, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	var cloudEvent common.CloudEvent
+	require.NoError(t, json.Unmarshal(deliveries[0].Payload, &cloudEvent))
+	assert.Equal(t, event.ID, cloudEvent.ID)
+	assert.Equal(t, "user.registered", cloudEvent.Type)
+	assert.Equal(t, common.CloudEventsSpecVersion, cloudEvent.SpecVersion)
+
+	t.Run("bus failure queues nothing", func(t *testing.T) {
+		failing := NewWebhookPublisher(&scriptedPublisher{fail: func(common.EventEnvelope) bool { return true }}, repo, observability.NewLogger("dev", "test-service"))
+		err := failing.Publish(user.NewTypedEvent(uuid.New(), user.RegisteredPayload{Email: "bob@example.com"}))
+		assert.Error(t, err)
+		assert.Len(t, repo.deliveries, 1)
+	})
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+)
+
+// WebhookPublisher decorates the event publisher so every event that reaches the bus is also
+// queued for the webhook subscriptions matching its type. Each delivery carries the event in
+// its CloudEvents JSON form, the same representation bus consumers receive.
+//
+// An enqueue failure is returned so the outbox relay retries the event; the bus publish is
+// then repeated, which consumers already tolerate, and enqueueing the same event twice adds
+// no deliveries.
+type WebhookPublisher struct {
+	next   common.EventPublisher
+	repo   webhook.Repository
+	logger *observability.Logger
+	now    func() time.Time
+}
+
+// NewWebhookPublisher wraps next so published events are also queued for webhook delivery
+func NewWebhookPublisher(next common.EventPublisher, repo webhook.Repository, logger *observability.Logger) *WebhookPublisher {
+	return &WebhookPublisher{
+		next:   next,
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Publish publishes the event and queues its webhook deliveries
+func (p *WebhookPublisher) Publish(event interface{}) error {
+	if err := p.next.Publish(event); err != nil {
+		return err
+	}
+	return p.enqueue(event)
+}
+
+// PublishBatch publishes the events and queues their webhook deliveries
+func (p *WebhookPublisher) PublishBatch(events []interface{}) error {
+	if err := p.next.PublishBatch(events); err != nil {
+		return err
+	}
+	for _, event := range events {
+		if err := p.enqueue(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the wrapped publisher
+func (p *WebhookPublisher) Close() error {
+	return p.next.Close()
+}
+
+// enqueue queues the event for every enabled subscription that matches its type
+func (p *WebhookPublisher) enqueue(event interface{}) error {
+	envelope, ok := event.(common.EventEnvelope)
+	if !ok {
+		return errors.New("event does not implement EventEnvelope")
+	}
+
+	cloudEvent, err := common.NewCloudEvent(envelope)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(cloudEvent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	queued, err := p.repo.Enqueue(context.Background(), envelope.EventID(), envelope.EventType(), payload, p.now())
+	if err != nil {
+		p.logger.WithError(err).WithFields(map[string]interface{}{
+			"event_id":   envelope.EventID(),
+			"event_type": envelope.EventType(),
+		}).Error("Failed to queue webhook deliveries")
+		return err
+	}
+
+	if queued > 0 {
+		p.logger.WithFields(map[string]interface{}{
+			"event_id":   envelope.EventID(),
+			"event_type": envelope.EventType(),
+			"deliveries": queued,
+		}).Debug("Webhook deliveries queued")
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+)
+
+// Audit events recorded when admins manage webhook subscriptions
+const (
+	EventWebhookSubscriptionCreated = "webhook.subscription.created"
+	EventWebhookSubscriptionUpdated = "webhook.subscription.updated"
+	EventWebhookSubscriptionDeleted = "webhook.subscription.deleted"
+	EventWebhookRedelivered         = "webhook.delivery.redelivered"
+)
+
+// webhookSecretPrefix marks generated secrets so they are recognizable in partner configs and secret scanners
+const webhookSecretPrefix = "whsec_"
+
+// Compile-time check to ensure WebhookService implements webhook.Service
+var _ webhook.Service = (*WebhookService)(nil)
+
+// WebhookService manages webhook subscriptions and their delivery log for admins.
+// Subscriptions send user data to external endpoints, so every change is audited.
+type WebhookService struct {
+	repo      webhook.Repository
+	auditRepo audit.Repository
+	logger    *observability.Logger
+	now       func() time.Time
+	allowHTTP bool
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(repo webhook.Repository, auditRepo audit.Repository, logger *observability.Logger) *WebhookService {
+	return &WebhookService{
+		repo:      repo,
+		auditRepo: auditRepo,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// WithPlainHTTP accepts subscription URLs using plain http, for local development endpoints
+func (s *WebhookService) WithPlainHTTP(allow bool) *WebhookService {
+	s.allowHTTP = allow
+	return s
+}
+
+// CreateSubscription validates and stores a subscription, generating its secret if none was given
+func (s *WebhookService) CreateSubscription(ctx context.Context, req *webhook.CreateRequest) (*webhook.Subscription, error) {
+	if err := req.Validate(s.allowHTTP); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	sub, err := s.repo.Create(ctx, &webhook.Subscription{
+		URL:         req.URL,
+		Description: req.Description,
+		EventTypes:  req.EventTypes,
+		Secret:      secret,
+		CreatedBy:   req.CreatedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"subscription_id": sub.ID.String(),
+		"url":             sub.URL,
+		"event_types":     sub.EventTypes,
+		"actor":           req.CreatedBy,
+	}).Info("Webhook subscription created")
+
+	s.recordAction(ctx, EventWebhookSubscriptionCreated, "create", sub, req.CreatedBy, nil)
+	return sub, nil
+}
+
+// GetSubscription retrieves a subscription
+func (s *WebhookService) GetSubscription(ctx context.Context, id uuid.UUID) (*webhook.Subscription, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListSubscriptions retrieves subscriptions, newest first
+func (s *WebhookService) ListSubscriptions(ctx context.Context, limit, offset int32) ([]*webhook.Subscription, error) {
+	return s.repo.List(ctx, limit, offset)
+}
+
+// UpdateSubscription changes a subscription's settings, re-enables or disables it, or rotates its secret
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id uuid.UUID, req *webhook.UpdateRequest) (*webhook.Subscription, error) {
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := req.Apply(current, s.allowHTTP)
+	if err != nil {
+		return nil, err
+	}
+
+	update := webhook.SubscriptionUpdate{
+		URL:         updated.URL,
+		Description: updated.Description,
+		EventTypes:  updated.EventTypes,
+		Enabled:     updated.Enabled,
+	}
+	if !updated.Enabled {
+		reason := "disabled by " + req.Actor
+		update.DisabledReason = &reason
+	}
+	if req.RotateSecret {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		update.Secret = &secret
+	}
+
+	sub, err := s.repo.Update(ctx, id, update)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]interface{}{}
+	if sub.URL != current.URL {
+		changes["previous_url"] = current.URL
+	}
+	if sub.Enabled != current.Enabled {
+		changes["enabled"] = sub.Enabled
+	}
+	if req.RotateSecret {
+		changes["secret_rotated"] = true
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"subscription_id": id.String(),
+		"enabled":         sub.Enabled,
+		"secret_rotated":  req.RotateSecret,
+		"actor":           req.Actor,
+	}).Info("Webhook subscription updated")
+
+	s.recordAction(ctx, EventWebhookSubscriptionUpdated, "update", sub, req.Actor, changes)
+	return sub, nil
+}
+
+// DeleteSubscription removes a subscription and its delivery log
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id uuid.UUID, actor string) error {
+	sub, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"subscription_id": id.String(),
+		"actor":           actor,
+	}).Info("Webhook subscription deleted")
+
+	s.recordAction(ctx, EventWebhookSubscriptionDeleted, "delete", sub, actor, nil)
+	return nil
+}
+
+// ListDeliveries retrieves a subscription's delivery log, newest first
+func (s *WebhookService) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, status *webhook.DeliveryStatus, limit, offset int32) ([]*webhook.Delivery, error) {
+	if _, err := s.repo.GetByID(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListDeliveries(ctx, subscriptionID, status, limit, offset)
+}
+
+// Redeliver queues a succeeded or failed delivery again with a fresh set of attempts.
+// Deliveries of a disabled subscription are not sent, so the subscription must be enabled first.
+func (s *WebhookService) Redeliver(ctx context.Context, subscriptionID, deliveryID uuid.UUID, actor string) (*webhook.Delivery, error) {
+	sub, err := s.repo.GetByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if !sub.Enabled {
+		return nil, webhook.ErrSubscriptionDisabled
+	}
+
+	delivery, err := s.repo.Redeliver(ctx, subscriptionID, deliveryID, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"subscription_id": subscriptionID.String(),
+		"delivery_id":     deliveryID.String(),
+		"event_id":        delivery.EventID,
+		"actor":           actor,
+	}).Info("Webhook delivery queued for redelivery")
+
+	s.recordAction(ctx, EventWebhookRedelivered, "redeliver", sub, actor, map[string]interface{}{
+		"delivery_id": deliveryID.String(),
+		"event_id":    delivery.EventID,
+		"event_type":  delivery.EventType,
+	})
+	return delivery, nil
+}
+
+// recordAction audits an admin's change to a subscription as a security event
+func (s *WebhookService) recordAction(ctx context.Context, eventType, action string, sub *webhook.Subscription, actor string, extra map[string]interface{}) {
+	resourceType := "webhook_subscription"
+	resourceID := sub.ID.String()
+
+	metadata := map[string]interface{}{
+		"url":         sub.URL,
+		"event_types": sub.EventTypes,
+		"enabled":     sub.Enabled,
+	}
+	for k, v := range extra {
+		metadata[k] = v
+	}
+
+	entry := &audit.Log{
+		EventType:       eventType,
+		EventCategory:   audit.CategorySecurity,
+		Severity:        audit.SeverityWarning,
+		ActorType:       audit.ActorAdmin,
+		ActorIdentifier: &actor,
+		Action:          action,
+		ResourceType:    &resourceType,
+		ResourceID:      &resourceID,
+		Metadata:        metadata,
+		Status:          audit.StatusSuccess,
+	}
+
+	if _, err := s.auditRepo.Create(ctx, entry); err != nil {
+		s.logger.WithError(err).WithField("subscription_id", resourceID).Error("Failed to record webhook subscription action in audit log")
+	}
+}
+
+// generateWebhookSecret returns a random 256-bit signing secret
+func generateWebhookSecret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(key), nil
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
+	"github.com/alex-necsoiu/pandora-exchange/internal/mocks"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebhookService() (*WebhookService, *memoryWebhooks, *mocks.MockAuditRepository) {
+	repo := newMemoryWebhooks()
+	auditRepo := new(mocks.MockAuditRepository)
+	return NewWebhookService(repo, auditRepo, observability.NewLogger("dev", "test-service")), repo, auditRepo
+}
+
+func auditedAs(eventType string) interface{} {
+	return mock.MatchedBy(func(l *audit.Log) bool {
+		return l.EventType == eventType && l.ActorType == audit.ActorAdmin && *l.ActorIdentifier == "admin@test.com"
+	})
+}
+
+func TestWebhookService_CreateSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("generates a secret when none is given", func(t *testing.T) {
+		svc, _, auditRepo := newTestWebhookService()
+		auditRepo.On("Create", mock.Anything, auditedAs(EventWebhookSubscriptionCreated)).Return(&audit.Log{}, nil).Once()
+
+		sub, err := svc.CreateSubscription(ctx, &webhook.CreateRequest{
+			URL:        "https://partner.example.com/hooks",
+			EventTypes: []string{"user.registered"},
+			CreatedBy:  "admin@test.com",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, sub.Enabled)
+		assert.True(t, strings.HasPrefix(sub.Secret, webhookSecretPrefix))
+		assert.Len(t, sub.Secret, len(webhookSecretPrefix)+64)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("keeps a supplied secret", func(t *testing.T) {
+		svc, _, auditRepo := newTestWebhookService()
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			_, hasSecret := l.Metadata["secret"]
+			return l.EventType == EventWebhookSubscriptionCreated &&
+				*l.ResourceType == "webhook_subscription" &&
+				l.Metadata["url"] == "https://partner.example.com/hooks" &&
+				!hasSecret
+		})).Return(&audit.Log{}, nil).Once()
+
+		sub, err := svc.CreateSubscription(ctx, &webhook.CreateRequest{
+			URL:       "https://partner.example.com/hooks",
+			Secret:    "partner-provided-secret",
+			CreatedBy: "admin@test.com",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "partner-provided-secret", sub.Secret)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid request is not stored", func(t *testing.T) {
+		svc, repo, auditRepo := newTestWebhookService()
+
+		_, err := svc.CreateSubscription(ctx, &webhook.CreateRequest{URL: "not a url", CreatedBy: "admin@test.com"})
+
+		assert.ErrorIs(t, err, webhook.ErrInvalidSubscription)
+		assert.Empty(t, repo.subscriptions)
+		auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("plain http is only accepted in development", func(t *testing.T) {
+		svc, repo, auditRepo := newTestWebhookService()
+		req := &webhook.CreateRequest{URL: "http://localhost:9000/hooks", CreatedBy: "admin@test.com"}
+
+		_, err := svc.CreateSubscription(ctx, req)
+		assert.ErrorIs(t, err, webhook.ErrInvalidSubscription)
+		assert.Empty(t, repo.subscriptions)
+
+		auditRepo.On("Create", mock.Anything, auditedAs(EventWebhookSubscriptionCreated)).Return(&audit.Log{}, nil).Once()
+		sub, err := svc.WithPlainHTTP(true).CreateSubscription(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost:9000/hooks", sub.URL)
+		auditRepo.AssertExpectations(t)
+	})
+}
+
+func TestWebhookService_UpdateSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("disabling records who disabled it", func(t *testing.T) {
+		svc, repo, auditRepo := newTestWebhookService()
+		sub := repo.subscribe(t, "https://partner.example.com/hooks")
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.EventType == EventWebhookSubscriptionUpdated && l.Metadata["enabled"] == false
+		})).Return(&audit.Log{}, nil).Once()
+
+		disabled := false
+		updated, err := svc.UpdateSubscription(ctx, sub.ID, &webhook.UpdateRequest{Enabled: &disabled, Actor: "admin@test.com"})
+
+		require.NoError(t, err)
+		assert.False(t, updated.Enabled)
+		require.NotNil(t, updated.DisabledReason)
+		assert.Equal(t, "disabled by admin@test.com", *updated.DisabledReason)
+		assert.Equal(t, sub.Secret, updated.Secret)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("re-enabling clears the failure count", func(t *testing.T) {
+		svc, repo, auditRepo := newTestWebhookService()
+		sub := repo.subscribe(t, "https://partner.example.com/hooks")
+		_, err := repo.RecordFailure(ctx, sub.ID, 1, "endpoint down", time.Now())
+		require.NoError(t, err)
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.EventType == EventWebhookSubscriptionUpdated &&
+				*l.ResourceType == "webhook_subscription" && *l.ResourceID == sub.ID.String() &&
+				l.Metadata["enabled"] == true
+		})).Return(&audit.Log{}, nil).Once()
+
+		enabled := true
+		updated, err := svc.UpdateSubscription(ctx, sub.ID, &webhook.UpdateRequest{Enabled: &enabled, Actor: "admin@test.com"})
+
+		require.NoError(t, err)
+		assert.True(t, updated.Enabled)
+		assert.Zero(t, updated.ConsecutiveFailures)
+		assert.Nil(t, updated.DisabledReason)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("rotates the secret", func(t *testing.T) {
+		svc, repo, auditRepo := newTestWebhookService()
+		sub := repo.subscribe(t, "https://partner.example.com/hooks")
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.Metadata["secret_rotated"] == true
+		})).Return(&audit.Log{}, nil).Once()
+
+		updated, err := svc.UpdateSubscription(ctx, sub.ID, &webhook.UpdateRequest{RotateSecret: true, Actor: "admin@test.com"})
+
+		require.NoError(t, err)
+		assert.NotEqual(t, sub.Secret, updated.Secret)
+		assert.True(t, strings.HasPrefix(updated.Secret, webhookSecretPrefix))
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc, _, _ := newTestWebhookService()
+
+		_, err := svc.UpdateSubscription(ctx, uuid.New(), &webhook.UpdateRequest{Actor: "admin@test.com"})
+
+		assert.ErrorIs(t, err, webhook.ErrSubscriptionNotFound)
+	})
+}
+
+func TestWebhookService_DeleteSubscription(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, auditRepo := newTestWebhookService()
+	sub := repo.subscribe(t, "https://partner.example.com/hooks")
+	auditRepo.On("Create", mock.Anything, auditedAs(EventWebhookSubscriptionDeleted)).Return(&audit.Log{}, nil).Once()
+
+	require.NoError(t, svc.DeleteSubscription(ctx, sub.ID, "admin@test.com"))
+
+	_, err := svc.GetSubscription(ctx, sub.ID)
+	assert.ErrorIs(t, err, webhook.ErrSubscriptionNotFound)
+	assert.ErrorIs(t, svc.DeleteSubscription(ctx, sub.ID, "admin@test.com"), webhook.ErrSubscriptionNotFound)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*WebhookService, *memoryWebhooks, *mocks.MockAuditRepository, *webhook.Subscription, *webhook.Delivery) {
+		svc, repo, auditRepo := newTestWebhookService()
+		sub := repo.subscribe(t, "https://partner.example.com/hooks")
+		_, err := repo.Enqueue(ctx, "evt-1", "user.registered", []byte(`{}`), time.Now())
+		require.NoError(t, err)
+		return svc, repo, auditRepo, sub, repo.deliveries[0]
+	}
+
+	t.Run("queues a failed delivery again", func(t *testing.T) {
+		svc, repo, auditRepo, sub, delivery := setup(t)
+		delivery.Status = webhook.DeliveryFailed
+		delivery.Attempts = 12
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.EventType == EventWebhookRedelivered && l.Metadata["event_id"] == "evt-1"
+		})).Return(&audit.Log{}, nil).Once()
+
+		result, err := svc.Redeliver(ctx, sub.ID, delivery.ID, "admin@test.com")
+
+		require.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryPending, result.Status)
+		assert.Zero(t, result.Attempts)
+		assert.Equal(t, webhook.DeliveryPending, repo.deliveries[0].Status)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("pending delivery is rejected", func(t *testing.T) {
+		svc, _, _, sub, delivery := setup(t)
+
+		_, err := svc.Redeliver(ctx, sub.ID, delivery.ID, "admin@test.com")
+
+		assert.ErrorIs(t, err, webhook.ErrDeliveryPending)
+	})
+
+	t.Run("disabled subscription is rejected", func(t *testing.T) {
+		svc, repo, _, sub, delivery := setup(t)
+		delivery.Status = webhook.DeliveryFailed
+		_, err := repo.RecordFailure(ctx, sub.ID, 1, "endpoint down", time.Now())
+		require.NoError(t, err)
+
+		_, err = svc.Redeliver(ctx, sub.ID, delivery.ID, "admin@test.com")
+
+		assert.ErrorIs(t, err, webhook.ErrSubscriptionDisabled)
+	})
+
+	t.Run("delivery of another subscription is not found", func(t *testing.T) {
+		svc, repo, _, _, delivery := setup(t)
+		delivery.Status = webhook.DeliveryFailed
+		other := repo.subscribe(t, "https://other.example.com/hooks")
+
+		_, err := svc.Redeliver(ctx, other.ID, delivery.ID, "admin@test.com")
+
+		assert.ErrorIs(t, err, webhook.ErrDeliveryNotFound)
+	})
+}
@@ -0,0 +1,38 @@
+// Package sms delivers phone verification codes. Senders implement user.PhoneCodeSender and
+// are selected by name in configuration.
+package sms
+
+import (
+	"context"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+)
+
+// Sender names used in configuration
+const (
+	SenderLog = "log"
+)
+
+// Compile-time check to ensure LogSender implements user.PhoneCodeSender
+var _ user.PhoneCodeSender = (*LogSender)(nil)
+
+// LogSender writes verification codes to the service log instead of sending them. It lets
+// phone verification be exercised in development and must not be used in production.
+type LogSender struct {
+	logger *observability.Logger
+}
+
+// NewLogSender creates a sender that logs codes
+func NewLogSender(logger *observability.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+// SendPhoneCode logs code for phone
+func (s *LogSender) SendPhoneCode(ctx context.Context, phone, code string) error {
+	s.logger.WithFields(map[string]interface{}{
+		"phone": phone,
+		"code":  code,
+	}).Warn("Phone verification code (log sender, not delivered)")
+	return nil
+}
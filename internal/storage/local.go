@@ -0,0 +1,174 @@
+// Package storage provides object store implementations for archives, documents and exports.
+// The LocalObjectStore keeps objects on the local filesystem and is intended for development,
+// single-node deployments and tests. Production deployments can plug in any implementation of
+// common.ObjectStore without changes to the service layer.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+)
+
+// Supported object store backends
+const (
+	BackendLocal = "local"
+)
+
+// LocalObjectStore implements common.ObjectStore on top of a directory tree
+type LocalObjectStore struct {
+	root string
+}
+
+// NewObjectStore creates the object store selected by backend
+//
+// Parameters:
+//   - backend: Storage backend name (currently only "local")
+//   - localPath: Root directory used by the local backend
+//
+// Returns:
+//   - common.ObjectStore: Configured object store
+//   - error: Returns error if the backend is unknown or cannot be initialised
+func NewObjectStore(backend, localPath string) (common.ObjectStore, error) {
+	switch backend {
+	case BackendLocal, "":
+		return NewLocalObjectStore(localPath)
+	default:
+		return nil, fmt.Errorf("unsupported storage backend %q", backend)
+	}
+}
+
+// NewLocalObjectStore creates a filesystem-backed object store rooted at root.
+// The directory is created if it does not exist.
+func NewLocalObjectStore(root string) (*LocalObjectStore, error) {
+	if root == "" {
+		return nil, fmt.Errorf("storage root cannot be empty")
+	}
+
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve storage root: %w", err)
+	}
+
+	if err := os.MkdirAll(abs, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage root: %w", err)
+	}
+
+	return &LocalObjectStore{root: abs}, nil
+}
+
+// Put writes the object to a temporary file and renames it into place,
+// so readers never observe a partially written object.
+func (s *LocalObjectStore) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := s.pathFor(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary object: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // no-op once renamed
+
+	if _, err := io.Copy(tmp, &contextReader{ctx: ctx, r: r}); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write object %s: %w", key, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync object %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close object %s: %w", key, err)
+	}
+
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("failed to commit object %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get opens the object for reading
+func (s *LocalObjectStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.pathFor(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path) // #nosec G304 -- path is confined to the store root by pathFor
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, common.ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to open object %s: %w", key, err)
+	}
+	return f, nil
+}
+
+// Exists reports whether the object exists
+func (s *LocalObjectStore) Exists(ctx context.Context, key string) (bool, error) {
+	path, err := s.pathFor(key)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to stat object %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// Delete removes the object; missing objects are ignored
+func (s *LocalObjectStore) Delete(ctx context.Context, key string) error {
+	path, err := s.pathFor(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete object %s: %w", key, err)
+	}
+	return nil
+}
+
+// pathFor maps a key to a path under the store root, rejecting keys that escape it
+func (s *LocalObjectStore) pathFor(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+
+	cleaned := filepath.Clean(filepath.FromSlash(key))
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+
+	return filepath.Join(s.root, cleaned), nil
+}
+
+// contextReader aborts long copies when the context is cancelled
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
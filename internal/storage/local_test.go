@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewObjectStore(t *testing.T) {
+	store, err := NewObjectStore(BackendLocal, t.TempDir())
+	require.NoError(t, err)
+	assert.IsType(t, &LocalObjectStore{}, store)
+
+	_, err = NewObjectStore("s3", t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported storage backend")
+}
+
+func TestLocalObjectStore_PutGet(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalObjectStore(t.TempDir())
+	require.NoError(t, err)
+
+	key := "audit-logs/2025/11/audit_logs_y2025m11.ndjson.gz"
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("hello")))
+
+	exists, err := store.Exists(ctx, key)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	rc, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+}
+
+func TestLocalObjectStore_PutOverwrites(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store, err := NewLocalObjectStore(root)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "a/b.txt", strings.NewReader("first")))
+	require.NoError(t, store.Put(ctx, "a/b.txt", strings.NewReader("second")))
+
+	data, err := os.ReadFile(filepath.Join(root, "a", "b.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	// No temporary files should be left behind
+	entries, err := os.ReadDir(filepath.Join(root, "a"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestLocalObjectStore_GetMissing(t *testing.T) {
+	store, err := NewLocalObjectStore(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = store.Get(context.Background(), "missing.txt")
+	assert.ErrorIs(t, err, common.ErrObjectNotFound)
+
+	exists, err := store.Exists(context.Background(), "missing.txt")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestLocalObjectStore_Delete(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalObjectStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "x.txt", strings.NewReader("x")))
+	require.NoError(t, store.Delete(ctx, "x.txt"))
+	require.NoError(t, store.Delete(ctx, "x.txt"), "deleting a missing key is not an error")
+
+	exists, err := store.Exists(ctx, "x.txt")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestLocalObjectStore_RejectsEscapingKeys(t *testing.T) {
+	store, err := NewLocalObjectStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../outside.txt", "a/../../outside.txt", ".."} {
+		err := store.Put(context.Background(), key, strings.NewReader("x"))
+		assert.Error(t, err, "key %q should be rejected", key)
+	}
+}
+
+func TestLocalObjectStore_PutCancelled(t *testing.T) {
+	store, err := NewLocalObjectStore(t.TempDir())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = store.Put(ctx, "cancelled.txt", strings.NewReader("data"))
+	require.Error(t, err)
+
+	exists, err := store.Exists(context.Background(), "cancelled.txt")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
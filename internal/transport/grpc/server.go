@@ -5,7 +5,9 @@ package grpc
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
 	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
 	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
 	pb "github.com/alex-necsoiu/pandora-exchange/internal/transport/grpc/proto"
@@ -19,15 +21,30 @@ import (
 type Server struct {
 	pb.UnimplementedUserServiceServer
 	userService userDomain.Service
+	kycService  kyc.Service
 	logger      *observability.Logger
 }
 
+// ServerOption configures optional dependencies of the gRPC server
+type ServerOption func(*Server)
+
+// WithKYCService enables GetUserEntitlements
+func WithKYCService(kycService kyc.Service) ServerOption {
+	return func(s *Server) {
+		s.kycService = kycService
+	}
+}
+
 // NewServer creates a new gRPC server instance
-func NewServer(userService userDomain.Service, logger *observability.Logger) *Server {
-	return &Server{
+func NewServer(userService userDomain.Service, logger *observability.Logger, opts ...ServerOption) *Server {
+	s := &Server{
 		userService: userService,
 		logger:      logger,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // GetUser retrieves a user by ID (internal services only)
@@ -82,39 +99,16 @@ func (s *Server) GetUserByEmail(ctx context.Context, req *pb.GetUserByEmailReque
 	}, nil
 }
 
-// UpdateKYCStatus updates a user's KYC verification status
+// UpdateKYCStatus is refused: a user's KYC status follows the decision on their KYC case, so
+// callers open and decide cases through the KYC review API instead
 func (s *Server) UpdateKYCStatus(ctx context.Context, req *pb.UpdateKYCRequest) (*pb.UpdateKYCResponse, error) {
 	s.logger.WithFields(map[string]interface{}{
 		"user_id":    req.UserId,
-		"kyc_status": req.KycStatus,
 		"updated_by": req.UpdatedBy,
 		"method":     "UpdateKYCStatus",
-	}).Info("gRPC KYC update request received")
-
-	// Validate UUID
-	userID, err := uuid.Parse(req.UserId)
-	if err != nil {
-		s.logger.WithField("error", err.Error()).Warn("Invalid user ID format")
-		return nil, status.Error(codes.InvalidArgument, "invalid user ID format")
-	}
-
-	// Parse KYC status
-	kycStatus := userDomain.KYCStatus(req.KycStatus)
-
-	// Update KYC status
-	user, err := s.userService.UpdateKYC(ctx, userID, kycStatus)
-	if err != nil {
-		return nil, s.handleServiceError(err, "failed to update KYC status")
-	}
-
-	s.logger.WithFields(map[string]interface{}{
-		"user_id":    userID,
-		"kyc_status": req.KycStatus,
-	}).Info("KYC status updated successfully")
+	}).Warn("gRPC KYC status override refused")
 
-	return &pb.UpdateKYCResponse{
-		User: toProtoUser(user),
-	}, nil
+	return nil, status.Error(codes.Unimplemented, "kyc status is derived from KYC cases")
 }
 
 // ValidateUser checks if a user exists and is active
@@ -145,20 +139,32 @@ func (s *Server) ValidateUser(ctx context.Context, req *pb.ValidateUserRequest)
 		return nil, s.handleServiceError(err, "failed to validate user")
 	}
 
-	// Check if user is active (not deleted)
-	isActive := !user.IsDeleted()
+	// Active users are not deleted and their account status allows logging in;
+	// withdrawals additionally require the account not to be frozen
+	accountStatus := user.EffectiveStatus(time.Now())
+	isActive := !user.IsDeleted() && accountStatus.AllowsLogin()
 
 	s.logger.WithFields(map[string]interface{}{
-		"user_id":   userID,
-		"is_valid":  true,
-		"is_active": isActive,
+		"user_id":        userID,
+		"is_valid":       true,
+		"is_active":      isActive,
+		"account_status": accountStatus.String(),
 	}).Debug("User validated")
 
-	return &pb.ValidateUserResponse{
-		IsValid:   true,
-		IsActive:  isActive,
-		KycStatus: user.KYCStatus.String(),
-	}, nil
+	resp := &pb.ValidateUserResponse{
+		IsValid:       true,
+		IsActive:      isActive,
+		KycStatus:     user.KYCStatus.String(),
+		AccountStatus: accountStatus.String(),
+		CanWithdraw:   isActive && accountStatus.AllowsWithdrawals(),
+	}
+	if accountStatus != userDomain.AccountStatusActive {
+		resp.StatusReason = user.StatusReason
+		if user.StatusExpiresAt != nil {
+			resp.StatusExpiresAt = timestamppb.New(*user.StatusExpiresAt)
+		}
+	}
+	return resp, nil
 }
 
 // ListUsers returns a paginated list of users (admin operations)
@@ -180,6 +186,13 @@ func (s *Server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.L
 		return nil, status.Error(codes.InvalidArgument, "offset must be non-negative")
 	}
 
+	if search, ok := toUserSearch(req); ok {
+		if req.Offset > 0 {
+			return nil, status.Error(codes.InvalidArgument, "offset cannot be combined with search fields; use cursor")
+		}
+		return s.searchUsers(ctx, search)
+	}
+
 	// Get users from service
 	users, total, err := s.userService.ListUsers(ctx, int(req.Limit), int(req.Offset))
 	if err != nil {
@@ -203,6 +216,52 @@ func (s *Server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.L
 	}, nil
 }
 
+// searchUsers serves ListUsers requests with search fields, paged by cursor
+func (s *Server) searchUsers(ctx context.Context, search userDomain.UserSearch) (*pb.ListUsersResponse, error) {
+	page, err := s.userService.SearchUsers(ctx, search)
+	if err != nil {
+		return nil, s.handleServiceError(err, "failed to search users")
+	}
+
+	protoUsers := make([]*pb.User, len(page.Users))
+	for i, user := range page.Users {
+		protoUsers[i] = toProtoUser(user)
+	}
+
+	s.logger.WithField("count", len(page.Users)).Debug("Users searched successfully")
+
+	return &pb.ListUsersResponse{
+		Users:      protoUsers,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// GetUserEntitlements returns the user's KYC tier, limits and allowed products
+func (s *Server) GetUserEntitlements(ctx context.Context, req *pb.GetUserEntitlementsRequest) (*pb.GetUserEntitlementsResponse, error) {
+	s.logger.WithFields(map[string]interface{}{
+		"user_id": req.UserId,
+		"method":  "GetUserEntitlements",
+	}).Debug("gRPC entitlements request received")
+
+	if s.kycService == nil {
+		return nil, status.Error(codes.Unimplemented, "entitlements are not available")
+	}
+
+	// Validate UUID
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		s.logger.WithField("error", err.Error()).Warn("Invalid user ID format")
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID format")
+	}
+
+	entitlements, err := s.kycService.GetEntitlements(ctx, userID)
+	if err != nil {
+		return nil, s.handleServiceError(err, "failed to get user entitlements")
+	}
+
+	return toProtoEntitlements(entitlements), nil
+}
+
 // handleServiceError converts domain errors to appropriate gRPC status codes
 func (s *Server) handleServiceError(err error, context string) error {
 	s.logger.WithFields(map[string]interface{}{
@@ -223,11 +282,41 @@ func (s *Server) handleServiceError(err error, context string) error {
 		return status.Error(codes.InvalidArgument, "invalid email format")
 	case errors.Is(err, userDomain.ErrWeakPassword):
 		return status.Error(codes.InvalidArgument, "password does not meet requirements")
+	case errors.Is(err, userDomain.ErrInvalidSearchCursor):
+		return status.Error(codes.InvalidArgument, "invalid cursor")
+	case errors.Is(err, userDomain.ErrInvalidSearch):
+		return status.Error(codes.InvalidArgument, err.Error())
 	default:
 		return status.Error(codes.Internal, "internal server error")
 	}
 }
 
+// toUserSearch converts the search fields of a ListUsers request to a user search,
+// reporting false if none are set
+func toUserSearch(req *pb.ListUsersRequest) (userDomain.UserSearch, bool) {
+	search := userDomain.UserSearch{
+		Query:     req.Query,
+		Role:      userDomain.Role(req.Role),
+		KYCStatus: userDomain.KYCStatus(req.KycStatus),
+		Status:    userDomain.AccountStatus(req.AccountStatus),
+		Deleted:   userDomain.DeletedFilter(req.Deleted),
+		Sort:      userDomain.SearchSort(req.Sort),
+		Cursor:    req.Cursor,
+		Limit:     int(req.Limit),
+	}
+	if req.CreatedFrom != nil {
+		from := req.CreatedFrom.AsTime()
+		search.CreatedFrom = &from
+	}
+	if req.CreatedTo != nil {
+		to := req.CreatedTo.AsTime()
+		search.CreatedTo = &to
+	}
+	isSearch := search.Query != "" || search.Role != "" || search.KYCStatus != "" || search.Status != "" ||
+		search.CreatedFrom != nil || search.CreatedTo != nil || search.Deleted != "" || search.Sort != "" || search.Cursor != ""
+	return search, isSearch
+}
+
 // toProtoUser converts a domain User to a protobuf User
 func toProtoUser(user *userDomain.User) *pb.User {
 	protoUser := &pb.User{
@@ -248,3 +337,27 @@ func toProtoUser(user *userDomain.User) *pb.User {
 
 	return protoUser
 }
+
+// toProtoEntitlements converts domain Entitlements to a protobuf response
+func toProtoEntitlements(e *kyc.Entitlements) *pb.GetUserEntitlementsResponse {
+	resp := &pb.GetUserEntitlementsResponse{
+		UserId:               e.UserID.String(),
+		Tier:                 int32(e.Tier), // #nosec G115 -- tiers are 0..3
+		TierName:             e.TierName,
+		KycStatus:            e.KYCStatus.String(),
+		IsActive:             e.Active,
+		Currency:             e.Currency,
+		DailyDepositLimit:    e.DailyDepositLimit,
+		DailyWithdrawalLimit: e.DailyWithdrawalLimit,
+		AllowedProducts:      e.Products,
+		AllowedJurisdictions: e.Jurisdictions,
+		HeldTier:             int32(e.HeldTier), // #nosec G115 -- tiers are 0..3
+	}
+
+	// Only set UpdatedAt if a tier was ever assigned
+	if e.UpdatedAt != nil {
+		resp.UpdatedAt = timestamppb.New(*e.UpdatedAt)
+	}
+
+	return resp
+}
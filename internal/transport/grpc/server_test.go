@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
 	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
 	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
 	grpcTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/grpc"
@@ -14,8 +16,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // MockUserService is a mock implementation of userDomain.Service for testing
@@ -81,16 +85,24 @@ func (m *MockUserService) GetByEmail(ctx context.Context, email string) (*userDo
 	return args.Get(0).(*userDomain.User), args.Error(1)
 }
 
-func (m *MockUserService) UpdateKYC(ctx context.Context, userID uuid.UUID, kycStatus userDomain.KYCStatus) (*userDomain.User, error) {
-	args := m.Called(ctx, userID, kycStatus)
+func (m *MockUserService) UpdateProfile(ctx context.Context, userID uuid.UUID, update userDomain.ProfileUpdate) (*userDomain.User, error) {
+	args := m.Called(ctx, userID, update)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*userDomain.User), args.Error(1)
 }
 
-func (m *MockUserService) UpdateProfile(ctx context.Context, userID uuid.UUID, firstName, lastName string) (*userDomain.User, error) {
-	args := m.Called(ctx, userID, firstName, lastName)
+func (m *MockUserService) StartPhoneVerification(ctx context.Context, userID uuid.UUID) (*userDomain.PhoneVerification, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.PhoneVerification), args.Error(1)
+}
+
+func (m *MockUserService) ConfirmPhone(ctx context.Context, userID uuid.UUID, code string) (*userDomain.User, error) {
+	args := m.Called(ctx, userID, code)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -102,6 +114,19 @@ func (m *MockUserService) DeleteAccount(ctx context.Context, userID uuid.UUID) e
 	return args.Error(0)
 }
 
+func (m *MockUserService) CheckAccountAccess(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserService) RestoreAccount(ctx context.Context, token string) (*userDomain.User, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
 func (m *MockUserService) GetActiveSessions(ctx context.Context, userID uuid.UUID) ([]*auth.RefreshToken, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -115,12 +140,36 @@ func (m *MockUserService) ListUsers(ctx context.Context, limit, offset int) ([]*
 	return args.Get(0).([]*userDomain.User), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockUserService) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*userDomain.User, error) {
-	args := m.Called(ctx, query, limit, offset)
+func (m *MockUserService) SearchUsers(ctx context.Context, search userDomain.UserSearch) (*userDomain.UserSearchPage, error) {
+	args := m.Called(ctx, search)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.UserSearchPage), args.Error(1)
+}
+
+func (m *MockUserService) SetAccountStatus(ctx context.Context, id uuid.UUID, update userDomain.AccountStatusUpdate, adminID uuid.UUID) (*userDomain.User, error) {
+	args := m.Called(ctx, id, update, adminID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*userDomain.User), args.Error(1)
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+func (m *MockUserService) GetAccountStatusHistory(ctx context.Context, id uuid.UUID) ([]*userDomain.AccountStatusChange, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*userDomain.AccountStatusChange), args.Error(1)
+}
+
+func (m *MockUserService) AdminRestoreAccount(ctx context.Context, id, adminID uuid.UUID) (*userDomain.User, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
 }
 
 func (m *MockUserService) GetUserByIDAdmin(ctx context.Context, id uuid.UUID) (*userDomain.User, error) {
@@ -149,12 +198,12 @@ func (m *MockUserService) ForceLogout(ctx context.Context, token string) error {
 	return args.Error(0)
 }
 
-func (m *MockUserService) GetSystemStats(ctx context.Context) (map[string]interface{}, error) {
-	args := m.Called(ctx)
+func (m *MockUserService) GetSystemStats(ctx context.Context, query stats.Query) (*stats.Dashboard, error) {
+	args := m.Called(ctx, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(map[string]interface{}), args.Error(1)
+	return args.Get(0).(*stats.Dashboard), args.Error(1)
 }
 
 // Helper to create test user
@@ -290,72 +339,25 @@ func TestGetUserByEmail(t *testing.T) {
 	}
 }
 
+// TestUpdateKYCStatus tests that the KYC status override is refused
 func TestUpdateKYCStatus(t *testing.T) {
-	tests := []struct {
-		name          string
-		userID        string
-		kycStatus     string
-		mockSetup     func(*MockUserService)
-		expectedError codes.Code
-	}{
-		{
-			name:      "successfully update KYC status",
-			userID:    uuid.New().String(),
-			kycStatus: "verified",
-			mockSetup: func(m *MockUserService) {
-				user := createTestUser()
-				user.KYCStatus = userDomain.KYCStatusVerified
-				m.On("UpdateKYC", mock.Anything, mock.Anything, userDomain.KYCStatusVerified).Return(user, nil)
-			},
-			expectedError: codes.OK,
-		},
-		{
-			name:          "invalid user ID format",
-			userID:        "invalid-uuid",
-			kycStatus:     "verified",
-			mockSetup:     func(m *MockUserService) {},
-			expectedError: codes.InvalidArgument,
-		},
-		{
-			name:      "user not found",
-			userID:    uuid.New().String(),
-			kycStatus: "verified",
-			mockSetup: func(m *MockUserService) {
-				m.On("UpdateKYC", mock.Anything, mock.Anything, mock.Anything).Return(nil, userDomain.ErrNotFound)
-			},
-			expectedError: codes.NotFound,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockService := new(MockUserService)
-			tt.mockSetup(mockService)
-			logger := observability.NewLogger("test", "grpc-test")
-			server := grpcTransport.NewServer(mockService, logger)
-
-			req := &pb.UpdateKYCRequest{
-				UserId:    tt.userID,
-				KycStatus: tt.kycStatus,
-				UpdatedBy: "admin@example.com",
-			}
-			resp, err := server.UpdateKYCStatus(context.Background(), req)
-
-			if tt.expectedError == codes.OK {
-				assert.NoError(t, err)
-				assert.NotNil(t, resp)
-				assert.NotNil(t, resp.User)
-				assert.Equal(t, tt.kycStatus, resp.User.KycStatus)
-			} else {
-				assert.Error(t, err)
-				st, ok := status.FromError(err)
-				assert.True(t, ok)
-				assert.Equal(t, tt.expectedError, st.Code())
-			}
+	mockService := new(MockUserService)
+	logger := observability.NewLogger("test", "grpc-test")
+	server := grpcTransport.NewServer(mockService, logger)
 
-			mockService.AssertExpectations(t)
-		})
+	req := &pb.UpdateKYCRequest{
+		UserId:    uuid.New().String(),
+		KycStatus: "verified",
+		UpdatedBy: "admin@example.com",
 	}
+	resp, err := server.UpdateKYCStatus(context.Background(), req)
+
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unimplemented, st.Code())
+	mockService.AssertExpectations(t)
 }
 
 func TestValidateUser(t *testing.T) {
@@ -391,6 +393,18 @@ func TestValidateUser(t *testing.T) {
 			expectedActive: false,
 			expectedError:  codes.OK,
 		},
+		{
+			name:   "user exists but is suspended",
+			userID: uuid.New().String(),
+			mockSetup: func(m *MockUserService) {
+				user := createTestUser()
+				user.Status = userDomain.AccountStatusSuspended
+				m.On("GetByID", mock.Anything, mock.Anything).Return(user, nil)
+			},
+			expectedValid:  true,
+			expectedActive: false,
+			expectedError:  codes.OK,
+		},
 		{
 			name:   "user not found",
 			userID: uuid.New().String(),
@@ -436,6 +450,29 @@ func TestValidateUser(t *testing.T) {
 	}
 }
 
+func TestValidateUser_AccountStatus(t *testing.T) {
+	user := createTestUser()
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	user.Status = userDomain.AccountStatusFrozenWithdrawals
+	user.StatusReason = "source of funds review"
+	user.StatusExpiresAt = &expiresAt
+
+	mockService := new(MockUserService)
+	mockService.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	logger := observability.NewLogger("test", "grpc-test")
+	server := grpcTransport.NewServer(mockService, logger)
+
+	resp, err := server.ValidateUser(context.Background(), &pb.ValidateUserRequest{UserId: user.ID.String()})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.IsActive)
+	assert.False(t, resp.CanWithdraw)
+	assert.Equal(t, "frozen_withdrawals", resp.AccountStatus)
+	assert.Equal(t, "source of funds review", resp.StatusReason)
+	assert.True(t, expiresAt.Equal(resp.StatusExpiresAt.AsTime()))
+	mockService.AssertExpectations(t)
+}
+
 func TestListUsers(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -536,7 +573,7 @@ func TestHandleServiceError(t *testing.T) {
 			name:         "ErrInvalidKYCStatus maps to InvalidArgument",
 			domainError:  userDomain.ErrInvalidKYCStatus,
 			expectedCode: codes.InvalidArgument,
-			rpcMethod:    "UpdateKYCStatus",
+			rpcMethod:    "GetUser",
 		},
 		{
 			name:         "ErrInvalidEmail maps to InvalidArgument",
@@ -582,16 +619,6 @@ func TestHandleServiceError(t *testing.T) {
 				assert.True(t, ok)
 				assert.Equal(t, tt.expectedCode, st.Code())
 
-			case "UpdateKYCStatus":
-				mockService.On("UpdateKYC", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.domainError)
-				_, err := server.UpdateKYCStatus(context.Background(), &pb.UpdateKYCRequest{
-					UserId:    uuid.New().String(),
-					KycStatus: "verified",
-				})
-				assert.Error(t, err)
-				st, ok := status.FromError(err)
-				assert.True(t, ok)
-				assert.Equal(t, tt.expectedCode, st.Code())
 			}
 
 			mockService.AssertExpectations(t)
@@ -639,30 +666,6 @@ func TestGetUserByEmail_InternalError(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
-// TestUpdateKYCStatus_InternalError tests internal error handling
-func TestUpdateKYCStatus_InternalError(t *testing.T) {
-	mockService := new(MockUserService)
-	logger := observability.NewLogger("test", "grpc-test")
-	server := grpcTransport.NewServer(mockService, logger)
-
-	mockService.On("UpdateKYC", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("database failure"))
-
-	req := &pb.UpdateKYCRequest{
-		UserId:    uuid.New().String(),
-		KycStatus: "verified",
-		UpdatedBy: "admin@example.com",
-	}
-	resp, err := server.UpdateKYCStatus(context.Background(), req)
-
-	assert.Error(t, err)
-	assert.Nil(t, resp)
-	st, ok := status.FromError(err)
-	assert.True(t, ok)
-	assert.Equal(t, codes.Internal, st.Code())
-
-	mockService.AssertExpectations(t)
-}
-
 // TestValidateUser_InternalError tests internal error handling for non-NotFound errors
 func TestValidateUser_InternalError(t *testing.T) {
 	mockService := new(MockUserService)
@@ -704,6 +707,66 @@ func TestListUsers_InternalError(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+// TestListUsers_Search tests that search fields switch ListUsers to cursor pagination
+func TestListUsers_Search(t *testing.T) {
+	logger := observability.NewLogger("test", "grpc-test")
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("search with filters", func(t *testing.T) {
+		mockService := new(MockUserService)
+		server := grpcTransport.NewServer(mockService, logger)
+		users := []*userDomain.User{createTestUser()}
+		mockService.On("SearchUsers", mock.Anything, userDomain.UserSearch{
+			Query:       "jane",
+			Role:        userDomain.RoleUser,
+			KYCStatus:   userDomain.KYCStatusVerified,
+			Status:      userDomain.AccountStatusSuspended,
+			CreatedFrom: &from,
+			Deleted:     userDomain.DeletedInclude,
+			Cursor:      "cursor",
+			Limit:       10,
+		}).Return(&userDomain.UserSearchPage{Users: users, NextCursor: "next"}, nil)
+
+		resp, err := server.ListUsers(context.Background(), &pb.ListUsersRequest{
+			Query:         "jane",
+			Role:          "user",
+			KycStatus:     "verified",
+			AccountStatus: "suspended",
+			CreatedFrom:   timestamppb.New(from),
+			Deleted:       "include",
+			Cursor:        "cursor",
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, resp.Users, 1)
+		assert.Equal(t, "next", resp.NextCursor)
+		assert.Zero(t, resp.Total)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("offset cannot be combined with search", func(t *testing.T) {
+		mockService := new(MockUserService)
+		server := grpcTransport.NewServer(mockService, logger)
+
+		_, err := server.ListUsers(context.Background(), &pb.ListUsersRequest{Query: "jane", Offset: 10})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockService.AssertNotCalled(t, "SearchUsers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid search", func(t *testing.T) {
+		for _, searchErr := range []error{userDomain.ErrInvalidSearchCursor, userDomain.ErrInvalidSearch} {
+			mockService := new(MockUserService)
+			server := grpcTransport.NewServer(mockService, logger)
+			mockService.On("SearchUsers", mock.Anything, mock.Anything).Return(nil, searchErr)
+
+			_, err := server.ListUsers(context.Background(), &pb.ListUsersRequest{Sort: "relevance"})
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
 // TestToProtoUser_WithDeletedUser tests timestamp conversion for soft-deleted users
 func TestToProtoUser_WithDeletedUser(t *testing.T) {
 	mockService := new(MockUserService)
@@ -774,3 +837,74 @@ func TestListUsers_WithTotal(t *testing.T) {
 
 	mockService.AssertExpectations(t)
 }
+
+// stubKYCService serves fixed entitlements; other kyc.Service methods are not used by the server
+type stubKYCService struct {
+	kyc.Service
+	entitlements *kyc.Entitlements
+	err          error
+}
+
+func (s *stubKYCService) GetEntitlements(ctx context.Context, userID uuid.UUID) (*kyc.Entitlements, error) {
+	return s.entitlements, s.err
+}
+
+func TestGetUserEntitlements(t *testing.T) {
+	logger := observability.NewLogger("test", "grpc-test")
+	userID := uuid.New()
+	updatedAt := time.Now()
+
+	t.Run("returns tier and limits", func(t *testing.T) {
+		kycService := &stubKYCService{entitlements: &kyc.Entitlements{
+			UserID:            userID,
+			Tier:              kyc.TierBasic,
+			HeldTier:          kyc.TierBasic,
+			TierName:          "basic",
+			KYCStatus:         userDomain.KYCStatusVerified,
+			Active:            true,
+			Currency:          "EUR",
+			DailyDepositLimit: "2000",
+			Products:          []string{"spot"},
+			UpdatedAt:         &updatedAt,
+		}}
+		server := grpcTransport.NewServer(new(MockUserService), logger, grpcTransport.WithKYCService(kycService))
+
+		resp, err := server.GetUserEntitlements(context.Background(), &pb.GetUserEntitlementsRequest{UserId: userID.String()})
+		assert.NoError(t, err)
+		assert.Equal(t, userID.String(), resp.UserId)
+		assert.Equal(t, int32(1), resp.Tier)
+		assert.Equal(t, "basic", resp.TierName)
+		assert.Equal(t, "verified", resp.KycStatus)
+		assert.True(t, resp.IsActive)
+		assert.Equal(t, "2000", resp.DailyDepositLimit)
+		assert.Empty(t, resp.DailyWithdrawalLimit)
+		assert.Equal(t, []string{"spot"}, resp.AllowedProducts)
+		assert.NotNil(t, resp.UpdatedAt)
+	})
+
+	tests := []struct {
+		name          string
+		userID        string
+		kycService    *stubKYCService
+		expectedError codes.Code
+	}{
+		{"kyc service not configured", userID.String(), nil, codes.Unimplemented},
+		{"invalid user ID format", "invalid-uuid", &stubKYCService{}, codes.InvalidArgument},
+		{"user not found", userID.String(), &stubKYCService{err: userDomain.ErrNotFound}, codes.NotFound},
+		{"internal error", userID.String(), &stubKYCService{err: errors.New("database down")}, codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []grpcTransport.ServerOption
+			if tt.kycService != nil {
+				opts = append(opts, grpcTransport.WithKYCService(tt.kycService))
+			}
+			server := grpcTransport.NewServer(new(MockUserService), logger, opts...)
+
+			_, err := server.GetUserEntitlements(context.Background(), &pb.GetUserEntitlementsRequest{UserId: tt.userID})
+			st, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expectedError, st.Code())
+		})
+	}
+}
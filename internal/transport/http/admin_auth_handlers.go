@@ -1,6 +1,7 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 
 	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
@@ -48,6 +49,7 @@ func NewAdminAuthHandler(userService userDomain.Service, logger *observability.L
 // Errors:
 //   - 400: Invalid request body
 //   - 401: Invalid credentials or not an admin
+//   - 403: Account suspended or closed
 //   - 500: Internal server error
 func (h *AdminAuthHandler) AdminLogin(c *gin.Context) {
 	var req LoginRequest
@@ -95,6 +97,9 @@ func (h *AdminAuthHandler) AdminLogin(c *gin.Context) {
 			})
 			return
 		}
+		if respondAccountStatusRefused(c, err) {
+			return
+		}
 
 		h.logger.WithError(err).Error("admin login failed")
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
@@ -134,6 +139,7 @@ func (h *AdminAuthHandler) AdminLogin(c *gin.Context) {
 // Errors:
 //   - 400: Invalid request body
 //   - 401: Invalid or expired refresh token
+//   - 403: Account suspended or closed
 //   - 500: Internal server error
 //
 // Note: This uses the same RefreshToken service method as regular users.
@@ -165,6 +171,9 @@ func (h *AdminAuthHandler) AdminRefreshToken(c *gin.Context) {
 	// The user's admin role is preserved in the database and included in new tokens
 	tokenPair, err := h.userService.RefreshToken(c.Request.Context(), req.RefreshToken, ipAddress, userAgent)
 	if err != nil {
+		if respondAccountStatusRefused(c, err) {
+			return
+		}
 		c.JSON(http.StatusUnauthorized, ErrorResponse{
 			Error: "invalid or expired refresh token",
 		})
@@ -178,3 +187,21 @@ func (h *AdminAuthHandler) AdminRefreshToken(c *gin.Context) {
 		ExpiresAt:    tokenPair.ExpiresAt,
 	})
 }
+
+// respondAccountStatusRefused responds 403 if err is a suspended or closed account and
+// reports whether it did.
+func respondAccountStatusRefused(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, userDomain.ErrAccountSuspended):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "account is suspended",
+		})
+	case errors.Is(err, userDomain.ErrAccountClosed):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "account is closed",
+		})
+	default:
+		return false
+	}
+	return true
+}
@@ -1,9 +1,14 @@
 package http
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
 	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
 	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
 	"github.com/gin-gonic/gin"
@@ -69,12 +74,9 @@ func (h *AdminHandler) ListUsers(c *gin.Context) {
 }
 
 // SearchUsers handles GET /api/v1/admin/users/search
-// Searches users by email, first name, or last name.
+// Searches users by email, name or ID with filters, sorting and cursor pagination.
 func (h *AdminHandler) SearchUsers(c *gin.Context) {
 	var req AdminSearchUsersRequest
-	req.Limit = 20  // default
-	req.Offset = 0  // default
-
 	if err := c.ShouldBindQuery(&req); err != nil {
 		h.logger.WithField("error", err.Error()).Warn("Invalid search users request")
 		c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -85,31 +87,53 @@ func (h *AdminHandler) SearchUsers(c *gin.Context) {
 	}
 
 	h.logger.WithFields(map[string]interface{}{
-		"query":  req.Query,
-		"limit":  req.Limit,
-		"offset": req.Offset,
+		"query": req.Query,
+		"sort":  req.Sort,
+		"limit": req.Limit,
 	}).Info("Admin: Processing search users request")
 
-	users, err := h.userService.SearchUsers(c.Request.Context(), req.Query, req.Limit, req.Offset)
+	page, err := h.userService.SearchUsers(c.Request.Context(), userDomain.UserSearch{
+		Query:       req.Query,
+		Role:        userDomain.Role(req.Role),
+		KYCStatus:   userDomain.KYCStatus(req.KYCStatus),
+		Status:      userDomain.AccountStatus(req.Status),
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		Deleted:     userDomain.DeletedFilter(req.Deleted),
+		Sort:        userDomain.SearchSort(req.Sort),
+		Cursor:      req.Cursor,
+		Limit:       req.Limit,
+	})
 	if err != nil {
-		h.logger.WithError(err).Error("Failed to search users")
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to search users",
-		})
+		switch {
+		case errors.Is(err, userDomain.ErrInvalidSearchCursor):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_cursor",
+				Message: "Cursor is invalid or was returned for a different search",
+			})
+		case errors.Is(err, userDomain.ErrInvalidSearch):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+		default:
+			h.logger.WithError(err).Error("Failed to search users")
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to search users",
+			})
+		}
 		return
 	}
 
-	adminUsers := make([]AdminUserDTO, len(users))
-	for i, user := range users {
+	adminUsers := make([]AdminUserDTO, len(page.Users))
+	for i, user := range page.Users {
 		adminUsers[i] = toAdminUserDTO(user)
 	}
 
-	c.JSON(http.StatusOK, AdminUsersListResponse{
-		Users:  adminUsers,
-		Total:  int64(len(adminUsers)),
-		Limit:  req.Limit,
-		Offset: req.Offset,
+	c.JSON(http.StatusOK, AdminUserSearchResponse{
+		Users:      adminUsers,
+		NextCursor: page.NextCursor,
 	})
 }
 
@@ -205,6 +229,164 @@ func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
 	c.JSON(http.StatusOK, toAdminUserDTO(user))
 }
 
+// RestoreUser handles POST /api/v1/admin/users/:id/restore
+// Restores a deleted user within the grace period. Their sessions stay revoked.
+func (h *AdminHandler) RestoreUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid user ID")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_user_id",
+			Message: "Invalid user ID format",
+		})
+		return
+	}
+	adminID := getUserIDFromContext(c)
+
+	h.logger.WithFields(map[string]interface{}{
+		"user_id":  userID,
+		"admin_id": adminID,
+	}).Info("Admin: Processing restore user request")
+
+	user, err := h.userService.AdminRestoreAccount(c.Request.Context(), userID, adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, userDomain.ErrNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "user_not_found",
+				Message: "User not found",
+			})
+		case errors.Is(err, userDomain.ErrNotDeleted):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "account_not_deleted",
+				Message: "User is not deleted",
+			})
+		case errors.Is(err, userDomain.ErrAlreadyExists):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "user_already_exists",
+				Message: "Another user has registered with this email since the deletion",
+			})
+		case errors.Is(err, userDomain.ErrRestoreWindowExpired):
+			c.JSON(http.StatusGone, ErrorResponse{
+				Error:   "restore_window_expired",
+				Message: "The grace period is over; the account can no longer be restored",
+			})
+		case errors.Is(err, userDomain.ErrAccountRestoreUnavailable):
+			c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+				Error:   "account_restore_unavailable",
+				Message: "Account restore is not enabled",
+			})
+		default:
+			h.logger.WithError(err).Error("Failed to restore user")
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to restore user",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toAdminUserDTO(user))
+}
+
+// SetUserStatus handles PUT /api/v1/admin/users/:id/status
+// Suspends, freezes withdrawals of, closes or reactivates a user.
+func (h *AdminHandler) SetUserStatus(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid user ID")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_user_id",
+			Message: "Invalid user ID format",
+		})
+		return
+	}
+
+	var req AdminSetAccountStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid set status request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+	adminID := getUserIDFromContext(c)
+
+	h.logger.WithFields(map[string]interface{}{
+		"user_id":  userID,
+		"status":   req.Status,
+		"admin_id": adminID,
+	}).Info("Admin: Processing set status request")
+
+	update := userDomain.AccountStatusUpdate{
+		Status:    userDomain.AccountStatus(req.Status),
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+	}
+	user, err := h.userService.SetAccountStatus(c.Request.Context(), userID, update, adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, userDomain.ErrNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "user_not_found",
+				Message: "User not found",
+			})
+		case errors.Is(err, userDomain.ErrInvalidAccountStatus):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_account_status",
+				Message: err.Error(),
+			})
+		default:
+			h.logger.WithError(err).Error("Failed to set user status")
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to set user status",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toAdminUserDTO(user))
+}
+
+// GetUserStatusHistory handles GET /api/v1/admin/users/:id/status/history
+// Lists a user's account status changes, oldest first.
+func (h *AdminHandler) GetUserStatusHistory(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid user ID")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_user_id",
+			Message: "Invalid user ID format",
+		})
+		return
+	}
+
+	changes, err := h.userService.GetAccountStatusHistory(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, userDomain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "user_not_found",
+				Message: "User not found",
+			})
+			return
+		}
+		h.logger.WithError(err).Error("Failed to get user status history")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to get user status history",
+		})
+		return
+	}
+
+	dtos := make([]AccountStatusChangeDTO, len(changes))
+	for i, change := range changes {
+		dtos[i] = toAccountStatusChangeDTO(change)
+	}
+	c.JSON(http.StatusOK, AccountStatusHistoryResponse{Changes: dtos})
+}
+
 // GetAllSessions handles GET /api/v1/admin/sessions
 // Gets all active sessions across all users.
 func (h *AdminHandler) GetAllSessions(c *gin.Context) {
@@ -297,19 +479,101 @@ func (h *AdminHandler) ForceLogout(c *gin.Context) {
 }
 
 // GetSystemStats handles GET /api/v1/admin/stats
-// Gets system statistics for admin dashboard.
+// Gets system statistics for admin dashboard: daily or weekly registrations, logins, failed
+// logins and KYC approvals, active users, the KYC funnel and active sessions by device.
 func (h *AdminHandler) GetSystemStats(c *gin.Context) {
+	var req AdminStatsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid system stats request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
 	h.logger.Info("Admin: Processing get system stats request")
 
-	stats, err := h.userService.GetSystemStats(c.Request.Context())
-	if err != nil {
-		h.logger.WithError(err).Error("Failed to get system stats")
+	dashboard, ok := h.systemStats(c, req)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, toAdminStatsResponse(dashboard))
+}
+
+// ExportSystemStats handles GET /api/v1/admin/stats/export
+// Exports a dataset of the system statistics (series, kyc_funnel or sessions_by_device) as CSV.
+func (h *AdminHandler) ExportSystemStats(c *gin.Context) {
+	var req AdminStatsExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid system stats export request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+	dataset := stats.DatasetSeries
+	if req.Dataset != "" {
+		dataset = stats.Dataset(req.Dataset)
+	}
+
+	h.logger.WithField("dataset", string(dataset)).Info("Admin: Processing export system stats request")
+
+	dashboard, ok := h.systemStats(c, req.AdminStatsRequest)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := stats.WriteCSV(&buf, dashboard, dataset); err != nil {
+		h.logger.WithError(err).Error("Failed to export system stats")
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
-			Message: "Failed to retrieve system statistics",
+			Message: "Failed to export system statistics",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, stats)
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"pandora-stats-%s-%s-%s.csv\"",
+		dataset, dashboard.Query.From.Format(time.DateOnly), dashboard.Query.To.Format(time.DateOnly)))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// systemStats builds the statistics dashboard of a request, writing the error response and
+// returning false if it fails
+func (h *AdminHandler) systemStats(c *gin.Context, req AdminStatsRequest) (*stats.Dashboard, bool) {
+	query := stats.Query{Granularity: stats.Granularity(req.Granularity)}
+	if req.From != nil {
+		query.From = *req.From
+	}
+	if req.To != nil {
+		query.To = *req.To
+	}
+
+	dashboard, err := h.userService.GetSystemStats(c.Request.Context(), query)
+	if err != nil {
+		switch {
+		case errors.Is(err, stats.ErrInvalidQuery):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+		case errors.Is(err, stats.ErrUnavailable):
+			c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+				Error:   "stats_unavailable",
+				Message: "System statistics are not enabled",
+			})
+		default:
+			h.logger.WithError(err).Error("Failed to get system stats")
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to retrieve system statistics",
+			})
+		}
+		return nil, false
+	}
+	return dashboard, true
 }
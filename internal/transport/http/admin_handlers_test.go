@@ -11,11 +11,13 @@ import (
 
 	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
 	httpTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/http"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // TestListUsers tests the ListUsers HTTP handler
@@ -176,35 +178,51 @@ func TestSearchUsers(t *testing.T) {
 			name:        "search users successfully",
 			queryParams: "?query=john",
 			mockSetup: func(m *MockUserService) {
-				m.On("SearchUsers", mock.Anything, "john", 20, 0).
-					Return([]*userDomain.User{user1}, nil)
+				m.On("SearchUsers", mock.Anything, userDomain.UserSearch{Query: "john"}).
+					Return(&userDomain.UserSearchPage{Users: []*userDomain.User{user1}, NextCursor: "next"}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			validateBody: func(t *testing.T, body map[string]interface{}) {
 				users := body["users"].([]interface{})
 				assert.Len(t, users, 1)
-				assert.Equal(t, float64(1), body["total"])
+				assert.Equal(t, "next", body["next_cursor"])
 			},
 		},
 		{
-			name:        "search users with no results",
-			queryParams: "?query=nonexistent",
+			name:        "search users with filters",
+			queryParams: "?role=admin&kyc_status=verified&status=suspended&deleted=include&sort=created_at_asc&created_from=2025-01-01T00:00:00Z&cursor=abc&limit=50",
 			mockSetup: func(m *MockUserService) {
-				m.On("SearchUsers", mock.Anything, "nonexistent", 20, 0).
-					Return([]*userDomain.User{}, nil)
+				m.On("SearchUsers", mock.Anything, mock.MatchedBy(func(s userDomain.UserSearch) bool {
+					return s.Role == userDomain.RoleAdmin && s.KYCStatus == userDomain.KYCStatusVerified &&
+						s.Status == userDomain.AccountStatusSuspended && s.Deleted == userDomain.DeletedInclude &&
+						s.Sort == userDomain.SearchSortOldest && s.Cursor == "abc" && s.Limit == 50 &&
+						s.CreatedFrom != nil && s.CreatedFrom.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) && s.CreatedTo == nil
+				})).Return(&userDomain.UserSearchPage{Users: []*userDomain.User{}}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			validateBody: func(t *testing.T, body map[string]interface{}) {
-				users := body["users"].([]interface{})
-				assert.Len(t, users, 0)
+				assert.Empty(t, body["users"])
+				assert.NotContains(t, body, "next_cursor")
+			},
+		},
+		{
+			name:        "search users without a query lists users",
+			queryParams: "",
+			mockSetup: func(m *MockUserService) {
+				m.On("SearchUsers", mock.Anything, userDomain.UserSearch{}).
+					Return(&userDomain.UserSearchPage{Users: []*userDomain.User{user1}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Len(t, body["users"], 1)
 			},
 		},
 		{
 			name:        "search users with service error",
 			queryParams: "?query=error",
 			mockSetup: func(m *MockUserService) {
-				m.On("SearchUsers", mock.Anything, "error", 20, 0).
-					Return([]*userDomain.User(nil), fmt.Errorf("search failed"))
+				m.On("SearchUsers", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("search failed"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			validateBody: func(t *testing.T, body map[string]interface{}) {
@@ -212,27 +230,51 @@ func TestSearchUsers(t *testing.T) {
 			},
 		},
 		{
-			name:        "search users with missing query",
-			queryParams: "",
-			mockSetup:   func(m *MockUserService) {},
+			name:        "search users with invalid search",
+			queryParams: "?sort=relevance",
+			mockSetup: func(m *MockUserService) {
+				m.On("SearchUsers", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("%w: relevance sort requires a query", userDomain.ErrInvalidSearch))
+			},
 			expectedStatus: http.StatusBadRequest,
 			validateBody: func(t *testing.T, body map[string]interface{}) {
 				assert.Equal(t, "invalid_request", body["error"])
 			},
 		},
 		{
-			name:        "search users with invalid limit",
-			queryParams: "?query=test&limit=200",
-			mockSetup:   func(m *MockUserService) {},
+			name:        "search users with invalid cursor",
+			queryParams: "?query=test&cursor=stale",
+			mockSetup: func(m *MockUserService) {
+				m.On("SearchUsers", mock.Anything, mock.Anything).
+					Return(nil, userDomain.ErrInvalidSearchCursor)
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "invalid_cursor", body["error"])
+			},
+		},
+		{
+			name:           "search users with invalid limit",
+			queryParams:    "?query=test&limit=200",
+			mockSetup:      func(m *MockUserService) {},
 			expectedStatus: http.StatusBadRequest,
 			validateBody: func(t *testing.T, body map[string]interface{}) {
 				assert.Equal(t, "invalid_request", body["error"])
 			},
 		},
 		{
-			name:        "search users with invalid offset",
-			queryParams: "?query=test&offset=-5",
-			mockSetup:   func(m *MockUserService) {},
+			name:           "search users with unknown sort",
+			queryParams:    "?query=test&sort=name",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "invalid_request", body["error"])
+			},
+		},
+		{
+			name:           "search users with invalid date",
+			queryParams:    "?created_to=yesterday",
+			mockSetup:      func(m *MockUserService) {},
 			expectedStatus: http.StatusBadRequest,
 			validateBody: func(t *testing.T, body map[string]interface{}) {
 				assert.Equal(t, "invalid_request", body["error"])
@@ -521,6 +563,236 @@ func TestUpdateUserRole(t *testing.T) {
 	}
 }
 
+// TestRestoreUser tests the RestoreUser HTTP handler
+func TestRestoreUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	adminID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		userID         string
+		mockSetup      func(m *MockUserService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:   "restore user successfully",
+			userID: userID.String(),
+			mockSetup: func(m *MockUserService) {
+				m.On("AdminRestoreAccount", mock.Anything, userID, adminID).
+					Return(&userDomain.User{ID: userID, Email: "test@test.com"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "restore user with invalid user ID",
+			userID:         "invalid-uuid",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_user_id",
+		},
+		{
+			name:   "restore user not found",
+			userID: userID.String(),
+			mockSetup: func(m *MockUserService) {
+				m.On("AdminRestoreAccount", mock.Anything, userID, adminID).
+					Return((*userDomain.User)(nil), userDomain.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "user_not_found",
+		},
+		{
+			name:   "restore user not deleted",
+			userID: userID.String(),
+			mockSetup: func(m *MockUserService) {
+				m.On("AdminRestoreAccount", mock.Anything, userID, adminID).
+					Return((*userDomain.User)(nil), userDomain.ErrNotDeleted)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "account_not_deleted",
+		},
+		{
+			name:   "restore user after grace period",
+			userID: userID.String(),
+			mockSetup: func(m *MockUserService) {
+				m.On("AdminRestoreAccount", mock.Anything, userID, adminID).
+					Return((*userDomain.User)(nil), userDomain.ErrRestoreWindowExpired)
+			},
+			expectedStatus: http.StatusGone,
+			expectedError:  "restore_window_expired",
+		},
+		{
+			name:   "restore user with service error",
+			userID: userID.String(),
+			mockSetup: func(m *MockUserService) {
+				m.On("AdminRestoreAccount", mock.Anything, userID, adminID).
+					Return((*userDomain.User)(nil), fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "internal_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockUserService)
+			tc.mockSetup(mockService)
+
+			handler := httpTransport.NewAdminHandler(mockService, getTestLogger())
+
+			router := gin.New()
+			router.POST("/admin/users/:id/restore", func(c *gin.Context) {
+				c.Set("user_id", adminID)
+				handler.RestoreUser(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/users/"+tc.userID+"/restore", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			if tc.expectedError != "" {
+				assert.Equal(t, tc.expectedError, response["error"])
+			} else {
+				assert.Equal(t, userID.String(), response["id"])
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestSetUserStatus tests the SetUserStatus HTTP handler
+func TestSetUserStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	adminID := uuid.New()
+	suspension := userDomain.AccountStatusUpdate{Status: userDomain.AccountStatusSuspended, Reason: "fraud review"}
+
+	testCases := []struct {
+		name           string
+		userID         string
+		body           string
+		mockSetup      func(m *MockUserService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:   "suspend user successfully",
+			userID: userID.String(),
+			body:   `{"status":"suspended","reason":"fraud review"}`,
+			mockSetup: func(m *MockUserService) {
+				m.On("SetAccountStatus", mock.Anything, userID, suspension, adminID).
+					Return(&userDomain.User{ID: userID, Status: userDomain.AccountStatusSuspended, StatusReason: "fraud review"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "set status without reason",
+			userID:         userID.String(),
+			body:           `{"status":"suspended"}`,
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_request",
+		},
+		{
+			name:   "set invalid status",
+			userID: userID.String(),
+			body:   `{"status":"banned","reason":"spam"}`,
+			mockSetup: func(m *MockUserService) {
+				m.On("SetAccountStatus", mock.Anything, userID, mock.Anything, adminID).
+					Return((*userDomain.User)(nil), fmt.Errorf("%w: %q", userDomain.ErrInvalidAccountStatus, "banned"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_account_status",
+		},
+		{
+			name:   "set status of unknown user",
+			userID: userID.String(),
+			body:   `{"status":"suspended","reason":"fraud review"}`,
+			mockSetup: func(m *MockUserService) {
+				m.On("SetAccountStatus", mock.Anything, userID, suspension, adminID).
+					Return((*userDomain.User)(nil), userDomain.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "user_not_found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockUserService)
+			tc.mockSetup(mockService)
+
+			handler := httpTransport.NewAdminHandler(mockService, getTestLogger())
+
+			router := gin.New()
+			router.PUT("/admin/users/:id/status", func(c *gin.Context) {
+				c.Set("user_id", adminID)
+				handler.SetUserStatus(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/users/"+tc.userID+"/status", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			if tc.expectedError != "" {
+				assert.Equal(t, tc.expectedError, response["error"])
+			} else {
+				assert.Equal(t, "suspended", response["status"])
+				assert.Equal(t, "fraud review", response["status_reason"])
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestGetUserStatusHistory tests the GetUserStatusHistory HTTP handler
+func TestGetUserStatusHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	adminID := uuid.New()
+	changes := []*userDomain.AccountStatusChange{
+		{ID: uuid.New(), UserID: userID, OldStatus: userDomain.AccountStatusActive, NewStatus: userDomain.AccountStatusSuspended, Reason: "fraud review", ChangedBy: &adminID, SessionsRevoked: true},
+		{ID: uuid.New(), UserID: userID, OldStatus: userDomain.AccountStatusSuspended, NewStatus: userDomain.AccountStatusActive, Reason: userDomain.StatusReasonExpired},
+	}
+
+	mockService := new(MockUserService)
+	mockService.On("GetAccountStatusHistory", mock.Anything, userID).Return(changes, nil)
+	handler := httpTransport.NewAdminHandler(mockService, getTestLogger())
+
+	router := gin.New()
+	router.GET("/admin/users/:id/status/history", handler.GetUserStatusHistory)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/users/"+userID.String()+"/status/history", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response httpTransport.AccountStatusHistoryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Changes, 2)
+	assert.Equal(t, "suspended", response.Changes[0].NewStatus)
+	assert.Equal(t, &adminID, response.Changes[0].ChangedBy)
+	assert.Nil(t, response.Changes[1].ChangedBy)
+	mockService.AssertExpectations(t)
+}
+
 // TestGetAllSessions tests the GetAllSessions HTTP handler
 func TestGetAllSessions(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -750,41 +1022,100 @@ func TestForceLogout(t *testing.T) {
 func TestGetSystemStats(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	stats := map[string]interface{}{
-		"total_users":     100,
-		"active_users":    80,
-		"deleted_users":   20,
-		"pending_kyc":     15,
-		"verified_kyc":    65,
-		"rejected_kyc":    0,
-		"active_sessions": 50,
-		"total_admins":    5,
-	}
+	from := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 11, 4, 0, 0, 0, 0, time.UTC)
+	refreshedAt := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	dashboard := stats.BuildDashboard(
+		stats.Query{From: from, To: to, Granularity: stats.GranularityDay},
+		[]stats.Daily{{Day: from, Registrations: 3, Logins: 12, FailedLogins: 2, KYCApprovals: 1, ActiveUsers: 9, MonthlyActiveUsers: 40}},
+		&stats.Snapshot{
+			KYCFunnel:        []stats.FunnelStage{{Status: "approved", Users: 60}, {Status: stats.FunnelStatusNotStarted, Users: 40}},
+			SessionsByDevice: []stats.DeviceSessions{{Device: stats.DeviceMobile, Sessions: 50, Users: 30}},
+			RefreshedAt:      &refreshedAt,
+		})
 
 	testCases := []struct {
 		name           string
+		query          string
 		mockSetup      func(m *MockUserService)
 		expectedStatus int
 		validateBody   func(t *testing.T, body map[string]interface{})
 	}{
 		{
-			name: "get system stats successfully",
+			name:  "get system stats successfully",
+			query: "?from=2025-11-03&to=2025-11-04&granularity=day",
 			mockSetup: func(m *MockUserService) {
-				m.On("GetSystemStats", mock.Anything).
-					Return(stats, nil)
+				m.On("GetSystemStats", mock.Anything, stats.Query{From: from, To: to, Granularity: stats.GranularityDay}).
+					Return(dashboard, nil)
 			},
 			expectedStatus: http.StatusOK,
 			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "2025-11-03", body["from"])
 				assert.Equal(t, float64(100), body["total_users"])
-				assert.Equal(t, float64(80), body["active_users"])
-				assert.Equal(t, float64(5), body["total_admins"])
+				assert.Equal(t, float64(50), body["active_sessions"])
+				assert.Equal(t, float64(12), body["totals"].(map[string]interface{})["logins"])
+
+				series := body["series"].([]interface{})
+				require.Len(t, series, 2)
+				assert.Equal(t, "2025-11-04", series[1].(map[string]interface{})["period_start"])
+				funnel := body["kyc_funnel"].([]interface{})
+				assert.Equal(t, map[string]interface{}{"status": "not_started", "users": float64(40)}, funnel[0])
+				assert.Len(t, body["sessions_by_device"], len(stats.Devices))
+				assert.Equal(t, "2025-11-08T12:00:00Z", body["refreshed_at"])
+			},
+		},
+		{
+			name:  "defaults leave the range to the service",
+			query: "",
+			mockSetup: func(m *MockUserService) {
+				m.On("GetSystemStats", mock.Anything, stats.Query{}).Return(dashboard, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "malformed date",
+			query:          "?from=2025-11-03T00:00:00Z",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "invalid_request", body["error"])
+			},
+		},
+		{
+			name:           "unknown granularity",
+			query:          "?granularity=month",
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid range",
+			query: "?from=2025-11-04&to=2025-11-03",
+			mockSetup: func(m *MockUserService) {
+				m.On("GetSystemStats", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("%w: from must not be after to", stats.ErrInvalidQuery))
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "invalid_request", body["error"])
+			},
+		},
+		{
+			name:  "statistics not configured",
+			query: "",
+			mockSetup: func(m *MockUserService) {
+				m.On("GetSystemStats", mock.Anything, mock.Anything).Return(nil, stats.ErrUnavailable)
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "stats_unavailable", body["error"])
 			},
 		},
 		{
-			name: "get system stats with service error",
+			name:  "get system stats with service error",
+			query: "",
 			mockSetup: func(m *MockUserService) {
-				m.On("GetSystemStats", mock.Anything).
-					Return(map[string]interface{}(nil), fmt.Errorf("database error"))
+				m.On("GetSystemStats", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			validateBody: func(t *testing.T, body map[string]interface{}) {
@@ -804,7 +1135,7 @@ func TestGetSystemStats(t *testing.T) {
 			router := gin.New()
 			router.GET("/admin/stats", handler.GetSystemStats)
 
-			req := httptest.NewRequest(http.MethodGet, "/admin/stats", nil)
+			req := httptest.NewRequest(http.MethodGet, "/admin/stats"+tc.query, nil)
 			w := httptest.NewRecorder()
 
 			router.ServeHTTP(w, req)
@@ -823,3 +1154,74 @@ func TestGetSystemStats(t *testing.T) {
 		})
 	}
 }
+
+// TestExportSystemStats tests the ExportSystemStats HTTP handler
+func TestExportSystemStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	day := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)
+	query := stats.Query{From: day, To: day, Granularity: stats.GranularityWeek}
+	dashboard := stats.BuildDashboard(query,
+		[]stats.Daily{{Day: day, Registrations: 3, Logins: 12, FailedLogins: 2, ActiveUsers: 9, MonthlyActiveUsers: 40}},
+		&stats.Snapshot{KYCFunnel: []stats.FunnelStage{{Status: "approved", Users: 60}}})
+
+	setup := func(t *testing.T) (*MockUserService, *gin.Engine) {
+		mockService := new(MockUserService)
+		handler := httpTransport.NewAdminHandler(mockService, getTestLogger())
+		router := gin.New()
+		router.GET("/admin/stats/export", handler.ExportSystemStats)
+		return mockService, router
+	}
+
+	t.Run("series by default", func(t *testing.T) {
+		mockService, router := setup(t)
+		mockService.On("GetSystemStats", mock.Anything, query).Return(dashboard, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/stats/export?from=2025-11-03&to=2025-11-03&granularity=week", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="pandora-stats-series-2025-11-03-2025-11-03.csv"`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "period_start,registrations,logins,failed_logins,kyc_approvals,active_users,monthly_active_users\n"+
+			"2025-11-03,3,12,2,0,9,40\n", w.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("KYC funnel", func(t *testing.T) {
+		mockService, router := setup(t)
+		mockService.On("GetSystemStats", mock.Anything, mock.Anything).Return(dashboard, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/stats/export?dataset=kyc_funnel", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "pandora-stats-kyc_funnel-")
+		assert.Contains(t, w.Body.String(), "status,users\nnot_started,0\n")
+		assert.Contains(t, w.Body.String(), "approved,60\n")
+	})
+
+	t.Run("unknown dataset", func(t *testing.T) {
+		mockService, router := setup(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/stats/export?dataset=users", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "GetSystemStats", mock.Anything, mock.Anything)
+	})
+
+	t.Run("statistics not configured", func(t *testing.T) {
+		mockService, router := setup(t)
+		mockService.On("GetSystemStats", mock.Anything, mock.Anything).Return(nil, stats.ErrUnavailable)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/stats/export", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
@@ -88,3 +88,15 @@ func GetUserRoleFromContext(c *gin.Context) (user.Role, error) {
 
 	return user.Role(role), nil
 }
+
+// GetAdminActorFromContext identifies the admin performing a request for audit records.
+// Returns the admin's email, falling back to the user ID when the email is not set.
+func GetAdminActorFromContext(c *gin.Context) string {
+	if email := c.GetString("email"); email != "" {
+		return email
+	}
+	if userID, err := GetUserIDFromContext(c); err == nil {
+		return userID.String()
+	}
+	return ""
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AlertHandler handles admin requests for security alerts.
+type AlertHandler struct {
+	alertService alert.Service
+	logger       *observability.Logger
+}
+
+// NewAlertHandler creates a new AlertHandler instance.
+func NewAlertHandler(alertService alert.Service, logger *observability.Logger) *AlertHandler {
+	return &AlertHandler{
+		alertService: alertService,
+		logger:       logger,
+	}
+}
+
+// ListAlerts handles GET /admin/alerts
+// Lists security alerts, newest first. Pass status=open or status=acknowledged to filter.
+func (h *AlertHandler) ListAlerts(c *gin.Context) {
+	var req ListAlertsRequest
+	req.Limit = 20 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid list alerts request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var status *alert.Status
+	if req.Status != "" {
+		s := alert.Status(req.Status)
+		status = &s
+	}
+
+	alerts, err := h.alertService.ListAlerts(c.Request.Context(), status, int32(req.Limit), int32(req.Offset)) // #nosec G115 -- bounded by binding (max=100)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list alerts")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to retrieve alerts",
+		})
+		return
+	}
+
+	dtos := make([]AlertDTO, len(alerts))
+	for i, a := range alerts {
+		dtos[i] = toAlertDTO(a)
+	}
+
+	c.JSON(http.StatusOK, AlertsListResponse{
+		Alerts: dtos,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+}
+
+// GetAlert handles GET /admin/alerts/:id
+func (h *AlertHandler) GetAlert(c *gin.Context) {
+	id, ok := h.parseAlertID(c)
+	if !ok {
+		return
+	}
+
+	a, err := h.alertService.GetAlert(c.Request.Context(), id)
+	if err != nil {
+		h.respondAlertError(c, err, "Failed to retrieve alert")
+		return
+	}
+
+	c.JSON(http.StatusOK, toAlertDTO(a))
+}
+
+// AcknowledgeAlert handles POST /admin/alerts/:id/acknowledge
+// Marks an open alert as triaged. Later firings of the same rule and group open a new alert.
+func (h *AlertHandler) AcknowledgeAlert(c *gin.Context) {
+	id, ok := h.parseAlertID(c)
+	if !ok {
+		return
+	}
+
+	var req AcknowledgeAlertRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.WithField("error", err.Error()).Warn("Invalid acknowledge alert request")
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	actor := GetAdminActorFromContext(c)
+	h.logger.WithFields(map[string]interface{}{
+		"alert_id": id.String(),
+		"actor":    actor,
+	}).Info("Admin: Processing acknowledge alert request")
+
+	a, err := h.alertService.AcknowledgeAlert(c.Request.Context(), id, actor, req.Note)
+	if err != nil {
+		h.respondAlertError(c, err, "Failed to acknowledge alert")
+		return
+	}
+
+	c.JSON(http.StatusOK, toAlertDTO(a))
+}
+
+// parseAlertID reads the :id path parameter, responding with 400 if it is not a UUID
+func (h *AlertHandler) parseAlertID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_alert_id",
+			Message: "Invalid alert ID format",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondAlertError maps alert domain errors to HTTP responses
+func (h *AlertHandler) respondAlertError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, alert.ErrAlertNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "alert_not_found",
+			Message: "Alert not found",
+		})
+	case errors.Is(err, alert.ErrAlreadyAcknowledged):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "alert_already_acknowledged",
+			Message: "Alert has already been acknowledged",
+		})
+	default:
+		h.logger.WithError(err).Error(message)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: message,
+		})
+	}
+}
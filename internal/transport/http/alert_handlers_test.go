@@ -0,0 +1,264 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	httpTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/http"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAlertService is a mock implementation of alert.Service
+type MockAlertService struct {
+	mock.Mock
+}
+
+func (m *MockAlertService) ListAlerts(ctx context.Context, status *alert.Status, limit, offset int32) ([]*alert.Alert, error) {
+	args := m.Called(ctx, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*alert.Alert), args.Error(1)
+}
+
+func (m *MockAlertService) GetAlert(ctx context.Context, id uuid.UUID) (*alert.Alert, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*alert.Alert), args.Error(1)
+}
+
+func (m *MockAlertService) AcknowledgeAlert(ctx context.Context, id uuid.UUID, actor string, note *string) (*alert.Alert, error) {
+	args := m.Called(ctx, id, actor, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*alert.Alert), args.Error(1)
+}
+
+func newAlertTestRouter(svc *MockAlertService) *gin.Engine {
+	handler := httpTransport.NewAlertHandler(svc, getTestLogger())
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uuid.New())
+		c.Set("email", "admin@test.com")
+		c.Next()
+	})
+	router.GET("/admin/alerts", handler.ListAlerts)
+	router.GET("/admin/alerts/:id", handler.GetAlert)
+	router.POST("/admin/alerts/:id/acknowledge", handler.AcknowledgeAlert)
+	return router
+}
+
+func sampleAlert(id uuid.UUID, status alert.Status) *alert.Alert {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	return &alert.Alert{
+		ID:          id,
+		RuleName:    "admin-login-bruteforce",
+		Severity:    alert.SeverityCritical,
+		Summary:     "admin-login-bruteforce: 5 admin.login.failed events within 10m0s for ip 10.0.0.1",
+		GroupBy:     alert.GroupByIP,
+		GroupValue:  "10.0.0.1",
+		DedupKey:    "admin-login-bruteforce|ip:10.0.0.1",
+		EventCount:  5,
+		FireCount:   1,
+		FirstSeenAt: now.Add(-time.Minute),
+		LastSeenAt:  now,
+		Events:      []alert.Event{{Type: "admin.login.failed", IPAddress: "10.0.0.1", OccurredAt: now}},
+		Status:      status,
+		CreatedAt:   now,
+	}
+}
+
+// TestListAlerts tests the ListAlerts HTTP handler
+func TestListAlerts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		queryParams    string
+		mockSetup      func(m *MockAlertService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "list open alerts",
+			queryParams: "?status=open",
+			mockSetup: func(m *MockAlertService) {
+				m.On("ListAlerts", mock.Anything, mock.MatchedBy(func(s *alert.Status) bool {
+					return s != nil && *s == alert.StatusOpen
+				}), int32(20), int32(0)).Return([]*alert.Alert{sampleAlert(uuid.New(), alert.StatusOpen)}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "list all alerts",
+			queryParams: "?limit=5",
+			mockSetup: func(m *MockAlertService) {
+				m.On("ListAlerts", mock.Anything, (*alert.Status)(nil), int32(5), int32(0)).Return([]*alert.Alert{sampleAlert(uuid.New(), alert.StatusAcknowledged)}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown status",
+			queryParams:    "?status=closed",
+			mockSetup:      func(m *MockAlertService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_request",
+		},
+		{
+			name:        "service error",
+			queryParams: "",
+			mockSetup: func(m *MockAlertService) {
+				m.On("ListAlerts", mock.Anything, (*alert.Status)(nil), int32(20), int32(0)).Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "internal_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockAlertService)
+			tc.mockSetup(mockService)
+			router := newAlertTestRouter(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/alerts"+tc.queryParams, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			if tc.expectedError != "" {
+				assert.Equal(t, tc.expectedError, body["error"])
+			} else {
+				alerts := body["alerts"].([]interface{})
+				require.Len(t, alerts, 1)
+				assert.Equal(t, "admin-login-bruteforce", alerts[0].(map[string]interface{})["rule_name"])
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestGetAlert tests the GetAlert HTTP handler
+func TestGetAlert(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	id := uuid.New()
+
+	t.Run("found", func(t *testing.T) {
+		mockService := new(MockAlertService)
+		mockService.On("GetAlert", mock.Anything, id).Return(sampleAlert(id, alert.StatusOpen), nil)
+		router := newAlertTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/alerts/"+id.String(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp httpTransport.AlertDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, id, resp.ID)
+		assert.Equal(t, "open", resp.Status)
+		require.Len(t, resp.Events, 1)
+		assert.Equal(t, "10.0.0.1", resp.Events[0].IPAddress)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockService := new(MockAlertService)
+		mockService.On("GetAlert", mock.Anything, id).Return(nil, alert.ErrAlertNotFound)
+		router := newAlertTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/alerts/"+id.String(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "alert_not_found")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		router := newAlertTestRouter(new(MockAlertService))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/alerts/not-a-uuid", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_alert_id")
+	})
+}
+
+// TestAcknowledgeAlert tests the AcknowledgeAlert HTTP handler
+func TestAcknowledgeAlert(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	id := uuid.New()
+
+	t.Run("acknowledge with note", func(t *testing.T) {
+		acknowledged := sampleAlert(id, alert.StatusAcknowledged)
+		actor := "admin@test.com"
+		note := "IP blocked"
+		acknowledged.AcknowledgedBy = &actor
+		acknowledged.AckNote = &note
+
+		mockService := new(MockAlertService)
+		mockService.On("AcknowledgeAlert", mock.Anything, id, "admin@test.com", mock.MatchedBy(func(n *string) bool {
+			return n != nil && *n == note
+		})).Return(acknowledged, nil)
+		router := newAlertTestRouter(mockService)
+
+		body, _ := json.Marshal(map[string]string{"note": note})
+		req := httptest.NewRequest(http.MethodPost, "/admin/alerts/"+id.String()+"/acknowledge", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "acknowledged", resp["status"])
+		assert.Equal(t, actor, resp["acknowledged_by"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("acknowledge without body", func(t *testing.T) {
+		mockService := new(MockAlertService)
+		mockService.On("AcknowledgeAlert", mock.Anything, id, "admin@test.com", (*string)(nil)).Return(sampleAlert(id, alert.StatusAcknowledged), nil)
+		router := newAlertTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/alerts/"+id.String()+"/acknowledge", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("already acknowledged", func(t *testing.T) {
+		mockService := new(MockAlertService)
+		mockService.On("AcknowledgeAlert", mock.Anything, id, "admin@test.com", (*string)(nil)).Return(nil, alert.ErrAlreadyAcknowledged)
+		router := newAlertTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/alerts/"+id.String()+"/acknowledge", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "alert_already_acknowledged")
+	})
+}
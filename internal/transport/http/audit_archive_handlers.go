@@ -0,0 +1,146 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditArchiveHandler handles admin requests for archived audit log months.
+type AuditArchiveHandler struct {
+	archiveService audit.ArchiveService
+	logger         *observability.Logger
+}
+
+// NewAuditArchiveHandler creates a new AuditArchiveHandler instance.
+func NewAuditArchiveHandler(archiveService audit.ArchiveService, logger *observability.Logger) *AuditArchiveHandler {
+	return &AuditArchiveHandler{
+		archiveService: archiveService,
+		logger:         logger,
+	}
+}
+
+// ListArchives handles GET /admin/audit/archives
+// Lists archived audit log months, newest first.
+func (h *AuditArchiveHandler) ListArchives(c *gin.Context) {
+	var req AdminListUsersRequest
+	req.Limit = 20 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid list audit archives request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	archives, err := h.archiveService.ListArchives(c.Request.Context(), int32(req.Limit), int32(req.Offset)) // #nosec G115 -- bounded by binding (max=100)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list audit archives")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to retrieve audit archives",
+		})
+		return
+	}
+
+	dtos := make([]AuditArchiveDTO, len(archives))
+	for i, archive := range archives {
+		dtos[i] = toAuditArchiveDTO(archive)
+	}
+
+	c.JSON(http.StatusOK, AuditArchivesListResponse{
+		Archives: dtos,
+		Limit:    req.Limit,
+		Offset:   req.Offset,
+	})
+}
+
+// RestoreArchive handles POST /admin/audit/archives/:month/restore
+// Loads an archived month (YYYY-MM) back into audit_logs for investigation.
+func (h *AuditArchiveHandler) RestoreArchive(c *gin.Context) {
+	month, err := audit.ParseMonth(c.Param("month"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_month",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	restoredBy := GetAdminActorFromContext(c)
+
+	h.logger.WithFields(map[string]interface{}{
+		"month":       c.Param("month"),
+		"restored_by": restoredBy,
+	}).Info("Admin: Processing restore audit archive request")
+
+	archive, err := h.archiveService.RestoreArchive(c.Request.Context(), month, restoredBy)
+	if err != nil {
+		h.respondArchiveError(c, err, "Failed to restore audit archive")
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuditArchiveDTO(archive))
+}
+
+// ReleaseArchive handles POST /admin/audit/archives/:month/release
+// Drops a restored month from audit_logs again. The archive itself is kept.
+func (h *AuditArchiveHandler) ReleaseArchive(c *gin.Context) {
+	month, err := audit.ParseMonth(c.Param("month"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_month",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	h.logger.WithField("month", c.Param("month")).Info("Admin: Processing release audit archive request")
+
+	archive, err := h.archiveService.ReleaseArchive(c.Request.Context(), month)
+	if err != nil {
+		h.respondArchiveError(c, err, "Failed to release audit archive")
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuditArchiveDTO(archive))
+}
+
+// respondArchiveError maps archive domain errors to HTTP responses
+func (h *AuditArchiveHandler) respondArchiveError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, audit.ErrArchiveNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "archive_not_found",
+			Message: "No archive exists for this month",
+		})
+	case errors.Is(err, audit.ErrArchiveAlreadyRestored):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "archive_already_restored",
+			Message: "This month is already restored",
+		})
+	case errors.Is(err, audit.ErrArchiveNotRestored):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "archive_not_restored",
+			Message: "This month is not restored",
+		})
+	case errors.Is(err, audit.ErrArchiveCorrupted):
+		h.logger.WithError(err).Error("Audit archive failed verification")
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Error:   "archive_corrupted",
+			Message: "Archive does not match its manifest",
+		})
+	default:
+		h.logger.WithError(err).Error(message)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: message,
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	httpTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/http"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockArchiveService is a mock implementation of audit.ArchiveService
+type MockArchiveService struct {
+	mock.Mock
+}
+
+func (m *MockArchiveService) ListArchives(ctx context.Context, limit, offset int32) ([]*audit.Archive, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*audit.Archive), args.Error(1)
+}
+
+func (m *MockArchiveService) RestoreArchive(ctx context.Context, month time.Time, restoredBy string) (*audit.Archive, error) {
+	args := m.Called(ctx, month, restoredBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.Archive), args.Error(1)
+}
+
+func (m *MockArchiveService) ReleaseArchive(ctx context.Context, month time.Time) (*audit.Archive, error) {
+	args := m.Called(ctx, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.Archive), args.Error(1)
+}
+
+func testArchive(month time.Time) *audit.Archive {
+	return &audit.Archive{
+		ID:            uuid.New(),
+		Month:         month,
+		PartitionName: audit.PartitionName(month),
+		ObjectKey:     "audit-logs/2024/01/audit_logs_y2024m01.ndjson.gz",
+		ManifestKey:   "audit-logs/2024/01/audit_logs_y2024m01.manifest.json",
+		RowCount:      42,
+		SizeBytes:     1024,
+		Checksum:      "abc123",
+		ArchivedAt:    time.Now(),
+	}
+}
+
+// TestListAuditArchives tests the ListArchives HTTP handler
+func TestListAuditArchives(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	month := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		queryParams    string
+		mockSetup      func(m *MockArchiveService)
+		expectedStatus int
+		validateBody   func(t *testing.T, body map[string]interface{})
+	}{
+		{
+			name:        "list archives with defaults",
+			queryParams: "",
+			mockSetup: func(m *MockArchiveService) {
+				m.On("ListArchives", mock.Anything, int32(20), int32(0)).
+					Return([]*audit.Archive{testArchive(month)}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				archives := body["archives"].([]interface{})
+				assert.Len(t, archives, 1)
+				first := archives[0].(map[string]interface{})
+				assert.Equal(t, "2024-01", first["month"])
+				assert.Equal(t, false, first["restored"])
+			},
+		},
+		{
+			name:           "list archives with invalid limit",
+			queryParams:    "?limit=101",
+			mockSetup:      func(m *MockArchiveService) {},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "invalid_request", body["error"])
+			},
+		},
+		{
+			name:        "list archives with service error",
+			queryParams: "",
+			mockSetup: func(m *MockArchiveService) {
+				m.On("ListArchives", mock.Anything, int32(20), int32(0)).
+					Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "internal_error", body["error"])
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockArchiveService)
+			tc.mockSetup(mockService)
+
+			handler := httpTransport.NewAuditArchiveHandler(mockService, getTestLogger())
+
+			router := gin.New()
+			router.GET("/admin/audit/archives", handler.ListArchives)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/audit/archives"+tc.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			var body map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &body)
+			assert.NoError(t, err)
+			tc.validateBody(t, body)
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestRestoreAuditArchive tests the RestoreArchive HTTP handler
+func TestRestoreAuditArchive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	month := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		month          string
+		mockSetup      func(m *MockArchiveService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:  "restore archive successfully",
+			month: "2024-01",
+			mockSetup: func(m *MockArchiveService) {
+				restored := testArchive(month)
+				now := time.Now()
+				by := "admin@test.com"
+				restored.RestoredAt = &now
+				restored.RestoredBy = &by
+				m.On("RestoreArchive", mock.Anything, month, "admin@test.com").Return(restored, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "restore archive with invalid month",
+			month:          "2024-13",
+			mockSetup:      func(m *MockArchiveService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_month",
+		},
+		{
+			name:  "restore missing archive",
+			month: "2024-01",
+			mockSetup: func(m *MockArchiveService) {
+				m.On("RestoreArchive", mock.Anything, month, "admin@test.com").
+					Return(nil, fmt.Errorf("restore: %w", audit.ErrArchiveNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "archive_not_found",
+		},
+		{
+			name:  "restore already restored archive",
+			month: "2024-01",
+			mockSetup: func(m *MockArchiveService) {
+				m.On("RestoreArchive", mock.Anything, month, "admin@test.com").
+					Return(nil, audit.ErrArchiveAlreadyRestored)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "archive_already_restored",
+		},
+		{
+			name:  "restore corrupted archive",
+			month: "2024-01",
+			mockSetup: func(m *MockArchiveService) {
+				m.On("RestoreArchive", mock.Anything, month, "admin@test.com").
+					Return(nil, audit.ErrArchiveCorrupted)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "archive_corrupted",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockArchiveService)
+			tc.mockSetup(mockService)
+
+			handler := httpTransport.NewAuditArchiveHandler(mockService, getTestLogger())
+
+			router := gin.New()
+			router.POST("/admin/audit/archives/:month/restore", func(c *gin.Context) {
+				c.Set("user_id", uuid.New())
+				c.Set("email", "admin@test.com")
+				c.Next()
+			}, handler.RestoreArchive)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/audit/archives/"+tc.month+"/restore", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			var body map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &body)
+			assert.NoError(t, err)
+			if tc.expectedError != "" {
+				assert.Equal(t, tc.expectedError, body["error"])
+			} else {
+				assert.Equal(t, true, body["restored"])
+				assert.Equal(t, "admin@test.com", body["restored_by"])
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestReleaseAuditArchive tests the ReleaseArchive HTTP handler
+func TestReleaseAuditArchive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	month := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		mockSetup      func(m *MockArchiveService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "release archive successfully",
+			mockSetup: func(m *MockArchiveService) {
+				m.On("ReleaseArchive", mock.Anything, month).Return(testArchive(month), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "release archive that is not restored",
+			mockSetup: func(m *MockArchiveService) {
+				m.On("ReleaseArchive", mock.Anything, month).Return(nil, audit.ErrArchiveNotRestored)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "archive_not_restored",
+		},
+		{
+			name: "release archive with service error",
+			mockSetup: func(m *MockArchiveService) {
+				m.On("ReleaseArchive", mock.Anything, month).Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "internal_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockArchiveService)
+			tc.mockSetup(mockService)
+
+			handler := httpTransport.NewAuditArchiveHandler(mockService, getTestLogger())
+
+			router := gin.New()
+			router.POST("/admin/audit/archives/:month/release", handler.ReleaseArchive)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/audit/archives/2024-01/release", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			var body map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &body)
+			assert.NoError(t, err)
+			if tc.expectedError != "" {
+				assert.Equal(t, tc.expectedError, body["error"])
+			} else {
+				assert.Equal(t, false, body["restored"])
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
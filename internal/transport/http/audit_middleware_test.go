@@ -286,6 +286,20 @@ func TestCategorizeRequest(t *testing.T) {
 			expectedType: "user.kyc_view",
 			expectedCat:  audit.CategoryDataAccess,
 		},
+		{
+			name:         "data export request",
+			path:         "/api/v1/users/me/data-export",
+			method:       "POST",
+			expectedType: "user.data_export_request",
+			expectedCat:  audit.CategoryCompliance,
+		},
+		{
+			name:         "data export download",
+			path:         "/api/v1/data-exports/download",
+			method:       "GET",
+			expectedType: "user.data_export_view",
+			expectedCat:  audit.CategoryDataAccess,
+		},
 		{
 			name:         "user view",
 			path:         "/users/123",
@@ -433,6 +447,7 @@ func TestIsSensitiveEndpoint(t *testing.T) {
 		{"/auth/login", true},
 		{"/auth/register", true},
 		{"/users/123/kyc", true},
+		{"/users/me/data-export", true},
 		{"/admin/users", true},
 		{"/users/123", false},
 		{"/health", false},
@@ -467,6 +482,24 @@ func TestShouldSkipAudit(t *testing.T) {
 	}
 }
 
+func TestRedactQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"limit=20&offset=0", "limit=20&offset=0"},
+		{"token=abc123", "token=[REDACTED]"},
+		{"a=1&token=abc123&b=2", "a=1&token=[REDACTED]&b=2"},
+		{"tokens=abc123", "tokens=abc123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.expected, redactQuery(tt.query))
+		})
+	}
+}
+
 func TestAuditMiddleware_WithRequestID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	logger := observability.NewLogger("dev", "test-service")
@@ -583,3 +616,58 @@ func TestAuditMiddleware_RetentionPeriod(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 	mockAuditRepo.AssertExpectations(t)
 }
+
+func TestAuditMiddleware_RetentionPolicyRules(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := observability.NewLogger("dev", "test-service")
+
+	cfg := &config.Config{
+		Audit: config.AuditConfig{
+			RetentionDays: 90,
+			RetentionRules: []config.RetentionRuleConfig{
+				{Category: "data_access", Days: 30},
+				{Category: "security", Days: 730},
+				{EventType: "user.delete", Days: 2555},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		days   int
+	}{
+		{name: "category rule", method: http.MethodGet, path: "/users", days: 30},
+		{name: "admin events use security rule", method: http.MethodGet, path: "/admin/users", days: 730},
+		{name: "event type override", method: http.MethodDelete, path: "/users/me", days: 2555},
+		{name: "unmatched event uses default", method: http.MethodPost, path: "/auth/login", days: 90},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuditRepo := new(mocks.MockAuditRepository)
+			mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(log *audit.Log) bool {
+				if log.RetentionUntil == nil {
+					return false
+				}
+				expected := time.Now().Add(time.Duration(tt.days) * 24 * time.Hour)
+				diff := log.RetentionUntil.Sub(expected)
+				return diff < time.Minute && diff > -time.Minute
+			})).Return(&audit.Log{}, nil).Once()
+
+			router := gin.New()
+			router.Use(AuditMiddleware(mockAuditRepo, cfg, logger))
+			router.Handle(tt.method, tt.path, func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			time.Sleep(100 * time.Millisecond)
+			mockAuditRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditRetentionHandler handles admin requests for the audit retention policy.
+type AuditRetentionHandler struct {
+	retentionService audit.RetentionService
+	logger           *observability.Logger
+}
+
+// NewAuditRetentionHandler creates a new AuditRetentionHandler instance.
+func NewAuditRetentionHandler(retentionService audit.RetentionService, logger *observability.Logger) *AuditRetentionHandler {
+	return &AuditRetentionHandler{
+		retentionService: retentionService,
+		logger:           logger,
+	}
+}
+
+// GetPolicy handles GET /admin/audit/retention/policy
+// Returns the retention policy applied to new audit logs.
+func (h *AuditRetentionHandler) GetPolicy(c *gin.Context) {
+	c.JSON(http.StatusOK, toRetentionPolicyResponse(h.retentionService.Policy()))
+}
+
+// DryRun handles GET /admin/audit/retention/dry-run
+// Reports what the cleanup job would delete, now or at as_of (RFC 3339), without deleting anything.
+func (h *AuditRetentionHandler) DryRun(c *gin.Context) {
+	var req RetentionDryRunRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid retention dry run request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	report, err := h.retentionService.DryRun(c.Request.Context(), req.AsOf)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to run audit retention dry run")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to compute audit retention dry run",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, toRetentionDryRunResponse(report))
+}
+
+// Restamp handles POST /admin/audit/retention/restamp
+// Recomputes retention_until of existing audit logs from the current policy.
+func (h *AuditRetentionHandler) Restamp(c *gin.Context) {
+	actor := GetAdminActorFromContext(c)
+
+	h.logger.WithField("actor", actor).Info("Admin: Processing restamp audit retention request")
+
+	updated, err := h.retentionService.RestampRetention(c.Request.Context(), actor)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to restamp audit retention")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to restamp audit retention",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, RetentionRestampResponse{Updated: updated})
+}
@@ -0,0 +1,226 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	httpTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/http"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRetentionService is a mock implementation of audit.RetentionService
+type MockRetentionService struct {
+	mock.Mock
+}
+
+func (m *MockRetentionService) Policy() *audit.RetentionPolicy {
+	args := m.Called()
+	return args.Get(0).(*audit.RetentionPolicy)
+}
+
+func (m *MockRetentionService) RestampRetention(ctx context.Context, actor string) (int64, error) {
+	args := m.Called(ctx, actor)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRetentionService) DryRun(ctx context.Context, asOf time.Time) (*audit.RetentionReport, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.RetentionReport), args.Error(1)
+}
+
+// TestGetRetentionPolicy tests the GetPolicy HTTP handler
+func TestGetRetentionPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	policy, err := audit.NewRetentionPolicy(90, []audit.RetentionRule{
+		{Category: audit.CategorySecurity, Days: 730},
+		{EventType: "user.login.failed", Days: 365},
+	})
+	require.NoError(t, err)
+
+	mockService := new(MockRetentionService)
+	mockService.On("Policy").Return(policy)
+
+	handler := httpTransport.NewAuditRetentionHandler(mockService, getTestLogger())
+	router := gin.New()
+	router.GET("/admin/audit/retention/policy", handler.GetPolicy)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit/retention/policy", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body httpTransport.RetentionPolicyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, 90, body.DefaultDays)
+	require.Len(t, body.Rules, 2)
+	assert.Equal(t, "user.login.failed", body.Rules[0].EventType)
+	assert.Equal(t, "security", body.Rules[1].Category)
+}
+
+// TestRetentionDryRun tests the DryRun HTTP handler
+func TestRetentionDryRun(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	asOf := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		queryParams    string
+		mockSetup      func(m *MockRetentionService)
+		expectedStatus int
+		validateBody   func(t *testing.T, body map[string]interface{})
+	}{
+		{
+			name:        "dry run now",
+			queryParams: "",
+			mockSetup: func(m *MockRetentionService) {
+				m.On("DryRun", mock.Anything, time.Time{}).Return(&audit.RetentionReport{
+					AsOf: asOf,
+					Buckets: []*audit.ExpiryBucket{
+						{Category: audit.CategoryDataAccess, Severity: audit.SeverityInfo, DeletableCount: 10, HeldCount: 2},
+					},
+					TotalDeletable: 10,
+					TotalHeld:      2,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, float64(10), body["total_deletable"])
+				assert.Equal(t, float64(2), body["total_held"])
+				buckets := body["buckets"].([]interface{})
+				assert.Len(t, buckets, 1)
+				assert.Equal(t, "data_access", buckets[0].(map[string]interface{})["category"])
+			},
+		},
+		{
+			name:        "dry run at a future date",
+			queryParams: "?as_of=2026-01-01T00:00:00Z",
+			mockSetup: func(m *MockRetentionService) {
+				m.On("DryRun", mock.Anything, mock.MatchedBy(func(t time.Time) bool {
+					return t.Equal(asOf)
+				})).Return(&audit.RetentionReport{AsOf: asOf, Buckets: []*audit.ExpiryBucket{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "2026-01-01T00:00:00Z", body["as_of"])
+				assert.Equal(t, float64(0), body["total_deletable"])
+			},
+		},
+		{
+			name:           "dry run with invalid date",
+			queryParams:    "?as_of=tomorrow",
+			mockSetup:      func(m *MockRetentionService) {},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "invalid_request", body["error"])
+			},
+		},
+		{
+			name:        "dry run with service error",
+			queryParams: "",
+			mockSetup: func(m *MockRetentionService) {
+				m.On("DryRun", mock.Anything, time.Time{}).Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "internal_error", body["error"])
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockRetentionService)
+			tc.mockSetup(mockService)
+
+			handler := httpTransport.NewAuditRetentionHandler(mockService, getTestLogger())
+			router := gin.New()
+			router.GET("/admin/audit/retention/dry-run", handler.DryRun)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/audit/retention/dry-run"+tc.queryParams, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			tc.validateBody(t, body)
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestRestampRetention tests the Restamp HTTP handler
+func TestRestampRetention(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		mockSetup      func(m *MockRetentionService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "restamp successfully",
+			mockSetup: func(m *MockRetentionService) {
+				m.On("RestampRetention", mock.Anything, "admin@test.com").Return(int64(42), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "restamp with service error",
+			mockSetup: func(m *MockRetentionService) {
+				m.On("RestampRetention", mock.Anything, "admin@test.com").Return(int64(0), fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "internal_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockRetentionService)
+			tc.mockSetup(mockService)
+
+			handler := httpTransport.NewAuditRetentionHandler(mockService, getTestLogger())
+			router := gin.New()
+			router.POST("/admin/audit/retention/restamp", func(c *gin.Context) {
+				c.Set("user_id", uuid.New())
+				c.Set("email", "admin@test.com")
+				c.Next()
+			}, handler.Restamp)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/audit/retention/restamp", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			if tc.expectedError != "" {
+				assert.Equal(t, tc.expectedError, body["error"])
+			} else {
+				assert.Equal(t, float64(42), body["updated"])
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
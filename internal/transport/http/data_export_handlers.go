@@ -0,0 +1,283 @@
+package http
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/dataexport"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// dataExportDownloadPath is the public download endpoint; the token in the query is the credential
+const dataExportDownloadPath = "/api/v1/data-exports/download"
+
+// DataExportHandler handles data subject access exports: users export their own data on the
+// user router, admins request exports on a user's behalf on the admin router.
+type DataExportHandler struct {
+	exportService dataexport.Service
+	logger        *observability.Logger
+}
+
+// NewDataExportHandler creates a new DataExportHandler instance.
+func NewDataExportHandler(exportService dataexport.Service, logger *observability.Logger) *DataExportHandler {
+	return &DataExportHandler{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+// RequestMyExport handles POST /api/v1/users/me/data-export
+// Queues an export of the user's data. The archive is built in the background; poll
+// GET /api/v1/users/me/data-export/:id until it completes.
+func (h *DataExportHandler) RequestMyExport(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.exportService.RequestExport(c.Request.Context(), userID, dataexport.Requester{
+		Type: audit.ActorUser,
+		ID:   userID.String(),
+	})
+	if err != nil {
+		h.respondDataExportError(c, err, "Failed to request data export")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toDataExportDTO(job, false))
+}
+
+// ListMyExports handles GET /api/v1/users/me/data-export
+// Lists the user's exports, newest first.
+func (h *DataExportHandler) ListMyExports(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	h.listExports(c, userID, false)
+}
+
+// GetMyExport handles GET /api/v1/users/me/data-export/:id
+func (h *DataExportHandler) GetMyExport(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	jobID, ok := h.parseID(c, "invalid_data_export_id", "Invalid data export ID format")
+	if !ok {
+		return
+	}
+
+	job, err := h.exportService.GetExport(c.Request.Context(), userID, jobID)
+	if err != nil {
+		h.respondDataExportError(c, err, "Failed to retrieve data export")
+		return
+	}
+
+	c.JSON(http.StatusOK, toDataExportDTO(job, false))
+}
+
+// CreateDownloadLink handles POST /api/v1/users/me/data-export/:id/download-link
+// Issues a short-lived link to a completed export's archive. Creating a link invalidates
+// the earlier ones.
+func (h *DataExportHandler) CreateDownloadLink(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	jobID, ok := h.parseID(c, "invalid_data_export_id", "Invalid data export ID format")
+	if !ok {
+		return
+	}
+
+	link, err := h.exportService.CreateDownloadLink(c.Request.Context(), userID, jobID)
+	if err != nil {
+		h.respondDataExportError(c, err, "Failed to create download link")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, DataExportDownloadLinkResponse{
+		DownloadURL: dataExportDownloadPath + "?token=" + url.QueryEscape(link.Token),
+		ExpiresAt:   link.ExpiresAt,
+	})
+}
+
+// Download handles GET /api/v1/data-exports/download?token=...
+// Streams the archive a download link was issued for. No authentication: the link is the credential.
+func (h *DataExportHandler) Download(c *gin.Context) {
+	job, content, err := h.exportService.OpenDownload(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		h.respondDataExportError(c, err, "Failed to open data export")
+		return
+	}
+	defer content.Close()
+
+	var size int64 = -1
+	if job.SizeBytes != nil {
+		size = *job.SizeBytes
+	}
+	if job.SHA256 != nil {
+		c.Header("X-Content-SHA256", *job.SHA256)
+	}
+	c.Header("Cache-Control", "no-store")
+	c.DataFromReader(http.StatusOK, size, "application/zip", content, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=\"pandora-data-export-%s.zip\"", job.ID),
+	})
+}
+
+// GetSigningKey handles GET /api/v1/data-exports/signing-key
+// Returns the public key archive manifests are signed with, to verify downloaded archives.
+func (h *DataExportHandler) GetSigningKey(c *gin.Context) {
+	publicKey := h.exportService.PublicKey()
+	c.JSON(http.StatusOK, DataExportSigningKeyResponse{
+		Algorithm: dataexport.SignatureAlgorithm,
+		KeyID:     dataexport.KeyID(publicKey),
+		PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+	})
+}
+
+// RequestUserExport handles POST /admin/users/:id/data-export
+// Queues an export of a user's data on their behalf, e.g. for a request received by post.
+// The user downloads the archive from their account.
+func (h *DataExportHandler) RequestUserExport(c *gin.Context) {
+	userID, ok := h.parseID(c, "invalid_user_id", "Invalid user ID format")
+	if !ok {
+		return
+	}
+
+	actor := GetAdminActorFromContext(c)
+	h.logger.WithFields(map[string]interface{}{
+		"user_id": userID.String(),
+		"actor":   actor,
+	}).Info("Admin: Processing data export request")
+
+	job, err := h.exportService.RequestExport(c.Request.Context(), userID, dataexport.Requester{
+		Type: audit.ActorAdmin,
+		ID:   actor,
+	})
+	if err != nil {
+		h.respondDataExportError(c, err, "Failed to request data export")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toDataExportDTO(job, true))
+}
+
+// ListUserExports handles GET /admin/users/:id/data-exports
+// Lists a user's exports, newest first, with failure details.
+func (h *DataExportHandler) ListUserExports(c *gin.Context) {
+	userID, ok := h.parseID(c, "invalid_user_id", "Invalid user ID format")
+	if !ok {
+		return
+	}
+	h.listExports(c, userID, true)
+}
+
+// listExports responds with a page of userID's exports
+func (h *DataExportHandler) listExports(c *gin.Context, userID uuid.UUID, admin bool) {
+	var req ListDataExportsRequest
+	req.Limit = 20 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid list data exports request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	jobs, err := h.exportService.ListExports(c.Request.Context(), userID, int32(req.Limit), int32(req.Offset)) // #nosec G115 -- bounded by binding (max=100)
+	if err != nil {
+		h.respondDataExportError(c, err, "Failed to retrieve data exports")
+		return
+	}
+
+	dtos := make([]DataExportDTO, len(jobs))
+	for i, job := range jobs {
+		dtos[i] = toDataExportDTO(job, admin)
+	}
+
+	c.JSON(http.StatusOK, DataExportsListResponse{
+		Exports: dtos,
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+	})
+}
+
+// currentUserID reads the authenticated user, responding with 401 if there is none
+func (h *DataExportHandler) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// parseID reads the :id path parameter, responding with 400 if it is not a UUID
+func (h *DataExportHandler) parseID(c *gin.Context, code, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   code,
+			Message: message,
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondDataExportError maps data export domain errors to HTTP responses
+func (h *DataExportHandler) respondDataExportError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, user.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "user_not_found",
+			Message: "User not found",
+		})
+	case errors.Is(err, dataexport.ErrJobNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "data_export_not_found",
+			Message: "Data export not found",
+		})
+	case errors.Is(err, dataexport.ErrExportInProgress):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "data_export_in_progress",
+			Message: "A data export is already in progress",
+		})
+	case errors.Is(err, dataexport.ErrNotReady):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "data_export_not_ready",
+			Message: "Data export has not completed yet",
+		})
+	case errors.Is(err, dataexport.ErrExpired):
+		c.JSON(http.StatusGone, ErrorResponse{
+			Error:   "data_export_expired",
+			Message: "Data export has expired; request a new one",
+		})
+	case errors.Is(err, dataexport.ErrInvalidDownloadToken):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "invalid_download_link",
+			Message: "Download link is invalid or has expired",
+		})
+	default:
+		h.logger.WithError(err).Error(message)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: message,
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/dataexport"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	httpTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/http"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDataExportService is a mock implementation of dataexport.Service
+type MockDataExportService struct {
+	mock.Mock
+}
+
+func (m *MockDataExportService) RequestExport(ctx context.Context, userID uuid.UUID, requester dataexport.Requester) (*dataexport.Job, error) {
+	args := m.Called(ctx, userID, requester)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dataexport.Job), args.Error(1)
+}
+
+func (m *MockDataExportService) GetExport(ctx context.Context, userID, jobID uuid.UUID) (*dataexport.Job, error) {
+	args := m.Called(ctx, userID, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dataexport.Job), args.Error(1)
+}
+
+func (m *MockDataExportService) ListExports(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*dataexport.Job, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dataexport.Job), args.Error(1)
+}
+
+func (m *MockDataExportService) CreateDownloadLink(ctx context.Context, userID, jobID uuid.UUID) (*dataexport.DownloadLink, error) {
+	args := m.Called(ctx, userID, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dataexport.DownloadLink), args.Error(1)
+}
+
+func (m *MockDataExportService) OpenDownload(ctx context.Context, token string) (*dataexport.Job, io.ReadCloser, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*dataexport.Job), args.Get(1).(io.ReadCloser), args.Error(2)
+}
+
+func (m *MockDataExportService) PublicKey() ed25519.PublicKey {
+	return m.Called().Get(0).(ed25519.PublicKey)
+}
+
+func newDataExportTestRouter(svc *MockDataExportService, userID uuid.UUID) *gin.Engine {
+	handler := httpTransport.NewDataExportHandler(svc, getTestLogger())
+
+	router := gin.New()
+	router.GET("/api/v1/data-exports/download", handler.Download)
+	router.GET("/api/v1/data-exports/signing-key", handler.GetSigningKey)
+
+	authenticated := router.Group("/")
+	authenticated.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("email", "admin@test.com")
+		c.Next()
+	})
+	authenticated.POST("/api/v1/users/me/data-export", handler.RequestMyExport)
+	authenticated.GET("/api/v1/users/me/data-export", handler.ListMyExports)
+	authenticated.GET("/api/v1/users/me/data-export/:id", handler.GetMyExport)
+	authenticated.POST("/api/v1/users/me/data-export/:id/download-link", handler.CreateDownloadLink)
+	authenticated.POST("/admin/users/:id/data-export", handler.RequestUserExport)
+	authenticated.GET("/admin/users/:id/data-exports", handler.ListUserExports)
+	return router
+}
+
+func sampleDataExport(userID uuid.UUID, status dataexport.Status) *dataexport.Job {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	lastError := "failed to list sessions for data export: connection reset"
+	return &dataexport.Job{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Status:        status,
+		RequestedBy:   "admin@test.com",
+		RequesterType: audit.ActorAdmin,
+		LastError:     &lastError,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// TestRequestMyDataExport tests the RequestMyExport HTTP handler
+func TestRequestMyDataExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+
+	t.Run("queues an export for the user", func(t *testing.T) {
+		mockService := new(MockDataExportService)
+		mockService.On("RequestExport", mock.Anything, userID, dataexport.Requester{Type: audit.ActorUser, ID: userID.String()}).
+			Return(sampleDataExport(userID, dataexport.StatusPending), nil)
+		router := newDataExportTestRouter(mockService, userID)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/users/me/data-export", nil))
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var response httpTransport.DataExportDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "pending", response.Status)
+		assert.Nil(t, response.LastError, "failure details are for admins")
+		assert.Empty(t, response.RequestedBy)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("one export at a time", func(t *testing.T) {
+		mockService := new(MockDataExportService)
+		mockService.On("RequestExport", mock.Anything, userID, mock.Anything).Return(nil, dataexport.ErrExportInProgress)
+		router := newDataExportTestRouter(mockService, userID)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/users/me/data-export", nil))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "data_export_in_progress")
+	})
+}
+
+// TestGetMyDataExport tests the GetMyExport HTTP handler
+func TestGetMyDataExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		id         string
+		err        error
+		wantStatus int
+	}{
+		{"found", uuid.New().String(), nil, http.StatusOK},
+		{"not found", uuid.New().String(), dataexport.ErrJobNotFound, http.StatusNotFound},
+		{"invalid id", "not-a-uuid", nil, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockDataExportService)
+			if tt.err != nil {
+				mockService.On("GetExport", mock.Anything, userID, uuid.MustParse(tt.id)).Return(nil, tt.err)
+			} else if tt.wantStatus == http.StatusOK {
+				mockService.On("GetExport", mock.Anything, userID, uuid.MustParse(tt.id)).
+					Return(sampleDataExport(userID, dataexport.StatusCompleted), nil)
+			}
+			router := newDataExportTestRouter(mockService, userID)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/users/me/data-export/"+tt.id, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestCreateDataExportDownloadLink tests the CreateDownloadLink HTTP handler
+func TestCreateDataExportDownloadLink(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	jobID := uuid.New()
+	path := fmt.Sprintf("/api/v1/users/me/data-export/%s/download-link", jobID)
+
+	t.Run("returns a download URL", func(t *testing.T) {
+		expiresAt := time.Date(2025, 11, 8, 12, 15, 0, 0, time.UTC)
+		mockService := new(MockDataExportService)
+		mockService.On("CreateDownloadLink", mock.Anything, userID, jobID).
+			Return(&dataexport.DownloadLink{JobID: jobID, Token: "tok_abc-123", ExpiresAt: expiresAt}, nil)
+		router := newDataExportTestRouter(mockService, userID)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		var response httpTransport.DataExportDownloadLinkResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "/api/v1/data-exports/download?token=tok_abc-123", response.DownloadURL)
+		assert.Equal(t, expiresAt, response.ExpiresAt)
+	})
+
+	errorCases := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"not ready", dataexport.ErrNotReady, http.StatusConflict},
+		{"expired", dataexport.ErrExpired, http.StatusGone},
+		{"another user's export", dataexport.ErrJobNotFound, http.StatusNotFound},
+		{"internal error", fmt.Errorf("database down"), http.StatusInternalServerError},
+	}
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockDataExportService)
+			mockService.On("CreateDownloadLink", mock.Anything, userID, jobID).Return(nil, tt.err)
+			router := newDataExportTestRouter(mockService, userID)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+// TestDownloadDataExport tests the Download HTTP handler
+func TestDownloadDataExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("streams the archive", func(t *testing.T) {
+		job := sampleDataExport(uuid.New(), dataexport.StatusCompleted)
+		content := "PK\x03\x04archive"
+		size := int64(len(content))
+		sha := "abc123"
+		job.SizeBytes = &size
+		job.SHA256 = &sha
+		mockService := new(MockDataExportService)
+		mockService.On("OpenDownload", mock.Anything, "tok_abc").Return(job, io.NopCloser(strings.NewReader(content)), nil)
+		router := newDataExportTestRouter(mockService, uuid.New())
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/data-exports/download?token=tok_abc", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, content, w.Body.String())
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		assert.Equal(t, "abc123", w.Header().Get("X-Content-SHA256"))
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), job.ID.String()+".zip")
+	})
+
+	t.Run("invalid link", func(t *testing.T) {
+		mockService := new(MockDataExportService)
+		mockService.On("OpenDownload", mock.Anything, "").Return(nil, nil, dataexport.ErrInvalidDownloadToken)
+		router := newDataExportTestRouter(mockService, uuid.New())
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/data-exports/download", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_download_link")
+	})
+}
+
+// TestGetDataExportSigningKey tests the GetSigningKey HTTP handler
+func TestGetDataExportSigningKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	publicKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+	mockService := new(MockDataExportService)
+	mockService.On("PublicKey").Return(publicKey)
+	router := newDataExportTestRouter(mockService, uuid.New())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/data-exports/signing-key", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response httpTransport.DataExportSigningKeyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "ed25519", response.Algorithm)
+	assert.Equal(t, dataexport.KeyID(publicKey), response.KeyID)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(publicKey), response.PublicKey)
+}
+
+// TestRequestUserDataExport tests the admin RequestUserExport HTTP handler
+func TestRequestUserDataExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+
+	t.Run("queues an export on the user's behalf", func(t *testing.T) {
+		mockService := new(MockDataExportService)
+		mockService.On("RequestExport", mock.Anything, userID, dataexport.Requester{Type: audit.ActorAdmin, ID: "admin@test.com"}).
+			Return(sampleDataExport(userID, dataexport.StatusPending), nil)
+		router := newDataExportTestRouter(mockService, uuid.New())
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/users/"+userID.String()+"/data-export", nil))
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var response httpTransport.DataExportDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "admin@test.com", response.RequestedBy)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		mockService := new(MockDataExportService)
+		mockService.On("RequestExport", mock.Anything, userID, mock.Anything).Return(nil, user.ErrNotFound)
+		router := newDataExportTestRouter(mockService, uuid.New())
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/users/"+userID.String()+"/data-export", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+// TestListUserDataExports tests the admin ListUserExports HTTP handler
+func TestListUserDataExports(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	mockService := new(MockDataExportService)
+	mockService.On("ListExports", mock.Anything, userID, int32(10), int32(0)).
+		Return([]*dataexport.Job{sampleDataExport(userID, dataexport.StatusFailed)}, nil)
+	router := newDataExportTestRouter(mockService, uuid.New())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/users/"+userID.String()+"/data-exports?limit=10", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response httpTransport.DataExportsListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Exports, 1)
+	require.NotNil(t, response.Exports[0].LastError, "admins see failure details")
+	assert.Equal(t, 10, response.Limit)
+	mockService.AssertExpectations(t)
+}
@@ -2,10 +2,19 @@
 package http
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/dataexport"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/replay"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
 	"github.com/google/uuid"
 )
 
@@ -33,17 +42,42 @@ type LogoutRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
 }
 
-// UpdateKYCRequest represents the request body for updating KYC status.
-type UpdateKYCRequest struct {
-	Status string `json:"status" binding:"required,oneof=none pending approved rejected" example:"approved"`
-}
-
 // UpdateProfileRequest represents the request body for updating user profile.
+// Deprecated: used by PUT /users/me, which requires both names; use PatchProfileRequest.
 type UpdateProfileRequest struct {
 	FirstName string `json:"first_name" binding:"required" example:"John"`
 	LastName  string `json:"last_name" binding:"required" example:"Doe"`
 }
 
+// PatchProfileRequest represents the request body for a partial profile update.
+// Omitted (or null) fields are left unchanged and empty strings clear optional fields.
+// An address replaces the whole address; an empty address object clears it.
+type PatchProfileRequest struct {
+	FirstName    *string     `json:"first_name,omitempty" example:"John"`
+	LastName     *string     `json:"last_name,omitempty" example:"Doe"`
+	Phone        *string     `json:"phone,omitempty" example:"+40721234567"`
+	DateOfBirth  *string     `json:"date_of_birth,omitempty" example:"1990-04-12"`
+	Address      *AddressDTO `json:"address,omitempty"`
+	Nationality  *string     `json:"nationality,omitempty" example:"RO"`
+	TaxResidency *string     `json:"tax_residency,omitempty" example:"RO"`
+}
+
+// ConfirmPhoneRequest represents the request body for confirming a phone number.
+type ConfirmPhoneRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric" example:"123456"`
+}
+
+// RestoreAccountRequest represents the request body for restoring a deleted account.
+type RestoreAccountRequest struct {
+	Token string `json:"token" binding:"required" example:"q1Z3...restore-token"`
+}
+
+// PhoneVerificationResponse represents a verification code sent to the user's phone.
+type PhoneVerificationResponse struct {
+	Phone     string    `json:"phone" example:"+40721234567"`
+	ExpiresAt time.Time `json:"expires_at" example:"2025-11-12T10:10:00Z"`
+}
+
 // AuthResponse represents the response body for authentication operations.
 type AuthResponse struct {
 	AccessToken  string    `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
@@ -53,14 +87,31 @@ type AuthResponse struct {
 }
 
 // UserDTO represents a user in API responses.
+// Optional profile fields are omitted until the user provides them.
 type UserDTO struct {
-	ID        uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Email     string    `json:"email" example:"user@example.com"`
-	FirstName string    `json:"first_name" example:"John"`
-	LastName  string    `json:"last_name" example:"Doe"`
-	KYCStatus string    `json:"kyc_status" example:"approved"`
-	CreatedAt time.Time `json:"created_at" example:"2025-11-12T10:00:00Z"`
-	UpdatedAt time.Time `json:"updated_at" example:"2025-11-12T10:00:00Z"`
+	ID            uuid.UUID   `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Email         string      `json:"email" example:"user@example.com"`
+	FirstName     string      `json:"first_name" example:"John"`
+	LastName      string      `json:"last_name" example:"Doe"`
+	Phone         string      `json:"phone,omitempty" example:"+40721234567"`
+	PhoneVerified bool        `json:"phone_verified" example:"true"`
+	DateOfBirth   string      `json:"date_of_birth,omitempty" example:"1990-04-12"`
+	Address       *AddressDTO `json:"address,omitempty"`
+	Nationality   string      `json:"nationality,omitempty" example:"RO"`
+	TaxResidency  string      `json:"tax_residency,omitempty" example:"RO"`
+	KYCStatus     string      `json:"kyc_status" example:"verified"`
+	CreatedAt     time.Time   `json:"created_at" example:"2025-11-12T10:00:00Z"`
+	UpdatedAt     time.Time   `json:"updated_at" example:"2025-11-12T10:00:00Z"`
+}
+
+// AddressDTO represents a residential address.
+type AddressDTO struct {
+	Line1      string `json:"line1" example:"Strada Lipscani 10"`
+	Line2      string `json:"line2,omitempty" example:"Apartment 4"`
+	City       string `json:"city" example:"Bucharest"`
+	PostalCode string `json:"postal_code" example:"030031"`
+	Region     string `json:"region,omitempty" example:"Bucuresti"`
+	Country    string `json:"country" example:"RO"`
 }
 
 // SessionDTO represents an active session in API responses.
@@ -91,15 +142,42 @@ type MessageResponse struct {
 
 // toUserDTO converts a domain User to a UserDTO.
 func toUserDTO(user *user.User) UserDTO {
-	return UserDTO{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		KYCStatus: user.KYCStatus.String(),
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+	dto := UserDTO{
+		ID:            user.ID,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Phone:         user.Phone,
+		PhoneVerified: user.IsPhoneVerified(),
+		DateOfBirth:   user.DateOfBirth,
+		Nationality:   user.Nationality,
+		TaxResidency:  user.TaxResidency,
+		KYCStatus:     user.KYCStatus.String(),
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
 	}
+	if !user.Address.IsZero() {
+		address := AddressDTO(user.Address)
+		dto.Address = &address
+	}
+	return dto
+}
+
+// toProfileUpdate converts a PatchProfileRequest to a domain ProfileUpdate.
+func (r PatchProfileRequest) toProfileUpdate() user.ProfileUpdate {
+	update := user.ProfileUpdate{
+		FirstName:    r.FirstName,
+		LastName:     r.LastName,
+		Phone:        r.Phone,
+		DateOfBirth:  r.DateOfBirth,
+		Nationality:  r.Nationality,
+		TaxResidency: r.TaxResidency,
+	}
+	if r.Address != nil {
+		address := user.Address(*r.Address)
+		update.Address = &address
+	}
+	return update
 }
 
 // toSessionDTO converts a domain RefreshToken to a SessionDTO.
@@ -122,10 +200,24 @@ type AdminListUsersRequest struct {
 }
 
 // AdminSearchUsersRequest represents query parameters for searching users (admin).
+// Every parameter is optional; cursor is the next_cursor of the previous page.
 type AdminSearchUsersRequest struct {
-	Query  string `form:"query" binding:"required,min=1"`
-	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
-	Offset int    `form:"offset" binding:"omitempty,min=0"`
+	Query       string     `form:"query" binding:"omitempty,max=200"`
+	Role        string     `form:"role" binding:"omitempty,oneof=user admin"`
+	KYCStatus   string     `form:"kyc_status" binding:"omitempty,oneof=pending verified rejected"`
+	Status      string     `form:"status" binding:"omitempty,oneof=active suspended frozen_withdrawals closed"`
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Deleted     string     `form:"deleted" binding:"omitempty,oneof=exclude only include"`
+	Sort        string     `form:"sort" binding:"omitempty,oneof=relevance created_at_desc created_at_asc"`
+	Cursor      string     `form:"cursor"`
+	Limit       int        `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// AdminUserSearchResponse represents a page of admin user search results.
+type AdminUserSearchResponse struct {
+	Users      []AdminUserDTO `json:"users"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 // AdminUserDTO represents detailed user information for admin panel.
@@ -140,6 +232,11 @@ type AdminUserDTO struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	Status          string     `json:"status"`
+	StatusReason    string     `json:"status_reason,omitempty"`
+	StatusExpiresAt *time.Time `json:"status_expires_at,omitempty"`
+	StatusSetBy     *uuid.UUID `json:"status_set_by,omitempty"`
+	StatusSetAt     *time.Time `json:"status_set_at,omitempty"`
 }
 
 // AdminUsersListResponse represents the response for list users endpoint.
@@ -150,6 +247,585 @@ type AdminUsersListResponse struct {
 	Offset int           `json:"offset"`
 }
 
+// AuditArchiveDTO represents an archived audit log month (admin).
+type AuditArchiveDTO struct {
+	Month         string     `json:"month" example:"2024-01"`
+	PartitionName string     `json:"partition_name" example:"audit_logs_y2024m01"`
+	ObjectKey     string     `json:"object_key"`
+	ManifestKey   string     `json:"manifest_key"`
+	RowCount      int64      `json:"row_count"`
+	SizeBytes     int64      `json:"size_bytes"`
+	Checksum      string     `json:"checksum_sha256"`
+	ArchivedAt    time.Time  `json:"archived_at"`
+	Restored      bool       `json:"restored"`
+	RestoredAt    *time.Time `json:"restored_at,omitempty"`
+	RestoredBy    *string    `json:"restored_by,omitempty"`
+}
+
+// AuditArchivesListResponse represents the response for list audit archives endpoint.
+type AuditArchivesListResponse struct {
+	Archives []AuditArchiveDTO `json:"archives"`
+	Limit    int               `json:"limit"`
+	Offset   int               `json:"offset"`
+}
+
+// CreateLegalHoldRequest represents the request body for placing a legal hold (admin).
+// At least one of user_id, starts_at, ends_at and event_category must be set.
+type CreateLegalHoldRequest struct {
+	UserID        *uuid.UUID `json:"user_id,omitempty"`
+	StartsAt      *time.Time `json:"starts_at,omitempty" example:"2024-01-01T00:00:00Z"`
+	EndsAt        *time.Time `json:"ends_at,omitempty" example:"2024-07-01T00:00:00Z"`
+	EventCategory *string    `json:"event_category,omitempty" example:"authentication"`
+	Reason        string     `json:"reason" binding:"required" example:"Regulator inquiry 2024-17"`
+	Owner         string     `json:"owner" binding:"required" example:"legal@pandora-exchange.com"`
+	ExpiresAt     time.Time  `json:"expires_at" binding:"required" example:"2026-01-01T00:00:00Z"`
+}
+
+// UpdateLegalHoldRequest represents the request body for changing the terms of a legal hold (admin).
+type UpdateLegalHoldRequest struct {
+	Reason    string    `json:"reason" binding:"required"`
+	Owner     string    `json:"owner" binding:"required"`
+	ExpiresAt time.Time `json:"expires_at" binding:"required"`
+}
+
+// ReleaseLegalHoldRequest represents the request body for releasing a legal hold (admin).
+type ReleaseLegalHoldRequest struct {
+	Reason string `json:"reason" binding:"required" example:"Inquiry closed"`
+}
+
+// ListLegalHoldsRequest represents query parameters for listing legal holds (admin).
+type ListLegalHoldsRequest struct {
+	Active bool `form:"active"`
+	Limit  int  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int  `form:"offset" binding:"omitempty,min=0"`
+}
+
+// LegalHoldDTO represents a legal hold (admin).
+type LegalHoldDTO struct {
+	ID            uuid.UUID  `json:"id"`
+	UserID        *uuid.UUID `json:"user_id,omitempty"`
+	StartsAt      *time.Time `json:"starts_at,omitempty"`
+	EndsAt        *time.Time `json:"ends_at,omitempty"`
+	EventCategory *string    `json:"event_category,omitempty"`
+	Reason        string     `json:"reason"`
+	Owner         string     `json:"owner"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	Active        bool       `json:"active"`
+	CreatedBy     string     `json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	ReleasedAt    *time.Time `json:"released_at,omitempty"`
+	ReleasedBy    *string    `json:"released_by,omitempty"`
+	ReleaseReason *string    `json:"release_reason,omitempty"`
+}
+
+// LegalHoldsListResponse represents the response for list legal holds endpoint.
+type LegalHoldsListResponse struct {
+	Holds  []LegalHoldDTO `json:"holds"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// ListAlertsRequest represents query parameters for listing security alerts (admin).
+type ListAlertsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=open acknowledged"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// AcknowledgeAlertRequest represents the request body for acknowledging a security alert (admin).
+type AcknowledgeAlertRequest struct {
+	Note *string `json:"note,omitempty" binding:"omitempty,max=2000" example:"Source IP blocked at the WAF"`
+}
+
+// AlertEventDTO represents an event that triggered a security alert (admin).
+type AlertEventDTO struct {
+	Type       string            `json:"type"`
+	Severity   string            `json:"severity,omitempty"`
+	UserID     string            `json:"user_id,omitempty"`
+	IPAddress  string            `json:"ip_address,omitempty"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// AlertDTO represents a security alert (admin).
+type AlertDTO struct {
+	ID             uuid.UUID       `json:"id"`
+	RuleName       string          `json:"rule_name"`
+	Severity       string          `json:"severity"`
+	Summary        string          `json:"summary"`
+	GroupBy        string          `json:"group_by,omitempty"`
+	GroupValue     string          `json:"group_value,omitempty"`
+	DedupKey       string          `json:"dedup_key"`
+	EventCount     int             `json:"event_count"`
+	FireCount      int             `json:"fire_count"`
+	FirstSeenAt    time.Time       `json:"first_seen_at"`
+	LastSeenAt     time.Time       `json:"last_seen_at"`
+	Events         []AlertEventDTO `json:"events,omitempty"`
+	Status         string          `json:"status"`
+	CreatedAt      time.Time       `json:"created_at"`
+	AcknowledgedAt *time.Time      `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy *string         `json:"acknowledged_by,omitempty"`
+	AckNote        *string         `json:"ack_note,omitempty"`
+}
+
+// AlertsListResponse represents the response for list alerts endpoint.
+type AlertsListResponse struct {
+	Alerts []AlertDTO `json:"alerts"`
+	Limit  int        `json:"limit"`
+	Offset int        `json:"offset"`
+}
+
+// CreateReplayJobRequest represents the request body for queueing an event replay job (admin).
+type CreateReplayJobRequest struct {
+	Source       string     `json:"source" binding:"required,oneof=snapshot outbox audit" example:"snapshot"`
+	EventTypes   []string   `json:"event_types,omitempty" example:"user.registered,user.kyc.updated"`
+	From         *time.Time `json:"from,omitempty" example:"2024-01-01T00:00:00Z"`
+	To           *time.Time `json:"to,omitempty" example:"2024-07-01T00:00:00Z"`
+	TargetStream string     `json:"target_stream" binding:"required,max=255" example:"user-service:replay:billing"`
+	RateLimit    int        `json:"rate_limit,omitempty" binding:"omitempty,min=1" example:"100"`
+	BatchSize    int        `json:"batch_size,omitempty" binding:"omitempty,min=1" example:"100"`
+}
+
+// ListReplayJobsRequest represents query parameters for listing event replay jobs (admin).
+type ListReplayJobsRequest struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ReplayJobDTO represents an event replay job and its progress (admin).
+type ReplayJobDTO struct {
+	ID               uuid.UUID  `json:"id"`
+	Source           string     `json:"source"`
+	EventTypes       []string   `json:"event_types"`
+	From             *time.Time `json:"from,omitempty"`
+	To               *time.Time `json:"to,omitempty"`
+	TargetStream     string     `json:"target_stream"`
+	RateLimit        int        `json:"rate_limit"`
+	BatchSize        int        `json:"batch_size"`
+	Status           string     `json:"status"`
+	Cursor           string     `json:"cursor,omitempty"`
+	TotalRecords     int64      `json:"total_records"`
+	ProcessedRecords int64      `json:"processed_records"`
+	PublishedEvents  int64      `json:"published_events"`
+	Progress         float64    `json:"progress"`
+	LastError        *string    `json:"last_error,omitempty"`
+	LeaseOwner       *string    `json:"lease_owner,omitempty"`
+	RequestedBy      string     `json:"requested_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	StartedAt        *time.Time `json:"started_at,omitempty"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+}
+
+// ReplayJobsListResponse represents the response for list event replay jobs endpoint.
+type ReplayJobsListResponse struct {
+	Jobs   []ReplayJobDTO `json:"jobs"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// ListDataExportsRequest represents query parameters for listing a user's data exports.
+type ListDataExportsRequest struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// DataExportDTO represents a data export and, once completed, its archive.
+// LastError is only shown to admins.
+type DataExportDTO struct {
+	ID                uuid.UUID  `json:"id"`
+	UserID            uuid.UUID  `json:"user_id"`
+	Status            string     `json:"status"`
+	RequesterType     string     `json:"requester_type"`
+	RequestedBy       string     `json:"requested_by,omitempty"`
+	LastError         *string    `json:"last_error,omitempty"`
+	SizeBytes         *int64     `json:"size_bytes,omitempty"`
+	SHA256            *string    `json:"sha256,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	DownloadExpiresAt *time.Time `json:"download_expires_at,omitempty"`
+	DownloadCount     int        `json:"download_count"`
+	LastDownloadedAt  *time.Time `json:"last_downloaded_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	StartedAt         *time.Time `json:"started_at,omitempty"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+}
+
+// DataExportsListResponse represents the response for list data exports endpoints.
+type DataExportsListResponse struct {
+	Exports []DataExportDTO `json:"exports"`
+	Limit   int             `json:"limit"`
+	Offset  int             `json:"offset"`
+}
+
+// DataExportDownloadLinkResponse represents a short-lived link to download an export archive.
+type DataExportDownloadLinkResponse struct {
+	DownloadURL string    `json:"download_url" example:"/api/v1/data-exports/download?token=..."`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// DataExportSigningKeyResponse represents the public key export archives are signed with.
+type DataExportSigningKeyResponse struct {
+	Algorithm string `json:"algorithm" example:"ed25519"`
+	KeyID     string `json:"key_id"`
+	PublicKey string `json:"public_key"`
+}
+
+// CreateWebhookRequest represents the request body for adding a webhook subscription (admin).
+// An empty secret makes the service generate one.
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required,url,max=2048" example:"https://partner.example.com/hooks/pandora"`
+	Description string   `json:"description,omitempty" binding:"max=255" example:"KYC provider callbacks"`
+	EventTypes  []string `json:"event_types,omitempty" example:"user.registered,user.kyc.updated"`
+	Secret      string   `json:"secret,omitempty" binding:"omitempty,min=16,max=255"`
+}
+
+// UpdateWebhookRequest represents the request body for changing a webhook subscription (admin).
+// Omitted fields are left as they are.
+type UpdateWebhookRequest struct {
+	URL          *string   `json:"url,omitempty" binding:"omitempty,url,max=2048"`
+	Description  *string   `json:"description,omitempty" binding:"omitempty,max=255"`
+	EventTypes   *[]string `json:"event_types,omitempty"`
+	Enabled      *bool     `json:"enabled,omitempty"`
+	RotateSecret bool      `json:"rotate_secret,omitempty"`
+}
+
+// ListWebhooksRequest represents query parameters for listing webhook subscriptions (admin).
+type ListWebhooksRequest struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ListWebhookDeliveriesRequest represents query parameters for a subscription's delivery log (admin).
+type ListWebhookDeliveriesRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending succeeded failed"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// WebhookSubscriptionDTO represents a webhook subscription (admin).
+// Secret is only set when the subscription is created or its secret is rotated.
+type WebhookSubscriptionDTO struct {
+	ID                  uuid.UUID  `json:"id"`
+	URL                 string     `json:"url"`
+	Description         string     `json:"description"`
+	EventTypes          []string   `json:"event_types"`
+	Secret              string     `json:"secret,omitempty"`
+	Enabled             bool       `json:"enabled"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason      *string    `json:"disabled_reason,omitempty"`
+	CreatedBy           string     `json:"created_by"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// WebhookSubscriptionsListResponse represents the response for list webhook subscriptions endpoint.
+type WebhookSubscriptionsListResponse struct {
+	Subscriptions []WebhookSubscriptionDTO `json:"subscriptions"`
+	Limit         int                      `json:"limit"`
+	Offset        int                      `json:"offset"`
+}
+
+// WebhookDeliveryDTO represents one entry of a subscription's delivery log (admin).
+type WebhookDeliveryDTO struct {
+	ID             uuid.UUID  `json:"id"`
+	SubscriptionID uuid.UUID  `json:"subscription_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	LastStatusCode *int       `json:"last_status_code,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// WebhookDeliveriesListResponse represents the response for list webhook deliveries endpoint.
+type WebhookDeliveriesListResponse struct {
+	Deliveries []WebhookDeliveryDTO `json:"deliveries"`
+	Limit      int                  `json:"limit"`
+	Offset     int                  `json:"offset"`
+}
+
+// KYCApplicantRequest represents the applicant data of a KYC case (user).
+// Drafts may leave fields empty; all but address_line2 are required to submit.
+type KYCApplicantRequest struct {
+	FirstName          string `json:"first_name" binding:"max=200" example:"Jane"`
+	LastName           string `json:"last_name" binding:"max=200" example:"Doe"`
+	DateOfBirth        string `json:"date_of_birth" binding:"omitempty,datetime=2006-01-02" example:"1990-04-12"`
+	Nationality        string `json:"nationality" binding:"omitempty,len=2" example:"DE"`
+	CountryOfResidence string `json:"country_of_residence" binding:"omitempty,len=2" example:"DE"`
+	AddressLine1       string `json:"address_line1" binding:"max=200" example:"Hauptstrasse 1"`
+	AddressLine2       string `json:"address_line2,omitempty" binding:"max=200"`
+	City               string `json:"city" binding:"max=200" example:"Berlin"`
+	PostalCode         string `json:"postal_code" binding:"max=200" example:"10115"`
+}
+
+// ListKYCCasesRequest represents query parameters for the KYC review queue (admin).
+type ListKYCCasesRequest struct {
+	Status     string `form:"status" binding:"omitempty,oneof=draft submitted in_review approved rejected needs_more_info"`
+	ReviewerID string `form:"reviewer_id" binding:"omitempty,uuid"`
+	RiskLevel  string `form:"risk_level" binding:"omitempty,oneof=low medium high"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset     int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// AssignKYCCaseRequest represents the request body for assigning a KYC case (admin).
+// An empty reviewer_id assigns the case to the acting admin.
+type AssignKYCCaseRequest struct {
+	ReviewerID string `json:"reviewer_id,omitempty" binding:"omitempty,uuid"`
+}
+
+// SetKYCRiskLevelRequest represents the request body for a KYC case's risk assessment (admin).
+type SetKYCRiskLevelRequest struct {
+	RiskLevel string `json:"risk_level" binding:"required,oneof=low medium high" example:"high"`
+}
+
+// KYCDecisionRequest represents the request body for approving a KYC case or requesting
+// more information (admin). The note is required when requesting information.
+type KYCDecisionRequest struct {
+	Note string `json:"note,omitempty" binding:"max=2000" example:"Proof of address is older than three months"`
+}
+
+// RejectKYCCaseRequest represents the request body for rejecting a KYC case (admin).
+type RejectKYCCaseRequest struct {
+	Reason string `json:"reason" binding:"required,max=50" example:"document_expired"`
+	Note   string `json:"note,omitempty" binding:"max=2000"`
+}
+
+// KYCDocumentDTO represents a document uploaded to a KYC case.
+type KYCDocumentDTO struct {
+	ID          uuid.UUID `json:"id"`
+	Type        string    `json:"type"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	SHA256      string    `json:"sha256"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// KYCCaseDTO represents a KYC case. Reviewer identities are only included for admins.
+
+type KYCCaseDTO struct {
+	ID                  uuid.UUID           `json:"id"`
+	UserID              uuid.UUID           `json:"user_id"`
+	Status              string              `json:"status"`
+	RiskLevel           string              `json:"risk_level,omitempty"`
+	Applicant           KYCApplicantRequest `json:"applicant"`
+	ReviewerID          *uuid.UUID          `json:"reviewer_id,omitempty"`
+	FirstApproverID     *uuid.UUID          `json:"first_approver_id,omitempty"`
+	FirstApprovedAt     *time.Time          `json:"first_approved_at,omitempty"`
+	DecidedBy           *uuid.UUID          `json:"decided_by,omitempty"`
+	DecidedAt           *time.Time          `json:"decided_at,omitempty"`
+	RejectionReason     *string             `json:"rejection_reason,omitempty"`
+	ReviewNote          *string             `json:"review_note,omitempty"`
+	Provider            *string             `json:"provider,omitempty"`
+	ProviderApplicantID *string             `json:"provider_applicant_id,omitempty"`
+	SubmittedAt         *time.Time          `json:"submitted_at,omitempty"`
+	Documents           []KYCDocumentDTO    `json:"documents,omitempty"`
+	CreatedAt           time.Time           `json:"created_at"`
+	UpdatedAt           time.Time           `json:"updated_at"`
+}
+
+// KYCCasesListResponse represents the response for the KYC review queue endpoint.
+type KYCCasesListResponse struct {
+	Cases  []KYCCaseDTO `json:"cases"`
+	Total  int64        `json:"total"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+}
+
+// KYCTransitionDTO represents one entry of a KYC case's history (admin).
+type KYCTransitionDTO struct {
+	ID         uuid.UUID `json:"id"`
+	Action     string    `json:"action"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ActorID    uuid.UUID `json:"actor_id"`
+	ReasonCode *string   `json:"reason_code,omitempty"`
+	Note       *string   `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// KYCHistoryResponse represents the response for a KYC case's history endpoint.
+type KYCHistoryResponse struct {
+	Transitions []KYCTransitionDTO `json:"transitions"`
+}
+
+// SetKYCTierRequest represents the request body for assigning a KYC tier (admin).
+type SetKYCTierRequest struct {
+	Tier   *int   `json:"tier" binding:"required,min=0,max=3" example:"2"`
+	Reason string `json:"reason" binding:"required,max=500" example:"Source of funds verified"`
+}
+
+// EntitlementsResponse represents what a user is allowed to do.
+// Tier is the effective tier: 0 while the user is not verified, whatever tier they hold.
+type EntitlementsResponse struct {
+	UserID               uuid.UUID  `json:"user_id"`
+	Tier                 int        `json:"tier"`
+	HeldTier             int        `json:"held_tier"`
+	TierName             string     `json:"tier_name"`
+	KYCStatus            string     `json:"kyc_status"`
+	Active               bool       `json:"active"`
+	Currency             string     `json:"currency"`
+	DailyDepositLimit    *string    `json:"daily_deposit_limit"`
+	DailyWithdrawalLimit *string    `json:"daily_withdrawal_limit"`
+	Products             []string   `json:"products"`
+	Jurisdictions        []string   `json:"jurisdictions"`
+	UpdatedAt            *time.Time `json:"updated_at,omitempty"`
+}
+
+// KYCTierChangeDTO represents one entry of a user's tier history (admin).
+type KYCTierChangeDTO struct {
+	ID        uuid.UUID  `json:"id"`
+	OldTier   int        `json:"old_tier"`
+	NewTier   int        `json:"new_tier"`
+	Reason    string     `json:"reason"`
+	ChangedBy *uuid.UUID `json:"changed_by,omitempty"`
+	CaseID    *uuid.UUID `json:"case_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// KYCTierHistoryResponse represents the response for a user's tier history endpoint.
+type KYCTierHistoryResponse struct {
+	Changes []KYCTierChangeDTO `json:"changes"`
+}
+
+// ListScreeningCasesRequest represents query parameters for the screening review queue (admin).
+type ListScreeningCasesRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=open cleared confirmed"`
+	UserID string `form:"user_id" binding:"omitempty,uuid"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ScreeningDecisionRequest represents the request body for clearing or confirming a
+// screening case (admin).
+type ScreeningDecisionRequest struct {
+	Note string `json:"note" binding:"required,max=2000" example:"Different date of birth and nationality"`
+}
+
+// ScreeningMatchDTO represents a watch list entry a user matched.
+type ScreeningMatchDTO struct {
+	Source      string   `json:"source"`
+	EntryID     string   `json:"entry_id"`
+	Kind        string   `json:"kind"`
+	ListedName  string   `json:"listed_name"`
+	MatchedName string   `json:"matched_name"`
+	Score       float64  `json:"score"`
+	DOBMatched  bool     `json:"dob_matched"`
+	Programs    []string `json:"programs,omitempty"`
+}
+
+// ScreeningCaseDTO represents a screening case (admin).
+type ScreeningCaseDTO struct {
+	ID          uuid.UUID           `json:"id"`
+	UserID      uuid.UUID           `json:"user_id"`
+	Status      string              `json:"status"`
+	Trigger     string              `json:"trigger"`
+	Names       []string            `json:"names"`
+	DateOfBirth string              `json:"date_of_birth,omitempty"`
+	Matches     []ScreeningMatchDTO `json:"matches"`
+	ListVersion string              `json:"list_version"`
+	ReviewedBy  *uuid.UUID          `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time          `json:"reviewed_at,omitempty"`
+	ReviewNote  *string             `json:"review_note,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// ScreeningCasesListResponse represents the response for the screening review queue endpoint.
+type ScreeningCasesListResponse struct {
+	Cases  []ScreeningCaseDTO `json:"cases"`
+	Total  int64              `json:"total"`
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+}
+
+// ScreenUserResponse represents the result of screening a user on demand (admin).
+// Case is nil when the user matched no entry that was not reviewed for them before.
+type ScreenUserResponse struct {
+	Case *ScreeningCaseDTO `json:"case"`
+}
+
+// WatchListsResponse represents the watch lists users are currently screened against.
+type WatchListsResponse struct {
+	Version  string         `json:"version"`
+	LoadedAt time.Time      `json:"loaded_at"`
+	Entries  map[string]int `json:"entries"`
+}
+
+// KYCVerificationLinkResponse represents where the applicant completes verification with
+// the external provider.
+type KYCVerificationLinkResponse struct {
+	Provider  string    `json:"provider" example:"simulator"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// KYCProviderCheckDTO represents one check the external provider ran.
+type KYCProviderCheckDTO struct {
+	Name   string `json:"name" example:"liveness"`
+	Result string `json:"result" example:"clear"`
+}
+
+// KYCProviderReportResponse represents the external provider's report on a case (admin).
+// Raw is the provider's own report.
+type KYCProviderReportResponse struct {
+	Provider    string                `json:"provider"`
+	ApplicantID string                `json:"applicant_id"`
+	Outcome     string                `json:"outcome" example:"approved"`
+	Reason      string                `json:"reason,omitempty"`
+	Checks      []KYCProviderCheckDTO `json:"checks"`
+	CompletedAt *time.Time            `json:"completed_at,omitempty"`
+	Raw         json.RawMessage       `json:"raw,omitempty"`
+}
+
+// RetentionRuleDTO represents one rule of the audit retention policy (admin).
+type RetentionRuleDTO struct {
+	EventType string `json:"event_type,omitempty"`
+	Category  string `json:"category,omitempty"`
+	Severity  string `json:"severity,omitempty"`
+	Days      int    `json:"days"`
+}
+
+// RetentionPolicyResponse represents the active audit retention policy (admin).
+// Rules are listed most specific first, which is the order they are matched in.
+type RetentionPolicyResponse struct {
+	DefaultDays int                `json:"default_days"`
+	Rules       []RetentionRuleDTO `json:"rules"`
+}
+
+// RetentionDryRunRequest represents query parameters for the retention dry run (admin).
+type RetentionDryRunRequest struct {
+	AsOf time.Time `form:"as_of" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// ExpiryBucketDTO represents expired audit logs of one category and severity (admin).
+type ExpiryBucketDTO struct {
+	Category        string    `json:"category"`
+	Severity        string    `json:"severity"`
+	DeletableCount  int64     `json:"deletable_count"`
+	HeldCount       int64     `json:"held_count"`
+	OldestCreatedAt time.Time `json:"oldest_created_at"`
+}
+
+// RetentionDryRunResponse represents what the audit cleanup job would delete (admin).
+type RetentionDryRunResponse struct {
+	AsOf           time.Time         `json:"as_of"`
+	Buckets        []ExpiryBucketDTO `json:"buckets"`
+	TotalDeletable int64             `json:"total_deletable"`
+	TotalHeld      int64             `json:"total_held"`
+}
+
+// RetentionRestampResponse represents the result of re-stamping audit log retention (admin).
+type RetentionRestampResponse struct {
+	Updated int64 `json:"updated"`
+}
+
 // AdminSessionDTO represents a user session with user details (admin).
 type AdminSessionDTO struct {
 	Token     string    `json:"token"`
@@ -181,17 +857,140 @@ type AdminUpdateRoleRequest struct {
 	Role string `json:"role" binding:"required"`
 }
 
+// AdminSetAccountStatusRequest represents the request to change a user's account status.
+type AdminSetAccountStatusRequest struct {
+	Status    string     `json:"status" binding:"required" example:"suspended"`
+	Reason    string     `json:"reason" binding:"required" example:"chargeback under investigation"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// AccountStatusChangeDTO represents an entry of a user's account status history.
+type AccountStatusChangeDTO struct {
+	ID              uuid.UUID  `json:"id"`
+	OldStatus       string     `json:"old_status"`
+	NewStatus       string     `json:"new_status"`
+	Reason          string     `json:"reason"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	ChangedBy       *uuid.UUID `json:"changed_by,omitempty"`
+	SessionsRevoked bool       `json:"sessions_revoked"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// AccountStatusHistoryResponse represents a user's account status history.
+type AccountStatusHistoryResponse struct {
+	Changes []AccountStatusChangeDTO `json:"changes"`
+}
+
+// AdminStatsRequest represents query parameters for admin statistics.
+// from and to are UTC days (YYYY-MM-DD), both inclusive; the default is the last 30 days.
+type AdminStatsRequest struct {
+	From        *time.Time `form:"from" time_format:"2006-01-02" time_utc:"1"`
+	To          *time.Time `form:"to" time_format:"2006-01-02" time_utc:"1"`
+	Granularity string     `form:"granularity" binding:"omitempty,oneof=day week"`
+}
+
+// AdminStatsExportRequest represents query parameters for exporting admin statistics as CSV.
+type AdminStatsExportRequest struct {
+	AdminStatsRequest
+	Dataset string `form:"dataset" binding:"omitempty,oneof=series kyc_funnel sessions_by_device"`
+}
+
 // AdminStatsResponse represents system statistics for admin dashboard.
 type AdminStatsResponse struct {
-	TotalUsers      int64 `json:"total_users"`
-	ActiveSessions  int64 `json:"active_sessions"`
-	VerifiedUsers   int64 `json:"verified_users,omitempty"`
-	PendingKYC      int64 `json:"pending_kyc,omitempty"`
+	From               string                  `json:"from" example:"2025-10-10"`
+	To                 string                  `json:"to" example:"2025-11-08"`
+	Granularity        string                  `json:"granularity" example:"day"`
+	TotalUsers         int64                   `json:"total_users"`
+	ActiveSessions     int64                   `json:"active_sessions"`
+	DailyActiveUsers   int64                   `json:"daily_active_users"`
+	MonthlyActiveUsers int64                   `json:"monthly_active_users"`
+	Totals             AdminStatsTotalsDTO     `json:"totals"`
+	Series             []AdminStatsBucketDTO   `json:"series"`
+	KYCFunnel          []AdminKYCFunnelDTO     `json:"kyc_funnel"`
+	SessionsByDevice   []AdminDeviceSessionDTO `json:"sessions_by_device"`
+	RefreshedAt        *time.Time              `json:"refreshed_at,omitempty"`
+}
+
+// AdminStatsTotalsDTO represents the daily counters summed over the range of admin statistics.
+type AdminStatsTotalsDTO struct {
+	Registrations int64 `json:"registrations"`
+	Logins        int64 `json:"logins"`
+	FailedLogins  int64 `json:"failed_logins"`
+	KYCApprovals  int64 `json:"kyc_approvals"`
+}
+
+// AdminStatsBucketDTO represents the statistics of a day or week of admin statistics.
+type AdminStatsBucketDTO struct {
+	PeriodStart        string `json:"period_start" example:"2025-11-03"`
+	Registrations      int64  `json:"registrations"`
+	Logins             int64  `json:"logins"`
+	FailedLogins       int64  `json:"failed_logins"`
+	KYCApprovals       int64  `json:"kyc_approvals"`
+	ActiveUsers        int64  `json:"active_users"`
+	MonthlyActiveUsers int64  `json:"monthly_active_users"`
+}
+
+// AdminKYCFunnelDTO represents the number of users at a stage of the KYC funnel.
+type AdminKYCFunnelDTO struct {
+	Status string `json:"status" example:"in_review"`
+	Users  int64  `json:"users"`
+}
+
+// AdminDeviceSessionDTO represents active sessions from a kind of device.
+type AdminDeviceSessionDTO struct {
+	Device   string `json:"device" example:"mobile"`
+	Sessions int64  `json:"sessions"`
+	Users    int64  `json:"users"`
+}
+
+// toAdminStatsResponse converts a statistics dashboard to an AdminStatsResponse.
+func toAdminStatsResponse(d *stats.Dashboard) AdminStatsResponse {
+	resp := AdminStatsResponse{
+		From:               d.Query.From.Format(time.DateOnly),
+		To:                 d.Query.To.Format(time.DateOnly),
+		Granularity:        string(d.Query.Granularity),
+		TotalUsers:         d.TotalUsers,
+		ActiveSessions:     d.ActiveSessions,
+		DailyActiveUsers:   d.DailyActiveUsers,
+		MonthlyActiveUsers: d.MonthlyActiveUsers,
+		Totals: AdminStatsTotalsDTO{
+			Registrations: d.Totals.Registrations,
+			Logins:        d.Totals.Logins,
+			FailedLogins:  d.Totals.FailedLogins,
+			KYCApprovals:  d.Totals.KYCApprovals,
+		},
+		Series:           make([]AdminStatsBucketDTO, 0, len(d.Series)),
+		KYCFunnel:        make([]AdminKYCFunnelDTO, 0, len(d.KYCFunnel)),
+		SessionsByDevice: make([]AdminDeviceSessionDTO, 0, len(d.SessionsByDevice)),
+		RefreshedAt:      d.RefreshedAt,
+	}
+	for _, b := range d.Series {
+		resp.Series = append(resp.Series, AdminStatsBucketDTO{
+			PeriodStart:        b.Start.Format(time.DateOnly),
+			Registrations:      b.Registrations,
+			Logins:             b.Logins,
+			FailedLogins:       b.FailedLogins,
+			KYCApprovals:       b.KYCApprovals,
+			ActiveUsers:        b.ActiveUsers,
+			MonthlyActiveUsers: b.MonthlyActiveUsers,
+		})
+	}
+	for _, stage := range d.KYCFunnel {
+		resp.KYCFunnel = append(resp.KYCFunnel, AdminKYCFunnelDTO{Status: stage.Status, Users: stage.Users})
+	}
+	for _, s := range d.SessionsByDevice {
+		resp.SessionsByDevice = append(resp.SessionsByDevice, AdminDeviceSessionDTO{
+			Device:   string(s.Device),
+			Sessions: s.Sessions,
+			Users:    s.Users,
+		})
+	}
+	return resp
 }
 
 // toAdminUserDTO converts a domain User to an AdminUserDTO.
 func toAdminUserDTO(user *user.User) AdminUserDTO {
-	return AdminUserDTO{
+	dto := AdminUserDTO{
 		ID:        user.ID,
 		Email:     user.Email,
 		FirstName: user.FirstName,
@@ -202,5 +1001,433 @@ func toAdminUserDTO(user *user.User) AdminUserDTO {
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 		DeletedAt: user.DeletedAt,
+		Status:          user.EffectiveStatus(time.Now()).String(),
+		StatusSetBy:     user.StatusSetBy,
+		StatusSetAt:     user.StatusSetAt,
+	}
+	if user.Status != "" && user.EffectiveStatus(time.Now()) == user.Status {
+		dto.StatusReason = user.StatusReason
+		dto.StatusExpiresAt = user.StatusExpiresAt
+	}
+	return dto
+}
+
+// toAccountStatusChangeDTO converts a domain AccountStatusChange to an AccountStatusChangeDTO.
+func toAccountStatusChangeDTO(change *user.AccountStatusChange) AccountStatusChangeDTO {
+	return AccountStatusChangeDTO{
+		ID:              change.ID,
+		OldStatus:       change.OldStatus.String(),
+		NewStatus:       change.NewStatus.String(),
+		Reason:          change.Reason,
+		ExpiresAt:       change.ExpiresAt,
+		ChangedBy:       change.ChangedBy,
+		SessionsRevoked: change.SessionsRevoked,
+		CreatedAt:       change.CreatedAt,
+	}
+}
+
+// toAuditArchiveDTO converts a domain audit Archive to an AuditArchiveDTO.
+func toAuditArchiveDTO(archive *audit.Archive) AuditArchiveDTO {
+	return AuditArchiveDTO{
+		Month:         archive.Month.Format("2006-01"),
+		PartitionName: archive.PartitionName,
+		ObjectKey:     archive.ObjectKey,
+		ManifestKey:   archive.ManifestKey,
+		RowCount:      archive.RowCount,
+		SizeBytes:     archive.SizeBytes,
+		Checksum:      archive.Checksum,
+		ArchivedAt:    archive.ArchivedAt,
+		Restored:      archive.IsRestored(),
+		RestoredAt:    archive.RestoredAt,
+		RestoredBy:    archive.RestoredBy,
+	}
+}
+
+// toLegalHoldDTO converts a domain LegalHold to a LegalHoldDTO.
+func toLegalHoldDTO(hold *audit.LegalHold) LegalHoldDTO {
+	dto := LegalHoldDTO{
+		ID:            hold.ID,
+		UserID:        hold.UserID,
+		StartsAt:      hold.StartsAt,
+		EndsAt:        hold.EndsAt,
+		Reason:        hold.Reason,
+		Owner:         hold.Owner,
+		ExpiresAt:     hold.ExpiresAt,
+		Active:        hold.IsActive(time.Now()),
+		CreatedBy:     hold.CreatedBy,
+		CreatedAt:     hold.CreatedAt,
+		UpdatedAt:     hold.UpdatedAt,
+		ReleasedAt:    hold.ReleasedAt,
+		ReleasedBy:    hold.ReleasedBy,
+		ReleaseReason: hold.ReleaseReason,
+	}
+	if hold.EventCategory != nil {
+		category := string(*hold.EventCategory)
+		dto.EventCategory = &category
+	}
+	return dto
+}
+
+// toAlertDTO converts a domain Alert to an AlertDTO.
+func toAlertDTO(a *alert.Alert) AlertDTO {
+	events := make([]AlertEventDTO, len(a.Events))
+	for i, event := range a.Events {
+		events[i] = AlertEventDTO{
+			Type:       event.Type,
+			Severity:   event.Severity,
+			UserID:     event.UserID,
+			IPAddress:  event.IPAddress,
+			OccurredAt: event.OccurredAt,
+			Attributes: event.Attributes,
+		}
+	}
+
+	return AlertDTO{
+		ID:             a.ID,
+		RuleName:       a.RuleName,
+		Severity:       string(a.Severity),
+		Summary:        a.Summary,
+		GroupBy:        string(a.GroupBy),
+		GroupValue:     a.GroupValue,
+		DedupKey:       a.DedupKey,
+		EventCount:     a.EventCount,
+		FireCount:      a.FireCount,
+		FirstSeenAt:    a.FirstSeenAt,
+		LastSeenAt:     a.LastSeenAt,
+		Events:         events,
+		Status:         string(a.Status),
+		CreatedAt:      a.CreatedAt,
+		AcknowledgedAt: a.AcknowledgedAt,
+		AcknowledgedBy: a.AcknowledgedBy,
+		AckNote:        a.AckNote,
+	}
+}
+
+// toReplayJobDTO converts a domain replay Job to a ReplayJobDTO.
+func toReplayJobDTO(job *replay.Job) ReplayJobDTO {
+	eventTypes := job.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return ReplayJobDTO{
+		ID:               job.ID,
+		Source:           string(job.Source),
+		EventTypes:       eventTypes,
+		From:             job.From,
+		To:               job.To,
+		TargetStream:     job.TargetStream,
+		RateLimit:        job.RateLimit,
+		BatchSize:        job.BatchSize,
+		Status:           string(job.Status),
+		Cursor:           string(job.Cursor),
+		TotalRecords:     job.TotalRecords,
+		ProcessedRecords: job.ProcessedRecords,
+		PublishedEvents:  job.PublishedEvents,
+		Progress:         job.Progress(),
+		LastError:        job.LastError,
+		LeaseOwner:       job.LeaseOwner,
+		RequestedBy:      job.RequestedBy,
+		CreatedAt:        job.CreatedAt,
+		UpdatedAt:        job.UpdatedAt,
+		StartedAt:        job.StartedAt,
+		CompletedAt:      job.CompletedAt,
+	}
+}
+
+// toDataExportDTO converts a domain data export Job to a DataExportDTO.
+// Failure details and the admin who requested it are only included for admins.
+func toDataExportDTO(job *dataexport.Job, admin bool) DataExportDTO {
+	dto := DataExportDTO{
+		ID:                job.ID,
+		UserID:            job.UserID,
+		Status:            string(job.Status),
+		RequesterType:     string(job.RequesterType),
+		SizeBytes:         job.SizeBytes,
+		SHA256:            job.SHA256,
+		ExpiresAt:         job.ExpiresAt,
+		DownloadExpiresAt: job.DownloadExpiresAt,
+		DownloadCount:     job.DownloadCount,
+		LastDownloadedAt:  job.LastDownloadedAt,
+		CreatedAt:         job.CreatedAt,
+		StartedAt:         job.StartedAt,
+		CompletedAt:       job.CompletedAt,
+	}
+	if admin {
+		dto.RequestedBy = job.RequestedBy
+		dto.LastError = job.LastError
+	}
+	return dto
+}
+
+// toWebhookSubscriptionDTO converts a domain webhook Subscription to a WebhookSubscriptionDTO.
+// The secret is only included when includeSecret is set.
+func toWebhookSubscriptionDTO(sub *webhook.Subscription, includeSecret bool) WebhookSubscriptionDTO {
+	eventTypes := sub.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	dto := WebhookSubscriptionDTO{
+		ID:                  sub.ID,
+		URL:                 sub.URL,
+		Description:         sub.Description,
+		EventTypes:          eventTypes,
+		Enabled:             sub.Enabled,
+		ConsecutiveFailures: sub.ConsecutiveFailures,
+		DisabledAt:          sub.DisabledAt,
+		DisabledReason:      sub.DisabledReason,
+		CreatedBy:           sub.CreatedBy,
+		CreatedAt:           sub.CreatedAt,
+		UpdatedAt:           sub.UpdatedAt,
+	}
+	if includeSecret {
+		dto.Secret = sub.Secret
+	}
+	return dto
+}
+
+// toWebhookDeliveryDTO converts a domain webhook Delivery to a WebhookDeliveryDTO.
+// Only pending deliveries report their next attempt.
+func toWebhookDeliveryDTO(d *webhook.Delivery) WebhookDeliveryDTO {
+	dto := WebhookDeliveryDTO{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastAttemptAt:  d.LastAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == webhook.DeliveryPending {
+		next := d.NextAttemptAt
+		dto.NextAttemptAt = &next
+	}
+	return dto
+}
+
+// toKYCApplicant converts a KYCApplicantRequest to a domain kyc Applicant.
+func toKYCApplicant(req KYCApplicantRequest) kyc.Applicant {
+	return kyc.Applicant{
+		FirstName:          req.FirstName,
+		LastName:           req.LastName,
+		DateOfBirth:        req.DateOfBirth,
+		Nationality:        req.Nationality,
+		CountryOfResidence: req.CountryOfResidence,
+		AddressLine1:       req.AddressLine1,
+		AddressLine2:       req.AddressLine2,
+		City:               req.City,
+		PostalCode:         req.PostalCode,
+	}
+}
+
+// toKYCCaseDTO converts a domain kyc Case to a KYCCaseDTO.
+// Risk level and reviewer identities are only included when forAdmin is set.
+func toKYCCaseDTO(c *kyc.Case, forAdmin bool) KYCCaseDTO {
+	a := c.Applicant
+	dto := KYCCaseDTO{
+		ID:     c.ID,
+		UserID: c.UserID,
+		Status: string(c.Status),
+		Applicant: KYCApplicantRequest{
+			FirstName:          a.FirstName,
+			LastName:           a.LastName,
+			DateOfBirth:        a.DateOfBirth,
+			Nationality:        a.Nationality,
+			CountryOfResidence: a.CountryOfResidence,
+			AddressLine1:       a.AddressLine1,
+			AddressLine2:       a.AddressLine2,
+			City:               a.City,
+			PostalCode:         a.PostalCode,
+		},
+		DecidedAt:       c.DecidedAt,
+		RejectionReason: (*string)(c.RejectionReason),
+		ReviewNote:      c.ReviewNote,
+		Provider:        c.Provider,
+		SubmittedAt:     c.SubmittedAt,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+	if forAdmin {
+		dto.RiskLevel = string(c.RiskLevel)
+		dto.ReviewerID = c.ReviewerID
+		dto.FirstApproverID = c.FirstApproverID
+		dto.FirstApprovedAt = c.FirstApprovedAt
+		dto.DecidedBy = c.DecidedBy
+		dto.ProviderApplicantID = c.ProviderApplicantID
+	}
+	if c.Documents != nil {
+		dto.Documents = make([]KYCDocumentDTO, len(c.Documents))
+		for i, d := range c.Documents {
+			dto.Documents[i] = toKYCDocumentDTO(d)
+		}
+	}
+	return dto
+}
+
+// toKYCDocumentDTO converts a domain kyc Document to a KYCDocumentDTO.
+func toKYCDocumentDTO(d *kyc.Document) KYCDocumentDTO {
+	return KYCDocumentDTO{
+		ID:          d.ID,
+		Type:        string(d.Type),
+		FileName:    d.FileName,
+		ContentType: d.ContentType,
+		SizeBytes:   d.SizeBytes,
+		SHA256:      d.SHA256,
+		UploadedAt:  d.UploadedAt,
+	}
+}
+
+// toKYCTransitionDTO converts a domain kyc Transition to a KYCTransitionDTO.
+func toKYCTransitionDTO(t *kyc.Transition) KYCTransitionDTO {
+	return KYCTransitionDTO{
+		ID:         t.ID,
+		Action:     string(t.Action),
+		FromStatus: string(t.FromStatus),
+		ToStatus:   string(t.ToStatus),
+		ActorID:    t.ActorID,
+		ReasonCode: (*string)(t.ReasonCode),
+		Note:       t.Note,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+// toEntitlementsResponse converts domain kyc Entitlements to an EntitlementsResponse.
+// Unlimited amounts are rendered as null.
+func toEntitlementsResponse(e *kyc.Entitlements) EntitlementsResponse {
+	limit := func(amount string) *string {
+		if amount == "" {
+			return nil
+		}
+		return &amount
+	}
+	return EntitlementsResponse{
+		UserID:               e.UserID,
+		Tier:                 int(e.Tier),
+		HeldTier:             int(e.HeldTier),
+		TierName:             e.TierName,
+		KYCStatus:            e.KYCStatus.String(),
+		Active:               e.Active,
+		Currency:             e.Currency,
+		DailyDepositLimit:    limit(e.DailyDepositLimit),
+		DailyWithdrawalLimit: limit(e.DailyWithdrawalLimit),
+		Products:             e.Products,
+		Jurisdictions:        e.Jurisdictions,
+		UpdatedAt:            e.UpdatedAt,
+	}
+}
+
+// toKYCTierChangeDTO converts a domain kyc TierChange to a KYCTierChangeDTO.
+func toKYCTierChangeDTO(c *kyc.TierChange) KYCTierChangeDTO {
+	return KYCTierChangeDTO{
+		ID:        c.ID,
+		OldTier:   int(c.OldTier),
+		NewTier:   int(c.NewTier),
+		Reason:    c.Reason,
+		ChangedBy: c.ChangedBy,
+		CaseID:    c.CaseID,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+// toKYCProviderReportResponse converts a domain kyc ProviderReport to a KYCProviderReportResponse.
+func toKYCProviderReportResponse(r *kyc.ProviderReport) KYCProviderReportResponse {
+	checks := make([]KYCProviderCheckDTO, len(r.Checks))
+	for i, check := range r.Checks {
+		checks[i] = KYCProviderCheckDTO{Name: check.Name, Result: check.Result}
+	}
+	return KYCProviderReportResponse{
+		Provider:    r.Provider,
+		ApplicantID: r.ApplicantID,
+		Outcome:     string(r.Outcome),
+		Reason:      string(r.Reason),
+		Checks:      checks,
+		CompletedAt: r.CompletedAt,
+		Raw:         r.Raw,
+	}
+}
+
+// toRetentionPolicyResponse converts a domain RetentionPolicy to a RetentionPolicyResponse.
+func toRetentionPolicyResponse(policy *audit.RetentionPolicy) RetentionPolicyResponse {
+	rules := policy.Rules()
+	dtos := make([]RetentionRuleDTO, len(rules))
+	for i, rule := range rules {
+		dtos[i] = RetentionRuleDTO{
+			EventType: rule.EventType,
+			Category:  string(rule.Category),
+			Severity:  string(rule.Severity),
+			Days:      rule.Days,
+		}
+	}
+	return RetentionPolicyResponse{
+		DefaultDays: policy.DefaultDays(),
+		Rules:       dtos,
+	}
+}
+
+// toRetentionDryRunResponse converts a domain RetentionReport to a RetentionDryRunResponse.
+func toRetentionDryRunResponse(report *audit.RetentionReport) RetentionDryRunResponse {
+	buckets := make([]ExpiryBucketDTO, len(report.Buckets))
+	for i, bucket := range report.Buckets {
+		buckets[i] = ExpiryBucketDTO{
+			Category:        string(bucket.Category),
+			Severity:        string(bucket.Severity),
+			DeletableCount:  bucket.DeletableCount,
+			HeldCount:       bucket.HeldCount,
+			OldestCreatedAt: bucket.OldestCreatedAt,
+		}
+	}
+	return RetentionDryRunResponse{
+		AsOf:           report.AsOf,
+		Buckets:        buckets,
+		TotalDeletable: report.TotalDeletable,
+		TotalHeld:      report.TotalHeld,
+	}
+}
+
+// toScreeningCaseDTO converts a domain screening Case to a ScreeningCaseDTO.
+func toScreeningCaseDTO(c *screening.Case) ScreeningCaseDTO {
+	matches := make([]ScreeningMatchDTO, len(c.Matches))
+	for i, m := range c.Matches {
+		matches[i] = ScreeningMatchDTO{
+			Source:      string(m.Source),
+			EntryID:     m.EntryID,
+			Kind:        string(m.Kind),
+			ListedName:  m.ListedName,
+			MatchedName: m.MatchedName,
+			Score:       m.Score,
+			DOBMatched:  m.DOBMatched,
+			Programs:    m.Programs,
+		}
+	}
+	return ScreeningCaseDTO{
+		ID:          c.ID,
+		UserID:      c.UserID,
+		Status:      string(c.Status),
+		Trigger:     string(c.Trigger),
+		Names:       c.Subject.Names,
+		DateOfBirth: c.Subject.DateOfBirth,
+		Matches:     matches,
+		ListVersion: c.ListVersion,
+		ReviewedBy:  c.ReviewedBy,
+		ReviewedAt:  c.ReviewedAt,
+		ReviewNote:  c.ReviewNote,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+// toWatchListsResponse converts a domain WatchList to a WatchListsResponse.
+func toWatchListsResponse(l *screening.WatchList) WatchListsResponse {
+	entries := make(map[string]int)
+	for source, count := range l.Counts() {
+		entries[string(source)] = count
+	}
+	return WatchListsResponse{
+		Version:  l.Version,
+		LoadedAt: l.LoadedAt,
+		Entries:  entries,
 	}
 }
@@ -106,8 +106,3 @@ type UpdateProfileRequest struct {
 	FirstName *string `json:"first_name,omitempty"`
 	LastName  *string `json:"last_name,omitempty"`
 }
-
-// UpdateKYCRequest represents the request body for admin KYC status updates.
-type UpdateKYCRequest struct {
-	KYCStatus string `json:"kyc_status" binding:"required,oneof=pending verified rejected"`
-}
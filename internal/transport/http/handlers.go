@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
 	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
 	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
@@ -295,23 +296,71 @@ func (h *Handler) GetProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, toUserDTO(user))
 }
 
-// UpdateKYC handles KYC status update requests (admin only).
-// PUT /api/v1/users/:id/kyc
-func (h *Handler) UpdateKYC(c *gin.Context) {
-	userIDStr := c.Param("id")
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		h.logger.WithField("error", err.Error()).Warn("Invalid user ID")
+// UpdateProfile handles user profile update requests.
+// Deprecated: PUT /users/me requires both names; clients should use PatchProfile.
+//
+//	@Summary		Update user profile
+//	@Description	Replace current user's first and last name. Deprecated: use PATCH /users/me
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		UpdateProfileRequest	true	"Profile update data"
+//	@Success		200		{object}	UserDTO					"Updated user profile"
+//	@Failure		400		{object}	ErrorResponse			"Invalid request"
+//	@Failure		401		{object}	ErrorResponse			"Unauthorized"
+//	@Failure		500		{object}	ErrorResponse			"Internal server error"
+//	@Router			/users/me [put]
+func (h *Handler) UpdateProfile(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+
+	var req UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid profile update request")
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_user_id",
-			Message: "invalid user ID format",
+			Error:   "invalid_request",
+			Message: err.Error(),
 		})
 		return
 	}
 
-	var req UpdateKYCRequest
+	h.logger.WithField("user_id", userID).Info("Processing profile update")
+
+	user, err := h.userService.UpdateProfile(c.Request.Context(), userID, userDomain.ProfileUpdate{
+		FirstName: &req.FirstName,
+		LastName:  &req.LastName,
+	})
+	if err != nil {
+		h.handleServiceError(c, err, "profile update failed")
+		return
+	}
+
+	h.logger.WithField("user_id", userID).Info("Profile updated successfully")
+
+	c.JSON(http.StatusOK, toUserDTO(user))
+}
+
+// PatchProfile handles partial profile update requests.
+//
+//	@Summary		Partially update user profile
+//	@Description	Update the given fields of the current user's profile. Omitted fields are unchanged, empty strings clear optional fields. Changing the phone number clears its verification.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		PatchProfileRequest	true	"Fields to update"
+//	@Success		200		{object}	UserDTO				"Updated user profile"
+//	@Failure		400		{object}	ErrorResponse		"Invalid request or profile field"
+//	@Failure		401		{object}	ErrorResponse		"Unauthorized"
+//	@Failure		422		{object}	ErrorResponse		"User is under the minimum age"
+//	@Failure		500		{object}	ErrorResponse		"Internal server error"
+//	@Router			/users/me [patch]
+func (h *Handler) PatchProfile(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+
+	var req PatchProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.WithField("error", err.Error()).Warn("Invalid KYC update request")
+		h.logger.WithField("error", err.Error()).Warn("Invalid profile patch request")
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: err.Error(),
@@ -319,46 +368,66 @@ func (h *Handler) UpdateKYC(c *gin.Context) {
 		return
 	}
 
-	h.logger.WithFields(map[string]interface{}{
-		"user_id": userID,
-		"status":  req.Status,
-	}).Info("Processing KYC status update")
+	h.logger.WithField("user_id", userID).Info("Processing partial profile update")
 
-	kycStatus := userDomain.KYCStatus(req.Status)
-	user, err := h.userService.UpdateKYC(c.Request.Context(), userID, kycStatus)
+	user, err := h.userService.UpdateProfile(c.Request.Context(), userID, req.toProfileUpdate())
 	if err != nil {
-		h.handleServiceError(c, err, "KYC update failed")
+		h.handleServiceError(c, err, "profile update failed")
 		return
 	}
 
-	h.logger.WithFields(map[string]interface{}{
-		"user_id": userID,
-		"status":  req.Status,
-	}).Info("KYC status updated successfully")
-
 	c.JSON(http.StatusOK, toUserDTO(user))
 }
 
-// UpdateProfile handles user profile update requests.
+// StartPhoneVerification handles requests to send a verification code to the user's phone.
 //
-//	@Summary		Update user profile
-//	@Description	Update current user's profile information
+//	@Summary		Send phone verification code
+//	@Description	Send a one-time code to the current user's phone number. A new code replaces the previous one.
+//	@Tags			Users
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		202	{object}	PhoneVerificationResponse	"Code sent"
+//	@Failure		401	{object}	ErrorResponse				"Unauthorized"
+//	@Failure		409	{object}	ErrorResponse				"No phone number set or already verified"
+//	@Failure		429	{object}	ErrorResponse				"A code was sent moments ago"
+//	@Failure		503	{object}	ErrorResponse				"Phone verification is not available"
+//	@Router			/users/me/phone/verification [post]
+func (h *Handler) StartPhoneVerification(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+
+	verification, err := h.userService.StartPhoneVerification(c.Request.Context(), userID)
+	if err != nil {
+		h.handleServiceError(c, err, "phone verification failed")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, PhoneVerificationResponse{
+		Phone:     verification.Phone,
+		ExpiresAt: verification.ExpiresAt,
+	})
+}
+
+// ConfirmPhone handles phone verification code confirmations.
+//
+//	@Summary		Confirm phone number
+//	@Description	Verify the current user's phone number with the code sent to it
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			request	body		UpdateProfileRequest	true	"Profile update data"
-//	@Success		200		{object}	UserDTO					"Updated user profile"
-//	@Failure		400		{object}	ErrorResponse			"Invalid request"
-//	@Failure		401		{object}	ErrorResponse			"Unauthorized"
-//	@Failure		500		{object}	ErrorResponse			"Internal server error"
-//	@Router			/users/me [put]
-func (h *Handler) UpdateProfile(c *gin.Context) {
+//	@Param			request	body		ConfirmPhoneRequest	true	"Verification code"
+//	@Success		200		{object}	UserDTO				"User with verified phone"
+//	@Failure		400		{object}	ErrorResponse		"Invalid or expired code"
+//	@Failure		401		{object}	ErrorResponse		"Unauthorized"
+//	@Failure		404		{object}	ErrorResponse		"No code pending"
+//	@Failure		429		{object}	ErrorResponse		"Too many wrong codes"
+//	@Router			/users/me/phone/verification/confirm [post]
+func (h *Handler) ConfirmPhone(c *gin.Context) {
 	userID := getUserIDFromContext(c)
 
-	var req UpdateProfileRequest
+	var req ConfirmPhoneRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.WithField("error", err.Error()).Warn("Invalid profile update request")
+		h.logger.WithField("error", err.Error()).Warn("Invalid phone confirmation request")
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: err.Error(),
@@ -366,15 +435,13 @@ func (h *Handler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	h.logger.WithField("user_id", userID).Info("Processing profile update")
-
-	user, err := h.userService.UpdateProfile(c.Request.Context(), userID, req.FirstName, req.LastName)
+	user, err := h.userService.ConfirmPhone(c.Request.Context(), userID, req.Code)
 	if err != nil {
-		h.handleServiceError(c, err, "profile update failed")
+		h.handleServiceError(c, err, "phone confirmation failed")
 		return
 	}
 
-	h.logger.WithField("user_id", userID).Info("Profile updated successfully")
+	h.logger.WithField("user_id", userID).Info("Phone number verified")
 
 	c.JSON(http.StatusOK, toUserDTO(user))
 }
@@ -397,6 +464,42 @@ func (h *Handler) DeleteAccount(c *gin.Context) {
 	})
 }
 
+// RestoreAccount handles restoring a deleted account through a restore link.
+//
+//	@Summary		Restore deleted account
+//	@Description	Restore an account deleted within the grace period with the token from the restore link. Sessions stay revoked; log in again afterwards.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		RestoreAccountRequest	true	"Restore token"
+//	@Success		200		{object}	UserDTO					"Restored user"
+//	@Failure		400		{object}	ErrorResponse			"Invalid or expired restore link"
+//	@Failure		409		{object}	ErrorResponse			"Account not deleted or email taken"
+//	@Failure		410		{object}	ErrorResponse			"Grace period over"
+//	@Failure		503		{object}	ErrorResponse			"Account restore is not available"
+//	@Router			/auth/restore [post]
+func (h *Handler) RestoreAccount(c *gin.Context) {
+	var req RestoreAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid account restore request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	user, err := h.userService.RestoreAccount(c.Request.Context(), req.Token)
+	if err != nil {
+		h.handleServiceError(c, err, "account restore failed")
+		return
+	}
+
+	h.logger.WithField("user_id", user.ID).Info("Account restored successfully")
+
+	c.JSON(http.StatusOK, toUserDTO(user))
+}
+
 // GetActiveSessions handles getting active sessions requests.
 // GET /api/v1/users/me/sessions
 func (h *Handler) GetActiveSessions(c *gin.Context) {
@@ -469,6 +572,70 @@ func (h *Handler) handleServiceError(c *gin.Context, err error, context string)
 		statusCode = http.StatusBadRequest
 		errorCode = "weak_password"
 		message = "password does not meet security requirements"
+	case errors.Is(err, audit.ErrLegalHoldActive):
+		statusCode = http.StatusConflict
+		errorCode = "legal_hold_active"
+		message = "account data is under legal hold and cannot be deleted"
+	case errors.Is(err, userDomain.ErrInvalidProfile):
+		statusCode = http.StatusBadRequest
+		errorCode = "invalid_profile"
+		message = err.Error()
+	case errors.Is(err, userDomain.ErrUnderage):
+		statusCode = http.StatusUnprocessableEntity
+		errorCode = "underage"
+		message = err.Error()
+	case errors.Is(err, userDomain.ErrPhoneNotSet):
+		statusCode = http.StatusConflict
+		errorCode = "phone_not_set"
+		message = "add a phone number to the profile before verifying it"
+	case errors.Is(err, userDomain.ErrPhoneAlreadyVerified):
+		statusCode = http.StatusConflict
+		errorCode = "phone_already_verified"
+		message = "phone number already verified"
+	case errors.Is(err, userDomain.ErrPhoneVerificationNotFound):
+		statusCode = http.StatusNotFound
+		errorCode = "phone_verification_not_found"
+		message = "no verification code pending, request a new one"
+	case errors.Is(err, userDomain.ErrInvalidPhoneCode):
+		statusCode = http.StatusBadRequest
+		errorCode = "invalid_phone_code"
+		message = "invalid or expired verification code"
+	case errors.Is(err, userDomain.ErrPhoneVerificationThrottled):
+		statusCode = http.StatusTooManyRequests
+		errorCode = "phone_verification_throttled"
+		message = "too many verification requests, request a new code later"
+	case errors.Is(err, userDomain.ErrPhoneVerificationUnavailable):
+		statusCode = http.StatusServiceUnavailable
+		errorCode = "phone_verification_unavailable"
+		message = "phone verification is not available"
+	case errors.Is(err, userDomain.ErrInvalidRestoreToken):
+		statusCode = http.StatusBadRequest
+		errorCode = "invalid_restore_token"
+		message = "invalid or expired restore link"
+	case errors.Is(err, userDomain.ErrNotDeleted):
+		statusCode = http.StatusConflict
+		errorCode = "account_not_deleted"
+		message = "account is not deleted"
+	case errors.Is(err, userDomain.ErrRestoreWindowExpired):
+		statusCode = http.StatusGone
+		errorCode = "restore_window_expired"
+		message = "account can no longer be restored"
+	case errors.Is(err, userDomain.ErrAccountRestoreUnavailable):
+		statusCode = http.StatusServiceUnavailable
+		errorCode = "account_restore_unavailable"
+		message = "account restore is not available"
+	case errors.Is(err, userDomain.ErrAccountSuspended):
+		statusCode = http.StatusForbidden
+		errorCode = "account_suspended"
+		message = "account is suspended"
+	case errors.Is(err, userDomain.ErrAccountClosed):
+		statusCode = http.StatusForbidden
+		errorCode = "account_closed"
+		message = "account is closed"
+	case errors.Is(err, userDomain.ErrInvalidAccountStatus):
+		statusCode = http.StatusBadRequest
+		errorCode = "invalid_account_status"
+		message = err.Error()
 	default:
 		statusCode = http.StatusInternalServerError
 		errorCode = "internal_error"
@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
 	httpTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/http"
 	"github.com/gin-gonic/gin"
@@ -675,7 +678,7 @@ func TestUpdateProfile(t *testing.T) {
 					LastName:  "Smith",
 					Role:      userDomain.RoleUser,
 				}
-				m.On("UpdateProfile", mock.Anything, userID, "Jane", "Smith").
+				m.On("UpdateProfile", mock.Anything, userID, nameUpdate("Jane", "Smith")).
 					Return(user, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -691,7 +694,7 @@ func TestUpdateProfile(t *testing.T) {
 				"last_name":  "Smith",
 			},
 			mockSetup: func(m *MockUserService) {
-				m.On("UpdateProfile", mock.Anything, userID, "Jane", "Smith").
+				m.On("UpdateProfile", mock.Anything, userID, nameUpdate("Jane", "Smith")).
 					Return(nil, userDomain.ErrNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
@@ -745,6 +748,199 @@ func TestUpdateProfile(t *testing.T) {
 	}
 }
 
+// nameUpdate is the profile update PUT /users/me makes
+func nameUpdate(firstName, lastName string) userDomain.ProfileUpdate {
+	return userDomain.ProfileUpdate{FirstName: &firstName, LastName: &lastName}
+}
+
+// TestPatchProfile tests the PatchProfile handler
+func TestPatchProfile(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(*MockUserService)
+		expectedStatus int
+		validateBody   func(t *testing.T, body map[string]interface{})
+	}{
+		{
+			name:        "only given fields are updated",
+			requestBody: `{"phone":"+40721234567","address":{"line1":"Strada Lipscani 10","city":"Bucharest","postal_code":"030031","country":"RO"}}`,
+			mockSetup: func(m *MockUserService) {
+				phone := "+40721234567"
+				update := userDomain.ProfileUpdate{
+					Phone: &phone,
+					Address: &userDomain.Address{
+						Line1:      "Strada Lipscani 10",
+						City:       "Bucharest",
+						PostalCode: "030031",
+						Country:    "RO",
+					},
+				}
+				user := &userDomain.User{
+					ID:        userID,
+					Email:     "user@test.com",
+					FirstName: "Jane",
+					LastName:  "Smith",
+					Phone:     phone,
+					Address:   *update.Address,
+				}
+				m.On("UpdateProfile", mock.Anything, userID, update).Return(user, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "+40721234567", body["phone"])
+				assert.Equal(t, false, body["phone_verified"])
+				assert.Equal(t, "Bucharest", body["address"].(map[string]interface{})["city"])
+				assert.NotContains(t, body, "date_of_birth")
+			},
+		},
+		{
+			name:        "empty string clears a field",
+			requestBody: `{"nationality":""}`,
+			mockSetup: func(m *MockUserService) {
+				empty := ""
+				m.On("UpdateProfile", mock.Anything, userID, userDomain.ProfileUpdate{Nationality: &empty}).
+					Return(&userDomain.User{ID: userID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody:   func(t *testing.T, body map[string]interface{}) {},
+		},
+		{
+			name:        "invalid field",
+			requestBody: `{"phone":"0721234567"}`,
+			mockSetup: func(m *MockUserService) {
+				m.On("UpdateProfile", mock.Anything, userID, mock.Anything).
+					Return(nil, fmt.Errorf("%w: phone must be an E.164 number", userDomain.ErrInvalidProfile))
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "invalid_profile", body["error"])
+				assert.Contains(t, body["message"], "E.164")
+			},
+		},
+		{
+			name:        "underage",
+			requestBody: `{"date_of_birth":"2015-01-01"}`,
+			mockSetup: func(m *MockUserService) {
+				m.On("UpdateProfile", mock.Anything, userID, mock.Anything).Return(nil, userDomain.ErrUnderage)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "underage", body["error"])
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockUserService)
+			tc.mockSetup(mockService)
+			handler := httpTransport.NewHandler(mockService, getTestLogger())
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/users/me", strings.NewReader(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router := gin.New()
+			router.PATCH("/api/v1/users/me", func(c *gin.Context) {
+				c.Set("user_id", userID)
+				handler.PatchProfile(c)
+			})
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			json.Unmarshal(w.Body.Bytes(), &response)
+			tc.validateBody(t, response)
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestPhoneVerification tests the StartPhoneVerification and ConfirmPhone handlers
+func TestPhoneVerification(t *testing.T) {
+	userID := uuid.New()
+
+	newRouter := func(m *MockUserService) *gin.Engine {
+		handler := httpTransport.NewHandler(m, getTestLogger())
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", userID)
+			c.Next()
+		})
+		router.POST("/api/v1/users/me/phone/verification", handler.StartPhoneVerification)
+		router.POST("/api/v1/users/me/phone/verification/confirm", handler.ConfirmPhone)
+		return router
+	}
+
+	t.Run("code sent", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("StartPhoneVerification", mock.Anything, userID).Return(&userDomain.PhoneVerification{
+			UserID:    userID,
+			Phone:     "+40721234567",
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		}, nil)
+
+		w := httptest.NewRecorder()
+		newRouter(mockService).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/users/me/phone/verification", nil))
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), `"phone":"+40721234567"`)
+	})
+
+	t.Run("resent too soon", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("StartPhoneVerification", mock.Anything, userID).Return(nil, userDomain.ErrPhoneVerificationThrottled)
+
+		w := httptest.NewRecorder()
+		newRouter(mockService).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/users/me/phone/verification", nil))
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+
+	t.Run("code must be six digits", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/phone/verification/confirm", strings.NewReader(`{"code":"12ab"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		newRouter(new(MockUserService)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		verifiedAt := time.Now()
+		mockService := new(MockUserService)
+		mockService.On("ConfirmPhone", mock.Anything, userID, "123456").Return(&userDomain.User{
+			ID: userID, Phone: "+40721234567", PhoneVerifiedAt: &verifiedAt,
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/phone/verification/confirm", strings.NewReader(`{"code":"123456"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		newRouter(mockService).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"phone_verified":true`)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("ConfirmPhone", mock.Anything, userID, "123456").Return(nil, userDomain.ErrInvalidPhoneCode)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/phone/verification/confirm", strings.NewReader(`{"code":"123456"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		newRouter(mockService).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_phone_code")
+	})
+}
+
 // TestDeleteAccount tests the DeleteAccount handler
 func TestDeleteAccount(t *testing.T) {
 	userID := uuid.New()
@@ -777,6 +973,17 @@ func TestDeleteAccount(t *testing.T) {
 				assert.Equal(t, "user_not_found", body["error"])
 			},
 		},
+		{
+			name: "user under legal hold",
+			mockSetup: func(m *MockUserService) {
+				m.On("DeleteAccount", mock.Anything, userID).
+					Return(audit.ErrLegalHoldActive)
+			},
+			expectedStatus: http.StatusConflict,
+			validateBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "legal_hold_active", body["error"])
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -806,6 +1013,64 @@ func TestDeleteAccount(t *testing.T) {
 	}
 }
 
+// TestRestoreAccount tests the RestoreAccount handler
+func TestRestoreAccount(t *testing.T) {
+	newRouter := func(m *MockUserService) *gin.Engine {
+		handler := httpTransport.NewHandler(m, getTestLogger())
+		router := gin.New()
+		router.POST("/api/v1/auth/restore", handler.RestoreAccount)
+		return router
+	}
+	post := func(router *gin.Engine, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/restore", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("restored", func(t *testing.T) {
+		userID := uuid.New()
+		mockService := new(MockUserService)
+		mockService.On("RestoreAccount", mock.Anything, "signed-token").Return(&userDomain.User{ID: userID, Email: "jane@example.com"}, nil)
+
+		w := post(newRouter(mockService), `{"token":"signed-token"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), userID.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("token required", func(t *testing.T) {
+		w := post(newRouter(new(MockUserService)), `{}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	errorCases := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{userDomain.ErrInvalidRestoreToken, http.StatusBadRequest, "invalid_restore_token"},
+		{userDomain.ErrNotDeleted, http.StatusConflict, "account_not_deleted"},
+		{userDomain.ErrAlreadyExists, http.StatusConflict, "user_already_exists"},
+		{userDomain.ErrRestoreWindowExpired, http.StatusGone, "restore_window_expired"},
+		{userDomain.ErrAccountRestoreUnavailable, http.StatusServiceUnavailable, "account_restore_unavailable"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.code, func(t *testing.T) {
+			mockService := new(MockUserService)
+			mockService.On("RestoreAccount", mock.Anything, "signed-token").Return(nil, tc.err)
+
+			w := post(newRouter(mockService), `{"token":"signed-token"}`)
+
+			assert.Equal(t, tc.status, w.Code)
+			assert.Contains(t, w.Body.String(), tc.code)
+		})
+	}
+}
+
 // TestGetActiveSessions tests the GetActiveSessions handler
 func TestGetActiveSessions(t *testing.T) {
 	userID := uuid.New()
@@ -886,108 +1151,6 @@ func TestGetActiveSessions(t *testing.T) {
 	}
 }
 
-// TestUpdateKYC tests the UpdateKYC handler
-func TestUpdateKYC(t *testing.T) {
-	userID := uuid.New()
-
-	testCases := []struct {
-		name           string
-		userID         string
-		requestBody    interface{}
-		mockSetup      func(*MockUserService)
-		expectedStatus int
-		validateBody   func(t *testing.T, body map[string]interface{})
-	}{
-		{
-			name:   "update KYC status successfully",
-			userID: userID.String(),
-			requestBody: map[string]interface{}{
-				"status": "approved",
-			},
-			mockSetup: func(m *MockUserService) {
-				user := &userDomain.User{
-					ID:        userID,
-					Email:     "user@test.com",
-					FirstName: "John",
-					LastName:  "Doe",
-					KYCStatus: userDomain.KYCStatus("approved"),
-				}
-				m.On("UpdateKYC", mock.Anything, userID, userDomain.KYCStatus("approved")).
-					Return(user, nil)
-			},
-			expectedStatus: http.StatusOK,
-			validateBody: func(t *testing.T, body map[string]interface{}) {
-				assert.Equal(t, "approved", body["kyc_status"])
-			},
-		},
-		{
-			name:   "invalid user ID",
-			userID: "invalid-uuid",
-			requestBody: map[string]interface{}{
-				"status": "verified",
-			},
-			mockSetup:      func(m *MockUserService) {},
-			expectedStatus: http.StatusBadRequest,
-			validateBody: func(t *testing.T, body map[string]interface{}) {
-				assert.Equal(t, "invalid_user_id", body["error"])
-			},
-		},
-		{
-			name:   "invalid KYC status",
-			userID: userID.String(),
-			requestBody: map[string]interface{}{
-				"status": "invalid_status",
-			},
-			mockSetup:      func(m *MockUserService) {},
-			expectedStatus: http.StatusBadRequest,
-			validateBody: func(t *testing.T, body map[string]interface{}) {
-				assert.Equal(t, "invalid_request", body["error"])
-			},
-		},
-		{
-			name:           "invalid JSON",
-			userID:         userID.String(),
-			requestBody:    "not json",
-			mockSetup:      func(m *MockUserService) {},
-			expectedStatus: http.StatusBadRequest,
-			validateBody: func(t *testing.T, body map[string]interface{}) {
-				assert.Equal(t, "invalid_request", body["error"])
-			},
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			mockService := new(MockUserService)
-			tc.mockSetup(mockService)
-			handler := httpTransport.NewHandler(mockService, getTestLogger())
-
-			var body []byte
-			if str, ok := tc.requestBody.(string); ok {
-				body = []byte(str)
-			} else {
-				body, _ = json.Marshal(tc.requestBody)
-			}
-
-			req := httptest.NewRequest(http.MethodPut, "/api/v1/users/"+tc.userID+"/kyc", bytes.NewReader(body))
-			req.Header.Set("Content-Type", "application/json")
-			w := httptest.NewRecorder()
-
-			router := gin.New()
-			router.PUT("/api/v1/users/:id/kyc", handler.UpdateKYC)
-			router.ServeHTTP(w, req)
-
-			assert.Equal(t, tc.expectedStatus, w.Code)
-
-			var response map[string]interface{}
-			json.Unmarshal(w.Body.Bytes(), &response)
-			tc.validateBody(t, response)
-
-			mockService.AssertExpectations(t)
-		})
-	}
-}
-
 // TestHealthCheck tests the HealthCheck handler
 func TestHealthCheck(t *testing.T) {
 	mockService := new(MockUserService)
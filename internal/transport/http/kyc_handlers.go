@@ -0,0 +1,738 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+// maxKYCUploadRequestBytes bounds a document upload request. The service enforces the
+// configured per-document limit, which must be lower.
+const maxKYCUploadRequestBytes = 25 << 20
+
+// maxKYCWebhookBytes bounds a provider decision webhook
+const maxKYCWebhookBytes = 1 << 20
+
+// KYCHandler handles KYC case requests: applicants manage their own case on the user router,
+// reviewers work the queue on the admin router.
+type KYCHandler struct {
+	kycService kyc.Service
+	logger     *observability.Logger
+}
+
+// NewKYCHandler creates a new KYCHandler instance.
+func NewKYCHandler(kycService kyc.Service, logger *observability.Logger) *KYCHandler {
+	return &KYCHandler{
+		kycService: kycService,
+		logger:     logger,
+	}
+}
+
+// CreateCase handles POST /api/v1/users/me/kyc/cases
+// Opens a draft case; the applicant data may be incomplete until submission.
+func (h *KYCHandler) CreateCase(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req KYCApplicantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid create KYC case request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	kycCase, err := h.kycService.CreateCase(c.Request.Context(), userID, toKYCApplicant(req))
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to open KYC case")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toKYCCaseDTO(kycCase, false))
+}
+
+// GetMyCase handles GET /api/v1/users/me/kyc
+// Returns the user's latest case with its documents.
+func (h *KYCHandler) GetMyCase(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	kycCase, err := h.kycService.GetMyCase(c.Request.Context(), userID)
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to retrieve KYC case")
+		return
+	}
+
+	c.JSON(http.StatusOK, toKYCCaseDTO(kycCase, false))
+}
+
+// UpdateApplicant handles PUT /api/v1/users/me/kyc/cases/:id/applicant
+// Replaces the applicant data of a case in draft or waiting for more information.
+func (h *KYCHandler) UpdateApplicant(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	caseID, ok := h.parseCaseID(c)
+	if !ok {
+		return
+	}
+
+	var req KYCApplicantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid update KYC applicant request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	kycCase, err := h.kycService.UpdateApplicant(c.Request.Context(), userID, caseID, toKYCApplicant(req))
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to update KYC applicant data")
+		return
+	}
+
+	c.JSON(http.StatusOK, toKYCCaseDTO(kycCase, false))
+}
+
+// UploadDocument handles POST /api/v1/users/me/kyc/cases/:id/documents
+// Accepts a multipart form with the document in "file" and its kind in "type".
+func (h *KYCHandler) UploadDocument(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	caseID, ok := h.parseCaseID(c)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxKYCUploadRequestBytes)
+	file, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.respondKYCError(c, kyc.ErrDocumentTooLarge, "")
+			return
+		}
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "multipart form with a \"file\" field is required",
+		})
+		return
+	}
+	content, err := file.Open()
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to read KYC document")
+		return
+	}
+	defer content.Close()
+
+	doc, err := h.kycService.UploadDocument(c.Request.Context(), userID, caseID, kyc.DocumentUpload{
+		Type:     kyc.DocumentType(c.PostForm("type")),
+		FileName: file.Filename,
+		Content:  content,
+	})
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to upload KYC document")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toKYCDocumentDTO(doc))
+}
+
+// Submit handles POST /api/v1/users/me/kyc/cases/:id/submit
+// Hands the case to reviewers; requires complete applicant data and an identity document.
+func (h *KYCHandler) Submit(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	caseID, ok := h.parseCaseID(c)
+	if !ok {
+		return
+	}
+
+	kycCase, err := h.kycService.Submit(c.Request.Context(), userID, caseID)
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to submit KYC case")
+		return
+	}
+
+	c.JSON(http.StatusOK, toKYCCaseDTO(kycCase, false))
+}
+
+// ListCases handles GET /admin/kyc/cases
+// Lists the review queue, oldest submission first, filtered by status, reviewer or risk level.
+func (h *KYCHandler) ListCases(c *gin.Context) {
+	var req ListKYCCasesRequest
+	req.Limit = 20 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid list KYC cases request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	filter := kyc.ListFilter{
+		Limit:  int32(req.Limit),  // #nosec G115 -- bounded by binding (max=100)
+		Offset: int32(req.Offset), // #nosec G115 -- bounded by binding (min=0)
+	}
+	if req.Status != "" {
+		status := kyc.Status(req.Status)
+		filter.Status = &status
+	}
+	if req.ReviewerID != "" {
+		reviewerID := uuid.MustParse(req.ReviewerID)
+		filter.ReviewerID = &reviewerID
+	}
+	if req.RiskLevel != "" {
+		riskLevel := kyc.RiskLevel(req.RiskLevel)
+		filter.RiskLevel = &riskLevel
+	}
+
+	cases, total, err := h.kycService.ListCases(c.Request.Context(), filter)
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to retrieve KYC cases")
+		return
+	}
+
+	dtos := make([]KYCCaseDTO, len(cases))
+	for i, kycCase := range cases {
+		dtos[i] = toKYCCaseDTO(kycCase, true)
+	}
+
+	c.JSON(http.StatusOK, KYCCasesListResponse{
+		Cases:  dtos,
+		Total:  total,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+}
+
+// GetCase handles GET /admin/kyc/cases/:id
+func (h *KYCHandler) GetCase(c *gin.Context) {
+	caseID, ok := h.parseCaseID(c)
+	if !ok {
+		return
+	}
+
+	kycCase, err := h.kycService.GetCase(c.Request.Context(), caseID)
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to retrieve KYC case")
+		return
+	}
+
+	c.JSON(http.StatusOK, toKYCCaseDTO(kycCase, true))
+}
+
+// GetHistory handles GET /admin/kyc/cases/:id/history
+// Lists every action taken on the case, in order.
+func (h *KYCHandler) GetHistory(c *gin.Context) {
+	caseID, ok := h.parseCaseID(c)
+	if !ok {
+		return
+	}
+
+	transitions, err := h.kycService.GetHistory(c.Request.Context(), caseID)
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to retrieve KYC case history")
+		return
+	}
+
+	dtos := make([]KYCTransitionDTO, len(transitions))
+	for i, t := range transitions {
+		dtos[i] = toKYCTransitionDTO(t)
+	}
+	c.JSON(http.StatusOK, KYCHistoryResponse{Transitions: dtos})
+}
+
+// DownloadDocument handles GET /admin/kyc/cases/:id/documents/:document_id
+// Streams the document content; every download is audited.
+func (h *KYCHandler) DownloadDocument(c *gin.Context) {
+	caseID, ok := h.parseCaseID(c)
+	if !ok {
+		return
+	}
+	documentID, err := uuid.Parse(c.Param("document_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_kyc_document_id",
+			Message: "Invalid KYC document ID format",
+		})
+		return
+	}
+	actor, ok := h.currentActor(c)
+	if !ok {
+		return
+	}
+
+	doc, content, err := h.kycService.OpenDocument(c.Request.Context(), caseID, documentID, actor)
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to open KYC document")
+		return
+	}
+	defer content.Close()
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Content-SHA256", doc.SHA256)
+	c.DataFromReader(http.StatusOK, doc.SizeBytes, doc.ContentType, content, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%s", strconv.Quote(doc.FileName)),
+	})
+}
+
+// Assign handles POST /admin/kyc/cases/:id/assign
+// Assigns the case to reviewer_id, or to the acting admin when it is omitted.
+func (h *KYCHandler) Assign(c *gin.Context) {
+	var req AssignKYCCaseRequest
+	if !h.bindDecision(c, &req) {
+		return
+	}
+	caseID, actor, ok := h.decisionContext(c)
+	if !ok {
+		return
+	}
+
+	reviewerID := actor.ID
+	if req.ReviewerID != "" {
+		reviewerID = uuid.MustParse(req.ReviewerID)
+	}
+
+	kycCase, err := h.kycService.Assign(c.Request.Context(), caseID, reviewerID, actor)
+	h.respondDecision(c, kycCase, err, "Failed to assign KYC case")
+}
+
+// SetRiskLevel handles PUT /admin/kyc/cases/:id/risk
+// High-risk cases need approval by two different admins.
+func (h *KYCHandler) SetRiskLevel(c *gin.Context) {
+	var req SetKYCRiskLevelRequest
+	if !h.bindDecision(c, &req) {
+		return
+	}
+	caseID, actor, ok := h.decisionContext(c)
+	if !ok {
+		return
+	}
+
+	kycCase, err := h.kycService.SetRiskLevel(c.Request.Context(), caseID, kyc.RiskLevel(req.RiskLevel), actor)
+	h.respondDecision(c, kycCase, err, "Failed to set KYC case risk level")
+}
+
+// Approve handles POST /admin/kyc/cases/:id/approve
+// A high-risk case stays in review after the first approval until a second admin approves.
+func (h *KYCHandler) Approve(c *gin.Context) {
+	var req KYCDecisionRequest
+	if !h.bindDecision(c, &req) {
+		return
+	}
+	caseID, actor, ok := h.decisionContext(c)
+	if !ok {
+		return
+	}
+
+	kycCase, err := h.kycService.Approve(c.Request.Context(), caseID, req.Note, actor)
+	h.respondDecision(c, kycCase, err, "Failed to approve KYC case")
+}
+
+// Reject handles POST /admin/kyc/cases/:id/reject
+func (h *KYCHandler) Reject(c *gin.Context) {
+	var req RejectKYCCaseRequest
+	if !h.bindDecision(c, &req) {
+		return
+	}
+	caseID, actor, ok := h.decisionContext(c)
+	if !ok {
+		return
+	}
+
+	kycCase, err := h.kycService.Reject(c.Request.Context(), caseID, kyc.RejectionReason(req.Reason), req.Note, actor)
+	h.respondDecision(c, kycCase, err, "Failed to reject KYC case")
+}
+
+// RequestMoreInfo handles POST /admin/kyc/cases/:id/request-info
+// Returns the case to the applicant; the note tells them what to correct.
+func (h *KYCHandler) RequestMoreInfo(c *gin.Context) {
+	var req KYCDecisionRequest
+	if !h.bindDecision(c, &req) {
+		return
+	}
+	caseID, actor, ok := h.decisionContext(c)
+	if !ok {
+		return
+	}
+
+	kycCase, err := h.kycService.RequestMoreInfo(c.Request.Context(), caseID, req.Note, actor)
+	h.respondDecision(c, kycCase, err, "Failed to request more information")
+}
+
+// GetMyEntitlements handles GET /api/v1/users/me/entitlements
+// Returns the user's tier and what it allows.
+func (h *KYCHandler) GetMyEntitlements(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	entitlements, err := h.kycService.GetEntitlements(c.Request.Context(), userID)
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to retrieve entitlements")
+		return
+	}
+
+	c.JSON(http.StatusOK, toEntitlementsResponse(entitlements))
+}
+
+// GetEntitlements handles GET /admin/users/:id/entitlements
+func (h *KYCHandler) GetEntitlements(c *gin.Context) {
+	userID, ok := h.parseUserID(c)
+	if !ok {
+		return
+	}
+
+	entitlements, err := h.kycService.GetEntitlements(c.Request.Context(), userID)
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to retrieve entitlements")
+		return
+	}
+
+	c.JSON(http.StatusOK, toEntitlementsResponse(entitlements))
+}
+
+// SetTier handles PUT /admin/users/:id/kyc-tier
+// Tiers above 0 can only be assigned to verified users; every change needs a reason.
+func (h *KYCHandler) SetTier(c *gin.Context) {
+	var req SetKYCTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid KYC tier request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+	userID, ok := h.parseUserID(c)
+	if !ok {
+		return
+	}
+	actor, ok := h.currentActor(c)
+	if !ok {
+		return
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"user_id": userID.String(),
+		"tier":    *req.Tier,
+		"actor":   GetAdminActorFromContext(c),
+	}).Info("Admin: Setting KYC tier")
+
+	entitlements, err := h.kycService.SetTier(c.Request.Context(), userID, kyc.Tier(*req.Tier), req.Reason, actor)
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to set KYC tier")
+		return
+	}
+
+	c.JSON(http.StatusOK, toEntitlementsResponse(entitlements))
+}
+
+// GetTierHistory handles GET /admin/users/:id/kyc-tier/history
+// Lists every tier change of the user, in order.
+func (h *KYCHandler) GetTierHistory(c *gin.Context) {
+	userID, ok := h.parseUserID(c)
+	if !ok {
+		return
+	}
+
+	changes, err := h.kycService.GetTierHistory(c.Request.Context(), userID)
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to retrieve KYC tier history")
+		return
+	}
+
+	dtos := make([]KYCTierChangeDTO, len(changes))
+	for i, change := range changes {
+		dtos[i] = toKYCTierChangeDTO(change)
+	}
+	c.JSON(http.StatusOK, KYCTierHistoryResponse{Changes: dtos})
+}
+
+// StartVerification handles POST /api/v1/users/me/kyc/cases/:id/verification
+// Submits the case to the external provider and returns the link where the applicant
+// completes verification; documents are collected by the provider.
+func (h *KYCHandler) StartVerification(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	caseID, ok := h.parseCaseID(c)
+	if !ok {
+		return
+	}
+
+	link, err := h.kycService.StartVerification(c.Request.Context(), userID, caseID)
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to start KYC verification")
+		return
+	}
+
+	c.JSON(http.StatusOK, KYCVerificationLinkResponse{
+		Provider:  link.Provider,
+		URL:       link.URL,
+		ExpiresAt: link.ExpiresAt,
+	})
+}
+
+// ProviderWebhook handles POST /api/v1/webhooks/kyc/:provider
+// Receives the provider's decisions. Requests are authenticated by the provider's signature,
+// not a token; anything the signature does not cover is ignored.
+func (h *KYCHandler) ProviderWebhook(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxKYCWebhookBytes))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+			Error:   "invalid_request",
+			Message: fmt.Sprintf("webhook body must not exceed %d bytes", maxKYCWebhookBytes),
+		})
+		return
+	}
+
+	if err := h.kycService.HandleProviderWebhook(c.Request.Context(), c.Param("provider"), c.Request.Header, body); err != nil {
+		h.respondKYCError(c, err, "Failed to process KYC provider webhook")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetProviderReport handles GET /admin/kyc/cases/:id/provider-report
+// Fetches the external provider's report on the case.
+func (h *KYCHandler) GetProviderReport(c *gin.Context) {
+	caseID, ok := h.parseCaseID(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.kycService.GetProviderReport(c.Request.Context(), caseID)
+	if err != nil {
+		h.respondKYCError(c, err, "Failed to retrieve KYC provider report")
+		return
+	}
+
+	c.JSON(http.StatusOK, toKYCProviderReportResponse(report))
+}
+
+// bindDecision binds the JSON body of a review action; an empty body is allowed
+func (h *KYCHandler) bindDecision(c *gin.Context, req interface{}) bool {
+	if c.Request.ContentLength == 0 {
+		if err := binding.Validator.ValidateStruct(req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return false
+		}
+		return true
+	}
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid KYC review request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// decisionContext reads the case ID and the acting admin of a review action
+func (h *KYCHandler) decisionContext(c *gin.Context) (uuid.UUID, kyc.Actor, bool) {
+	caseID, ok := h.parseCaseID(c)
+	if !ok {
+		return uuid.Nil, kyc.Actor{}, false
+	}
+	actor, ok := h.currentActor(c)
+	if !ok {
+		return uuid.Nil, kyc.Actor{}, false
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"case_id": caseID.String(),
+		"path":    c.FullPath(),
+		"actor":   GetAdminActorFromContext(c),
+	}).Info("Admin: Processing KYC review request")
+	return caseID, actor, true
+}
+
+func (h *KYCHandler) respondDecision(c *gin.Context, kycCase *kyc.Case, err error, message string) {
+	if err != nil {
+		h.respondKYCError(c, err, message)
+		return
+	}
+	c.JSON(http.StatusOK, toKYCCaseDTO(kycCase, true))
+}
+
+// currentUserID reads the authenticated user, responding with 401 if there is none
+func (h *KYCHandler) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// currentActor reads the authenticated admin as a KYC actor
+func (h *KYCHandler) currentActor(c *gin.Context) (kyc.Actor, bool) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return kyc.Actor{}, false
+	}
+	return kyc.Actor{ID: userID, Email: c.GetString("email")}, true
+}
+
+// parseCaseID reads the :id path parameter, responding with 400 if it is not a UUID
+func (h *KYCHandler) parseCaseID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_kyc_case_id",
+			Message: "Invalid KYC case ID format",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parseUserID reads the :id path parameter, responding with 400 if it is not a UUID
+func (h *KYCHandler) parseUserID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_user_id",
+			Message: "Invalid user ID format",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondKYCError maps KYC domain errors to HTTP responses
+func (h *KYCHandler) respondKYCError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, kyc.ErrCaseNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "kyc_case_not_found",
+			Message: "KYC case not found",
+		})
+	case errors.Is(err, kyc.ErrDocumentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "kyc_document_not_found",
+			Message: "KYC document not found",
+		})
+	case errors.Is(err, user.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "user_not_found",
+			Message: "User not found",
+		})
+	case errors.Is(err, kyc.ErrInvalidApplicant), errors.Is(err, kyc.ErrMissingDocuments),
+		errors.Is(err, kyc.ErrInvalidDocument), errors.Is(err, kyc.ErrInvalidDecision),
+		errors.Is(err, kyc.ErrInvalidReviewer):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_kyc_request",
+			Message: err.Error(),
+		})
+	case errors.Is(err, kyc.ErrInvalidTier):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_kyc_tier",
+			Message: err.Error(),
+		})
+	case errors.Is(err, kyc.ErrTierRequiresVerification):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "kyc_tier_requires_verification",
+			Message: err.Error(),
+		})
+	case errors.Is(err, kyc.ErrDocumentTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+			Error:   "kyc_document_too_large",
+			Message: err.Error(),
+		})
+	case errors.Is(err, kyc.ErrNotAssignedReviewer):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "not_assigned_reviewer",
+			Message: err.Error(),
+		})
+	case errors.Is(err, kyc.ErrSelfReview):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "kyc_self_review",
+			Message: err.Error(),
+		})
+	case errors.Is(err, kyc.ErrFourEyesRequired):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "four_eyes_required",
+			Message: err.Error(),
+		})
+	case errors.Is(err, kyc.ErrScreeningHitsOpen):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "screening_hits_uncleared",
+			Message: err.Error(),
+		})
+	case errors.Is(err, screening.ErrListsNotLoaded):
+		h.logger.WithError(err).Error(message)
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "screening_unavailable",
+			Message: "Screening watch lists are not loaded, please try again later",
+		})
+	case errors.Is(err, kyc.ErrProviderNotConfigured):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "kyc_provider_not_configured",
+			Message: err.Error(),
+		})
+	case errors.Is(err, kyc.ErrInvalidProviderWebhook):
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "invalid_kyc_provider_webhook",
+			Message: "Webhook signature or body is invalid",
+		})
+	case errors.Is(err, kyc.ErrProviderUnavailable):
+		h.logger.WithError(err).Error(message)
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "kyc_provider_unavailable",
+			Message: "KYC provider is unavailable, please try again later",
+		})
+	case errors.Is(err, kyc.ErrCaseAlreadyOpen), errors.Is(err, kyc.ErrAlreadyVerified),
+		errors.Is(err, kyc.ErrInvalidTransition), errors.Is(err, kyc.ErrCaseNotEditable),
+		errors.Is(err, kyc.ErrCaseConflict):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "kyc_case_conflict",
+			Message: err.Error(),
+		})
+	default:
+		h.logger.WithError(err).Error(message)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: message,
+		})
+	}
+}
@@ -0,0 +1,736 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	httpTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/http"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockKYCService is a mock implementation of kyc.Service
+type MockKYCService struct {
+	mock.Mock
+}
+
+func (m *MockKYCService) CreateCase(ctx context.Context, userID uuid.UUID, applicant kyc.Applicant) (*kyc.Case, error) {
+	args := m.Called(ctx, userID, applicant)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.Case), args.Error(1)
+}
+
+func (m *MockKYCService) GetMyCase(ctx context.Context, userID uuid.UUID) (*kyc.Case, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.Case), args.Error(1)
+}
+
+func (m *MockKYCService) UpdateApplicant(ctx context.Context, userID, caseID uuid.UUID, applicant kyc.Applicant) (*kyc.Case, error) {
+	args := m.Called(ctx, userID, caseID, applicant)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.Case), args.Error(1)
+}
+
+func (m *MockKYCService) UploadDocument(ctx context.Context, userID, caseID uuid.UUID, upload kyc.DocumentUpload) (*kyc.Document, error) {
+	args := m.Called(ctx, userID, caseID, upload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.Document), args.Error(1)
+}
+
+func (m *MockKYCService) Submit(ctx context.Context, userID, caseID uuid.UUID) (*kyc.Case, error) {
+	args := m.Called(ctx, userID, caseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.Case), args.Error(1)
+}
+
+func (m *MockKYCService) ListCases(ctx context.Context, filter kyc.ListFilter) ([]*kyc.Case, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*kyc.Case), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockKYCService) GetCase(ctx context.Context, caseID uuid.UUID) (*kyc.Case, error) {
+	args := m.Called(ctx, caseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.Case), args.Error(1)
+}
+
+func (m *MockKYCService) GetHistory(ctx context.Context, caseID uuid.UUID) ([]*kyc.Transition, error) {
+	args := m.Called(ctx, caseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*kyc.Transition), args.Error(1)
+}
+
+func (m *MockKYCService) OpenDocument(ctx context.Context, caseID, documentID uuid.UUID, actor kyc.Actor) (*kyc.Document, io.ReadCloser, error) {
+	args := m.Called(ctx, caseID, documentID, actor)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*kyc.Document), args.Get(1).(io.ReadCloser), args.Error(2)
+}
+
+func (m *MockKYCService) Assign(ctx context.Context, caseID, reviewerID uuid.UUID, actor kyc.Actor) (*kyc.Case, error) {
+	args := m.Called(ctx, caseID, reviewerID, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.Case), args.Error(1)
+}
+
+func (m *MockKYCService) SetRiskLevel(ctx context.Context, caseID uuid.UUID, level kyc.RiskLevel, actor kyc.Actor) (*kyc.Case, error) {
+	args := m.Called(ctx, caseID, level, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.Case), args.Error(1)
+}
+
+func (m *MockKYCService) Approve(ctx context.Context, caseID uuid.UUID, note string, actor kyc.Actor) (*kyc.Case, error) {
+	args := m.Called(ctx, caseID, note, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.Case), args.Error(1)
+}
+
+func (m *MockKYCService) Reject(ctx context.Context, caseID uuid.UUID, reason kyc.RejectionReason, note string, actor kyc.Actor) (*kyc.Case, error) {
+	args := m.Called(ctx, caseID, reason, note, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.Case), args.Error(1)
+}
+
+func (m *MockKYCService) RequestMoreInfo(ctx context.Context, caseID uuid.UUID, note string, actor kyc.Actor) (*kyc.Case, error) {
+	args := m.Called(ctx, caseID, note, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.Case), args.Error(1)
+}
+
+func (m *MockKYCService) GetEntitlements(ctx context.Context, userID uuid.UUID) (*kyc.Entitlements, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.Entitlements), args.Error(1)
+}
+
+func (m *MockKYCService) SetTier(ctx context.Context, userID uuid.UUID, tier kyc.Tier, reason string, actor kyc.Actor) (*kyc.Entitlements, error) {
+	args := m.Called(ctx, userID, tier, reason, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.Entitlements), args.Error(1)
+}
+
+func (m *MockKYCService) GetTierHistory(ctx context.Context, userID uuid.UUID) ([]*kyc.TierChange, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*kyc.TierChange), args.Error(1)
+}
+
+func (m *MockKYCService) StartVerification(ctx context.Context, userID, caseID uuid.UUID) (*kyc.VerificationLink, error) {
+	args := m.Called(ctx, userID, caseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.VerificationLink), args.Error(1)
+}
+
+func (m *MockKYCService) HandleProviderWebhook(ctx context.Context, provider string, header http.Header, body []byte) error {
+	args := m.Called(ctx, provider, header, body)
+	return args.Error(0)
+}
+
+func (m *MockKYCService) GetProviderReport(ctx context.Context, caseID uuid.UUID) (*kyc.ProviderReport, error) {
+	args := m.Called(ctx, caseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kyc.ProviderReport), args.Error(1)
+}
+
+var kycTestActorID = uuid.MustParse("7f1d2c3b-4a59-4e6f-8a7b-9c0d1e2f3a4b")
+
+func newKYCTestRouter(svc *MockKYCService) *gin.Engine {
+	handler := httpTransport.NewKYCHandler(svc, getTestLogger())
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", kycTestActorID)
+		c.Set("email", "admin@test.com")
+		c.Next()
+	})
+	router.GET("/api/v1/users/me/kyc", handler.GetMyCase)
+	router.POST("/api/v1/users/me/kyc/cases", handler.CreateCase)
+	router.POST("/api/v1/users/me/kyc/cases/:id/documents", handler.UploadDocument)
+	router.POST("/api/v1/users/me/kyc/cases/:id/submit", handler.Submit)
+	router.GET("/admin/kyc/cases", handler.ListCases)
+	router.GET("/admin/kyc/cases/:id/documents/:document_id", handler.DownloadDocument)
+	router.POST("/admin/kyc/cases/:id/assign", handler.Assign)
+	router.POST("/admin/kyc/cases/:id/approve", handler.Approve)
+	router.POST("/admin/kyc/cases/:id/reject", handler.Reject)
+	router.GET("/api/v1/users/me/entitlements", handler.GetMyEntitlements)
+	router.PUT("/admin/users/:id/kyc-tier", handler.SetTier)
+	router.GET("/admin/users/:id/kyc-tier/history", handler.GetTierHistory)
+	router.POST("/api/v1/users/me/kyc/cases/:id/verification", handler.StartVerification)
+	router.POST("/api/v1/webhooks/kyc/:provider", handler.ProviderWebhook)
+	router.GET("/admin/kyc/cases/:id/provider-report", handler.GetProviderReport)
+	return router
+}
+
+func sampleKYCCase(id uuid.UUID, status kyc.Status) *kyc.Case {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	return &kyc.Case{
+		ID:        id,
+		UserID:    kycTestActorID,
+		Status:    status,
+		Applicant: kyc.Applicant{FirstName: "Ada", LastName: "Lovelace"},
+		Version:   1,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func kycTestActor() kyc.Actor {
+	return kyc.Actor{ID: kycTestActorID, Email: "admin@test.com"}
+}
+
+// TestCreateKYCCase tests the CreateCase HTTP handler
+func TestCreateKYCCase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("opens a draft case", func(t *testing.T) {
+		id := uuid.New()
+		mockService := new(MockKYCService)
+		mockService.On("CreateCase", mock.Anything, kycTestActorID, mock.MatchedBy(func(a kyc.Applicant) bool {
+			return a.FirstName == "Ada" && a.LastName == "Lovelace"
+		})).Return(sampleKYCCase(id, kyc.StatusDraft), nil)
+		router := newKYCTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/kyc/cases",
+			bytes.NewReader([]byte(`{"first_name":"Ada","last_name":"Lovelace"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var resp httpTransport.KYCCaseDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, id, resp.ID)
+		assert.Equal(t, "draft", resp.Status)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("case already open", func(t *testing.T) {
+		mockService := new(MockKYCService)
+		mockService.On("CreateCase", mock.Anything, mock.Anything, mock.Anything).Return(nil, kyc.ErrCaseAlreadyOpen)
+		router := newKYCTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/kyc/cases", bytes.NewReader([]byte(`{}`)))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "kyc_case_conflict")
+	})
+}
+
+// TestGetMyKYCCase tests the GetMyCase HTTP handler
+func TestGetMyKYCCase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockKYCService)
+	mockService.On("GetMyCase", mock.Anything, kycTestActorID).Return(nil, kyc.ErrCaseNotFound)
+	router := newKYCTestRouter(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/kyc", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "kyc_case_not_found")
+}
+
+// TestUploadKYCDocument tests the UploadDocument HTTP handler
+func TestUploadKYCDocument(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	caseID := uuid.New()
+
+	multipartBody := func(t *testing.T) (*bytes.Buffer, string) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		require.NoError(t, writer.WriteField("type", "passport"))
+		part, err := writer.CreateFormFile("file", "passport.png")
+		require.NoError(t, err)
+		_, err = part.Write([]byte("\x89PNG\r\n\x1a\nimage"))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		return &body, writer.FormDataContentType()
+	}
+
+	t.Run("stores the document", func(t *testing.T) {
+		docID := uuid.New()
+		mockService := new(MockKYCService)
+		mockService.On("UploadDocument", mock.Anything, kycTestActorID, caseID, mock.MatchedBy(func(u kyc.DocumentUpload) bool {
+			return u.Type == kyc.DocumentPassport && u.FileName == "passport.png" && u.Content != nil
+		})).Return(&kyc.Document{ID: docID, CaseID: caseID, Type: kyc.DocumentPassport, StorageKey: "kyc-documents/secret"}, nil)
+		router := newKYCTestRouter(mockService)
+
+		body, contentType := multipartBody(t)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/kyc/cases/"+caseID.String()+"/documents", body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), docID.String())
+		assert.NotContains(t, w.Body.String(), "kyc-documents/secret", "storage keys are not exposed")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		router := newKYCTestRouter(new(MockKYCService))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/kyc/cases/"+caseID.String()+"/documents",
+			bytes.NewReader([]byte(`{}`)))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("document too large", func(t *testing.T) {
+		mockService := new(MockKYCService)
+		mockService.On("UploadDocument", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("%w: limit is 10485760 bytes", kyc.ErrDocumentTooLarge))
+		router := newKYCTestRouter(mockService)
+
+		body, contentType := multipartBody(t)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/kyc/cases/"+caseID.String()+"/documents", body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
+
+// TestSubmitKYCCase tests the Submit HTTP handler
+func TestSubmitKYCCase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	caseID := uuid.New()
+	mockService := new(MockKYCService)
+	mockService.On("Submit", mock.Anything, kycTestActorID, caseID).Return(nil, kyc.ErrMissingDocuments)
+	router := newKYCTestRouter(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/kyc/cases/"+caseID.String()+"/submit", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_kyc_request")
+}
+
+// TestListKYCCases tests the ListCases HTTP handler
+func TestListKYCCases(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("filters the queue", func(t *testing.T) {
+		mockService := new(MockKYCService)
+		mockService.On("ListCases", mock.Anything, mock.MatchedBy(func(f kyc.ListFilter) bool {
+			return f.Status != nil && *f.Status == kyc.StatusSubmitted &&
+				f.RiskLevel != nil && *f.RiskLevel == kyc.RiskHigh &&
+				f.ReviewerID == nil && f.Limit == 5 && f.Offset == 0
+		})).Return([]*kyc.Case{sampleKYCCase(uuid.New(), kyc.StatusSubmitted)}, int64(7), nil)
+		router := newKYCTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/kyc/cases?status=submitted&risk_level=high&limit=5", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp httpTransport.KYCCasesListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Len(t, resp.Cases, 1)
+		assert.Equal(t, int64(7), resp.Total)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		router := newKYCTestRouter(new(MockKYCService))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/kyc/cases?status=verified", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+// TestDownloadKYCDocument tests the DownloadDocument HTTP handler
+func TestDownloadKYCDocument(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	caseID, docID := uuid.New(), uuid.New()
+	content := []byte("%PDF-1.7 statement")
+	mockService := new(MockKYCService)
+	mockService.On("OpenDocument", mock.Anything, caseID, docID, kycTestActor()).Return(&kyc.Document{
+		ID:          docID,
+		CaseID:      caseID,
+		FileName:    "statement.pdf",
+		ContentType: "application/pdf",
+		SizeBytes:   int64(len(content)),
+		SHA256:      "abc123",
+	}, io.NopCloser(bytes.NewReader(content)), nil)
+	router := newKYCTestRouter(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/kyc/cases/"+caseID.String()+"/documents/"+docID.String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `"statement.pdf"`)
+	assert.Equal(t, content, w.Body.Bytes())
+	mockService.AssertExpectations(t)
+}
+
+// TestReviewKYCCase tests the Assign, Approve and Reject HTTP handlers
+func TestReviewKYCCase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	caseID := uuid.New()
+
+	t.Run("assign defaults to the acting admin", func(t *testing.T) {
+		mockService := new(MockKYCService)
+		mockService.On("Assign", mock.Anything, caseID, kycTestActorID, kycTestActor()).
+			Return(sampleKYCCase(caseID, kyc.StatusInReview), nil)
+		router := newKYCTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/kyc/cases/"+caseID.String()+"/assign", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("approval by the first approver again", func(t *testing.T) {
+		mockService := new(MockKYCService)
+		mockService.On("Approve", mock.Anything, caseID, "looks good", kycTestActor()).Return(nil, kyc.ErrFourEyesRequired)
+		router := newKYCTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/kyc/cases/"+caseID.String()+"/approve",
+			strings.NewReader(`{"note":"looks good"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "four_eyes_required")
+	})
+
+	t.Run("approval by another reviewer", func(t *testing.T) {
+		mockService := new(MockKYCService)
+		mockService.On("Approve", mock.Anything, caseID, "", kycTestActor()).Return(nil, kyc.ErrNotAssignedReviewer)
+		router := newKYCTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/kyc/cases/"+caseID.String()+"/approve", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("approval with uncleared screening matches", func(t *testing.T) {
+		mockService := new(MockKYCService)
+		mockService.On("Approve", mock.Anything, caseID, "", kycTestActor()).Return(nil, kyc.ErrScreeningHitsOpen)
+		router := newKYCTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/kyc/cases/"+caseID.String()+"/approve", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "screening_hits_uncleared")
+	})
+
+	t.Run("reject requires a reason", func(t *testing.T) {
+		router := newKYCTestRouter(new(MockKYCService))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/kyc/cases/"+caseID.String()+"/reject", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("reject with a reason code", func(t *testing.T) {
+		rejected := sampleKYCCase(caseID, kyc.StatusRejected)
+		reason := kyc.ReasonDocumentExpired
+		rejected.RejectionReason = &reason
+		mockService := new(MockKYCService)
+		mockService.On("Reject", mock.Anything, caseID, kyc.ReasonDocumentExpired, "", kycTestActor()).Return(rejected, nil)
+		router := newKYCTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/kyc/cases/"+caseID.String()+"/reject",
+			strings.NewReader(`{"reason":"document_expired"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "document_expired")
+		mockService.AssertExpectations(t)
+	})
+}
+
+// TestGetMyEntitlements tests the GetMyEntitlements HTTP handler
+func TestGetMyEntitlements(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockKYCService)
+	mockService.On("GetEntitlements", mock.Anything, kycTestActorID).Return(&kyc.Entitlements{
+		UserID:            kycTestActorID,
+		Tier:              kyc.TierBasic,
+		HeldTier:          kyc.TierBasic,
+		TierName:          "basic",
+		KYCStatus:         userDomain.KYCStatusVerified,
+		Active:            true,
+		Currency:          "EUR",
+		DailyDepositLimit: "2000",
+		Products:          []string{"spot"},
+	}, nil)
+	router := newKYCTestRouter(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/entitlements", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp httpTransport.EntitlementsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Tier)
+	assert.Equal(t, "2000", *resp.DailyDepositLimit)
+	assert.Nil(t, resp.DailyWithdrawalLimit, "unlimited amounts are null")
+	assert.Equal(t, []string{"spot"}, resp.Products)
+}
+
+// TestSetKYCTier tests the SetTier HTTP handler
+func TestSetKYCTier(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+
+	t.Run("assigns the tier", func(t *testing.T) {
+		mockService := new(MockKYCService)
+		mockService.On("SetTier", mock.Anything, userID, kyc.TierNone, "suspicious activity", kycTestActor()).
+			Return(&kyc.Entitlements{UserID: userID, TierName: "unverified", KYCStatus: userDomain.KYCStatusVerified}, nil)
+		router := newKYCTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPut, "/admin/users/"+userID.String()+"/kyc-tier",
+			strings.NewReader(`{"tier":0,"reason":"suspicious activity"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "unverified")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("tier out of range", func(t *testing.T) {
+		router := newKYCTestRouter(new(MockKYCService))
+
+		req := httptest.NewRequest(http.MethodPut, "/admin/users/"+userID.String()+"/kyc-tier",
+			strings.NewReader(`{"tier":4,"reason":"volume"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("user not verified", func(t *testing.T) {
+		mockService := new(MockKYCService)
+		mockService.On("SetTier", mock.Anything, userID, kyc.Tier(2), "volume", kycTestActor()).
+			Return(nil, kyc.ErrTierRequiresVerification)
+		router := newKYCTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPut, "/admin/users/"+userID.String()+"/kyc-tier",
+			strings.NewReader(`{"tier":2,"reason":"volume"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "kyc_tier_requires_verification")
+	})
+}
+
+// TestGetKYCTierHistory tests the GetTierHistory HTTP handler
+func TestGetKYCTierHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	caseID := uuid.New()
+	mockService := new(MockKYCService)
+	mockService.On("GetTierHistory", mock.Anything, userID).Return([]*kyc.TierChange{
+		{ID: uuid.New(), UserID: userID, OldTier: kyc.TierNone, NewTier: kyc.TierBasic, Reason: kyc.TierReasonKYCApproved, CaseID: &caseID},
+	}, nil)
+	router := newKYCTestRouter(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/users/"+userID.String()+"/kyc-tier/history", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp httpTransport.KYCTierHistoryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Changes, 1)
+	assert.Equal(t, 1, resp.Changes[0].NewTier)
+	assert.Equal(t, caseID, *resp.Changes[0].CaseID)
+}
+
+// TestStartKYCVerification tests the StartVerification HTTP handler
+func TestStartKYCVerification(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "returns the verification link", wantStatus: http.StatusOK, wantBody: "https://verify.example.com/app-1"},
+		{name: "no provider", err: kyc.ErrProviderNotConfigured, wantStatus: http.StatusNotFound, wantBody: "kyc_provider_not_configured"},
+		{name: "provider down", err: fmt.Errorf("%w: timeout", kyc.ErrProviderUnavailable), wantStatus: http.StatusServiceUnavailable, wantBody: "kyc_provider_unavailable"},
+		{name: "case already submitted", err: kyc.ErrCaseNotEditable, wantStatus: http.StatusConflict, wantBody: "kyc_case_conflict"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caseID := uuid.New()
+			mockService := new(MockKYCService)
+			if tt.err != nil {
+				mockService.On("StartVerification", mock.Anything, kycTestActorID, caseID).Return(nil, tt.err)
+			} else {
+				mockService.On("StartVerification", mock.Anything, kycTestActorID, caseID).Return(&kyc.VerificationLink{
+					Provider:  "simulator",
+					URL:       "https://verify.example.com/app-1",
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, nil)
+			}
+			router := newKYCTestRouter(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/kyc/cases/"+caseID.String()+"/verification", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+// TestKYCProviderWebhook tests the ProviderWebhook HTTP handler
+func TestKYCProviderWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	body := `{"applicant_id":"app-1","review_answer":"GREEN"}`
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "accepted", wantStatus: http.StatusNoContent},
+		{name: "bad signature", err: kyc.ErrInvalidProviderWebhook, wantStatus: http.StatusUnauthorized},
+		{name: "unknown provider", err: kyc.ErrProviderNotConfigured, wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockKYCService)
+			mockService.On("HandleProviderWebhook", mock.Anything, "simulator", mock.MatchedBy(func(h http.Header) bool {
+				return h.Get("X-Kyc-Simulator-Signature") == "v1=abc"
+			}), []byte(body)).Return(tt.err)
+			router := newKYCTestRouter(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/kyc/simulator", strings.NewReader(body))
+			req.Header.Set("X-Kyc-Simulator-Signature", "v1=abc")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+
+	t.Run("oversized body", func(t *testing.T) {
+		router := newKYCTestRouter(new(MockKYCService))
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/kyc/simulator", bytes.NewReader(make([]byte, 2<<20)))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
+
+// TestGetKYCProviderReport tests the GetProviderReport HTTP handler
+func TestGetKYCProviderReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	caseID := uuid.New()
+	mockService := new(MockKYCService)
+	mockService.On("GetProviderReport", mock.Anything, caseID).Return(&kyc.ProviderReport{
+		Provider:    "simulator",
+		ApplicantID: "app-1",
+		Outcome:     kyc.ProviderRejected,
+		Reason:      kyc.ReasonDocumentMismatch,
+		Checks:      []kyc.ProviderCheck{{Name: "document", Result: "consider"}},
+		Raw:         json.RawMessage(`{"review_answer":"RED"}`),
+	}, nil)
+	router := newKYCTestRouter(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/kyc/cases/"+caseID.String()+"/provider-report", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp httpTransport.KYCProviderReportResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "rejected", resp.Outcome)
+	assert.Equal(t, "document_mismatch", resp.Reason)
+	require.Len(t, resp.Checks, 1)
+	assert.JSONEq(t, `{"review_answer":"RED"}`, string(resp.Raw))
+}
@@ -0,0 +1,222 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// LegalHoldHandler handles admin requests for legal holds.
+type LegalHoldHandler struct {
+	holdService audit.LegalHoldService
+	logger      *observability.Logger
+}
+
+// NewLegalHoldHandler creates a new LegalHoldHandler instance.
+func NewLegalHoldHandler(holdService audit.LegalHoldService, logger *observability.Logger) *LegalHoldHandler {
+	return &LegalHoldHandler{
+		holdService: holdService,
+		logger:      logger,
+	}
+}
+
+// CreateHold handles POST /admin/legal-holds
+// Places a legal hold scoped to a user, a date range and/or an event category.
+func (h *LegalHoldHandler) CreateHold(c *gin.Context) {
+	var req CreateLegalHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid create legal hold request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	actor := GetAdminActorFromContext(c)
+	h.logger.WithField("actor", actor).Info("Admin: Processing create legal hold request")
+
+	hold := &audit.LegalHold{
+		UserID:    req.UserID,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		Reason:    req.Reason,
+		Owner:     req.Owner,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if req.EventCategory != nil {
+		category := audit.EventCategory(*req.EventCategory)
+		hold.EventCategory = &category
+	}
+
+	created, err := h.holdService.PlaceHold(c.Request.Context(), hold, actor)
+	if err != nil {
+		h.respondHoldError(c, err, "Failed to place legal hold")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toLegalHoldDTO(created))
+}
+
+// ListHolds handles GET /admin/legal-holds
+// Lists legal holds, newest first. Pass active=true to leave out released and expired holds.
+func (h *LegalHoldHandler) ListHolds(c *gin.Context) {
+	var req ListLegalHoldsRequest
+	req.Limit = 20 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid list legal holds request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	holds, err := h.holdService.ListHolds(c.Request.Context(), req.Active, int32(req.Limit), int32(req.Offset)) // #nosec G115 -- bounded by binding (max=100)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list legal holds")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to retrieve legal holds",
+		})
+		return
+	}
+
+	dtos := make([]LegalHoldDTO, len(holds))
+	for i, hold := range holds {
+		dtos[i] = toLegalHoldDTO(hold)
+	}
+
+	c.JSON(http.StatusOK, LegalHoldsListResponse{
+		Holds:  dtos,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+}
+
+// GetHold handles GET /admin/legal-holds/:id
+func (h *LegalHoldHandler) GetHold(c *gin.Context) {
+	id, ok := h.parseHoldID(c)
+	if !ok {
+		return
+	}
+
+	hold, err := h.holdService.GetHold(c.Request.Context(), id)
+	if err != nil {
+		h.respondHoldError(c, err, "Failed to retrieve legal hold")
+		return
+	}
+
+	c.JSON(http.StatusOK, toLegalHoldDTO(hold))
+}
+
+// UpdateHold handles PUT /admin/legal-holds/:id
+// Changes the reason, owner and expiry of an unreleased hold. The scope cannot be changed.
+func (h *LegalHoldHandler) UpdateHold(c *gin.Context) {
+	id, ok := h.parseHoldID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateLegalHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid update legal hold request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	actor := GetAdminActorFromContext(c)
+	h.logger.WithFields(map[string]interface{}{
+		"hold_id": id.String(),
+		"actor":   actor,
+	}).Info("Admin: Processing update legal hold request")
+
+	hold, err := h.holdService.UpdateHold(c.Request.Context(), id, req.Reason, req.Owner, req.ExpiresAt, actor)
+	if err != nil {
+		h.respondHoldError(c, err, "Failed to update legal hold")
+		return
+	}
+
+	c.JSON(http.StatusOK, toLegalHoldDTO(hold))
+}
+
+// ReleaseHold handles POST /admin/legal-holds/:id/release
+func (h *LegalHoldHandler) ReleaseHold(c *gin.Context) {
+	id, ok := h.parseHoldID(c)
+	if !ok {
+		return
+	}
+
+	var req ReleaseLegalHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid release legal hold request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	actor := GetAdminActorFromContext(c)
+	h.logger.WithFields(map[string]interface{}{
+		"hold_id": id.String(),
+		"actor":   actor,
+	}).Info("Admin: Processing release legal hold request")
+
+	hold, err := h.holdService.ReleaseHold(c.Request.Context(), id, req.Reason, actor)
+	if err != nil {
+		h.respondHoldError(c, err, "Failed to release legal hold")
+		return
+	}
+
+	c.JSON(http.StatusOK, toLegalHoldDTO(hold))
+}
+
+// parseHoldID reads the :id path parameter, responding with 400 if it is not a UUID
+func (h *LegalHoldHandler) parseHoldID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_hold_id",
+			Message: "Invalid legal hold ID format",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondHoldError maps legal hold domain errors to HTTP responses
+func (h *LegalHoldHandler) respondHoldError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, audit.ErrInvalidLegalHold):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_legal_hold",
+			Message: err.Error(),
+		})
+	case errors.Is(err, audit.ErrLegalHoldNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "legal_hold_not_found",
+			Message: "Legal hold not found",
+		})
+	case errors.Is(err, audit.ErrLegalHoldReleased):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "legal_hold_released",
+			Message: "Legal hold has already been released",
+		})
+	default:
+		h.logger.WithError(err).Error(message)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: message,
+		})
+	}
+}
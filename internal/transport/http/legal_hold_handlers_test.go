@@ -0,0 +1,303 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
+	httpTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/http"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLegalHoldService is a mock implementation of audit.LegalHoldService
+type MockLegalHoldService struct {
+	mock.Mock
+}
+
+func (m *MockLegalHoldService) PlaceHold(ctx context.Context, hold *audit.LegalHold, actor string) (*audit.LegalHold, error) {
+	args := m.Called(ctx, hold, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.LegalHold), args.Error(1)
+}
+
+func (m *MockLegalHoldService) GetHold(ctx context.Context, id uuid.UUID) (*audit.LegalHold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.LegalHold), args.Error(1)
+}
+
+func (m *MockLegalHoldService) ListHolds(ctx context.Context, activeOnly bool, limit, offset int32) ([]*audit.LegalHold, error) {
+	args := m.Called(ctx, activeOnly, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*audit.LegalHold), args.Error(1)
+}
+
+func (m *MockLegalHoldService) UpdateHold(ctx context.Context, id uuid.UUID, reason, owner string, expiresAt time.Time, actor string) (*audit.LegalHold, error) {
+	args := m.Called(ctx, id, reason, owner, expiresAt, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.LegalHold), args.Error(1)
+}
+
+func (m *MockLegalHoldService) ReleaseHold(ctx context.Context, id uuid.UUID, reason, actor string) (*audit.LegalHold, error) {
+	args := m.Called(ctx, id, reason, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.LegalHold), args.Error(1)
+}
+
+func (m *MockLegalHoldService) IsUserOnHold(ctx context.Context, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLegalHoldService) IsRangeOnHold(ctx context.Context, start, end time.Time) (bool, error) {
+	args := m.Called(ctx, start, end)
+	return args.Bool(0), args.Error(1)
+}
+
+func newLegalHoldTestRouter(svc *MockLegalHoldService) *gin.Engine {
+	handler := httpTransport.NewLegalHoldHandler(svc, getTestLogger())
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uuid.New())
+		c.Set("email", "admin@test.com")
+		c.Next()
+	})
+	router.POST("/admin/legal-holds", handler.CreateHold)
+	router.GET("/admin/legal-holds", handler.ListHolds)
+	router.GET("/admin/legal-holds/:id", handler.GetHold)
+	router.PUT("/admin/legal-holds/:id", handler.UpdateHold)
+	router.POST("/admin/legal-holds/:id/release", handler.ReleaseHold)
+	return router
+}
+
+// TestCreateLegalHold tests the CreateHold HTTP handler
+func TestCreateLegalHold(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	expiresAt := time.Now().Add(365 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	testCases := []struct {
+		name           string
+		body           map[string]interface{}
+		mockSetup      func(m *MockLegalHoldService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "place user hold successfully",
+			body: map[string]interface{}{
+				"user_id":    userID.String(),
+				"reason":     "Regulator inquiry",
+				"owner":      "legal",
+				"expires_at": expiresAt.Format(time.RFC3339),
+			},
+			mockSetup: func(m *MockLegalHoldService) {
+				m.On("PlaceHold", mock.Anything, mock.MatchedBy(func(h *audit.LegalHold) bool {
+					return *h.UserID == userID && h.ExpiresAt.Equal(expiresAt)
+				}), "admin@test.com").Return(&audit.LegalHold{
+					ID:        uuid.New(),
+					UserID:    &userID,
+					Reason:    "Regulator inquiry",
+					Owner:     "legal",
+					ExpiresAt: expiresAt,
+					CreatedBy: "admin@test.com",
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "missing reason",
+			body: map[string]interface{}{
+				"user_id":    userID.String(),
+				"owner":      "legal",
+				"expires_at": expiresAt.Format(time.RFC3339),
+			},
+			mockSetup:      func(m *MockLegalHoldService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_request",
+		},
+		{
+			name: "domain validation error",
+			body: map[string]interface{}{
+				"reason":     "No scope",
+				"owner":      "legal",
+				"expires_at": expiresAt.Format(time.RFC3339),
+			},
+			mockSetup: func(m *MockLegalHoldService) {
+				m.On("PlaceHold", mock.Anything, mock.Anything, "admin@test.com").
+					Return(nil, fmt.Errorf("%w: scope required", audit.ErrInvalidLegalHold))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_legal_hold",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockLegalHoldService)
+			tc.mockSetup(mockService)
+			router := newLegalHoldTestRouter(mockService)
+
+			body, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/admin/legal-holds", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			var resp map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			assert.NoError(t, err)
+			if tc.expectedError != "" {
+				assert.Equal(t, tc.expectedError, resp["error"])
+			} else {
+				assert.Equal(t, true, resp["active"])
+				assert.Equal(t, userID.String(), resp["user_id"])
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestListLegalHolds tests the ListHolds HTTP handler
+func TestListLegalHolds(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockLegalHoldService)
+	mockService.On("ListHolds", mock.Anything, true, int32(20), int32(0)).Return([]*audit.LegalHold{
+		{ID: uuid.New(), Reason: "Inquiry", Owner: "legal", ExpiresAt: time.Now().Add(time.Hour)},
+	}, nil)
+	router := newLegalHoldTestRouter(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/legal-holds?active=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp["holds"].([]interface{}), 1)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetLegalHold tests the GetHold HTTP handler
+func TestGetLegalHold(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	id := uuid.New()
+
+	t.Run("not found", func(t *testing.T) {
+		mockService := new(MockLegalHoldService)
+		mockService.On("GetHold", mock.Anything, id).Return(nil, audit.ErrLegalHoldNotFound)
+		router := newLegalHoldTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/legal-holds/"+id.String(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "legal_hold_not_found")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		router := newLegalHoldTestRouter(new(MockLegalHoldService))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/legal-holds/not-a-uuid", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_hold_id")
+	})
+}
+
+// TestUpdateLegalHold tests the UpdateHold HTTP handler
+func TestUpdateLegalHold(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	id := uuid.New()
+	expiresAt := time.Now().Add(730 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	mockService := new(MockLegalHoldService)
+	mockService.On("UpdateHold", mock.Anything, id, "Extended", "legal", mock.MatchedBy(func(t time.Time) bool {
+		return t.Equal(expiresAt)
+	}), "admin@test.com").Return(nil, audit.ErrLegalHoldReleased)
+	router := newLegalHoldTestRouter(mockService)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"reason":     "Extended",
+		"owner":      "legal",
+		"expires_at": expiresAt.Format(time.RFC3339),
+	})
+	req := httptest.NewRequest(http.MethodPut, "/admin/legal-holds/"+id.String(), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "legal_hold_released")
+	mockService.AssertExpectations(t)
+}
+
+// TestReleaseLegalHold tests the ReleaseHold HTTP handler
+func TestReleaseLegalHold(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	id := uuid.New()
+	now := time.Now()
+	releasedBy := "admin@test.com"
+
+	t.Run("release successfully", func(t *testing.T) {
+		mockService := new(MockLegalHoldService)
+		mockService.On("ReleaseHold", mock.Anything, id, "Inquiry closed", releasedBy).Return(&audit.LegalHold{
+			ID:         id,
+			ExpiresAt:  now.Add(time.Hour),
+			ReleasedAt: &now,
+			ReleasedBy: &releasedBy,
+		}, nil)
+		router := newLegalHoldTestRouter(mockService)
+
+		body, _ := json.Marshal(map[string]string{"reason": "Inquiry closed"})
+		req := httptest.NewRequest(http.MethodPost, "/admin/legal-holds/"+id.String()+"/release", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, false, resp["active"])
+		assert.Equal(t, releasedBy, resp["released_by"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("reason required", func(t *testing.T) {
+		router := newLegalHoldTestRouter(new(MockLegalHoldService))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/legal-holds/"+id.String()+"/release", bytes.NewReader([]byte(`{}`)))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
 	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -17,8 +19,15 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// AccountStatusChecker reports whether a user's account status lets them use their sessions.
+type AccountStatusChecker interface {
+	CheckAccountAccess(ctx context.Context, id uuid.UUID) error
+}
+
 // AuthMiddleware provides JWT authentication for protected routes.
-func AuthMiddleware(jwtManager *auth.JWTManager, logger *observability.Logger) gin.HandlerFunc {
+// Valid tokens of suspended, closed or deleted accounts are refused, so a status change
+// takes effect before the access token expires.
+func AuthMiddleware(jwtManager *auth.JWTManager, statuses AccountStatusChecker, logger *observability.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Extract token from Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -60,6 +69,37 @@ func AuthMiddleware(jwtManager *auth.JWTManager, logger *observability.Logger) g
 			return
 		}
 
+		if err := statuses.CheckAccountAccess(c.Request.Context(), claims.UserID); err != nil {
+			logger.WithFields(map[string]interface{}{
+				"user_id": claims.UserID,
+				"error":   err.Error(),
+			}).Warn("Access token refused by account status")
+			switch {
+			case errors.Is(err, userDomain.ErrAccountSuspended):
+				c.JSON(http.StatusForbidden, ErrorResponse{
+					Error:   "account_suspended",
+					Message: "account is suspended",
+				})
+			case errors.Is(err, userDomain.ErrAccountClosed):
+				c.JSON(http.StatusForbidden, ErrorResponse{
+					Error:   "account_closed",
+					Message: "account is closed",
+				})
+			case errors.Is(err, userDomain.ErrNotFound):
+				c.JSON(http.StatusUnauthorized, ErrorResponse{
+					Error:   "unauthorized",
+					Message: "account no longer exists",
+				})
+			default:
+				c.JSON(http.StatusInternalServerError, ErrorResponse{
+					Error:   "internal_error",
+					Message: "failed to check account status",
+				})
+			}
+			c.Abort()
+			return
+		}
+
 		// Set user ID and email in context for handlers
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
@@ -143,9 +183,31 @@ func TracingMiddleware(serviceName string) gin.HandlerFunc {
 	return otelgin.Middleware(serviceName)
 }
 
+// RequestMetadataMiddleware attaches the client IP, user agent and request ID to the request
+// context, where services read them to annotate the domain events they publish
+func RequestMetadataMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader("X-Request-ID")
+		if requestID == "" {
+			requestID = c.GetString("request_id")
+		}
+
+		ctx := common.ContextWithRequestMetadata(c.Request.Context(), common.RequestMetadata{
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			RequestID: requestID,
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
 // AuditMiddleware logs HTTP requests to the audit_logs table
 // Should be placed after AuthMiddleware to capture user information
 func AuditMiddleware(auditRepo audit.Repository, cfg *config.Config, logger *observability.Logger) gin.HandlerFunc {
+	policy := retentionPolicyFromConfig(cfg, logger)
+
 	return func(c *gin.Context) {
 		// Capture start time
 		startTime := time.Now()
@@ -160,7 +222,7 @@ func AuditMiddleware(auditRepo audit.Repository, cfg *config.Config, logger *obs
 
 		// Create audit log asynchronously to avoid blocking the response
 		go func() {
-			if err := createAuditLog(c, auditRepo, cfg, startTime, logger); err != nil {
+			if err := createAuditLog(c, auditRepo, policy, startTime, logger); err != nil {
 				logger.WithError(err).Error("Failed to create audit log")
 			}
 		}()
@@ -184,8 +246,35 @@ func shouldSkipAudit(path string) bool {
 	return false
 }
 
+// redactedQueryParams are query parameters carrying credentials, such as data export download tokens
+var redactedQueryParams = map[string]bool{
+	"token": true,
+}
+
+// redactQuery replaces the values of credential query parameters so they are not stored in audit logs
+func redactQuery(rawQuery string) string {
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		if name, _, found := strings.Cut(param, "="); found && redactedQueryParams[name] {
+			params[i] = name + "=[REDACTED]"
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// retentionPolicyFromConfig builds the retention policy applied to new audit logs.
+// config.Validate rejects invalid policies at startup, so the fallback only guards hand-built configs.
+func retentionPolicyFromConfig(cfg *config.Config, logger *observability.Logger) *audit.RetentionPolicy {
+	policy, err := cfg.Audit.RetentionPolicy()
+	if err != nil {
+		logger.WithError(err).Error("Invalid audit retention policy, retaining all audit logs for 90 days")
+		policy, _ = audit.NewRetentionPolicy(90, nil)
+	}
+	return policy
+}
+
 // createAuditLog builds and stores the audit log entry
-func createAuditLog(c *gin.Context, auditRepo audit.Repository, cfg *config.Config, startTime time.Time, logger *observability.Logger) error {
+func createAuditLog(c *gin.Context, auditRepo audit.Repository, policy *audit.RetentionPolicy, startTime time.Time, logger *observability.Logger) error {
 	// Extract user information from context (set by AuthMiddleware)
 	var userID *uuid.UUID
 	var actorType audit.ActorType
@@ -236,7 +325,7 @@ func createAuditLog(c *gin.Context, auditRepo audit.Repository, cfg *config.Conf
 
 	// Add query parameters if present
 	if len(c.Request.URL.RawQuery) > 0 {
-		metadata["query"] = c.Request.URL.RawQuery
+		metadata["query"] = redactQuery(c.Request.URL.RawQuery)
 	}
 
 	// Add request ID if present
@@ -251,13 +340,6 @@ func createAuditLog(c *gin.Context, auditRepo audit.Repository, cfg *config.Conf
 	// Get user agent
 	userAgent := c.Request.UserAgent()
 
-	// Calculate retention date based on environment
-	retentionDays := cfg.Audit.RetentionDays
-	if retentionDays == 0 {
-		retentionDays = 90 // Default fallback
-	}
-	retentionUntil := time.Now().Add(time.Duration(retentionDays) * 24 * time.Hour)
-
 	// Create audit log
 	auditLog := &audit.Log{
 		EventType:      eventType,
@@ -272,10 +354,13 @@ func createAuditLog(c *gin.Context, auditRepo audit.Repository, cfg *config.Conf
 		UserAgent:      &userAgent,
 		RequestID:      stringPtr(requestID),
 		Metadata:       metadata,
-		RetentionUntil: &retentionUntil,
 		IsSensitive:    isSensitiveEndpoint(c.Request.URL.Path),
 	}
 
+	// Calculate retention date from the policy rule matching the event
+	retentionUntil := policy.RetentionUntil(auditLog, time.Now())
+	auditLog.RetentionUntil = &retentionUntil
+
 	// Store audit log
 	_, err := auditRepo.Create(c.Request.Context(), auditLog)
 	return err
@@ -313,6 +398,14 @@ func categorizeRequest(c *gin.Context) (string, audit.EventCategory) {
 		return "user.kyc_view", audit.CategoryDataAccess
 	}
 
+	// Data subject access exports
+	if strings.Contains(path, "/data-export") {
+		if method == "POST" {
+			return "user.data_export_request", audit.CategoryCompliance
+		}
+		return "user.data_export_view", audit.CategoryDataAccess
+	}
+
 	// User management
 	if strings.Contains(path, "/users") {
 		switch method {
@@ -394,6 +487,7 @@ func isSensitiveEndpoint(path string) bool {
 		"/auth/login",
 		"/auth/register",
 		"/kyc",
+		"/data-export",
 		"/admin",
 	}
 
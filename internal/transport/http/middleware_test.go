@@ -0,0 +1,70 @@
+package http_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	httpTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/http"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuthMiddleware_AccountStatus tests that valid tokens are refused by account status
+func TestAuthMiddleware_AccountStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	jwtManager, err := auth.NewJWTManager("test-secret-key-must-be-at-least-32-characters-long", 15*time.Minute, 7*24*time.Hour)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		checkErr       error
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "active account passes", expectedStatus: http.StatusOK},
+		{name: "suspended account is refused", checkErr: userDomain.ErrAccountSuspended, expectedStatus: http.StatusForbidden, expectedError: "account_suspended"},
+		{name: "closed account is refused", checkErr: userDomain.ErrAccountClosed, expectedStatus: http.StatusForbidden, expectedError: "account_closed"},
+		{name: "deleted account is refused", checkErr: userDomain.ErrNotFound, expectedStatus: http.StatusUnauthorized, expectedError: "unauthorized"},
+		{name: "status check failure", checkErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError, expectedError: "internal_error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			token, err := jwtManager.GenerateAccessToken(userID, "jane@example.com", string(userDomain.RoleUser))
+			require.NoError(t, err)
+
+			mockService := new(MockUserService)
+			mockService.On("CheckAccountAccess", mock.Anything, userID).Return(tc.checkErr)
+
+			router := gin.New()
+			router.Use(httpTransport.AuthMiddleware(jwtManager, mockService, getTestLogger()))
+			router.GET("/protected", func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"status": "ok"})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedError != "" {
+				var response map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tc.expectedError, response["error"])
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
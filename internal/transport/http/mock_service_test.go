@@ -5,6 +5,7 @@ import (
 
 	userDomain "github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/stats"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
@@ -80,18 +81,27 @@ func (m *MockUserService) GetByEmail(ctx context.Context, email string) (*userDo
 	return args.Get(0).(*userDomain.User), args.Error(1)
 }
 
-// UpdateKYC mocks the UpdateKYC method
-func (m *MockUserService) UpdateKYC(ctx context.Context, userID uuid.UUID, status userDomain.KYCStatus) (*userDomain.User, error) {
-	args := m.Called(ctx, userID, status)
+// UpdateProfile mocks the UpdateProfile method
+func (m *MockUserService) UpdateProfile(ctx context.Context, userID uuid.UUID, update userDomain.ProfileUpdate) (*userDomain.User, error) {
+	args := m.Called(ctx, userID, update)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*userDomain.User), args.Error(1)
 }
 
-// UpdateProfile mocks the UpdateProfile method
-func (m *MockUserService) UpdateProfile(ctx context.Context, userID uuid.UUID, firstName, lastName string) (*userDomain.User, error) {
-	args := m.Called(ctx, userID, firstName, lastName)
+// StartPhoneVerification mocks the StartPhoneVerification method
+func (m *MockUserService) StartPhoneVerification(ctx context.Context, userID uuid.UUID) (*userDomain.PhoneVerification, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.PhoneVerification), args.Error(1)
+}
+
+// ConfirmPhone mocks the ConfirmPhone method
+func (m *MockUserService) ConfirmPhone(ctx context.Context, userID uuid.UUID, code string) (*userDomain.User, error) {
+	args := m.Called(ctx, userID, code)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -104,6 +114,21 @@ func (m *MockUserService) DeleteAccount(ctx context.Context, userID uuid.UUID) e
 	return args.Error(0)
 }
 
+// CheckAccountAccess mocks the CheckAccountAccess method
+func (m *MockUserService) CheckAccountAccess(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// RestoreAccount mocks the RestoreAccount method
+func (m *MockUserService) RestoreAccount(ctx context.Context, token string) (*userDomain.User, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
 // GetActiveSessions mocks the GetActiveSessions method
 func (m *MockUserService) GetActiveSessions(ctx context.Context, userID uuid.UUID) ([]*auth.RefreshToken, error) {
 	args := m.Called(ctx, userID)
@@ -120,12 +145,39 @@ func (m *MockUserService) ListUsers(ctx context.Context, limit, offset int) ([]*
 }
 
 // SearchUsers mocks the SearchUsers method
-func (m *MockUserService) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*userDomain.User, error) {
-	args := m.Called(ctx, query, limit, offset)
+func (m *MockUserService) SearchUsers(ctx context.Context, search userDomain.UserSearch) (*userDomain.UserSearchPage, error) {
+	args := m.Called(ctx, search)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*userDomain.User), args.Error(1)
+	return args.Get(0).(*userDomain.UserSearchPage), args.Error(1)
+}
+
+// SetAccountStatus mocks the SetAccountStatus method
+func (m *MockUserService) SetAccountStatus(ctx context.Context, id uuid.UUID, update userDomain.AccountStatusUpdate, adminID uuid.UUID) (*userDomain.User, error) {
+	args := m.Called(ctx, id, update, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+// GetAccountStatusHistory mocks the GetAccountStatusHistory method
+func (m *MockUserService) GetAccountStatusHistory(ctx context.Context, id uuid.UUID) ([]*userDomain.AccountStatusChange, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*userDomain.AccountStatusChange), args.Error(1)
+}
+
+// AdminRestoreAccount mocks the AdminRestoreAccount method
+func (m *MockUserService) AdminRestoreAccount(ctx context.Context, id, adminID uuid.UUID) (*userDomain.User, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
 }
 
 // GetUserByIDAdmin mocks the GetUserByIDAdmin method
@@ -159,10 +211,10 @@ func (m *MockUserService) ForceLogout(ctx context.Context, token string) error {
 }
 
 // GetSystemStats mocks the GetSystemStats method
-func (m *MockUserService) GetSystemStats(ctx context.Context) (map[string]interface{}, error) {
-	args := m.Called(ctx)
+func (m *MockUserService) GetSystemStats(ctx context.Context, query stats.Query) (*stats.Dashboard, error) {
+	args := m.Called(ctx, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(map[string]interface{}), args.Error(1)
+	return args.Get(0).(*stats.Dashboard), args.Error(1)
 }
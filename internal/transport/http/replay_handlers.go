@@ -0,0 +1,206 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/replay"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReplayHandler handles admin requests for event replay jobs.
+type ReplayHandler struct {
+	replayService replay.Service
+	logger        *observability.Logger
+}
+
+// NewReplayHandler creates a new ReplayHandler instance.
+func NewReplayHandler(replayService replay.Service, logger *observability.Logger) *ReplayHandler {
+	return &ReplayHandler{
+		replayService: replayService,
+		logger:        logger,
+	}
+}
+
+// CreateJob handles POST /admin/events/replays
+// Queues a replay of snapshot events built from current user state, or of outbox or audit
+// history for a time range, to a target stream. The job runs in the background; poll
+// GET /admin/events/replays/:id for progress.
+func (h *ReplayHandler) CreateJob(c *gin.Context) {
+	var req CreateReplayJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid create replay job request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	actor := GetAdminActorFromContext(c)
+	h.logger.WithFields(map[string]interface{}{
+		"source":        req.Source,
+		"target_stream": req.TargetStream,
+		"actor":         actor,
+	}).Info("Admin: Processing create replay job request")
+
+	job, err := h.replayService.CreateJob(c.Request.Context(), &replay.Request{
+		Source:       replay.Source(req.Source),
+		EventTypes:   req.EventTypes,
+		From:         req.From,
+		To:           req.To,
+		TargetStream: req.TargetStream,
+		RateLimit:    req.RateLimit,
+		BatchSize:    req.BatchSize,
+		RequestedBy:  actor,
+	})
+	if err != nil {
+		h.respondReplayError(c, err, "Failed to create replay job")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toReplayJobDTO(job))
+}
+
+// ListJobs handles GET /admin/events/replays
+// Lists replay jobs, newest first.
+func (h *ReplayHandler) ListJobs(c *gin.Context) {
+	var req ListReplayJobsRequest
+	req.Limit = 20 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid list replay jobs request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	jobs, err := h.replayService.ListJobs(c.Request.Context(), int32(req.Limit), int32(req.Offset)) // #nosec G115 -- bounded by binding (max=100)
+	if err != nil {
+		h.respondReplayError(c, err, "Failed to retrieve replay jobs")
+		return
+	}
+
+	dtos := make([]ReplayJobDTO, len(jobs))
+	for i, job := range jobs {
+		dtos[i] = toReplayJobDTO(job)
+	}
+
+	c.JSON(http.StatusOK, ReplayJobsListResponse{
+		Jobs:   dtos,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+}
+
+// GetJob handles GET /admin/events/replays/:id
+func (h *ReplayHandler) GetJob(c *gin.Context) {
+	id, ok := h.parseJobID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.replayService.GetJob(c.Request.Context(), id)
+	if err != nil {
+		h.respondReplayError(c, err, "Failed to retrieve replay job")
+		return
+	}
+
+	c.JSON(http.StatusOK, toReplayJobDTO(job))
+}
+
+// CancelJob handles POST /admin/events/replays/:id/cancel
+// Stops a pending or running job; a running job stops after the page it is publishing.
+func (h *ReplayHandler) CancelJob(c *gin.Context) {
+	id, ok := h.parseJobID(c)
+	if !ok {
+		return
+	}
+
+	actor := GetAdminActorFromContext(c)
+	h.logger.WithFields(map[string]interface{}{
+		"job_id": id.String(),
+		"actor":  actor,
+	}).Info("Admin: Processing cancel replay job request")
+
+	job, err := h.replayService.CancelJob(c.Request.Context(), id, actor)
+	if err != nil {
+		h.respondReplayError(c, err, "Failed to cancel replay job")
+		return
+	}
+
+	c.JSON(http.StatusOK, toReplayJobDTO(job))
+}
+
+// ResumeJob handles POST /admin/events/replays/:id/resume
+// Queues a failed or cancelled job again; it continues from its last checkpoint.
+func (h *ReplayHandler) ResumeJob(c *gin.Context) {
+	id, ok := h.parseJobID(c)
+	if !ok {
+		return
+	}
+
+	actor := GetAdminActorFromContext(c)
+	h.logger.WithFields(map[string]interface{}{
+		"job_id": id.String(),
+		"actor":  actor,
+	}).Info("Admin: Processing resume replay job request")
+
+	job, err := h.replayService.ResumeJob(c.Request.Context(), id, actor)
+	if err != nil {
+		h.respondReplayError(c, err, "Failed to resume replay job")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toReplayJobDTO(job))
+}
+
+// parseJobID reads the :id path parameter, responding with 400 if it is not a UUID
+func (h *ReplayHandler) parseJobID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_replay_job_id",
+			Message: "Invalid replay job ID format",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondReplayError maps replay domain errors to HTTP responses
+func (h *ReplayHandler) respondReplayError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, replay.ErrInvalidJob):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_replay_job",
+			Message: err.Error(),
+		})
+	case errors.Is(err, replay.ErrJobNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "replay_job_not_found",
+			Message: "Replay job not found",
+		})
+	case errors.Is(err, replay.ErrJobNotCancellable):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "replay_job_not_cancellable",
+			Message: "Replay job has already finished",
+		})
+	case errors.Is(err, replay.ErrJobNotResumable):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "replay_job_not_resumable",
+			Message: "Only failed or cancelled replay jobs can be resumed",
+		})
+	default:
+		h.logger.WithError(err).Error(message)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: message,
+		})
+	}
+}
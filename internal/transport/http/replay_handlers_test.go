@@ -0,0 +1,320 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/replay"
+	httpTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/http"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockReplayService is a mock implementation of replay.Service
+type MockReplayService struct {
+	mock.Mock
+}
+
+func (m *MockReplayService) CreateJob(ctx context.Context, req *replay.Request) (*replay.Job, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*replay.Job), args.Error(1)
+}
+
+func (m *MockReplayService) GetJob(ctx context.Context, id uuid.UUID) (*replay.Job, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*replay.Job), args.Error(1)
+}
+
+func (m *MockReplayService) ListJobs(ctx context.Context, limit, offset int32) ([]*replay.Job, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*replay.Job), args.Error(1)
+}
+
+func (m *MockReplayService) CancelJob(ctx context.Context, id uuid.UUID, actor string) (*replay.Job, error) {
+	args := m.Called(ctx, id, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*replay.Job), args.Error(1)
+}
+
+func (m *MockReplayService) ResumeJob(ctx context.Context, id uuid.UUID, actor string) (*replay.Job, error) {
+	args := m.Called(ctx, id, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*replay.Job), args.Error(1)
+}
+
+func newReplayTestRouter(svc *MockReplayService) *gin.Engine {
+	handler := httpTransport.NewReplayHandler(svc, getTestLogger())
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uuid.New())
+		c.Set("email", "admin@test.com")
+		c.Next()
+	})
+	router.POST("/admin/events/replays", handler.CreateJob)
+	router.GET("/admin/events/replays", handler.ListJobs)
+	router.GET("/admin/events/replays/:id", handler.GetJob)
+	router.POST("/admin/events/replays/:id/cancel", handler.CancelJob)
+	router.POST("/admin/events/replays/:id/resume", handler.ResumeJob)
+	return router
+}
+
+func sampleReplayJob(id uuid.UUID, status replay.Status) *replay.Job {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	return &replay.Job{
+		ID:               id,
+		Source:           replay.SourceSnapshot,
+		EventTypes:       []string{"user.registered"},
+		TargetStream:     "user-service:replay:billing",
+		RateLimit:        100,
+		BatchSize:        100,
+		Status:           status,
+		TotalRecords:     400,
+		ProcessedRecords: 100,
+		PublishedEvents:  100,
+		RequestedBy:      "admin@test.com",
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// TestCreateReplayJob tests the CreateJob HTTP handler
+func TestCreateReplayJob(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("queues a job for the admin", func(t *testing.T) {
+		id := uuid.New()
+		mockService := new(MockReplayService)
+		mockService.On("CreateJob", mock.Anything, mock.MatchedBy(func(r *replay.Request) bool {
+			return r.Source == replay.SourceSnapshot &&
+				r.TargetStream == "user-service:replay:billing" &&
+				r.RequestedBy == "admin@test.com" &&
+				r.From != nil && r.To == nil &&
+				len(r.EventTypes) == 2
+		})).Return(sampleReplayJob(id, replay.StatusPending), nil)
+		router := newReplayTestRouter(mockService)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"source":        "snapshot",
+			"event_types":   []string{"user.registered", "user.kyc.updated"},
+			"from":          "2024-01-01T00:00:00Z",
+			"target_stream": "user-service:replay:billing",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/admin/events/replays", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var resp httpTransport.ReplayJobDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, id, resp.ID)
+		assert.Equal(t, "pending", resp.Status)
+		assert.Equal(t, 0.25, resp.Progress)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unknown source", func(t *testing.T) {
+		router := newReplayTestRouter(new(MockReplayService))
+
+		body, _ := json.Marshal(map[string]string{"source": "kafka", "target_stream": "replay"})
+		req := httptest.NewRequest(http.MethodPost, "/admin/events/replays", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_request")
+	})
+
+	t.Run("rejected by the service", func(t *testing.T) {
+		mockService := new(MockReplayService)
+		mockService.On("CreateJob", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: from must be before to", replay.ErrInvalidJob))
+		router := newReplayTestRouter(mockService)
+
+		body, _ := json.Marshal(map[string]string{
+			"source":        "outbox",
+			"from":          "2024-02-01T00:00:00Z",
+			"to":            "2024-01-01T00:00:00Z",
+			"target_stream": "replay",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/admin/events/replays", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_replay_job")
+		assert.Contains(t, w.Body.String(), "from must be before to")
+	})
+}
+
+// TestListReplayJobs tests the ListJobs HTTP handler
+func TestListReplayJobs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockReplayService)
+	mockService.On("ListJobs", mock.Anything, int32(5), int32(10)).Return([]*replay.Job{sampleReplayJob(uuid.New(), replay.StatusRunning)}, nil)
+	router := newReplayTestRouter(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/events/replays?limit=5&offset=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp httpTransport.ReplayJobsListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Jobs, 1)
+	assert.Equal(t, "running", resp.Jobs[0].Status)
+	assert.Equal(t, 5, resp.Limit)
+	mockService.AssertExpectations(t)
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/events/replays?limit=500", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// TestGetReplayJob tests the GetJob HTTP handler
+func TestGetReplayJob(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	id := uuid.New()
+
+	t.Run("found", func(t *testing.T) {
+		mockService := new(MockReplayService)
+		mockService.On("GetJob", mock.Anything, id).Return(sampleReplayJob(id, replay.StatusRunning), nil)
+		router := newReplayTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/events/replays/"+id.String(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp httpTransport.ReplayJobDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, int64(400), resp.TotalRecords)
+		assert.Equal(t, int64(100), resp.ProcessedRecords)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockService := new(MockReplayService)
+		mockService.On("GetJob", mock.Anything, id).Return(nil, replay.ErrJobNotFound)
+		router := newReplayTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/events/replays/"+id.String(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "replay_job_not_found")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		router := newReplayTestRouter(new(MockReplayService))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/events/replays/not-a-uuid", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_replay_job_id")
+	})
+}
+
+// TestCancelAndResumeReplayJob tests the CancelJob and ResumeJob HTTP handlers
+func TestCancelAndResumeReplayJob(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	id := uuid.New()
+
+	testCases := []struct {
+		name           string
+		path           string
+		mockSetup      func(m *MockReplayService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "cancel",
+			path: "/cancel",
+			mockSetup: func(m *MockReplayService) {
+				m.On("CancelJob", mock.Anything, id, "admin@test.com").Return(sampleReplayJob(id, replay.StatusCancelled), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "cancel finished job",
+			path: "/cancel",
+			mockSetup: func(m *MockReplayService) {
+				m.On("CancelJob", mock.Anything, id, "admin@test.com").Return(nil, replay.ErrJobNotCancellable)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "replay_job_not_cancellable",
+		},
+		{
+			name: "resume",
+			path: "/resume",
+			mockSetup: func(m *MockReplayService) {
+				m.On("ResumeJob", mock.Anything, id, "admin@test.com").Return(sampleReplayJob(id, replay.StatusPending), nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name: "resume completed job",
+			path: "/resume",
+			mockSetup: func(m *MockReplayService) {
+				m.On("ResumeJob", mock.Anything, id, "admin@test.com").Return(nil, replay.ErrJobNotResumable)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "replay_job_not_resumable",
+		},
+		{
+			name: "service error",
+			path: "/resume",
+			mockSetup: func(m *MockReplayService) {
+				m.On("ResumeJob", mock.Anything, id, "admin@test.com").Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "internal_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockReplayService)
+			tc.mockSetup(mockService)
+			router := newReplayTestRouter(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/events/replays/"+id.String()+tc.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tc.expectedError)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
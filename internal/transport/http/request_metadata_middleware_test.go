@@ -0,0 +1,57 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestMetadataMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(req *http.Request) common.RequestMetadata {
+		var captured common.RequestMetadata
+		router := gin.New()
+		router.Use(RequestMetadataMiddleware())
+		router.POST("/users/me/logout", func(c *gin.Context) {
+			captured = common.RequestMetadataFromContext(c.Request.Context())
+			c.Status(http.StatusNoContent)
+		})
+		router.ServeHTTP(httptest.NewRecorder(), req)
+		return captured
+	}
+
+	t.Run("attaches client IP, user agent and request ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/users/me/logout", nil)
+		req.RemoteAddr = "203.0.113.7:41000"
+		req.Header.Set("User-Agent", "pandora-ios/2.1")
+		req.Header.Set("X-Request-ID", "req-123")
+
+		metadata := serve(req)
+
+		assert.Equal(t, common.RequestMetadata{
+			IPAddress: "203.0.113.7",
+			UserAgent: "pandora-ios/2.1",
+			RequestID: "req-123",
+		}, metadata)
+		assert.Equal(t, map[string]string{
+			"ip_address": "203.0.113.7",
+			"user_agent": "pandora-ios/2.1",
+			"request_id": "req-123",
+		}, metadata.Fields())
+	})
+
+	t.Run("omits missing fields", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/users/me/logout", nil)
+		req.RemoteAddr = "203.0.113.7:41000"
+
+		metadata := serve(req)
+
+		assert.Empty(t, metadata.RequestID)
+		assert.Equal(t, map[string]string{"ip_address": "203.0.113.7"}, metadata.Fields())
+	})
+}
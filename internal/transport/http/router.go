@@ -4,9 +4,15 @@ import (
 	"regexp"
 
 	"github.com/alex-necsoiu/pandora-exchange/internal/config"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/alert"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/audit"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/auth"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/dataexport"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/kyc"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/replay"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
 	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
 	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
 	grpcTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/grpc"
 	"github.com/gin-gonic/gin"
@@ -39,6 +45,30 @@ func ValidateParamMiddleware(param string, re *regexp.Regexp) gin.HandlerFunc {
 	}
 }
 
+// UserRouterOption configures optional user router features.
+type UserRouterOption func(*userRouterOptions)
+
+type userRouterOptions struct {
+	kycService        kyc.Service
+	dataExportService dataexport.Service
+}
+
+// WithKYCService mounts the applicant KYC endpoints under /api/v1/users/me/kyc,
+// GET /api/v1/users/me/entitlements and the provider webhook under /api/v1/webhooks/kyc.
+func WithKYCService(svc kyc.Service) UserRouterOption {
+	return func(o *userRouterOptions) {
+		o.kycService = svc
+	}
+}
+
+// WithDataExportService mounts the data export endpoints under /api/v1/users/me/data-export
+// and the public download endpoints under /api/v1/data-exports.
+func WithDataExportService(svc dataexport.Service) UserRouterOption {
+	return func(o *userRouterOptions) {
+		o.dataExportService = svc
+	}
+}
+
 // SetupUserRouter configures and returns a Gin router for user-facing endpoints only.
 func SetupUserRouter(
 	userService user.Service,
@@ -48,7 +78,13 @@ func SetupUserRouter(
 	logger *observability.Logger,
 	mode string, // "release" or "debug"
 	tracingEnabled bool,
+	opts ...UserRouterOption,
 ) *gin.Engine {
+	var options userRouterOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// Set Gin mode
 	if mode == "release" {
 		gin.SetMode(gin.ReleaseMode)
@@ -63,6 +99,9 @@ func SetupUserRouter(
 	if tracingEnabled {
 		router.Use(TracingMiddleware("user-service"))
 	}
+
+	// Request metadata middleware - exposes client IP, user agent and request ID to services
+	router.Use(RequestMetadataMiddleware())
 	
 	// Audit middleware - logs all requests to audit_logs table
 	router.Use(AuditMiddleware(auditRepo, cfg, logger))
@@ -91,29 +130,146 @@ func SetupUserRouter(
 			auth.POST("/register", handler.Register)
 			auth.POST("/login", handler.Login)
 			auth.POST("/refresh", handler.RefreshToken)
+			// Authenticated by the signed token in the restore link
+			auth.POST("/restore", handler.RestoreAccount)
+		}
+
+		// Provider decision webhooks authenticate by signature, not by token
+		if options.kycService != nil {
+			v1.POST("/webhooks/kyc/:provider", NewKYCHandler(options.kycService, logger).ProviderWebhook)
+		}
+
+		// Export downloads authenticate by the short-lived token in the link
+		if options.dataExportService != nil {
+			exportHandler := NewDataExportHandler(options.dataExportService, logger)
+			v1.GET("/data-exports/download", exportHandler.Download)
+			v1.GET("/data-exports/signing-key", exportHandler.GetSigningKey)
 		}
 
 		// Protected user routes (authentication required)
 		users := v1.Group("/users")
-		users.Use(AuthMiddleware(jwtManager, logger))
+		users.Use(AuthMiddleware(jwtManager, userService, logger))
 		{
 			// Current user endpoints
 			users.GET("/me", handler.GetProfile)
-			users.PUT("/me", handler.UpdateProfile)
+			users.PUT("/me", handler.UpdateProfile) // deprecated: use PATCH /me
+			users.PATCH("/me", handler.PatchProfile)
+			users.POST("/me/phone/verification", handler.StartPhoneVerification)
+			users.POST("/me/phone/verification/confirm", handler.ConfirmPhone)
 			users.DELETE("/me", handler.DeleteAccount)
 			users.GET("/me/sessions", handler.GetActiveSessions)
 			users.POST("/me/logout", handler.Logout)
 			users.POST("/me/logout-all", handler.LogoutAll)
 
-			// KYC update (only numeric/uuid id allowed) - validate id param
 			uuidRe := regexp.MustCompile(`^[a-f0-9-]{36}$`)
-			users.PUT("/:id/kyc", ValidateParamMiddleware("id", uuidRe), AdminMiddleware(logger), handler.UpdateKYC)
+
+			// KYC case routes are only mounted when the KYC workflow is wired in
+			if options.kycService != nil {
+				kycHandler := NewKYCHandler(options.kycService, logger)
+
+				users.GET("/me/kyc", kycHandler.GetMyCase)
+				users.POST("/me/kyc/cases", kycHandler.CreateCase)
+				users.PUT("/me/kyc/cases/:id/applicant", ValidateParamMiddleware("id", uuidRe), kycHandler.UpdateApplicant)
+				users.POST("/me/kyc/cases/:id/documents", ValidateParamMiddleware("id", uuidRe), kycHandler.UploadDocument)
+				users.POST("/me/kyc/cases/:id/submit", ValidateParamMiddleware("id", uuidRe), kycHandler.Submit)
+				users.POST("/me/kyc/cases/:id/verification", ValidateParamMiddleware("id", uuidRe), kycHandler.StartVerification)
+				users.GET("/me/entitlements", kycHandler.GetMyEntitlements)
+			}
+
+			if options.dataExportService != nil {
+				exportHandler := NewDataExportHandler(options.dataExportService, logger)
+
+				users.POST("/me/data-export", exportHandler.RequestMyExport)
+				users.GET("/me/data-export", exportHandler.ListMyExports)
+				users.GET("/me/data-export/:id", ValidateParamMiddleware("id", uuidRe), exportHandler.GetMyExport)
+				users.POST("/me/data-export/:id/download-link", ValidateParamMiddleware("id", uuidRe), exportHandler.CreateDownloadLink)
+			}
 		}
 	}
 
 	return router
 }
 
+// AdminRouterOption configures optional admin router features.
+type AdminRouterOption func(*adminRouterOptions)
+
+type adminRouterOptions struct {
+	auditArchiveService audit.ArchiveService
+	legalHoldService    audit.LegalHoldService
+	retentionService    audit.RetentionService
+	alertService        alert.Service
+	replayService       replay.Service
+	webhookService      webhook.Service
+	kycService          kyc.Service
+	screeningService    screening.Service
+	dataExportService   dataexport.Service
+}
+
+// WithAuditArchiveService mounts the audit archive endpoints under /admin/audit/archives.
+func WithAuditArchiveService(svc audit.ArchiveService) AdminRouterOption {
+	return func(o *adminRouterOptions) {
+		o.auditArchiveService = svc
+	}
+}
+
+// WithLegalHoldService mounts the legal hold endpoints under /admin/legal-holds.
+func WithLegalHoldService(svc audit.LegalHoldService) AdminRouterOption {
+	return func(o *adminRouterOptions) {
+		o.legalHoldService = svc
+	}
+}
+
+// WithAuditRetentionService mounts the audit retention endpoints under /admin/audit/retention.
+func WithAuditRetentionService(svc audit.RetentionService) AdminRouterOption {
+	return func(o *adminRouterOptions) {
+		o.retentionService = svc
+	}
+}
+
+// WithAlertService mounts the security alert endpoints under /admin/alerts.
+func WithAlertService(svc alert.Service) AdminRouterOption {
+	return func(o *adminRouterOptions) {
+		o.alertService = svc
+	}
+}
+
+// WithReplayService mounts the event replay endpoints under /admin/events/replays.
+func WithReplayService(svc replay.Service) AdminRouterOption {
+	return func(o *adminRouterOptions) {
+		o.replayService = svc
+	}
+}
+
+// WithWebhookService mounts the webhook subscription endpoints under /admin/webhooks.
+func WithWebhookService(svc webhook.Service) AdminRouterOption {
+	return func(o *adminRouterOptions) {
+		o.webhookService = svc
+	}
+}
+
+// WithKYCReviewService mounts the KYC review endpoints under /admin/kyc/cases and the
+// tier endpoints under /admin/users/:id.
+func WithKYCReviewService(svc kyc.Service) AdminRouterOption {
+	return func(o *adminRouterOptions) {
+		o.kycService = svc
+	}
+}
+
+// WithScreeningService mounts the sanctions and PEP screening endpoints under
+// /admin/screening and POST /admin/users/:id/screen.
+func WithScreeningService(svc screening.Service) AdminRouterOption {
+	return func(o *adminRouterOptions) {
+		o.screeningService = svc
+	}
+}
+
+// WithAdminDataExportService mounts the data export endpoints under /admin/users/:id.
+func WithAdminDataExportService(svc dataexport.Service) AdminRouterOption {
+	return func(o *adminRouterOptions) {
+		o.dataExportService = svc
+	}
+}
+
 // SetupAdminRouter configures and returns a Gin router for admin-only endpoints.
 // This router is intended to be started as a separate HTTP server (different port) so
 // admin routes never share the same server instance or path space with user routes.
@@ -126,7 +282,13 @@ func SetupAdminRouter(
 	mode string,
 	tracingEnabled bool,
 	registry ServiceRegistry,
+	opts ...AdminRouterOption,
 ) *gin.Engine {
+	var options adminRouterOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	if mode == "release" {
 		gin.SetMode(gin.ReleaseMode)
 	}
@@ -140,6 +302,9 @@ func SetupAdminRouter(
 	if tracingEnabled {
 		router.Use(TracingMiddleware("admin-service"))
 	}
+
+	// Request metadata middleware - exposes client IP, user agent and request ID to services
+	router.Use(RequestMetadataMiddleware())
 	
 	// Audit middleware - logs all requests to audit_logs table (CRITICAL for admin actions)
 	router.Use(AuditMiddleware(auditRepo, cfg, logger))
@@ -172,7 +337,7 @@ func SetupAdminRouter(
 	// Admin routes are mounted under /admin to keep separation of concerns.
 	// All routes require authentication + admin role.
 	admin := router.Group("/admin")
-	admin.Use(AuthMiddleware(jwtManager, logger))
+	admin.Use(AuthMiddleware(jwtManager, userService, logger))
 	admin.Use(AdminMiddleware(logger))
 	{
 		// Validate UUID params using a conservative regex
@@ -182,11 +347,111 @@ func SetupAdminRouter(
 		admin.GET("/users/search", adminHandler.SearchUsers)
 		admin.GET("/users/:id", ValidateParamMiddleware("id", uuidRe), adminHandler.GetUser)
 		admin.PUT("/users/:id/role", ValidateParamMiddleware("id", uuidRe), adminHandler.UpdateUserRole)
+		admin.POST("/users/:id/restore", ValidateParamMiddleware("id", uuidRe), adminHandler.RestoreUser)
+		admin.PUT("/users/:id/status", ValidateParamMiddleware("id", uuidRe), adminHandler.SetUserStatus)
+		admin.GET("/users/:id/status/history", ValidateParamMiddleware("id", uuidRe), adminHandler.GetUserStatusHistory)
 
 		admin.GET("/sessions", adminHandler.GetAllSessions)
 		admin.POST("/sessions/revoke", adminHandler.ForceLogout)
 
 		admin.GET("/stats", adminHandler.GetSystemStats)
+		admin.GET("/stats/export", adminHandler.ExportSystemStats)
+
+		// Audit archive routes are only mounted when archival is wired in
+		if options.auditArchiveService != nil {
+			archiveHandler := NewAuditArchiveHandler(options.auditArchiveService, logger)
+			monthRe := regexp.MustCompile(`^\d{4}-\d{2}$`)
+
+			admin.GET("/audit/archives", archiveHandler.ListArchives)
+			admin.POST("/audit/archives/:month/restore", ValidateParamMiddleware("month", monthRe), archiveHandler.RestoreArchive)
+			admin.POST("/audit/archives/:month/release", ValidateParamMiddleware("month", monthRe), archiveHandler.ReleaseArchive)
+		}
+
+		if options.retentionService != nil {
+			retentionHandler := NewAuditRetentionHandler(options.retentionService, logger)
+
+			admin.GET("/audit/retention/policy", retentionHandler.GetPolicy)
+			admin.GET("/audit/retention/dry-run", retentionHandler.DryRun)
+			admin.POST("/audit/retention/restamp", retentionHandler.Restamp)
+		}
+
+		if options.legalHoldService != nil {
+			holdHandler := NewLegalHoldHandler(options.legalHoldService, logger)
+
+			admin.POST("/legal-holds", holdHandler.CreateHold)
+			admin.GET("/legal-holds", holdHandler.ListHolds)
+			admin.GET("/legal-holds/:id", ValidateParamMiddleware("id", uuidRe), holdHandler.GetHold)
+			admin.PUT("/legal-holds/:id", ValidateParamMiddleware("id", uuidRe), holdHandler.UpdateHold)
+			admin.POST("/legal-holds/:id/release", ValidateParamMiddleware("id", uuidRe), holdHandler.ReleaseHold)
+		}
+
+		if options.alertService != nil {
+			alertHandler := NewAlertHandler(options.alertService, logger)
+
+			admin.GET("/alerts", alertHandler.ListAlerts)
+			admin.GET("/alerts/:id", ValidateParamMiddleware("id", uuidRe), alertHandler.GetAlert)
+			admin.POST("/alerts/:id/acknowledge", ValidateParamMiddleware("id", uuidRe), alertHandler.AcknowledgeAlert)
+		}
+
+		if options.replayService != nil {
+			replayHandler := NewReplayHandler(options.replayService, logger)
+
+			admin.POST("/events/replays", replayHandler.CreateJob)
+			admin.GET("/events/replays", replayHandler.ListJobs)
+			admin.GET("/events/replays/:id", ValidateParamMiddleware("id", uuidRe), replayHandler.GetJob)
+			admin.POST("/events/replays/:id/cancel", ValidateParamMiddleware("id", uuidRe), replayHandler.CancelJob)
+			admin.POST("/events/replays/:id/resume", ValidateParamMiddleware("id", uuidRe), replayHandler.ResumeJob)
+		}
+
+		if options.webhookService != nil {
+			webhookHandler := NewWebhookHandler(options.webhookService, logger)
+
+			admin.POST("/webhooks", webhookHandler.CreateSubscription)
+			admin.GET("/webhooks", webhookHandler.ListSubscriptions)
+			admin.GET("/webhooks/:id", ValidateParamMiddleware("id", uuidRe), webhookHandler.GetSubscription)
+			admin.PATCH("/webhooks/:id", ValidateParamMiddleware("id", uuidRe), webhookHandler.UpdateSubscription)
+			admin.DELETE("/webhooks/:id", ValidateParamMiddleware("id", uuidRe), webhookHandler.DeleteSubscription)
+			admin.GET("/webhooks/:id/deliveries", ValidateParamMiddleware("id", uuidRe), webhookHandler.ListDeliveries)
+			admin.POST("/webhooks/:id/deliveries/:delivery_id/redeliver",
+				ValidateParamMiddleware("id", uuidRe), ValidateParamMiddleware("delivery_id", uuidRe), webhookHandler.Redeliver)
+		}
+
+		if options.kycService != nil {
+			kycHandler := NewKYCHandler(options.kycService, logger)
+
+			admin.GET("/kyc/cases", kycHandler.ListCases)
+			admin.GET("/kyc/cases/:id", ValidateParamMiddleware("id", uuidRe), kycHandler.GetCase)
+			admin.GET("/kyc/cases/:id/history", ValidateParamMiddleware("id", uuidRe), kycHandler.GetHistory)
+			admin.GET("/kyc/cases/:id/documents/:document_id",
+				ValidateParamMiddleware("id", uuidRe), ValidateParamMiddleware("document_id", uuidRe), kycHandler.DownloadDocument)
+			admin.POST("/kyc/cases/:id/assign", ValidateParamMiddleware("id", uuidRe), kycHandler.Assign)
+			admin.PUT("/kyc/cases/:id/risk", ValidateParamMiddleware("id", uuidRe), kycHandler.SetRiskLevel)
+			admin.POST("/kyc/cases/:id/approve", ValidateParamMiddleware("id", uuidRe), kycHandler.Approve)
+			admin.POST("/kyc/cases/:id/reject", ValidateParamMiddleware("id", uuidRe), kycHandler.Reject)
+			admin.POST("/kyc/cases/:id/request-info", ValidateParamMiddleware("id", uuidRe), kycHandler.RequestMoreInfo)
+			admin.GET("/kyc/cases/:id/provider-report", ValidateParamMiddleware("id", uuidRe), kycHandler.GetProviderReport)
+			admin.GET("/users/:id/entitlements", ValidateParamMiddleware("id", uuidRe), kycHandler.GetEntitlements)
+			admin.PUT("/users/:id/kyc-tier", ValidateParamMiddleware("id", uuidRe), kycHandler.SetTier)
+			admin.GET("/users/:id/kyc-tier/history", ValidateParamMiddleware("id", uuidRe), kycHandler.GetTierHistory)
+		}
+
+		if options.screeningService != nil {
+			screeningHandler := NewScreeningHandler(options.screeningService, logger)
+
+			admin.GET("/screening/lists", screeningHandler.GetWatchLists)
+			admin.GET("/screening/cases", screeningHandler.ListCases)
+			admin.GET("/screening/cases/:id", ValidateParamMiddleware("id", uuidRe), screeningHandler.GetCase)
+			admin.POST("/screening/cases/:id/clear", ValidateParamMiddleware("id", uuidRe), screeningHandler.Clear)
+			admin.POST("/screening/cases/:id/confirm", ValidateParamMiddleware("id", uuidRe), screeningHandler.Confirm)
+			admin.POST("/users/:id/screen", ValidateParamMiddleware("id", uuidRe), screeningHandler.ScreenUser)
+		}
+
+		if options.dataExportService != nil {
+			exportHandler := NewDataExportHandler(options.dataExportService, logger)
+
+			admin.POST("/users/:id/data-export", ValidateParamMiddleware("id", uuidRe), exportHandler.RequestUserExport)
+			admin.GET("/users/:id/data-exports", ValidateParamMiddleware("id", uuidRe), exportHandler.ListUserExports)
+		}
 	}
 
 	return router
@@ -134,12 +134,6 @@ func TestSetupUserRouter(t *testing.T) {
 			path:   "/api/v1/users/me/logout-all",
 			description: "Protected logout all endpoint",
 		},
-		{
-			name:   "update KYC route exists",
-			method: "PUT",
-			path:   "/api/v1/users/" + uuid.New().String() + "/kyc",
-			description: "Protected admin KYC update endpoint with UUID validation",
-		},
 	}
 
 	for _, tc := range testCases {
@@ -229,6 +223,12 @@ func TestSetupAdminRouter(t *testing.T) {
 			path:   "/admin/stats",
 			description: "Protected admin system stats endpoint",
 		},
+		{
+			name:   "export system stats route exists",
+			method: "GET",
+			path:   "/admin/stats/export",
+			description: "Protected admin system stats CSV export endpoint",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -305,6 +305,14 @@ func TestRouterSeparation(t *testing.T) {
 			shouldExist: false,
 			description: "Health check should only be on user router",
 		},
+		{
+			name:        "KYC status override not routed",
+			router:      userRouter,
+			method:      "PUT",
+			path:        "/api/v1/users/" + uuid.New().String() + "/kyc",
+			shouldExist: false,
+			description: "KYC status is derived from KYC cases and cannot be set directly",
+		},
 	}
 
 	for _, tc := range testCases {
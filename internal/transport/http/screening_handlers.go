@@ -0,0 +1,250 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/user"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ScreeningHandler handles the compliance review of sanctions and PEP screening matches on
+// the admin router.
+type ScreeningHandler struct {
+	screeningService screening.Service
+	logger           *observability.Logger
+}
+
+// NewScreeningHandler creates a new ScreeningHandler instance.
+func NewScreeningHandler(screeningService screening.Service, logger *observability.Logger) *ScreeningHandler {
+	return &ScreeningHandler{
+		screeningService: screeningService,
+		logger:           logger,
+	}
+}
+
+// ListCases handles GET /admin/screening/cases
+// Lists screening cases, oldest first, filtered by status or user.
+func (h *ScreeningHandler) ListCases(c *gin.Context) {
+	var req ListScreeningCasesRequest
+	req.Limit = 20 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid list screening cases request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	filter := screening.ListFilter{
+		Limit:  int32(req.Limit),  // #nosec G115 -- bounded by binding (max=100)
+		Offset: int32(req.Offset), // #nosec G115 -- bounded by binding (min=0)
+	}
+	if req.Status != "" {
+		status := screening.Status(req.Status)
+		filter.Status = &status
+	}
+	if req.UserID != "" {
+		userID := uuid.MustParse(req.UserID)
+		filter.UserID = &userID
+	}
+
+	cases, total, err := h.screeningService.ListCases(c.Request.Context(), filter)
+	if err != nil {
+		h.respondScreeningError(c, err, "Failed to retrieve screening cases")
+		return
+	}
+
+	dtos := make([]ScreeningCaseDTO, len(cases))
+	for i, screeningCase := range cases {
+		dtos[i] = toScreeningCaseDTO(screeningCase)
+	}
+
+	c.JSON(http.StatusOK, ScreeningCasesListResponse{
+		Cases:  dtos,
+		Total:  total,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+}
+
+// GetCase handles GET /admin/screening/cases/:id
+func (h *ScreeningHandler) GetCase(c *gin.Context) {
+	caseID, ok := h.parseCaseID(c)
+	if !ok {
+		return
+	}
+
+	screeningCase, err := h.screeningService.GetCase(c.Request.Context(), caseID)
+	if err != nil {
+		h.respondScreeningError(c, err, "Failed to retrieve screening case")
+		return
+	}
+
+	c.JSON(http.StatusOK, toScreeningCaseDTO(screeningCase))
+}
+
+// Clear handles POST /admin/screening/cases/:id/clear
+// Closes the case as a false positive; the note explaining why is required.
+func (h *ScreeningHandler) Clear(c *gin.Context) {
+	caseID, actor, req, ok := h.decisionContext(c)
+	if !ok {
+		return
+	}
+
+	screeningCase, err := h.screeningService.Clear(c.Request.Context(), caseID, req.Note, actor)
+	h.respondDecision(c, screeningCase, err, "Failed to clear screening case")
+}
+
+// Confirm handles POST /admin/screening/cases/:id/confirm
+// Closes the case as a true match; the user's KYC approval stays blocked.
+func (h *ScreeningHandler) Confirm(c *gin.Context) {
+	caseID, actor, req, ok := h.decisionContext(c)
+	if !ok {
+		return
+	}
+
+	screeningCase, err := h.screeningService.Confirm(c.Request.Context(), caseID, req.Note, actor)
+	h.respondDecision(c, screeningCase, err, "Failed to confirm screening case")
+}
+
+// ScreenUser handles POST /admin/users/:id/screen
+// Screens the user against the current lists. Responds with 201 and the opened case, or 200
+// and a null case when the user matched nothing new.
+func (h *ScreeningHandler) ScreenUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_user_id",
+			Message: "Invalid user ID format",
+		})
+		return
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"user_id": userID.String(),
+		"actor":   GetAdminActorFromContext(c),
+	}).Info("Admin: Screening user")
+
+	screeningCase, err := h.screeningService.ScreenUser(c.Request.Context(), userID, screening.TriggerManual)
+	if err != nil {
+		h.respondScreeningError(c, err, "Failed to screen user")
+		return
+	}
+	if screeningCase == nil {
+		c.JSON(http.StatusOK, ScreenUserResponse{})
+		return
+	}
+
+	dto := toScreeningCaseDTO(screeningCase)
+	c.JSON(http.StatusCreated, ScreenUserResponse{Case: &dto})
+}
+
+// GetWatchLists handles GET /admin/screening/lists
+// Returns the version and size of the lists users are screened against.
+func (h *ScreeningHandler) GetWatchLists(c *gin.Context) {
+	list := h.screeningService.WatchList()
+	if list == nil {
+		h.respondScreeningError(c, screening.ErrListsNotLoaded, "Screening watch lists are not loaded")
+		return
+	}
+
+	c.JSON(http.StatusOK, toWatchListsResponse(list))
+}
+
+// decisionContext reads the case ID, the acting admin and the body of a review decision
+func (h *ScreeningHandler) decisionContext(c *gin.Context) (uuid.UUID, screening.Actor, ScreeningDecisionRequest, bool) {
+	var req ScreeningDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid screening decision request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return uuid.Nil, screening.Actor{}, req, false
+	}
+	caseID, ok := h.parseCaseID(c)
+	if !ok {
+		return uuid.Nil, screening.Actor{}, req, false
+	}
+	adminID, err := GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Authentication required",
+		})
+		return uuid.Nil, screening.Actor{}, req, false
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"case_id": caseID.String(),
+		"path":    c.FullPath(),
+		"actor":   GetAdminActorFromContext(c),
+	}).Info("Admin: Processing screening decision")
+	return caseID, screening.Actor{ID: adminID, Email: c.GetString("email")}, req, true
+}
+
+func (h *ScreeningHandler) respondDecision(c *gin.Context, screeningCase *screening.Case, err error, message string) {
+	if err != nil {
+		h.respondScreeningError(c, err, message)
+		return
+	}
+	c.JSON(http.StatusOK, toScreeningCaseDTO(screeningCase))
+}
+
+// parseCaseID reads the :id path parameter, responding with 400 if it is not a UUID
+func (h *ScreeningHandler) parseCaseID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_screening_case_id",
+			Message: "Invalid screening case ID format",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondScreeningError maps screening domain errors to HTTP responses
+func (h *ScreeningHandler) respondScreeningError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, screening.ErrCaseNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "screening_case_not_found",
+			Message: "Screening case not found",
+		})
+	case errors.Is(err, user.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "user_not_found",
+			Message: "User not found",
+		})
+	case errors.Is(err, screening.ErrInvalidDecision):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_screening_request",
+			Message: err.Error(),
+		})
+	case errors.Is(err, screening.ErrCaseAlreadyDecided), errors.Is(err, screening.ErrCaseConflict):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "screening_case_conflict",
+			Message: err.Error(),
+		})
+	case errors.Is(err, screening.ErrListsNotLoaded):
+		h.logger.WithError(err).Error(message)
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "screening_unavailable",
+			Message: "Screening watch lists are not loaded, please try again later",
+		})
+	default:
+		h.logger.WithError(err).Error(message)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: message,
+		})
+	}
+}
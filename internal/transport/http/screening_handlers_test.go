@@ -0,0 +1,290 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	httpTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/http"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockScreeningService is a mock implementation of screening.Service
+type MockScreeningService struct {
+	mock.Mock
+}
+
+func (m *MockScreeningService) ScreenUser(ctx context.Context, userID uuid.UUID, trigger screening.Trigger) (*screening.Case, error) {
+	args := m.Called(ctx, userID, trigger)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*screening.Case), args.Error(1)
+}
+
+func (m *MockScreeningService) ApprovalBlocked(ctx context.Context, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockScreeningService) ListCases(ctx context.Context, filter screening.ListFilter) ([]*screening.Case, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*screening.Case), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockScreeningService) GetCase(ctx context.Context, caseID uuid.UUID) (*screening.Case, error) {
+	args := m.Called(ctx, caseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*screening.Case), args.Error(1)
+}
+
+func (m *MockScreeningService) Clear(ctx context.Context, caseID uuid.UUID, note string, actor screening.Actor) (*screening.Case, error) {
+	args := m.Called(ctx, caseID, note, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*screening.Case), args.Error(1)
+}
+
+func (m *MockScreeningService) Confirm(ctx context.Context, caseID uuid.UUID, note string, actor screening.Actor) (*screening.Case, error) {
+	args := m.Called(ctx, caseID, note, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*screening.Case), args.Error(1)
+}
+
+func (m *MockScreeningService) WatchList() *screening.WatchList {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*screening.WatchList)
+}
+
+func newScreeningTestRouter(svc *MockScreeningService) *gin.Engine {
+	handler := httpTransport.NewScreeningHandler(svc, getTestLogger())
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", kycTestActorID)
+		c.Set("email", "admin@test.com")
+		c.Next()
+	})
+	router.GET("/admin/screening/lists", handler.GetWatchLists)
+	router.GET("/admin/screening/cases", handler.ListCases)
+	router.GET("/admin/screening/cases/:id", handler.GetCase)
+	router.POST("/admin/screening/cases/:id/clear", handler.Clear)
+	router.POST("/admin/screening/cases/:id/confirm", handler.Confirm)
+	router.POST("/admin/users/:id/screen", handler.ScreenUser)
+	return router
+}
+
+func sampleScreeningCase(id uuid.UUID, status screening.Status) *screening.Case {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	return &screening.Case{
+		ID:      id,
+		UserID:  userID,
+		Status:  status,
+		Trigger: screening.TriggerRegistration,
+		Subject: screening.Subject{UserID: userID, Names: []string{"Abu Abbas"}},
+		Matches: []screening.Match{{
+			Source:      screening.SourceOFACSDN,
+			EntryID:     "2674",
+			Kind:        screening.KindSanctions,
+			ListedName:  "ABBAS, Abu",
+			MatchedName: "Abu Abbas",
+			Score:       1,
+			Programs:    []string{"SDGT"},
+		}},
+		ListVersion: "0123456789abcdef",
+		Version:     1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// TestListScreeningCases tests the ListCases HTTP handler
+func TestListScreeningCases(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("filters the queue", func(t *testing.T) {
+		mockService := new(MockScreeningService)
+		mockService.On("ListCases", mock.Anything, mock.MatchedBy(func(f screening.ListFilter) bool {
+			return f.Status != nil && *f.Status == screening.StatusOpen && f.UserID == nil && f.Limit == 20
+		})).Return([]*screening.Case{sampleScreeningCase(uuid.New(), screening.StatusOpen)}, int64(3), nil)
+		router := newScreeningTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/screening/cases?status=open", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp httpTransport.ScreeningCasesListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Cases, 1)
+		assert.Equal(t, int64(3), resp.Total)
+		assert.Equal(t, "ofac_sdn", resp.Cases[0].Matches[0].Source)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		router := newScreeningTestRouter(new(MockScreeningService))
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/screening/cases?status=pending", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+// TestDecideScreeningCase tests the Clear and Confirm HTTP handlers
+func TestDecideScreeningCase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	caseID := uuid.New()
+	actor := screening.Actor{ID: kycTestActorID, Email: "admin@test.com"}
+
+	t.Run("clear requires a note", func(t *testing.T) {
+		router := newScreeningTestRouter(new(MockScreeningService))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/screening/cases/"+caseID.String()+"/clear",
+			strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("clear a false positive", func(t *testing.T) {
+		cleared := sampleScreeningCase(caseID, screening.StatusCleared)
+		mockService := new(MockScreeningService)
+		mockService.On("Clear", mock.Anything, caseID, "different date of birth", actor).Return(cleared, nil)
+		router := newScreeningTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/screening/cases/"+caseID.String()+"/clear",
+			strings.NewReader(`{"note":"different date of birth"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"cleared"`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("confirm a decided case", func(t *testing.T) {
+		mockService := new(MockScreeningService)
+		mockService.On("Confirm", mock.Anything, caseID, "same person", actor).Return(nil, screening.ErrCaseAlreadyDecided)
+		router := newScreeningTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/screening/cases/"+caseID.String()+"/confirm",
+			strings.NewReader(`{"note":"same person"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+// TestScreenUser tests the ScreenUser HTTP handler
+func TestScreenUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+
+	t.Run("opens a case", func(t *testing.T) {
+		mockService := new(MockScreeningService)
+		mockService.On("ScreenUser", mock.Anything, userID, screening.TriggerManual).
+			Return(sampleScreeningCase(uuid.New(), screening.StatusOpen), nil)
+		router := newScreeningTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/"+userID.String()+"/screen", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var resp httpTransport.ScreenUserResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Case)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("nothing new", func(t *testing.T) {
+		mockService := new(MockScreeningService)
+		mockService.On("ScreenUser", mock.Anything, userID, screening.TriggerManual).Return(nil, nil)
+		router := newScreeningTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/"+userID.String()+"/screen", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"case":null}`, w.Body.String())
+	})
+
+	t.Run("lists not loaded", func(t *testing.T) {
+		mockService := new(MockScreeningService)
+		mockService.On("ScreenUser", mock.Anything, userID, screening.TriggerManual).Return(nil, screening.ErrListsNotLoaded)
+		router := newScreeningTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/"+userID.String()+"/screen", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
+
+// TestGetWatchLists tests the GetWatchLists HTTP handler
+func TestGetWatchLists(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("loaded", func(t *testing.T) {
+		list := screening.NewWatchList([]screening.Entry{
+			{Source: screening.SourceOFACSDN, ID: "1", Kind: screening.KindSanctions, Names: []string{"Abu Abbas"}},
+			{Source: screening.SourcePEP, ID: "p1", Kind: screening.KindPEP, Names: []string{"Maria Popescu"}},
+		}, "0123456789abcdef", time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC))
+		mockService := new(MockScreeningService)
+		mockService.On("WatchList").Return(list)
+		router := newScreeningTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/screening/lists", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp httpTransport.WatchListsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "0123456789abcdef", resp.Version)
+		assert.Equal(t, map[string]int{"ofac_sdn": 1, "pep": 1}, resp.Entries)
+	})
+
+	t.Run("not loaded", func(t *testing.T) {
+		mockService := new(MockScreeningService)
+		mockService.On("WatchList").Return(nil)
+		router := newScreeningTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/screening/lists", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
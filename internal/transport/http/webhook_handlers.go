@@ -0,0 +1,301 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// WebhookHandler handles admin requests for outbound webhook subscriptions.
+type WebhookHandler struct {
+	webhookService webhook.Service
+	logger         *observability.Logger
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance.
+func NewWebhookHandler(webhookService webhook.Service, logger *observability.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		logger:         logger,
+	}
+}
+
+// CreateSubscription handles POST /admin/webhooks
+// The response is the only time the signing secret is shown.
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid create webhook request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	actor := GetAdminActorFromContext(c)
+	h.logger.WithFields(map[string]interface{}{
+		"url":         req.URL,
+		"event_types": req.EventTypes,
+		"actor":       actor,
+	}).Info("Admin: Processing create webhook request")
+
+	sub, err := h.webhookService.CreateSubscription(c.Request.Context(), &webhook.CreateRequest{
+		URL:         req.URL,
+		Description: req.Description,
+		EventTypes:  req.EventTypes,
+		Secret:      req.Secret,
+		CreatedBy:   actor,
+	})
+	if err != nil {
+		h.respondWebhookError(c, err, "Failed to create webhook subscription")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toWebhookSubscriptionDTO(sub, true))
+}
+
+// ListSubscriptions handles GET /admin/webhooks
+// Lists webhook subscriptions, newest first.
+func (h *WebhookHandler) ListSubscriptions(c *gin.Context) {
+	var req ListWebhooksRequest
+	req.Limit = 20 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid list webhooks request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	subs, err := h.webhookService.ListSubscriptions(c.Request.Context(), int32(req.Limit), int32(req.Offset)) // #nosec G115 -- bounded by binding (max=100)
+	if err != nil {
+		h.respondWebhookError(c, err, "Failed to retrieve webhook subscriptions")
+		return
+	}
+
+	dtos := make([]WebhookSubscriptionDTO, len(subs))
+	for i, sub := range subs {
+		dtos[i] = toWebhookSubscriptionDTO(sub, false)
+	}
+
+	c.JSON(http.StatusOK, WebhookSubscriptionsListResponse{
+		Subscriptions: dtos,
+		Limit:         req.Limit,
+		Offset:        req.Offset,
+	})
+}
+
+// GetSubscription handles GET /admin/webhooks/:id
+func (h *WebhookHandler) GetSubscription(c *gin.Context) {
+	id, ok := h.parseSubscriptionID(c)
+	if !ok {
+		return
+	}
+
+	sub, err := h.webhookService.GetSubscription(c.Request.Context(), id)
+	if err != nil {
+		h.respondWebhookError(c, err, "Failed to retrieve webhook subscription")
+		return
+	}
+
+	c.JSON(http.StatusOK, toWebhookSubscriptionDTO(sub, false))
+}
+
+// UpdateSubscription handles PATCH /admin/webhooks/:id
+// Changes the URL, description or event filter, enables or disables the subscription, or
+// rotates its secret; a rotated secret is returned once in the response.
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	id, ok := h.parseSubscriptionID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid update webhook request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	actor := GetAdminActorFromContext(c)
+	h.logger.WithFields(map[string]interface{}{
+		"subscription_id": id.String(),
+		"rotate_secret":   req.RotateSecret,
+		"actor":           actor,
+	}).Info("Admin: Processing update webhook request")
+
+	sub, err := h.webhookService.UpdateSubscription(c.Request.Context(), id, &webhook.UpdateRequest{
+		URL:          req.URL,
+		Description:  req.Description,
+		EventTypes:   req.EventTypes,
+		Enabled:      req.Enabled,
+		RotateSecret: req.RotateSecret,
+		Actor:        actor,
+	})
+	if err != nil {
+		h.respondWebhookError(c, err, "Failed to update webhook subscription")
+		return
+	}
+
+	c.JSON(http.StatusOK, toWebhookSubscriptionDTO(sub, req.RotateSecret))
+}
+
+// DeleteSubscription handles DELETE /admin/webhooks/:id
+// Removes the subscription together with its delivery log.
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	id, ok := h.parseSubscriptionID(c)
+	if !ok {
+		return
+	}
+
+	actor := GetAdminActorFromContext(c)
+	h.logger.WithFields(map[string]interface{}{
+		"subscription_id": id.String(),
+		"actor":           actor,
+	}).Info("Admin: Processing delete webhook request")
+
+	if err := h.webhookService.DeleteSubscription(c.Request.Context(), id, actor); err != nil {
+		h.respondWebhookError(c, err, "Failed to delete webhook subscription")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /admin/webhooks/:id/deliveries
+// Lists the subscription's delivery log, newest first, optionally filtered by status.
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	id, ok := h.parseSubscriptionID(c)
+	if !ok {
+		return
+	}
+
+	var req ListWebhookDeliveriesRequest
+	req.Limit = 20 // default
+	req.Offset = 0 // default
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Warn("Invalid list webhook deliveries request")
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var status *webhook.DeliveryStatus
+	if req.Status != "" {
+		s := webhook.DeliveryStatus(req.Status)
+		status = &s
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.Request.Context(), id, status, int32(req.Limit), int32(req.Offset)) // #nosec G115 -- bounded by binding (max=100)
+	if err != nil {
+		h.respondWebhookError(c, err, "Failed to retrieve webhook deliveries")
+		return
+	}
+
+	dtos := make([]WebhookDeliveryDTO, len(deliveries))
+	for i, delivery := range deliveries {
+		dtos[i] = toWebhookDeliveryDTO(delivery)
+	}
+
+	c.JSON(http.StatusOK, WebhookDeliveriesListResponse{
+		Deliveries: dtos,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	})
+}
+
+// Redeliver handles POST /admin/webhooks/:id/deliveries/:delivery_id/redeliver
+// Queues a succeeded or failed delivery again; the dispatcher sends it on its next poll.
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	id, ok := h.parseSubscriptionID(c)
+	if !ok {
+		return
+	}
+	deliveryID, err := uuid.Parse(c.Param("delivery_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_webhook_delivery_id",
+			Message: "Invalid webhook delivery ID format",
+		})
+		return
+	}
+
+	actor := GetAdminActorFromContext(c)
+	h.logger.WithFields(map[string]interface{}{
+		"subscription_id": id.String(),
+		"delivery_id":     deliveryID.String(),
+		"actor":           actor,
+	}).Info("Admin: Processing webhook redeliver request")
+
+	delivery, err := h.webhookService.Redeliver(c.Request.Context(), id, deliveryID, actor)
+	if err != nil {
+		h.respondWebhookError(c, err, "Failed to redeliver webhook")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toWebhookDeliveryDTO(delivery))
+}
+
+// parseSubscriptionID reads the :id path parameter, responding with 400 if it is not a UUID
+func (h *WebhookHandler) parseSubscriptionID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_webhook_id",
+			Message: "Invalid webhook subscription ID format",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondWebhookError maps webhook domain errors to HTTP responses
+func (h *WebhookHandler) respondWebhookError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, webhook.ErrInvalidSubscription):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_webhook",
+			Message: err.Error(),
+		})
+	case errors.Is(err, webhook.ErrSubscriptionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "webhook_not_found",
+			Message: "Webhook subscription not found",
+		})
+	case errors.Is(err, webhook.ErrDeliveryNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "webhook_delivery_not_found",
+			Message: "Webhook delivery not found",
+		})
+	case errors.Is(err, webhook.ErrDeliveryPending):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "webhook_delivery_pending",
+			Message: "Webhook delivery is still pending",
+		})
+	case errors.Is(err, webhook.ErrSubscriptionDisabled):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "webhook_disabled",
+			Message: "Webhook subscription is disabled; enable it before redelivering",
+		})
+	default:
+		h.logger.WithError(err).Error(message)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: message,
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
+	httpTransport "github.com/alex-necsoiu/pandora-exchange/internal/transport/http"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockWebhookService is a mock implementation of webhook.Service
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) CreateSubscription(ctx context.Context, req *webhook.CreateRequest) (*webhook.Subscription, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.Subscription), args.Error(1)
+}
+
+func (m *MockWebhookService) GetSubscription(ctx context.Context, id uuid.UUID) (*webhook.Subscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.Subscription), args.Error(1)
+}
+
+func (m *MockWebhookService) ListSubscriptions(ctx context.Context, limit, offset int32) ([]*webhook.Subscription, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*webhook.Subscription), args.Error(1)
+}
+
+func (m *MockWebhookService) UpdateSubscription(ctx context.Context, id uuid.UUID, req *webhook.UpdateRequest) (*webhook.Subscription, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.Subscription), args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteSubscription(ctx context.Context, id uuid.UUID, actor string) error {
+	args := m.Called(ctx, id, actor)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, status *webhook.DeliveryStatus, limit, offset int32) ([]*webhook.Delivery, error) {
+	args := m.Called(ctx, subscriptionID, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*webhook.Delivery), args.Error(1)
+}
+
+func (m *MockWebhookService) Redeliver(ctx context.Context, subscriptionID, deliveryID uuid.UUID, actor string) (*webhook.Delivery, error) {
+	args := m.Called(ctx, subscriptionID, deliveryID, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.Delivery), args.Error(1)
+}
+
+func newWebhookTestRouter(svc *MockWebhookService) *gin.Engine {
+	handler := httpTransport.NewWebhookHandler(svc, getTestLogger())
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", uuid.New())
+		c.Set("email", "admin@test.com")
+		c.Next()
+	})
+	router.POST("/admin/webhooks", handler.CreateSubscription)
+	router.GET("/admin/webhooks", handler.ListSubscriptions)
+	router.GET("/admin/webhooks/:id", handler.GetSubscription)
+	router.PATCH("/admin/webhooks/:id", handler.UpdateSubscription)
+	router.DELETE("/admin/webhooks/:id", handler.DeleteSubscription)
+	router.GET("/admin/webhooks/:id/deliveries", handler.ListDeliveries)
+	router.POST("/admin/webhooks/:id/deliveries/:delivery_id/redeliver", handler.Redeliver)
+	return router
+}
+
+func sampleWebhookSubscription(id uuid.UUID) *webhook.Subscription {
+	now := time.Date(2025, 11, 8, 12, 0, 0, 0, time.UTC)
+	return &webhook.Subscription{
+		ID:         id,
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{"user.registered"},
+		Secret:     "whsec_0123456789abcdef",
+		Enabled:    true,
+		CreatedBy:  "admin@test.com",
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// TestCreateWebhook tests the CreateSubscription HTTP handler
+func TestCreateWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("creates a subscription and returns its secret", func(t *testing.T) {
+		id := uuid.New()
+		mockService := new(MockWebhookService)
+		mockService.On("CreateSubscription", mock.Anything, mock.MatchedBy(func(r *webhook.CreateRequest) bool {
+			return r.URL == "https://partner.example.com/hooks" &&
+				r.CreatedBy == "admin@test.com" &&
+				len(r.EventTypes) == 1
+		})).Return(sampleWebhookSubscription(id), nil)
+		router := newWebhookTestRouter(mockService)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"url":         "https://partner.example.com/hooks",
+			"event_types": []string{"user.registered"},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/admin/webhooks", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var resp httpTransport.WebhookSubscriptionDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, id, resp.ID)
+		assert.Equal(t, "whsec_0123456789abcdef", resp.Secret)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("missing url", func(t *testing.T) {
+		router := newWebhookTestRouter(new(MockWebhookService))
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/webhooks", bytes.NewReader([]byte(`{"event_types":["user.registered"]}`)))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_request")
+	})
+
+	t.Run("rejected by the service", func(t *testing.T) {
+		mockService := new(MockWebhookService)
+		mockService.On("CreateSubscription", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: url must use http or https", webhook.ErrInvalidSubscription))
+		router := newWebhookTestRouter(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/webhooks", bytes.NewReader([]byte(`{"url":"ftp://partner.example.com"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_webhook")
+	})
+}
+
+// TestListAndGetWebhooks tests the ListSubscriptions and GetSubscription HTTP handlers
+func TestListAndGetWebhooks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	id := uuid.New()
+	mockService := new(MockWebhookService)
+	mockService.On("ListSubscriptions", mock.Anything, int32(5), int32(0)).Return([]*webhook.Subscription{sampleWebhookSubscription(id)}, nil)
+	mockService.On("GetSubscription", mock.Anything, id).Return(sampleWebhookSubscription(id), nil)
+	mockService.On("GetSubscription", mock.Anything, mock.Anything).Return(nil, webhook.ErrSubscriptionNotFound)
+	router := newWebhookTestRouter(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/webhooks?limit=5", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list httpTransport.WebhookSubscriptionsListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Subscriptions, 1)
+	assert.Empty(t, list.Subscriptions[0].Secret, "secrets are not listed")
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/webhooks/"+id.String(), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "whsec_")
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/webhooks/"+uuid.New().String(), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/webhooks/not-a-uuid", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_webhook_id")
+}
+
+// TestUpdateWebhook tests the UpdateSubscription HTTP handler
+func TestUpdateWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		body         string
+		match        func(r *webhook.UpdateRequest) bool
+		expectSecret bool
+	}{
+		{
+			name:  "disables the subscription",
+			body:  `{"enabled":false}`,
+			match: func(r *webhook.UpdateRequest) bool { return r.Enabled != nil && !*r.Enabled && !r.RotateSecret },
+		},
+		{
+			name:         "rotation returns the new secret",
+			body:         `{"rotate_secret":true}`,
+			match:        func(r *webhook.UpdateRequest) bool { return r.RotateSecret && r.Enabled == nil },
+			expectSecret: true,
+		},
+		{
+			name:  "replaces the event filter",
+			body:  `{"event_types":[]}`,
+			match: func(r *webhook.UpdateRequest) bool { return r.EventTypes != nil && len(*r.EventTypes) == 0 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			mockService := new(MockWebhookService)
+			mockService.On("UpdateSubscription", mock.Anything, id, mock.MatchedBy(func(r *webhook.UpdateRequest) bool {
+				return r.Actor == "admin@test.com" && tt.match(r)
+			})).Return(sampleWebhookSubscription(id), nil)
+			router := newWebhookTestRouter(mockService)
+
+			req := httptest.NewRequest(http.MethodPatch, "/admin/webhooks/"+id.String(), bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			var resp httpTransport.WebhookSubscriptionDTO
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expectSecret, resp.Secret != "")
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestDeleteWebhook tests the DeleteSubscription HTTP handler
+func TestDeleteWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	id := uuid.New()
+	mockService := new(MockWebhookService)
+	mockService.On("DeleteSubscription", mock.Anything, id, "admin@test.com").Return(nil).Once()
+	mockService.On("DeleteSubscription", mock.Anything, id, "admin@test.com").Return(webhook.ErrSubscriptionNotFound).Once()
+	router := newWebhookTestRouter(mockService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/webhooks/"+id.String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/admin/webhooks/"+id.String(), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestListWebhookDeliveries tests the ListDeliveries HTTP handler
+func TestListWebhookDeliveries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	id := uuid.New()
+	code := http.StatusServiceUnavailable
+	lastError := "webhook returned status 503"
+	failed := webhook.DeliveryFailed
+	mockService := new(MockWebhookService)
+	mockService.On("ListDeliveries", mock.Anything, id, &failed, int32(20), int32(0)).Return([]*webhook.Delivery{{
+		ID:             uuid.New(),
+		SubscriptionID: id,
+		EventID:        "evt-1",
+		EventType:      "user.registered",
+		Status:         webhook.DeliveryFailed,
+		Attempts:       12,
+		LastStatusCode: &code,
+		LastError:      &lastError,
+	}}, nil)
+	router := newWebhookTestRouter(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/webhooks/"+id.String()+"/deliveries?status=failed", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp httpTransport.WebhookDeliveriesListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Deliveries, 1)
+	assert.Equal(t, "failed", resp.Deliveries[0].Status)
+	assert.Nil(t, resp.Deliveries[0].NextAttemptAt)
+	assert.Equal(t, code, *resp.Deliveries[0].LastStatusCode)
+	mockService.AssertExpectations(t)
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/webhooks/"+id.String()+"/deliveries?status=lost", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// TestRedeliverWebhook tests the Redeliver HTTP handler
+func TestRedeliverWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{"queued", nil, http.StatusAccepted, `"status":"pending"`},
+		{"still pending", webhook.ErrDeliveryPending, http.StatusConflict, "webhook_delivery_pending"},
+		{"subscription disabled", webhook.ErrSubscriptionDisabled, http.StatusConflict, "webhook_disabled"},
+		{"unknown delivery", webhook.ErrDeliveryNotFound, http.StatusNotFound, "webhook_delivery_not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, deliveryID := uuid.New(), uuid.New()
+			mockService := new(MockWebhookService)
+			if tt.err != nil {
+				mockService.On("Redeliver", mock.Anything, id, deliveryID, "admin@test.com").Return(nil, tt.err)
+			} else {
+				mockService.On("Redeliver", mock.Anything, id, deliveryID, "admin@test.com").Return(&webhook.Delivery{
+					ID:             deliveryID,
+					SubscriptionID: id,
+					EventID:        "evt-1",
+					Status:         webhook.DeliveryPending,
+					NextAttemptAt:  time.Now(),
+				}, nil)
+			}
+			router := newWebhookTestRouter(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/webhooks/"+id.String()+"/deliveries/"+deliveryID.String()+"/redeliver", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/common"
+)
+
+// Compile-time check to ensure TransitKeyManager implements common.KeyManager
+var _ common.KeyManager = (*TransitKeyManager)(nil)
+
+// TransitKeyManager wraps data keys with a Vault Transit encryption key.
+// The key never leaves Vault; rotating it in Vault (transit/keys/<name>/rotate) starts a new
+// key version, and keys wrapped with older versions are rewrapped without being exposed.
+type TransitKeyManager struct {
+	client *Client
+	mount  string
+	key    string
+}
+
+// NewTransitKeyManager creates a key manager using the Transit key name mounted at mount
+//
+// Parameters:
+//   - client: Enabled Vault client
+//   - mount: Transit secrets engine mount path (e.g., "transit")
+//   - key: Name of the Transit encryption key (e.g., "user-pii")
+//
+// Returns:
+//   - *TransitKeyManager: Key manager backed by Vault Transit
+//   - error: Returns error if the client is disabled or mount/key are empty
+func NewTransitKeyManager(client *Client, mount, key string) (*TransitKeyManager, error) {
+	if client == nil || !client.enabled || client.client == nil {
+		return nil, fmt.Errorf("vault transit requires an enabled vault client")
+	}
+	if mount == "" || key == "" {
+		return nil, fmt.Errorf("vault transit mount and key name cannot be empty")
+	}
+	return &TransitKeyManager{
+		client: client,
+		mount:  strings.Trim(mount, "/"),
+		key:    key,
+	}, nil
+}
+
+// WrapKey encrypts key with the latest version of the Transit key
+func (m *TransitKeyManager) WrapKey(ctx context.Context, key []byte) (string, int, error) {
+	data, err := m.write(ctx, "encrypt", map[string]interface{}{
+		"plaintext": base64.StdEncoding.EncodeToString(key),
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	return transitCiphertext(data)
+}
+
+// UnwrapKey decrypts a key wrapped with any version of the Transit key
+func (m *TransitKeyManager) UnwrapKey(ctx context.Context, wrapped string) ([]byte, error) {
+	data, err := m.write(ctx, "decrypt", map[string]interface{}{
+		"ciphertext": wrapped,
+	})
+	if err != nil {
+		return nil, err
+	}
+	encoded, ok := data["plaintext"].(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected vault transit decrypt response")
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid plaintext in vault transit decrypt response: %w", err)
+	}
+	return key, nil
+}
+
+// RewrapKey re-encrypts a wrapped key with the latest version of the Transit key inside Vault
+func (m *TransitKeyManager) RewrapKey(ctx context.Context, wrapped string) (string, int, error) {
+	data, err := m.write(ctx, "rewrap", map[string]interface{}{
+		"ciphertext": wrapped,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	return transitCiphertext(data)
+}
+
+// CurrentVersion returns the latest version of the Transit key
+func (m *TransitKeyManager) CurrentVersion(ctx context.Context) (int, error) {
+	secret, err := m.client.client.Logical().ReadWithContext(ctx, m.mount+"/keys/"+m.key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read vault transit key %s: %w", m.key, err)
+	}
+	if secret == nil {
+		return 0, fmt.Errorf("vault transit key not found: %s", m.key)
+	}
+
+	switch v := secret.Data["latest_version"].(type) {
+	case json.Number:
+		version, err := v.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("invalid latest_version of vault transit key %s: %w", m.key, err)
+		}
+		return int(version), nil
+	case float64:
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("unexpected vault transit key response for %s", m.key)
+	}
+}
+
+// write calls a Transit operation on the key and returns the response data
+func (m *TransitKeyManager) write(ctx context.Context, operation string, body map[string]interface{}) (map[string]interface{}, error) {
+	secret, err := m.client.client.Logical().WriteWithContext(ctx, m.mount+"/"+operation+"/"+m.key, body)
+	if err != nil {
+		return nil, fmt.Errorf("vault transit %s failed: %w", operation, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("empty vault transit %s response", operation)
+	}
+	return secret.Data, nil
+}
+
+// transitCiphertext extracts the ciphertext and its key version from an encrypt or rewrap response
+func transitCiphertext(data map[string]interface{}) (string, int, error) {
+	ciphertext, ok := data["ciphertext"].(string)
+	if !ok {
+		return "", 0, fmt.Errorf("unexpected vault transit response")
+	}
+	version, err := parseTransitVersion(ciphertext)
+	if err != nil {
+		return "", 0, err
+	}
+	return ciphertext, version, nil
+}
+
+// parseTransitVersion returns the key version of a Transit ciphertext ("vault:v<version>:...")
+func parseTransitVersion(ciphertext string) (int, error) {
+	parts := strings.SplitN(ciphertext, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" || !strings.HasPrefix(parts[1], "v") {
+		return 0, fmt.Errorf("invalid vault transit ciphertext format")
+	}
+	version, err := strconv.Atoi(strings.TrimPrefix(parts[1], "v"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid vault transit ciphertext version: %w", err)
+	}
+	return version, nil
+}
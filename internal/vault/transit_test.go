@@ -0,0 +1,125 @@
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransit serves the Transit endpoints used by TransitKeyManager for key "user-pii".
+// Ciphertexts are "vault:v<latest>:<base64 plaintext>", which is enough to test the client.
+func fakeTransit(t *testing.T, latest *int) *httptest.Server {
+	t.Helper()
+	reply := func(w http.ResponseWriter, data map[string]interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}
+	ciphertext := func(plaintext string) string {
+		return "vault:v" + strconv.Itoa(*latest) + ":" + plaintext
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if r.Method != http.MethodGet {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}
+
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/user-pii":
+			reply(w, map[string]interface{}{"ciphertext": ciphertext(body["plaintext"])})
+		case "/v1/transit/decrypt/user-pii":
+			parts := strings.SplitN(body["ciphertext"], ":", 3)
+			reply(w, map[string]interface{}{"plaintext": parts[2]})
+		case "/v1/transit/rewrap/user-pii":
+			parts := strings.SplitN(body["ciphertext"], ":", 3)
+			reply(w, map[string]interface{}{"ciphertext": ciphertext(parts[2])})
+		case "/v1/transit/keys/user-pii":
+			reply(w, map[string]interface{}{"latest_version": *latest})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":["not found"]}`))
+		}
+	}))
+}
+
+func TestNewTransitKeyManager(t *testing.T) {
+	_, err := NewTransitKeyManager(NewDisabledClient(), "transit", "user-pii")
+	assert.Error(t, err, "disabled client cannot reach transit")
+
+	client, err := NewClient("http://localhost:8200", "token")
+	require.NoError(t, err)
+	_, err = NewTransitKeyManager(client, "transit", "")
+	assert.Error(t, err)
+
+	m, err := NewTransitKeyManager(client, "/transit/", "user-pii")
+	require.NoError(t, err)
+	assert.Equal(t, "transit", m.mount)
+}
+
+func TestTransitKeyManager(t *testing.T) {
+	ctx := context.Background()
+	latest := 1
+	server := fakeTransit(t, &latest)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "token")
+	require.NoError(t, err)
+	m, err := NewTransitKeyManager(client, "transit", "user-pii")
+	require.NoError(t, err)
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	wrapped, version, err := m.WrapKey(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+	assert.Equal(t, "vault:v1:"+base64.StdEncoding.EncodeToString(key), wrapped)
+
+	unwrapped, err := m.UnwrapKey(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, key, unwrapped)
+
+	// Rotate the key in Vault
+	latest = 2
+	current, err := m.CurrentVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, current)
+
+	rewrapped, version, err := m.RewrapKey(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+	assert.True(t, strings.HasPrefix(rewrapped, "vault:v2:"))
+}
+
+func TestTransitKeyManager_UnknownKey(t *testing.T) {
+	latest := 1
+	server := fakeTransit(t, &latest)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "token")
+	require.NoError(t, err)
+	m, err := NewTransitKeyManager(client, "transit", "other-key")
+	require.NoError(t, err)
+
+	_, _, err = m.WrapKey(context.Background(), []byte("key"))
+	assert.Error(t, err)
+	_, err = m.CurrentVersion(context.Background())
+	assert.Error(t, err)
+}
+
+func TestParseTransitVersion(t *testing.T) {
+	version, err := parseTransitVersion("vault:v12:abc")
+	require.NoError(t, err)
+	assert.Equal(t, 12, version)
+
+	for _, invalid := range []string{"", "vault:abc", "local:v1:abc", "vault:vx:abc"} {
+		_, err := parseTransitVersion(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,262 @@
+// Package watchlist loads sanctions and PEP watch lists from local files for screening:
+// the OFAC SDN list (sdn.csv), the EU consolidated financial sanctions list (XML) and a PEP
+// list in a simple CSV layout. Only natural persons are kept, since only users are screened.
+package watchlist
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+)
+
+// ofacNull is how sdn.csv marks an empty field
+const ofacNull = "-0-"
+
+// ofacColumns is the number of columns of sdn.csv: ent_num, SDN_Name, SDN_Type, Program,
+// Title, Call_Sign, Vess_type, Tonnage, GRT, Vess_flag, Vess_owner, Remarks
+const ofacColumns = 12
+
+// yearRe finds years in free-text dates of birth
+var yearRe = regexp.MustCompile(`\b(1[89]|20)\d{2}\b`)
+
+// ParseOFACSDN parses the OFAC SDN list in its CSV layout (sdn.csv, no header row).
+// Dates of birth are read from the "DOB" remarks; programs are split on "] [" as OFAC
+// lists several as "SDGT] [IRGC".
+func ParseOFACSDN(r io.Reader) ([]screening.Entry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var entries []screening.Entry
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse OFAC SDN list: %w", err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "\x1a" {
+			// The published file ends with an EOF control character
+			continue
+		}
+		if len(record) < ofacColumns {
+			return nil, fmt.Errorf("failed to parse OFAC SDN list: line %d has %d columns, want %d", line, len(record), ofacColumns)
+		}
+		if ofacField(record[2]) != "individual" {
+			continue
+		}
+
+		name := ofacField(record[1])
+		if name == "" {
+			continue
+		}
+		entry := screening.Entry{
+			Source: screening.SourceOFACSDN,
+			ID:     ofacField(record[0]),
+			Kind:   screening.KindSanctions,
+			Names:  []string{name},
+		}
+		if programs := ofacField(record[3]); programs != "" {
+			for _, p := range strings.Split(programs, "] [") {
+				entry.Programs = append(entry.Programs, strings.Trim(p, "[] "))
+			}
+		}
+		for _, remark := range strings.Split(ofacField(record[11]), ";") {
+			remark = strings.TrimSpace(remark)
+			if strings.HasPrefix(remark, "DOB ") {
+				entry.DatesOfBirth = append(entry.DatesOfBirth, parseDOB(strings.TrimPrefix(remark, "DOB "))...)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func ofacField(s string) string {
+	s = strings.TrimSpace(s)
+	if s == ofacNull {
+		return ""
+	}
+	return s
+}
+
+// parseDOB converts a free-text date of birth such as "31 Jan 1953", "Jan 1953",
+// "circa 1953" or "1953 to 1955" into "YYYY-MM-DD" or "YYYY" dates
+func parseDOB(s string) []string {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{"02 Jan 2006", "2 Jan 2006", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return []string{t.Format("2006-01-02")}
+		}
+	}
+
+	years := yearRe.FindAllString(s, -1)
+	if len(years) == 2 && strings.Contains(s, " to ") {
+		// Expand short ranges so every year in them is compared
+		from, _ := strconv.Atoi(years[0])
+		to, _ := strconv.Atoi(years[1])
+		if to > from && to-from <= 10 {
+			years = years[:0]
+			for y := from; y <= to; y++ {
+				years = append(years, strconv.Itoa(y))
+			}
+		}
+	}
+	return years
+}
+
+// euEntity is the part of an EU consolidated list sanctionEntity that screening uses
+type euEntity struct {
+	LogicalID         string `xml:"logicalId,attr"`
+	EUReferenceNumber string `xml:"euReferenceNumber,attr"`
+	Regulations       []struct {
+		Programme string `xml:"programme,attr"`
+	} `xml:"regulation"`
+	SubjectType struct {
+		Code string `xml:"code,attr"`
+	} `xml:"subjectType"`
+	NameAliases []struct {
+		WholeName string `xml:"wholeName,attr"`
+	} `xml:"nameAlias"`
+	Birthdates []struct {
+		Birthdate string `xml:"birthdate,attr"`
+		Year      string `xml:"year,attr"`
+	} `xml:"birthdate"`
+}
+
+// ParseEUConsolidated parses the EU consolidated financial sanctions list XML
+func ParseEUConsolidated(r io.Reader) ([]screening.Entry, error) {
+	decoder := xml.NewDecoder(r)
+
+	var entries []screening.Entry
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse EU consolidated list: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "sanctionEntity" {
+			continue
+		}
+
+		var e euEntity
+		if err := decoder.DecodeElement(&e, &start); err != nil {
+			return nil, fmt.Errorf("failed to parse EU consolidated list: %w", err)
+		}
+		if e.SubjectType.Code != "person" {
+			continue
+		}
+
+		entry := screening.Entry{
+			Source: screening.SourceEUConsolidated,
+			ID:     e.EUReferenceNumber,
+			Kind:   screening.KindSanctions,
+		}
+		if entry.ID == "" {
+			entry.ID = e.LogicalID
+		}
+		for _, alias := range e.NameAliases {
+			if name := strings.TrimSpace(alias.WholeName); name != "" {
+				entry.Names = appendUnique(entry.Names, name)
+			}
+		}
+		if len(entry.Names) == 0 {
+			continue
+		}
+		for _, b := range e.Birthdates {
+			switch {
+			case b.Birthdate != "":
+				entry.DatesOfBirth = appendUnique(entry.DatesOfBirth, b.Birthdate)
+			case b.Year != "":
+				entry.DatesOfBirth = appendUnique(entry.DatesOfBirth, b.Year)
+			}
+		}
+		for _, reg := range e.Regulations {
+			if reg.Programme != "" {
+				entry.Programs = appendUnique(entry.Programs, reg.Programme)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// pepHeader is the header row a PEP list must start with. Aliases are separated by ";";
+// the date of birth is "YYYY-MM-DD", "YYYY" or empty.
+var pepHeader = []string{"id", "name", "aliases", "date_of_birth", "country", "position"}
+
+// ParsePEP parses a politically exposed persons list in CSV. The country and position are
+// kept as the entry's programs so reviewers see why the person is listed.
+func ParsePEP(r io.Reader) ([]screening.Entry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(pepHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PEP list: %w", err)
+	}
+	for i, column := range pepHeader {
+		if strings.ToLower(strings.TrimSpace(header[i])) != column {
+			return nil, fmt.Errorf("failed to parse PEP list: header must be %s", strings.Join(pepHeader, ","))
+		}
+	}
+
+	var entries []screening.Entry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PEP list: %w", err)
+		}
+
+		id, name := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if id == "" || name == "" {
+			return nil, fmt.Errorf("failed to parse PEP list: id and name are required")
+		}
+		entry := screening.Entry{
+			Source: screening.SourcePEP,
+			ID:     id,
+			Kind:   screening.KindPEP,
+			Names:  []string{name},
+		}
+		for _, alias := range strings.Split(record[2], ";") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				entry.Names = appendUnique(entry.Names, alias)
+			}
+		}
+		if dob := strings.TrimSpace(record[3]); dob != "" {
+			entry.DatesOfBirth = []string{dob}
+		}
+		for _, p := range record[4:] {
+			if p = strings.TrimSpace(p); p != "" {
+				entry.Programs = append(entry.Programs, p)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func appendUnique(values []string, v string) []string {
+	for _, existing := range values {
+		if existing == v {
+			return values
+		}
+	}
+	return append(values, v)
+}
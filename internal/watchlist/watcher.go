@@ -0,0 +1,142 @@
+package watchlist
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+)
+
+// File is a list file and the source it holds
+type File struct {
+	Source screening.Source
+	Path   string
+}
+
+// parsers maps each source to the parser of its file layout
+var parsers = map[screening.Source]func(io.Reader) ([]screening.Entry, error){
+	screening.SourceOFACSDN:        ParseOFACSDN,
+	screening.SourceEUConsolidated: ParseEUConsolidated,
+	screening.SourcePEP:            ParsePEP,
+}
+
+// Sink receives watch lists; implemented by the screening service
+type Sink interface {
+	// SetWatchList replaces the lists users are screened against
+	SetWatchList(l *screening.WatchList)
+	// RescreenAll screens every user against the current lists
+	RescreenAll(ctx context.Context) error
+}
+
+// Watcher loads the watch list files and reloads them when they change. Lists are installed
+// only once every file parsed, so a bad download keeps the previous lists in use.
+type Watcher struct {
+	files    []File
+	interval time.Duration
+	sink     Sink
+	logger   *observability.Logger
+	lastHash [sha256.Size]byte
+}
+
+// NewWatcher creates a watcher that installs the lists in files into sink, checking them
+// for changes every interval
+func NewWatcher(files []File, interval time.Duration, sink Sink, logger *observability.Logger) *Watcher {
+	return &Watcher{
+		files:    files,
+		interval: interval,
+		sink:     sink,
+		logger:   logger,
+	}
+}
+
+// Load reads the files and installs their entries if any file changed since the last load.
+// It reports whether new lists were installed.
+func (w *Watcher) Load() (bool, error) {
+	contents := make([][]byte, len(w.files))
+	digest := sha256.New()
+	for i, f := range w.files {
+		data, err := readListFile(f.Path)
+		if err != nil {
+			return false, err
+		}
+		contents[i] = data
+		sum := sha256.Sum256(data)
+		digest.Write([]byte(f.Source))
+		digest.Write(sum[:])
+	}
+	var hash [sha256.Size]byte
+	copy(hash[:], digest.Sum(nil))
+	if hash == w.lastHash {
+		return false, nil
+	}
+
+	var entries []screening.Entry
+	counts := make(map[string]interface{}, len(w.files))
+	for i, f := range w.files {
+		parse, ok := parsers[f.Source]
+		if !ok {
+			return false, fmt.Errorf("unsupported watch list source: %s", f.Source)
+		}
+		parsed, err := parse(bytes.NewReader(contents[i]))
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", f.Path, err)
+		}
+		entries = append(entries, parsed...)
+		counts[string(f.Source)] = len(parsed)
+	}
+
+	list := screening.NewWatchList(entries, hex.EncodeToString(hash[:])[:16], time.Now().UTC())
+	w.sink.SetWatchList(list)
+	w.lastHash = hash
+	w.logger.WithFields(counts).WithField("version", list.Version).Info("Screening watch lists loaded")
+	return true, nil
+}
+
+// Run checks the files every interval until ctx is cancelled. When the lists changed, every
+// user is screened again against them.
+func (w *Watcher) Run(ctx context.Context) {
+	if w.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			loaded, err := w.Load()
+			if err != nil {
+				w.logger.WithError(err).Error("Failed to reload screening watch lists, keeping current lists")
+				continue
+			}
+			if !loaded {
+				continue
+			}
+			if err := w.sink.RescreenAll(ctx); err != nil {
+				w.logger.WithError(err).Error("Failed to re-screen users against updated watch lists")
+			}
+		}
+	}
+}
+
+func readListFile(filename string) ([]byte, error) {
+	if strings.Contains(filename, "..") {
+		return nil, fmt.Errorf("invalid watch list file path: %s", filename)
+	}
+	data, err := os.ReadFile(filename) // #nosec G304 - path is validated above
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watch list file: %w", err)
+	}
+	return data, nil
+}
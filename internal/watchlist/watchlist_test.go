@@ -0,0 +1,201 @@
+package watchlist
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/screening"
+	"github.com/alex-necsoiu/pandora-exchange/internal/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSDN = `36,"AEROCARIBBEAN AIRLINES",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-
+173,"ANGLO-CARIBBEAN CO., LTD.",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,"Ibex House, The Minories, London EC3N 1DY, United Kingdom."
+2674,"ABBAS, Abu","individual","SDGT] [IRGC",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,"DOB 10 Dec 1948; POB Safad, Israel; a.k.a. 'ABU ABBAS'."
+9647,"IVANOV, Sergei Borisovich","individual","UKRAINE-EO13661",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,"DOB 1953 to 1955; nationality Russia."
+` + "\x1a\n"
+
+const testEU = `<?xml version="1.0" encoding="UTF-8"?>
+<export xmlns="http://eu.europa.ec/fpi/fsd/export" generationDate="2025-11-01T10:00:00">
+  <sanctionEntity logicalId="13" euReferenceNumber="EU.27.28">
+    <regulation programme="IRQ"/>
+    <subjectType code="person" classificationCode="P"/>
+    <nameAlias firstName="Saddam" lastName="Hussein Al-Tikriti" wholeName="Saddam Hussein Al-Tikriti"/>
+    <nameAlias wholeName="Abu Ali"/>
+    <birthdate birthdate="1937-04-28" year="1937"/>
+  </sanctionEntity>
+  <sanctionEntity logicalId="20" euReferenceNumber="">
+    <regulation programme="UKR"/>
+    <regulation programme="RUS"/>
+    <subjectType code="person" classificationCode="P"/>
+    <nameAlias wholeName="Олег Петренко"/>
+    <nameAlias wholeName="Oleg Petrenko"/>
+    <birthdate year="1965"/>
+  </sanctionEntity>
+  <sanctionEntity logicalId="30" euReferenceNumber="EU.30.1">
+    <subjectType code="enterprise" classificationCode="E"/>
+    <nameAlias wholeName="Example Trading LLC"/>
+  </sanctionEntity>
+</export>
+`
+
+const testPEP = `id,name,aliases,date_of_birth,country,position
+pep-1,Maria Popescu,Maria Popesku; M. Popescu,1971-02-03,RO,Minister of Finance
+pep-2,Jan Novák,,,CZ,
+`
+
+func TestParseOFACSDN(t *testing.T) {
+	entries, err := ParseOFACSDN(strings.NewReader(testSDN))
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "only individuals are kept")
+
+	assert.Equal(t, screening.Entry{
+		Source:       screening.SourceOFACSDN,
+		ID:           "2674",
+		Kind:         screening.KindSanctions,
+		Names:        []string{"ABBAS, Abu"},
+		DatesOfBirth: []string{"1948-12-10"},
+		Programs:     []string{"SDGT", "IRGC"},
+	}, entries[0])
+	assert.Equal(t, []string{"1953", "1954", "1955"}, entries[1].DatesOfBirth)
+
+	_, err = ParseOFACSDN(strings.NewReader("1,\"SHORT\",individual\n"))
+	assert.Error(t, err)
+}
+
+func TestParseDOB(t *testing.T) {
+	assert.Equal(t, []string{"1948-12-10"}, parseDOB("10 Dec 1948"))
+	assert.Equal(t, []string{"1960"}, parseDOB("circa 1960"))
+	assert.Equal(t, []string{"1960"}, parseDOB("Mar 1960"))
+	assert.Equal(t, []string{"1950", "1980"}, parseDOB("1950 to 1980"), "long ranges are not expanded")
+	assert.Empty(t, parseDOB("unknown"))
+}
+
+func TestParseEUConsolidated(t *testing.T) {
+	entries, err := ParseEUConsolidated(strings.NewReader(testEU))
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "only persons are kept")
+
+	assert.Equal(t, screening.Entry{
+		Source:       screening.SourceEUConsolidated,
+		ID:           "EU.27.28",
+		Kind:         screening.KindSanctions,
+		Names:        []string{"Saddam Hussein Al-Tikriti", "Abu Ali"},
+		DatesOfBirth: []string{"1937-04-28"},
+		Programs:     []string{"IRQ"},
+	}, entries[0])
+	assert.Equal(t, "20", entries[1].ID, "logical ID is used without a reference number")
+	assert.Equal(t, []string{"1965"}, entries[1].DatesOfBirth)
+	assert.Equal(t, []string{"UKR", "RUS"}, entries[1].Programs)
+
+	_, err = ParseEUConsolidated(strings.NewReader("<export><sanctionEntity>"))
+	assert.Error(t, err)
+}
+
+func TestParsePEP(t *testing.T) {
+	entries, err := ParsePEP(strings.NewReader(testPEP))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, screening.Entry{
+		Source:       screening.SourcePEP,
+		ID:           "pep-1",
+		Kind:         screening.KindPEP,
+		Names:        []string{"Maria Popescu", "Maria Popesku", "M. Popescu"},
+		DatesOfBirth: []string{"1971-02-03"},
+		Programs:     []string{"RO", "Minister of Finance"},
+	}, entries[0])
+	assert.Empty(t, entries[1].DatesOfBirth)
+	assert.Equal(t, []string{"CZ"}, entries[1].Programs)
+
+	_, err = ParsePEP(strings.NewReader("name,id,aliases,date_of_birth,country,position\n"))
+	assert.Error(t, err)
+	_, err = ParsePEP(strings.NewReader("id,name,aliases,date_of_birth,country,position\n,Nameless,,,,\n"))
+	assert.Error(t, err)
+}
+
+type recordingSink struct {
+	lists      []*screening.WatchList
+	rescreened int
+}
+
+func (s *recordingSink) SetWatchList(l *screening.WatchList) { s.lists = append(s.lists, l) }
+
+func (s *recordingSink) RescreenAll(context.Context) error {
+	s.rescreened++
+	return nil
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	sdn, eu := filepath.Join(dir, "sdn.csv"), filepath.Join(dir, "eu.xml")
+	writeFile(t, sdn, testSDN)
+	writeFile(t, eu, testEU)
+
+	sink := &recordingSink{}
+	watcher := NewWatcher([]File{
+		{Source: screening.SourceOFACSDN, Path: sdn},
+		{Source: screening.SourceEUConsolidated, Path: eu},
+	}, time.Second, sink, observability.NewLogger("dev", "test-service"))
+
+	loaded, err := watcher.Load()
+	require.NoError(t, err)
+	assert.True(t, loaded)
+	require.Len(t, sink.lists, 1)
+	first := sink.lists[0]
+	assert.Len(t, first.Entries, 4)
+	assert.Len(t, first.Version, 16)
+	assert.Equal(t, map[screening.Source]int{screening.SourceOFACSDN: 2, screening.SourceEUConsolidated: 2}, first.Counts())
+
+	loaded, err = watcher.Load()
+	require.NoError(t, err)
+	assert.False(t, loaded, "unchanged files must not reload")
+
+	writeFile(t, eu, "<export><sanctionEntity>")
+	_, err = watcher.Load()
+	assert.Error(t, err)
+	assert.Len(t, sink.lists, 1, "an invalid file must keep the current lists")
+
+	writeFile(t, eu, "<export/>")
+	loaded, err = watcher.Load()
+	require.NoError(t, err)
+	assert.True(t, loaded)
+	require.Len(t, sink.lists, 2)
+	assert.NotEqual(t, first.Version, sink.lists[1].Version)
+	assert.Len(t, sink.lists[1].Entries, 2)
+	assert.Zero(t, sink.rescreened, "Load alone does not re-screen")
+}
+
+func TestWatcher_RunRescreensOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pep.csv")
+	writeFile(t, path, testPEP)
+
+	sink := &recordingSink{}
+	watcher := NewWatcher([]File{{Source: screening.SourcePEP, Path: path}}, 10*time.Millisecond, sink, observability.NewLogger("dev", "test-service"))
+	_, err := watcher.Load()
+	require.NoError(t, err)
+
+	writeFile(t, path, "id,name,aliases,date_of_birth,country,position\n")
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	watcher.Run(ctx)
+
+	require.Len(t, sink.lists, 2)
+	assert.Equal(t, 1, sink.rescreened)
+}
+
+func TestWatcher_RejectsTraversal(t *testing.T) {
+	watcher := NewWatcher([]File{{Source: screening.SourcePEP, Path: "../etc/pep.csv"}}, 0, &recordingSink{}, observability.NewLogger("dev", "test-service"))
+	_, err := watcher.Load()
+	assert.Error(t, err)
+}
@@ -0,0 +1,160 @@
+// Package webhooks delivers outbound webhook requests over HTTP. HTTPSender implements
+// webhook.Sender and is driven by the webhook dispatcher in the service layer, which owns
+// retries, backoff and the delivery log.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
+)
+
+const (
+	// contentType is the media type of the structured-mode CloudEvent each delivery carries
+	contentType = "application/cloudevents+json"
+	userAgent   = "pandora-user-service-webhooks"
+	// maxErrorBody bounds how much of an error response is kept in the delivery log
+	maxErrorBody = 512
+)
+
+// ErrForbiddenAddress is returned when an endpoint resolves to an address deliveries must not
+// reach, such as a private network or a cloud metadata service
+var ErrForbiddenAddress = errors.New("webhook endpoint resolves to a forbidden address")
+
+// forbiddenPrefixes are the non-public ranges netip.Addr has no predicate for. Loopback,
+// link-local (169.254.169.254 metadata), private (fd00:ec2::254 metadata) and multicast
+// addresses are rejected by isForbiddenAddr directly.
+var forbiddenPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // shared address space, 100.100.100.200 metadata
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"), // NAT64, which can reach any IPv4 address
+}
+
+// HTTPSender POSTs signed deliveries to subscription endpoints
+type HTTPSender struct {
+	client       *http.Client
+	timeout      time.Duration
+	now          func() time.Time
+	allowPrivate bool
+}
+
+// NewHTTPSender creates a sender. A nil client uses a client that does not follow redirects,
+// so an endpoint cannot bounce signed payloads elsewhere, and that refuses to connect to
+// non-public addresses after DNS resolution; timeout bounds each request.
+func NewHTTPSender(client *http.Client, timeout time.Duration) *HTTPSender {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	s := &HTTPSender{client: client, timeout: timeout, now: time.Now}
+	if s.client == nil {
+		dialer := &net.Dialer{
+			Timeout:   timeout,
+			KeepAlive: 30 * time.Second,
+			Control:   s.checkAddress,
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		// A proxy would be dialed instead of the endpoint, bypassing the address check
+		transport.Proxy = nil
+		transport.DialContext = dialer.DialContext
+		s.client = &http.Client{
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+	return s
+}
+
+// WithPrivateNetworks lets the default client connect to loopback and private addresses,
+// for local development endpoints
+func (s *HTTPSender) WithPrivateNetworks(allow bool) *HTTPSender {
+	s.allowPrivate = allow
+	return s
+}
+
+// checkAddress is the dialer's Control hook. It runs for every resolved address right before
+// connecting, so a hostname cannot pass a check and then resolve elsewhere (DNS rebinding).
+func (s *HTTPSender) checkAddress(network, address string, _ syscall.RawConn) error {
+	if s.allowPrivate {
+		return nil
+	}
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+	if isForbiddenAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+// isForbiddenAddr reports whether addr is not a public unicast address
+func isForbiddenAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return true
+	}
+	for _, prefix := range forbiddenPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Send POSTs the delivery payload signed with the subscription secret.
+// Any response other than 2xx, including redirects, is a failed attempt.
+func (s *HTTPSender) Send(ctx context.Context, sub *webhook.Subscription, delivery *webhook.Delivery) webhook.Attempt {
+	signedAt := s.now()
+	attempt := webhook.Attempt{At: signedAt}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Err = fmt.Errorf("failed to build webhook request: %w", err)
+		return attempt
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(webhook.HeaderDeliveryID, delivery.ID.String())
+	req.Header.Set(webhook.HeaderEventID, delivery.EventID)
+	req.Header.Set(webhook.HeaderEventType, delivery.EventType)
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(signedAt.Unix(), 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(sub.Secret, signedAt, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		attempt.Err = fmt.Errorf("failed to send webhook: %w", err)
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	attempt.StatusCode = resp.StatusCode
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if body = bytes.TrimSpace(body); len(body) > 0 {
+			attempt.Err = fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, body)
+		} else {
+			attempt.Err = fmt.Errorf("webhook returned status %d", resp.StatusCode)
+		}
+	}
+	return attempt
+}
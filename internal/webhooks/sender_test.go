@@ -0,0 +1,148 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alex-necsoiu/pandora-exchange/internal/domain/webhook"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDelivery() (*webhook.Subscription, *webhook.Delivery) {
+	sub := &webhook.Subscription{ID: uuid.New(), Secret: "whsec_0123456789abcdef", Enabled: true}
+	delivery := &webhook.Delivery{
+		ID:             uuid.New(),
+		SubscriptionID: sub.ID,
+		EventID:        "evt-1",
+		EventType:      "user.registered",
+		Payload:        []byte(`{"specversion":"1.0","id":"evt-1","type":"user.registered"}`),
+	}
+	return sub, delivery
+}
+
+func TestHTTPSender_Send(t *testing.T) {
+	t.Run("posts a signed payload", func(t *testing.T) {
+		sub, delivery := testDelivery()
+		var verifyErr error
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/cloudevents+json", r.Header.Get("Content-Type"))
+			assert.Equal(t, delivery.ID.String(), r.Header.Get(webhook.HeaderDeliveryID))
+			assert.Equal(t, "evt-1", r.Header.Get(webhook.HeaderEventID))
+			assert.Equal(t, "user.registered", r.Header.Get(webhook.HeaderEventType))
+			assert.Equal(t, delivery.Payload, body)
+			verifyErr = webhook.Verify(sub.Secret, r.Header.Get(webhook.HeaderSignature), r.Header.Get(webhook.HeaderTimestamp), body, time.Now(), time.Minute)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+		sub.URL = server.URL
+
+		attempt := NewHTTPSender(nil, time.Second).WithPrivateNetworks(true).Send(context.Background(), sub, delivery)
+
+		require.NoError(t, attempt.Err)
+		assert.Equal(t, http.StatusAccepted, attempt.StatusCode)
+		assert.NoError(t, verifyErr)
+	})
+
+	t.Run("non-2xx response fails with its body", func(t *testing.T) {
+		sub, delivery := testDelivery()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("maintenance\n"))
+		}))
+		defer server.Close()
+		sub.URL = server.URL
+
+		attempt := NewHTTPSender(nil, time.Second).WithPrivateNetworks(true).Send(context.Background(), sub, delivery)
+
+		require.Error(t, attempt.Err)
+		assert.Equal(t, http.StatusServiceUnavailable, attempt.StatusCode)
+		assert.Contains(t, attempt.Err.Error(), "status 503: maintenance")
+	})
+
+	t.Run("redirects are not followed", func(t *testing.T) {
+		sub, delivery := testDelivery()
+		followed := false
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			followed = true
+		}))
+		defer target.Close()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+		}))
+		defer server.Close()
+		sub.URL = server.URL
+
+		attempt := NewHTTPSender(nil, time.Second).WithPrivateNetworks(true).Send(context.Background(), sub, delivery)
+
+		require.Error(t, attempt.Err)
+		assert.Equal(t, http.StatusTemporaryRedirect, attempt.StatusCode)
+		assert.False(t, followed)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		sub, delivery := testDelivery()
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		defer close(release)
+		sub.URL = server.URL
+
+		attempt := NewHTTPSender(nil, 50*time.Millisecond).WithPrivateNetworks(true).Send(context.Background(), sub, delivery)
+
+		require.Error(t, attempt.Err)
+		assert.Zero(t, attempt.StatusCode)
+	})
+}
+
+func TestHTTPSender_RefusesNonPublicAddresses(t *testing.T) {
+	reached := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+	defer server.Close()
+
+	for _, endpoint := range []string{
+		server.URL,
+		// Resolved by DNS, so only the dial-time check sees the loopback address
+		strings.Replace(server.URL, "127.0.0.1", "localhost", 1),
+	} {
+		sub, delivery := testDelivery()
+		sub.URL = endpoint
+
+		attempt := NewHTTPSender(nil, time.Second).Send(context.Background(), sub, delivery)
+
+		require.Error(t, attempt.Err, endpoint)
+		assert.ErrorIs(t, attempt.Err, ErrForbiddenAddress)
+		assert.Zero(t, attempt.StatusCode)
+	}
+	assert.False(t, reached)
+}
+
+func TestIsForbiddenAddr(t *testing.T) {
+	forbidden := []string{
+		"127.0.0.1", "::1", "0.0.0.0", "::",
+		"10.1.2.3", "172.16.0.1", "192.168.1.1", "fd12::1",
+		"169.254.169.254", "fd00:ec2::254", "100.100.100.200", "fe80::1",
+		"224.0.0.1", "255.255.255.255", "::ffff:127.0.0.1", "::ffff:10.0.0.1",
+		"64:ff9b::a9fe:a9fe",
+	}
+	for _, addr := range forbidden {
+		assert.True(t, isForbiddenAddr(netip.MustParseAddr(addr)), addr)
+	}
+
+	for _, addr := range []string{"93.184.215.14", "8.8.8.8", "2606:4700::1111"} {
+		assert.False(t, isForbiddenAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
@@ -0,0 +1,62 @@
+-- Revert audit_logs to a regular (non-partitioned) table
+-- Archived months are NOT reloaded; restore them first if they are still needed.
+
+DROP TABLE IF EXISTS audit_log_archives;
+
+ALTER TABLE audit_logs RENAME TO audit_logs_partitioned;
+ALTER INDEX audit_logs_pkey RENAME TO audit_logs_partitioned_pkey;
+
+CREATE TABLE audit_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_type VARCHAR(100) NOT NULL,
+    event_category VARCHAR(50) NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    actor_type VARCHAR(50) NOT NULL,
+    actor_identifier VARCHAR(255),
+    action VARCHAR(100) NOT NULL,
+    resource_type VARCHAR(100),
+    resource_id VARCHAR(255),
+    ip_address INET,
+    user_agent TEXT,
+    request_id VARCHAR(100),
+    session_id VARCHAR(100),
+    metadata JSONB,
+    previous_state JSONB,
+    new_state JSONB,
+    status VARCHAR(20) NOT NULL,
+    failure_reason TEXT,
+    retention_until TIMESTAMP WITH TIME ZONE,
+    is_sensitive BOOLEAN DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO audit_logs SELECT * FROM audit_logs_partitioned;
+
+-- Dropping the parent drops every attached partition
+DROP TABLE audit_logs_partitioned;
+
+DROP FUNCTION IF EXISTS audit_logs_drop_partition(TIMESTAMP WITH TIME ZONE);
+DROP FUNCTION IF EXISTS audit_logs_detach_partition(TIMESTAMP WITH TIME ZONE);
+DROP FUNCTION IF EXISTS audit_logs_ensure_partitions(TIMESTAMP WITH TIME ZONE, TIMESTAMP WITH TIME ZONE);
+DROP FUNCTION IF EXISTS audit_logs_create_partition(TIMESTAMP WITH TIME ZONE);
+DROP FUNCTION IF EXISTS audit_logs_partition_name(TIMESTAMP WITH TIME ZONE);
+
+CREATE INDEX idx_audit_logs_event_type ON audit_logs(event_type);
+CREATE INDEX idx_audit_logs_user_id ON audit_logs(user_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at DESC);
+CREATE INDEX idx_audit_logs_event_category ON audit_logs(event_category);
+CREATE INDEX idx_audit_logs_severity ON audit_logs(severity);
+CREATE INDEX idx_audit_logs_actor_identifier ON audit_logs(actor_identifier);
+CREATE INDEX idx_audit_logs_resource ON audit_logs(resource_type, resource_id);
+CREATE INDEX idx_audit_logs_ip_address ON audit_logs(ip_address);
+CREATE INDEX idx_audit_logs_user_created ON audit_logs(user_id, created_at DESC);
+CREATE INDEX idx_audit_logs_category_created ON audit_logs(event_category, created_at DESC);
+CREATE INDEX idx_audit_logs_metadata ON audit_logs USING GIN (metadata);
+
+COMMENT ON TABLE audit_logs IS 'Immutable audit trail for security, compliance, and forensic analysis';
+COMMENT ON COLUMN audit_logs.event_type IS 'Specific event identifier (e.g., user.login, kyc.approved)';
+COMMENT ON COLUMN audit_logs.event_category IS 'High-level categorization for filtering and reporting';
+COMMENT ON COLUMN audit_logs.severity IS 'Event severity for alerting and monitoring';
+COMMENT ON COLUMN audit_logs.metadata IS 'Flexible JSONB field for event-specific structured data';
+COMMENT ON COLUMN audit_logs.retention_until IS 'Auto-deletion timestamp for compliance with data retention policies';
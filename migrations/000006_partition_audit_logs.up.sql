@@ -0,0 +1,204 @@
+-- Convert audit_logs into a natively partitioned table (monthly RANGE on created_at)
+-- Retention is enforced by detaching, archiving and dropping whole partitions
+-- instead of deleting rows one by one from an ever-growing table.
+
+-- Move the existing table out of the way (its primary key index name would collide)
+ALTER TABLE audit_logs RENAME TO audit_logs_legacy;
+ALTER INDEX audit_logs_pkey RENAME TO audit_logs_legacy_pkey;
+
+CREATE TABLE audit_logs (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+
+    -- Event classification
+    event_type VARCHAR(100) NOT NULL,
+    event_category VARCHAR(50) NOT NULL, -- authentication, authorization, data_access, data_modification, security, compliance
+    severity VARCHAR(20) NOT NULL, -- info, warning, high, critical
+
+    -- Actor information
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    actor_type VARCHAR(50) NOT NULL, -- user, system, admin, api
+    actor_identifier VARCHAR(255), -- email, API key ID, service name
+
+    -- Action details
+    action VARCHAR(100) NOT NULL, -- login, logout, create, update, delete, access, etc.
+    resource_type VARCHAR(100), -- user, wallet, order, kyc_document, etc.
+    resource_id VARCHAR(255), -- ID of the affected resource
+
+    -- Request context
+    ip_address INET,
+    user_agent TEXT,
+    request_id VARCHAR(100), -- For correlation with application logs
+    session_id VARCHAR(100),
+
+    -- Event payload
+    metadata JSONB, -- Additional event-specific data
+    previous_state JSONB, -- Before state for data modifications
+    new_state JSONB, -- After state for data modifications
+
+    -- Security
+    status VARCHAR(20) NOT NULL, -- success, failure, error
+    failure_reason TEXT, -- For failed operations
+
+    -- Compliance
+    retention_until TIMESTAMP WITH TIME ZONE, -- For GDPR/compliance retention policies
+    is_sensitive BOOLEAN DEFAULT false, -- Flags logs containing PII
+
+    -- Timestamps (immutable - no updated_at)
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- The partition key must be part of the primary key
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+-- audit_logs_partition_name returns the partition table name for the month containing p_month (UTC)
+-- Example: 2025-11-08 -> audit_logs_y2025m11
+CREATE OR REPLACE FUNCTION audit_logs_partition_name(p_month TIMESTAMP WITH TIME ZONE)
+RETURNS TEXT
+LANGUAGE sql
+STABLE
+AS $$
+    SELECT 'audit_logs_' || to_char(p_month AT TIME ZONE 'UTC', '"y"YYYY"m"MM');
+$$;
+
+-- audit_logs_create_partition creates the partition for the month containing p_month
+-- Returns false if a table with the partition name already exists
+CREATE OR REPLACE FUNCTION audit_logs_create_partition(p_month TIMESTAMP WITH TIME ZONE)
+RETURNS BOOLEAN
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_start TIMESTAMP WITH TIME ZONE := date_trunc('month', p_month AT TIME ZONE 'UTC') AT TIME ZONE 'UTC';
+    v_end   TIMESTAMP WITH TIME ZONE := (date_trunc('month', p_month AT TIME ZONE 'UTC') + INTERVAL '1 month') AT TIME ZONE 'UTC';
+    v_name  TEXT := audit_logs_partition_name(p_month);
+BEGIN
+    IF to_regclass(v_name) IS NOT NULL THEN
+        RETURN FALSE;
+    END IF;
+
+    EXECUTE format(
+        'CREATE TABLE %I PARTITION OF audit_logs FOR VALUES FROM (%L) TO (%L)',
+        v_name, v_start, v_end
+    );
+    RETURN TRUE;
+END;
+$$;
+
+-- audit_logs_ensure_partitions creates every missing monthly partition between p_from and p_to (inclusive)
+-- Returns the number of partitions created
+CREATE OR REPLACE FUNCTION audit_logs_ensure_partitions(p_from TIMESTAMP WITH TIME ZONE, p_to TIMESTAMP WITH TIME ZONE)
+RETURNS INTEGER
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_month   TIMESTAMP WITH TIME ZONE := date_trunc('month', p_from AT TIME ZONE 'UTC') AT TIME ZONE 'UTC';
+    v_created INTEGER := 0;
+BEGIN
+    WHILE v_month <= p_to LOOP
+        IF audit_logs_create_partition(v_month) THEN
+            v_created := v_created + 1;
+        END IF;
+        v_month := ((v_month AT TIME ZONE 'UTC') + INTERVAL '1 month') AT TIME ZONE 'UTC';
+    END LOOP;
+    RETURN v_created;
+END;
+$$;
+
+-- audit_logs_detach_partition detaches the partition for the month containing p_month
+-- The table is kept so its rows can be archived. Returns false if it was not attached.
+CREATE OR REPLACE FUNCTION audit_logs_detach_partition(p_month TIMESTAMP WITH TIME ZONE)
+RETURNS BOOLEAN
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_name TEXT := audit_logs_partition_name(p_month);
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1
+        FROM pg_inherits i
+        JOIN pg_class c ON c.oid = i.inhrelid
+        WHERE i.inhparent = 'audit_logs'::regclass
+          AND c.relname = v_name
+    ) THEN
+        RETURN FALSE;
+    END IF;
+
+    EXECUTE format('ALTER TABLE audit_logs DETACH PARTITION %I', v_name);
+    RETURN TRUE;
+END;
+$$;
+
+-- audit_logs_drop_partition drops the partition table for the month containing p_month,
+-- detaching it first if needed. Returns false if the table does not exist.
+CREATE OR REPLACE FUNCTION audit_logs_drop_partition(p_month TIMESTAMP WITH TIME ZONE)
+RETURNS BOOLEAN
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_name TEXT := audit_logs_partition_name(p_month);
+BEGIN
+    IF to_regclass(v_name) IS NULL THEN
+        RETURN FALSE;
+    END IF;
+
+    PERFORM audit_logs_detach_partition(p_month);
+    EXECUTE format('DROP TABLE %I', v_name);
+    RETURN TRUE;
+END;
+$$;
+
+-- Create partitions covering all existing rows plus three months ahead
+SELECT audit_logs_ensure_partitions(
+    COALESCE((SELECT MIN(created_at) FROM audit_logs_legacy), NOW()),
+    NOW() + INTERVAL '3 months'
+);
+
+INSERT INTO audit_logs SELECT * FROM audit_logs_legacy;
+
+DROP TABLE audit_logs_legacy;
+
+-- Indexes for common query patterns (created on the parent, inherited by every partition)
+CREATE INDEX idx_audit_logs_event_type ON audit_logs(event_type);
+CREATE INDEX idx_audit_logs_user_id ON audit_logs(user_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at DESC);
+CREATE INDEX idx_audit_logs_event_category ON audit_logs(event_category);
+CREATE INDEX idx_audit_logs_severity ON audit_logs(severity);
+CREATE INDEX idx_audit_logs_actor_identifier ON audit_logs(actor_identifier);
+CREATE INDEX idx_audit_logs_resource ON audit_logs(resource_type, resource_id);
+CREATE INDEX idx_audit_logs_ip_address ON audit_logs(ip_address);
+
+-- Composite index for common filtering
+CREATE INDEX idx_audit_logs_user_created ON audit_logs(user_id, created_at DESC);
+CREATE INDEX idx_audit_logs_category_created ON audit_logs(event_category, created_at DESC);
+
+-- GIN index for JSONB metadata queries
+CREATE INDEX idx_audit_logs_metadata ON audit_logs USING GIN (metadata);
+
+-- Comment for documentation
+COMMENT ON TABLE audit_logs IS 'Immutable audit trail for security, compliance, and forensic analysis (partitioned monthly by created_at)';
+COMMENT ON COLUMN audit_logs.event_type IS 'Specific event identifier (e.g., user.login, kyc.approved)';
+COMMENT ON COLUMN audit_logs.event_category IS 'High-level categorization for filtering and reporting';
+COMMENT ON COLUMN audit_logs.severity IS 'Event severity for alerting and monitoring';
+COMMENT ON COLUMN audit_logs.metadata IS 'Flexible JSONB field for event-specific structured data';
+COMMENT ON COLUMN audit_logs.retention_until IS 'Auto-deletion timestamp for compliance with data retention policies';
+
+-- Tracks partitions that were exported to cold storage before being dropped
+CREATE TABLE IF NOT EXISTS audit_log_archives (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    partition_month TIMESTAMP WITH TIME ZONE NOT NULL UNIQUE,
+    partition_name VARCHAR(63) NOT NULL,
+    object_key TEXT NOT NULL,
+    manifest_key TEXT NOT NULL,
+    row_count BIGINT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    checksum_sha256 VARCHAR(64) NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    restored_at TIMESTAMP WITH TIME ZONE,
+    restored_by VARCHAR(255)
+);
+
+COMMENT ON TABLE audit_log_archives IS 'Monthly audit_logs partitions archived to cold storage';
+COMMENT ON COLUMN audit_log_archives.partition_month IS 'First instant (UTC) of the archived month';
+COMMENT ON COLUMN audit_log_archives.object_key IS 'Object store key of the gzip-compressed NDJSON export';
+COMMENT ON COLUMN audit_log_archives.manifest_key IS 'Object store key of the JSON manifest describing the export';
+COMMENT ON COLUMN audit_log_archives.checksum_sha256 IS 'Hex SHA-256 of the compressed export, verified on restore';
+COMMENT ON COLUMN audit_log_archives.restored_at IS 'Set while the month is restored into audit_logs for investigation (NULL otherwise)';
@@ -0,0 +1,2 @@
+-- Drop legal_holds table and all associated indexes
+DROP TABLE IF EXISTS legal_holds CASCADE;
@@ -0,0 +1,47 @@
+-- Create legal_holds table
+-- A legal hold freezes audit records and user data while an investigation is open.
+-- A hold matches audit logs by any combination of user, created_at range and event category;
+-- scope columns left NULL match everything. While a hold is active (not released and not
+-- past expires_at) matching audit logs are never deleted, the months it covers are never
+-- archived, and a user it names cannot be erased.
+
+CREATE TABLE IF NOT EXISTS legal_holds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Scope (at least one must be set)
+    user_id UUID, -- No FK: the hold must outlive the user row and is kept after release
+    starts_at TIMESTAMP WITH TIME ZONE, -- Inclusive lower bound on audit_logs.created_at
+    ends_at TIMESTAMP WITH TIME ZONE, -- Exclusive upper bound on audit_logs.created_at
+    event_category VARCHAR(50),
+
+    -- Justification
+    reason TEXT NOT NULL,
+    owner VARCHAR(255) NOT NULL, -- Person or team accountable for the hold (e.g. legal counsel)
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    -- Lifecycle
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    released_at TIMESTAMP WITH TIME ZONE,
+    released_by VARCHAR(255),
+    release_reason TEXT,
+
+    CONSTRAINT legal_holds_scope_check CHECK (
+        user_id IS NOT NULL OR starts_at IS NOT NULL OR ends_at IS NOT NULL OR event_category IS NOT NULL
+    ),
+    CONSTRAINT legal_holds_range_check CHECK (
+        starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at
+    )
+);
+
+-- Only unreleased holds are consulted by cleanup, archival and erasure
+CREATE INDEX idx_legal_holds_active ON legal_holds(expires_at) WHERE released_at IS NULL;
+CREATE INDEX idx_legal_holds_user_id ON legal_holds(user_id) WHERE user_id IS NOT NULL;
+
+COMMENT ON TABLE legal_holds IS 'Legal holds blocking deletion, archival and erasure of matching audit records and user data';
+COMMENT ON COLUMN legal_holds.user_id IS 'Held user; NULL matches all users';
+COMMENT ON COLUMN legal_holds.starts_at IS 'Inclusive start of the held created_at range; NULL is unbounded';
+COMMENT ON COLUMN legal_holds.ends_at IS 'Exclusive end of the held created_at range; NULL is unbounded';
+COMMENT ON COLUMN legal_holds.event_category IS 'Held audit event category; NULL matches all categories';
+COMMENT ON COLUMN legal_holds.expires_at IS 'The hold lapses automatically after this instant unless extended';
@@ -0,0 +1,2 @@
+-- Drop alerts table and all associated indexes
+DROP TABLE IF EXISTS alerts CASCADE;
@@ -0,0 +1,47 @@
+-- Create alerts table
+-- Security alerts raised by the rule engine when audit or security events match an alert rule.
+-- While an alert is open, repeat firings with the same dedup key (rule + group) update the
+-- existing row instead of adding a new one. Admins acknowledge alerts once triaged; the next
+-- firing after that opens a new alert.
+
+CREATE TABLE IF NOT EXISTS alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Rule that fired
+    rule_name VARCHAR(100) NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    summary TEXT NOT NULL,
+
+    -- Grouping and deduplication
+    group_by VARCHAR(20) NOT NULL DEFAULT '',
+    group_value VARCHAR(255) NOT NULL DEFAULT '',
+    dedup_key VARCHAR(400) NOT NULL,
+
+    -- Evidence
+    event_count INTEGER NOT NULL,
+    fire_count INTEGER NOT NULL DEFAULT 1,
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    events JSONB NOT NULL DEFAULT '[]'::jsonb,
+
+    -- Acknowledge workflow
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    acknowledged_at TIMESTAMP WITH TIME ZONE,
+    acknowledged_by VARCHAR(255),
+    ack_note TEXT,
+
+    CONSTRAINT alerts_severity_check CHECK (severity IN ('low', 'medium', 'high', 'critical')),
+    CONSTRAINT alerts_status_check CHECK (status IN ('open', 'acknowledged'))
+);
+
+-- At most one open alert per dedup key; repeat firings are folded into it
+CREATE UNIQUE INDEX idx_alerts_open_dedup_key ON alerts(dedup_key) WHERE status = 'open';
+CREATE INDEX idx_alerts_status_created_at ON alerts(status, created_at DESC);
+
+COMMENT ON TABLE alerts IS 'Security alerts raised by the alert rule engine';
+COMMENT ON COLUMN alerts.dedup_key IS 'Rule name and group value; repeat firings update the open alert with this key';
+COMMENT ON COLUMN alerts.event_count IS 'Events that triggered the alert, summed over all firings';
+COMMENT ON COLUMN alerts.fire_count IS 'Times the rule fired while this alert was open';
+COMMENT ON COLUMN alerts.events IS 'Most recent triggering events, as evidence';
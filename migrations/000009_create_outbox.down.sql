@@ -0,0 +1,2 @@
+-- Drop outbox table and all associated indexes
+DROP TABLE IF EXISTS outbox CASCADE;
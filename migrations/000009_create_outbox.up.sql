@@ -0,0 +1,43 @@
+-- Create outbox table (transactional outbox for domain events)
+-- Events are inserted in the same transaction as the change they describe and published
+-- to the event bus by the outbox relay. Delivery is at-least-once: a row is only marked
+-- published after the bus accepted it. Rows of one aggregate are published in id order;
+-- while one is backing off after a failed attempt, later rows of that aggregate wait.
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY, -- Publish order
+
+    -- Aggregate the event belongs to (ordering key)
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+
+    -- Event envelope
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    attributes JSONB NOT NULL DEFAULT '{}',
+    payload JSONB NOT NULL DEFAULT '{}',
+    metadata JSONB NOT NULL DEFAULT '{}',
+
+    -- Delivery state
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT outbox_event_id_unique UNIQUE (event_id),
+    CONSTRAINT outbox_attempts_check CHECK (attempts >= 0)
+);
+
+-- The relay scans pending rows in id order and checks earlier pending rows per aggregate
+CREATE INDEX idx_outbox_pending ON outbox(id) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_pending_aggregate ON outbox(aggregate_type, aggregate_id, id) WHERE published_at IS NULL;
+-- Cleanup of delivered rows
+CREATE INDEX idx_outbox_published_at ON outbox(published_at) WHERE published_at IS NOT NULL;
+
+COMMENT ON TABLE outbox IS 'Domain events awaiting (or recently completed) relay to the event bus';
+COMMENT ON COLUMN outbox.aggregate_id IS 'Ordering key: events of one aggregate are published in id order';
+COMMENT ON COLUMN outbox.attempts IS 'Failed publish attempts so far';
+COMMENT ON COLUMN outbox.next_attempt_at IS 'Earliest time of the next publish attempt (exponential backoff after failures)';
+COMMENT ON COLUMN outbox.published_at IS 'When the event bus accepted the event; NULL while pending';
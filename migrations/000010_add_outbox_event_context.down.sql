@@ -0,0 +1,9 @@
+-- Rollback: Remove CloudEvents context from outbox table
+-- Migration: 000010_add_outbox_event_context (down)
+
+ALTER TABLE outbox DROP CONSTRAINT IF EXISTS outbox_schema_version_check;
+
+ALTER TABLE outbox
+DROP COLUMN IF EXISTS schema_version,
+DROP COLUMN IF EXISTS subject,
+DROP COLUMN IF EXISTS source;
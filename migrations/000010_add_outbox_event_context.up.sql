@@ -0,0 +1,16 @@
+-- Add CloudEvents context to outbox table
+-- Migration: 000010_add_outbox_event_context
+-- Description: Store the event source, subject and payload schema version so relayed events
+-- keep their CloudEvents attributes
+
+ALTER TABLE outbox
+ADD COLUMN source VARCHAR(255) NOT NULL DEFAULT '/pandora-exchange/user-service',
+ADD COLUMN subject VARCHAR(255) NOT NULL DEFAULT '',
+ADD COLUMN schema_version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE outbox
+ADD CONSTRAINT outbox_schema_version_check CHECK (schema_version >= 1);
+
+COMMENT ON COLUMN outbox.source IS 'CloudEvents source: the producer of the event';
+COMMENT ON COLUMN outbox.subject IS 'CloudEvents subject: the entity the event is about, e.g. the user ID';
+COMMENT ON COLUMN outbox.schema_version IS 'Version of the payload JSON Schema';
@@ -0,0 +1,2 @@
+-- Drop event_replay_jobs table and all associated indexes
+DROP TABLE IF EXISTS event_replay_jobs CASCADE;
@@ -0,0 +1,56 @@
+-- Create event_replay_jobs table
+-- Replay jobs regenerate events for consumers that need history the event bus no longer holds:
+-- snapshot events built from current users state, or events re-read from the outbox or
+-- audit_logs for a time range. Jobs are published to their own target stream at a bounded
+-- rate. The cursor records how far a job got, so a job interrupted by a restart, a failure
+-- or a cancellation resumes where it stopped. A running job is leased by one instance at a
+-- time; a job whose lease expired is picked up again by any instance.
+
+CREATE TABLE IF NOT EXISTS event_replay_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- What to replay
+    source VARCHAR(20) NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    from_time TIMESTAMP WITH TIME ZONE,
+    to_time TIMESTAMP WITH TIME ZONE,
+    target_stream VARCHAR(255) NOT NULL,
+    rate_limit INTEGER NOT NULL,
+    batch_size INTEGER NOT NULL,
+
+    -- Progress
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    resume_cursor TEXT NOT NULL DEFAULT '',
+    total_records BIGINT NOT NULL DEFAULT 0,
+    processed_records BIGINT NOT NULL DEFAULT 0,
+    published_events BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT,
+
+    -- Lease held by the instance running the job
+    lease_owner VARCHAR(255),
+    lease_expires_at TIMESTAMP WITH TIME ZONE,
+
+    requested_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT event_replay_jobs_source_check CHECK (source IN ('snapshot', 'outbox', 'audit')),
+    CONSTRAINT event_replay_jobs_status_check CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled')),
+    CONSTRAINT event_replay_jobs_rate_limit_check CHECK (rate_limit > 0),
+    CONSTRAINT event_replay_jobs_batch_size_check CHECK (batch_size > 0),
+    CONSTRAINT event_replay_jobs_range_check CHECK (from_time IS NULL OR to_time IS NULL OR from_time < to_time)
+);
+
+-- Workers look for pending jobs and running jobs whose lease expired
+CREATE INDEX idx_event_replay_jobs_claimable ON event_replay_jobs(created_at) WHERE status IN ('pending', 'running');
+CREATE INDEX idx_event_replay_jobs_created_at ON event_replay_jobs(created_at DESC);
+
+COMMENT ON TABLE event_replay_jobs IS 'Event replay and backfill jobs';
+COMMENT ON COLUMN event_replay_jobs.source IS 'snapshot (current users state), outbox or audit (history for a time range)';
+COMMENT ON COLUMN event_replay_jobs.event_types IS 'Event types to replay; empty replays every type the source supports';
+COMMENT ON COLUMN event_replay_jobs.rate_limit IS 'Maximum events published per second';
+COMMENT ON COLUMN event_replay_jobs.resume_cursor IS 'Source position after the last replayed record; the job resumes from here';
+COMMENT ON COLUMN event_replay_jobs.total_records IS 'Source records in range when the job started, for progress reporting';
+COMMENT ON COLUMN event_replay_jobs.lease_expires_at IS 'A running job whose lease expired is resumed by another instance';
@@ -0,0 +1,3 @@
+-- Drop webhook tables and all associated indexes
+DROP TABLE IF EXISTS webhook_deliveries CASCADE;
+DROP TABLE IF EXISTS webhook_subscriptions CASCADE;
@@ -0,0 +1,69 @@
+-- Create webhook_subscriptions and webhook_deliveries tables
+-- Webhook subscriptions deliver user lifecycle events to partner and back-office HTTP endpoints.
+-- Every event relayed to the event bus is fanned out into one delivery row per enabled
+-- subscription whose event types match. Deliveries are POSTed signed with the subscription
+-- secret and retried with exponential backoff; a subscription whose endpoint keeps failing is
+-- disabled until an admin re-enables it. Delivery rows double as the delivery log.
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    url TEXT NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    secret VARCHAR(255) NOT NULL,
+
+    -- Health
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    disabled_at TIMESTAMP WITH TIME ZONE,
+    disabled_reason TEXT,
+
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT webhook_subscriptions_failures_check CHECK (consecutive_failures >= 0)
+);
+
+CREATE INDEX idx_webhook_subscriptions_created_at ON webhook_subscriptions(created_at DESC);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+
+    -- Event, stored as the exact request body so redeliveries are byte-identical
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+
+    -- Delivery state
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_attempt_at TIMESTAMP WITH TIME ZONE,
+    last_status_code INTEGER,
+    last_error TEXT,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT webhook_deliveries_event_unique UNIQUE (subscription_id, event_id),
+    CONSTRAINT webhook_deliveries_status_check CHECK (status IN ('pending', 'succeeded', 'failed')),
+    CONSTRAINT webhook_deliveries_attempts_check CHECK (attempts >= 0)
+);
+
+-- The dispatcher scans pending deliveries by due time
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+-- Delivery log per subscription, newest first
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, created_at DESC);
+-- Cleanup of finished deliveries
+CREATE INDEX idx_webhook_deliveries_created_at ON webhook_deliveries(created_at) WHERE status <> 'pending';
+
+COMMENT ON TABLE webhook_subscriptions IS 'HTTP endpoints receiving user lifecycle events';
+COMMENT ON COLUMN webhook_subscriptions.event_types IS 'Event types delivered; empty delivers every type';
+COMMENT ON COLUMN webhook_subscriptions.secret IS 'HMAC-SHA256 key signing each delivery';
+COMMENT ON COLUMN webhook_subscriptions.consecutive_failures IS 'Failed attempts since the last successful delivery; the subscription is disabled past a threshold';
+COMMENT ON TABLE webhook_deliveries IS 'Webhook delivery queue and delivery log';
+COMMENT ON COLUMN webhook_deliveries.payload IS 'CloudEvent sent as the request body';
+COMMENT ON COLUMN webhook_deliveries.next_attempt_at IS 'Earliest time of the next attempt (exponential backoff after failures); pushed forward while an attempt is in flight';
+COMMENT ON COLUMN webhook_deliveries.status IS 'pending until delivered (succeeded) or out of attempts (failed)';
@@ -0,0 +1,4 @@
+-- Drop KYC case tables and all associated indexes
+DROP TABLE IF EXISTS kyc_case_transitions CASCADE;
+DROP TABLE IF EXISTS kyc_documents CASCADE;
+DROP TABLE IF EXISTS kyc_cases CASCADE;
@@ -0,0 +1,83 @@
+-- Create kyc_cases, kyc_documents and kyc_case_transitions tables
+-- A KYC case is one application of a user: the identity data they submit, the documents
+-- they upload and its review by admins. Cases follow a strict state machine
+-- (draft -> submitted -> in_review -> approved / rejected / needs_more_info) and every action
+-- is recorded in kyc_case_transitions. users.kyc_status is derived from the user's latest case.
+-- Document content lives in the object store; kyc_documents holds its metadata and checksum.
+
+CREATE TABLE IF NOT EXISTS kyc_cases (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    risk_level VARCHAR(10) NOT NULL DEFAULT 'low',
+    applicant JSONB NOT NULL DEFAULT '{}',
+
+    -- Review
+    reviewer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    first_approver_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    first_approved_at TIMESTAMP WITH TIME ZONE,
+    decided_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    rejection_reason VARCHAR(50),
+    review_note TEXT,
+
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT kyc_cases_status_check CHECK (status IN ('draft', 'submitted', 'in_review', 'approved', 'rejected', 'needs_more_info')),
+    CONSTRAINT kyc_cases_risk_level_check CHECK (risk_level IN ('low', 'medium', 'high')),
+    CONSTRAINT kyc_cases_rejection_check CHECK ((status = 'rejected') = (rejection_reason IS NOT NULL)),
+    CONSTRAINT kyc_cases_four_eyes_check CHECK (first_approver_id IS NULL OR decided_by IS NULL OR first_approver_id <> decided_by)
+);
+
+-- A user has at most one case in progress; approved and rejected cases are kept as history
+CREATE UNIQUE INDEX idx_kyc_cases_open_user ON kyc_cases(user_id) WHERE status NOT IN ('approved', 'rejected');
+CREATE INDEX idx_kyc_cases_user ON kyc_cases(user_id, created_at DESC);
+-- Review queue, oldest submission first
+CREATE INDEX idx_kyc_cases_queue ON kyc_cases(status, submitted_at);
+CREATE INDEX idx_kyc_cases_reviewer ON kyc_cases(reviewer_id) WHERE reviewer_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS kyc_documents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    case_id UUID NOT NULL REFERENCES kyc_cases(id) ON DELETE CASCADE,
+
+    document_type VARCHAR(30) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE,
+    uploaded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT kyc_documents_type_check CHECK (document_type IN ('passport', 'national_id', 'drivers_license', 'proof_of_address', 'selfie')),
+    CONSTRAINT kyc_documents_size_check CHECK (size_bytes > 0)
+);
+
+CREATE INDEX idx_kyc_documents_case ON kyc_documents(case_id, uploaded_at);
+
+CREATE TABLE IF NOT EXISTS kyc_case_transitions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    case_id UUID NOT NULL REFERENCES kyc_cases(id) ON DELETE CASCADE,
+
+    action VARCHAR(30) NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor_id UUID NOT NULL,
+    reason_code VARCHAR(50),
+    note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_kyc_case_transitions_case ON kyc_case_transitions(case_id, created_at);
+
+COMMENT ON TABLE kyc_cases IS 'KYC applications and their review';
+COMMENT ON COLUMN kyc_cases.applicant IS 'Identity data submitted by the applicant (name, date of birth, nationality, address)';
+COMMENT ON COLUMN kyc_cases.first_approver_id IS 'First of the two admins approving a high-risk case (four-eyes principle)';
+COMMENT ON COLUMN kyc_cases.version IS 'Incremented on every update; guards against concurrent review decisions';
+COMMENT ON TABLE kyc_documents IS 'Metadata of documents uploaded to KYC cases; content lives in the object store';
+COMMENT ON COLUMN kyc_documents.sha256 IS 'Hex SHA-256 of the content, to verify the stored object was not altered';
+COMMENT ON TABLE kyc_case_transitions IS 'History of actions taken on KYC cases';
+COMMENT ON COLUMN kyc_case_transitions.actor_id IS 'Applicant or admin who took the action; not a foreign key so history survives user removal';
@@ -0,0 +1,3 @@
+-- Drop KYC tier tables and all associated indexes
+DROP TABLE IF EXISTS user_kyc_tier_changes CASCADE;
+DROP TABLE IF EXISTS user_kyc_tiers CASCADE;
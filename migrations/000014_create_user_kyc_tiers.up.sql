@@ -0,0 +1,34 @@
+-- Create user_kyc_tiers and user_kyc_tier_changes tables
+-- A user's KYC tier (0-3) selects their limits and allowed products; the limits of each tier
+-- are configuration, not data. Users without a row hold tier 0. Tiers above 0 only take effect
+-- while the user is verified. Every change is kept in user_kyc_tier_changes.
+
+CREATE TABLE IF NOT EXISTS user_kyc_tiers (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    tier SMALLINT NOT NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT user_kyc_tiers_tier_check CHECK (tier BETWEEN 0 AND 3)
+);
+
+CREATE TABLE IF NOT EXISTS user_kyc_tier_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    old_tier SMALLINT NOT NULL,
+    new_tier SMALLINT NOT NULL,
+    reason TEXT NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    case_id UUID REFERENCES kyc_cases(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT user_kyc_tier_changes_tier_check CHECK (old_tier BETWEEN 0 AND 3 AND new_tier BETWEEN 0 AND 3)
+);
+
+CREATE INDEX idx_user_kyc_tier_changes_user ON user_kyc_tier_changes(user_id, created_at);
+
+COMMENT ON TABLE user_kyc_tiers IS 'KYC tier held by each user; users without a row hold tier 0';
+COMMENT ON COLUMN user_kyc_tiers.updated_by IS 'Admin who last changed the tier; NULL for automatic upgrades on KYC approval';
+COMMENT ON TABLE user_kyc_tier_changes IS 'History of KYC tier changes';
+COMMENT ON COLUMN user_kyc_tier_changes.case_id IS 'Approved KYC case behind an automatic upgrade';
@@ -0,0 +1,8 @@
+-- Drop external verification provider columns from kyc_cases
+
+DROP INDEX IF EXISTS idx_kyc_cases_provider_applicant;
+
+ALTER TABLE kyc_cases
+    DROP CONSTRAINT IF EXISTS kyc_cases_provider_check,
+    DROP COLUMN IF EXISTS provider_applicant_id,
+    DROP COLUMN IF EXISTS provider;
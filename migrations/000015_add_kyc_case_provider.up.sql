@@ -0,0 +1,14 @@
+-- Add external verification provider columns to kyc_cases
+-- A case can be verified by an external KYC provider instead of, or before, an admin. The
+-- provider's applicant ID links its decision webhooks back to the case.
+
+ALTER TABLE kyc_cases
+    ADD COLUMN provider VARCHAR(50),
+    ADD COLUMN provider_applicant_id VARCHAR(255),
+    ADD CONSTRAINT kyc_cases_provider_check CHECK ((provider IS NULL) = (provider_applicant_id IS NULL));
+
+-- Decision webhooks look cases up by the provider's applicant ID
+CREATE UNIQUE INDEX idx_kyc_cases_provider_applicant ON kyc_cases(provider, provider_applicant_id) WHERE provider IS NOT NULL;
+
+COMMENT ON COLUMN kyc_cases.provider IS 'External KYC provider verifying the case; NULL for cases reviewed by admins only';
+COMMENT ON COLUMN kyc_cases.provider_applicant_id IS 'Applicant ID assigned by the provider';
@@ -0,0 +1,2 @@
+-- Drop screening cases table and all associated indexes
+DROP TABLE IF EXISTS screening_cases CASCADE;
@@ -0,0 +1,39 @@
+-- Create screening_cases table
+-- A screening case holds the watch list entries (sanctions and PEP lists) a user matched and
+-- their review by compliance: open -> cleared (false positive) or confirmed (true match).
+-- Open and confirmed cases block the user's KYC approval. The matched entries are stored
+-- with the case so the decision stays reviewable after the lists change.
+
+CREATE TABLE IF NOT EXISTS screening_cases (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    trigger VARCHAR(20) NOT NULL,
+    subject JSONB NOT NULL DEFAULT '{}',
+    matches JSONB NOT NULL DEFAULT '[]',
+    list_version VARCHAR(64) NOT NULL,
+
+    -- Review
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT,
+
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT screening_cases_status_check CHECK (status IN ('open', 'cleared', 'confirmed')),
+    CONSTRAINT screening_cases_trigger_check CHECK (trigger IN ('registration', 'profile_change', 'kyc_approval', 'rescreen', 'manual')),
+    CONSTRAINT screening_cases_review_check CHECK ((status = 'open') = (reviewed_at IS NULL))
+);
+
+CREATE INDEX idx_screening_cases_user ON screening_cases(user_id, created_at DESC);
+-- Review queue, oldest case first
+CREATE INDEX idx_screening_cases_queue ON screening_cases(status, created_at);
+
+COMMENT ON TABLE screening_cases IS 'Sanctions and PEP watch list matches of users and their compliance review';
+COMMENT ON COLUMN screening_cases.subject IS 'Names and date of birth the user was screened with';
+COMMENT ON COLUMN screening_cases.matches IS 'Matched watch list entries with their similarity scores';
+COMMENT ON COLUMN screening_cases.list_version IS 'Version of the watch lists the user was screened against';
+COMMENT ON COLUMN screening_cases.version IS 'Incremented on every update; guards against concurrent review decisions';
@@ -0,0 +1,17 @@
+-- Drop extended profile fields from users
+
+DROP TABLE IF EXISTS phone_verifications CASCADE;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_phone_verified_check,
+    DROP COLUMN IF EXISTS tax_residency,
+    DROP COLUMN IF EXISTS nationality,
+    DROP COLUMN IF EXISTS address_country,
+    DROP COLUMN IF EXISTS address_region,
+    DROP COLUMN IF EXISTS address_postal_code,
+    DROP COLUMN IF EXISTS address_city,
+    DROP COLUMN IF EXISTS address_line2,
+    DROP COLUMN IF EXISTS address_line1,
+    DROP COLUMN IF EXISTS date_of_birth,
+    DROP COLUMN IF EXISTS phone_verified_at,
+    DROP COLUMN IF EXISTS phone;
@@ -0,0 +1,40 @@
+-- Add extended profile fields to users
+-- KYC and tax reporting need a phone number, date of birth, residential address,
+-- nationality and tax residency. Every field is optional; the application validates formats
+-- and the minimum age.
+
+ALTER TABLE users
+    ADD COLUMN phone VARCHAR(16),
+    ADD COLUMN phone_verified_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN date_of_birth DATE,
+    ADD COLUMN address_line1 VARCHAR(200),
+    ADD COLUMN address_line2 VARCHAR(200),
+    ADD COLUMN address_city VARCHAR(200),
+    ADD COLUMN address_postal_code VARCHAR(200),
+    ADD COLUMN address_region VARCHAR(200),
+    ADD COLUMN address_country CHAR(2),
+    ADD COLUMN nationality CHAR(2),
+    ADD COLUMN tax_residency CHAR(2),
+    ADD CONSTRAINT users_phone_verified_check CHECK (phone_verified_at IS NULL OR phone IS NOT NULL);
+
+COMMENT ON COLUMN users.phone IS 'Phone number in E.164 format';
+COMMENT ON COLUMN users.phone_verified_at IS 'When the user confirmed a code sent to phone; NULL until verified and reset when phone changes';
+COMMENT ON COLUMN users.date_of_birth IS 'Date of birth; users must be at least 18';
+COMMENT ON COLUMN users.address_country IS 'Residential address country (ISO 3166-1 alpha-2)';
+COMMENT ON COLUMN users.nationality IS 'Nationality (ISO 3166-1 alpha-2)';
+COMMENT ON COLUMN users.tax_residency IS 'Country of tax residency (ISO 3166-1 alpha-2)';
+
+-- Codes sent to verify a phone number; at most one pending per user
+CREATE TABLE phone_verifications (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    phone VARCHAR(16) NOT NULL,
+    code_hash BYTEA NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE phone_verifications IS 'Pending phone number verification codes';
+COMMENT ON COLUMN phone_verifications.phone IS 'Number the code was sent to; the code only verifies this number';
+COMMENT ON COLUMN phone_verifications.code_hash IS 'SHA-256 of the code; the code itself is never stored';
+COMMENT ON COLUMN phone_verifications.attempts IS 'Wrong codes entered so far';
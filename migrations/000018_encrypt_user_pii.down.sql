@@ -0,0 +1,27 @@
+-- Drop PII encryption columns from users
+-- Encrypted values cannot be decrypted in SQL, so only roll back databases that never
+-- enabled PII encryption.
+
+DROP INDEX IF EXISTS idx_users_pii_key_version;
+DROP INDEX IF EXISTS idx_users_pii_pending;
+DROP INDEX IF EXISTS idx_users_email_index;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_pii_key_version_check,
+    DROP COLUMN IF EXISTS pii_key_version,
+    DROP COLUMN IF EXISTS pii_key,
+    DROP COLUMN IF EXISTS email_index,
+    ALTER COLUMN date_of_birth TYPE DATE USING date_of_birth::DATE,
+    ALTER COLUMN address_country TYPE CHAR(2),
+    ALTER COLUMN nationality TYPE CHAR(2),
+    ALTER COLUMN tax_residency TYPE CHAR(2),
+    ALTER COLUMN phone TYPE VARCHAR(16),
+    ALTER COLUMN address_line1 TYPE VARCHAR(200),
+    ALTER COLUMN address_line2 TYPE VARCHAR(200),
+    ALTER COLUMN address_city TYPE VARCHAR(200),
+    ALTER COLUMN address_postal_code TYPE VARCHAR(200),
+    ALTER COLUMN address_region TYPE VARCHAR(200),
+    ALTER COLUMN first_name TYPE VARCHAR(100),
+    ALTER COLUMN last_name TYPE VARCHAR(100);
+
+COMMENT ON COLUMN users.date_of_birth IS 'Date of birth; users must be at least 18';
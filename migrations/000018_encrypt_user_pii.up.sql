@@ -0,0 +1,35 @@
+-- Prepare users for field-level encryption of PII
+-- Encrypted values are stored as "enc:v1:<base64>" in the existing columns, so every PII
+-- column becomes TEXT. Each user has a data key, wrapped by Vault Transit or the local KMS;
+-- rows without one still hold plaintext until the re-encryption job has processed them.
+-- Email lookups go through a keyed blind index because ciphertexts cannot be compared.
+
+ALTER TABLE users
+    ALTER COLUMN first_name TYPE TEXT,
+    ALTER COLUMN last_name TYPE TEXT,
+    ALTER COLUMN phone TYPE TEXT,
+    ALTER COLUMN date_of_birth TYPE TEXT USING to_char(date_of_birth, 'YYYY-MM-DD'),
+    ALTER COLUMN address_line1 TYPE TEXT,
+    ALTER COLUMN address_line2 TYPE TEXT,
+    ALTER COLUMN address_city TYPE TEXT,
+    ALTER COLUMN address_postal_code TYPE TEXT,
+    ALTER COLUMN address_region TYPE TEXT,
+    ALTER COLUMN address_country TYPE TEXT,
+    ALTER COLUMN nationality TYPE TEXT,
+    ALTER COLUMN tax_residency TYPE TEXT,
+    ADD COLUMN email_index BYTEA,
+    ADD COLUMN pii_key TEXT,
+    ADD COLUMN pii_key_version INTEGER,
+    ADD CONSTRAINT users_pii_key_version_check CHECK ((pii_key IS NULL) = (pii_key_version IS NULL));
+
+-- Emails stay unique once encrypted, including those of deleted users
+CREATE UNIQUE INDEX idx_users_email_index ON users(email_index);
+
+-- Finds rows the re-encryption job still has to process
+CREATE INDEX idx_users_pii_pending ON users(id) WHERE email_index IS NULL;
+CREATE INDEX idx_users_pii_key_version ON users(pii_key_version);
+
+COMMENT ON COLUMN users.date_of_birth IS 'Date of birth (YYYY-MM-DD); users must be at least 18';
+COMMENT ON COLUMN users.email_index IS 'HMAC-SHA256 blind index of email; NULL until the email is encrypted';
+COMMENT ON COLUMN users.pii_key IS 'Data key encrypting the PII columns, wrapped by the key manager; NULL while the row holds plaintext';
+COMMENT ON COLUMN users.pii_key_version IS 'Version of the key encryption key that wrapped pii_key';
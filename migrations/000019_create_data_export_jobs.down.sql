@@ -0,0 +1,3 @@
+-- Drop data_export_jobs table and all associated indexes
+-- Archives already written stay in the object store under DATA_EXPORT_PREFIX.
+DROP TABLE IF EXISTS data_export_jobs CASCADE;
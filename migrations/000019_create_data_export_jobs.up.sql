@@ -0,0 +1,57 @@
+-- Create data_export_jobs table
+-- Data subject access exports (GDPR Art. 15 and 20): a job collects the personal data held
+-- about a user into a signed ZIP archive stored in the object store. Users request exports
+-- for themselves; admins can request one on a user's behalf. A running job is leased by one
+-- instance at a time; a job whose lease expired is picked up again by any instance.
+-- Completed archives are downloaded through short-lived links and deleted once they expire.
+
+CREATE TABLE IF NOT EXISTS data_export_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    requested_by VARCHAR(255) NOT NULL,
+    requester_type VARCHAR(20) NOT NULL,
+    last_error TEXT,
+
+    -- Archive, set once completed
+    object_key TEXT,
+    size_bytes BIGINT,
+    sha256 VARCHAR(64),
+    expires_at TIMESTAMP WITH TIME ZONE,
+
+    -- Latest download link; earlier links are invalidated when a new one is issued
+    download_token_hash BYTEA,
+    download_expires_at TIMESTAMP WITH TIME ZONE,
+    download_count INTEGER NOT NULL DEFAULT 0,
+    last_downloaded_at TIMESTAMP WITH TIME ZONE,
+
+    -- Lease held by the instance building the archive
+    lease_owner VARCHAR(255),
+    lease_expires_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT data_export_jobs_status_check CHECK (status IN ('pending', 'running', 'completed', 'failed', 'expired')),
+    CONSTRAINT data_export_jobs_requester_type_check CHECK (requester_type IN ('user', 'admin')),
+    CONSTRAINT data_export_jobs_archive_check CHECK (status <> 'completed' OR (object_key IS NOT NULL AND expires_at IS NOT NULL))
+);
+
+-- A user has at most one export pending or running
+CREATE UNIQUE INDEX idx_data_export_jobs_active_user ON data_export_jobs(user_id) WHERE status IN ('pending', 'running');
+-- Workers look for pending jobs and running jobs whose lease expired
+CREATE INDEX idx_data_export_jobs_claimable ON data_export_jobs(created_at) WHERE status IN ('pending', 'running');
+-- The purge looks for completed archives past their expiry
+CREATE INDEX idx_data_export_jobs_expires_at ON data_export_jobs(expires_at) WHERE status = 'completed';
+CREATE INDEX idx_data_export_jobs_user_created ON data_export_jobs(user_id, created_at DESC);
+CREATE UNIQUE INDEX idx_data_export_jobs_download_token ON data_export_jobs(download_token_hash) WHERE download_token_hash IS NOT NULL;
+
+COMMENT ON TABLE data_export_jobs IS 'GDPR data subject access exports';
+COMMENT ON COLUMN data_export_jobs.requested_by IS 'User ID, or email of the admin who requested the export on the user''s behalf';
+COMMENT ON COLUMN data_export_jobs.object_key IS 'Object store key of the signed ZIP archive';
+COMMENT ON COLUMN data_export_jobs.expires_at IS 'When the archive is deleted and the job moves to expired';
+COMMENT ON COLUMN data_export_jobs.download_token_hash IS 'SHA-256 of the latest download link token';
+COMMENT ON COLUMN data_export_jobs.lease_expires_at IS 'A running job whose lease expired is resumed by another instance';
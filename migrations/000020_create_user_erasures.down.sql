@@ -0,0 +1,14 @@
+-- Drop user_erasures and make emails unique across all users again
+-- Fails if a deleted user's email was registered again.
+
+DROP INDEX IF EXISTS idx_users_email_index;
+CREATE UNIQUE INDEX idx_users_email_index ON users(email_index);
+
+DROP INDEX IF EXISTS idx_users_email;
+CREATE INDEX idx_users_email ON users(email) WHERE deleted_at IS NULL;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+COMMENT ON COLUMN users.email IS 'User email address (unique, used for login)';
+
+DROP INDEX IF EXISTS idx_user_erasures_deferred;
+DROP TABLE IF EXISTS user_erasures;
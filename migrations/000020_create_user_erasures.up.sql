@@ -0,0 +1,48 @@
+-- Create user_erasures table and let deleted users' emails be registered again
+-- Deleted accounts are erased (GDPR Art. 17) once their cooling-off period has passed: the
+-- user row is pseudonymised, its data key destroyed, sessions and pending verifications
+-- deleted, KYC and screening data cleared, and the PII in audit_logs replaced with a stable
+-- pseudonym. Erasure waits while a legal hold or a retention obligation applies; this table
+-- records each deferral and the completed erasure.
+
+CREATE TABLE IF NOT EXISTS user_erasures (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+
+    status VARCHAR(20) NOT NULL,
+
+    -- Set while deferred
+    deferral_reason VARCHAR(30),
+    deferred_until TIMESTAMP WITH TIME ZONE,
+
+    -- Set once erased
+    pseudonym VARCHAR(64),
+    audit_logs_rewritten BIGINT NOT NULL DEFAULT 0,
+    erased_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT user_erasures_status_check CHECK (status IN ('deferred', 'erased')),
+    CONSTRAINT user_erasures_reason_check CHECK (deferral_reason IS NULL OR deferral_reason IN ('legal_hold', 'retention')),
+    CONSTRAINT user_erasures_deferred_check CHECK ((status = 'deferred') = (deferral_reason IS NOT NULL AND deferred_until IS NOT NULL)),
+    CONSTRAINT user_erasures_erased_check CHECK ((status = 'erased') = (pseudonym IS NOT NULL AND erased_at IS NOT NULL))
+);
+
+-- The erasure job skips users deferred past now
+CREATE INDEX idx_user_erasures_deferred ON user_erasures(deferred_until) WHERE status = 'deferred';
+
+-- Emails only need to be unique among active users, so a deleted account does not block
+-- registering its email again
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+DROP INDEX IF EXISTS idx_users_email;
+CREATE UNIQUE INDEX idx_users_email ON users(email) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_users_email_index;
+CREATE UNIQUE INDEX idx_users_email_index ON users(email_index) WHERE deleted_at IS NULL;
+
+COMMENT ON TABLE user_erasures IS 'GDPR erasure of deleted users: deferrals and completed erasures';
+COMMENT ON COLUMN user_erasures.deferral_reason IS 'legal_hold (an active hold names the user or covers their audit history) or retention (KYC/AML records must be kept)';
+COMMENT ON COLUMN user_erasures.deferred_until IS 'The erasure job retries the user after this instant';
+COMMENT ON COLUMN user_erasures.pseudonym IS 'Stable pseudonym that replaced the user''s PII in users and audit_logs';
+COMMENT ON COLUMN user_erasures.audit_logs_rewritten IS 'Number of audit_logs rows whose PII was replaced';
+COMMENT ON COLUMN users.email IS 'User email address (unique among active users, used for login)';
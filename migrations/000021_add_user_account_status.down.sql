@@ -0,0 +1,14 @@
+-- Drop account status from users and drop user_status_changes
+
+DROP TABLE IF EXISTS user_status_changes CASCADE;
+
+DROP INDEX IF EXISTS idx_users_status_expires_at;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_status_expiry_check,
+    DROP CONSTRAINT IF EXISTS users_status_check,
+    DROP COLUMN IF EXISTS status_set_at,
+    DROP COLUMN IF EXISTS status_set_by,
+    DROP COLUMN IF EXISTS status_expires_at,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,40 @@
+-- Add account status to users and create user_status_changes
+-- Compliance can suspend an account (no login), freeze its withdrawals or close it without
+-- deleting it. Each change records a reason and the admin who made it; suspensions and
+-- freezes can expire, after which the account is active again. Every change is kept in
+-- user_status_changes.
+
+ALTER TABLE users
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active',
+    ADD COLUMN status_reason TEXT,
+    ADD COLUMN status_expires_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN status_set_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN status_set_at TIMESTAMP WITH TIME ZONE,
+    ADD CONSTRAINT users_status_check CHECK (status IN ('active', 'suspended', 'frozen_withdrawals', 'closed')),
+    ADD CONSTRAINT users_status_expiry_check CHECK (status_expires_at IS NULL OR status IN ('suspended', 'frozen_withdrawals'));
+
+-- The expiry job lifts statuses whose expiry has passed
+CREATE INDEX idx_users_status_expires_at ON users(status_expires_at) WHERE status_expires_at IS NOT NULL AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS user_status_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    old_status VARCHAR(20) NOT NULL,
+    new_status VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    sessions_revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_status_changes_user ON user_status_changes(user_id, created_at);
+
+COMMENT ON COLUMN users.status IS 'Account status: active, suspended (no login), frozen_withdrawals or closed (no login)';
+COMMENT ON COLUMN users.status_reason IS 'Why the status was last changed; NULL if it never was';
+COMMENT ON COLUMN users.status_expires_at IS 'When a suspension or freeze ends; NULL if it does not expire';
+COMMENT ON COLUMN users.status_set_by IS 'Admin who last changed the status; NULL when lifted on expiry';
+COMMENT ON TABLE user_status_changes IS 'History of account status changes';
+COMMENT ON COLUMN user_status_changes.changed_by IS 'Admin who changed the status; NULL when lifted on expiry';
+COMMENT ON COLUMN user_status_changes.sessions_revoked IS 'Whether the user''s sessions were revoked with the change';
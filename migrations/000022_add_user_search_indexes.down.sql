@@ -0,0 +1,11 @@
+-- Drop the admin user search indexes
+
+DROP INDEX IF EXISTS idx_users_status;
+DROP INDEX IF EXISTS idx_users_role;
+DROP INDEX IF EXISTS idx_users_created_at_id;
+DROP INDEX IF EXISTS idx_users_search_tsv;
+DROP INDEX IF EXISTS idx_users_search_trgm;
+
+DROP FUNCTION IF EXISTS user_search_text(TEXT, TEXT, TEXT);
+
+-- pg_trgm is left installed; other objects may depend on it
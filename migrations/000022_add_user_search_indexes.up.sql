@@ -0,0 +1,31 @@
+-- Add indexes for admin user search
+-- Admins search users by email and name with substring (trigram) and full-text matching,
+-- filter them by role, KYC status and account status, and page through them by
+-- (created_at, id) keyset cursors instead of OFFSET.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- user_search_text is the lowercased text that search matches: the plaintext email and names
+-- of a user. Encrypted values are left out; encrypted users only match on their exact email,
+-- through its blind index.
+CREATE OR REPLACE FUNCTION user_search_text(email TEXT, first_name TEXT, last_name TEXT)
+RETURNS TEXT
+LANGUAGE sql IMMUTABLE PARALLEL SAFE
+AS $$
+    SELECT lower(concat_ws(' ',
+        CASE WHEN email LIKE 'enc:%' THEN NULL ELSE email END,
+        CASE WHEN first_name LIKE 'enc:%' THEN NULL ELSE first_name END,
+        CASE WHEN last_name LIKE 'enc:%' THEN NULL ELSE last_name END))
+$$;
+
+CREATE INDEX idx_users_search_trgm ON users
+    USING gin (user_search_text(email, first_name, last_name) gin_trgm_ops);
+CREATE INDEX idx_users_search_tsv ON users
+    USING gin (to_tsvector('simple', user_search_text(email, first_name, last_name)));
+
+-- Keyset pagination orders by (created_at, id)
+CREATE INDEX idx_users_created_at_id ON users(created_at, id);
+CREATE INDEX idx_users_role ON users(role, created_at);
+CREATE INDEX idx_users_status ON users(status, created_at) WHERE status <> 'active';
+
+COMMENT ON FUNCTION user_search_text(TEXT, TEXT, TEXT) IS 'Lowercased plaintext email and names matched by admin user search; encrypted values are left out';
@@ -0,0 +1,12 @@
+-- Drop the admin statistics rollups
+
+DROP MATERIALIZED VIEW IF EXISTS stats_sessions_by_device;
+DROP MATERIALIZED VIEW IF EXISTS stats_kyc_funnel;
+DROP FUNCTION IF EXISTS user_agent_device(TEXT);
+
+DROP INDEX IF EXISTS idx_kyc_cases_approved_at;
+DROP INDEX IF EXISTS idx_audit_logs_event_type_created_at;
+DROP INDEX IF EXISTS idx_refresh_tokens_created_at;
+
+DROP TABLE IF EXISTS stats_daily;
+DROP TABLE IF EXISTS user_activity_daily;
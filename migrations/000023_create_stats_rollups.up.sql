@@ -0,0 +1,84 @@
+-- Create the rollups behind the admin statistics dashboard
+-- The dashboard reads daily counters from stats_daily and current breakdowns from
+-- materialized views, all maintained by the stats rollup job, instead of scanning users,
+-- audit_logs, kyc_cases and refresh_tokens on every request.
+
+-- Users active on each UTC day, from the refresh tokens issued to them on login and
+-- refresh. Only kept as long as the monthly active user count needs it.
+CREATE TABLE IF NOT EXISTS user_activity_daily (
+    day DATE NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (day, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS stats_daily (
+    day DATE PRIMARY KEY,
+    registrations BIGINT NOT NULL DEFAULT 0,
+    logins BIGINT NOT NULL DEFAULT 0,
+    failed_logins BIGINT NOT NULL DEFAULT 0,
+    kyc_approvals BIGINT NOT NULL DEFAULT 0,
+    active_users BIGINT NOT NULL DEFAULT 0,
+    monthly_active_users BIGINT NOT NULL DEFAULT 0,
+    refreshed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Let the rollup count a day without scanning whole tables
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_created_at ON refresh_tokens(created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_event_type_created_at ON audit_logs(event_type, created_at);
+CREATE INDEX IF NOT EXISTS idx_kyc_cases_approved_at ON kyc_cases(decided_at) WHERE status = 'approved';
+
+-- user_agent_device classifies a session's user agent as tablet, mobile, desktop, other
+-- (API clients, bots) or unknown when there is none
+CREATE OR REPLACE FUNCTION user_agent_device(p_user_agent TEXT)
+RETURNS TEXT
+LANGUAGE sql
+IMMUTABLE
+AS $$
+    SELECT CASE
+        WHEN p_user_agent IS NULL OR p_user_agent = '' THEN 'unknown'
+        WHEN p_user_agent ~* 'ipad|tablet|kindle|silk|playbook'
+            OR (p_user_agent ~* 'android' AND p_user_agent !~* 'mobile') THEN 'tablet'
+        WHEN p_user_agent ~* 'mobile|iphone|ipod|android|blackberry|opera mini|windows phone' THEN 'mobile'
+        WHEN p_user_agent ~* 'windows|macintosh|mac os x|x11|linux|cros' THEN 'desktop'
+        ELSE 'other'
+    END;
+$$;
+
+-- Non-deleted users by the status of their latest KYC case, or not_started without one
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_kyc_funnel AS
+SELECT COALESCE(latest.status, 'not_started')::VARCHAR(20) AS status,
+       COUNT(*)::BIGINT AS users,
+       NOW() AS refreshed_at
+FROM users u
+LEFT JOIN LATERAL (
+    SELECT c.status FROM kyc_cases c
+    WHERE c.user_id = u.id
+    ORDER BY c.created_at DESC
+    LIMIT 1
+) latest ON TRUE
+WHERE u.deleted_at IS NULL
+GROUP BY 1;
+
+-- Unique indexes let the views be refreshed concurrently, without blocking reads
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stats_kyc_funnel_status ON stats_kyc_funnel(status);
+
+-- Active sessions and the users holding them by device
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_sessions_by_device AS
+SELECT user_agent_device(user_agent) AS device,
+       COUNT(*)::BIGINT AS sessions,
+       COUNT(DISTINCT user_id)::BIGINT AS users,
+       NOW() AS refreshed_at
+FROM refresh_tokens
+WHERE revoked_at IS NULL AND expires_at > NOW()
+GROUP BY 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stats_sessions_by_device_device ON stats_sessions_by_device(device);
+
+COMMENT ON TABLE user_activity_daily IS 'Users who logged in or refreshed a session on each UTC day';
+COMMENT ON TABLE stats_daily IS 'Daily user statistics per UTC day, maintained by the stats rollup job';
+COMMENT ON COLUMN stats_daily.logins IS 'Successful logins, from user.login audit logs';
+COMMENT ON COLUMN stats_daily.failed_logins IS 'Failed logins, from user.login audit logs';
+COMMENT ON COLUMN stats_daily.active_users IS 'Users active on the day (DAU)';
+COMMENT ON COLUMN stats_daily.monthly_active_users IS 'Users active in the 30 days ending on the day (MAU)';
+COMMENT ON MATERIALIZED VIEW stats_kyc_funnel IS 'Non-deleted users by the status of their latest KYC case';
+COMMENT ON MATERIALIZED VIEW stats_sessions_by_device IS 'Active sessions by device, classified from their user agent';
@@ -0,0 +1,11 @@
+-- Drop webhook secret encryption columns
+-- Encrypted secrets cannot be decrypted in SQL, so only roll back databases that never
+-- enabled PII encryption.
+
+ALTER TABLE webhook_subscriptions
+    DROP CONSTRAINT IF EXISTS webhook_subscriptions_secret_key_version_check,
+    DROP COLUMN IF EXISTS secret_key_version,
+    DROP COLUMN IF EXISTS secret_key,
+    ALTER COLUMN secret TYPE VARCHAR(255);
+
+COMMENT ON COLUMN webhook_subscriptions.secret IS 'HMAC-SHA256 key signing each delivery';
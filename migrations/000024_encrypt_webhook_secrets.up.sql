@@ -0,0 +1,14 @@
+-- Encrypt webhook subscription secrets at rest
+-- A secret is stored as "enc:v1:<base64>", encrypted with a data key of its own that is
+-- wrapped by the PII key manager (Vault Transit or the local KMS), like user PII. Rows without
+-- a data key still hold the plaintext secret until the service encrypts them at startup.
+
+ALTER TABLE webhook_subscriptions
+    ALTER COLUMN secret TYPE TEXT,
+    ADD COLUMN secret_key TEXT,
+    ADD COLUMN secret_key_version INTEGER,
+    ADD CONSTRAINT webhook_subscriptions_secret_key_version_check CHECK ((secret_key IS NULL) = (secret_key_version IS NULL));
+
+COMMENT ON COLUMN webhook_subscriptions.secret IS 'HMAC-SHA256 key signing each delivery; encrypted with secret_key when it is set';
+COMMENT ON COLUMN webhook_subscriptions.secret_key IS 'Data key encrypting secret, wrapped by the key manager; NULL while secret is plaintext';
+COMMENT ON COLUMN webhook_subscriptions.secret_key_version IS 'Version of the key encryption key that wrapped secret_key';
@@ -0,0 +1,4 @@
+-- Drop the erasure lookup indexes
+
+DROP INDEX IF EXISTS idx_webhook_deliveries_subject;
+DROP INDEX IF EXISTS idx_outbox_aggregate;
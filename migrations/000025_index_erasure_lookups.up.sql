@@ -0,0 +1,6 @@
+-- Index the outbox and webhook deliveries by the user an event is about
+-- Erasing a user deletes their outbox messages, found by aggregate, and the deliveries of
+-- their events, found by the CloudEvents subject (the user ID) in the payload.
+
+CREATE INDEX IF NOT EXISTS idx_outbox_aggregate ON outbox(aggregate_type, aggregate_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subject ON webhook_deliveries((payload->>'subject'));